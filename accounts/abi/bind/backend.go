@@ -43,6 +43,10 @@ var (
 	// ErrNoCodeAfterDeploy is returned by WaitDeployed if contract creation leaves
 	// an empty contract behind.
 	ErrNoCodeAfterDeploy = errors.New("no contract code after deployment")
+
+	// ErrInvalidBlockTag is returned if the block tag of the call options is
+	// unknown or set together with an explicit block number.
+	ErrInvalidBlockTag = errors.New("invalid block tag")
 )
 
 // ContractCaller defines the methods needed to allow operating with a contract on a read
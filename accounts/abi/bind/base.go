@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 const basefeeWiggleMultiplier = 2
@@ -50,6 +51,32 @@ type CallOpts struct {
 	BlockNumber *big.Int        // Optional the block number on which the call should be performed
 	BlockHash   common.Hash     // Optional the block hash on which the call should be performed
 	Context     context.Context // Network context to support cancellation and timeouts (nil = no timeout)
+
+	// BlockTag optionally selects the block to call on by tag: "latest", "safe"
+	// or "finalized". Nero nodes resolve the latter two from the Turbo finality
+	// of the validator attestations.
+	BlockTag string
+}
+
+// blockNumber returns the block number to call on, resolving the block tag into
+// the number encoding it for the backend.
+func (opts *CallOpts) blockNumber() (*big.Int, error) {
+	if opts.BlockTag == "" {
+		return opts.BlockNumber, nil
+	}
+	if opts.BlockNumber != nil {
+		return nil, ErrInvalidBlockTag
+	}
+	switch opts.BlockTag {
+	case "latest":
+		return nil, nil
+	case "safe":
+		return big.NewInt(int64(rpc.SafeBlockNumber)), nil
+	case "finalized":
+		return big.NewInt(int64(rpc.FinalizedBlockNumber)), nil
+	default:
+		return nil, ErrInvalidBlockTag
+	}
 }
 
 // TransactOpts is the collection of authorization data required to create a
@@ -84,6 +111,11 @@ type FilterOpts struct {
 type WatchOpts struct {
 	Start   *uint64         // Start of the queried range (nil = latest)
 	Context context.Context // Network context to support cancellation and timeouts (nil = no timeout)
+
+	// Resubscribe keeps the subscription alive across connection failures (e.g.
+	// websocket reconnects), replaying the logs missed in between and dropping
+	// duplicates. The subscription then only ends when unsubscribed.
+	Resubscribe bool
 }
 
 // MetaData collects all metadata for a bound contract.
@@ -208,13 +240,17 @@ func (c *BoundContract) Call(opts *CallOpts, results *[]interface{}, method stri
 			}
 		}
 	} else {
-		output, err = c.caller.CallContract(ctx, msg, opts.BlockNumber)
+		var number *big.Int
+		if number, err = opts.blockNumber(); err != nil {
+			return err
+		}
+		output, err = c.caller.CallContract(ctx, msg, number)
 		if err != nil {
 			return err
 		}
 		if len(output) == 0 {
 			// Make sure we have a contract to operate on, and bail out otherwise.
-			if code, err = c.caller.CodeAt(ctx, c.address, opts.BlockNumber); err != nil {
+			if code, err = c.caller.CodeAt(ctx, c.address, number); err != nil {
 				return err
 			} else if len(code) == 0 {
 				return ErrNoCode
@@ -489,16 +525,19 @@ func (c *BoundContract) WatchLogs(opts *WatchOpts, name string, query ...[]inter
 	if err != nil {
 		return nil, nil, err
 	}
-	// Start the background filtering
-	logs := make(chan types.Log, 128)
-
 	config := ethereum.FilterQuery{
 		Addresses: []common.Address{c.address},
 		Topics:    topics,
 	}
+	if opts.Resubscribe {
+		logs, sub := c.watchLogsManaged(opts, config)
+		return logs, sub, nil
+	}
 	if opts.Start != nil {
 		config.FromBlock = new(big.Int).SetUint64(*opts.Start)
 	}
+	// Start the background filtering
+	logs := make(chan types.Log, 128)
 	sub, err := c.filterer.SubscribeFilterLogs(ensureContext(opts.Context), config, logs)
 	if err != nil {
 		return nil, nil, err
@@ -508,20 +547,26 @@ func (c *BoundContract) WatchLogs(opts *WatchOpts, name string, query ...[]inter
 
 // UnpackLog unpacks a retrieved log into the provided output structure.
 func (c *BoundContract) UnpackLog(out interface{}, event string, log types.Log) error {
+	return UnpackLog(c.abi, out, event, log)
+}
+
+// UnpackLog unpacks a log of the given contract event into the provided output
+// structure, without requiring a bound contract.
+func UnpackLog(contractABI abi.ABI, out interface{}, event string, log types.Log) error {
 	// Anonymous events are not supported.
 	if len(log.Topics) == 0 {
 		return errNoEventSignature
 	}
-	if log.Topics[0] != c.abi.Events[event].ID {
+	if log.Topics[0] != contractABI.Events[event].ID {
 		return errEventSignatureMismatch
 	}
 	if len(log.Data) > 0 {
-		if err := c.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
+		if err := contractABI.UnpackIntoInterface(out, event, log.Data); err != nil {
 			return err
 		}
 	}
 	var indexed abi.Arguments
-	for _, arg := range c.abi.Events[event].Inputs {
+	for _, arg := range contractABI.Events[event].Inputs {
 		if arg.Indexed {
 			indexed = append(indexed, arg)
 		}
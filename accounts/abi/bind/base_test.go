@@ -32,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -184,6 +185,38 @@ func TestPassingBlockNumber(t *testing.T) {
 	}
 }
 
+func TestCallBlockTag(t *testing.T) {
+	t.Parallel()
+	mc := &mockCaller{codeAtBytes: []byte{1, 2, 3}}
+	bc := bind.NewBoundContract(common.HexToAddress("0x0"), abi.ABI{
+		Methods: map[string]abi.Method{
+			"something": {
+				Name:    "something",
+				Outputs: abi.Arguments{},
+			},
+		},
+	}, mc, nil, nil)
+
+	for tag, want := range map[string]*big.Int{
+		"latest":    nil,
+		"safe":      big.NewInt(int64(rpc.SafeBlockNumber)),
+		"finalized": big.NewInt(int64(rpc.FinalizedBlockNumber)),
+	} {
+		if err := bc.Call(&bind.CallOpts{BlockTag: tag}, nil, "something"); err != nil {
+			t.Fatalf("%s: call failed: %v", tag, err)
+		}
+		if (want == nil) != (mc.callContractBlockNumber == nil) || (want != nil && want.Cmp(mc.callContractBlockNumber) != 0) {
+			t.Errorf("%s: wrong block number: have %v, want %v", tag, mc.callContractBlockNumber, want)
+		}
+	}
+	if err := bc.Call(&bind.CallOpts{BlockTag: "earliest"}, nil, "something"); err != bind.ErrInvalidBlockTag {
+		t.Errorf("unknown tag: have %v, want %v", err, bind.ErrInvalidBlockTag)
+	}
+	if err := bc.Call(&bind.CallOpts{BlockTag: "safe", BlockNumber: big.NewInt(1)}, nil, "something"); err != bind.ErrInvalidBlockTag {
+		t.Errorf("conflicting tag: have %v, want %v", err, bind.ErrInvalidBlockTag)
+	}
+}
+
 const hexData = "0x000000000000000000000000376c47978271565f56deb45495afa69e59c16ab200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000060000000000000000000000000000000000000000000000000000000000000000158"
 
 func TestUnpackIndexedStringTyLogIntoMap(t *testing.T) {
@@ -0,0 +1,71 @@
+package bind
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const bindV2TestABI = `[
+	{"type":"error","name":"Insufficient","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+	{"type":"error","name":"Unauthorized","inputs":[]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"info","stateMutability":"view","inputs":[],"outputs":[{"name":"a","type":"uint256"},{"name":"b","type":"string"}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[]}
+]`
+
+// Tests that the v2 binder generates the offline helpers and typed errors.
+func TestBindV2(t *testing.T) {
+	code, err := BindV2([]string{"Token"}, []string{bindV2TestABI}, []string{""}, "bindtest", nil, nil)
+	if err != nil {
+		t.Fatalf("failed to generate binding: %v", err)
+	}
+	for _, want := range []string{
+		"func NewToken() (*Token, error)",
+		"func (c *Token) PackBalanceOf(owner common.Address) ([]byte, error)",
+		"func (c *Token) UnpackBalanceOf(data []byte) (*big.Int, error)",
+		"type TokenInfoOutput struct",
+		"func (c *Token) UnpackInfo(data []byte) (TokenInfoOutput, error)",
+		"func (c *Token) PackTransfer(to common.Address, value *big.Int) ([]byte, error)",
+		"func (c *Token) UnpackTransferEvent(log *types.Log) (*TokenTransfer, error)",
+		"func (e *TokenInsufficient) Error() string",
+		"func (c *Token) UnpackInsufficientError(raw []byte) (*TokenInsufficient, error)",
+		"func (c *Token) UnpackUnauthorizedError(raw []byte) (*TokenUnauthorized, error)",
+		"func (c *Token) DecodeError(err error) error",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("binding missing %q", want)
+		}
+	}
+	// Errors and events share the type namespace
+	clash := `[{"type":"error","name":"Transfer","inputs":[]},{"type":"event","name":"Transfer","anonymous":false,"inputs":[]}]`
+	if _, err := BindV2([]string{"Token"}, []string{clash}, []string{""}, "bindtest", nil, nil); err == nil {
+		t.Error("expected duplicated identifier error")
+	}
+}
+
+type testDataError struct{ data interface{} }
+
+func (e testDataError) Error() string          { return "execution reverted" }
+func (e testDataError) ErrorData() interface{} { return e.data }
+
+func TestRevertData(t *testing.T) {
+	tests := []struct {
+		err  error
+		data []byte
+		ok   bool
+	}{
+		{errors.New("plain"), nil, false},
+		{testDataError{"0x01020304"}, []byte{1, 2, 3, 4}, true},
+		{testDataError{"not hex"}, nil, false},
+		{testDataError{[]byte{5}}, []byte{5}, true},
+		{testDataError{42}, nil, false},
+	}
+	for i, tt := range tests {
+		data, ok := RevertData(tt.err)
+		if ok != tt.ok || string(data) != string(tt.data) {
+			t.Errorf("test %d: have %x/%v, want %x/%v", i, data, ok, tt.data, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package bind
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchCaller defines the methods needed to execute several contract calls in a
+// single round trip. It is implemented by ethclient.Client through JSON-RPC
+// batch requests.
+type BatchCaller interface {
+	// BatchCallContract executes the calls at the given block, returning their
+	// outputs in order. The block number is encoded as in ContractCaller, nil
+	// standing for the latest block and negative values for the block tags.
+	BatchCallContract(ctx context.Context, calls []ethereum.CallMsg, blockNumber *big.Int) ([][]byte, error)
+}
+
+// Multicall collects view calls of a contract to execute them in a single round
+// trip through a BatchCaller. It is the base of the generated multicallers.
+type Multicall struct {
+	address common.Address
+	abi     abi.ABI
+	caller  BatchCaller
+
+	calls   []ethereum.CallMsg
+	methods []string
+	results []func([]interface{})
+}
+
+// NewMulticall creates a multicall of the contract deployed at the given address.
+func NewMulticall(address common.Address, abi abi.ABI, caller BatchCaller) *Multicall {
+	return &Multicall{
+		address: address,
+		abi:     abi,
+		caller:  caller,
+	}
+}
+
+// Queue adds a call of the contract method to the batch. The result callback is
+// invoked with the unpacked outputs once the batch is executed.
+func (m *Multicall) Queue(method string, result func([]interface{}), params ...interface{}) error {
+	input, err := m.abi.Pack(method, params...)
+	if err != nil {
+		return err
+	}
+	m.calls = append(m.calls, ethereum.CallMsg{To: &m.address, Data: input})
+	m.methods = append(m.methods, method)
+	m.results = append(m.results, result)
+	return nil
+}
+
+// Execute performs the queued calls in a single round trip and delivers their
+// results. The queue is reset afterwards, even if the execution failed.
+func (m *Multicall) Execute(opts *CallOpts) error {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+	calls, methods, results := m.calls, m.methods, m.results
+	m.calls, m.methods, m.results = nil, nil, nil
+
+	if len(calls) == 0 {
+		return nil
+	}
+	number, err := opts.blockNumber()
+	if err != nil {
+		return err
+	}
+	switch {
+	case opts.BlockHash != (common.Hash{}):
+		return ErrNoBlockHashState
+	case opts.Pending:
+		number = big.NewInt(int64(rpc.PendingBlockNumber))
+	}
+	for i := range calls {
+		calls[i].From = opts.From
+	}
+	outputs, err := m.caller.BatchCallContract(ensureContext(opts.Context), calls, number)
+	if err != nil {
+		return err
+	}
+	if len(outputs) != len(calls) {
+		return fmt.Errorf("batch returned %d outputs for %d calls", len(outputs), len(calls))
+	}
+	for i, output := range outputs {
+		if len(output) == 0 {
+			return ErrNoCode
+		}
+		values, err := m.abi.Unpack(methods[i], output)
+		if err != nil {
+			return err
+		}
+		results[i](values)
+	}
+	return nil
+}
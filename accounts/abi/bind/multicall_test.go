@@ -0,0 +1,69 @@
+package bind_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// mockBatchCaller answers every call with the same output, counting round trips.
+type mockBatchCaller struct {
+	output      []byte
+	blockNumber *big.Int
+	batches     int
+	calls       int
+}
+
+func (mc *mockBatchCaller) BatchCallContract(ctx context.Context, calls []ethereum.CallMsg, blockNumber *big.Int) ([][]byte, error) {
+	mc.blockNumber = blockNumber
+	mc.batches++
+	mc.calls += len(calls)
+
+	outputs := make([][]byte, len(calls))
+	for i := range calls {
+		outputs[i] = mc.output
+	}
+	return outputs, nil
+}
+
+func TestMulticall(t *testing.T) {
+	t.Parallel()
+	parsed, _ := abi.JSON(strings.NewReader(`[{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}]`))
+	output, _ := parsed.Methods["balanceOf"].Outputs.Pack(big.NewInt(42))
+
+	caller := &mockBatchCaller{output: output}
+	multicall := bind.NewMulticall(common.Address{}, parsed, caller)
+
+	balances := make([]*big.Int, 3)
+	for i := range balances {
+		i := i
+		if err := multicall.Queue("balanceOf", func(out []interface{}) { balances[i] = out[0].(*big.Int) }, common.Address{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := multicall.Execute(&bind.CallOpts{BlockTag: "finalized"}); err != nil {
+		t.Fatal(err)
+	}
+	if caller.batches != 1 || caller.calls != 3 {
+		t.Errorf("wrong round trips: have %d batches of %d calls, want 1 of 3", caller.batches, caller.calls)
+	}
+	if caller.blockNumber.Int64() != int64(rpc.FinalizedBlockNumber) {
+		t.Errorf("wrong block number: have %v, want %d", caller.blockNumber, rpc.FinalizedBlockNumber)
+	}
+	for i, balance := range balances {
+		if balance == nil || balance.Int64() != 42 {
+			t.Errorf("balance %d: have %v, want 42", i, balance)
+		}
+	}
+	// The queue is reset after execution
+	if err := multicall.Execute(nil); err != nil || caller.batches != 1 {
+		t.Errorf("empty queue executed: %v, %d batches", err, caller.batches)
+	}
+}
@@ -0,0 +1,39 @@
+package bind
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrUnknownCustomError is returned by the v2 bindings when decoding revert data
+// that doesn't match any custom error of the contract.
+var ErrUnknownCustomError = errors.New("unknown custom error")
+
+// dataError is an error carrying additional data, implemented by the errors of
+// the RPC client. It mirrors rpc.DataError to avoid depending on the package.
+type dataError interface {
+	error
+	ErrorData() interface{}
+}
+
+// RevertData extracts the revert data of a failed contract call, transaction
+// or gas estimation from the error returned by the backend, if it carries any.
+func RevertData(err error) ([]byte, bool) {
+	var dataErr dataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	switch data := dataErr.ErrorData().(type) {
+	case []byte:
+		return data, true
+	case string:
+		blob, err := hexutil.Decode(data)
+		if err != nil {
+			return nil, false
+		}
+		return blob, true
+	default:
+		return nil, false
+	}
+}
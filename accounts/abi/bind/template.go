@@ -24,6 +24,7 @@ type tmplData struct {
 	Contracts map[string]*tmplContract // List of contracts to generate into this file
 	Libraries map[string]string        // Map the bytecode's link pattern to the library name
 	Structs   map[string]*tmplStruct   // Contract struct type definitions
+	Multicall bool                     // Whether to generate multicallers for the contracts
 }
 
 // tmplContract contains the data needed to generate an individual contract binding.
@@ -38,6 +39,7 @@ type tmplContract struct {
 	Fallback    *tmplMethod            // Additional special fallback function
 	Receive     *tmplMethod            // Additional special receive function
 	Events      map[string]*tmplEvent  // Contract events accessors
+	Errors      map[string]*tmplError  // Contract custom errors (v2 bindings only)
 	Libraries   map[string]string      // Same as tmplData, but filtered to only keep what the contract needs
 	Library     bool                   // Indicator whether the contract is a library
 }
@@ -57,6 +59,13 @@ type tmplEvent struct {
 	Normalized abi.Event // Normalized version of the parsed fields
 }
 
+// tmplError is a wrapper around an abi.Error that contains a few preprocessed
+// and cached data fields.
+type tmplError struct {
+	Original   abi.Error // Original error as parsed by the abi package
+	Normalized abi.Error // Normalized version of the parsed fields
+}
+
 // tmplField is a wrapper around a struct field with binding language
 // struct type definition and relative filed name.
 type tmplField struct {
@@ -355,6 +364,48 @@ var (
 		}
 	{{end}}
 
+	{{if $.Multicall}}
+		// {{.Type}}Multicaller is an auto generated Go binding aggregating view calls of
+		// an Ethereum contract into a single round trip.
+		type {{.Type}}Multicaller struct {
+			multicall *bind.Multicall // Generic multicall wrapper queueing the calls
+		}
+
+		// New{{.Type}}Multicaller creates a new multicaller instance of {{.Type}}, bound to a specific deployed contract.
+		func New{{.Type}}Multicaller(address common.Address, caller bind.BatchCaller) (*{{.Type}}Multicaller, error) {
+			parsed, err := {{.Type}}MetaData.GetAbi()
+			if err != nil {
+				return nil, err
+			}
+			return &{{.Type}}Multicaller{multicall: bind.NewMulticall(address, *parsed, caller)}, nil
+		}
+
+		// Execute performs the queued calls in a single round trip, filling in the
+		// results returned when queueing them.
+		func (_{{$contract.Type}} *{{$contract.Type}}Multicaller) Execute(opts *bind.CallOpts) error {
+			return _{{$contract.Type}}.multicall.Execute(opts)
+		}
+
+		{{range .Calls}}
+			{{$method := .}}
+			// {{.Normalized.Name}} queues a free data retrieval call binding the contract method 0x{{printf "%x" .Original.ID}}.
+			// The result is filled in once the queued calls are executed.
+			//
+			// Solidity: {{.Original.String}}
+			func (_{{$contract.Type}} *{{$contract.Type}}Multicaller) {{.Normalized.Name}}({{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}}, {{end}}{{.Name}} {{bindtype .Type $structs}}{{end}}) (*{{if eq (len .Normalized.Outputs) 1}}{{range .Normalized.Outputs}}{{bindtype .Type $structs}}{{end}}{{else}}struct{ {{range $i, $_ := .Normalized.Outputs}}{{if $method.Structured}}{{.Name}}{{else}}Arg{{$i}}{{end}} {{bindtype .Type $structs}};{{end}} }{{end}}, error) {
+				result := new({{if eq (len .Normalized.Outputs) 1}}{{range .Normalized.Outputs}}{{bindtype .Type $structs}}{{end}}{{else}}struct{ {{range $i, $_ := .Normalized.Outputs}}{{if $method.Structured}}{{.Name}}{{else}}Arg{{$i}}{{end}} {{bindtype .Type $structs}};{{end}} }{{end}})
+				err := _{{$contract.Type}}.multicall.Queue("{{.Original.Name}}", func(out []interface{}) {
+					{{if eq (len .Normalized.Outputs) 1}}{{range .Normalized.Outputs}}*result = *abi.ConvertType(out[0], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}{{else}}{{range $i, $_ := .Normalized.Outputs}}{{if ne $i 0}}
+					{{end}}result.{{if $method.Structured}}{{.Name}}{{else}}Arg{{$i}}{{end}} = *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}{{end}}
+				}{{range .Normalized.Inputs}}, {{.Name}}{{end}})
+				if err != nil {
+					return nil, err
+				}
+				return result, nil
+			}
+		{{end}}
+	{{end}}
+
 	{{range .Transacts}}
 		// {{.Normalized.Name}} is a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}}.
 		//
@@ -0,0 +1,208 @@
+package bind
+
+// tmplSourceGoV2 is the Go source template that the generated v2-style Go
+// contract binding is based on.
+const tmplSourceGoV2 = `
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = bytes.Equal
+	_ = errors.New
+	_ = fmt.Sprintf
+	_ = big.NewInt
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = abi.ConvertType
+)
+
+{{$structs := .Structs}}
+{{range $structs}}
+	// {{.Name}} is an auto generated low-level Go binding around an user-defined struct.
+	type {{.Name}} struct {
+	{{range $field := .Fields}}
+	{{$field.Name}} {{$field.Type}}{{end}}
+	}
+{{end}}
+
+{{range $contract := .Contracts}}
+	// {{.Type}}MetaData contains all meta data concerning the {{.Type}} contract.
+	var {{.Type}}MetaData = &bind.MetaData{
+		ABI: "{{.InputABI}}",
+		{{if .InputBin -}}
+		Bin: "0x{{.InputBin}}",
+		{{end}}
+	}
+
+	// {{.Type}} is an auto generated Go binding around an Ethereum contract. It
+	// packs and unpacks the contract calls, events and errors without requiring
+	// a connected backend.
+	type {{.Type}} struct {
+		abi abi.ABI
+	}
+
+	// New{{.Type}} creates a new instance of {{.Type}}.
+	func New{{.Type}}() (*{{.Type}}, error) {
+		parsed, err := {{.Type}}MetaData.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		return &{{.Type}}{abi: *parsed}, nil
+	}
+
+	// Instance binds the contract to an instance deployed at the given address,
+	// to operate on it through the given backend.
+	func (c *{{.Type}}) Instance(backend bind.ContractBackend, addr common.Address) *bind.BoundContract {
+		return bind.NewBoundContract(addr, c.abi, backend, backend, backend)
+	}
+
+	{{if .InputBin}}
+		// PackConstructor packs the constructor parameters, to be appended to the
+		// contract bytecode when deploying it.
+		//
+		// Solidity: {{.Constructor.String}}
+		func (c *{{.Type}}) PackConstructor({{range $i, $_ := .Constructor.Inputs}}{{if ne $i 0}}, {{end}}{{.Name}} {{bindtype .Type $structs}}{{end}}) ([]byte, error) {
+			return c.abi.Pack(""{{range .Constructor.Inputs}}, {{.Name}}{{end}})
+		}
+	{{end}}
+
+	{{range .Calls}}` + tmplMethodGoV2 + `{{end}}
+	{{range .Transacts}}` + tmplMethodGoV2 + `{{end}}
+
+	{{range .Events}}
+		// {{$contract.Type}}{{.Normalized.Name}} represents a {{.Normalized.Name}} event raised by the {{$contract.Type}} contract.
+		type {{$contract.Type}}{{.Normalized.Name}} struct { {{range .Normalized.Inputs}}
+			{{capitalise .Name}} {{if .Indexed}}{{bindtopictype .Type $structs}}{{else}}{{bindtype .Type $structs}}{{end}}; {{end}}
+			Raw *types.Log // Blockchain specific contextual infos
+		}
+
+		// Unpack{{.Normalized.Name}}Event unpacks a log of the contract event 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (c *{{$contract.Type}}) Unpack{{.Normalized.Name}}Event(log *types.Log) (*{{$contract.Type}}{{.Normalized.Name}}, error) {
+			event := new({{$contract.Type}}{{.Normalized.Name}})
+			if err := bind.UnpackLog(c.abi, event, "{{.Original.Name}}", *log); err != nil {
+				return nil, err
+			}
+			event.Raw = log
+			return event, nil
+		}
+	{{end}}
+
+	{{range .Errors}}
+		// {{$contract.Type}}{{.Normalized.Name}} represents a {{.Normalized.Name}} custom error raised by the {{$contract.Type}} contract.
+		type {{$contract.Type}}{{.Normalized.Name}} struct { {{range .Normalized.Inputs}}
+			{{capitalise .Name}} {{bindtype .Type $structs}}; {{end}}
+		}
+
+		// Error implements the error interface.
+		func (e *{{$contract.Type}}{{.Normalized.Name}}) Error() string {
+			return fmt.Sprintf("execution reverted: {{.Original.Name}}({{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}}, {{end}}{{.Name}}=%v{{end}})"{{range .Normalized.Inputs}}, e.{{capitalise .Name}}{{end}})
+		}
+
+		// Unpack{{.Normalized.Name}}Error unpacks the revert data of the custom error 0x{{printf "%x" (slice .Original.ID.Bytes 0 4)}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (c *{{$contract.Type}}) Unpack{{.Normalized.Name}}Error(raw []byte) (*{{$contract.Type}}{{.Normalized.Name}}, error) {
+			errABI := c.abi.Errors["{{.Original.Name}}"]
+			values, err := errABI.Unpack(raw)
+			if err != nil {
+				return nil, err
+			}
+			out := new({{$contract.Type}}{{.Normalized.Name}})
+			if err := errABI.Inputs.Copy(out, values.([]interface{})); err != nil {
+				return nil, err
+			}
+			return out, nil
+		}
+	{{end}}
+
+	// UnpackError unpacks the revert data of a failed call into the typed custom
+	// error of the {{.Type}} contract it matches, or returns bind.ErrUnknownCustomError.
+	func (c *{{.Type}}) UnpackError(raw []byte) (error, error) {
+		if len(raw) < 4 {
+			return nil, bind.ErrUnknownCustomError
+		}
+		{{range .Errors}}
+		if id := c.abi.Errors["{{.Original.Name}}"].ID; bytes.Equal(raw[:4], id[:4]) {
+			return c.Unpack{{.Normalized.Name}}Error(raw)
+		}{{end}}
+		return nil, bind.ErrUnknownCustomError
+	}
+
+	// DecodeError converts the error of a failed call, transaction or gas estimation
+	// into the typed custom error of the {{.Type}} contract carried in its revert
+	// data. The error is returned unchanged if it doesn't carry a known custom error.
+	func (c *{{.Type}}) DecodeError(err error) error {
+		raw, ok := bind.RevertData(err)
+		if !ok {
+			return err
+		}
+		if custom, uerr := c.UnpackError(raw); uerr == nil {
+			return custom
+		}
+		return err
+	}
+{{end}}
+`
+
+// tmplMethodGoV2 is the Go source template of the pack and unpack helpers of a
+// contract method in the v2-style binding, shared by calls and transacts.
+const tmplMethodGoV2 = `
+		// Pack{{.Normalized.Name}} packs the parameters of the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (c *{{$contract.Type}}) Pack{{.Normalized.Name}}({{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}}, {{end}}{{.Name}} {{bindtype .Type $structs}}{{end}}) ([]byte, error) {
+			return c.abi.Pack("{{.Original.Name}}"{{range .Normalized.Inputs}}, {{.Name}}{{end}})
+		}
+
+		{{$method := .}}
+		{{if gt (len .Normalized.Outputs) 1}}
+			// {{$contract.Type}}{{.Normalized.Name}}Output is the result of the contract method 0x{{printf "%x" .Original.ID}}.
+			type {{$contract.Type}}{{.Normalized.Name}}Output struct {
+			{{range $i, $_ := .Normalized.Outputs}}
+				{{if $method.Structured}}{{.Name}}{{else}}Arg{{$i}}{{end}} {{bindtype .Type $structs}}{{end}}
+			}
+
+			// Unpack{{.Normalized.Name}} unpacks the result of the contract method 0x{{printf "%x" .Original.ID}}.
+			//
+			// Solidity: {{.Original.String}}
+			func (c *{{$contract.Type}}) Unpack{{.Normalized.Name}}(data []byte) ({{$contract.Type}}{{.Normalized.Name}}Output, error) {
+				out, err := c.abi.Unpack("{{.Original.Name}}", data)
+				result := new({{$contract.Type}}{{.Normalized.Name}}Output)
+				if err != nil {
+					return *result, err
+				}
+				{{range $i, $_ := .Normalized.Outputs}}
+				result.{{if $method.Structured}}{{.Name}}{{else}}Arg{{$i}}{{end}} = *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}
+				return *result, nil
+			}
+		{{else if .Normalized.Outputs}}
+			// Unpack{{.Normalized.Name}} unpacks the result of the contract method 0x{{printf "%x" .Original.ID}}.
+			//
+			// Solidity: {{.Original.String}}
+			func (c *{{$contract.Type}}) Unpack{{.Normalized.Name}}(data []byte) ({{range .Normalized.Outputs}}{{bindtype .Type $structs}}{{end}}, error) {
+				out, err := c.abi.Unpack("{{.Original.Name}}", data)
+				if err != nil {
+					return {{range .Normalized.Outputs}}*new({{bindtype .Type $structs}}){{end}}, err
+				}
+				return {{range .Normalized.Outputs}}*abi.ConvertType(out[0], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}, nil
+			}
+		{{end}}
+`
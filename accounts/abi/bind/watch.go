@@ -0,0 +1,137 @@
+package bind
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// watchBackoffMax is the maximum time between attempts to reestablish a
+	// failed managed log subscription.
+	watchBackoffMax = 30 * time.Second
+
+	// watchDedupDepth is the number of blocks behind the last delivered log for
+	// which the delivered logs are remembered to drop replayed duplicates.
+	watchDedupDepth = 64
+)
+
+// watchedLog identifies a delivered log for deduplication.
+type watchedLog struct {
+	block   common.Hash
+	index   uint
+	removed bool
+}
+
+// logWatcher keeps a log subscription alive across connection failures. Every
+// time the subscription is reestablished, the logs emitted since the last
+// delivered block are replayed from the filterer and those already delivered
+// are dropped, so the sink observes every log exactly once.
+type logWatcher struct {
+	filterer ContractFilterer
+	query    ethereum.FilterQuery
+	sink     chan types.Log
+
+	from *uint64               // Block to replay the logs from when resubscribing
+	seen map[watchedLog]uint64 // Recently delivered logs, mapped to their block number
+}
+
+// watchLogsManaged subscribes to the logs matching the query, resubscribing
+// transparently whenever the subscription fails. The returned subscription only
+// ends when unsubscribed.
+func (c *BoundContract) watchLogsManaged(opts *WatchOpts, query ethereum.FilterQuery) (chan types.Log, event.Subscription) {
+	w := &logWatcher{
+		filterer: c.filterer,
+		query:    query,
+		sink:     make(chan types.Log, 128),
+		from:     opts.Start,
+		seen:     make(map[watchedLog]uint64),
+	}
+
+	sub := event.ResubscribeErr(watchBackoffMax, w.subscribe)
+	return w.sink, sub
+}
+
+// subscribe establishes the live log subscription and replays the logs missed
+// since the last delivered block.
+func (w *logWatcher) subscribe(ctx context.Context, lastErr error) (event.Subscription, error) {
+	if lastErr != nil {
+		log.Warn("Contract log subscription failed, resubscribing", "err", lastErr)
+	}
+	live := make(chan types.Log, 128)
+	sub, err := w.filterer.SubscribeFilterLogs(ctx, w.query, live)
+	if err != nil {
+		return nil, err
+	}
+	// Retrieve the logs emitted while not subscribed. The live subscription is
+	// already established, so nothing falls between the two.
+	var missed []types.Log
+	if w.from != nil {
+		query := w.query
+		query.FromBlock = new(big.Int).SetUint64(*w.from)
+		if missed, err = w.filterer.FilterLogs(ctx, query); err != nil {
+			sub.Unsubscribe()
+			return nil, err
+		}
+	} else if reader, ok := w.filterer.(ethereum.BlockNumberReader); ok {
+		// Nothing delivered yet, remember the head to not miss any logs if the
+		// subscription fails before the first one arrives
+		if head, err := reader.BlockNumber(ctx); err == nil {
+			w.from = &head
+		}
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for _, log := range missed {
+			if !w.deliver(log, quit) {
+				return nil
+			}
+		}
+		for {
+			select {
+			case log := <-live:
+				if !w.deliver(log, quit) {
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// deliver forwards a log to the sink unless it was delivered already, returning
+// false if the subscription was torn down meanwhile.
+func (w *logWatcher) deliver(log types.Log, quit <-chan struct{}) bool {
+	id := watchedLog{block: log.BlockHash, index: log.Index, removed: log.Removed}
+	if _, ok := w.seen[id]; ok {
+		return true
+	}
+	select {
+	case w.sink <- log:
+	case <-quit:
+		return false
+	}
+	w.seen[id] = log.BlockNumber
+
+	// Advance the replay position and forget logs too old to be replayed again
+	if !log.Removed && (w.from == nil || log.BlockNumber > *w.from) {
+		from := log.BlockNumber
+		w.from = &from
+
+		for id, number := range w.seen {
+			if number+watchDedupDepth < from {
+				delete(w.seen, id)
+			}
+		}
+	}
+	return true
+}
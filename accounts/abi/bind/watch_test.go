@@ -0,0 +1,147 @@
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// flakyFilterer is a log filterer whose subscriptions can be dropped, emulating
+// connection failures.
+type flakyFilterer struct {
+	lock  sync.Mutex
+	logs  []types.Log      // All logs emitted on chain
+	sink  chan<- types.Log // Sink of the live subscription, if any
+	fail  chan error       // Fails the live subscription
+	subs  int              // Number of subscriptions established
+	froms []*big.Int       // Start blocks of the replay queries
+}
+
+func (f *flakyFilterer) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.froms = append(f.froms, query.FromBlock)
+	var logs []types.Log
+	for _, log := range f.logs {
+		if query.FromBlock == nil || log.BlockNumber >= query.FromBlock.Uint64() {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func (f *flakyFilterer) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.subs++
+	f.sink, f.fail = ch, make(chan error, 1)
+	fail := f.fail
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		select {
+		case err := <-fail:
+			return err
+		case <-quit:
+			return nil
+		}
+	}), nil
+}
+
+// emit adds a log to the chain, delivering it to the live subscription if the
+// connection is up.
+func (f *flakyFilterer) emit(log types.Log, connected bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.logs = append(f.logs, log)
+	if connected {
+		f.sink <- log
+	}
+}
+
+// disconnect fails the live subscription.
+func (f *flakyFilterer) disconnect() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.fail <- errors.New("connection lost")
+}
+
+func TestWatchLogsResubscribe(t *testing.T) {
+	parsed, _ := abi.JSON(strings.NewReader(`[{"type":"event","name":"Ping","anonymous":false,"inputs":[]}]`))
+	id := parsed.Events["Ping"].ID
+
+	filterer := new(flakyFilterer)
+	contract := bind.NewBoundContract(common.Address{}, parsed, nil, nil, filterer)
+
+	logs, sub, err := contract.WatchLogs(&bind.WatchOpts{Resubscribe: true}, "Ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	ping := func(number uint64, index uint) types.Log {
+		return types.Log{Topics: []common.Hash{id}, BlockNumber: number, BlockHash: common.Hash{byte(number)}, Index: index}
+	}
+	next := func() types.Log {
+		select {
+		case log := <-logs:
+			return log
+		case <-time.After(time.Second):
+			t.Fatal("log not delivered")
+		}
+		return types.Log{}
+	}
+	// Wait for the subscription and deliver a live log
+	for {
+		filterer.lock.Lock()
+		subs := filterer.subs
+		filterer.lock.Unlock()
+		if subs == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	filterer.emit(ping(1, 0), true)
+	if log := next(); log.BlockNumber != 1 {
+		t.Fatalf("wrong log: have block %d, want 1", log.BlockNumber)
+	}
+	// Drop the connection and emit logs while disconnected, they need to be
+	// replayed exactly once after resubscribing
+	filterer.emit(ping(1, 1), false)
+	filterer.emit(ping(2, 0), false)
+	filterer.disconnect()
+
+	if log := next(); log.BlockNumber != 1 || log.Index != 1 {
+		t.Fatalf("wrong replayed log: have %d/%d, want 1/1", log.BlockNumber, log.Index)
+	}
+	if log := next(); log.BlockNumber != 2 {
+		t.Fatalf("wrong replayed log: have block %d, want 2", log.BlockNumber)
+	}
+	select {
+	case log := <-logs:
+		t.Fatalf("duplicate log delivered: %d/%d", log.BlockNumber, log.Index)
+	case <-time.After(50 * time.Millisecond):
+	}
+	filterer.lock.Lock()
+	defer filterer.lock.Unlock()
+
+	if filterer.subs != 2 {
+		t.Errorf("wrong number of subscriptions: have %d, want 2", filterer.subs)
+	}
+	if len(filterer.froms) != 1 || filterer.froms[0].Uint64() != 1 {
+		t.Errorf("wrong replay queries: %v", filterer.froms)
+	}
+}
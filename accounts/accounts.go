@@ -40,6 +40,7 @@ const (
 	MimetypeTypedData         = "data/typed"
 	MimetypeClique            = "application/x-clique-header"
 	MimetypeTurbo             = "application/x-turbo-header"
+	MimetypeTurboAttestation  = "application/x-turbo-attestation"
 	MimetypeTextPlain         = "text/plain"
 )
 
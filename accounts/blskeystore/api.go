@@ -0,0 +1,48 @@
+package blskeystore
+
+import "github.com/ethereum/go-ethereum/common/hexutil"
+
+// API exposes the attestation key manager over RPC under the blskey namespace.
+// Secret keys are never returned, exporting them is only possible from the CLI.
+type API struct {
+	manager *Manager
+}
+
+// NewAPI creates the RPC API of the key manager.
+func NewAPI(manager *Manager) *API {
+	return &API{manager: manager}
+}
+
+// ListKeys returns the keys stored in the keystore.
+func (api *API) ListKeys() ([]Key, error) {
+	return api.manager.Keys()
+}
+
+// NewKey generates a new key encrypted with the password.
+func (api *API) NewKey(password string) (PublicKey, error) {
+	return api.manager.NewKey(password)
+}
+
+// ImportKey stores an EIP-2335 key file encrypted with the password.
+func (api *API) ImportKey(keyJSON string, password string) (PublicKey, error) {
+	return api.manager.Import([]byte(keyJSON), password)
+}
+
+// UnlockKey unlocks the key for signing until locked again.
+func (api *API) UnlockKey(pub PublicKey, password string) error {
+	return api.manager.Unlock(pub, password)
+}
+
+// LockKey removes the unlocked key from memory.
+func (api *API) LockKey(pub PublicKey) {
+	api.manager.Lock(pub)
+}
+
+// Sign signs the message with the unlocked key.
+func (api *API) Sign(pub PublicKey, msg hexutil.Bytes) (hexutil.Bytes, error) {
+	sig, err := api.manager.Sign(pub, msg)
+	if err != nil {
+		return nil, err
+	}
+	return sig[:], nil
+}
@@ -0,0 +1,270 @@
+package blskeystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/google/uuid"
+	blsu "github.com/protolambda/bls12-381-util"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// keyFileVersion is the EIP-2335 keystore version.
+	keyFileVersion = 4
+
+	// StandardScryptN is the N parameter of Scrypt recommended by EIP-2335,
+	// using 256MB memory and taking approximately 1s CPU time.
+	StandardScryptN = 1 << 18
+
+	// StandardScryptP is the P parameter of Scrypt recommended by EIP-2335.
+	StandardScryptP = 1
+
+	// LightScryptN is the N parameter of Scrypt encryption algorithm, using 4MB
+	// memory and taking approximately 100ms CPU time.
+	LightScryptN = 1 << 12
+
+	// LightScryptP is the P parameter of Scrypt encryption algorithm, using 4MB
+	// memory and taking approximately 100ms CPU time.
+	LightScryptP = 6
+
+	scryptR     = 8
+	scryptDKLen = 32
+)
+
+var (
+	// ErrDecrypt is returned if the key file can't be decrypted with the password.
+	ErrDecrypt = errors.New("could not decrypt key with given password")
+
+	errUnsupported = errors.New("unsupported key file")
+)
+
+// keyFile is the EIP-2335 JSON encoding of an encrypted BLS secret key.
+type keyFile struct {
+	Crypto      keyCrypto `json:"crypto"`
+	Description string    `json:"description"`
+	Pubkey      string    `json:"pubkey"`
+	Path        string    `json:"path"`
+	UUID        string    `json:"uuid"`
+	Version     int       `json:"version"`
+}
+
+type keyCrypto struct {
+	KDF      keyModule `json:"kdf"`
+	Checksum keyModule `json:"checksum"`
+	Cipher   keyModule `json:"cipher"`
+}
+
+type keyModule struct {
+	Function string                 `json:"function"`
+	Params   map[string]interface{} `json:"params"`
+	Message  string                 `json:"message"`
+}
+
+// EncryptKey encrypts a BLS secret key with the password into an EIP-2335 key
+// file, deriving the decryption key with scrypt.
+func EncryptKey(secret *blsu.SecretKey, password string, scryptN, scryptP int) ([]byte, error) {
+	pubkey, err := blsu.SkToPk(secret)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	dk, err := scrypt.Key(normalizePassword(password), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+	raw := secret.Serialize()
+	ciphertext, err := aesCTRXOR(dk[:16], raw[:], iv)
+	if err != nil {
+		return nil, err
+	}
+	pub := pubkey.Serialize()
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(keyFile{
+		Crypto: keyCrypto{
+			KDF: keyModule{
+				Function: "scrypt",
+				Params: map[string]interface{}{
+					"dklen": scryptDKLen,
+					"n":     scryptN,
+					"p":     scryptP,
+					"r":     scryptR,
+					"salt":  hexutil.Encode(salt)[2:],
+				},
+			},
+			Checksum: keyModule{
+				Function: "sha256",
+				Params:   map[string]interface{}{},
+				Message:  hexutil.Encode(checksum(dk, ciphertext))[2:],
+			},
+			Cipher: keyModule{
+				Function: "aes-128-ctr",
+				Params:   map[string]interface{}{"iv": hexutil.Encode(iv)[2:]},
+				Message:  hexutil.Encode(ciphertext)[2:],
+			},
+		},
+		Pubkey:  hexutil.Encode(pub[:])[2:],
+		UUID:    id.String(),
+		Version: keyFileVersion,
+	}, "", "  ")
+}
+
+// DecryptKey decrypts an EIP-2335 key file with the password, returning the BLS
+// secret key. Both the scrypt and pbkdf2 key derivation functions are supported.
+func DecryptKey(keyJSON []byte, password string) (*blsu.SecretKey, error) {
+	var kf keyFile
+	if err := json.Unmarshal(keyJSON, &kf); err != nil {
+		return nil, err
+	}
+	if kf.Version != keyFileVersion {
+		return nil, fmt.Errorf("%w: version %d", errUnsupported, kf.Version)
+	}
+	if kf.Crypto.Checksum.Function != "sha256" {
+		return nil, fmt.Errorf("%w: checksum %q", errUnsupported, kf.Crypto.Checksum.Function)
+	}
+	if kf.Crypto.Cipher.Function != "aes-128-ctr" {
+		return nil, fmt.Errorf("%w: cipher %q", errUnsupported, kf.Crypto.Cipher.Function)
+	}
+	dk, err := deriveKey(&kf.Crypto.KDF, normalizePassword(password))
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := decodeHex(kf.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, err
+	}
+	want, err := decodeHex(kf.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(checksum(dk, ciphertext), want) {
+		return nil, ErrDecrypt
+	}
+	iv, err := decodeHex(paramString(kf.Crypto.Cipher.Params, "iv"))
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("invalid cipher iv length %d", len(iv))
+	}
+	plain, err := aesCTRXOR(dk[:16], ciphertext, iv)
+	if err != nil {
+		return nil, err
+	}
+	if len(plain) != 32 {
+		return nil, fmt.Errorf("invalid secret key length %d", len(plain))
+	}
+	var raw [32]byte
+	copy(raw[:], plain)
+
+	secret := new(blsu.SecretKey)
+	if err := secret.Deserialize(&raw); err != nil {
+		return nil, err
+	}
+	// Ensure the key matches the advertised public key, if any
+	if kf.Pubkey != "" {
+		pubkey, err := blsu.SkToPk(secret)
+		if err != nil {
+			return nil, err
+		}
+		pub := pubkey.Serialize()
+		if hexutil.Encode(pub[:])[2:] != strings.ToLower(strings.TrimPrefix(kf.Pubkey, "0x")) {
+			return nil, errors.New("secret key does not match public key")
+		}
+	}
+	return secret, nil
+}
+
+// deriveKey derives the decryption key from the password with the key derivation
+// function of the key file.
+func deriveKey(kdf *keyModule, password []byte) ([]byte, error) {
+	salt, err := decodeHex(paramString(kdf.Params, "salt"))
+	if err != nil {
+		return nil, err
+	}
+	dklen := paramInt(kdf.Params, "dklen")
+	if dklen < 32 {
+		return nil, fmt.Errorf("invalid derived key length %d", dklen)
+	}
+	switch kdf.Function {
+	case "scrypt":
+		n, r, p := paramInt(kdf.Params, "n"), paramInt(kdf.Params, "r"), paramInt(kdf.Params, "p")
+		return scrypt.Key(password, salt, n, r, p, dklen)
+
+	case "pbkdf2":
+		if prf := paramString(kdf.Params, "prf"); prf != "hmac-sha256" {
+			return nil, fmt.Errorf("%w: pbkdf2 prf %q", errUnsupported, prf)
+		}
+		c := paramInt(kdf.Params, "c")
+		if c <= 0 {
+			return nil, fmt.Errorf("invalid pbkdf2 iteration count %d", c)
+		}
+		return pbkdf2.Key(password, salt, c, dklen, sha256.New), nil
+
+	default:
+		return nil, fmt.Errorf("%w: kdf %q", errUnsupported, kdf.Function)
+	}
+}
+
+// normalizePassword prepares the password as required by EIP-2335: it is
+// NFKD-normalized and stripped of control codes.
+func normalizePassword(password string) []byte {
+	return []byte(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, norm.NFKD.String(password)))
+}
+
+// checksum computes the checksum of the encrypted secret.
+func checksum(dk []byte, ciphertext []byte) []byte {
+	sum := sha256.Sum256(append(append([]byte{}, dk[16:32]...), ciphertext...))
+	return sum[:]
+}
+
+func aesCTRXOR(key, inText, iv []byte) ([]byte, error) {
+	aesBlock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	stream := cipher.NewCTR(aesBlock, iv)
+	outText := make([]byte, len(inText))
+	stream.XORKeyStream(outText, inText)
+	return outText, nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hexutil.Decode("0x" + strings.TrimPrefix(s, "0x"))
+}
+
+func paramString(params map[string]interface{}, key string) string {
+	s, _ := params[key].(string)
+	return s
+}
+
+func paramInt(params map[string]interface{}, key string) int {
+	f, _ := params[key].(float64)
+	return int(f)
+}
@@ -0,0 +1,141 @@
+package blskeystore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	blsu "github.com/protolambda/bls12-381-util"
+)
+
+// Test vectors of EIP-2335.
+const (
+	testVectorPassword = "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511"
+	testVectorSecret   = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+
+	testVectorScrypt = `{
+		"crypto": {
+			"kdf": {"function": "scrypt", "params": {"dklen": 32, "n": 262144, "p": 1, "r": 8, "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"}, "message": ""},
+			"checksum": {"function": "sha256", "params": {}, "message": "d2217fe5f3e9a1e34581ef8a78f7c9928e436d36dacc5e846690a5581e8ea484"},
+			"cipher": {"function": "aes-128-ctr", "params": {"iv": "264daa3f303d7259501c93d997d84fe6"}, "message": "06ae90d55fe0a6e9c5c3bc5b170827b2e5cce3929ed3f116c2811e6366dfe20f"}
+		},
+		"description": "This is a test keystore that uses scrypt to secure the secret.",
+		"pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+		"path": "m/12381/60/3141592653/589793238",
+		"uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+		"version": 4
+	}`
+	testVectorPBKDF2 = `{
+		"crypto": {
+			"kdf": {"function": "pbkdf2", "params": {"dklen": 32, "c": 262144, "prf": "hmac-sha256", "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"}, "message": ""},
+			"checksum": {"function": "sha256", "params": {}, "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"},
+			"cipher": {"function": "aes-128-ctr", "params": {"iv": "264daa3f303d7259501c93d997d84fe6"}, "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"}
+		},
+		"description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+		"pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+		"path": "m/12381/60/0/0",
+		"uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+		"version": 4
+	}`
+)
+
+func TestDecryptKeyVectors(t *testing.T) {
+	for name, keyJSON := range map[string]string{"scrypt": testVectorScrypt, "pbkdf2": testVectorPBKDF2} {
+		secret, err := DecryptKey([]byte(keyJSON), testVectorPassword)
+		if err != nil {
+			t.Fatalf("%s: failed to decrypt: %v", name, err)
+		}
+		raw := secret.Serialize()
+		if have := hex.EncodeToString(raw[:]); have != testVectorSecret {
+			t.Errorf("%s: wrong secret: have %s, want %s", name, have, testVectorSecret)
+		}
+		if _, err := DecryptKey([]byte(keyJSON), "wrong"); !errors.Is(err, ErrDecrypt) {
+			t.Errorf("%s: wrong error for bad password: have %v, want %v", name, err, ErrDecrypt)
+		}
+	}
+}
+
+func TestEncryptKey(t *testing.T) {
+	secret, err := generateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Control codes are stripped from the password
+	keyJSON, err := EncryptKey(secret, "pass\x7fword", LightScryptN, LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := DecryptKey(keyJSON, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, want := decrypted.Serialize(), secret.Serialize()
+	if !bytes.Equal(have[:], want[:]) {
+		t.Fatalf("wrong secret: have %x, want %x", have, want)
+	}
+}
+
+func TestManager(t *testing.T) {
+	m := NewManager(t.TempDir(), LightScryptN, LightScryptP)
+
+	pub, err := m.NewKey("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Sign(pub, []byte("msg")); !errors.Is(err, ErrLocked) {
+		t.Fatalf("wrong error signing with locked key: have %v, want %v", err, ErrLocked)
+	}
+	if err := m.Unlock(pub, "bar"); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("wrong error unlocking with bad password: have %v, want %v", err, ErrDecrypt)
+	}
+	if err := m.Unlock(pub, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	sig, err := m.Sign(pub, []byte("msg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		pubkey    blsu.Pubkey
+		signature blsu.Signature
+	)
+	raw := [48]byte(pub)
+	if err := pubkey.Deserialize(&raw); err != nil {
+		t.Fatal(err)
+	}
+	if err := signature.Deserialize(&sig); err != nil {
+		t.Fatal(err)
+	}
+	if !blsu.Verify(&pubkey, []byte("msg"), &signature) {
+		t.Fatal("invalid signature")
+	}
+	keys, err := m.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].PublicKey != pub || !keys[0].Unlocked {
+		t.Fatalf("wrong keys: %+v", keys)
+	}
+	// Move the key to another keystore
+	keyJSON, err := m.Export(pub, "foo", "baz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := NewManager(t.TempDir(), LightScryptN, LightScryptP)
+	if imported, err := other.Import(keyJSON, "baz"); err != nil || imported != pub {
+		t.Fatalf("failed to import key: %v (%v)", err, imported)
+	}
+	if _, err := other.Import(keyJSON, "baz"); !errors.Is(err, ErrKeyExists) {
+		t.Fatalf("wrong error importing existing key: have %v, want %v", err, ErrKeyExists)
+	}
+	if err := m.Delete(pub, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Sign(pub, []byte("msg")); !errors.Is(err, ErrLocked) {
+		t.Fatalf("deleted key still unlocked: %v", err)
+	}
+	if err := m.Unlock(pub, "foo"); !errors.Is(err, ErrNoKey) {
+		t.Fatalf("wrong error unlocking deleted key: have %v, want %v", err, ErrNoKey)
+	}
+}
@@ -0,0 +1,247 @@
+// Package blskeystore implements the encrypted storage of the BLS12-381 keys
+// used for attestations, kept apart from the ECDSA accounts sealing the blocks.
+//
+// Keys are stored as EIP-2335 key files, so they can be exchanged with other
+// tools following the standard.
+package blskeystore
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	blsu "github.com/protolambda/bls12-381-util"
+)
+
+var (
+	// ErrNoKey is returned if the requested key isn't in the keystore.
+	ErrNoKey = errors.New("no key for given public key")
+
+	// ErrLocked is returned if the requested key isn't unlocked.
+	ErrLocked = errors.New("key is locked")
+
+	// ErrKeyExists is returned when importing a key which is already stored.
+	ErrKeyExists = errors.New("key already exists")
+)
+
+// PublicKey is a compressed BLS12-381 public key.
+type PublicKey [48]byte
+
+// String implements fmt.Stringer.
+func (pub PublicKey) String() string {
+	return hexutil.Encode(pub[:])
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (pub PublicKey) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(pub[:]).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (pub *PublicKey) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("PublicKey", input, pub[:])
+}
+
+// Key describes a key stored in the keystore.
+type Key struct {
+	PublicKey PublicKey `json:"publicKey"`
+	File      string    `json:"file"`
+	Unlocked  bool      `json:"unlocked"`
+}
+
+// Manager stores the encrypted attestation keys in a directory and holds the
+// unlocked ones in memory for signing.
+type Manager struct {
+	dir     string
+	scryptN int
+	scryptP int
+
+	lock     sync.RWMutex
+	unlocked map[PublicKey]*blsu.SecretKey
+}
+
+// NewManager creates a key manager storing the keys in the given directory,
+// encrypting the new keys with the given scrypt parameters.
+func NewManager(dir string, scryptN, scryptP int) *Manager {
+	return &Manager{
+		dir:      dir,
+		scryptN:  scryptN,
+		scryptP:  scryptP,
+		unlocked: make(map[PublicKey]*blsu.SecretKey),
+	}
+}
+
+// Dir returns the directory of the key files.
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+// keyFilePath returns the path of the key file of the public key.
+func (m *Manager) keyFilePath(pub PublicKey) string {
+	return filepath.Join(m.dir, fmt.Sprintf("keystore-%x.json", pub[:]))
+}
+
+// Keys returns the keys stored in the keystore, sorted by public key.
+func (m *Manager) Keys() ([]Key, error) {
+	files, err := filepath.Glob(filepath.Join(m.dir, "keystore-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var keys []Key
+	for _, file := range files {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "keystore-"), ".json")
+
+		var pub PublicKey
+		if err := pub.UnmarshalText([]byte("0x" + name)); err != nil {
+			continue // Not written by the manager
+		}
+		_, unlocked := m.unlocked[pub]
+		keys = append(keys, Key{PublicKey: pub, File: file, Unlocked: unlocked})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].PublicKey.String() < keys[j].PublicKey.String()
+	})
+	return keys, nil
+}
+
+// NewKey generates a new key and stores it encrypted with the password.
+func (m *Manager) NewKey(password string) (PublicKey, error) {
+	secret, err := generateKey()
+	if err != nil {
+		return PublicKey{}, err
+	}
+	keyJSON, err := EncryptKey(secret, password, m.scryptN, m.scryptP)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return m.store(secret, keyJSON)
+}
+
+// Import stores an EIP-2335 key file after checking that it decrypts with the
+// password.
+func (m *Manager) Import(keyJSON []byte, password string) (PublicKey, error) {
+	secret, err := DecryptKey(keyJSON, password)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return m.store(secret, keyJSON)
+}
+
+// Export decrypts the key with the password and returns it as an EIP-2335 key
+// file encrypted with the new password.
+func (m *Manager) Export(pub PublicKey, password, newPassword string) ([]byte, error) {
+	secret, err := m.decrypt(pub, password)
+	if err != nil {
+		return nil, err
+	}
+	return EncryptKey(secret, newPassword, m.scryptN, m.scryptP)
+}
+
+// Delete removes the key from the keystore after checking the password.
+func (m *Manager) Delete(pub PublicKey, password string) error {
+	if _, err := m.decrypt(pub, password); err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.unlocked, pub)
+	return os.Remove(m.keyFilePath(pub))
+}
+
+// Unlock decrypts the key with the password and keeps it in memory for signing
+// until locked.
+func (m *Manager) Unlock(pub PublicKey, password string) error {
+	secret, err := m.decrypt(pub, password)
+	if err != nil {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.unlocked[pub] = secret
+	return nil
+}
+
+// Lock removes the unlocked key from memory.
+func (m *Manager) Lock(pub PublicKey) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.unlocked, pub)
+}
+
+// Sign signs the message with the unlocked key, returning the compressed BLS
+// signature.
+func (m *Manager) Sign(pub PublicKey, msg []byte) ([96]byte, error) {
+	m.lock.RLock()
+	secret, ok := m.unlocked[pub]
+	m.lock.RUnlock()
+
+	if !ok {
+		return [96]byte{}, ErrLocked
+	}
+	return blsu.Sign(secret, msg).Serialize(), nil
+}
+
+// decrypt reads the key file of the public key and decrypts it.
+func (m *Manager) decrypt(pub PublicKey, password string) (*blsu.SecretKey, error) {
+	keyJSON, err := os.ReadFile(m.keyFilePath(pub))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	return DecryptKey(keyJSON, password)
+}
+
+// store writes the key file of the secret key into the keystore.
+func (m *Manager) store(secret *blsu.SecretKey, keyJSON []byte) (PublicKey, error) {
+	pubkey, err := blsu.SkToPk(secret)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	pub := PublicKey(pubkey.Serialize())
+
+	if err := os.MkdirAll(m.dir, 0700); err != nil {
+		return PublicKey{}, err
+	}
+	f, err := os.OpenFile(m.keyFilePath(pub), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if errors.Is(err, os.ErrExist) {
+		return PublicKey{}, ErrKeyExists
+	}
+	if err != nil {
+		return PublicKey{}, err
+	}
+	if _, err := f.Write(keyJSON); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return PublicKey{}, err
+	}
+	return pub, f.Close()
+}
+
+// generateKey creates a random BLS secret key.
+func generateKey() (*blsu.SecretKey, error) {
+	for {
+		var raw [32]byte
+		if _, err := rand.Read(raw[:]); err != nil {
+			return nil, err
+		}
+		// Values out of the scalar field are rejected, retry with fresh ones
+		secret := new(blsu.SecretKey)
+		if err := secret.Deserialize(&raw); err == nil {
+			return secret, nil
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// AWSSigner is a secp256k1 key held by AWS KMS, with key spec ECC_SECG_P256K1.
+// Requests are sent to the KMS JSON API and authenticated with the credentials
+// of the default AWS configuration.
+type AWSSigner struct {
+	keyID    string
+	region   string
+	endpoint string
+	creds    aws.CredentialsProvider
+	signer   *v4.Signer
+	client   *http.Client
+}
+
+// NewAWSSigner creates a signer of the AWS KMS key with the given id or ARN.
+func NewAWSSigner(ctx context.Context, region, keyID string) (*AWSSigner, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize AWS configuration: %v", err)
+	}
+	return newAWSSigner(fmt.Sprintf("https://kms.%s.amazonaws.com/", region), region, keyID, cfg.Credentials), nil
+}
+
+func newAWSSigner(endpoint, region, keyID string, creds aws.CredentialsProvider) *AWSSigner {
+	return &AWSSigner{
+		keyID:    keyID,
+		region:   region,
+		endpoint: endpoint,
+		creds:    creds,
+		signer:   v4.NewSigner(),
+		client:   new(http.Client),
+	}
+}
+
+// URL implements Signer.
+func (s *AWSSigner) URL() accounts.URL {
+	return accounts.URL{Scheme: "awskms", Path: s.region + "/" + s.keyID}
+}
+
+// PublicKey implements Signer.
+func (s *AWSSigner) PublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	var res struct {
+		PublicKey []byte
+	}
+	if err := s.call(ctx, "GetPublicKey", map[string]interface{}{"KeyId": s.keyID}, &res); err != nil {
+		return nil, err
+	}
+	return parsePublicKey(res.PublicKey)
+}
+
+// SignDigest implements Signer.
+func (s *AWSSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	req := map[string]interface{}{
+		"KeyId":            s.keyID,
+		"Message":          digest,
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": "ECDSA_SHA_256",
+	}
+	var res struct {
+		Signature []byte
+	}
+	if err := s.call(ctx, "Sign", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// call invokes an operation of the KMS JSON API.
+func (s *AWSSigner) call(ctx context.Context, op string, req interface{}, res interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	r.Header.Set("X-Amz-Target", "TrentService."+op)
+
+	creds, err := s.creds.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(body)
+	if err := s.signer.SignHTTP(ctx, creds, r, hex.EncodeToString(hash[:]), "kms", s.region, time.Now()); err != nil {
+		return err
+	}
+	resp, err := s.client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		json.Unmarshal(data, &failure)
+		return fmt.Errorf("aws kms %s failed: %s %s %s", op, resp.Status, failure.Type, failure.Message)
+	}
+	return json.Unmarshal(data, res)
+}
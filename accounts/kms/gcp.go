@@ -0,0 +1,175 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+const (
+	gcpEndpoint      = "https://cloudkms.googleapis.com/v1/"
+	gcpMetadataToken = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// TokenSource returns an OAuth2 access token authorizing Cloud KMS requests.
+type TokenSource func(ctx context.Context) (string, error)
+
+// GCPSigner is a secp256k1 key version held by Google Cloud KMS, with algorithm
+// EC_SIGN_SECP256K1_SHA256. Requests are sent to the Cloud KMS REST API.
+type GCPSigner struct {
+	name     string // Resource name of the key version
+	endpoint string
+	token    TokenSource
+	client   *http.Client
+}
+
+// NewGCPSigner creates a signer of the Cloud KMS key version with the given
+// resource name, projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*.
+// If token is nil, the access token is taken from the GOOGLE_OAUTH_ACCESS_TOKEN
+// environment variable or else from the metadata server of the instance.
+func NewGCPSigner(name string, token TokenSource) *GCPSigner {
+	if token == nil {
+		token = defaultGCPToken()
+	}
+	return &GCPSigner{
+		name:     name,
+		endpoint: gcpEndpoint,
+		token:    token,
+		client:   new(http.Client),
+	}
+}
+
+// URL implements Signer.
+func (s *GCPSigner) URL() accounts.URL {
+	return accounts.URL{Scheme: "gcpkms", Path: s.name}
+}
+
+// PublicKey implements Signer.
+func (s *GCPSigner) PublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	var res struct {
+		Pem string `json:"pem"`
+	}
+	if err := s.call(ctx, http.MethodGet, s.name+"/publicKey", nil, &res); err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(res.Pem))
+	if block == nil {
+		return nil, errors.New("invalid public key PEM")
+	}
+	return parsePublicKey(block.Bytes)
+}
+
+// SignDigest implements Signer. Cloud KMS signs any 32 byte digest, so the
+// Keccak256 hash is passed in place of the SHA256 one.
+func (s *GCPSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	req := map[string]interface{}{
+		"digest": map[string]interface{}{"sha256": digest},
+	}
+	var res struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := s.call(ctx, http.MethodPost, s.name+":asymmetricSign", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// call invokes a method of the Cloud KMS REST API.
+func (s *GCPSigner) call(ctx context.Context, method, path string, req interface{}, res interface{}) error {
+	var body io.Reader
+	if req != nil {
+		data, err := json.Marshal(req)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	r, err := http.NewRequestWithContext(ctx, method, s.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	token, err := s.token(ctx)
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+	if req != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := s.client.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var failure struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		json.Unmarshal(data, &failure)
+		return fmt.Errorf("gcp kms request failed: %s %s", resp.Status, failure.Error.Message)
+	}
+	return json.Unmarshal(data, res)
+}
+
+// defaultGCPToken returns the token source of the environment: the token of the
+// GOOGLE_OAUTH_ACCESS_TOKEN variable if set, else the tokens of the instance
+// service account, cached until shortly before they expire.
+func defaultGCPToken() TokenSource {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return func(context.Context) (string, error) { return token, nil }
+	}
+	var (
+		lock    sync.Mutex
+		token   string
+		expires time.Time
+	)
+	return func(ctx context.Context) (string, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if token != "" && time.Now().Before(expires) {
+			return token, nil
+		}
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataToken, nil)
+		if err != nil {
+			return "", err
+		}
+		r.Header.Set("Metadata-Flavor", "Google")
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("metadata token request failed: %s", resp.Status)
+		}
+		var res struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int64  `json:"expires_in"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+			return "", err
+		}
+		token, expires = res.AccessToken, time.Now().Add(time.Duration(res.ExpiresIn)*time.Second-time.Minute)
+		return token, nil
+	}
+}
@@ -0,0 +1,304 @@
+// Package kms implements wallets whose keys are held by a key management
+// service, so the validator sealing key never enters the node process.
+//
+// The service signs the Keccak256 digests with a secp256k1 key, the wallet
+// turning the returned ASN.1 signatures into Ethereum ones. Every signing
+// request is bounded by a latency budget, so an unresponsive service fails the
+// sealing of the block instead of stalling it.
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// DefaultTimeout is the default latency budget of a signing request.
+const DefaultTimeout = time.Second
+
+// BackendType is the reflect type of the KMS backend, to retrieve it from the
+// account manager.
+var BackendType = reflect.TypeOf(&Backend{})
+
+var errNotSupported = errors.New("operation not supported on KMS wallets")
+
+// Signer is a secp256k1 key held by a key management service.
+type Signer interface {
+	// URL identifies the key within the service.
+	URL() accounts.URL
+
+	// PublicKey retrieves the public key of the key.
+	PublicKey(ctx context.Context) (*ecdsa.PublicKey, error)
+
+	// SignDigest signs the 32 byte digest, returning the ASN.1 DER encoded
+	// ECDSA signature.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// NewSigner creates the signer of a KMS key from its URL, either
+// awskms://<region>/<key id or ARN> or gcpkms://<key version resource name>.
+func NewSigner(ctx context.Context, url string) (Signer, error) {
+	scheme, path, ok := strings.Cut(url, "://")
+	if !ok || path == "" {
+		return nil, errors.New("invalid KMS key URL")
+	}
+	switch scheme {
+	case "awskms":
+		region, keyID, ok := strings.Cut(path, "/")
+		if !ok || region == "" || keyID == "" {
+			return nil, errors.New("AWS KMS key URL needs region and key id")
+		}
+		return NewAWSSigner(ctx, region, keyID)
+
+	case "gcpkms":
+		return NewGCPSigner(path, nil), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported KMS scheme %q", scheme)
+	}
+}
+
+// Health reports the state of a KMS wallet.
+type Health struct {
+	URL         string         `json:"url"`
+	Address     common.Address `json:"address"`
+	Healthy     bool           `json:"healthy"`
+	Latency     time.Duration  `json:"latency"`              // Latency of the health check
+	LastLatency time.Duration  `json:"lastSignLatency"`      // Latency of the last signing request
+	Signatures  uint64         `json:"signatures"`           // Number of successful signing requests
+	Failures    uint64         `json:"failures"`             // Number of failed signing requests
+	LastError   string         `json:"lastError,omitempty"`  // Error of the last failed request
+	CheckError  string         `json:"checkError,omitempty"` // Error of the health check
+}
+
+// Backend is an account backend serving the wallets of KMS keys.
+type Backend struct {
+	wallets []accounts.Wallet
+}
+
+// NewBackend creates a backend of the given KMS keys, retrieving their public
+// keys to derive the account addresses. The timeout bounds every request to
+// the services, DefaultTimeout being used if zero.
+func NewBackend(ctx context.Context, timeout time.Duration, signers ...Signer) (*Backend, error) {
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	b := new(Backend)
+	for _, signer := range signers {
+		w, err := newWallet(ctx, signer, timeout)
+		if err != nil {
+			return nil, err
+		}
+		b.wallets = append(b.wallets, w)
+	}
+	return b, nil
+}
+
+// Wallets implements accounts.Backend.
+func (b *Backend) Wallets() []accounts.Wallet {
+	return b.wallets
+}
+
+// Subscribe implements accounts.Backend. The wallets are static, so no events
+// are ever sent.
+func (b *Backend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+// Health checks the reachability of the services by retrieving the public key
+// of every wallet.
+func (b *Backend) Health(ctx context.Context) []Health {
+	reports := make([]Health, len(b.wallets))
+	for i, w := range b.wallets {
+		reports[i] = w.(*wallet).health(ctx)
+	}
+	return reports
+}
+
+// wallet is an accounts.Wallet signing with a KMS key.
+type wallet struct {
+	signer  Signer
+	account accounts.Account
+	timeout time.Duration
+
+	lock        sync.Mutex
+	lastLatency time.Duration
+	signatures  uint64
+	failures    uint64
+	lastErr     error
+}
+
+func newWallet(ctx context.Context, signer Signer, timeout time.Duration) (*wallet, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pub, err := signer.PublicKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &wallet{
+		signer:  signer,
+		account: accounts.Account{Address: crypto.PubkeyToAddress(*pub), URL: signer.URL()},
+		timeout: timeout,
+	}, nil
+}
+
+// URL implements accounts.Wallet.
+func (w *wallet) URL() accounts.URL {
+	return w.account.URL
+}
+
+// Status implements accounts.Wallet, returning the error of the last signing
+// request if it failed.
+func (w *wallet) Status() (string, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.lastErr != nil {
+		return "Failing", w.lastErr
+	}
+	return "Online", nil
+}
+
+// Open implements accounts.Wallet, the keys need no unlocking.
+func (w *wallet) Open(passphrase string) error { return nil }
+
+// Close implements accounts.Wallet.
+func (w *wallet) Close() error { return nil }
+
+// Accounts implements accounts.Wallet.
+func (w *wallet) Accounts() []accounts.Account {
+	return []accounts.Account{w.account}
+}
+
+// Contains implements accounts.Wallet.
+func (w *wallet) Contains(account accounts.Account) bool {
+	return account.Address == w.account.Address
+}
+
+// Derive implements accounts.Wallet, KMS keys are not hierarchical.
+func (w *wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, errNotSupported
+}
+
+// SelfDerive implements accounts.Wallet.
+func (w *wallet) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {}
+
+// SignData implements accounts.Wallet, signing the Keccak256 hash of the data.
+func (w *wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return w.SignHash(account, crypto.Keccak256(data))
+}
+
+// SignDataWithPassphrase implements accounts.Wallet, the passphrase is ignored.
+func (w *wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return w.SignData(account, mimeType, data)
+}
+
+// SignText implements accounts.Wallet.
+func (w *wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	sig, err := w.SignHash(account, accounts.TextHash(text))
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, the passphrase is ignored.
+func (w *wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	return w.SignText(account, text)
+}
+
+// SignTx implements accounts.Wallet.
+func (w *wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	sig, err := w.SignHash(account, signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, the passphrase is ignored.
+func (w *wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.SignTx(account, tx, chainID)
+}
+
+// SignHash signs the hash with the KMS key within the latency budget, returning
+// the signature in the [R || S || V] format with V 0 or 1.
+func (w *wallet) SignHash(account accounts.Account, hash []byte) ([]byte, error) {
+	if !w.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	start := time.Now()
+	der, err := w.signer.SignDigest(ctx, hash)
+	var sig []byte
+	if err == nil {
+		sig, err = toEthereumSignature(der, hash, w.account.Address)
+	}
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.lastLatency = time.Since(start)
+	if err != nil {
+		w.failures++
+		w.lastErr = err
+		return nil, err
+	}
+	w.signatures++
+	w.lastErr = nil
+	return sig, nil
+}
+
+// health checks the reachability of the service.
+func (w *wallet) health(ctx context.Context) Health {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	start := time.Now()
+	pub, err := w.signer.PublicKey(ctx)
+	if err == nil && crypto.PubkeyToAddress(*pub) != w.account.Address {
+		err = errors.New("public key changed")
+	}
+	report := Health{
+		URL:     w.account.URL.String(),
+		Address: w.account.Address,
+		Healthy: err == nil,
+		Latency: time.Since(start),
+	}
+	if err != nil {
+		report.CheckError = err.Error()
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	report.LastLatency = w.lastLatency
+	report.Signatures = w.signatures
+	report.Failures = w.failures
+	if w.lastErr != nil {
+		report.LastError = w.lastErr.Error()
+	}
+	return report
+}
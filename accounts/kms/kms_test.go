@@ -0,0 +1,168 @@
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testSigner is a local key answering like a KMS, with a configurable delay.
+type testSigner struct {
+	key   *ecdsa.PrivateKey
+	delay time.Duration
+	high  bool // Return the high-S form of the signatures
+}
+
+func (s *testSigner) URL() accounts.URL {
+	return accounts.URL{Scheme: "testkms", Path: "key"}
+}
+
+func (s *testSigner) PublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	return &s.key.PublicKey, nil
+}
+
+func (s *testSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	sig, err := crypto.Sign(digest, s.key)
+	if err != nil {
+		return nil, err
+	}
+	r, sv := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if s.high {
+		sv.Sub(secp256k1N, sv)
+	}
+	return asn1.Marshal(derSignature{R: r, S: sv})
+}
+
+func TestWalletSign(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	for _, high := range []bool{false, true} {
+		backend, err := NewBackend(context.Background(), 0, &testSigner{key: key, high: high})
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := backend.Wallets()[0]
+		account := accounts.Account{Address: addr}
+		if !w.Contains(account) {
+			t.Fatal("wallet does not contain the KMS account")
+		}
+		sig, err := w.SignData(account, accounts.MimetypeTurbo, []byte("header"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := crypto.SigToPub(crypto.Keccak256([]byte("header")), sig)
+		if err != nil || crypto.PubkeyToAddress(*pub) != addr {
+			t.Fatalf("wrong signer recovered: %v", err)
+		}
+		tx := types.NewTransaction(0, common.Address{1}, big.NewInt(1), 21000, big.NewInt(1), nil)
+		signed, err := w.SignTx(account, tx, big.NewInt(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), signed); err != nil || from != addr {
+			t.Fatalf("wrong transaction sender: have %x, want %x (%v)", from, addr, err)
+		}
+	}
+}
+
+func TestWalletLatencyBudget(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := &testSigner{key: key, delay: time.Second}
+
+	backend, err := NewBackend(context.Background(), 50*time.Millisecond, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := backend.Wallets()[0]
+	account := accounts.Account{Address: crypto.PubkeyToAddress(key.PublicKey)}
+
+	if _, err := w.SignData(account, accounts.MimetypeTurbo, []byte("header")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wrong error exceeding the budget: have %v, want %v", err, context.DeadlineExceeded)
+	}
+	if _, err := w.Status(); err == nil {
+		t.Error("failing wallet reported healthy status")
+	}
+	reports := backend.Health(context.Background())
+	if len(reports) != 1 || !reports[0].Healthy || reports[0].Failures != 1 || reports[0].LastError == "" {
+		t.Fatalf("wrong health report: %+v", reports)
+	}
+}
+
+func TestGCPSigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	local := &testSigner{key: key}
+	name := "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/" + name + "/publicKey":
+			curve, _ := asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 10})
+			info, _ := asn1.Marshal(subjectPublicKeyInfo{
+				Algorithm: pkix.AlgorithmIdentifier{
+					Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+					Parameters: asn1.RawValue{FullBytes: curve},
+				},
+				PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(&key.PublicKey), BitLength: 65 * 8},
+			})
+			json.NewEncoder(w).Encode(map[string]string{"pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: info}))})
+
+		case "/" + name + ":asymmetricSign":
+			var req struct {
+				Digest struct {
+					Sha256 []byte `json:"sha256"`
+				} `json:"digest"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			sig, _ := local.SignDigest(r.Context(), req.Digest.Sha256)
+			json.NewEncoder(w).Encode(map[string][]byte{"signature": sig})
+
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	signer := NewGCPSigner(name, func(context.Context) (string, error) { return "token", nil })
+	signer.endpoint = server.URL + "/"
+
+	backend, err := NewBackend(context.Background(), 0, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	if have := backend.Wallets()[0].Accounts()[0].Address; have != addr {
+		t.Fatalf("wrong address: have %x, want %x", have, addr)
+	}
+	sig, err := backend.Wallets()[0].SignText(accounts.Account{Address: addr}, []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] -= 27
+	if pub, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), sig); err != nil || crypto.PubkeyToAddress(*pub) != addr {
+		t.Fatalf("wrong signer recovered: %v", err)
+	}
+}
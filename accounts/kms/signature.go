@@ -0,0 +1,76 @@
+package kms
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	secp256k1N, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	secp256k1halfN = new(big.Int).Rsh(secp256k1N, 1)
+
+	errInvalidSignature = errors.New("invalid KMS signature")
+)
+
+// derSignature is the ASN.1 structure of an ECDSA signature.
+type derSignature struct {
+	R, S *big.Int
+}
+
+// subjectPublicKeyInfo is the ASN.1 structure of an encoded public key.
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// toEthereumSignature converts the ASN.1 DER encoded ECDSA signature of the hash
+// into the [R || S || V] format, normalizing S to the lower half of the curve
+// order and finding the recovery id matching the signing address.
+func toEthereumSignature(der []byte, hash []byte, address common.Address) ([]byte, error) {
+	var parsed derSignature
+	if rest, err := asn1.Unmarshal(der, &parsed); err != nil || len(rest) > 0 {
+		return nil, errInvalidSignature
+	}
+	if parsed.R == nil || parsed.S == nil || parsed.R.Sign() <= 0 || parsed.S.Sign() <= 0 ||
+		parsed.R.Cmp(secp256k1N) >= 0 || parsed.S.Cmp(secp256k1N) >= 0 {
+		return nil, errInvalidSignature
+	}
+	s := parsed.S
+	if s.Cmp(secp256k1halfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	parsed.R.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+
+	for v := byte(0); v < 2; v++ {
+		sig[crypto.RecoveryIDOffset] = v
+		pub, err := crypto.Ecrecover(hash, sig)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(crypto.Keccak256(pub[1:])[12:], address[:]) {
+			return sig, nil
+		}
+	}
+	return nil, errInvalidSignature
+}
+
+// parsePublicKey decodes a DER encoded SubjectPublicKeyInfo holding a secp256k1
+// public key, which the standard library doesn't support.
+func parsePublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info subjectPublicKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("trailing data after public key")
+	}
+	return crypto.UnmarshalPubkey(info.PublicKey.RightAlign())
+}
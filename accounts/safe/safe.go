@@ -0,0 +1,341 @@
+// Package safe builds, signs and executes transactions of Gnosis Safe multisig
+// wallets, so that accounts such as validator managers can be controlled by a
+// team of owners rather than a single key.
+//
+// A Safe transaction is proposed with the current nonce of the Safe, signed
+// offline by the owners, each signature being added to the proposal, and then
+// executed by any account once the threshold of the Safe is reached. The
+// proposals are JSON encodable to be passed around between the owners.
+package safe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SafeABI contains the methods of the Safe contract (v1.3.0 and later) used to
+// propose and execute transactions.
+const SafeABI = `[
+	{
+		"inputs": [
+			{"internalType": "address", "name": "to", "type": "address"},
+			{"internalType": "uint256", "name": "value", "type": "uint256"},
+			{"internalType": "bytes", "name": "data", "type": "bytes"},
+			{"internalType": "enum Enum.Operation", "name": "operation", "type": "uint8"},
+			{"internalType": "uint256", "name": "safeTxGas", "type": "uint256"},
+			{"internalType": "uint256", "name": "baseGas", "type": "uint256"},
+			{"internalType": "uint256", "name": "gasPrice", "type": "uint256"},
+			{"internalType": "address", "name": "gasToken", "type": "address"},
+			{"internalType": "address payable", "name": "refundReceiver", "type": "address"},
+			{"internalType": "bytes", "name": "signatures", "type": "bytes"}
+		],
+		"name": "execTransaction",
+		"outputs": [{"internalType": "bool", "name": "success", "type": "bool"}],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "nonce",
+		"outputs": [{"internalType": "uint256", "name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getThreshold",
+		"outputs": [{"internalType": "uint256", "name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [],
+		"name": "getOwners",
+		"outputs": [{"internalType": "address[]", "name": "", "type": "address[]"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// safeABI is the parsed SafeABI.
+var safeABI abi.ABI
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(SafeABI))
+	if err != nil {
+		panic(err)
+	}
+	safeABI = parsed
+}
+
+var (
+	// domainTypeHash is the EIP-712 type hash of the Safe domain.
+	domainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(uint256 chainId,address verifyingContract)"))
+
+	// safeTxTypeHash is the EIP-712 type hash of the Safe transactions.
+	safeTxTypeHash = crypto.Keccak256Hash([]byte("SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"))
+)
+
+var (
+	ErrNotOwner          = errors.New("signer is not an owner of the safe")
+	ErrThreshold         = errors.New("not enough signatures")
+	errInvalidSignature  = errors.New("invalid signature")
+	errSafeMismatch      = errors.New("proposal of another safe")
+	errMissingProposalID = errors.New("proposal without safe or chain id")
+)
+
+// Operation is the kind of call executed by the Safe.
+type Operation uint8
+
+const (
+	Call         Operation = 0
+	DelegateCall Operation = 1
+)
+
+// Transaction is a transaction executed by a Safe, with the fields of the
+// SafeTx EIP-712 type.
+type Transaction struct {
+	To             common.Address `json:"to"`
+	Value          *big.Int       `json:"value"`
+	Data           hexutil.Bytes  `json:"data"`
+	Operation      Operation      `json:"operation"`
+	SafeTxGas      *big.Int       `json:"safeTxGas"`
+	BaseGas        *big.Int       `json:"baseGas"`
+	GasPrice       *big.Int       `json:"gasPrice"`
+	GasToken       common.Address `json:"gasToken"`
+	RefundReceiver common.Address `json:"refundReceiver"`
+	Nonce          *big.Int       `json:"nonce"`
+}
+
+// word returns the 32 bytes ABI encoding of the integer, zero if nil.
+func word(n *big.Int) []byte {
+	if n == nil {
+		return make([]byte, 32)
+	}
+	return math.U256Bytes(new(big.Int).Set(n))
+}
+
+// SigningData returns the EIP-712 encoding of the transaction executed by the
+// given Safe, whose Keccak256 hash is signed by the owners.
+func (tx *Transaction) SigningData(chainID *big.Int, safe common.Address) []byte {
+	domain := crypto.Keccak256(
+		domainTypeHash[:],
+		word(chainID),
+		common.LeftPadBytes(safe[:], 32),
+	)
+	message := crypto.Keccak256(
+		safeTxTypeHash[:],
+		common.LeftPadBytes(tx.To[:], 32),
+		word(tx.Value),
+		crypto.Keccak256(tx.Data),
+		word(big.NewInt(int64(tx.Operation))),
+		word(tx.SafeTxGas),
+		word(tx.BaseGas),
+		word(tx.GasPrice),
+		common.LeftPadBytes(tx.GasToken[:], 32),
+		common.LeftPadBytes(tx.RefundReceiver[:], 32),
+		word(tx.Nonce),
+	)
+	return append(append([]byte{0x19, 0x01}, domain...), message...)
+}
+
+// Hash returns the Safe transaction hash of the transaction executed by the
+// given Safe.
+func (tx *Transaction) Hash(chainID *big.Int, safe common.Address) common.Hash {
+	return crypto.Keccak256Hash(tx.SigningData(chainID, safe))
+}
+
+// Signature is the signature of a Safe transaction by one of the owners.
+type Signature struct {
+	Owner     common.Address `json:"owner"`
+	Signature hexutil.Bytes  `json:"signature"` // [R || S || V] with V 27 or 28
+}
+
+// Proposal is a Safe transaction along with the signatures of the owners
+// collected so far.
+type Proposal struct {
+	Safe        common.Address `json:"safe"`
+	ChainID     *big.Int       `json:"chainId"`
+	Description string         `json:"description,omitempty"`
+	Tx          Transaction    `json:"tx"`
+	Signatures  []Signature    `json:"signatures"`
+}
+
+// Hash returns the Safe transaction hash signed by the owners.
+func (p *Proposal) Hash() common.Hash {
+	return p.Tx.Hash(p.ChainID, p.Safe)
+}
+
+// AddSignature adds the signature of an owner to the proposal, replacing its
+// previous signature if any, and returns the owner. The recovery id of the
+// signature may be 0 or 1, or 27 or 28.
+func (p *Proposal) AddSignature(sig []byte) (common.Address, error) {
+	if p.ChainID == nil || p.Safe == (common.Address{}) {
+		return common.Address{}, errMissingProposalID
+	}
+	if len(sig) != crypto.SignatureLength || (sig[crypto.RecoveryIDOffset] > 1 && sig[crypto.RecoveryIDOffset] != 27 && sig[crypto.RecoveryIDOffset] != 28) {
+		return common.Address{}, errInvalidSignature
+	}
+	sig = common.CopyBytes(sig)
+	if sig[crypto.RecoveryIDOffset] < 27 {
+		sig[crypto.RecoveryIDOffset] += 27
+	}
+	recoverable := common.CopyBytes(sig)
+	recoverable[crypto.RecoveryIDOffset] -= 27
+
+	hash := p.Hash()
+	pub, err := crypto.SigToPub(hash[:], recoverable)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %v", errInvalidSignature, err)
+	}
+	owner := crypto.PubkeyToAddress(*pub)
+
+	p.Signatures = slices.DeleteFunc(p.Signatures, func(s Signature) bool { return s.Owner == owner })
+	p.Signatures = append(p.Signatures, Signature{Owner: owner, Signature: sig})
+	slices.SortFunc(p.Signatures, func(a, b Signature) int { return bytes.Compare(a.Owner[:], b.Owner[:]) })
+	return owner, nil
+}
+
+// Sign signs the proposal with the account of the wallet, which must sign the
+// Keccak256 hash of the typed data, and adds the signature to the proposal.
+func (p *Proposal) Sign(wallet accounts.Wallet, account accounts.Account) error {
+	sig, err := wallet.SignData(account, accounts.MimetypeTypedData, p.Tx.SigningData(p.ChainID, p.Safe))
+	if err != nil {
+		return err
+	}
+	owner, err := p.AddSignature(sig)
+	if err != nil {
+		return err
+	}
+	if owner != account.Address {
+		return fmt.Errorf("%w: signed by %s instead of %s", errInvalidSignature, owner, account.Address)
+	}
+	return nil
+}
+
+// PackedSignatures returns the signatures in the format expected by the Safe,
+// concatenated in the ascending order of the owners.
+func (p *Proposal) PackedSignatures() []byte {
+	packed := make([]byte, 0, len(p.Signatures)*crypto.SignatureLength)
+	for _, sig := range p.Signatures {
+		packed = append(packed, sig.Signature...)
+	}
+	return packed
+}
+
+// Safe proposes and executes transactions of a Safe contract through a backend.
+type Safe struct {
+	address  common.Address
+	contract *bind.BoundContract
+}
+
+// NewSafe creates a client of the Safe contract deployed at the given address.
+func NewSafe(address common.Address, backend bind.ContractBackend) *Safe {
+	return &Safe{
+		address:  address,
+		contract: bind.NewBoundContract(address, safeABI, backend, backend, backend),
+	}
+}
+
+// Address returns the address of the Safe contract.
+func (s *Safe) Address() common.Address {
+	return s.address
+}
+
+// callUint retrieves an integer returned by a view method of the Safe.
+func (s *Safe) callUint(opts *bind.CallOpts, method string) (*big.Int, error) {
+	var out []interface{}
+	if err := s.contract.Call(opts, &out, method); err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+// Nonce retrieves the nonce of the next transaction executed by the Safe.
+func (s *Safe) Nonce(opts *bind.CallOpts) (*big.Int, error) {
+	return s.callUint(opts, "nonce")
+}
+
+// Threshold retrieves the number of owner signatures needed to execute a
+// transaction.
+func (s *Safe) Threshold(opts *bind.CallOpts) (*big.Int, error) {
+	return s.callUint(opts, "getThreshold")
+}
+
+// Owners retrieves the owners of the Safe.
+func (s *Safe) Owners(opts *bind.CallOpts) ([]common.Address, error) {
+	var out []interface{}
+	if err := s.contract.Call(opts, &out, "getOwners"); err != nil {
+		return nil, err
+	}
+	return *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address), nil
+}
+
+// Propose creates an unsigned proposal of the transaction, executed with the
+// next nonce of the Safe on the chain with the given id.
+func (s *Safe) Propose(opts *bind.CallOpts, chainID *big.Int, tx Transaction) (*Proposal, error) {
+	nonce, err := s.Nonce(opts)
+	if err != nil {
+		return nil, err
+	}
+	tx.Nonce = nonce
+	return &Proposal{Safe: s.address, ChainID: chainID, Tx: tx}, nil
+}
+
+// Verify checks that the proposal is signed by enough owners of the Safe to be
+// executed.
+func (s *Safe) Verify(opts *bind.CallOpts, p *Proposal) error {
+	if p.Safe != s.address {
+		return errSafeMismatch
+	}
+	owners, err := s.Owners(opts)
+	if err != nil {
+		return err
+	}
+	threshold, err := s.Threshold(opts)
+	if err != nil {
+		return err
+	}
+	for _, sig := range p.Signatures {
+		if !slices.Contains(owners, sig.Owner) {
+			return fmt.Errorf("%w: %s", ErrNotOwner, sig.Owner)
+		}
+	}
+	if big.NewInt(int64(len(p.Signatures))).Cmp(threshold) < 0 {
+		return fmt.Errorf("%w: have %d, want %d", ErrThreshold, len(p.Signatures), threshold)
+	}
+	return nil
+}
+
+// Exec submits the execution of the signed proposal with the given transaction
+// options. Any account can execute a proposal signed by enough owners.
+func (s *Safe) Exec(opts *bind.TransactOpts, p *Proposal) (*types.Transaction, error) {
+	if p.Safe != s.address {
+		return nil, errSafeMismatch
+	}
+	tx := &p.Tx
+	return s.contract.Transact(opts, "execTransaction",
+		tx.To, zeroIfNil(tx.Value), []byte(tx.Data), uint8(tx.Operation),
+		zeroIfNil(tx.SafeTxGas), zeroIfNil(tx.BaseGas), zeroIfNil(tx.GasPrice),
+		tx.GasToken, tx.RefundReceiver, p.PackedSignatures())
+}
+
+func zeroIfNil(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return n
+}
@@ -0,0 +1,137 @@
+package safe
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Tests that the transaction hash matches the generic EIP-712 hashing of the
+// SafeTx typed data.
+func TestTransactionHash(t *testing.T) {
+	var (
+		chainID = big.NewInt(689)
+		safe    = common.HexToAddress("0x5afe5afe5afe5afe5afe5afe5afe5afe5afe5afe")
+	)
+	tx, err := SubStake(common.HexToAddress("0x1234"), big.NewInt(1000))
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	tx.Nonce = big.NewInt(7)
+	tx.GasPrice = big.NewInt(3)
+	tx.RefundReceiver = common.HexToAddress("0xfee")
+
+	typed := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: safe.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          "0",
+			"data":           tx.Data,
+			"operation":      "0",
+			"safeTxGas":      "0",
+			"baseGas":        "0",
+			"gasPrice":       "3",
+			"gasToken":       tx.GasToken.Hex(),
+			"refundReceiver": tx.RefundReceiver.Hex(),
+			"nonce":          "7",
+		},
+	}
+	want, _, err := apitypes.TypedDataAndHash(typed)
+	if err != nil {
+		t.Fatalf("failed to hash typed data: %v", err)
+	}
+	if have := tx.Hash(chainID, safe); !bytes.Equal(have[:], want) {
+		t.Fatalf("hash mismatch: have %x, want %x", have, want)
+	}
+	if tx.To != system.StakingContract {
+		t.Fatalf("target mismatch: have %s, want %s", tx.To, system.StakingContract)
+	}
+}
+
+// Tests that the owners signatures are recovered and packed in their order.
+func TestProposalSignatures(t *testing.T) {
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+
+	tx, err := ExitStaking(common.HexToAddress("0x1234"))
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	p := &Proposal{Safe: common.HexToAddress("0x5afe"), ChainID: big.NewInt(689), Tx: tx}
+	for i := 0; i < 3; i++ {
+		account, err := ks.NewAccount("")
+		if err != nil {
+			t.Fatalf("failed to create account: %v", err)
+		}
+		if err := ks.Unlock(account, ""); err != nil {
+			t.Fatalf("failed to unlock account: %v", err)
+		}
+		for _, wallet := range ks.Wallets() {
+			if wallet.Contains(account) {
+				if err := p.Sign(wallet, account); err != nil {
+					t.Fatalf("failed to sign proposal: %v", err)
+				}
+			}
+		}
+	}
+	if len(p.Signatures) != 3 {
+		t.Fatalf("signature count mismatch: have %d, want 3", len(p.Signatures))
+	}
+	// Signatures with 0 or 1 recovery ids are accepted
+	key, _ := crypto.GenerateKey()
+	sig, _ := crypto.Sign(p.Hash().Bytes(), key)
+	if _, err := p.AddSignature(sig); err != nil {
+		t.Fatalf("failed to add signature: %v", err)
+	}
+	if len(p.Signatures) != 4 {
+		t.Fatalf("signature count mismatch: have %d, want 4", len(p.Signatures))
+	}
+	// Signing again replaces the signature of the owner
+	resigned := common.CopyBytes(p.Signatures[1].Signature)
+	if _, err := p.AddSignature(resigned); err != nil {
+		t.Fatalf("failed to add signature: %v", err)
+	}
+	if len(p.Signatures) != 4 {
+		t.Fatalf("signature count mismatch after resigning: have %d, want 4", len(p.Signatures))
+	}
+	packed := p.PackedSignatures()
+	for i, s := range p.Signatures {
+		if i > 0 && bytes.Compare(p.Signatures[i-1].Owner[:], s.Owner[:]) >= 0 {
+			t.Fatalf("signatures not sorted by owner")
+		}
+		if v := s.Signature[crypto.RecoveryIDOffset]; v != 27 && v != 28 {
+			t.Fatalf("signature %d: invalid recovery id %d", i, v)
+		}
+		if !bytes.Equal(packed[i*crypto.SignatureLength:(i+1)*crypto.SignatureLength], s.Signature) {
+			t.Fatalf("signature %d: packed signature mismatch", i)
+		}
+	}
+}
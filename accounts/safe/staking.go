@@ -0,0 +1,50 @@
+package safe
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/system"
+)
+
+// stakingCall creates a Safe transaction calling the Staking system contract.
+func stakingCall(value *big.Int, method string, args ...interface{}) (Transaction, error) {
+	data, err := system.ABIPack(system.StakingContract, method, args...)
+	if err != nil {
+		return Transaction{}, err
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+	return Transaction{To: system.StakingContract, Value: value, Data: data, Operation: Call}, nil
+}
+
+// AddStake creates the Safe transaction adding the amount to the stakes of the
+// validator managed by the Safe.
+func AddStake(validator common.Address, amount *big.Int) (Transaction, error) {
+	return stakingCall(amount, "addStake", validator)
+}
+
+// SubStake creates the Safe transaction withdrawing the amount from the stakes
+// of the validator managed by the Safe.
+func SubStake(validator common.Address, amount *big.Int) (Transaction, error) {
+	return stakingCall(nil, "subStake", validator, amount)
+}
+
+// ExitStaking creates the Safe transaction exiting the validator managed by the
+// Safe.
+func ExitStaking(validator common.Address) (Transaction, error) {
+	return stakingCall(nil, "exitStaking", validator)
+}
+
+// ReStake creates the Safe transaction moving the amount of the stakes of a
+// validator managed by the Safe to another one.
+func ReStake(from, to common.Address, amount *big.Int) (Transaction, error) {
+	return stakingCall(nil, "reStaking", from, to, amount)
+}
+
+// ClaimRewards creates the Safe transaction claiming the rewards of the
+// validator managed by the Safe.
+func ClaimRewards(validator common.Address) (Transaction, error) {
+	return stakingCall(nil, "validatorClaimAny", validator)
+}
@@ -0,0 +1,270 @@
+// Package autocompound periodically claims and restakes delegation rewards.
+//
+// For each configured delegation, the rewards claimable from the Staking
+// contract are claimed and delegated again to the same validator once they
+// reach a minimum amount, compounding the rewards without operator action.
+// The transactions are signed with the delegator accounts of the node keystore,
+// which must be unlocked. In dry-run mode, the transactions are only built and
+// logged.
+package autocompound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/contracts/system/bindings"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+)
+
+const (
+	// DefaultInterval is the default time between two compounding rounds.
+	DefaultInterval = time.Hour
+
+	// DefaultGasCap is the default maximum gas of a compounding transaction.
+	DefaultGasCap = 300000
+
+	// receiptTimeout is the time waited for a transaction to be mined.
+	receiptTimeout = 5 * time.Minute
+)
+
+// Delegation is a delegation whose rewards are compounded.
+type Delegation struct {
+	Delegator common.Address // Keystore account the delegation is owned by
+	Validator common.Address // Validator the rewards are delegated to again
+}
+
+// ParseDelegation parses a delegation in the delegator:validator format.
+func ParseDelegation(s string) (Delegation, error) {
+	delegator, validator, _ := strings.Cut(s, ":")
+	if !common.IsHexAddress(delegator) || !common.IsHexAddress(validator) {
+		return Delegation{}, fmt.Errorf("invalid delegation %q, want delegator:validator", s)
+	}
+	return Delegation{
+		Delegator: common.HexToAddress(delegator),
+		Validator: common.HexToAddress(validator),
+	}, nil
+}
+
+// Config is the configuration of the reward compounder.
+type Config struct {
+	Delegations []Delegation  `toml:",omitempty"` // Delegations to compound, the service is disabled if empty
+	Interval    time.Duration `toml:",omitempty"` // Time between two compounding rounds
+	MinRewards  *big.Int      `toml:",omitempty"` // Minimum rewards worth compounding, in wei
+	GasCap      uint64        `toml:",omitempty"` // Maximum gas of a compounding transaction
+	DryRun      bool          `toml:",omitempty"` // Only log the transactions instead of sending them
+}
+
+// Backend is the chain the compounding transactions are sent to.
+type Backend interface {
+	bind.DeployBackend
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+}
+
+// stakingContract is the subset of the Staking contract bindings used to
+// compound the rewards.
+type stakingContract interface {
+	ClaimableRewards(opts *bind.CallOpts, val common.Address, stakeOwner common.Address) (*big.Int, error)
+	DelegatorClaimAny(opts *bind.TransactOpts, val common.Address) (*types.Transaction, error)
+	AddDelegation(opts *bind.TransactOpts, val common.Address) (*types.Transaction, error)
+}
+
+// Service compounds the delegation rewards, run as a node lifecycle.
+type Service struct {
+	config  Config
+	backend Backend
+	staking stakingContract
+	signer  bind.SignerFn
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates the reward compounder and registers it with the node. The
+// delegators must be accounts of the node keystore.
+func New(stack *node.Node, chainID *big.Int, config Config) (*Service, error) {
+	if len(config.Delegations) == 0 {
+		return nil, errors.New("no delegation to compound")
+	}
+	am := stack.AccountManager()
+	for _, d := range config.Delegations {
+		if _, err := am.Find(accounts.Account{Address: d.Delegator}); err != nil {
+			return nil, fmt.Errorf("delegator %s: %w", d.Delegator, err)
+		}
+	}
+	client := ethclient.NewClient(stack.Attach())
+	staking, err := bindings.NewStaking(system.StakingContract, client)
+	if err != nil {
+		return nil, err
+	}
+	s := newService(client, staking, keystoreSigner(am, chainID), config)
+	stack.RegisterLifecycle(s)
+	return s, nil
+}
+
+func newService(backend Backend, staking stakingContract, signer bind.SignerFn, config Config) *Service {
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.GasCap == 0 {
+		config.GasCap = DefaultGasCap
+	}
+	return &Service{
+		config:  config,
+		backend: backend,
+		staking: staking,
+		signer:  signer,
+	}
+}
+
+// keystoreSigner returns a transaction signer using the accounts of the
+// manager.
+func keystoreSigner(am *accounts.Manager, chainID *big.Int) bind.SignerFn {
+	return func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		account := accounts.Account{Address: addr}
+		wallet, err := am.Find(account)
+		if err != nil {
+			return nil, err
+		}
+		return wallet.SignTx(account, tx, chainID)
+	}
+}
+
+// Start implements node.Lifecycle, compounding the rewards in the background.
+func (s *Service) Start() error {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.wg.Add(1)
+	go s.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle, interrupting the pending round.
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// loop runs a compounding round at every interval.
+func (s *Service) loop() {
+	defer s.wg.Done()
+
+	log.Info("Started reward compounder", "delegations", len(s.config.Delegations), "interval", s.config.Interval, "dryrun", s.config.DryRun)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.compoundAll()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// compoundAll compounds the rewards of all the delegations, unless the node
+// is syncing and the claimable rewards are not up to date.
+func (s *Service) compoundAll() {
+	progress, err := s.backend.SyncProgress(s.ctx)
+	if err != nil {
+		log.Warn("Failed to check sync progress", "err", err)
+		return
+	}
+	if progress != nil {
+		log.Debug("Skipping reward compounding while syncing")
+		return
+	}
+	for _, d := range s.config.Delegations {
+		if err := s.compound(d); err != nil {
+			log.Warn("Failed to compound delegation rewards", "delegator", d.Delegator, "validator", d.Validator, "err", err)
+		}
+	}
+}
+
+// compound claims the rewards of the delegation and delegates them again to
+// the validator. Only the rewards read before the claim are restaked, the ones
+// released by the time the claim is mined are left for the next round.
+func (s *Service) compound(d Delegation) error {
+	rewards, err := s.staking.ClaimableRewards(&bind.CallOpts{Context: s.ctx}, d.Validator, d.Delegator)
+	if err != nil {
+		return err
+	}
+	if rewards.Sign() == 0 || (s.config.MinRewards != nil && rewards.Cmp(s.config.MinRewards) < 0) {
+		log.Debug("Delegation rewards below minimum", "delegator", d.Delegator, "validator", d.Validator, "rewards", rewards)
+		return nil
+	}
+	claim, err := s.transact(d.Delegator, nil, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.staking.DelegatorClaimAny(opts, d.Validator)
+	})
+	if err != nil {
+		return fmt.Errorf("claim failed: %w", err)
+	}
+	if s.config.DryRun {
+		log.Info("Would compound delegation rewards", "delegator", d.Delegator, "validator", d.Validator, "rewards", rewards, "claimgas", claim.Gas())
+		return nil
+	}
+	restake, err := s.transact(d.Delegator, rewards, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return s.staking.AddDelegation(opts, d.Validator)
+	})
+	if err != nil {
+		return fmt.Errorf("restake failed: %w", err)
+	}
+	log.Info("Compounded delegation rewards", "delegator", d.Delegator, "validator", d.Validator, "rewards", rewards, "claim", claim.Hash(), "restake", restake.Hash())
+	return nil
+}
+
+// transact builds the transaction of the delegator, rejecting it if it exceeds
+// the gas cap, then sends it and waits for it to be successfully mined. In
+// dry-run mode, the unsigned transaction is returned without being sent.
+func (s *Service) transact(delegator common.Address, value *big.Int, build func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	opts := &bind.TransactOpts{
+		From:    delegator,
+		Signer:  s.signer,
+		Value:   value,
+		Context: s.ctx,
+		NoSend:  true,
+	}
+	if s.config.DryRun {
+		opts.Signer = func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) { return tx, nil }
+	}
+	tx, err := build(opts)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Gas() > s.config.GasCap {
+		return nil, fmt.Errorf("gas %d above cap %d", tx.Gas(), s.config.GasCap)
+	}
+	if s.config.DryRun {
+		return tx, nil
+	}
+	if err := s.backend.SendTransaction(s.ctx, tx); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, receiptTimeout)
+	defer cancel()
+
+	receipt, err := bind.WaitMined(ctx, s.backend, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", tx.Hash())
+	}
+	return tx, nil
+}
@@ -0,0 +1,162 @@
+package autocompound
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// testStaking builds the Staking contract transactions without executing them,
+// reporting the configured rewards.
+type testStaking struct {
+	rewards *big.Int
+	gas     uint64
+	nonce   uint64
+}
+
+func (s *testStaking) ClaimableRewards(opts *bind.CallOpts, val common.Address, stakeOwner common.Address) (*big.Int, error) {
+	return new(big.Int).Set(s.rewards), nil
+}
+
+func (s *testStaking) DelegatorClaimAny(opts *bind.TransactOpts, val common.Address) (*types.Transaction, error) {
+	return s.transact(opts, []byte("claim"))
+}
+
+func (s *testStaking) AddDelegation(opts *bind.TransactOpts, val common.Address) (*types.Transaction, error) {
+	return s.transact(opts, []byte("restake"))
+}
+
+func (s *testStaking) transact(opts *bind.TransactOpts, data []byte) (*types.Transaction, error) {
+	if !opts.NoSend {
+		return nil, errors.New("transaction sent by the binding")
+	}
+	value := opts.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	tx := types.NewTx(&types.LegacyTx{Nonce: s.nonce, Gas: s.gas, GasPrice: big.NewInt(1), Value: value, Data: data})
+	s.nonce++
+	return opts.Signer(opts.From, tx)
+}
+
+// testBackend records the sent transactions, mining them right away.
+type testBackend struct {
+	sent    []*types.Transaction
+	syncing bool
+}
+
+func (b *testBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.sent = append(b.sent, tx)
+	return nil
+}
+
+func (b *testBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	for _, tx := range b.sent {
+		if tx.Hash() == hash {
+			return &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: hash, BlockNumber: big.NewInt(1)}, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+func (b *testBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (b *testBackend) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	if b.syncing {
+		return &ethereum.SyncProgress{}, nil
+	}
+	return nil, nil
+}
+
+func TestParseDelegation(t *testing.T) {
+	var (
+		delegator = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+		validator = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	)
+	d, err := ParseDelegation(delegator.Hex() + ":" + validator.Hex())
+	if err != nil {
+		t.Fatalf("failed to parse delegation: %v", err)
+	}
+	if d.Delegator != delegator || d.Validator != validator {
+		t.Errorf("delegation mismatch: have %+v", d)
+	}
+	for _, invalid := range []string{"", delegator.Hex(), delegator.Hex() + ":", ":" + validator.Hex(), delegator.Hex() + ":0x1234"} {
+		if _, err := ParseDelegation(invalid); err == nil {
+			t.Errorf("invalid delegation %q accepted", invalid)
+		}
+	}
+}
+
+func TestCompound(t *testing.T) {
+	d := Delegation{Delegator: common.Address{0xaa}, Validator: common.Address{0xbb}}
+	signer := func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) { return tx, nil }
+
+	tests := []struct {
+		name    string
+		rewards int64
+		gas     uint64
+		config  Config
+		sent    int
+		fails   bool
+	}{
+		{name: "no rewards", rewards: 0, gas: 50000},
+		{name: "below minimum", rewards: 99, gas: 50000, config: Config{MinRewards: big.NewInt(100)}},
+		{name: "compounded", rewards: 100, gas: 50000, config: Config{MinRewards: big.NewInt(100)}, sent: 2},
+		{name: "dry run", rewards: 100, gas: 50000, config: Config{DryRun: true}},
+		{name: "gas above cap", rewards: 100, gas: 50001, config: Config{GasCap: 50000}, fails: true},
+	}
+	for _, tt := range tests {
+		var (
+			staking = &testStaking{rewards: big.NewInt(tt.rewards), gas: tt.gas}
+			backend = new(testBackend)
+		)
+		tt.config.Delegations = []Delegation{d}
+		s := newService(backend, staking, signer, tt.config)
+		s.ctx = context.Background()
+
+		err := s.compound(d)
+		if (err != nil) != tt.fails {
+			t.Errorf("%s: error mismatch: have %v, want failure %v", tt.name, err, tt.fails)
+		}
+		if len(backend.sent) != tt.sent {
+			t.Fatalf("%s: sent transactions mismatch: have %d, want %d", tt.name, len(backend.sent), tt.sent)
+		}
+		if tt.sent == 2 {
+			if claim := backend.sent[0]; string(claim.Data()) != "claim" || claim.Value().Sign() != 0 {
+				t.Errorf("%s: invalid claim transaction", tt.name)
+			}
+			if restake := backend.sent[1]; string(restake.Data()) != "restake" || restake.Value().Int64() != tt.rewards {
+				t.Errorf("%s: invalid restake transaction, value %v", tt.name, restake.Value())
+			}
+		}
+	}
+}
+
+func TestCompoundWhileSyncing(t *testing.T) {
+	var (
+		d       = Delegation{Delegator: common.Address{0xaa}, Validator: common.Address{0xbb}}
+		staking = &testStaking{rewards: big.NewInt(100), gas: 50000}
+		backend = &testBackend{syncing: true}
+		signer  = func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) { return tx, nil }
+	)
+	s := newService(backend, staking, signer, Config{Delegations: []Delegation{d}})
+	s.ctx = context.Background()
+
+	s.compoundAll()
+	if len(backend.sent) != 0 {
+		t.Fatalf("transactions sent while syncing: %d", len(backend.sent))
+	}
+	backend.syncing = false
+	s.compoundAll()
+	if len(backend.sent) != 2 {
+		t.Fatalf("sent transactions mismatch: have %d, want 2", len(backend.sent))
+	}
+}
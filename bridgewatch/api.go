@@ -0,0 +1,214 @@
+package bridgewatch
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Finality status of the watched events.
+const (
+	StatusUnsafe    = "unsafe"
+	StatusJustified = "justified"
+	StatusFinalized = "finalized"
+)
+
+// maxEventsRange is the maximum number of blocks the events are retrieved for
+// at once.
+const maxEventsRange = 10000
+
+var (
+	errEventNotFound = errors.New("bridge event not found")
+	errNotFinalized  = errors.New("bridge event not finalized")
+	errNoSigner      = errors.New("no attestation signer configured")
+)
+
+// Event is a watched bridge event.
+type Event struct {
+	Kind        string         `json:"kind"`
+	Contract    common.Address `json:"contract"`
+	Topics      []common.Hash  `json:"topics"`
+	Data        hexutil.Bytes  `json:"data"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	TxHash      common.Hash    `json:"transactionHash"`
+	LogIndex    hexutil.Uint   `json:"logIndex"`
+	Status      string         `json:"status"`
+}
+
+// Attestation is the signature of a finalized bridge event by the node signer.
+type Attestation struct {
+	Event     *Event         `json:"event"`
+	Digest    common.Hash    `json:"digest"`
+	Signer    common.Address `json:"signer"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// Status is the progress of the bridge watcher.
+type Status struct {
+	Next      hexutil.Uint64  `json:"next"` // Next block to watch
+	Justified hexutil.Uint64  `json:"justified"`
+	Finalized hexutil.Uint64  `json:"finalized"`
+	Signer    *common.Address `json:"signer,omitempty"`
+}
+
+// API exposes the watched bridge events and their attestations.
+type API struct {
+	s *Service
+}
+
+// NewAPI creates the API of the bridge watcher.
+func NewAPI(s *Service) *API {
+	return &API{s: s}
+}
+
+// Status returns the progress of the watcher and the finality checkpoints.
+func (api *API) Status() *Status {
+	justified, finalized := api.s.checkpoints()
+	status := &Status{
+		Next:      hexutil.Uint64(api.s.next()),
+		Justified: hexutil.Uint64(justified),
+		Finalized: hexutil.Uint64(finalized),
+	}
+	if api.s.sign != nil {
+		status.Signer = &api.s.signer
+	}
+	return status
+}
+
+// GetEvents returns the events watched in the canonical blocks of the range,
+// up to the last watched block if the end of the range is omitted.
+func (api *API) GetEvents(from hexutil.Uint64, to *hexutil.Uint64) ([]*Event, error) {
+	next := api.s.next()
+	last := uint64(math.MaxUint64)
+	if to != nil {
+		last = uint64(*to)
+	}
+	if next == 0 {
+		return []*Event{}, nil
+	}
+	if last >= next {
+		last = next - 1
+	}
+	if uint64(from) > last {
+		return []*Event{}, nil
+	}
+	if last-uint64(from) >= maxEventsRange {
+		return nil, fmt.Errorf("block range too large, max %d blocks", maxEventsRange)
+	}
+	var (
+		justified, finalized = api.s.checkpoints()
+		events               = []*Event{}
+	)
+	rawdb.IterateBridgeEvents(api.s.db, uint64(from), func(watched []*rawdb.BridgeEvent) bool {
+		number := watched[0].BlockNumber
+		if number > last {
+			return false
+		}
+		if api.s.chain.GetCanonicalHash(number) != watched[0].BlockHash {
+			return true
+		}
+		for _, e := range watched {
+			events = append(events, newEvent(e, justified, finalized))
+		}
+		return true
+	})
+	return events, nil
+}
+
+// GetAttestation returns the attestation of the finalized event logged by the
+// transaction at the given index.
+func (api *API) GetAttestation(txHash common.Hash, logIndex hexutil.Uint) (*Attestation, error) {
+	if api.s.sign == nil {
+		return nil, errNoSigner
+	}
+	number := rawdb.ReadTxLookupEntry(api.s.db, txHash)
+	if number == nil || *number >= api.s.next() {
+		return nil, errEventNotFound
+	}
+	hash := api.s.chain.GetCanonicalHash(*number)
+	var found *rawdb.BridgeEvent
+	for _, e := range rawdb.ReadBridgeEvents(api.s.db, *number, hash) {
+		if e.TxHash == txHash && e.LogIndex == uint(logIndex) {
+			found = e
+			break
+		}
+	}
+	if found == nil {
+		return nil, errEventNotFound
+	}
+	justified, finalized := api.s.checkpoints()
+	ev := newEvent(found, justified, finalized)
+	if ev.Status != StatusFinalized {
+		return nil, errNotFinalized
+	}
+	digest := api.s.digest(found)
+	sig, err := api.s.sign(digest.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27 // Transform V from 0/1 to 27/28 for ecrecover
+	return &Attestation{Event: ev, Digest: digest, Signer: api.s.signer, Signature: sig}, nil
+}
+
+// checkpoints returns the numbers of the justified and finalized blocks.
+func (s *Service) checkpoints() (justified, finalized uint64) {
+	if header := s.chain.CurrentSafeBlock(); header != nil {
+		justified = header.Number.Uint64()
+	}
+	if header := s.chain.CurrentFinalBlock(); header != nil {
+		finalized = header.Number.Uint64()
+	}
+	return justified, finalized
+}
+
+// digest returns the digest of the event signed by the attestations:
+//
+//	keccak256(chainId ‖ contract ‖ blockHash ‖ txHash ‖ logIndex ‖ keccak256(topics ‖ data))
+//
+// the chain identifier and the log index being 32 bytes big endian, as packed
+// by abi.encodePacked with uint256 values. The digest is signed as an EIP-191
+// text, like eth_sign.
+func (s *Service) digest(e *rawdb.BridgeEvent) common.Hash {
+	payload := make([]byte, 0, len(e.Topics)*common.HashLength+len(e.Data))
+	for _, topic := range e.Topics {
+		payload = append(payload, topic.Bytes()...)
+	}
+	payload = append(payload, e.Data...)
+
+	return crypto.Keccak256Hash(
+		common.LeftPadBytes(s.chainID().Bytes(), 32),
+		e.Contract.Bytes(),
+		e.BlockHash.Bytes(),
+		e.TxHash.Bytes(),
+		math.U256Bytes(new(big.Int).SetUint64(uint64(e.LogIndex))),
+		crypto.Keccak256(payload),
+	)
+}
+
+func newEvent(e *rawdb.BridgeEvent, justified, finalized uint64) *Event {
+	status := StatusUnsafe
+	switch {
+	case e.BlockNumber <= finalized:
+		status = StatusFinalized
+	case e.BlockNumber <= justified:
+		status = StatusJustified
+	}
+	return &Event{
+		Kind:        e.Kind,
+		Contract:    e.Contract,
+		Topics:      e.Topics,
+		Data:        e.Data,
+		BlockNumber: hexutil.Uint64(e.BlockNumber),
+		BlockHash:   e.BlockHash,
+		TxHash:      e.TxHash,
+		LogIndex:    hexutil.Uint(e.LogIndex),
+		Status:      status,
+	}
+}
@@ -0,0 +1,367 @@
+// Package bridgewatch watches the lock and unlock events of bridge contracts.
+//
+// The events of the configured contracts are collected from the canonical
+// blocks into a journal, checkpointed by a cursor so that the watcher resumes
+// where it stopped after a restart, and rewound when the watched blocks are
+// reorged. The events are served over the bridge RPC namespace along with their
+// finality status, and the finalized ones are attested with a signature of the
+// node signer account, so that the bridges relaying them to another chain only
+// have to check the attestations instead of running their own watcher.
+package bridgewatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Kinds of the watched events.
+const (
+	KindLock   = "lock"
+	KindUnlock = "unlock"
+)
+
+const (
+	// maxBatchBlocks is the maximum number of blocks watched at once.
+	maxBatchBlocks = 256
+
+	chainHeadChanSize = 10
+)
+
+// Config is the configuration of the bridge watcher.
+type Config struct {
+	Contracts    []common.Address `toml:",omitempty"` // Bridge contracts to watch, the service is disabled if empty
+	LockEvents   []string         `toml:",omitempty"` // Signatures of the lock events, e.g. Locked(address,uint256)
+	UnlockEvents []string         `toml:",omitempty"` // Signatures of the unlock events
+	Signer       common.Address   `toml:",omitempty"` // Keystore account signing the attestations, none if zero
+	From         *uint64          `toml:",omitempty"` // Block to watch from, overriding the cursor
+}
+
+// Chain is the blockchain the bridge events are watched on.
+type Chain interface {
+	Config() *params.ChainConfig
+	CurrentBlock() *types.Header
+	CurrentSafeBlock() *types.Header
+	CurrentFinalBlock() *types.Header
+	GetCanonicalHash(number uint64) common.Hash
+	GetHeader(hash common.Hash, number uint64) *types.Header
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// SignerFn signs the digest of an attestation as an EIP-191 text.
+type SignerFn func(digest []byte) ([]byte, error)
+
+// Service watches the bridge events, run as a node lifecycle.
+type Service struct {
+	chain     Chain
+	db        ethdb.Database
+	contracts map[common.Address]struct{}
+	kinds     map[common.Hash]string // Kind of the events by topic
+	signer    common.Address
+	sign      SignerFn
+	from      *uint64
+
+	cursor *rawdb.EventStreamCursor
+	lock   sync.RWMutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates the bridge watcher, registers it with the node and exposes its
+// API. The signer must be an account of the node keystore, unlocked for the
+// attestations to be signed.
+func New(stack *node.Node, chain Chain, db ethdb.Database, config Config) (*Service, error) {
+	if len(config.Contracts) == 0 {
+		return nil, errors.New("no bridge contract to watch")
+	}
+	var sign SignerFn
+	if config.Signer != (common.Address{}) {
+		am := stack.AccountManager()
+		if _, err := am.Find(accounts.Account{Address: config.Signer}); err != nil {
+			return nil, fmt.Errorf("signer %s: %w", config.Signer, err)
+		}
+		sign = keystoreSigner(am, config.Signer)
+	}
+	s, err := newService(chain, db, sign, config)
+	if err != nil {
+		return nil, err
+	}
+	stack.RegisterLifecycle(s)
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "bridge",
+		Service:   NewAPI(s),
+	}})
+	return s, nil
+}
+
+func newService(chain Chain, db ethdb.Database, sign SignerFn, config Config) (*Service, error) {
+	kinds := make(map[common.Hash]string)
+	for kind, sigs := range map[string][]string{KindLock: config.LockEvents, KindUnlock: config.UnlockEvents} {
+		for _, sig := range sigs {
+			topic, err := eventTopic(sig)
+			if err != nil {
+				return nil, err
+			}
+			if other, ok := kinds[topic]; ok && other != kind {
+				return nil, fmt.Errorf("event %q is both a lock and an unlock event", sig)
+			}
+			kinds[topic] = kind
+		}
+	}
+	if len(kinds) == 0 {
+		return nil, errors.New("no bridge event to watch")
+	}
+	contracts := make(map[common.Address]struct{}, len(config.Contracts))
+	for _, addr := range config.Contracts {
+		contracts[addr] = struct{}{}
+	}
+	s := &Service{
+		chain:     chain,
+		db:        db,
+		contracts: contracts,
+		kinds:     kinds,
+		from:      config.From,
+	}
+	if sign != nil {
+		s.signer, s.sign = config.Signer, sign
+	}
+	return s, nil
+}
+
+// eventTopic returns the topic of the event with the given signature, or the
+// topic itself if given as a hash.
+func eventTopic(sig string) (common.Hash, error) {
+	sig = strings.ReplaceAll(sig, " ", "")
+	if len(sig) == 2*common.HashLength+2 && strings.HasPrefix(sig, "0x") {
+		return common.HexToHash(sig), nil
+	}
+	open := strings.IndexByte(sig, '(')
+	if open <= 0 || !strings.HasSuffix(sig, ")") {
+		return common.Hash{}, fmt.Errorf("invalid event signature %q, want Name(type,...)", sig)
+	}
+	return crypto.Keccak256Hash([]byte(sig)), nil
+}
+
+// keystoreSigner returns an attestation signer using the account of the
+// manager.
+func keystoreSigner(am *accounts.Manager, addr common.Address) SignerFn {
+	return func(digest []byte) ([]byte, error) {
+		account := accounts.Account{Address: addr}
+		wallet, err := am.Find(account)
+		if err != nil {
+			return nil, err
+		}
+		return wallet.SignText(account, digest)
+	}
+}
+
+// Start implements node.Lifecycle, watching the events in the background.
+func (s *Service) Start() error {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.wg.Add(1)
+	go s.loop()
+	return nil
+}
+
+// Stop implements node.Lifecycle, interrupting the pending watch.
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// loop watches the events of the new blocks as the chain progresses.
+func (s *Service) loop() {
+	defer s.wg.Done()
+
+	heads := make(chan core.ChainHeadEvent, chainHeadChanSize)
+	headSub := s.chain.SubscribeChainHeadEvent(heads)
+	defer headSub.Unsubscribe()
+
+	s.init()
+	log.Info("Started bridge watcher", "contracts", len(s.contracts), "next", s.next())
+
+	for {
+		s.watch()
+		select {
+		case <-heads:
+		case <-headSub.Err():
+			return
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// init sets the cursor up, from the configured block if any, from the stored
+// cursor otherwise, or from the current head if the watcher never ran.
+func (s *Service) init() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch {
+	case s.from != nil:
+		s.cursor = &rawdb.EventStreamCursor{Next: *s.from}
+		if *s.from > 0 {
+			s.cursor.Parent = s.chain.GetCanonicalHash(*s.from - 1)
+		}
+	default:
+		if s.cursor = rawdb.ReadBridgeCursor(s.db); s.cursor == nil {
+			head := s.chain.CurrentBlock()
+			s.cursor = &rawdb.EventStreamCursor{Next: head.Number.Uint64(), Parent: head.ParentHash}
+		}
+	}
+}
+
+// next returns the number of the next block to watch, 0 until the watcher
+// started.
+func (s *Service) next() uint64 {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.cursor == nil {
+		return 0
+	}
+	return s.cursor.Next
+}
+
+// watch collects the events of the blocks from the cursor up to the chain head.
+func (s *Service) watch() {
+	for s.ctx.Err() == nil {
+		s.rewind()
+
+		head := s.chain.CurrentBlock().Number.Uint64()
+		next := s.next()
+		if next > head {
+			return
+		}
+		last := next + maxBatchBlocks - 1
+		if last > head {
+			last = head
+		}
+		if err := s.watchBlocks(next, last); err != nil {
+			// The blocks are missing while the chain is reorged or rewound,
+			// retry from the new head
+			log.Debug("Failed to watch bridge events", "next", next, "err", err)
+			return
+		}
+	}
+}
+
+// rewind moves the cursor back to the canonical chain if the watched blocks
+// were reorged, dropping their events from the journal.
+func (s *Service) rewind() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for s.cursor.Next > 0 && s.cursor.Parent != (common.Hash{}) {
+		if s.chain.GetCanonicalHash(s.cursor.Next-1) == s.cursor.Parent {
+			return
+		}
+		header := s.chain.GetHeader(s.cursor.Parent, s.cursor.Next-1)
+		if header == nil {
+			// The reorged block is gone, watch again the canonical one.
+			s.cursor.Parent = common.Hash{}
+			return
+		}
+		log.Debug("Rewinding bridge watcher", "number", header.Number, "hash", header.Hash())
+		rawdb.DeleteBridgeEvents(s.db, s.cursor.Next-1, s.cursor.Parent)
+		s.cursor.Next--
+		s.cursor.Parent = header.ParentHash
+	}
+}
+
+// watchBlocks collects the events of the canonical blocks in the range and
+// advances the cursor, atomically.
+func (s *Service) watchBlocks(first, last uint64) error {
+	var (
+		batch  = s.db.NewBatch()
+		parent common.Hash
+		count  int
+	)
+	for number := first; number <= last; number++ {
+		hash := s.chain.GetCanonicalHash(number)
+		if hash == (common.Hash{}) {
+			return errors.New("missing canonical block")
+		}
+		receipts := s.chain.GetReceiptsByHash(hash)
+		if receipts == nil {
+			return errors.New("missing receipts")
+		}
+		if events := s.filter(receipts); len(events) > 0 {
+			rawdb.WriteBridgeEvents(batch, number, hash, events)
+			count += len(events)
+		}
+		parent = hash
+	}
+	cursor := &rawdb.EventStreamCursor{Next: last + 1, Parent: parent}
+	rawdb.WriteBridgeCursor(batch, cursor)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The cursor may have been rewound meanwhile, the range is watched again
+	if s.cursor.Next != first {
+		return nil
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to write bridge events", "err", err)
+	}
+	s.cursor = cursor
+	if count > 0 {
+		log.Info("Watched bridge events", "first", first, "last", last, "events", count)
+	}
+	return nil
+}
+
+// filter returns the watched events of the receipts.
+func (s *Service) filter(receipts types.Receipts) []*rawdb.BridgeEvent {
+	var events []*rawdb.BridgeEvent
+	for _, receipt := range receipts {
+		for _, l := range receipt.Logs {
+			if _, ok := s.contracts[l.Address]; !ok || len(l.Topics) == 0 {
+				continue
+			}
+			kind, ok := s.kinds[l.Topics[0]]
+			if !ok {
+				continue
+			}
+			events = append(events, &rawdb.BridgeEvent{
+				Kind:     kind,
+				Contract: l.Address,
+				Topics:   l.Topics,
+				Data:     l.Data,
+				TxHash:   l.TxHash,
+				LogIndex: l.Index,
+			})
+		}
+	}
+	return events
+}
+
+// chainID returns the chain identifier the attestations are bound to.
+func (s *Service) chainID() *big.Int {
+	if id := s.chain.Config().ChainID; id != nil {
+		return id
+	}
+	return new(big.Int)
+}
@@ -0,0 +1,247 @@
+package bridgewatch
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	testBridge = common.Address{0xb1}
+	testOther  = common.Address{0xb2}
+	lockTopic  = crypto.Keccak256Hash([]byte("Locked(address,uint256)"))
+	otherTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+)
+
+// testChain is a chain of blocks with a lock event in each, reorgable and
+// finalizable at will.
+type testChain struct {
+	db        ethdb.Database
+	canonical []*types.Header
+	headers   map[common.Hash]*types.Header
+	receipts  map[common.Hash]types.Receipts
+	safe      *types.Header
+	final     *types.Header
+	feed      event.Feed
+}
+
+func newTestChain(db ethdb.Database, n int) *testChain {
+	genesis := &types.Header{Number: new(big.Int)}
+	c := &testChain{
+		db:        db,
+		canonical: []*types.Header{genesis},
+		headers:   map[common.Hash]*types.Header{genesis.Hash(): genesis},
+		receipts:  map[common.Hash]types.Receipts{genesis.Hash(): {}},
+	}
+	c.extend(n, 0)
+	return c
+}
+
+// extend appends blocks to the chain, the salt making them distinct from the
+// blocks of another branch.
+func (c *testChain) extend(n int, salt byte) {
+	for i := 0; i < n; i++ {
+		parent := c.canonical[len(c.canonical)-1]
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Extra:      []byte{salt},
+		}
+		hash := header.Hash()
+		tx := common.Hash{salt, byte(header.Number.Uint64())}
+		c.receipts[hash] = types.Receipts{{
+			TxHash: tx,
+			Logs: []*types.Log{
+				{Address: testBridge, Topics: []common.Hash{lockTopic, {0x01}}, Data: []byte{salt}, TxHash: tx, Index: 0},
+				{Address: testBridge, Topics: []common.Hash{otherTopic}, TxHash: tx, Index: 1},
+				{Address: testOther, Topics: []common.Hash{lockTopic}, TxHash: tx, Index: 2},
+			},
+		}}
+		rawdb.WriteTxLookupEntries(c.db, header.Number.Uint64(), []common.Hash{tx})
+		c.headers[hash] = header
+		c.canonical = append(c.canonical, header)
+	}
+}
+
+// reorg replaces the blocks after the given number by a new branch.
+func (c *testChain) reorg(number uint64, n int, salt byte) {
+	c.canonical = c.canonical[:number+1]
+	c.extend(n, salt)
+}
+
+func (c *testChain) Config() *params.ChainConfig { return params.TestChainConfig }
+func (c *testChain) CurrentBlock() *types.Header { return c.canonical[len(c.canonical)-1] }
+func (c *testChain) CurrentSafeBlock() *types.Header {
+	return c.safe
+}
+func (c *testChain) CurrentFinalBlock() *types.Header {
+	return c.final
+}
+
+func (c *testChain) GetCanonicalHash(number uint64) common.Hash {
+	if number >= uint64(len(c.canonical)) {
+		return common.Hash{}
+	}
+	return c.canonical[number].Hash()
+}
+
+func (c *testChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	return c.headers[hash]
+}
+
+func (c *testChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return c.receipts[hash]
+}
+
+func (c *testChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func newTestService(t *testing.T, chain *testChain, sign SignerFn, from *uint64) *Service {
+	t.Helper()
+	s, err := newService(chain, chain.db, sign, Config{
+		Contracts:  []common.Address{testBridge},
+		LockEvents: []string{"Locked(address, uint256)"},
+		Signer:     common.Address{0x5},
+		From:       from,
+	})
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	t.Cleanup(s.cancel)
+	s.init()
+	return s
+}
+
+func checkEvents(t *testing.T, api *API, want ...uint64) []*Event {
+	t.Helper()
+	events, err := api.GetEvents(0, nil)
+	if err != nil {
+		t.Fatalf("failed to get events: %v", err)
+	}
+	if len(events) != len(want) {
+		t.Fatalf("events mismatch: have %d, want %d", len(events), len(want))
+	}
+	for i, ev := range events {
+		if uint64(ev.BlockNumber) != want[i] || ev.Kind != KindLock || ev.Contract != testBridge || ev.LogIndex != 0 {
+			t.Errorf("event %d mismatch: %+v", i, ev)
+		}
+	}
+	return events
+}
+
+func TestWatchResumeAndReorg(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	chain := newTestChain(db, 5)
+	from := uint64(1)
+	s := newTestService(t, chain, nil, &from)
+	api := NewAPI(s)
+	s.watch()
+	checkEvents(t, api, 1, 2, 3, 4, 5)
+
+	if cursor := rawdb.ReadBridgeCursor(db); cursor == nil || cursor.Next != 6 || cursor.Parent != chain.canonical[5].Hash() {
+		t.Fatalf("cursor mismatch: have %+v, want next 6", cursor)
+	}
+	// Restart from the stored cursor on a reorged chain, the events of the
+	// replaced blocks are dropped
+	reorged := chain.canonical[5].Hash()
+	chain.reorg(3, 4, 1)
+	s = newTestService(t, chain, nil, nil)
+	api = NewAPI(s)
+	s.watch()
+	events := checkEvents(t, api, 1, 2, 3, 4, 5, 6, 7)
+	for _, ev := range events[3:] {
+		if len(ev.Data) != 1 || ev.Data[0] != 1 {
+			t.Errorf("event of block %d not reorged", ev.BlockNumber)
+		}
+	}
+	if rawdb.ReadBridgeEvents(db, 5, reorged) != nil {
+		t.Fatalf("events of the reorged block not dropped")
+	}
+}
+
+func TestEventStatus(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	chain := newTestChain(db, 4)
+	from := uint64(1)
+	s := newTestService(t, chain, nil, &from)
+	s.watch()
+
+	chain.safe, chain.final = chain.canonical[3], chain.canonical[1]
+	events := checkEvents(t, NewAPI(s), 1, 2, 3, 4)
+	for i, want := range []string{StatusFinalized, StatusJustified, StatusJustified, StatusUnsafe} {
+		if events[i].Status != want {
+			t.Errorf("event %d status mismatch: have %s, want %s", i, events[i].Status, want)
+		}
+	}
+}
+
+func TestAttestation(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sign := func(digest []byte) ([]byte, error) {
+		return crypto.Sign(accounts.TextHash(digest), key)
+	}
+	db := rawdb.NewMemoryDatabase()
+	chain := newTestChain(db, 3)
+	from := uint64(1)
+	s := newTestService(t, chain, sign, &from)
+	api := NewAPI(s)
+	s.watch()
+	chain.final = chain.canonical[2]
+
+	tx := common.Hash{0, 2}
+	att, err := api.GetAttestation(tx, 0)
+	if err != nil {
+		t.Fatalf("failed to attest event: %v", err)
+	}
+	if att.Event.TxHash != tx || att.Event.Status != StatusFinalized {
+		t.Fatalf("attested event mismatch: %+v", att.Event)
+	}
+	sig := append([]byte{}, att.Signature...)
+	sig[crypto.RecoveryIDOffset] -= 27
+	pub, err := crypto.SigToPub(accounts.TextHash(att.Digest.Bytes()), sig)
+	if err != nil {
+		t.Fatalf("invalid signature: %v", err)
+	}
+	if crypto.PubkeyToAddress(*pub) != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("signer mismatch")
+	}
+	// Events not finalized, unwatched or not logged by the bridge
+	if _, err := api.GetAttestation(common.Hash{0, 3}, 0); err != errNotFinalized {
+		t.Errorf("unfinalized event: have %v, want %v", err, errNotFinalized)
+	}
+	if _, err := api.GetAttestation(tx, 1); err != errEventNotFound {
+		t.Errorf("other event: have %v, want %v", err, errEventNotFound)
+	}
+	if _, err := api.GetAttestation(tx, 2); err != errEventNotFound {
+		t.Errorf("other contract: have %v, want %v", err, errEventNotFound)
+	}
+	if _, err := api.GetAttestation(common.Hash{0xff}, 0); err != errEventNotFound {
+		t.Errorf("unknown transaction: have %v, want %v", err, errEventNotFound)
+	}
+}
+
+func TestEventTopic(t *testing.T) {
+	if _, err := newService(nil, nil, nil, Config{Contracts: []common.Address{testBridge}}); err == nil {
+		t.Errorf("service without events created")
+	}
+	for _, sig := range []string{"Locked", "(address)", "Locked(address"} {
+		if _, err := eventTopic(sig); err == nil {
+			t.Errorf("invalid signature %q accepted", sig)
+		}
+	}
+	if topic, err := eventTopic(lockTopic.Hex()); err != nil || topic != lockTopic {
+		t.Errorf("topic mismatch: have %x, want %x", topic, lockTopic)
+	}
+}
@@ -72,6 +72,14 @@ var (
 		Name:  "alias",
 		Usage: "Comma separated aliases for function and event renaming, e.g. original1=alias1, original2=alias2",
 	}
+	multicallFlag = &cli.BoolFlag{
+		Name:  "multicall",
+		Usage: "Generate multicallers aggregating view calls into a single batched round trip",
+	}
+	v2Flag = &cli.BoolFlag{
+		Name:  "v2",
+		Usage: "Generate v2-style bindings packing and unpacking calls, events and custom errors without a backend",
+	}
 )
 
 var app = flags.NewApp("Ethereum ABI wrapper code generator")
@@ -88,6 +96,8 @@ func init() {
 		outFlag,
 		langFlag,
 		aliasFlag,
+		multicallFlag,
+		v2Flag,
 	}
 	app.Action = abigen
 }
@@ -216,7 +226,18 @@ func abigen(c *cli.Context) error {
 		}
 	}
 	// Generate the contract binding
-	code, err := bind.Bind(types, abis, bins, sigs, c.String(pkgFlag.Name), lang, libs, aliases)
+	var (
+		code string
+		err  error
+	)
+	switch {
+	case c.Bool(v2Flag.Name):
+		code, err = bind.BindV2(types, abis, bins, c.String(pkgFlag.Name), libs, aliases)
+	case c.Bool(multicallFlag.Name):
+		code, err = bind.BindWithMulticaller(types, abis, bins, sigs, c.String(pkgFlag.Name), lang, libs, aliases)
+	default:
+		code, err = bind.Bind(types, abis, bins, sigs, c.String(pkgFlag.Name), lang, libs, aliases)
+	}
 	if err != nil {
 		utils.Fatalf("Failed to generate ABI binding: %v", err)
 	}
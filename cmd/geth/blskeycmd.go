@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/blskeystore"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	blsKeyFlags = []cli.Flag{
+		utils.DataDirFlag,
+		utils.BLSKeyStoreDirFlag,
+		utils.PasswordFileFlag,
+		utils.LightKDFFlag,
+	}
+
+	blsKeyCommand = &cli.Command{
+		Name:  "blskey",
+		Usage: "Manage BLS attestation keys",
+		Description: `
+
+Manage the BLS12-381 keys used for attestations, separate from the accounts
+sealing the blocks.
+
+Keys are stored encrypted as EIP-2335 key files under <DATADIR>/blskeystore,
+so they can be exchanged with other tools following the standard. The running
+node exposes the same operations, except exporting, through the blskey RPC
+namespace.`,
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "Print the public keys of the stored keys",
+				Action: blsKeyList,
+				Flags:  blsKeyFlags,
+			},
+			{
+				Name:   "new",
+				Usage:  "Generate a new key",
+				Action: blsKeyCreate,
+				Flags:  blsKeyFlags,
+				Description: `
+    geth blskey new
+
+Generates a new key and prints its public key. The key is saved encrypted, you
+are prompted for a password.`,
+			},
+			{
+				Name:      "import",
+				Usage:     "Import an EIP-2335 key file",
+				ArgsUsage: "<keyFile>",
+				Action:    blsKeyImport,
+				Flags:     blsKeyFlags,
+				Description: `
+    geth blskey import <keyfile>
+
+Imports the EIP-2335 key file, you are prompted for its password.`,
+			},
+			{
+				Name:      "export",
+				Usage:     "Export a key as an EIP-2335 key file",
+				ArgsUsage: "<publicKey>",
+				Action:    blsKeyExport,
+				Flags:     blsKeyFlags,
+				Description: `
+    geth blskey export <publickey>
+
+Prints the key as an EIP-2335 key file, you are prompted for the password of
+the key and the password to encrypt the exported file with.`,
+			},
+		},
+	}
+)
+
+// makeBLSKeyManager creates the attestation key manager defined by the CLI flags.
+func makeBLSKeyManager(ctx *cli.Context) *blskeystore.Manager {
+	cfg := loadBaseConfig(ctx)
+	manager := utils.MakeBLSKeyManager(ctx, &cfg.Node)
+	if manager == nil {
+		utils.Fatalf("Can't use ephemeral directory as BLS keystore path")
+	}
+	return manager
+}
+
+func blsKeyList(ctx *cli.Context) error {
+	keys, err := makeBLSKeyManager(ctx).Keys()
+	if err != nil {
+		utils.Fatalf("Failed to list keys: %v", err)
+	}
+	for i, key := range keys {
+		fmt.Printf("Key #%d: %s %s\n", i, key.PublicKey, key.File)
+	}
+	return nil
+}
+
+func blsKeyCreate(ctx *cli.Context) error {
+	manager := makeBLSKeyManager(ctx)
+	password := utils.GetPassPhraseWithList("Your new key is locked with a password. Please give a password. Do not forget this password.", true, 0, utils.MakePasswordList(ctx))
+
+	pub, err := manager.NewKey(password)
+	if err != nil {
+		utils.Fatalf("Failed to create key: %v", err)
+	}
+	fmt.Printf("Public key: %s\n", pub)
+	return nil
+}
+
+func blsKeyImport(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("keyfile must be given as the only argument")
+	}
+	keyJSON, err := os.ReadFile(ctx.Args().First())
+	if err != nil {
+		utils.Fatalf("Could not read key file: %v", err)
+	}
+	manager := makeBLSKeyManager(ctx)
+	password := utils.GetPassPhraseWithList("Please give the password of the key file.", false, 0, utils.MakePasswordList(ctx))
+
+	pub, err := manager.Import(keyJSON, password)
+	if err != nil {
+		utils.Fatalf("Could not import the key: %v", err)
+	}
+	fmt.Printf("Public key: %s\n", pub)
+	return nil
+}
+
+func blsKeyExport(ctx *cli.Context) error {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("public key must be given as the only argument")
+	}
+	var pub blskeystore.PublicKey
+	if err := pub.UnmarshalText([]byte(ctx.Args().First())); err != nil {
+		utils.Fatalf("Invalid public key: %v", err)
+	}
+	manager := makeBLSKeyManager(ctx)
+	passwords := utils.MakePasswordList(ctx)
+	password := utils.GetPassPhraseWithList("Please give the password of the key.", false, 0, passwords)
+	newPassword := utils.GetPassPhraseWithList("Please give a password for the exported key file.", true, 1, passwords)
+
+	keyJSON, err := manager.Export(pub, password, newPassword)
+	if err != nil {
+		utils.Fatalf("Could not export the key: %v", err)
+	}
+	fmt.Println(string(keyJSON))
+	return nil
+}
@@ -185,6 +185,33 @@ It's deprecated, please use "geth db import" instead.
 		}, utils.DatabaseFlags),
 		Description: `
 This command dumps out the state for a given block (or latest, if none provided).
+`,
+	}
+	rollbackToFlag = &cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Block number to roll the chain back to",
+	}
+	rollbackToFinalizedFlag = &cli.BoolFlag{
+		Name:  "to-finalized",
+		Usage: "Roll the chain back to the last finalized block",
+	}
+	rollbackCommand = &cli.Command{
+		Action: rollback,
+		Name:   "rollback",
+		Usage:  "Roll the chain back to a previous block, never crossing the finalized block",
+		Flags: flags.Merge([]cli.Flag{
+			rollbackToFlag,
+			rollbackToFinalizedFlag,
+		}, utils.DatabaseFlags),
+		Description: `
+The rollback command rewinds the chain to the given block, or to the last
+finalized block, refusing to cross the last finalized block. If the state of
+the target block is missing, the chain is rewound further back to the first
+block with a state above the finalized block. The internal transactions and
+the block statuses of the discarded blocks are removed along with them, and
+a summary of the discarded data is printed.
+
+This command replaces the raw debug.setHead, which only rewinds the head.
 `,
 	}
 )
@@ -597,6 +624,36 @@ func dump(ctx *cli.Context) error {
 	return nil
 }
 
+// rollback rewinds the chain to the requested block and prints a summary of
+// the discarded data.
+func rollback(ctx *cli.Context) error {
+	utils.CheckExclusive(ctx, rollbackToFlag, rollbackToFinalizedFlag)
+	if !ctx.IsSet(rollbackToFlag.Name) && !ctx.Bool(rollbackToFinalizedFlag.Name) {
+		utils.Fatalf("Either --%s or --%s is required", rollbackToFlag.Name, rollbackToFinalizedFlag.Name)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, false)
+	defer db.Close()
+	defer chain.Stop()
+
+	target := ctx.Uint64(rollbackToFlag.Name)
+	if ctx.Bool(rollbackToFinalizedFlag.Name) {
+		target = chain.FinalizedCheckpoint()
+	}
+	summary, err := chain.Rollback(target)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Rolled back from block %d to block %d (requested %d, finalized %d)\n", summary.PreviousHead, summary.Head, summary.Requested, summary.Finalized)
+	fmt.Printf("Discarded blocks:         %d\n", summary.Blocks)
+	fmt.Printf("Discarded transactions:   %d\n", summary.Transactions)
+	fmt.Printf("Discarded internal txs:   %d blocks\n", summary.InternalTxs)
+	fmt.Printf("Discarded block statuses: %d\n", summary.BlockStatuses)
+	return nil
+}
+
 // hashish returns true for strings that look like hashes.
 func hashish(x string) bool {
 	_, err := strconv.Atoi(x)
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -29,14 +30,18 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/external"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/kms"
 	"github.com/ethereum/go-ethereum/accounts/scwallet"
 	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/autocompound"
 	"github.com/ethereum/go-ethereum/beacon/blsync"
+	"github.com/ethereum/go-ethereum/bridgewatch"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eventstream"
 	"github.com/ethereum/go-ethereum/internal/debug"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
@@ -95,10 +100,13 @@ type ethstatsConfig struct {
 }
 
 type gethConfig struct {
-	Eth      ethconfig.Config
-	Node     node.Config
-	Ethstats ethstatsConfig
-	Metrics  metrics.Config
+	Eth          ethconfig.Config
+	Node         node.Config
+	Ethstats     ethstatsConfig
+	EventStream  eventstream.Config
+	AutoCompound autocompound.Config
+	BridgeWatch  bridgewatch.Config
+	Metrics      metrics.Config
 }
 
 func loadConfig(file string, cfg *gethConfig) error {
@@ -165,6 +173,9 @@ func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 	if ctx.IsSet(utils.EthStatsURLFlag.Name) {
 		cfg.Ethstats.URL = ctx.String(utils.EthStatsURLFlag.Name)
 	}
+	utils.SetEventStreamConfig(ctx, &cfg.EventStream)
+	utils.SetAutoCompoundConfig(ctx, &cfg.AutoCompound)
+	utils.SetBridgeWatchConfig(ctx, &cfg.BridgeWatch)
 	applyMetricConfig(ctx, &cfg)
 
 	return stack, cfg
@@ -182,6 +193,7 @@ func makeFullNode(ctx *cli.Context) (*node.Node, ethapi.Backend) {
 		cfg.Eth.OverrideVerkle = &v
 	}
 
+	utils.LoadTxRulePlugins(ctx)
 	backend, eth := utils.RegisterEthService(stack, &cfg.Eth)
 	debug.ID = enode.PubkeyToIDV4(&cfg.Node.NodeKey().PublicKey).TerminalString()
 
@@ -199,17 +211,41 @@ func makeFullNode(ctx *cli.Context) (*node.Node, ethapi.Backend) {
 		})
 	}
 
+	// Configure the attestation key manager, unless running without datadir.
+	if manager := utils.MakeBLSKeyManager(ctx, &cfg.Node); manager != nil {
+		utils.RegisterBLSKeyService(stack, manager)
+	}
 	// Configure log filter RPC API.
 	filterSystem := utils.RegisterFilterAPI(stack, backend, &cfg.Eth)
 
+	// Configure the contract verification API if requested.
+	if ctx.IsSet(utils.VerifierSolcFlag.Name) {
+		utils.RegisterVerifierAPI(stack, backend, ctx.String(utils.VerifierSolcFlag.Name))
+	}
 	// Configure GraphQL if requested.
 	if ctx.IsSet(utils.GraphQLEnabledFlag.Name) {
 		utils.RegisterGraphQLService(stack, backend, filterSystem, &cfg.Node)
 	}
+	// Configure gRPC if requested.
+	if cfg.Node.GRPCHost != "" {
+		utils.RegisterGRPCService(stack, eth.APIBackend, &cfg.Node)
+	}
 	// Add the Ethereum Stats daemon if requested.
 	if cfg.Ethstats.URL != "" {
 		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats.URL)
 	}
+	// Add the chain event publisher if requested.
+	if cfg.EventStream.URL != "" {
+		utils.RegisterEventStreamService(stack, eth, &cfg.EventStream)
+	}
+	// Add the delegation reward compounder if requested.
+	if len(cfg.AutoCompound.Delegations) > 0 {
+		utils.RegisterAutoCompoundService(stack, eth, &cfg.AutoCompound)
+	}
+	// Add the bridge watcher if requested.
+	if len(cfg.BridgeWatch.Contracts) > 0 {
+		utils.RegisterBridgeWatchService(stack, eth, &cfg.BridgeWatch)
+	}
 	// Configure full-sync tester service if requested
 	if ctx.IsSet(utils.SyncTargetFlag.Name) {
 		hex := hexutil.MustDecode(ctx.String(utils.SyncTargetFlag.Name))
@@ -234,6 +270,10 @@ func makeFullNode(ctx *cli.Context) (*node.Node, ethapi.Backend) {
 		blsyncer := blsync.NewClient(ctx)
 		blsyncer.SetEngineRPC(rpc.DialInProc(srv))
 		stack.RegisterLifecycle(blsyncer)
+	} else if eth.BlockChain().Config().Turbo != nil {
+		// Turbo chains aren't driven by a consensus client, only expose the
+		// read-only engine API views for the tooling.
+		catalyst.RegisterTurbo(stack, eth)
 	} else {
 		// Launch the engine API for interacting with external consensus client.
 		err := catalyst.Register(stack, eth)
@@ -385,6 +425,24 @@ func setAccountManagerBackends(conf *node.Config, am *accounts.Manager, keydir s
 			am.AddBackend(schub)
 		}
 	}
-
+	if len(conf.KMSKeys) > 0 {
+		// Connect the keys held by key management services
+		var signers []kms.Signer
+		for _, url := range conf.KMSKeys {
+			signer, err := kms.NewSigner(context.Background(), url)
+			if err != nil {
+				return fmt.Errorf("invalid KMS key %q: %v", url, err)
+			}
+			signers = append(signers, signer)
+		}
+		backend, err := kms.NewBackend(context.Background(), conf.KMSTimeout, signers...)
+		if err != nil {
+			return fmt.Errorf("error connecting to KMS: %v", err)
+		}
+		for _, wallet := range backend.Wallets() {
+			log.Info("Using KMS key", "url", wallet.URL(), "address", wallet.Accounts()[0].Address)
+		}
+		am.AddBackend(backend)
+	}
 	return nil
 }
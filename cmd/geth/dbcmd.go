@@ -65,6 +65,11 @@ var (
 		Description: `
 Remove blockchain and state databases`,
 	}
+	tableFlag = &cli.StringFlag{
+		Name:     "table",
+		Usage:    "Table to export (internaltxs or blockstatus)",
+		Required: true,
+	}
 	dbCommand = &cli.Command{
 		Name:      "db",
 		Usage:     "Low level database operations",
@@ -83,6 +88,11 @@ Remove blockchain and state databases`,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
 			dbInspectHistoryCmd,
+			dbMigrateBlockStatusCmd,
+			dbMigrateTracesCmd,
+			dbExportTableCmd,
+			dbImportTableCmd,
+			dbMoveAncientCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -229,6 +239,67 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		}, utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command queries the history of the account or storage slot within the specified block range",
 	}
+	dbExportTableCmd = &cli.Command{
+		Action: exportTable,
+		Name:   "export-table",
+		Usage:  "Exports a Nero specific key-value table into an RLP dump",
+		Flags: flags.Merge([]cli.Flag{
+			utils.SyncModeFlag,
+			tableFlag,
+			&cli.StringFlag{
+				Name:     "out",
+				Usage:    "Dump file to write, gzip compressed if it has the .gz suffix",
+				Required: true,
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command exports the entries of a Nero specific table (internaltxs or
+blockstatus) into an RLP dump, which can be imported into another node with
+'geth db import-table'. It allows to seed e.g. an RPC replica with the data of
+an archive node without a full resync.`,
+	}
+	dbImportTableCmd = &cli.Command{
+		Action: importTable,
+		Name:   "import-table",
+		Usage:  "Imports a Nero specific key-value table from an RLP dump",
+		Flags: flags.Merge([]cli.Flag{
+			utils.SyncModeFlag,
+			&cli.StringFlag{
+				Name:     "in",
+				Usage:    "Dump file created by 'geth db export-table'",
+				Required: true,
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command imports the entries of a table exported by 'geth db export-table',
+overwriting the entries already present.`,
+	}
+	dbMigrateBlockStatusCmd = &cli.Command{
+		Action: migrateBlockStatus,
+		Name:   "migrate-blockstatus",
+		Usage:  "Move the statuses of finalized blocks into the ancient store",
+		Flags: flags.Merge([]cli.Flag{
+			utils.SyncModeFlag,
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command moves the justified/finalized statuses of all blocks up to the
+last finalized block from the key-value database into the block status ancient
+store. Reads fall through to the ancient store, so the statuses stay available
+while the key-value database no longer grows with them. The migration can be
+interrupted and resumed, and should be repeated from time to time.`,
+	}
+	dbMigrateTracesCmd = &cli.Command{
+		Action: migrateTraces,
+		Name:   "migrate-traces",
+		Usage:  "Move the internal transactions of finalized blocks into the ancient store",
+		Flags: flags.Merge([]cli.Flag{
+			utils.SyncModeFlag,
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command moves the internal transactions of all canonical blocks up to the
+last finalized block from the key-value database into the trace ancient store,
+dropping those of the side blocks. Reads fall through to the ancient store, so
+the internal transactions stay available while the key-value database no longer
+grows with them, and they follow the ancient store to a cheaper volume (see
+'geth db move-ancient'). The migration can be interrupted and resumed, and
+should be repeated from time to time.`,
+	}
 )
 
 func removeDB(ctx *cli.Context) error {
@@ -933,3 +1004,149 @@ func inspectHistory(ctx *cli.Context) error {
 	}
 	return inspectStorage(triedb, start, end, address, slot, ctx.Bool("raw"))
 }
+
+func migrateBlockStatus(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	ancient, err := db.AncientDatadir()
+	if err != nil {
+		return fmt.Errorf("ancient store unavailable: %v", err)
+	}
+	freezer, err := rawdb.NewBlockStatusFreezer(ancient, false)
+	if err != nil {
+		return err
+	}
+	defer freezer.Close()
+
+	finalized := rawdb.LastFinalizedBlockNumber(db).Uint64()
+	if finalized == 0 {
+		log.Info("No finalized blocks, nothing to migrate")
+		return nil
+	}
+	start := time.Now()
+	migrated, err := rawdb.MigrateBlockStatus(db, freezer, finalized)
+	if err != nil {
+		return err
+	}
+	log.Info("Block status migration completed", "finalized", finalized, "migrated", migrated, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+func migrateTraces(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	ancient, err := db.AncientDatadir()
+	if err != nil {
+		return fmt.Errorf("ancient store unavailable: %v", err)
+	}
+	freezer, err := rawdb.NewTraceFreezer(ancient, false)
+	if err != nil {
+		return err
+	}
+	defer freezer.Close()
+
+	finalized := rawdb.LastFinalizedBlockNumber(db).Uint64()
+	if finalized == 0 {
+		log.Info("No finalized blocks, nothing to migrate")
+		return nil
+	}
+	start := time.Now()
+	migrated, err := rawdb.MigrateInternalTxs(db, freezer, finalized)
+	if err != nil {
+		return err
+	}
+	log.Info("Internal transaction migration completed", "finalized", finalized, "migrated", migrated, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// tableIterator iterates the entries of an exported table, starting with its
+// standalone markers.
+type tableIterator struct {
+	db      ethdb.Database
+	table   rawdb.ExportTable
+	markers int
+	iter    ethdb.Iterator
+}
+
+func (iter *tableIterator) Next() (byte, []byte, []byte, bool) {
+	for iter.markers < len(iter.table.Markers) {
+		key := iter.table.Markers[iter.markers]
+		iter.markers++
+		if value, err := iter.db.Get(key); err == nil {
+			return utils.OpBatchAdd, key, value, true
+		}
+	}
+	for iter.iter.Next() {
+		if key := iter.iter.Key(); iter.table.Match(key) {
+			return utils.OpBatchAdd, key, iter.iter.Value(), true
+		}
+	}
+	return 0, nil, nil, false
+}
+
+func (iter *tableIterator) Release() {
+	iter.iter.Release()
+}
+
+// interruptOnSignal returns a channel closed when the process is interrupted.
+func interruptOnSignal(what string) (chan struct{}, func()) {
+	var (
+		interrupt = make(chan os.Signal, 1)
+		stop      = make(chan struct{})
+	)
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		if _, ok := <-interrupt; ok {
+			log.Info(fmt.Sprintf("Interrupted during %s, stopping at next batch", what))
+		}
+		close(stop)
+	}()
+	return stop, func() {
+		signal.Stop(interrupt)
+		close(interrupt)
+	}
+}
+
+func exportTable(ctx *cli.Context) error {
+	name := strings.ToLower(ctx.String(tableFlag.Name))
+	table, ok := rawdb.ExportTables[name]
+	if !ok {
+		var names []string
+		for name := range rawdb.ExportTables {
+			names = append(names, name)
+		}
+		return fmt.Errorf("invalid table %s, supported tables: %s", name, strings.Join(names, ", "))
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	stop, release := interruptOnSignal("table export")
+	defer release()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	iter := &tableIterator{db: db, table: table, iter: db.NewIterator(table.Prefix, nil)}
+	return utils.ExportChaindata(ctx.String("out"), name, iter, stop)
+}
+
+func importTable(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	stop, release := interruptOnSignal("table import")
+	defer release()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	return utils.ImportLDBData(db, ctx.String("in"), 0, stop)
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/urfave/cli/v2"
+)
+
+var dbMoveAncientCmd = &cli.Command{
+	Action: moveAncient,
+	Name:   "move-ancient",
+	Usage:  "Relocate the ancient store to another directory",
+	Flags: flags.Merge([]cli.Flag{
+		utils.SyncModeFlag,
+		&cli.StringFlag{
+			Name:     "to",
+			Usage:    "Directory to move the ancient store to",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "precopy",
+			Usage: "Only copy the immutable data files, can run while the node is running",
+		},
+		&cli.BoolFlag{
+			Name:  "remove-source",
+			Usage: "Remove the original ancient store once the relocation is verified",
+		},
+	}, utils.NetworkFlags, utils.DatabaseFlags),
+	Description: `This command moves all the freezers of the ancient store (chain segments,
+state histories, block statuses and internal transactions) to another directory,
+e.g. on a cheaper volume. The block statuses and internal transactions are only
+part of the ancient store once migrated (see 'geth db migrate-blockstatus' and
+'geth db migrate-traces').
+
+The relocation runs online, apart from a short final step. The bulk of the
+ancient store consists of data files which are never modified once full. With
+--precopy these are copied while the node is running, and the run can be
+repeated to catch up with the files filled in the meantime. The running node
+keeps the freezers open and appends to the last data files, so the final run
+requires the node to be stopped: it only copies the remaining files, verifies
+the relocated ancient store against the chain database and optionally removes
+the original one. Afterwards the node must be started with --datadir.ancient
+pointing to the new location.`,
+}
+
+// freezerDataFile matches the data files of the freezer tables, e.g. headers.0001.rdat.
+var freezerDataFile = regexp.MustCompile(`^(.+)\.(\d{4})\.(rdat|cdat)$`)
+
+// ancientFile is a file of the ancient store.
+type ancientFile struct {
+	path      string // Path relative to the ancient directory
+	size      int64
+	mode      fs.FileMode
+	immutable bool // Whether it's a full data file, which is never modified
+}
+
+// listAncientFiles lists the files of the ancient directory. The data files of
+// a table are immutable, apart from the last one being appended to.
+func listAncientFiles(root string) ([]ancientFile, error) {
+	var (
+		files []ancientFile
+		heads = make(map[string]int) // Last data file index per table
+	)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		if d.Name() == "FLOCK" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, ancientFile{path: rel, size: info.Size(), mode: info.Mode()})
+		if m := freezerDataFile.FindStringSubmatch(rel); m != nil {
+			index, _ := strconv.Atoi(m[2])
+			if head, ok := heads[m[1]]; !ok || index > head {
+				heads[m[1]] = index
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, file := range files {
+		if m := freezerDataFile.FindStringSubmatch(file.path); m != nil {
+			index, _ := strconv.Atoi(m[2])
+			files[i].immutable = index < heads[m[1]]
+		}
+	}
+	return files, nil
+}
+
+// copyProgress reports the progress of the relocation.
+type copyProgress struct {
+	copied, total uint64
+	start, logged time.Time
+}
+
+func (p *copyProgress) Write(b []byte) (int, error) {
+	p.copied += uint64(len(b))
+	if time.Since(p.logged) > 8*time.Second {
+		var eta time.Duration
+		if p.copied > 0 {
+			eta = time.Duration(float64(time.Since(p.start)) / float64(p.copied) * float64(p.total-min(p.copied, p.total)))
+		}
+		log.Info("Copying ancient store", "copied", common.StorageSize(p.copied), "total", common.StorageSize(p.total),
+			"elapsed", common.PrettyDuration(time.Since(p.start)), "eta", common.PrettyDuration(eta))
+		p.logged = time.Now()
+	}
+	return len(b), nil
+}
+
+// copyAncientFile copies a file of the ancient store and syncs it to disk.
+func copyAncientFile(src, dst string, mode fs.FileMode, progress io.Writer) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.MultiWriter(out, progress), in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// resolveAncientDir resolves the ancient directory like the node does.
+func resolveAncientDir(cfg *node.Config, ancient string) string {
+	switch {
+	case ancient == "":
+		return filepath.Join(cfg.ResolvePath("chaindata"), "ancient")
+	case !filepath.IsAbs(ancient):
+		return cfg.ResolvePath(ancient)
+	}
+	return ancient
+}
+
+// isWithin reports whether path is dir or located inside of it.
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func moveAncient(ctx *cli.Context) error {
+	target, err := filepath.Abs(ctx.String("to"))
+	if err != nil {
+		return err
+	}
+	precopy := ctx.Bool("precopy")
+
+	// The final run opens the node, which fails while the node is running
+	// as the data directory is locked.
+	var (
+		source string
+		stack  *node.Node
+	)
+	if precopy {
+		cfg := loadBaseConfig(ctx)
+		ancient := cfg.Eth.DatabaseFreezer
+		if ctx.IsSet(utils.AncientFlag.Name) {
+			ancient = ctx.String(utils.AncientFlag.Name)
+		}
+		source = resolveAncientDir(&cfg.Node, ancient)
+	} else {
+		var cfg gethConfig
+		stack, cfg = makeConfigNode(ctx)
+		defer stack.Close()
+		source = stack.ResolveAncient("chaindata", cfg.Eth.DatabaseFreezer)
+	}
+	if source, err = filepath.Abs(source); err != nil {
+		return err
+	}
+	if info, err := os.Stat(source); err != nil || !info.IsDir() {
+		return fmt.Errorf("ancient store %s not found", source)
+	}
+	if isWithin(target, source) || isWithin(source, target) {
+		return fmt.Errorf("target %s overlaps with the ancient store %s", target, source)
+	}
+	files, err := listAncientFiles(source)
+	if err != nil {
+		return err
+	}
+	// Skip the immutable files copied by a previous run, and the mutable ones
+	// while the node might be running.
+	var (
+		pending  []ancientFile
+		progress = &copyProgress{start: time.Now(), logged: time.Now()}
+	)
+	for _, file := range files {
+		if precopy && !file.immutable {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(target, file.path)); err == nil && file.immutable && info.Size() == file.size {
+			continue
+		}
+		pending = append(pending, file)
+		progress.total += uint64(file.size)
+	}
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return err
+	}
+	free, err := utils.FreeDiskSpace(target)
+	if err != nil {
+		return fmt.Errorf("failed to check the free disk space of %s: %v", target, err)
+	}
+	if free < progress.total {
+		return fmt.Errorf("not enough free disk space at %s: need %v, have %v", target, common.StorageSize(progress.total), common.StorageSize(free))
+	}
+	log.Info("Relocating ancient store", "from", source, "to", target, "files", len(pending), "size", common.StorageSize(progress.total), "precopy", precopy)
+	for _, file := range pending {
+		if err := copyAncientFile(filepath.Join(source, file.path), filepath.Join(target, file.path), file.mode, progress); err != nil {
+			return err
+		}
+	}
+	if precopy {
+		log.Info("Ancient store pre-copied, stop the node and rerun without --precopy to complete the relocation",
+			"copied", common.StorageSize(progress.copied), "elapsed", common.PrettyDuration(time.Since(progress.start)))
+		return nil
+	}
+	// Make sure the relocated ancient store is complete and consistent with the
+	// chain database before anything gets removed.
+	for _, file := range files {
+		info, err := os.Stat(filepath.Join(target, file.path))
+		if err != nil {
+			return fmt.Errorf("relocated file %s is missing: %v", file.path, err)
+		}
+		if info.Size() != file.size {
+			return fmt.Errorf("relocated file %s size mismatch: have %d, want %d", file.path, info.Size(), file.size)
+		}
+	}
+	if err := verifyAncientRelocation(stack, source, target); err != nil {
+		return err
+	}
+	if ctx.Bool("remove-source") {
+		log.Info("Removing the original ancient store", "path", source)
+		if err := os.RemoveAll(source); err != nil {
+			return err
+		}
+	}
+	log.Info("Ancient store relocated", "from", source, "to", target, "elapsed", common.PrettyDuration(time.Since(progress.start)))
+	log.Warn(fmt.Sprintf("Start the node with --%s=%s to use the relocated ancient store", utils.AncientFlag.Name, target))
+	return nil
+}
+
+// verifyAncientRelocation opens the chain database with both ancient stores and
+// checks that they hold the same items.
+func verifyAncientRelocation(stack *node.Node, source, target string) error {
+	items := func(ancient string) (uint64, uint64, error) {
+		db, err := rawdb.Open(rawdb.OpenOptions{
+			Type:              stack.Config().DBEngine,
+			Directory:         stack.ResolvePath("chaindata"),
+			AncientsDirectory: ancient,
+			ReadOnly:          true,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		defer db.Close()
+
+		frozen, err := db.Ancients()
+		if err != nil {
+			return 0, 0, err
+		}
+		tail, err := db.Tail()
+		if err != nil {
+			return 0, 0, err
+		}
+		return frozen, tail, nil
+	}
+	srcFrozen, srcTail, err := items(source)
+	if err != nil {
+		return fmt.Errorf("failed to open the original ancient store: %v", err)
+	}
+	dstFrozen, dstTail, err := items(target)
+	if err != nil {
+		return fmt.Errorf("failed to open the relocated ancient store: %v", err)
+	}
+	if srcFrozen != dstFrozen || srcTail != dstTail {
+		return errors.New("relocated ancient store doesn't match the original one")
+	}
+	// The freezers of the migrated block statuses and internal transactions
+	// are standalone, check their items separately.
+	for _, freezer := range []struct {
+		name string
+		open func(string, bool) (ethdb.AncientStore, error)
+	}{
+		{rawdb.BlockStatusFreezerName, rawdb.NewBlockStatusFreezer},
+		{rawdb.TraceFreezerName, rawdb.NewTraceFreezer},
+	} {
+		if _, err := os.Stat(filepath.Join(source, freezer.name)); err != nil {
+			continue
+		}
+		count := func(ancient string) (uint64, error) {
+			f, err := freezer.open(ancient, true)
+			if err != nil {
+				return 0, err
+			}
+			defer f.Close()
+			return f.Ancients()
+		}
+		srcItems, err := count(source)
+		if err != nil {
+			return fmt.Errorf("failed to open the original %s ancient store: %v", freezer.name, err)
+		}
+		dstItems, err := count(target)
+		if err != nil {
+			return fmt.Errorf("failed to open the relocated %s ancient store: %v", freezer.name, err)
+		}
+		if srcItems != dstItems {
+			return fmt.Errorf("relocated %s ancient store doesn't match the original one: have %d items, want %d", freezer.name, dstItems, srcItems)
+		}
+	}
+	log.Info("Verified relocated ancient store", "frozen", dstFrozen, "tail", dstTail)
+	return nil
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/internal/debug"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/internal/otel"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/node"
@@ -61,8 +62,15 @@ var (
 		utils.PasswordFileFlag,
 		utils.BootnodesFlag,
 		utils.MinFreeDiskSpaceFlag,
+		utils.DiskForecastFlag,
+		utils.DiskForecastWindowFlag,
+		utils.DiskForecastAlarmFlag,
+		utils.DiskForecastWebhookFlag,
 		utils.KeyStoreDirFlag,
+		utils.BLSKeyStoreDirFlag,
 		utils.ExternalSignerFlag,
+		utils.KMSKeysFlag,
+		utils.KMSTimeoutFlag,
 		utils.NoUSBFlag, // deprecated
 		utils.USBFlag,
 		utils.SmartCardDaemonPathFlag,
@@ -91,6 +99,11 @@ var (
 		utils.TxLookupLimitFlag, // deprecated
 		utils.TransactionHistoryFlag,
 		utils.StateHistoryFlag,
+		utils.ReceiptDedupFlag,
+		utils.TokenTransferIndexFlag,
+		utils.BloomSectionSizeFlag,
+		utils.BloomRebuildFlag,
+		utils.StateExpiryResearchFlag,
 		utils.LightServeFlag,    // deprecated
 		utils.LightIngressFlag,  // deprecated
 		utils.LightEgressFlag,   // deprecated
@@ -104,6 +117,7 @@ var (
 		utils.CacheFlag,
 		utils.CacheDatabaseFlag,
 		utils.CacheTrieFlag,
+		utils.CacheTrieRPCShareFlag,
 		utils.CacheTrieJournalFlag,   // deprecated
 		utils.CacheTrieRejournalFlag, // deprecated
 		utils.CacheGCFlag,
@@ -124,6 +138,8 @@ var (
 		&utils.MinerThreadsFlag,
 		utils.MinerExtraDataFlag,
 		utils.MinerRecommitIntervalFlag,
+		utils.MinerBuildLogFlag,
+		utils.MinerShadowFlag,
 		utils.MinerPendingFeeRecipientFlag,
 		utils.MinerNewPayloadTimeoutFlag, // deprecated
 		utils.NATFlag,
@@ -143,15 +159,54 @@ var (
 		utils.VMTraceJsonConfigFlag,
 		utils.NetworkIdFlag,
 		utils.EthStatsURLFlag,
+		utils.EventStreamURLFlag,
+		utils.EventStreamPrefixFlag,
+		utils.EventStreamReplayFlag,
+		utils.AutoCompoundDelegationsFlag,
+		utils.AutoCompoundIntervalFlag,
+		utils.AutoCompoundMinRewardsFlag,
+		utils.AutoCompoundGasCapFlag,
+		utils.AutoCompoundDryRunFlag,
+		utils.BridgeContractsFlag,
+		utils.BridgeLockEventsFlag,
+		utils.BridgeUnlockEventsFlag,
+		utils.BridgeSignerFlag,
+		utils.BridgeFromFlag,
+		utils.VerifierSolcFlag,
 		utils.NoCompactionFlag,
+		utils.BadBlockDirFlag,
+		utils.WatchdogFlag,
+		utils.WatchdogPeriodsFlag,
+		utils.WatchdogCPUProfileFlag,
+		utils.WatchdogRetainFlag,
+		utils.ClockMaxDriftFlag,
+		utils.ClockFutureWindowFlag,
+		utils.ClockNTPServerFlag,
+		utils.ClockNTPIntervalFlag,
+		utils.FailoverPeerFlag,
+		utils.FailoverStandbyFlag,
+		utils.FailoverHeartbeatFlag,
+		utils.FailoverExpiryFlag,
+		utils.MinerNoIOThrottleFlag,
+		utils.MinerHaltBlockFlag,
 		utils.GpoBlocksFlag,
 		utils.GpoPercentileFlag,
 		utils.GpoMaxGasPriceFlag,
 		utils.GpoIgnoreGasPriceFlag,
+		utils.GpoStrategyFlag,
 		configFileFlag,
 		utils.LogDebugFlag,
 		utils.LogBacktraceAtFlag,
 		utils.TraceActionFlag,
+		utils.TraceArchiveFlag,
+		utils.TxRulePluginsFlag,
+		utils.LocalAccessListFlag,
+		utils.CheckpointServeFlag,
+		utils.CheckpointIntervalFlag,
+		utils.CheckpointRetainFlag,
+		utils.DBVerifyFlag,
+		utils.DBMigrationDryRunFlag,
+		utils.DBMigrationBackupFlag,
 		utils.BeaconApiFlag,
 		utils.BeaconApiHeaderFlag,
 		utils.BeaconThresholdFlag,
@@ -175,6 +230,9 @@ var (
 		utils.GraphQLEnabledFlag,
 		utils.GraphQLCORSDomainFlag,
 		utils.GraphQLVirtualHostsFlag,
+		utils.GRPCEnabledFlag,
+		utils.GRPCListenAddrFlag,
+		utils.GRPCPortFlag,
 		utils.HTTPApiFlag,
 		utils.HTTPPathPrefixFlag,
 		utils.WSEnabledFlag,
@@ -183,15 +241,27 @@ var (
 		utils.WSApiFlag,
 		utils.WSAllowedOriginsFlag,
 		utils.WSPathPrefixFlag,
+		utils.WSCompressionFlag,
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
+		utils.AdminIPCPathFlag,
 		utils.InsecureUnlockAllowedFlag,
 		utils.RPCGlobalGasCapFlag,
 		utils.RPCGlobalEVMTimeoutFlag,
+		utils.RPCGlobalEVMMemoryFlag,
+		utils.RPCGlobalTraceTimeoutFlag,
+		utils.RPCCallBreakerFlag,
+		utils.RPCCallBreakerCooldownFlag,
+		utils.RPCMaxLogsFlag,
 		utils.RPCGlobalTxFeeCapFlag,
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
+		utils.RPCUsageConsumersFlag,
+		utils.RPCUsageHeaderFlag,
+		utils.RPCAuthJWTSecretFlag,
+		utils.RPCAuthAPIKeysFlag,
+		utils.RPCAuthPublicFlag,
 	}
 
 	metricsFlags = []cli.Flag{
@@ -209,6 +279,11 @@ var (
 		utils.MetricsInfluxDBTokenFlag,
 		utils.MetricsInfluxDBBucketFlag,
 		utils.MetricsInfluxDBOrganizationFlag,
+		utils.OTelEnabledFlag,
+		utils.OTelEndpointFlag,
+		utils.OTelServiceFlag,
+		utils.OTelSampleFlag,
+		utils.OTelHeadersFlag,
 	}
 )
 
@@ -227,10 +302,16 @@ func init() {
 		importPreimagesCommand,
 		removedbCommand,
 		dumpCommand,
+		rollbackCommand,
 		dumpGenesisCommand,
 		// See accountcmd.go:
 		accountCommand,
+		blsKeyCommand,
 		walletCommand,
+		// See validatorcmd.go:
+		validatorCommand,
+		// See tracediffcmd.go:
+		traceDiffCommand,
 		// See consolecmd.go:
 		consoleCommand,
 		attachCommand,
@@ -330,6 +411,9 @@ func prepare(ctx *cli.Context) {
 	// Start metrics export if enabled
 	utils.SetupMetrics(ctx)
 
+	// Start span export if enabled
+	utils.SetupOTel(ctx)
+
 	// Start system runtime metrics collection
 	go metrics.CollectProcessMetrics(3 * time.Second)
 }
@@ -348,6 +432,7 @@ func geth(ctx *cli.Context) error {
 
 	startNode(ctx, stack, backend, false)
 	stack.Wait()
+	otel.Shutdown()
 	return nil
 }
 
@@ -80,9 +80,17 @@ func printVersion(ctx *cli.Context) error {
 	if git.Date != "" {
 		fmt.Println("Git Commit Date:", git.Date)
 	}
+	build := version.BuildProvenance()
+	if build.Dirty {
+		fmt.Println("Git Tree: dirty")
+	}
 	fmt.Println("Architecture:", runtime.GOARCH)
 	fmt.Println("Go Version:", runtime.Version())
 	fmt.Println("Operating System:", runtime.GOOS)
+	fmt.Println("CGO:", build.CGO)
+	if build.Hash != "" {
+		fmt.Println("Reproducibility Hash:", build.Hash)
+	}
 	fmt.Printf("GOPATH=%s\n", os.Getenv("GOPATH"))
 	fmt.Printf("GOROOT=%s\n", runtime.GOROOT())
 	return nil
@@ -18,22 +18,30 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/checkpoint"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/flags"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/urfave/cli/v2"
@@ -52,6 +60,7 @@ var (
 				Action:    pruneState,
 				Flags: flags.Merge([]cli.Flag{
 					utils.BloomFilterSizeFlag,
+					utils.PruneMaxDurationFlag,
 				}, utils.NetworkFlags, utils.DatabaseFlags),
 				Description: `
 geth snapshot prune-state <state-root>
@@ -62,6 +71,13 @@ two version states are available: genesis and the specific one.
 
 The default pruning target is the HEAD-127 state.
 
+The progress is persisted, an interrupted pruning continues where it
+stopped on the next run. With --prune.max-duration the pruning is paused
+once the given duration has elapsed, leaving the database usable at the
+target state, so that it can be spread across maintenance windows. Each
+run prunes towards its own target state, the state becoming stale between
+two runs is left for the next complete pruning.
+
 WARNING: it's only supported in hash mode(--state.scheme=hash)".
 `,
 			},
@@ -148,6 +164,59 @@ as the backend data source, making this command a lot faster.
 
 The argument is interpreted as block number or hash. If none is provided, the latest
 block is used.
+`,
+			},
+			{
+				Name:      "create-archive",
+				Usage:     "Archive the chain database for cloning nodes",
+				ArgsUsage: "<archive>",
+				Action:    createArchive,
+				Flags:     flags.Merge(utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot create-archive <archive>
+writes the chain database and the ancient store into a gzipped tarball, along
+with a manifest of the archived chain and a sha256sum checksum file. The node
+must be stopped, the data directory being locked while the database is flushed
+and copied, so that the archive is consistent.
+
+The archived chain is the one of the stopped node, its head and its finalized
+block are recorded in the manifest. The archive is written to a temporary file
+renamed once complete.
+`,
+			},
+			{
+				Name:      "restore-archive",
+				Usage:     "Restore the chain database from an archive",
+				ArgsUsage: "<archive | url>",
+				Action:    restoreArchive,
+				Flags:     flags.Merge(utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot restore-archive <archive | url>
+restores the chain database and the ancient store of an empty data directory
+from an archive created by create-archive, downloading it first if a http(s)
+url is given. An interrupted download is resumed when the command is rerun.
+The archive is checked against its checksum file if there's one, and the
+restored database against the manifest of the archive.
+`,
+			},
+			{
+				Name:      "import-checkpoint",
+				Usage:     "Import the state of a finalized checkpoint from a checkpoint server",
+				ArgsUsage: "<url> [<id>]",
+				Action:    importCheckpoint,
+				Flags:     flags.Merge(utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot import-checkpoint <url> [<id>]
+retrieves the state checkpoint with the given id, or the latest one, from the
+checkpoint server at the url (e.g. http://127.0.0.1:8545/checkpoint) and writes
+its state into the database. The checkpoint block must be in the local header
+chain and its finality proof is checked against the local validator sets, the
+imported state is then checked against the state root of the block.
+
+The node resumes from the checkpoint block on the next start if the state of
+its head is missing. Any state snapshot in the database is discarded.
+
+WARNING: it's only supported in hash mode (--state.scheme=hash).
 `,
 			},
 			{
@@ -178,8 +247,9 @@ func pruneState(ctx *cli.Context) error {
 		log.Crit("Offline pruning is not required for path scheme")
 	}
 	prunerconfig := pruner.Config{
-		Datadir:   stack.ResolvePath(""),
-		BloomSize: ctx.Uint64(utils.BloomFilterSizeFlag.Name),
+		Datadir:     stack.ResolvePath(""),
+		BloomSize:   ctx.Uint64(utils.BloomFilterSizeFlag.Name),
+		MaxDuration: ctx.Duration(utils.PruneMaxDurationFlag.Name),
 	}
 	pruner, err := pruner.NewPruner(chaindb, prunerconfig)
 	if err != nil {
@@ -692,3 +762,243 @@ func checkAccount(ctx *cli.Context) error {
 	log.Info("Checked the snapshot journalled storage", "time", common.PrettyDuration(time.Since(start)))
 	return nil
 }
+
+// archiveDirs returns the directories of the chain database and of the ancient
+// store as stored in a chain archive.
+func archiveDirs(ctx *cli.Context, stack *node.Node) []utils.ArchiveDir {
+	return []utils.ArchiveDir{
+		{Prefix: utils.ArchiveChaindataDir, Path: stack.ResolvePath("chaindata")},
+		{Prefix: utils.ArchiveAncientDir, Path: stack.ResolveAncient("chaindata", ctx.String(utils.AncientFlag.Name))},
+	}
+}
+
+// createArchive writes the chain database of the stopped node into an archive.
+func createArchive(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	archive, err := filepath.Abs(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	// The node holds the data directory lock, so that the database can't be
+	// opened by a running node while being archived.
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	dirs := archiveDirs(ctx, stack)
+	for _, dir := range dirs {
+		if isWithin(archive, dir.Path) {
+			return fmt.Errorf("archive %s located inside of the database %s", archive, dir.Path)
+		}
+	}
+	// Open the database read-write for it to be recovered and flushed on close,
+	// leaving consistent files to copy.
+	chaindb := utils.MakeChainDatabase(ctx, stack, false)
+	manifest, err := readArchiveManifest(chaindb)
+	chaindb.Close()
+	if err != nil {
+		return err
+	}
+	if manifest.Finalized == nil {
+		log.Warn("No finalized block in the archived chain")
+	}
+	log.Info("Creating chain archive", "path", archive, "head", manifest.Head.Number, "hash", manifest.Head.Hash, "scheme", manifest.Scheme)
+
+	start := time.Now()
+	f, err := os.Create(archive + ".tmp")
+	if err != nil {
+		return err
+	}
+	if err := utils.WriteChainArchive(f, manifest, dirs); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(archive+".tmp", archive); err != nil {
+		return err
+	}
+	sum, err := utils.WriteArchiveChecksum(archive)
+	if err != nil {
+		return err
+	}
+	log.Info("Chain archive created", "path", archive, "sha256", sum, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// readArchiveManifest describes the chain held by the database.
+func readArchiveManifest(db ethdb.Database) (*utils.ArchiveManifest, error) {
+	block := func(hash common.Hash) *utils.ArchiveBlock {
+		number := rawdb.ReadHeaderNumber(db, hash)
+		if number == nil {
+			return nil
+		}
+		return &utils.ArchiveBlock{Number: *number, Hash: hash}
+	}
+	genesis := rawdb.ReadCanonicalHash(db, 0)
+	if genesis == (common.Hash{}) {
+		return nil, errors.New("database not initialized")
+	}
+	head := block(rawdb.ReadHeadBlockHash(db))
+	if head == nil {
+		return nil, errors.New("no head block")
+	}
+	return &utils.ArchiveManifest{
+		Version:   utils.ArchiveVersion,
+		Genesis:   genesis,
+		Scheme:    rawdb.ReadStateScheme(db),
+		Head:      *head,
+		Finalized: block(rawdb.ReadFinalizedBlockHash(db)),
+		Created:   time.Now().UTC(),
+	}, nil
+}
+
+// restoreArchive restores the chain database of an empty data directory from
+// an archive, downloading it first if needed.
+func restoreArchive(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	dirs := archiveDirs(ctx, stack)
+	for _, dir := range dirs {
+		if entries, err := os.ReadDir(dir.Path); err == nil && len(entries) > 0 {
+			return fmt.Errorf("database %s already exists", dir.Path)
+		}
+	}
+	var (
+		archive    = ctx.Args().First()
+		downloaded bool
+	)
+	if strings.HasPrefix(archive, "http://") || strings.HasPrefix(archive, "https://") {
+		file := stack.ResolvePath("chain-archive.tar.gz")
+		log.Info("Downloading chain archive", "url", archive, "path", file)
+		if err := utils.DownloadArchive(archive, file); err != nil {
+			return fmt.Errorf("failed to download archive, rerun to resume: %v", err)
+		}
+		archive, downloaded = file, true
+	}
+	if ok, err := utils.VerifyArchiveChecksum(archive); err != nil {
+		return err
+	} else if ok {
+		log.Info("Chain archive checksum verified", "path", archive)
+	} else {
+		log.Warn("No checksum for chain archive, skipping verification", "path", archive)
+	}
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	start := time.Now()
+	manifest, err := utils.ExtractChainArchive(f, dirs)
+	if err != nil {
+		return fmt.Errorf("failed to extract archive, remove the partially restored database: %v", err)
+	}
+	chaindb := utils.MakeChainDatabase(ctx, stack, true)
+	restored, err := readArchiveManifest(chaindb)
+	chaindb.Close()
+	if err != nil {
+		return err
+	}
+	if restored.Genesis != manifest.Genesis || restored.Head != manifest.Head {
+		return fmt.Errorf("restored chain mismatch: have head %d (%x), want %d (%x)",
+			restored.Head.Number, restored.Head.Hash, manifest.Head.Number, manifest.Head.Hash)
+	}
+	if downloaded {
+		os.Remove(archive)
+		os.Remove(archive + ".sha256")
+	}
+	log.Info("Chain archive restored", "head", manifest.Head.Number, "hash", manifest.Head.Hash, "scheme", manifest.Scheme, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// importCheckpoint retrieves a state checkpoint from a checkpoint server and
+// imports it once its finality proof is verified against the local chain.
+func importCheckpoint(ctx *cli.Context) error {
+	if ctx.NArg() < 1 || ctx.NArg() > 2 {
+		return errors.New("expected the checkpoint server url and optionally the checkpoint id")
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chaindb := utils.MakeChainDatabase(ctx, stack, false)
+	defer chaindb.Close()
+
+	scheme, err := rawdb.ParseStateScheme(ctx.String(utils.StateSchemeFlag.Name), chaindb)
+	if err != nil {
+		return err
+	}
+	if scheme != rawdb.HashScheme {
+		return errors.New("checkpoint import is only supported in hash mode")
+	}
+	config, err := core.LoadChainConfig(chaindb, utils.MakeGenesis(ctx))
+	if err != nil {
+		return err
+	}
+	engine, err := ethconfig.CreateConsensusEngine(config, chaindb)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	verifier, ok := engine.(checkpoint.AttestationVerifier)
+	if !ok {
+		return errors.New("checkpoints are only supported by the Turbo engine")
+	}
+	headers, err := core.NewHeaderChain(chaindb, config, engine, func() bool { return false })
+	if err != nil {
+		return err
+	}
+	client := checkpoint.NewClient(ctx.Args().First())
+
+	var id common.Hash
+	if ctx.NArg() == 2 {
+		id = common.HexToHash(ctx.Args().Get(1))
+	} else {
+		list, err := client.Checkpoints(context.Background())
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return errors.New("no checkpoint served")
+		}
+		id = list[0].ID
+	}
+	m, err := client.Manifest(context.Background(), id)
+	if err != nil {
+		return err
+	}
+	if err := checkpoint.VerifyProof(headers, verifier, m); err != nil {
+		return fmt.Errorf("invalid checkpoint %x: %w", id, err)
+	}
+	log.Info("Importing state checkpoint", "id", id, "number", m.Number, "hash", m.Hash, "root", m.Root, "chunks", len(m.Chunks))
+
+	var (
+		start  = time.Now()
+		logged = time.Now()
+		done   int
+	)
+	err = checkpoint.Import(chaindb, scheme, m, func(hash common.Hash) ([]checkpoint.Item, error) {
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Importing state checkpoint", "chunks", done, "total", len(m.Chunks), "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+		done++
+		return client.Chunk(context.Background(), hash)
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Imported state checkpoint", "number", m.Number, "hash", m.Hash, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	traceDiffRPCAFlag = &cli.StringFlag{
+		Name:     "rpc-a",
+		Usage:    "RPC endpoint of the first node",
+		Required: true,
+	}
+	traceDiffRPCBFlag = &cli.StringFlag{
+		Name:     "rpc-b",
+		Usage:    "RPC endpoint of the second node",
+		Required: true,
+	}
+	traceDiffBlockFlag = &cli.Uint64Flag{
+		Name:     "block",
+		Usage:    "Number of the first block compared",
+		Required: true,
+	}
+	traceDiffCountFlag = &cli.Uint64Flag{
+		Name:  "count",
+		Usage: "Number of blocks compared",
+		Value: 1,
+	}
+	traceDiffRegenerateAFlag = &cli.BoolFlag{
+		Name:  "regenerate-a",
+		Usage: "Regenerate the traces of the first node by re-executing the blocks instead of fetching the stored ones",
+	}
+	traceDiffRegenerateBFlag = &cli.BoolFlag{
+		Name:  "regenerate-b",
+		Usage: "Regenerate the traces of the second node by re-executing the blocks instead of fetching the stored ones",
+	}
+	traceDiffOnlyValueFlag = &cli.BoolFlag{
+		Name:  "only-value",
+		Usage: "Only regenerate the actions transferring value, as stored with --traceaction=1",
+	}
+
+	traceDiffCommand = &cli.Command{
+		Name:   "trace-diff",
+		Usage:  "Compare the action traces of blocks between two nodes",
+		Action: traceDiff,
+		Flags: []cli.Flag{
+			traceDiffRPCAFlag,
+			traceDiffRPCBFlag,
+			traceDiffBlockFlag,
+			traceDiffCountFlag,
+			traceDiffRegenerateAFlag,
+			traceDiffRegenerateBFlag,
+			traceDiffOnlyValueFlag,
+		},
+		Description: `
+    geth trace-diff --rpc-a <endpoint> --rpc-b <endpoint> --block <number> [--count <n>]
+
+Fetches the action traces of the blocks from two nodes, typically running two
+client versions, and reports the structural differences action by action: the
+transactions or actions found on a single node, and the fields whose values
+differ. The command fails if any difference is found, to verify that a tracer
+change doesn't alter the historical traces.
+
+The traces are the ones stored by the nodes with --traceaction, served by
+debug_traceActionByBlockNumber. With --regenerate-a or --regenerate-b, the
+blocks are re-executed by the node with the actionTracer instead, so that the
+code of its version produces the traces. The nodes must then have the state of
+the parent blocks.`,
+	}
+)
+
+// actionTrace is the action trace of a transaction, with the fields of the
+// actions left encoded to compare them whatever the client version.
+type actionTrace struct {
+	TxHash  common.Hash                  `json:"transactionHash"`
+	Actions []map[string]json.RawMessage `json:"logs"`
+}
+
+// traceDifference is a difference between the action traces of two nodes.
+type traceDifference struct {
+	TxHash common.Hash
+	Action int    // Index of the action in the transaction, -1 for the whole transaction
+	Field  string // Field of the action which differs, empty if the action is missing
+	A, B   string // Values on each node, empty if missing
+}
+
+func (d traceDifference) String() string {
+	switch {
+	case d.Action < 0:
+		return fmt.Sprintf("tx %s: %s", d.TxHash.Hex(), d.A+d.B)
+	case d.Field == "":
+		return fmt.Sprintf("tx %s action %d: %s", d.TxHash.Hex(), d.Action, d.A+d.B)
+	default:
+		return fmt.Sprintf("tx %s action %d: %s: a=%s b=%s", d.TxHash.Hex(), d.Action, d.Field, d.A, d.B)
+	}
+}
+
+func traceDiff(ctx *cli.Context) error {
+	var (
+		first   = ctx.Uint64(traceDiffBlockFlag.Name)
+		count   = ctx.Uint64(traceDiffCountFlag.Name)
+		tracerA = newTraceFetcher(ctx, traceDiffRPCAFlag, traceDiffRegenerateAFlag)
+		tracerB = newTraceFetcher(ctx, traceDiffRPCBFlag, traceDiffRegenerateBFlag)
+		total   int
+	)
+	defer tracerA.client.Close()
+	defer tracerB.client.Close()
+
+	for number := first; number < first+count; number++ {
+		tracesA, err := tracerA.traces(ctx.Context, number)
+		if err != nil {
+			return fmt.Errorf("block %d on %s: %v", number, tracerA.endpoint, err)
+		}
+		tracesB, err := tracerB.traces(ctx.Context, number)
+		if err != nil {
+			return fmt.Errorf("block %d on %s: %v", number, tracerB.endpoint, err)
+		}
+		diffs := diffActionTraces(tracesA, tracesB)
+		if len(diffs) == 0 {
+			fmt.Printf("Block %d: %d traced transactions match\n", number, len(tracesA))
+			continue
+		}
+		fmt.Printf("Block %d: %d differences\n", number, len(diffs))
+		for _, diff := range diffs {
+			fmt.Printf("  %v\n", diff)
+		}
+		total += len(diffs)
+	}
+	if total > 0 {
+		return fmt.Errorf("%d differences found", total)
+	}
+	return nil
+}
+
+// traceFetcher fetches the action traces of the blocks from a node.
+type traceFetcher struct {
+	endpoint   string
+	client     *rpc.Client
+	regenerate bool
+	onlyValue  bool
+}
+
+func newTraceFetcher(ctx *cli.Context, endpointFlag *cli.StringFlag, regenerateFlag *cli.BoolFlag) *traceFetcher {
+	endpoint := ctx.String(endpointFlag.Name)
+	client, err := rpc.DialContext(ctx.Context, endpoint)
+	if err != nil {
+		utils.Fatalf("Unable to connect to %s: %v", endpoint, err)
+	}
+	return &traceFetcher{
+		endpoint:   endpoint,
+		client:     client,
+		regenerate: ctx.Bool(regenerateFlag.Name),
+		onlyValue:  ctx.Bool(traceDiffOnlyValueFlag.Name),
+	}
+}
+
+// traces returns the action traces of the transactions of the block, leaving
+// out the transactions without actions as the block processor does.
+func (f *traceFetcher) traces(ctx context.Context, number uint64) ([]*actionTrace, error) {
+	if !f.regenerate {
+		var traces []*actionTrace
+		if err := f.client.CallContext(ctx, &traces, "debug_traceActionByBlockNumber", hexutil.Uint64(number), nil); err != nil {
+			return nil, err
+		}
+		return traces, nil
+	}
+	var results []struct {
+		TxHash common.Hash                  `json:"txHash"`
+		Result []map[string]json.RawMessage `json:"result"`
+		Error  string                       `json:"error"`
+	}
+	config := map[string]interface{}{
+		"tracer":       "actionTracer",
+		"tracerConfig": map[string]bool{"onlyValue": f.onlyValue},
+	}
+	if err := f.client.CallContext(ctx, &results, "debug_traceBlockByNumber", hexutil.Uint64(number), config); err != nil {
+		return nil, err
+	}
+	traces := make([]*actionTrace, 0, len(results))
+	for _, result := range results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("tx %s: %s", result.TxHash.Hex(), result.Error)
+		}
+		if len(result.Result) > 0 {
+			traces = append(traces, &actionTrace{TxHash: result.TxHash, Actions: result.Result})
+		}
+	}
+	return traces, nil
+}
+
+// diffActionTraces compares the action traces of the transactions of a block
+// from two nodes.
+func diffActionTraces(a, b []*actionTrace) []traceDifference {
+	var (
+		diffs   []traceDifference
+		tracesB = make(map[common.Hash]*actionTrace, len(b))
+	)
+	for _, trace := range b {
+		tracesB[trace.TxHash] = trace
+	}
+	for _, traceA := range a {
+		traceB, ok := tracesB[traceA.TxHash]
+		if !ok {
+			diffs = append(diffs, traceDifference{TxHash: traceA.TxHash, Action: -1, A: "only traced on a"})
+			continue
+		}
+		delete(tracesB, traceA.TxHash)
+		diffs = append(diffs, diffActions(traceA.TxHash, traceA.Actions, traceB.Actions)...)
+	}
+	for _, trace := range b {
+		if _, ok := tracesB[trace.TxHash]; ok {
+			diffs = append(diffs, traceDifference{TxHash: trace.TxHash, Action: -1, B: "only traced on b"})
+		}
+	}
+	return diffs
+}
+
+// diffActions compares the actions of a transaction field by field.
+func diffActions(tx common.Hash, a, b []map[string]json.RawMessage) []traceDifference {
+	var diffs []traceDifference
+	for i := 0; i < max(len(a), len(b)); i++ {
+		switch {
+		case i >= len(b):
+			diffs = append(diffs, traceDifference{TxHash: tx, Action: i, A: "only on a"})
+			continue
+		case i >= len(a):
+			diffs = append(diffs, traceDifference{TxHash: tx, Action: i, B: "only on b"})
+			continue
+		}
+		fields := make([]string, 0, len(a[i]))
+		for field := range a[i] {
+			fields = append(fields, field)
+		}
+		for field := range b[i] {
+			if _, ok := a[i][field]; !ok {
+				fields = append(fields, field)
+			}
+		}
+		slices.Sort(fields)
+		for _, field := range fields {
+			valueA, valueB := compactJSON(a[i][field]), compactJSON(b[i][field])
+			if valueA != valueB {
+				diffs = append(diffs, traceDifference{TxHash: tx, Action: i, Field: field, A: valueA, B: valueB})
+			}
+		}
+	}
+	return diffs
+}
+
+// compactJSON returns the encoded value without insignificant spaces, or
+// "missing" if not set.
+func compactJSON(value json.RawMessage) string {
+	if value == nil {
+		return "missing"
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, value); err != nil {
+		return strings.TrimSpace(string(value))
+	}
+	return buf.String()
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDiffActionTraces(t *testing.T) {
+	parse := func(actions string) []map[string]json.RawMessage {
+		var parsed []map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(actions), &parsed); err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	var (
+		tx1 = common.Hash{0x01}
+		tx2 = common.Hash{0x02}
+		tx3 = common.Hash{0x03}
+		a   = []*actionTrace{
+			{TxHash: tx1, Actions: parse(`[{"opcode": "CALL", "gas_used": 100}, {"opcode": "CALL"}]`)},
+			{TxHash: tx2, Actions: parse(`[{"opcode": "CREATE", "error": "out of gas"}]`)},
+		}
+		b = []*actionTrace{
+			{TxHash: tx1, Actions: parse(`[{"opcode":"CALL","gas_used":120}]`)},
+			{TxHash: tx2, Actions: parse(`[{"opcode": "CREATE"}]`)},
+			{TxHash: tx3, Actions: parse(`[{"opcode": "CALL"}]`)},
+		}
+	)
+	want := []traceDifference{
+		{TxHash: tx1, Action: 0, Field: "gas_used", A: "100", B: "120"},
+		{TxHash: tx1, Action: 1, A: "only on a"},
+		{TxHash: tx2, Action: 0, Field: "error", A: `"out of gas"`, B: "missing"},
+		{TxHash: tx3, Action: -1, B: "only traced on b"},
+	}
+	if have := diffActionTraces(a, b); !reflect.DeepEqual(have, want) {
+		t.Fatalf("differences mismatch:\nhave %v\nwant %v", have, want)
+	}
+	if diffs := diffActionTraces(a, a); len(diffs) != 0 {
+		t.Fatalf("differences between identical traces: %v", diffs)
+	}
+}
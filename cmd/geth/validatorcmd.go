@@ -0,0 +1,381 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/safe"
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	safeAddressFlag = &cli.StringFlag{
+		Name:     "safe",
+		Usage:    "Address of the Safe managing the validator",
+		Required: true,
+	}
+	endpointFlag = &cli.StringFlag{
+		Name:  "endpoint",
+		Usage: "RPC endpoint of the node, the IPC endpoint of the data directory by default",
+	}
+	safeOutFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the proposal to, the standard output by default",
+	}
+	earningsOutFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the CSV statement to, the standard output by default",
+	}
+	safeOwnerFlag = &cli.StringFlag{
+		Name:     "owner",
+		Usage:    "Keystore account of the owner signing the proposal",
+		Required: true,
+	}
+	safeFromFlag = &cli.StringFlag{
+		Name:     "from",
+		Usage:    "Keystore account sending the execution transaction",
+		Required: true,
+	}
+
+	validatorCommand = &cli.Command{
+		Name:  "validator",
+		Usage: "Manage validators",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "earnings",
+				Usage:     "Export the earnings statement of a validator",
+				ArgsUsage: "<validator> <fromEpoch> <toEpoch>",
+				Action:    validatorEarnings,
+				Flags:     []cli.Flag{utils.DataDirFlag, endpointFlag, earningsOutFlag},
+				Description: `
+    geth validator earnings <validator> <fromEpoch> <toEpoch>
+
+Exports the block rewards, fee distributions and slashing deductions of the
+validator in each epoch of the range as CSV, for accounting. The amounts are
+in wei, and the last line holds the totals. The node must have summarized the
+epochs, see nero_getEpochSummary.`,
+			},
+			{
+				Name:  "safe",
+				Usage: "Manage validators through a Gnosis Safe multisig",
+				Description: `
+
+Validators whose manager is a Gnosis Safe are managed by proposing Staking
+operations as Safe transactions, signed offline by the owners of the Safe and
+executed once the threshold of signatures is reached.
+
+Proposals are JSON files passed around between the owners, each one adding
+its signature with the sign command.`,
+				Subcommands: []*cli.Command{
+					{
+						Name:      "propose",
+						Usage:     "Create a proposal of a Staking operation",
+						ArgsUsage: "<operation> <arguments>",
+						Action:    safePropose,
+						Flags:     []cli.Flag{utils.DataDirFlag, safeAddressFlag, endpointFlag, safeOutFlag},
+						Description: `
+    geth validator safe propose --safe <address> <operation> <arguments>
+
+Creates a proposal of the Staking operation with the next nonce of the Safe.
+The operations are:
+
+    addstake <validator> <amount>      add the amount (in wei) to the stakes
+    substake <validator> <amount>      withdraw the amount (in wei) from the stakes
+    restake <from> <to> <amount>       move the amount (in wei) to another validator
+    exit <validator>                   exit the validator
+    claim <validator>                  claim the rewards of the validator
+
+The Staking contract has no method to change the commission rate of a
+validator, it is set when registering.`,
+					},
+					{
+						Name:      "sign",
+						Usage:     "Sign a proposal with the key of an owner",
+						ArgsUsage: "<proposalFile>",
+						Action:    safeSign,
+						Flags:     []cli.Flag{utils.DataDirFlag, utils.KeyStoreDirFlag, utils.PasswordFileFlag, safeOwnerFlag},
+						Description: `
+    geth validator safe sign --owner <address> <proposalfile>
+
+Signs the proposal with the keystore account of an owner and adds the
+signature to the proposal file.`,
+					},
+					{
+						Name:      "exec",
+						Usage:     "Execute a signed proposal",
+						ArgsUsage: "<proposalFile>",
+						Action:    safeExec,
+						Flags:     []cli.Flag{utils.DataDirFlag, utils.KeyStoreDirFlag, utils.PasswordFileFlag, safeFromFlag, endpointFlag},
+						Description: `
+    geth validator safe exec --from <address> <proposalfile>
+
+Checks that the proposal is signed by enough owners and sends the transaction
+executing it from the keystore account, which needn't be an owner.`,
+					},
+				},
+			},
+		},
+	}
+)
+
+// dialEndpoint connects to the node serving the chain of the validators.
+func dialEndpoint(ctx *cli.Context) *ethclient.Client {
+	endpoint := ctx.String(endpointFlag.Name)
+	if endpoint == "" {
+		cfg := defaultNodeConfig()
+		utils.SetDataDir(ctx, &cfg)
+		endpoint = cfg.IPCEndpoint()
+	}
+	client, err := ethclient.Dial(endpoint)
+	if err != nil {
+		utils.Fatalf("Unable to connect to %s: %v", endpoint, err)
+	}
+	return client
+}
+
+// parseStakingOperation creates the Safe transaction of the Staking operation
+// given as arguments.
+func parseStakingOperation(args []string) (safe.Transaction, error) {
+	if len(args) == 0 {
+		return safe.Transaction{}, errors.New("missing operation")
+	}
+	op, args := args[0], args[1:]
+
+	// Parse the validator addresses followed by the amount, if any
+	var (
+		addrs  []common.Address
+		amount *big.Int
+	)
+	for i, arg := range args {
+		if i == len(args)-1 && (op == "addstake" || op == "substake" || op == "restake") {
+			value, ok := math.ParseBig256(arg)
+			if !ok {
+				return safe.Transaction{}, fmt.Errorf("invalid amount %q", arg)
+			}
+			amount = value
+			break
+		}
+		if !common.IsHexAddress(arg) {
+			return safe.Transaction{}, fmt.Errorf("invalid address %q", arg)
+		}
+		addrs = append(addrs, common.HexToAddress(arg))
+	}
+	switch {
+	case op == "addstake" && len(args) == 2:
+		return safe.AddStake(addrs[0], amount)
+	case op == "substake" && len(args) == 2:
+		return safe.SubStake(addrs[0], amount)
+	case op == "restake" && len(args) == 3:
+		return safe.ReStake(addrs[0], addrs[1], amount)
+	case op == "exit" && len(args) == 1:
+		return safe.ExitStaking(addrs[0])
+	case op == "claim" && len(args) == 1:
+		return safe.ClaimRewards(addrs[0])
+	case op == "addstake" || op == "substake" || op == "restake" || op == "exit" || op == "claim":
+		return safe.Transaction{}, fmt.Errorf("invalid number of arguments for %s", op)
+	}
+	return safe.Transaction{}, fmt.Errorf("unknown operation %q", op)
+}
+
+// readProposal reads the proposal file given as the only argument.
+func readProposal(ctx *cli.Context) (string, *safe.Proposal) {
+	if ctx.Args().Len() != 1 {
+		utils.Fatalf("proposal file must be given as the only argument")
+	}
+	path := ctx.Args().First()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		utils.Fatalf("Could not read proposal file: %v", err)
+	}
+	proposal := new(safe.Proposal)
+	if err := json.Unmarshal(data, proposal); err != nil {
+		utils.Fatalf("Invalid proposal file: %v", err)
+	}
+	return path, proposal
+}
+
+// writeProposal writes the proposal to the file, or to the standard output if
+// no path is given.
+func writeProposal(path string, proposal *safe.Proposal) {
+	data, err := json.MarshalIndent(proposal, "", "  ")
+	if err != nil {
+		utils.Fatalf("Failed to encode proposal: %v", err)
+	}
+	if path == "" {
+		fmt.Println(string(data))
+		return
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		utils.Fatalf("Failed to write proposal file: %v", err)
+	}
+}
+
+// makeKeyStore returns the keystore of the accounts defined by the CLI flags.
+func makeKeyStore(ctx *cli.Context) *keystore.KeyStore {
+	backends := makeAccountManager(ctx).Backends(keystore.KeyStoreType)
+	if len(backends) == 0 {
+		utils.Fatalf("Keystore is not available")
+	}
+	return backends[0].(*keystore.KeyStore)
+}
+
+func safePropose(ctx *cli.Context) error {
+	if !common.IsHexAddress(ctx.String(safeAddressFlag.Name)) {
+		utils.Fatalf("Invalid Safe address %q", ctx.String(safeAddressFlag.Name))
+	}
+	tx, err := parseStakingOperation(ctx.Args().Slice())
+	if err != nil {
+		utils.Fatalf("Invalid operation: %v", err)
+	}
+	client := dialEndpoint(ctx)
+	defer client.Close()
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		utils.Fatalf("Failed to retrieve chain id: %v", err)
+	}
+	wallet := safe.NewSafe(common.HexToAddress(ctx.String(safeAddressFlag.Name)), client)
+	proposal, err := wallet.Propose(&bind.CallOpts{}, chainID, tx)
+	if err != nil {
+		utils.Fatalf("Failed to create proposal: %v", err)
+	}
+	proposal.Description = strings.Join(ctx.Args().Slice(), " ")
+	writeProposal(ctx.String(safeOutFlag.Name), proposal)
+
+	fmt.Fprintf(os.Stderr, "Safe transaction hash: %s\n", proposal.Hash())
+	return nil
+}
+
+func safeSign(ctx *cli.Context) error {
+	path, proposal := readProposal(ctx)
+
+	ks := makeKeyStore(ctx)
+	account, _ := unlockAccount(ks, ctx.String(safeOwnerFlag.Name), 0, utils.MakePasswordList(ctx))
+	for _, wallet := range ks.Wallets() {
+		if wallet.Contains(account) {
+			if err := proposal.Sign(wallet, account); err != nil {
+				utils.Fatalf("Failed to sign proposal: %v", err)
+			}
+			break
+		}
+	}
+	writeProposal(path, proposal)
+
+	fmt.Printf("Signed Safe transaction %s, %d signatures\n", proposal.Hash(), len(proposal.Signatures))
+	return nil
+}
+
+func safeExec(ctx *cli.Context) error {
+	_, proposal := readProposal(ctx)
+
+	client := dialEndpoint(ctx)
+	defer client.Close()
+
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		utils.Fatalf("Failed to retrieve chain id: %v", err)
+	}
+	if chainID.Cmp(proposal.ChainID) != 0 {
+		utils.Fatalf("Proposal of chain %d, connected to chain %d", proposal.ChainID, chainID)
+	}
+	wallet := safe.NewSafe(proposal.Safe, client)
+	if err := wallet.Verify(&bind.CallOpts{}, proposal); err != nil {
+		utils.Fatalf("Invalid proposal: %v", err)
+	}
+	ks := makeKeyStore(ctx)
+	account, _ := unlockAccount(ks, ctx.String(safeFromFlag.Name), 0, utils.MakePasswordList(ctx))
+	opts, err := bind.NewKeyStoreTransactorWithChainID(ks, account, chainID)
+	if err != nil {
+		utils.Fatalf("Failed to create transactor: %v", err)
+	}
+	tx, err := wallet.Exec(opts, proposal)
+	if err != nil {
+		utils.Fatalf("Failed to execute proposal: %v", err)
+	}
+	fmt.Printf("Sent transaction %s\n", tx.Hash())
+	return nil
+}
+
+// validatorEarnings exports the earnings statement of a validator as CSV.
+func validatorEarnings(ctx *cli.Context) error {
+	if ctx.NArg() != 3 {
+		utils.Fatalf("This command requires the validator and the epoch range as arguments.")
+	}
+	if !common.IsHexAddress(ctx.Args().Get(0)) {
+		utils.Fatalf("Invalid validator address %q", ctx.Args().Get(0))
+	}
+	validator := common.HexToAddress(ctx.Args().Get(0))
+	fromEpoch, ok := math.ParseUint64(ctx.Args().Get(1))
+	if !ok {
+		utils.Fatalf("Invalid epoch %q", ctx.Args().Get(1))
+	}
+	toEpoch, ok := math.ParseUint64(ctx.Args().Get(2))
+	if !ok {
+		utils.Fatalf("Invalid epoch %q", ctx.Args().Get(2))
+	}
+	client := dialEndpoint(ctx)
+	defer client.Close()
+
+	var earnings eth.ValidatorEarnings
+	if err := client.Client().CallContext(ctx.Context, &earnings, "nero_getValidatorEarnings", validator, hexutil.Uint64(fromEpoch), hexutil.Uint64(toEpoch)); err != nil {
+		utils.Fatalf("Failed to retrieve earnings: %v", err)
+	}
+	out := io.Writer(os.Stdout)
+	if path := ctx.String(earningsOutFlag.Name); path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			utils.Fatalf("Failed to create statement file: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+	if err := writeEarnings(out, &earnings); err != nil {
+		utils.Fatalf("Failed to write statement: %v", err)
+	}
+	return nil
+}
+
+// writeEarnings writes the earnings statement as CSV, one line per epoch
+// followed by the totals.
+func writeEarnings(out io.Writer, earnings *eth.ValidatorEarnings) error {
+	amount := func(value *hexutil.Big) string {
+		if value == nil {
+			return ""
+		}
+		return value.ToInt().String()
+	}
+	w := csv.NewWriter(out)
+	w.Write([]string{"epoch", "firstBlock", "lastBlock", "active", "stake", "blockRewards", "feeRewards", "slashed", "net"})
+	for _, epoch := range earnings.Epochs {
+		w.Write([]string{
+			strconv.FormatUint(uint64(epoch.Epoch), 10),
+			strconv.FormatUint(uint64(epoch.FirstBlock), 10),
+			strconv.FormatUint(uint64(epoch.LastBlock), 10),
+			strconv.FormatBool(epoch.Active),
+			amount(epoch.Stake),
+			amount(epoch.BlockRewards),
+			amount(epoch.FeeRewards),
+			amount(epoch.Slashed),
+			amount(epoch.Net),
+		})
+	}
+	w.Write([]string{"total", "", "", "", "", amount(earnings.BlockRewards), amount(earnings.FeeRewards), amount(earnings.Slashed), amount(earnings.Net)})
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,344 @@
+package utils
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// archiveManifestName is the name of the first entry of a chain archive,
+	// describing its content.
+	archiveManifestName = "manifest.json"
+
+	// ArchiveVersion is the version of the chain archive format.
+	ArchiveVersion = 1
+
+	// ArchiveChaindataDir and ArchiveAncientDir are the directories of a chain
+	// archive holding the key-value store and the ancient store.
+	ArchiveChaindataDir = "chaindata"
+	ArchiveAncientDir   = "ancient"
+)
+
+// ArchiveBlock identifies a block of a chain archive.
+type ArchiveBlock struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// ArchiveManifest describes the chain data of a chain archive.
+type ArchiveManifest struct {
+	Version   int           `json:"version"`
+	Genesis   common.Hash   `json:"genesis"`
+	Scheme    string        `json:"scheme"`              // State scheme of the database
+	Head      ArchiveBlock  `json:"head"`                // Head block of the archived chain
+	Finalized *ArchiveBlock `json:"finalized,omitempty"` // Finalized block of the archived chain, if any
+	Created   time.Time     `json:"created"`
+}
+
+// ArchiveDir is a directory stored in a chain archive under a prefix.
+type ArchiveDir struct {
+	Prefix string // Directory of the archive
+	Path   string // Directory of the file system
+}
+
+// isArchivedFile reports whether the file of a database is archived, the
+// lock files being left out.
+func isArchivedFile(name string) bool {
+	return name != "LOCK" && name != "FLOCK"
+}
+
+// WriteChainArchive writes the manifest and the directories into a gzipped
+// tarball. A directory nested in another one is only written under its own
+// prefix. The databases must be closed, the archive being a copy of the files.
+func WriteChainArchive(w io.Writer, manifest *ArchiveManifest, dirs []ArchiveDir) error {
+	var (
+		zw = gzip.NewWriter(w)
+		tw = tar.NewWriter(zw)
+	)
+	blob, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: archiveManifestName, Mode: 0644, Size: int64(len(blob)), ModTime: manifest.Created}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(blob); err != nil {
+		return err
+	}
+	nested := make(map[string]bool)
+	for _, dir := range dirs {
+		nested[filepath.Clean(dir.Path)] = true
+	}
+	for _, dir := range dirs {
+		root := filepath.Clean(dir.Path)
+		err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if file != root && nested[file] {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !info.Mode().IsRegular() || !isArchivedFile(info.Name()) {
+				return nil
+			}
+			rel, err := filepath.Rel(root, file)
+			if err != nil {
+				return err
+			}
+			return writeArchiveFile(tw, path.Join(dir.Prefix, filepath.ToSlash(rel)), file, info)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// writeArchiveFile writes a file into the tarball.
+func writeArchiveFile(tw *tar.Writer, name string, file string, info os.FileInfo) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: info.Size(), ModTime: info.ModTime()}); err != nil {
+		return err
+	}
+	// The database being closed, the file must not change while being copied
+	if n, err := io.Copy(tw, f); err != nil {
+		return err
+	} else if n != info.Size() {
+		return fmt.Errorf("file %s changed while archiving", file)
+	}
+	return nil
+}
+
+// ExtractChainArchive extracts a chain archive written by WriteChainArchive,
+// placing the directories at the given paths, and returns its manifest. The
+// entries outside of the directories are rejected.
+func ExtractChainArchive(r io.Reader, dirs []ArchiveDir) (*ArchiveManifest, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var (
+		tr       = tar.NewReader(zr)
+		manifest *ArchiveManifest
+	)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if manifest == nil {
+			if header.Name != archiveManifestName {
+				return nil, errors.New("chain archive manifest missing")
+			}
+			manifest = new(ArchiveManifest)
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("invalid chain archive manifest: %v", err)
+			}
+			if manifest.Version != ArchiveVersion {
+				return nil, fmt.Errorf("unsupported chain archive version %d", manifest.Version)
+			}
+			continue
+		}
+		if header.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("unexpected chain archive entry %s", header.Name)
+		}
+		file, err := archiveEntryPath(header.Name, dirs)
+		if err != nil {
+			return nil, err
+		}
+		if err := extractArchiveFile(tr, file); err != nil {
+			return nil, err
+		}
+	}
+	if manifest == nil {
+		return nil, errors.New("empty chain archive")
+	}
+	return manifest, nil
+}
+
+// archiveEntryPath resolves the file system path of an archive entry.
+func archiveEntryPath(name string, dirs []ArchiveDir) (string, error) {
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("invalid chain archive entry %s", name)
+	}
+	for _, dir := range dirs {
+		if rel, ok := strings.CutPrefix(clean, dir.Prefix+"/"); ok {
+			return filepath.Join(dir.Path, filepath.FromSlash(rel)), nil
+		}
+	}
+	return "", fmt.Errorf("unexpected chain archive entry %s", name)
+}
+
+func extractArchiveFile(r io.Reader, file string) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteArchiveChecksum writes the SHA-256 checksum of the archive next to it,
+// in the format of sha256sum.
+func WriteArchiveChecksum(archive string) (string, error) {
+	sum, err := archiveChecksum(archive)
+	if err != nil {
+		return "", err
+	}
+	line := fmt.Sprintf("%s  %s\n", sum, filepath.Base(archive))
+	return sum, os.WriteFile(archive+".sha256", []byte(line), 0644)
+}
+
+// VerifyArchiveChecksum checks the archive against the checksum written next to
+// it, returning false if there's no checksum.
+func VerifyArchiveChecksum(archive string) (bool, error) {
+	blob, err := os.ReadFile(archive + ".sha256")
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	fields := strings.Fields(string(blob))
+	if len(fields) == 0 {
+		return false, fmt.Errorf("invalid checksum file %s.sha256", archive)
+	}
+	sum, err := archiveChecksum(archive)
+	if err != nil {
+		return false, err
+	}
+	if !strings.EqualFold(fields[0], sum) {
+		return false, fmt.Errorf("checksum mismatch: have %s, want %s", sum, fields[0])
+	}
+	return true, nil
+}
+
+func archiveChecksum(archive string) (string, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// DownloadArchive downloads the archive at the URL into the file, along with
+// its checksum if published next to it. An interrupted download is resumed
+// from the partially downloaded file if the server supports range requests.
+func DownloadArchive(url string, file string) error {
+	if err := downloadResumable(url, file); err != nil {
+		return err
+	}
+	resp, err := http.Get(url + ".sha256")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		log.Warn("Archive checksum not available", "url", url+".sha256", "status", resp.Status)
+		return nil
+	}
+	blob, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file+".sha256", blob, 0644)
+}
+
+// downloadResumable downloads the URL into the file through a partial file,
+// continuing the partial download if any.
+func downloadResumable(url string, file string) error {
+	partial := file + ".partial"
+	f, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	offset, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		log.Info("Resuming archive download", "url", url, "offset", offset)
+	case http.StatusOK:
+		// The server ignored the range, start over
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is already complete
+	default:
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	if resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		if _, err := io.Copy(f, resp.Body); err != nil {
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partial, file)
+}
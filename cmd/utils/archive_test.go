@@ -0,0 +1,175 @@
+package utils
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func writeTestFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Tests that a chain archive restores the key-value store and the nested
+// ancient store at their own locations, leaving out the lock files.
+func TestChainArchive(t *testing.T) {
+	var (
+		source   = t.TempDir()
+		chaindb  = filepath.Join(source, "chaindata")
+		ancient  = filepath.Join(chaindb, "ancient")
+		manifest = &ArchiveManifest{
+			Version:   ArchiveVersion,
+			Genesis:   common.Hash{0x1},
+			Scheme:    "path",
+			Head:      ArchiveBlock{Number: 10, Hash: common.Hash{0x2}},
+			Finalized: &ArchiveBlock{Number: 8, Hash: common.Hash{0x3}},
+			Created:   time.Unix(1700000000, 0).UTC(),
+		}
+	)
+	writeTestFiles(t, chaindb, map[string]string{
+		"000001.log":           "log",
+		"MANIFEST-000001":      "manifest",
+		"LOCK":                 "",
+		"ancient/chain/FLOCK":  "",
+		"ancient/chain/h.cidx": "headers",
+		"ancient/state/s.meta": "state",
+	})
+	var archive bytes.Buffer
+	dirs := []ArchiveDir{{Prefix: ArchiveChaindataDir, Path: chaindb}, {Prefix: ArchiveAncientDir, Path: ancient}}
+	if err := WriteChainArchive(&archive, manifest, dirs); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	target := t.TempDir()
+	restored := []ArchiveDir{
+		{Prefix: ArchiveChaindataDir, Path: filepath.Join(target, "chaindata")},
+		{Prefix: ArchiveAncientDir, Path: filepath.Join(target, "ancient")},
+	}
+	have, err := ExtractChainArchive(bytes.NewReader(archive.Bytes()), restored)
+	if err != nil {
+		t.Fatalf("failed to extract archive: %v", err)
+	}
+	if have.Head != manifest.Head || *have.Finalized != *manifest.Finalized || have.Genesis != manifest.Genesis || !have.Created.Equal(manifest.Created) {
+		t.Errorf("manifest mismatch: have %+v, want %+v", have, manifest)
+	}
+	want := map[string]string{
+		"chaindata/000001.log":      "log",
+		"chaindata/MANIFEST-000001": "manifest",
+		"ancient/chain/h.cidx":      "headers",
+		"ancient/state/s.meta":      "state",
+	}
+	var files []string
+	filepath.Walk(target, func(file string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(target, file)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	})
+	if len(files) != len(want) {
+		t.Fatalf("restored files mismatch: have %v", files)
+	}
+	for name, content := range want {
+		blob, err := os.ReadFile(filepath.Join(target, filepath.FromSlash(name)))
+		if err != nil || string(blob) != content {
+			t.Errorf("restored file %s mismatch: have %q, %v, want %q", name, blob, err, content)
+		}
+	}
+}
+
+// Tests that the archive entries escaping the restored directories are rejected.
+func TestChainArchiveTraversal(t *testing.T) {
+	for _, name := range []string{"../evil", "chaindata/../../evil", "/etc/evil", "other/file"} {
+		var (
+			buf bytes.Buffer
+			zw  = gzip.NewWriter(&buf)
+			tw  = tar.NewWriter(zw)
+		)
+		manifest := []byte(`{"version":1}`)
+		tw.WriteHeader(&tar.Header{Name: archiveManifestName, Mode: 0644, Size: int64(len(manifest))})
+		tw.Write(manifest)
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 4})
+		tw.Write([]byte("evil"))
+		tw.Close()
+		zw.Close()
+
+		dir := t.TempDir()
+		if _, err := ExtractChainArchive(&buf, []ArchiveDir{{Prefix: ArchiveChaindataDir, Path: filepath.Join(dir, "chaindata")}}); err == nil {
+			t.Errorf("entry %s not rejected", name)
+		}
+	}
+}
+
+// Tests that an interrupted download is resumed and checked against the
+// published checksum.
+func TestDownloadArchive(t *testing.T) {
+	var (
+		content = bytes.Repeat([]byte("archive"), 1000)
+		dir     = t.TempDir()
+		source  = filepath.Join(dir, "source.tar.gz")
+		file    = filepath.Join(dir, "download.tar.gz")
+		ranges  []string
+	)
+	if err := os.WriteFile(source, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WriteArchiveChecksum(source); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			http.ServeFile(w, r, source+".sha256")
+			return
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	// Leave a partial download behind
+	if err := os.WriteFile(file+".partial", content[:1234], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := DownloadArchive(srv.URL+"/archive.tar.gz", file); err != nil {
+		t.Fatalf("failed to download archive: %v", err)
+	}
+	if len(ranges) != 1 || ranges[0] != "bytes=1234-" {
+		t.Errorf("download not resumed: %v", ranges)
+	}
+	blob, err := os.ReadFile(file)
+	if err != nil || !bytes.Equal(blob, content) {
+		t.Fatalf("downloaded archive mismatch: %v", err)
+	}
+	if _, err := os.Stat(file + ".partial"); !os.IsNotExist(err) {
+		t.Errorf("partial download left behind")
+	}
+	if ok, err := VerifyArchiveChecksum(file); !ok || err != nil {
+		t.Errorf("checksum not verified: %v, %v", ok, err)
+	}
+	// Corrupt the archive
+	blob[0] ^= 0xff
+	if err := os.WriteFile(file, blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyArchiveChecksum(file); err == nil {
+		t.Errorf("corrupted archive verified")
+	}
+}
@@ -78,6 +78,14 @@ func StartNode(ctx *cli.Context, stack *node.Node, isConsole bool) {
 	if err := stack.Start(); err != nil {
 		Fatalf("Error starting protocol stack: %v", err)
 	}
+	if ctx.Bool(DiskForecastFlag.Name) && stack.DataDir() != "" {
+		forecaster := newDiskForecaster(ctx, stack)
+		go forecaster.loop()
+		go func() {
+			stack.Wait()
+			forecaster.stop()
+		}()
+	}
 	go func() {
 		sigc := make(chan os.Signal, 1)
 		signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
@@ -144,6 +152,11 @@ func monitorFreeDiskSpace(sigc chan os.Signal, path string, freeDiskSpaceCritica
 	}
 }
 
+// FreeDiskSpace returns the free disk space available to the given path.
+func FreeDiskSpace(path string) (uint64, error) {
+	return getFreeDiskSpace(path)
+}
+
 func ImportChain(chain *core.BlockChain, fn string) error {
 	// Watch for Ctrl-C while the import is running.
 	// If a signal is received, the import will stop at the next batch.
@@ -864,3 +877,63 @@ func ExportChaindata(fn string, kind string, iter ChainDataIterator, interrupt c
 		"elapsed", common.PrettyDuration(time.Since(start)))
 	return nil
 }
+
+// prefixIterator iterates the entries of the database under the given key
+// prefixes, one prefix after another.
+type prefixIterator struct {
+	db       ethdb.Database
+	prefixes [][]byte
+	iter     ethdb.Iterator
+}
+
+func (iter *prefixIterator) Next() (byte, []byte, []byte, bool) {
+	for {
+		if iter.iter == nil {
+			if len(iter.prefixes) == 0 {
+				return 0, nil, nil, false
+			}
+			iter.iter = iter.db.NewIterator(iter.prefixes[0], nil)
+			iter.prefixes = iter.prefixes[1:]
+		}
+		if iter.iter.Next() {
+			return OpBatchAdd, common.CopyBytes(iter.iter.Key()), common.CopyBytes(iter.iter.Value()), true
+		}
+		iter.iter.Release()
+		iter.iter = nil
+	}
+}
+
+func (iter *prefixIterator) Release() {
+	if iter.iter != nil {
+		iter.iter.Release()
+		iter.iter = nil
+	}
+}
+
+// ExportMigrationBackup exports the entries rewritten by the pending schema
+// migrations into the file, in the format imported by 'geth db import'. The
+// prefixes covered by another one are exported once.
+func ExportMigrationBackup(db ethdb.Database, fn string, pending []rawdb.SchemaMigration) error {
+	var prefixes [][]byte
+	for _, migration := range pending {
+		prefixes = append(prefixes, migration.Prefixes...)
+	}
+	var unique [][]byte
+	for i, prefix := range prefixes {
+		covered := false
+		for j, other := range prefixes {
+			if i != j && bytes.HasPrefix(prefix, other) && (len(other) < len(prefix) || j < i) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			unique = append(unique, prefix)
+		}
+	}
+	if len(unique) == 0 {
+		log.Info("Schema migrations rewrite no entries, skipping backup")
+		return nil
+	}
+	return ExportChaindata(fn, "schema-migration", &prefixIterator{db: db, prefixes: unique}, nil)
+}
@@ -0,0 +1,250 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"math"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// diskForecastInterval is the interval between store size samples.
+	diskForecastInterval = 5 * time.Minute
+
+	// diskWebhookTimeout is the timeout of alarm webhook deliveries.
+	diskWebhookTimeout = 10 * time.Second
+)
+
+var errDiskForecastStopped = errors.New("disk forecaster stopped")
+
+// diskSample is a measured store size at a point in time.
+type diskSample struct {
+	time time.Time
+	size uint64
+}
+
+// diskStore is a store whose growth is tracked by the forecaster.
+type diskStore struct {
+	name    string
+	path    string                 // Directory on the disk the store fills up
+	measure func() (uint64, error) // Measures the size of the store
+
+	samples []diskSample // Samples within the forecast window, oldest first
+	alarmed bool         // Whether the alarm is currently raised
+
+	sizeGauge   metrics.Gauge
+	growthGauge metrics.Gauge        // Bytes per day
+	daysGauge   metrics.GaugeFloat64 // Projected days until the disk is full, -1 if not growing
+}
+
+// diskForecaster periodically measures the size of the chain stores, derives
+// their growth rates and projects the days left until their disks fill up,
+// raising an alarm if the projection drops below a threshold.
+type diskForecaster struct {
+	stores  []*diskStore
+	window  time.Duration // Time span the growth rate is derived over
+	alarm   float64       // Projected days until full raising the alarm
+	webhook string        // URL the alarms are posted to, optional
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+// diskAlarm is the payload posted to the alarm webhook.
+type diskAlarm struct {
+	Store    string  `json:"store"`
+	Path     string  `json:"path"`
+	Alarm    bool    `json:"alarm"` // False once the projection recovered
+	DaysLeft float64 `json:"daysLeft"`
+	Size     uint64  `json:"size"`
+	Free     uint64  `json:"free"`
+	Growth   uint64  `json:"growth"` // Bytes per day
+}
+
+// newDiskForecaster creates a forecaster of the chaindata, ancient and trace
+// stores of the node, configured from the command line flags. The traces are
+// kept within the chaindata database, so the trace store is measured by the
+// bytes of traces written since the start instead of its size on disk.
+func newDiskForecaster(ctx *cli.Context, stack *node.Node) *diskForecaster {
+	var (
+		chaindata = stack.ResolvePath("chaindata")
+		ancient   = stack.ResolveAncient("chaindata", ctx.String(AncientFlag.Name))
+	)
+	f := &diskForecaster{
+		window:  ctx.Duration(DiskForecastWindowFlag.Name),
+		alarm:   ctx.Float64(DiskForecastAlarmFlag.Name),
+		webhook: ctx.String(DiskForecastWebhookFlag.Name),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	f.stores = []*diskStore{
+		newDiskStore("chaindata", chaindata, func() (uint64, error) { return storeSize(chaindata, ancient, f.quit) }),
+		newDiskStore("ancient", ancient, func() (uint64, error) { return storeSize(ancient, "", f.quit) }),
+		newDiskStore("traces", chaindata, func() (uint64, error) { return rawdb.TraceBytesWritten(), nil }),
+	}
+	return f
+}
+
+func newDiskStore(name string, path string, measure func() (uint64, error)) *diskStore {
+	return &diskStore{
+		name:        name,
+		path:        path,
+		measure:     measure,
+		sizeGauge:   metrics.NewRegisteredGauge("disk/"+name+"/size", nil),
+		growthGauge: metrics.NewRegisteredGauge("disk/"+name+"/growth", nil),
+		daysGauge:   metrics.NewRegisteredGaugeFloat64("disk/"+name+"/daysleft", nil),
+	}
+}
+
+// loop samples the stores until the forecaster is stopped.
+func (f *diskForecaster) loop() {
+	defer close(f.done)
+
+	ticker := time.NewTicker(diskForecastInterval)
+	defer ticker.Stop()
+
+	f.sample(time.Now())
+	for {
+		select {
+		case now := <-ticker.C:
+			f.sample(now)
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// stop terminates the sampling, aborting the one in progress.
+func (f *diskForecaster) stop() {
+	close(f.quit)
+	<-f.done
+}
+
+// sample measures all stores and updates their forecasts.
+func (f *diskForecaster) sample(now time.Time) {
+	for _, store := range f.stores {
+		select {
+		case <-f.quit:
+			return
+		default:
+		}
+		size, err := store.measure()
+		if err != nil {
+			log.Debug("Failed to measure store size", "store", store.name, "path", store.path, "err", err)
+			continue
+		}
+		free, err := getFreeDiskSpace(store.path)
+		if err != nil {
+			log.Debug("Failed to get free disk space", "store", store.name, "path", store.path, "err", err)
+			continue
+		}
+		store.samples = append(store.samples, diskSample{time: now, size: size})
+		for len(store.samples) > 2 && now.Sub(store.samples[1].time) >= f.window {
+			store.samples = store.samples[1:]
+		}
+		growth, days := forecastDisk(store.samples, free)
+
+		store.sizeGauge.Update(int64(size))
+		store.growthGauge.Update(int64(growth))
+		if math.IsInf(days, 1) {
+			store.daysGauge.Update(-1)
+		} else {
+			store.daysGauge.Update(days)
+		}
+		switch {
+		case days < f.alarm && !store.alarmed:
+			store.alarmed = true
+			log.Error("Disk projected to run full", "store", store.name, "days", days, "free", common.StorageSize(free), "growth", common.StorageSize(growth), "path", store.path)
+			f.notify(diskAlarm{store.name, store.path, true, days, size, free, growth})
+
+		case days >= f.alarm && store.alarmed:
+			store.alarmed = false
+			log.Info("Disk usage projection recovered", "store", store.name, "free", common.StorageSize(free), "path", store.path)
+			f.notify(diskAlarm{store.name, store.path, false, days, size, free, growth})
+		}
+	}
+}
+
+// notify posts an alarm to the configured webhook, if any.
+func (f *diskForecaster) notify(alarm diskAlarm) {
+	if f.webhook == "" {
+		return
+	}
+	if math.IsInf(alarm.DaysLeft, 1) {
+		alarm.DaysLeft = -1
+	}
+	blob, err := json.Marshal(alarm)
+	if err != nil {
+		log.Warn("Failed to encode disk alarm", "err", err)
+		return
+	}
+	client := &http.Client{Timeout: diskWebhookTimeout}
+	res, err := client.Post(f.webhook, "application/json", bytes.NewReader(blob))
+	if err != nil {
+		log.Warn("Failed to deliver disk alarm", "url", f.webhook, "err", err)
+		return
+	}
+	res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		log.Warn("Disk alarm webhook rejected delivery", "url", f.webhook, "status", res.Status)
+	}
+}
+
+// forecastDisk derives the growth rate in bytes per day from the samples and
+// the days left until the free space is exhausted. The projection is infinite
+// if the store is not growing or not enough samples are available.
+func forecastDisk(samples []diskSample, free uint64) (uint64, float64) {
+	if len(samples) < 2 {
+		return 0, math.Inf(1)
+	}
+	var (
+		first   = samples[0]
+		last    = samples[len(samples)-1]
+		elapsed = last.time.Sub(first.time)
+	)
+	if elapsed <= 0 || last.size <= first.size {
+		return 0, math.Inf(1)
+	}
+	growth := float64(last.size-first.size) / elapsed.Hours() * 24
+	return uint64(growth), float64(free) / growth
+}
+
+// storeSize sums up the sizes of all files below root, skipping the exclude
+// directory. The walk is aborted once quit is closed.
+func storeSize(root string, exclude string, quit chan struct{}) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		select {
+		case <-quit:
+			return errDiskForecastStopped
+		default:
+		}
+		if d.IsDir() {
+			if path == exclude {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestForecastDisk(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	tests := []struct {
+		samples []diskSample
+		free    uint64
+		growth  uint64
+		days    float64
+	}{
+		// Not enough samples to derive a rate
+		{nil, 100, 0, math.Inf(1)},
+		{[]diskSample{{start, 10}}, 100, 0, math.Inf(1)},
+		// Shrinking or constant stores never fill up
+		{[]diskSample{{start, 10}, {start.Add(time.Hour), 10}}, 100, 0, math.Inf(1)},
+		{[]diskSample{{start, 10}, {start.Add(time.Hour), 5}}, 100, 0, math.Inf(1)},
+		// Growing by 1000 bytes in 12 hours is 2000 bytes per day
+		{[]diskSample{{start, 1000}, {start.Add(6 * time.Hour), 1200}, {start.Add(12 * time.Hour), 2000}}, 10000, 2000, 5},
+	}
+	for i, tt := range tests {
+		growth, days := forecastDisk(tt.samples, tt.free)
+		if growth != tt.growth || days != tt.days {
+			t.Errorf("test %d: have growth %d days %v, want growth %d days %v", i, growth, days, tt.growth, tt.days)
+		}
+	}
+}
+
+func TestStoreSizeExclude(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "ancient")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(root, "000001.sst"), make([]byte, 100), 0644)
+	os.WriteFile(filepath.Join(nested, "headers.0000.cdat"), make([]byte, 40), 0644)
+
+	if size, err := storeSize(root, nested, nil); err != nil || size != 100 {
+		t.Errorf("excluded size mismatch: have %d (%v), want 100", size, err)
+	}
+	if size, err := storeSize(root, "", nil); err != nil || size != 140 {
+		t.Errorf("total size mismatch: have %d (%v), want 140", size, err)
+	}
+}
+
+// Tests that the forecaster samples the stores right away and stops sampling
+// once stopped.
+func TestDiskForecasterStop(t *testing.T) {
+	var (
+		measured = make(chan struct{}, 1)
+		dir      = t.TempDir()
+	)
+	f := &diskForecaster{
+		stores: []*diskStore{newDiskStore("test", dir, func() (uint64, error) {
+			measured <- struct{}{}
+			return 0, nil
+		})},
+		window: time.Hour,
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go f.loop()
+	select {
+	case <-measured:
+	case <-time.After(time.Second):
+		t.Fatal("store not sampled")
+	}
+	stopped := make(chan struct{})
+	go func() {
+		f.stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("forecaster not stopped")
+	}
+}
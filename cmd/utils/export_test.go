@@ -197,3 +197,47 @@ func TestImportFutureFormat(t *testing.T) {
 		t.Fatalf("wrong error: %v", err)
 	}
 }
+
+// Tests that the migration backup holds the entries under the migrated prefixes
+// once, and restores them with the regular import.
+func TestExportMigrationBackup(t *testing.T) {
+	var (
+		f  = fmt.Sprintf("%v/migrationbackup.gz", t.TempDir())
+		db = rawdb.NewMemoryDatabase()
+	)
+	for _, key := range []string{"xa1", "xa2", "xb1", "y1", "z1"} {
+		db.Put([]byte(key), []byte("v-"+key))
+	}
+	pending := []rawdb.SchemaMigration{
+		{Version: 1, Prefixes: [][]byte{[]byte("xa")}},
+		{Version: 2, Prefixes: [][]byte{[]byte("x"), []byte("y")}},
+		{Version: 3, Prefixes: [][]byte{[]byte("y")}},
+	}
+	if err := ExportMigrationBackup(db, f, pending); err != nil {
+		t.Fatalf("failed to export backup: %v", err)
+	}
+	restored := rawdb.NewMemoryDatabase()
+	if err := ImportLDBData(restored, f, 0, make(chan struct{})); err != nil {
+		t.Fatalf("failed to import backup: %v", err)
+	}
+	it := restored.NewIterator(nil, nil)
+	defer it.Release()
+
+	var keys []string
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+		if string(it.Value()) != "v-"+string(it.Key()) {
+			t.Errorf("value mismatch for %s: %s", it.Key(), it.Value())
+		}
+	}
+	if strings.Join(keys, ",") != "xa1,xa2,xb1,y1" {
+		t.Errorf("restored keys mismatch: %v", keys)
+	}
+	// No backup is written if the migrations rewrite nothing
+	if err := ExportMigrationBackup(db, f+".none", []rawdb.SchemaMigration{{Version: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(f + ".none"); !os.IsNotExist(err) {
+		t.Errorf("empty backup written")
+	}
+}
@@ -36,10 +36,15 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/blskeystore"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/kms"
+	"github.com/ethereum/go-ethereum/autocompound"
 	bparams "github.com/ethereum/go-ethereum/beacon/params"
+	"github.com/ethereum/go-ethereum/bridgewatch"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/ethereum/go-ethereum/consensus/turbo"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
@@ -56,9 +61,12 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/remotedb"
 	"github.com/ethereum/go-ethereum/ethstats"
+	"github.com/ethereum/go-ethereum/eventstream"
 	"github.com/ethereum/go-ethereum/graphql"
+	"github.com/ethereum/go-ethereum/grpcapi"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/internal/otel"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/metrics/exp"
@@ -74,6 +82,7 @@ import (
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/ethereum/go-ethereum/triedb/hashdb"
 	"github.com/ethereum/go-ethereum/triedb/pathdb"
+	"github.com/ethereum/go-ethereum/verifier"
 	pcsclite "github.com/gballet/go-libpcsclite"
 	gopsutil "github.com/shirou/gopsutil/mem"
 	"github.com/urfave/cli/v2"
@@ -105,6 +114,27 @@ var (
 		Value:    node.DefaultConfig.DBEngine,
 		Category: flags.EthCategory,
 	}
+	DBProfileFlag = &cli.StringFlag{
+		Name:     "db.profile",
+		Usage:    "Database tuning profile matching the node role ('validator', 'archive' or 'rpc')",
+		Category: flags.EthCategory,
+	}
+	DBVerifyFlag = &cli.StringFlag{
+		Name:     "db.verify",
+		Usage:    "Consistency check of the chain database at startup ('off', 'fast' or 'full')",
+		Value:    ethconfig.Defaults.DatabaseVerify,
+		Category: flags.EthCategory,
+	}
+	DBMigrationDryRunFlag = &cli.BoolFlag{
+		Name:     "db.migration.dryrun",
+		Usage:    "Report the pending schema migrations of the chain database without applying them or starting",
+		Category: flags.EthCategory,
+	}
+	DBMigrationBackupFlag = &cli.StringFlag{
+		Name:     "db.migration.backup",
+		Usage:    "File to export the entries rewritten by the pending schema migrations into before applying them, restorable with 'geth db import'",
+		Category: flags.EthCategory,
+	}
 	AncientFlag = &flags.DirectoryFlag{
 		Name:     "datadir.ancient",
 		Usage:    "Root directory for ancient data (default = inside chaindata)",
@@ -115,11 +145,38 @@ var (
 		Usage:    "Minimum free disk space in MB, once reached triggers auto shut down (default = --cache.gc converted to MB, 0 = disabled)",
 		Category: flags.EthCategory,
 	}
+	DiskForecastFlag = &cli.BoolFlag{
+		Name:     "datadir.forecast",
+		Usage:    "Track the growth of the chain stores and project the days left until the disk is full",
+		Category: flags.EthCategory,
+	}
+	DiskForecastWindowFlag = &cli.DurationFlag{
+		Name:     "datadir.forecast.window",
+		Usage:    "Time span the growth rate of the chain stores is derived over",
+		Value:    24 * time.Hour,
+		Category: flags.EthCategory,
+	}
+	DiskForecastAlarmFlag = &cli.Float64Flag{
+		Name:     "datadir.forecast.alarm",
+		Usage:    "Projected days until the disk is full raising an alarm (0 = disabled)",
+		Value:    14,
+		Category: flags.EthCategory,
+	}
+	DiskForecastWebhookFlag = &cli.StringFlag{
+		Name:     "datadir.forecast.webhook",
+		Usage:    "URL the disk usage alarms are posted to as JSON",
+		Category: flags.EthCategory,
+	}
 	KeyStoreDirFlag = &flags.DirectoryFlag{
 		Name:     "keystore",
 		Usage:    "Directory for the keystore (default = inside the datadir)",
 		Category: flags.AccountCategory,
 	}
+	BLSKeyStoreDirFlag = &flags.DirectoryFlag{
+		Name:     "blskeystore",
+		Usage:    "Directory for the encrypted BLS attestation keys (default = inside the datadir)",
+		Category: flags.AccountCategory,
+	}
 	USBFlag = &cli.BoolFlag{
 		Name:     "usb",
 		Usage:    "Enable monitoring and management of USB hardware wallets",
@@ -233,6 +290,11 @@ var (
 		Value:    2048,
 		Category: flags.EthCategory,
 	}
+	PruneMaxDurationFlag = &cli.DurationFlag{
+		Name:     "prune.max-duration",
+		Usage:    "Maximum duration of a pruning run, an unfinished pruning resumes on the next run (0 = unlimited)",
+		Category: flags.EthCategory,
+	}
 	OverrideCancun = &cli.Uint64Flag{
 		Name:     "override.cancun",
 		Usage:    "Manually specify the Cancun fork timestamp, overriding the bundled setting",
@@ -272,6 +334,32 @@ var (
 		Value:    ethconfig.Defaults.TransactionHistory,
 		Category: flags.StateCategory,
 	}
+	ReceiptDedupFlag = &cli.BoolFlag{
+		Name:     "history.receiptdedup",
+		Usage:    "Store large receipt log data deduplicated, reducing the disk usage of repeated system transaction payloads",
+		Category: flags.StateCategory,
+	}
+	TokenTransferIndexFlag = &cli.BoolFlag{
+		Name:     "history.tokentransfers",
+		Usage:    "Index the ERC-20/721/1155 token transfers of every address, served by nero_getTokenTransfers",
+		Category: flags.StateCategory,
+	}
+	BloomSectionSizeFlag = &cli.Uint64Flag{
+		Name:     "history.bloomsection",
+		Usage:    "Number of blocks of the log index (bloombits) sections, a multiple of 8; changing it rebuilds the index in the background",
+		Value:    ethconfig.Defaults.BloomSectionSize,
+		Category: flags.StateCategory,
+	}
+	BloomRebuildFlag = &cli.BoolFlag{
+		Name:     "history.bloomrebuild",
+		Usage:    "Rebuild the log index (bloombits) in the background on startup, e.g. after importing or pruning the chain offline",
+		Category: flags.StateCategory,
+	}
+	StateExpiryResearchFlag = &cli.Uint64Flag{
+		Name:     "state.expiryresearch",
+		Usage:    "Experimental: record the last access of the state in epochs of the given number of blocks, reported by debug_getStateExpiryReport (0 = disabled)",
+		Category: flags.StateCategory,
+	}
 	// Beacon client light sync settings
 	BeaconApiFlag = &cli.StringSliceFlag{
 		Name:     "beacon.api",
@@ -427,6 +515,11 @@ var (
 		Value:    15,
 		Category: flags.PerfCategory,
 	}
+	CacheTrieRPCShareFlag = &cli.IntFlag{
+		Name:     "cache.trie.rpcshare",
+		Usage:    "Percentage of the trie cache reserved to the RPC state reads, so that they don't evict the nodes of the block import (hash scheme only, 0 = shared)",
+		Category: flags.PerfCategory,
+	}
 	CacheGCFlag = &cli.IntFlag{
 		Name:     "cache.gc",
 		Usage:    "Percentage of cache memory allowance to use for trie pruning (default = 25% full mode, 0% archive mode)",
@@ -507,6 +600,18 @@ var (
 		Value:    ethconfig.Defaults.Miner.Recommit,
 		Category: flags.MinerCategory,
 	}
+	MinerBuildLogFlag = &cli.IntFlag{
+		Name:     "miner.buildlog",
+		Usage:    "Number of sealed blocks whose transaction inclusion decisions are retained for debug_getBlockBuildLog (0 = disabled)",
+		Value:    ethconfig.Defaults.Miner.BuildLogBlocks,
+		Category: flags.MinerCategory,
+	}
+	MinerShadowFlag = &cli.IntFlag{
+		Name:     "miner.shadow",
+		Usage:    "Number of slots whose blocks are assembled without sealing while not mining, retained for debug_getShadowBlocks (0 = disabled)",
+		Value:    ethconfig.Defaults.Miner.ShadowBlocks,
+		Category: flags.MinerCategory,
+	}
 	MinerPendingFeeRecipientFlag = &cli.StringFlag{
 		Name:     "miner.pending.feeRecipient",
 		Usage:    "0x prefixed public address for the pending block producer (not used for actual block production)",
@@ -532,6 +637,17 @@ var (
 		Value:    "",
 		Category: flags.AccountCategory,
 	}
+	KMSKeysFlag = &cli.StringSliceFlag{
+		Name:     "signer.kms",
+		Usage:    "Keys held by key management services to sign with (awskms://<region>/<key id> or gcpkms://<key version name>)",
+		Category: flags.AccountCategory,
+	}
+	KMSTimeoutFlag = &cli.DurationFlag{
+		Name:     "signer.kms.timeout",
+		Usage:    "Latency budget of the key management service requests",
+		Value:    kms.DefaultTimeout,
+		Category: flags.AccountCategory,
+	}
 	InsecureUnlockAllowedFlag = &cli.BoolFlag{
 		Name:     "allow-insecure-unlock",
 		Usage:    "Allow insecure account unlocking when account-related RPCs are exposed by http",
@@ -567,6 +683,36 @@ var (
 		Value:    ethconfig.Defaults.RPCEVMTimeout,
 		Category: flags.APICategory,
 	}
+	RPCGlobalEVMMemoryFlag = &cli.Uint64Flag{
+		Name:     "rpc.evmmemory",
+		Usage:    "Sets a cap on the EVM memory (in bytes) of eth_call, eth_estimateGas and the traces (0 = no cap)",
+		Value:    ethconfig.Defaults.RPCEVMMemoryLimit,
+		Category: flags.APICategory,
+	}
+	RPCGlobalTraceTimeoutFlag = &cli.DurationFlag{
+		Name:     "rpc.tracetimeout",
+		Usage:    "Sets a cap on the timeout requested by the traces (0 = no cap)",
+		Value:    ethconfig.Defaults.RPCTraceTimeout,
+		Category: flags.APICategory,
+	}
+	RPCCallBreakerFlag = &cli.IntFlag{
+		Name:     "rpc.callbreaker",
+		Usage:    "Number of consecutive calls to a contract hitting the execution limits after which the calls to it are refused (0 = disabled)",
+		Value:    ethconfig.Defaults.RPCCallBreakerTrips,
+		Category: flags.APICategory,
+	}
+	RPCCallBreakerCooldownFlag = &cli.DurationFlag{
+		Name:     "rpc.callbreaker.cooldown",
+		Usage:    "Time the calls to a contract are refused once its breaker tripped",
+		Value:    ethconfig.Defaults.RPCCallBreakerCooldown,
+		Category: flags.APICategory,
+	}
+	RPCMaxLogsFlag = &cli.IntFlag{
+		Name:     "rpc.maxlogs",
+		Usage:    "Maximum number of logs returned by eth_getLogs, the larger results being paged with nero_getLogsPaged (0 = no limit)",
+		Value:    ethconfig.Defaults.FilterMaxLogs,
+		Category: flags.APICategory,
+	}
 	RPCGlobalTxFeeCapFlag = &cli.Float64Flag{
 		Name:     "rpc.txfeecap",
 		Usage:    "Sets a cap on transaction fee (in ether) that can be sent via the RPC APIs (0 = no cap)",
@@ -604,11 +750,172 @@ var (
 		Usage:    "Reporting URL of a ethstats service (nodename:secret@host:port)",
 		Category: flags.MetricsCategory,
 	}
+	EventStreamURLFlag = &cli.StringFlag{
+		Name:     "eventstream.url",
+		Usage:    "Broker URL to publish the chain events to (nats://[user:pass@]host:port or kafka+http(s)://rest-proxy:port)",
+		Category: flags.APICategory,
+	}
+	EventStreamPrefixFlag = &cli.StringFlag{
+		Name:     "eventstream.prefix",
+		Usage:    "Prefix of the topics the chain events are published to",
+		Value:    eventstream.DefaultPrefix,
+		Category: flags.APICategory,
+	}
+	EventStreamReplayFlag = &cli.Uint64Flag{
+		Name:     "eventstream.replay",
+		Usage:    "Block number to publish the chain events from, overriding the stored cursor",
+		Category: flags.APICategory,
+	}
+	AutoCompoundDelegationsFlag = &cli.StringFlag{
+		Name:     "autocompound.delegations",
+		Usage:    "Comma separated delegator:validator pairs whose rewards are periodically claimed and restaked (delegators must be unlocked keystore accounts)",
+		Category: flags.AccountCategory,
+	}
+	AutoCompoundIntervalFlag = &cli.DurationFlag{
+		Name:     "autocompound.interval",
+		Usage:    "Time between two delegation reward compounding rounds",
+		Value:    autocompound.DefaultInterval,
+		Category: flags.AccountCategory,
+	}
+	AutoCompoundMinRewardsFlag = &flags.BigFlag{
+		Name:     "autocompound.minrewards",
+		Usage:    "Minimum delegation rewards worth compounding (wei)",
+		Category: flags.AccountCategory,
+	}
+	AutoCompoundGasCapFlag = &cli.Uint64Flag{
+		Name:     "autocompound.gascap",
+		Usage:    "Maximum gas of a reward compounding transaction",
+		Value:    autocompound.DefaultGasCap,
+		Category: flags.AccountCategory,
+	}
+	AutoCompoundDryRunFlag = &cli.BoolFlag{
+		Name:     "autocompound.dryrun",
+		Usage:    "Only log the reward compounding transactions instead of sending them",
+		Category: flags.AccountCategory,
+	}
+	BridgeContractsFlag = &cli.StringFlag{
+		Name:     "bridge.contracts",
+		Usage:    "Comma separated bridge contracts whose lock and unlock events are watched and attested",
+		Category: flags.APICategory,
+	}
+	BridgeLockEventsFlag = &cli.StringFlag{
+		Name:     "bridge.lockevents",
+		Usage:    "Semicolon separated signatures (or topics) of the bridge lock events, e.g. Locked(address,uint256)",
+		Category: flags.APICategory,
+	}
+	BridgeUnlockEventsFlag = &cli.StringFlag{
+		Name:     "bridge.unlockevents",
+		Usage:    "Semicolon separated signatures (or topics) of the bridge unlock events",
+		Category: flags.APICategory,
+	}
+	BridgeSignerFlag = &cli.StringFlag{
+		Name:     "bridge.signer",
+		Usage:    "Keystore account signing the bridge event attestations (must be unlocked)",
+		Category: flags.APICategory,
+	}
+	BridgeFromFlag = &cli.Uint64Flag{
+		Name:     "bridge.from",
+		Usage:    "Block number to watch the bridge events from, overriding the stored cursor",
+		Category: flags.APICategory,
+	}
+	VerifierSolcFlag = &flags.DirectoryFlag{
+		Name:     "verifier.solc",
+		Usage:    "Path of the solc executable, or of a directory of solc-<version> executables, enabling the contract verification API",
+		Category: flags.APICategory,
+	}
 	NoCompactionFlag = &cli.BoolFlag{
 		Name:     "nocompaction",
 		Usage:    "Disables db compaction after import",
 		Category: flags.LoggingCategory,
 	}
+	BadBlockDirFlag = &flags.DirectoryFlag{
+		Name:     "badblock.dir",
+		Usage:    "Directory to capture forensic bundles of rejected blocks into (default = inside the datadir)",
+		Category: flags.LoggingCategory,
+	}
+	WatchdogFlag = &cli.BoolFlag{
+		Name:     "watchdog",
+		Usage:    "Capture CPU/heap/goroutine profiles and the consensus state when the chain head stalls",
+		Category: flags.LoggingCategory,
+	}
+	WatchdogPeriodsFlag = &cli.Uint64Flag{
+		Name:     "watchdog.periods",
+		Usage:    "Number of block periods without head advance considered a stall",
+		Value:    ethconfig.Defaults.Watchdog.Periods,
+		Category: flags.LoggingCategory,
+	}
+	WatchdogCPUProfileFlag = &cli.DurationFlag{
+		Name:     "watchdog.cpuprofile",
+		Usage:    "Duration of the CPU profile captured on a stall (0 = disabled)",
+		Value:    ethconfig.Defaults.Watchdog.CPUProfile,
+		Category: flags.LoggingCategory,
+	}
+	WatchdogRetainFlag = &cli.IntFlag{
+		Name:     "watchdog.retain",
+		Usage:    "Number of stall diagnostics captures kept on disk",
+		Value:    ethconfig.Defaults.Watchdog.Retain,
+		Category: flags.LoggingCategory,
+	}
+
+	// Header timestamp drift settings
+	ClockMaxDriftFlag = &cli.DurationFlag{
+		Name:     "clock.maxdrift",
+		Usage:    "Time the block headers may be stamped ahead of the local clock (turbo chains)",
+		Value:    ethconfig.Defaults.Clock.MaxDrift,
+		Category: flags.EthCategory,
+	}
+	ClockFutureWindowFlag = &cli.DurationFlag{
+		Name:     "clock.futurewindow",
+		Usage:    "Time beyond the max drift the future blocks are queued for later import (turbo chains)",
+		Value:    ethconfig.Defaults.Clock.FutureWindow,
+		Category: flags.EthCategory,
+	}
+	ClockNTPServerFlag = &cli.StringFlag{
+		Name:     "clock.ntp",
+		Usage:    "NTP server the local clock is periodically checked against, reporting the drift via metrics (empty = disabled)",
+		Value:    ethconfig.Defaults.Clock.NTPServer,
+		Category: flags.EthCategory,
+	}
+	ClockNTPIntervalFlag = &cli.DurationFlag{
+		Name:     "clock.ntp.interval",
+		Usage:    "Time between two NTP checks of the local clock",
+		Value:    ethconfig.Defaults.Clock.NTPInterval,
+		Category: flags.EthCategory,
+	}
+
+	// Validator failover settings
+	FailoverPeerFlag = &cli.StringFlag{
+		Name:     "failover.peer",
+		Usage:    "RPC endpoint of the failover peer running the same validator, enabling the active-passive failover (turbo chains)",
+		Category: flags.MinerCategory,
+	}
+	FailoverStandbyFlag = &cli.BoolFlag{
+		Name:     "failover.standby",
+		Usage:    "Start as the passive node of the failover pair, sealing only once the peer hands over or expires",
+		Category: flags.MinerCategory,
+	}
+	FailoverHeartbeatFlag = &cli.DurationFlag{
+		Name:     "failover.heartbeat",
+		Usage:    "Time between two heartbeats polled from the failover peer",
+		Value:    ethconfig.Defaults.Failover.Heartbeat,
+		Category: flags.MinerCategory,
+	}
+	FailoverExpiryFlag = &cli.DurationFlag{
+		Name:     "failover.expiry",
+		Usage:    "Time without sign of life of the active failover peer before the passive node takes the sealing over",
+		Value:    ethconfig.Defaults.Failover.Expiry,
+		Category: flags.MinerCategory,
+	}
+	MinerNoIOThrottleFlag = &cli.BoolFlag{
+		Name:     "miner.noiothrottle",
+		Usage:    "Keep the snapshot generation, compactions and pruning at full speed while the validator is in turn (turbo chains)",
+		Category: flags.MinerCategory,
+	}
+	MinerHaltBlockFlag = &cli.Uint64Flag{
+		Name:     "miner.haltblock",
+		Usage:    "Block number above which the validator stops sealing, overriding the governed emergency halt (0 = ignore the governed halt)",
+		Category: flags.MinerCategory,
+	}
 
 	// MISC settings
 	SyncTargetFlag = &cli.StringFlag{
@@ -629,6 +936,11 @@ var (
 		Usage:    "Filename for IPC socket/pipe within the datadir (explicit paths escape it)",
 		Category: flags.APICategory,
 	}
+	AdminIPCPathFlag = &flags.DirectoryFlag{
+		Name:     "ipc.admin",
+		Usage:    "Filename for the admin command socket within the datadir, the only endpoint serving the privileged methods with audit logging (explicit paths escape it)",
+		Category: flags.APICategory,
+	}
 	HTTPEnabledFlag = &cli.BoolFlag{
 		Name:     "http",
 		Usage:    "Enable the HTTP-RPC server",
@@ -687,6 +999,23 @@ var (
 		Value:    strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
 		Category: flags.APICategory,
 	}
+	GRPCEnabledFlag = &cli.BoolFlag{
+		Name:     "grpc",
+		Usage:    "Enable the gRPC server",
+		Category: flags.APICategory,
+	}
+	GRPCListenAddrFlag = &cli.StringFlag{
+		Name:     "grpc.addr",
+		Usage:    "gRPC server listening interface",
+		Value:    node.DefaultGRPCHost,
+		Category: flags.APICategory,
+	}
+	GRPCPortFlag = &cli.IntFlag{
+		Name:     "grpc.port",
+		Usage:    "gRPC server listening port",
+		Value:    node.DefaultGRPCPort,
+		Category: flags.APICategory,
+	}
 	WSEnabledFlag = &cli.BoolFlag{
 		Name:     "ws",
 		Usage:    "Enable the WS-RPC server",
@@ -722,6 +1051,11 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	WSCompressionFlag = &cli.BoolFlag{
+		Name:     "ws.compression",
+		Usage:    "Enable permessage-deflate compression of the large WS-RPC messages",
+		Category: flags.APICategory,
+	}
 	ExecFlag = &cli.StringFlag{
 		Name:     "exec",
 		Usage:    "Execute JavaScript statement",
@@ -749,6 +1083,32 @@ var (
 		Value:    node.DefaultConfig.BatchResponseMaxSize,
 		Category: flags.APICategory,
 	}
+	RPCUsageConsumersFlag = &cli.IntFlag{
+		Name:     "rpc.usage.consumers",
+		Usage:    "Number of RPC consumers to account request usage for (0 = disabled)",
+		Value:    node.DefaultConfig.RPCUsageConsumers,
+		Category: flags.APICategory,
+	}
+	RPCUsageHeaderFlag = &cli.StringFlag{
+		Name:     "rpc.usage.header",
+		Usage:    "HTTP header identifying RPC consumers for usage accounting (default = remote IP)",
+		Category: flags.APICategory,
+	}
+	RPCAuthJWTSecretFlag = &flags.DirectoryFlag{
+		Name:     "rpc.auth.jwtsecret",
+		Usage:    "Path to a JWT secret authenticating the HTTP, WebSocket and GraphQL callers with bearer tokens",
+		Category: flags.APICategory,
+	}
+	RPCAuthAPIKeysFlag = &flags.DirectoryFlag{
+		Name:     "rpc.auth.apikeys",
+		Usage:    "Path to a file of <name>:<key> API keys authenticating the HTTP, WebSocket and GraphQL callers",
+		Category: flags.APICategory,
+	}
+	RPCAuthPublicFlag = &cli.StringFlag{
+		Name:     "rpc.auth.public",
+		Usage:    "Comma separated API namespaces available without authentication (\"graphql\" for the GraphQL endpoint)",
+		Category: flags.APICategory,
+	}
 	EnablePersonal = &cli.BoolFlag{
 		Name:     "rpc.enabledeprecatedpersonal",
 		Usage:    "Enables the (deprecated) personal namespace",
@@ -870,6 +1230,11 @@ var (
 		Value:    ethconfig.Defaults.GPO.IgnorePrice.Int64(),
 		Category: flags.GasPriceCategory,
 	}
+	GpoStrategyFlag = &cli.StringFlag{
+		Name:     "gpo.strategy",
+		Usage:    `Strategy of the suggested priority fees ("sample" or "posa", defaults to "posa" on turbo chains)`,
+		Category: flags.GasPriceCategory,
+	}
 
 	// Metrics flags
 	MetricsEnabledFlag = &cli.BoolFlag{
@@ -960,11 +1325,75 @@ Please note that --` + MetricsHTTPFlag.Name + ` must be set to start the server.
 		Category: flags.MetricsCategory,
 	}
 
+	// OpenTelemetry span tracing settings
+	OTelEnabledFlag = &cli.BoolFlag{
+		Name:     "otel",
+		Usage:    "Enable OpenTelemetry tracing of block import, block building and RPC requests",
+		Category: flags.MetricsCategory,
+	}
+	OTelEndpointFlag = &cli.StringFlag{
+		Name:     "otel.endpoint",
+		Usage:    "OTLP/HTTP traces endpoint of the OpenTelemetry collector",
+		Value:    otel.DefaultConfig.Endpoint,
+		Category: flags.MetricsCategory,
+	}
+	OTelServiceFlag = &cli.StringFlag{
+		Name:     "otel.service",
+		Usage:    "Service name reported with the exported spans",
+		Value:    otel.DefaultConfig.ServiceName,
+		Category: flags.MetricsCategory,
+	}
+	OTelSampleFlag = &cli.Float64Flag{
+		Name:     "otel.sample",
+		Usage:    "Fraction of the traces to record (0-1)",
+		Value:    otel.DefaultConfig.SampleRatio,
+		Category: flags.MetricsCategory,
+	}
+	OTelHeadersFlag = &cli.StringFlag{
+		Name:     "otel.headers",
+		Usage:    "Comma-separated headers (key=value) sent to the collector, e.g. for authentication",
+		Category: flags.MetricsCategory,
+	}
+
 	// TraceActionFlag is the flag for internal tx
 	TraceActionFlag = &cli.IntFlag{
 		Name:  "traceaction",
 		Usage: "Trace internal tx call/create/suicide action, 0=no trace, 1=trace only native token > 0, 2=trace all",
 	}
+	// TraceArchiveFlag is the flag for the archive of the internal txs
+	TraceArchiveFlag = &cli.BoolFlag{
+		Name:  "traceaction.archive",
+		Usage: "Store the full internal tx actions of all blocks with dictionary compression (implies --traceaction=2)",
+	}
+	TxRulePluginsFlag = &cli.StringFlag{
+		Name:     "txrule.plugins",
+		Usage:    "Comma separated Go plugins registering the transaction rules enabled by the chain config",
+		Category: flags.EthCategory,
+	}
+	LocalAccessListFlag = &cli.StringFlag{
+		Name:     "accessfilter.local",
+		Usage:    "JSON file of addresses denied or allowed by the local node's txpool and RPC on top of the on-chain access filter (reloaded on change, never used by consensus)",
+		Category: flags.EthCategory,
+	}
+
+	// Finalized state checkpoint settings
+	CheckpointServeFlag = &cli.BoolFlag{
+		Name:     "checkpoint.serve",
+		Usage:    "Build and serve finalized state checkpoints over HTTP (requires --http)",
+		Category: flags.StateCategory,
+	}
+	CheckpointIntervalFlag = &cli.Uint64Flag{
+		Name:     "checkpoint.interval",
+		Usage:    "Minimum number of blocks between two served state checkpoints",
+		Value:    ethconfig.Defaults.Checkpoint.Interval,
+		Category: flags.StateCategory,
+	}
+	CheckpointRetainFlag = &cli.IntFlag{
+		Name:     "checkpoint.retain",
+		Usage:    "Number of served state checkpoints kept on disk",
+		Value:    ethconfig.Defaults.Checkpoint.Retain,
+		Category: flags.StateCategory,
+	}
 )
 
 var (
@@ -981,6 +1410,7 @@ var (
 		AncientFlag,
 		RemoteDBFlag,
 		DBEngineFlag,
+		DBProfileFlag,
 		StateSchemeFlag,
 		HttpHeaderFlag,
 	}
@@ -1182,6 +1612,26 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(BatchResponseMaxSize.Name) {
 		cfg.BatchResponseMaxSize = ctx.Int(BatchResponseMaxSize.Name)
 	}
+
+	if ctx.IsSet(RPCUsageConsumersFlag.Name) {
+		cfg.RPCUsageConsumers = ctx.Int(RPCUsageConsumersFlag.Name)
+	}
+
+	if ctx.IsSet(RPCUsageHeaderFlag.Name) {
+		cfg.RPCUsageHeader = ctx.String(RPCUsageHeaderFlag.Name)
+	}
+
+	if ctx.IsSet(RPCAuthJWTSecretFlag.Name) {
+		cfg.RPCAuthJWTSecret = ctx.String(RPCAuthJWTSecretFlag.Name)
+	}
+
+	if ctx.IsSet(RPCAuthAPIKeysFlag.Name) {
+		cfg.RPCAuthAPIKeys = ctx.String(RPCAuthAPIKeysFlag.Name)
+	}
+
+	if ctx.IsSet(RPCAuthPublicFlag.Name) {
+		cfg.RPCAuthPublicModules = SplitAndTrim(ctx.String(RPCAuthPublicFlag.Name))
+	}
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1195,6 +1645,22 @@ func setGraphQL(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setGRPC creates the gRPC listener interface string from the set command line
+// flags, returning empty if the gRPC endpoint is disabled.
+func setGRPC(ctx *cli.Context, cfg *node.Config) {
+	if ctx.Bool(GRPCEnabledFlag.Name) {
+		if cfg.GRPCHost == "" {
+			cfg.GRPCHost = "127.0.0.1"
+		}
+		if ctx.IsSet(GRPCListenAddrFlag.Name) {
+			cfg.GRPCHost = ctx.String(GRPCListenAddrFlag.Name)
+		}
+	}
+	if ctx.IsSet(GRPCPortFlag.Name) {
+		cfg.GRPCPort = ctx.Int(GRPCPortFlag.Name)
+	}
+}
+
 // setWS creates the WebSocket RPC listener interface string from the set
 // command line flags, returning empty if the HTTP endpoint is disabled.
 func setWS(ctx *cli.Context, cfg *node.Config) {
@@ -1221,6 +1687,10 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(WSPathPrefixFlag.Name) {
 		cfg.WSPathPrefix = ctx.String(WSPathPrefixFlag.Name)
 	}
+
+	if ctx.IsSet(WSCompressionFlag.Name) {
+		cfg.WSCompression = ctx.Bool(WSCompressionFlag.Name)
+	}
 }
 
 // setIPC creates an IPC path configuration from the set command line flags,
@@ -1233,6 +1703,9 @@ func setIPC(ctx *cli.Context, cfg *node.Config) {
 	case ctx.IsSet(IPCPathFlag.Name):
 		cfg.IPCPath = ctx.String(IPCPathFlag.Name)
 	}
+	if ctx.IsSet(AdminIPCPathFlag.Name) {
+		cfg.AdminIPCPath = ctx.String(AdminIPCPathFlag.Name)
+	}
 }
 
 // setLes shows the deprecation warnings for LES flags.
@@ -1257,6 +1730,78 @@ func setLes(ctx *cli.Context, cfg *ethconfig.Config) {
 	}
 }
 
+// cacheShares are the percentages of the cache allowance given to the database,
+// the trie clean cache, the trie dirty cache and the snapshot cache.
+type cacheShares struct {
+	database int
+	trie     int
+	gc       int
+	snapshot int
+}
+
+// makeCacheShares retrieves the shares of the cache allowance. The database
+// share is taken from the database tuning profile unless it's configured
+// explicitly, the other shares not configured explicitly being scaled down to
+// keep the total within the allowance.
+func makeCacheShares(ctx *cli.Context) cacheShares {
+	shares := cacheShares{
+		database: ctx.Int(CacheDatabaseFlag.Name),
+		trie:     ctx.Int(CacheTrieFlag.Name),
+		gc:       ctx.Int(CacheGCFlag.Name),
+		snapshot: ctx.Int(CacheSnapshotFlag.Name),
+	}
+	if !ctx.IsSet(DBProfileFlag.Name) || ctx.IsSet(CacheDatabaseFlag.Name) {
+		return shares
+	}
+	profile, err := rawdb.LookupDatabaseProfile(ctx.String(DBProfileFlag.Name))
+	if err != nil || profile.CacheShare == 0 {
+		return shares
+	}
+	shares.database = profile.CacheShare
+	if err := shares.fit(ctx.IsSet(CacheTrieFlag.Name), ctx.IsSet(CacheGCFlag.Name), ctx.IsSet(CacheSnapshotFlag.Name)); err != nil {
+		Fatalf("Invalid cache shares for db.profile %s: %v", profile.Name, err)
+	}
+	return shares
+}
+
+// fit scales down the trie, gc and snapshot shares not configured explicitly
+// so that the shares add up to at most 100%. It fails if the database share
+// and the explicit shares leave no room for the others.
+func (s *cacheShares) fit(trie, gc, snapshot bool) error {
+	var (
+		shares   = []*int{&s.trie, &s.gc, &s.snapshot}
+		explicit = []bool{trie, gc, snapshot}
+		fixed    = s.database
+		free     int
+	)
+	for i, share := range shares {
+		if explicit[i] {
+			fixed += *share
+		} else {
+			free += *share
+		}
+	}
+	if fixed+free <= 100 {
+		return nil
+	}
+	if fixed >= 100 {
+		return fmt.Errorf("database and explicit cache shares add up to %d%%, lower --%s, --%s or --%s", fixed, CacheTrieFlag.Name, CacheGCFlag.Name, CacheSnapshotFlag.Name)
+	}
+	for i, share := range shares {
+		if !explicit[i] {
+			*share = *share * (100 - fixed) / free
+		}
+	}
+	return nil
+}
+
+// MakeDatabaseCache retrieves the cache allowance of the database in megabytes.
+// The share of the total cache is taken from the database tuning profile, unless
+// it's configured explicitly.
+func MakeDatabaseCache(ctx *cli.Context) int {
+	return ctx.Int(CacheFlag.Name) * makeCacheShares(ctx).database / 100
+}
+
 // MakeDatabaseHandles raises out the number of allowed file handles per process
 // for Geth and returns half of the allowance to assign to the database.
 func MakeDatabaseHandles(max int) int {
@@ -1398,6 +1943,7 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	setIPC(ctx, cfg)
 	setHTTP(ctx, cfg)
 	setGraphQL(ctx, cfg)
+	setGRPC(ctx, cfg)
 	setWS(ctx, cfg)
 	setNodeUserIdent(ctx, cfg)
 	SetDataDir(ctx, cfg)
@@ -1415,6 +1961,12 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 		cfg.ExternalSigner = ctx.String(ExternalSignerFlag.Name)
 	}
 
+	if ctx.IsSet(KMSKeysFlag.Name) {
+		cfg.KMSKeys = ctx.StringSlice(KMSKeysFlag.Name)
+	}
+	if ctx.IsSet(KMSTimeoutFlag.Name) {
+		cfg.KMSTimeout = ctx.Duration(KMSTimeoutFlag.Name)
+	}
 	if ctx.IsSet(KeyStoreDirFlag.Name) {
 		cfg.KeyStoreDir = ctx.String(KeyStoreDirFlag.Name)
 	}
@@ -1441,6 +1993,14 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 		log.Info(fmt.Sprintf("Using %s as db engine", dbEngine))
 		cfg.DBEngine = dbEngine
 	}
+	if ctx.IsSet(DBProfileFlag.Name) {
+		profile, err := rawdb.LookupDatabaseProfile(ctx.String(DBProfileFlag.Name))
+		if err != nil {
+			Fatalf("%v", err)
+		}
+		log.Info("Using database tuning profile", "profile", profile.Name, "description", profile.Description)
+		cfg.DBProfile = profile.Name
+	}
 	// deprecation notice for log debug flags (TODO: find a more appropriate place to put these?)
 	if ctx.IsSet(LogBacktraceAtFlag.Name) {
 		log.Warn("log.backtrace flag is deprecated")
@@ -1494,6 +2054,9 @@ func setGPO(ctx *cli.Context, cfg *gasprice.Config) {
 	if ctx.IsSet(GpoIgnoreGasPriceFlag.Name) {
 		cfg.IgnorePrice = big.NewInt(ctx.Int64(GpoIgnoreGasPriceFlag.Name))
 	}
+	if ctx.IsSet(GpoStrategyFlag.Name) {
+		cfg.Strategy = ctx.String(GpoStrategyFlag.Name)
+	}
 }
 
 func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
@@ -1552,6 +2115,12 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 	if ctx.IsSet(MinerRecommitIntervalFlag.Name) {
 		cfg.Recommit = ctx.Duration(MinerRecommitIntervalFlag.Name)
 	}
+	if ctx.IsSet(MinerBuildLogFlag.Name) {
+		cfg.BuildLogBlocks = ctx.Int(MinerBuildLogFlag.Name)
+	}
+	if ctx.IsSet(MinerShadowFlag.Name) {
+		cfg.ShadowBlocks = ctx.Int(MinerShadowFlag.Name)
+	}
 	if ctx.IsSet(MinerNewPayloadTimeoutFlag.Name) {
 		log.Warn("The flag --miner.newpayload-timeout is deprecated and will be removed, please use --miner.recommit")
 		cfg.Recommit = ctx.Duration(MinerNewPayloadTimeoutFlag.Name)
@@ -1636,6 +2205,64 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(TraceActionFlag.Name) {
 		cfg.TraceAction = ctx.Int(TraceActionFlag.Name)
 	}
+	if ctx.IsSet(TraceArchiveFlag.Name) {
+		cfg.TraceArchive = ctx.Bool(TraceArchiveFlag.Name)
+	}
+	if ctx.IsSet(CheckpointServeFlag.Name) {
+		cfg.Checkpoint.Enabled = ctx.Bool(CheckpointServeFlag.Name)
+	}
+	if ctx.IsSet(CheckpointIntervalFlag.Name) {
+		cfg.Checkpoint.Interval = ctx.Uint64(CheckpointIntervalFlag.Name)
+	}
+	if ctx.IsSet(CheckpointRetainFlag.Name) {
+		cfg.Checkpoint.Retain = ctx.Int(CheckpointRetainFlag.Name)
+	}
+	if ctx.IsSet(WatchdogFlag.Name) {
+		cfg.Watchdog.Enabled = ctx.Bool(WatchdogFlag.Name)
+	}
+	if ctx.IsSet(WatchdogPeriodsFlag.Name) {
+		cfg.Watchdog.Periods = ctx.Uint64(WatchdogPeriodsFlag.Name)
+	}
+	if ctx.IsSet(WatchdogCPUProfileFlag.Name) {
+		cfg.Watchdog.CPUProfile = ctx.Duration(WatchdogCPUProfileFlag.Name)
+	}
+	if ctx.IsSet(WatchdogRetainFlag.Name) {
+		cfg.Watchdog.Retain = ctx.Int(WatchdogRetainFlag.Name)
+	}
+	if ctx.IsSet(ClockMaxDriftFlag.Name) {
+		cfg.Clock.MaxDrift = ctx.Duration(ClockMaxDriftFlag.Name)
+	}
+	if ctx.IsSet(ClockFutureWindowFlag.Name) {
+		cfg.Clock.FutureWindow = ctx.Duration(ClockFutureWindowFlag.Name)
+	}
+	if ctx.IsSet(ClockNTPServerFlag.Name) {
+		cfg.Clock.NTPServer = ctx.String(ClockNTPServerFlag.Name)
+	}
+	if ctx.IsSet(ClockNTPIntervalFlag.Name) {
+		cfg.Clock.NTPInterval = ctx.Duration(ClockNTPIntervalFlag.Name)
+	}
+	if ctx.IsSet(FailoverPeerFlag.Name) {
+		cfg.Failover.Peer = ctx.String(FailoverPeerFlag.Name)
+	}
+	if ctx.IsSet(FailoverStandbyFlag.Name) {
+		cfg.Failover.Standby = ctx.Bool(FailoverStandbyFlag.Name)
+	}
+	if ctx.IsSet(FailoverHeartbeatFlag.Name) {
+		cfg.Failover.Heartbeat = ctx.Duration(FailoverHeartbeatFlag.Name)
+	}
+	if ctx.IsSet(FailoverExpiryFlag.Name) {
+		cfg.Failover.Expiry = ctx.Duration(FailoverExpiryFlag.Name)
+	}
+	if ctx.IsSet(LocalAccessListFlag.Name) {
+		cfg.LocalAccessList = ctx.String(LocalAccessListFlag.Name)
+	}
+	if ctx.IsSet(MinerNoIOThrottleFlag.Name) {
+		cfg.NoIOThrottle = ctx.Bool(MinerNoIOThrottleFlag.Name)
+	}
+	if ctx.IsSet(MinerHaltBlockFlag.Name) {
+		halt := ctx.Uint64(MinerHaltBlockFlag.Name)
+		cfg.HaltOverride = &halt
+	}
 
 	// Set configurations from CLI flags
 	setEtherbase(ctx, cfg)
@@ -1673,13 +2300,25 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(NetworkIdFlag.Name) {
 		cfg.NetworkId = ctx.Uint64(NetworkIdFlag.Name)
 	}
-	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheDatabaseFlag.Name) {
-		cfg.DatabaseCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheDatabaseFlag.Name) / 100
+	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheDatabaseFlag.Name) || ctx.IsSet(DBProfileFlag.Name) {
+		cfg.DatabaseCache = MakeDatabaseCache(ctx)
 	}
 	cfg.DatabaseHandles = MakeDatabaseHandles(ctx.Int(FDLimitFlag.Name))
 	if ctx.IsSet(AncientFlag.Name) {
 		cfg.DatabaseFreezer = ctx.String(AncientFlag.Name)
 	}
+	if ctx.IsSet(DBVerifyFlag.Name) {
+		cfg.DatabaseVerify = ctx.String(DBVerifyFlag.Name)
+	}
+	if ctx.IsSet(DBMigrationDryRunFlag.Name) {
+		cfg.DatabaseMigrationDryRun = ctx.Bool(DBMigrationDryRunFlag.Name)
+	}
+	if ctx.IsSet(DBMigrationBackupFlag.Name) {
+		file := ctx.String(DBMigrationBackupFlag.Name)
+		cfg.DatabaseMigrationBackup = func(db ethdb.Database, pending []rawdb.SchemaMigration) error {
+			return ExportMigrationBackup(db, file, pending)
+		}
+	}
 
 	if gcmode := ctx.String(GCModeFlag.Name); gcmode != "full" && gcmode != "archive" {
 		Fatalf("--%s must be either 'full' or 'archive'", GCModeFlag.Name)
@@ -1699,6 +2338,24 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(StateHistoryFlag.Name) {
 		cfg.StateHistory = ctx.Uint64(StateHistoryFlag.Name)
 	}
+	if ctx.IsSet(ReceiptDedupFlag.Name) {
+		cfg.ReceiptDedup = ctx.Bool(ReceiptDedupFlag.Name)
+	}
+	if ctx.IsSet(TokenTransferIndexFlag.Name) {
+		cfg.TokenTransferIndex = ctx.Bool(TokenTransferIndexFlag.Name)
+	}
+	if ctx.IsSet(BloomSectionSizeFlag.Name) {
+		cfg.BloomSectionSize = ctx.Uint64(BloomSectionSizeFlag.Name)
+	}
+	if ctx.IsSet(BloomRebuildFlag.Name) {
+		cfg.BloomRebuild = ctx.Bool(BloomRebuildFlag.Name)
+	}
+	if ctx.IsSet(StateExpiryResearchFlag.Name) {
+		cfg.StateExpiryEpoch = ctx.Uint64(StateExpiryResearchFlag.Name)
+	}
+	if ctx.IsSet(BadBlockDirFlag.Name) {
+		cfg.BadBlockDir = ctx.Path(BadBlockDirFlag.Name)
+	}
 	if ctx.IsSet(StateSchemeFlag.Name) {
 		cfg.StateScheme = ctx.String(StateSchemeFlag.Name)
 	}
@@ -1721,14 +2378,18 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		cfg.StateScheme = rawdb.HashScheme
 		log.Warn("Forcing hash state-scheme for archive mode")
 	}
-	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheTrieFlag.Name) {
-		cfg.TrieCleanCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheTrieFlag.Name) / 100
+	shares := makeCacheShares(ctx)
+	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheTrieFlag.Name) || ctx.IsSet(DBProfileFlag.Name) {
+		cfg.TrieCleanCache = ctx.Int(CacheFlag.Name) * shares.trie / 100
+	}
+	if ctx.IsSet(CacheTrieRPCShareFlag.Name) {
+		cfg.TrieCleanRPCShare = ctx.Int(CacheTrieRPCShareFlag.Name)
 	}
-	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheGCFlag.Name) {
-		cfg.TrieDirtyCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheGCFlag.Name) / 100
+	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheGCFlag.Name) || ctx.IsSet(DBProfileFlag.Name) {
+		cfg.TrieDirtyCache = ctx.Int(CacheFlag.Name) * shares.gc / 100
 	}
-	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheSnapshotFlag.Name) {
-		cfg.SnapshotCache = ctx.Int(CacheFlag.Name) * ctx.Int(CacheSnapshotFlag.Name) / 100
+	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheSnapshotFlag.Name) || ctx.IsSet(DBProfileFlag.Name) {
+		cfg.SnapshotCache = ctx.Int(CacheFlag.Name) * shares.snapshot / 100
 	}
 	if ctx.IsSet(CacheLogSizeFlag.Name) {
 		cfg.FilterLogCacheSize = ctx.Int(CacheLogSizeFlag.Name)
@@ -1767,6 +2428,21 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.IsSet(RPCGlobalEVMTimeoutFlag.Name) {
 		cfg.RPCEVMTimeout = ctx.Duration(RPCGlobalEVMTimeoutFlag.Name)
 	}
+	if ctx.IsSet(RPCGlobalEVMMemoryFlag.Name) {
+		cfg.RPCEVMMemoryLimit = ctx.Uint64(RPCGlobalEVMMemoryFlag.Name)
+	}
+	if ctx.IsSet(RPCGlobalTraceTimeoutFlag.Name) {
+		cfg.RPCTraceTimeout = ctx.Duration(RPCGlobalTraceTimeoutFlag.Name)
+	}
+	if ctx.IsSet(RPCCallBreakerFlag.Name) {
+		cfg.RPCCallBreakerTrips = ctx.Int(RPCCallBreakerFlag.Name)
+	}
+	if ctx.IsSet(RPCCallBreakerCooldownFlag.Name) {
+		cfg.RPCCallBreakerCooldown = ctx.Duration(RPCCallBreakerCooldownFlag.Name)
+	}
+	if ctx.IsSet(RPCMaxLogsFlag.Name) {
+		cfg.FilterMaxLogs = ctx.Int(RPCMaxLogsFlag.Name)
+	}
 	if ctx.IsSet(RPCGlobalTxFeeCapFlag.Name) {
 		cfg.RPCTxFeeCap = ctx.Float64(RPCGlobalTxFeeCapFlag.Name)
 	}
@@ -1921,6 +2597,32 @@ func RegisterEthService(stack *node.Node, cfg *ethconfig.Config) (ethapi.Backend
 	return backend.APIBackend, backend
 }
 
+// MakeBLSKeyManager creates the manager of the BLS attestation keys, stored in
+// the directory given by the flag or in the datadir by default. Nil is returned
+// if the node has no datadir.
+func MakeBLSKeyManager(ctx *cli.Context, cfg *node.Config) *blskeystore.Manager {
+	dir := ctx.String(BLSKeyStoreDirFlag.Name)
+	if dir == "" {
+		if cfg.DataDir == "" {
+			return nil
+		}
+		dir = filepath.Join(cfg.DataDir, "blskeystore")
+	}
+	scryptN, scryptP := blskeystore.StandardScryptN, blskeystore.StandardScryptP
+	if cfg.UseLightweightKDF {
+		scryptN, scryptP = blskeystore.LightScryptN, blskeystore.LightScryptP
+	}
+	return blskeystore.NewManager(dir, scryptN, scryptP)
+}
+
+// RegisterBLSKeyService adds the RPC API of the BLS attestation key manager.
+func RegisterBLSKeyService(stack *node.Node, manager *blskeystore.Manager) {
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "blskey",
+		Service:   blskeystore.NewAPI(manager),
+	}})
+}
+
 // RegisterEthStatsService configures the Ethereum Stats daemon and adds it to the node.
 func RegisterEthStatsService(stack *node.Node, backend ethapi.Backend, url string) {
 	if err := ethstats.New(stack, backend, backend.Engine(), url); err != nil {
@@ -1928,6 +2630,116 @@ func RegisterEthStatsService(stack *node.Node, backend ethapi.Backend, url strin
 	}
 }
 
+// SetEventStreamConfig applies the event publisher command line flags to the
+// config.
+func SetEventStreamConfig(ctx *cli.Context, cfg *eventstream.Config) {
+	if ctx.IsSet(EventStreamURLFlag.Name) {
+		cfg.URL = ctx.String(EventStreamURLFlag.Name)
+	}
+	if ctx.IsSet(EventStreamPrefixFlag.Name) {
+		cfg.Prefix = ctx.String(EventStreamPrefixFlag.Name)
+	}
+	if ctx.IsSet(EventStreamReplayFlag.Name) {
+		replay := ctx.Uint64(EventStreamReplayFlag.Name)
+		cfg.Replay = &replay
+	}
+}
+
+// RegisterEventStreamService adds the chain event publisher to the node.
+func RegisterEventStreamService(stack *node.Node, backend *eth.Ethereum, cfg *eventstream.Config) {
+	if _, err := eventstream.New(stack, backend.BlockChain(), backend.ChainDb(), *cfg); err != nil {
+		Fatalf("Failed to register the event stream service: %v", err)
+	}
+}
+
+// SetAutoCompoundConfig applies the delegation reward compounder command line
+// flags to the config.
+func SetAutoCompoundConfig(ctx *cli.Context, cfg *autocompound.Config) {
+	if ctx.IsSet(AutoCompoundDelegationsFlag.Name) {
+		cfg.Delegations = cfg.Delegations[:0]
+		for _, s := range SplitAndTrim(ctx.String(AutoCompoundDelegationsFlag.Name)) {
+			d, err := autocompound.ParseDelegation(s)
+			if err != nil {
+				Fatalf("Option %q: %v", AutoCompoundDelegationsFlag.Name, err)
+			}
+			cfg.Delegations = append(cfg.Delegations, d)
+		}
+	}
+	if ctx.IsSet(AutoCompoundIntervalFlag.Name) {
+		cfg.Interval = ctx.Duration(AutoCompoundIntervalFlag.Name)
+	}
+	if ctx.IsSet(AutoCompoundMinRewardsFlag.Name) {
+		cfg.MinRewards = flags.GlobalBig(ctx, AutoCompoundMinRewardsFlag.Name)
+	}
+	if ctx.IsSet(AutoCompoundGasCapFlag.Name) {
+		cfg.GasCap = ctx.Uint64(AutoCompoundGasCapFlag.Name)
+	}
+	if ctx.IsSet(AutoCompoundDryRunFlag.Name) {
+		cfg.DryRun = ctx.Bool(AutoCompoundDryRunFlag.Name)
+	}
+}
+
+// RegisterAutoCompoundService adds the delegation reward compounder to the node.
+func RegisterAutoCompoundService(stack *node.Node, backend *eth.Ethereum, cfg *autocompound.Config) {
+	if _, err := autocompound.New(stack, backend.BlockChain().Config().ChainID, *cfg); err != nil {
+		Fatalf("Failed to register the reward compounder service: %v", err)
+	}
+}
+
+// SetBridgeWatchConfig applies the bridge watcher command line flags to the
+// config.
+func SetBridgeWatchConfig(ctx *cli.Context, cfg *bridgewatch.Config) {
+	if ctx.IsSet(BridgeContractsFlag.Name) {
+		cfg.Contracts = cfg.Contracts[:0]
+		for _, s := range SplitAndTrim(ctx.String(BridgeContractsFlag.Name)) {
+			if !common.IsHexAddress(s) {
+				Fatalf("Option %q: invalid address %q", BridgeContractsFlag.Name, s)
+			}
+			cfg.Contracts = append(cfg.Contracts, common.HexToAddress(s))
+		}
+	}
+	// The event signatures contain commas, they are separated by semicolons
+	if ctx.IsSet(BridgeLockEventsFlag.Name) {
+		cfg.LockEvents = splitEventSignatures(ctx.String(BridgeLockEventsFlag.Name))
+	}
+	if ctx.IsSet(BridgeUnlockEventsFlag.Name) {
+		cfg.UnlockEvents = splitEventSignatures(ctx.String(BridgeUnlockEventsFlag.Name))
+	}
+	if ctx.IsSet(BridgeSignerFlag.Name) {
+		signer := ctx.String(BridgeSignerFlag.Name)
+		if !common.IsHexAddress(signer) {
+			Fatalf("Option %q: invalid address %q", BridgeSignerFlag.Name, signer)
+		}
+		cfg.Signer = common.HexToAddress(signer)
+	}
+	if ctx.IsSet(BridgeFromFlag.Name) {
+		from := ctx.Uint64(BridgeFromFlag.Name)
+		cfg.From = &from
+	}
+}
+
+func splitEventSignatures(s string) []string {
+	var sigs []string
+	for _, sig := range strings.Split(s, ";") {
+		if sig = strings.TrimSpace(sig); sig != "" {
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
+
+// RegisterBridgeWatchService adds the bridge watcher and its API to the node.
+func RegisterBridgeWatchService(stack *node.Node, backend *eth.Ethereum, cfg *bridgewatch.Config) {
+	if _, err := bridgewatch.New(stack, backend.BlockChain(), backend.ChainDb(), *cfg); err != nil {
+		Fatalf("Failed to register the bridge watcher service: %v", err)
+	}
+}
+
+// RegisterVerifierAPI adds the contract verification API to the node.
+func RegisterVerifierAPI(stack *node.Node, backend ethapi.Backend, solc string) {
+	stack.RegisterAPIs(verifier.APIs(backend, verifier.NewSolc(solc)))
+}
+
 // RegisterGraphQLService adds the GraphQL API to the node.
 func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cfg *node.Config) {
 	err := graphql.New(stack, backend, filterSystem, cfg.GraphQLCors, cfg.GraphQLVirtualHosts)
@@ -1936,15 +2748,33 @@ func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSyst
 	}
 }
 
+// RegisterGRPCService adds the gRPC API to the node.
+func RegisterGRPCService(stack *node.Node, backend grpcapi.Backend, cfg *node.Config) {
+	if _, err := grpcapi.New(stack, backend, cfg.GRPCEndpoint()); err != nil {
+		Fatalf("Failed to register the gRPC service: %v", err)
+	}
+}
+
 // RegisterFilterAPI adds the eth log filtering RPC API to the node.
 func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconfig.Config) *filters.FilterSystem {
 	filterSystem := filters.NewFilterSystem(backend, filters.Config{
 		LogCacheSize: ethcfg.FilterLogCacheSize,
+		MaxLogs:      ethcfg.FilterMaxLogs,
 	})
-	stack.RegisterAPIs([]rpc.API{{
+	apis := []rpc.API{{
 		Namespace: "eth",
 		Service:   filters.NewFilterAPI(filterSystem),
-	}})
+	}, {
+		Namespace: "nero",
+		Service:   filters.NewNeroFilterAPI(filterSystem),
+	}}
+	if denied, ok := backend.(filters.DeniedLogsBackend); ok {
+		apis = append(apis, rpc.API{
+			Namespace: "debug",
+			Service:   filters.NewDebugFilterAPI(filterSystem, denied),
+		})
+	}
+	stack.RegisterAPIs(apis)
 	return filterSystem
 }
 
@@ -2015,6 +2845,22 @@ func SetupMetrics(ctx *cli.Context) {
 	}
 }
 
+// SetupOTel enables the OpenTelemetry span export if requested.
+func SetupOTel(ctx *cli.Context) {
+	if !ctx.Bool(OTelEnabledFlag.Name) {
+		return
+	}
+	config := otel.Config{
+		Endpoint:    ctx.String(OTelEndpointFlag.Name),
+		ServiceName: ctx.String(OTelServiceFlag.Name),
+		SampleRatio: ctx.Float64(OTelSampleFlag.Name),
+		Headers:     SplitTagsFlag(ctx.String(OTelHeadersFlag.Name)),
+	}
+	if err := otel.Setup(config); err != nil {
+		Fatalf("Failed to enable OpenTelemetry tracing: %v", err)
+	}
+}
+
 func SplitTagsFlag(tagsFlag string) map[string]string {
 	tags := strings.Split(tagsFlag, ",")
 	tagsMap := map[string]string{}
@@ -2035,7 +2881,7 @@ func SplitTagsFlag(tagsFlag string) map[string]string {
 // MakeChainDatabase opens a database using the flags passed to the client and will hard crash if it fails.
 func MakeChainDatabase(ctx *cli.Context, stack *node.Node, readonly bool) ethdb.Database {
 	var (
-		cache   = ctx.Int(CacheFlag.Name) * ctx.Int(CacheDatabaseFlag.Name) / 100
+		cache   = MakeDatabaseCache(ctx)
 		handles = MakeDatabaseHandles(ctx.Int(FDLimitFlag.Name))
 		err     error
 		chainDb ethdb.Database
@@ -2118,8 +2964,23 @@ func MakeGenesis(ctx *cli.Context) *core.Genesis {
 	return genesis
 }
 
+// LoadTxRulePlugins loads the plugins registering additional transaction rules
+// with the Turbo engine. They must be loaded before the engine is created.
+func LoadTxRulePlugins(ctx *cli.Context) {
+	if !ctx.IsSet(TxRulePluginsFlag.Name) {
+		return
+	}
+	for _, path := range SplitAndTrim(ctx.String(TxRulePluginsFlag.Name)) {
+		if err := turbo.LoadTxRulePlugin(path); err != nil {
+			Fatalf("%v", err)
+		}
+	}
+}
+
 // MakeChain creates a chain manager from set command line flags.
 func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockChain, ethdb.Database) {
+	LoadTxRulePlugins(ctx)
+
 	var (
 		gspec   = MakeGenesis(ctx)
 		chainDb = MakeChainDatabase(ctx, stack, readonly)
@@ -2149,6 +3010,8 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		Preimages:           ctx.Bool(CachePreimagesFlag.Name),
 		StateScheme:         scheme,
 		StateHistory:        ctx.Uint64(StateHistoryFlag.Name),
+		ReceiptDedup:        ctx.Bool(ReceiptDedupFlag.Name),
+		TraceArchive:        ctx.Bool(TraceArchiveFlag.Name),
 	}
 	if cache.TrieDirtyDisabled && !cache.Preimages {
 		cache.Preimages = true
@@ -2162,11 +3025,12 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		cache.SnapshotNoBuild = true
 	}
 
-	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheTrieFlag.Name) {
-		cache.TrieCleanLimit = ctx.Int(CacheFlag.Name) * ctx.Int(CacheTrieFlag.Name) / 100
+	shares := makeCacheShares(ctx)
+	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheTrieFlag.Name) || ctx.IsSet(DBProfileFlag.Name) {
+		cache.TrieCleanLimit = ctx.Int(CacheFlag.Name) * shares.trie / 100
 	}
-	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheGCFlag.Name) {
-		cache.TrieDirtyLimit = ctx.Int(CacheFlag.Name) * ctx.Int(CacheGCFlag.Name) / 100
+	if ctx.IsSet(CacheFlag.Name) || ctx.IsSet(CacheGCFlag.Name) || ctx.IsSet(DBProfileFlag.Name) {
+		cache.TrieDirtyLimit = ctx.Int(CacheFlag.Name) * shares.gc / 100
 	}
 	vmcfg := vm.Config{EnablePreimageRecording: ctx.Bool(VMEnableDebugFlag.Name)}
 	if ctx.IsSet(VMTraceFlag.Name) {
@@ -65,3 +65,43 @@ func Test_SplitTagsFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheSharesFit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		shares   cacheShares
+		explicit [3]bool
+		want     cacheShares
+		fail     bool
+	}{
+		// Within the allowance
+		{shares: cacheShares{50, 15, 25, 10}, want: cacheShares{50, 15, 25, 10}},
+		// Profile share carved out of the default shares
+		{shares: cacheShares{70, 15, 25, 10}, want: cacheShares{70, 9, 15, 6}},
+		// Explicit shares are kept
+		{shares: cacheShares{60, 20, 25, 10}, explicit: [3]bool{true, false, false}, want: cacheShares{60, 20, 14, 5}},
+		// No room left for the shares not configured explicitly
+		{shares: cacheShares{70, 20, 10, 10}, explicit: [3]bool{true, true, false}, fail: true},
+		{shares: cacheShares{70, 20, 20, 0}, explicit: [3]bool{true, true, true}, fail: true},
+	}
+	for i, tt := range tests {
+		shares := tt.shares
+		err := shares.fit(tt.explicit[0], tt.explicit[1], tt.explicit[2])
+		if tt.fail {
+			if err == nil {
+				t.Errorf("test %d: oversized shares accepted: %+v", i, shares)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: %v", i, err)
+			continue
+		}
+		if shares != tt.want {
+			t.Errorf("test %d: shares mismatch: have %+v, want %+v", i, shares, tt.want)
+		}
+		if total := shares.database + shares.trie + shares.gc + shares.snapshot; total > 100 {
+			t.Errorf("test %d: shares exceed the allowance: %d%%", i, total)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package compiler
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// StandardOutput is the output of a solc --standard-json run, restricted to the
+// fields needed to match the compiled contracts against the deployed code.
+type StandardOutput struct {
+	Errors    []StandardError                        `json:"errors"`
+	Contracts map[string]map[string]StandardContract `json:"contracts"`
+}
+
+// StandardError is a compilation error or warning.
+type StandardError struct {
+	Severity         string `json:"severity"`
+	Type             string `json:"type"`
+	Message          string `json:"message"`
+	FormattedMessage string `json:"formattedMessage"`
+}
+
+// StandardContract is the output of a compiled contract.
+type StandardContract struct {
+	ABI      json.RawMessage `json:"abi"`
+	Metadata string          `json:"metadata"`
+	EVM      struct {
+		DeployedBytecode StandardBytecode `json:"deployedBytecode"`
+	} `json:"evm"`
+}
+
+// StandardBytecode is the bytecode of a compiled contract. The object is hex
+// encoded, with placeholders in place of the unlinked library addresses.
+type StandardBytecode struct {
+	Object              string                            `json:"object"`
+	LinkReferences      map[string]map[string][]CodeRange `json:"linkReferences"`
+	ImmutableReferences map[string][]CodeRange            `json:"immutableReferences"`
+}
+
+// CodeRange is a range of bytes in the bytecode.
+type CodeRange struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// StandardOutputSelection is the output selection needed to fill StandardOutput.
+var StandardOutputSelection = map[string]map[string][]string{
+	"*": {
+		"*": {
+			"abi",
+			"metadata",
+			"evm.deployedBytecode.object",
+			"evm.deployedBytecode.linkReferences",
+			"evm.deployedBytecode.immutableReferences",
+		},
+	},
+}
+
+// ParseStandardJSON parses the output of a solc --standard-json run, returning
+// the compilation errors if any.
+func ParseStandardJSON(output []byte) (*StandardOutput, error) {
+	var res StandardOutput
+	if err := json.Unmarshal(output, &res); err != nil {
+		return nil, err
+	}
+	var failures []string
+	for _, e := range res.Errors {
+		if e.Severity != "error" {
+			continue
+		}
+		msg := e.FormattedMessage
+		if msg == "" {
+			msg = e.Type + ": " + e.Message
+		}
+		failures = append(failures, strings.TrimSpace(msg))
+	}
+	if len(failures) > 0 {
+		return nil, errors.New("solc: " + strings.Join(failures, "\n"))
+	}
+	return &res, nil
+}
@@ -31,6 +31,10 @@ import (
 
 var (
 	FeeRecoder = common.HexToAddress("0xffffffffffffffffffffffffffffffffffffffff")
+
+	// SupplyRecorder is the account whose storage holds the supply counters of
+	// the Turbo engine.
+	SupplyRecorder = common.HexToAddress("0xfffffffffffffffffffffffffffffffffffffffe")
 )
 
 // ChainHeaderReader defines a small collection of methods needed to access the local
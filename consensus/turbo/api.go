@@ -17,10 +17,14 @@
 package turbo
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
+	"github.com/ethereum/go-ethereum/contracts"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -90,6 +94,137 @@ func (api *API) GetValidatorsAtHash(hash common.Hash) ([]common.Address, error)
 	return snap.validators(), nil
 }
 
+// GetJailedValidators retrieves the jailed validators at the specified block.
+// From the jail fork, these are the jails recorded at the last epoch block and
+// enforced by the consensus rules, with the block first recording them.
+// Before, the validators are read from the Staking contract at the state of
+// the block, with the block they were jailed at.
+func (api *API) GetJailedValidators(number *rpc.BlockNumber) (map[common.Address]hexutil.Uint64, error) {
+	// Retrieve the requested block number (or current if none requested)
+	var header *types.Header
+	if number == nil || *number == rpc.LatestBlockNumber {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+	// Ensure we have an actually valid block and return the jails from its snapshot
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	snap, err := api.turbo.snapshot(api.chain, header.Number.Uint64(), header.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	jailed := make(map[common.Address]hexutil.Uint64, len(snap.Jailed))
+	if api.turbo.config.IsJail(header.Number) {
+		for validator, number := range snap.Jailed {
+			jailed[validator] = hexutil.Uint64(number)
+		}
+		return jailed, nil
+	}
+	if api.turbo.stateFn == nil {
+		return nil, errors.New("state not available")
+	}
+	statedb, err := api.turbo.stateFn(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	validators := snap.validators()
+	statuses, err := systemcontract.GetValidatorStatuses(&contracts.CallContext{
+		Statedb:      statedb,
+		Header:       header,
+		ChainContext: newChainContext(api.chain, api.turbo),
+		ChainConfig:  api.turbo.chainConfig,
+	}, validators)
+	if err != nil {
+		return nil, err
+	}
+	for i, validator := range validators {
+		if statuses[i].State == systemcontract.ValidatorJailed {
+			jailed[validator] = hexutil.Uint64(statuses[i].PunishBlock)
+		}
+	}
+	return jailed, nil
+}
+
+// SlashingParams is the JSON form of the slashing parameters.
+type SlashingParams struct {
+	Governed            bool           `json:"governed"` // Whether the slashing fork is active
+	LazyPunishThreshold hexutil.Uint64 `json:"lazyPunishThreshold"`
+	DecreaseInterval    hexutil.Uint64 `json:"decreaseInterval"`
+	DoubleSignPenalty   hexutil.Uint64 `json:"doubleSignPenalty"`
+}
+
+// GetSlashingParams retrieves the slashing parameters at the specified block.
+func (api *API) GetSlashingParams(number *rpc.BlockNumber) (*SlashingParams, error) {
+	// Retrieve the requested block number (or current if none requested)
+	var header *types.Header
+	if number == nil || *number == rpc.LatestBlockNumber {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	if api.turbo.stateFn == nil {
+		return nil, errors.New("state not available")
+	}
+	statedb, err := api.turbo.stateFn(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	params := systemcontract.ReadSlashingParams(&contracts.CallContext{
+		Statedb:      statedb,
+		Header:       header,
+		ChainContext: newChainContext(api.chain, api.turbo),
+		ChainConfig:  api.turbo.chainConfig,
+	})
+	return &SlashingParams{
+		Governed:            api.turbo.config.IsSlashing(header.Number),
+		LazyPunishThreshold: hexutil.Uint64(params.LazyPunishThreshold),
+		DecreaseInterval:    hexutil.Uint64(params.DecreaseInterval),
+		DoubleSignPenalty:   hexutil.Uint64(params.DoubleSignPenalty),
+	}, nil
+}
+
+// ValidatorSet is the JSON form of a validator set reconstructed from the
+// epoch headers.
+type ValidatorSet struct {
+	Number         hexutil.Uint64   `json:"number"`
+	Hash           common.Hash      `json:"hash"`
+	Checkpoint     hexutil.Uint64   `json:"checkpoint"` // Epoch block recording the validator set
+	CheckpointHash common.Hash      `json:"checkpointHash"`
+	Validators     []common.Address `json:"validators"`
+}
+
+// GetValidatorsFromHeaders reconstructs the validator set in effect after the
+// specified block from the epoch headers, without needing its state or
+// snapshot.
+func (api *API) GetValidatorsFromHeaders(number *rpc.BlockNumber) (*ValidatorSet, error) {
+	// Retrieve the requested block number (or current if none requested)
+	var header *types.Header
+	if number == nil || *number == rpc.LatestBlockNumber {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+	set, err := api.turbo.ValidatorsFromHeaders(api.chain, header)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatorSet{
+		Number:         hexutil.Uint64(set.Number),
+		Hash:           set.Hash,
+		Checkpoint:     hexutil.Uint64(set.Checkpoint.Number.Uint64()),
+		CheckpointHash: set.Checkpoint.Hash(),
+		Validators:     set.Validators,
+	}, nil
+}
+
 type status struct {
 	InturnPercent float64                `json:"inturnPercent"`
 	SigningStatus map[common.Address]int `json:"sealerActivity"`
@@ -145,3 +280,63 @@ func (api *API) Status() (*status, error) {
 		NumBlocks:     numBlocks,
 	}, nil
 }
+
+// StopSealing stops sealing the blocks above the given number, the current head
+// if omitted, to hand the slots over before a restart. It returns the number of
+// the last block the validator may seal.
+func (api *API) StopSealing(afterBlock *hexutil.Uint64) hexutil.Uint64 {
+	after := api.chain.CurrentHeader().Number.Uint64()
+	if afterBlock != nil {
+		after = uint64(*afterBlock)
+	}
+	api.turbo.StopSealing(after)
+	return hexutil.Uint64(after)
+}
+
+// ResumeSealing resumes the sealing stopped by StopSealing.
+func (api *API) ResumeSealing() {
+	api.turbo.ResumeSealing()
+}
+
+// HaltStatus is the JSON form of the emergency halt status.
+type HaltStatus struct {
+	Head     hexutil.Uint64  `json:"head"`
+	Governed hexutil.Uint64  `json:"governedBlock"` // Halt block set by the governance, 0 if none
+	Override *hexutil.Uint64 `json:"overrideBlock"` // Halt block set locally in place of the governed one
+	Block    hexutil.Uint64  `json:"haltBlock"`     // Halt block in effect, 0 if none
+	Halted   bool            `json:"halted"`        // Whether the head reached the halt block
+}
+
+// GetHaltStatus returns the emergency halt in effect for the blocks on top of
+// the current head.
+func (api *API) GetHaltStatus() (*HaltStatus, error) {
+	head := api.chain.CurrentHeader()
+	status, err := api.turbo.haltStatus(api.chain, head)
+	if err != nil {
+		return nil, err
+	}
+	number := head.Number.Uint64()
+	result := &HaltStatus{
+		Head:     hexutil.Uint64(number),
+		Governed: hexutil.Uint64(status.governed),
+		Block:    hexutil.Uint64(status.block()),
+		Halted:   status.halts(number + 1),
+	}
+	if status.override != nil {
+		override := hexutil.Uint64(*status.override)
+		result.Override = &override
+	}
+	return result, nil
+}
+
+// OverrideHalt stops sealing the blocks above the given number in place of the
+// governed halt block, or ignores the governed halt with 0.
+func (api *API) OverrideHalt(block hexutil.Uint64) {
+	api.turbo.OverrideHalt(uint64(block))
+}
+
+// ClearHaltOverride lifts the override set by OverrideHalt, following the
+// governed halt again.
+func (api *API) ClearHaltOverride() {
+	api.turbo.ClearHaltOverride()
+}
@@ -0,0 +1,131 @@
+package turbo
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// haltStatus is the emergency halt in effect for the blocks on top of a head.
+type haltStatus struct {
+	governed uint64  // Halt block set by the governance, 0 if none
+	override *uint64 // Halt block set locally in place of the governed one, nil if none
+}
+
+// block returns the halt block in effect, 0 if none.
+func (s haltStatus) block() uint64 {
+	if s.override != nil {
+		return *s.override
+	}
+	return s.governed
+}
+
+// halts returns whether the sealing of the given block is refused.
+func (s haltStatus) halts(number uint64) bool {
+	halt := s.block()
+	return halt != 0 && number > halt
+}
+
+// OverrideHalt makes the validator stop sealing the blocks above the given
+// number in place of the governed halt block, so that the validators can halt
+// the chain ahead of the governance in an emergency. With 0, the governed halt
+// is ignored, for the upgraded validators to resume the chain past it.
+func (c *Turbo) OverrideHalt(block uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.haltOverride = &block
+	if block == 0 {
+		log.Warn("Ignoring the governed chain halt")
+	} else {
+		log.Warn("Halting the chain locally", "after", block)
+	}
+}
+
+// ClearHaltOverride lifts the override set by OverrideHalt, following the
+// governed halt again.
+func (c *Turbo) ClearHaltOverride() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.haltOverride != nil {
+		log.Info("Following the governed chain halt again")
+	}
+	c.haltOverride = nil
+}
+
+// haltStatus returns the halt in effect for the blocks on top of the given head,
+// reading the governed halt block at its state.
+func (c *Turbo) haltStatus(chain consensus.ChainHeaderReader, head *types.Header) (haltStatus, error) {
+	governed, err := c.governedHalt(chain, head)
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return haltStatus{governed: governed, override: c.haltOverride}, err
+}
+
+// governedHalt returns the halt block set by the governance at the state of the
+// given head. The block read is cached for the head, and its changes logged.
+func (c *Turbo) governedHalt(chain consensus.ChainHeaderReader, head *types.Header) (uint64, error) {
+	if c.chainConfig.Turbo.Halt == nil {
+		return 0, nil
+	}
+	hash := head.Hash()
+
+	c.lock.RLock()
+	cached, governed := c.haltHash == hash, c.haltGoverned
+	c.lock.RUnlock()
+	if cached {
+		return governed, nil
+	}
+	if c.stateFn == nil {
+		return 0, errors.New("state not available")
+	}
+	statedb, err := c.stateFn(head.Root)
+	if err != nil {
+		return 0, err
+	}
+	block := systemcontract.ReadHaltBlock(&contracts.CallContext{
+		Statedb:      statedb,
+		Header:       head,
+		ChainContext: newChainContext(chain, c),
+		ChainConfig:  c.chainConfig,
+	})
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if block != c.haltGoverned {
+		if block != 0 {
+			log.Warn("Governed chain halt scheduled", "after", block, "number", head.Number)
+		} else {
+			log.Warn("Governed chain halt lifted", "number", head.Number)
+		}
+	}
+	c.haltHash, c.haltGoverned = hash, block
+	return block, nil
+}
+
+// sealingHalted returns whether the sealing of the given block is refused by
+// the emergency halt. The sealing goes on if the governed halt can't be read,
+// not to halt the chain on an error.
+func (c *Turbo) sealingHalted(chain consensus.ChainHeaderReader, header *types.Header) bool {
+	number := header.Number.Uint64()
+	parent := chain.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return false
+	}
+	status, err := c.haltStatus(chain, parent)
+	if err != nil {
+		log.Warn("Failed to read the governed chain halt", "number", number, "err", err)
+	}
+	if !status.halts(number) {
+		return false
+	}
+	log.Warn("Sealing halted", "number", number, "halt", status.block(), "overridden", status.override != nil)
+	return true
+}
@@ -0,0 +1,112 @@
+package turbo
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// flushedSnapshotKey is the database key of the hash of the snapshot flushed on
+// shutdown, reloaded on restart instead of being rebuilt from the last
+// checkpoint.
+var flushedSnapshotKey = []byte("turbo-flushed")
+
+// StopSealing makes the engine refuse to seal any block above the given number,
+// so that a validator restarted for an upgrade hands its slots over cleanly
+// instead of having a block it sealed lost mid-propagation. The blocks being
+// sealed above the number are discarded before their slot.
+func (c *Turbo) StopSealing(after uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.sealStopped, c.sealLimit = true, after
+	log.Info("Stopping sealing for handover", "after", after)
+}
+
+// ResumeSealing lifts the limit set by StopSealing.
+func (c *Turbo) ResumeSealing() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.sealStopped {
+		log.Info("Resuming sealing", "after", c.sealLimit)
+	}
+	c.sealStopped, c.sealLimit = false, 0
+}
+
+// sealingStopped returns whether the sealing of the given block is refused.
+func (c *Turbo) sealingStopped(number uint64) bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.sealStopped && number > c.sealLimit
+}
+
+// SigningFloor returns the lowest block number the validator may sign, the
+// number of the last block it signed, or above once another node took the
+// signing over.
+func (c *Turbo) SigningFloor(val common.Address) uint64 {
+	c.signLock.Lock()
+	defer c.signLock.Unlock()
+
+	return rawdb.ReadSigningFloor(c.db, val)
+}
+
+// RaiseSigningFloor makes the engine refuse to sign the blocks of the validator
+// below the given number, signed by another node maybe. A lower number is
+// ignored, the floor never goes down.
+func (c *Turbo) RaiseSigningFloor(val common.Address, number uint64) {
+	c.signLock.Lock()
+	defer c.signLock.Unlock()
+
+	if floor := rawdb.ReadSigningFloor(c.db, val); number > floor {
+		rawdb.WriteSigningFloor(c.db, val, number)
+		log.Info("Raised signing floor", "validator", val, "floor", number)
+	}
+}
+
+// protectSigning refuses the sealing of a block below the signing floor of the
+// validator, and raises the floor to the block before it's signed, so that no other block is signed at a lower height, even after
+// a restart. The blocks resealed at the same height, as the miner updates its
+// work, are permitted, the previous ones being discarded before their slot. It
+// returns whether the block may be signed, not if the sealing was stopped for
+// handover meanwhile.
+func (c *Turbo) protectSigning(val common.Address, number uint64) (bool, error) {
+	c.signLock.Lock()
+	defer c.signLock.Unlock()
+
+	// Checked under the lock for the floor read on handover to be final
+	if c.sealingStopped(number) {
+		log.Info("Sealing stopped for handover", "number", number)
+		return false, nil
+	}
+	floor := rawdb.ReadSigningFloor(c.db, val)
+	if number < floor {
+		log.Error("Refused to sign block below signing floor", "number", number, "floor", floor)
+		return false, errBelowSigningFloor
+	}
+	if number > floor {
+		rawdb.WriteSigningFloor(c.db, val, number)
+	}
+	return true, nil
+}
+
+// FlushSnapshot persists the snapshot of the current head, so that a restarted
+// node doesn't rebuild it from the last checkpoint, or lose the jails tracked
+// since, before sealing again.
+func (c *Turbo) FlushSnapshot(chain consensus.ChainHeaderReader) error {
+	head := chain.CurrentHeader()
+	snap, err := c.snapshot(chain, head.Number.Uint64(), head.Hash(), nil)
+	if err != nil {
+		return err
+	}
+	if err := snap.store(c.db); err != nil {
+		return err
+	}
+	if err := c.db.Put(flushedSnapshotKey, snap.Hash[:]); err != nil {
+		return err
+	}
+	log.Info("Flushed validator snapshot", "number", snap.Number, "hash", snap.Hash)
+	return nil
+}
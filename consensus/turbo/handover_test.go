@@ -0,0 +1,135 @@
+package turbo
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestStopSealing(t *testing.T) {
+	engine := newTestVerifier()
+	if engine.sealingStopped(100) {
+		t.Fatal("sealing stopped before any request")
+	}
+	engine.StopSealing(10)
+	for number, stopped := range map[uint64]bool{9: false, 10: false, 11: true, 100: true} {
+		if have := engine.sealingStopped(number); have != stopped {
+			t.Errorf("block %d: stopped mismatch: have %v, want %v", number, have, stopped)
+		}
+	}
+	engine.ResumeSealing()
+	if engine.sealingStopped(100) {
+		t.Fatal("sealing still stopped after resuming")
+	}
+}
+
+func TestHaltOverride(t *testing.T) {
+	var (
+		engine = newTestVerifier()
+		chain  = &testHeaderChain{}
+		head   = &types.Header{Number: big.NewInt(10)}
+	)
+	// Without the governed halt configured, only the local override halts
+	for i, tt := range []struct {
+		override *uint64
+		block    uint64
+	}{
+		{block: 0},
+		{override: newUint64(10), block: 10},
+		{override: newUint64(0), block: 0},
+	} {
+		if tt.override != nil {
+			engine.OverrideHalt(*tt.override)
+		} else {
+			engine.ClearHaltOverride()
+		}
+		status, err := engine.haltStatus(chain, head)
+		if err != nil {
+			t.Fatalf("test %d: failed to read halt status: %v", i, err)
+		}
+		if block := status.block(); block != tt.block {
+			t.Errorf("test %d: halt block mismatch: have %d, want %d", i, block, tt.block)
+		}
+		if halted := status.halts(11); halted != (tt.block == 10) {
+			t.Errorf("test %d: halted mismatch: have %v", i, halted)
+		}
+		if status.halts(10) {
+			t.Errorf("test %d: halt block refused", i)
+		}
+	}
+}
+
+func newUint64(n uint64) *uint64 { return &n }
+
+func TestFlushSnapshot(t *testing.T) {
+	var (
+		db             = rawdb.NewMemoryDatabase()
+		engine         = New(params.AllTurboProtocolChanges, db)
+		chain, headers = newTestHeaderChain(3, 40)
+		head           = headers[len(headers)-1]
+	)
+	if err := engine.FlushSnapshot(chain); err != nil {
+		t.Fatalf("failed to flush snapshot: %v", err)
+	}
+	// The restarted engine reloads the flushed snapshot instead of walking
+	// back to the genesis, which the pruned chain can't serve.
+	pruned := &testHeaderChain{headers: map[common.Hash]*types.Header{head.Hash(): head}}
+	pruned.canonical = []*types.Header{head}
+
+	restarted := New(params.AllTurboProtocolChanges, db)
+	if restarted.flushed != head.Hash() {
+		t.Fatalf("flushed hash mismatch: have %x, want %x", restarted.flushed, head.Hash())
+	}
+	snap, err := restarted.snapshot(pruned, head.Number.Uint64(), head.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to reload snapshot: %v", err)
+	}
+	if snap.Number != head.Number.Uint64() || len(snap.Validators) != 3 {
+		t.Fatalf("snapshot mismatch: number %d, validators %d", snap.Number, len(snap.Validators))
+	}
+}
+
+func TestSigningFloor(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		engine = New(params.AllTurboProtocolChanges, db)
+		val    = common.Address{0x01}
+	)
+	for i, tt := range []struct {
+		number uint64
+		sign   bool
+		err    error
+	}{
+		{number: 10, sign: true},
+		{number: 10, sign: true}, // Resealed by the miner
+		{number: 9, err: errBelowSigningFloor},
+		{number: 11, sign: true},
+	} {
+		sign, err := engine.protectSigning(val, tt.number)
+		if sign != tt.sign || err != tt.err {
+			t.Errorf("test %d: block %d: have %v, %v, want %v, %v", i, tt.number, sign, err, tt.sign, tt.err)
+		}
+	}
+	// The floor survives restarts and only goes up
+	restarted := New(params.AllTurboProtocolChanges, db)
+	restarted.RaiseSigningFloor(val, 5)
+	if floor := restarted.SigningFloor(val); floor != 11 {
+		t.Fatalf("floor mismatch: have %d, want 11", floor)
+	}
+	restarted.RaiseSigningFloor(val, 20)
+	if _, err := restarted.protectSigning(val, 19); err != errBelowSigningFloor {
+		t.Fatalf("signed below raised floor: %v", err)
+	}
+	// Nothing is signed nor recorded once stopped for handover
+	restarted.StopSealing(20)
+	if sign, err := restarted.protectSigning(val, 21); sign || err != nil {
+		t.Fatalf("signed while stopped: %v, %v", sign, err)
+	}
+	if floor := restarted.SigningFloor(val); floor != 20 {
+		t.Fatalf("floor mismatch: have %d, want 20", floor)
+	}
+}
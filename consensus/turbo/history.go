@@ -0,0 +1,63 @@
+package turbo
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/turbo/light"
+	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// HeaderValidators is a validator set reconstructed from the header chain.
+type HeaderValidators struct {
+	Number     uint64           // Block the validator set is in effect after
+	Hash       common.Hash      // Hash of the block
+	Checkpoint *types.Header    // Epoch header recording the validator set
+	Validators []common.Address // Validators in ascending order
+}
+
+// ValidatorsFromHeaders reconstructs the validator set in effect after the given
+// header, i.e. the one of its snapshot, purely from the extra-data of the epoch
+// headers. Unlike the snapshots, it neither needs the state nor replays the
+// headers in between, which makes it suitable to check old blocks, e.g. to
+// verify their finality proofs or backfill explorers. The signatures of the
+// headers are not checked, so the header chain must already be verified.
+func (c *Turbo) ValidatorsFromHeaders(chain consensus.ChainHeaderReader, header *types.Header) (*HeaderValidators, error) {
+	checkpoint := headerAncestor(chain, header, light.ValidatorCheckpoint(c.config, header.Number.Uint64()))
+	if checkpoint == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	var (
+		seen       = make(map[common.Address]struct{})
+		validators []common.Address
+	)
+	for _, validator := range EpochValidators(checkpoint) {
+		if _, ok := seen[validator]; !ok {
+			seen[validator] = struct{}{}
+			validators = append(validators, validator)
+		}
+	}
+	sort.Sort(systemcontract.AddrAscend(validators))
+
+	return &HeaderValidators{
+		Number:     header.Number.Uint64(),
+		Hash:       header.Hash(),
+		Checkpoint: checkpoint,
+		Validators: validators,
+	}, nil
+}
+
+// headerAncestor returns the ancestor of the header with the given number. The
+// parents are followed until the canonical chain is reached, the ancestor is
+// then looked up by number.
+func headerAncestor(chain consensus.ChainHeaderReader, header *types.Header, number uint64) *types.Header {
+	for header != nil && header.Number.Uint64() > number {
+		if canonical := chain.GetHeaderByNumber(header.Number.Uint64()); canonical != nil && canonical.Hash() == header.Hash() {
+			return chain.GetHeaderByNumber(number)
+		}
+		header = chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+	return header
+}
@@ -0,0 +1,108 @@
+package turbo
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testHeaderChain is a header chain with a canonical branch and side headers.
+type testHeaderChain struct {
+	canonical []*types.Header
+	headers   map[common.Hash]*types.Header
+}
+
+func (c *testHeaderChain) add(header *types.Header, canonical bool) {
+	c.headers[header.Hash()] = header
+	if canonical {
+		c.canonical = append(c.canonical, header)
+	}
+}
+
+func (c *testHeaderChain) Config() *params.ChainConfig  { return params.AllTurboProtocolChanges }
+func (c *testHeaderChain) CurrentHeader() *types.Header { return c.canonical[len(c.canonical)-1] }
+func (c *testHeaderChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	return c.headers[hash]
+}
+func (c *testHeaderChain) GetTd(hash common.Hash, number uint64) *big.Int { return nil }
+
+func (c *testHeaderChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.headers[hash]; header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+func (c *testHeaderChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number < uint64(len(c.canonical)) {
+		return c.canonical[number]
+	}
+	return nil
+}
+
+func TestValidatorsFromHeaders(t *testing.T) {
+	const epoch = 4
+	var (
+		chain  = &testHeaderChain{headers: make(map[common.Hash]*types.Header)}
+		engine = &Turbo{config: &params.TurboConfig{Epoch: epoch}}
+		sets   = map[uint64][]common.Address{
+			0:  {{0x01}},
+			4:  {{0x02}, {0x01}},
+			8:  {{0x03}},
+			12: {{0x01}, {0x03}},
+		}
+		newHeader = func(number uint64, parent common.Hash, extra []common.Address) *types.Header {
+			header := &types.Header{Number: new(big.Int).SetUint64(number), ParentHash: parent, Extra: make([]byte, extraVanity)}
+			for _, validator := range extra {
+				header.Extra = append(header.Extra, validator[:]...)
+			}
+			header.Extra = append(header.Extra, make([]byte, extraSeal)...)
+			return header
+		}
+	)
+	for number := uint64(0); number <= 16; number++ {
+		var parent common.Hash
+		if number > 0 {
+			parent = chain.canonical[number-1].Hash()
+		}
+		chain.add(newHeader(number, parent, sets[number]), true)
+	}
+	// A side chain recording another validator set at block 12
+	side := newHeader(12, chain.canonical[11].Hash(), []common.Address{{0x04}})
+	chain.add(side, false)
+	for number := uint64(13); number <= 16; number++ {
+		side = newHeader(number, side.Hash(), nil)
+		side.Time = 1
+		chain.add(side, false)
+	}
+
+	tests := []struct {
+		header     *types.Header
+		checkpoint uint64
+		validators []common.Address
+	}{
+		{chain.canonical[0], 0, []common.Address{{0x01}}},
+		{chain.canonical[7], 0, []common.Address{{0x01}}},
+		{chain.canonical[8], 4, []common.Address{{0x01}, {0x02}}},
+		{chain.canonical[11], 4, []common.Address{{0x01}, {0x02}}},
+		{chain.canonical[12], 8, []common.Address{{0x03}}},
+		{chain.canonical[16], 12, []common.Address{{0x01}, {0x03}}},
+		{side, 12, []common.Address{{0x04}}},
+	}
+	for i, tt := range tests {
+		set, err := engine.ValidatorsFromHeaders(chain, tt.header)
+		if err != nil {
+			t.Fatalf("test %d: failed to reconstruct validators: %v", i, err)
+		}
+		if have := set.Checkpoint.Number.Uint64(); have != tt.checkpoint {
+			t.Errorf("test %d: checkpoint mismatch: have %d, want %d", i, have, tt.checkpoint)
+		}
+		if !reflect.DeepEqual(set.Validators, tt.validators) {
+			t.Errorf("test %d: validators mismatch: have %x, want %x", i, set.Validators, tt.validators)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package light
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	attestationThresholdNumerator   = 2
+	attestationThresholdDenominator = 3
+)
+
+// ErrNotJustified is returned if the attestations don't justify a block.
+var ErrNotJustified = errors.New("not enough attestations to justify block")
+
+var (
+	errNotValidator  = errors.New("the signer is not a validator")
+	errForeignTarget = errors.New("attestation targets another block")
+	errNotChild      = errors.New("child attestations do not target a child of the block")
+)
+
+// AttestationThreshold returns the number of attestations justifying a block
+// attested by the given number of validators, i.e. more than two thirds.
+func AttestationThreshold(validators int) int {
+	return validators*attestationThresholdNumerator/attestationThresholdDenominator + 1
+}
+
+// AttestationFn checks an attestation against the validator set of its target
+// block, returning its signer and the number of attestations justifying the
+// target.
+type AttestationFn func(a *types.Attestation) (common.Address, int, error)
+
+// VerifyAttestation checks that the attestation is signed by a validator of
+// the set, which must be the set in effect after the target block.
+func (s ValidatorSet) VerifyAttestation(a *types.Attestation) (common.Address, int, error) {
+	signer, err := a.RecoverSigner()
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+	if !s.Contains(signer) {
+		return common.Address{}, 0, errNotValidator
+	}
+	return signer, AttestationThreshold(len(s)), nil
+}
+
+// VerifyJustification checks that the attestations justify the given block.
+// Like the attestation processing of the nodes, only attestations sharing the
+// same source and target are counted together.
+func VerifyJustification(atts []*types.Attestation, number uint64, hash common.Hash, verify AttestationFn) error {
+	var (
+		threshold int
+		signers   = make(map[common.Hash]map[common.Address]struct{})
+	)
+	for _, a := range atts {
+		if err := a.SanityCheck(); err != nil {
+			return err
+		}
+		if a.TargetRangeEdge.Number.Uint64() != number || a.TargetRangeEdge.Hash != hash {
+			return errForeignTarget
+		}
+		signer, t, err := verify(a)
+		if err != nil {
+			return err
+		}
+		threshold = t
+
+		sigHash := a.SignHash()
+		if signers[sigHash] == nil {
+			signers[sigHash] = make(map[common.Address]struct{})
+		}
+		signers[sigHash][signer] = struct{}{}
+		if len(signers[sigHash]) >= threshold {
+			return nil
+		}
+	}
+	return ErrNotJustified
+}
+
+// FinalityProof contains the attestations proving that a block is finalized.
+// A block is finalized once both itself and its direct child are justified,
+// i.e. attested by more than two thirds of the validators.
+type FinalityProof struct {
+	Target []*types.Attestation // Attestations justifying the block
+	Child  []*types.Attestation // Attestations justifying the child of the block
+}
+
+// VerifyFinality checks that the proof finalizes the header, given its child
+// and the validator sets in effect after both of them.
+func VerifyFinality(header, child *types.Header, validators, childValidators ValidatorSet, proof *FinalityProof) error {
+	if child.ParentHash != header.Hash() || child.Number.Uint64() != header.Number.Uint64()+1 {
+		return errNotChild
+	}
+	if err := VerifyJustification(proof.Target, header.Number.Uint64(), header.Hash(), validators.VerifyAttestation); err != nil {
+		return err
+	}
+	return VerifyJustification(proof.Child, child.Number.Uint64(), child.Hash(), childValidators.VerifyAttestation)
+}
@@ -0,0 +1,249 @@
+// Package light implements the verification of Turbo headers and finality
+// proofs for light clients.
+//
+// The verification is self-contained: it needs neither a database nor the
+// state, only the headers and attestations handed over by the caller, and it
+// depends on nothing but the core types and the crypto primitives, so that it
+// compiles to WebAssembly for browser and mobile clients. The Turbo engine
+// shares the sealing primitives of this package.
+//
+// Starting from a trusted epoch header, a Verifier follows the header chain,
+// checking the seal of every header against the validator set in effect and
+// applying the validator set transitions recorded in the epoch headers. The
+// finality of a header is checked against the attestations of the validators.
+// The jails recorded in the epoch headers are enforced, but the rules depending
+// on the state, such as whether the recorded jails match the Staking contract,
+// or on the execution, such as the base fee, are not checked.
+package light
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	ExtraVanity = 32                     // Fixed number of extra-data prefix bytes reserved for validator vanity
+	ExtraSeal   = crypto.SignatureLength // Fixed number of extra-data suffix bytes reserved for validator seal
+)
+
+// errMissingSignature is returned if a block's extra-data section doesn't seem
+// to contain a 65 byte secp256k1 signature.
+var errMissingSignature = errors.New("extra-data 65 byte signature suffix missing")
+
+// SealHash returns the hash of a block prior to it being sealed.
+func SealHash(header *types.Header) (hash common.Hash) {
+	hasher := sha3.NewLegacyKeccak256()
+	EncodeSigHeader(hasher, header)
+	hasher.Sum(hash[:0])
+	return hash
+}
+
+// EncodeSigHeader writes the RLP signed by the validator sealing the header,
+// which consists of the entire header apart from the 65 byte signature
+// contained at the end of the extra data.
+//
+// Note, the method requires the extra data to be at least 65 bytes, otherwise it
+// panics. This is done to avoid accidentally using both forms (signature present
+// or not), which could be abused to produce different hashes for the same header.
+func EncodeSigHeader(w io.Writer, header *types.Header) {
+	err := rlp.Encode(w, []interface{}{
+		header.ParentHash,
+		header.UncleHash,
+		header.Coinbase,
+		header.Root,
+		header.TxHash,
+		header.ReceiptHash,
+		header.Bloom,
+		header.Difficulty,
+		header.Number,
+		header.GasLimit,
+		header.GasUsed,
+		header.Time,
+		header.Extra[:len(header.Extra)-crypto.SignatureLength], // Yes, this will panic if extra is too short
+		header.MixDigest,
+		header.Nonce,
+	})
+	if err != nil {
+		panic("can't encode: " + err.Error())
+	}
+}
+
+// RecoverSigner extracts the address of the validator that sealed the header.
+func RecoverSigner(header *types.Header) (common.Address, error) {
+	if len(header.Extra) < ExtraSeal {
+		return common.Address{}, errMissingSignature
+	}
+	signature := header.Extra[len(header.Extra)-ExtraSeal:]
+
+	pubkey, err := crypto.Ecrecover(SealHash(header).Bytes(), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var validator common.Address
+	copy(validator[:], crypto.Keccak256(pubkey[1:])[12:])
+	return validator, nil
+}
+
+// EpochValidators returns the validator set recorded in the extra-data of an
+// epoch block, empty for any other block.
+func EpochValidators(header *types.Header) []common.Address {
+	validators, _ := epochSections(header)
+	return decodeAddresses(validators)
+}
+
+// EpochJailed returns the jailed validators recorded in the extra-data of an
+// epoch block from the jail fork, empty for any other block.
+func EpochJailed(header *types.Header) []common.Address {
+	_, jailed := epochSections(header)
+	return decodeAddresses(jailed)
+}
+
+// EncodeJailed returns the jail section of the extra-data of an epoch block
+// from the jail fork: the jailed validators followed by their count.
+func EncodeJailed(jailed []common.Address) []byte {
+	section := make([]byte, 0, len(jailed)*common.AddressLength+1)
+	for _, validator := range jailed {
+		section = append(section, validator.Bytes()...)
+	}
+	return append(section, byte(len(jailed)))
+}
+
+// ValidExtra reports whether the extra-data of the block, known to hold the
+// vanity and the seal, records the sections expected at its height: none for
+// a block within an epoch, the validator set for an epoch block, followed by
+// the jail section from the jail fork.
+func ValidExtra(config *params.TurboConfig, number uint64, extra []byte) bool {
+	size := len(extra) - ExtraVanity - ExtraSeal
+	switch {
+	case !config.IsEpoch(number):
+		return size == 0
+	case !config.IsJail(new(big.Int).SetUint64(number)):
+		return size%common.AddressLength == 0
+	default:
+		return size%common.AddressLength == 1 && int(extra[ExtraVanity+size-1])*common.AddressLength < size
+	}
+}
+
+// epochSections splits the extra-data of an epoch block into the validator set
+// and the jail section. The jail section, recorded from the jail fork, ends
+// with the count of the jailed validators on one byte, which tells the forms
+// apart by their length.
+func epochSections(header *types.Header) (validators, jailed []byte) {
+	if len(header.Extra) < ExtraVanity+ExtraSeal {
+		return nil, nil
+	}
+	data := header.Extra[ExtraVanity : len(header.Extra)-ExtraSeal]
+	if len(data)%common.AddressLength != 1 {
+		return data, nil
+	}
+	size := int(data[len(data)-1]) * common.AddressLength
+	if size >= len(data) {
+		return nil, nil
+	}
+	split := len(data) - 1 - size
+	return data[:split], data[split : len(data)-1]
+}
+
+// decodeAddresses splits the section into addresses, dropping any trailing
+// partial address.
+func decodeAddresses(section []byte) []common.Address {
+	addrs := make([]common.Address, len(section)/common.AddressLength)
+	for i := range addrs {
+		copy(addrs[i][:], section[i*common.AddressLength:])
+	}
+	return addrs
+}
+
+// ValidatorCheckpoint returns the number of the epoch header recording the
+// validator set in effect after the given block. The validators recorded at an
+// epoch block only take over at the next epoch block, the genesis validators
+// are in effect until the end of the second epoch.
+func ValidatorCheckpoint(config *params.TurboConfig, number uint64) uint64 {
+	last := config.LastEpoch(number)
+	if last == 0 {
+		return 0
+	}
+	return config.LastEpoch(last - 1)
+}
+
+// StrictTime reports whether the header follows the strict timestamps rules:
+// it must be stamped after its parent, and after the slot ending the period if
+// sealed out of turn, that slot being reserved to the in-turn validator.
+func StrictTime(parent, header *types.Header, period uint64) bool {
+	if header.Difficulty.Cmp(diffNoTurn) == 0 {
+		return header.Time > parent.Time+period
+	}
+	return header.Time > parent.Time
+}
+
+// ValidatorSet is a set of validators in ascending order.
+type ValidatorSet []common.Address
+
+// NewValidatorSet creates the set of the given validators, dropping the
+// duplicates.
+func NewValidatorSet(validators []common.Address) ValidatorSet {
+	var (
+		seen = make(map[common.Address]struct{})
+		set  = make(ValidatorSet, 0, len(validators))
+	)
+	for _, validator := range validators {
+		if _, ok := seen[validator]; !ok {
+			seen[validator] = struct{}{}
+			set = append(set, validator)
+		}
+	}
+	sort.Slice(set, func(i, j int) bool { return bytes.Compare(set[i][:], set[j][:]) < 0 })
+	return set
+}
+
+// index returns the rank of the validator in the set, the size of the set if
+// it's not part of it.
+func (s ValidatorSet) index(validator common.Address) int {
+	i := sort.Search(len(s), func(i int) bool { return bytes.Compare(s[i][:], validator[:]) >= 0 })
+	if i < len(s) && s[i] == validator {
+		return i
+	}
+	return len(s)
+}
+
+// Contains returns whether the validator is part of the set.
+func (s ValidatorSet) Contains(validator common.Address) bool {
+	return s.index(validator) < len(s)
+}
+
+// intersect returns the set of the given validators that are part of the set.
+func (s ValidatorSet) intersect(validators []common.Address) ValidatorSet {
+	set := make(ValidatorSet, 0, len(validators))
+	for _, validator := range NewValidatorSet(validators) {
+		if s.Contains(validator) {
+			set = append(set, validator)
+		}
+	}
+	return set
+}
+
+// proposers returns the validators taking turns to seal the blocks: the
+// validators less the jailed ones, unless all of them are jailed, which would
+// halt the chain.
+func proposers(validators, jailed ValidatorSet) ValidatorSet {
+	if len(jailed) == 0 || len(jailed) >= len(validators) {
+		return validators
+	}
+	set := make(ValidatorSet, 0, len(validators)-len(jailed))
+	for _, validator := range validators {
+		if !jailed.Contains(validator) {
+			set = append(set, validator)
+		}
+	}
+	return set
+}
@@ -0,0 +1,271 @@
+package light
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	diffInTurn = big.NewInt(2) // Block difficulty for in-turn signatures
+	diffNoTurn = big.NewInt(1) // Block difficulty for out-of-turn signatures
+)
+
+var (
+	errNotTurbo              = errors.New("not a turbo chain")
+	errNotCheckpoint         = errors.New("trusted header is not an epoch header")
+	errUnknownAncestor       = errors.New("unknown ancestor")
+	errUnknownBlock          = errors.New("unknown block")
+	errMissingVanity         = errors.New("extra-data 32 byte vanity prefix missing")
+	errExtraValidators       = errors.New("non-checkpoint block contains extra validator list")
+	errInvalidMixDigest      = errors.New("non-zero mix digest")
+	errInvalidUncleHash      = errors.New("non empty uncle hash")
+	errInvalidDifficulty     = errors.New("invalid difficulty")
+	errWrongDifficulty       = errors.New("wrong difficulty")
+	errInvalidTimestamp      = errors.New("invalid timestamp")
+	errInvalidCoinbase       = errors.New("invalid coin base")
+	errUnauthorizedValidator = errors.New("unauthorized validator")
+	errJailedValidator       = errors.New("jailed validator")
+	errRecentlySigned        = errors.New("recently signed")
+)
+
+// Verifier follows a Turbo header chain from a trusted epoch header, tracking
+// the validator set the headers are sealed by.
+type Verifier struct {
+	config *params.ChainConfig
+
+	head       *types.Header
+	validators ValidatorSet              // Validators in effect after the head
+	jailed     ValidatorSet              // Jailed validators of the set in effect, recorded at the last epoch header
+	pending    ValidatorSet              // Validators recorded at the last epoch header, taking over at the next one
+	recents    map[uint64]common.Address // Recent signers by block number
+
+	parent           *types.Header // Parent of the head, nil at the trusted header
+	parentValidators ValidatorSet  // Validators in effect after the parent
+}
+
+// NewVerifier creates a verifier trusting the given epoch header. The epoch
+// header preceding it, recording the validator set in effect after the
+// trusted header, must be given too unless the trusted header is the genesis.
+//
+// The recent signers before the trusted header are unknown, so the headers
+// following it within half of the validator set are not checked against the
+// signers that sealed the headers preceding it.
+func NewVerifier(config *params.ChainConfig, trusted, previous *types.Header) (*Verifier, error) {
+	if config.Turbo == nil || config.Turbo.Epoch == 0 {
+		return nil, errNotTurbo
+	}
+	number := trusted.Number.Uint64()
+	if !config.Turbo.IsEpoch(number) {
+		return nil, errNotCheckpoint
+	}
+	v := &Verifier{
+		config:  config,
+		head:    trusted,
+		pending: NewValidatorSet(EpochValidators(trusted)),
+		recents: make(map[uint64]common.Address),
+	}
+	if number == 0 {
+		v.validators = v.pending
+	} else {
+		if checkpoint := ValidatorCheckpoint(config.Turbo, number); previous == nil || previous.Number.Uint64() != checkpoint {
+			return nil, fmt.Errorf("%w: epoch header %d required", errUnknownAncestor, checkpoint)
+		}
+		v.validators = NewValidatorSet(EpochValidators(previous))
+	}
+	v.jailed = v.validators.intersect(EpochJailed(trusted))
+	return v, nil
+}
+
+// Head returns the last verified header.
+func (v *Verifier) Head() *types.Header {
+	return v.head
+}
+
+// Validators returns the validator set in effect after the last verified
+// header, i.e. the set sealing the next header and attesting the head.
+func (v *Verifier) Validators() ValidatorSet {
+	return v.validators
+}
+
+// InsertHeaders verifies the headers in order as descendants of the head,
+// returning the number of headers inserted before the first failure.
+func (v *Verifier) InsertHeaders(headers []*types.Header) (int, error) {
+	for i, header := range headers {
+		if err := v.InsertHeader(header); err != nil {
+			return i, fmt.Errorf("header %d: %w", header.Number, err)
+		}
+	}
+	return len(headers), nil
+}
+
+// InsertHeader verifies the header as the child of the head, making it the
+// new head if valid.
+func (v *Verifier) InsertHeader(header *types.Header) error {
+	if err := v.verifyStandaloneFields(header); err != nil {
+		return err
+	}
+	number := header.Number.Uint64()
+	if number != v.head.Number.Uint64()+1 || header.ParentHash != v.head.Hash() {
+		return errUnknownAncestor
+	}
+	if v.head.Time+v.config.Turbo.PeriodAt(number) > header.Time {
+		return errInvalidTimestamp
+	}
+	if v.config.Turbo.IsStrictTime(header.Number) && !StrictTime(v.head, header, v.config.Turbo.PeriodAt(number)) {
+		return errInvalidTimestamp
+	}
+	if header.GasUsed > header.GasLimit {
+		return fmt.Errorf("invalid gasUsed: have %d, gasLimit %d", header.GasUsed, header.GasLimit)
+	}
+	signer, err := RecoverSigner(header)
+	if err != nil {
+		return err
+	}
+	if signer != header.Coinbase {
+		return errInvalidCoinbase
+	}
+	if !v.validators.Contains(signer) {
+		return errUnauthorizedValidator
+	}
+	if !v.proposers().Contains(signer) {
+		return errJailedValidator
+	}
+	if v.signedRecently(number, signer) {
+		return errRecentlySigned
+	}
+	want := diffNoTurn
+	if v.inturn(number, signer) {
+		want = diffInTurn
+	}
+	if header.Difficulty.Cmp(want) != 0 {
+		return errWrongDifficulty
+	}
+	v.apply(header, signer)
+	return nil
+}
+
+// VerifyFinality checks that the proof finalizes the parent of the head, the
+// head being its child.
+func (v *Verifier) VerifyFinality(proof *FinalityProof) error {
+	if v.parent == nil {
+		return errUnknownBlock
+	}
+	return VerifyFinality(v.parent, v.head, v.parentValidators, v.validators, proof)
+}
+
+// verifyStandaloneFields checks the header fields that don't depend on other
+// headers.
+func (v *Verifier) verifyStandaloneFields(header *types.Header) error {
+	if header.Number == nil {
+		return errUnknownBlock
+	}
+	number := header.Number.Uint64()
+
+	// Check that the extra-data contains the vanity, validators and signature
+	if len(header.Extra) < ExtraVanity {
+		return errMissingVanity
+	}
+	if len(header.Extra) < ExtraVanity+ExtraSeal {
+		return errMissingSignature
+	}
+	// Ensure that the extra-data contains a validator list on checkpoint, but none otherwise
+	if !ValidExtra(v.config.Turbo, number, header.Extra) {
+		return errExtraValidators
+	}
+	if header.MixDigest != (common.Hash{}) {
+		return errInvalidMixDigest
+	}
+	if header.UncleHash != types.EmptyUncleHash {
+		return errInvalidUncleHash
+	}
+	if header.Difficulty == nil {
+		return errInvalidDifficulty
+	}
+	if header.GasLimit > params.MaxGasLimit {
+		return fmt.Errorf("invalid gasLimit: have %v, max %v", header.GasLimit, params.MaxGasLimit)
+	}
+	if header.WithdrawalsHash != nil {
+		return fmt.Errorf("invalid withdrawalsHash: have %x, expected nil", header.WithdrawalsHash)
+	}
+	switch {
+	case header.ExcessBlobGas != nil:
+		return fmt.Errorf("invalid excessBlobGas: have %d, expected nil", header.ExcessBlobGas)
+	case header.BlobGasUsed != nil:
+		return fmt.Errorf("invalid blobGasUsed: have %d, expected nil", header.BlobGasUsed)
+	case header.ParentBeaconRoot != nil:
+		return fmt.Errorf("invalid parentBeaconRoot, have %#x, expected nil", header.ParentBeaconRoot)
+	}
+	return nil
+}
+
+// continuousInturn returns the number of consecutive blocks a validator seals
+// in its turn.
+func (v *Verifier) continuousInturn(number uint64) uint64 {
+	return v.config.TurboContinuousInturn(new(big.Int).SetUint64(number))
+}
+
+// proposers returns the validators taking turns to seal the headers: the
+// validators in effect less the jailed ones, unless all of them are jailed.
+func (v *Verifier) proposers() ValidatorSet {
+	return proposers(v.validators, v.jailed)
+}
+
+// signedRecently returns whether the validator sealed too many of the recent
+// blocks to seal the given one, the oldest of the window being shifted out by
+// it.
+func (v *Verifier) signedRecently(number uint64, validator common.Address) bool {
+	continuous := v.continuousInturn(number)
+	limit := uint64(len(v.proposers())/2+1) * continuous
+	var count uint64
+	for n, recent := range v.recents {
+		if n != number-limit && recent == validator {
+			count++
+		}
+	}
+	return count >= continuous
+}
+
+// inturn returns whether the validator is in turn to seal the given block.
+func (v *Verifier) inturn(number uint64, validator common.Address) bool {
+	var (
+		continuous = v.continuousInturn(number)
+		proposers  = v.proposers()
+	)
+	return (number%(uint64(len(proposers))*continuous))/continuous == uint64(proposers.index(validator))
+}
+
+// apply makes the verified header the head, updating the recent signers and
+// the validator set at the epoch headers.
+func (v *Verifier) apply(header *types.Header, signer common.Address) {
+	var (
+		number     = header.Number.Uint64()
+		continuous = v.continuousInturn(number)
+	)
+	if limit := uint64(len(v.proposers())/2+1) * continuous; number >= limit {
+		delete(v.recents, number-limit)
+	}
+	v.recents[number] = signer
+	v.parent, v.parentValidators = v.head, v.validators
+
+	if v.config.Turbo.IsEpoch(number) {
+		// The validators recorded at the previous epoch header take over with
+		// the jails recorded at this one, the recent signers beyond the window
+		// of the new proposers are dropped
+		var (
+			jailed = v.pending.intersect(EpochJailed(header))
+			prev   = len(v.proposers())
+			next   = len(proposers(v.pending, jailed))
+			limit  = uint64(next/2+1) * continuous
+		)
+		for i := 0; i < (prev/2-next/2)*int(continuous); i++ {
+			delete(v.recents, number-limit-uint64(i))
+		}
+		v.validators, v.jailed, v.pending = v.pending, jailed, NewValidatorSet(EpochValidators(header))
+	}
+	v.head = header
+}
@@ -0,0 +1,469 @@
+package light
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const testEpoch = 4
+
+// testChain seals headers with deterministic validator keys.
+type testChain struct {
+	config  *params.ChainConfig
+	keys    map[common.Address]*ecdsa.PrivateKey
+	headers []*types.Header
+	jailed  []common.Address // Jails recorded at the epoch headers after the jail fork
+}
+
+func newTestChain(t *testing.T, validators int) *testChain {
+	config := *params.AllTurboProtocolChanges
+	config.Turbo = &params.TurboConfig{Period: 1, Epoch: testEpoch}
+
+	c := &testChain{config: &config, keys: make(map[common.Address]*ecdsa.PrivateKey)}
+	for i := 0; i < 8; i++ {
+		key, _ := crypto.ToECDSA(crypto.Keccak256([]byte{byte(i)}))
+		c.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	}
+	genesis := &types.Header{
+		Number:     new(big.Int),
+		Difficulty: big.NewInt(2),
+		UncleHash:  types.EmptyUncleHash,
+		Extra:      c.epochExtra(c.addresses()[:validators]),
+	}
+	c.headers = append(c.headers, genesis)
+	return c
+}
+
+// addresses returns the addresses of the keys in ascending order.
+func (c *testChain) addresses() []common.Address {
+	var addrs []common.Address
+	for addr := range c.keys {
+		addrs = append(addrs, addr)
+	}
+	return NewValidatorSet(addrs)
+}
+
+func (c *testChain) epochExtra(validators []common.Address) []byte {
+	extra := make([]byte, ExtraVanity, ExtraVanity+len(validators)*common.AddressLength+ExtraSeal)
+	for _, validator := range validators {
+		extra = append(extra, validator[:]...)
+	}
+	return append(extra, make([]byte, ExtraSeal)...)
+}
+
+// seal creates the next header sealed by the signer. The validators, and the
+// jails after the jail fork, are recorded if it's an epoch header.
+func (c *testChain) seal(t *testing.T, signer common.Address, difficulty int64, validators []common.Address) *types.Header {
+	var (
+		parent = c.headers[len(c.headers)-1]
+		number = parent.Number.Uint64() + 1
+	)
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).SetUint64(number),
+		Time:       parent.Time + c.config.Turbo.PeriodAt(number),
+		GasLimit:   params.GenesisGasLimit,
+		Difficulty: big.NewInt(difficulty),
+		UncleHash:  types.EmptyUncleHash,
+		Coinbase:   signer,
+		Extra:      make([]byte, ExtraVanity+ExtraSeal),
+	}
+	if c.config.Turbo.IsEpoch(number) {
+		header.Extra = c.epochExtra(validators)
+		if c.config.Turbo.IsJail(header.Number) {
+			jails := append(header.Extra[:len(header.Extra)-ExtraSeal:len(header.Extra)-ExtraSeal], EncodeJailed(c.jailed)...)
+			header.Extra = append(jails, make([]byte, ExtraSeal)...)
+		}
+	}
+	sig, err := crypto.Sign(SealHash(header).Bytes(), c.keys[signer])
+	if err != nil {
+		t.Fatalf("failed to seal header: %v", err)
+	}
+	copy(header.Extra[len(header.Extra)-ExtraSeal:], sig)
+	c.headers = append(c.headers, header)
+	return header
+}
+
+// sealInTurn creates the next headers sealed in turn by the given validator
+// set, recording the next set at the epoch headers.
+func (c *testChain) sealInTurn(t *testing.T, n int, validators, next []common.Address) {
+	for i := 0; i < n; i++ {
+		number := c.headers[len(c.headers)-1].Number.Uint64() + 1
+		c.seal(t, validators[number%uint64(len(validators))], 2, next)
+	}
+}
+
+func (c *testChain) attest(t *testing.T, signers []common.Address, header *types.Header) []*types.Attestation {
+	var (
+		source = &types.RangeEdge{Hash: c.headers[0].Hash(), Number: new(big.Int)}
+		target = &types.RangeEdge{Hash: header.Hash(), Number: header.Number}
+		atts   []*types.Attestation
+	)
+	for _, signer := range signers {
+		sig, err := crypto.Sign(types.AttestationSignHash(source, target).Bytes(), c.keys[signer])
+		if err != nil {
+			t.Fatalf("failed to sign attestation: %v", err)
+		}
+		atts = append(atts, types.NewAttestation(source, target, sig))
+	}
+	return atts
+}
+
+// Tests that the verifier follows the validator set transitions: the set
+// recorded at an epoch header takes over at the next epoch header.
+func TestVerifierValidatorTransitions(t *testing.T) {
+	var (
+		chain = newTestChain(t, 3)
+		all   = chain.addresses()
+		old   = all[:3]
+		grown = all[:5]
+	)
+	chain.sealInTurn(t, 2*testEpoch, old, grown)
+	chain.sealInTurn(t, 2*testEpoch, grown, grown)
+
+	verifier, err := NewVerifier(chain.config, chain.headers[0], nil)
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	for _, header := range chain.headers[1:] {
+		if err := verifier.InsertHeader(header); err != nil {
+			t.Fatalf("header %d: %v", header.Number, err)
+		}
+		want := old
+		if header.Number.Uint64() >= 2*testEpoch {
+			want = grown
+		}
+		if have := verifier.Validators(); len(have) != len(want) {
+			t.Fatalf("header %d: validator count mismatch: have %d, want %d", header.Number, len(have), len(want))
+		}
+	}
+	// Following the chain from a trusted epoch header
+	verifier, err = NewVerifier(chain.config, chain.headers[2*testEpoch], chain.headers[testEpoch])
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	if n, err := verifier.InsertHeaders(chain.headers[2*testEpoch+1:]); err != nil {
+		t.Fatalf("failed to insert header %d: %v", n, err)
+	}
+	if _, err := NewVerifier(chain.config, chain.headers[2*testEpoch], chain.headers[0]); err == nil {
+		t.Errorf("wrong previous epoch header accepted")
+	}
+	if _, err := NewVerifier(chain.config, chain.headers[1], nil); !errors.Is(err, errNotCheckpoint) {
+		t.Errorf("non-epoch trusted header error mismatch: have %v, want %v", err, errNotCheckpoint)
+	}
+}
+
+// Tests that the verifier follows the block period and epoch length changes
+// scheduled in the config.
+func TestVerifierSchedule(t *testing.T) {
+	var (
+		chain = newTestChain(t, 3)
+		all   = chain.addresses()
+		old   = all[:3]
+		grown = all[:5]
+	)
+	chain.config.Turbo.Schedule = []params.TurboSchedule{{Block: big.NewInt(2 * testEpoch), Period: 2, Epoch: 15}}
+
+	// The validators recorded at block 8 take over at block 23
+	chain.sealInTurn(t, testEpoch, old, old)
+	chain.sealInTurn(t, testEpoch, old, grown)
+	chain.sealInTurn(t, 15, old, grown)
+	chain.sealInTurn(t, 4, grown, grown)
+	if have := chain.headers[2*testEpoch+1].Time - chain.headers[2*testEpoch].Time; have != 2 {
+		t.Fatalf("period mismatch after the change: have %d, want 2", have)
+	}
+	verifier, err := NewVerifier(chain.config, chain.headers[0], nil)
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	for _, header := range chain.headers[1:] {
+		if err := verifier.InsertHeader(header); err != nil {
+			t.Fatalf("header %d: %v", header.Number, err)
+		}
+		want := old
+		if header.Number.Uint64() >= 23 {
+			want = grown
+		}
+		if have := verifier.Validators(); len(have) != len(want) {
+			t.Fatalf("header %d: validator count mismatch: have %d, want %d", header.Number, len(have), len(want))
+		}
+	}
+	// Following the chain from the epoch header after the change
+	verifier, err = NewVerifier(chain.config, chain.headers[23], chain.headers[2*testEpoch])
+	if err != nil {
+		t.Fatalf("failed to create verifier after the change: %v", err)
+	}
+	if n, err := verifier.InsertHeaders(chain.headers[24:]); err != nil {
+		t.Fatalf("failed to insert header %d: %v", n, err)
+	}
+	if _, err := NewVerifier(chain.config, chain.headers[12], nil); !errors.Is(err, errNotCheckpoint) {
+		t.Errorf("former epoch header error mismatch: have %v, want %v", err, errNotCheckpoint)
+	}
+	// Headers sealed at the former period are rejected after the change
+	chain.headers = chain.headers[:2*testEpoch+1]
+	signer := old[(2*testEpoch+1)%3]
+	header := chain.seal(t, signer, 2, nil)
+	header.Time--
+	sig, _ := crypto.Sign(SealHash(header).Bytes(), chain.keys[signer])
+	copy(header.Extra[len(header.Extra)-ExtraSeal:], sig)
+
+	verifier, _ = NewVerifier(chain.config, chain.headers[0], nil)
+	if _, err := verifier.InsertHeaders(chain.headers[1 : 2*testEpoch+1]); err != nil {
+		t.Fatalf("failed to insert headers: %v", err)
+	}
+	if err := verifier.InsertHeader(header); !errors.Is(err, errInvalidTimestamp) {
+		t.Errorf("early header error mismatch: have %v, want %v", err, errInvalidTimestamp)
+	}
+}
+
+// Tests that the out-of-turn headers can't take the slot of the in-turn
+// validator after the strict timestamps fork.
+func TestVerifierStrictTime(t *testing.T) {
+	chain := newTestChain(t, 3)
+	validators := chain.addresses()[:3]
+	chain.config.Turbo.StrictTimeBlock = big.NewInt(3)
+	chain.sealInTurn(t, 2, validators, validators)
+
+	// restamp reseals the last header at the given delay after its parent
+	restamp := func(signer common.Address, delay uint64) *types.Header {
+		header := chain.headers[len(chain.headers)-1]
+		header.Time = chain.headers[len(chain.headers)-2].Time + delay
+		sig, _ := crypto.Sign(SealHash(header).Bytes(), chain.keys[signer])
+		copy(header.Extra[len(header.Extra)-ExtraSeal:], sig)
+		return header
+	}
+	tests := []struct {
+		inturn bool
+		delay  uint64
+		err    error
+	}{
+		{true, 1, nil},
+		{false, 1, errInvalidTimestamp},
+		{false, 2, nil},
+	}
+	for i, tt := range tests {
+		verifier, _ := NewVerifier(chain.config, chain.headers[0], nil)
+		if _, err := verifier.InsertHeaders(chain.headers[1:3]); err != nil {
+			t.Fatalf("test %d: failed to insert headers: %v", i, err)
+		}
+		signer, difficulty := validators[0], int64(2)
+		if !tt.inturn {
+			signer, difficulty = validators[1], 1
+		}
+		chain.seal(t, signer, difficulty, nil)
+		if err := verifier.InsertHeader(restamp(signer, tt.delay)); !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		chain.headers = chain.headers[:3]
+	}
+}
+
+// Tests that the validators jailed at an epoch header after the jail fork are
+// left out of the rotation until the next epoch header releases them.
+func TestVerifierJails(t *testing.T) {
+	var (
+		chain      = newTestChain(t, 4)
+		validators = chain.addresses()[:4]
+		proposers  = []common.Address{validators[0], validators[2], validators[3]}
+	)
+	chain.config.Turbo.JailBlock = big.NewInt(testEpoch)
+	chain.sealInTurn(t, testEpoch-1, validators, validators)
+	chain.jailed = validators[1:2]
+	chain.sealInTurn(t, 1, validators, validators)
+	chain.jailed = nil
+	chain.sealInTurn(t, testEpoch, proposers, validators)
+	chain.sealInTurn(t, 2, validators, validators)
+
+	verifier, err := NewVerifier(chain.config, chain.headers[0], nil)
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	if n, err := verifier.InsertHeaders(chain.headers[1:]); err != nil {
+		t.Fatalf("failed to insert header %d: %v", n, err)
+	}
+	// Following the chain from the epoch header recording the jail
+	verifier, err = NewVerifier(chain.config, chain.headers[testEpoch], chain.headers[0])
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	if n, err := verifier.InsertHeaders(chain.headers[testEpoch+1:]); err != nil {
+		t.Fatalf("failed to insert header %d: %v", n, err)
+	}
+	// The jailed validator can't seal, nor the jails be left out after the fork
+	chain.headers = chain.headers[:testEpoch]
+	epoch := chain.seal(t, validators[0], 2, validators)
+	epoch.Extra = chain.epochExtra(validators)
+
+	verifier, _ = NewVerifier(chain.config, chain.headers[0], nil)
+	if _, err := verifier.InsertHeaders(chain.headers[1:testEpoch]); err != nil {
+		t.Fatalf("failed to insert headers: %v", err)
+	}
+	if err := verifier.InsertHeader(epoch); !errors.Is(err, errExtraValidators) {
+		t.Errorf("epoch header without jails error mismatch: have %v, want %v", err, errExtraValidators)
+	}
+	chain.headers = chain.headers[:testEpoch]
+	chain.jailed = validators[1:2]
+	chain.sealInTurn(t, 1, validators, validators)
+	jailed := chain.seal(t, validators[1], 1, nil)
+
+	verifier, _ = NewVerifier(chain.config, chain.headers[0], nil)
+	if _, err := verifier.InsertHeaders(chain.headers[1 : testEpoch+1]); err != nil {
+		t.Fatalf("failed to insert headers: %v", err)
+	}
+	if err := verifier.InsertHeader(jailed); !errors.Is(err, errJailedValidator) {
+		t.Errorf("jailed signer error mismatch: have %v, want %v", err, errJailedValidator)
+	}
+}
+
+func TestVerifierRejects(t *testing.T) {
+	chain := newTestChain(t, 3)
+	validators := chain.addresses()[:3]
+	chain.sealInTurn(t, 2, validators, validators)
+
+	tests := []struct {
+		name   string
+		header func() *types.Header
+		err    error
+	}{
+		{
+			name: "out-of-turn difficulty in turn",
+			header: func() *types.Header {
+				return chain.seal(t, validators[3%3], 1, nil)
+			},
+			err: errWrongDifficulty,
+		},
+		{
+			name: "in-turn difficulty out of turn",
+			header: func() *types.Header {
+				return chain.seal(t, validators[1], 2, nil)
+			},
+			err: errWrongDifficulty,
+		},
+		{
+			name: "recently signed",
+			header: func() *types.Header {
+				return chain.seal(t, validators[2], 1, nil)
+			},
+			err: errRecentlySigned,
+		},
+		{
+			name: "unauthorized",
+			header: func() *types.Header {
+				return chain.seal(t, chain.addresses()[5], 1, nil)
+			},
+			err: errUnauthorizedValidator,
+		},
+		{
+			name: "coinbase not the signer",
+			header: func() *types.Header {
+				header := types.CopyHeader(chain.seal(t, validators[0], 2, nil))
+				header.Coinbase = validators[1]
+				return header
+			},
+			err: errInvalidCoinbase,
+		},
+		{
+			name: "too early",
+			header: func() *types.Header {
+				header := chain.seal(t, validators[0], 2, nil)
+				header.Time--
+				sig, _ := crypto.Sign(SealHash(header).Bytes(), chain.keys[validators[0]])
+				copy(header.Extra[len(header.Extra)-ExtraSeal:], sig)
+				return header
+			},
+			err: errInvalidTimestamp,
+		},
+		{
+			name: "unknown parent",
+			header: func() *types.Header {
+				chain.seal(t, validators[0], 2, nil)
+				return chain.seal(t, validators[1], 1, nil)
+			},
+			err: errUnknownAncestor,
+		},
+	}
+	for _, tt := range tests {
+		verifier, err := NewVerifier(chain.config, chain.headers[0], nil)
+		if err != nil {
+			t.Fatalf("failed to create verifier: %v", err)
+		}
+		if _, err := verifier.InsertHeaders(chain.headers[1:3]); err != nil {
+			t.Fatalf("%s: failed to insert headers: %v", tt.name, err)
+		}
+		if err := verifier.InsertHeader(tt.header()); !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+		chain.headers = chain.headers[:3]
+	}
+}
+
+func TestVerifierFinality(t *testing.T) {
+	chain := newTestChain(t, 4)
+	validators := chain.addresses()[:4]
+	chain.sealInTurn(t, 6, validators, validators)
+
+	verifier, err := NewVerifier(chain.config, chain.headers[0], nil)
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+	if err := verifier.VerifyFinality(&FinalityProof{}); !errors.Is(err, errUnknownBlock) {
+		t.Errorf("finality of the trusted header: have %v, want %v", err, errUnknownBlock)
+	}
+	if _, err := verifier.InsertHeaders(chain.headers[1:]); err != nil {
+		t.Fatalf("failed to insert headers: %v", err)
+	}
+	var (
+		target, child = chain.headers[5], chain.headers[6]
+		outsider      = chain.addresses()[6]
+	)
+	tests := []struct {
+		name  string
+		proof *FinalityProof
+		err   error
+	}{
+		{
+			name:  "finalized",
+			proof: &FinalityProof{Target: chain.attest(t, validators[:3], target), Child: chain.attest(t, validators[1:], child)},
+		},
+		{
+			name:  "target below threshold",
+			proof: &FinalityProof{Target: chain.attest(t, validators[:2], target), Child: chain.attest(t, validators, child)},
+			err:   ErrNotJustified,
+		},
+		{
+			name:  "duplicated attestations",
+			proof: &FinalityProof{Target: chain.attest(t, []common.Address{validators[0], validators[0], validators[1]}, target), Child: chain.attest(t, validators, child)},
+			err:   ErrNotJustified,
+		},
+		{
+			name:  "child below threshold",
+			proof: &FinalityProof{Target: chain.attest(t, validators, target), Child: chain.attest(t, validators[:2], child)},
+			err:   ErrNotJustified,
+		},
+		{
+			name:  "outsider attestation",
+			proof: &FinalityProof{Target: chain.attest(t, []common.Address{outsider, validators[0], validators[1]}, target), Child: chain.attest(t, validators, child)},
+			err:   errNotValidator,
+		},
+		{
+			name:  "attestations swapped",
+			proof: &FinalityProof{Target: chain.attest(t, validators, child), Child: chain.attest(t, validators, target)},
+			err:   errForeignTarget,
+		},
+	}
+	for _, tt := range tests {
+		if err := verifier.VerifyFinality(tt.proof); !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+	}
+	if err := VerifyFinality(chain.headers[4], child, validators, validators, tests[0].proof); !errors.Is(err, errNotChild) {
+		t.Errorf("non-child error mismatch: have %v, want %v", err, errNotChild)
+	}
+}
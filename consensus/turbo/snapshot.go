@@ -23,12 +23,13 @@ type Snapshot struct {
 	Hash       common.Hash                 `json:"hash"`       // Block hash where the snapshot was created
 	Validators map[common.Address]struct{} `json:"validators"` // Set of authorized validators at this moment
 	Recents    map[uint64]common.Address   `json:"recents"`    // Set of recent validators for spam protections
+	Jailed     map[common.Address]uint64   `json:"jails"`      // Jailed validators recorded at the epoch blocks, with the block first recording them
 }
 
 // newSnapshot creates a new snapshot with the specified startup parameters. This
 // method does not initialize the set of recent validators, so only ever use if for
 // the genesis block.
-func newSnapshot(config *params.ChainConfig, sigcache *lru.ARCCache, number uint64, hash common.Hash, validators []common.Address) *Snapshot {
+func newSnapshot(config *params.ChainConfig, sigcache *lru.ARCCache, number uint64, hash common.Hash, validators []common.Address, jailed []common.Address) *Snapshot {
 	snap := &Snapshot{
 		config:     config,
 		sigcache:   sigcache,
@@ -36,14 +37,22 @@ func newSnapshot(config *params.ChainConfig, sigcache *lru.ARCCache, number uint
 		Hash:       hash,
 		Validators: make(map[common.Address]struct{}),
 		Recents:    make(map[uint64]common.Address),
+		Jailed:     make(map[common.Address]uint64),
 	}
 	for _, validator := range validators {
 		snap.Validators[validator] = struct{}{}
 	}
+	for _, validator := range jailed {
+		if _, ok := snap.Validators[validator]; ok {
+			snap.Jailed[validator] = number
+		}
+	}
 	return snap
 }
 
-// loadSnapshot loads an existing snapshot from the database.
+// loadSnapshot loads an existing snapshot from the database. The jails stored
+// before the jail fork under the "jailed" key were read from the state on a best
+// effort basis, they are dropped in favour of the ones recorded in the headers.
 func loadSnapshot(config *params.ChainConfig, sigcache *lru.ARCCache, db ethdb.Database, hash common.Hash) (*Snapshot, error) {
 	blob, err := db.Get(append([]byte("turbo-"), hash[:]...))
 	if err != nil {
@@ -55,6 +64,9 @@ func loadSnapshot(config *params.ChainConfig, sigcache *lru.ARCCache, db ethdb.D
 	}
 	snap.config = config
 	snap.sigcache = sigcache
+	if snap.Jailed == nil {
+		snap.Jailed = make(map[common.Address]uint64)
+	}
 
 	return snap, nil
 }
@@ -77,6 +89,7 @@ func (s *Snapshot) copy() *Snapshot {
 		Hash:       s.Hash,
 		Validators: make(map[common.Address]struct{}),
 		Recents:    make(map[uint64]common.Address),
+		Jailed:     make(map[common.Address]uint64),
 	}
 	for validator := range s.Validators {
 		cpy.Validators[validator] = struct{}{}
@@ -84,6 +97,9 @@ func (s *Snapshot) copy() *Snapshot {
 	for block, validator := range s.Recents {
 		cpy.Recents[block] = validator
 	}
+	for validator, number := range s.Jailed {
+		cpy.Jailed[validator] = number
+	}
 
 	return cpy
 }
@@ -91,7 +107,7 @@ func (s *Snapshot) copy() *Snapshot {
 // SignedRecently checks whether the validator signed block recently
 func (s *Snapshot) SignedRecently(block uint64, validator common.Address) bool {
 	continuousInturn := s.config.TurboContinuousInturn(big.NewInt(int64(block)))
-	limit := uint64(len(s.Validators)/2+1) * continuousInturn
+	limit := uint64(s.proposerCount()/2+1) * continuousInturn
 	var count uint64
 	for blockNum, recent := range s.Recents {
 		if blockNum != block-limit && recent == validator {
@@ -124,7 +140,7 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 		// Remove any votes on checkpoint blocks
 		number := header.Number.Uint64()
 		continuousInturn := s.config.TurboContinuousInturn(header.Number)
-		if limit := uint64(len(snap.Validators)/2+1) * continuousInturn; number >= limit {
+		if limit := uint64(snap.proposerCount()/2+1) * continuousInturn; number >= limit {
 			// Delete the oldest validator from the recent list to allow it signing again
 			delete(snap.Recents, number-limit)
 		}
@@ -145,19 +161,22 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 		// Starting from the first epoch block after Waterdrop hard-fork: use a look-back validator.
 		// Which means: the blocks in range [1, ((waterdropBlock/EpochPeriod)+1)*EpochPeriod ] are using the latest validators set;
 		// the blocks ≥ ((waterdropBlock/EpochPeriod)+1)*EpochPeriod + 1 are using the look-back validators set.
-		if number > 0 && number%s.config.Turbo.Epoch == 0 {
-			var checkpointHeader *types.Header
+		if number > 0 && s.config.Turbo.IsEpoch(number) {
+			var (
+				checkpointHeader *types.Header
+				epoch            = number - s.config.Turbo.LastEpoch(number-1) // Length of the previous epoch
+			)
 			// For a large chain insertion, the previous blocks may not have been written to db,
 			// so we need to find it through both previous `headers` and parents
-			if uint64(i) >= s.config.Turbo.Epoch {
-				checkpointHeader = headers[i-int(s.config.Turbo.Epoch)]
+			if uint64(i) >= epoch {
+				checkpointHeader = headers[i-int(epoch)]
 			} else {
 				// i < epoch ==> epoch -i >= 1
-				idxInParents := len(parents) - (int(s.config.Turbo.Epoch) - i)
+				idxInParents := len(parents) - (int(epoch) - i)
 				if idxInParents >= 0 {
 					checkpointHeader = parents[idxInParents]
 				} else {
-					checkpointHeader = chain.GetHeaderByNumber(number - s.config.Turbo.Epoch)
+					checkpointHeader = chain.GetHeaderByNumber(number - epoch)
 					if checkpointHeader == nil {
 						return nil, consensus.ErrUnknownAncestor
 					}
@@ -165,24 +184,35 @@ func (s *Snapshot) apply(headers []*types.Header, chain consensus.ChainHeaderRea
 			}
 
 			// get validators from headers and use that for new validator set
-			validators := make([]common.Address, (len(checkpointHeader.Extra)-extraVanity-extraSeal)/common.AddressLength)
-			for i := 0; i < len(validators); i++ {
-				copy(validators[i][:], checkpointHeader.Extra[extraVanity+i*common.AddressLength:])
-			}
+			validators := EpochValidators(checkpointHeader)
 
 			newValidators := make(map[common.Address]struct{})
 			for _, validator := range validators {
 				newValidators[validator] = struct{}{}
 			}
 
+			// The jails recorded from the jail fork apply to the new set, the
+			// validators staying jailed keep the block first recording them
+			newJailed := make(map[common.Address]uint64)
+			for _, validator := range EpochJailed(header) {
+				if _, ok := newValidators[validator]; !ok {
+					continue
+				}
+				if since, ok := snap.Jailed[validator]; ok {
+					newJailed[validator] = since
+				} else {
+					newJailed[validator] = number
+				}
+			}
+			proposers := snap.proposerCount()
+			snap.Validators, snap.Jailed = newValidators, newJailed
+
 			// need to delete recorded recent seen blocks if necessary, it may pause whole chain when validators length
 			// decreases.
-			limit := uint64(len(newValidators)/2+1) * continuousInturn
-			for i := 0; i < (len(snap.Validators)/2-len(newValidators)/2)*int(continuousInturn); i++ {
+			limit := uint64(snap.proposerCount()/2+1) * continuousInturn
+			for i := 0; i < (proposers/2-snap.proposerCount()/2)*int(continuousInturn); i++ {
 				delete(snap.Recents, number-limit-uint64(i))
 			}
-
-			snap.Validators = newValidators
 		}
 	}
 
@@ -202,9 +232,40 @@ func (s *Snapshot) validators() []common.Address {
 	return sigs
 }
 
+// jailsEnforced returns whether the jailed validators are left out of the
+// sealing rotation, which they are unless all the validators are jailed.
+func (s *Snapshot) jailsEnforced() bool {
+	return len(s.Jailed) > 0 && len(s.Jailed) < len(s.Validators)
+}
+
+// proposers retrieves the list of validators taking turns to seal the blocks
+// in ascending order, i.e. the validators not jailed.
+func (s *Snapshot) proposers() []common.Address {
+	validators := s.validators()
+	if !s.jailsEnforced() {
+		return validators
+	}
+	proposers := make([]common.Address, 0, len(validators)-len(s.Jailed))
+	for _, validator := range validators {
+		if _, jailed := s.Jailed[validator]; !jailed {
+			proposers = append(proposers, validator)
+		}
+	}
+	return proposers
+}
+
+// proposerCount returns the number of validators taking turns to seal the
+// blocks.
+func (s *Snapshot) proposerCount() int {
+	if !s.jailsEnforced() {
+		return len(s.Validators)
+	}
+	return len(s.Validators) - len(s.Jailed)
+}
+
 // inturn returns if a validator at a given block height is in-turn or not.
 func (s *Snapshot) inturn(number uint64, validator common.Address) bool {
-	validators, offset := s.validators(), 0
+	validators, offset := s.proposers(), 0
 	for offset < len(validators) && validators[offset] != validator {
 		offset++
 	}
@@ -212,6 +273,22 @@ func (s *Snapshot) inturn(number uint64, validator common.Address) bool {
 	return (number%(uint64(len(validators))*continuousInturn))/continuousInturn == uint64(offset)
 }
 
+// IsJailed returns whether the validator is jailed, as recorded at the last
+// epoch block from the jail fork.
+func (s *Snapshot) IsJailed(addr common.Address) bool {
+	_, jailed := s.Jailed[addr]
+	return jailed
+}
+
+// IsProposer returns whether the validator takes turns to seal the blocks,
+// i.e. it's authorized and not jailed.
+func (s *Snapshot) IsProposer(addr common.Address) bool {
+	if !s.IsAuthorized(addr) {
+		return false
+	}
+	return !s.jailsEnforced() || !s.IsJailed(addr)
+}
+
 func (s *Snapshot) IsAuthorized(addr common.Address) bool {
 	_, exist := s.Validators[addr]
 	return exist
@@ -119,3 +119,66 @@ func TestSnapshot_inturn(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshot_copyJailed(t *testing.T) {
+	f := genFields(100)
+	snap := &Snapshot{config: f.config, Number: f.Number, Validators: f.Validators, Recents: f.Recents,
+		Jailed: map[common.Address]uint64{validatorAddress(3): 90}}
+
+	cpy := snap.copy()
+	if !cpy.IsJailed(validatorAddress(3)) || cpy.IsJailed(validatorAddress(4)) {
+		t.Fatalf("jailed validators not copied: %v", cpy.Jailed)
+	}
+	delete(cpy.Jailed, validatorAddress(3))
+	if !snap.IsJailed(validatorAddress(3)) {
+		t.Errorf("jailed validators of the copy shared with the original")
+	}
+}
+
+func TestSnapshot_proposers(t *testing.T) {
+	tests := []struct {
+		name   string
+		jailed []int64
+		want   int
+	}{
+		{"none", nil, 21},
+		{"some", []int64{3, 16}, 19},
+		{"all", []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}, 21},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := genFields(100)
+			snap := &Snapshot{config: f.config, Number: f.Number, Validators: f.Validators, Recents: f.Recents,
+				Jailed: make(map[common.Address]uint64)}
+			for _, i := range tt.jailed {
+				snap.Jailed[validatorAddress(i)] = 90
+			}
+			proposers := snap.proposers()
+			if len(proposers) != tt.want || snap.proposerCount() != tt.want {
+				t.Fatalf("proposer count mismatch: have %d (%d), want %d", len(proposers), snap.proposerCount(), tt.want)
+			}
+			for _, i := range tt.jailed {
+				if have, want := snap.IsProposer(validatorAddress(i)), tt.want == 21; have != want {
+					t.Errorf("validator %d proposer mismatch: have %v, want %v", i, have, want)
+				}
+			}
+			// The jailed validators never seal in turn, the others take turns
+			var inturn int
+			for number := uint64(100); number < 100+uint64(len(proposers)); number++ {
+				for _, validator := range proposers {
+					if snap.inturn(number, validator) {
+						inturn++
+					}
+				}
+				for _, i := range tt.jailed {
+					if tt.want != 21 && snap.inturn(number, validatorAddress(i)) {
+						t.Errorf("jailed validator %d in turn at block %d", i, number)
+					}
+				}
+			}
+			if inturn != len(proposers) {
+				t.Errorf("in-turn slot count mismatch: have %d, want %d", inturn, len(proposers))
+			}
+		})
+	}
+}
@@ -50,49 +50,87 @@ func GetTopValidators(ctx *contracts.CallContext) ([]common.Address, error) {
 	return validators, nil
 }
 
+// GetActiveValidators return the result of calling method `getActiveValidators` in Staking contract
+func GetActiveValidators(ctx *contracts.CallContext) ([]common.Address, error) {
+	const method = "getActiveValidators"
+	result, err := contractRead(ctx, system.StakingContract, method)
+	if err != nil {
+		log.Error("GetActiveValidators contractRead failed", "err", err)
+		return []common.Address{}, err
+	}
+	validators, ok := result.([]common.Address)
+	if !ok {
+		return []common.Address{}, errors.New("GetActiveValidators: invalid validator format")
+	}
+	return validators, nil
+}
+
 // UpdateActiveValidatorSet return the result of calling method `updateActiveValidatorSet` in Staking contract
 func UpdateActiveValidatorSet(ctx *contracts.CallContext, newValidators []common.Address) error {
+	_, err := UpdateActiveValidatorSetWithGas(ctx, newValidators, math.MaxUint64)
+	return err
+}
+
+// UpdateActiveValidatorSetWithGas calls method `updateActiveValidatorSet` in
+// Staking contract with the given gas, returning the gas used.
+func UpdateActiveValidatorSetWithGas(ctx *contracts.CallContext, newValidators []common.Address, gas uint64) (uint64, error) {
 	const method = "updateActiveValidatorSet"
-	err := contractWrite(ctx, system.EngineCaller, system.StakingContract, method, newValidators)
+	used, err := contractWriteWithGas(ctx, system.EngineCaller, system.StakingContract, common.U2560, gas, method, newValidators)
 	if err != nil {
 		log.Error("UpdateActiveValidatorSet failed", "newValidators", newValidators, "err", err)
 	}
-	return err
+	return used, err
 }
 
 // DecreaseMissedBlocksCounter return the result of calling method `decreaseMissedBlocksCounter` in Staking contract
 func DecreaseMissedBlocksCounter(ctx *contracts.CallContext) error {
+	_, err := DecreaseMissedBlocksCounterWithGas(ctx, math.MaxUint64)
+	return err
+}
+
+// DecreaseMissedBlocksCounterWithGas calls method `decreaseMissedBlocksCounter`
+// in Staking contract with the given gas, returning the gas used.
+func DecreaseMissedBlocksCounterWithGas(ctx *contracts.CallContext, gas uint64) (uint64, error) {
 	const method = "decreaseMissedBlocksCounter"
-	err := contractWrite(ctx, system.EngineCaller, system.StakingContract, method)
+	used, err := contractWriteWithGas(ctx, system.EngineCaller, system.StakingContract, common.U2560, gas, method)
 	if err != nil {
 		log.Error("DecreaseMissedBlocksCounter failed", "err", err)
 	}
-	return err
+	return used, err
 }
 
 // DistributeBlockFee return the result of calling method `distributeBlockFee` in Staking contract
 func DistributeBlockFee(ctx *contracts.CallContext, fee *uint256.Int) error {
+	_, err := DistributeBlockFeeWithGas(ctx, fee, math.MaxUint64)
+	return err
+}
+
+// DistributeBlockFeeWithGas calls method `distributeBlockFee` in Staking
+// contract with the given gas, returning the gas used.
+func DistributeBlockFeeWithGas(ctx *contracts.CallContext, fee *uint256.Int, gas uint64) (uint64, error) {
 	const method = "distributeBlockFee"
-	data, err := system.ABIPack(system.StakingContract, method)
+	used, err := contractWriteWithGas(ctx, system.EngineCaller, system.StakingContract, fee, gas, method)
 	if err != nil {
-		log.Error("Can't pack data for distributeBlockFee", "error", err)
-		return err
-	}
-	if _, err := contracts.CallContractWithValue(ctx, system.EngineCaller, &system.StakingContract, data, fee); err != nil {
 		log.Error("DistributeBlockFee failed", "fee", fee, "err", err)
-		return err
 	}
-	return nil
+	return used, err
 }
 
 // LazyPunish return the result of calling method `lazyPunish` in Staking contract
 func LazyPunish(ctx *contracts.CallContext, validator common.Address) error {
+	_, err := LazyPunishWithGas(ctx, validator, math.MaxUint64)
+	return err
+}
+
+// LazyPunishWithGas calls method `lazyPunish` in Staking contract with the
+// given gas, returning the gas used.
+func LazyPunishWithGas(ctx *contracts.CallContext, validator common.Address, gas uint64) (uint64, error) {
 	const method = "lazyPunish"
-	err := contractWrite(ctx, system.EngineCaller, system.StakingContract, method, validator)
+	used, err := contractWriteWithGas(ctx, system.EngineCaller, system.StakingContract, common.U2560, gas, method, validator)
 	if err != nil {
 		log.Error("LazyPunish failed", "validator", validator, "err", err)
 	}
-	return err
+	return used, err
 }
 
 // DoubleSignPunish return the result of calling method `doubleSignPunish` in Staking contract
@@ -105,6 +143,21 @@ func DoubleSignPunish(ctx *contracts.CallContext, punishHash common.Hash, valida
 	return err
 }
 
+// DoubleSignPunishWithGas calls method `doubleSignPunish` in Staking contract
+// with the given gas, returning the gas used.
+func DoubleSignPunishWithGas(ctx *contracts.CallContext, punishHash common.Hash, validator common.Address, gas uint64) (uint64, error) {
+	data, err := system.ABIPack(system.StakingContract, "doubleSignPunish", punishHash, validator)
+	if err != nil {
+		log.Error("Can't pack data for doubleSignPunish", "error", err)
+		return 0, err
+	}
+	_, usedGas, err := contracts.CallContractWithGas(ctx, system.EngineCaller, &system.StakingContract, data, common.U2560, gas)
+	if err != nil {
+		log.Error("DoubleSignPunish failed", "punishHash", punishHash, "validator", validator, "gas", gas, "err", err)
+	}
+	return usedGas, err
+}
+
 // DoubleSignPunishWithGivenEVM return the result of calling method `doubleSignPunish` in Staking contract with given EVM
 func DoubleSignPunishWithGivenEVM(evm *vm.EVM, from common.Address, punishHash common.Hash, validator common.Address) error {
 	// execute contract
@@ -190,3 +243,18 @@ func contractWrite(ctx *contracts.CallContext, from common.Address, contract com
 	}
 	return nil
 }
+
+// contractWriteWithGas performs a contract write with the given value and gas,
+// returning the gas used.
+func contractWriteWithGas(ctx *contracts.CallContext, from common.Address, contract common.Address, value *uint256.Int, gas uint64, method string, args ...interface{}) (uint64, error) {
+	data, err := system.ABIPack(contract, method, args...)
+	if err != nil {
+		log.Error("Can't pack data", "method", method, "error", err)
+		return 0, err
+	}
+	_, used, err := contracts.CallContractWithGas(ctx, from, &contract, data, value, gas)
+	if err != nil {
+		log.Error("Failed to execute", "method", method, "err", err)
+	}
+	return used, err
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/holiman/uint256"
@@ -333,6 +334,47 @@ func TestDoubleSignPunish(t *testing.T) {
 	assert.True(t, punished)
 }
 
+func TestDoubleSignPunishWithGas(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	// Out of gas, the punishment isn't recorded
+	punishHash := common.BigToHash(big.NewInt(886))
+	_, err = DoubleSignPunishWithGas(ctx, punishHash, GenesisValidators[0], 1000)
+	assert.ErrorIs(t, err, vm.ErrOutOfGas)
+
+	punished, err := IsDoubleSignPunished(ctx, punishHash)
+	assert.NoError(t, err)
+	assert.False(t, punished)
+
+	used, err := DoubleSignPunishWithGas(ctx, punishHash, GenesisValidators[0], params.DefaultSystemGasLimit)
+	assert.NoError(t, err)
+	assert.True(t, used > 1000 && used < params.DefaultSystemGasLimit, "gas used %d", used)
+
+	punished, err = IsDoubleSignPunished(ctx, punishHash)
+	assert.NoError(t, err)
+	assert.True(t, punished)
+}
+
+func TestLazyPunishWithGas(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	// Out of gas, the missed block isn't recorded
+	_, err = LazyPunishWithGas(ctx, GenesisValidators[0], 1000)
+	assert.ErrorIs(t, err, vm.ErrOutOfGas)
+
+	missed := readSystemContract(t, ctx, "getPunishRecord", GenesisValidators[0])
+	assert.Equal(t, uint64(0), missed.(*big.Int).Uint64())
+
+	used, err := LazyPunishWithGas(ctx, GenesisValidators[0], params.DefaultSystemGasLimit)
+	assert.NoError(t, err)
+	assert.True(t, used > 1000 && used < params.DefaultSystemGasLimit, "gas used %d", used)
+
+	missed = readSystemContract(t, ctx, "getPunishRecord", GenesisValidators[0])
+	assert.Equal(t, uint64(1), missed.(*big.Int).Uint64())
+}
+
 func TestDoubleSignPunishGivenEVM(t *testing.T) {
 	ctx, err := initCallContext()
 	assert.NoError(t, err, "Init call context error")
@@ -0,0 +1,64 @@
+package systemcontract
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Storage slots of the reward records of the Staking contract.
+var (
+	totalStakeSlot         = common.BigToHash(big.NewInt(16))
+	rewardsPerBlockSlot    = common.BigToHash(big.NewInt(17))
+	accRewardsPerStakeSlot = common.BigToHash(big.NewInt(18))
+	lastUpdateAccBlockSlot = common.BigToHash(big.NewInt(19))
+)
+
+// SetRewardsPerBlock changes the staking rewards released per block from the
+// block of the call context on.
+//
+// The Staking contract has no setter for the rewards, and accrues them lazily
+// at the current rate since the last update of its reward records. These are
+// first brought up to date by the contract itself on a scratch copy of the
+// state, through a double sign punishment of an active validator by the engine,
+// which updates the records before changing the stake. The updated records are
+// then carried over to the state along with the new rate, so that the previous
+// rate applies up to this block. Without active validators the records can't be
+// brought up to date, the rate is left unchanged until a later call.
+func SetRewardsPerBlock(ctx *contracts.CallContext, rewards *big.Int) error {
+	current := ctx.Statedb.GetState(system.StakingContract, rewardsPerBlockSlot).Big()
+	if current.Cmp(rewards) == 0 {
+		return nil
+	}
+	if rewards.Sign() < 0 || rewards.BitLen() > 256 {
+		return errors.New("invalid rewards per block")
+	}
+	validators, err := GetActiveValidators(ctx)
+	if err != nil {
+		return err
+	}
+	if len(validators) == 0 {
+		log.Warn("No active validators to update the staking rewards", "number", ctx.Header.Number, "rewards", rewards)
+		return nil
+	}
+	scratch := *ctx
+	scratch.Statedb = ctx.Statedb.Copy()
+
+	punishHash := crypto.Keccak256Hash([]byte("emission"), ctx.Header.Number.Bytes())
+	if err := contractWrite(&scratch, system.EngineCaller, system.StakingContract, "doubleSignPunish", punishHash, validators[0]); err != nil {
+		log.Error("Failed to update the staking reward records", "number", ctx.Header.Number, "err", err)
+		return err
+	}
+	for _, slot := range []common.Hash{accRewardsPerStakeSlot, lastUpdateAccBlockSlot} {
+		ctx.Statedb.SetState(system.StakingContract, slot, scratch.Statedb.GetState(system.StakingContract, slot))
+	}
+	ctx.Statedb.SetState(system.StakingContract, rewardsPerBlockSlot, common.BigToHash(rewards))
+
+	log.Info("Updated staking rewards per block", "number", ctx.Header.Number, "old", current, "new", rewards)
+	return nil
+}
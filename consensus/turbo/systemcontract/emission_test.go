@@ -0,0 +1,54 @@
+package systemcontract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRewardsPerBlock(t *testing.T) {
+	// emitted returns the staking rewards released per stake so far, settling
+	// the reward records of the Staking contract at the given block.
+	emitted := func(ctx *contracts.CallContext, number int64) *big.Int {
+		ctx.Header.Number = big.NewInt(number)
+		assert.NoError(t, DoubleSignPunish(ctx, common.BigToHash(big.NewInt(number)), GenesisValidators[1]))
+		acc, err := contractRead(ctx, system.StakingContract, "accRewardsPerStake")
+		assert.NoError(t, err)
+		return acc.(*big.Int)
+	}
+	ref, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	rewards, err := contractRead(ctx, system.StakingContract, "rewardsPerBlock")
+	assert.NoError(t, err)
+	halved := new(big.Int).Rsh(rewards.(*big.Int), 1)
+
+	// Reference emission at the genesis rate.
+	refFirst, refTotal := emitted(ref, 500), emitted(ref, 900)
+
+	// Halve the rewards at block 500, the previous rate applying up to it.
+	ctx.Header.Number = big.NewInt(500)
+	assert.NoError(t, SetRewardsPerBlock(ctx, halved))
+	have, err := contractRead(ctx, system.StakingContract, "rewardsPerBlock")
+	if assert.NoError(t, err) {
+		assert.Equal(t, halved, have)
+	}
+	first, total := emitted(ctx, 500), emitted(ctx, 900)
+	assert.Equal(t, refFirst, first)
+
+	want := new(big.Int).Sub(refTotal, refFirst)
+	want.Add(refFirst, want.Rsh(want, 1))
+	assert.Equal(t, want, total)
+
+	// Setting the current rate is a no-op.
+	before := ctx.Statedb.GetState(system.StakingContract, lastUpdateAccBlockSlot)
+	ctx.Header.Number = big.NewInt(1000)
+	assert.NoError(t, SetRewardsPerBlock(ctx, halved))
+	assert.Equal(t, before, ctx.Statedb.GetState(system.StakingContract, lastUpdateAccBlockSlot))
+}
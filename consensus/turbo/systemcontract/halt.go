@@ -0,0 +1,60 @@
+package systemcontract
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// HaltABI is the ABI of the governed halt contract.
+const HaltABI = `[
+	{
+		"inputs": [],
+		"name": "haltBlock",
+		"outputs": [
+			{"internalType": "uint256", "name": "", "type": "uint256"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+var haltContractABI abi.ABI
+
+func init() {
+	var err error
+	if haltContractABI, err = abi.JSON(strings.NewReader(HaltABI)); err != nil {
+		panic(err)
+	}
+}
+
+// ReadHaltBlock returns the block above which the governance halts the chain,
+// at the state of the call context. It returns 0 if no halt is set, the halt
+// contract isn't configured or deployed, or it can't be read.
+func ReadHaltBlock(ctx *contracts.CallContext) uint64 {
+	halt := ctx.ChainConfig.Turbo.Halt
+	if halt == nil || ctx.Statedb.GetCodeSize(halt.Contract) == 0 {
+		return 0
+	}
+	result, err := contractReadBytes(ctx, halt.Contract, &haltContractABI, "haltBlock")
+	if err != nil {
+		return 0
+	}
+	values, err := haltContractABI.Unpack("haltBlock", result)
+	if err == nil && len(values) != 1 {
+		err = errors.New("invalid result length")
+	}
+	if err != nil {
+		log.Warn("Invalid governed halt block", "contract", halt.Contract, "err", err)
+		return 0
+	}
+	block, ok := values[0].(*big.Int)
+	if !ok || !block.IsUint64() {
+		return 0
+	}
+	return block.Uint64()
+}
@@ -0,0 +1,33 @@
+package systemcontract
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadHaltBlock(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	config := *ctx.ChainConfig
+	turboConfig := *config.Turbo
+	config.Turbo = &turboConfig
+	ctx.ChainConfig = &config
+
+	// No halt without the contract configured and deployed
+	assert.Equal(t, uint64(0), ReadHaltBlock(ctx))
+	contract := common.HexToAddress("0x000000000000000000000000000000000000F0AB")
+	turboConfig.Halt = &params.HaltConfig{Contract: contract}
+	assert.Equal(t, uint64(0), ReadHaltBlock(ctx))
+
+	// The contract returns 100
+	ctx.Statedb.SetCode(contract, common.FromHex("0x606460005260206000f3"))
+	assert.Equal(t, uint64(100), ReadHaltBlock(ctx))
+
+	// An invalid result is ignored
+	ctx.Statedb.SetCode(contract, common.FromHex("0x60006000f3"))
+	assert.Equal(t, uint64(0), ReadHaltBlock(ctx))
+}
@@ -0,0 +1,216 @@
+package systemcontract
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/contracts/system/bindings"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// paramsCacheSize is the number of blocks the read parameters are cached for.
+const paramsCacheSize = 32
+
+// Params are the on-chain parameters read by the engine from the system
+// contracts, at the state of a given block.
+type Params struct {
+	TopValidators       []common.Address // Sorted validators with the most stake
+	TotalStake          *big.Int
+	RewardsPerBlock     *big.Int
+	BlockEpoch          *big.Int
+	LazyPunishThreshold *big.Int
+	JailPeriod          *big.Int
+	MaxValidators       uint8
+}
+
+// ParamsReader reads all the on-chain parameters of a block in a single batch
+// and caches them by block hash, so that repeated lookups, e.g. while preparing
+// and then finalizing a block, don't execute the contracts again.
+type ParamsReader struct {
+	lock  sync.Mutex
+	cache lru.BasicLRU[common.Hash, *Params]
+}
+
+// NewParamsReader creates a parameter reader with an empty cache.
+func NewParamsReader() *ParamsReader {
+	return &ParamsReader{
+		cache: lru.NewBasicLRU[common.Hash, *Params](paramsCacheSize),
+	}
+}
+
+// Read returns the parameters at the state of the call context header, which
+// must be the post state of the block.
+func (r *ParamsReader) Read(ctx *contracts.CallContext) (*Params, error) {
+	hash := ctx.Header.Hash()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if params, ok := r.cache.Get(hash); ok {
+		return params, nil
+	}
+	params, err := readParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Add(hash, params)
+	return params, nil
+}
+
+// TopValidators returns the sorted validators with the most stake at the state
+// of the call context header.
+func (r *ParamsReader) TopValidators(ctx *contracts.CallContext) ([]common.Address, error) {
+	params, err := r.Read(ctx)
+	if err != nil {
+		return []common.Address{}, err
+	}
+	return append([]common.Address{}, params.TopValidators...), nil
+}
+
+// TotalStake returns the stake of all the validators and their delegators.
+func (r *ParamsReader) TotalStake(ctx *contracts.CallContext) (*big.Int, error) {
+	params, err := r.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(params.TotalStake), nil
+}
+
+// RewardsPerBlock returns the staking rewards distributed at each block.
+func (r *ParamsReader) RewardsPerBlock(ctx *contracts.CallContext) (*big.Int, error) {
+	params, err := r.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(params.RewardsPerBlock), nil
+}
+
+// BlockEpoch returns the epoch length set in the Staking contract.
+func (r *ParamsReader) BlockEpoch(ctx *contracts.CallContext) (*big.Int, error) {
+	params, err := r.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(params.BlockEpoch), nil
+}
+
+// LazyPunishThreshold returns the number of missed blocks a validator is
+// jailed after.
+func (r *ParamsReader) LazyPunishThreshold(ctx *contracts.CallContext) (*big.Int, error) {
+	params, err := r.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(params.LazyPunishThreshold), nil
+}
+
+// JailPeriod returns the number of blocks a punished validator stays jailed.
+func (r *ParamsReader) JailPeriod(ctx *contracts.CallContext) (*big.Int, error) {
+	params, err := r.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(params.JailPeriod), nil
+}
+
+// MaxValidators returns the maximum number of active validators.
+func (r *ParamsReader) MaxValidators(ctx *contracts.CallContext) (uint8, error) {
+	params, err := r.Read(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return params.MaxValidators, nil
+}
+
+// readParams reads all the parameters through a single multicall of the
+// Staking contract.
+func readParams(ctx *contracts.CallContext) (*Params, error) {
+	staking, err := bindings.NewStakingMulticaller(system.StakingContract, &evmBatchCaller{ctx: ctx})
+	if err != nil {
+		return nil, err
+	}
+	var (
+		validators, errValidators = staking.GetTopValidators(TopValidatorNum)
+		stake, errStake           = staking.TotalStake()
+		rewards, errRewards       = staking.RewardsPerBlock()
+		epoch, errEpoch           = staking.BlockEpoch()
+		threshold, errThreshold   = staking.LazyPunishThreshold()
+		jail, errJail             = staking.JailPeriod()
+		maxValidators, errMax     = staking.MaxValidators()
+	)
+	for _, err := range []error{errValidators, errStake, errRewards, errEpoch, errThreshold, errJail, errMax} {
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := staking.Execute(nil); err != nil {
+		log.Error("Failed to read system contract params", "number", ctx.Header.Number, "err", err)
+		return nil, err
+	}
+	params := &Params{
+		TopValidators:       *validators,
+		TotalStake:          *stake,
+		RewardsPerBlock:     *rewards,
+		BlockEpoch:          *epoch,
+		LazyPunishThreshold: *threshold,
+		JailPeriod:          *jail,
+		MaxValidators:       *maxValidators,
+	}
+	sort.Sort(AddrAscend(params.TopValidators))
+	return params, nil
+}
+
+// GetValidatorStakes returns the stakes of the given validators, delegations
+// included, reading them through a single multicall of the Staking contract.
+func GetValidatorStakes(ctx *contracts.CallContext, validators []common.Address) ([]*big.Int, error) {
+	staking, err := bindings.NewStakingMulticaller(system.StakingContract, &evmBatchCaller{ctx: ctx})
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*struct {
+		Stake       *big.Int
+		Debt        *big.Int
+		IncomeFees  *big.Int
+		UnWithdrawn *big.Int
+	}, len(validators))
+	for i, validator := range validators {
+		if infos[i], err = staking.ValInfos(validator); err != nil {
+			return nil, err
+		}
+	}
+	if err := staking.Execute(nil); err != nil {
+		log.Error("Failed to read validator stakes", "number", ctx.Header.Number, "err", err)
+		return nil, err
+	}
+	stakes := make([]*big.Int, len(validators))
+	for i, info := range infos {
+		stakes[i] = info.Stake
+	}
+	return stakes, nil
+}
+
+// evmBatchCaller implements bind.BatchCaller by executing the calls against the
+// state of a call context, ignoring the requested block.
+type evmBatchCaller struct {
+	ctx *contracts.CallContext
+}
+
+// BatchCallContract implements bind.BatchCaller.
+func (b *evmBatchCaller) BatchCallContract(_ context.Context, calls []ethereum.CallMsg, _ *big.Int) ([][]byte, error) {
+	outputs := make([][]byte, len(calls))
+	for i, call := range calls {
+		output, err := contracts.CallContract(b.ctx, b.ctx.Header.Coinbase, call.To, call.Data)
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = output
+	}
+	return outputs, nil
+}
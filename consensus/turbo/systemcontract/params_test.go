@@ -0,0 +1,65 @@
+package systemcontract
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamsReader(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	reader := NewParamsReader()
+	params, err := reader.Read(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, GenesisValidators, params.TopValidators)
+	for method, want := range map[string]interface{}{
+		"totalStake":          params.TotalStake,
+		"rewardsPerBlock":     params.RewardsPerBlock,
+		"blockEpoch":          params.BlockEpoch,
+		"LazyPunishThreshold": params.LazyPunishThreshold,
+		"JailPeriod":          params.JailPeriod,
+		"MaxValidators":       params.MaxValidators,
+	} {
+		have, err := contractRead(ctx, system.StakingContract, method)
+		if assert.NoError(t, err) {
+			assert.Equal(t, want, have, method)
+		}
+	}
+
+	// Reads of the same block are served from the cache, even if the state changed.
+	assert.NoError(t, LazyPunish(ctx, GenesisValidators[0]))
+	cached, err := reader.Read(ctx)
+	if assert.NoError(t, err) {
+		assert.Same(t, params, cached)
+	}
+	vals, err := reader.TopValidators(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, GenesisValidators, vals)
+	}
+	// Reads of another block execute the contracts again.
+	ctx.Header.Number.SetUint64(201)
+	fresh, err := reader.Read(ctx)
+	if assert.NoError(t, err) {
+		assert.NotSame(t, params, fresh)
+	}
+}
+
+func TestGetValidatorStakes(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	stakes, err := GetValidatorStakes(ctx, GenesisValidators)
+	if assert.NoError(t, err) && assert.Len(t, stakes, len(GenesisValidators)) {
+		for i, validator := range GenesisValidators {
+			info, err := contractReadAll(ctx, system.StakingContract, "valInfos", validator)
+			if assert.NoError(t, err) {
+				assert.Equal(t, info[0], stakes[i])
+			}
+		}
+	}
+}
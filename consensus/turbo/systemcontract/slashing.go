@@ -0,0 +1,316 @@
+package systemcontract
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Slashing parameters hardcoded in the Staking contract, used before the
+// slashing fork.
+const (
+	legacyLazyPunishThreshold = 48
+	legacyDoubleSignPenalty   = 50 // EvilPunishFactor per PunishBase
+
+	slashingPenaltyBase = 1000 // PunishBase of the Staking contract
+)
+
+// SlashingHardfork is the name of the system contract upgrade applied at the
+// slashing fork block.
+const SlashingHardfork = "slashing"
+
+// SlashingParams are the parameters of the validator punishments.
+type SlashingParams struct {
+	LazyPunishThreshold uint64 // Missed blocks before a validator is jailed
+	DecreaseInterval    uint64 // Blocks between two decreases of the missed blocks counters
+	DoubleSignPenalty   uint64 // Stake share slashed for double signing, per thousand
+}
+
+// SlashingABI is the ABI of the governed slashing parameter contract.
+const SlashingABI = `[
+	{
+		"inputs": [],
+		"name": "getSlashingParams",
+		"outputs": [
+			{"internalType": "uint256", "name": "lazyPunishThreshold", "type": "uint256"},
+			{"internalType": "uint256", "name": "decreaseInterval", "type": "uint256"},
+			{"internalType": "uint256", "name": "doubleSignPenalty", "type": "uint256"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+var slashingContractABI abi.ABI
+
+func init() {
+	var err error
+	if slashingContractABI, err = abi.JSON(strings.NewReader(SlashingABI)); err != nil {
+		panic(err)
+	}
+}
+
+// DefaultSlashingParams returns the slashing parameters applying at the given
+// block if the parameter contract doesn't set them: the values hardcoded in the
+// Staking contract before the slashing fork, the configured defaults after.
+func DefaultSlashingParams(config *params.ChainConfig, number *big.Int) SlashingParams {
+	defaults := SlashingParams{
+		LazyPunishThreshold: legacyLazyPunishThreshold,
+		DecreaseInterval:    config.Turbo.EpochAt(number.Uint64()),
+		DoubleSignPenalty:   legacyDoubleSignPenalty,
+	}
+	if slashing := config.Turbo.Slashing; config.Turbo.IsSlashing(number) && slashing != nil {
+		if slashing.LazyPunishThreshold != 0 {
+			defaults.LazyPunishThreshold = slashing.LazyPunishThreshold
+		}
+		if slashing.DecreaseInterval != 0 {
+			defaults.DecreaseInterval = slashing.DecreaseInterval
+		}
+		if slashing.DoubleSignPenalty != 0 {
+			defaults.DoubleSignPenalty = slashing.DoubleSignPenalty
+		}
+	}
+	return defaults
+}
+
+// ReadSlashingParams returns the slashing parameters at the state of the call
+// context. After the slashing fork, the parameters set in the governed contract
+// override the defaults, which are kept if the contract isn't deployed or
+// can't be read.
+func ReadSlashingParams(ctx *contracts.CallContext) SlashingParams {
+	config, number := ctx.ChainConfig, ctx.Header.Number
+	slashing := DefaultSlashingParams(config, number)
+	if !config.Turbo.IsSlashing(number) || config.Turbo.Slashing == nil {
+		return slashing
+	}
+	contract := config.Turbo.Slashing.Contract
+	if ctx.Statedb.GetCodeSize(contract) == 0 {
+		return slashing
+	}
+	result, err := contractReadBytes(ctx, contract, &slashingContractABI, "getSlashingParams")
+	if err != nil {
+		return slashing
+	}
+	values, err := slashingContractABI.Unpack("getSlashingParams", result)
+	if err == nil && len(values) != 3 {
+		err = errors.New("invalid result length")
+	}
+	if err != nil {
+		log.Warn("Invalid governed slashing params", "contract", contract, "err", err)
+		return slashing
+	}
+	for i, field := range []*uint64{&slashing.LazyPunishThreshold, &slashing.DecreaseInterval, &slashing.DoubleSignPenalty} {
+		if value, ok := values[i].(*big.Int); ok && value.Sign() > 0 && value.IsUint64() {
+			*field = value.Uint64()
+		}
+	}
+	if slashing.DoubleSignPenalty > slashingPenaltyBase {
+		log.Warn("Governed double sign penalty above the whole stake", "contract", contract, "penalty", slashing.DoubleSignPenalty)
+		slashing.DoubleSignPenalty = slashingPenaltyBase
+	}
+	return slashing
+}
+
+// stakingSlashingUpgrade upgrades the Staking contract at the slashing fork to
+// read the slashing parameters from the governed contract, instead of using its
+// hardcoded values.
+//
+// The pushes of the hardcoded lazy punish threshold and double sign penalty,
+// in the punishments and their getters, are replaced by jumps to stubs
+// appended to the code. A stub calls getSlashingParams on the governed
+// contract, keeping the default parameter applying at the fork if the contract
+// isn't deployed, fails or leaves the parameter unset, then resumes the
+// replaced code.
+type stakingSlashingUpgrade struct{}
+
+func (u *stakingSlashingUpgrade) GetName() string {
+	return "Staking"
+}
+
+func (u *stakingSlashingUpgrade) DoUpdate(state *state.StateDB, header *types.Header, chainContext core.ChainContext, config *params.ChainConfig) error {
+	if config.Turbo == nil || config.Turbo.Slashing == nil {
+		log.Warn("No governed slashing contract, keeping the Staking slashing params", "number", header.Number)
+		return nil
+	}
+	code, err := slashingStakingCode(state.GetCode(system.StakingContract), config.Turbo.Slashing.Contract, DefaultSlashingParams(config, header.Number))
+	if err != nil {
+		log.Warn("Keeping the Staking slashing params", "number", header.Number, "err", err)
+		return nil
+	}
+	state.SetCode(system.StakingContract, code)
+	return nil
+}
+
+// slashingPatch is the replacement of a hardcoded slashing parameter push in
+// the Staking code.
+type slashingPatch struct {
+	pc       int    // Position of the replaced code
+	original []byte // Code replaced, the parameter push being preceded by head
+	head     int    // Length of the code kept before the jump to the stub
+	resume   []byte // Code resuming the replaced one after the parameter push
+	penalty  bool   // Whether the parameter is the double sign penalty, the lazy punish threshold otherwise
+}
+
+// slashingPatches are the replacements of the hardcoded slashing parameters in
+// the Staking code.
+var slashingPatches = []slashingPatch{
+	{ // LazyPunishThreshold(): PUSH3 ret, PUSH1 48, DUP2, JUMP
+		pc:       1066,
+		original: common.FromHex("0x6200043260308156"),
+		head:     4,
+		resume:   []byte{byte(vm.DUP2), byte(vm.JUMP)},
+	},
+	{ // lazyPunish: PUSH3 ret, SWAP1, PUSH1 48, SWAP1, PUSH3 mod, JUMP
+		pc:       10653,
+		original: common.FromHex("0x620029aa9060309062004c5456"),
+		head:     5,
+		resume:   common.FromHex("0x9062004c5456"),
+	},
+	{ // EvilPunishFactor(): PUSH3 ret, PUSH1 50, DUP2, JUMP
+		pc:       2400,
+		original: common.FromHex("0x6200043260328156"),
+		head:     4,
+		resume:   []byte{byte(vm.DUP2), byte(vm.JUMP)},
+		penalty:  true,
+	},
+	{ // doubleSignPunish: PUSH3 ret, DUP4, PUSH1 50, PUSH3 punish, JUMP
+		pc:       3277,
+		original: common.FromHex("0x62000cd983603262002a2256"),
+		head:     5,
+		resume:   common.FromHex("0x62002a2256"),
+		penalty:  true,
+	},
+}
+
+// slashingStubPadding separates the stubs from the original code, so that any
+// push started by its trailing metadata ends before them.
+const slashingStubPadding = 33
+
+// slashingStakingCode returns the Staking code reading the slashing parameters
+// from the governed contract, falling back to the given defaults.
+func slashingStakingCode(code []byte, contract common.Address, defaults SlashingParams) ([]byte, error) {
+	for _, patch := range slashingPatches {
+		if len(code) < patch.pc+len(patch.original) || !bytes.Equal(code[patch.pc:patch.pc+len(patch.original)], patch.original) {
+			return nil, fmt.Errorf("unexpected Staking code at %d", patch.pc)
+		}
+	}
+	upgraded := append(common.CopyBytes(code), make([]byte, slashingStubPadding)...)
+	for _, patch := range slashingPatches {
+		stub := len(upgraded)
+		if stub > math.MaxUint16 {
+			return nil, errors.New("Staking code too large")
+		}
+		// Jump from the replaced code to the stub, the leftover being invalid
+		jump := append(common.CopyBytes(patch.original[:patch.head]), byte(vm.PUSH2), byte(stub>>8), byte(stub), byte(vm.JUMP))
+		for len(jump) < len(patch.original) {
+			jump = append(jump, byte(vm.INVALID))
+		}
+		copy(upgraded[patch.pc:], jump)
+
+		field, value, limit := 0, defaults.LazyPunishThreshold, uint64(0)
+		if patch.penalty {
+			field, value, limit = 2, defaults.DoubleSignPenalty, slashingPenaltyBase
+		}
+		upgraded = append(upgraded, slashingStub(stub, contract, field, value, limit, patch.resume)...)
+	}
+	return upgraded, nil
+}
+
+// slashingStub assembles the stub at the given position pushing the parameter
+// of the getSlashingParams result at field, or value if it can't be read or is
+// unset, capped at limit if not zero. The stub ends with the resume code.
+func slashingStub(pc int, contract common.Address, field int, value uint64, limit uint64, resume []byte) []byte {
+	var (
+		stub     []byte
+		done     []int // Positions of the jumps to the end of the stub
+		drop     []int // Positions of the jumps dropping the read parameter
+		selector = slashingContractABI.Methods["getSlashingParams"].ID
+	)
+	op := func(ops ...vm.OpCode) {
+		for _, op := range ops {
+			stub = append(stub, byte(op))
+		}
+	}
+	push := func(data []byte) {
+		stub = append(append(stub, byte(vm.PUSH1)+byte(len(data)-1)), data...)
+	}
+	jump := func(targets *[]int, cond bool) {
+		*targets = append(*targets, len(stub)+1)
+		push([]byte{0, 0})
+		if cond {
+			op(vm.JUMPI)
+		} else {
+			op(vm.JUMP)
+		}
+	}
+	op(vm.JUMPDEST)
+	push(new(big.Int).SetUint64(value).FillBytes(make([]byte, 8)))
+
+	// Keep the default if the contract isn't deployed
+	push(contract.Bytes())
+	op(vm.EXTCODESIZE, vm.ISZERO)
+	jump(&done, true)
+
+	// Call getSlashingParams, at the free memory left untouched
+	push(selector)
+	push([]byte{0xe0})
+	op(vm.SHL)
+	push([]byte{0x40})
+	op(vm.MLOAD, vm.MSTORE)
+	push([]byte{0x60})
+	push([]byte{0x40})
+	op(vm.MLOAD)
+	push([]byte{0x04})
+	op(vm.DUP2)
+	push(contract.Bytes())
+	op(vm.GAS, vm.STATICCALL, vm.ISZERO)
+	jump(&done, true)
+	push([]byte{0x60})
+	op(vm.RETURNDATASIZE, vm.LT)
+	jump(&done, true)
+
+	// Replace the default by the parameter if set and fitting 64 bits
+	push([]byte{0x40})
+	op(vm.MLOAD)
+	push([]byte{byte(field * common.HashLength)})
+	op(vm.ADD, vm.MLOAD, vm.DUP1, vm.ISZERO)
+	jump(&drop, true)
+	op(vm.DUP1)
+	push([]byte{0x40})
+	op(vm.SHR)
+	jump(&drop, true)
+	op(vm.SWAP1, vm.POP)
+	if limit != 0 {
+		capped := new(big.Int).SetUint64(limit).FillBytes(make([]byte, 8))
+		push(capped)
+		op(vm.DUP2, vm.GT, vm.ISZERO)
+		jump(&done, true)
+		op(vm.POP)
+		push(capped)
+	}
+	jump(&done, false)
+
+	for _, at := range drop {
+		stub[at], stub[at+1] = byte((pc+len(stub))>>8), byte(pc+len(stub))
+	}
+	op(vm.JUMPDEST, vm.POP)
+	for _, at := range done {
+		stub[at], stub[at+1] = byte((pc+len(stub))>>8), byte(pc+len(stub))
+	}
+	op(vm.JUMPDEST)
+	return append(stub, resume...)
+}
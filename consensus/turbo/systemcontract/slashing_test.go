@@ -0,0 +1,172 @@
+package systemcontract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadSlashingParams(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	config := *ctx.ChainConfig
+	turboConfig := *config.Turbo
+	config.Turbo = &turboConfig
+	ctx.ChainConfig = &config
+
+	legacy := SlashingParams{
+		LazyPunishThreshold: legacyLazyPunishThreshold,
+		DecreaseInterval:    turboConfig.Epoch,
+		DoubleSignPenalty:   legacyDoubleSignPenalty,
+	}
+	threshold, err := contractRead(ctx, system.StakingContract, "LazyPunishThreshold")
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint64(legacy.LazyPunishThreshold), threshold.(*big.Int).Uint64())
+	}
+	assert.Equal(t, legacy, ReadSlashingParams(ctx))
+
+	// After the fork, the configured defaults apply until the contract is deployed.
+	contract := common.HexToAddress("0x000000000000000000000000000000000000F0AA")
+	turboConfig.SlashingBlock = big.NewInt(100)
+	turboConfig.Slashing = &params.SlashingConfig{Contract: contract, DecreaseInterval: 50}
+
+	forked := legacy
+	forked.DecreaseInterval = 50
+	assert.Equal(t, forked, ReadSlashingParams(ctx))
+	assert.Equal(t, legacy, DefaultSlashingParams(&config, big.NewInt(99)))
+
+	// The contract overrides the parameters it sets, returning (0, 20, 100).
+	ctx.Statedb.SetCode(contract, common.FromHex("0x60006000526014602052606460405260606000f3"))
+	governed := forked
+	governed.DecreaseInterval = 20
+	governed.DoubleSignPenalty = 100
+	assert.Equal(t, governed, ReadSlashingParams(ctx))
+}
+
+// slashingContext returns a call context past the slashing fork, with the given
+// default slashing parameters.
+func slashingContext(t *testing.T, slashing *params.SlashingConfig) *contracts.CallContext {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	config := *ctx.ChainConfig
+	turboConfig := *config.Turbo
+	turboConfig.SlashingBlock = big.NewInt(100)
+	turboConfig.Slashing = slashing
+	config.Turbo = &turboConfig
+	ctx.ChainConfig = &config
+	return ctx
+}
+
+// upgradeStaking applies the Staking upgrade of the slashing fork.
+func upgradeStaking(t *testing.T, ctx *contracts.CallContext) {
+	code := ctx.Statedb.GetCode(system.StakingContract)
+	assert.NoError(t, ApplySystemContractUpgrade(SlashingHardfork, ctx.Statedb, ctx.Header, ctx.ChainContext, ctx.ChainConfig))
+	assert.NotEqual(t, code, ctx.Statedb.GetCode(system.StakingContract), "Staking code not upgraded")
+}
+
+// slashingCode returns the code of a parameter contract returning the given
+// slashing parameters.
+func slashingCode(threshold, interval, penalty uint16) []byte {
+	var code []byte
+	for i, value := range []uint16{threshold, interval, penalty} {
+		code = append(code, 0x61, byte(value>>8), byte(value), 0x60, byte(i*32), 0x52) // PUSH2 value, PUSH1 offset, MSTORE
+	}
+	return append(code, 0x60, 0x60, 0x60, 0x00, 0xf3) // PUSH1 96, PUSH1 0, RETURN
+}
+
+func TestLazyPunishThreshold(t *testing.T) {
+	contract := common.HexToAddress("0x000000000000000000000000000000000000F0AA")
+	tests := []struct {
+		defaults  uint64
+		governed  uint16
+		threshold uint64
+	}{
+		{defaults: 3, threshold: 3},
+		{defaults: 3, governed: 60, threshold: 60},
+		{governed: 5, threshold: 5},
+	}
+	for _, tt := range tests {
+		ctx := slashingContext(t, &params.SlashingConfig{Contract: contract, LazyPunishThreshold: tt.defaults})
+		if tt.governed != 0 {
+			ctx.Statedb.SetCode(contract, slashingCode(tt.governed, 0, 0))
+		}
+		// The hardcoded threshold applies until the Staking contract is upgraded
+		threshold, err := contractRead(ctx, system.StakingContract, "LazyPunishThreshold")
+		if assert.NoError(t, err) {
+			assert.Equal(t, uint64(legacyLazyPunishThreshold), threshold.(*big.Int).Uint64())
+		}
+		upgradeStaking(t, ctx)
+		threshold, err = contractRead(ctx, system.StakingContract, "LazyPunishThreshold")
+		if assert.NoError(t, err) {
+			assert.Equal(t, tt.threshold, threshold.(*big.Int).Uint64())
+		}
+		validator := GenesisValidators[1]
+		for missed := uint64(1); missed <= tt.threshold; missed++ {
+			ctx.Header.Number = new(big.Int).SetUint64(200 + missed)
+			assert.NoError(t, LazyPunish(ctx, validator))
+
+			want := missed % tt.threshold // Reset once punished
+			have, err := contractRead(ctx, system.StakingContract, "getPunishRecord", validator)
+			if assert.NoError(t, err) {
+				assert.Equal(t, want, have.(*big.Int).Uint64(), "threshold %d, missed %d", tt.threshold, missed)
+			}
+		}
+	}
+}
+
+func TestDoubleSignPenalty(t *testing.T) {
+	contract := common.HexToAddress("0x000000000000000000000000000000000000F0AA")
+
+	// slashed returns the stake slashed for a double sign of the validator.
+	slashed := func(ctx *contracts.CallContext) *big.Int {
+		before, err := contractRead(ctx, system.StakingContract, "totalStake")
+		assert.NoError(t, err)
+		assert.NoError(t, DoubleSignPunish(ctx, common.HexToHash("0x01"), GenesisValidators[1]))
+		after, err := contractRead(ctx, system.StakingContract, "totalStake")
+		assert.NoError(t, err)
+		return new(big.Int).Sub(before.(*big.Int), after.(*big.Int))
+	}
+	// The hardcoded penalty applies until the Staking contract is upgraded
+	legacy := slashed(slashingContext(t, nil))
+	assert.Equal(t, legacy, slashed(slashingContext(t, &params.SlashingConfig{Contract: contract, DoubleSignPenalty: 300})))
+
+	tests := []struct {
+		defaults uint64
+		governed uint16
+		penalty  int64
+	}{
+		{defaults: 300, penalty: 300},
+		{defaults: 300, governed: 100, penalty: 100},
+		{governed: 5000, penalty: slashingPenaltyBase},
+	}
+	for _, tt := range tests {
+		ctx := slashingContext(t, &params.SlashingConfig{Contract: contract, DoubleSignPenalty: tt.defaults})
+		if tt.governed != 0 {
+			ctx.Statedb.SetCode(contract, slashingCode(0, 0, tt.governed))
+		}
+		upgradeStaking(t, ctx)
+		penalty, err := contractRead(ctx, system.StakingContract, "EvilPunishFactor")
+		if assert.NoError(t, err) {
+			assert.Equal(t, tt.penalty, penalty.(*big.Int).Int64())
+		}
+		if tt.penalty < slashingPenaltyBase {
+			want := new(big.Int).Div(new(big.Int).Mul(legacy, big.NewInt(tt.penalty)), big.NewInt(legacyDoubleSignPenalty))
+			assert.Equal(t, want, slashed(ctx))
+		}
+	}
+}
+
+func TestSlashingStakingCodeMismatch(t *testing.T) {
+	code := common.FromHex(system.StakingV1Code)
+	code[slashingPatches[1].pc]++
+	if _, err := slashingStakingCode(code, common.Address{}, SlashingParams{}); err == nil {
+		t.Fatalf("unexpected Staking code upgraded")
+	}
+}
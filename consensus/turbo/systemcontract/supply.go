@@ -0,0 +1,86 @@
+package systemcontract
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/holiman/uint256"
+)
+
+// Storage slots of the supply counters in the SupplyRecorder account.
+var (
+	burnedSlot       = common.BigToHash(big.NewInt(0))
+	emittedSlot      = common.BigToHash(big.NewInt(1))
+	feesSlot         = common.BigToHash(big.NewInt(2))
+	treasuryFeesSlot = common.BigToHash(big.NewInt(3))
+)
+
+// SupplyCounters are the cumulative amounts counted since the supply
+// accounting fork.
+type SupplyCounters struct {
+	Burned       *big.Int // Base fees burned
+	Emitted      *big.Int // Staking rewards released by the Staking contract
+	Fees         *big.Int // Transaction fees distributed to the validators
+	TreasuryFees *big.Int // Transaction fees redirected to the treasury
+}
+
+// ReadSupplyCounters returns the supply counters at the given state.
+func ReadSupplyCounters(statedb *state.StateDB) *SupplyCounters {
+	read := func(slot common.Hash) *big.Int {
+		return statedb.GetState(consensus.SupplyRecorder, slot).Big()
+	}
+	return &SupplyCounters{
+		Burned:       read(burnedSlot),
+		Emitted:      read(emittedSlot),
+		Fees:         read(feesSlot),
+		TreasuryFees: read(treasuryFeesSlot),
+	}
+}
+
+// AccountBlockSupply counts the base fees burned by the block of the call
+// context, and the staking rewards released for it at the current rate of the
+// Staking contract, which accrues none while nothing is staked. Nothing is
+// counted before the supply accounting fork.
+func AccountBlockSupply(ctx *contracts.CallContext) {
+	if !ctx.ChainConfig.Turbo.IsSupply(ctx.Header.Number) {
+		return
+	}
+	if baseFee := ctx.Header.BaseFee; baseFee != nil {
+		burned := new(uint256.Int).Mul(uint256.NewInt(ctx.Header.GasUsed), uint256.MustFromBig(baseFee))
+		addSupplyCounter(ctx.Statedb, burnedSlot, burned)
+	}
+	if totalStake := ctx.Statedb.GetState(system.StakingContract, totalStakeSlot); totalStake != (common.Hash{}) {
+		rewards := ctx.Statedb.GetState(system.StakingContract, rewardsPerBlockSlot)
+		addSupplyCounter(ctx.Statedb, emittedSlot, new(uint256.Int).SetBytes(rewards.Bytes()))
+	}
+}
+
+// AccountFeeSupply counts the transaction fees of the block of the call
+// context distributed to the validators and redirected to the treasury.
+// Nothing is counted before the supply accounting fork.
+func AccountFeeSupply(ctx *contracts.CallContext, fees, treasuryFees *uint256.Int) {
+	if !ctx.ChainConfig.Turbo.IsSupply(ctx.Header.Number) {
+		return
+	}
+	addSupplyCounter(ctx.Statedb, feesSlot, fees)
+	addSupplyCounter(ctx.Statedb, treasuryFeesSlot, treasuryFees)
+}
+
+// addSupplyCounter adds the amount to a supply counter. The SupplyRecorder
+// account is given a nonce so that it isn't deleted as empty along with the
+// counters.
+func addSupplyCounter(statedb *state.StateDB, slot common.Hash, amount *uint256.Int) {
+	if amount.IsZero() {
+		return
+	}
+	if statedb.GetNonce(consensus.SupplyRecorder) == 0 {
+		statedb.SetNonce(consensus.SupplyRecorder, 1)
+	}
+	counter := new(uint256.Int).SetBytes(statedb.GetState(consensus.SupplyRecorder, slot).Bytes())
+	counter.Add(counter, amount)
+	statedb.SetState(consensus.SupplyRecorder, slot, counter.Bytes32())
+}
@@ -0,0 +1,60 @@
+package systemcontract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountSupply(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	config := *ctx.ChainConfig
+	turboConfig := *config.Turbo
+	config.Turbo = &turboConfig
+	ctx.ChainConfig = &config
+
+	ctx.Header.BaseFee = big.NewInt(1000)
+	ctx.Header.GasUsed = 21000
+	rewards := ctx.Statedb.GetState(system.StakingContract, rewardsPerBlockSlot).Big()
+	assert.NotZero(t, rewards.Sign(), "no staking rewards per block")
+
+	assertCounters := func(want *SupplyCounters) {
+		t.Helper()
+		have := ReadSupplyCounters(ctx.Statedb)
+		assert.Zero(t, want.Burned.Cmp(have.Burned), "burned mismatch: have %v, want %v", have.Burned, want.Burned)
+		assert.Zero(t, want.Emitted.Cmp(have.Emitted), "emitted mismatch: have %v, want %v", have.Emitted, want.Emitted)
+		assert.Zero(t, want.Fees.Cmp(have.Fees), "fees mismatch: have %v, want %v", have.Fees, want.Fees)
+		assert.Zero(t, want.TreasuryFees.Cmp(have.TreasuryFees), "treasury fees mismatch: have %v, want %v", have.TreasuryFees, want.TreasuryFees)
+	}
+	zero := &SupplyCounters{Burned: new(big.Int), Emitted: new(big.Int), Fees: new(big.Int), TreasuryFees: new(big.Int)}
+
+	// Nothing is counted before the fork.
+	turboConfig.SupplyBlock = big.NewInt(300)
+	AccountFeeSupply(ctx, uint256.NewInt(800), uint256.NewInt(200))
+	AccountBlockSupply(ctx)
+	assertCounters(zero)
+
+	turboConfig.SupplyBlock = big.NewInt(100)
+	for i := 0; i < 2; i++ {
+		AccountFeeSupply(ctx, uint256.NewInt(800), uint256.NewInt(200))
+		AccountBlockSupply(ctx)
+	}
+	want := &SupplyCounters{
+		Burned:       big.NewInt(2 * 21000 * 1000),
+		Emitted:      new(big.Int).Mul(rewards, big.NewInt(2)),
+		Fees:         big.NewInt(1600),
+		TreasuryFees: big.NewInt(400),
+	}
+	assertCounters(want)
+
+	// The counters survive the deletion of the empty accounts.
+	ctx.Statedb.Finalise(true)
+	assert.True(t, ctx.Statedb.Exist(consensus.SupplyRecorder))
+	assertCounters(want)
+}
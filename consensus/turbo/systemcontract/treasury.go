@@ -0,0 +1,116 @@
+package systemcontract
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+)
+
+// treasuryShareBase is the base of the treasury fee share.
+const treasuryShareBase = 1000
+
+// TreasuryParams are the parameters of the fee redirection to the treasury.
+type TreasuryParams struct {
+	Treasury common.Address // Address the fees are redirected to
+	Share    uint64         // Share of the fees redirected, per thousand
+}
+
+// TreasuryABI is the ABI of the governed treasury parameter contract.
+const TreasuryABI = `[
+	{
+		"inputs": [],
+		"name": "getTreasuryParams",
+		"outputs": [
+			{"internalType": "address", "name": "treasury", "type": "address"},
+			{"internalType": "uint256", "name": "share", "type": "uint256"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+var treasuryContractABI abi.ABI
+
+func init() {
+	var err error
+	if treasuryContractABI, err = abi.JSON(strings.NewReader(TreasuryABI)); err != nil {
+		panic(err)
+	}
+}
+
+// ReadTreasuryParams returns the treasury parameters at the state of the call
+// context, with no share redirected before the treasury fork. After the fork,
+// the parameters set in the governed contract override the configured ones as
+// a whole once the contract sets a treasury, so that the share can also be
+// governed down to zero.
+func ReadTreasuryParams(ctx *contracts.CallContext) TreasuryParams {
+	config, number := ctx.ChainConfig, ctx.Header.Number
+	if !config.Turbo.IsTreasury(number) || config.Turbo.Treasury == nil {
+		return TreasuryParams{}
+	}
+	treasury := TreasuryParams{
+		Treasury: config.Turbo.Treasury.Address,
+		Share:    config.Turbo.Treasury.Share,
+	}
+	contract := config.Turbo.Treasury.Contract
+	if ctx.Statedb.GetCodeSize(contract) != 0 {
+		treasury = readGovernedTreasuryParams(ctx, contract, treasury)
+	}
+	if treasury.Share > treasuryShareBase {
+		log.Warn("Treasury fee share above the whole fees", "contract", contract, "share", treasury.Share)
+		treasury.Share = treasuryShareBase
+	}
+	return treasury
+}
+
+// readGovernedTreasuryParams reads the treasury parameters from the governed
+// contract, keeping the defaults if it can't be read or sets no treasury.
+func readGovernedTreasuryParams(ctx *contracts.CallContext, contract common.Address, defaults TreasuryParams) TreasuryParams {
+	result, err := contractReadBytes(ctx, contract, &treasuryContractABI, "getTreasuryParams")
+	if err != nil {
+		return defaults
+	}
+	values, err := treasuryContractABI.Unpack("getTreasuryParams", result)
+	if err == nil && len(values) != 2 {
+		err = errors.New("invalid result length")
+	}
+	if err != nil {
+		log.Warn("Invalid governed treasury params", "contract", contract, "err", err)
+		return defaults
+	}
+	treasury, ok := values[0].(common.Address)
+	if !ok || treasury == (common.Address{}) {
+		return defaults
+	}
+	share, ok := values[1].(*big.Int)
+	if !ok {
+		return defaults
+	}
+	if !share.IsUint64() {
+		share = big.NewInt(treasuryShareBase)
+	}
+	return TreasuryParams{Treasury: treasury, Share: share.Uint64()}
+}
+
+// RedirectTreasuryFee credits the treasury with its share of the block fee,
+// returning the remaining fee to be distributed to the validators.
+func RedirectTreasuryFee(ctx *contracts.CallContext, fee *uint256.Int) *uint256.Int {
+	treasury := ReadTreasuryParams(ctx)
+	if treasury.Share == 0 || treasury.Treasury == (common.Address{}) {
+		return fee
+	}
+	redirected := new(uint256.Int).Mul(fee, uint256.NewInt(treasury.Share))
+	redirected.Div(redirected, uint256.NewInt(treasuryShareBase))
+	if redirected.IsZero() {
+		return fee
+	}
+	ctx.Statedb.AddBalance(treasury.Treasury, redirected, tracing.BalanceIncreaseRewardTransactionFee)
+	return new(uint256.Int).Sub(fee, redirected)
+}
@@ -0,0 +1,83 @@
+package systemcontract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTreasuryParams(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	config := *ctx.ChainConfig
+	turboConfig := *config.Turbo
+	config.Turbo = &turboConfig
+	ctx.ChainConfig = &config
+
+	assert.Equal(t, TreasuryParams{}, ReadTreasuryParams(ctx))
+
+	// After the fork, the configured parameters apply until the contract is deployed.
+	var (
+		contract = common.HexToAddress("0x000000000000000000000000000000000000F0BB")
+		treasury = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+	)
+	turboConfig.TreasuryBlock = big.NewInt(300)
+	turboConfig.Treasury = &params.TreasuryConfig{Contract: contract, Address: treasury, Share: 50}
+	assert.Equal(t, TreasuryParams{}, ReadTreasuryParams(ctx))
+
+	turboConfig.TreasuryBlock = big.NewInt(100)
+	assert.Equal(t, TreasuryParams{Treasury: treasury, Share: 50}, ReadTreasuryParams(ctx))
+
+	// The contract overrides the parameters, returning (0xbb, 100).
+	ctx.Statedb.SetCode(contract, common.FromHex("0x7300000000000000000000000000000000000000bb600052606460205260406000f3"))
+	governed := TreasuryParams{Treasury: common.HexToAddress("0xbb"), Share: 100}
+	assert.Equal(t, governed, ReadTreasuryParams(ctx))
+
+	// Unless it sets no treasury, returning (0, 100).
+	ctx.Statedb.SetCode(contract, common.FromHex("0x6000600052606460205260406000f3"))
+	assert.Equal(t, TreasuryParams{Treasury: treasury, Share: 50}, ReadTreasuryParams(ctx))
+
+	// Shares above the whole fees are capped, returning (0xbb, 2000).
+	ctx.Statedb.SetCode(contract, common.FromHex("0x7300000000000000000000000000000000000000bb6000526107d060205260406000f3"))
+	assert.Equal(t, TreasuryParams{Treasury: governed.Treasury, Share: treasuryShareBase}, ReadTreasuryParams(ctx))
+}
+
+func TestRedirectTreasuryFee(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	config := *ctx.ChainConfig
+	turboConfig := *config.Turbo
+	config.Turbo = &turboConfig
+	ctx.ChainConfig = &config
+
+	getValidatorFee := func(val common.Address) *big.Int {
+		valInfoFields := readSystemContract(t, ctx, "valInfos", val).([]interface{})
+		return valInfoFields[2].(*big.Int)
+	}
+	assert.NoError(t, UpdateActiveValidatorSet(ctx, GenesisValidators))
+
+	fee := uint256.NewInt(1000000000000000000)
+
+	// Before the fork, the whole fee is left to the validators.
+	treasury := common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+	turboConfig.TreasuryBlock = big.NewInt(300)
+	turboConfig.Treasury = &params.TreasuryConfig{Address: treasury, Share: 200}
+	assert.Equal(t, fee, RedirectTreasuryFee(ctx, fee))
+	assert.True(t, ctx.Statedb.GetBalance(treasury).IsZero())
+
+	turboConfig.TreasuryBlock = big.NewInt(100)
+	remaining := RedirectTreasuryFee(ctx, fee)
+	assert.Equal(t, uint256.NewInt(800000000000000000), remaining)
+	assert.Equal(t, uint256.NewInt(200000000000000000), ctx.Statedb.GetBalance(treasury))
+
+	assert.NoError(t, DistributeBlockFee(ctx, remaining))
+	valAmount := big.NewInt(remaining.ToBig().Int64() / 2)
+	assert.Equal(t, valAmount, getValidatorFee(GenesisValidators[0]))
+	assert.Equal(t, valAmount, getValidatorFee(GenesisValidators[1]))
+}
@@ -12,6 +12,7 @@ import (
 
 var hardforkContracts map[string][]IUpgradeAction = map[string][]IUpgradeAction{
 	// Add forks here
+	SlashingHardfork: {&stakingSlashingUpgrade{}},
 }
 
 // IUpgradeAction is the interface for system contracts upgrades
@@ -0,0 +1,115 @@
+package systemcontract
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ValidatorState is the state of a validator in its Validator contract.
+type ValidatorState uint8
+
+const (
+	ValidatorIdle   ValidatorState = iota // Registered, waiting for enough stake
+	ValidatorReady                        // Eligible to the active validator set
+	ValidatorJailed                       // Lazy punished over the threshold
+	ValidatorExited                       // Exited staking
+)
+
+// String implements fmt.Stringer.
+func (s ValidatorState) String() string {
+	switch s {
+	case ValidatorIdle:
+		return "idle"
+	case ValidatorReady:
+		return "ready"
+	case ValidatorJailed:
+		return "jailed"
+	case ValidatorExited:
+		return "exited"
+	default:
+		return "unknown"
+	}
+}
+
+// validatorABI is the subset of the ABI of the Validator contracts deployed by
+// the Staking contract for every registered validator.
+const validatorABI = `[
+	{"inputs":[],"name":"state","outputs":[{"internalType":"enum State","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"punishBlk","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+var validatorContractABI abi.ABI
+
+func init() {
+	var err error
+	if validatorContractABI, err = abi.JSON(strings.NewReader(validatorABI)); err != nil {
+		panic(err)
+	}
+}
+
+// ValidatorStatus is the state of a validator read from its Validator contract.
+type ValidatorStatus struct {
+	State       ValidatorState
+	PunishBlock uint64 // Block the validator was last jailed at
+}
+
+// GetValidatorStatuses returns the states of the given validators. Validators
+// that are not registered in the Staking contract are reported idle.
+func GetValidatorStatuses(ctx *contracts.CallContext, validators []common.Address) ([]ValidatorStatus, error) {
+	statuses := make([]ValidatorStatus, len(validators))
+	for i, validator := range validators {
+		result, err := contractRead(ctx, system.StakingContract, "valMaps", validator)
+		if err != nil {
+			log.Error("GetValidatorStatuses contractRead failed", "validator", validator, "err", err)
+			return nil, err
+		}
+		contract, ok := result.(common.Address)
+		if !ok {
+			return nil, errors.New("GetValidatorStatuses: invalid validator contract format")
+		}
+		if contract == (common.Address{}) {
+			continue
+		}
+		state, err := validatorRead(ctx, contract, "state")
+		if err != nil {
+			return nil, err
+		}
+		punishBlk, err := validatorRead(ctx, contract, "punishBlk")
+		if err != nil {
+			return nil, err
+		}
+		stateValue, ok := state.(uint8)
+		if !ok {
+			return nil, errors.New("GetValidatorStatuses: invalid state format")
+		}
+		punishBlock, ok := punishBlk.(*big.Int)
+		if !ok {
+			return nil, errors.New("GetValidatorStatuses: invalid punish block format")
+		}
+		statuses[i] = ValidatorStatus{State: ValidatorState(stateValue), PunishBlock: punishBlock.Uint64()}
+	}
+	return statuses, nil
+}
+
+// validatorRead reads a single value from a Validator contract.
+func validatorRead(ctx *contracts.CallContext, contract common.Address, method string) (interface{}, error) {
+	result, err := contractReadBytes(ctx, contract, &validatorContractABI, method)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := validatorContractABI.Unpack(method, result)
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) != 1 {
+		return nil, errors.New(method + ": invalid result length")
+	}
+	return ret[0], nil
+}
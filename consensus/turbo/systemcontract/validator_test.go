@@ -0,0 +1,39 @@
+package systemcontract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetValidatorStatuses(t *testing.T) {
+	ctx, err := initCallContext()
+	assert.NoError(t, err, "Init call context error")
+
+	validators := append([]common.Address{}, GenesisValidators...)
+	validators = append(validators, common.Address{0x01})
+
+	statuses, err := GetValidatorStatuses(ctx, validators)
+	if assert.NoError(t, err) && assert.Len(t, statuses, len(validators)) {
+		for i := range GenesisValidators {
+			assert.Equal(t, ValidatorStatus{State: ValidatorReady}, statuses[i])
+		}
+		assert.Equal(t, ValidatorStatus{State: ValidatorIdle}, statuses[len(validators)-1])
+	}
+	// Punishing a validator over the threshold jails it.
+	threshold, err := contractRead(ctx, system.StakingContract, "LazyPunishThreshold")
+	assert.NoError(t, err)
+	for i := int64(0); i <= threshold.(*big.Int).Int64(); i++ {
+		ctx.Header.Number = big.NewInt(200 + i)
+		assert.NoError(t, LazyPunish(ctx, GenesisValidators[0]))
+	}
+	statuses, err = GetValidatorStatuses(ctx, GenesisValidators)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ValidatorJailed, statuses[0].State)
+		assert.NotZero(t, statuses[0].PunishBlock)
+		assert.Equal(t, ValidatorStatus{State: ValidatorReady}, statuses[1])
+	}
+}
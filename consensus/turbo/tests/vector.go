@@ -0,0 +1,208 @@
+// Package tests implements the Turbo consensus conformance tests.
+//
+// A test vector is a JSON file holding a chain configuration, a genesis header
+// and a sequence of sealed headers, each with the expected outcome of its
+// verification on top of the previously accepted headers:
+//
+//	{
+//	  "name":    "inturn",
+//	  "config":  { ...chain configuration... },
+//	  "genesis": { ...header... },
+//	  "headers": [
+//	    {
+//	      "header":     { ...header... },
+//	      "valid":      true,
+//	      "validators": ["0x...", "0x..."],
+//	      "punish":     {"validator": "0x...", "punished": true}
+//	    },
+//	    {
+//	      "header": { ...header... },
+//	      "valid":  false,
+//	      "error":  "recently signed"
+//	    }
+//	  ]
+//	}
+//
+// The validators are the set authorized to seal the next block once the header
+// is accepted, in ascending order. The punish outcome is set for the headers
+// sealed out of turn, naming the validator which missed its turn and whether it
+// is lazy punished for it. Rejected headers are not part of the chain the next
+// headers are verified against. The error messages are those of this
+// implementation, alternative implementations only need to match the validity.
+//
+// The jails of the Staking contract depend on the state of the chain and are
+// not covered by the vectors.
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/turbo"
+	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Vector is a Turbo consensus test vector.
+type Vector struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description,omitempty"`
+	Config      *params.ChainConfig `json:"config"`
+	Genesis     *types.Header       `json:"genesis"`
+	Headers     []*HeaderTest       `json:"headers"`
+}
+
+// HeaderTest is a header of a vector with the expected outcome of its
+// verification.
+type HeaderTest struct {
+	Header     *types.Header    `json:"header"`
+	Valid      bool             `json:"valid"`
+	Error      string           `json:"error,omitempty"`      // Error rejecting the header, if invalid
+	Validators []common.Address `json:"validators,omitempty"` // Validator set after the header, if valid
+	Punish     *PunishTest      `json:"punish,omitempty"`     // Punish outcome, if valid and sealed out of turn
+}
+
+// PunishTest is the expected punish outcome of a header sealed out of turn.
+type PunishTest struct {
+	Validator common.Address `json:"validator"` // In-turn validator which missed its turn
+	Punished  bool           `json:"punished"`  // Whether it is lazy punished, not having sealed recently
+}
+
+// LoadVector reads a test vector from a file.
+func LoadVector(file string) (*Vector, error) {
+	blob, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	vector := new(Vector)
+	if err := json.Unmarshal(blob, vector); err != nil {
+		return nil, fmt.Errorf("invalid test vector %s: %v", file, err)
+	}
+	return vector, nil
+}
+
+// Run verifies the headers of the vector in sequence with the Turbo engine,
+// returning an error at the first outcome mismatch.
+func (v *Vector) Run() error {
+	if v.Config == nil || v.Config.Turbo == nil {
+		return fmt.Errorf("vector %s: missing turbo config", v.Name)
+	}
+	if v.Genesis == nil || v.Genesis.Number == nil || v.Genesis.Number.Sign() != 0 {
+		return fmt.Errorf("vector %s: missing genesis", v.Name)
+	}
+	var (
+		engine = turbo.New(v.Config, rawdb.NewMemoryDatabase())
+		chain  = newHeaderChain(v.Config, v.Genesis)
+	)
+	for i, test := range v.Headers {
+		if err := v.runHeader(engine, chain, test); err != nil {
+			return fmt.Errorf("vector %s, header %d (#%v): %v", v.Name, i, test.Header.Number, err)
+		}
+	}
+	return nil
+}
+
+// runHeader verifies a header of the vector, adding it to the chain if valid.
+func (v *Vector) runHeader(engine *turbo.Turbo, chain *headerChain, test *HeaderTest) error {
+	header := test.Header
+	if err := engine.VerifyHeader(chain, header); err != nil {
+		if test.Valid {
+			return fmt.Errorf("valid header rejected: %v", err)
+		}
+		if test.Error != "" && err.Error() != test.Error {
+			return fmt.Errorf("error mismatch: have %q, want %q", err, test.Error)
+		}
+		return nil
+	}
+	if !test.Valid {
+		return fmt.Errorf("invalid header accepted, want error %q", test.Error)
+	}
+	chain.insert(header)
+
+	if test.Validators != nil {
+		snap, err := engine.SnapshotAt(chain, header.Number.Uint64(), header.Hash())
+		if err != nil {
+			return fmt.Errorf("failed to retrieve snapshot: %v", err)
+		}
+		validators := make([]common.Address, 0, len(test.Validators))
+		for validator := range snap.(*turbo.Snapshot).Validators {
+			validators = append(validators, validator)
+		}
+		sort.Sort(systemcontract.AddrAscend(validators))
+		if !equalAddresses(validators, test.Validators) {
+			return fmt.Errorf("validators mismatch: have %v, want %v", validators, test.Validators)
+		}
+	}
+	if header.Difficulty.Cmp(big.NewInt(2)) == 0 {
+		if test.Punish != nil {
+			return fmt.Errorf("in-turn header with punish outcome")
+		}
+		return nil
+	}
+	validator, punished, err := engine.MissedTurn(chain, header)
+	if err != nil {
+		return fmt.Errorf("failed to resolve missed turn: %v", err)
+	}
+	if test.Punish != nil && (validator != test.Punish.Validator || punished != test.Punish.Punished) {
+		return fmt.Errorf("punish mismatch: have %v (punished %v), want %v (punished %v)", validator, punished, test.Punish.Validator, test.Punish.Punished)
+	}
+	return nil
+}
+
+func equalAddresses(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// headerChain is the chain of the accepted headers of a vector, a header
+// replacing the canonical one of its number and its descendants.
+type headerChain struct {
+	config    *params.ChainConfig
+	canonical []*types.Header
+	headers   map[common.Hash]*types.Header
+}
+
+func newHeaderChain(config *params.ChainConfig, genesis *types.Header) *headerChain {
+	chain := &headerChain{config: config, headers: make(map[common.Hash]*types.Header)}
+	chain.insert(genesis)
+	return chain
+}
+
+func (c *headerChain) insert(header *types.Header) {
+	c.headers[header.Hash()] = header
+	c.canonical = append(c.canonical[:header.Number.Uint64()], header)
+}
+
+func (c *headerChain) Config() *params.ChainConfig  { return c.config }
+func (c *headerChain) CurrentHeader() *types.Header { return c.canonical[len(c.canonical)-1] }
+func (c *headerChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	return c.headers[hash]
+}
+func (c *headerChain) GetTd(hash common.Hash, number uint64) *big.Int { return nil }
+
+func (c *headerChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.headers[hash]; header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+func (c *headerChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number < uint64(len(c.canonical)) {
+		return c.canonical[number]
+	}
+	return nil
+}
@@ -0,0 +1,344 @@
+package tests
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/turbo"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// To regenerate the Turbo test vectors, run
+//
+//	go test -run TestVectors -write-test-vectors
+var writeTestVectorsFlag = flag.Bool("write-test-vectors", false, "Overwrite Turbo test vectors in testdata/")
+
+const (
+	extraVanity = 32                     // Fixed number of extra-data prefix bytes reserved for validator vanity
+	extraSeal   = crypto.SignatureLength // Fixed number of extra-data suffix bytes reserved for validator seal
+
+	genesisTime = 1700000000
+)
+
+// testKeys are the deterministic keys of the validators, in ascending order of
+// their addresses, so that validator i seals in turn the blocks i mod N of a
+// set of the N first validators.
+var testKeys = func() []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, 6)
+	for i := range keys {
+		keys[i], _ = crypto.ToECDSA(crypto.Keccak256([]byte("turbo"), []byte{byte(i)}))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(crypto.PubkeyToAddress(keys[i].PublicKey).Bytes(), crypto.PubkeyToAddress(keys[j].PublicKey).Bytes()) < 0
+	})
+	return keys
+}()
+
+func addr(i int) common.Address {
+	return crypto.PubkeyToAddress(testKeys[i].PublicKey)
+}
+
+func addrs(indexes []int) []common.Address {
+	list := make([]common.Address, len(indexes))
+	for i, index := range indexes {
+		list[i] = addr(index)
+	}
+	return list
+}
+
+// headerSpec describes a header of a generated vector, sealed on top of the
+// last valid one.
+type headerSpec struct {
+	signer     int                 // Index of the sealing key
+	difficulty int64               // Sealed difficulty, in turn (2) if zero
+	delay      uint64              // Seconds after the parent, the period if zero
+	checkpoint []int               // Validators of an epoch header, the previous ones if nil
+	modify     func(*types.Header) // Change of the header before sealing
+
+	err        string // Error rejecting the header, valid if empty
+	validators []int  // Expected validator set after the header
+	punish     *punishSpec
+}
+
+type punishSpec struct {
+	validator int
+	punished  bool
+}
+
+// vectorSpec describes a generated vector.
+type vectorSpec struct {
+	name        string
+	description string
+	epoch       uint64
+	london      *big.Int // London fork block, 0 if nil
+	validators  []int    // Genesis validators
+	headers     []headerSpec
+}
+
+// inturn returns the specs of the headers from first to last sealed in turn by
+// a set of n validators.
+func inturn(first, last, n int) []headerSpec {
+	var specs []headerSpec
+	for number := first; number <= last; number++ {
+		specs = append(specs, headerSpec{signer: number % n})
+	}
+	return specs
+}
+
+func extra(validators []int) []byte {
+	extra := make([]byte, 0, extraVanity+len(validators)*common.AddressLength+extraSeal)
+	extra = append(extra, make([]byte, extraVanity)...)
+	for _, validator := range validators {
+		extra = append(extra, addr(validator).Bytes()...)
+	}
+	return append(extra, make([]byte, extraSeal)...)
+}
+
+// generate seals the headers of the vector described by the spec.
+func (spec *vectorSpec) generate() *Vector {
+	config := *params.AllTurboProtocolChanges
+	config.Turbo = &params.TurboConfig{Period: 1, Epoch: spec.epoch}
+	config.LondonBlock = spec.london
+	if config.LondonBlock == nil {
+		config.LondonBlock = new(big.Int)
+	}
+	genesis := &types.Header{
+		Number:     new(big.Int),
+		Time:       genesisTime,
+		GasLimit:   params.GenesisGasLimit,
+		Difficulty: big.NewInt(2),
+		UncleHash:  types.EmptyUncleHash,
+		Extra:      extra(spec.validators),
+	}
+	if config.IsLondon(genesis.Number) {
+		genesis.BaseFee = big.NewInt(params.InitialBaseFee)
+	}
+	vector := &Vector{
+		Name:        spec.name,
+		Description: spec.description,
+		Config:      &config,
+		Genesis:     genesis,
+	}
+	var (
+		parent     = genesis
+		checkpoint = spec.validators
+	)
+	for _, hs := range spec.headers {
+		number := new(big.Int).Add(parent.Number, common.Big1)
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     number,
+			Time:       parent.Time + config.Turbo.Period,
+			GasLimit:   parent.GasLimit,
+			Difficulty: big.NewInt(2),
+			UncleHash:  types.EmptyUncleHash,
+			Coinbase:   addr(hs.signer),
+			Extra:      extra(nil),
+		}
+		if hs.delay != 0 {
+			header.Time = parent.Time + hs.delay
+		}
+		if hs.difficulty != 0 {
+			header.Difficulty = big.NewInt(hs.difficulty)
+		}
+		if config.IsLondon(number) {
+			if !config.IsLondon(parent.Number) {
+				header.GasLimit = parent.GasLimit * config.ElasticityMultiplier()
+			}
+			header.BaseFee = eip1559.CalcBaseFee(&config, parent)
+		}
+		if number.Uint64()%spec.epoch == 0 {
+			if hs.checkpoint != nil {
+				checkpoint = hs.checkpoint
+			}
+			header.Extra = extra(checkpoint)
+		}
+		if hs.modify != nil {
+			hs.modify(header)
+		}
+		sig, err := crypto.Sign(turbo.SealHash(header).Bytes(), testKeys[hs.signer])
+		if err != nil {
+			panic(err)
+		}
+		copy(header.Extra[len(header.Extra)-extraSeal:], sig)
+
+		test := &HeaderTest{
+			Header: header,
+			Valid:  hs.err == "",
+			Error:  hs.err,
+		}
+		if hs.validators != nil {
+			test.Validators = addrs(hs.validators)
+		}
+		if hs.punish != nil {
+			test.Punish = &PunishTest{Validator: addr(hs.punish.validator), Punished: hs.punish.punished}
+		}
+		vector.Headers = append(vector.Headers, test)
+		if test.Valid {
+			parent = header
+		}
+	}
+	return vector
+}
+
+var vectorSpecs = []*vectorSpec{
+	{
+		name:        "inturn",
+		description: "Three validators sealing in turn",
+		epoch:       100,
+		validators:  []int{0, 1, 2},
+		headers: append(inturn(1, 5, 3), headerSpec{
+			signer: 0, validators: []int{0, 1, 2},
+		}),
+	},
+	{
+		name:        "outofturn",
+		description: "Blocks sealed out of turn, lazy punishing the in-turn validator unless it sealed recently",
+		epoch:       100,
+		validators:  []int{0, 1, 2},
+		headers: []headerSpec{
+			{signer: 1},
+			{signer: 2},
+			// Validator 0 misses block 3 without having sealed recently
+			{signer: 1, difficulty: 1, punish: &punishSpec{validator: 0, punished: true}},
+			// Validator 1 sealed block 3, missing block 4 isn't punished
+			{signer: 1, difficulty: 1, err: "recently signed"},
+			{signer: 0, difficulty: 1, punish: &punishSpec{validator: 1, punished: false}},
+			// Out-of-turn sealing with the in-turn difficulty and conversely
+			{signer: 1, err: "wrong difficulty"},
+			{signer: 2, difficulty: 1, err: "wrong difficulty"},
+			{signer: 2},
+			{signer: 0, validators: []int{0, 1, 2}},
+		},
+	},
+	{
+		name:        "rejects",
+		description: "Headers breaking the sealing and header rules, each followed by a valid one",
+		epoch:       100,
+		validators:  []int{0, 1, 2},
+		headers: []headerSpec{
+			{signer: 3, err: "unauthorized validator"},
+			{signer: 1, modify: func(h *types.Header) { h.Coinbase = addr(2) }, err: "invalid coin base"},
+			{signer: 1, delay: 1 << 40, err: "block in the future"},
+			{signer: 1, modify: func(h *types.Header) { h.Extra = extra([]int{1}) }, err: "non-checkpoint block contains extra validator list"},
+			{signer: 1, modify: func(h *types.Header) { h.Extra = make([]byte, extraVanity+extraSeal-1) }, err: "extra-data 65 byte signature suffix missing"},
+			{signer: 1, modify: func(h *types.Header) { h.MixDigest = common.Hash{0x01} }, err: "non-zero mix digest"},
+			{signer: 1, modify: func(h *types.Header) { h.UncleHash = common.Hash{0x01} }, err: "non empty uncle hash"},
+			{signer: 1, modify: func(h *types.Header) { h.ParentHash = common.Hash{0x01} }, err: "unknown ancestor"},
+			{signer: 1, modify: func(h *types.Header) { h.GasUsed = h.GasLimit + 1 }, err: "invalid gasUsed: have 4712389, gasLimit 4712388"},
+			{signer: 1, modify: func(h *types.Header) { h.BaseFee = big.NewInt(1) }, err: "invalid baseFee: have 1, want 875000000, parentBaseFee 1000000000, parentGasUsed 0"},
+			{signer: 1, validators: []int{0, 1, 2}},
+			{signer: 2, delay: 0, modify: func(h *types.Header) { h.Time-- }, err: "invalid timestamp"},
+			{signer: 1, err: "recently signed"},
+			{signer: 2},
+		},
+	},
+	{
+		name:        "epoch",
+		description: "Validator set growing and shrinking, the checkpoint of an epoch applying from the next one",
+		epoch:       4,
+		validators:  []int{0, 1, 2},
+		headers: func() []headerSpec {
+			specs := inturn(1, 3, 3)
+			specs = append(specs,
+				headerSpec{signer: 1, checkpoint: []int{0, 1, 2, 3}, modify: func(h *types.Header) { h.Extra = h.Extra[:len(h.Extra)-1] }, err: "non-checkpoint block contains extra validator list"},
+				headerSpec{signer: 1, checkpoint: []int{0, 1, 2, 3}, validators: []int{0, 1, 2}},
+			)
+			specs = append(specs, headerSpec{signer: 3, err: "unauthorized validator"})
+			specs = append(specs, inturn(5, 7, 3)...)
+			specs = append(specs,
+				headerSpec{signer: 2, validators: []int{0, 1, 2, 3}},
+				// The in-turn validators sealed recently in the smaller set
+				headerSpec{signer: 0, difficulty: 1, punish: &punishSpec{validator: 1, punished: false}},
+				headerSpec{signer: 1, difficulty: 1, punish: &punishSpec{validator: 2, punished: false}},
+				headerSpec{signer: 3},
+			)
+			specs = append(specs, headerSpec{signer: 0, checkpoint: []int{0, 1}, validators: []int{0, 1, 2, 3}})
+			specs = append(specs, inturn(13, 15, 4)...)
+			specs = append(specs,
+				headerSpec{signer: 0, validators: []int{0, 1}},
+				headerSpec{signer: 2, err: "unauthorized validator"},
+				headerSpec{signer: 1},
+				headerSpec{signer: 0},
+			)
+			return specs
+		}(),
+	},
+	{
+		name:        "london",
+		description: "Base fee introduced by the London fork at block 2",
+		epoch:       100,
+		london:      big.NewInt(2),
+		validators:  []int{0, 1, 2},
+		headers: []headerSpec{
+			{signer: 1, modify: func(h *types.Header) { h.BaseFee = big.NewInt(params.InitialBaseFee) }, err: "invalid baseFee before fork: have 1000000000, want <nil>"},
+			{signer: 1},
+			{signer: 2, modify: func(h *types.Header) { h.BaseFee = nil }, err: "header is missing baseFee"},
+			{signer: 2},
+			{signer: 0, validators: []int{0, 1, 2}},
+		},
+	},
+}
+
+// Tests that the Turbo engine conforms to the test vectors.
+func TestVectors(t *testing.T) {
+	if *writeTestVectorsFlag {
+		for _, spec := range vectorSpecs {
+			blob, err := json.MarshalIndent(spec.generate(), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join("testdata", spec.name+".json"), append(blob, '\n'), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	files, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(vectorSpecs) {
+		t.Fatalf("test vector count mismatch: have %d, want %d", len(files), len(vectorSpecs))
+	}
+	for _, file := range files {
+		vector, err := LoadVector(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(vector.Name, func(t *testing.T) {
+			if err := vector.Run(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Tests that the runner detects the outcome mismatches.
+func TestVectorMismatch(t *testing.T) {
+	for i, alter := range []func(v *Vector){
+		func(v *Vector) { v.Headers[0].Valid = false },
+		func(v *Vector) { v.Headers[3].Error = "unauthorized validator" },
+		func(v *Vector) { v.Headers[2].Punish.Punished = false },
+		func(v *Vector) { v.Headers[8].Validators = v.Headers[8].Validators[1:] },
+	} {
+		vector := vectorSpecs[1].generate()
+		if err := vector.Run(); err != nil {
+			t.Fatalf("test %d: unaltered vector failed: %v", i, err)
+		}
+		alter(vector)
+		if err := vector.Run(); err == nil {
+			t.Errorf("test %d: altered vector passed", i)
+		}
+	}
+}
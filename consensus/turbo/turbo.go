@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"io"
 	"math/big"
 	"math/rand"
+	"runtime"
 	"sync"
 	"time"
 
@@ -15,21 +15,21 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/turbo/light"
 	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
 	"github.com/ethereum/go-ethereum/contracts"
 	"github.com/ethereum/go-ethereum/contracts/system"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
 	lru "github.com/hashicorp/golang-lru"
-	"golang.org/x/crypto/sha3"
+	"github.com/holiman/uint256"
 )
 
 const (
@@ -46,8 +46,8 @@ const (
 var (
 	epochLength = uint64(30000) // Default number of blocks after which to checkpoint and reset the pending votes
 
-	extraVanity = 32                     // Fixed number of extra-data prefix bytes reserved for validator vanity
-	extraSeal   = crypto.SignatureLength // Fixed number of extra-data suffix bytes reserved for validator seal
+	extraVanity = light.ExtraVanity // Fixed number of extra-data prefix bytes reserved for validator vanity
+	extraSeal   = light.ExtraSeal   // Fixed number of extra-data suffix bytes reserved for validator seal
 
 	uncleHash = types.CalcUncleHash(nil) // Always Keccak256(RLP([])) as uncles are meaningless outside of PoW.
 
@@ -60,6 +60,10 @@ var (
 	lazyPunishByte4       = []byte{0xe8, 0x18, 0xef, 0x86}
 )
 
+// jailedGauge reports the number of jailed validators recorded at the last epoch
+// block.
+var jailedGauge = metrics.NewRegisteredGauge("turbo/validators/jailed", nil)
+
 // Various error messages to mark blocks invalid. These should be private to
 // prevent engine specific errors from being referenced in the remainder of the
 // codebase, inherently breaking if the engine is swapped out. Please put common
@@ -120,10 +124,18 @@ var (
 	// errUnauthorizedValidator is returned if a header is signed by a non-authorized entity.
 	errUnauthorizedValidator = errors.New("unauthorized validator")
 
+	// errJailedValidator is returned if a header is signed by a validator jailed
+	// at the last epoch block.
+	errJailedValidator = errors.New("jailed validator")
+
 	// errRecentlySigned is returned if a header is signed by an authorized entity
 	// that already signed a header recently, thus is temporarily not allowed to.
 	errRecentlySigned = errors.New("recently signed")
 
+	// errBelowSigningFloor is returned when sealing a block below the signing
+	// floor of the validator, which might have signed another block already.
+	errBelowSigningFloor = errors.New("block below signing floor, refusing to double sign")
+
 	// errInvalidValidatorLen is returned if validators length is zero or bigger than maxValidators.
 	// errInvalidValidatorsLength = errors.New("Invalid validators length")
 
@@ -154,20 +166,10 @@ func ecrecover(header *types.Header, sigcache *lru.ARCCache) (common.Address, er
 	if address, known := sigcache.Get(hash); known {
 		return address.(common.Address), nil
 	}
-	// Retrieve the signature from the header extra-data
-	if len(header.Extra) < extraSeal {
-		return common.Address{}, errMissingSignature
-	}
-	signature := header.Extra[len(header.Extra)-extraSeal:]
-
-	// Recover the public key and the Ethereum address
-	pubkey, err := crypto.Ecrecover(SealHash(header).Bytes(), signature)
+	validator, err := light.RecoverSigner(header)
 	if err != nil {
 		return common.Address{}, err
 	}
-	var validator common.Address
-	copy(validator[:], crypto.Keccak256(pubkey[1:])[12:])
-
 	sigcache.Add(hash, validator)
 	return validator, nil
 }
@@ -184,7 +186,9 @@ type Turbo struct {
 	accesslist      *lru.Cache // accesslists caches recent accesslist to speed up transactions validation
 	eventCheckRules *lru.Cache // eventCheckRules caches recent EventCheckRules to speed up log validation
 
-	signer types.Signer // the signer instance to recover tx sender
+	signer  types.Signer                 // the signer instance to recover tx sender
+	txRules []activeTxRule               // additional tx validation rules enabled by the chain config
+	params  *systemcontract.ParamsReader // cached reader of the on-chain parameters
 
 	validator common.Address // Ethereum address of the signing key
 	signFn    ValidatorFn    // Validator function to authorize hashes with
@@ -194,6 +198,17 @@ type Turbo struct {
 
 	stateFn StateFn // Function to get state by state root
 
+	maxDrift time.Duration // Time the headers may be stamped ahead of the local clock
+
+	sealStopped bool        // Whether the sealing is stopped to hand the slots over
+	sealLimit   uint64      // Number of the last block sealed once the sealing is stopped
+	flushed     common.Hash // Hash of the snapshot flushed on the last shutdown
+	signLock    sync.Mutex  // Serializes the signing floor checks and updates
+
+	haltOverride *uint64     // Halt block set locally in place of the governed one, nil if none
+	haltHash     common.Hash // Hash of the head the governed halt block was last read at
+	haltGoverned uint64      // Governed halt block read at haltHash, 0 if none
+
 	chain consensus.ChainHeaderReader
 
 	// The fields below are for testing only
@@ -217,6 +232,10 @@ func New(chainConfig *params.ChainConfig, db ethdb.Database) *Turbo {
 	accesslist, _ := lru.New(inmemoryAccesslist)
 	eventCheckRules, _ := lru.New(inmemoryAccesslist)
 
+	var flushed common.Hash
+	if blob, err := db.Get(flushedSnapshotKey); err == nil {
+		flushed = common.BytesToHash(blob)
+	}
 	return &Turbo{
 		chainConfig:     chainConfig,
 		config:          &conf,
@@ -226,6 +245,9 @@ func New(chainConfig *params.ChainConfig, db ethdb.Database) *Turbo {
 		accesslist:      accesslist,
 		eventCheckRules: eventCheckRules,
 		signer:          types.LatestSignerForChainID(chainConfig.ChainID),
+		txRules:         newActiveTxRules(conf.TxRules),
+		params:          systemcontract.NewParamsReader(),
+		flushed:         flushed,
 	}
 }
 
@@ -242,6 +264,12 @@ func (c *Turbo) SetStateFn(fn StateFn) {
 	c.stateFn = fn
 }
 
+// SetMaxClockDrift sets the time the headers may be stamped ahead of the local
+// clock before being considered future blocks.
+func (c *Turbo) SetMaxClockDrift(drift time.Duration) {
+	c.maxDrift = drift
+}
+
 // Author implements consensus.Engine, returning the Ethereum address recovered
 // from the signature in the header's extra-data section.
 func (c *Turbo) Author(header *types.Header) (common.Address, error) {
@@ -257,14 +285,54 @@ func (c *Turbo) VerifyHeader(chain consensus.ChainHeaderReader, header *types.He
 // VerifyHeaders is similar to VerifyHeader, but verifies a batch of headers. The
 // method returns a quit channel to abort the operations and a results channel to
 // retrieve the async verifications (the order is that of the input slice).
+//
+// The standalone fields of the headers are checked and their signers recovered
+// by a pool of workers, ahead of the in-order verification of the cascading
+// fields and seals. The recovered signers are shared through the signature
+// cache, and the snapshot of each header is derived from the one of its parent
+// through the snapshot cache.
 func (c *Turbo) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*types.Header) (chan<- struct{}, <-chan error) {
-	abort := make(chan struct{})
-	results := make(chan error, len(headers))
-
+	var (
+		abort     = make(chan struct{})
+		results   = make(chan error, len(headers))
+		inputs    = make(chan int)
+		prechecks = make([]chan error, len(headers))
+		workers   = runtime.GOMAXPROCS(0)
+	)
+	if workers > len(headers) {
+		workers = len(headers)
+	}
+	for i := range prechecks {
+		prechecks[i] = make(chan error, 1)
+	}
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range inputs {
+				prechecks[i] <- c.preverifyHeader(headers[i])
+			}
+		}()
+	}
+	go func() {
+		defer close(inputs)
+		for i := range headers {
+			select {
+			case <-abort:
+				return
+			case inputs <- i:
+			}
+		}
+	}()
 	go func() {
 		for i, header := range headers {
-			err := c.verifyHeader(chain, header, headers[:i])
-
+			var err error
+			select {
+			case <-abort:
+				return
+			case err = <-prechecks[i]:
+			}
+			if err == nil {
+				err = c.verifyCascadingFields(chain, header, headers[:i])
+			}
 			select {
 			case <-abort:
 				return
@@ -275,18 +343,42 @@ func (c *Turbo) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*type
 	return abort, results
 }
 
+// preverifyHeader checks the standalone fields of a header, and warms up the
+// signature cache with its signer. Signature recovery failures are left to the
+// seal verification, so that the errors are reported in the same order as for
+// a single header.
+func (c *Turbo) preverifyHeader(header *types.Header) error {
+	if err := c.verifyStandaloneFields(header); err != nil {
+		return err
+	}
+	if header.Number.Sign() > 0 {
+		ecrecover(header, c.signatures)
+	}
+	return nil
+}
+
 // verifyHeader checks whether a header conforms to the consensus rules.The
 // caller may optionally pass in a batch of parents (ascending order) to avoid
 // looking those up from the database. This is useful for concurrently verifying
 // a batch of new headers.
 func (c *Turbo) verifyHeader(chain consensus.ChainHeaderReader, header *types.Header, parents []*types.Header) error {
+	if err := c.verifyStandaloneFields(header); err != nil {
+		return err
+	}
+	// All basic checks passed, verify cascading fields
+	return c.verifyCascadingFields(chain, header, parents)
+}
+
+// verifyStandaloneFields checks the header fields that don't depend on other
+// headers.
+func (c *Turbo) verifyStandaloneFields(header *types.Header) error {
 	if header.Number == nil {
 		return errUnknownBlock
 	}
 	number := header.Number.Uint64()
 
 	// Don't waste time checking blocks from the future
-	if header.Time > uint64(time.Now().Unix()) {
+	if header.Time > uint64(time.Now().Add(c.maxDrift).Unix()) {
 		return consensus.ErrFutureBlock
 	}
 	// Check that the extra-data contains the vanity, validators and signature.
@@ -296,16 +388,9 @@ func (c *Turbo) verifyHeader(chain consensus.ChainHeaderReader, header *types.He
 	if len(header.Extra) < extraVanity+extraSeal {
 		return errMissingSignature
 	}
-	// check extra data
-	isEpoch := number%c.config.Epoch == 0
-
-	// Ensure that the extra-data contains a validator list on checkpoint, but none otherwise
-	validatorsBytes := len(header.Extra) - extraVanity - extraSeal
-	if !isEpoch && validatorsBytes != 0 {
-		return errExtraValidators
-	}
-	// Ensure that the validator bytes length is valid
-	if isEpoch && validatorsBytes%common.AddressLength != 0 {
+	// Ensure that the extra-data contains a validator list (and the jails from
+	// the jail fork) on checkpoint, but none otherwise
+	if !light.ValidExtra(c.config, number, header.Extra) {
 		return errExtraValidators
 	}
 
@@ -338,8 +423,7 @@ func (c *Turbo) verifyHeader(chain consensus.ChainHeaderReader, header *types.He
 	case header.ParentBeaconRoot != nil:
 		return fmt.Errorf("invalid parentBeaconRoot, have %#x, expected nil", header.ParentBeaconRoot)
 	}
-	// All basic checks passed, verify cascading fields
-	return c.verifyCascadingFields(chain, header, parents)
+	return nil
 }
 
 // verifyCascadingFields verifies all the header fields that are not standalone,
@@ -363,7 +447,10 @@ func (c *Turbo) verifyCascadingFields(chain consensus.ChainHeaderReader, header
 		return consensus.ErrUnknownAncestor
 	}
 
-	if parent.Time+c.config.Period > header.Time {
+	if parent.Time+c.config.PeriodAt(number) > header.Time {
+		return ErrInvalidTimestamp
+	}
+	if c.config.IsStrictTime(header.Number) && !light.StrictTime(parent, header, c.config.PeriodAt(number)) {
 		return ErrInvalidTimestamp
 	}
 
@@ -401,8 +488,8 @@ func (c *Turbo) snapshot(chain consensus.ChainHeaderReader, number uint64, hash
 			snap = s.(*Snapshot)
 			break
 		}
-		// If an on-disk checkpoint snapshot can be found, use that
-		if number%checkpointInterval == 0 {
+		// If an on-disk checkpoint or flushed snapshot can be found, use that
+		if number%checkpointInterval == 0 || hash == c.flushed {
 			if s, err := loadSnapshot(c.chainConfig, c.signatures, c.db, hash); err == nil {
 				log.Trace("Loaded voting snapshot from disk", "number", number, "hash", hash)
 				snap = s
@@ -413,16 +500,12 @@ func (c *Turbo) snapshot(chain consensus.ChainHeaderReader, number uint64, hash
 		// at a checkpoint block without a parent (light client CHT), or we have piled
 		// up more headers than allowed to be reorged (chain reinit from a freezer),
 		// consider the checkpoint trusted and snapshot it.
-		if number == 0 || (number%c.config.Epoch == 0 && (len(headers) > params.FullImmutabilityThreshold || chain.GetHeaderByNumber(number-1) == nil)) {
+		if number == 0 || (c.config.IsEpoch(number) && (len(headers) > params.FullImmutabilityThreshold || chain.GetHeaderByNumber(number-1) == nil)) {
 			checkpoint := chain.GetHeaderByNumber(number)
 			if checkpoint != nil {
 				hash := checkpoint.Hash()
 
-				validators := make([]common.Address, (len(checkpoint.Extra)-extraVanity-extraSeal)/common.AddressLength)
-				for i := 0; i < len(validators); i++ {
-					copy(validators[i][:], checkpoint.Extra[extraVanity+i*common.AddressLength:])
-				}
-				snap = newSnapshot(c.chainConfig, c.signatures, number, hash, validators)
+				snap = newSnapshot(c.chainConfig, c.signatures, number, hash, EpochValidators(checkpoint), EpochJailed(checkpoint))
 				if err := snap.store(c.db); err != nil {
 					return nil, err
 				}
@@ -457,6 +540,9 @@ func (c *Turbo) snapshot(chain consensus.ChainHeaderReader, number uint64, hash
 	if err != nil {
 		return nil, err
 	}
+	if len(headers) > 0 {
+		jailedGauge.Update(int64(len(snap.Jailed)))
+	}
 	c.recents.Add(snap.Hash, snap)
 
 	// If we've generated a new checkpoint snapshot, save to disk
@@ -469,6 +555,29 @@ func (c *Turbo) snapshot(chain consensus.ChainHeaderReader, number uint64, hash
 	return snap, err
 }
 
+// SnapshotAt returns the authorization snapshot at the given block, typed
+// loosely so callers outside the package can dump it for diagnostics.
+func (c *Turbo) SnapshotAt(chain consensus.ChainHeaderReader, number uint64, hash common.Hash) (interface{}, error) {
+	snap, err := c.snapshot(chain, number, hash, nil)
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// InTurn reports whether the validator is authorized to seal the block on top
+// of the given parent, and whether it is its turn to do so.
+func (c *Turbo) InTurn(chain consensus.ChainHeaderReader, parent *types.Header, validator common.Address) (authorized bool, inturn bool, err error) {
+	snap, err := c.snapshot(chain, parent.Number.Uint64(), parent.Hash(), nil)
+	if err != nil {
+		return false, false, err
+	}
+	if !snap.IsProposer(validator) {
+		return false, false, nil
+	}
+	return true, snap.inturn(snap.Number+1, validator), nil
+}
+
 // VerifyUncles implements consensus.Engine, always returning an error for any
 // uncles as this consensus mechanism doesn't permit uncles.
 func (c *Turbo) VerifyUncles(chain consensus.ChainReader, block *types.Block) error {
@@ -512,6 +621,10 @@ func (c *Turbo) verifySeal(chain consensus.ChainHeaderReader, header *types.Head
 	if _, ok := snap.Validators[signer]; !ok {
 		return errUnauthorizedValidator
 	}
+	// Jailed validators are left out of the rotation until released
+	if !snap.IsProposer(signer) {
+		return errJailedValidator
+	}
 
 	// Validator is among recents, only fail if the current block doesn't shift it out
 	if snap.SignedRecently(number, signer) {
@@ -554,7 +667,7 @@ func (c *Turbo) Prepare(chain consensus.ChainHeaderReader, header *types.Header)
 	}
 	header.Extra = header.Extra[:extraVanity]
 
-	if number%c.config.Epoch == 0 {
+	if c.config.IsEpoch(number) {
 		newSortedValidators, err := c.getTopValidators(chain, header)
 		if err != nil {
 			return err
@@ -563,6 +676,13 @@ func (c *Turbo) Prepare(chain consensus.ChainHeaderReader, header *types.Header)
 		for _, validator := range newSortedValidators {
 			header.Extra = append(header.Extra, validator.Bytes()...)
 		}
+		if c.config.IsJail(header.Number) {
+			jailed, err := c.getJailedValidators(chain, header)
+			if err != nil {
+				return err
+			}
+			header.Extra = append(header.Extra, light.EncodeJailed(jailed)...)
+		}
 	}
 	header.Extra = append(header.Extra, make([]byte, extraSeal)...)
 
@@ -574,7 +694,10 @@ func (c *Turbo) Prepare(chain consensus.ChainHeaderReader, header *types.Header)
 	if parent == nil {
 		return consensus.ErrUnknownAncestor
 	}
-	header.Time = parent.Time + c.config.Period
+	header.Time = parent.Time + c.config.PeriodAt(number)
+	if c.config.IsStrictTime(header.Number) && !light.StrictTime(parent, header, c.config.PeriodAt(number)) {
+		header.Time++
+	}
 	if header.Time < uint64(time.Now().Unix()) {
 		header.Time = uint64(time.Now().Unix())
 	}
@@ -628,43 +751,82 @@ func (c *Turbo) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *t
 // * update validators
 // * decrease missed blocks counter
 // * update rewards info
+// * account supply
 // * punish double sign
+//
+// Once metered, all the system contract calls are executed against the system
+// gas allowance of the block.
 func (c *Turbo) prepareFinalize(chain consensus.ChainHeaderReader, header *types.Header,
 	state *state.StateDB, txs *[]*types.Transaction, receipts *[]*types.Receipt, punishTxs []*types.Transaction, mined bool) error {
+	gas := c.newSystemGas(header, *receipts)
+
 	// punish validator if low difficulty block found
 	if header.Difficulty.Cmp(diffInTurn) != 0 {
-		if err := c.tryLazyPunish(chain, header, state); err != nil {
+		if err := c.tryLazyPunish(chain, header, state, gas, !mined && isLiveImport(chain, header)); err != nil {
 			return err
 		}
 	}
 	// execute block reward tx.
 	if len(*txs) > 0 {
-		if err := c.tryDistributeBlockFee(chain, header, state); err != nil {
+		if err := c.tryDistributeBlockFee(chain, header, state, gas); err != nil {
 			return err
 		}
 	}
+	vmCtx := &contracts.CallContext{
+		Statedb:      state,
+		Header:       header,
+		ChainContext: newChainContext(chain, c),
+		ChainConfig:  c.chainConfig,
+	}
 	// do epoch thing at the end, because it will update active validators
-	if header.Number.Uint64()%c.config.Epoch == 0 {
-		vmCtx := &contracts.CallContext{
-			Statedb:      state,
-			Header:       header,
-			ChainContext: newChainContext(chain, c),
-			ChainConfig:  c.chainConfig,
+	if c.config.IsEpoch(header.Number.Uint64()) {
+		if err := c.updateValidators(vmCtx, chain, mined, gas); err != nil {
+			return err
 		}
-		if err := c.updateValidators(vmCtx, chain, mined); err != nil {
+		if err := c.applyEmission(vmCtx); err != nil {
 			return err
 		}
-		//  decrease validator missed blocks counter at epoch
-		if err := systemcontract.DecreaseMissedBlocksCounter(vmCtx); err != nil {
+	}
+	// decrease validator missed blocks counter, at epoch unless governed otherwise
+	if c.isDecreaseBlock(vmCtx) {
+		err := gas.meter(func(gas uint64) (uint64, error) {
+			return systemcontract.DecreaseMissedBlocksCounterWithGas(vmCtx, gas)
+		})
+		if err != nil {
 			return err
 		}
 	}
+	// count the burned base fees and released staking rewards of the block
+	systemcontract.AccountBlockSupply(vmCtx)
 	// punish double sign
-	return c.punishDoubleSign(chain, header, state, txs, receipts, punishTxs, mined)
+	return c.punishDoubleSign(chain, header, state, txs, receipts, punishTxs, mined, gas)
+}
+
+// applyEmission sets the staking rewards per block scheduled at the epoch block
+// by the emission config, if any.
+func (c *Turbo) applyEmission(vmCtx *contracts.CallContext) error {
+	if c.config.Emission == nil {
+		return nil
+	}
+	rewards := c.config.RewardsPerBlock(vmCtx.Header.Number.Uint64())
+	if rewards == nil {
+		return nil
+	}
+	return systemcontract.SetRewardsPerBlock(vmCtx, rewards)
+}
+
+// isDecreaseBlock returns whether the missed blocks counters are decreased at
+// the block, which happens at the epoch blocks before the slashing fork.
+func (c *Turbo) isDecreaseBlock(vmCtx *contracts.CallContext) bool {
+	number := vmCtx.Header.Number.Uint64()
+	if !c.config.IsSlashing(vmCtx.Header.Number) {
+		return c.config.IsEpoch(number)
+	}
+	return number%systemcontract.ReadSlashingParams(vmCtx).DecreaseInterval == 0
 }
 
 // updateValidators updates validators info to system contracts
-func (c *Turbo) updateValidators(vmCtx *contracts.CallContext, chain consensus.ChainHeaderReader, mined bool) error {
+func (c *Turbo) updateValidators(vmCtx *contracts.CallContext, chain consensus.ChainHeaderReader, mined bool, gas *systemGas) error {
 	newValidators, err := c.getTopValidators(chain, vmCtx.Header)
 	if err != nil {
 		return err
@@ -675,68 +837,121 @@ func (c *Turbo) updateValidators(vmCtx *contracts.CallContext, chain consensus.C
 		for i, validator := range newValidators {
 			copy(validatorsBytes[i*common.AddressLength:], validator.Bytes())
 		}
+		// and so are the jails from the jail fork
+		if c.config.IsJail(vmCtx.Header.Number) {
+			jailed, err := c.getJailedValidators(chain, vmCtx.Header)
+			if err != nil {
+				return err
+			}
+			validatorsBytes = append(validatorsBytes, light.EncodeJailed(jailed)...)
+		}
 		if !bytes.Equal(vmCtx.Header.Extra[extraVanity:len(vmCtx.Header.Extra)-extraSeal], validatorsBytes) {
 			return errInvalidExtraValidators
 		}
 	}
 	// update contract new validators if new set exists
-	if err := systemcontract.UpdateActiveValidatorSet(vmCtx, newValidators); err != nil {
+	err = gas.meter(func(gas uint64) (uint64, error) {
+		return systemcontract.UpdateActiveValidatorSetWithGas(vmCtx, newValidators, gas)
+	})
+	if err != nil {
 		log.Error("Fail to update validators to system contract", "err", err)
 		return err
 	}
 	return nil
 }
 
-// tryDistributeBlockFee distributes block fee to validators
-func (c *Turbo) tryDistributeBlockFee(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
+// tryDistributeBlockFee distributes block fee to validators, less the share
+// redirected to the treasury.
+func (c *Turbo) tryDistributeBlockFee(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, gas *systemGas) error {
 	fee := state.GetBalance(consensus.FeeRecoder)
 	if fee.Cmp(common.U2560) <= 0 {
 		return nil
 	}
 
-	// Miner will send tx to deposit block fees to contract, add to his balance first.
-	state.AddBalance(system.EngineCaller, fee, tracing.BalanceIncreaseRewardTransactionFee)
-	// reset fee
-	state.SetBalance(consensus.FeeRecoder, common.U2560, tracing.BalanceClearFeeRecored)
-
-	return systemcontract.DistributeBlockFee(&contracts.CallContext{
+	vmCtx := &contracts.CallContext{
 		Statedb:      state,
 		Header:       header,
 		ChainContext: newChainContext(chain, c),
 		ChainConfig:  c.chainConfig,
-	}, fee)
+	}
+	// reset fee
+	state.SetBalance(consensus.FeeRecoder, common.U2560, tracing.BalanceClearFeeRecored)
+
+	// redirect the treasury share of the fee after the treasury fork
+	remaining := systemcontract.RedirectTreasuryFee(vmCtx, fee)
+	systemcontract.AccountFeeSupply(vmCtx, remaining, new(uint256.Int).Sub(fee, remaining))
+	if fee = remaining; fee.IsZero() {
+		return nil
+	}
+	// Miner will send tx to deposit block fees to contract, add to his balance first.
+	state.AddBalance(system.EngineCaller, fee, tracing.BalanceIncreaseRewardTransactionFee)
+
+	return gas.meter(func(gas uint64) (uint64, error) {
+		return systemcontract.DistributeBlockFeeWithGas(vmCtx, fee, gas)
+	})
 }
 
-// tryLazyPunish punishes validators that didn't produce blocks
-func (c *Turbo) tryLazyPunish(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
+// isLiveImport reports whether the header is being imported on top of the chain,
+// rather than processed again for e.g. state regeneration or during sync. Key
+// events are only logged for live imports.
+func isLiveImport(chain consensus.ChainHeaderReader, header *types.Header) bool {
+	current := chain.CurrentHeader()
+	return current == nil || header.Number.Cmp(current.Number) > 0
+}
+
+// tryLazyPunish punishes validators that didn't produce blocks. If report is set,
+// the missed turn and the punishment are logged as key events.
+func (c *Turbo) tryLazyPunish(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, gas *systemGas, report bool) error {
 	number := header.Number.Uint64()
-	snap, err := c.snapshot(chain, number-1, header.ParentHash, nil)
+	outTurnValidator, punish, err := c.MissedTurn(chain, header)
 	if err != nil {
 		return err
 	}
-	validators := snap.validators()
-	continuousBlocks := c.chainConfig.TurboContinuousInturn(header.Number)
-	outTurnValidator := validators[number%(uint64(len(validators))*continuousBlocks)/continuousBlocks]
-	// check sigend recently or not
-	signedRecently := false
-	for _, recent := range snap.Recents {
-		if recent == outTurnValidator {
-			signedRecently = true
-			break
-		}
+	if report {
+		log.Info("Validator missed its turn", log.EventKey, log.EventMissedTurn, "number", number,
+			"validator", outTurnValidator, "signer", header.Coinbase)
 	}
-	if !signedRecently {
-		return systemcontract.LazyPunish(&contracts.CallContext{
+	if punish {
+		vmCtx := &contracts.CallContext{
 			Statedb:      state,
 			Header:       header,
 			ChainContext: newChainContext(chain, c),
 			ChainConfig:  c.chainConfig,
-		}, outTurnValidator)
+		}
+		err := gas.meter(func(gas uint64) (uint64, error) {
+			return systemcontract.LazyPunishWithGas(vmCtx, outTurnValidator, gas)
+		})
+		if err == nil && report {
+			log.Info("Validator punished", log.EventKey, log.EventPunishExecuted, "number", number,
+				"validator", outTurnValidator, "kind", "lazy")
+		}
+		return err
 	}
 
 	return nil
 }
 
+// MissedTurn returns the in-turn validator of a block sealed out of turn, and
+// whether it is lazy punished for missing its turn, i.e. it didn't seal any of
+// the recent blocks.
+func (c *Turbo) MissedTurn(chain consensus.ChainHeaderReader, header *types.Header) (common.Address, bool, error) {
+	number := header.Number.Uint64()
+	snap, err := c.snapshot(chain, number-1, header.ParentHash, nil)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	validators := snap.proposers()
+	continuousBlocks := c.chainConfig.TurboContinuousInturn(header.Number)
+	outTurnValidator := validators[number%(uint64(len(validators))*continuousBlocks)/continuousBlocks]
+	// check sigend recently or not
+	for _, recent := range snap.Recents {
+		if recent == outTurnValidator {
+			return outTurnValidator, false, nil
+		}
+	}
+	return outTurnValidator, true, nil
+}
+
 // call this at epoch block to get top validators based on the state of epoch block - 1
 func (c *Turbo) getTopValidators(chain consensus.ChainHeaderReader, header *types.Header) ([]common.Address, error) {
 	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
@@ -747,13 +962,50 @@ func (c *Turbo) getTopValidators(chain consensus.ChainHeaderReader, header *type
 	if err != nil {
 		return []common.Address{}, err
 	}
-	return systemcontract.GetTopValidators(&contracts.CallContext{
+	return c.params.TopValidators(&contracts.CallContext{
 		Statedb:      statedb,
 		Header:       parent,
 		ChainContext: newChainContext(chain, c),
 		ChainConfig:  c.chainConfig})
 }
 
+// getJailedValidators returns the jails to record at an epoch block from the
+// jail fork: the validators of the set taking over at the block that are
+// jailed in the Staking contract at the state of its parent, in ascending
+// order.
+func (c *Turbo) getJailedValidators(chain consensus.ChainHeaderReader, header *types.Header) ([]common.Address, error) {
+	number := header.Number.Uint64()
+	parent := chain.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	checkpoint := chain.GetHeaderByNumber(light.ValidatorCheckpoint(c.config, number))
+	if checkpoint == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	statedb, err := c.stateFn(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	validators := light.NewValidatorSet(EpochValidators(checkpoint))
+	statuses, err := systemcontract.GetValidatorStatuses(&contracts.CallContext{
+		Statedb:      statedb,
+		Header:       parent,
+		ChainContext: newChainContext(chain, c),
+		ChainConfig:  c.chainConfig,
+	}, validators)
+	if err != nil {
+		return nil, err
+	}
+	jailed := make([]common.Address, 0)
+	for i, validator := range validators {
+		if statuses[i].State == systemcontract.ValidatorJailed {
+			jailed = append(jailed, validator)
+		}
+	}
+	return jailed, nil
+}
+
 // Authorize injects a private key into the consensus engine to mint new blocks with.
 func (c *Turbo) Authorize(validator common.Address, signFn ValidatorFn, signTxFn SignTxFn) {
 	c.lock.Lock()
@@ -776,8 +1028,17 @@ func (c *Turbo) Seal(chain consensus.ChainHeaderReader, block *types.Block, resu
 	if number == 0 {
 		return errUnknownBlock
 	}
+	// Refuse to seal past the handover block, skipping the slot cleanly
+	if c.sealingStopped(number) {
+		log.Info("Sealing stopped for handover", "number", number)
+		return nil
+	}
+	// Refuse to seal past the emergency halt, freezing the chain at its block
+	if c.sealingHalted(chain, header) {
+		return nil
+	}
 	// For 0-period chains, refuse to seal empty blocks (no reward but would spin sealing)
-	if c.config.Period == 0 && len(block.Transactions()) == 0 {
+	if c.config.PeriodAt(number) == 0 && len(block.Transactions()) == 0 {
 		log.Info("Sealing paused, waiting for transactions")
 		return nil
 	}
@@ -794,12 +1055,23 @@ func (c *Turbo) Seal(chain consensus.ChainHeaderReader, block *types.Block, resu
 	if _, authorized := snap.Validators[val]; !authorized {
 		return errUnauthorizedValidator
 	}
+	// If we're jailed, leave the slots to the other validators until released
+	if !snap.IsProposer(val) {
+		log.Info("Validator jailed, must wait for the release")
+		return nil
+	}
 	// If we're amongst the recent validators, wait for the next block
 	if snap.SignedRecently(number, val) {
 		log.Info("Signed recently, must wait for others")
 		return nil
 	}
 
+	// Never sign below a block possibly signed already, by this node or by a
+	// failover peer
+	if sign, err := c.protectSigning(val, number); err != nil || !sign {
+		return err
+	}
+
 	// Sweet, the protocol permits us to sign the block, wait for our time
 	delay := time.Until(time.Unix(int64(header.Time), 0))
 	if header.Difficulty.Cmp(diffNoTurn) == 0 {
@@ -813,12 +1085,20 @@ func (c *Turbo) Seal(chain consensus.ChainHeaderReader, block *types.Block, resu
 
 		log.Trace("Out-of-turn signing requested", "wiggle", common.PrettyDuration(wiggle))
 	}
-	// Sign all the things!
+	// Sign all the things! Remote signers take time, which is accounted for in
+	// the delay instead of postponing the block.
+	deadline := time.Now().Add(delay)
+	start := time.Now()
 	sighash, err := signFn(accounts.Account{Address: val}, accounts.MimetypeTurbo, TurboRLP(header))
 	if err != nil {
 		return err
 	}
 	copy(header.Extra[len(header.Extra)-extraSeal:], sighash)
+
+	delay = time.Until(deadline)
+	if delay < 0 {
+		log.Warn("Block signing exceeded the sealing slot", "number", number, "elapsed", common.PrettyDuration(time.Since(start)), "late", common.PrettyDuration(-delay))
+	}
 	// Wait until sealing is terminated or delay timeout.
 	log.Trace("Waiting for slot to sign and propagate", "delay", common.PrettyDuration(delay))
 	go func() {
@@ -827,7 +1107,11 @@ func (c *Turbo) Seal(chain consensus.ChainHeaderReader, block *types.Block, resu
 			return
 		case <-time.After(delay):
 		}
-
+		// The sealing might have been stopped in the meantime
+		if c.sealingStopped(number) {
+			log.Info("Discarded sealed block for handover", "number", number, "sealhash", SealHash(header))
+			return
+		}
 		select {
 		case results <- block.WithSeal(header):
 		default:
@@ -861,6 +1145,18 @@ func calcDifficulty(snap *Snapshot, validator common.Address) *big.Int {
 	return new(big.Int).Set(diffNoTurn)
 }
 
+// EpochValidators returns the validator set recorded in the extra-data of an
+// epoch block, empty for any other block.
+func EpochValidators(header *types.Header) []common.Address {
+	return light.EpochValidators(header)
+}
+
+// EpochJailed returns the jailed validators recorded in the extra-data of an
+// epoch block from the jail fork, empty for any other block.
+func EpochJailed(header *types.Header) []common.Address {
+	return light.EpochJailed(header)
+}
+
 // SealHash returns the hash of a block prior to it being sealed.
 func (c *Turbo) SealHash(header *types.Header) common.Hash {
 	return SealHash(header)
@@ -884,10 +1180,7 @@ func (c *Turbo) APIs(chain consensus.ChainHeaderReader) []rpc.API {
 
 // SealHash returns the hash of a block prior to it being sealed.
 func SealHash(header *types.Header) (hash common.Hash) {
-	hasher := sha3.NewLegacyKeccak256()
-	encodeSigHeader(hasher, header)
-	hasher.Sum(hash[:0])
-	return hash
+	return light.SealHash(header)
 }
 
 // TurboRLP returns the rlp bytes which needs to be signed for the proof-of-stake-authority
@@ -899,36 +1192,20 @@ func SealHash(header *types.Header) (hash common.Hash) {
 // or not), which could be abused to produce different hashes for the same header.
 func TurboRLP(header *types.Header) []byte {
 	b := new(bytes.Buffer)
-	encodeSigHeader(b, header)
+	light.EncodeSigHeader(b, header)
 	return b.Bytes()
 }
 
-func encodeSigHeader(w io.Writer, header *types.Header) {
-	err := rlp.Encode(w, []interface{}{
-		header.ParentHash,
-		header.UncleHash,
-		header.Coinbase,
-		header.Root,
-		header.TxHash,
-		header.ReceiptHash,
-		header.Bloom,
-		header.Difficulty,
-		header.Number,
-		header.GasLimit,
-		header.GasUsed,
-		header.Time,
-		header.Extra[:len(header.Extra)-crypto.SignatureLength], // Yes, this will panic if extra is too short
-		header.MixDigest,
-		header.Nonce,
-	})
-	if err != nil {
-		panic("can't encode: " + err.Error())
+// hardforks returns the system contract upgrades scheduled by the config.
+func (c *Turbo) hardforks() []systemcontract.Hardfork {
+	return []systemcontract.Hardfork{
+		{Name: systemcontract.SlashingHardfork, Number: c.config.SlashingBlock},
 	}
 }
 
 // PreHandle handles before tx execution in miner
 func (c *Turbo) PreHandle(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
-	for _, hardfork := range []systemcontract.Hardfork{} {
+	for _, hardfork := range c.hardforks() {
 		if hardfork.Number != nil && hardfork.Number.Cmp(header.Number) == 0 {
 			if err := systemcontract.ApplySystemContractUpgrade(hardfork.Name, state, header,
 				newChainContext(chain, c), c.chainConfig); err != nil {
@@ -948,6 +1225,8 @@ func (c *Turbo) CalculateGasPool(header *types.Header) uint64 {
 	return header.GasLimit
 }
 
+// ExtraValidateOfTx rejects the punish calls of the block producer to the staking
+// contract, and the transactions rejected by the rules enabled by the chain config.
 func (c *Turbo) ExtraValidateOfTx(sender common.Address, tx *types.Transaction, header *types.Header) error {
 	// check invalid call to the Staking contract;
 	// Miner should not call the following funcs through transaction:
@@ -963,5 +1242,5 @@ func (c *Turbo) ExtraValidateOfTx(sender common.Address, tx *types.Transaction,
 			}
 		}
 	}
-	return nil
+	return c.validateTxRules(sender, tx, header)
 }
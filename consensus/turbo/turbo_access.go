@@ -1,7 +1,10 @@
 package turbo
 
 import (
+	"fmt"
 	"math/big"
+	"sort"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
@@ -36,6 +39,29 @@ func (b *turboAccessFilter) IsLogDenied(evLog *types.Log) bool {
 	return false
 }
 
+// DeniedLogRule implements vm.LogRuleReporter, describing the event check rule
+// of the log's event signature.
+func (b *turboAccessFilter) DeniedLogRule(evLog *types.Log) string {
+	if len(evLog.Topics) == 0 {
+		return ""
+	}
+	rule, ok := b.rules[evLog.Topics[0]]
+	if !ok {
+		return ""
+	}
+	indexes := make([]int, 0, len(rule.Checks))
+	for index := range rule.Checks {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	checks := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		checks = append(checks, fmt.Sprintf("topic %d (check %d)", index, rule.Checks[index]))
+	}
+	return fmt.Sprintf("event %#x: %s", rule.EventSig, strings.Join(checks, ", "))
+}
+
 // CanCreate determines where a given address can create a new contract.
 //
 // This will queries the system Developers contract, by DIRECTLY to get the target slot value of the contract,
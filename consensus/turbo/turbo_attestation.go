@@ -2,6 +2,8 @@ package turbo
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/contracts"
@@ -10,8 +12,10 @@ import (
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/turbo/light"
 	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
 	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -19,12 +23,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
-const (
-	attestationThresholdNumerator   = 2
-	attestationThresholdDenominator = 3
-
-	// maxOldBlockToAttest = 4
-)
+// const maxOldBlockToAttest = 4
 
 var (
 	doubleSignIdentity = common.HexToAddress("0xfffffffffffffffffffffffffffffffffffffffe")
@@ -35,9 +34,41 @@ var (
 	executedDoubleSignPunishEventSig = common.HexToHash("0x250969e8ccb0e19752686619d1ce1af974eeea52b88479ca3ec6cced6b7c9198")
 )
 
+// systemGas meters the system transactions of a block against their gas
+// allowance, from the SystemGasBlock fork.
+type systemGas struct {
+	left       uint64 // Allowance left to the next system transactions
+	cumulative uint64 // Gas used by the transactions of the block so far
+}
+
+// newSystemGas returns the gas meter of the system transactions of the block,
+// nil before the SystemGasBlock fork.
+func (c *Turbo) newSystemGas(header *types.Header, receipts []*types.Receipt) *systemGas {
+	if !c.config.IsSystemGas(header.Number) {
+		return nil
+	}
+	gas := &systemGas{left: c.config.SystemGasAllowance()}
+	if len(receipts) > 0 {
+		gas.cumulative = receipts[len(receipts)-1].CumulativeGasUsed
+	}
+	return gas
+}
+
+// meter runs a mandatory system contract call without receipt, and charges
+// the gas used to the allowance left. The call gets all the gas it needs, as
+// the blocks can't do without it: an exhausted allowance only defers the
+// double sign punishments to the next blocks.
+func (g *systemGas) meter(call func(gas uint64) (uint64, error)) error {
+	used, err := call(math.MaxUint64)
+	if g != nil {
+		g.left -= min(used, g.left)
+	}
+	return err
+}
+
 // punishDoubleSign punishes double sign attack in casper ffg
 func (c *Turbo) punishDoubleSign(chain consensus.ChainHeaderReader, header *types.Header,
-	state *state.StateDB, txs *[]*types.Transaction, receipts *[]*types.Receipt, punishTxs []*types.Transaction, mined bool) error {
+	state *state.StateDB, txs *[]*types.Transaction, receipts *[]*types.Receipt, punishTxs []*types.Transaction, mined bool, gas *systemGas) error {
 	if !mined {
 		// handle violating CasperFFG rules
 		totalTxIndex := len(punishTxs)
@@ -46,10 +77,19 @@ func (c *Turbo) punishDoubleSign(chain consensus.ChainHeaderReader, header *type
 			// execute the doubleSignPunish
 			// If one transaction fails to execute, the whole block will be discarded
 			tx := punishTxs[int(i)]
-			receipt, err := c.replayDoubleSignPunish(chain, header, state, totalTxIndex, tx)
+			receipt, err := c.replayDoubleSignPunish(chain, header, state, totalTxIndex, tx, gas)
 			if err != nil {
 				return err
 			}
+			if isLiveImport(chain, header) {
+				var p types.ViolateCasperFFGPunish
+				if err := rlp.DecodeBytes(tx.Data(), &p); err == nil {
+					if violator, err := p.RecoverSigner(); err == nil {
+						log.Info("Validator punished", log.EventKey, log.EventPunishExecuted, "number", header.Number.Uint64(),
+							"validator", violator, "kind", "doublesign")
+					}
+				}
+			}
 			*txs = append(*txs, tx)
 			*receipts = append(*receipts, receipt)
 		}
@@ -73,8 +113,15 @@ func (c *Turbo) punishDoubleSign(chain consensus.ChainHeaderReader, header *type
 					return err
 				}
 				if !b {
-					// execute the Punish.sol doubleSignPunish
-					tx, receipt, err := c.executeDoubleSignPunish(chain, header, state, p, len(punishList))
+					// execute the Punish.sol doubleSignPunish, leaving the
+					// punishments over the system gas allowance to the next blocks
+					snapshot := state.Snapshot()
+					tx, receipt, err := c.executeDoubleSignPunish(chain, header, state, p, len(punishList), gas)
+					if errors.Is(err, vm.ErrOutOfGas) && gas != nil {
+						state.RevertToSnapshot(snapshot)
+						log.Warn("System gas allowance exhausted, deferring double sign punishments", "number", header.Number, "left", gas.left)
+						break
+					}
 					if err != nil {
 						log.Error("executeDoubleSignPunish error", "error", err.Error())
 						return err
@@ -123,7 +170,18 @@ func (c *Turbo) VerifyAttestation(chain consensus.ChainHeaderReader, a *types.At
 	// check if it is an authorized validator?
 	snap, err := c.snapshot(chain, header.Number.Uint64(), header.Hash(), nil)
 	if err != nil {
-		return common.Address{}, 0, err
+		// The snapshot of old blocks may not be rebuildable, fall back to the
+		// validator set recorded in the epoch headers.
+		set, herr := c.ValidatorsFromHeaders(chain, header)
+		if herr != nil {
+			return common.Address{}, 0, err
+		}
+		for _, validator := range set.Validators {
+			if validator == signer {
+				return signer, attestationThreshold(len(set.Validators)), nil
+			}
+		}
+		return common.Address{}, 0, errIsNotValidator
 	}
 
 	if !snap.IsAuthorized(signer) {
@@ -133,7 +191,7 @@ func (c *Turbo) VerifyAttestation(chain consensus.ChainHeaderReader, a *types.At
 }
 
 func attestationThreshold(valsCnt int) int {
-	return valsCnt*attestationThresholdNumerator/attestationThresholdDenominator + 1
+	return light.AttestationThreshold(valsCnt)
 }
 
 func (c *Turbo) CurrentValidator() common.Address {
@@ -158,7 +216,7 @@ func (c *Turbo) Attest(chain consensus.ChainHeaderReader, headerNum *big.Int, so
 // t is the hash of the current block to vote, and h(s) h(T) are the corresponding block numbers respectively.
 func (c *Turbo) makeNewAttestation(sourceRangeEdge *types.RangeEdge, targetRangeEdge *types.RangeEdge) (*types.Attestation, error) {
 	// because the sign function is `Wallet.SignData`，so we should pass the data to it, not the hash.
-	sig, err := c.signFn(accounts.Account{Address: c.validator}, "", types.AttestationData(sourceRangeEdge, targetRangeEdge))
+	sig, err := c.signFn(accounts.Account{Address: c.validator}, accounts.MimetypeTurboAttestation, types.AttestationData(sourceRangeEdge, targetRangeEdge))
 	if err != nil {
 		return nil, errSignFailed
 	}
@@ -247,7 +305,7 @@ func (c *Turbo) VerifyCasperFFGRule(beforeSourceNum uint64, beforeTargetNum uint
 
 // Assembly of penalty transactions in violation of CasperFFG rules
 func (c *Turbo) executeDoubleSignPunish(chain consensus.ChainHeaderReader, header *types.Header,
-	state *state.StateDB, p *types.ViolateCasperFFGPunish, totalTxIndex int) (*types.Transaction, *types.Receipt, error) {
+	state *state.StateDB, p *types.ViolateCasperFFGPunish, totalTxIndex int, gas *systemGas) (*types.Transaction, *types.Receipt, error) {
 	if c.signTxFn == nil {
 		return nil, nil, errors.New("signTxFn not set")
 	}
@@ -277,14 +335,14 @@ func (c *Turbo) executeDoubleSignPunish(chain consensus.ChainHeaderReader, heade
 
 	//add nonce for validator
 	state.SetNonce(c.validator, nonce+1)
-	receipt, err := c.executeDoubleSignPunishMsg(chain, header, state, p, totalTxIndex, tx.Hash(), common.Hash{})
+	receipt, err := c.executeDoubleSignPunishMsg(chain, header, state, p, totalTxIndex, tx, common.Hash{}, gas)
 
 	return tx, receipt, err
 }
 
 // After receiving a block containing multiple signed penalty transactions, execute the penalty transactions in it.
 // If the execution fails, discard the whole block. BAD BLOCK
-func (c *Turbo) replayDoubleSignPunish(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, totalTxIndex int, tx *types.Transaction) (*types.Receipt, error) {
+func (c *Turbo) replayDoubleSignPunish(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, totalTxIndex int, tx *types.Transaction, gas *systemGas) (*types.Receipt, error) {
 	log.Debug("replayDoubleSignPunish", "Number", header.Number.Uint64())
 	sender, err := types.Sender(c.signer, tx)
 	if err != nil {
@@ -318,7 +376,7 @@ func (c *Turbo) replayDoubleSignPunish(chain consensus.ChainHeaderReader, header
 	nonce := state.GetNonce(sender)
 	//add nonce for validator
 	state.SetNonce(sender, nonce+1)
-	return c.executeDoubleSignPunishMsg(chain, header, state, &p, totalTxIndex, tx.Hash(), header.Hash())
+	return c.executeDoubleSignPunishMsg(chain, header, state, &p, totalTxIndex, tx, header.Hash(), gas)
 }
 
 // IsDoubleSignPunished Execute the query of punishment contract to judge whether the punishment hash of the current query has been punished
@@ -332,9 +390,15 @@ func (c *Turbo) IsDoubleSignPunished(chain consensus.ChainHeaderReader, header *
 	}, punishHash)
 }
 
-// Execute multi sign penalty transaction in EVM
-func (c *Turbo) executeDoubleSignPunishMsg(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, p *types.ViolateCasperFFGPunish, totalTxIndex int, txHash, bHash common.Hash) (*types.Receipt, error) {
-	var receipt *types.Receipt
+// Execute multi sign penalty transaction in EVM. Once the system transactions
+// are metered, the transaction is charged its intrinsic gas and the gas used by
+// the contract call against the allowance left, failing with vm.ErrOutOfGas if
+// it runs out, and its receipt reports the gas used.
+func (c *Turbo) executeDoubleSignPunishMsg(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, p *types.ViolateCasperFFGPunish, totalTxIndex int, tx *types.Transaction, bHash common.Hash, gas *systemGas) (*types.Receipt, error) {
+	var (
+		receipt *types.Receipt
+		txHash  = tx.Hash()
+	)
 
 	state.SetTxContext(txHash, totalTxIndex)
 	topics := []common.Hash{
@@ -353,18 +417,36 @@ func (c *Turbo) executeDoubleSignPunishMsg(chain consensus.ChainHeaderReader, he
 	}
 	state.AddLog(pLog)
 
-	// must succeed
-	err := systemcontract.DoubleSignPunish(&contracts.CallContext{
+	ctx := &contracts.CallContext{
 		Statedb:      state,
 		Header:       header,
 		ChainContext: newChainContext(chain, c),
 		ChainConfig:  c.chainConfig,
-	}, p.Hash(), p.Defendant)
-	if err != nil {
-		return nil, err
 	}
+	// must succeed
+	if gas == nil {
+		if err := systemcontract.DoubleSignPunish(ctx, p.Hash(), p.Defendant); err != nil {
+			return nil, err
+		}
+		receipt = types.NewReceipt([]byte{}, false, header.GasUsed)
+	} else {
+		intrinsic, err := core.IntrinsicGas(tx.Data(), nil, false, true, c.chainConfig.IsIstanbul(header.Number), false)
+		if err != nil {
+			return nil, err
+		}
+		if intrinsic > gas.left {
+			return nil, fmt.Errorf("%w: system transaction intrinsic gas %d, allowance left %d", vm.ErrOutOfGas, intrinsic, gas.left)
+		}
+		used, err := systemcontract.DoubleSignPunishWithGas(ctx, p.Hash(), p.Defendant, gas.left-intrinsic)
+		if err != nil {
+			return nil, err
+		}
+		gas.left -= intrinsic + used
+		gas.cumulative += intrinsic + used
 
-	receipt = types.NewReceipt([]byte{}, err != nil, header.GasUsed)
+		receipt = types.NewReceipt([]byte{}, false, gas.cumulative)
+		receipt.GasUsed = intrinsic + used
+	}
 	log.Info("executeDoubleSignPunishMsg", "Plaintiff", p.Plaintiff, "Defendant", p.Defendant, "pushHash", p.Hash().String(), "success", true)
 
 	receipt.Logs = state.GetLogs(txHash, header.Number.Uint64(), bHash)
@@ -0,0 +1,223 @@
+package turbo
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/turbo/light"
+	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	fuzzPeriod     = 2  // Minimum time between two blocks of the fuzzed chains
+	fuzzMaxHeaders = 32 // Maximum number of headers of a fuzzed chain
+)
+
+// fuzzKeys are the deterministic keys of the fuzzed validators, the last one
+// never being part of the validator set.
+var fuzzKeys = func() []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, 8)
+	for i := range keys {
+		keys[i], _ = crypto.ToECDSA(crypto.Keccak256([]byte{byte(i)}))
+	}
+	return keys
+}()
+
+// turboModel is a reference model of the Turbo sealing rules over a fixed
+// validator set:
+//
+//   - blocks are at least the period apart
+//   - blocks are sealed by a validator, the coinbase being the signer
+//   - a validator doesn't seal again before N/2 other blocks were sealed, N
+//     being the number of validators
+//   - the in-turn validator of block B is the validator of rank B mod N in the
+//     ascending order, sealing with difficulty 2, the others with difficulty 1
+type turboModel struct {
+	validators []common.Address
+	signers    []common.Address // Signers of the accepted blocks, the genesis has none
+	times      []uint64
+}
+
+func (m *turboModel) difficulty(number uint64, signer common.Address) *big.Int {
+	if m.validators[number%uint64(len(m.validators))] == signer {
+		return big.NewInt(2)
+	}
+	return big.NewInt(1)
+}
+
+// accept checks the next header against the rules, recording it if valid.
+func (m *turboModel) accept(header *types.Header, signer common.Address) bool {
+	number := uint64(len(m.signers))
+	if header.Time < m.times[number-1]+fuzzPeriod {
+		return false
+	}
+	if header.Coinbase != signer {
+		return false
+	}
+	authorized := false
+	for _, validator := range m.validators {
+		authorized = authorized || validator == signer
+	}
+	if !authorized {
+		return false
+	}
+	recent := uint64(len(m.validators) / 2)
+	for n := number - 1; n > 0 && number-n <= recent; n-- {
+		if m.signers[n] == signer {
+			return false
+		}
+	}
+	if header.Difficulty.Cmp(m.difficulty(number, signer)) != 0 {
+		return false
+	}
+	m.signers = append(m.signers, signer)
+	m.times = append(m.times, header.Time)
+	return true
+}
+
+// fuzzInput decodes the fuzzer data, padding it with zeroes once exhausted.
+type fuzzInput []byte
+
+func (in *fuzzInput) byte() byte {
+	if len(*in) == 0 {
+		return 0
+	}
+	b := (*in)[0]
+	*in = (*in)[1:]
+	return b
+}
+
+// FuzzVerifyHeaders generates random header sequences sealed by in-turn,
+// out-of-turn and unauthorized signers with random timestamps and difficulties,
+// and cross-checks the headers accepted by the engine and the light verifier,
+// and the difficulties the engine would seal them with, against the reference
+// model.
+func FuzzVerifyHeaders(f *testing.F) {
+	f.Add([]byte{3, 8})
+	f.Add([]byte{1, 4, 0x10, 0, 0x10, 0, 0x10, 0})
+	f.Add([]byte{4, 16, 0x01, 0x21, 0x12, 0x03, 0x24, 0x11, 0x07, 0x42})
+	f.Add([]byte{7, 32, 0x05, 0x16, 0x27, 0x30, 0x41, 0x52, 0x63, 0x10, 0x21})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		in := fuzzInput(data)
+		var (
+			count   = 1 + int(in.byte())%(len(fuzzKeys)-1)
+			length  = 1 + int(in.byte())%fuzzMaxHeaders
+			model   = &turboModel{validators: make([]common.Address, count)}
+			chain   = &testHeaderChain{headers: make(map[common.Hash]*types.Header)}
+			config  = *params.AllTurboProtocolChanges
+			turboCf = *config.Turbo
+		)
+		turboCf.Period = fuzzPeriod
+		config.Turbo = &turboCf
+		engine := New(&config, rawdb.NewMemoryDatabase())
+
+		for i := range model.validators {
+			model.validators[i] = crypto.PubkeyToAddress(fuzzKeys[i].PublicKey)
+		}
+		sort.Sort(systemcontract.AddrAscend(model.validators))
+
+		genesis := &types.Header{
+			Number:     new(big.Int),
+			Time:       uint64(time.Now().Unix()) - fuzzMaxHeaders*(fuzzPeriod+8),
+			GasLimit:   params.GenesisGasLimit,
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+			Difficulty: big.NewInt(2),
+			UncleHash:  uncleHash,
+		}
+		genesis.Extra = make([]byte, extraVanity, extraVanity+count*common.AddressLength+extraSeal)
+		for _, validator := range model.validators {
+			genesis.Extra = append(genesis.Extra, validator[:]...)
+		}
+		genesis.Extra = append(genesis.Extra, make([]byte, extraSeal)...)
+		chain.add(genesis, true)
+		model.signers = []common.Address{{}}
+		model.times = []uint64{genesis.Time}
+
+		// Generate the headers, each consuming two bytes: the signer and the
+		// time offset in the low and high nibbles of the first one, and the
+		// sealed difficulty and coinbase of the second one
+		var (
+			headers = make([]*types.Header, length)
+			signers = make([]common.Address, length)
+		)
+		for i := range headers {
+			var (
+				parent = chain.canonical[i]
+				choice = in.byte()
+				seal   = in.byte()
+				key    = fuzzKeys[int(choice&0x0f)%len(fuzzKeys)]
+			)
+			if int(choice&0x0f)%len(fuzzKeys) >= count {
+				key = fuzzKeys[len(fuzzKeys)-1]
+			}
+			signers[i] = crypto.PubkeyToAddress(key.PublicKey)
+
+			header := &types.Header{
+				ParentHash: parent.Hash(),
+				Number:     big.NewInt(int64(i + 1)),
+				Time:       parent.Time + uint64(choice>>4)%(fuzzPeriod+6),
+				GasLimit:   parent.GasLimit,
+				BaseFee:    eip1559.CalcBaseFee(&config, parent),
+				UncleHash:  uncleHash,
+				Coinbase:   signers[i],
+				Extra:      make([]byte, extraVanity+extraSeal),
+			}
+			switch seal & 0x03 {
+			case 0, 1:
+				header.Difficulty = model.difficulty(header.Number.Uint64(), signers[i])
+			case 2:
+				header.Difficulty = big.NewInt(int64(seal>>2)%3 + 1)
+			case 3:
+				header.Difficulty = new(big.Int).Sub(big.NewInt(3), model.difficulty(header.Number.Uint64(), signers[i]))
+			}
+			if seal == 0xff {
+				header.Coinbase = common.Address{0xff}
+			}
+			sig, err := crypto.Sign(SealHash(header).Bytes(), key)
+			if err != nil {
+				t.Fatalf("failed to seal header: %v", err)
+			}
+			copy(header.Extra[extraVanity:], sig)
+
+			headers[i] = header
+			chain.add(header, true)
+		}
+		// Cross-check the verification results up to the first rejection,
+		// following headers being discarded by the chain insertion anyway,
+		// with the light verifier too
+		verifier, err := light.NewVerifier(&config, genesis, nil)
+		if err != nil {
+			t.Fatalf("failed to create light verifier: %v", err)
+		}
+		_, results := engine.VerifyHeaders(chain, headers)
+		for i, header := range headers {
+			err := <-results
+			if accepted := model.accept(header, signers[i]); accepted != (err == nil) {
+				t.Fatalf("header %d: acceptance mismatch: engine error %v, model accepts %v", i+1, err, accepted)
+			}
+			if lerr := verifier.InsertHeader(header); (lerr == nil) != (err == nil) {
+				t.Fatalf("header %d: light acceptance mismatch: engine error %v, light error %v", i+1, err, lerr)
+			}
+			if err != nil {
+				return
+			}
+			// The difficulty the signer would seal the next block with
+			if i+1 < len(headers) {
+				engine.validator = signers[i+1]
+				want := model.difficulty(header.Number.Uint64()+1, signers[i+1])
+				if have := engine.CalcDifficulty(chain, header.Time+fuzzPeriod, header); have == nil || have.Cmp(want) != 0 {
+					t.Fatalf("header %d: difficulty mismatch: have %v, want %v", i+2, have, want)
+				}
+			}
+		}
+	})
+}
@@ -4,14 +4,17 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"fmt"
+	"math"
 	"math/big"
 	"os"
+	"reflect"
 	"sort"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
+	"github.com/ethereum/go-ethereum/contracts"
 	"github.com/ethereum/go-ethereum/contracts/system"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -464,4 +467,83 @@ func runTurboTest(t *testing.T, testID int, tc *testcase) {
 			t.Errorf("test %d, signer %d: signer mismatch: have %x, want %x", testID, i, result[i], signers[i])
 		}
 	}
+	// The validator sets reconstructed from the headers match the snapshots
+	for _, block := range blocks {
+		snap, err := engine.snapshot(chain, block.NumberU64(), block.Hash(), nil)
+		if err != nil {
+			t.Errorf("test %d: failed to retrieve snapshot %d: %v", testID, block.NumberU64(), err)
+			return
+		}
+		set, err := engine.ValidatorsFromHeaders(chain, block.Header())
+		if err != nil {
+			t.Errorf("test %d: failed to reconstruct validators %d: %v", testID, block.NumberU64(), err)
+			return
+		}
+		if want := snap.validators(); !reflect.DeepEqual(set.Validators, want) {
+			t.Errorf("test %d, block %d: reconstructed validators mismatch: have %x, want %x", testID, block.NumberU64(), set.Validators, want)
+		}
+	}
+}
+
+func TestSystemGasMeter(t *testing.T) {
+	accounts := newTesterAccountPool()
+	validators := []common.Address{accounts.address("A"), accounts.address("B")}
+	sort.Sort(systemcontract.AddrAscend(validators))
+
+	config := *params.AllTurboProtocolChanges
+	config.Turbo = &params.TurboConfig{Period: 1, Epoch: 200}
+
+	db := rawdb.NewMemoryDatabase()
+	triedb := triedb.NewDatabase(db, nil)
+	genesisBlock, err := core.BasicTurboGenesisBlock(&config, validators, accounts.adminAddr).Commit(db, triedb)
+	if err != nil {
+		t.Fatalf("failed to commit genesis: %v", err)
+	}
+	statedb, err := state.New(genesisBlock.Root(), state.NewDatabaseWithNodeDB(db, triedb), nil)
+	if err != nil {
+		t.Fatalf("failed to open genesis state: %v", err)
+	}
+	header := &types.Header{
+		ParentHash: genesisBlock.Hash(),
+		Number:     big.NewInt(200),
+		Difficulty: diffInTurn,
+		Coinbase:   validators[0],
+	}
+	ctx := &contracts.CallContext{
+		Statedb:      statedb,
+		Header:       header,
+		ChainContext: newChainContext(nil, New(&config, db)),
+		ChainConfig:  &config,
+	}
+	update := func(gas uint64) (uint64, error) {
+		return systemcontract.UpdateActiveValidatorSetWithGas(ctx, validators, gas)
+	}
+	// Measure the gas of the epoch validator set update on a scratch state
+	scratch := *ctx
+	scratch.Statedb = statedb.Copy()
+	need, err := systemcontract.UpdateActiveValidatorSetWithGas(&scratch, validators, math.MaxUint64)
+	if err != nil {
+		t.Fatalf("failed to update the validators: %v", err)
+	}
+	// An allowance smaller than the update doesn't fail it, but is exhausted
+	gas := &systemGas{left: need / 2}
+	if err := gas.meter(update); err != nil {
+		t.Fatalf("mandatory system call failed over the allowance: %v", err)
+	}
+	if gas.left != 0 {
+		t.Fatalf("allowance left mismatch: have %d, want 0", gas.left)
+	}
+	active, err := systemcontract.GetActiveValidators(ctx)
+	if err != nil || len(active) != len(validators) {
+		t.Fatalf("active validators mismatch: have %v, %v, want %v", active, err, validators)
+	}
+	// A larger allowance is charged the gas used at the next epoch
+	header.Number = big.NewInt(400)
+	gas = &systemGas{left: 2 * need}
+	if err := gas.meter(update); err != nil {
+		t.Fatalf("mandatory system call failed: %v", err)
+	}
+	if gas.left == 0 || gas.left >= 2*need {
+		t.Fatalf("allowance left mismatch: have %d, want in (0, %d)", gas.left, 2*need)
+	}
 }
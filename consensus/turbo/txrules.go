@@ -0,0 +1,116 @@
+package turbo
+
+import (
+	"fmt"
+	"math/big"
+	"plugin"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// TxRule is an additional consensus validation of the transactions of the
+// blocks, on top of the built-in ones of ExtraValidateOfTx. A block containing
+// a transaction rejected by an active rule is invalid.
+type TxRule func(sender common.Address, tx *types.Transaction, header *types.Header) error
+
+var (
+	txRulesLock sync.RWMutex
+	txRules     = make(map[string]TxRule)
+)
+
+// RegisterTxRule makes a transaction rule available under the given name. The
+// rules are registered by the in-tree modules and the plugins in their init
+// function, and are only enforced from the block set in the txRules field of
+// the Turbo chain config.
+//
+// Registering two rules with the same name panics.
+func RegisterTxRule(name string, rule TxRule) {
+	txRulesLock.Lock()
+	defer txRulesLock.Unlock()
+
+	if _, exists := txRules[name]; exists {
+		panic(fmt.Sprintf("turbo: tx rule %q registered twice", name))
+	}
+	txRules[name] = rule
+}
+
+// lookupTxRule returns the transaction rule of the given name.
+func lookupTxRule(name string) (TxRule, bool) {
+	txRulesLock.RLock()
+	defer txRulesLock.RUnlock()
+
+	rule, ok := txRules[name]
+	return rule, ok
+}
+
+// LoadTxRulePlugin opens the Go plugin at the given path, which registers its
+// transaction rules with RegisterTxRule when initialized. The plugin must be
+// built against the exact same sources as the node.
+func LoadTxRulePlugin(path string) error {
+	if _, err := plugin.Open(path); err != nil {
+		return fmt.Errorf("failed to load tx rule plugin %s: %w", path, err)
+	}
+	log.Info("Loaded tx rule plugin", "path", path)
+	return nil
+}
+
+// CheckTxRules returns an error if a transaction rule enabled by the chain config
+// isn't registered, as the blocks it applies to couldn't be validated.
+func CheckTxRules(config map[string]*big.Int) error {
+	names := make([]string, 0, len(config))
+	for name, block := range config {
+		if _, ok := lookupTxRule(name); !ok && block != nil {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return fmt.Errorf("tx rules enabled by the chain config are not registered: %v", names)
+	}
+	return nil
+}
+
+// activeTxRule is a transaction rule enabled by the chain config.
+type activeTxRule struct {
+	name  string
+	block *big.Int // Number of the first block the rule is enforced at
+}
+
+// newActiveTxRules returns the transaction rules enabled by the chain config,
+// sorted by name for a deterministic validation order. The rules which aren't
+// registered are reported, the blocks they apply to being rejected.
+func newActiveTxRules(config map[string]*big.Int) []activeTxRule {
+	rules := make([]activeTxRule, 0, len(config))
+	for name, block := range config {
+		if block == nil {
+			continue
+		}
+		if _, ok := lookupTxRule(name); !ok {
+			log.Error("Tx rule enabled by the chain config is not registered", "rule", name, "block", block)
+		}
+		rules = append(rules, activeTxRule{name: name, block: block})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].name < rules[j].name })
+	return rules
+}
+
+// validateTxRules checks the transaction against the rules active at the block.
+func (c *Turbo) validateTxRules(sender common.Address, tx *types.Transaction, header *types.Header) error {
+	for _, active := range c.txRules {
+		if header.Number.Cmp(active.block) < 0 {
+			continue
+		}
+		rule, ok := lookupTxRule(active.name)
+		if !ok {
+			return fmt.Errorf("tx rule %q is not registered", active.name)
+		}
+		if err := rule(sender, tx, header); err != nil {
+			return fmt.Errorf("tx %s rejected by rule %q: %w", tx.Hash(), active.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package turbo
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestTxRules(t *testing.T) {
+	var (
+		denied  = common.Address{0xde}
+		errDeny = errors.New("denied recipient")
+	)
+	RegisterTxRule("testDenyRecipient", func(sender common.Address, tx *types.Transaction, header *types.Header) error {
+		if tx.To() != nil && *tx.To() == denied {
+			return errDeny
+		}
+		return nil
+	})
+	config := *params.AllTurboProtocolChanges
+	config.Turbo = &params.TurboConfig{
+		Period: 1,
+		Epoch:  10,
+		TxRules: map[string]*big.Int{
+			"testDenyRecipient": big.NewInt(5),
+		},
+	}
+	engine := New(&config, rawdb.NewMemoryDatabase())
+
+	var (
+		allowedTx = types.NewTransaction(0, common.Address{0x01}, new(big.Int), 21000, new(big.Int), nil)
+		deniedTx  = types.NewTransaction(0, denied, new(big.Int), 21000, new(big.Int), nil)
+	)
+	tests := []struct {
+		number uint64
+		tx     *types.Transaction
+		fail   bool
+	}{
+		{4, allowedTx, false},
+		{4, deniedTx, false}, // Rule not active yet
+		{5, allowedTx, false},
+		{5, deniedTx, true},
+		{100, deniedTx, true},
+	}
+	for i, tt := range tests {
+		header := &types.Header{Number: new(big.Int).SetUint64(tt.number)}
+		err := engine.ExtraValidateOfTx(common.Address{0x02}, tt.tx, header)
+		if tt.fail && !errors.Is(err, errDeny) {
+			t.Errorf("test %d: have error %v, want %v", i, err, errDeny)
+		}
+		if !tt.fail && err != nil {
+			t.Errorf("test %d: unexpected error %v", i, err)
+		}
+	}
+	if err := CheckTxRules(config.Turbo.TxRules); err != nil {
+		t.Errorf("registered rules reported: %v", err)
+	}
+	// Blocks are rejected if an enabled rule isn't registered, and the engine
+	// isn't even created by the node.
+	config.Turbo = &params.TurboConfig{Period: 1, Epoch: 10, TxRules: map[string]*big.Int{"testMissing": common.Big0}}
+	if err := CheckTxRules(config.Turbo.TxRules); err == nil {
+		t.Error("no error for unregistered rule")
+	}
+	engine = New(&config, rawdb.NewMemoryDatabase())
+	if err := engine.ExtraValidateOfTx(common.Address{0x02}, allowedTx, &types.Header{Number: common.Big1}); err == nil {
+		t.Error("no error for unregistered rule")
+	}
+	// Rules can't be registered twice.
+	defer func() {
+		if recover() == nil {
+			t.Error("no panic for duplicate rule")
+		}
+	}()
+	RegisterTxRule("testDenyRecipient", func(common.Address, *types.Transaction, *types.Header) error { return nil })
+}
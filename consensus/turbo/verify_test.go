@@ -0,0 +1,143 @@
+package turbo
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newTestHeaderChain creates a chain of headers sealed in turn by the given
+// number of validators, returning the headers following the genesis.
+func newTestHeaderChain(validators, length int) (*testHeaderChain, []*types.Header) {
+	var (
+		accounts = newTesterAccountPool()
+		config   = params.AllTurboProtocolChanges
+		chain    = &testHeaderChain{headers: make(map[common.Hash]*types.Header)}
+		signers  = make([]string, validators)
+		start    = uint64(time.Now().Unix()) - uint64(length) - 1
+	)
+	for i := range signers {
+		signers[i] = fmt.Sprintf("V%d", i)
+	}
+	genesis := &types.Header{
+		Number:     new(big.Int),
+		Time:       start,
+		GasLimit:   params.GenesisGasLimit,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Difficulty: new(big.Int).Set(diffInTurn),
+		UncleHash:  uncleHash,
+		Extra:      make([]byte, extraVanity+validators*common.AddressLength+extraSeal),
+	}
+	accounts.checkpoint(genesis, signers)
+	chain.add(genesis, true)
+
+	validatorSet := EpochValidators(genesis)
+	for i := 1; i <= length; i++ {
+		parent := chain.canonical[i-1]
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     big.NewInt(int64(i)),
+			Time:       start + uint64(i),
+			GasLimit:   parent.GasLimit,
+			BaseFee:    eip1559.CalcBaseFee(config, parent),
+			Difficulty: new(big.Int).Set(diffInTurn),
+			UncleHash:  uncleHash,
+			Coinbase:   validatorSet[i%validators],
+			Extra:      make([]byte, extraVanity+extraSeal),
+		}
+		accounts.sign(header)
+		chain.add(header, true)
+	}
+	return chain, chain.canonical[1:]
+}
+
+// newTestVerifier creates a Turbo engine verifying the test header chains.
+func newTestVerifier() *Turbo {
+	engine := New(params.AllTurboProtocolChanges, rawdb.NewMemoryDatabase())
+	engine.fakeDiff = true
+	return engine
+}
+
+func TestVerifyHeaders(t *testing.T) {
+	chain, headers := newTestHeaderChain(3, 64)
+
+	// Corrupt the signature of a header, failing it and its descendants
+	bad := types.CopyHeader(headers[40])
+	bad.Extra[len(bad.Extra)-extraSeal] ^= 0xff
+	corrupted := append(append(append([]*types.Header{}, headers[:40]...), bad), headers[41:]...)
+
+	for _, batch := range [][]*types.Header{headers, corrupted} {
+		want := make([]error, len(batch))
+		sequential := newTestVerifier()
+		for i, header := range batch {
+			want[i] = sequential.verifyHeader(chain, header, batch[:i])
+		}
+		_, results := newTestVerifier().VerifyHeaders(chain, batch)
+		for i := range batch {
+			if err := <-results; !errors.Is(err, want[i]) {
+				t.Errorf("header %d: error mismatch: have %v, want %v", i, err, want[i])
+			}
+		}
+	}
+}
+
+func BenchmarkVerifyHeaders(b *testing.B) {
+	for _, validators := range []int{3, 21} {
+		b.Run(fmt.Sprintf("validators=%d", validators), func(b *testing.B) {
+			chain, headers := newTestHeaderChain(validators, 1024)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, results := newTestVerifier().VerifyHeaders(chain, headers)
+				for range headers {
+					if err := <-results; err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyHeadersSequential(b *testing.B) {
+	for _, validators := range []int{3, 21} {
+		b.Run(fmt.Sprintf("validators=%d", validators), func(b *testing.B) {
+			chain, headers := newTestHeaderChain(validators, 1024)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				engine := newTestVerifier()
+				for j, header := range headers {
+					if err := engine.verifyHeader(chain, header, headers[:j]); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// Tests that the headers stamped ahead of the local clock within the max drift
+// aren't considered future blocks.
+func TestVerifyClockDrift(t *testing.T) {
+	_, headers := newTestHeaderChain(3, 4)
+
+	header := types.CopyHeader(headers[len(headers)-1])
+	header.Time = uint64(time.Now().Add(3 * time.Second).Unix())
+
+	engine := newTestVerifier()
+	if err := engine.verifyStandaloneFields(header); !errors.Is(err, consensus.ErrFutureBlock) {
+		t.Fatalf("error mismatch without drift: have %v, want %v", err, consensus.ErrFutureBlock)
+	}
+	engine.SetMaxClockDrift(5 * time.Second)
+	if err := engine.verifyStandaloneFields(header); err != nil {
+		t.Fatalf("header within the max drift rejected: %v", err)
+	}
+}
@@ -31,17 +31,29 @@ func CallContract(ctx *CallContext, from common.Address, to *common.Address, dat
 
 // CallContract executes transaction sent to system contracts.
 func CallContractWithValue(ctx *CallContext, from common.Address, to *common.Address, data []byte, value *uint256.Int) (ret []byte, err error) {
+	ret, _, err = callContract(ctx, from, to, data, value, math.MaxUint64)
+	return ret, err
+}
+
+// CallContractWithGas executes transaction sent to system contracts with the
+// given value and gas, returning the gas used.
+func CallContractWithGas(ctx *CallContext, from common.Address, to *common.Address, data []byte, value *uint256.Int, gas uint64) (ret []byte, usedGas uint64, err error) {
+	ret, leftOverGas, err := callContract(ctx, from, to, data, value, gas)
+	return ret, gas - leftOverGas, err
+}
+
+func callContract(ctx *CallContext, from common.Address, to *common.Address, data []byte, value *uint256.Int, gas uint64) (ret []byte, leftOverGas uint64, err error) {
 	evm := vm.NewEVM(core.NewEVMBlockContext(ctx.Header, ctx.ChainContext, nil), vm.TxContext{
 		Origin:   from,
 		GasPrice: big.NewInt(0),
 	}, ctx.Statedb, ctx.ChainConfig, vm.Config{})
 
-	ret, _, err = evm.Call(vm.AccountRef(from), *to, data, math.MaxUint64, value)
+	ret, leftOverGas, err = evm.Call(vm.AccountRef(from), *to, data, gas, value)
 	// Finalise the statedb so any changes can take effect,
 	// and especially if the `from` account is empty, it can be finally deleted.
 	ctx.Statedb.Finalise(true)
 
-	return ret, WrapVMError(err, ret)
+	return ret, leftOverGas, WrapVMError(err, ret)
 }
 
 // VMCallContract executes transaction sent to system contracts with given EVM.
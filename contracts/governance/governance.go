@@ -0,0 +1,146 @@
+// Package governance builds, simulates and submits proposals to the on-chain
+// DAO of a Nero network.
+//
+// A proposal asks the DAO to execute an action on behalf of the chain, typically
+// a call of another contract with the given value and input. The DAO contract
+// isn't part of the system contracts deployed in the genesis, so its address is
+// provided by the caller.
+package governance
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DaoABI contains the methods to submit proposals to the DAO contract.
+const DaoABI = `[
+	{
+		"inputs": [
+			{"internalType": "uint256", "name": "action", "type": "uint256"},
+			{"internalType": "address", "name": "from", "type": "address"},
+			{"internalType": "address", "name": "to", "type": "address"},
+			{"internalType": "uint256", "name": "value", "type": "uint256"},
+			{"internalType": "bytes", "name": "input", "type": "bytes"}
+		],
+		"name": "commitProposal",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+// daoABI is the parsed DaoABI.
+var daoABI abi.ABI
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(DaoABI))
+	if err != nil {
+		panic(err)
+	}
+	daoABI = parsed
+}
+
+var errNoTarget = errors.New("proposal without target")
+
+// Proposal is an action submitted to the DAO.
+type Proposal struct {
+	Action *big.Int       // Action identifier interpreted by the DAO
+	From   common.Address // Account the action is executed on behalf of
+	To     common.Address // Target of the action
+	Value  *big.Int       // Value transferred to the target, nil for none
+	Input  []byte         // Input data of the action
+}
+
+// NewCallProposal creates a proposal calling the given method of the contract
+// deployed at the target address, packing the arguments with its ABI.
+func NewCallProposal(action *big.Int, from common.Address, to common.Address, value *big.Int, contract abi.ABI, method string, args ...interface{}) (*Proposal, error) {
+	input, err := contract.Pack(method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &Proposal{
+		Action: action,
+		From:   from,
+		To:     to,
+		Value:  value,
+		Input:  input,
+	}, nil
+}
+
+// args returns the commitProposal arguments of the proposal.
+func (p *Proposal) args() ([]interface{}, error) {
+	if p.To == (common.Address{}) {
+		return nil, errNoTarget
+	}
+	var (
+		action = p.Action
+		value  = p.Value
+	)
+	if action == nil {
+		action = new(big.Int)
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+	return []interface{}{action, p.From, p.To, value, p.Input}, nil
+}
+
+// Pack ABI-encodes the proposal into the input of the commitProposal call.
+func (p *Proposal) Pack() ([]byte, error) {
+	args, err := p.args()
+	if err != nil {
+		return nil, err
+	}
+	return daoABI.Pack("commitProposal", args...)
+}
+
+// Dao submits proposals to a DAO contract through a backend.
+type Dao struct {
+	address  common.Address
+	backend  bind.ContractBackend
+	contract *bind.BoundContract
+}
+
+// NewDao creates a client of the DAO contract deployed at the given address.
+func NewDao(address common.Address, backend bind.ContractBackend) *Dao {
+	return &Dao{
+		address:  address,
+		backend:  backend,
+		contract: bind.NewBoundContract(address, daoABI, backend, backend, backend),
+	}
+}
+
+// Address returns the address of the DAO contract.
+func (d *Dao) Address() common.Address {
+	return d.address
+}
+
+// Simulate executes the submission of the proposal by the proposer on the
+// latest state without sending a transaction, returning the error the DAO would
+// revert with. The revert data can be extracted with bind.RevertData.
+func (d *Dao) Simulate(ctx context.Context, proposer common.Address, p *Proposal) error {
+	input, err := p.Pack()
+	if err != nil {
+		return err
+	}
+	_, err = d.backend.CallContract(ctx, ethereum.CallMsg{From: proposer, To: &d.address, Data: input}, nil)
+	return err
+}
+
+// Commit signs and submits the proposal to the DAO with the given transaction
+// options.
+func (d *Dao) Commit(opts *bind.TransactOpts, p *Proposal) (*types.Transaction, error) {
+	args, err := p.args()
+	if err != nil {
+		return nil, err
+	}
+	return d.contract.Transact(opts, "commitProposal", args...)
+}
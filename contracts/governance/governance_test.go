@@ -0,0 +1,46 @@
+package governance
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestProposalPack(t *testing.T) {
+	target, _ := abi.JSON(strings.NewReader(`[{"type":"function","name":"setLimit","stateMutability":"nonpayable","inputs":[{"name":"limit","type":"uint256"}],"outputs":[]}]`))
+
+	var (
+		from = common.HexToAddress("0x1000")
+		to   = common.HexToAddress("0x2000")
+	)
+	p, err := NewCallProposal(big.NewInt(1), from, to, nil, target, "setLimit", big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input, err := p.Pack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	selector := crypto.Keccak256([]byte("commitProposal(uint256,address,address,uint256,bytes)"))[:4]
+	if !bytes.Equal(input[:4], selector) {
+		t.Fatalf("wrong selector: have %x, want %x", input[:4], selector)
+	}
+	args, err := daoABI.Methods["commitProposal"].Inputs.Unpack(input[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args[0].(*big.Int).Int64() != 1 || args[1].(common.Address) != from || args[2].(common.Address) != to || args[3].(*big.Int).Sign() != 0 {
+		t.Errorf("wrong arguments: %v", args)
+	}
+	if !bytes.Equal(args[4].([]byte), p.Input) {
+		t.Errorf("wrong input: have %x, want %x", args[4], p.Input)
+	}
+	if _, err := new(Proposal).Pack(); err != errNoTarget {
+		t.Errorf("wrong error for missing target: have %v, want %v", err, errNoTarget)
+	}
+}
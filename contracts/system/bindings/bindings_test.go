@@ -0,0 +1,112 @@
+package bindings_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/contracts/system/bindings"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestStakingBinding(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim := simulated.NewTurboBackend(types.GenesisAlloc{}, key)
+	defer sim.Close()
+
+	staking, err := bindings.NewStaking(system.StakingContract, sim.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &bind.CallOpts{}
+	validators, err := staking.GetActiveValidators(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(validators) != 1 || validators[0] != sim.Validator() {
+		t.Errorf("wrong active validators: have %v, want [%v]", validators, sim.Validator())
+	}
+	admin, err := staking.Admin(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if admin != sim.Validator() {
+		t.Errorf("wrong staking admin: have %v, want %v", admin, sim.Validator())
+	}
+}
+
+func TestStakingBindingFinalized(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim := simulated.NewTurboBackend(types.GenesisAlloc{}, key)
+	defer sim.Close()
+
+	staking, err := bindings.NewStaking(system.StakingContract, sim.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		sim.Commit()
+	}
+	if sim.Finalized() == 0 {
+		t.Fatal("no block finalized")
+	}
+	for _, tag := range []string{"latest", "safe", "finalized"} {
+		validators, err := staking.GetActiveValidators(&bind.CallOpts{BlockTag: tag})
+		if err != nil {
+			t.Fatalf("%s call failed: %v", tag, err)
+		}
+		if len(validators) != 1 || validators[0] != sim.Validator() {
+			t.Errorf("wrong %s active validators: have %v, want [%v]", tag, validators, sim.Validator())
+		}
+	}
+	header, err := sim.Client().HeaderByNumber(context.Background(), big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header.Number.Uint64() != sim.Finalized() {
+		t.Errorf("wrong finalized block: have %d, want %d", header.Number, sim.Finalized())
+	}
+	if _, err := staking.GetActiveValidators(&bind.CallOpts{BlockTag: "earliest"}); err != bind.ErrInvalidBlockTag {
+		t.Errorf("wrong error for unknown tag: have %v, want %v", err, bind.ErrInvalidBlockTag)
+	}
+}
+
+func TestStakingMulticaller(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sim := simulated.NewTurboBackend(types.GenesisAlloc{}, key)
+	defer sim.Close()
+
+	staking, err := bindings.NewStakingMulticaller(system.StakingContract, sim.Client().(bind.BatchCaller))
+	if err != nil {
+		t.Fatal(err)
+	}
+	validators, err := staking.GetActiveValidators()
+	if err != nil {
+		t.Fatal(err)
+	}
+	admin, err := staking.Admin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := staking.ValInfos(sim.Validator())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := staking.Execute(nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(*validators) != 1 || (*validators)[0] != sim.Validator() {
+		t.Errorf("wrong active validators: have %v, want [%v]", *validators, sim.Validator())
+	}
+	if *admin != sim.Validator() {
+		t.Errorf("wrong staking admin: have %v, want %v", *admin, sim.Validator())
+	}
+	if info.Stake == nil || info.Stake.Sign() <= 0 {
+		t.Errorf("validator without stake: %v", info.Stake)
+	}
+}
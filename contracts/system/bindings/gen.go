@@ -0,0 +1,12 @@
+// Package bindings contains the Go bindings of the Nero system contracts,
+// generated by abigen from the contract ABIs in this directory.
+//
+// The bindings can be used against any bind.ContractBackend, e.g. an ethclient
+// connected to a Nero node, to read staking and lock state, submit transactions
+// and iterate or watch the contract events:
+//
+//	staking, err := bindings.NewStaking(system.StakingContract, client)
+package bindings
+
+//go:generate go run ../../../cmd/abigen --abi staking.abi --pkg bindings --type Staking --multicall --out staking.go
+//go:generate go run ../../../cmd/abigen --abi genesislock.abi --pkg bindings --type GenesisLock --out genesislock.go
@@ -0,0 +1,1327 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// GenesisLockMetaData contains all meta data concerning the GenesisLock contract.
+var GenesisLockMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_typeId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_lockAmount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_firstLockTime\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_lockPeriod\",\"type\":\"uint256\"}],\"name\":\"LockRecordAppened\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_claimedPeriodCount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"_claimedAmount\",\"type\":\"uint256\"}],\"name\":\"ReleaseClaimed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_fromOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_toOwner\",\"type\":\"address\"}],\"name\":\"RightsAccepted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_fromOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"_toOwner\",\"type\":\"address\"}],\"name\":\"RightsChanging\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_from\",\"type\":\"address\"}],\"name\":\"acceptAllRights\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_userAddr\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_typeId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_firstLockTime\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_lockPeriodCnt\",\"type\":\"uint256\"}],\"name\":\"appendLockRecord\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_to\",\"type\":\"address\"}],\"name\":\"changeAllRights\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"claim\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"claimedPeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"currentTimestamp\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"firstPeriodLockedTime\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getClaimableAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"claimableAmt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"period\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getClaimablePeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"period\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"getUserInfo\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"typId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"lockedAmount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"firstLockTime\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"totalPeriod\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"alreadyClaimed\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"releases\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"userAddress\",\"type\":\"address[]\"},{\"internalType\":\"uint256[]\",\"name\":\"typeId\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"lockedAmount\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"lockedTime\",\"type\":\"uint256[]\"},{\"internalType\":\"uint256[]\",\"name\":\"periodAmount\",\"type\":\"uint256[]\"}],\"name\":\"init\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_periodTime\",\"type\":\"uint256\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"lockedPeriodAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"periodTime\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"rightsChanging\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"startTime\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"userLockedAmount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"userType\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// GenesisLockABI is the input ABI used to generate the binding from.
+// Deprecated: Use GenesisLockMetaData.ABI instead.
+var GenesisLockABI = GenesisLockMetaData.ABI
+
+// GenesisLock is an auto generated Go binding around an Ethereum contract.
+type GenesisLock struct {
+	GenesisLockCaller     // Read-only binding to the contract
+	GenesisLockTransactor // Write-only binding to the contract
+	GenesisLockFilterer   // Log filterer for contract events
+}
+
+// GenesisLockCaller is an auto generated read-only Go binding around an Ethereum contract.
+type GenesisLockCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// GenesisLockTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type GenesisLockTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// GenesisLockFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type GenesisLockFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// GenesisLockSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type GenesisLockSession struct {
+	Contract     *GenesisLock      // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// GenesisLockCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type GenesisLockCallerSession struct {
+	Contract *GenesisLockCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts      // Call options to use throughout this session
+}
+
+// GenesisLockTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type GenesisLockTransactorSession struct {
+	Contract     *GenesisLockTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts      // Transaction auth options to use throughout this session
+}
+
+// GenesisLockRaw is an auto generated low-level Go binding around an Ethereum contract.
+type GenesisLockRaw struct {
+	Contract *GenesisLock // Generic contract binding to access the raw methods on
+}
+
+// GenesisLockCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type GenesisLockCallerRaw struct {
+	Contract *GenesisLockCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// GenesisLockTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type GenesisLockTransactorRaw struct {
+	Contract *GenesisLockTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewGenesisLock creates a new instance of GenesisLock, bound to a specific deployed contract.
+func NewGenesisLock(address common.Address, backend bind.ContractBackend) (*GenesisLock, error) {
+	contract, err := bindGenesisLock(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &GenesisLock{GenesisLockCaller: GenesisLockCaller{contract: contract}, GenesisLockTransactor: GenesisLockTransactor{contract: contract}, GenesisLockFilterer: GenesisLockFilterer{contract: contract}}, nil
+}
+
+// NewGenesisLockCaller creates a new read-only instance of GenesisLock, bound to a specific deployed contract.
+func NewGenesisLockCaller(address common.Address, caller bind.ContractCaller) (*GenesisLockCaller, error) {
+	contract, err := bindGenesisLock(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GenesisLockCaller{contract: contract}, nil
+}
+
+// NewGenesisLockTransactor creates a new write-only instance of GenesisLock, bound to a specific deployed contract.
+func NewGenesisLockTransactor(address common.Address, transactor bind.ContractTransactor) (*GenesisLockTransactor, error) {
+	contract, err := bindGenesisLock(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GenesisLockTransactor{contract: contract}, nil
+}
+
+// NewGenesisLockFilterer creates a new log filterer instance of GenesisLock, bound to a specific deployed contract.
+func NewGenesisLockFilterer(address common.Address, filterer bind.ContractFilterer) (*GenesisLockFilterer, error) {
+	contract, err := bindGenesisLock(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &GenesisLockFilterer{contract: contract}, nil
+}
+
+// bindGenesisLock binds a generic wrapper to an already deployed contract.
+func bindGenesisLock(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := GenesisLockMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_GenesisLock *GenesisLockRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _GenesisLock.Contract.GenesisLockCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_GenesisLock *GenesisLockRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _GenesisLock.Contract.GenesisLockTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_GenesisLock *GenesisLockRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _GenesisLock.Contract.GenesisLockTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_GenesisLock *GenesisLockCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _GenesisLock.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_GenesisLock *GenesisLockTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _GenesisLock.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_GenesisLock *GenesisLockTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _GenesisLock.Contract.contract.Transact(opts, method, params...)
+}
+
+// ClaimedPeriod is a free data retrieval call binding the contract method 0xb5671fef.
+//
+// Solidity: function claimedPeriod(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCaller) ClaimedPeriod(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "claimedPeriod", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ClaimedPeriod is a free data retrieval call binding the contract method 0xb5671fef.
+//
+// Solidity: function claimedPeriod(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockSession) ClaimedPeriod(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.ClaimedPeriod(&_GenesisLock.CallOpts, arg0)
+}
+
+// ClaimedPeriod is a free data retrieval call binding the contract method 0xb5671fef.
+//
+// Solidity: function claimedPeriod(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCallerSession) ClaimedPeriod(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.ClaimedPeriod(&_GenesisLock.CallOpts, arg0)
+}
+
+// CurrentTimestamp is a free data retrieval call binding the contract method 0x82e06dd5.
+//
+// Solidity: function currentTimestamp(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCaller) CurrentTimestamp(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "currentTimestamp", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CurrentTimestamp is a free data retrieval call binding the contract method 0x82e06dd5.
+//
+// Solidity: function currentTimestamp(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockSession) CurrentTimestamp(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.CurrentTimestamp(&_GenesisLock.CallOpts, arg0)
+}
+
+// CurrentTimestamp is a free data retrieval call binding the contract method 0x82e06dd5.
+//
+// Solidity: function currentTimestamp(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCallerSession) CurrentTimestamp(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.CurrentTimestamp(&_GenesisLock.CallOpts, arg0)
+}
+
+// FirstPeriodLockedTime is a free data retrieval call binding the contract method 0x5e464da5.
+//
+// Solidity: function firstPeriodLockedTime(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCaller) FirstPeriodLockedTime(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "firstPeriodLockedTime", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// FirstPeriodLockedTime is a free data retrieval call binding the contract method 0x5e464da5.
+//
+// Solidity: function firstPeriodLockedTime(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockSession) FirstPeriodLockedTime(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.FirstPeriodLockedTime(&_GenesisLock.CallOpts, arg0)
+}
+
+// FirstPeriodLockedTime is a free data retrieval call binding the contract method 0x5e464da5.
+//
+// Solidity: function firstPeriodLockedTime(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCallerSession) FirstPeriodLockedTime(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.FirstPeriodLockedTime(&_GenesisLock.CallOpts, arg0)
+}
+
+// GetClaimableAmount is a free data retrieval call binding the contract method 0xe12f3a61.
+//
+// Solidity: function getClaimableAmount(address account) view returns(uint256 claimableAmt, uint256 period)
+func (_GenesisLock *GenesisLockCaller) GetClaimableAmount(opts *bind.CallOpts, account common.Address) (struct {
+	ClaimableAmt *big.Int
+	Period       *big.Int
+}, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "getClaimableAmount", account)
+
+	outstruct := new(struct {
+		ClaimableAmt *big.Int
+		Period       *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.ClaimableAmt = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Period = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetClaimableAmount is a free data retrieval call binding the contract method 0xe12f3a61.
+//
+// Solidity: function getClaimableAmount(address account) view returns(uint256 claimableAmt, uint256 period)
+func (_GenesisLock *GenesisLockSession) GetClaimableAmount(account common.Address) (struct {
+	ClaimableAmt *big.Int
+	Period       *big.Int
+}, error) {
+	return _GenesisLock.Contract.GetClaimableAmount(&_GenesisLock.CallOpts, account)
+}
+
+// GetClaimableAmount is a free data retrieval call binding the contract method 0xe12f3a61.
+//
+// Solidity: function getClaimableAmount(address account) view returns(uint256 claimableAmt, uint256 period)
+func (_GenesisLock *GenesisLockCallerSession) GetClaimableAmount(account common.Address) (struct {
+	ClaimableAmt *big.Int
+	Period       *big.Int
+}, error) {
+	return _GenesisLock.Contract.GetClaimableAmount(&_GenesisLock.CallOpts, account)
+}
+
+// GetClaimablePeriod is a free data retrieval call binding the contract method 0x77279db1.
+//
+// Solidity: function getClaimablePeriod(address account) view returns(uint256 period)
+func (_GenesisLock *GenesisLockCaller) GetClaimablePeriod(opts *bind.CallOpts, account common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "getClaimablePeriod", account)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetClaimablePeriod is a free data retrieval call binding the contract method 0x77279db1.
+//
+// Solidity: function getClaimablePeriod(address account) view returns(uint256 period)
+func (_GenesisLock *GenesisLockSession) GetClaimablePeriod(account common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.GetClaimablePeriod(&_GenesisLock.CallOpts, account)
+}
+
+// GetClaimablePeriod is a free data retrieval call binding the contract method 0x77279db1.
+//
+// Solidity: function getClaimablePeriod(address account) view returns(uint256 period)
+func (_GenesisLock *GenesisLockCallerSession) GetClaimablePeriod(account common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.GetClaimablePeriod(&_GenesisLock.CallOpts, account)
+}
+
+// GetUserInfo is a free data retrieval call binding the contract method 0x6386c1c7.
+//
+// Solidity: function getUserInfo(address account) view returns(uint256 typId, uint256 lockedAmount, uint256 firstLockTime, uint256 totalPeriod, uint256 alreadyClaimed, uint256 releases)
+func (_GenesisLock *GenesisLockCaller) GetUserInfo(opts *bind.CallOpts, account common.Address) (struct {
+	TypId          *big.Int
+	LockedAmount   *big.Int
+	FirstLockTime  *big.Int
+	TotalPeriod    *big.Int
+	AlreadyClaimed *big.Int
+	Releases       *big.Int
+}, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "getUserInfo", account)
+
+	outstruct := new(struct {
+		TypId          *big.Int
+		LockedAmount   *big.Int
+		FirstLockTime  *big.Int
+		TotalPeriod    *big.Int
+		AlreadyClaimed *big.Int
+		Releases       *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.TypId = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.LockedAmount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.FirstLockTime = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.TotalPeriod = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.AlreadyClaimed = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.Releases = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetUserInfo is a free data retrieval call binding the contract method 0x6386c1c7.
+//
+// Solidity: function getUserInfo(address account) view returns(uint256 typId, uint256 lockedAmount, uint256 firstLockTime, uint256 totalPeriod, uint256 alreadyClaimed, uint256 releases)
+func (_GenesisLock *GenesisLockSession) GetUserInfo(account common.Address) (struct {
+	TypId          *big.Int
+	LockedAmount   *big.Int
+	FirstLockTime  *big.Int
+	TotalPeriod    *big.Int
+	AlreadyClaimed *big.Int
+	Releases       *big.Int
+}, error) {
+	return _GenesisLock.Contract.GetUserInfo(&_GenesisLock.CallOpts, account)
+}
+
+// GetUserInfo is a free data retrieval call binding the contract method 0x6386c1c7.
+//
+// Solidity: function getUserInfo(address account) view returns(uint256 typId, uint256 lockedAmount, uint256 firstLockTime, uint256 totalPeriod, uint256 alreadyClaimed, uint256 releases)
+func (_GenesisLock *GenesisLockCallerSession) GetUserInfo(account common.Address) (struct {
+	TypId          *big.Int
+	LockedAmount   *big.Int
+	FirstLockTime  *big.Int
+	TotalPeriod    *big.Int
+	AlreadyClaimed *big.Int
+	Releases       *big.Int
+}, error) {
+	return _GenesisLock.Contract.GetUserInfo(&_GenesisLock.CallOpts, account)
+}
+
+// LockedPeriodAmount is a free data retrieval call binding the contract method 0x5c511dda.
+//
+// Solidity: function lockedPeriodAmount(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCaller) LockedPeriodAmount(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "lockedPeriodAmount", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// LockedPeriodAmount is a free data retrieval call binding the contract method 0x5c511dda.
+//
+// Solidity: function lockedPeriodAmount(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockSession) LockedPeriodAmount(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.LockedPeriodAmount(&_GenesisLock.CallOpts, arg0)
+}
+
+// LockedPeriodAmount is a free data retrieval call binding the contract method 0x5c511dda.
+//
+// Solidity: function lockedPeriodAmount(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCallerSession) LockedPeriodAmount(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.LockedPeriodAmount(&_GenesisLock.CallOpts, arg0)
+}
+
+// PeriodTime is a free data retrieval call binding the contract method 0x1d31fac0.
+//
+// Solidity: function periodTime() view returns(uint256)
+func (_GenesisLock *GenesisLockCaller) PeriodTime(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "periodTime")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PeriodTime is a free data retrieval call binding the contract method 0x1d31fac0.
+//
+// Solidity: function periodTime() view returns(uint256)
+func (_GenesisLock *GenesisLockSession) PeriodTime() (*big.Int, error) {
+	return _GenesisLock.Contract.PeriodTime(&_GenesisLock.CallOpts)
+}
+
+// PeriodTime is a free data retrieval call binding the contract method 0x1d31fac0.
+//
+// Solidity: function periodTime() view returns(uint256)
+func (_GenesisLock *GenesisLockCallerSession) PeriodTime() (*big.Int, error) {
+	return _GenesisLock.Contract.PeriodTime(&_GenesisLock.CallOpts)
+}
+
+// RightsChanging is a free data retrieval call binding the contract method 0x81e6985e.
+//
+// Solidity: function rightsChanging(address ) view returns(address)
+func (_GenesisLock *GenesisLockCaller) RightsChanging(opts *bind.CallOpts, arg0 common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "rightsChanging", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// RightsChanging is a free data retrieval call binding the contract method 0x81e6985e.
+//
+// Solidity: function rightsChanging(address ) view returns(address)
+func (_GenesisLock *GenesisLockSession) RightsChanging(arg0 common.Address) (common.Address, error) {
+	return _GenesisLock.Contract.RightsChanging(&_GenesisLock.CallOpts, arg0)
+}
+
+// RightsChanging is a free data retrieval call binding the contract method 0x81e6985e.
+//
+// Solidity: function rightsChanging(address ) view returns(address)
+func (_GenesisLock *GenesisLockCallerSession) RightsChanging(arg0 common.Address) (common.Address, error) {
+	return _GenesisLock.Contract.RightsChanging(&_GenesisLock.CallOpts, arg0)
+}
+
+// StartTime is a free data retrieval call binding the contract method 0x78e97925.
+//
+// Solidity: function startTime() view returns(uint256)
+func (_GenesisLock *GenesisLockCaller) StartTime(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "startTime")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// StartTime is a free data retrieval call binding the contract method 0x78e97925.
+//
+// Solidity: function startTime() view returns(uint256)
+func (_GenesisLock *GenesisLockSession) StartTime() (*big.Int, error) {
+	return _GenesisLock.Contract.StartTime(&_GenesisLock.CallOpts)
+}
+
+// StartTime is a free data retrieval call binding the contract method 0x78e97925.
+//
+// Solidity: function startTime() view returns(uint256)
+func (_GenesisLock *GenesisLockCallerSession) StartTime() (*big.Int, error) {
+	return _GenesisLock.Contract.StartTime(&_GenesisLock.CallOpts)
+}
+
+// UserLockedAmount is a free data retrieval call binding the contract method 0x2bfc9467.
+//
+// Solidity: function userLockedAmount(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCaller) UserLockedAmount(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "userLockedAmount", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UserLockedAmount is a free data retrieval call binding the contract method 0x2bfc9467.
+//
+// Solidity: function userLockedAmount(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockSession) UserLockedAmount(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.UserLockedAmount(&_GenesisLock.CallOpts, arg0)
+}
+
+// UserLockedAmount is a free data retrieval call binding the contract method 0x2bfc9467.
+//
+// Solidity: function userLockedAmount(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCallerSession) UserLockedAmount(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.UserLockedAmount(&_GenesisLock.CallOpts, arg0)
+}
+
+// UserType is a free data retrieval call binding the contract method 0xb83458b0.
+//
+// Solidity: function userType(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCaller) UserType(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _GenesisLock.contract.Call(opts, &out, "userType", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UserType is a free data retrieval call binding the contract method 0xb83458b0.
+//
+// Solidity: function userType(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockSession) UserType(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.UserType(&_GenesisLock.CallOpts, arg0)
+}
+
+// UserType is a free data retrieval call binding the contract method 0xb83458b0.
+//
+// Solidity: function userType(address ) view returns(uint256)
+func (_GenesisLock *GenesisLockCallerSession) UserType(arg0 common.Address) (*big.Int, error) {
+	return _GenesisLock.Contract.UserType(&_GenesisLock.CallOpts, arg0)
+}
+
+// AcceptAllRights is a paid mutator transaction binding the contract method 0x12e7f885.
+//
+// Solidity: function acceptAllRights(address _from) returns()
+func (_GenesisLock *GenesisLockTransactor) AcceptAllRights(opts *bind.TransactOpts, _from common.Address) (*types.Transaction, error) {
+	return _GenesisLock.contract.Transact(opts, "acceptAllRights", _from)
+}
+
+// AcceptAllRights is a paid mutator transaction binding the contract method 0x12e7f885.
+//
+// Solidity: function acceptAllRights(address _from) returns()
+func (_GenesisLock *GenesisLockSession) AcceptAllRights(_from common.Address) (*types.Transaction, error) {
+	return _GenesisLock.Contract.AcceptAllRights(&_GenesisLock.TransactOpts, _from)
+}
+
+// AcceptAllRights is a paid mutator transaction binding the contract method 0x12e7f885.
+//
+// Solidity: function acceptAllRights(address _from) returns()
+func (_GenesisLock *GenesisLockTransactorSession) AcceptAllRights(_from common.Address) (*types.Transaction, error) {
+	return _GenesisLock.Contract.AcceptAllRights(&_GenesisLock.TransactOpts, _from)
+}
+
+// AppendLockRecord is a paid mutator transaction binding the contract method 0x878c46a4.
+//
+// Solidity: function appendLockRecord(address _userAddr, uint256 _typeId, uint256 _firstLockTime, uint256 _lockPeriodCnt) payable returns()
+func (_GenesisLock *GenesisLockTransactor) AppendLockRecord(opts *bind.TransactOpts, _userAddr common.Address, _typeId *big.Int, _firstLockTime *big.Int, _lockPeriodCnt *big.Int) (*types.Transaction, error) {
+	return _GenesisLock.contract.Transact(opts, "appendLockRecord", _userAddr, _typeId, _firstLockTime, _lockPeriodCnt)
+}
+
+// AppendLockRecord is a paid mutator transaction binding the contract method 0x878c46a4.
+//
+// Solidity: function appendLockRecord(address _userAddr, uint256 _typeId, uint256 _firstLockTime, uint256 _lockPeriodCnt) payable returns()
+func (_GenesisLock *GenesisLockSession) AppendLockRecord(_userAddr common.Address, _typeId *big.Int, _firstLockTime *big.Int, _lockPeriodCnt *big.Int) (*types.Transaction, error) {
+	return _GenesisLock.Contract.AppendLockRecord(&_GenesisLock.TransactOpts, _userAddr, _typeId, _firstLockTime, _lockPeriodCnt)
+}
+
+// AppendLockRecord is a paid mutator transaction binding the contract method 0x878c46a4.
+//
+// Solidity: function appendLockRecord(address _userAddr, uint256 _typeId, uint256 _firstLockTime, uint256 _lockPeriodCnt) payable returns()
+func (_GenesisLock *GenesisLockTransactorSession) AppendLockRecord(_userAddr common.Address, _typeId *big.Int, _firstLockTime *big.Int, _lockPeriodCnt *big.Int) (*types.Transaction, error) {
+	return _GenesisLock.Contract.AppendLockRecord(&_GenesisLock.TransactOpts, _userAddr, _typeId, _firstLockTime, _lockPeriodCnt)
+}
+
+// ChangeAllRights is a paid mutator transaction binding the contract method 0x2486c798.
+//
+// Solidity: function changeAllRights(address _to) returns()
+func (_GenesisLock *GenesisLockTransactor) ChangeAllRights(opts *bind.TransactOpts, _to common.Address) (*types.Transaction, error) {
+	return _GenesisLock.contract.Transact(opts, "changeAllRights", _to)
+}
+
+// ChangeAllRights is a paid mutator transaction binding the contract method 0x2486c798.
+//
+// Solidity: function changeAllRights(address _to) returns()
+func (_GenesisLock *GenesisLockSession) ChangeAllRights(_to common.Address) (*types.Transaction, error) {
+	return _GenesisLock.Contract.ChangeAllRights(&_GenesisLock.TransactOpts, _to)
+}
+
+// ChangeAllRights is a paid mutator transaction binding the contract method 0x2486c798.
+//
+// Solidity: function changeAllRights(address _to) returns()
+func (_GenesisLock *GenesisLockTransactorSession) ChangeAllRights(_to common.Address) (*types.Transaction, error) {
+	return _GenesisLock.Contract.ChangeAllRights(&_GenesisLock.TransactOpts, _to)
+}
+
+// Claim is a paid mutator transaction binding the contract method 0x4e71d92d.
+//
+// Solidity: function claim() returns()
+func (_GenesisLock *GenesisLockTransactor) Claim(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _GenesisLock.contract.Transact(opts, "claim")
+}
+
+// Claim is a paid mutator transaction binding the contract method 0x4e71d92d.
+//
+// Solidity: function claim() returns()
+func (_GenesisLock *GenesisLockSession) Claim() (*types.Transaction, error) {
+	return _GenesisLock.Contract.Claim(&_GenesisLock.TransactOpts)
+}
+
+// Claim is a paid mutator transaction binding the contract method 0x4e71d92d.
+//
+// Solidity: function claim() returns()
+func (_GenesisLock *GenesisLockTransactorSession) Claim() (*types.Transaction, error) {
+	return _GenesisLock.Contract.Claim(&_GenesisLock.TransactOpts)
+}
+
+// Init is a paid mutator transaction binding the contract method 0xbb8416ec.
+//
+// Solidity: function init(address[] userAddress, uint256[] typeId, uint256[] lockedAmount, uint256[] lockedTime, uint256[] periodAmount) returns()
+func (_GenesisLock *GenesisLockTransactor) Init(opts *bind.TransactOpts, userAddress []common.Address, typeId []*big.Int, lockedAmount []*big.Int, lockedTime []*big.Int, periodAmount []*big.Int) (*types.Transaction, error) {
+	return _GenesisLock.contract.Transact(opts, "init", userAddress, typeId, lockedAmount, lockedTime, periodAmount)
+}
+
+// Init is a paid mutator transaction binding the contract method 0xbb8416ec.
+//
+// Solidity: function init(address[] userAddress, uint256[] typeId, uint256[] lockedAmount, uint256[] lockedTime, uint256[] periodAmount) returns()
+func (_GenesisLock *GenesisLockSession) Init(userAddress []common.Address, typeId []*big.Int, lockedAmount []*big.Int, lockedTime []*big.Int, periodAmount []*big.Int) (*types.Transaction, error) {
+	return _GenesisLock.Contract.Init(&_GenesisLock.TransactOpts, userAddress, typeId, lockedAmount, lockedTime, periodAmount)
+}
+
+// Init is a paid mutator transaction binding the contract method 0xbb8416ec.
+//
+// Solidity: function init(address[] userAddress, uint256[] typeId, uint256[] lockedAmount, uint256[] lockedTime, uint256[] periodAmount) returns()
+func (_GenesisLock *GenesisLockTransactorSession) Init(userAddress []common.Address, typeId []*big.Int, lockedAmount []*big.Int, lockedTime []*big.Int, periodAmount []*big.Int) (*types.Transaction, error) {
+	return _GenesisLock.Contract.Init(&_GenesisLock.TransactOpts, userAddress, typeId, lockedAmount, lockedTime, periodAmount)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xfe4b84df.
+//
+// Solidity: function initialize(uint256 _periodTime) returns()
+func (_GenesisLock *GenesisLockTransactor) Initialize(opts *bind.TransactOpts, _periodTime *big.Int) (*types.Transaction, error) {
+	return _GenesisLock.contract.Transact(opts, "initialize", _periodTime)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xfe4b84df.
+//
+// Solidity: function initialize(uint256 _periodTime) returns()
+func (_GenesisLock *GenesisLockSession) Initialize(_periodTime *big.Int) (*types.Transaction, error) {
+	return _GenesisLock.Contract.Initialize(&_GenesisLock.TransactOpts, _periodTime)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0xfe4b84df.
+//
+// Solidity: function initialize(uint256 _periodTime) returns()
+func (_GenesisLock *GenesisLockTransactorSession) Initialize(_periodTime *big.Int) (*types.Transaction, error) {
+	return _GenesisLock.Contract.Initialize(&_GenesisLock.TransactOpts, _periodTime)
+}
+
+// GenesisLockLockRecordAppenedIterator is returned from FilterLockRecordAppened and is used to iterate over the raw logs and unpacked data for LockRecordAppened events raised by the GenesisLock contract.
+type GenesisLockLockRecordAppenedIterator struct {
+	Event *GenesisLockLockRecordAppened // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *GenesisLockLockRecordAppenedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(GenesisLockLockRecordAppened)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(GenesisLockLockRecordAppened)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *GenesisLockLockRecordAppenedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *GenesisLockLockRecordAppenedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// GenesisLockLockRecordAppened represents a LockRecordAppened event raised by the GenesisLock contract.
+type GenesisLockLockRecordAppened struct {
+	Owner         common.Address
+	TypeId        *big.Int
+	LockAmount    *big.Int
+	FirstLockTime *big.Int
+	LockPeriod    *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterLockRecordAppened is a free log retrieval operation binding the contract event 0xd998434f094626d5f119d63185a774410437bde8b685f5f075fe18802e71de34.
+//
+// Solidity: event LockRecordAppened(address indexed _owner, uint256 _typeId, uint256 _lockAmount, uint256 _firstLockTime, uint256 _lockPeriod)
+func (_GenesisLock *GenesisLockFilterer) FilterLockRecordAppened(opts *bind.FilterOpts, _owner []common.Address) (*GenesisLockLockRecordAppenedIterator, error) {
+
+	var _ownerRule []interface{}
+	for _, _ownerItem := range _owner {
+		_ownerRule = append(_ownerRule, _ownerItem)
+	}
+
+	logs, sub, err := _GenesisLock.contract.FilterLogs(opts, "LockRecordAppened", _ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &GenesisLockLockRecordAppenedIterator{contract: _GenesisLock.contract, event: "LockRecordAppened", logs: logs, sub: sub}, nil
+}
+
+// WatchLockRecordAppened is a free log subscription operation binding the contract event 0xd998434f094626d5f119d63185a774410437bde8b685f5f075fe18802e71de34.
+//
+// Solidity: event LockRecordAppened(address indexed _owner, uint256 _typeId, uint256 _lockAmount, uint256 _firstLockTime, uint256 _lockPeriod)
+func (_GenesisLock *GenesisLockFilterer) WatchLockRecordAppened(opts *bind.WatchOpts, sink chan<- *GenesisLockLockRecordAppened, _owner []common.Address) (event.Subscription, error) {
+
+	var _ownerRule []interface{}
+	for _, _ownerItem := range _owner {
+		_ownerRule = append(_ownerRule, _ownerItem)
+	}
+
+	logs, sub, err := _GenesisLock.contract.WatchLogs(opts, "LockRecordAppened", _ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(GenesisLockLockRecordAppened)
+				if err := _GenesisLock.contract.UnpackLog(event, "LockRecordAppened", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseLockRecordAppened is a log parse operation binding the contract event 0xd998434f094626d5f119d63185a774410437bde8b685f5f075fe18802e71de34.
+//
+// Solidity: event LockRecordAppened(address indexed _owner, uint256 _typeId, uint256 _lockAmount, uint256 _firstLockTime, uint256 _lockPeriod)
+func (_GenesisLock *GenesisLockFilterer) ParseLockRecordAppened(log types.Log) (*GenesisLockLockRecordAppened, error) {
+	event := new(GenesisLockLockRecordAppened)
+	if err := _GenesisLock.contract.UnpackLog(event, "LockRecordAppened", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// GenesisLockReleaseClaimedIterator is returned from FilterReleaseClaimed and is used to iterate over the raw logs and unpacked data for ReleaseClaimed events raised by the GenesisLock contract.
+type GenesisLockReleaseClaimedIterator struct {
+	Event *GenesisLockReleaseClaimed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *GenesisLockReleaseClaimedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(GenesisLockReleaseClaimed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(GenesisLockReleaseClaimed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *GenesisLockReleaseClaimedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *GenesisLockReleaseClaimedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// GenesisLockReleaseClaimed represents a ReleaseClaimed event raised by the GenesisLock contract.
+type GenesisLockReleaseClaimed struct {
+	Owner              common.Address
+	ClaimedPeriodCount *big.Int
+	ClaimedAmount      *big.Int
+	Raw                types.Log // Blockchain specific contextual infos
+}
+
+// FilterReleaseClaimed is a free log retrieval operation binding the contract event 0x0a596e8f33cf31b9625c1f2a4d1d82b48dc1d62f5f264a29556ae234b052f2b0.
+//
+// Solidity: event ReleaseClaimed(address indexed _owner, uint256 _claimedPeriodCount, uint256 _claimedAmount)
+func (_GenesisLock *GenesisLockFilterer) FilterReleaseClaimed(opts *bind.FilterOpts, _owner []common.Address) (*GenesisLockReleaseClaimedIterator, error) {
+
+	var _ownerRule []interface{}
+	for _, _ownerItem := range _owner {
+		_ownerRule = append(_ownerRule, _ownerItem)
+	}
+
+	logs, sub, err := _GenesisLock.contract.FilterLogs(opts, "ReleaseClaimed", _ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &GenesisLockReleaseClaimedIterator{contract: _GenesisLock.contract, event: "ReleaseClaimed", logs: logs, sub: sub}, nil
+}
+
+// WatchReleaseClaimed is a free log subscription operation binding the contract event 0x0a596e8f33cf31b9625c1f2a4d1d82b48dc1d62f5f264a29556ae234b052f2b0.
+//
+// Solidity: event ReleaseClaimed(address indexed _owner, uint256 _claimedPeriodCount, uint256 _claimedAmount)
+func (_GenesisLock *GenesisLockFilterer) WatchReleaseClaimed(opts *bind.WatchOpts, sink chan<- *GenesisLockReleaseClaimed, _owner []common.Address) (event.Subscription, error) {
+
+	var _ownerRule []interface{}
+	for _, _ownerItem := range _owner {
+		_ownerRule = append(_ownerRule, _ownerItem)
+	}
+
+	logs, sub, err := _GenesisLock.contract.WatchLogs(opts, "ReleaseClaimed", _ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(GenesisLockReleaseClaimed)
+				if err := _GenesisLock.contract.UnpackLog(event, "ReleaseClaimed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseReleaseClaimed is a log parse operation binding the contract event 0x0a596e8f33cf31b9625c1f2a4d1d82b48dc1d62f5f264a29556ae234b052f2b0.
+//
+// Solidity: event ReleaseClaimed(address indexed _owner, uint256 _claimedPeriodCount, uint256 _claimedAmount)
+func (_GenesisLock *GenesisLockFilterer) ParseReleaseClaimed(log types.Log) (*GenesisLockReleaseClaimed, error) {
+	event := new(GenesisLockReleaseClaimed)
+	if err := _GenesisLock.contract.UnpackLog(event, "ReleaseClaimed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// GenesisLockRightsAcceptedIterator is returned from FilterRightsAccepted and is used to iterate over the raw logs and unpacked data for RightsAccepted events raised by the GenesisLock contract.
+type GenesisLockRightsAcceptedIterator struct {
+	Event *GenesisLockRightsAccepted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *GenesisLockRightsAcceptedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(GenesisLockRightsAccepted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(GenesisLockRightsAccepted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *GenesisLockRightsAcceptedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *GenesisLockRightsAcceptedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// GenesisLockRightsAccepted represents a RightsAccepted event raised by the GenesisLock contract.
+type GenesisLockRightsAccepted struct {
+	FromOwner common.Address
+	ToOwner   common.Address
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterRightsAccepted is a free log retrieval operation binding the contract event 0x9801eccdebecb62efd4aa0977729683d4868fef30bd77dc4d0e620848063a89e.
+//
+// Solidity: event RightsAccepted(address indexed _fromOwner, address indexed _toOwner)
+func (_GenesisLock *GenesisLockFilterer) FilterRightsAccepted(opts *bind.FilterOpts, _fromOwner []common.Address, _toOwner []common.Address) (*GenesisLockRightsAcceptedIterator, error) {
+
+	var _fromOwnerRule []interface{}
+	for _, _fromOwnerItem := range _fromOwner {
+		_fromOwnerRule = append(_fromOwnerRule, _fromOwnerItem)
+	}
+	var _toOwnerRule []interface{}
+	for _, _toOwnerItem := range _toOwner {
+		_toOwnerRule = append(_toOwnerRule, _toOwnerItem)
+	}
+
+	logs, sub, err := _GenesisLock.contract.FilterLogs(opts, "RightsAccepted", _fromOwnerRule, _toOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &GenesisLockRightsAcceptedIterator{contract: _GenesisLock.contract, event: "RightsAccepted", logs: logs, sub: sub}, nil
+}
+
+// WatchRightsAccepted is a free log subscription operation binding the contract event 0x9801eccdebecb62efd4aa0977729683d4868fef30bd77dc4d0e620848063a89e.
+//
+// Solidity: event RightsAccepted(address indexed _fromOwner, address indexed _toOwner)
+func (_GenesisLock *GenesisLockFilterer) WatchRightsAccepted(opts *bind.WatchOpts, sink chan<- *GenesisLockRightsAccepted, _fromOwner []common.Address, _toOwner []common.Address) (event.Subscription, error) {
+
+	var _fromOwnerRule []interface{}
+	for _, _fromOwnerItem := range _fromOwner {
+		_fromOwnerRule = append(_fromOwnerRule, _fromOwnerItem)
+	}
+	var _toOwnerRule []interface{}
+	for _, _toOwnerItem := range _toOwner {
+		_toOwnerRule = append(_toOwnerRule, _toOwnerItem)
+	}
+
+	logs, sub, err := _GenesisLock.contract.WatchLogs(opts, "RightsAccepted", _fromOwnerRule, _toOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(GenesisLockRightsAccepted)
+				if err := _GenesisLock.contract.UnpackLog(event, "RightsAccepted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRightsAccepted is a log parse operation binding the contract event 0x9801eccdebecb62efd4aa0977729683d4868fef30bd77dc4d0e620848063a89e.
+//
+// Solidity: event RightsAccepted(address indexed _fromOwner, address indexed _toOwner)
+func (_GenesisLock *GenesisLockFilterer) ParseRightsAccepted(log types.Log) (*GenesisLockRightsAccepted, error) {
+	event := new(GenesisLockRightsAccepted)
+	if err := _GenesisLock.contract.UnpackLog(event, "RightsAccepted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// GenesisLockRightsChangingIterator is returned from FilterRightsChanging and is used to iterate over the raw logs and unpacked data for RightsChanging events raised by the GenesisLock contract.
+type GenesisLockRightsChangingIterator struct {
+	Event *GenesisLockRightsChanging // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *GenesisLockRightsChangingIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(GenesisLockRightsChanging)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(GenesisLockRightsChanging)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *GenesisLockRightsChangingIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *GenesisLockRightsChangingIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// GenesisLockRightsChanging represents a RightsChanging event raised by the GenesisLock contract.
+type GenesisLockRightsChanging struct {
+	FromOwner common.Address
+	ToOwner   common.Address
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterRightsChanging is a free log retrieval operation binding the contract event 0xade1f9cb27f9c5cefdfcd883a784c328d9d2dec9e735069c706606d6a06e33a8.
+//
+// Solidity: event RightsChanging(address indexed _fromOwner, address indexed _toOwner)
+func (_GenesisLock *GenesisLockFilterer) FilterRightsChanging(opts *bind.FilterOpts, _fromOwner []common.Address, _toOwner []common.Address) (*GenesisLockRightsChangingIterator, error) {
+
+	var _fromOwnerRule []interface{}
+	for _, _fromOwnerItem := range _fromOwner {
+		_fromOwnerRule = append(_fromOwnerRule, _fromOwnerItem)
+	}
+	var _toOwnerRule []interface{}
+	for _, _toOwnerItem := range _toOwner {
+		_toOwnerRule = append(_toOwnerRule, _toOwnerItem)
+	}
+
+	logs, sub, err := _GenesisLock.contract.FilterLogs(opts, "RightsChanging", _fromOwnerRule, _toOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &GenesisLockRightsChangingIterator{contract: _GenesisLock.contract, event: "RightsChanging", logs: logs, sub: sub}, nil
+}
+
+// WatchRightsChanging is a free log subscription operation binding the contract event 0xade1f9cb27f9c5cefdfcd883a784c328d9d2dec9e735069c706606d6a06e33a8.
+//
+// Solidity: event RightsChanging(address indexed _fromOwner, address indexed _toOwner)
+func (_GenesisLock *GenesisLockFilterer) WatchRightsChanging(opts *bind.WatchOpts, sink chan<- *GenesisLockRightsChanging, _fromOwner []common.Address, _toOwner []common.Address) (event.Subscription, error) {
+
+	var _fromOwnerRule []interface{}
+	for _, _fromOwnerItem := range _fromOwner {
+		_fromOwnerRule = append(_fromOwnerRule, _fromOwnerItem)
+	}
+	var _toOwnerRule []interface{}
+	for _, _toOwnerItem := range _toOwner {
+		_toOwnerRule = append(_toOwnerRule, _toOwnerItem)
+	}
+
+	logs, sub, err := _GenesisLock.contract.WatchLogs(opts, "RightsChanging", _fromOwnerRule, _toOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(GenesisLockRightsChanging)
+				if err := _GenesisLock.contract.UnpackLog(event, "RightsChanging", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRightsChanging is a log parse operation binding the contract event 0xade1f9cb27f9c5cefdfcd883a784c328d9d2dec9e735069c706606d6a06e33a8.
+//
+// Solidity: event RightsChanging(address indexed _fromOwner, address indexed _toOwner)
+func (_GenesisLock *GenesisLockFilterer) ParseRightsChanging(log types.Log) (*GenesisLockRightsChanging, error) {
+	event := new(GenesisLockRightsChanging)
+	if err := _GenesisLock.contract.UnpackLog(event, "RightsChanging", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,4291 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package bindings
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// StakingMetaData contains all meta data concerning the Staking contract.
+var StakingMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"oldAdmin\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newAdmin\",\"type\":\"address\"}],\"name\":\"AdminChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newAdmin\",\"type\":\"address\"}],\"name\":\"AdminChanging\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"val\",\"type\":\"address\"}],\"name\":\"ClaimWithoutUnboundStake\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"val\",\"type\":\"address\"}],\"name\":\"FounderUnlocked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"LogDecreaseMissedBlocksCounter\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"val\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"time\",\"type\":\"uint256\"}],\"name\":\"LogDoubleSignPunishValidator\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"val\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"time\",\"type\":\"uint256\"}],\"name\":\"LogLazyPunishValidator\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bool\",\"name\":\"opened\",\"type\":\"bool\"}],\"name\":\"PermissionLess\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"val\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"StakeWithdrawn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"empty\",\"type\":\"bool\"}],\"name\":\"StakingRewardsEmpty\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"changer\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldStake\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newStake\",\"type\":\"uint256\"}],\"name\":\"TotalStakeChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"val\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"manager\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"commissionRate\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"enumState\",\"name\":\"st\",\"type\":\"uint8\"}],\"name\":\"ValidatorRegistered\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"DecreaseRate\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"EvilPunishFactor\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"JailPeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"LazyPunishFactor\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"LazyPunishThreshold\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MaxStakes\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MaxValidators\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"MinSelfStakes\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"PunishBase\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"StakeUnit\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"ThresholdStakes\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"UnboundLockPeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"accRewardsPerStake\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"acceptAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"addDelegation\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"addStake\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"admin\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"allValidatorAddrs\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_stakeOwner\",\"type\":\"address\"}],\"name\":\"anyClaimable\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"basicLockEnd\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"blockEpoch\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newAdmin\",\"type\":\"address\"}],\"name\":\"changeAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_stakeOwner\",\"type\":\"address\"}],\"name\":\"claimableRewards\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"decreaseMissedBlocksCounter\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"delegatorClaimAny\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"distributeBlockFee\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_punishHash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"doubleSignPunish\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"doubleSignPunished\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"exitDelegation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"exitStaking\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"founders\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"initialStake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unboundStake\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"locking\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getActiveValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getAllValidatorsLength\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"getPunishRecord\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getPunishValidatorsLen\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint8\",\"name\":\"_count\",\"type\":\"uint8\"}],\"name\":\"getTopValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_manager\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_rate\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_stakes\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"_acceptDelegation\",\"type\":\"bool\"}],\"name\":\"initValidator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_admin\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_firstLockPeriod\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_releasePeriod\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_releaseCnt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_totalRewards\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_rewardsPerBlock\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_epoch\",\"type\":\"uint256\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"initialized\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"punishHash\",\"type\":\"bytes32\"}],\"name\":\"isDoubleSignPunished\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"isOpened\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"isReleaseLockEnd\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastUpdateAccBlock\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"lazyPunish\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"lazyPunishedValidators\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pendingAdmin\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_oldVal\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_newVal\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\"}],\"name\":\"reDelegation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_oldVal\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_newVal\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\"}],\"name\":\"reStaking\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_manager\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_rate\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"_acceptDelegation\",\"type\":\"bool\"}],\"name\":\"registerValidator\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"releaseCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"releasePeriod\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"removePermission\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"rewardsPerBlock\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\"}],\"name\":\"subDelegation\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_amount\",\"type\":\"uint256\"}],\"name\":\"subStake\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalStake\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalStakingRewards\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address[]\",\"name\":\"newSet\",\"type\":\"address[]\"}],\"name\":\"updateActiveValidatorSet\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"valInfos\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"stake\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"debt\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"incomeFees\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"unWithdrawn\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"valMaps\",\"outputs\":[{\"internalType\":\"contractIValidator\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_val\",\"type\":\"address\"}],\"name\":\"validatorClaimAny\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// StakingABI is the input ABI used to generate the binding from.
+// Deprecated: Use StakingMetaData.ABI instead.
+var StakingABI = StakingMetaData.ABI
+
+// Staking is an auto generated Go binding around an Ethereum contract.
+type Staking struct {
+	StakingCaller     // Read-only binding to the contract
+	StakingTransactor // Write-only binding to the contract
+	StakingFilterer   // Log filterer for contract events
+}
+
+// StakingCaller is an auto generated read-only Go binding around an Ethereum contract.
+type StakingCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StakingTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type StakingTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StakingFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type StakingFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StakingSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type StakingSession struct {
+	Contract     *Staking          // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// StakingCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type StakingCallerSession struct {
+	Contract *StakingCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts  // Call options to use throughout this session
+}
+
+// StakingTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type StakingTransactorSession struct {
+	Contract     *StakingTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// StakingRaw is an auto generated low-level Go binding around an Ethereum contract.
+type StakingRaw struct {
+	Contract *Staking // Generic contract binding to access the raw methods on
+}
+
+// StakingCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type StakingCallerRaw struct {
+	Contract *StakingCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// StakingTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type StakingTransactorRaw struct {
+	Contract *StakingTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewStaking creates a new instance of Staking, bound to a specific deployed contract.
+func NewStaking(address common.Address, backend bind.ContractBackend) (*Staking, error) {
+	contract, err := bindStaking(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Staking{StakingCaller: StakingCaller{contract: contract}, StakingTransactor: StakingTransactor{contract: contract}, StakingFilterer: StakingFilterer{contract: contract}}, nil
+}
+
+// NewStakingCaller creates a new read-only instance of Staking, bound to a specific deployed contract.
+func NewStakingCaller(address common.Address, caller bind.ContractCaller) (*StakingCaller, error) {
+	contract, err := bindStaking(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingCaller{contract: contract}, nil
+}
+
+// NewStakingTransactor creates a new write-only instance of Staking, bound to a specific deployed contract.
+func NewStakingTransactor(address common.Address, transactor bind.ContractTransactor) (*StakingTransactor, error) {
+	contract, err := bindStaking(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingTransactor{contract: contract}, nil
+}
+
+// NewStakingFilterer creates a new log filterer instance of Staking, bound to a specific deployed contract.
+func NewStakingFilterer(address common.Address, filterer bind.ContractFilterer) (*StakingFilterer, error) {
+	contract, err := bindStaking(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingFilterer{contract: contract}, nil
+}
+
+// bindStaking binds a generic wrapper to an already deployed contract.
+func bindStaking(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := StakingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Staking *StakingRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Staking.Contract.StakingCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Staking *StakingRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Staking.Contract.StakingTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Staking *StakingRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Staking.Contract.StakingTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Staking *StakingCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Staking.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Staking *StakingTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Staking.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Staking *StakingTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Staking.Contract.contract.Transact(opts, method, params...)
+}
+
+// DecreaseRate is a free data retrieval call binding the contract method 0xd2957b2f.
+//
+// Solidity: function DecreaseRate() view returns(uint256)
+func (_Staking *StakingCaller) DecreaseRate(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "DecreaseRate")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// DecreaseRate is a free data retrieval call binding the contract method 0xd2957b2f.
+//
+// Solidity: function DecreaseRate() view returns(uint256)
+func (_Staking *StakingSession) DecreaseRate() (*big.Int, error) {
+	return _Staking.Contract.DecreaseRate(&_Staking.CallOpts)
+}
+
+// DecreaseRate is a free data retrieval call binding the contract method 0xd2957b2f.
+//
+// Solidity: function DecreaseRate() view returns(uint256)
+func (_Staking *StakingCallerSession) DecreaseRate() (*big.Int, error) {
+	return _Staking.Contract.DecreaseRate(&_Staking.CallOpts)
+}
+
+// EvilPunishFactor is a free data retrieval call binding the contract method 0x98c2199d.
+//
+// Solidity: function EvilPunishFactor() view returns(uint256)
+func (_Staking *StakingCaller) EvilPunishFactor(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "EvilPunishFactor")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// EvilPunishFactor is a free data retrieval call binding the contract method 0x98c2199d.
+//
+// Solidity: function EvilPunishFactor() view returns(uint256)
+func (_Staking *StakingSession) EvilPunishFactor() (*big.Int, error) {
+	return _Staking.Contract.EvilPunishFactor(&_Staking.CallOpts)
+}
+
+// EvilPunishFactor is a free data retrieval call binding the contract method 0x98c2199d.
+//
+// Solidity: function EvilPunishFactor() view returns(uint256)
+func (_Staking *StakingCallerSession) EvilPunishFactor() (*big.Int, error) {
+	return _Staking.Contract.EvilPunishFactor(&_Staking.CallOpts)
+}
+
+// JailPeriod is a free data retrieval call binding the contract method 0x15de360e.
+//
+// Solidity: function JailPeriod() view returns(uint256)
+func (_Staking *StakingCaller) JailPeriod(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "JailPeriod")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// JailPeriod is a free data retrieval call binding the contract method 0x15de360e.
+//
+// Solidity: function JailPeriod() view returns(uint256)
+func (_Staking *StakingSession) JailPeriod() (*big.Int, error) {
+	return _Staking.Contract.JailPeriod(&_Staking.CallOpts)
+}
+
+// JailPeriod is a free data retrieval call binding the contract method 0x15de360e.
+//
+// Solidity: function JailPeriod() view returns(uint256)
+func (_Staking *StakingCallerSession) JailPeriod() (*big.Int, error) {
+	return _Staking.Contract.JailPeriod(&_Staking.CallOpts)
+}
+
+// LazyPunishFactor is a free data retrieval call binding the contract method 0x5a651019.
+//
+// Solidity: function LazyPunishFactor() view returns(uint256)
+func (_Staking *StakingCaller) LazyPunishFactor(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "LazyPunishFactor")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// LazyPunishFactor is a free data retrieval call binding the contract method 0x5a651019.
+//
+// Solidity: function LazyPunishFactor() view returns(uint256)
+func (_Staking *StakingSession) LazyPunishFactor() (*big.Int, error) {
+	return _Staking.Contract.LazyPunishFactor(&_Staking.CallOpts)
+}
+
+// LazyPunishFactor is a free data retrieval call binding the contract method 0x5a651019.
+//
+// Solidity: function LazyPunishFactor() view returns(uint256)
+func (_Staking *StakingCallerSession) LazyPunishFactor() (*big.Int, error) {
+	return _Staking.Contract.LazyPunishFactor(&_Staking.CallOpts)
+}
+
+// LazyPunishThreshold is a free data retrieval call binding the contract method 0x02345565.
+//
+// Solidity: function LazyPunishThreshold() view returns(uint256)
+func (_Staking *StakingCaller) LazyPunishThreshold(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "LazyPunishThreshold")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// LazyPunishThreshold is a free data retrieval call binding the contract method 0x02345565.
+//
+// Solidity: function LazyPunishThreshold() view returns(uint256)
+func (_Staking *StakingSession) LazyPunishThreshold() (*big.Int, error) {
+	return _Staking.Contract.LazyPunishThreshold(&_Staking.CallOpts)
+}
+
+// LazyPunishThreshold is a free data retrieval call binding the contract method 0x02345565.
+//
+// Solidity: function LazyPunishThreshold() view returns(uint256)
+func (_Staking *StakingCallerSession) LazyPunishThreshold() (*big.Int, error) {
+	return _Staking.Contract.LazyPunishThreshold(&_Staking.CallOpts)
+}
+
+// MaxStakes is a free data retrieval call binding the contract method 0x7977f981.
+//
+// Solidity: function MaxStakes() view returns(uint256)
+func (_Staking *StakingCaller) MaxStakes(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "MaxStakes")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxStakes is a free data retrieval call binding the contract method 0x7977f981.
+//
+// Solidity: function MaxStakes() view returns(uint256)
+func (_Staking *StakingSession) MaxStakes() (*big.Int, error) {
+	return _Staking.Contract.MaxStakes(&_Staking.CallOpts)
+}
+
+// MaxStakes is a free data retrieval call binding the contract method 0x7977f981.
+//
+// Solidity: function MaxStakes() view returns(uint256)
+func (_Staking *StakingCallerSession) MaxStakes() (*big.Int, error) {
+	return _Staking.Contract.MaxStakes(&_Staking.CallOpts)
+}
+
+// MaxValidators is a free data retrieval call binding the contract method 0xc967f90f.
+//
+// Solidity: function MaxValidators() view returns(uint8)
+func (_Staking *StakingCaller) MaxValidators(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "MaxValidators")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// MaxValidators is a free data retrieval call binding the contract method 0xc967f90f.
+//
+// Solidity: function MaxValidators() view returns(uint8)
+func (_Staking *StakingSession) MaxValidators() (uint8, error) {
+	return _Staking.Contract.MaxValidators(&_Staking.CallOpts)
+}
+
+// MaxValidators is a free data retrieval call binding the contract method 0xc967f90f.
+//
+// Solidity: function MaxValidators() view returns(uint8)
+func (_Staking *StakingCallerSession) MaxValidators() (uint8, error) {
+	return _Staking.Contract.MaxValidators(&_Staking.CallOpts)
+}
+
+// MinSelfStakes is a free data retrieval call binding the contract method 0xb9373707.
+//
+// Solidity: function MinSelfStakes() view returns(uint256)
+func (_Staking *StakingCaller) MinSelfStakes(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "MinSelfStakes")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinSelfStakes is a free data retrieval call binding the contract method 0xb9373707.
+//
+// Solidity: function MinSelfStakes() view returns(uint256)
+func (_Staking *StakingSession) MinSelfStakes() (*big.Int, error) {
+	return _Staking.Contract.MinSelfStakes(&_Staking.CallOpts)
+}
+
+// MinSelfStakes is a free data retrieval call binding the contract method 0xb9373707.
+//
+// Solidity: function MinSelfStakes() view returns(uint256)
+func (_Staking *StakingCallerSession) MinSelfStakes() (*big.Int, error) {
+	return _Staking.Contract.MinSelfStakes(&_Staking.CallOpts)
+}
+
+// PunishBase is a free data retrieval call binding the contract method 0x1927207f.
+//
+// Solidity: function PunishBase() view returns(uint256)
+func (_Staking *StakingCaller) PunishBase(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "PunishBase")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PunishBase is a free data retrieval call binding the contract method 0x1927207f.
+//
+// Solidity: function PunishBase() view returns(uint256)
+func (_Staking *StakingSession) PunishBase() (*big.Int, error) {
+	return _Staking.Contract.PunishBase(&_Staking.CallOpts)
+}
+
+// PunishBase is a free data retrieval call binding the contract method 0x1927207f.
+//
+// Solidity: function PunishBase() view returns(uint256)
+func (_Staking *StakingCallerSession) PunishBase() (*big.Int, error) {
+	return _Staking.Contract.PunishBase(&_Staking.CallOpts)
+}
+
+// StakeUnit is a free data retrieval call binding the contract method 0xf28e5f61.
+//
+// Solidity: function StakeUnit() view returns(uint256)
+func (_Staking *StakingCaller) StakeUnit(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "StakeUnit")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// StakeUnit is a free data retrieval call binding the contract method 0xf28e5f61.
+//
+// Solidity: function StakeUnit() view returns(uint256)
+func (_Staking *StakingSession) StakeUnit() (*big.Int, error) {
+	return _Staking.Contract.StakeUnit(&_Staking.CallOpts)
+}
+
+// StakeUnit is a free data retrieval call binding the contract method 0xf28e5f61.
+//
+// Solidity: function StakeUnit() view returns(uint256)
+func (_Staking *StakingCallerSession) StakeUnit() (*big.Int, error) {
+	return _Staking.Contract.StakeUnit(&_Staking.CallOpts)
+}
+
+// ThresholdStakes is a free data retrieval call binding the contract method 0x820805af.
+//
+// Solidity: function ThresholdStakes() view returns(uint256)
+func (_Staking *StakingCaller) ThresholdStakes(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "ThresholdStakes")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ThresholdStakes is a free data retrieval call binding the contract method 0x820805af.
+//
+// Solidity: function ThresholdStakes() view returns(uint256)
+func (_Staking *StakingSession) ThresholdStakes() (*big.Int, error) {
+	return _Staking.Contract.ThresholdStakes(&_Staking.CallOpts)
+}
+
+// ThresholdStakes is a free data retrieval call binding the contract method 0x820805af.
+//
+// Solidity: function ThresholdStakes() view returns(uint256)
+func (_Staking *StakingCallerSession) ThresholdStakes() (*big.Int, error) {
+	return _Staking.Contract.ThresholdStakes(&_Staking.CallOpts)
+}
+
+// UnboundLockPeriod is a free data retrieval call binding the contract method 0x213a4894.
+//
+// Solidity: function UnboundLockPeriod() view returns(uint256)
+func (_Staking *StakingCaller) UnboundLockPeriod(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "UnboundLockPeriod")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UnboundLockPeriod is a free data retrieval call binding the contract method 0x213a4894.
+//
+// Solidity: function UnboundLockPeriod() view returns(uint256)
+func (_Staking *StakingSession) UnboundLockPeriod() (*big.Int, error) {
+	return _Staking.Contract.UnboundLockPeriod(&_Staking.CallOpts)
+}
+
+// UnboundLockPeriod is a free data retrieval call binding the contract method 0x213a4894.
+//
+// Solidity: function UnboundLockPeriod() view returns(uint256)
+func (_Staking *StakingCallerSession) UnboundLockPeriod() (*big.Int, error) {
+	return _Staking.Contract.UnboundLockPeriod(&_Staking.CallOpts)
+}
+
+// AccRewardsPerStake is a free data retrieval call binding the contract method 0x073d2eb4.
+//
+// Solidity: function accRewardsPerStake() view returns(uint256)
+func (_Staking *StakingCaller) AccRewardsPerStake(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "accRewardsPerStake")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AccRewardsPerStake is a free data retrieval call binding the contract method 0x073d2eb4.
+//
+// Solidity: function accRewardsPerStake() view returns(uint256)
+func (_Staking *StakingSession) AccRewardsPerStake() (*big.Int, error) {
+	return _Staking.Contract.AccRewardsPerStake(&_Staking.CallOpts)
+}
+
+// AccRewardsPerStake is a free data retrieval call binding the contract method 0x073d2eb4.
+//
+// Solidity: function accRewardsPerStake() view returns(uint256)
+func (_Staking *StakingCallerSession) AccRewardsPerStake() (*big.Int, error) {
+	return _Staking.Contract.AccRewardsPerStake(&_Staking.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_Staking *StakingCaller) Admin(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "admin")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_Staking *StakingSession) Admin() (common.Address, error) {
+	return _Staking.Contract.Admin(&_Staking.CallOpts)
+}
+
+// Admin is a free data retrieval call binding the contract method 0xf851a440.
+//
+// Solidity: function admin() view returns(address)
+func (_Staking *StakingCallerSession) Admin() (common.Address, error) {
+	return _Staking.Contract.Admin(&_Staking.CallOpts)
+}
+
+// AllValidatorAddrs is a free data retrieval call binding the contract method 0x820013c2.
+//
+// Solidity: function allValidatorAddrs(uint256 ) view returns(address)
+func (_Staking *StakingCaller) AllValidatorAddrs(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "allValidatorAddrs", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// AllValidatorAddrs is a free data retrieval call binding the contract method 0x820013c2.
+//
+// Solidity: function allValidatorAddrs(uint256 ) view returns(address)
+func (_Staking *StakingSession) AllValidatorAddrs(arg0 *big.Int) (common.Address, error) {
+	return _Staking.Contract.AllValidatorAddrs(&_Staking.CallOpts, arg0)
+}
+
+// AllValidatorAddrs is a free data retrieval call binding the contract method 0x820013c2.
+//
+// Solidity: function allValidatorAddrs(uint256 ) view returns(address)
+func (_Staking *StakingCallerSession) AllValidatorAddrs(arg0 *big.Int) (common.Address, error) {
+	return _Staking.Contract.AllValidatorAddrs(&_Staking.CallOpts, arg0)
+}
+
+// AnyClaimable is a free data retrieval call binding the contract method 0x4d0859cc.
+//
+// Solidity: function anyClaimable(address _val, address _stakeOwner) view returns(uint256)
+func (_Staking *StakingCaller) AnyClaimable(opts *bind.CallOpts, _val common.Address, _stakeOwner common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "anyClaimable", _val, _stakeOwner)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// AnyClaimable is a free data retrieval call binding the contract method 0x4d0859cc.
+//
+// Solidity: function anyClaimable(address _val, address _stakeOwner) view returns(uint256)
+func (_Staking *StakingSession) AnyClaimable(_val common.Address, _stakeOwner common.Address) (*big.Int, error) {
+	return _Staking.Contract.AnyClaimable(&_Staking.CallOpts, _val, _stakeOwner)
+}
+
+// AnyClaimable is a free data retrieval call binding the contract method 0x4d0859cc.
+//
+// Solidity: function anyClaimable(address _val, address _stakeOwner) view returns(uint256)
+func (_Staking *StakingCallerSession) AnyClaimable(_val common.Address, _stakeOwner common.Address) (*big.Int, error) {
+	return _Staking.Contract.AnyClaimable(&_Staking.CallOpts, _val, _stakeOwner)
+}
+
+// BasicLockEnd is a free data retrieval call binding the contract method 0xf3dc9283.
+//
+// Solidity: function basicLockEnd() view returns(uint256)
+func (_Staking *StakingCaller) BasicLockEnd(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "basicLockEnd")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BasicLockEnd is a free data retrieval call binding the contract method 0xf3dc9283.
+//
+// Solidity: function basicLockEnd() view returns(uint256)
+func (_Staking *StakingSession) BasicLockEnd() (*big.Int, error) {
+	return _Staking.Contract.BasicLockEnd(&_Staking.CallOpts)
+}
+
+// BasicLockEnd is a free data retrieval call binding the contract method 0xf3dc9283.
+//
+// Solidity: function basicLockEnd() view returns(uint256)
+func (_Staking *StakingCallerSession) BasicLockEnd() (*big.Int, error) {
+	return _Staking.Contract.BasicLockEnd(&_Staking.CallOpts)
+}
+
+// BlockEpoch is a free data retrieval call binding the contract method 0xf08b9284.
+//
+// Solidity: function blockEpoch() view returns(uint256)
+func (_Staking *StakingCaller) BlockEpoch(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "blockEpoch")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BlockEpoch is a free data retrieval call binding the contract method 0xf08b9284.
+//
+// Solidity: function blockEpoch() view returns(uint256)
+func (_Staking *StakingSession) BlockEpoch() (*big.Int, error) {
+	return _Staking.Contract.BlockEpoch(&_Staking.CallOpts)
+}
+
+// BlockEpoch is a free data retrieval call binding the contract method 0xf08b9284.
+//
+// Solidity: function blockEpoch() view returns(uint256)
+func (_Staking *StakingCallerSession) BlockEpoch() (*big.Int, error) {
+	return _Staking.Contract.BlockEpoch(&_Staking.CallOpts)
+}
+
+// ClaimableRewards is a free data retrieval call binding the contract method 0x6be9dcce.
+//
+// Solidity: function claimableRewards(address _val, address _stakeOwner) view returns(uint256)
+func (_Staking *StakingCaller) ClaimableRewards(opts *bind.CallOpts, _val common.Address, _stakeOwner common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "claimableRewards", _val, _stakeOwner)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ClaimableRewards is a free data retrieval call binding the contract method 0x6be9dcce.
+//
+// Solidity: function claimableRewards(address _val, address _stakeOwner) view returns(uint256)
+func (_Staking *StakingSession) ClaimableRewards(_val common.Address, _stakeOwner common.Address) (*big.Int, error) {
+	return _Staking.Contract.ClaimableRewards(&_Staking.CallOpts, _val, _stakeOwner)
+}
+
+// ClaimableRewards is a free data retrieval call binding the contract method 0x6be9dcce.
+//
+// Solidity: function claimableRewards(address _val, address _stakeOwner) view returns(uint256)
+func (_Staking *StakingCallerSession) ClaimableRewards(_val common.Address, _stakeOwner common.Address) (*big.Int, error) {
+	return _Staking.Contract.ClaimableRewards(&_Staking.CallOpts, _val, _stakeOwner)
+}
+
+// DoubleSignPunished is a free data retrieval call binding the contract method 0xcfe9f8ec.
+//
+// Solidity: function doubleSignPunished(bytes32 ) view returns(bool)
+func (_Staking *StakingCaller) DoubleSignPunished(opts *bind.CallOpts, arg0 [32]byte) (bool, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "doubleSignPunished", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// DoubleSignPunished is a free data retrieval call binding the contract method 0xcfe9f8ec.
+//
+// Solidity: function doubleSignPunished(bytes32 ) view returns(bool)
+func (_Staking *StakingSession) DoubleSignPunished(arg0 [32]byte) (bool, error) {
+	return _Staking.Contract.DoubleSignPunished(&_Staking.CallOpts, arg0)
+}
+
+// DoubleSignPunished is a free data retrieval call binding the contract method 0xcfe9f8ec.
+//
+// Solidity: function doubleSignPunished(bytes32 ) view returns(bool)
+func (_Staking *StakingCallerSession) DoubleSignPunished(arg0 [32]byte) (bool, error) {
+	return _Staking.Contract.DoubleSignPunished(&_Staking.CallOpts, arg0)
+}
+
+// Founders is a free data retrieval call binding the contract method 0xde6746a5.
+//
+// Solidity: function founders(address ) view returns(uint256 initialStake, uint256 unboundStake, bool locking)
+func (_Staking *StakingCaller) Founders(opts *bind.CallOpts, arg0 common.Address) (struct {
+	InitialStake *big.Int
+	UnboundStake *big.Int
+	Locking      bool
+}, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "founders", arg0)
+
+	outstruct := new(struct {
+		InitialStake *big.Int
+		UnboundStake *big.Int
+		Locking      bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.InitialStake = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.UnboundStake = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Locking = *abi.ConvertType(out[2], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// Founders is a free data retrieval call binding the contract method 0xde6746a5.
+//
+// Solidity: function founders(address ) view returns(uint256 initialStake, uint256 unboundStake, bool locking)
+func (_Staking *StakingSession) Founders(arg0 common.Address) (struct {
+	InitialStake *big.Int
+	UnboundStake *big.Int
+	Locking      bool
+}, error) {
+	return _Staking.Contract.Founders(&_Staking.CallOpts, arg0)
+}
+
+// Founders is a free data retrieval call binding the contract method 0xde6746a5.
+//
+// Solidity: function founders(address ) view returns(uint256 initialStake, uint256 unboundStake, bool locking)
+func (_Staking *StakingCallerSession) Founders(arg0 common.Address) (struct {
+	InitialStake *big.Int
+	UnboundStake *big.Int
+	Locking      bool
+}, error) {
+	return _Staking.Contract.Founders(&_Staking.CallOpts, arg0)
+}
+
+// GetActiveValidators is a free data retrieval call binding the contract method 0x9de70258.
+//
+// Solidity: function getActiveValidators() view returns(address[])
+func (_Staking *StakingCaller) GetActiveValidators(opts *bind.CallOpts) ([]common.Address, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "getActiveValidators")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetActiveValidators is a free data retrieval call binding the contract method 0x9de70258.
+//
+// Solidity: function getActiveValidators() view returns(address[])
+func (_Staking *StakingSession) GetActiveValidators() ([]common.Address, error) {
+	return _Staking.Contract.GetActiveValidators(&_Staking.CallOpts)
+}
+
+// GetActiveValidators is a free data retrieval call binding the contract method 0x9de70258.
+//
+// Solidity: function getActiveValidators() view returns(address[])
+func (_Staking *StakingCallerSession) GetActiveValidators() ([]common.Address, error) {
+	return _Staking.Contract.GetActiveValidators(&_Staking.CallOpts)
+}
+
+// GetAllValidatorsLength is a free data retrieval call binding the contract method 0x9cc02c30.
+//
+// Solidity: function getAllValidatorsLength() view returns(uint256)
+func (_Staking *StakingCaller) GetAllValidatorsLength(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "getAllValidatorsLength")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetAllValidatorsLength is a free data retrieval call binding the contract method 0x9cc02c30.
+//
+// Solidity: function getAllValidatorsLength() view returns(uint256)
+func (_Staking *StakingSession) GetAllValidatorsLength() (*big.Int, error) {
+	return _Staking.Contract.GetAllValidatorsLength(&_Staking.CallOpts)
+}
+
+// GetAllValidatorsLength is a free data retrieval call binding the contract method 0x9cc02c30.
+//
+// Solidity: function getAllValidatorsLength() view returns(uint256)
+func (_Staking *StakingCallerSession) GetAllValidatorsLength() (*big.Int, error) {
+	return _Staking.Contract.GetAllValidatorsLength(&_Staking.CallOpts)
+}
+
+// GetPunishRecord is a free data retrieval call binding the contract method 0x32f3c17f.
+//
+// Solidity: function getPunishRecord(address _val) view returns(uint256)
+func (_Staking *StakingCaller) GetPunishRecord(opts *bind.CallOpts, _val common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "getPunishRecord", _val)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetPunishRecord is a free data retrieval call binding the contract method 0x32f3c17f.
+//
+// Solidity: function getPunishRecord(address _val) view returns(uint256)
+func (_Staking *StakingSession) GetPunishRecord(_val common.Address) (*big.Int, error) {
+	return _Staking.Contract.GetPunishRecord(&_Staking.CallOpts, _val)
+}
+
+// GetPunishRecord is a free data retrieval call binding the contract method 0x32f3c17f.
+//
+// Solidity: function getPunishRecord(address _val) view returns(uint256)
+func (_Staking *StakingCallerSession) GetPunishRecord(_val common.Address) (*big.Int, error) {
+	return _Staking.Contract.GetPunishRecord(&_Staking.CallOpts, _val)
+}
+
+// GetPunishValidatorsLen is a free data retrieval call binding the contract method 0xe0d8ea53.
+//
+// Solidity: function getPunishValidatorsLen() view returns(uint256)
+func (_Staking *StakingCaller) GetPunishValidatorsLen(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "getPunishValidatorsLen")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetPunishValidatorsLen is a free data retrieval call binding the contract method 0xe0d8ea53.
+//
+// Solidity: function getPunishValidatorsLen() view returns(uint256)
+func (_Staking *StakingSession) GetPunishValidatorsLen() (*big.Int, error) {
+	return _Staking.Contract.GetPunishValidatorsLen(&_Staking.CallOpts)
+}
+
+// GetPunishValidatorsLen is a free data retrieval call binding the contract method 0xe0d8ea53.
+//
+// Solidity: function getPunishValidatorsLen() view returns(uint256)
+func (_Staking *StakingCallerSession) GetPunishValidatorsLen() (*big.Int, error) {
+	return _Staking.Contract.GetPunishValidatorsLen(&_Staking.CallOpts)
+}
+
+// GetTopValidators is a free data retrieval call binding the contract method 0xc086559e.
+//
+// Solidity: function getTopValidators(uint8 _count) view returns(address[])
+func (_Staking *StakingCaller) GetTopValidators(opts *bind.CallOpts, _count uint8) ([]common.Address, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "getTopValidators", _count)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetTopValidators is a free data retrieval call binding the contract method 0xc086559e.
+//
+// Solidity: function getTopValidators(uint8 _count) view returns(address[])
+func (_Staking *StakingSession) GetTopValidators(_count uint8) ([]common.Address, error) {
+	return _Staking.Contract.GetTopValidators(&_Staking.CallOpts, _count)
+}
+
+// GetTopValidators is a free data retrieval call binding the contract method 0xc086559e.
+//
+// Solidity: function getTopValidators(uint8 _count) view returns(address[])
+func (_Staking *StakingCallerSession) GetTopValidators(_count uint8) ([]common.Address, error) {
+	return _Staking.Contract.GetTopValidators(&_Staking.CallOpts, _count)
+}
+
+// Initialized is a free data retrieval call binding the contract method 0x158ef93e.
+//
+// Solidity: function initialized() view returns(bool)
+func (_Staking *StakingCaller) Initialized(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "initialized")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Initialized is a free data retrieval call binding the contract method 0x158ef93e.
+//
+// Solidity: function initialized() view returns(bool)
+func (_Staking *StakingSession) Initialized() (bool, error) {
+	return _Staking.Contract.Initialized(&_Staking.CallOpts)
+}
+
+// Initialized is a free data retrieval call binding the contract method 0x158ef93e.
+//
+// Solidity: function initialized() view returns(bool)
+func (_Staking *StakingCallerSession) Initialized() (bool, error) {
+	return _Staking.Contract.Initialized(&_Staking.CallOpts)
+}
+
+// IsDoubleSignPunished is a free data retrieval call binding the contract method 0x4b0b32c5.
+//
+// Solidity: function isDoubleSignPunished(bytes32 punishHash) view returns(bool)
+func (_Staking *StakingCaller) IsDoubleSignPunished(opts *bind.CallOpts, punishHash [32]byte) (bool, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "isDoubleSignPunished", punishHash)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsDoubleSignPunished is a free data retrieval call binding the contract method 0x4b0b32c5.
+//
+// Solidity: function isDoubleSignPunished(bytes32 punishHash) view returns(bool)
+func (_Staking *StakingSession) IsDoubleSignPunished(punishHash [32]byte) (bool, error) {
+	return _Staking.Contract.IsDoubleSignPunished(&_Staking.CallOpts, punishHash)
+}
+
+// IsDoubleSignPunished is a free data retrieval call binding the contract method 0x4b0b32c5.
+//
+// Solidity: function isDoubleSignPunished(bytes32 punishHash) view returns(bool)
+func (_Staking *StakingCallerSession) IsDoubleSignPunished(punishHash [32]byte) (bool, error) {
+	return _Staking.Contract.IsDoubleSignPunished(&_Staking.CallOpts, punishHash)
+}
+
+// IsOpened is a free data retrieval call binding the contract method 0x692aa97e.
+//
+// Solidity: function isOpened() view returns(bool)
+func (_Staking *StakingCaller) IsOpened(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "isOpened")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsOpened is a free data retrieval call binding the contract method 0x692aa97e.
+//
+// Solidity: function isOpened() view returns(bool)
+func (_Staking *StakingSession) IsOpened() (bool, error) {
+	return _Staking.Contract.IsOpened(&_Staking.CallOpts)
+}
+
+// IsOpened is a free data retrieval call binding the contract method 0x692aa97e.
+//
+// Solidity: function isOpened() view returns(bool)
+func (_Staking *StakingCallerSession) IsOpened() (bool, error) {
+	return _Staking.Contract.IsOpened(&_Staking.CallOpts)
+}
+
+// IsReleaseLockEnd is a free data retrieval call binding the contract method 0x58f91943.
+//
+// Solidity: function isReleaseLockEnd() view returns(bool)
+func (_Staking *StakingCaller) IsReleaseLockEnd(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "isReleaseLockEnd")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsReleaseLockEnd is a free data retrieval call binding the contract method 0x58f91943.
+//
+// Solidity: function isReleaseLockEnd() view returns(bool)
+func (_Staking *StakingSession) IsReleaseLockEnd() (bool, error) {
+	return _Staking.Contract.IsReleaseLockEnd(&_Staking.CallOpts)
+}
+
+// IsReleaseLockEnd is a free data retrieval call binding the contract method 0x58f91943.
+//
+// Solidity: function isReleaseLockEnd() view returns(bool)
+func (_Staking *StakingCallerSession) IsReleaseLockEnd() (bool, error) {
+	return _Staking.Contract.IsReleaseLockEnd(&_Staking.CallOpts)
+}
+
+// LastUpdateAccBlock is a free data retrieval call binding the contract method 0x4f586573.
+//
+// Solidity: function lastUpdateAccBlock() view returns(uint256)
+func (_Staking *StakingCaller) LastUpdateAccBlock(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "lastUpdateAccBlock")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// LastUpdateAccBlock is a free data retrieval call binding the contract method 0x4f586573.
+//
+// Solidity: function lastUpdateAccBlock() view returns(uint256)
+func (_Staking *StakingSession) LastUpdateAccBlock() (*big.Int, error) {
+	return _Staking.Contract.LastUpdateAccBlock(&_Staking.CallOpts)
+}
+
+// LastUpdateAccBlock is a free data retrieval call binding the contract method 0x4f586573.
+//
+// Solidity: function lastUpdateAccBlock() view returns(uint256)
+func (_Staking *StakingCallerSession) LastUpdateAccBlock() (*big.Int, error) {
+	return _Staking.Contract.LastUpdateAccBlock(&_Staking.CallOpts)
+}
+
+// LazyPunishedValidators is a free data retrieval call binding the contract method 0xaa5c9afd.
+//
+// Solidity: function lazyPunishedValidators(uint256 ) view returns(address)
+func (_Staking *StakingCaller) LazyPunishedValidators(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "lazyPunishedValidators", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// LazyPunishedValidators is a free data retrieval call binding the contract method 0xaa5c9afd.
+//
+// Solidity: function lazyPunishedValidators(uint256 ) view returns(address)
+func (_Staking *StakingSession) LazyPunishedValidators(arg0 *big.Int) (common.Address, error) {
+	return _Staking.Contract.LazyPunishedValidators(&_Staking.CallOpts, arg0)
+}
+
+// LazyPunishedValidators is a free data retrieval call binding the contract method 0xaa5c9afd.
+//
+// Solidity: function lazyPunishedValidators(uint256 ) view returns(address)
+func (_Staking *StakingCallerSession) LazyPunishedValidators(arg0 *big.Int) (common.Address, error) {
+	return _Staking.Contract.LazyPunishedValidators(&_Staking.CallOpts, arg0)
+}
+
+// PendingAdmin is a free data retrieval call binding the contract method 0x26782247.
+//
+// Solidity: function pendingAdmin() view returns(address)
+func (_Staking *StakingCaller) PendingAdmin(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "pendingAdmin")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// PendingAdmin is a free data retrieval call binding the contract method 0x26782247.
+//
+// Solidity: function pendingAdmin() view returns(address)
+func (_Staking *StakingSession) PendingAdmin() (common.Address, error) {
+	return _Staking.Contract.PendingAdmin(&_Staking.CallOpts)
+}
+
+// PendingAdmin is a free data retrieval call binding the contract method 0x26782247.
+//
+// Solidity: function pendingAdmin() view returns(address)
+func (_Staking *StakingCallerSession) PendingAdmin() (common.Address, error) {
+	return _Staking.Contract.PendingAdmin(&_Staking.CallOpts)
+}
+
+// ReleaseCount is a free data retrieval call binding the contract method 0xb8d08db2.
+//
+// Solidity: function releaseCount() view returns(uint256)
+func (_Staking *StakingCaller) ReleaseCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "releaseCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ReleaseCount is a free data retrieval call binding the contract method 0xb8d08db2.
+//
+// Solidity: function releaseCount() view returns(uint256)
+func (_Staking *StakingSession) ReleaseCount() (*big.Int, error) {
+	return _Staking.Contract.ReleaseCount(&_Staking.CallOpts)
+}
+
+// ReleaseCount is a free data retrieval call binding the contract method 0xb8d08db2.
+//
+// Solidity: function releaseCount() view returns(uint256)
+func (_Staking *StakingCallerSession) ReleaseCount() (*big.Int, error) {
+	return _Staking.Contract.ReleaseCount(&_Staking.CallOpts)
+}
+
+// ReleasePeriod is a free data retrieval call binding the contract method 0x63ef1627.
+//
+// Solidity: function releasePeriod() view returns(uint256)
+func (_Staking *StakingCaller) ReleasePeriod(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "releasePeriod")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ReleasePeriod is a free data retrieval call binding the contract method 0x63ef1627.
+//
+// Solidity: function releasePeriod() view returns(uint256)
+func (_Staking *StakingSession) ReleasePeriod() (*big.Int, error) {
+	return _Staking.Contract.ReleasePeriod(&_Staking.CallOpts)
+}
+
+// ReleasePeriod is a free data retrieval call binding the contract method 0x63ef1627.
+//
+// Solidity: function releasePeriod() view returns(uint256)
+func (_Staking *StakingCallerSession) ReleasePeriod() (*big.Int, error) {
+	return _Staking.Contract.ReleasePeriod(&_Staking.CallOpts)
+}
+
+// RewardsPerBlock is a free data retrieval call binding the contract method 0x5eeb6710.
+//
+// Solidity: function rewardsPerBlock() view returns(uint256)
+func (_Staking *StakingCaller) RewardsPerBlock(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "rewardsPerBlock")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// RewardsPerBlock is a free data retrieval call binding the contract method 0x5eeb6710.
+//
+// Solidity: function rewardsPerBlock() view returns(uint256)
+func (_Staking *StakingSession) RewardsPerBlock() (*big.Int, error) {
+	return _Staking.Contract.RewardsPerBlock(&_Staking.CallOpts)
+}
+
+// RewardsPerBlock is a free data retrieval call binding the contract method 0x5eeb6710.
+//
+// Solidity: function rewardsPerBlock() view returns(uint256)
+func (_Staking *StakingCallerSession) RewardsPerBlock() (*big.Int, error) {
+	return _Staking.Contract.RewardsPerBlock(&_Staking.CallOpts)
+}
+
+// TotalStake is a free data retrieval call binding the contract method 0x8b0e9f3f.
+//
+// Solidity: function totalStake() view returns(uint256)
+func (_Staking *StakingCaller) TotalStake(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "totalStake")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalStake is a free data retrieval call binding the contract method 0x8b0e9f3f.
+//
+// Solidity: function totalStake() view returns(uint256)
+func (_Staking *StakingSession) TotalStake() (*big.Int, error) {
+	return _Staking.Contract.TotalStake(&_Staking.CallOpts)
+}
+
+// TotalStake is a free data retrieval call binding the contract method 0x8b0e9f3f.
+//
+// Solidity: function totalStake() view returns(uint256)
+func (_Staking *StakingCallerSession) TotalStake() (*big.Int, error) {
+	return _Staking.Contract.TotalStake(&_Staking.CallOpts)
+}
+
+// TotalStakingRewards is a free data retrieval call binding the contract method 0x93ef357a.
+//
+// Solidity: function totalStakingRewards() view returns(uint256)
+func (_Staking *StakingCaller) TotalStakingRewards(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "totalStakingRewards")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalStakingRewards is a free data retrieval call binding the contract method 0x93ef357a.
+//
+// Solidity: function totalStakingRewards() view returns(uint256)
+func (_Staking *StakingSession) TotalStakingRewards() (*big.Int, error) {
+	return _Staking.Contract.TotalStakingRewards(&_Staking.CallOpts)
+}
+
+// TotalStakingRewards is a free data retrieval call binding the contract method 0x93ef357a.
+//
+// Solidity: function totalStakingRewards() view returns(uint256)
+func (_Staking *StakingCallerSession) TotalStakingRewards() (*big.Int, error) {
+	return _Staking.Contract.TotalStakingRewards(&_Staking.CallOpts)
+}
+
+// ValInfos is a free data retrieval call binding the contract method 0x2c51c34a.
+//
+// Solidity: function valInfos(address ) view returns(uint256 stake, uint256 debt, uint256 incomeFees, uint256 unWithdrawn)
+func (_Staking *StakingCaller) ValInfos(opts *bind.CallOpts, arg0 common.Address) (struct {
+	Stake       *big.Int
+	Debt        *big.Int
+	IncomeFees  *big.Int
+	UnWithdrawn *big.Int
+}, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "valInfos", arg0)
+
+	outstruct := new(struct {
+		Stake       *big.Int
+		Debt        *big.Int
+		IncomeFees  *big.Int
+		UnWithdrawn *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Stake = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.Debt = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.IncomeFees = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.UnWithdrawn = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// ValInfos is a free data retrieval call binding the contract method 0x2c51c34a.
+//
+// Solidity: function valInfos(address ) view returns(uint256 stake, uint256 debt, uint256 incomeFees, uint256 unWithdrawn)
+func (_Staking *StakingSession) ValInfos(arg0 common.Address) (struct {
+	Stake       *big.Int
+	Debt        *big.Int
+	IncomeFees  *big.Int
+	UnWithdrawn *big.Int
+}, error) {
+	return _Staking.Contract.ValInfos(&_Staking.CallOpts, arg0)
+}
+
+// ValInfos is a free data retrieval call binding the contract method 0x2c51c34a.
+//
+// Solidity: function valInfos(address ) view returns(uint256 stake, uint256 debt, uint256 incomeFees, uint256 unWithdrawn)
+func (_Staking *StakingCallerSession) ValInfos(arg0 common.Address) (struct {
+	Stake       *big.Int
+	Debt        *big.Int
+	IncomeFees  *big.Int
+	UnWithdrawn *big.Int
+}, error) {
+	return _Staking.Contract.ValInfos(&_Staking.CallOpts, arg0)
+}
+
+// ValMaps is a free data retrieval call binding the contract method 0x0cd2c6fa.
+//
+// Solidity: function valMaps(address ) view returns(address)
+func (_Staking *StakingCaller) ValMaps(opts *bind.CallOpts, arg0 common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _Staking.contract.Call(opts, &out, "valMaps", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// ValMaps is a free data retrieval call binding the contract method 0x0cd2c6fa.
+//
+// Solidity: function valMaps(address ) view returns(address)
+func (_Staking *StakingSession) ValMaps(arg0 common.Address) (common.Address, error) {
+	return _Staking.Contract.ValMaps(&_Staking.CallOpts, arg0)
+}
+
+// ValMaps is a free data retrieval call binding the contract method 0x0cd2c6fa.
+//
+// Solidity: function valMaps(address ) view returns(address)
+func (_Staking *StakingCallerSession) ValMaps(arg0 common.Address) (common.Address, error) {
+	return _Staking.Contract.ValMaps(&_Staking.CallOpts, arg0)
+}
+
+// StakingMulticaller is an auto generated Go binding aggregating view calls of
+// an Ethereum contract into a single round trip.
+type StakingMulticaller struct {
+	multicall *bind.Multicall // Generic multicall wrapper queueing the calls
+}
+
+// NewStakingMulticaller creates a new multicaller instance of Staking, bound to a specific deployed contract.
+func NewStakingMulticaller(address common.Address, caller bind.BatchCaller) (*StakingMulticaller, error) {
+	parsed, err := StakingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &StakingMulticaller{multicall: bind.NewMulticall(address, *parsed, caller)}, nil
+}
+
+// Execute performs the queued calls in a single round trip, filling in the
+// results returned when queueing them.
+func (_Staking *StakingMulticaller) Execute(opts *bind.CallOpts) error {
+	return _Staking.multicall.Execute(opts)
+}
+
+// DecreaseRate queues a free data retrieval call binding the contract method 0xd2957b2f.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function DecreaseRate() view returns(uint256)
+func (_Staking *StakingMulticaller) DecreaseRate() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("DecreaseRate", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EvilPunishFactor queues a free data retrieval call binding the contract method 0x98c2199d.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function EvilPunishFactor() view returns(uint256)
+func (_Staking *StakingMulticaller) EvilPunishFactor() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("EvilPunishFactor", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// JailPeriod queues a free data retrieval call binding the contract method 0x15de360e.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function JailPeriod() view returns(uint256)
+func (_Staking *StakingMulticaller) JailPeriod() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("JailPeriod", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LazyPunishFactor queues a free data retrieval call binding the contract method 0x5a651019.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function LazyPunishFactor() view returns(uint256)
+func (_Staking *StakingMulticaller) LazyPunishFactor() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("LazyPunishFactor", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LazyPunishThreshold queues a free data retrieval call binding the contract method 0x02345565.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function LazyPunishThreshold() view returns(uint256)
+func (_Staking *StakingMulticaller) LazyPunishThreshold() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("LazyPunishThreshold", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MaxStakes queues a free data retrieval call binding the contract method 0x7977f981.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function MaxStakes() view returns(uint256)
+func (_Staking *StakingMulticaller) MaxStakes() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("MaxStakes", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MaxValidators queues a free data retrieval call binding the contract method 0xc967f90f.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function MaxValidators() view returns(uint8)
+func (_Staking *StakingMulticaller) MaxValidators() (*uint8, error) {
+	result := new(uint8)
+	err := _Staking.multicall.Queue("MaxValidators", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(uint8)).(*uint8)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MinSelfStakes queues a free data retrieval call binding the contract method 0xb9373707.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function MinSelfStakes() view returns(uint256)
+func (_Staking *StakingMulticaller) MinSelfStakes() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("MinSelfStakes", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PunishBase queues a free data retrieval call binding the contract method 0x1927207f.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function PunishBase() view returns(uint256)
+func (_Staking *StakingMulticaller) PunishBase() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("PunishBase", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StakeUnit queues a free data retrieval call binding the contract method 0xf28e5f61.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function StakeUnit() view returns(uint256)
+func (_Staking *StakingMulticaller) StakeUnit() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("StakeUnit", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ThresholdStakes queues a free data retrieval call binding the contract method 0x820805af.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function ThresholdStakes() view returns(uint256)
+func (_Staking *StakingMulticaller) ThresholdStakes() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("ThresholdStakes", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnboundLockPeriod queues a free data retrieval call binding the contract method 0x213a4894.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function UnboundLockPeriod() view returns(uint256)
+func (_Staking *StakingMulticaller) UnboundLockPeriod() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("UnboundLockPeriod", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AccRewardsPerStake queues a free data retrieval call binding the contract method 0x073d2eb4.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function accRewardsPerStake() view returns(uint256)
+func (_Staking *StakingMulticaller) AccRewardsPerStake() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("accRewardsPerStake", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Admin queues a free data retrieval call binding the contract method 0xf851a440.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function admin() view returns(address)
+func (_Staking *StakingMulticaller) Admin() (*common.Address, error) {
+	result := new(common.Address)
+	err := _Staking.multicall.Queue("admin", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AllValidatorAddrs queues a free data retrieval call binding the contract method 0x820013c2.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function allValidatorAddrs(uint256 ) view returns(address)
+func (_Staking *StakingMulticaller) AllValidatorAddrs(arg0 *big.Int) (*common.Address, error) {
+	result := new(common.Address)
+	err := _Staking.multicall.Queue("allValidatorAddrs", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	}, arg0)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AnyClaimable queues a free data retrieval call binding the contract method 0x4d0859cc.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function anyClaimable(address _val, address _stakeOwner) view returns(uint256)
+func (_Staking *StakingMulticaller) AnyClaimable(_val common.Address, _stakeOwner common.Address) (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("anyClaimable", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	}, _val, _stakeOwner)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BasicLockEnd queues a free data retrieval call binding the contract method 0xf3dc9283.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function basicLockEnd() view returns(uint256)
+func (_Staking *StakingMulticaller) BasicLockEnd() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("basicLockEnd", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BlockEpoch queues a free data retrieval call binding the contract method 0xf08b9284.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function blockEpoch() view returns(uint256)
+func (_Staking *StakingMulticaller) BlockEpoch() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("blockEpoch", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ClaimableRewards queues a free data retrieval call binding the contract method 0x6be9dcce.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function claimableRewards(address _val, address _stakeOwner) view returns(uint256)
+func (_Staking *StakingMulticaller) ClaimableRewards(_val common.Address, _stakeOwner common.Address) (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("claimableRewards", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	}, _val, _stakeOwner)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DoubleSignPunished queues a free data retrieval call binding the contract method 0xcfe9f8ec.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function doubleSignPunished(bytes32 ) view returns(bool)
+func (_Staking *StakingMulticaller) DoubleSignPunished(arg0 [32]byte) (*bool, error) {
+	result := new(bool)
+	err := _Staking.multicall.Queue("doubleSignPunished", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(bool)).(*bool)
+	}, arg0)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Founders queues a free data retrieval call binding the contract method 0xde6746a5.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function founders(address ) view returns(uint256 initialStake, uint256 unboundStake, bool locking)
+func (_Staking *StakingMulticaller) Founders(arg0 common.Address) (*struct {
+	InitialStake *big.Int
+	UnboundStake *big.Int
+	Locking      bool
+}, error) {
+	result := new(struct {
+		InitialStake *big.Int
+		UnboundStake *big.Int
+		Locking      bool
+	})
+	err := _Staking.multicall.Queue("founders", func(out []interface{}) {
+		result.InitialStake = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+		result.UnboundStake = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+		result.Locking = *abi.ConvertType(out[2], new(bool)).(*bool)
+	}, arg0)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetActiveValidators queues a free data retrieval call binding the contract method 0x9de70258.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function getActiveValidators() view returns(address[])
+func (_Staking *StakingMulticaller) GetActiveValidators() (*[]common.Address, error) {
+	result := new([]common.Address)
+	err := _Staking.multicall.Queue("getActiveValidators", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAllValidatorsLength queues a free data retrieval call binding the contract method 0x9cc02c30.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function getAllValidatorsLength() view returns(uint256)
+func (_Staking *StakingMulticaller) GetAllValidatorsLength() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("getAllValidatorsLength", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetPunishRecord queues a free data retrieval call binding the contract method 0x32f3c17f.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function getPunishRecord(address _val) view returns(uint256)
+func (_Staking *StakingMulticaller) GetPunishRecord(_val common.Address) (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("getPunishRecord", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	}, _val)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetPunishValidatorsLen queues a free data retrieval call binding the contract method 0xe0d8ea53.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function getPunishValidatorsLen() view returns(uint256)
+func (_Staking *StakingMulticaller) GetPunishValidatorsLen() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("getPunishValidatorsLen", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTopValidators queues a free data retrieval call binding the contract method 0xc086559e.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function getTopValidators(uint8 _count) view returns(address[])
+func (_Staking *StakingMulticaller) GetTopValidators(_count uint8) (*[]common.Address, error) {
+	result := new([]common.Address)
+	err := _Staking.multicall.Queue("getTopValidators", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+	}, _count)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Initialized queues a free data retrieval call binding the contract method 0x158ef93e.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function initialized() view returns(bool)
+func (_Staking *StakingMulticaller) Initialized() (*bool, error) {
+	result := new(bool)
+	err := _Staking.multicall.Queue("initialized", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(bool)).(*bool)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// IsDoubleSignPunished queues a free data retrieval call binding the contract method 0x4b0b32c5.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function isDoubleSignPunished(bytes32 punishHash) view returns(bool)
+func (_Staking *StakingMulticaller) IsDoubleSignPunished(punishHash [32]byte) (*bool, error) {
+	result := new(bool)
+	err := _Staking.multicall.Queue("isDoubleSignPunished", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(bool)).(*bool)
+	}, punishHash)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// IsOpened queues a free data retrieval call binding the contract method 0x692aa97e.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function isOpened() view returns(bool)
+func (_Staking *StakingMulticaller) IsOpened() (*bool, error) {
+	result := new(bool)
+	err := _Staking.multicall.Queue("isOpened", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(bool)).(*bool)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// IsReleaseLockEnd queues a free data retrieval call binding the contract method 0x58f91943.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function isReleaseLockEnd() view returns(bool)
+func (_Staking *StakingMulticaller) IsReleaseLockEnd() (*bool, error) {
+	result := new(bool)
+	err := _Staking.multicall.Queue("isReleaseLockEnd", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(bool)).(*bool)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LastUpdateAccBlock queues a free data retrieval call binding the contract method 0x4f586573.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function lastUpdateAccBlock() view returns(uint256)
+func (_Staking *StakingMulticaller) LastUpdateAccBlock() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("lastUpdateAccBlock", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LazyPunishedValidators queues a free data retrieval call binding the contract method 0xaa5c9afd.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function lazyPunishedValidators(uint256 ) view returns(address)
+func (_Staking *StakingMulticaller) LazyPunishedValidators(arg0 *big.Int) (*common.Address, error) {
+	result := new(common.Address)
+	err := _Staking.multicall.Queue("lazyPunishedValidators", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	}, arg0)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PendingAdmin queues a free data retrieval call binding the contract method 0x26782247.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function pendingAdmin() view returns(address)
+func (_Staking *StakingMulticaller) PendingAdmin() (*common.Address, error) {
+	result := new(common.Address)
+	err := _Staking.multicall.Queue("pendingAdmin", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReleaseCount queues a free data retrieval call binding the contract method 0xb8d08db2.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function releaseCount() view returns(uint256)
+func (_Staking *StakingMulticaller) ReleaseCount() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("releaseCount", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReleasePeriod queues a free data retrieval call binding the contract method 0x63ef1627.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function releasePeriod() view returns(uint256)
+func (_Staking *StakingMulticaller) ReleasePeriod() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("releasePeriod", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RewardsPerBlock queues a free data retrieval call binding the contract method 0x5eeb6710.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function rewardsPerBlock() view returns(uint256)
+func (_Staking *StakingMulticaller) RewardsPerBlock() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("rewardsPerBlock", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TotalStake queues a free data retrieval call binding the contract method 0x8b0e9f3f.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function totalStake() view returns(uint256)
+func (_Staking *StakingMulticaller) TotalStake() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("totalStake", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TotalStakingRewards queues a free data retrieval call binding the contract method 0x93ef357a.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function totalStakingRewards() view returns(uint256)
+func (_Staking *StakingMulticaller) TotalStakingRewards() (**big.Int, error) {
+	result := new(*big.Int)
+	err := _Staking.multicall.Queue("totalStakingRewards", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ValInfos queues a free data retrieval call binding the contract method 0x2c51c34a.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function valInfos(address ) view returns(uint256 stake, uint256 debt, uint256 incomeFees, uint256 unWithdrawn)
+func (_Staking *StakingMulticaller) ValInfos(arg0 common.Address) (*struct {
+	Stake       *big.Int
+	Debt        *big.Int
+	IncomeFees  *big.Int
+	UnWithdrawn *big.Int
+}, error) {
+	result := new(struct {
+		Stake       *big.Int
+		Debt        *big.Int
+		IncomeFees  *big.Int
+		UnWithdrawn *big.Int
+	})
+	err := _Staking.multicall.Queue("valInfos", func(out []interface{}) {
+		result.Stake = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+		result.Debt = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+		result.IncomeFees = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+		result.UnWithdrawn = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	}, arg0)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ValMaps queues a free data retrieval call binding the contract method 0x0cd2c6fa.
+// The result is filled in once the queued calls are executed.
+//
+// Solidity: function valMaps(address ) view returns(address)
+func (_Staking *StakingMulticaller) ValMaps(arg0 common.Address) (*common.Address, error) {
+	result := new(common.Address)
+	err := _Staking.multicall.Queue("valMaps", func(out []interface{}) {
+		*result = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	}, arg0)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AcceptAdmin is a paid mutator transaction binding the contract method 0x0e18b681.
+//
+// Solidity: function acceptAdmin() returns()
+func (_Staking *StakingTransactor) AcceptAdmin(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "acceptAdmin")
+}
+
+// AcceptAdmin is a paid mutator transaction binding the contract method 0x0e18b681.
+//
+// Solidity: function acceptAdmin() returns()
+func (_Staking *StakingSession) AcceptAdmin() (*types.Transaction, error) {
+	return _Staking.Contract.AcceptAdmin(&_Staking.TransactOpts)
+}
+
+// AcceptAdmin is a paid mutator transaction binding the contract method 0x0e18b681.
+//
+// Solidity: function acceptAdmin() returns()
+func (_Staking *StakingTransactorSession) AcceptAdmin() (*types.Transaction, error) {
+	return _Staking.Contract.AcceptAdmin(&_Staking.TransactOpts)
+}
+
+// AddDelegation is a paid mutator transaction binding the contract method 0x70a996a9.
+//
+// Solidity: function addDelegation(address _val) payable returns()
+func (_Staking *StakingTransactor) AddDelegation(opts *bind.TransactOpts, _val common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "addDelegation", _val)
+}
+
+// AddDelegation is a paid mutator transaction binding the contract method 0x70a996a9.
+//
+// Solidity: function addDelegation(address _val) payable returns()
+func (_Staking *StakingSession) AddDelegation(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.AddDelegation(&_Staking.TransactOpts, _val)
+}
+
+// AddDelegation is a paid mutator transaction binding the contract method 0x70a996a9.
+//
+// Solidity: function addDelegation(address _val) payable returns()
+func (_Staking *StakingTransactorSession) AddDelegation(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.AddDelegation(&_Staking.TransactOpts, _val)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x10767904.
+//
+// Solidity: function addStake(address _val) payable returns()
+func (_Staking *StakingTransactor) AddStake(opts *bind.TransactOpts, _val common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "addStake", _val)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x10767904.
+//
+// Solidity: function addStake(address _val) payable returns()
+func (_Staking *StakingSession) AddStake(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.AddStake(&_Staking.TransactOpts, _val)
+}
+
+// AddStake is a paid mutator transaction binding the contract method 0x10767904.
+//
+// Solidity: function addStake(address _val) payable returns()
+func (_Staking *StakingTransactorSession) AddStake(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.AddStake(&_Staking.TransactOpts, _val)
+}
+
+// ChangeAdmin is a paid mutator transaction binding the contract method 0x8f283970.
+//
+// Solidity: function changeAdmin(address newAdmin) returns()
+func (_Staking *StakingTransactor) ChangeAdmin(opts *bind.TransactOpts, newAdmin common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "changeAdmin", newAdmin)
+}
+
+// ChangeAdmin is a paid mutator transaction binding the contract method 0x8f283970.
+//
+// Solidity: function changeAdmin(address newAdmin) returns()
+func (_Staking *StakingSession) ChangeAdmin(newAdmin common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.ChangeAdmin(&_Staking.TransactOpts, newAdmin)
+}
+
+// ChangeAdmin is a paid mutator transaction binding the contract method 0x8f283970.
+//
+// Solidity: function changeAdmin(address newAdmin) returns()
+func (_Staking *StakingTransactorSession) ChangeAdmin(newAdmin common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.ChangeAdmin(&_Staking.TransactOpts, newAdmin)
+}
+
+// DecreaseMissedBlocksCounter is a paid mutator transaction binding the contract method 0x5e81f1f8.
+//
+// Solidity: function decreaseMissedBlocksCounter() returns()
+func (_Staking *StakingTransactor) DecreaseMissedBlocksCounter(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "decreaseMissedBlocksCounter")
+}
+
+// DecreaseMissedBlocksCounter is a paid mutator transaction binding the contract method 0x5e81f1f8.
+//
+// Solidity: function decreaseMissedBlocksCounter() returns()
+func (_Staking *StakingSession) DecreaseMissedBlocksCounter() (*types.Transaction, error) {
+	return _Staking.Contract.DecreaseMissedBlocksCounter(&_Staking.TransactOpts)
+}
+
+// DecreaseMissedBlocksCounter is a paid mutator transaction binding the contract method 0x5e81f1f8.
+//
+// Solidity: function decreaseMissedBlocksCounter() returns()
+func (_Staking *StakingTransactorSession) DecreaseMissedBlocksCounter() (*types.Transaction, error) {
+	return _Staking.Contract.DecreaseMissedBlocksCounter(&_Staking.TransactOpts)
+}
+
+// DelegatorClaimAny is a paid mutator transaction binding the contract method 0x15dd2d55.
+//
+// Solidity: function delegatorClaimAny(address _val) returns()
+func (_Staking *StakingTransactor) DelegatorClaimAny(opts *bind.TransactOpts, _val common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "delegatorClaimAny", _val)
+}
+
+// DelegatorClaimAny is a paid mutator transaction binding the contract method 0x15dd2d55.
+//
+// Solidity: function delegatorClaimAny(address _val) returns()
+func (_Staking *StakingSession) DelegatorClaimAny(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.DelegatorClaimAny(&_Staking.TransactOpts, _val)
+}
+
+// DelegatorClaimAny is a paid mutator transaction binding the contract method 0x15dd2d55.
+//
+// Solidity: function delegatorClaimAny(address _val) returns()
+func (_Staking *StakingTransactorSession) DelegatorClaimAny(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.DelegatorClaimAny(&_Staking.TransactOpts, _val)
+}
+
+// DistributeBlockFee is a paid mutator transaction binding the contract method 0x64252643.
+//
+// Solidity: function distributeBlockFee() payable returns()
+func (_Staking *StakingTransactor) DistributeBlockFee(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "distributeBlockFee")
+}
+
+// DistributeBlockFee is a paid mutator transaction binding the contract method 0x64252643.
+//
+// Solidity: function distributeBlockFee() payable returns()
+func (_Staking *StakingSession) DistributeBlockFee() (*types.Transaction, error) {
+	return _Staking.Contract.DistributeBlockFee(&_Staking.TransactOpts)
+}
+
+// DistributeBlockFee is a paid mutator transaction binding the contract method 0x64252643.
+//
+// Solidity: function distributeBlockFee() payable returns()
+func (_Staking *StakingTransactorSession) DistributeBlockFee() (*types.Transaction, error) {
+	return _Staking.Contract.DistributeBlockFee(&_Staking.TransactOpts)
+}
+
+// DoubleSignPunish is a paid mutator transaction binding the contract method 0x01036cae.
+//
+// Solidity: function doubleSignPunish(bytes32 _punishHash, address _val) returns()
+func (_Staking *StakingTransactor) DoubleSignPunish(opts *bind.TransactOpts, _punishHash [32]byte, _val common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "doubleSignPunish", _punishHash, _val)
+}
+
+// DoubleSignPunish is a paid mutator transaction binding the contract method 0x01036cae.
+//
+// Solidity: function doubleSignPunish(bytes32 _punishHash, address _val) returns()
+func (_Staking *StakingSession) DoubleSignPunish(_punishHash [32]byte, _val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.DoubleSignPunish(&_Staking.TransactOpts, _punishHash, _val)
+}
+
+// DoubleSignPunish is a paid mutator transaction binding the contract method 0x01036cae.
+//
+// Solidity: function doubleSignPunish(bytes32 _punishHash, address _val) returns()
+func (_Staking *StakingTransactorSession) DoubleSignPunish(_punishHash [32]byte, _val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.DoubleSignPunish(&_Staking.TransactOpts, _punishHash, _val)
+}
+
+// ExitDelegation is a paid mutator transaction binding the contract method 0xd2a71d35.
+//
+// Solidity: function exitDelegation(address _val) returns()
+func (_Staking *StakingTransactor) ExitDelegation(opts *bind.TransactOpts, _val common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "exitDelegation", _val)
+}
+
+// ExitDelegation is a paid mutator transaction binding the contract method 0xd2a71d35.
+//
+// Solidity: function exitDelegation(address _val) returns()
+func (_Staking *StakingSession) ExitDelegation(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.ExitDelegation(&_Staking.TransactOpts, _val)
+}
+
+// ExitDelegation is a paid mutator transaction binding the contract method 0xd2a71d35.
+//
+// Solidity: function exitDelegation(address _val) returns()
+func (_Staking *StakingTransactorSession) ExitDelegation(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.ExitDelegation(&_Staking.TransactOpts, _val)
+}
+
+// ExitStaking is a paid mutator transaction binding the contract method 0x7bd8d00c.
+//
+// Solidity: function exitStaking(address _val) returns()
+func (_Staking *StakingTransactor) ExitStaking(opts *bind.TransactOpts, _val common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "exitStaking", _val)
+}
+
+// ExitStaking is a paid mutator transaction binding the contract method 0x7bd8d00c.
+//
+// Solidity: function exitStaking(address _val) returns()
+func (_Staking *StakingSession) ExitStaking(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.ExitStaking(&_Staking.TransactOpts, _val)
+}
+
+// ExitStaking is a paid mutator transaction binding the contract method 0x7bd8d00c.
+//
+// Solidity: function exitStaking(address _val) returns()
+func (_Staking *StakingTransactorSession) ExitStaking(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.ExitStaking(&_Staking.TransactOpts, _val)
+}
+
+// InitValidator is a paid mutator transaction binding the contract method 0xa967e660.
+//
+// Solidity: function initValidator(address _val, address _manager, uint256 _rate, uint256 _stakes, bool _acceptDelegation) returns()
+func (_Staking *StakingTransactor) InitValidator(opts *bind.TransactOpts, _val common.Address, _manager common.Address, _rate *big.Int, _stakes *big.Int, _acceptDelegation bool) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "initValidator", _val, _manager, _rate, _stakes, _acceptDelegation)
+}
+
+// InitValidator is a paid mutator transaction binding the contract method 0xa967e660.
+//
+// Solidity: function initValidator(address _val, address _manager, uint256 _rate, uint256 _stakes, bool _acceptDelegation) returns()
+func (_Staking *StakingSession) InitValidator(_val common.Address, _manager common.Address, _rate *big.Int, _stakes *big.Int, _acceptDelegation bool) (*types.Transaction, error) {
+	return _Staking.Contract.InitValidator(&_Staking.TransactOpts, _val, _manager, _rate, _stakes, _acceptDelegation)
+}
+
+// InitValidator is a paid mutator transaction binding the contract method 0xa967e660.
+//
+// Solidity: function initValidator(address _val, address _manager, uint256 _rate, uint256 _stakes, bool _acceptDelegation) returns()
+func (_Staking *StakingTransactorSession) InitValidator(_val common.Address, _manager common.Address, _rate *big.Int, _stakes *big.Int, _acceptDelegation bool) (*types.Transaction, error) {
+	return _Staking.Contract.InitValidator(&_Staking.TransactOpts, _val, _manager, _rate, _stakes, _acceptDelegation)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8142951a.
+//
+// Solidity: function initialize(address _admin, uint256 _firstLockPeriod, uint256 _releasePeriod, uint256 _releaseCnt, uint256 _totalRewards, uint256 _rewardsPerBlock, uint256 _epoch) payable returns()
+func (_Staking *StakingTransactor) Initialize(opts *bind.TransactOpts, _admin common.Address, _firstLockPeriod *big.Int, _releasePeriod *big.Int, _releaseCnt *big.Int, _totalRewards *big.Int, _rewardsPerBlock *big.Int, _epoch *big.Int) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "initialize", _admin, _firstLockPeriod, _releasePeriod, _releaseCnt, _totalRewards, _rewardsPerBlock, _epoch)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8142951a.
+//
+// Solidity: function initialize(address _admin, uint256 _firstLockPeriod, uint256 _releasePeriod, uint256 _releaseCnt, uint256 _totalRewards, uint256 _rewardsPerBlock, uint256 _epoch) payable returns()
+func (_Staking *StakingSession) Initialize(_admin common.Address, _firstLockPeriod *big.Int, _releasePeriod *big.Int, _releaseCnt *big.Int, _totalRewards *big.Int, _rewardsPerBlock *big.Int, _epoch *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.Initialize(&_Staking.TransactOpts, _admin, _firstLockPeriod, _releasePeriod, _releaseCnt, _totalRewards, _rewardsPerBlock, _epoch)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8142951a.
+//
+// Solidity: function initialize(address _admin, uint256 _firstLockPeriod, uint256 _releasePeriod, uint256 _releaseCnt, uint256 _totalRewards, uint256 _rewardsPerBlock, uint256 _epoch) payable returns()
+func (_Staking *StakingTransactorSession) Initialize(_admin common.Address, _firstLockPeriod *big.Int, _releasePeriod *big.Int, _releaseCnt *big.Int, _totalRewards *big.Int, _rewardsPerBlock *big.Int, _epoch *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.Initialize(&_Staking.TransactOpts, _admin, _firstLockPeriod, _releasePeriod, _releaseCnt, _totalRewards, _rewardsPerBlock, _epoch)
+}
+
+// LazyPunish is a paid mutator transaction binding the contract method 0xe818ef86.
+//
+// Solidity: function lazyPunish(address _val) returns()
+func (_Staking *StakingTransactor) LazyPunish(opts *bind.TransactOpts, _val common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "lazyPunish", _val)
+}
+
+// LazyPunish is a paid mutator transaction binding the contract method 0xe818ef86.
+//
+// Solidity: function lazyPunish(address _val) returns()
+func (_Staking *StakingSession) LazyPunish(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.LazyPunish(&_Staking.TransactOpts, _val)
+}
+
+// LazyPunish is a paid mutator transaction binding the contract method 0xe818ef86.
+//
+// Solidity: function lazyPunish(address _val) returns()
+func (_Staking *StakingTransactorSession) LazyPunish(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.LazyPunish(&_Staking.TransactOpts, _val)
+}
+
+// ReDelegation is a paid mutator transaction binding the contract method 0x0f51922d.
+//
+// Solidity: function reDelegation(address _oldVal, address _newVal, uint256 _amount) returns()
+func (_Staking *StakingTransactor) ReDelegation(opts *bind.TransactOpts, _oldVal common.Address, _newVal common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "reDelegation", _oldVal, _newVal, _amount)
+}
+
+// ReDelegation is a paid mutator transaction binding the contract method 0x0f51922d.
+//
+// Solidity: function reDelegation(address _oldVal, address _newVal, uint256 _amount) returns()
+func (_Staking *StakingSession) ReDelegation(_oldVal common.Address, _newVal common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.ReDelegation(&_Staking.TransactOpts, _oldVal, _newVal, _amount)
+}
+
+// ReDelegation is a paid mutator transaction binding the contract method 0x0f51922d.
+//
+// Solidity: function reDelegation(address _oldVal, address _newVal, uint256 _amount) returns()
+func (_Staking *StakingTransactorSession) ReDelegation(_oldVal common.Address, _newVal common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.ReDelegation(&_Staking.TransactOpts, _oldVal, _newVal, _amount)
+}
+
+// ReStaking is a paid mutator transaction binding the contract method 0x4fa48d81.
+//
+// Solidity: function reStaking(address _oldVal, address _newVal, uint256 _amount) returns()
+func (_Staking *StakingTransactor) ReStaking(opts *bind.TransactOpts, _oldVal common.Address, _newVal common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "reStaking", _oldVal, _newVal, _amount)
+}
+
+// ReStaking is a paid mutator transaction binding the contract method 0x4fa48d81.
+//
+// Solidity: function reStaking(address _oldVal, address _newVal, uint256 _amount) returns()
+func (_Staking *StakingSession) ReStaking(_oldVal common.Address, _newVal common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.ReStaking(&_Staking.TransactOpts, _oldVal, _newVal, _amount)
+}
+
+// ReStaking is a paid mutator transaction binding the contract method 0x4fa48d81.
+//
+// Solidity: function reStaking(address _oldVal, address _newVal, uint256 _amount) returns()
+func (_Staking *StakingTransactorSession) ReStaking(_oldVal common.Address, _newVal common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.ReStaking(&_Staking.TransactOpts, _oldVal, _newVal, _amount)
+}
+
+// RegisterValidator is a paid mutator transaction binding the contract method 0xd2710909.
+//
+// Solidity: function registerValidator(address _val, address _manager, uint256 _rate, bool _acceptDelegation) payable returns()
+func (_Staking *StakingTransactor) RegisterValidator(opts *bind.TransactOpts, _val common.Address, _manager common.Address, _rate *big.Int, _acceptDelegation bool) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "registerValidator", _val, _manager, _rate, _acceptDelegation)
+}
+
+// RegisterValidator is a paid mutator transaction binding the contract method 0xd2710909.
+//
+// Solidity: function registerValidator(address _val, address _manager, uint256 _rate, bool _acceptDelegation) payable returns()
+func (_Staking *StakingSession) RegisterValidator(_val common.Address, _manager common.Address, _rate *big.Int, _acceptDelegation bool) (*types.Transaction, error) {
+	return _Staking.Contract.RegisterValidator(&_Staking.TransactOpts, _val, _manager, _rate, _acceptDelegation)
+}
+
+// RegisterValidator is a paid mutator transaction binding the contract method 0xd2710909.
+//
+// Solidity: function registerValidator(address _val, address _manager, uint256 _rate, bool _acceptDelegation) payable returns()
+func (_Staking *StakingTransactorSession) RegisterValidator(_val common.Address, _manager common.Address, _rate *big.Int, _acceptDelegation bool) (*types.Transaction, error) {
+	return _Staking.Contract.RegisterValidator(&_Staking.TransactOpts, _val, _manager, _rate, _acceptDelegation)
+}
+
+// RemovePermission is a paid mutator transaction binding the contract method 0x476776d4.
+//
+// Solidity: function removePermission() returns()
+func (_Staking *StakingTransactor) RemovePermission(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "removePermission")
+}
+
+// RemovePermission is a paid mutator transaction binding the contract method 0x476776d4.
+//
+// Solidity: function removePermission() returns()
+func (_Staking *StakingSession) RemovePermission() (*types.Transaction, error) {
+	return _Staking.Contract.RemovePermission(&_Staking.TransactOpts)
+}
+
+// RemovePermission is a paid mutator transaction binding the contract method 0x476776d4.
+//
+// Solidity: function removePermission() returns()
+func (_Staking *StakingTransactorSession) RemovePermission() (*types.Transaction, error) {
+	return _Staking.Contract.RemovePermission(&_Staking.TransactOpts)
+}
+
+// SubDelegation is a paid mutator transaction binding the contract method 0xda71584b.
+//
+// Solidity: function subDelegation(address _val, uint256 _amount) returns()
+func (_Staking *StakingTransactor) SubDelegation(opts *bind.TransactOpts, _val common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "subDelegation", _val, _amount)
+}
+
+// SubDelegation is a paid mutator transaction binding the contract method 0xda71584b.
+//
+// Solidity: function subDelegation(address _val, uint256 _amount) returns()
+func (_Staking *StakingSession) SubDelegation(_val common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.SubDelegation(&_Staking.TransactOpts, _val, _amount)
+}
+
+// SubDelegation is a paid mutator transaction binding the contract method 0xda71584b.
+//
+// Solidity: function subDelegation(address _val, uint256 _amount) returns()
+func (_Staking *StakingTransactorSession) SubDelegation(_val common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.SubDelegation(&_Staking.TransactOpts, _val, _amount)
+}
+
+// SubStake is a paid mutator transaction binding the contract method 0x6936c70d.
+//
+// Solidity: function subStake(address _val, uint256 _amount) returns()
+func (_Staking *StakingTransactor) SubStake(opts *bind.TransactOpts, _val common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "subStake", _val, _amount)
+}
+
+// SubStake is a paid mutator transaction binding the contract method 0x6936c70d.
+//
+// Solidity: function subStake(address _val, uint256 _amount) returns()
+func (_Staking *StakingSession) SubStake(_val common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.SubStake(&_Staking.TransactOpts, _val, _amount)
+}
+
+// SubStake is a paid mutator transaction binding the contract method 0x6936c70d.
+//
+// Solidity: function subStake(address _val, uint256 _amount) returns()
+func (_Staking *StakingTransactorSession) SubStake(_val common.Address, _amount *big.Int) (*types.Transaction, error) {
+	return _Staking.Contract.SubStake(&_Staking.TransactOpts, _val, _amount)
+}
+
+// UpdateActiveValidatorSet is a paid mutator transaction binding the contract method 0x5bcee382.
+//
+// Solidity: function updateActiveValidatorSet(address[] newSet) returns()
+func (_Staking *StakingTransactor) UpdateActiveValidatorSet(opts *bind.TransactOpts, newSet []common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "updateActiveValidatorSet", newSet)
+}
+
+// UpdateActiveValidatorSet is a paid mutator transaction binding the contract method 0x5bcee382.
+//
+// Solidity: function updateActiveValidatorSet(address[] newSet) returns()
+func (_Staking *StakingSession) UpdateActiveValidatorSet(newSet []common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.UpdateActiveValidatorSet(&_Staking.TransactOpts, newSet)
+}
+
+// UpdateActiveValidatorSet is a paid mutator transaction binding the contract method 0x5bcee382.
+//
+// Solidity: function updateActiveValidatorSet(address[] newSet) returns()
+func (_Staking *StakingTransactorSession) UpdateActiveValidatorSet(newSet []common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.UpdateActiveValidatorSet(&_Staking.TransactOpts, newSet)
+}
+
+// ValidatorClaimAny is a paid mutator transaction binding the contract method 0x4eb4d1ba.
+//
+// Solidity: function validatorClaimAny(address _val) returns()
+func (_Staking *StakingTransactor) ValidatorClaimAny(opts *bind.TransactOpts, _val common.Address) (*types.Transaction, error) {
+	return _Staking.contract.Transact(opts, "validatorClaimAny", _val)
+}
+
+// ValidatorClaimAny is a paid mutator transaction binding the contract method 0x4eb4d1ba.
+//
+// Solidity: function validatorClaimAny(address _val) returns()
+func (_Staking *StakingSession) ValidatorClaimAny(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.ValidatorClaimAny(&_Staking.TransactOpts, _val)
+}
+
+// ValidatorClaimAny is a paid mutator transaction binding the contract method 0x4eb4d1ba.
+//
+// Solidity: function validatorClaimAny(address _val) returns()
+func (_Staking *StakingTransactorSession) ValidatorClaimAny(_val common.Address) (*types.Transaction, error) {
+	return _Staking.Contract.ValidatorClaimAny(&_Staking.TransactOpts, _val)
+}
+
+// StakingAdminChangedIterator is returned from FilterAdminChanged and is used to iterate over the raw logs and unpacked data for AdminChanged events raised by the Staking contract.
+type StakingAdminChangedIterator struct {
+	Event *StakingAdminChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingAdminChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingAdminChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingAdminChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingAdminChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingAdminChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingAdminChanged represents a AdminChanged event raised by the Staking contract.
+type StakingAdminChanged struct {
+	OldAdmin common.Address
+	NewAdmin common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterAdminChanged is a free log retrieval operation binding the contract event 0x7e644d79422f17c01e4894b5f4f588d331ebfa28653d42ae832dc59e38c9798f.
+//
+// Solidity: event AdminChanged(address indexed oldAdmin, address indexed newAdmin)
+func (_Staking *StakingFilterer) FilterAdminChanged(opts *bind.FilterOpts, oldAdmin []common.Address, newAdmin []common.Address) (*StakingAdminChangedIterator, error) {
+
+	var oldAdminRule []interface{}
+	for _, oldAdminItem := range oldAdmin {
+		oldAdminRule = append(oldAdminRule, oldAdminItem)
+	}
+	var newAdminRule []interface{}
+	for _, newAdminItem := range newAdmin {
+		newAdminRule = append(newAdminRule, newAdminItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "AdminChanged", oldAdminRule, newAdminRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingAdminChangedIterator{contract: _Staking.contract, event: "AdminChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchAdminChanged is a free log subscription operation binding the contract event 0x7e644d79422f17c01e4894b5f4f588d331ebfa28653d42ae832dc59e38c9798f.
+//
+// Solidity: event AdminChanged(address indexed oldAdmin, address indexed newAdmin)
+func (_Staking *StakingFilterer) WatchAdminChanged(opts *bind.WatchOpts, sink chan<- *StakingAdminChanged, oldAdmin []common.Address, newAdmin []common.Address) (event.Subscription, error) {
+
+	var oldAdminRule []interface{}
+	for _, oldAdminItem := range oldAdmin {
+		oldAdminRule = append(oldAdminRule, oldAdminItem)
+	}
+	var newAdminRule []interface{}
+	for _, newAdminItem := range newAdmin {
+		newAdminRule = append(newAdminRule, newAdminItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "AdminChanged", oldAdminRule, newAdminRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingAdminChanged)
+				if err := _Staking.contract.UnpackLog(event, "AdminChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAdminChanged is a log parse operation binding the contract event 0x7e644d79422f17c01e4894b5f4f588d331ebfa28653d42ae832dc59e38c9798f.
+//
+// Solidity: event AdminChanged(address indexed oldAdmin, address indexed newAdmin)
+func (_Staking *StakingFilterer) ParseAdminChanged(log types.Log) (*StakingAdminChanged, error) {
+	event := new(StakingAdminChanged)
+	if err := _Staking.contract.UnpackLog(event, "AdminChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingAdminChangingIterator is returned from FilterAdminChanging and is used to iterate over the raw logs and unpacked data for AdminChanging events raised by the Staking contract.
+type StakingAdminChangingIterator struct {
+	Event *StakingAdminChanging // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingAdminChangingIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingAdminChanging)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingAdminChanging)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingAdminChangingIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingAdminChangingIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingAdminChanging represents a AdminChanging event raised by the Staking contract.
+type StakingAdminChanging struct {
+	NewAdmin common.Address
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterAdminChanging is a free log retrieval operation binding the contract event 0xaefcaa6215f99fe8c2f605dd268ee4d23a5b596bbca026e25ce8446187f4f1ba.
+//
+// Solidity: event AdminChanging(address indexed newAdmin)
+func (_Staking *StakingFilterer) FilterAdminChanging(opts *bind.FilterOpts, newAdmin []common.Address) (*StakingAdminChangingIterator, error) {
+
+	var newAdminRule []interface{}
+	for _, newAdminItem := range newAdmin {
+		newAdminRule = append(newAdminRule, newAdminItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "AdminChanging", newAdminRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingAdminChangingIterator{contract: _Staking.contract, event: "AdminChanging", logs: logs, sub: sub}, nil
+}
+
+// WatchAdminChanging is a free log subscription operation binding the contract event 0xaefcaa6215f99fe8c2f605dd268ee4d23a5b596bbca026e25ce8446187f4f1ba.
+//
+// Solidity: event AdminChanging(address indexed newAdmin)
+func (_Staking *StakingFilterer) WatchAdminChanging(opts *bind.WatchOpts, sink chan<- *StakingAdminChanging, newAdmin []common.Address) (event.Subscription, error) {
+
+	var newAdminRule []interface{}
+	for _, newAdminItem := range newAdmin {
+		newAdminRule = append(newAdminRule, newAdminItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "AdminChanging", newAdminRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingAdminChanging)
+				if err := _Staking.contract.UnpackLog(event, "AdminChanging", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAdminChanging is a log parse operation binding the contract event 0xaefcaa6215f99fe8c2f605dd268ee4d23a5b596bbca026e25ce8446187f4f1ba.
+//
+// Solidity: event AdminChanging(address indexed newAdmin)
+func (_Staking *StakingFilterer) ParseAdminChanging(log types.Log) (*StakingAdminChanging, error) {
+	event := new(StakingAdminChanging)
+	if err := _Staking.contract.UnpackLog(event, "AdminChanging", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingClaimWithoutUnboundStakeIterator is returned from FilterClaimWithoutUnboundStake and is used to iterate over the raw logs and unpacked data for ClaimWithoutUnboundStake events raised by the Staking contract.
+type StakingClaimWithoutUnboundStakeIterator struct {
+	Event *StakingClaimWithoutUnboundStake // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingClaimWithoutUnboundStakeIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingClaimWithoutUnboundStake)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingClaimWithoutUnboundStake)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingClaimWithoutUnboundStakeIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingClaimWithoutUnboundStakeIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingClaimWithoutUnboundStake represents a ClaimWithoutUnboundStake event raised by the Staking contract.
+type StakingClaimWithoutUnboundStake struct {
+	Val common.Address
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterClaimWithoutUnboundStake is a free log retrieval operation binding the contract event 0x8d582b00f475c8e1026abf1c7f8c3c4e81e4874ff58fabfca37eb222735d0c0d.
+//
+// Solidity: event ClaimWithoutUnboundStake(address indexed val)
+func (_Staking *StakingFilterer) FilterClaimWithoutUnboundStake(opts *bind.FilterOpts, val []common.Address) (*StakingClaimWithoutUnboundStakeIterator, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "ClaimWithoutUnboundStake", valRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingClaimWithoutUnboundStakeIterator{contract: _Staking.contract, event: "ClaimWithoutUnboundStake", logs: logs, sub: sub}, nil
+}
+
+// WatchClaimWithoutUnboundStake is a free log subscription operation binding the contract event 0x8d582b00f475c8e1026abf1c7f8c3c4e81e4874ff58fabfca37eb222735d0c0d.
+//
+// Solidity: event ClaimWithoutUnboundStake(address indexed val)
+func (_Staking *StakingFilterer) WatchClaimWithoutUnboundStake(opts *bind.WatchOpts, sink chan<- *StakingClaimWithoutUnboundStake, val []common.Address) (event.Subscription, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "ClaimWithoutUnboundStake", valRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingClaimWithoutUnboundStake)
+				if err := _Staking.contract.UnpackLog(event, "ClaimWithoutUnboundStake", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseClaimWithoutUnboundStake is a log parse operation binding the contract event 0x8d582b00f475c8e1026abf1c7f8c3c4e81e4874ff58fabfca37eb222735d0c0d.
+//
+// Solidity: event ClaimWithoutUnboundStake(address indexed val)
+func (_Staking *StakingFilterer) ParseClaimWithoutUnboundStake(log types.Log) (*StakingClaimWithoutUnboundStake, error) {
+	event := new(StakingClaimWithoutUnboundStake)
+	if err := _Staking.contract.UnpackLog(event, "ClaimWithoutUnboundStake", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingFounderUnlockedIterator is returned from FilterFounderUnlocked and is used to iterate over the raw logs and unpacked data for FounderUnlocked events raised by the Staking contract.
+type StakingFounderUnlockedIterator struct {
+	Event *StakingFounderUnlocked // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingFounderUnlockedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingFounderUnlocked)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingFounderUnlocked)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingFounderUnlockedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingFounderUnlockedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingFounderUnlocked represents a FounderUnlocked event raised by the Staking contract.
+type StakingFounderUnlocked struct {
+	Val common.Address
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterFounderUnlocked is a free log retrieval operation binding the contract event 0xc484462ff1beade69379ba04c4af2d90b5c8482405682a8fda61ac09fdc1c40d.
+//
+// Solidity: event FounderUnlocked(address indexed val)
+func (_Staking *StakingFilterer) FilterFounderUnlocked(opts *bind.FilterOpts, val []common.Address) (*StakingFounderUnlockedIterator, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "FounderUnlocked", valRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingFounderUnlockedIterator{contract: _Staking.contract, event: "FounderUnlocked", logs: logs, sub: sub}, nil
+}
+
+// WatchFounderUnlocked is a free log subscription operation binding the contract event 0xc484462ff1beade69379ba04c4af2d90b5c8482405682a8fda61ac09fdc1c40d.
+//
+// Solidity: event FounderUnlocked(address indexed val)
+func (_Staking *StakingFilterer) WatchFounderUnlocked(opts *bind.WatchOpts, sink chan<- *StakingFounderUnlocked, val []common.Address) (event.Subscription, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "FounderUnlocked", valRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingFounderUnlocked)
+				if err := _Staking.contract.UnpackLog(event, "FounderUnlocked", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFounderUnlocked is a log parse operation binding the contract event 0xc484462ff1beade69379ba04c4af2d90b5c8482405682a8fda61ac09fdc1c40d.
+//
+// Solidity: event FounderUnlocked(address indexed val)
+func (_Staking *StakingFilterer) ParseFounderUnlocked(log types.Log) (*StakingFounderUnlocked, error) {
+	event := new(StakingFounderUnlocked)
+	if err := _Staking.contract.UnpackLog(event, "FounderUnlocked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingLogDecreaseMissedBlocksCounterIterator is returned from FilterLogDecreaseMissedBlocksCounter and is used to iterate over the raw logs and unpacked data for LogDecreaseMissedBlocksCounter events raised by the Staking contract.
+type StakingLogDecreaseMissedBlocksCounterIterator struct {
+	Event *StakingLogDecreaseMissedBlocksCounter // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingLogDecreaseMissedBlocksCounterIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingLogDecreaseMissedBlocksCounter)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingLogDecreaseMissedBlocksCounter)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingLogDecreaseMissedBlocksCounterIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingLogDecreaseMissedBlocksCounterIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingLogDecreaseMissedBlocksCounter represents a LogDecreaseMissedBlocksCounter event raised by the Staking contract.
+type StakingLogDecreaseMissedBlocksCounter struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterLogDecreaseMissedBlocksCounter is a free log retrieval operation binding the contract event 0x181d51be54e8e8eaca6eae0eab32d4162099236bd519e7238d015d0870db4641.
+//
+// Solidity: event LogDecreaseMissedBlocksCounter()
+func (_Staking *StakingFilterer) FilterLogDecreaseMissedBlocksCounter(opts *bind.FilterOpts) (*StakingLogDecreaseMissedBlocksCounterIterator, error) {
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "LogDecreaseMissedBlocksCounter")
+	if err != nil {
+		return nil, err
+	}
+	return &StakingLogDecreaseMissedBlocksCounterIterator{contract: _Staking.contract, event: "LogDecreaseMissedBlocksCounter", logs: logs, sub: sub}, nil
+}
+
+// WatchLogDecreaseMissedBlocksCounter is a free log subscription operation binding the contract event 0x181d51be54e8e8eaca6eae0eab32d4162099236bd519e7238d015d0870db4641.
+//
+// Solidity: event LogDecreaseMissedBlocksCounter()
+func (_Staking *StakingFilterer) WatchLogDecreaseMissedBlocksCounter(opts *bind.WatchOpts, sink chan<- *StakingLogDecreaseMissedBlocksCounter) (event.Subscription, error) {
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "LogDecreaseMissedBlocksCounter")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingLogDecreaseMissedBlocksCounter)
+				if err := _Staking.contract.UnpackLog(event, "LogDecreaseMissedBlocksCounter", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseLogDecreaseMissedBlocksCounter is a log parse operation binding the contract event 0x181d51be54e8e8eaca6eae0eab32d4162099236bd519e7238d015d0870db4641.
+//
+// Solidity: event LogDecreaseMissedBlocksCounter()
+func (_Staking *StakingFilterer) ParseLogDecreaseMissedBlocksCounter(log types.Log) (*StakingLogDecreaseMissedBlocksCounter, error) {
+	event := new(StakingLogDecreaseMissedBlocksCounter)
+	if err := _Staking.contract.UnpackLog(event, "LogDecreaseMissedBlocksCounter", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingLogDoubleSignPunishValidatorIterator is returned from FilterLogDoubleSignPunishValidator and is used to iterate over the raw logs and unpacked data for LogDoubleSignPunishValidator events raised by the Staking contract.
+type StakingLogDoubleSignPunishValidatorIterator struct {
+	Event *StakingLogDoubleSignPunishValidator // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingLogDoubleSignPunishValidatorIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingLogDoubleSignPunishValidator)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingLogDoubleSignPunishValidator)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingLogDoubleSignPunishValidatorIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingLogDoubleSignPunishValidatorIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingLogDoubleSignPunishValidator represents a LogDoubleSignPunishValidator event raised by the Staking contract.
+type StakingLogDoubleSignPunishValidator struct {
+	Val  common.Address
+	Time *big.Int
+	Raw  types.Log // Blockchain specific contextual infos
+}
+
+// FilterLogDoubleSignPunishValidator is a free log retrieval operation binding the contract event 0xda5de20b8278e154fa3f60bbee4cf3f2f8b00c704fcc1042929f222d499a2494.
+//
+// Solidity: event LogDoubleSignPunishValidator(address indexed val, uint256 time)
+func (_Staking *StakingFilterer) FilterLogDoubleSignPunishValidator(opts *bind.FilterOpts, val []common.Address) (*StakingLogDoubleSignPunishValidatorIterator, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "LogDoubleSignPunishValidator", valRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingLogDoubleSignPunishValidatorIterator{contract: _Staking.contract, event: "LogDoubleSignPunishValidator", logs: logs, sub: sub}, nil
+}
+
+// WatchLogDoubleSignPunishValidator is a free log subscription operation binding the contract event 0xda5de20b8278e154fa3f60bbee4cf3f2f8b00c704fcc1042929f222d499a2494.
+//
+// Solidity: event LogDoubleSignPunishValidator(address indexed val, uint256 time)
+func (_Staking *StakingFilterer) WatchLogDoubleSignPunishValidator(opts *bind.WatchOpts, sink chan<- *StakingLogDoubleSignPunishValidator, val []common.Address) (event.Subscription, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "LogDoubleSignPunishValidator", valRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingLogDoubleSignPunishValidator)
+				if err := _Staking.contract.UnpackLog(event, "LogDoubleSignPunishValidator", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseLogDoubleSignPunishValidator is a log parse operation binding the contract event 0xda5de20b8278e154fa3f60bbee4cf3f2f8b00c704fcc1042929f222d499a2494.
+//
+// Solidity: event LogDoubleSignPunishValidator(address indexed val, uint256 time)
+func (_Staking *StakingFilterer) ParseLogDoubleSignPunishValidator(log types.Log) (*StakingLogDoubleSignPunishValidator, error) {
+	event := new(StakingLogDoubleSignPunishValidator)
+	if err := _Staking.contract.UnpackLog(event, "LogDoubleSignPunishValidator", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingLogLazyPunishValidatorIterator is returned from FilterLogLazyPunishValidator and is used to iterate over the raw logs and unpacked data for LogLazyPunishValidator events raised by the Staking contract.
+type StakingLogLazyPunishValidatorIterator struct {
+	Event *StakingLogLazyPunishValidator // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingLogLazyPunishValidatorIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingLogLazyPunishValidator)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingLogLazyPunishValidator)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingLogLazyPunishValidatorIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingLogLazyPunishValidatorIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingLogLazyPunishValidator represents a LogLazyPunishValidator event raised by the Staking contract.
+type StakingLogLazyPunishValidator struct {
+	Val  common.Address
+	Time *big.Int
+	Raw  types.Log // Blockchain specific contextual infos
+}
+
+// FilterLogLazyPunishValidator is a free log retrieval operation binding the contract event 0x958c4e419432304eb5d6a49f6d489079229a221430ab45c481a51bcf90a5b78c.
+//
+// Solidity: event LogLazyPunishValidator(address indexed val, uint256 time)
+func (_Staking *StakingFilterer) FilterLogLazyPunishValidator(opts *bind.FilterOpts, val []common.Address) (*StakingLogLazyPunishValidatorIterator, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "LogLazyPunishValidator", valRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingLogLazyPunishValidatorIterator{contract: _Staking.contract, event: "LogLazyPunishValidator", logs: logs, sub: sub}, nil
+}
+
+// WatchLogLazyPunishValidator is a free log subscription operation binding the contract event 0x958c4e419432304eb5d6a49f6d489079229a221430ab45c481a51bcf90a5b78c.
+//
+// Solidity: event LogLazyPunishValidator(address indexed val, uint256 time)
+func (_Staking *StakingFilterer) WatchLogLazyPunishValidator(opts *bind.WatchOpts, sink chan<- *StakingLogLazyPunishValidator, val []common.Address) (event.Subscription, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "LogLazyPunishValidator", valRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingLogLazyPunishValidator)
+				if err := _Staking.contract.UnpackLog(event, "LogLazyPunishValidator", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseLogLazyPunishValidator is a log parse operation binding the contract event 0x958c4e419432304eb5d6a49f6d489079229a221430ab45c481a51bcf90a5b78c.
+//
+// Solidity: event LogLazyPunishValidator(address indexed val, uint256 time)
+func (_Staking *StakingFilterer) ParseLogLazyPunishValidator(log types.Log) (*StakingLogLazyPunishValidator, error) {
+	event := new(StakingLogLazyPunishValidator)
+	if err := _Staking.contract.UnpackLog(event, "LogLazyPunishValidator", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingPermissionLessIterator is returned from FilterPermissionLess and is used to iterate over the raw logs and unpacked data for PermissionLess events raised by the Staking contract.
+type StakingPermissionLessIterator struct {
+	Event *StakingPermissionLess // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingPermissionLessIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingPermissionLess)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingPermissionLess)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingPermissionLessIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingPermissionLessIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingPermissionLess represents a PermissionLess event raised by the Staking contract.
+type StakingPermissionLess struct {
+	Opened bool
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterPermissionLess is a free log retrieval operation binding the contract event 0xdd288448284e1ccc5e28842bad7eb5c8cfa9587059e1642595fa8666246957a9.
+//
+// Solidity: event PermissionLess(bool indexed opened)
+func (_Staking *StakingFilterer) FilterPermissionLess(opts *bind.FilterOpts, opened []bool) (*StakingPermissionLessIterator, error) {
+
+	var openedRule []interface{}
+	for _, openedItem := range opened {
+		openedRule = append(openedRule, openedItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "PermissionLess", openedRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingPermissionLessIterator{contract: _Staking.contract, event: "PermissionLess", logs: logs, sub: sub}, nil
+}
+
+// WatchPermissionLess is a free log subscription operation binding the contract event 0xdd288448284e1ccc5e28842bad7eb5c8cfa9587059e1642595fa8666246957a9.
+//
+// Solidity: event PermissionLess(bool indexed opened)
+func (_Staking *StakingFilterer) WatchPermissionLess(opts *bind.WatchOpts, sink chan<- *StakingPermissionLess, opened []bool) (event.Subscription, error) {
+
+	var openedRule []interface{}
+	for _, openedItem := range opened {
+		openedRule = append(openedRule, openedItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "PermissionLess", openedRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingPermissionLess)
+				if err := _Staking.contract.UnpackLog(event, "PermissionLess", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePermissionLess is a log parse operation binding the contract event 0xdd288448284e1ccc5e28842bad7eb5c8cfa9587059e1642595fa8666246957a9.
+//
+// Solidity: event PermissionLess(bool indexed opened)
+func (_Staking *StakingFilterer) ParsePermissionLess(log types.Log) (*StakingPermissionLess, error) {
+	event := new(StakingPermissionLess)
+	if err := _Staking.contract.UnpackLog(event, "PermissionLess", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingStakeWithdrawnIterator is returned from FilterStakeWithdrawn and is used to iterate over the raw logs and unpacked data for StakeWithdrawn events raised by the Staking contract.
+type StakingStakeWithdrawnIterator struct {
+	Event *StakingStakeWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingStakeWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingStakeWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingStakeWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingStakeWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingStakeWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingStakeWithdrawn represents a StakeWithdrawn event raised by the Staking contract.
+type StakingStakeWithdrawn struct {
+	Val       common.Address
+	Recipient common.Address
+	Amount    *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterStakeWithdrawn is a free log retrieval operation binding the contract event 0xb7c918e0e249f999e965cafeb6c664271b3f4317d296461500e71da39f0cbda3.
+//
+// Solidity: event StakeWithdrawn(address indexed val, address indexed recipient, uint256 amount)
+func (_Staking *StakingFilterer) FilterStakeWithdrawn(opts *bind.FilterOpts, val []common.Address, recipient []common.Address) (*StakingStakeWithdrawnIterator, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "StakeWithdrawn", valRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingStakeWithdrawnIterator{contract: _Staking.contract, event: "StakeWithdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchStakeWithdrawn is a free log subscription operation binding the contract event 0xb7c918e0e249f999e965cafeb6c664271b3f4317d296461500e71da39f0cbda3.
+//
+// Solidity: event StakeWithdrawn(address indexed val, address indexed recipient, uint256 amount)
+func (_Staking *StakingFilterer) WatchStakeWithdrawn(opts *bind.WatchOpts, sink chan<- *StakingStakeWithdrawn, val []common.Address, recipient []common.Address) (event.Subscription, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+	var recipientRule []interface{}
+	for _, recipientItem := range recipient {
+		recipientRule = append(recipientRule, recipientItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "StakeWithdrawn", valRule, recipientRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingStakeWithdrawn)
+				if err := _Staking.contract.UnpackLog(event, "StakeWithdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStakeWithdrawn is a log parse operation binding the contract event 0xb7c918e0e249f999e965cafeb6c664271b3f4317d296461500e71da39f0cbda3.
+//
+// Solidity: event StakeWithdrawn(address indexed val, address indexed recipient, uint256 amount)
+func (_Staking *StakingFilterer) ParseStakeWithdrawn(log types.Log) (*StakingStakeWithdrawn, error) {
+	event := new(StakingStakeWithdrawn)
+	if err := _Staking.contract.UnpackLog(event, "StakeWithdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingStakingRewardsEmptyIterator is returned from FilterStakingRewardsEmpty and is used to iterate over the raw logs and unpacked data for StakingRewardsEmpty events raised by the Staking contract.
+type StakingStakingRewardsEmptyIterator struct {
+	Event *StakingStakingRewardsEmpty // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingStakingRewardsEmptyIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingStakingRewardsEmpty)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingStakingRewardsEmpty)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingStakingRewardsEmptyIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingStakingRewardsEmptyIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingStakingRewardsEmpty represents a StakingRewardsEmpty event raised by the Staking contract.
+type StakingStakingRewardsEmpty struct {
+	Empty bool
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterStakingRewardsEmpty is a free log retrieval operation binding the contract event 0xfea2322f7ff1da0a9aaf4bdbe1f48d197c176670e395853ad39a1aea895654f1.
+//
+// Solidity: event StakingRewardsEmpty(bool empty)
+func (_Staking *StakingFilterer) FilterStakingRewardsEmpty(opts *bind.FilterOpts) (*StakingStakingRewardsEmptyIterator, error) {
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "StakingRewardsEmpty")
+	if err != nil {
+		return nil, err
+	}
+	return &StakingStakingRewardsEmptyIterator{contract: _Staking.contract, event: "StakingRewardsEmpty", logs: logs, sub: sub}, nil
+}
+
+// WatchStakingRewardsEmpty is a free log subscription operation binding the contract event 0xfea2322f7ff1da0a9aaf4bdbe1f48d197c176670e395853ad39a1aea895654f1.
+//
+// Solidity: event StakingRewardsEmpty(bool empty)
+func (_Staking *StakingFilterer) WatchStakingRewardsEmpty(opts *bind.WatchOpts, sink chan<- *StakingStakingRewardsEmpty) (event.Subscription, error) {
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "StakingRewardsEmpty")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingStakingRewardsEmpty)
+				if err := _Staking.contract.UnpackLog(event, "StakingRewardsEmpty", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseStakingRewardsEmpty is a log parse operation binding the contract event 0xfea2322f7ff1da0a9aaf4bdbe1f48d197c176670e395853ad39a1aea895654f1.
+//
+// Solidity: event StakingRewardsEmpty(bool empty)
+func (_Staking *StakingFilterer) ParseStakingRewardsEmpty(log types.Log) (*StakingStakingRewardsEmpty, error) {
+	event := new(StakingStakingRewardsEmpty)
+	if err := _Staking.contract.UnpackLog(event, "StakingRewardsEmpty", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingTotalStakeChangedIterator is returned from FilterTotalStakeChanged and is used to iterate over the raw logs and unpacked data for TotalStakeChanged events raised by the Staking contract.
+type StakingTotalStakeChangedIterator struct {
+	Event *StakingTotalStakeChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingTotalStakeChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingTotalStakeChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingTotalStakeChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingTotalStakeChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingTotalStakeChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingTotalStakeChanged represents a TotalStakeChanged event raised by the Staking contract.
+type StakingTotalStakeChanged struct {
+	Changer  common.Address
+	OldStake *big.Int
+	NewStake *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterTotalStakeChanged is a free log retrieval operation binding the contract event 0x58bd02592b2e4e68b1f0f2fb4a43adebfdeb3ef5d910065113767b37058d3e99.
+//
+// Solidity: event TotalStakeChanged(address indexed changer, uint256 oldStake, uint256 newStake)
+func (_Staking *StakingFilterer) FilterTotalStakeChanged(opts *bind.FilterOpts, changer []common.Address) (*StakingTotalStakeChangedIterator, error) {
+
+	var changerRule []interface{}
+	for _, changerItem := range changer {
+		changerRule = append(changerRule, changerItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "TotalStakeChanged", changerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingTotalStakeChangedIterator{contract: _Staking.contract, event: "TotalStakeChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchTotalStakeChanged is a free log subscription operation binding the contract event 0x58bd02592b2e4e68b1f0f2fb4a43adebfdeb3ef5d910065113767b37058d3e99.
+//
+// Solidity: event TotalStakeChanged(address indexed changer, uint256 oldStake, uint256 newStake)
+func (_Staking *StakingFilterer) WatchTotalStakeChanged(opts *bind.WatchOpts, sink chan<- *StakingTotalStakeChanged, changer []common.Address) (event.Subscription, error) {
+
+	var changerRule []interface{}
+	for _, changerItem := range changer {
+		changerRule = append(changerRule, changerItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "TotalStakeChanged", changerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingTotalStakeChanged)
+				if err := _Staking.contract.UnpackLog(event, "TotalStakeChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTotalStakeChanged is a log parse operation binding the contract event 0x58bd02592b2e4e68b1f0f2fb4a43adebfdeb3ef5d910065113767b37058d3e99.
+//
+// Solidity: event TotalStakeChanged(address indexed changer, uint256 oldStake, uint256 newStake)
+func (_Staking *StakingFilterer) ParseTotalStakeChanged(log types.Log) (*StakingTotalStakeChanged, error) {
+	event := new(StakingTotalStakeChanged)
+	if err := _Staking.contract.UnpackLog(event, "TotalStakeChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// StakingValidatorRegisteredIterator is returned from FilterValidatorRegistered and is used to iterate over the raw logs and unpacked data for ValidatorRegistered events raised by the Staking contract.
+type StakingValidatorRegisteredIterator struct {
+	Event *StakingValidatorRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *StakingValidatorRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(StakingValidatorRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(StakingValidatorRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *StakingValidatorRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *StakingValidatorRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// StakingValidatorRegistered represents a ValidatorRegistered event raised by the Staking contract.
+type StakingValidatorRegistered struct {
+	Val            common.Address
+	Manager        common.Address
+	CommissionRate *big.Int
+	Stake          *big.Int
+	St             uint8
+	Raw            types.Log // Blockchain specific contextual infos
+}
+
+// FilterValidatorRegistered is a free log retrieval operation binding the contract event 0xa89c42d28da99880ab0a25764963efb2991ebf2e19b963718698ecc33c1ee602.
+//
+// Solidity: event ValidatorRegistered(address indexed val, address indexed manager, uint256 commissionRate, uint256 stake, uint8 st)
+func (_Staking *StakingFilterer) FilterValidatorRegistered(opts *bind.FilterOpts, val []common.Address, manager []common.Address) (*StakingValidatorRegisteredIterator, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+	var managerRule []interface{}
+	for _, managerItem := range manager {
+		managerRule = append(managerRule, managerItem)
+	}
+
+	logs, sub, err := _Staking.contract.FilterLogs(opts, "ValidatorRegistered", valRule, managerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingValidatorRegisteredIterator{contract: _Staking.contract, event: "ValidatorRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchValidatorRegistered is a free log subscription operation binding the contract event 0xa89c42d28da99880ab0a25764963efb2991ebf2e19b963718698ecc33c1ee602.
+//
+// Solidity: event ValidatorRegistered(address indexed val, address indexed manager, uint256 commissionRate, uint256 stake, uint8 st)
+func (_Staking *StakingFilterer) WatchValidatorRegistered(opts *bind.WatchOpts, sink chan<- *StakingValidatorRegistered, val []common.Address, manager []common.Address) (event.Subscription, error) {
+
+	var valRule []interface{}
+	for _, valItem := range val {
+		valRule = append(valRule, valItem)
+	}
+	var managerRule []interface{}
+	for _, managerItem := range manager {
+		managerRule = append(managerRule, managerItem)
+	}
+
+	logs, sub, err := _Staking.contract.WatchLogs(opts, "ValidatorRegistered", valRule, managerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(StakingValidatorRegistered)
+				if err := _Staking.contract.UnpackLog(event, "ValidatorRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValidatorRegistered is a log parse operation binding the contract event 0xa89c42d28da99880ab0a25764963efb2991ebf2e19b963718698ecc33c1ee602.
+//
+// Solidity: event ValidatorRegistered(address indexed val, address indexed manager, uint256 commissionRate, uint256 stake, uint8 st)
+func (_Staking *StakingFilterer) ParseValidatorRegistered(log types.Log) (*StakingValidatorRegistered, error) {
+	event := new(StakingValidatorRegistered)
+	if err := _Staking.contract.UnpackLog(event, "ValidatorRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,71 @@
+package system
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EventCategory groups the indexed system contract events.
+type EventCategory uint8
+
+const (
+	GovernanceEvents EventCategory = iota // Administration and permission changes
+	PunishEvents                          // Validator punishments
+)
+
+// Event is a system contract event tracked by the system event index. Its
+// position in Events is the identifier stored in the index, so new events
+// must only ever be appended.
+type Event struct {
+	Contract common.Address
+	Name     string
+	Category EventCategory
+}
+
+// Events lists the indexed system contract events.
+//
+// Note the system contracts don't emit a ProposalExecuted event, governance
+// decisions are instead visible through admin and permission changes.
+var Events = []Event{
+	{StakingContract, "AdminChanging", GovernanceEvents},
+	{StakingContract, "AdminChanged", GovernanceEvents},
+	{StakingContract, "PermissionLess", GovernanceEvents},
+	{StakingContract, "ValidatorRegistered", GovernanceEvents},
+	{GenesisLockContract, "RightsChanging", GovernanceEvents},
+	{GenesisLockContract, "RightsAccepted", GovernanceEvents},
+	{StakingContract, "LogLazyPunishValidator", PunishEvents},
+	{StakingContract, "LogDoubleSignPunishValidator", PunishEvents},
+}
+
+// eventIds maps the contract and event topic to the position in Events.
+var eventIds = make(map[common.Address]map[common.Hash]uint8)
+
+func init() {
+	for i, event := range Events {
+		if eventIds[event.Contract] == nil {
+			eventIds[event.Contract] = make(map[common.Hash]uint8)
+		}
+		eventIds[event.Contract][event.ABI().ID] = uint8(i)
+	}
+}
+
+// ABI returns the abi definition of the event.
+func (e Event) ABI() abi.Event {
+	return ABI(e.Contract).Events[e.Name]
+}
+
+// LookupEvent returns the position in Events of the event with the given
+// signature topic emitted by the given contract.
+func LookupEvent(contract common.Address, topic common.Hash) (uint8, bool) {
+	id, ok := eventIds[contract][topic]
+	return id, ok
+}
+
+// EventContracts returns the system contracts emitting indexed events.
+func EventContracts() []common.Address {
+	contracts := make([]common.Address, 0, len(eventIds))
+	for contract := range eventIds {
+		contracts = append(contracts, contract)
+	}
+	return contracts
+}
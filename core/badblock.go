@@ -0,0 +1,374 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/internal/version"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+const (
+	// badBlockBundleLimit is the maximum number of forensic bundles retained
+	// on disk, the oldest ones are removed once the limit is exceeded.
+	badBlockBundleLimit = 64
+
+	// badBlockQueueSize is the number of rejected blocks waiting for their
+	// bundle to be captured, the ones rejected while it's full are skipped.
+	badBlockQueueSize = 4
+)
+
+// errBadBlockBundleNotFound is returned if no bundle was captured for a block.
+var errBadBlockBundleNotFound = errors.New("bad block bundle not found")
+
+// engineSnapshotter is implemented by consensus engines which can dump their
+// internal authorization snapshot, e.g. Turbo.
+type engineSnapshotter interface {
+	SnapshotAt(chain consensus.ChainHeaderReader, number uint64, hash common.Hash) (interface{}, error)
+}
+
+// BadBlockBundle is the forensic record captured when a block fails import.
+type BadBlockBundle struct {
+	Number   uint64        `json:"number"`
+	Hash     common.Hash   `json:"hash"`
+	Error    string        `json:"error"`
+	Captured uint64        `json:"captured"`
+	Platform string        `json:"platform"`
+	BlockRLP hexutil.Bytes `json:"blockRlp"`
+
+	Parent         BadBlockParent        `json:"parent"`
+	Receipts       *BadBlockReceiptsDiff `json:"receipts,omitempty"`
+	FailingTx      *BadBlockTxTrace      `json:"failingTx,omitempty"`
+	EngineSnapshot json.RawMessage       `json:"engineSnapshot,omitempty"`
+}
+
+// BadBlockParent describes the availability of the rejected block's parent.
+type BadBlockParent struct {
+	Hash           common.Hash `json:"hash"`
+	Known          bool        `json:"known"`
+	Root           common.Hash `json:"root"`
+	StateAvailable bool        `json:"stateAvailable"`
+}
+
+// BadBlockReceiptsDiff compares the locally produced receipts against the
+// commitments in the rejected block's header, and the receipts of the import
+// against the ones of the replay one by one.
+type BadBlockReceiptsDiff struct {
+	Partial             bool                       `json:"partial"`
+	ReceiptHash         common.Hash                `json:"receiptHash"`
+	ExpectedReceiptHash common.Hash                `json:"expectedReceiptHash"`
+	GasUsed             uint64                     `json:"gasUsed"`
+	ExpectedGasUsed     uint64                     `json:"expectedGasUsed"`
+	BloomMatch          bool                       `json:"bloomMatch"`
+	Receipts            types.Receipts             `json:"receipts"`
+	Mismatches          []*BadBlockReceiptMismatch `json:"mismatches,omitempty"`
+}
+
+// BadBlockReceiptMismatch is a receipt field differing between the import of
+// the rejected block and its replay, revealing a non-deterministic execution.
+type BadBlockReceiptMismatch struct {
+	Index    int         `json:"index"`
+	TxHash   common.Hash `json:"txHash"`
+	Field    string      `json:"field"`
+	Imported string      `json:"imported"`
+	Replayed string      `json:"replayed"`
+}
+
+// BadBlockTxTrace is the action trace of the transaction that failed to apply.
+type BadBlockTxTrace struct {
+	Index   int             `json:"index"`
+	Hash    common.Hash     `json:"hash"`
+	Error   string          `json:"error"`
+	Actions []*types.Action `json:"actions,omitempty"`
+}
+
+// badBlockTask is a rejected block waiting for its bundle to be captured.
+type badBlockTask struct {
+	block    *types.Block
+	receipts types.Receipts
+	reason   error
+}
+
+// captureBadBlock queues the capture of the forensic bundle of a rejected block.
+// The capture re-executes the block, so it's done in the background not to
+// hold the import, and skipped if too many blocks are waiting already.
+func (bc *BlockChain) captureBadBlock(block *types.Block, receipts types.Receipts, reason error) {
+	if bc.badBlockCh == nil {
+		return
+	}
+	select {
+	case bc.badBlockCh <- &badBlockTask{block: block, receipts: receipts, reason: reason}:
+	default:
+		log.Warn("Skipping bad block bundle, too many queued", "number", block.NumberU64(), "hash", block.Hash())
+	}
+}
+
+// badBlockLoop captures the bundles of the queued rejected blocks and writes
+// them into the configured bundle directory. Any failure is logged only,
+// bundle capture must never interfere with block import.
+func (bc *BlockChain) badBlockLoop() {
+	defer bc.wg.Done()
+
+	for {
+		select {
+		case task := <-bc.badBlockCh:
+			block := task.block
+			bundle, err := bc.newBadBlockBundle(block, task.receipts, task.reason)
+			if err != nil {
+				log.Warn("Failed to assemble bad block bundle", "number", block.NumberU64(), "hash", block.Hash(), "err", err)
+				continue
+			}
+			path, err := writeBadBlockBundle(bc.cacheConfig.BadBlockDir, bundle)
+			if err != nil {
+				log.Warn("Failed to write bad block bundle", "number", block.NumberU64(), "hash", block.Hash(), "err", err)
+				continue
+			}
+			log.Info("Captured bad block bundle", "number", block.NumberU64(), "hash", block.Hash(), "path", path)
+		case <-bc.quit:
+			return
+		}
+	}
+}
+
+// newBadBlockBundle collects the diagnostics of a rejected block. If the parent
+// state is available the block is replayed with action tracing to find the
+// failing transaction and to produce the local receipts.
+func (bc *BlockChain) newBadBlockBundle(block *types.Block, receipts types.Receipts, reason error) (*BadBlockBundle, error) {
+	blob, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return nil, err
+	}
+	vsn, vcs := version.Info()
+	platform := fmt.Sprintf("%s %s %s %s", vsn, runtime.Version(), runtime.GOARCH, runtime.GOOS)
+	if vcs != "" {
+		platform += " " + vcs
+	}
+	bundle := &BadBlockBundle{
+		Number:   block.NumberU64(),
+		Hash:     block.Hash(),
+		Captured: uint64(time.Now().Unix()),
+		Platform: platform,
+		BlockRLP: blob,
+		Parent:   BadBlockParent{Hash: block.ParentHash()},
+	}
+	if reason != nil {
+		bundle.Error = reason.Error()
+	}
+	if block.NumberU64() == 0 {
+		return bundle, nil
+	}
+	parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return bundle, nil
+	}
+	bundle.Parent.Known = true
+	bundle.Parent.Root = parent.Root
+	bundle.Parent.StateAvailable = bc.HasState(parent.Root)
+
+	if engine, ok := bc.engine.(engineSnapshotter); ok {
+		if snap, err := engine.SnapshotAt(bc, parent.Number.Uint64(), parent.Hash()); err == nil {
+			bundle.EngineSnapshot, _ = json.Marshal(snap)
+		}
+	}
+	var (
+		replayed types.Receipts
+		partial  bool
+	)
+	if bundle.Parent.StateAvailable {
+		if statedb, err := bc.StateAt(parent.Root); err == nil {
+			replayed, bundle.FailingTx, partial = bc.replayBadBlock(block, statedb)
+		}
+	}
+	imported := receipts
+	if imported == nil {
+		receipts = replayed
+	} else {
+		partial = false
+	}
+	if receipts != nil {
+		diff := &BadBlockReceiptsDiff{
+			Partial:             partial,
+			ReceiptHash:         types.DeriveSha(receipts, trie.NewStackTrie(nil)),
+			ExpectedReceiptHash: block.ReceiptHash(),
+			ExpectedGasUsed:     block.GasUsed(),
+			BloomMatch:          types.CreateBloom(receipts) == block.Bloom(),
+			Receipts:            receipts,
+		}
+		if len(receipts) > 0 {
+			diff.GasUsed = receipts[len(receipts)-1].CumulativeGasUsed
+		}
+		if imported != nil && replayed != nil {
+			diff.Mismatches = diffBadBlockReceipts(receipts, replayed)
+		}
+		bundle.Receipts = diff
+	}
+	return bundle, nil
+}
+
+// replayBadBlock re-executes a rejected block on top of the given parent state
+// with an action logger attached. It returns the receipts produced, only up to
+// the first failing transaction if partial is set, along with the trace of that
+// transaction. The failing trace is nil if every transaction applied cleanly,
+// in which case the fault lies with the finalization or post-state validation.
+func (bc *BlockChain) replayBadBlock(block *types.Block, statedb *state.StateDB) (receipts types.Receipts, failing *BadBlockTxTrace, partial bool) {
+	var (
+		tracer  = vm.NewActionLogger()
+		hooks   = tracer.Hooks()
+		applied = make(types.Receipts, 0)
+		started common.Hash // Transaction being applied
+	)
+	hooks.OnTxStart = func(_ *tracing.VMContext, tx *types.Transaction, from common.Address) {
+		tracer.Clear()
+		started = tx.Hash()
+	}
+	hooks.OnTxEnd = func(receipt *types.Receipt, err error) {
+		if err == nil {
+			applied = append(applied, receipt)
+		}
+	}
+	receipts, _, _, _, err := bc.processor.Process(context.Background(), block, statedb, vm.Config{Tracer: hooks})
+	if err == nil {
+		return receipts, nil, false
+	}
+	var txErr *txApplyError
+	if errors.As(err, &txErr) {
+		failing = &BadBlockTxTrace{Index: txErr.index, Hash: txErr.hash, Error: txErr.err.Error()}
+		if started == txErr.hash {
+			actions, _ := tracer.GetResult()
+			if len(actions) > 0 && actions[0].OpCode != "" { // Empty if rejected before reaching the EVM
+				failing.Actions = actions
+			}
+		}
+	}
+	return applied, failing, true
+}
+
+// diffBadBlockReceipts compares the receipts of the import of a rejected block
+// with the ones of its replay, field by field.
+func diffBadBlockReceipts(imported, replayed types.Receipts) []*BadBlockReceiptMismatch {
+	var diffs []*BadBlockReceiptMismatch
+	if len(imported) != len(replayed) {
+		diffs = append(diffs, &BadBlockReceiptMismatch{
+			Index:    -1,
+			Field:    "count",
+			Imported: fmt.Sprint(len(imported)),
+			Replayed: fmt.Sprint(len(replayed)),
+		})
+	}
+	for i := 0; i < len(imported) && i < len(replayed); i++ {
+		have, want := imported[i], replayed[i]
+		for _, field := range []struct {
+			name       string
+			have, want string
+		}{
+			{"txHash", have.TxHash.Hex(), want.TxHash.Hex()},
+			{"status", fmt.Sprint(have.Status), fmt.Sprint(want.Status)},
+			{"cumulativeGasUsed", fmt.Sprint(have.CumulativeGasUsed), fmt.Sprint(want.CumulativeGasUsed)},
+			{"gasUsed", fmt.Sprint(have.GasUsed), fmt.Sprint(want.GasUsed)},
+			{"contractAddress", have.ContractAddress.Hex(), want.ContractAddress.Hex()},
+			{"logs", fmt.Sprint(len(have.Logs)), fmt.Sprint(len(want.Logs))},
+			{"logsBloom", hexutil.Encode(have.Bloom[:]), hexutil.Encode(want.Bloom[:])},
+		} {
+			if field.have != field.want {
+				diffs = append(diffs, &BadBlockReceiptMismatch{
+					Index:    i,
+					TxHash:   want.TxHash,
+					Field:    field.name,
+					Imported: field.have,
+					Replayed: field.want,
+				})
+			}
+		}
+	}
+	return diffs
+}
+
+// badBlockBundlePath returns the file a block's forensic bundle is stored in.
+func badBlockBundlePath(dir string, hash common.Hash) string {
+	return filepath.Join(dir, hash.Hex()+".json")
+}
+
+// writeBadBlockBundle persists the bundle and prunes the directory down to the
+// most recent badBlockBundleLimit entries.
+func writeBadBlockBundle(dir string, bundle *BadBlockBundle) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	blob, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := badBlockBundlePath(dir, bundle.Hash)
+	if err := os.WriteFile(path, blob, 0644); err != nil {
+		return "", err
+	}
+	pruneBadBlockBundles(dir, badBlockBundleLimit)
+	return path, nil
+}
+
+// pruneBadBlockBundles deletes the oldest bundles in dir beyond limit.
+func pruneBadBlockBundles(dir string, limit int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	type bundleFile struct {
+		name    string
+		modTime time.Time
+	}
+	var files []bundleFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, bundleFile{entry.Name(), info.ModTime()})
+	}
+	if len(files) <= limit {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, file := range files[:len(files)-limit] {
+		os.Remove(filepath.Join(dir, file.name))
+	}
+}
+
+// ReadBadBlockBundle loads the JSON encoded forensic bundle captured for the
+// given block hash, if any.
+func (bc *BlockChain) ReadBadBlockBundle(hash common.Hash) (json.RawMessage, error) {
+	dir := bc.cacheConfig.BadBlockDir
+	if dir == "" {
+		return nil, errors.New("bad block bundle capture is disabled")
+	}
+	blob, err := os.ReadFile(badBlockBundlePath(dir, hash))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errBadBlockBundleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(blob) {
+		return nil, fmt.Errorf("corrupted bad block bundle %s", hash.Hex())
+	}
+	return blob, nil
+}
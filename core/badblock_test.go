@@ -0,0 +1,144 @@
+package core
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestBadBlockBundlePruning(t *testing.T) {
+	dir := t.TempDir()
+
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		bundle := &BadBlockBundle{Number: uint64(i), Hash: common.Hash{byte(i)}}
+		path, err := writeBadBlockBundle(dir, bundle)
+		if err != nil {
+			t.Fatalf("failed to write bundle %d: %v", i, err)
+		}
+		stamp := base.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(path, stamp, stamp); err != nil {
+			t.Fatalf("failed to set bundle time: %v", err)
+		}
+	}
+	pruneBadBlockBundles(dir, 3)
+
+	for i := 0; i < 5; i++ {
+		_, err := os.Stat(badBlockBundlePath(dir, common.Hash{byte(i)}))
+		if exist := err == nil; exist != (i >= 2) {
+			t.Errorf("bundle %d: existence mismatch, have %v", i, exist)
+		}
+	}
+}
+
+// Tests that the bundles of the rejected blocks are captured in the background,
+// recording the failing transaction or the replayed receipts.
+func TestCaptureBadBlock(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		config = DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	)
+	config.BadBlockDir = t.TempDir()
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), config, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	newTx := func(nonce uint64) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTransaction(nonce, common.Address{0x01}, big.NewInt(1), params.TxGas, big.NewInt(params.GWei), nil), signer, key)
+		return tx
+	}
+	// The receipts are decoded loosely, the bundled ones miss their block fields
+	type bundleSummary struct {
+		FailingTx *BadBlockTxTrace `json:"failingTx"`
+		Receipts  *struct {
+			Partial    bool                      `json:"partial"`
+			Receipts   []json.RawMessage         `json:"receipts"`
+			Mismatches []BadBlockReceiptMismatch `json:"mismatches"`
+		} `json:"receipts"`
+	}
+	capture := func(block *types.Block) *bundleSummary {
+		if _, err := chain.InsertChain(types.Blocks{block}); err == nil {
+			t.Fatal("bad block imported")
+		}
+		for i := 0; i < 100; i++ {
+			if blob, err := chain.ReadBadBlockBundle(block.Hash()); err == nil {
+				var bundle bundleSummary
+				if err := json.Unmarshal(blob, &bundle); err != nil {
+					t.Fatalf("failed to decode bundle: %v", err)
+				}
+				return &bundle
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		t.Fatal("bundle not captured")
+		return nil
+	}
+	// A transaction failing to apply is traced
+	bundle := capture(GenerateBadBlock(chain.Genesis(), ethash.NewFaker(), types.Transactions{newTx(5)}, gspec.Config))
+	if bundle.FailingTx == nil || bundle.FailingTx.Index != 0 || bundle.FailingTx.Hash != newTx(5).Hash() {
+		t.Fatalf("failing transaction mismatch: have %+v", bundle.FailingTx)
+	}
+	if bundle.Receipts == nil || !bundle.Receipts.Partial || len(bundle.Receipts.Receipts) != 0 {
+		t.Fatalf("partial receipts mismatch: have %+v", bundle.Receipts)
+	}
+	// A block failing the post-state validation is replayed into the same receipts
+	bundle = capture(GenerateBadBlock(chain.Genesis(), ethash.NewFaker(), types.Transactions{newTx(0)}, gspec.Config))
+	if bundle.FailingTx != nil {
+		t.Fatalf("unexpected failing transaction: %+v", bundle.FailingTx)
+	}
+	if bundle.Receipts == nil || bundle.Receipts.Partial || len(bundle.Receipts.Receipts) != 1 {
+		t.Fatalf("receipts mismatch: have %+v", bundle.Receipts)
+	}
+	if len(bundle.Receipts.Mismatches) != 0 {
+		t.Fatalf("replayed receipts mismatch: have %+v", bundle.Receipts.Mismatches)
+	}
+}
+
+func TestDiffBadBlockReceipts(t *testing.T) {
+	newReceipts := func() types.Receipts {
+		return types.Receipts{
+			{TxHash: common.Hash{0x01}, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, GasUsed: 21000},
+			{TxHash: common.Hash{0x02}, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 42000, GasUsed: 21000},
+		}
+	}
+	if diffs := diffBadBlockReceipts(newReceipts(), newReceipts()); len(diffs) != 0 {
+		t.Fatalf("identical receipts differ: %+v", diffs)
+	}
+	replayed := newReceipts()
+	replayed[1].Status = types.ReceiptStatusFailed
+	replayed[1].Logs = []*types.Log{{}}
+	replayed = append(replayed, &types.Receipt{TxHash: common.Hash{0x03}})
+
+	diffs := diffBadBlockReceipts(newReceipts(), replayed)
+	want := []BadBlockReceiptMismatch{
+		{Index: -1, Field: "count", Imported: "2", Replayed: "3"},
+		{Index: 1, TxHash: common.Hash{0x02}, Field: "status", Imported: "1", Replayed: "0"},
+		{Index: 1, TxHash: common.Hash{0x02}, Field: "logs", Imported: "0", Replayed: "1"},
+	}
+	if len(diffs) != len(want) {
+		t.Fatalf("mismatch count: have %d, want %d", len(diffs), len(want))
+	}
+	for i, diff := range diffs {
+		if *diff != want[i] {
+			t.Errorf("mismatch %d: have %+v, want %+v", i, *diff, want[i])
+		}
+	}
+}
@@ -17,6 +17,7 @@
 package core
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"math/big"
 	"testing"
@@ -329,3 +330,143 @@ func benchReadChain(b *testing.B, full bool, count uint64) {
 		db.Close()
 	}
 }
+
+func BenchmarkProcessWithTraceAction0(b *testing.B) {
+	benchProcessTraced(b, 0)
+}
+func BenchmarkProcessWithTraceAction1(b *testing.B) {
+	benchProcessTraced(b, 1)
+}
+func BenchmarkProcessWithTraceAction2(b *testing.B) {
+	benchProcessTraced(b, 2)
+}
+
+const (
+	tracedBenchBlocks  = 8   // Number of blocks processed per benchmark iteration
+	tracedBenchTxs     = 200 // Number of transactions per block
+	tracedBenchSenders = 64  // Number of accounts sending the transactions
+)
+
+var (
+	tracedBenchToken    = common.HexToAddress("0x000000000000000000000000000000000000aa01")
+	tracedBenchRouter   = common.HexToAddress("0x000000000000000000000000000000000000aa02")
+	tracedBenchReverter = common.HexToAddress("0x000000000000000000000000000000000000aa03")
+	tracedBenchPayee    = common.HexToAddress("0x000000000000000000000000000000000000bb01")
+
+	// tracedBenchCode are the contracts called by the traced benchmark blocks:
+	// a token incrementing a counter of the caller, a router forwarding half of
+	// the received value to a payee and calling the token, and a contract
+	// calling the token before reverting.
+	tracedBenchCode = map[common.Address][]byte{
+		tracedBenchToken:    common.FromHex("0x3354600101335500"),
+		tracedBenchRouter:   common.FromHex("0x60006000600060006002340473" + tracedBenchPayee.Hex()[2:] + "5af150" + "6000600060006000600073" + tracedBenchToken.Hex()[2:] + "5af15000"),
+		tracedBenchReverter: common.FromHex("0x6000600060006000600073" + tracedBenchToken.Hex()[2:] + "5af15060006000fd"),
+	}
+)
+
+// tracedBenchKey returns the deterministic key of a transaction sender of the
+// traced benchmark blocks.
+func tracedBenchKey(i int) *ecdsa.PrivateKey {
+	key, _ := crypto.ToECDSA(crypto.Keccak256([]byte("bench"), big.NewInt(int64(i)).Bytes()))
+	return key
+}
+
+// makeTracedBenchChain creates a chain of blocks mixing transactions as seen on
+// mainnet: value transfers, token calls, contract calls moving value through
+// nested calls, reverted calls and contract creations. The chain is the same
+// across runs, so the benchmarks are reproducible.
+func makeTracedBenchChain(b *testing.B) (*BlockChain, []*types.Block) {
+	var (
+		keys  = make([]*ecdsa.PrivateKey, tracedBenchSenders)
+		addrs = make([]common.Address, tracedBenchSenders)
+		alloc = make(types.GenesisAlloc)
+	)
+	for i := range keys {
+		keys[i] = tracedBenchKey(i)
+		addrs[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+		alloc[addrs[i]] = types.Account{Balance: benchRootFunds}
+	}
+	for addr, code := range tracedBenchCode {
+		alloc[addr] = types.Account{Balance: new(big.Int), Code: code}
+	}
+	gspec := &Genesis{
+		Config:   params.TestChainConfig,
+		GasLimit: 30_000_000,
+		Alloc:    alloc,
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), tracedBenchBlocks, func(i int, gen *BlockGen) {
+		signer := gen.Signer()
+		for j := 0; j < tracedBenchTxs; j++ {
+			var (
+				sender = (i*tracedBenchTxs + j) % tracedBenchSenders
+				tx     = &types.LegacyTx{
+					Nonce:    gen.TxNonce(addrs[sender]),
+					GasPrice: gen.BaseFee(),
+				}
+			)
+			switch j % 10 {
+			case 0, 1, 2, 3:
+				to := addrs[(sender+1)%tracedBenchSenders]
+				tx.To, tx.Value, tx.Gas = &to, big.NewInt(1e15), params.TxGas
+			case 4, 5, 6:
+				tx.To, tx.Gas = &tracedBenchToken, 50000
+			case 7, 8:
+				tx.To, tx.Value, tx.Gas = &tracedBenchRouter, big.NewInt(2e15), 100000
+			default:
+				if i%2 == 0 {
+					tx.To, tx.Gas = &tracedBenchReverter, 60000
+				} else {
+					tx.Data, tx.Gas = []byte{byte(vm.STOP)}, 60000
+				}
+			}
+			gen.AddTx(types.MustSignNewTx(keys[sender], signer, tx))
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		b.Fatalf("failed to create chain: %v", err)
+	}
+	if n, err := chain.InsertChain(blocks); err != nil {
+		b.Fatalf("failed to insert block %d: %v", n, err)
+	}
+	return chain, blocks
+}
+
+// benchProcessTraced measures the processing of the traced benchmark blocks
+// with the given action tracing level, reporting the gas processed per second
+// and the actions collected per iteration along the usual metrics, so the
+// results can be compared across releases with benchstat.
+func benchProcessTraced(b *testing.B, traceAction int) {
+	chain, blocks := makeTracedBenchChain(b)
+	defer chain.Stop()
+
+	var (
+		cfg     = vm.Config{TraceAction: traceAction}
+		gas     uint64
+		actions int
+	)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, block := range blocks {
+			b.StopTimer()
+			statedb, err := chain.StateAt(chain.GetHeaderByHash(block.ParentHash()).Root)
+			if err != nil {
+				b.Fatalf("failed to retrieve parent state: %v", err)
+			}
+			b.StartTimer()
+
+			_, _, internalTxs, used, err := chain.Processor().Process(context.Background(), block, statedb, cfg)
+			if err != nil {
+				b.Fatalf("failed to process block %d: %v", block.NumberU64(), err)
+			}
+			gas += used
+			for _, itx := range internalTxs {
+				actions += len(itx.Actions)
+			}
+		}
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(gas)/1e6/b.Elapsed().Seconds(), "mgas/s")
+	b.ReportMetric(float64(actions)/float64(b.N), "actions/op")
+}
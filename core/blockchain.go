@@ -18,6 +18,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -43,6 +44,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/otel"
 	"github.com/ethereum/go-ethereum/internal/syncx"
 	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
@@ -102,7 +104,9 @@ const (
 	receiptsCacheLimit  = 32
 	txLookupCacheLimit  = 1024
 	maxFutureBlocks     = 256
-	maxTimeFutureBlocks = 30
+	maxTimeFutureBlocks = 30 * time.Second
+
+	receiptLogPruneInterval = 10 * time.Minute // Interval between the prunings of the unreferenced receipt log data
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	//
@@ -135,6 +139,7 @@ const (
 type CacheConfig struct {
 	TrieCleanLimit      int           // Memory allowance (MB) to use for caching trie nodes in memory
 	TrieCleanNoPrefetch bool          // Whether to disable heuristic state prefetching for followup blocks
+	TrieCleanRPCShare   int           // Percentage of the clean trie cache reserved to the RPC state reads, hash scheme only (0 = shared)
 	TrieDirtyLimit      int           // Memory limit (MB) at which to start flushing dirty trie nodes to disk
 	TrieDirtyDisabled   bool          // Whether to disable trie write caching and GC altogether (archive node)
 	TrieTimeLimit       time.Duration // Time limit after which to flush the current in-memory trie to disk
@@ -143,6 +148,12 @@ type CacheConfig struct {
 	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
 	StateScheme         string        // Scheme used to store ethereum states and merkle tree nodes on top
 
+	ReceiptDedup bool   // Whether to store large receipt log data deduplicated
+	TraceArchive bool   // Whether to store the action traces in the dictionary compressed archive form
+	BadBlockDir  string // Directory to capture forensic bundles of rejected blocks into (empty = disabled)
+
+	StateExpiryEpoch uint64 // Length in blocks of the epochs the state last accesses are recorded in (0 = disabled)
+
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
 }
@@ -154,8 +165,10 @@ func (c *CacheConfig) triedbConfig(isVerkle bool) *triedb.Config {
 		IsVerkle:  isVerkle,
 	}
 	if c.StateScheme == rawdb.HashScheme {
+		serving := c.TrieCleanLimit * c.TrieCleanRPCShare / 100
 		config.HashDB = &hashdb.Config{
-			CleanCacheSize: c.TrieCleanLimit * 1024 * 1024,
+			CleanCacheSize:   (c.TrieCleanLimit - serving) * 1024 * 1024,
+			ServingCacheSize: serving * 1024 * 1024,
 		}
 	}
 	if c.StateScheme == rawdb.PathScheme {
@@ -218,8 +231,10 @@ type BlockChain struct {
 	gcproc        time.Duration                    // Accumulates canonical block processing for trie dumping
 	lastWrite     uint64                           // Last block when the state was flushed
 	flushInterval atomic.Int64                     // Time interval (processing time) after which to flush a state
+	futureWindow  atomic.Int64                     // Time ahead of the local clock the blocks are queued for later import
 	triedb        *triedb.Database                 // The database handler for maintaining trie nodes.
 	stateCache    state.Database                   // State database to reuse between imports (contains state cache)
+	servingCache  state.Database                   // State database serving the RPC state reads
 	txIndexer     *txIndexer                       // Transaction indexer, might be nil if not enabled
 
 	// txLookupLimit is the maximum number of blocks from head whose tx indices
@@ -234,6 +249,7 @@ type BlockChain struct {
 	chainFeed                        event.Feed
 	chainSideFeed                    event.Feed
 	chainHeadFeed                    event.Feed
+	chainReorgFeed                   event.Feed
 	logsFeed                         event.Feed
 	blockProcFeed                    event.Feed
 	newAttestationFeed               event.Feed
@@ -258,6 +274,9 @@ type BlockChain struct {
 	txLookupLock  sync.RWMutex
 	txLookupCache *lru.Cache[common.Hash, txLookup]
 	futureBlocks  *lru.Cache[common.Hash, *types.Block] // future blocks are blocks added for later processing
+	traceDict     *rawdb.TraceDictionary                // dictionary of the archived action traces, nil if not archiving
+	badBlockCh    chan *badBlockTask                    // rejected blocks to capture the bundle of, nil if disabled
+	stateAccesses *stateAccessRecorder                  // recorder of the state last accesses, nil if not researching state expiry
 
 	wg            sync.WaitGroup
 	quit          chan struct{} // shutdown signal, closed in Stop.
@@ -283,6 +302,7 @@ type BlockChain struct {
 	HistoryAttessCache       *lru.Cache[uint64, *types.HistoryAttestations]
 	CasperFFGHistoryCache    *lru.Cache[interface{}, types.CasperFFGHistoryList]
 	BlockStatusCache         *lru.Cache[uint64, *types.BlockStatus]
+	blockStatusFreezer       ethdb.AncientStore // Statuses of finalized blocks migrated out of the key-value store, nil if none
 
 	currentEpochCheckBps atomic.Value // types.EpochCheckBps
 
@@ -310,6 +330,9 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
 		return nil, genesisErr
 	}
+	if err := vm.CheckPrecompiles(chainConfig); err != nil {
+		return nil, err
+	}
 	log.Info("")
 	log.Info(strings.Repeat("-", 153))
 	for _, line := range strings.Split(chainConfig.Description(), "\n") {
@@ -336,9 +359,19 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		vmConfig:      vmConfig,
 		logger:        vmConfig.Tracer,
 	}
+	if cacheConfig.TraceArchive {
+		bc.traceDict = rawdb.NewTraceDictionary(db)
+		log.Info("Archiving action traces", "dictionary", bc.traceDict.Len())
+	}
+	if cacheConfig.StateExpiryEpoch > 0 {
+		bc.stateAccesses = newStateAccessRecorder(cacheConfig.StateExpiryEpoch)
+		log.Warn("Recording state accesses for state expiry research", "epoch", cacheConfig.StateExpiryEpoch)
+	}
 	bc.flushInterval.Store(int64(cacheConfig.TrieTimeLimit))
+	bc.futureWindow.Store(int64(maxTimeFutureBlocks))
 	bc.forker = NewForkChoice(bc, shouldPreserve)
 	bc.stateCache = state.NewDatabaseWithNodeDB(bc.db, bc.triedb)
+	bc.servingCache = state.NewDatabaseWithNodeDB(bc.db, bc.triedb.ServingView())
 	bc.validator = NewBlockValidator(chainConfig, bc, engine)
 	bc.prefetcher = newStatePrefetcher(chainConfig, bc, engine)
 	bc.processor = NewStateProcessor(chainConfig, bc, engine)
@@ -362,8 +395,11 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		bc.RecentAttessCache = lru.NewCache[uint64, *types.BlockNumAttestations](attestationsCacheLimit)
 		bc.HistoryAttessCache = lru.NewCache[uint64, *types.HistoryAttestations](historyAttessCacheLimit)
 		bc.CasperFFGHistoryCache = lru.NewCache[interface{}, types.CasperFFGHistoryList](casperFFGHistoryCacheLimit)
+		bc.loadCasperFFGJournal()
 
 		bc.BlockStatusCache = lru.NewCache[uint64, *types.BlockStatus](blockStatusCacheLimit)
+
+		bc.blockStatusFreezer = rawdb.OpenBlockStatusFreezer(bc.db)
 	}
 
 	var err error
@@ -518,6 +554,17 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	// Start future block processor.
 	bc.wg.Add(1)
 	go bc.futureBlocksLoop()
+	// Start the pruner of the deduplicated receipt log data if enabled
+	if cacheConfig.ReceiptDedup {
+		bc.wg.Add(1)
+		go bc.receiptLogPruneLoop()
+	}
+	// Start the capture of the bad block bundles if enabled
+	if cacheConfig.BadBlockDir != "" {
+		bc.badBlockCh = make(chan *badBlockTask, badBlockQueueSize)
+		bc.wg.Add(1)
+		go bc.badBlockLoop()
+	}
 	// Start attestation processor
 	// if bc.isTurboEngine {
 	// 	bc.wg.Add(1)
@@ -1177,6 +1224,11 @@ func (bc *BlockChain) stopWithoutSaving() {
 func (bc *BlockChain) Stop() {
 	bc.stopWithoutSaving()
 
+	// Ensure that the attestation histories survive the restart.
+	if bc.isTurboEngine {
+		bc.journalCasperFFG()
+	}
+
 	// Ensure that the entirety of the state snapshot is journaled to disk.
 	var snapBase common.Hash
 	if bc.snaps != nil {
@@ -1228,6 +1280,11 @@ func (bc *BlockChain) Stop() {
 	if bc.logger != nil && bc.logger.OnClose != nil {
 		bc.logger.OnClose()
 	}
+	if bc.blockStatusFreezer != nil {
+		if err := bc.blockStatusFreezer.Close(); err != nil {
+			log.Error("Failed to close block status freezer", "err", err)
+		}
+	}
 	// Close the trie database, release all the held resources as the last step.
 	if err := bc.triedb.Close(); err != nil {
 		log.Error("Failed to close trie database", "err", err)
@@ -1457,7 +1514,7 @@ func (bc *BlockChain) InsertReceiptChain(blockChain types.Blocks, receiptChain [
 			}
 			// Write all the data out into the database
 			rawdb.WriteBody(batch, block.Hash(), block.NumberU64(), block.Body())
-			rawdb.WriteReceipts(batch, block.Hash(), block.NumberU64(), receiptChain[i])
+			bc.writeReceipts(batch, block.Hash(), block.NumberU64(), receiptChain[i])
 
 			// Write everything belongs to the blocks into the database. So that
 			// we can ensure all components of body is completed(body, receipts)
@@ -1546,6 +1603,58 @@ func (bc *BlockChain) writeKnownBlock(block *types.Block) error {
 	return nil
 }
 
+// writeReceipts stores the receipts of a block, deduplicating large log data
+// if configured.
+func (bc *BlockChain) writeReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64, receipts types.Receipts) {
+	if bc.cacheConfig.ReceiptDedup {
+		rawdb.WriteDedupReceipts(db, hash, number, receipts)
+		return
+	}
+	rawdb.WriteReceipts(db, hash, number, receipts)
+}
+
+// writeInternalTxs stores the internal transactions of a block, in the archive
+// form if configured.
+func (bc *BlockChain) writeInternalTxs(db ethdb.KeyValueWriter, hash common.Hash, number uint64, internalTxs types.InternalTxs) {
+	if bc.traceDict != nil {
+		rawdb.WriteArchivedInternalTxs(db, bc.traceDict, hash, number, internalTxs)
+		return
+	}
+	rawdb.WriteInternalTxs(db, hash, number, internalTxs)
+}
+
+// writeAccessDenials stores the access filter denials met by the transactions
+// of a block, if any.
+func writeAccessDenials(db ethdb.KeyValueWriter, block *types.Block, receipts types.Receipts) {
+	var denials []*types.AccessDenial
+	for _, receipt := range receipts {
+		if receipt.AccessDenial != nil {
+			denials = append(denials, receipt.AccessDenial)
+		}
+	}
+	if len(denials) > 0 {
+		rawdb.WriteAccessDenials(db, block.NumberU64(), block.Hash(), denials)
+	}
+}
+
+// pruneReceiptLogData deletes the deduplicated receipt log data no longer
+// referenced by any receipts, deleted or frozen since.
+func (bc *BlockChain) pruneReceiptLogData() {
+	stale := rawdb.StaleReceiptLogRefs(bc.db)
+	if len(stale) == 0 {
+		return
+	}
+	// The references are rechecked with the receipt writes held off, as a block
+	// might be imported again or refer to the same log data meanwhile.
+	if !bc.chainmu.TryLock() {
+		return
+	}
+	pruned := rawdb.PruneReceiptLogData(bc.db, stale)
+	bc.chainmu.Unlock()
+
+	log.Debug("Pruned receipt log data", "references", len(stale), "data", pruned)
+}
+
 // writeBlockWithState writes block, metadata and corresponding state data to the
 // database.
 func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.Receipt, internalTxs []*types.InternalTx, statedb *state.StateDB) error {
@@ -1564,9 +1673,14 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	blockBatch := bc.db.NewBatch()
 	rawdb.WriteTd(blockBatch, block.Hash(), block.NumberU64(), externTd)
 	rawdb.WriteBlock(blockBatch, block)
-	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
+	bc.writeReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
 	if len(internalTxs) > 0 {
-		rawdb.WriteInternalTxs(blockBatch, block.Hash(), block.NumberU64(), internalTxs)
+		bc.writeInternalTxs(blockBatch, block.Hash(), block.NumberU64(), internalTxs)
+	}
+	writeContractCreations(blockBatch, bc.chainConfig, block, receipts, internalTxs)
+	writeAccessDenials(blockBatch, block, receipts)
+	if bc.stateAccesses != nil {
+		bc.stateAccesses.record(bc.db, blockBatch, block, statedb.AccessedState())
 	}
 	rawdb.WritePreimages(blockBatch, statedb.Preimages())
 	if err := blockBatch.Write(); err != nil {
@@ -1671,6 +1785,9 @@ func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types
 	// Set new head.
 	if status == CanonStatTy {
 		bc.writeHeadBlock(block)
+		if bc.stateAccesses != nil {
+			bc.stateAccesses.aggregate(bc.db, block.NumberU64())
+		}
 	}
 	bc.futureBlocks.Remove(block.Hash())
 
@@ -1697,7 +1814,7 @@ func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types
 // accepted for future processing, and returns an error if the block is too far
 // ahead and was not added.
 func (bc *BlockChain) addFutureBlock(block *types.Block) error {
-	max := uint64(time.Now().Unix() + maxTimeFutureBlocks)
+	max := uint64(time.Now().Add(time.Duration(bc.futureWindow.Load())).Unix())
 	if block.Time() > max {
 		return fmt.Errorf("future block timestamp %v > allowed %v", block.Time(), max)
 	}
@@ -1710,6 +1827,12 @@ func (bc *BlockChain) addFutureBlock(block *types.Block) error {
 // the index number of the failing block as well an error describing what went
 // wrong. After insertion is done, all accumulated events will be fired.
 func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
+	return bc.InsertChainWithContext(context.Background(), chain)
+}
+
+// InsertChainWithContext is InsertChain tracing the block imports as children
+// of the span carried by ctx, if any.
+func (bc *BlockChain) InsertChainWithContext(ctx context.Context, chain types.Blocks) (int, error) {
 	// Sanity check that we have something meaningful to import
 	if len(chain) == 0 {
 		return 0, nil
@@ -1737,7 +1860,7 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 		return 0, errChainStopped
 	}
 	defer bc.chainmu.Unlock()
-	return bc.insertChain(chain, true)
+	return bc.insertChain(ctx, chain, true)
 }
 
 // insertChain is the internal implementation of InsertChain, which assumes that
@@ -1748,7 +1871,7 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 // racey behaviour. If a sidechain import is in progress, and the historic state
 // is imported, but then new canon-head is added before the actual sidechain
 // completes, then the historic state could be pruned again
-func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error) {
+func (bc *BlockChain) insertChain(ctx context.Context, chain types.Blocks, setHead bool) (int, error) {
 	// If the chain is terminating, don't even bother starting up.
 	if bc.insertStopped() {
 		return 0, nil
@@ -1835,7 +1958,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		if setHead {
 			// First block is pruned, insert as sidechain and reorg only if TD grows enough
 			log.Debug("Pruned ancestor, inserting as sidechain", "number", block.Number(), "hash", block.Hash())
-			return bc.insertSideChain(block, it)
+			return bc.insertSideChain(ctx, block, it)
 		} else {
 			// We're post-merge and the parent is pruned, try to recover the parent state
 			log.Debug("Pruned ancestor", "number", block.Number(), "hash", block.Hash())
@@ -1973,7 +2096,14 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool) (int, error)
 		}
 
 		// The traced section of block import.
-		res, err := bc.processBlock(block, statedb, start, setHead)
+		span := otel.StartAt(otel.SpanFromContext(ctx), "block.import", it.validateStart,
+			otel.Uint64("block.number", block.NumberU64()), otel.String("block.hash", block.Hash().Hex()),
+			otel.Int("block.txs", len(block.Transactions())), otel.Uint64("block.gas", block.GasUsed()))
+		otel.StartAt(span, "block.validate", it.validateStart).EndAt(it.validateEnd)
+
+		res, err := bc.processBlock(otel.ContextWithSpan(ctx, span), block, statedb, start, setHead)
+		span.RecordError(err)
+		span.End()
 		followupInterrupt.Store(true)
 		if err != nil {
 			return it.index, err
@@ -2051,7 +2181,7 @@ type blockProcessingResult struct {
 
 // processBlock executes and validates the given block. If there was no error
 // it writes the block and associated state to database.
-func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, start time.Time, setHead bool) (_ *blockProcessingResult, blockEndErr error) {
+func (bc *BlockChain) processBlock(ctx context.Context, block *types.Block, statedb *state.StateDB, start time.Time, setHead bool) (_ *blockProcessingResult, blockEndErr error) {
 	if bc.logger != nil && bc.logger.OnBlockStart != nil {
 		td := bc.GetTd(block.ParentHash(), block.NumberU64()-1)
 		bc.logger.OnBlockStart(tracing.BlockEvent{
@@ -2069,7 +2199,10 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 
 	// Process block using the parent state as reference point
 	pstart := time.Now()
-	receipts, logs, internalTxs, usedGas, err := bc.processor.Process(block, statedb, bc.vmConfig)
+	pctx, span := otel.Start(ctx, "block.execute")
+	receipts, logs, internalTxs, usedGas, err := bc.processor.Process(pctx, block, statedb, bc.vmConfig)
+	span.RecordError(err)
+	span.End()
 	if err != nil {
 		bc.reportBlock(block, receipts, err)
 		return nil, err
@@ -2077,7 +2210,11 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 	ptime := time.Since(pstart)
 
 	vstart := time.Now()
-	if err := bc.validator.ValidateState(block, statedb, receipts, usedGas); err != nil {
+	_, span = otel.Start(ctx, "block.validate_state")
+	err = bc.validator.ValidateState(block, statedb, receipts, usedGas)
+	span.RecordError(err)
+	span.End()
+	if err != nil {
 		bc.reportBlock(block, receipts, err)
 		return nil, err
 	}
@@ -2104,12 +2241,15 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 		wstart = time.Now()
 		status WriteStatus
 	)
+	_, span = otel.Start(ctx, "block.write")
 	if !setHead {
 		// Don't set the head, only insert the block
 		err = bc.writeBlockWithState(block, receipts, internalTxs, statedb)
 	} else {
 		status, err = bc.writeBlockAndSetHead(block, receipts, logs, internalTxs, statedb, false)
 	}
+	span.RecordError(err)
+	span.End()
 	if err != nil {
 		return nil, err
 	}
@@ -2132,7 +2272,7 @@ func (bc *BlockChain) processBlock(block *types.Block, statedb *state.StateDB, s
 // The method writes all (header-and-body-valid) blocks to disk, then tries to
 // switch over to the new chain if the TD exceeded the current chain.
 // insertSideChain is only used pre-merge.
-func (bc *BlockChain) insertSideChain(block *types.Block, it *insertIterator) (int, error) {
+func (bc *BlockChain) insertSideChain(ctx context.Context, block *types.Block, it *insertIterator) (int, error) {
 	var (
 		externTd  *big.Int
 		lastBlock = block
@@ -2242,7 +2382,7 @@ func (bc *BlockChain) insertSideChain(block *types.Block, it *insertIterator) (i
 		// memory here.
 		if len(blocks) >= 2048 || memory > 64*1024*1024 {
 			log.Info("Importing heavy sidechain segment", "blocks", len(blocks), "start", blocks[0].NumberU64(), "end", block.NumberU64())
-			if _, err := bc.insertChain(blocks, true); err != nil {
+			if _, err := bc.insertChain(ctx, blocks, true); err != nil {
 				return 0, err
 			}
 			blocks, memory = blocks[:0], 0
@@ -2256,7 +2396,7 @@ func (bc *BlockChain) insertSideChain(block *types.Block, it *insertIterator) (i
 	}
 	if len(blocks) > 0 {
 		log.Info("Importing sidechain segment", "start", blocks[0].NumberU64(), "end", blocks[len(blocks)-1].NumberU64())
-		return bc.insertChain(blocks, true)
+		return bc.insertChain(ctx, blocks, true)
 	}
 	return 0, nil
 }
@@ -2305,7 +2445,7 @@ func (bc *BlockChain) recoverAncestors(block *types.Block) (common.Hash, error)
 		} else {
 			b = bc.GetBlock(hashes[i], numbers[i])
 		}
-		if _, err := bc.insertChain(types.Blocks{b}, false); err != nil {
+		if _, err := bc.insertChain(context.Background(), types.Blocks{b}, false); err != nil {
 			return b.ParentHash(), err
 		}
 	}
@@ -2513,9 +2653,41 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Block) error {
 	if len(rebirthLogs) > 0 {
 		bc.logsFeed.Send(rebirthLogs)
 	}
+	if len(oldChain) > 0 && len(newChain) > 0 {
+		bc.chainReorgFeed.Send(newChainReorgEvent(commonBlock, oldChain, newChain, deletedTxs, addedTxs))
+	}
 	return nil
 }
 
+// newChainReorgEvent creates the event of a reorg, splitting the transactions
+// of the dropped blocks into the ones missing in the new chain and the ones
+// included again. The transactions of the new head aren't part of the added
+// ones, the head being written separately.
+func newChainReorgEvent(commonBlock *types.Block, oldChain, newChain types.Blocks, deletedTxs, addedTxs []common.Hash) ChainReorgEvent {
+	included := make(map[common.Hash]struct{}, len(addedTxs)+newChain[0].Transactions().Len())
+	for _, hash := range addedTxs {
+		included[hash] = struct{}{}
+	}
+	for _, tx := range newChain[0].Transactions() {
+		included[tx.Hash()] = struct{}{}
+	}
+	ev := ChainReorgEvent{
+		OldHead:    oldChain[0].Header(),
+		NewHead:    newChain[0].Header(),
+		Common:     commonBlock.Header(),
+		Dropped:    []common.Hash{},
+		Reincluded: []common.Hash{},
+	}
+	for _, hash := range deletedTxs {
+		if _, ok := included[hash]; ok {
+			ev.Reincluded = append(ev.Reincluded, hash)
+		} else {
+			ev.Dropped = append(ev.Dropped, hash)
+		}
+	}
+	return ev
+}
+
 // InsertBlockWithoutSetHead executes the block, runs the necessary verification
 // upon it and then persist the block and the associate state into the database.
 // The key difference between the InsertChain is it won't do the canonical chain
@@ -2527,7 +2699,7 @@ func (bc *BlockChain) InsertBlockWithoutSetHead(block *types.Block) error {
 	}
 	defer bc.chainmu.Unlock()
 
-	_, err := bc.insertChain(types.Blocks{block}, false)
+	_, err := bc.insertChain(context.Background(), types.Blocks{block}, false)
 	return err
 }
 
@@ -2593,6 +2765,22 @@ func (bc *BlockChain) futureBlocksLoop() {
 	}
 }
 
+// receiptLogPruneLoop periodically prunes the unreferenced receipt log data.
+func (bc *BlockChain) receiptLogPruneLoop() {
+	defer bc.wg.Done()
+
+	pruneTimer := time.NewTicker(receiptLogPruneInterval)
+	defer pruneTimer.Stop()
+	for {
+		select {
+		case <-pruneTimer.C:
+			bc.pruneReceiptLogData()
+		case <-bc.quit:
+			return
+		}
+	}
+}
+
 // skipBlock returns 'true', if the block being imported can be skipped over, meaning
 // that the block does not need to be processed but can be considered already fully 'done'.
 func (bc *BlockChain) skipBlock(err error, it *insertIterator) bool {
@@ -2637,7 +2825,9 @@ func (bc *BlockChain) skipBlock(err error, it *insertIterator) bool {
 // reportBlock logs a bad block error.
 func (bc *BlockChain) reportBlock(block *types.Block, receipts types.Receipts, err error) {
 	rawdb.WriteBadBlock(bc.db, block)
+	log.Error("Rejected bad block", log.EventKey, log.EventBadBlock, "number", block.NumberU64(), "hash", block.Hash(), "err", err)
 	log.Error(summarizeBadBlock(block, receipts, bc.Config(), err))
+	bc.captureBadBlock(block, receipts, err)
 }
 
 // summarizeBadBlock returns a string summarizing the bad block and other
@@ -2700,6 +2890,12 @@ func (bc *BlockChain) SetTrieFlushInterval(interval time.Duration) {
 	bc.flushInterval.Store(int64(interval))
 }
 
+// SetFutureBlockWindow configures how far ahead of the local clock the blocks
+// are queued for a later import instead of being rejected.
+func (bc *BlockChain) SetFutureBlockWindow(window time.Duration) {
+	bc.futureWindow.Store(int64(window))
+}
+
 // GetTrieFlushInterval gets the in-memory tries flushAlloc interval
 func (bc *BlockChain) GetTrieFlushInterval() time.Duration {
 	return time.Duration(bc.flushInterval.Load())
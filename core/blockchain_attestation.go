@@ -29,6 +29,8 @@ const (
 	catchUpDiffBlocks     = 2
 	catchUpDiffTime       = catchUpDiffBlocks * 3
 	catchUpSafetyMultiple = 2
+
+	casperFFGJournalInterval = 128 // Number of blocks between the journals of the attestation histories
 )
 
 const (
@@ -36,6 +38,10 @@ const (
 	unableSureBlockStateInterval = 100
 )
 
+// errAttestationReplay is returned if the local validator is to attest a target
+// not above its last journaled one, which it may have signed already.
+var errAttestationReplay = errors.New("attestation target already journaled")
+
 // HandleAttestation The attestations received from other P2P nodes are processed through a series of security checks.
 // The certificates that meet the inspection will be stored according to the height of the current chain plot.
 // If they are higher than the local height, they will be stored in the future cache.
@@ -90,6 +96,9 @@ func (bc *BlockChain) AttestationHandleLoop() {
 		select {
 		case ev := <-chainHeadCh:
 			bc.processAttestationOnHead(ev.Block.Header())
+			if ev.Block.NumberU64()%casperFFGJournalInterval == 0 {
+				bc.journalCasperFFG()
+			}
 		case <-bc.quit:
 			return
 		}
@@ -136,7 +145,7 @@ func (bc *BlockChain) bestAttestationToProcessed(headNum *big.Int) (*types.Attes
 		if re.Number.Uint64() <= diffNumber {
 			b := bc.GetBlockByNumber(diffNumber)
 			source := &types.RangeEdge{Number: new(big.Int).Set(b.Number()), Hash: b.Hash()}
-			return bc.TurboEngine.Attest(bc, new(big.Int).SetUint64(currentNeedHandleHeight), source, target)
+			return bc.attest(new(big.Int).SetUint64(currentNeedHandleHeight), source, target)
 		}
 	}
 	// Fast update
@@ -146,11 +155,61 @@ func (bc *BlockChain) bestAttestationToProcessed(headNum *big.Int) (*types.Attes
 		if status == types.BasJustified || status == types.BasFinalized {
 			b := bc.GetBlockByNumber(latestAttestedNum)
 			source := &types.RangeEdge{Number: new(big.Int).Set(b.Number()), Hash: b.Hash()}
-			return bc.TurboEngine.Attest(bc, new(big.Int).SetUint64(currentNeedHandleHeight), source, target)
+			return bc.attest(new(big.Int).SetUint64(currentNeedHandleHeight), source, target)
 		}
 		return nil, errors.New("the current block height does not reach the range")
 	}
-	return bc.TurboEngine.Attest(bc, new(big.Int).SetUint64(currentNeedHandleHeight), re, target)
+	return bc.attest(new(big.Int).SetUint64(currentNeedHandleHeight), re, target)
+}
+
+// attest signs an attestation of the local validator. The vote is journaled
+// before it's signed: a crash in between leaves a vote that was never sent,
+// whereas signing first could sign the same height again after a restart.
+func (bc *BlockChain) attest(headNum *big.Int, source, target *types.RangeEdge) (*types.Attestation, error) {
+	validator := bc.TurboEngine.CurrentValidator()
+	journal := rawdb.ReadAttestationJournal(bc.db, validator)
+	if n := len(journal); n > 0 && target.Number.Cmp(journal[n-1].TargetNum) <= 0 {
+		return nil, fmt.Errorf("%w: target %d, journaled %d", errAttestationReplay, target.Number, journal[n-1].TargetNum)
+	}
+	journal = append(journal, &types.CasperFFGHistory{
+		TargetNum:  new(big.Int).Set(target.Number),
+		SourceNum:  new(big.Int).Set(source.Number),
+		TargetHash: target.Hash,
+	})
+	if len(journal) > casperFFGHistoryCacheToKeep {
+		journal = journal[len(journal)-casperFFGHistoryCacheToKeep:]
+	}
+	rawdb.WriteAttestationJournal(bc.db, validator, journal)
+	return bc.TurboEngine.Attest(bc, headNum, source, target)
+}
+
+// resumeAttestation resumes the attestation of a restarted validator right after
+// its last attested checkpoint, instead of waiting for the catch-up window. It
+// returns false if there is nothing to resume: the validator never attested, or
+// was offline for long enough to have to catch up first.
+func (bc *BlockChain) resumeAttestation(head *types.Header) bool {
+	validator := bc.TurboEngine.CurrentValidator()
+	if validator == (common.Address{}) {
+		return false
+	}
+	last := rawdb.ReadLastAttestNumber(bc.db, validator)
+	if journal := rawdb.ReadAttestationJournal(bc.db, validator); len(journal) > 0 && journal[len(journal)-1].TargetNum.Cmp(last) > 0 {
+		last = new(big.Int).Set(journal[len(journal)-1].TargetNum)
+	}
+	// The number loaded on startup may predate the authorization of the validator
+	if last.Cmp(bc.currentAttestedNumber.Load().(*big.Int)) > 0 {
+		bc.currentAttestedNumber.Store(last)
+	}
+	if last.Sign() == 0 || uint64(time.Now().Unix()) > head.Time+catchUpDiffTime {
+		return false
+	}
+	if head.Number.Cmp(last) < 0 || head.Number.Uint64()-last.Uint64() > bc.TurboEngine.AttestationDelay()+unableSureBlockStateInterval {
+		return false
+	}
+	bc.firstCatchUpNumber.Store(new(big.Int).Set(last))
+	bc.TurboEngine.StartAttestation()
+	log.Info("Resuming attestation", "lastAttested", last, "currentHeight", head.Number)
+	return true
 }
 
 // Subscribe to the ChainHeadEvent message. After obtaining the new block event, first check whether it meets
@@ -158,6 +217,10 @@ func (bc *BlockChain) bestAttestationToProcessed(headNum *big.Int) (*types.Attes
 // according to the block information and the previous valid block status information, and finally carry out
 // broadcast storage and other processes
 func (bc *BlockChain) processAttestationOnHead(head *types.Header) {
+	// A restarted validator resumes from its checkpoint, if still recent enough
+	if bc.TurboEngine.AttestationStatus() == types.AttestationPending && bc.firstCatchUpNumber.Load().(*big.Int).Sign() == 0 {
+		bc.resumeAttestation(head)
+	}
 	if bc.TurboEngine.AttestationStatus() == types.AttestationPending {
 		// Give priority to judge whether it has caught up
 		firstCatchup := bc.firstCatchUpNumber.Load().(*big.Int)
@@ -413,6 +476,39 @@ func (bc *BlockChain) addOneValidAttestationForCasperFFG(signer common.Address,
 	return nil
 }
 
+// journalCasperFFG persists the attestation histories used by the Casper FFG
+// rules, so that a restarted node still detects the double and surround votes
+// spanning the restart. It runs periodically and on shutdown, so a crash loses
+// at most the histories of the last interval.
+func (bc *BlockChain) journalCasperFFG() {
+	bc.lockCasperFFGHistoryCache.Lock()
+	defer bc.lockCasperFFGHistoryCache.Unlock()
+
+	var journal []*types.CasperFFGJournal
+	for _, key := range bc.CasperFFGHistoryCache.Keys() {
+		signer, ok := key.(common.Address)
+		if !ok {
+			continue
+		}
+		if history, found := bc.CasperFFGHistoryCache.Peek(signer); found {
+			journal = append(journal, &types.CasperFFGJournal{Signer: signer, History: history})
+		}
+	}
+	rawdb.WriteCasperFFGJournal(bc.db, journal)
+	log.Debug("Journaled attestation histories", "validators", len(journal))
+}
+
+// loadCasperFFGJournal restores the last journaled attestation histories.
+func (bc *BlockChain) loadCasperFFGJournal() {
+	journal := rawdb.ReadCasperFFGJournal(bc.db)
+	for _, entry := range journal {
+		bc.CasperFFGHistoryCache.Add(entry.Signer, entry.History)
+	}
+	if len(journal) > 0 {
+		log.Info("Loaded attestation journal", "validators", len(journal))
+	}
+}
+
 // MoveAttestsCacheFutureToRecent Review and merge future data
 func (bc *BlockChain) MoveAttestsCacheFutureToRecent(num *big.Int) error {
 	bc.lockFutureAttessCache.Lock()
@@ -543,7 +639,7 @@ func (bc *BlockChain) BroadcastNewJustifiedOrFinalizedBlockToOtherNodes(bs *type
 }
 
 func (bc *BlockChain) CalculateCurrentEpochIndex(number uint64) uint64 {
-	return number / bc.chainConfig.Turbo.Epoch
+	return bc.chainConfig.Turbo.EpochIndex(number)
 }
 
 // UpdateCurrentEpochBPList Continuously update the BP list within two epoch cycles for verification when receiving the
@@ -566,9 +662,8 @@ func (bc *BlockChain) UpdateCurrentEpochBPList(hash common.Hash, number uint64)
 			lastEpochBps = last.CurrentEpochBps
 			lastEpochIndex = new(big.Int).Set(last.CurrentEpochIndex)
 		} else { //Update previous cycle
-			epoch := bc.chainConfig.Turbo.Epoch
-			if number > epoch {
-				block := bc.GetBlockByNumber(number - epoch)
+			if start := bc.chainConfig.Turbo.LastEpoch(number); start > 0 {
+				block := bc.GetBlockByNumber(start - 1)
 				lastBps, err := bc.TurboEngine.Validators(bc, block.Hash(), block.NumberU64())
 				if err != nil {
 					return err
@@ -693,45 +788,45 @@ func (bc *BlockChain) IsFiliation(parent, child *types.RangeEdge) (bool, error)
 // Check the two branches respectively. If which branch contains a block in the finalized state and the block height is higher,
 // which branch will be retained, and then compare the justified state under the same logic. If both branches fail to hit,
 // compare the difficulty of the two blocks according to the old logic
-func (bc *BlockChain) IsNeedReorgByCasperFFG(oldBlock, newBlock *types.Block) (uint8, error) {
+func (bc *BlockChain) IsNeedReorgByCasperFFG(oldHeader, newHeader *types.Header) (uint8, error) {
 	if has, err := rawdb.IsReadyReadBlockStatus(bc.db); !has || err != nil {
-		return types.BasUnknown, nil
+		return NotSure, nil
 	}
 	oldLastJustifiedNum := uint64(0)
 	newLastJustifiedNum := uint64(0)
-	if oldBlock.NumberU64() > newBlock.NumberU64() {
-		for ; oldBlock != nil && oldBlock.NumberU64() != newBlock.NumberU64(); oldBlock = bc.GetBlock(oldBlock.ParentHash(), oldBlock.NumberU64()-1) {
-			status, hash := bc.GetBlockStatusByNum(oldBlock.Number().Uint64())
-			if status != types.BasUnknown && hash == oldBlock.Hash() {
+	if oldHeader.Number.Uint64() > newHeader.Number.Uint64() {
+		for ; oldHeader != nil && oldHeader.Number.Uint64() != newHeader.Number.Uint64(); oldHeader = bc.GetHeader(oldHeader.ParentHash, oldHeader.Number.Uint64()-1) {
+			status, hash := bc.GetBlockStatusByNum(oldHeader.Number.Uint64())
+			if status != types.BasUnknown && hash == oldHeader.Hash() {
 				if status == types.BasFinalized {
 					// the old branch already exists with the finalized status flag
 					return NoNeedReorg, nil
 				} else if status == types.BasJustified && oldLastJustifiedNum == 0 {
-					oldLastJustifiedNum = oldBlock.Number().Uint64()
+					oldLastJustifiedNum = oldHeader.Number.Uint64()
 				}
 			}
 		}
 	} else {
-		for ; newBlock != nil && newBlock.NumberU64() != oldBlock.NumberU64(); newBlock = bc.GetBlock(newBlock.ParentHash(), newBlock.NumberU64()-1) {
-			status, hash := bc.GetBlockStatusByNum(newBlock.Number().Uint64())
-			if status != types.BasUnknown && hash == newBlock.Hash() {
+		for ; newHeader != nil && newHeader.Number.Uint64() != oldHeader.Number.Uint64(); newHeader = bc.GetHeader(newHeader.ParentHash, newHeader.Number.Uint64()-1) {
+			status, hash := bc.GetBlockStatusByNum(newHeader.Number.Uint64())
+			if status != types.BasUnknown && hash == newHeader.Hash() {
 				if status == types.BasFinalized {
 					return NeedReorg, nil // need to reorg
 				} else if status == types.BasJustified && newLastJustifiedNum == 0 {
-					newLastJustifiedNum = newBlock.Number().Uint64()
+					newLastJustifiedNum = newHeader.Number.Uint64()
 				}
 			}
 		}
 	}
-	if oldBlock == nil {
+	if oldHeader == nil {
 		return NotSure, fmt.Errorf("invalid old chain")
 	}
-	if newBlock == nil {
+	if newHeader == nil {
 		return NotSure, fmt.Errorf("invalid new chain")
 	}
 	for {
 		// If the common ancestor was found, bail out
-		if oldBlock.Hash() == newBlock.Hash() {
+		if oldHeader.Hash() == newHeader.Hash() {
 			if oldLastJustifiedNum < newLastJustifiedNum {
 				// The block height of the justified status of the new branch is higher than that of the old branch
 				return NeedReorg, nil
@@ -740,31 +835,31 @@ func (bc *BlockChain) IsNeedReorgByCasperFFG(oldBlock, newBlock *types.Block) (u
 			}
 			return NotSure, nil // Execute old logic
 		}
-		status, hash := bc.GetBlockStatusByNum(oldBlock.Number().Uint64())
+		status, hash := bc.GetBlockStatusByNum(oldHeader.Number.Uint64())
 		if status != types.BasUnknown {
-			if hash == oldBlock.Hash() {
+			if hash == oldHeader.Hash() {
 				if status == types.BasFinalized {
 					// the old branch already exists with the finalized status flag
 					return NoNeedReorg, nil
 				} else if status == types.BasJustified && oldLastJustifiedNum == 0 {
-					oldLastJustifiedNum = oldBlock.Number().Uint64()
+					oldLastJustifiedNum = oldHeader.Number.Uint64()
 				}
 			}
-			if hash == newBlock.Hash() {
+			if hash == newHeader.Hash() {
 				if status == types.BasFinalized {
 					return NeedReorg, nil // need to reorg
 				} else if status == types.BasJustified && newLastJustifiedNum == 0 {
-					newLastJustifiedNum = newBlock.Number().Uint64()
+					newLastJustifiedNum = newHeader.Number.Uint64()
 				}
 			}
 		}
 		// Step back with both chains
-		oldBlock = bc.GetBlock(oldBlock.ParentHash(), oldBlock.NumberU64()-1)
-		if oldBlock == nil {
+		oldHeader = bc.GetHeader(oldHeader.ParentHash, oldHeader.Number.Uint64()-1)
+		if oldHeader == nil {
 			return NotSure, fmt.Errorf("invalid old chain")
 		}
-		newBlock = bc.GetBlock(newBlock.ParentHash(), newBlock.NumberU64()-1)
-		if newBlock == nil {
+		newHeader = bc.GetHeader(newHeader.ParentHash, newHeader.Number.Uint64()-1)
+		if newHeader == nil {
 			return NotSure, fmt.Errorf("invalid new chain")
 		}
 	}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// attestingEngine is a Turbo engine only signing attestations, which refuses
+// to sign the votes not journaled beforehand.
+type attestingEngine struct {
+	consensus.TurboEngine
+
+	db        ethdb.Database
+	validator common.Address
+	status    uint8
+	signed    []uint64 // Targets of the signed attestations
+}
+
+func (e *attestingEngine) CurrentValidator() common.Address { return e.validator }
+func (e *attestingEngine) AttestationDelay() uint64         { return 2 }
+func (e *attestingEngine) AttestationStatus() uint8         { return e.status }
+func (e *attestingEngine) StartAttestation()                { e.status = types.AttestationStart }
+
+func (e *attestingEngine) Attest(chain consensus.ChainHeaderReader, headerNum *big.Int, source, target *types.RangeEdge) (*types.Attestation, error) {
+	journal := rawdb.ReadAttestationJournal(e.db, e.validator)
+	if len(journal) == 0 || journal[len(journal)-1].TargetNum.Cmp(target.Number) != 0 {
+		return nil, errors.New("vote signed before journaled")
+	}
+	e.signed = append(e.signed, target.Number.Uint64())
+	return &types.Attestation{SourceRangeEdge: source, TargetRangeEdge: target}, nil
+}
+
+// newAttestingChain creates the attestation state of a chain started on the
+// database, the validator being authorized after the startup.
+func newAttestingChain(db ethdb.Database, validator common.Address) (*BlockChain, *attestingEngine) {
+	engine := &attestingEngine{db: db}
+	bc := &BlockChain{db: db, TurboEngine: engine, isTurboEngine: true}
+	bc.currentAttestedNumber.Store(rawdb.ReadLastAttestNumber(db, engine.CurrentValidator()))
+	bc.firstCatchUpNumber.Store(new(big.Int))
+	engine.validator = validator
+	return bc, engine
+}
+
+func rangeEdge(number int64) *types.RangeEdge {
+	return &types.RangeEdge{Number: big.NewInt(number), Hash: common.BigToHash(big.NewInt(number))}
+}
+
+// Tests that a restarted validator resumes the attestation right after the last
+// vote it journaled, even if it crashed before storing it as attested, and that
+// it never signs the journaled heights again.
+func TestAttestationResume(t *testing.T) {
+	var (
+		db        = rawdb.NewMemoryDatabase()
+		validator = common.HexToAddress("0x1000")
+	)
+	bc, engine := newAttestingChain(db, validator)
+	engine.StartAttestation()
+	for _, target := range []int64{11, 12} {
+		if _, err := bc.attest(big.NewInt(target+2), rangeEdge(10), rangeEdge(target)); err != nil {
+			t.Fatalf("failed to attest %d: %v", target, err)
+		}
+	}
+	// Crash after signing the second vote, before storing it as attested
+	bc.StoreLastAttested(big.NewInt(11))
+
+	// Nothing is resumed from a stale head, or before the validator is known
+	bc, engine = newAttestingChain(db, common.Address{})
+	now := uint64(time.Now().Unix())
+	if bc.resumeAttestation(&types.Header{Number: big.NewInt(14), Time: now}) {
+		t.Fatal("attestation resumed without validator")
+	}
+	engine.validator = validator
+	if bc.resumeAttestation(&types.Header{Number: big.NewInt(14), Time: now - 3600}) {
+		t.Fatal("attestation resumed from a stale head")
+	}
+	if bc.resumeAttestation(&types.Header{Number: big.NewInt(12 + 2 + unableSureBlockStateInterval + 1), Time: now}) {
+		t.Fatal("attestation resumed past the catch-up window")
+	}
+	if !bc.resumeAttestation(&types.Header{Number: big.NewInt(14), Time: now}) {
+		t.Fatal("attestation not resumed")
+	}
+	if engine.status != types.AttestationStart {
+		t.Fatalf("attestation status mismatch: have %d, want %d", engine.status, types.AttestationStart)
+	}
+	if last := bc.currentAttestedNumber.Load().(*big.Int); last.Uint64() != 12 {
+		t.Fatalf("last attested number mismatch: have %d, want 12", last)
+	}
+	// The journaled heights are never signed again, even bypassing the checkpoint
+	for _, target := range []int64{11, 12} {
+		if _, err := bc.attest(big.NewInt(target+2), rangeEdge(10), rangeEdge(target)); !errors.Is(err, errAttestationReplay) {
+			t.Fatalf("attestation of %d replayed: %v", target, err)
+		}
+	}
+	if _, err := bc.attest(big.NewInt(15), rangeEdge(12), rangeEdge(13)); err != nil {
+		t.Fatalf("failed to attest after the restart: %v", err)
+	}
+	if len(engine.signed) != 1 || engine.signed[0] != 13 {
+		t.Fatalf("signed targets mismatch: have %v, want [13]", engine.signed)
+	}
+}
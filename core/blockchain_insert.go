@@ -99,6 +99,9 @@ type insertIterator struct {
 
 	index     int       // Current offset of the iterator
 	validator Validator // Validator to run if verification succeeds
+
+	validateStart time.Time // Time the validation of the current block started
+	validateEnd   time.Time // Time the validation of the current block finished
 }
 
 // newInsertIterator creates a new iterator based on the given blocks, which are
@@ -123,6 +126,9 @@ func (it *insertIterator) next() (*types.Block, error) {
 	}
 	// Advance the iterator and wait for verification result if not yet done
 	it.index++
+	it.validateStart = time.Now()
+	defer func() { it.validateEnd = time.Now() }()
+
 	if len(it.errors) <= it.index {
 		it.errors = append(it.errors, <-it.results)
 	}
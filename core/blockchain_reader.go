@@ -386,6 +386,13 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.StateDB, error) {
 	return state.New(root, bc.stateCache, bc.snaps)
 }
 
+// ServingStateAt returns a new mutable state based on a particular point in
+// time for serving the RPC state reads. The trie nodes it loads are cached
+// apart from the ones of the block import if the clean cache is partitioned.
+func (bc *BlockChain) ServingStateAt(root common.Hash) (*state.StateDB, error) {
+	return state.New(root, bc.servingCache, bc.snaps)
+}
+
 // Config retrieves the chain's fork configuration.
 func (bc *BlockChain) Config() *params.ChainConfig { return bc.chainConfig }
 
@@ -487,6 +494,11 @@ func (bc *BlockChain) SubscribeChainSideEvent(ch chan<- ChainSideEvent) event.Su
 	return bc.scope.Track(bc.chainSideFeed.Subscribe(ch))
 }
 
+// SubscribeChainReorgEvent registers a subscription of ChainReorgEvent.
+func (bc *BlockChain) SubscribeChainReorgEvent(ch chan<- ChainReorgEvent) event.Subscription {
+	return bc.scope.Track(bc.chainReorgFeed.Subscribe(ch))
+}
+
 // SubscribeLogsEvent registers a subscription of []*types.Log.
 func (bc *BlockChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return bc.scope.Track(bc.logsFeed.Subscribe(ch))
@@ -519,7 +531,7 @@ func (bc *BlockChain) GetBlockStatusByNum(number uint64) (uint8, common.Hash) {
 	if data, ok := bc.BlockStatusCache.Get(number); ok {
 		return data.Status, data.Hash
 	}
-	status, hash := rawdb.ReadBlockStatusByNum(bc.db, new(big.Int).SetUint64(number))
+	status, hash := rawdb.ReadBlockStatus(bc.db, bc.blockStatusFreezer, number)
 	// Cache the found status for next time and return
 	// Only deterministic data is saved, and data tracking is required only at the beginning of startup
 	if status == types.BasFinalized {
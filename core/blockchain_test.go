@@ -17,6 +17,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -163,7 +164,7 @@ func testBlockChainImport(chain types.Blocks, blockchain *BlockChain) error {
 		if err != nil {
 			return err
 		}
-		receipts, _, _, usedGas, err := blockchain.processor.Process(block, statedb, vm.Config{})
+		receipts, _, _, usedGas, err := blockchain.processor.Process(context.Background(), block, statedb, vm.Config{})
 		if err != nil {
 			blockchain.reportBlock(block, receipts, err)
 			return err
@@ -1411,6 +1412,85 @@ done:
 	}
 }
 
+// Tests that a reorg event lists the transactions of the dropped blocks, split
+// into the ones dropped and the ones included again by the new chain.
+func TestChainReorgEvent(t *testing.T) {
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{addr1: {Balance: big.NewInt(10000000000000000)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		price  = big.NewInt(2 * params.InitialBaseFee)
+	)
+	newTx := func(nonce uint64, to common.Address) *types.Transaction {
+		tx, err := types.SignTx(types.NewTransaction(nonce, to, big.NewInt(1), params.TxGas, price, nil), signer, key1)
+		if err != nil {
+			t.Fatalf("failed to create tx: %v", err)
+		}
+		return tx
+	}
+	var (
+		txA = newTx(0, common.Address{0xa})
+		txB = newTx(1, common.Address{0xb})
+		txC = newTx(1, common.Address{0xc})
+	)
+	blockchain, _ := NewBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfigWithScheme(rawdb.HashScheme), gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	defer blockchain.Stop()
+
+	_, chain, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, func(i int, gen *BlockGen) {
+		switch i {
+		case 0:
+			gen.AddTx(txA)
+		case 1:
+			gen.AddTx(txB)
+		}
+	})
+	if _, err := blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	// The replacement chain only becomes heavier with its last block.
+	_, replacementBlocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, func(i int, gen *BlockGen) {
+		switch i {
+		case 0:
+			gen.SetCoinbase(common.Address{0x1})
+			gen.AddTx(txA)
+		case 2:
+			gen.OffsetTime(-9)
+			gen.AddTx(txC)
+		}
+	})
+	reorgCh := make(chan ChainReorgEvent, 8)
+	blockchain.SubscribeChainReorgEvent(reorgCh)
+	if _, err := blockchain.InsertChain(replacementBlocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	select {
+	case ev := <-reorgCh:
+		if ev.OldHead.Hash() != chain[2].Hash() || ev.NewHead.Hash() != replacementBlocks[2].Hash() || ev.Common.Hash() != blockchain.Genesis().Hash() {
+			t.Errorf("reorg blocks mismatch: old %d, new %d, common %d", ev.OldHead.Number, ev.NewHead.Number, ev.Common.Number)
+		}
+		if ev.Depth() != 3 {
+			t.Errorf("reorg depth mismatch: have %d, want 3", ev.Depth())
+		}
+		if len(ev.Dropped) != 1 || ev.Dropped[0] != txB.Hash() {
+			t.Errorf("dropped txs mismatch: have %v, want [%v]", ev.Dropped, txB.Hash())
+		}
+		if len(ev.Reincluded) != 1 || ev.Reincluded[0] != txA.Hash() {
+			t.Errorf("re-included txs mismatch: have %v, want [%v]", ev.Reincluded, txA.Hash())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no reorg event fired")
+	}
+	select {
+	case ev := <-reorgCh:
+		t.Errorf("unexpected reorg event: %d -> %d", ev.OldHead.Number, ev.NewHead.Number)
+	default:
+	}
+}
+
 // Tests if the canonical block can be fetched from the database during chain insertion.
 func TestCanonicalBlockRetrieval(t *testing.T) {
 	testCanonicalBlockRetrieval(t, rawdb.HashScheme)
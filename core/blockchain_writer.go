@@ -12,7 +12,7 @@ import (
 // Maximize performance, space for time
 
 func (bc *BlockChain) UpdateBlockStatus(num *big.Int, hash common.Hash, status uint8) error {
-	s, h := rawdb.ReadBlockStatusByNum(bc.db, num)
+	s, h := bc.GetBlockStatusByNum(num.Uint64())
 	if s == status && h == hash {
 		return nil
 	}
@@ -36,6 +36,7 @@ func (bc *BlockChain) UpdateBlockStatus(num *big.Int, hash common.Hash, status u
 	if num.Cmp(last) > 0 && status == types.BasFinalized {
 		rawdb.WriteLastFinalizedBlockNumber(bc.db, num)
 		bc.lastFinalizedBlockNumber.Store(new(big.Int).Set(num))
+		log.Info("Finality advanced", log.EventKey, log.EventFinalityAdvanced, "number", num.Uint64(), "hash", hash)
 	}
 
 	if bc.TurboEngine.AttestationStatus() == types.AttestationPending {
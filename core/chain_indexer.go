@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 // ChainIndexerBackend defines the methods needed to process chain segments in
@@ -94,6 +95,9 @@ type ChainIndexer struct {
 
 	throttling time.Duration // Disk throttling to prevent a heavy upgrade from hogging resources
 
+	storedGauge metrics.Gauge // Number of sections indexed, to report the progress of the rebuilds
+	knownGauge  metrics.Gauge // Number of sections complete, to be indexed
+
 	log  log.Logger
 	lock sync.Mutex
 }
@@ -111,6 +115,8 @@ func NewChainIndexer(chainDb ethdb.Database, indexDb ethdb.Database, backend Cha
 		sectionSize: section,
 		confirmsReq: confirm,
 		throttling:  throttling,
+		storedGauge: metrics.GetOrRegisterGauge("chain/indexer/"+kind+"/stored", nil),
+		knownGauge:  metrics.GetOrRegisterGauge("chain/indexer/"+kind+"/known", nil),
 		log:         log.New("type", kind),
 	}
 	// Initialize database dependent fields and start the updater
@@ -143,6 +149,33 @@ func (c *ChainIndexer) AddCheckpoint(section uint64, shead common.Hash) {
 	c.setValidSections(section + 1)
 }
 
+// Rebuild discards the processed sections past the checkpoint and indexes them
+// again in the background, e.g. once the index went out of sync with a chain
+// imported or pruned offline. The progress is reported by the stored and known
+// section gauges of the indexer.
+func (c *ChainIndexer) Rebuild() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.log.Info("Rebuilding chain index", "sections", c.storedSections, "checkpoint", c.checkpointSections)
+	c.setValidSections(c.checkpointSections)
+
+	var head uint64
+	if c.storedSections > 0 {
+		head = c.storedSections*c.sectionSize - 1
+	}
+	if head < c.cascadedHead {
+		c.cascadedHead = head
+		for _, child := range c.children {
+			child.newHead(c.cascadedHead, true)
+		}
+	}
+	select {
+	case c.update <- struct{}{}:
+	default:
+	}
+}
+
 // Start creates a goroutine to feed chain head events into the indexer for
 // cascading background processing. Children do not need to be started, they
 // are notified about new events by their parents.
@@ -258,6 +291,7 @@ func (c *ChainIndexer) newHead(head uint64, reorg bool) {
 		}
 		if known < c.knownSections {
 			c.knownSections = known
+			c.knownGauge.Update(int64(known))
 		}
 		// Revert the stored sections from the database to the reorg point
 		if stored < c.storedSections {
@@ -291,6 +325,7 @@ func (c *ChainIndexer) newHead(head uint64, reorg bool) {
 				}
 			}
 			c.knownSections = sections
+			c.knownGauge.Update(int64(sections))
 
 			select {
 			case c.update <- struct{}{}:
@@ -366,6 +401,7 @@ func (c *ChainIndexer) updateLoop() {
 					c.log.Debug("Chain index processing failed", "section", section, "err", err)
 					c.verifyLastHead()
 					c.knownSections = c.storedSections
+					c.knownGauge.Update(int64(c.knownSections))
 				}
 			}
 			// If there are still further sections to process, reschedule
@@ -489,6 +525,7 @@ func (c *ChainIndexer) setValidSections(sections uint64) {
 		c.removeSectionHead(c.storedSections)
 	}
 	c.storedSections = sections // needed if new > old
+	c.storedGauge.Update(int64(sections))
 }
 
 // SectionHead retrieves the last block hash of a processed section from the
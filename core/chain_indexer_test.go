@@ -134,6 +134,33 @@ func testChainIndexer(t *testing.T, count int) {
 	}
 }
 
+// Tests that a rebuilt index processes again all the sections.
+func TestChainIndexerRebuild(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	defer db.Close()
+
+	backend := &testChainIndexBackend{t: t, processCh: make(chan uint64)}
+	backend.indexer = NewChainIndexer(db, rawdb.NewTable(db, "i"), backend, 10, 0, 0, "rebuild")
+	defer backend.indexer.Close()
+
+	var parent common.Hash
+	for i := uint64(0); i < 50; i++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(i), ParentHash: parent}
+		rawdb.WriteHeader(db, header)
+		rawdb.WriteCanonicalHash(db, header.Hash(), i)
+		parent = header.Hash()
+	}
+	backend.indexer.newHead(49, false)
+	backend.assertBlocks(49, 49)
+	backend.assertSections()
+
+	backend.indexer.Rebuild()
+	backend.stored = 0
+	backend.assertSections()
+	backend.assertBlocks(49, 49)
+	backend.assertSections()
+}
+
 // testChainIndexBackend implements ChainIndexerBackend
 type testChainIndexBackend struct {
 	t                          *testing.T
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// writeContractCreations records the contracts created by the block: by its
+// contract creation transactions, and by the CREATE and CREATE2 actions of its
+// internal transactions, if recorded. Only the creations which weren't reverted
+// are recorded.
+func writeContractCreations(db ethdb.KeyValueWriter, config *params.ChainConfig, block *types.Block, receipts types.Receipts, internalTxs []*types.InternalTx) {
+	var (
+		hash      = block.Hash()
+		number    = block.NumberU64()
+		signer    = types.MakeSigner(config, block.Number(), block.Time())
+		succeeded = make(map[common.Hash]bool)
+	)
+	for i, tx := range block.Transactions() {
+		if i >= len(receipts) || receipts[i].Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		succeeded[tx.Hash()] = true
+		if tx.To() != nil {
+			continue
+		}
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		rawdb.WriteContractCreation(db, receipts[i].ContractAddress, number, hash, &rawdb.ContractCreation{
+			Creator:      sender,
+			TxHash:       tx.Hash(),
+			InitCodeHash: crypto.Keccak256Hash(tx.Data()),
+		})
+	}
+	for _, itx := range internalTxs {
+		if !succeeded[itx.TxHash] {
+			continue
+		}
+		var failed [][]uint64
+		for _, action := range itx.Actions {
+			// The top level creations are recorded from the receipts
+			if len(action.TraceAddress) == 0 {
+				continue
+			}
+			if !action.Success || revertedByParent(failed, action.TraceAddress) {
+				failed = append(failed, action.TraceAddress)
+				continue
+			}
+			if action.OpCode != vm.CREATE.String() && action.OpCode != vm.CREATE2.String() {
+				continue
+			}
+			rawdb.WriteContractCreation(db, action.To, number, hash, &rawdb.ContractCreation{
+				Creator:      action.From,
+				TxHash:       itx.TxHash,
+				InitCodeHash: crypto.Keccak256Hash(action.Input),
+			})
+		}
+	}
+}
+
+// revertedByParent reports whether one of the failed trace addresses is a
+// parent of the given trace address, reverting its effects.
+func revertedByParent(failed [][]uint64, trace []uint64) bool {
+	for _, parent := range failed {
+		if len(parent) < len(trace) && slices.Equal(parent, trace[:len(parent)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContractCreation returns the creation of the contract by the canonical chain,
+// nil if it isn't recorded.
+func (bc *BlockChain) ContractCreation(address common.Address) *rawdb.ContractCreation {
+	return rawdb.ReadCanonicalContractCreation(bc.db, address)
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the contracts created by transactions and by other contracts are
+// recorded on import, except the reverted creations.
+func TestContractCreations(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		signer  = types.LatestSigner(params.TestChainConfig)
+		factory = common.HexToAddress("0x1000") // CREATE(0, 0, 0)
+		failing = common.HexToAddress("0x2000") // CREATE(0, 0, 0), REVERT(0, 0)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr:    {Balance: big.NewInt(params.Ether)},
+				factory: {Code: common.FromHex("0x600060006000f000")},
+				failing: {Code: common.FromHex("0x600060006000f060006000fd")},
+			},
+		}
+		initCode = []byte{0x00}
+	)
+	_, blocks, receipts := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, func(i int, gen *BlockGen) {
+		switch i {
+		case 0:
+			gen.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 0, GasPrice: gen.BaseFee(), Gas: 100000, Data: initCode}))
+			gen.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 1, GasPrice: gen.BaseFee(), Gas: 100000, To: &factory}))
+		case 1:
+			gen.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 2, GasPrice: gen.BaseFee(), Gas: 100000, To: &failing}))
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{TraceAction: 2}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	// Created by a transaction
+	created := receipts[0][0].ContractAddress
+	creation := chain.ContractCreation(created)
+	if creation == nil {
+		t.Fatal("transaction creation not recorded")
+	}
+	if creation.Creator != addr || creation.TxHash != blocks[0].Transactions()[0].Hash() || creation.Number != 1 ||
+		creation.BlockHash != blocks[0].Hash() || creation.InitCodeHash != crypto.Keccak256Hash(initCode) {
+		t.Errorf("transaction creation mismatch: %+v", creation)
+	}
+	// Created by a contract
+	creation = chain.ContractCreation(crypto.CreateAddress(factory, 0))
+	if creation == nil {
+		t.Fatal("internal creation not recorded")
+	}
+	if creation.Creator != factory || creation.TxHash != blocks[0].Transactions()[1].Hash() || creation.InitCodeHash != crypto.Keccak256Hash(nil) {
+		t.Errorf("internal creation mismatch: %+v", creation)
+	}
+	// Reverted creations and unknown contracts aren't recorded
+	if creation := chain.ContractCreation(crypto.CreateAddress(failing, 0)); creation != nil {
+		t.Errorf("reverted creation recorded: %+v", creation)
+	}
+	if creation := chain.ContractCreation(factory); creation != nil {
+		t.Errorf("genesis contract creation recorded: %+v", creation)
+	}
+}
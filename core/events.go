@@ -42,6 +42,21 @@ type ChainSideEvent struct {
 
 type ChainHeadEvent struct{ Block *types.Block }
 
+// ChainReorgEvent is posted when blocks are dropped from the canonical chain,
+// before the events of the new chain head.
+type ChainReorgEvent struct {
+	OldHead    *types.Header // Head of the dropped chain segment
+	NewHead    *types.Header // Head of the new canonical chain
+	Common     *types.Header // Common ancestor of both chains
+	Dropped    []common.Hash // Transactions of the dropped blocks not included in the new chain
+	Reincluded []common.Hash // Transactions of the dropped blocks included again in the new chain
+}
+
+// Depth returns the number of blocks dropped from the canonical chain.
+func (ev *ChainReorgEvent) Depth() uint64 {
+	return ev.OldHead.Number.Uint64() - ev.Common.Number.Uint64()
+}
+
 type NewAttestationEvent struct{ A *types.Attestation }
 
 type NewJustifiedOrFinalizedBlockEvent struct {
@@ -40,6 +40,15 @@ type ChainReader interface {
 	GetTd(common.Hash, uint64) *big.Int
 }
 
+// finalityReader is implemented by the chains tracking the justified and
+// finalized blocks, comparing two branches by their attestation statuses.
+type finalityReader interface {
+	// IsNeedReorgByCasperFFG returns NeedReorg if the extern branch holds the
+	// highest finalized or justified block, NoNeedReorg if the current one
+	// does, and NotSure if the statuses don't decide.
+	IsNeedReorgByCasperFFG(current, extern *types.Header) (uint8, error)
+}
+
 // ForkChoice is the fork chooser based on the highest total difficulty of the
 // chain(the fork choice used in the eth1) and the external fork choice (the fork
 // choice used in the eth2). This main goal of this ForkChoice is not only for
@@ -73,7 +82,10 @@ func NewForkChoice(chainReader ChainReader, preserve func(header *types.Header)
 // based on the given external header and local canonical chain.
 // In the td mode, the new head is chosen if the corresponding
 // total difficulty is higher. In the extern mode, the trusted
-// header is always selected as the head.
+// header is always selected as the head. After the Turbo finality
+// fork choice fork, the branch holding the highest finalized or justified
+// block is chosen regardless of the total difficulties, which only break the
+// tie between the branches.
 func (f *ForkChoice) ReorgNeeded(current *types.Header, extern *types.Header) (bool, error) {
 	var (
 		localTD  = f.chain.GetTd(current.Hash(), current.Number.Uint64())
@@ -88,7 +100,10 @@ func (f *ForkChoice) ReorgNeeded(current *types.Header, extern *types.Header) (b
 	if ttd := f.chain.Config().TerminalTotalDifficulty; ttd != nil && ttd.Cmp(externTd) <= 0 {
 		return true, nil
 	}
-
+	// Prefer the attested branch if the statuses of the blocks decide
+	if reorg, ok := f.finalityReorg(current, extern); ok {
+		return reorg, nil
+	}
 	// If the total difficulty is higher than our known, add it to the canonical chain
 	if diff := externTd.Cmp(localTD); diff > 0 {
 		return true, nil
@@ -111,3 +126,29 @@ func (f *ForkChoice) ReorgNeeded(current *types.Header, extern *types.Header) (b
 	}
 	return reorg, nil
 }
+
+// finalityReorg compares the branches of the given headers by their justified
+// and finalized blocks, reporting whether the comparison is decisive.
+func (f *ForkChoice) finalityReorg(current *types.Header, extern *types.Header) (bool, bool) {
+	turbo := f.chain.Config().Turbo
+	if turbo == nil || !turbo.IsFinalityChoice(extern.Number) {
+		return false, false
+	}
+	reader, ok := f.chain.(finalityReader)
+	if !ok {
+		return false, false
+	}
+	result, err := reader.IsNeedReorgByCasperFFG(current, extern)
+	if err != nil {
+		log.Debug("Failed to compare branch finality", "current", current.Hash(), "extern", extern.Hash(), "err", err)
+		return false, false
+	}
+	switch result {
+	case NeedReorg:
+		return true, true
+	case NoNeedReorg:
+		return false, true
+	default:
+		return false, false
+	}
+}
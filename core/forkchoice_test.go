@@ -0,0 +1,181 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// finalityStatus is the attestation status of a block of a test branch.
+type finalityStatus struct {
+	heavy  bool  // Whether the block is on the heavy branch, the light one otherwise
+	index  int   // Index of the block in its branch
+	status uint8 // Attestation status of the block
+}
+
+// Tests that after the finality fork choice fork, the branch holding the
+// highest finalized or justified block is chosen over a heavier one, whichever
+// branch is imported first, while the total difficulties still decide before
+// the fork or without attestation statuses.
+func TestFinalityForkChoice(t *testing.T) {
+	testFinalityForkChoice(t, true)
+}
+
+func TestFinalityForkChoiceHeaders(t *testing.T) {
+	testFinalityForkChoice(t, false)
+}
+
+func testFinalityForkChoice(t *testing.T, full bool) {
+	tests := []struct {
+		name       string
+		fork       *big.Int // Finality fork choice block, disabled if nil
+		unready    bool     // Whether the attestation statuses are not tracked yet
+		statuses   []finalityStatus
+		heavyFirst bool // Whether the heavy branch is imported first
+		wantHeavy  bool // Whether the heavy branch is expected to be the head
+	}{
+		{
+			name:      "no statuses, heavy branch wins",
+			fork:      common.Big0,
+			wantHeavy: true,
+		},
+		{
+			name:     "justified light branch kept",
+			fork:     common.Big0,
+			statuses: []finalityStatus{{index: 0, status: types.BasJustified}},
+		},
+		{
+			name:       "justified light side branch taken over",
+			fork:       common.Big0,
+			statuses:   []finalityStatus{{index: 0, status: types.BasJustified}},
+			heavyFirst: true,
+		},
+		{
+			name:       "justified heavy branch kept",
+			fork:       common.Big0,
+			statuses:   []finalityStatus{{heavy: true, index: 3, status: types.BasJustified}},
+			heavyFirst: true,
+			wantHeavy:  true,
+		},
+		{
+			name: "higher justified block wins",
+			fork: common.Big0,
+			statuses: []finalityStatus{
+				{index: 1, status: types.BasJustified},
+				{heavy: true, index: 0, status: types.BasJustified},
+			},
+			heavyFirst: true,
+		},
+		{
+			name: "lower justified block loses",
+			fork: common.Big0,
+			statuses: []finalityStatus{
+				{index: 0, status: types.BasJustified},
+				{heavy: true, index: 2, status: types.BasJustified},
+			},
+			wantHeavy: true,
+		},
+		{
+			name: "finalized block wins over higher justified",
+			fork: common.Big0,
+			statuses: []finalityStatus{
+				{index: 0, status: types.BasFinalized},
+				{heavy: true, index: 3, status: types.BasJustified},
+			},
+			heavyFirst: true,
+		},
+		{
+			name:      "before the fork, heavy branch wins",
+			fork:      big.NewInt(100),
+			statuses:  []finalityStatus{{index: 0, status: types.BasJustified}},
+			wantHeavy: true,
+		},
+		{
+			name:      "fork not configured, heavy branch wins",
+			statuses:  []finalityStatus{{index: 0, status: types.BasJustified}},
+			wantHeavy: true,
+		},
+		{
+			name:      "statuses not tracked, heavy branch wins",
+			fork:      common.Big0,
+			unready:   true,
+			statuses:  []finalityStatus{{index: 0, status: types.BasJustified}},
+			wantHeavy: true,
+		},
+	}
+	for _, tt := range tests {
+		var (
+			genesis = &Genesis{
+				Config:  params.TestChainConfig,
+				BaseFee: big.NewInt(params.InitialBaseFee),
+			}
+			engine           = ethash.NewFaker()
+			genDb, prefix, _ = GenerateChainWithGenesis(genesis, engine, 3, nil)
+			light, _         = GenerateChain(params.TestChainConfig, prefix[len(prefix)-1], engine, genDb, 2, nil)
+			heavy, _         = GenerateChain(params.TestChainConfig, prefix[len(prefix)-1], engine, genDb, 5, func(i int, b *BlockGen) {
+				b.SetCoinbase(common.Address{0x01})
+			})
+		)
+		// Track the attestation statuses as with a Turbo engine
+		chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), DefaultCacheConfigWithScheme(rawdb.HashScheme), genesis, nil, engine, vm.Config{}, nil, nil)
+		if err != nil {
+			t.Fatalf("%s: failed to create chain: %v", tt.name, err)
+		}
+		config := *chain.chainConfig
+		config.Turbo = &params.TurboConfig{Period: 3, Epoch: 200, FinalityChoiceBlock: tt.fork}
+		chain.chainConfig = &config
+		chain.BlockStatusCache = lru.NewCache[uint64, *types.BlockStatus](blockStatusCacheLimit)
+
+		if !tt.unready {
+			rawdb.WriteBlockStatus(chain.db, common.Big0, chain.genesisBlock.Hash(), types.BasFinalized)
+		}
+		for _, s := range tt.statuses {
+			branch := light
+			if s.heavy {
+				branch = heavy
+			}
+			block := branch[s.index]
+			rawdb.WriteBlockStatus(chain.db, block.Number(), block.Hash(), s.status)
+		}
+		first, second := light, heavy
+		if tt.heavyFirst {
+			first, second = heavy, light
+		}
+		for _, blocks := range [][]*types.Block{prefix, first, second} {
+			if full {
+				_, err = chain.InsertChain(blocks)
+			} else {
+				headers := make([]*types.Header, len(blocks))
+				for i, block := range blocks {
+					headers[i] = block.Header()
+				}
+				_, err = chain.InsertHeaderChain(headers)
+			}
+			if err != nil {
+				t.Fatalf("%s: failed to insert blocks: %v", tt.name, err)
+			}
+		}
+		want := light[len(light)-1]
+		if tt.wantHeavy {
+			want = heavy[len(heavy)-1]
+		}
+		head := chain.CurrentHeader()
+		if full {
+			head = chain.CurrentBlock()
+		}
+		if head.Hash() != want.Hash() {
+			t.Errorf("%s: head mismatch: have #%d [%x], want #%d [%x]", tt.name, head.Number, head.Hash().Bytes()[:4], want.Number(), want.Hash().Bytes()[:4])
+		}
+		if canonical := chain.GetCanonicalHash(want.NumberU64()); canonical != want.Hash() {
+			t.Errorf("%s: canonical hash mismatch: have %x, want %x", tt.name, canonical, want.Hash())
+		}
+		chain.Stop()
+	}
+}
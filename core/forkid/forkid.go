@@ -132,11 +132,17 @@ func NewStaticFilter(config *params.ChainConfig, genesis *types.Block) Filter {
 // instead of a chain. The reason is to allow testing it without having to simulate
 // an entire blockchain.
 func newFilter(config *params.ChainConfig, genesis *types.Block, headfn func() (uint64, uint64)) Filter {
+	forksByBlock, forksByTime := gatherForks(config, genesis.Time())
+	return newFilterWithForks(genesis, forksByBlock, forksByTime, headfn)
+}
+
+// newFilterWithForks creates a fork id filter from an already gathered set of
+// block and time based forks.
+func newFilterWithForks(genesis *types.Block, forksByBlock, forksByTime []uint64, headfn func() (uint64, uint64)) Filter {
 	// Calculate the all the valid fork hash and fork next combos
 	var (
-		forksByBlock, forksByTime = gatherForks(config, genesis.Time())
-		forks                     = append(append([]uint64{}, forksByBlock...), forksByTime...)
-		sums                      = make([][4]byte, len(forks)+1) // 0th is the genesis
+		forks = append(append([]uint64{}, forksByBlock...), forksByTime...)
+		sums  = make([][4]byte, len(forks)+1) // 0th is the genesis
 	)
 	hash := crc32.ChecksumIEEE(genesis.Hash().Bytes())
 	sums[0] = checksumToBytes(hash)
@@ -239,33 +245,35 @@ func checksumToBytes(hash uint32) [4]byte {
 
 // gatherForks gathers all the known forks and creates two sorted lists out of
 // them, one for the block number based forks and the second for the timestamps.
+//
+// Besides the generic Ethereum forks, the forks scheduled in the Turbo engine
+// config are included too, so that peers on mismatching Nero forks reject each
+// other during the handshake instead of exchanging incompatible blocks.
 func gatherForks(config *params.ChainConfig, genesis uint64) ([]uint64, []uint64) {
 	// Gather all the fork block numbers via reflection
-	kind := reflect.TypeOf(params.ChainConfig{})
-	conf := reflect.ValueOf(config).Elem()
-	x := uint64(0)
-	var (
-		forksByBlock []uint64
-		forksByTime  []uint64
-	)
-	for i := 0; i < kind.NumField(); i++ {
-		// Fetch the next field and skip non-fork rules
-		field := kind.Field(i)
-
-		time := strings.HasSuffix(field.Name, "Time")
-		if !time && !strings.HasSuffix(field.Name, "Block") {
-			continue
+	forksByBlock, forksByTime := gatherForkFields(reflect.ValueOf(config).Elem(), nil, nil)
+	if config.Turbo != nil {
+		forksByBlock, forksByTime = gatherForkFields(reflect.ValueOf(config.Turbo).Elem(), forksByBlock, forksByTime)
+		for _, entry := range config.Turbo.Schedule {
+			if entry.Block != nil {
+				forksByBlock = append(forksByBlock, entry.Block.Uint64())
+			}
 		}
-
-		// Extract the fork rule block number or timestamp and aggregate it
-		if field.Type == reflect.TypeOf(&x) {
-			if rule := conf.Field(i).Interface().(*uint64); rule != nil {
-				forksByTime = append(forksByTime, *rule)
+		for _, block := range config.Turbo.Precompiles {
+			if block != nil {
+				forksByBlock = append(forksByBlock, block.Uint64())
 			}
 		}
-		if field.Type == reflect.TypeOf(new(big.Int)) {
-			if rule := conf.Field(i).Interface().(*big.Int); rule != nil {
-				forksByBlock = append(forksByBlock, rule.Uint64())
+		for _, block := range config.Turbo.TxRules {
+			if block != nil {
+				forksByBlock = append(forksByBlock, block.Uint64())
+			}
+		}
+		if config.Turbo.Emission != nil {
+			for _, step := range config.Turbo.Emission.Steps {
+				if step.Block != nil {
+					forksByBlock = append(forksByBlock, step.Block.Uint64())
+				}
 			}
 		}
 	}
@@ -295,3 +303,33 @@ func gatherForks(config *params.ChainConfig, genesis uint64) ([]uint64, []uint64
 	}
 	return forksByBlock, forksByTime
 }
+
+// gatherForkFields appends the block and time based fork rules declared in the
+// given config struct. Fields are considered forks if they are named with a
+// Block or Time suffix and are of *big.Int or *uint64 type respectively.
+func gatherForkFields(conf reflect.Value, forksByBlock, forksByTime []uint64) ([]uint64, []uint64) {
+	kind := conf.Type()
+	x := uint64(0)
+	for i := 0; i < kind.NumField(); i++ {
+		// Fetch the next field and skip non-fork rules
+		field := kind.Field(i)
+
+		time := strings.HasSuffix(field.Name, "Time")
+		if !time && !strings.HasSuffix(field.Name, "Block") {
+			continue
+		}
+
+		// Extract the fork rule block number or timestamp and aggregate it
+		if time && field.Type == reflect.TypeOf(&x) {
+			if rule := conf.Field(i).Interface().(*uint64); rule != nil {
+				forksByTime = append(forksByTime, *rule)
+			}
+		}
+		if !time && field.Type == reflect.TypeOf(new(big.Int)) {
+			if rule := conf.Field(i).Interface().(*big.Int); rule != nil {
+				forksByBlock = append(forksByBlock, rule.Uint64())
+			}
+		}
+	}
+	return forksByBlock, forksByTime
+}
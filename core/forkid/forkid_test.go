@@ -21,6 +21,7 @@ import (
 	"hash/crc32"
 	"math"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -103,3 +104,114 @@ func TestTimeBasedForkInGenesis(t *testing.T) {
 		}
 	}
 }
+
+// Tests that fork rules declared in an engine config struct are gathered the
+// same way as the ones declared in the chain config itself.
+func TestGatherForkFields(t *testing.T) {
+	type turboForks struct {
+		Period        uint64
+		Epoch         uint64
+		FirstBlock    *big.Int
+		SecondBlock   *big.Int
+		DisabledBlock *big.Int
+		FirstTime     *uint64
+		DisabledTime  *uint64
+	}
+	var (
+		time = uint64(1700000000)
+		conf = turboForks{
+			Period:      3,
+			Epoch:       250,
+			FirstBlock:  big.NewInt(100),
+			SecondBlock: big.NewInt(50),
+			FirstTime:   &time,
+		}
+	)
+	byBlock, byTime := gatherForkFields(reflect.ValueOf(conf), []uint64{10}, nil)
+	if want := []uint64{10, 100, 50}; !reflect.DeepEqual(byBlock, want) {
+		t.Errorf("block forks mismatch: have %v, want %v", byBlock, want)
+	}
+	if want := []uint64{time}; !reflect.DeepEqual(byTime, want) {
+		t.Errorf("time forks mismatch: have %v, want %v", byTime, want)
+	}
+}
+
+// Tests that the Nero networks don't pick up any spurious forks from the Turbo
+// engine config.
+func TestGatherForksTurbo(t *testing.T) {
+	for _, config := range []*params.ChainConfig{params.MainnetChainConfig, params.TestnetChainConfig} {
+		byBlock, byTime := gatherForks(config, 0)
+		if len(byBlock) != 0 || len(byTime) != 0 {
+			t.Errorf("chain %v: unexpected forks: block %v, time %v", config.ChainID, byBlock, byTime)
+		}
+	}
+}
+
+// Tests that the scheduled Turbo precompiles, transaction rules and emission
+// steps are forks.
+func TestGatherForksTurboScheduled(t *testing.T) {
+	config := &params.ChainConfig{Turbo: &params.TurboConfig{
+		Precompiles: map[string]*big.Int{"a": big.NewInt(60), "b": big.NewInt(0)},
+		TxRules:     map[string]*big.Int{"c": big.NewInt(50), "d": big.NewInt(60)},
+		Emission:    &params.EmissionConfig{Steps: []params.EmissionStep{{Block: big.NewInt(70)}, {Block: big.NewInt(0)}}},
+	}}
+	byBlock, _ := gatherForks(config, 0)
+	if want := []uint64{50, 60, 70}; !reflect.DeepEqual(byBlock, want) {
+		t.Errorf("block forks mismatch: have %v, want %v", byBlock, want)
+	}
+}
+
+// Tests that nodes running different client versions (i.e. one of them being
+// aware of an upcoming Turbo fork and the other not) are kept connected until
+// the fork is passed, and get disconnected afterwards.
+func TestTurboForkCompatibility(t *testing.T) {
+	var (
+		genesis  = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})
+		fork     = uint64(100)
+		oldForks []uint64
+		newForks = []uint64{fork}
+	)
+	newID := func(forks []uint64, head uint64) ID {
+		hash := crc32.ChecksumIEEE(genesis.Hash().Bytes())
+		for _, f := range forks {
+			if f > head {
+				return ID{Hash: checksumToBytes(hash), Next: f}
+			}
+			hash = checksumUpdate(hash, f)
+		}
+		return ID{Hash: checksumToBytes(hash)}
+	}
+	newFilter := func(forks []uint64, head uint64) Filter {
+		return newFilterWithForks(genesis, forks, nil, func() (uint64, uint64) { return head, 0 })
+	}
+	tests := []struct {
+		local      []uint64
+		localHead  uint64
+		remote     []uint64
+		remoteHead uint64
+		err        error
+	}{
+		// Both nodes are before the fork, the upgraded one announces it
+		{newForks, 50, oldForks, 50, nil},
+		{oldForks, 50, newForks, 50, nil},
+
+		// The upgraded node is past the fork, the old one isn't aware of it and
+		// cannot validate the announced checksum, disconnect
+		{oldForks, 50, newForks, 150, ErrLocalIncompatibleOrStale},
+
+		// The upgraded node is past the fork, the old one stayed on the previous
+		// rules and is past the fork block too, disconnect
+		{newForks, 150, oldForks, 150, ErrRemoteStale},
+		{oldForks, 150, newForks, 150, ErrLocalIncompatibleOrStale},
+
+		// The old node is still syncing, the upgraded one announces a fork that
+		// the old node already passed
+		{oldForks, 150, newForks, 50, ErrLocalIncompatibleOrStale},
+	}
+	for i, tt := range tests {
+		filter := newFilter(tt.local, tt.localHead)
+		if err := filter(newID(tt.remote, tt.remoteHead)); err != tt.err {
+			t.Errorf("test %d: validation error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
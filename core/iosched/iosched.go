@@ -0,0 +1,104 @@
+// Package iosched schedules the background disk I/O of the node around the
+// validator duty.
+//
+// The snapshot generation, the database compactions and the index pruning
+// compete for the disk with the block sealing. While the local validator is in
+// turn, the scheduler is throttled and these tasks pause at their next safe
+// point, resuming at full speed once the slot is over. The pauses are bounded,
+// so that a stuck throttle can't starve the tasks.
+package iosched
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// maxPause is the maximum time a task is paused by a single throttle.
+const maxPause = 10 * time.Second
+
+var (
+	throttledGauge = metrics.NewRegisteredGauge("iosched/throttled", nil)
+	throttleMeter  = metrics.NewRegisteredMeter("iosched/throttles", nil)
+	expiredMeter   = metrics.NewRegisteredMeter("iosched/expired", nil) // Pauses cut short by the maximum
+)
+
+// closed is the channel returned to the tasks allowed to proceed.
+var closed = make(chan struct{})
+
+func init() {
+	close(closed)
+}
+
+var (
+	lock    sync.Mutex
+	release chan struct{} // Closed once the throttle is released, nil if not throttled
+)
+
+// Throttle pauses the background tasks until Release is called.
+func Throttle() {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if release == nil {
+		release = make(chan struct{})
+		throttledGauge.Update(1)
+		throttleMeter.Mark(1)
+	}
+}
+
+// Release resumes the background tasks at full speed.
+func Release() {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if release != nil {
+		close(release)
+		release = nil
+		throttledGauge.Update(0)
+	}
+}
+
+// Throttled reports whether the background tasks are paused.
+func Throttled() bool {
+	lock.Lock()
+	defer lock.Unlock()
+
+	return release != nil
+}
+
+// Pause returns a channel closed once the task may proceed: at once if not
+// throttled, otherwise when the throttle is released or the task has waited
+// long enough. The tasks which must stay responsive select on it along their
+// interruptions.
+func Pause(task string) <-chan struct{} {
+	lock.Lock()
+	ch := release
+	lock.Unlock()
+
+	if ch == nil {
+		return closed
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		start := time.Now()
+		timer := time.NewTimer(maxPause)
+		defer timer.Stop()
+
+		select {
+		case <-ch:
+		case <-timer.C:
+			expiredMeter.Mark(1)
+		}
+		metrics.GetOrRegisterResettingTimer("iosched/pause/"+task, nil).UpdateSince(start)
+	}()
+	return done
+}
+
+// Wait blocks the task while throttled, up to the maximum pause.
+func Wait(task string) {
+	<-Pause(task)
+}
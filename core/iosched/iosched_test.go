@@ -0,0 +1,39 @@
+package iosched
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests that the tasks proceed at once unless throttled, and resume once the
+// throttle is released.
+func TestPause(t *testing.T) {
+	defer Release()
+
+	select {
+	case <-Pause("test"):
+	default:
+		t.Fatal("task paused while not throttled")
+	}
+	Throttle()
+	if !Throttled() {
+		t.Fatal("throttle not reported")
+	}
+	paused := Pause("test")
+	select {
+	case <-paused:
+		t.Fatal("task not paused while throttled")
+	case <-time.After(50 * time.Millisecond):
+	}
+	Release()
+	select {
+	case <-paused:
+	case <-time.After(time.Second):
+		t.Fatal("task not resumed after release")
+	}
+	if Throttled() {
+		t.Fatal("throttle reported after release")
+	}
+	// Releasing again is a no-op
+	Release()
+}
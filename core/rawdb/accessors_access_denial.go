@@ -0,0 +1,36 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ReadAccessDenials retrieves the access filter denials met by the transactions
+// of the block.
+func ReadAccessDenials(db ethdb.KeyValueReader, number uint64, hash common.Hash) []*types.AccessDenial {
+	data, _ := db.Get(accessDenialsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var denials []*types.AccessDenial
+	if err := rlp.DecodeBytes(data, &denials); err != nil {
+		log.Error("Invalid access denials RLP", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	return denials
+}
+
+// WriteAccessDenials stores the access filter denials met by the transactions
+// of the block.
+func WriteAccessDenials(db ethdb.KeyValueWriter, number uint64, hash common.Hash, denials []*types.AccessDenial) {
+	blob, err := rlp.EncodeToBytes(denials)
+	if err != nil {
+		log.Crit("Failed to encode access denials", "err", err)
+	}
+	if err := db.Put(accessDenialsKey(number, hash), blob); err != nil {
+		log.Crit("Failed to store access denials", "err", err)
+	}
+}
@@ -0,0 +1,42 @@
+package rawdb
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestAccessDenialStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		hash  = common.Hash{0x01}
+		topic = common.Hash{0xaa}
+		tx1   = types.NewTransaction(1, common.Address{0x1}, big.NewInt(1), 1, big.NewInt(1), nil)
+		tx2   = types.NewTransaction(2, common.Address{0x2}, big.NewInt(2), 2, big.NewInt(2), nil)
+	)
+	denials := []*types.AccessDenial{
+		{Kind: types.AccessDeniedLog, Address: common.Address{0xe}, Topic: &topic, Rule: "rule", TxIndex: 1},
+	}
+	if have := ReadAccessDenials(db, 1, hash); have != nil {
+		t.Fatalf("unexpected denials: %v", have)
+	}
+	WriteAccessDenials(db, 1, hash, denials)
+	if have := ReadAccessDenials(db, 1, hash); !reflect.DeepEqual(have, denials) {
+		t.Fatalf("denials mismatch: have %+v, want %+v", have, denials)
+	}
+	// The denials are attached to the receipts of their transactions
+	WriteBody(db, hash, 1, &types.Body{Transactions: types.Transactions{tx1, tx2}})
+	WriteReceipts(db, hash, 1, types.Receipts{{TxHash: tx1.Hash()}, {TxHash: tx2.Hash(), Status: types.ReceiptStatusFailed}})
+	receipts := ReadReceipts(db, hash, 1, 0, params.TestChainConfig)
+	if len(receipts) != 2 {
+		t.Fatalf("receipt count mismatch: have %d, want 2", len(receipts))
+	}
+	if receipts[0].AccessDenial != nil || !reflect.DeepEqual(receipts[1].AccessDenial, denials[0]) {
+		t.Fatalf("receipt denials mismatch: %+v, %+v", receipts[0].AccessDenial, receipts[1].AccessDenial)
+	}
+}
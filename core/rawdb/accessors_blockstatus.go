@@ -0,0 +1,127 @@
+package rawdb
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// blockStatusMigrationBatch is the number of block statuses moved into the
+// ancient store at once.
+const blockStatusMigrationBatch = 10000
+
+// ReadFrozenBlockStatus retrieves the status of a block from the block status
+// ancient store. Blocks without a recorded status are stored as empty items.
+func ReadFrozenBlockStatus(db ethdb.AncientReaderOp, number uint64) (uint8, common.Hash) {
+	blob, err := db.Ancient(blockStatusTable, number)
+	if err != nil || len(blob) == 0 {
+		return types.BasUnknown, common.Hash{}
+	}
+	var bs types.BlockStatus
+	if err := rlp.DecodeBytes(blob, &bs); err != nil {
+		log.Error("Failed to decode frozen block status", "number", number, "err", err)
+		return types.BasUnknown, common.Hash{}
+	}
+	return bs.Status, bs.Hash
+}
+
+// ReadBlockStatus retrieves the status of a block from the key-value store, or
+// from the block status ancient store once migrated. The ancient store is nil
+// if the statuses were never migrated.
+func ReadBlockStatus(db ethdb.Reader, freezer ethdb.AncientReaderOp, number uint64) (uint8, common.Hash) {
+	status, hash := ReadBlockStatusByNum(db, new(big.Int).SetUint64(number))
+	if status == types.BasUnknown && freezer != nil {
+		status, hash = ReadFrozenBlockStatus(freezer, number)
+	}
+	return status, hash
+}
+
+// OpenBlockStatusFreezer opens the ancient store of the migrated block statuses
+// read-only, as it's only written by the offline migration. Nil is returned if
+// the database has none.
+func OpenBlockStatusFreezer(db ethdb.AncientStater) ethdb.AncientStore {
+	ancient, err := db.AncientDatadir()
+	if err != nil || ancient == "" {
+		return nil
+	}
+	freezer, err := NewBlockStatusFreezer(ancient, true)
+	if err != nil {
+		log.Debug("No migrated block statuses", "err", err)
+		return nil
+	}
+	return freezer
+}
+
+// MigrateBlockStatus moves the statuses of the blocks up to and including
+// limit from the key-value store into the block status ancient store, which
+// holds one item per block number. Statuses below the last finalized block
+// no longer change, so limit is expected to be the last finalized block.
+//
+// Items are synced to the ancient store before the key-value entries are
+// deleted, an interrupted migration can simply be resumed. The entry of the
+// genesis block is retained as it also marks the availability of the block
+// statuses. The number of migrated statuses is returned.
+func MigrateBlockStatus(db ethdb.KeyValueStore, freezer ethdb.AncientStore, limit uint64) (uint64, error) {
+	frozen, err := freezer.Ancients()
+	if err != nil {
+		return 0, err
+	}
+	var migrated uint64
+	for frozen <= limit {
+		var (
+			last  = min(frozen+blockStatusMigrationBatch-1, limit)
+			blobs = make([][]byte, 0, last-frozen+1)
+		)
+		for number := frozen; number <= last; number++ {
+			blob, _ := db.Get(blockStatusKeyByNum(number))
+			blobs = append(blobs, blob)
+		}
+		_, err := freezer.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+			for i, blob := range blobs {
+				if err := op.AppendRaw(blockStatusTable, frozen+uint64(i), blob); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return migrated, err
+		}
+		if err := freezer.Sync(); err != nil {
+			return migrated, err
+		}
+		batch := db.NewBatch()
+		for i, blob := range blobs {
+			number := frozen + uint64(i)
+			if len(blob) == 0 || number == 0 {
+				continue
+			}
+			if err := batch.Delete(blockStatusKeyByNum(number)); err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+		if err := batch.Write(); err != nil {
+			return migrated, err
+		}
+		log.Info("Migrated block statuses", "number", last, "migrated", migrated)
+		frozen = last + 1
+	}
+	return migrated, nil
+}
+
+// DeleteBlockStatus removes the status of the block from the key-value store.
+func DeleteBlockStatus(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Delete(blockStatusKeyByNum(number)); err != nil {
+		log.Crit("Failed to delete block status", "number", number, "err", err)
+	}
+}
+
+// blockStatusKeyByNum = blockStatusKey + num (big endian, minimal encoding)
+func blockStatusKeyByNum(number uint64) []byte {
+	return append(append([]byte{}, blockStatusKey...), new(big.Int).SetUint64(number).Bytes()...)
+}
@@ -0,0 +1,111 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// BridgeEvent is a lock or unlock event of a watched bridge contract. The block
+// fields are stored in the key.
+type BridgeEvent struct {
+	Kind        string
+	Contract    common.Address
+	Topics      []common.Hash
+	Data        []byte
+	TxHash      common.Hash
+	LogIndex    uint
+	BlockNumber uint64      `rlp:"-"`
+	BlockHash   common.Hash `rlp:"-"`
+}
+
+// ReadBridgeCursor retrieves the progress of the bridge watcher, nil if it
+// never ran. The cursor has the same form as the ones of the event publishers.
+func ReadBridgeCursor(db ethdb.KeyValueReader) *EventStreamCursor {
+	blob, err := db.Get(bridgeCursorKey)
+	if err != nil {
+		return nil
+	}
+	cursor := new(EventStreamCursor)
+	if err := rlp.DecodeBytes(blob, cursor); err != nil {
+		log.Error("Invalid bridge cursor", "err", err)
+		return nil
+	}
+	return cursor
+}
+
+// WriteBridgeCursor stores the progress of the bridge watcher.
+func WriteBridgeCursor(db ethdb.KeyValueWriter, cursor *EventStreamCursor) {
+	blob, err := rlp.EncodeToBytes(cursor)
+	if err != nil {
+		log.Crit("Failed to encode bridge cursor", "err", err)
+	}
+	if err := db.Put(bridgeCursorKey, blob); err != nil {
+		log.Crit("Failed to store bridge cursor", "err", err)
+	}
+}
+
+// ReadBridgeEvents retrieves the bridge events watched in the given block.
+func ReadBridgeEvents(db ethdb.KeyValueReader, number uint64, hash common.Hash) []*BridgeEvent {
+	blob, err := db.Get(bridgeEventsKey(number, hash))
+	if err != nil {
+		return nil
+	}
+	var events []*BridgeEvent
+	if err := rlp.DecodeBytes(blob, &events); err != nil {
+		log.Error("Invalid bridge events", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	for _, event := range events {
+		event.BlockNumber, event.BlockHash = number, hash
+	}
+	return events
+}
+
+// IterateBridgeEvents calls fn with the bridge events watched in the blocks
+// from the given number on, canonical or not, by ascending block number, until
+// it returns false.
+func IterateBridgeEvents(db ethdb.Iteratee, from uint64, fn func(events []*BridgeEvent) bool) {
+	it := db.NewIterator(bridgeEventsPrefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()[len(bridgeEventsPrefix):]
+		if len(key) != 8+common.HashLength {
+			continue
+		}
+		var events []*BridgeEvent
+		if err := rlp.DecodeBytes(it.Value(), &events); err != nil {
+			log.Error("Invalid bridge events", "err", err)
+			continue
+		}
+		number, hash := binary.BigEndian.Uint64(key[:8]), common.BytesToHash(key[8:])
+		for _, event := range events {
+			event.BlockNumber, event.BlockHash = number, hash
+		}
+		if !fn(events) {
+			return
+		}
+	}
+}
+
+// WriteBridgeEvents stores the bridge events watched in the given block.
+func WriteBridgeEvents(db ethdb.KeyValueWriter, number uint64, hash common.Hash, events []*BridgeEvent) {
+	blob, err := rlp.EncodeToBytes(events)
+	if err != nil {
+		log.Crit("Failed to encode bridge events", "err", err)
+	}
+	if err := db.Put(bridgeEventsKey(number, hash), blob); err != nil {
+		log.Crit("Failed to store bridge events", "err", err)
+	}
+}
+
+// DeleteBridgeEvents removes the bridge events watched in the given block.
+func DeleteBridgeEvents(db ethdb.KeyValueWriter, number uint64, hash common.Hash) {
+	if err := db.Delete(bridgeEventsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete bridge events", "err", err)
+	}
+}
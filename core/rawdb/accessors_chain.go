@@ -606,6 +606,12 @@ func HasReceipts(db ethdb.Reader, hash common.Hash, number uint64) bool {
 
 // ReadReceiptsRLP retrieves all the transaction receipts belonging to a block in RLP encoding.
 func ReadReceiptsRLP(db ethdb.Reader, hash common.Hash, number uint64) rlp.RawValue {
+	return expandReceiptsRLP(db, readStoredReceiptsRLP(db, hash, number))
+}
+
+// readStoredReceiptsRLP retrieves all the transaction receipts belonging to a
+// block in their storage form, which might be deduplicated.
+func readStoredReceiptsRLP(db ethdb.Reader, hash common.Hash, number uint64) rlp.RawValue {
 	var data []byte
 	db.ReadAncients(func(reader ethdb.AncientReaderOp) error {
 		// Check if the data is in ancients
@@ -677,6 +683,11 @@ func ReadReceipts(db ethdb.Reader, hash common.Hash, number uint64, time uint64,
 		log.Error("Failed to derive block receipts fields", "hash", hash, "number", number, "err", err)
 		return nil
 	}
+	for _, denial := range ReadAccessDenials(db, number, hash) {
+		if denial.TxIndex < uint(len(receipts)) {
+			receipts[denial.TxIndex].AccessDenial = denial
+		}
+	}
 	return receipts
 }
 
@@ -1138,3 +1149,54 @@ func WriteViolateCasperFFGPunish(db ethdb.KeyValueStore, before *types.Attestati
 	}
 	return nil
 }
+
+// ReadCasperFFGJournal retrieves the last journaled attestation histories.
+func ReadCasperFFGJournal(db ethdb.Reader) []*types.CasperFFGJournal {
+	blob, err := db.Get(casperFFGJournalKey)
+	if err != nil {
+		return nil
+	}
+	var journal []*types.CasperFFGJournal
+	if err := rlp.DecodeBytes(blob, &journal); err != nil {
+		log.Error("Invalid attestation journal", "err", err)
+		return nil
+	}
+	return journal
+}
+
+// WriteCasperFFGJournal stores the attestation histories.
+func WriteCasperFFGJournal(db ethdb.KeyValueWriter, journal []*types.CasperFFGJournal) {
+	data, err := rlp.EncodeToBytes(journal)
+	if err != nil {
+		log.Crit("Failed to encode attestation journal", "err", err)
+	}
+	if err := db.Put(casperFFGJournalKey, data); err != nil {
+		log.Crit("Failed to store attestation journal", "err", err)
+	}
+}
+
+// ReadAttestationJournal retrieves the votes journaled by a local validator,
+// the latest last.
+func ReadAttestationJournal(db ethdb.KeyValueReader, val common.Address) types.CasperFFGHistoryList {
+	blob, err := db.Get(append(attestationJournalPrefix, val.Bytes()...))
+	if err != nil {
+		return nil
+	}
+	var journal types.CasperFFGHistoryList
+	if err := rlp.DecodeBytes(blob, &journal); err != nil {
+		log.Error("Invalid validator attestation journal", "validator", val, "err", err)
+		return nil
+	}
+	return journal
+}
+
+// WriteAttestationJournal stores the votes of a local validator.
+func WriteAttestationJournal(db ethdb.KeyValueWriter, val common.Address, journal types.CasperFFGHistoryList) {
+	data, err := rlp.EncodeToBytes(journal)
+	if err != nil {
+		log.Crit("Failed to encode validator attestation journal", "err", err)
+	}
+	if err := db.Put(append(attestationJournalPrefix, val.Bytes()...), data); err != nil {
+		log.Crit("Failed to store validator attestation journal", "err", err)
+	}
+}
@@ -880,6 +880,38 @@ func TestWriteAndReadLastAttestNumber(t *testing.T) {
 	require.True(t, result.Uint64() == num.Uint64())
 }
 
+func TestWriteAndReadCasperFFGJournal(t *testing.T) {
+	db := NewMemoryDatabase()
+	require.True(t, ReadCasperFFGJournal(db) == nil)
+
+	journal := []*types.CasperFFGJournal{{
+		Signer: common.BytesToAddress([]byte{0x11}),
+		History: types.CasperFFGHistoryList{{
+			TargetNum:       big.NewInt(12),
+			SourceNum:       big.NewInt(11),
+			TargetHash:      common.BytesToHash([]byte{0x12}),
+			AttestationHash: common.BytesToHash([]byte{0x13}),
+		}},
+	}}
+	WriteCasperFFGJournal(db, journal)
+	require.Equal(t, journal, ReadCasperFFGJournal(db))
+}
+
+func TestWriteAndReadAttestationJournal(t *testing.T) {
+	db := NewMemoryDatabase()
+	val := common.BytesToAddress([]byte{0x11})
+	require.True(t, ReadAttestationJournal(db, val) == nil)
+
+	journal := types.CasperFFGHistoryList{{
+		TargetNum:  big.NewInt(12),
+		SourceNum:  big.NewInt(11),
+		TargetHash: common.BytesToHash([]byte{0x12}),
+	}}
+	WriteAttestationJournal(db, val, journal)
+	require.Equal(t, journal, ReadAttestationJournal(db, val))
+	require.True(t, ReadAttestationJournal(db, common.BytesToAddress([]byte{0x22})) == nil)
+}
+
 func TestWriteAndReadBlockBasJustified1(t *testing.T) {
 	db := NewMemoryDatabase()
 	blockNumber1 := new(big.Int).SetUint64(1)
@@ -892,6 +924,64 @@ func TestWriteAndReadBlockBasJustified1(t *testing.T) {
 	require.True(t, hash == blockHash)
 }
 
+func TestMigrateBlockStatus(t *testing.T) {
+	db := NewMemoryDatabase()
+	freezer := NewMemoryFreezer(false, blockStatusFreezerNoSnappy)
+	hash := func(n uint64) common.Hash { return common.BigToHash(new(big.Int).SetUint64(n + 1)) }
+
+	// Blocks 0-9 finalized, 10 justified, 5 without status
+	for n := uint64(0); n <= 10; n++ {
+		if n == 5 {
+			continue
+		}
+		status := types.BasFinalized
+		if n == 10 {
+			status = types.BasJustified
+		}
+		require.NoError(t, WriteBlockStatus(db, new(big.Int).SetUint64(n), hash(n), status))
+	}
+	migrated, err := MigrateBlockStatus(db, freezer, 9)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), migrated)
+
+	frozen, _ := freezer.Ancients()
+	require.Equal(t, uint64(10), frozen)
+	for n := uint64(1); n < 10; n++ {
+		status, h := ReadBlockStatusByNum(db, new(big.Int).SetUint64(n))
+		require.Equal(t, types.BasUnknown, status, "block %d left in key-value store", n)
+
+		status, h = ReadFrozenBlockStatus(freezer, n)
+		if n == 5 {
+			require.Equal(t, types.BasUnknown, status)
+			continue
+		}
+		require.Equal(t, types.BasFinalized, status)
+		require.Equal(t, hash(n), h)
+
+		// The migrated statuses are read through the ancient store
+		status, h = ReadBlockStatus(db, freezer, n)
+		require.Equal(t, types.BasFinalized, status)
+		require.Equal(t, hash(n), h)
+	}
+	status, h := ReadBlockStatus(db, freezer, 10)
+	require.Equal(t, types.BasJustified, status)
+	require.Equal(t, hash(10), h)
+
+	// The genesis entry marks the availability of the statuses and is kept
+	ready, _ := IsReadyReadBlockStatus(db)
+	require.True(t, ready)
+	status, _ = ReadBlockStatusByNum(db, big.NewInt(10))
+	require.Equal(t, types.BasJustified, status)
+
+	// Resuming migrates only the new statuses
+	require.NoError(t, WriteBlockStatus(db, big.NewInt(10), hash(10), types.BasFinalized))
+	migrated, err = MigrateBlockStatus(db, freezer, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), migrated)
+	status, _ = ReadFrozenBlockStatus(freezer, 10)
+	require.Equal(t, types.BasFinalized, status)
+}
+
 func TestWriteAndReadAndDeleteAndClearViolateCasperFFGPunish(t *testing.T) {
 	db := NewMemoryDatabase()
 	priv, err := crypto.GenerateKey()
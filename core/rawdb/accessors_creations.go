@@ -0,0 +1,70 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ContractCreation records the creation of a contract, by a transaction or by
+// another contract. The block fields are stored in the key.
+type ContractCreation struct {
+	Creator      common.Address // Sender of the creation transaction, or the creating contract
+	TxHash       common.Hash
+	InitCodeHash common.Hash
+	Number       uint64      `rlp:"-"`
+	BlockHash    common.Hash `rlp:"-"`
+}
+
+// ReadContractCreations retrieves the creations of the contract recorded by all
+// the blocks creating it, canonical or not, ordered by block number.
+func ReadContractCreations(db ethdb.Iteratee, address common.Address) []*ContractCreation {
+	prefix := append(append([]byte{}, contractCreationPrefix...), address.Bytes()...)
+
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	var creations []*ContractCreation
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength {
+			continue
+		}
+		creation := new(ContractCreation)
+		if err := rlp.DecodeBytes(it.Value(), creation); err != nil {
+			log.Error("Invalid contract creation", "address", address, "err", err)
+			continue
+		}
+		creation.Number = binary.BigEndian.Uint64(key[len(prefix) : len(prefix)+8])
+		creation.BlockHash = common.BytesToHash(key[len(prefix)+8:])
+		creations = append(creations, creation)
+	}
+	return creations
+}
+
+// ReadCanonicalContractCreation retrieves the creation of the contract by the
+// canonical chain, the latest one if it was created again after destructing
+// itself. Nil is returned if the contract creation isn't recorded.
+func ReadCanonicalContractCreation(db ethdb.Database, address common.Address) *ContractCreation {
+	creations := ReadContractCreations(db, address)
+	for i := len(creations) - 1; i >= 0; i-- {
+		if ReadCanonicalHash(db, creations[i].Number) == creations[i].BlockHash {
+			return creations[i]
+		}
+	}
+	return nil
+}
+
+// WriteContractCreation stores the creation of the contract by the given block.
+func WriteContractCreation(db ethdb.KeyValueWriter, address common.Address, number uint64, hash common.Hash, creation *ContractCreation) {
+	blob, err := rlp.EncodeToBytes(creation)
+	if err != nil {
+		log.Crit("Failed to encode contract creation", "err", err)
+	}
+	if err := db.Put(contractCreationKey(address, number, hash), blob); err != nil {
+		log.Crit("Failed to store contract creation", "err", err)
+	}
+}
@@ -0,0 +1,47 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestContractCreationStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		address  = common.Address{0xaa}
+		first    = &ContractCreation{Creator: common.Address{0x01}, TxHash: common.Hash{0x01}, InitCodeHash: common.Hash{0xc1}}
+		reorged  = &ContractCreation{Creator: common.Address{0x02}, TxHash: common.Hash{0x02}, InitCodeHash: common.Hash{0xc2}}
+		recreate = &ContractCreation{Creator: common.Address{0x03}, TxHash: common.Hash{0x03}, InitCodeHash: common.Hash{0xc3}}
+	)
+	if creation := ReadCanonicalContractCreation(db, address); creation != nil {
+		t.Fatalf("unexpected creation: %+v", creation)
+	}
+	WriteContractCreation(db, address, 10, common.Hash{0x10}, first)
+	WriteContractCreation(db, address, 20, common.Hash{0x20}, reorged)
+	WriteContractCreation(db, address, 30, common.Hash{0x30}, recreate)
+	WriteContractCreation(db, common.Address{0xab}, 5, common.Hash{0x05}, first)
+	WriteCanonicalHash(db, common.Hash{0x10}, 10)
+	WriteCanonicalHash(db, common.Hash{0x21}, 20)
+
+	creations := ReadContractCreations(db, address)
+	if len(creations) != 3 {
+		t.Fatalf("creation count mismatch: have %d, want 3", len(creations))
+	}
+	for i, want := range []uint64{10, 20, 30} {
+		if creations[i].Number != want {
+			t.Errorf("creation %d: number mismatch: have %d, want %d", i, creations[i].Number, want)
+		}
+	}
+	// The creations of the reorged and unknown blocks are skipped
+	creation := ReadCanonicalContractCreation(db, address)
+	if creation == nil || creation.Creator != first.Creator || creation.InitCodeHash != first.InitCodeHash || creation.BlockHash != (common.Hash{0x10}) {
+		t.Fatalf("canonical creation mismatch: %+v", creation)
+	}
+	// The latest canonical creation is returned
+	WriteCanonicalHash(db, common.Hash{0x30}, 30)
+	if creation := ReadCanonicalContractCreation(db, address); creation == nil || creation.TxHash != recreate.TxHash || creation.Number != 30 {
+		t.Fatalf("recreation mismatch: %+v", creation)
+	}
+}
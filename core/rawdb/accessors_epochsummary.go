@@ -0,0 +1,59 @@
+package rawdb
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// EpochPunish records a validator punishment executed during an epoch.
+type EpochPunish struct {
+	Number    uint64
+	Validator common.Address
+	Event     uint8    // Position of the punish event in the list of indexed system events
+	Deduction *big.Int `rlp:"optional"` // Stake slashed by the punishment, nil if unknown
+}
+
+// EpochSummary is the record of a Turbo epoch, from the epoch block updating
+// the validator set to the block preceding the next one.
+type EpochSummary struct {
+	Epoch          uint64
+	FirstBlock     uint64
+	LastBlock      uint64
+	Validators     []common.Address
+	FeeRewards     *big.Int // Transaction fees distributed to the validators
+	Punishes       []EpochPunish
+	TotalStake     *big.Int   `rlp:"optional"` // Stake at the last block, nil if its state was unavailable
+	StakingRewards *big.Int   `rlp:"optional"` // Staking rewards released, nil if the state was unavailable
+	Stakes         []*big.Int `rlp:"optional"` // Stakes of the validators at the last block, in the same order
+}
+
+// ReadEpochSummary retrieves the summary of the given epoch, whose last block
+// has the given hash. Nil is returned if the epoch isn't summarized.
+func ReadEpochSummary(db ethdb.KeyValueReader, epoch uint64, head common.Hash) *EpochSummary {
+	blob, err := db.Get(epochSummaryKey(epoch, head))
+	if err != nil {
+		return nil
+	}
+	summary := new(EpochSummary)
+	if err := rlp.DecodeBytes(blob, summary); err != nil {
+		log.Error("Invalid epoch summary RLP", "epoch", epoch, "err", err)
+		return nil
+	}
+	return summary
+}
+
+// WriteEpochSummary stores the summary of an epoch, keyed by the hash of its
+// last block.
+func WriteEpochSummary(db ethdb.KeyValueWriter, head common.Hash, summary *EpochSummary) {
+	blob, err := rlp.EncodeToBytes(summary)
+	if err != nil {
+		log.Crit("Failed to encode epoch summary", "err", err)
+	}
+	if err := db.Put(epochSummaryKey(summary.Epoch, head), blob); err != nil {
+		log.Crit("Failed to store epoch summary", "err", err)
+	}
+}
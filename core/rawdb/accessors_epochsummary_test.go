@@ -0,0 +1,42 @@
+package rawdb
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestEpochSummaryStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	head := common.Hash{0x01}
+	if summary := ReadEpochSummary(db, 1, head); summary != nil {
+		t.Fatalf("unexpected summary for unsummarized epoch: %v", summary)
+	}
+	want := &EpochSummary{
+		Epoch:          1,
+		FirstBlock:     200,
+		LastBlock:      399,
+		Validators:     []common.Address{{0x01}, {0x02}},
+		TotalStake:     big.NewInt(1000),
+		FeeRewards:     big.NewInt(21000),
+		StakingRewards: big.NewInt(200),
+		Punishes:       []EpochPunish{{Number: 250, Validator: common.Address{0x02}, Event: 7, Deduction: big.NewInt(50)}},
+		Stakes:         []*big.Int{big.NewInt(600), big.NewInt(400)},
+	}
+	WriteEpochSummary(db, head, want)
+	if have := ReadEpochSummary(db, 1, head); !reflect.DeepEqual(have, want) {
+		t.Fatalf("summary mismatch: have %+v, want %+v", have, want)
+	}
+	if summary := ReadEpochSummary(db, 1, common.Hash{0x02}); summary != nil {
+		t.Fatalf("unexpected summary for reorged epoch: %v", summary)
+	}
+	// The stake is left out if the state was unavailable.
+	want = &EpochSummary{Epoch: 2, FeeRewards: new(big.Int), Validators: []common.Address{}, Punishes: []EpochPunish{}}
+	WriteEpochSummary(db, head, want)
+	if have := ReadEpochSummary(db, 2, head); have == nil || have.TotalStake != nil || have.StakingRewards != nil {
+		t.Fatalf("summary without stake mismatch: have %+v", have)
+	}
+}
@@ -0,0 +1,42 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// EventStreamCursor is the progress of an event publisher: Next is the number
+// of the next block to publish and Parent the hash of the last published one,
+// zero if none.
+type EventStreamCursor struct {
+	Next   uint64
+	Parent common.Hash
+}
+
+// ReadEventStreamCursor retrieves the cursor of the named event publisher, nil
+// if it never published.
+func ReadEventStreamCursor(db ethdb.KeyValueReader, name string) *EventStreamCursor {
+	blob, err := db.Get(append(eventStreamCursorPrefix, name...))
+	if err != nil {
+		return nil
+	}
+	cursor := new(EventStreamCursor)
+	if err := rlp.DecodeBytes(blob, cursor); err != nil {
+		log.Error("Invalid event stream cursor", "name", name, "err", err)
+		return nil
+	}
+	return cursor
+}
+
+// WriteEventStreamCursor stores the cursor of the named event publisher.
+func WriteEventStreamCursor(db ethdb.KeyValueWriter, name string, cursor *EventStreamCursor) {
+	blob, err := rlp.EncodeToBytes(cursor)
+	if err != nil {
+		log.Crit("Failed to encode event stream cursor", "err", err)
+	}
+	if err := db.Put(append(eventStreamCursorPrefix, name...), blob); err != nil {
+		log.Crit("Failed to store event stream cursor", "err", err)
+	}
+}
@@ -18,6 +18,7 @@ package rawdb
 
 import (
 	"bytes"
+	"encoding/binary"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -159,6 +160,24 @@ func WriteBloomBits(db ethdb.KeyValueWriter, bit uint, section uint64, head comm
 	}
 }
 
+// ReadBloomSectionSize retrieves the number of blocks of the sections of the
+// bloombits index, zero if not recorded.
+func ReadBloomSectionSize(db ethdb.KeyValueReader) uint64 {
+	data, _ := db.Get(bloomSectionSizeKey)
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+// WriteBloomSectionSize stores the number of blocks of the sections of the
+// bloombits index.
+func WriteBloomSectionSize(db ethdb.KeyValueWriter, size uint64) {
+	if err := db.Put(bloomSectionSizeKey, encodeBlockNumber(size)); err != nil {
+		log.Crit("Failed to store bloom section size", "err", err)
+	}
+}
+
 // DeleteBloombits removes all compressed bloom bits vector belonging to the
 // given section range and bit index.
 func DeleteBloombits(db ethdb.Database, bit uint, from uint64, to uint64) {
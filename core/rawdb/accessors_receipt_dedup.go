@@ -0,0 +1,217 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// dedupLogDataThreshold is the minimum size of log data which is stored
+	// content-addressed instead of inline in the receipts.
+	dedupLogDataThreshold = 256
+
+	// dedupReceiptsVersion prefixes the deduplicated storage form of the block
+	// receipts. The plain form is always an RLP list, so it can't start with it.
+	dedupReceiptsVersion = byte(0x01)
+)
+
+// receiptLogRefKeyLength is the length of the keys referencing log data.
+var receiptLogRefKeyLength = len(receiptLogRefPrefix) + common.HashLength + 8 + common.HashLength
+
+// dedupReceiptRLP is the deduplicated storage encoding of a receipt.
+type dedupReceiptRLP struct {
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Logs              []*dedupLogRLP
+}
+
+// dedupLogRLP is the deduplicated storage encoding of a log. If Dedup is set,
+// Data holds the hash of the log data stored separately.
+type dedupLogRLP struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+	Dedup   bool
+}
+
+// ReadReceiptLogData retrieves the deduplicated log data of the given hash.
+func ReadReceiptLogData(db ethdb.KeyValueReader, hash common.Hash) []byte {
+	data, _ := db.Get(receiptLogDataKey(hash))
+	return data
+}
+
+// WriteDedupReceipts stores all the transaction receipts belonging to a block
+// like WriteReceipts, but moves large log data out of the receipts into shared
+// content-addressed entries. The receipts of system transactions repeat the same
+// payloads over and over, which is only stored once this way.
+//
+// Each block references the log data entries it uses, which are pruned along
+// with the references once the receipts are deleted or frozen. Reads of the
+// receipts transparently restore the plain storage form.
+func WriteDedupReceipts(db ethdb.KeyValueWriter, hash common.Hash, number uint64, receipts types.Receipts) {
+	var (
+		stored = make([]*dedupReceiptRLP, len(receipts))
+		dedup  bool
+	)
+	for i, receipt := range receipts {
+		stored[i] = &dedupReceiptRLP{
+			PostStateOrStatus: receiptStatusEncoding(receipt),
+			CumulativeGasUsed: receipt.CumulativeGasUsed,
+			Logs:              make([]*dedupLogRLP, len(receipt.Logs)),
+		}
+		for j, l := range receipt.Logs {
+			entry := &dedupLogRLP{Address: l.Address, Topics: l.Topics, Data: l.Data}
+			if len(l.Data) >= dedupLogDataThreshold {
+				dataHash := crypto.Keccak256Hash(l.Data)
+				if err := db.Put(receiptLogDataKey(dataHash), l.Data); err != nil {
+					log.Crit("Failed to store receipt log data", "err", err)
+				}
+				if err := db.Put(receiptLogRefKey(dataHash, number, hash), nil); err != nil {
+					log.Crit("Failed to store receipt log reference", "err", err)
+				}
+				entry.Data, entry.Dedup = dataHash.Bytes(), true
+				dedup = true
+			}
+			stored[i].Logs[j] = entry
+		}
+	}
+	// Nothing to share, stick to the plain form
+	if !dedup {
+		WriteReceipts(db, hash, number, receipts)
+		return
+	}
+	blob, err := rlp.EncodeToBytes(stored)
+	if err != nil {
+		log.Crit("Failed to encode block receipts", "err", err)
+	}
+	if err := db.Put(blockReceiptsKey(number, hash), append([]byte{dedupReceiptsVersion}, blob...)); err != nil {
+		log.Crit("Failed to store block receipts", "err", err)
+	}
+}
+
+// receiptStatusEncoding returns the post state or status field of a receipt
+// as stored in the database.
+func receiptStatusEncoding(receipt *types.Receipt) []byte {
+	if len(receipt.PostState) > 0 {
+		return receipt.PostState
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return []byte{}
+	}
+	return []byte{0x01}
+}
+
+// expandReceiptsRLP restores the plain storage form of the block receipts if
+// they are stored deduplicated. Nil is returned if the deduplicated log data
+// can't be retrieved.
+func expandReceiptsRLP(db ethdb.KeyValueReader, data []byte) []byte {
+	if len(data) == 0 || data[0] != dedupReceiptsVersion {
+		return data
+	}
+	var stored []*dedupReceiptRLP
+	if err := rlp.DecodeBytes(data[1:], &stored); err != nil {
+		log.Error("Invalid deduplicated receipt array RLP", "err", err)
+		return nil
+	}
+	receipts := make([]*storedReceiptRLP, len(stored))
+	for i, receipt := range stored {
+		receipts[i] = &storedReceiptRLP{
+			PostStateOrStatus: receipt.PostStateOrStatus,
+			CumulativeGasUsed: receipt.CumulativeGasUsed,
+			Logs:              make([]*types.Log, len(receipt.Logs)),
+		}
+		for j, l := range receipt.Logs {
+			data := l.Data
+			if l.Dedup {
+				dataHash := common.BytesToHash(l.Data)
+				if data = ReadReceiptLogData(db, dataHash); data == nil {
+					log.Error("Missing receipt log data", "hash", dataHash)
+					return nil
+				}
+			}
+			receipts[i].Logs[j] = &types.Log{Address: l.Address, Topics: l.Topics, Data: data}
+		}
+	}
+	blob, err := rlp.EncodeToBytes(receipts)
+	if err != nil {
+		log.Error("Failed to encode expanded receipts", "err", err)
+		return nil
+	}
+	return blob
+}
+
+// StaleReceiptLogRefs returns the references to log data of the receipts no
+// longer stored deduplicated in the key-value store: deleted, frozen or
+// rewritten in the plain form.
+func StaleReceiptLogRefs(db ethdb.KeyValueStore) [][]byte {
+	it := db.NewIterator(receiptLogRefPrefix, nil)
+	defer it.Release()
+
+	var stale [][]byte
+	for it.Next() {
+		if key := it.Key(); len(key) == receiptLogRefKeyLength && !receiptLogRefLive(db, key) {
+			stale = append(stale, common.CopyBytes(key))
+		}
+	}
+	return stale
+}
+
+// receiptLogRefLive reports whether the receipts holding a log data reference
+// are still stored deduplicated.
+func receiptLogRefLive(db ethdb.KeyValueReader, key []byte) bool {
+	var (
+		offset = len(receiptLogRefPrefix) + common.HashLength
+		number = binary.BigEndian.Uint64(key[offset : offset+8])
+		hash   = common.BytesToHash(key[offset+8:])
+	)
+	data, _ := db.Get(blockReceiptsKey(number, hash))
+	return len(data) > 0 && data[0] == dedupReceiptsVersion
+}
+
+// PruneReceiptLogData deletes the given references if still stale, and the log
+// data left unreferenced, returning the number of log data entries deleted. The
+// references are rechecked, but the caller must ensure no receipts are written
+// concurrently.
+func PruneReceiptLogData(db ethdb.KeyValueStore, stale [][]byte) int {
+	var (
+		batch    = db.NewBatch()
+		orphaned = make(map[common.Hash]struct{})
+	)
+	for _, key := range stale {
+		if receiptLogRefLive(db, key) {
+			continue
+		}
+		if err := batch.Delete(key); err != nil {
+			log.Crit("Failed to delete receipt log reference", "err", err)
+		}
+		orphaned[common.BytesToHash(key[len(receiptLogRefPrefix):len(receiptLogRefPrefix)+common.HashLength])] = struct{}{}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete receipt log references", "err", err)
+	}
+	batch.Reset()
+
+	var pruned int
+	for dataHash := range orphaned {
+		it := db.NewIterator(append(receiptLogRefPrefix, dataHash.Bytes()...), nil)
+		referenced := it.Next()
+		it.Release()
+		if referenced {
+			continue
+		}
+		if err := batch.Delete(receiptLogDataKey(dataHash)); err != nil {
+			log.Crit("Failed to delete receipt log data", "err", err)
+		}
+		pruned++
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete receipt log data", "err", err)
+	}
+	return pruned
+}
@@ -0,0 +1,116 @@
+package rawdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestDedupReceiptStorage(t *testing.T) {
+	payload := bytes.Repeat([]byte{0xaa}, dedupLogDataThreshold)
+	receipts := types.Receipts{
+		&types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 1,
+			Logs: []*types.Log{
+				{Address: common.Address{0x11}, Topics: []common.Hash{{0x01}}, Data: payload},
+				{Address: common.Address{0x12}, Data: []byte{0x01, 0x02}},
+			},
+		},
+		&types.Receipt{
+			Status:            types.ReceiptStatusFailed,
+			CumulativeGasUsed: 2,
+		},
+		&types.Receipt{
+			PostState:         common.Hash{0x03}.Bytes(),
+			CumulativeGasUsed: 3,
+			Logs: []*types.Log{
+				{Address: common.Address{0x11}, Topics: []common.Hash{{0x01}, {0x02}}, Data: payload},
+			},
+		},
+	}
+	for _, receipt := range receipts {
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	}
+	var (
+		plain = NewMemoryDatabase()
+		dedup = NewMemoryDatabase()
+		hash  = common.Hash{0x01}
+	)
+	WriteReceipts(plain, hash, 1, receipts)
+	WriteDedupReceipts(dedup, hash, 1, receipts)
+
+	want := ReadReceiptsRLP(plain, hash, 1)
+	if have := ReadReceiptsRLP(dedup, hash, 1); !bytes.Equal(have, want) {
+		t.Fatalf("receipts RLP mismatch: have %x, want %x", have, want)
+	}
+	if stored := readStoredReceiptsRLP(dedup, hash, 1); stored[0] != dedupReceiptsVersion || len(stored) >= len(want) {
+		t.Fatalf("receipts not stored deduplicated: %x", stored)
+	}
+	if err := checkReceiptsRLP(ReadRawReceipts(dedup, hash, 1), receipts); err != nil {
+		t.Fatal(err)
+	}
+	if data := ReadReceiptLogData(dedup, crypto.Keccak256Hash(payload)); !bytes.Equal(data, payload) {
+		t.Fatalf("log data mismatch: have %x", data)
+	}
+	// Receipts without large log data are stored in the plain form
+	small := types.Receipts{receipts[1]}
+	WriteDedupReceipts(dedup, hash, 2, small)
+	blob, _ := rlp.EncodeToBytes([]*types.ReceiptForStorage{(*types.ReceiptForStorage)(small[0])})
+	if stored := readStoredReceiptsRLP(dedup, hash, 2); !bytes.Equal(stored, blob) {
+		t.Fatalf("small receipts mismatch: have %x, want %x", stored, blob)
+	}
+	// Missing log data makes the receipts unavailable
+	dedup.Delete(receiptLogDataKey(crypto.Keccak256Hash(payload)))
+	if rs := ReadRawReceipts(dedup, hash, 1); rs != nil {
+		t.Fatalf("receipts returned with missing log data: %v", rs)
+	}
+}
+
+// Tests that the deduplicated log data is pruned once no longer referenced by
+// any stored receipts, and only then.
+func TestPruneReceiptLogData(t *testing.T) {
+	var (
+		db       = NewMemoryDatabase()
+		payload  = bytes.Repeat([]byte{0xaa}, dedupLogDataThreshold)
+		dataHash = crypto.Keccak256Hash(payload)
+		receipts = types.Receipts{&types.Receipt{
+			Status: types.ReceiptStatusSuccessful,
+			Logs:   []*types.Log{{Address: common.Address{0x11}, Data: payload}},
+		}}
+	)
+	WriteDedupReceipts(db, common.Hash{0x01}, 1, receipts)
+	WriteDedupReceipts(db, common.Hash{0x02}, 2, receipts)
+	if stale := StaleReceiptLogRefs(db); len(stale) != 0 {
+		t.Fatalf("stale references of stored receipts: %x", stale)
+	}
+	// Receipts rewritten in the plain form, e.g. frozen, release their reference
+	WriteReceipts(db, common.Hash{0x01}, 1, receipts)
+	stale := StaleReceiptLogRefs(db)
+	if len(stale) != 1 {
+		t.Fatalf("stale references mismatch: have %d, want 1", len(stale))
+	}
+	if pruned := PruneReceiptLogData(db, stale); pruned != 0 || ReadReceiptLogData(db, dataHash) == nil {
+		t.Fatalf("referenced log data pruned")
+	}
+	// Deleted receipts release their reference, the last one the log data
+	DeleteReceipts(db, common.Hash{0x02}, 2)
+	if pruned := PruneReceiptLogData(db, StaleReceiptLogRefs(db)); pruned != 1 || ReadReceiptLogData(db, dataHash) != nil {
+		t.Fatalf("unreferenced log data not pruned")
+	}
+	if stale := StaleReceiptLogRefs(db); len(stale) != 0 {
+		t.Fatalf("stale references left: %x", stale)
+	}
+	// References rechecked live are kept
+	WriteDedupReceipts(db, common.Hash{0x03}, 3, receipts)
+	if pruned := PruneReceiptLogData(db, [][]byte{receiptLogRefKey(dataHash, 3, common.Hash{0x03})}); pruned != 0 {
+		t.Fatalf("live reference pruned")
+	}
+	if have := ReadRawReceipts(db, common.Hash{0x03}, 3); len(have) != 1 {
+		t.Fatalf("receipts unavailable after pruning")
+	}
+}
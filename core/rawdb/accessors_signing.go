@@ -0,0 +1,26 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ReadSigningFloor retrieves the lowest block number the validator may sign,
+// zero if it never signed.
+func ReadSigningFloor(db ethdb.KeyValueReader, validator common.Address) uint64 {
+	data, _ := db.Get(signingFloorKey(validator))
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+// WriteSigningFloor stores the lowest block number the validator may sign.
+func WriteSigningFloor(db ethdb.KeyValueWriter, validator common.Address, number uint64) {
+	if err := db.Put(signingFloorKey(validator), encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store signing floor", "err", err)
+	}
+}
@@ -196,6 +196,31 @@ func DeleteSnapshotRecoveryNumber(db ethdb.KeyValueWriter) {
 	}
 }
 
+// ReadPruningMarker retrieves the database key up to which the offline state
+// pruning has deleted the stale state, nil if no pruning is in progress.
+func ReadPruningMarker(db ethdb.KeyValueReader) []byte {
+	data, _ := db.Get(pruningMarkerKey)
+	if len(data) == 0 {
+		return nil
+	}
+	return data
+}
+
+// WritePruningMarker stores the database key up to which the offline state
+// pruning has deleted the stale state.
+func WritePruningMarker(db ethdb.KeyValueWriter, marker []byte) {
+	if err := db.Put(pruningMarkerKey, marker); err != nil {
+		log.Crit("Failed to store pruning marker", "err", err)
+	}
+}
+
+// DeletePruningMarker deletes the progress marker of the offline state pruning.
+func DeletePruningMarker(db ethdb.KeyValueWriter) {
+	if err := db.Delete(pruningMarkerKey); err != nil {
+		log.Crit("Failed to remove pruning marker", "err", err)
+	}
+}
+
 // ReadSnapshotSyncStatus retrieves the serialized sync status saved at shutdown.
 func ReadSnapshotSyncStatus(db ethdb.KeyValueReader) []byte {
 	data, _ := db.Get(snapshotSyncStatusKey)
@@ -0,0 +1,155 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// StateAccessAge counts the accounts and storage slots accessed again after
+// not being accessed for the given number of epochs.
+type StateAccessAge struct {
+	Age      uint64
+	Accounts uint64
+	Slots    uint64
+}
+
+// StateAccessStats records the state accessed during an epoch, for the state
+// expiry research mode.
+type StateAccessStats struct {
+	Accounts    uint64           // Accounts accessed during the epoch
+	Slots       uint64           // Storage slots accessed during the epoch
+	NewAccounts uint64           // Accounts accessed for the first time since the recording started
+	NewSlots    uint64           // Storage slots accessed for the first time since the recording started
+	Ages        []StateAccessAge // State accessed again, by epochs since the previous access, ascending
+}
+
+// ReadStateAccess retrieves the last epoch the account, or its storage slot if
+// not nil, was accessed in.
+func ReadStateAccess(db ethdb.KeyValueReader, address common.Address, slot *common.Hash) (uint64, bool) {
+	data, _ := db.Get(stateAccessKey(address, slot))
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// WriteStateAccess stores the last epoch the account, or its storage slot if
+// not nil, was accessed in.
+func WriteStateAccess(db ethdb.KeyValueWriter, address common.Address, slot *common.Hash, epoch uint64) {
+	if err := db.Put(stateAccessKey(address, slot), encodeBlockNumber(epoch)); err != nil {
+		log.Crit("Failed to store state access", "err", err)
+	}
+}
+
+// IterateStateAccesses calls fn with the last access epoch of all the recorded
+// accounts and storage slots, the slot being nil for the accounts, until it
+// returns false.
+func IterateStateAccesses(db ethdb.Iteratee, fn func(address common.Address, slot *common.Hash, epoch uint64) bool) {
+	it := db.NewIterator(stateAccessPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key, value := it.Key()[len(stateAccessPrefix):], it.Value()
+		if len(value) != 8 {
+			continue
+		}
+		var slot *common.Hash
+		switch len(key) {
+		case common.AddressLength:
+		case common.AddressLength + common.HashLength:
+			hash := common.BytesToHash(key[common.AddressLength:])
+			slot = &hash
+		default:
+			continue
+		}
+		if !fn(common.BytesToAddress(key[:common.AddressLength]), slot, binary.BigEndian.Uint64(value)) {
+			return
+		}
+	}
+}
+
+// ReadStateAccessStats retrieves the state access statistics of the epoch.
+func ReadStateAccessStats(db ethdb.KeyValueReader, epoch uint64) *StateAccessStats {
+	data, _ := db.Get(stateAccessEpochKey(epoch))
+	if len(data) == 0 {
+		return nil
+	}
+	stats := new(StateAccessStats)
+	if err := rlp.DecodeBytes(data, stats); err != nil {
+		log.Error("Invalid state access stats", "epoch", epoch, "err", err)
+		return nil
+	}
+	return stats
+}
+
+// WriteStateAccessStats stores the state access statistics of the epoch.
+func WriteStateAccessStats(db ethdb.KeyValueWriter, epoch uint64, stats *StateAccessStats) {
+	blob, err := rlp.EncodeToBytes(stats)
+	if err != nil {
+		log.Crit("Failed to encode state access stats", "err", err)
+	}
+	if err := db.Put(stateAccessEpochKey(epoch), blob); err != nil {
+		log.Crit("Failed to store state access stats", "err", err)
+	}
+}
+
+// BlockStateAccess is an account accessed by a block, along with its accessed
+// storage slots.
+type BlockStateAccess struct {
+	Address common.Address
+	Slots   []common.Hash
+}
+
+// ReadBlockStateAccesses retrieves the state accessed by the block.
+func ReadBlockStateAccesses(db ethdb.KeyValueReader, number uint64, hash common.Hash) []BlockStateAccess {
+	data, _ := db.Get(blockStateAccessKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	var accesses []BlockStateAccess
+	if err := rlp.DecodeBytes(data, &accesses); err != nil {
+		log.Error("Invalid block state accesses RLP", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	return accesses
+}
+
+// WriteBlockStateAccesses stores the state accessed by the block.
+func WriteBlockStateAccesses(db ethdb.KeyValueWriter, number uint64, hash common.Hash, accesses []BlockStateAccess) {
+	blob, err := rlp.EncodeToBytes(accesses)
+	if err != nil {
+		log.Crit("Failed to encode block state accesses", "err", err)
+	}
+	if err := db.Put(blockStateAccessKey(number, hash), blob); err != nil {
+		log.Crit("Failed to store block state accesses", "err", err)
+	}
+}
+
+// DeleteBlockStateAccesses removes the state accessed by the block.
+func DeleteBlockStateAccesses(db ethdb.KeyValueWriter, number uint64, hash common.Hash) {
+	if err := db.Delete(blockStateAccessKey(number, hash)); err != nil {
+		log.Crit("Failed to delete block state accesses", "err", err)
+	}
+}
+
+// ReadStateAccessHead retrieves the next epoch the state accesses are aggregated
+// for, false if the recording never started.
+func ReadStateAccessHead(db ethdb.KeyValueReader) (uint64, bool) {
+	data, _ := db.Get(stateAccessHeadKey)
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// WriteStateAccessHead stores the next epoch the state accesses are aggregated
+// for.
+func WriteStateAccessHead(db ethdb.KeyValueWriter, epoch uint64) {
+	if err := db.Put(stateAccessHeadKey, encodeBlockNumber(epoch)); err != nil {
+		log.Crit("Failed to store state access head", "err", err)
+	}
+}
@@ -0,0 +1,41 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SystemEventEntry records a block emitting a system contract event. Event is
+// the position of the event in the list of indexed system events.
+type SystemEventEntry struct {
+	Number uint64
+	Event  uint8
+}
+
+// ReadSystemEvents retrieves the system event entries of the given section,
+// ordered by block number. Nil is returned if the section isn't indexed.
+func ReadSystemEvents(db ethdb.KeyValueReader, section uint64, head common.Hash) []SystemEventEntry {
+	blob, err := db.Get(systemEventsKey(section, head))
+	if err != nil {
+		return nil
+	}
+	entries := []SystemEventEntry{}
+	if err := rlp.DecodeBytes(blob, &entries); err != nil {
+		log.Error("Invalid system event index entries", "section", section, "err", err)
+		return nil
+	}
+	return entries
+}
+
+// WriteSystemEvents stores the system event entries of the given section.
+func WriteSystemEvents(db ethdb.KeyValueWriter, section uint64, head common.Hash, entries []SystemEventEntry) {
+	blob, err := rlp.EncodeToBytes(entries)
+	if err != nil {
+		log.Crit("Failed to encode system event index entries", "err", err)
+	}
+	if err := db.Put(systemEventsKey(section, head), blob); err != nil {
+		log.Crit("Failed to store system event index entries", "err", err)
+	}
+}
@@ -0,0 +1,29 @@
+package rawdb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSystemEventsStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	head := common.Hash{0x01}
+	if entries := ReadSystemEvents(db, 1, head); entries != nil {
+		t.Fatalf("unexpected entries for unindexed section: %v", entries)
+	}
+	WriteSystemEvents(db, 2, head, nil)
+	if entries := ReadSystemEvents(db, 2, head); entries == nil || len(entries) != 0 {
+		t.Fatalf("empty section mismatch: have %v", entries)
+	}
+	want := []SystemEventEntry{{Number: 4096, Event: 0}, {Number: 4100, Event: 6}, {Number: 4100, Event: 7}}
+	WriteSystemEvents(db, 1, head, want)
+	if have := ReadSystemEvents(db, 1, head); !reflect.DeepEqual(have, want) {
+		t.Fatalf("entries mismatch: have %v, want %v", have, want)
+	}
+	if entries := ReadSystemEvents(db, 1, common.Hash{0x02}); entries != nil {
+		t.Fatalf("unexpected entries for reorged section: %v", entries)
+	}
+}
@@ -0,0 +1,60 @@
+package rawdb
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Token standards of the indexed transfers.
+const (
+	TokenERC20   uint16 = 20
+	TokenERC721  uint16 = 721
+	TokenERC1155 uint16 = 1155
+)
+
+// TokenTransferEntry records a token moved by a standard transfer event. The
+// token id is zero for the ERC-20 transfers, and the value is one for the
+// ERC-721 ones.
+type TokenTransferEntry struct {
+	Number   uint64
+	TxHash   common.Hash
+	LogIndex uint32
+	Token    common.Address
+	Standard uint16
+	From     common.Address
+	To       common.Address
+	TokenID  *big.Int
+	Value    *big.Int
+}
+
+// ReadTokenTransfers retrieves the token transfers of the address within the
+// given section, ordered by block number and log index. Nil is returned if the
+// address has no transfers in the section or the section isn't indexed.
+func ReadTokenTransfers(db ethdb.KeyValueReader, address common.Address, section uint64, head common.Hash) []TokenTransferEntry {
+	blob, err := db.Get(tokenTransfersKey(address, section, head))
+	if err != nil {
+		return nil
+	}
+	var entries []TokenTransferEntry
+	if err := rlp.DecodeBytes(blob, &entries); err != nil {
+		log.Error("Invalid token transfer index entries", "address", address, "section", section, "err", err)
+		return nil
+	}
+	return entries
+}
+
+// WriteTokenTransfers stores the token transfers of the address within the
+// given section.
+func WriteTokenTransfers(db ethdb.KeyValueWriter, address common.Address, section uint64, head common.Hash, entries []TokenTransferEntry) {
+	blob, err := rlp.EncodeToBytes(entries)
+	if err != nil {
+		log.Crit("Failed to encode token transfer index entries", "err", err)
+	}
+	if err := db.Put(tokenTransfersKey(address, section, head), blob); err != nil {
+		log.Crit("Failed to store token transfer index entries", "err", err)
+	}
+}
@@ -0,0 +1,35 @@
+package rawdb
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTokenTransfersStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		head    = common.Hash{0x01}
+		address = common.Address{0xaa}
+	)
+	if entries := ReadTokenTransfers(db, address, 1, head); entries != nil {
+		t.Fatalf("unexpected entries for unindexed section: %v", entries)
+	}
+	want := []TokenTransferEntry{
+		{Number: 4096, TxHash: common.Hash{0x02}, Token: common.Address{0x10}, Standard: TokenERC20, From: address, To: common.Address{0xbb}, TokenID: new(big.Int), Value: big.NewInt(1000)},
+		{Number: 4100, TxHash: common.Hash{0x03}, LogIndex: 3, Token: common.Address{0x11}, Standard: TokenERC721, From: common.Address{0xbb}, To: address, TokenID: big.NewInt(7), Value: big.NewInt(1)},
+	}
+	WriteTokenTransfers(db, address, 1, head, want)
+	if have := ReadTokenTransfers(db, address, 1, head); !reflect.DeepEqual(have, want) {
+		t.Fatalf("entries mismatch: have %v, want %v", have, want)
+	}
+	if entries := ReadTokenTransfers(db, common.Address{0xbb}, 1, head); entries != nil {
+		t.Fatalf("unexpected entries for other address: %v", entries)
+	}
+	if entries := ReadTokenTransfers(db, address, 1, common.Hash{0x02}); entries != nil {
+		t.Fatalf("unexpected entries for reorged section: %v", entries)
+	}
+}
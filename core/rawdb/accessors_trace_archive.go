@@ -0,0 +1,255 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/snappy"
+)
+
+const (
+	// archivedInternalTxsVersion prefixes the archived storage form of the block
+	// internal transactions. The plain form is always an RLP list, so it can't
+	// start with it.
+	archivedInternalTxsVersion = byte(0x02)
+
+	// traceSelectorLength is the length of the input prefix stored in the
+	// dictionary, the selector of the called method.
+	traceSelectorLength = 4
+
+	// traceDictCacheSize is the number of dictionary entries cached in memory.
+	traceDictCacheSize = 65536
+)
+
+// archivedInternalTxRLP is the archived storage encoding of an internal
+// transaction. The block hash and number are implied by the database key, so
+// they are not stored, as in the plain form where they are filled on reads.
+type archivedInternalTxRLP struct {
+	TxHash  common.Hash
+	Actions []*archivedActionRLP
+}
+
+// archivedActionRLP is the archived storage encoding of an action. The values
+// repeated across blocks are replaced by their index in the trace dictionary,
+// zero standing for an empty value.
+type archivedActionRLP struct {
+	From         uint64
+	To           uint64
+	Value        *big.Int
+	Success      bool
+	OpCode       uint64
+	Depth        uint64
+	Gas          uint64
+	GasUsed      uint64
+	Selector     uint64 // Dictionary index of the input prefix
+	Input        []byte // Input following the prefix
+	Output       []byte
+	TraceAddress []uint64
+	Error        uint64
+}
+
+// TraceDictionary assigns persistent indexes to the values repeated in the
+// action traces of all the blocks: addresses, opcodes, method selectors and
+// errors. The entries are never deleted, any archived block might refer to them.
+type TraceDictionary struct {
+	db      ethdb.KeyValueStore
+	lock    sync.Mutex
+	indexes lru.BasicLRU[string, uint64]
+	count   uint64
+}
+
+// NewTraceDictionary opens the trace dictionary stored in the database.
+func NewTraceDictionary(db ethdb.KeyValueStore) *TraceDictionary {
+	d := &TraceDictionary{
+		db:      db,
+		indexes: lru.NewBasicLRU[string, uint64](traceDictCacheSize),
+	}
+	if data, _ := db.Get(traceDictCountKey); len(data) == 8 {
+		d.count = binary.BigEndian.Uint64(data)
+	}
+	return d
+}
+
+// Len returns the number of entries of the dictionary.
+func (d *TraceDictionary) Len() uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.count
+}
+
+// index returns the index of the entry, adding it to the batch if it's new.
+// The caller must hold the lock.
+func (d *TraceDictionary) index(batch ethdb.Batch, entry []byte) uint64 {
+	if len(entry) == 0 {
+		return 0
+	}
+	if index, ok := d.indexes.Get(string(entry)); ok {
+		return index
+	}
+	if data, _ := d.db.Get(traceDictEntryKey(entry)); len(data) == 8 {
+		index := binary.BigEndian.Uint64(data)
+		d.indexes.Add(string(entry), index)
+		return index
+	}
+	d.count++
+	index := d.count
+	if err := batch.Put(traceDictEntryKey(entry), encodeBlockNumber(index)); err != nil {
+		log.Crit("Failed to store trace dictionary entry", "err", err)
+	}
+	if err := batch.Put(traceDictIndexKey(index), entry); err != nil {
+		log.Crit("Failed to store trace dictionary entry", "err", err)
+	}
+	d.indexes.Add(string(entry), index)
+	return index
+}
+
+// ReadTraceDictEntry retrieves the trace dictionary entry of the given index.
+func ReadTraceDictEntry(db ethdb.KeyValueReader, index uint64) []byte {
+	data, _ := db.Get(traceDictIndexKey(index))
+	return data
+}
+
+// WriteArchivedInternalTxs stores all the internal transactions belonging to a
+// block like WriteInternalTxs, but in the archived form meant to keep the full
+// action traces of the entire chain: the addresses, opcodes, method selectors
+// and errors are replaced by their index in the dictionary shared by all the
+// blocks, and the result is snappy compressed.
+//
+// The new dictionary entries are written to the database right away, before
+// the internal transactions referring to them. Reads of the internal
+// transactions transparently restore the plain storage form.
+func WriteArchivedInternalTxs(db ethdb.KeyValueWriter, dict *TraceDictionary, hash common.Hash, number uint64, internalTxs types.InternalTxs) {
+	dict.lock.Lock()
+	defer dict.lock.Unlock()
+
+	var (
+		batch  = dict.db.NewBatch()
+		count  = dict.count
+		stored = make([]*archivedInternalTxRLP, len(internalTxs))
+	)
+	for i, itx := range internalTxs {
+		stored[i] = &archivedInternalTxRLP{
+			TxHash:  itx.TxHash,
+			Actions: make([]*archivedActionRLP, len(itx.Actions)),
+		}
+		for j, action := range itx.Actions {
+			entry := &archivedActionRLP{
+				From:         dict.index(batch, action.From.Bytes()),
+				To:           dict.index(batch, action.To.Bytes()),
+				Value:        action.Value,
+				Success:      action.Success,
+				OpCode:       dict.index(batch, []byte(action.OpCode)),
+				Depth:        action.Depth,
+				Gas:          action.Gas,
+				GasUsed:      action.GasUsed,
+				Input:        action.Input,
+				Output:       action.Output,
+				TraceAddress: action.TraceAddress,
+				Error:        dict.index(batch, []byte(action.Error)),
+			}
+			if len(action.Input) >= traceSelectorLength {
+				entry.Selector = dict.index(batch, action.Input[:traceSelectorLength])
+				entry.Input = action.Input[traceSelectorLength:]
+			}
+			stored[i].Actions[j] = entry
+		}
+	}
+	if dict.count != count {
+		if err := batch.Put(traceDictCountKey, encodeBlockNumber(dict.count)); err != nil {
+			log.Crit("Failed to store trace dictionary size", "err", err)
+		}
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to store trace dictionary entries", "err", err)
+		}
+	}
+	blob, err := rlp.EncodeToBytes(stored)
+	if err != nil {
+		log.Crit("Failed to encode block internal txs", "err", err)
+	}
+	data := append([]byte{archivedInternalTxsVersion}, snappy.Encode(nil, blob)...)
+	if err := db.Put(blockInternalTxsKey(number, hash), data); err != nil {
+		log.Crit("Failed to store block internal txs", "err", err)
+	}
+	traceBytesWritten.Add(uint64(len(data)))
+}
+
+// expandInternalTxsRLP restores the plain storage form of the block internal
+// transactions if they are archived. Nil is returned if the dictionary entries
+// can't be retrieved.
+func expandInternalTxsRLP(db ethdb.KeyValueReader, data []byte) []byte {
+	if len(data) == 0 || data[0] != archivedInternalTxsVersion {
+		return data
+	}
+	blob, err := snappy.Decode(nil, data[1:])
+	if err != nil {
+		log.Error("Invalid archived internal txs compression", "err", err)
+		return nil
+	}
+	var stored []*archivedInternalTxRLP
+	if err := rlp.DecodeBytes(blob, &stored); err != nil {
+		log.Error("Invalid archived internal txs RLP", "err", err)
+		return nil
+	}
+	var (
+		entries = make(map[uint64][]byte)
+		missing bool
+	)
+	entry := func(index uint64) []byte {
+		if index == 0 {
+			return nil
+		}
+		data, ok := entries[index]
+		if !ok {
+			if data = ReadTraceDictEntry(db, index); data == nil {
+				log.Error("Missing trace dictionary entry", "index", index)
+				missing = true
+			}
+			entries[index] = data
+		}
+		return data
+	}
+	internalTxs := make([]*types.InternalTxForStorage, len(stored))
+	for i, itx := range stored {
+		internalTxs[i] = &types.InternalTxForStorage{
+			TxHash:  itx.TxHash,
+			Actions: make([]*types.Action, len(itx.Actions)),
+		}
+		for j, action := range itx.Actions {
+			input := action.Input
+			if action.Selector != 0 {
+				input = append(common.CopyBytes(entry(action.Selector)), action.Input...)
+			}
+			internalTxs[i].Actions[j] = &types.Action{
+				From:         common.BytesToAddress(entry(action.From)),
+				To:           common.BytesToAddress(entry(action.To)),
+				Value:        action.Value,
+				Success:      action.Success,
+				OpCode:       string(entry(action.OpCode)),
+				Depth:        action.Depth,
+				Gas:          action.Gas,
+				GasUsed:      action.GasUsed,
+				Input:        input,
+				Output:       action.Output,
+				TraceAddress: action.TraceAddress,
+				Error:        string(entry(action.Error)),
+			}
+		}
+	}
+	if missing {
+		return nil
+	}
+	blob, err = rlp.EncodeToBytes(internalTxs)
+	if err != nil {
+		log.Error("Failed to encode expanded internal txs", "err", err)
+		return nil
+	}
+	return blob
+}
@@ -0,0 +1,166 @@
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// makeInternalTxs generates the internal transactions of a block, token
+// transfers and contract calls among a small set of accounts, as seen on
+// chains dominated by a few applications.
+func makeInternalTxs(rng *rand.Rand, txs int) types.InternalTxs {
+	var (
+		accounts  = 256
+		selectors = [][]byte{{0xa9, 0x05, 0x9c, 0xbb}, {0x23, 0xb8, 0x72, 0xdd}, {0x09, 0x5e, 0xa7, 0xb3}, {0x70, 0xa0, 0x82, 0x31}}
+		address   = func() common.Address {
+			var addr common.Address
+			binary.BigEndian.PutUint64(addr[12:], uint64(rng.Intn(accounts))*0x9e3779b97f4a7c15)
+			return addr
+		}
+	)
+	internalTxs := make(types.InternalTxs, txs)
+	for i := range internalTxs {
+		var hash common.Hash
+		rng.Read(hash[:])
+
+		itx := &types.InternalTx{TxHash: hash}
+		for j := 0; j < 1+rng.Intn(4); j++ {
+			input := append([]byte{}, selectors[rng.Intn(len(selectors))]...)
+			input = append(input, common.LeftPadBytes(address().Bytes(), 32)...)
+			input = append(input, common.LeftPadBytes(big.NewInt(rng.Int63()).Bytes(), 32)...)
+
+			action := &types.Action{
+				From:         address(),
+				To:           address(),
+				Value:        new(big.Int),
+				Success:      j != 2,
+				OpCode:       "CALL",
+				Depth:        uint64(j),
+				Gas:          uint64(rng.Intn(1000000)),
+				GasUsed:      uint64(rng.Intn(50000)),
+				Input:        input,
+				Output:       common.LeftPadBytes([]byte{0x01}, 32),
+				TraceAddress: []uint64{uint64(j)},
+			}
+			if j == 2 {
+				action.Error = "execution reverted"
+			}
+			if j == 0 {
+				action.Value = big.NewInt(rng.Int63())
+			}
+			itx.Actions = append(itx.Actions, action)
+		}
+		internalTxs[i] = itx
+	}
+	return internalTxs
+}
+
+func TestArchivedInternalTxs(t *testing.T) {
+	var (
+		db   = NewMemoryDatabase()
+		dict = NewTraceDictionary(db)
+		rng  = rand.New(rand.NewSource(1))
+	)
+	for number := uint64(1); number <= 5; number++ {
+		var (
+			hash        = common.Hash{byte(number)}
+			internalTxs = makeInternalTxs(rng, 20)
+		)
+		// Short inputs and empty actions are archived as well.
+		internalTxs[0].Actions[0].Input = []byte{0x01}
+		internalTxs = append(internalTxs, &types.InternalTx{TxHash: common.Hash{0xff}, Actions: []*types.Action{{OpCode: "CREATE"}}})
+
+		WriteArchivedInternalTxs(db, dict, hash, number, internalTxs)
+		stored, _ := db.Get(blockInternalTxsKey(number, hash))
+		if len(stored) == 0 || stored[0] != archivedInternalTxsVersion {
+			t.Fatalf("block %d: internal txs not archived", number)
+		}
+		storageITxs := make([]*types.InternalTxForStorage, len(internalTxs))
+		for i, itx := range internalTxs {
+			storageITxs[i] = (*types.InternalTxForStorage)(itx)
+		}
+		want, _ := rlp.EncodeToBytes(storageITxs)
+		if have := ReadInternalTxsRLP(db, hash, number); !bytes.Equal(have, want) {
+			t.Fatalf("block %d: expanded internal txs mismatch", number)
+		}
+		if have := ReadInternalTxs(db, hash, number); len(have) != len(internalTxs) || have[3].Actions[0].From != internalTxs[3].Actions[0].From {
+			t.Fatalf("block %d: decoded internal txs mismatch", number)
+		}
+	}
+	// The dictionary is reloaded from the database.
+	if reopened := NewTraceDictionary(db); reopened.Len() != dict.Len() || dict.Len() == 0 {
+		t.Errorf("dictionary size mismatch: have %d, want %d", reopened.Len(), dict.Len())
+	}
+	// Archived internal txs without their dictionary entries are unreadable.
+	db.Delete(traceDictIndexKey(1))
+	if data := ReadInternalTxsRLP(db, common.Hash{1}, 1); data != nil {
+		t.Error("internal txs expanded with missing dictionary entries")
+	}
+}
+
+// BenchmarkInternalTxsStorage compares the storage size of the action traces
+// of a chain segment in the plain RLP and the archived form, the dictionary
+// included.
+func BenchmarkInternalTxsStorage(b *testing.B) {
+	const blocks = 200
+
+	var (
+		rng    = rand.New(rand.NewSource(1))
+		chain  = make([]types.InternalTxs, blocks)
+		sizeOf = func(db interface{ Get([]byte) ([]byte, error) }, number uint64) int {
+			data, _ := db.Get(blockInternalTxsKey(number, common.Hash{}))
+			return len(data)
+		}
+	)
+	for i := range chain {
+		chain[i] = makeInternalTxs(rng, 100)
+	}
+	b.Run("plain", func(b *testing.B) {
+		var size int
+		for i := 0; i < b.N; i++ {
+			db := NewMemoryDatabase()
+			size = 0
+			for number, internalTxs := range chain {
+				WriteInternalTxs(db, common.Hash{}, uint64(number), internalTxs)
+				size += sizeOf(db, uint64(number))
+			}
+		}
+		b.ReportMetric(float64(size)/blocks, "bytes/block")
+	})
+	b.Run("archived", func(b *testing.B) {
+		var size int
+		for i := 0; i < b.N; i++ {
+			db := NewMemoryDatabase()
+			dict := NewTraceDictionary(db)
+			size = 0
+			for number, internalTxs := range chain {
+				WriteArchivedInternalTxs(db, dict, common.Hash{}, uint64(number), internalTxs)
+				size += sizeOf(db, uint64(number))
+			}
+			it := db.NewIterator([]byte("trace-dict-"), nil)
+			for it.Next() {
+				size += len(it.Key()) + len(it.Value())
+			}
+			it.Release()
+		}
+		b.ReportMetric(float64(size)/blocks, "bytes/block")
+	})
+	b.Run("read", func(b *testing.B) {
+		db := NewMemoryDatabase()
+		dict := NewTraceDictionary(db)
+		for number, internalTxs := range chain {
+			WriteArchivedInternalTxs(db, dict, common.Hash{}, uint64(number), internalTxs)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			ReadInternalTxs(db, common.Hash{}, uint64(i%blocks))
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package rawdb
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// traceMigrationBatch is the number of blocks whose internal transactions are
+// moved into the ancient store at once.
+const traceMigrationBatch = 1000
+
+// traceAncientReader is implemented by the databases with an attached ancient
+// store of migrated internal transactions.
+type traceAncientReader interface {
+	traceAncients() ethdb.AncientReaderOp
+}
+
+// OpenTraceFreezer opens the ancient store of the migrated internal transactions
+// read-only, as it's only written by the offline migration. Nil is returned if
+// the ancient directory has none.
+func OpenTraceFreezer(ancient string) ethdb.AncientStore {
+	if ancient == "" {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(ancient, TraceFreezerName)); err != nil {
+		return nil
+	}
+	freezer, err := NewTraceFreezer(ancient, true)
+	if err != nil {
+		log.Warn("Failed to open the migrated internal transactions", "err", err)
+		return nil
+	}
+	return freezer
+}
+
+// readFrozenInternalTxsRLP retrieves the internal transactions of a canonical
+// block from the trace ancient store in their storage form. The items hold the
+// block hash followed by the stored internal transactions, the blocks without
+// any being stored as empty items.
+func readFrozenInternalTxsRLP(db ethdb.Reader, hash common.Hash, number uint64) rlp.RawValue {
+	reader, ok := db.(traceAncientReader)
+	if !ok {
+		return nil
+	}
+	freezer := reader.traceAncients()
+	if freezer == nil {
+		return nil
+	}
+	blob, err := freezer.Ancient(traceTable, number)
+	if err != nil || len(blob) <= common.HashLength || !bytes.Equal(blob[:common.HashLength], hash[:]) {
+		return nil
+	}
+	return blob[common.HashLength:]
+}
+
+// MigrateInternalTxs moves the internal transactions of the canonical blocks up
+// to and including limit from the key-value store into the trace ancient store,
+// which holds one item per block number. The internal transactions of the side
+// blocks at the migrated heights are deleted, the blocks below the last
+// finalized one can't be reorged, so limit is expected to be the last finalized
+// block. The trace dictionary of the archived internal transactions is kept in
+// the key-value store.
+//
+// Items are synced to the ancient store before the key-value entries are
+// deleted, an interrupted migration can simply be resumed. The number of blocks
+// with migrated internal transactions is returned.
+func MigrateInternalTxs(db ethdb.Database, freezer ethdb.AncientStore, limit uint64) (uint64, error) {
+	frozen, err := freezer.Ancients()
+	if err != nil {
+		return 0, err
+	}
+	var migrated uint64
+	for frozen <= limit {
+		var (
+			last  = min(frozen+traceMigrationBatch-1, limit)
+			blobs = make([][]byte, 0, last-frozen+1)
+		)
+		for number := frozen; number <= last; number++ {
+			hash := ReadCanonicalHash(db, number)
+			if hash == (common.Hash{}) {
+				return migrated, fmt.Errorf("canonical hash of block #%d is missing", number)
+			}
+			var blob []byte
+			if data, _ := db.Get(blockInternalTxsKey(number, hash)); len(data) > 0 {
+				blob = append(hash.Bytes(), data...)
+			}
+			blobs = append(blobs, blob)
+		}
+		_, err := freezer.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+			for i, blob := range blobs {
+				if err := op.AppendRaw(traceTable, frozen+uint64(i), blob); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return migrated, err
+		}
+		if err := freezer.Sync(); err != nil {
+			return migrated, err
+		}
+		batch := db.NewBatch()
+		for i, blob := range blobs {
+			if len(blob) > 0 {
+				migrated++
+			}
+			prefix := append(append([]byte{}, blockInternalTxPrefix...), encodeBlockNumber(frozen+uint64(i))...)
+			it := db.NewIterator(prefix, nil)
+			for it.Next() {
+				if len(it.Key()) != len(prefix)+common.HashLength {
+					continue
+				}
+				if err := batch.Delete(it.Key()); err != nil {
+					it.Release()
+					return migrated, err
+				}
+			}
+			it.Release()
+		}
+		if err := batch.Write(); err != nil {
+			return migrated, err
+		}
+		log.Info("Migrated internal transactions", "number", last, "migrated", migrated)
+		frozen = last + 1
+	}
+	return migrated, nil
+}
@@ -0,0 +1,82 @@
+package rawdb
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// Tests that the internal transactions of the canonical blocks are moved into
+// the trace ancient store and still read through the database, while those of
+// the side blocks are dropped.
+func TestMigrateInternalTxs(t *testing.T) {
+	ancient := t.TempDir()
+	db, err := NewDatabaseWithFreezer(memorydb.New(), ancient, "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	var (
+		rng  = rand.New(rand.NewSource(1))
+		hash = func(n uint64) common.Hash { return common.BigToHash(new(big.Int).SetUint64(n + 1)) }
+		side = common.Hash{0xff}
+		dict = NewTraceDictionary(db)
+	)
+	// Blocks 0-5 canonical, 2 without internal transactions, 3 archived
+	for n := uint64(0); n <= 5; n++ {
+		WriteCanonicalHash(db, hash(n), n)
+		switch n {
+		case 2:
+		case 3:
+			WriteArchivedInternalTxs(db, dict, hash(n), n, makeInternalTxs(rng, 2))
+		default:
+			WriteInternalTxs(db, hash(n), n, makeInternalTxs(rng, 2))
+		}
+	}
+	WriteInternalTxs(db, side, 4, makeInternalTxs(rng, 1))
+
+	// The trace freezer is opened on the first read falling through the
+	// key-value store, so block 2 is only read after the migration
+	want := make(map[uint64][]byte)
+	for n := uint64(0); n <= 5; n++ {
+		if n != 2 {
+			want[n] = ReadInternalTxsRLP(db, hash(n), n)
+		}
+	}
+	freezer, err := NewTraceFreezer(ancient, false)
+	if err != nil {
+		t.Fatalf("failed to open trace freezer: %v", err)
+	}
+	migrated, err := MigrateInternalTxs(db, freezer, 4)
+	if err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	freezer.Close()
+	if migrated != 4 {
+		t.Fatalf("migrated blocks mismatch: have %d, want 4", migrated)
+	}
+	for n := uint64(0); n <= 4; n++ {
+		if has, _ := db.Has(blockInternalTxsKey(n, hash(n))); has {
+			t.Fatalf("block %d left in key-value store", n)
+		}
+	}
+	if has, _ := db.Has(blockInternalTxsKey(4, side)); has {
+		t.Fatal("side block left in key-value store")
+	}
+	if has, _ := db.Has(blockInternalTxsKey(5, hash(5))); !has {
+		t.Fatal("block above the limit migrated")
+	}
+	for n := uint64(0); n <= 5; n++ {
+		if have := ReadInternalTxsRLP(db, hash(n), n); !bytes.Equal(have, want[n]) {
+			t.Fatalf("block %d internal txs mismatch: have %x, want %x", n, have, want[n])
+		}
+	}
+	if data := ReadInternalTxsRLP(db, side, 4); data != nil {
+		t.Fatalf("side block read from the trace freezer: %x", data)
+	}
+}
@@ -0,0 +1,22 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ReadVerifiedContract retrieves the encoded source verification record of the
+// contract, nil if it isn't verified.
+func ReadVerifiedContract(db ethdb.KeyValueReader, address common.Address) []byte {
+	blob, _ := db.Get(append(verifiedContractPrefix, address.Bytes()...))
+	return blob
+}
+
+// WriteVerifiedContract stores the encoded source verification record of the
+// contract.
+func WriteVerifiedContract(db ethdb.KeyValueWriter, address common.Address, record []byte) {
+	if err := db.Put(append(verifiedContractPrefix, address.Bytes()...), record); err != nil {
+		log.Crit("Failed to store contract verification", "err", err)
+	}
+}
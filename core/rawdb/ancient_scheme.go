@@ -73,14 +73,34 @@ var stateFreezerNoSnappy = map[string]bool{
 	stateHistoryStorageData:  false,
 }
 
+// blockStatusTable indicates the name of the freezer block status table.
+const blockStatusTable = "status"
+
+// Block statuses are small records dominated by the block hash, which doesn't
+// compress well.
+var blockStatusFreezerNoSnappy = map[string]bool{
+	blockStatusTable: true,
+}
+
+// traceTable indicates the name of the freezer internal transaction table.
+const traceTable = "traces"
+
+// The internal transactions repeat the same addresses and inputs, which
+// compress well.
+var traceFreezerNoSnappy = map[string]bool{
+	traceTable: false,
+}
+
 // The list of identifiers of ancient stores.
 var (
-	ChainFreezerName = "chain" // the folder name of chain segment ancient store.
-	StateFreezerName = "state" // the folder name of reverse diff ancient store.
+	ChainFreezerName       = "chain"       // the folder name of chain segment ancient store.
+	StateFreezerName       = "state"       // the folder name of reverse diff ancient store.
+	BlockStatusFreezerName = "blockstatus" // the folder name of finalized block status ancient store.
+	TraceFreezerName       = "traces"      // the folder name of finalized internal transaction ancient store.
 )
 
 // freezers the collections of all builtin freezers.
-var freezers = []string{ChainFreezerName, StateFreezerName}
+var freezers = []string{ChainFreezerName, StateFreezerName, BlockStatusFreezerName, TraceFreezerName}
 
 // NewStateFreezer initializes the ancient store for state history.
 //
@@ -94,3 +114,31 @@ func NewStateFreezer(ancientDir string, readOnly bool) (ethdb.ResettableAncientS
 	}
 	return newResettableFreezer(filepath.Join(ancientDir, StateFreezerName), "eth/db/state", readOnly, stateHistoryTableSize, stateFreezerNoSnappy)
 }
+
+// NewBlockStatusFreezer initializes the ancient store for the statuses of the
+// finalized blocks.
+//
+//   - if the empty directory is given, initializes the pure in-memory
+//     block status freezer (e.g. dev mode).
+//   - if non-empty directory is given, initializes the regular file-based
+//     block status freezer.
+func NewBlockStatusFreezer(ancientDir string, readOnly bool) (ethdb.AncientStore, error) {
+	if ancientDir == "" {
+		return NewMemoryFreezer(readOnly, blockStatusFreezerNoSnappy), nil
+	}
+	return NewFreezer(filepath.Join(ancientDir, BlockStatusFreezerName), "eth/db/blockstatus", readOnly, freezerTableSize, blockStatusFreezerNoSnappy)
+}
+
+// NewTraceFreezer initializes the ancient store for the internal transactions
+// of the finalized blocks.
+//
+//   - if the empty directory is given, initializes the pure in-memory
+//     trace freezer (e.g. dev mode).
+//   - if non-empty directory is given, initializes the regular file-based
+//     trace freezer.
+func NewTraceFreezer(ancientDir string, readOnly bool) (ethdb.AncientStore, error) {
+	if ancientDir == "" {
+		return NewMemoryFreezer(readOnly, traceFreezerNoSnappy), nil
+	}
+	return NewFreezer(filepath.Join(ancientDir, TraceFreezerName), "eth/db/traces", readOnly, freezerTableSize, traceFreezerNoSnappy)
+}
@@ -105,6 +105,40 @@ func inspectFreezers(db ethdb.Database) ([]freezerInfo, error) {
 			}
 			infos = append(infos, info)
 
+		case BlockStatusFreezerName:
+			datadir, err := db.AncientDatadir()
+			if err != nil {
+				return nil, err
+			}
+			f, err := NewBlockStatusFreezer(datadir, true)
+			if err != nil {
+				continue // block statuses might not be migrated yet
+			}
+			defer f.Close()
+
+			info, err := inspect(freezer, blockStatusFreezerNoSnappy, f)
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, info)
+
+		case TraceFreezerName:
+			datadir, err := db.AncientDatadir()
+			if err != nil {
+				return nil, err
+			}
+			f, err := NewTraceFreezer(datadir, true)
+			if err != nil {
+				continue // internal transactions might not be migrated yet
+			}
+			defer f.Close()
+
+			info, err := inspect(freezer, traceFreezerNoSnappy, f)
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, info)
+
 		default:
 			return nil, fmt.Errorf("unknown freezer, supported ones: %v", freezers)
 		}
@@ -126,6 +160,10 @@ func InspectFreezerTable(ancient string, freezerName string, tableName string, s
 		path, tables = resolveChainFreezerDir(ancient), chainFreezerNoSnappy
 	case StateFreezerName:
 		path, tables = filepath.Join(ancient, freezerName), stateFreezerNoSnappy
+	case BlockStatusFreezerName:
+		path, tables = filepath.Join(ancient, freezerName), blockStatusFreezerNoSnappy
+	case TraceFreezerName:
+		path, tables = filepath.Join(ancient, freezerName), traceFreezerNoSnappy
 	default:
 		return fmt.Errorf("unknown freezer, supported ones: %v", freezers)
 	}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/prque"
+	"github.com/ethereum/go-ethereum/core/iosched"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
@@ -320,6 +321,12 @@ func unindexTransactions(db ethdb.Database, from uint64, to uint64, interrupt ch
 					return
 				}
 				batch.Reset()
+
+				// Pause the pruning while the local validator is in turn
+				select {
+				case <-iosched.Pause("pruning"):
+				case <-interrupt:
+				}
 			}
 			// If we've spent too much time already, notify the user of what we're doing
 			if time.Since(logged) > 8*time.Second {
@@ -0,0 +1,263 @@
+package rawdb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// The supported modes of the chain database verification.
+const (
+	VerifyOff  = "off"  // No verification
+	VerifyFast = "fast" // Head pointers, statuses and the most recent canonical blocks
+	VerifyFull = "full" // Like fast, but walks the entire canonical chain and all block statuses
+)
+
+// verifyFastDepth is the number of canonical blocks below the head header
+// checked by the fast verification.
+const verifyFastDepth = 1024
+
+// ChainIssue is an inconsistency found in the chain database.
+type ChainIssue struct {
+	Check      string // Name of the failed check
+	Problem    string // Description of the inconsistency
+	Suggestion string // Suggested repair
+	Fatal      bool   // Whether the node can't safely operate on the database
+}
+
+func (issue ChainIssue) String() string {
+	return fmt.Sprintf("%s: %s (%s)", issue.Check, issue.Problem, issue.Suggestion)
+}
+
+// rewindSuggestion suggests rewinding the chain to below the given block.
+func rewindSuggestion(number uint64) string {
+	if number == 0 {
+		return "resynchronise the chain after removing the database with 'geth removedb'"
+	}
+	return fmt.Sprintf("rewind the chain with 'debug.setHead(\"%#x\")' from the console, or resynchronise after 'geth removedb'", number-1)
+}
+
+// VerifyChain checks the consistency of the chain database: the head pointers
+// must reference stored canonical blocks, the canonical chain must be linked
+// and the block statuses must agree with the canonical chain and the last
+// finalized block. The fast mode limits the walk to the most recent blocks,
+// the full mode covers the whole chain.
+func VerifyChain(db ethdb.Reader, mode string) ([]ChainIssue, error) {
+	var depth uint64
+	switch mode {
+	case VerifyOff, "":
+		return nil, nil
+	case VerifyFast:
+		depth = verifyFastDepth
+	case VerifyFull:
+	default:
+		return nil, fmt.Errorf("unknown db.verify mode %q, supported ones: %s, %s, %s", mode, VerifyOff, VerifyFast, VerifyFull)
+	}
+	start := time.Now()
+
+	headHash := ReadHeadHeaderHash(db)
+	if headHash == (common.Hash{}) {
+		return nil, nil // Empty database, nothing to verify
+	}
+	issues, head := verifyHeads(db, headHash)
+	if head == nil {
+		return issues, nil
+	}
+	var lowest uint64
+	if depth > 0 && head.Number.Uint64() > depth {
+		lowest = head.Number.Uint64() - depth
+	}
+	issues = append(issues, verifyCanonical(db, head, lowest)...)
+	var freezer ethdb.AncientStore
+	if stater, ok := db.(ethdb.AncientStater); ok {
+		if freezer = OpenBlockStatusFreezer(stater); freezer != nil {
+			defer freezer.Close()
+		}
+	}
+	issues = append(issues, verifyBlockStatus(db, freezer, head.Number.Uint64(), lowest)...)
+
+	log.Info("Verified chain database", "mode", mode, "head", head.Number, "issues", len(issues), "elapsed", common.PrettyDuration(time.Since(start)))
+	return issues, nil
+}
+
+// verifyHeads checks that the head pointers reference stored canonical headers
+// and blocks, returning the head header the chain restarts from if available.
+// The lost head pointers are reported as warnings: the startup falls back to
+// the head block, or resets the chain if the head block itself is lost.
+func verifyHeads(db ethdb.Reader, headHash common.Hash) ([]ChainIssue, *types.Header) {
+	var issues []ChainIssue
+
+	head, problem := readHeadHeader(db, headHash)
+	if head == nil {
+		issues = append(issues, ChainIssue{
+			Check:      "head header",
+			Problem:    problem,
+			Suggestion: "the head header is reset to the head block on startup, the headers above it are downloaded again",
+		})
+		head, _ = readHeadHeader(db, ReadHeadBlockHash(db))
+	} else if canon := ReadCanonicalHash(db, head.Number.Uint64()); canon != headHash {
+		issues = append(issues, ChainIssue{
+			Check:      "head header",
+			Problem:    fmt.Sprintf("head header #%d [%x] is not canonical, canonical hash is %x", head.Number, headHash, canon),
+			Suggestion: rewindSuggestion(head.Number.Uint64()),
+		})
+	}
+	for _, ptr := range []struct {
+		name       string
+		hash       common.Hash
+		suggestion string
+	}{
+		{"head block", ReadHeadBlockHash(db), "the chain is reset to the genesis block on startup and synchronised again, restore a backup to keep the blocks"},
+		{"head snap block", ReadHeadFastBlockHash(db), "the head snap block is reset to the head block on startup"},
+	} {
+		if ptr.hash == (common.Hash{}) {
+			continue
+		}
+		n := ReadHeaderNumber(db, ptr.hash)
+		if n == nil || !HasBody(db, ptr.hash, *n) {
+			issues = append(issues, ChainIssue{
+				Check:      ptr.name,
+				Problem:    fmt.Sprintf("%s %x is not stored", ptr.name, ptr.hash),
+				Suggestion: ptr.suggestion,
+			})
+			continue
+		}
+		if head != nil && *n > head.Number.Uint64() {
+			issues = append(issues, ChainIssue{
+				Check:      ptr.name,
+				Problem:    fmt.Sprintf("%s #%d is above the head header #%d", ptr.name, *n, head.Number),
+				Suggestion: rewindSuggestion(head.Number.Uint64() + 1),
+			})
+		}
+		if canon := ReadCanonicalHash(db, *n); canon != ptr.hash {
+			issues = append(issues, ChainIssue{
+				Check:      ptr.name,
+				Problem:    fmt.Sprintf("%s #%d [%x] is not canonical, canonical hash is %x", ptr.name, *n, ptr.hash, canon),
+				Suggestion: rewindSuggestion(*n),
+			})
+		}
+	}
+	return issues, head
+}
+
+// readHeadHeader reads the header referenced by a head pointer, describing
+// the problem if it's not stored.
+func readHeadHeader(db ethdb.Reader, hash common.Hash) (*types.Header, string) {
+	number := ReadHeaderNumber(db, hash)
+	if number == nil {
+		return nil, fmt.Sprintf("head header %x has no number mapping", hash)
+	}
+	header := ReadHeader(db, hash, *number)
+	if header == nil {
+		return nil, fmt.Sprintf("head header #%d [%x] is missing", *number, hash)
+	}
+	return header, ""
+}
+
+// verifyCanonical walks the canonical chain from the head down to the lowest
+// block, checking that every block is stored and linked to its parent. The
+// walk stops at the first broken link.
+func verifyCanonical(db ethdb.Reader, head *types.Header, lowest uint64) []ChainIssue {
+	var (
+		header = head
+		logged = time.Now()
+	)
+	for number := head.Number.Uint64(); number > lowest; number-- {
+		parentHash := ReadCanonicalHash(db, number-1)
+		if parentHash == (common.Hash{}) {
+			return []ChainIssue{{
+				Check:      "canonical chain",
+				Problem:    fmt.Sprintf("canonical hash of block #%d is missing", number-1),
+				Suggestion: rewindSuggestion(number - 1),
+				Fatal:      true,
+			}}
+		}
+		if header.ParentHash != parentHash {
+			return []ChainIssue{{
+				Check:      "canonical chain",
+				Problem:    fmt.Sprintf("block #%d [%x] doesn't link to canonical parent %x", number, header.Hash(), parentHash),
+				Suggestion: rewindSuggestion(number - 1),
+				Fatal:      true,
+			}}
+		}
+		parent := ReadHeader(db, parentHash, number-1)
+		if parent == nil {
+			return []ChainIssue{{
+				Check:      "canonical chain",
+				Problem:    fmt.Sprintf("canonical header #%d [%x] is missing", number-1, parentHash),
+				Suggestion: rewindSuggestion(number - 1),
+				Fatal:      true,
+			}}
+		}
+		header = parent
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Verifying canonical chain", "number", number, "lowest", lowest)
+			logged = time.Now()
+		}
+	}
+	return nil
+}
+
+// verifyBlockStatus checks that the justified/finalized statuses agree with the
+// last finalized block and the canonical chain. The statuses of blocks below
+// lowest are not checked, apart from the last finalized one. The statuses are
+// read from the block status ancient store once migrated, if given.
+func verifyBlockStatus(db ethdb.Reader, freezer ethdb.AncientReaderOp, head uint64, lowest uint64) []ChainIssue {
+	var (
+		issues    []ChainIssue
+		finalized = LastFinalizedBlockNumber(db).Uint64()
+		last      = LastBlockStatusNumber(db).Uint64()
+	)
+	if finalized == 0 && last == 0 {
+		return nil // No attestations
+	}
+	if finalized > last {
+		issues = append(issues, ChainIssue{
+			Check:      "block status",
+			Problem:    fmt.Sprintf("last finalized block #%d is above the last block with a status #%d", finalized, last),
+			Suggestion: "the status markers are inconsistent, they are corrected as new blocks get finalized",
+		})
+	}
+	if finalized > head {
+		issues = append(issues, ChainIssue{
+			Check:      "block status",
+			Problem:    fmt.Sprintf("last finalized block #%d is above the head header #%d", finalized, head),
+			Suggestion: "the chain was rewound below finality, resynchronise to restore the finalized blocks",
+		})
+	}
+	check := func(number uint64) {
+		status, hash := ReadBlockStatus(db, freezer, number)
+		if status == types.BasUnknown {
+			return
+		}
+		if status == types.BasFinalized && number > finalized {
+			issues = append(issues, ChainIssue{
+				Check:      "block status",
+				Problem:    fmt.Sprintf("block #%d is finalized above the last finalized block #%d", number, finalized),
+				Suggestion: "the status markers are inconsistent, they are corrected as new blocks get finalized",
+			})
+		}
+		if status == types.BasFinalized && number <= head {
+			if canon := ReadCanonicalHash(db, number); canon != hash {
+				issues = append(issues, ChainIssue{
+					Check:      "block status",
+					Problem:    fmt.Sprintf("finalized block #%d [%x] is not canonical, canonical hash is %x", number, hash, canon),
+					Suggestion: rewindSuggestion(number),
+					Fatal:      true,
+				})
+			}
+		}
+	}
+	if finalized > 0 && finalized < lowest {
+		check(finalized)
+	}
+	for number := max(lowest, 1); number <= last; number++ {
+		check(number)
+	}
+	return issues
+}
@@ -0,0 +1,99 @@
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// writeVerifyTestChain writes a canonical chain of n blocks and points the
+// heads to its last block.
+func writeVerifyTestChain(db ethdb.Database, n int) []*types.Block {
+	var (
+		blocks []*types.Block
+		parent common.Hash
+	)
+	for i := 0; i < n; i++ {
+		block := types.NewBlockWithHeader(&types.Header{
+			Number:     big.NewInt(int64(i)),
+			ParentHash: parent,
+			Extra:      []byte("verify"),
+		})
+		WriteBlock(db, block)
+		WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	head := blocks[len(blocks)-1].Hash()
+	WriteHeadHeaderHash(db, head)
+	WriteHeadBlockHash(db, head)
+	WriteHeadFastBlockHash(db, head)
+	return blocks
+}
+
+func TestVerifyChain(t *testing.T) {
+	// A consistent chain has no issues
+	db := NewMemoryDatabase()
+	blocks := writeVerifyTestChain(db, 16)
+	for i := 1; i < 15; i++ {
+		status := types.BasFinalized
+		if i == 14 {
+			status = types.BasJustified
+		}
+		WriteBlockStatus(db, blocks[i].Number(), blocks[i].Hash(), status)
+	}
+	WriteLastBlockStatusNumber(db, big.NewInt(14))
+	WriteLastFinalizedBlockNumber(db, big.NewInt(13))
+
+	for _, mode := range []string{VerifyFast, VerifyFull} {
+		issues, err := VerifyChain(db, mode)
+		if err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+		if len(issues) != 0 {
+			t.Fatalf("%s: unexpected issues: %v", mode, issues)
+		}
+	}
+	if _, err := VerifyChain(db, "deep"); err == nil {
+		t.Fatal("unknown mode accepted")
+	}
+	// Break the canonical chain in the middle
+	WriteCanonicalHash(db, common.Hash{0x01}, 7)
+	issues, _ := VerifyChain(db, VerifyFull)
+	if len(issues) == 0 || issues[0].Check != "canonical chain" || !issues[0].Fatal {
+		t.Fatalf("broken canonical chain: unexpected issues: %v", issues)
+	}
+	WriteCanonicalHash(db, blocks[7].Hash(), 7)
+
+	// Finalized status of a non-canonical block
+	WriteBlockStatus(db, big.NewInt(10), common.Hash{0x02}, types.BasFinalized)
+	issues, _ = VerifyChain(db, VerifyFull)
+	if len(issues) != 1 || issues[0].Check != "block status" || !issues[0].Fatal {
+		t.Fatalf("non-canonical finalized block: unexpected issues: %v", issues)
+	}
+	WriteBlockStatus(db, big.NewInt(10), blocks[10].Hash(), types.BasFinalized)
+
+	// Missing head header, repaired on startup by falling back to the head block
+	WriteHeadHeaderHash(db, common.Hash{0x03})
+	issues, _ = VerifyChain(db, VerifyFull)
+	if len(issues) != 1 || issues[0].Check != "head header" || issues[0].Fatal {
+		t.Fatalf("missing head header: unexpected issues: %v", issues)
+	}
+	WriteHeadHeaderHash(db, blocks[15].Hash())
+
+	// Missing head block, also referenced by the head snap block, repaired on
+	// startup by resetting the chain
+	DeleteBody(db, blocks[15].Hash(), 15)
+	issues, _ = VerifyChain(db, VerifyFast)
+	if len(issues) != 2 || issues[0].Check != "head block" || issues[1].Check != "head snap block" {
+		t.Fatalf("missing head block: unexpected issues: %v", issues)
+	}
+	for _, issue := range issues {
+		if issue.Fatal {
+			t.Fatalf("self-healing issue reported as fatal: %v", issue)
+		}
+	}
+}
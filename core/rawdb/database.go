@@ -23,6 +23,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -41,6 +42,9 @@ type freezerdb struct {
 
 	readOnly    bool
 	ancientRoot string
+
+	traceOnce sync.Once
+	traces    ethdb.AncientStore // Internal transactions of the finalized blocks, nil if never migrated
 }
 
 // AncientDatadir returns the path of root ancient directory.
@@ -52,6 +56,12 @@ func (frdb *freezerdb) AncientDatadir() (string, error) {
 // the slow ancient tables.
 func (frdb *freezerdb) Close() error {
 	var errs []error
+	frdb.traceOnce.Do(func() {}) // Don't open the trace freezer after closing
+	if frdb.traces != nil {
+		if err := frdb.traces.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	if err := frdb.chainFreezer.Close(); err != nil {
 		errs = append(errs, err)
 	}
@@ -64,6 +74,18 @@ func (frdb *freezerdb) Close() error {
 	return nil
 }
 
+// traceAncients returns the ancient store of the migrated internal transactions,
+// opened on first use so that the offline migration can open it for writing.
+func (frdb *freezerdb) traceAncients() ethdb.AncientReaderOp {
+	frdb.traceOnce.Do(func() {
+		frdb.traces = OpenTraceFreezer(frdb.ancientRoot)
+	})
+	if frdb.traces == nil {
+		return nil
+	}
+	return frdb.traces
+}
+
 // Freeze is a helper method used for external testing to trigger and block until
 // a freeze cycle completes, without having to sleep for a minute to trigger the
 // automatic background run.
@@ -315,7 +337,11 @@ func NewMemoryDatabaseWithCap(size int) ethdb.Database {
 // NewLevelDBDatabase creates a persistent key-value database without a freezer
 // moving immutable chain segments into cold storage.
 func NewLevelDBDatabase(file string, cache int, handles int, namespace string, readonly bool) (ethdb.Database, error) {
-	db, err := leveldb.New(file, cache, handles, namespace, readonly)
+	return newLevelDBDatabase(file, cache, handles, namespace, readonly, leveldb.Config{})
+}
+
+func newLevelDBDatabase(file string, cache int, handles int, namespace string, readonly bool, config leveldb.Config) (ethdb.Database, error) {
+	db, err := leveldb.NewWithConfig(file, cache, handles, namespace, readonly, config)
 	if err != nil {
 		return nil, err
 	}
@@ -326,7 +352,11 @@ func NewLevelDBDatabase(file string, cache int, handles int, namespace string, r
 // NewPebbleDBDatabase creates a persistent key-value database without a freezer
 // moving immutable chain segments into cold storage.
 func NewPebbleDBDatabase(file string, cache int, handles int, namespace string, readonly, ephemeral bool) (ethdb.Database, error) {
-	db, err := pebble.New(file, cache, handles, namespace, readonly, ephemeral)
+	return newPebbleDBDatabase(file, cache, handles, namespace, readonly, ephemeral, pebble.Config{})
+}
+
+func newPebbleDBDatabase(file string, cache int, handles int, namespace string, readonly, ephemeral bool, config pebble.Config) (ethdb.Database, error) {
+	db, err := pebble.NewWithConfig(file, cache, handles, namespace, readonly, ephemeral, config)
 	if err != nil {
 		return nil, err
 	}
@@ -363,6 +393,7 @@ type OpenOptions struct {
 	Namespace         string // the namespace for database relevant metrics
 	Cache             int    // the capacity(in megabytes) of the data caching
 	Handles           int    // number of files to be open simultaneously
+	Profile           string // the tuning profile, see DatabaseProfiles
 	ReadOnly          bool
 	// Ephemeral means that filesystem sync operations should be avoided: data integrity in the face of
 	// a crash is not important. This option should typically be used in tests.
@@ -386,17 +417,24 @@ func openKeyValueDatabase(o OpenOptions) (ethdb.Database, error) {
 	if len(existingDb) != 0 && len(o.Type) != 0 && o.Type != existingDb {
 		return nil, fmt.Errorf("db.engine choice was %v but found pre-existing %v database in specified data directory", o.Type, existingDb)
 	}
+	profile, err := LookupDatabaseProfile(o.Profile)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Name != "" {
+		log.Info("Using database tuning profile", "profile", profile.Name)
+	}
 	if o.Type == dbPebble || existingDb == dbPebble {
 		log.Info("Using pebble as the backing database")
-		return NewPebbleDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly, o.Ephemeral)
+		return newPebbleDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly, o.Ephemeral, profile.Pebble)
 	}
 	if o.Type == dbLeveldb || existingDb == dbLeveldb {
 		log.Info("Using leveldb as the backing database")
-		return NewLevelDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly)
+		return newLevelDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly, profile.LevelDB)
 	}
 	// No pre-existing database, no user-requested one either. Default to Pebble.
 	log.Info("Defaulting to pebble as the backing database")
-	return NewPebbleDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly, o.Ephemeral)
+	return newPebbleDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly, o.Ephemeral, profile.Pebble)
 }
 
 // Open opens both a disk-based key-value database such as leveldb or pebble, but also
@@ -465,6 +503,10 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		headers         stat
 		bodies          stat
 		receipts        stat
+		receiptLogData  stat
+		receiptLogRefs  stat
+		internalTxs     stat
+		traceDict       stat
 		tds             stat
 		numHashPairings stat
 		hashNumPairings stat
@@ -478,6 +520,13 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		storageSnaps    stat
 		preimages       stat
 		bloomBits       stat
+		systemEvents    stat
+		epochSummaries  stat
+		tokenTransfers  stat
+		creations       stat
+		stateAccesses   stat
+		bridgeEvents    stat
+		accessDenials   stat
 		beaconHeaders   stat
 		cliqueSnaps     stat
 		turboSnaps      stat
@@ -507,6 +556,14 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 			bodies.Add(size)
 		case bytes.HasPrefix(key, blockReceiptsPrefix) && len(key) == (len(blockReceiptsPrefix)+8+common.HashLength):
 			receipts.Add(size)
+		case bytes.HasPrefix(key, receiptLogDataPrefix) && len(key) == (len(receiptLogDataPrefix)+common.HashLength):
+			receiptLogData.Add(size)
+		case bytes.HasPrefix(key, receiptLogRefPrefix) && len(key) == receiptLogRefKeyLength:
+			receiptLogRefs.Add(size)
+		case bytes.HasPrefix(key, blockInternalTxPrefix) && len(key) == (len(blockInternalTxPrefix)+8+common.HashLength):
+			internalTxs.Add(size)
+		case bytes.HasPrefix(key, traceDictEntryPrefix) || bytes.HasPrefix(key, traceDictIndexPrefix) || bytes.Equal(key, traceDictCountKey):
+			traceDict.Add(size)
 		case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerTDSuffix):
 			tds.Add(size)
 		case bytes.HasPrefix(key, headerPrefix) && bytes.HasSuffix(key, headerHashSuffix):
@@ -539,6 +596,30 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 			bloomBits.Add(size)
 		case bytes.HasPrefix(key, BloomBitsIndexPrefix):
 			bloomBits.Add(size)
+		case bytes.HasPrefix(key, systemEventsPrefix) && len(key) == (len(systemEventsPrefix)+8+common.HashLength):
+			systemEvents.Add(size)
+		case bytes.HasPrefix(key, SystemEventsIndexPrefix):
+			systemEvents.Add(size)
+		case bytes.HasPrefix(key, epochSummaryPrefix) && len(key) == (len(epochSummaryPrefix)+8+common.HashLength):
+			epochSummaries.Add(size)
+		case bytes.HasPrefix(key, EpochSummaryIndexPrefix):
+			epochSummaries.Add(size)
+		case bytes.HasPrefix(key, tokenTransfersPrefix) && len(key) == (len(tokenTransfersPrefix)+common.AddressLength+8+common.HashLength):
+			tokenTransfers.Add(size)
+		case bytes.HasPrefix(key, TokenTransfersIndexPrefix):
+			tokenTransfers.Add(size)
+		case bytes.HasPrefix(key, contractCreationPrefix) && len(key) == (len(contractCreationPrefix)+common.AddressLength+8+common.HashLength):
+			creations.Add(size)
+		case bytes.HasPrefix(key, stateAccessEpochPrefix) && len(key) == len(stateAccessEpochPrefix)+8:
+			stateAccesses.Add(size)
+		case bytes.HasPrefix(key, stateAccessPrefix) && (len(key) == len(stateAccessPrefix)+common.AddressLength || len(key) == len(stateAccessPrefix)+common.AddressLength+common.HashLength):
+			stateAccesses.Add(size)
+		case bytes.HasPrefix(key, blockStateAccessPrefix) && len(key) == len(blockStateAccessPrefix)+8+common.HashLength:
+			stateAccesses.Add(size)
+		case bytes.HasPrefix(key, bridgeEventsPrefix) && len(key) == len(bridgeEventsPrefix)+8+common.HashLength:
+			bridgeEvents.Add(size)
+		case bytes.HasPrefix(key, accessDenialsPrefix) && len(key) == len(accessDenialsPrefix)+8+common.HashLength:
+			accessDenials.Add(size)
 		case bytes.HasPrefix(key, skeletonHeaderPrefix) && len(key) == (len(skeletonHeaderPrefix)+8):
 			beaconHeaders.Add(size)
 		case bytes.HasPrefix(key, CliqueSnapshotPrefix) && len(key) == 7+common.HashLength:
@@ -553,14 +634,18 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 			bytes.HasPrefix(key, BloomTrieIndexPrefix) ||
 			bytes.HasPrefix(key, BloomTriePrefix): // Bloomtrie sub
 			bloomTrieNodes.Add(size)
+		case bytes.HasPrefix(key, eventStreamCursorPrefix) || bytes.HasPrefix(key, verifiedContractPrefix) ||
+			bytes.HasPrefix(key, signingFloorPrefix):
+			metadata.Add(size)
 		default:
 			var accounted bool
 			for _, meta := range [][]byte{
-				databaseVersionKey, headHeaderKey, headBlockKey, headFastBlockKey, headFinalizedBlockKey,
+				databaseVersionKey, schemaVersionKey, headHeaderKey, headBlockKey, headFastBlockKey, headFinalizedBlockKey,
 				lastPivotKey, fastTrieProgressKey, snapshotDisabledKey, SnapshotRootKey, snapshotJournalKey,
 				snapshotGeneratorKey, snapshotRecoveryKey, txIndexTailKey, fastTxLookupLimitKey,
 				uncleanShutdownKey, badBlockKey, transitionStatusKey, skeletonSyncStatusKey,
 				persistentStateIDKey, trieJournalKey, snapshotSyncStatusKey, snapSyncStatusFlagKey,
+				pruningMarkerKey, bridgeCursorKey, bloomSectionSizeKey, stateAccessHeadKey,
 			} {
 				if bytes.Equal(key, meta) {
 					metadata.Add(size)
@@ -583,11 +668,22 @@ func InspectDatabase(db ethdb.Database, keyPrefix, keyStart []byte) error {
 		{"Key-Value store", "Headers", headers.Size(), headers.Count()},
 		{"Key-Value store", "Bodies", bodies.Size(), bodies.Count()},
 		{"Key-Value store", "Receipt lists", receipts.Size(), receipts.Count()},
+		{"Key-Value store", "Receipt log data", receiptLogData.Size(), receiptLogData.Count()},
+		{"Key-Value store", "Receipt log references", receiptLogRefs.Size(), receiptLogRefs.Count()},
+		{"Key-Value store", "Internal transactions", internalTxs.Size(), internalTxs.Count()},
+		{"Key-Value store", "Trace dictionary", traceDict.Size(), traceDict.Count()},
 		{"Key-Value store", "Difficulties", tds.Size(), tds.Count()},
 		{"Key-Value store", "Block number->hash", numHashPairings.Size(), numHashPairings.Count()},
 		{"Key-Value store", "Block hash->number", hashNumPairings.Size(), hashNumPairings.Count()},
 		{"Key-Value store", "Transaction index", txLookups.Size(), txLookups.Count()},
 		{"Key-Value store", "Bloombit index", bloomBits.Size(), bloomBits.Count()},
+		{"Key-Value store", "System event index", systemEvents.Size(), systemEvents.Count()},
+		{"Key-Value store", "Epoch summaries", epochSummaries.Size(), epochSummaries.Count()},
+		{"Key-Value store", "Token transfers", tokenTransfers.Size(), tokenTransfers.Count()},
+		{"Key-Value store", "Contract creations", creations.Size(), creations.Count()},
+		{"Key-Value store", "State accesses", stateAccesses.Size(), stateAccesses.Count()},
+		{"Key-Value store", "Bridge events", bridgeEvents.Size(), bridgeEvents.Count()},
+		{"Key-Value store", "Access denials", accessDenials.Size(), accessDenials.Count()},
 		{"Key-Value store", "Contract codes", codes.Size(), codes.Count()},
 		{"Key-Value store", "Hash trie nodes", legacyTries.Size(), legacyTries.Count()},
 		{"Key-Value store", "Path trie state lookups", stateLookups.Size(), stateLookups.Count()},
@@ -652,6 +748,7 @@ func ReadChainMetadata(db ethdb.KeyValueStore) [][]string {
 	}
 	data := [][]string{
 		{"databaseVersion", pp(ReadDatabaseVersion(db))},
+		{"schemaVersion", pp(ReadSchemaVersion(db))},
 		{"headBlockHash", fmt.Sprintf("%v", ReadHeadBlockHash(db))},
 		{"headFastBlockHash", fmt.Sprintf("%v", ReadHeadFastBlockHash(db))},
 		{"headHeaderHash", fmt.Sprintf("%v", ReadHeadHeaderHash(db))},
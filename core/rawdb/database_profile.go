@@ -0,0 +1,91 @@
+package rawdb
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+)
+
+// DatabaseProfile tunes the key-value database for the role of a node.
+type DatabaseProfile struct {
+	Name        string
+	Description string
+
+	// CacheShare is the percentage of the total cache allowance dedicated to
+	// the database, used unless the share is configured explicitly.
+	CacheShare int
+
+	Pebble  pebble.Config
+	LevelDB leveldb.Config
+}
+
+// DatabaseProfiles are the supported database tuning profiles.
+//
+//   - validator: block processing competes with the compactions for the CPU,
+//     so the compactions are limited to half of the CPUs and the memory is
+//     shared with the trie caches.
+//   - archive: a write heavy workload, larger write buffers absorb the bursts
+//     and the compactions use all CPUs to keep up.
+//   - rpc: a read heavy workload, most of the memory goes to the block cache
+//     and denser bloom filters avoid needless disk reads for missing keys.
+var DatabaseProfiles = map[string]DatabaseProfile{
+	"validator": {
+		Name:        "validator",
+		Description: "low latency block processing, moderate cache",
+		CacheShare:  50,
+		Pebble: pebble.Config{
+			MemTableLimit:         2,
+			CompactionConcurrency: max(runtime.NumCPU()/2, 1),
+			BloomBits:             10,
+		},
+		LevelDB: leveldb.Config{WriteBufferShare: 50, BloomBits: 10},
+	},
+	"archive": {
+		Name:        "archive",
+		Description: "write heavy, large write buffers and unrestricted compactions",
+		CacheShare:  60,
+		Pebble: pebble.Config{
+			MemTableLimit:         4,
+			CompactionConcurrency: runtime.NumCPU(),
+			BloomBits:             10,
+		},
+		LevelDB: leveldb.Config{WriteBufferShare: 70, BloomBits: 10},
+	},
+	"rpc": {
+		Name:        "rpc",
+		Description: "read heavy, large block cache and dense bloom filters",
+		CacheShare:  70,
+		Pebble: pebble.Config{
+			MemTableLimit:         2,
+			CompactionConcurrency: runtime.NumCPU(),
+			BloomBits:             16,
+		},
+		LevelDB: leveldb.Config{WriteBufferShare: 25, BloomBits: 16},
+	},
+}
+
+// DatabaseProfileNames returns the names of the supported profiles.
+func DatabaseProfileNames() []string {
+	names := make([]string, 0, len(DatabaseProfiles))
+	for name := range DatabaseProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupDatabaseProfile returns the profile with the given name. The empty
+// name selects the default tuning.
+func LookupDatabaseProfile(name string) (DatabaseProfile, error) {
+	if name == "" {
+		return DatabaseProfile{}, nil
+	}
+	profile, ok := DatabaseProfiles[name]
+	if !ok {
+		return DatabaseProfile{}, fmt.Errorf("unknown db.profile %q, supported ones: %v", name, DatabaseProfileNames())
+	}
+	return profile, nil
+}
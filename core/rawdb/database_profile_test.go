@@ -0,0 +1,98 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+func TestLookupDatabaseProfile(t *testing.T) {
+	for _, name := range DatabaseProfileNames() {
+		profile, err := LookupDatabaseProfile(name)
+		if err != nil {
+			t.Fatalf("profile %s: %v", name, err)
+		}
+		if profile.Name != name {
+			t.Errorf("profile name mismatch: have %s, want %s", profile.Name, name)
+		}
+		if profile.CacheShare <= 0 || profile.CacheShare > 100 {
+			t.Errorf("profile %s: invalid cache share %d", name, profile.CacheShare)
+		}
+	}
+	if profile, err := LookupDatabaseProfile(""); err != nil || profile.Name != "" {
+		t.Errorf("default profile: have %v %v", profile, err)
+	}
+	if _, err := LookupDatabaseProfile("miner"); err == nil {
+		t.Error("unknown profile accepted")
+	}
+}
+
+// benchmarkProfiles runs the given workload against a pebble database opened
+// with the default tuning (before) and with each of the profiles (after).
+func benchmarkProfiles(b *testing.B, workload func(b *testing.B, db ethdb.Database)) {
+	for _, name := range append([]string{""}, DatabaseProfileNames()...) {
+		label := name
+		if label == "" {
+			label = "default"
+		}
+		b.Run(label, func(b *testing.B) {
+			db, err := Open(OpenOptions{
+				Type:      dbPebble,
+				Directory: b.TempDir(),
+				Cache:     64,
+				Handles:   64,
+				Profile:   name,
+				Ephemeral: true,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer db.Close()
+			workload(b, db)
+		})
+	}
+}
+
+func profileBenchKey(i int) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(i))
+	return crypto.Keccak256(buf[:])
+}
+
+func BenchmarkDatabaseProfileWrite(b *testing.B) {
+	benchmarkProfiles(b, func(b *testing.B, db ethdb.Database) {
+		value := make([]byte, 128)
+		batch := db.NewBatch()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			batch.Put(profileBenchKey(i), value)
+			if batch.ValueSize() >= ethdb.IdealBatchSize {
+				batch.Write()
+				batch.Reset()
+			}
+		}
+		batch.Write()
+	})
+}
+
+func BenchmarkDatabaseProfileRead(b *testing.B) {
+	const items = 100000
+	benchmarkProfiles(b, func(b *testing.B, db ethdb.Database) {
+		value := make([]byte, 128)
+		batch := db.NewBatch()
+		for i := 0; i < items; i++ {
+			batch.Put(profileBenchKey(i), value)
+		}
+		batch.Write()
+
+		// Read a mix of existing and missing keys, the latter exercising the
+		// bloom filters.
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			db.Get(profileBenchKey(rand.Intn(2 * items)))
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package rawdb
 
 import (
+	"sync/atomic"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -8,8 +10,25 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+// traceBytesWritten counts the bytes of block internal transactions written by
+// the process, tracking the growth of the trace store within the database.
+var traceBytesWritten atomic.Uint64
+
+// TraceBytesWritten returns the number of bytes of block internal transactions
+// written since the process started.
+func TraceBytesWritten() uint64 {
+	return traceBytesWritten.Load()
+}
+
 // ReadInternalTxsRLP retrieves all the transaction receipts belonging to a block in RLP encoding.
 func ReadInternalTxsRLP(db ethdb.Reader, hash common.Hash, number uint64) rlp.RawValue {
+	return expandInternalTxsRLP(db, readStoredInternalTxsRLP(db, hash, number))
+}
+
+// readStoredInternalTxsRLP retrieves all the internal transactions belonging to
+// a block in their storage form, which might be archived, from the key-value
+// store or the trace ancient store once migrated.
+func readStoredInternalTxsRLP(db ethdb.Reader, hash common.Hash, number uint64) rlp.RawValue {
 	var data []byte
 	db.ReadAncients(func(reader ethdb.AncientReaderOp) error {
 		// // // Check if the data is in ancients
@@ -29,6 +48,10 @@ func ReadInternalTxsRLP(db ethdb.Reader, hash common.Hash, number uint64) rlp.Ra
 		}
 		return nil
 	})
+	if data == nil {
+		// Fall back to the internal transactions migrated out of the key-value store
+		data = readFrozenInternalTxsRLP(db, hash, number)
+	}
 	return data
 }
 
@@ -64,6 +87,7 @@ func WriteInternalTxs(db ethdb.KeyValueWriter, hash common.Hash, number uint64,
 	if err := db.Put(blockInternalTxsKey(number, hash), bytes); err != nil {
 		log.Crit("Failed to encode block internal txs", "err", err)
 	}
+	traceBytesWritten.Add(uint64(len(bytes)))
 }
 
 // DeleteInternalTxs removes all internal transactions associated with a block hash.
@@ -0,0 +1,74 @@
+package rawdb
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// encodeInternalTxs encodes the internal transactions in their plain storage
+// form, without the block fields which aren't stored in the archived one.
+func encodeInternalTxs(internalTxs []*types.InternalTx) ([]byte, error) {
+	storageITxs := make([]*types.InternalTxForStorage, len(internalTxs))
+	for i, itx := range internalTxs {
+		storageITxs[i] = &types.InternalTxForStorage{TxHash: itx.TxHash, Actions: itx.Actions}
+	}
+	return rlp.EncodeToBytes(storageITxs)
+}
+
+// FuzzInternalTxs stores random data as the internal transactions of a block,
+// in the plain and the archived storage forms, checking that reading them
+// doesn't panic, and that the decodable ones survive being stored again in
+// either form.
+func FuzzInternalTxs(f *testing.F) {
+	var (
+		seed = NewMemoryDatabase()
+		dict = NewTraceDictionary(seed)
+	)
+	internalTxs := makeInternalTxs(rand.New(rand.NewSource(1)), 3)
+	WriteInternalTxs(seed, common.Hash{}, 1, internalTxs)
+	WriteArchivedInternalTxs(seed, dict, common.Hash{}, 2, internalTxs)
+	for number := uint64(1); number <= 2; number++ {
+		data, _ := seed.Get(blockInternalTxsKey(number, common.Hash{}))
+		f.Add(data)
+	}
+	f.Add([]byte{archivedInternalTxsVersion})
+	f.Add([]byte{0xc0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Store the dictionary entries of the seeds, so the archived data can
+		// refer to existing ones
+		db := NewMemoryDatabase()
+		WriteArchivedInternalTxs(db, NewTraceDictionary(db), common.Hash{}, 0, internalTxs)
+		if err := db.Put(blockInternalTxsKey(1, common.Hash{}), data); err != nil {
+			t.Fatal(err)
+		}
+		decoded := ReadInternalTxs(db, common.Hash{}, 1)
+		if decoded == nil {
+			return
+		}
+		want, err := encodeInternalTxs(decoded)
+		if err != nil {
+			t.Fatalf("failed to encode decoded internal txs: %v", err)
+		}
+		WriteInternalTxs(db, common.Hash{}, 2, decoded)
+		WriteArchivedInternalTxs(db, NewTraceDictionary(db), common.Hash{}, 3, decoded)
+		for number := uint64(2); number <= 3; number++ {
+			stored := ReadInternalTxs(db, common.Hash{}, number)
+			if stored == nil {
+				t.Fatalf("block %d: stored internal txs unreadable", number)
+			}
+			have, err := encodeInternalTxs(stored)
+			if err != nil {
+				t.Fatalf("block %d: failed to encode stored internal txs: %v", number, err)
+			}
+			if !bytes.Equal(have, want) {
+				t.Fatalf("block %d: stored internal txs mismatch\nhave %x\nwant %x", number, have, want)
+			}
+		}
+	})
+}
@@ -31,6 +31,9 @@ var (
 	// databaseVersionKey tracks the current database version.
 	databaseVersionKey = []byte("DatabaseVersion")
 
+	// schemaVersionKey tracks the version of the format of the Nero tables.
+	schemaVersionKey = []byte("NeroSchemaVersion")
+
 	// headHeaderKey tracks the latest known header's hash.
 	headHeaderKey = []byte("LastHeader")
 
@@ -67,6 +70,9 @@ var (
 	// snapshotRecoveryKey tracks the snapshot recovery marker across restarts.
 	snapshotRecoveryKey = []byte("SnapshotRecovery")
 
+	// pruningMarkerKey tracks the progress of the offline state pruning across restarts.
+	pruningMarkerKey = []byte("PruningMarker")
+
 	// snapshotSyncStatusKey tracks the snapshot sync status across restarts.
 	snapshotSyncStatusKey = []byte("SnapshotSyncStatus")
 
@@ -96,6 +102,62 @@ var (
 	// snapSyncStatusFlagKey flags that status of snap sync.
 	snapSyncStatusFlagKey = []byte("SnapSyncStatus")
 
+	// eventStreamCursorPrefix tracks the progress of the event publishers.
+	eventStreamCursorPrefix = []byte("eventstream-cursor-") // eventStreamCursorPrefix + name -> cursor
+
+	// bridgeCursorKey tracks the progress of the bridge watcher.
+	bridgeCursorKey = []byte("bridge-cursor")
+
+	// bloomSectionSizeKey tracks the section size of the bloombits index.
+	bloomSectionSizeKey = []byte("bloom-section-size")
+
+	// bridgeEventsPrefix records the bridge events watched per block.
+	bridgeEventsPrefix = []byte("bridge-events-") // bridgeEventsPrefix + num (uint64 big endian) + hash -> bridge events
+
+	// accessDenialsPrefix records the access filter denials met by the
+	// transactions of a block.
+	accessDenialsPrefix = []byte("access-denials-") // accessDenialsPrefix + num (uint64 big endian) + hash -> access denials
+
+	// verifiedContractPrefix tracks the source verifications of the contracts.
+	verifiedContractPrefix = []byte("verified-contract-") // verifiedContractPrefix + address -> verification record
+
+	// signingFloorPrefix tracks the lowest block number each local validator
+	// may sign, protecting it against double signing.
+	signingFloorPrefix = []byte("signing-floor-") // signingFloorPrefix + address -> block number (uint64 big endian)
+
+	// traceDictCountKey tracks the number of entries of the trace dictionary.
+	traceDictCountKey = []byte("TraceDictCount")
+
+	// traceDictEntryPrefix and traceDictIndexPrefix map the values shared by the
+	// archived action traces to their dictionary index and back.
+	traceDictEntryPrefix = []byte("trace-dict-e") // traceDictEntryPrefix + entry -> index (uint64 big endian)
+	traceDictIndexPrefix = []byte("trace-dict-i") // traceDictIndexPrefix + index (uint64 big endian) -> entry
+
+	// epochSummaryPrefix records the summaries of the Turbo epochs.
+	epochSummaryPrefix = []byte("epoch-summary-") // epochSummaryPrefix + epoch (uint64 big endian) + last block hash -> epoch summary
+
+	// contractCreationPrefix records the creations of the contracts, once per
+	// block creating them so the ones of reorged blocks can be told apart.
+	contractCreationPrefix = []byte("contract-creation-") // contractCreationPrefix + address + num (uint64 big endian) + hash -> contract creation
+
+	// stateAccessPrefix records the last epoch the accounts and storage slots
+	// were accessed in, for the state expiry research mode.
+	stateAccessPrefix = []byte("state-access-") // stateAccessPrefix + address (+ slot) -> epoch (uint64 big endian)
+
+	// stateAccessEpochPrefix records the state access statistics of the epochs.
+	stateAccessEpochPrefix = []byte("state-access-epoch-") // stateAccessEpochPrefix + epoch (uint64 big endian) -> state access stats
+
+	// blockStateAccessPrefix records the state accessed by the blocks, until
+	// their epoch is aggregated into the last access epochs.
+	blockStateAccessPrefix = []byte("block-state-access-") // blockStateAccessPrefix + num (uint64 big endian) + hash -> state accesses
+
+	// stateAccessHeadKey tracks the next epoch the state accesses are
+	// aggregated for.
+	stateAccessHeadKey = []byte("StateAccessHead")
+
+	// tokenTransfersPrefix records the token transfers of the addresses.
+	tokenTransfersPrefix = []byte("token-transfers-") // tokenTransfersPrefix + address + section (uint64 big endian) + hash -> token transfers
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -105,9 +167,13 @@ var (
 	blockBodyPrefix       = []byte("b") // blockBodyPrefix + num (uint64 big endian) + hash -> block body
 	blockReceiptsPrefix   = []byte("r") // blockReceiptsPrefix + num (uint64 big endian) + hash -> block receipts
 	blockInternalTxPrefix = []byte("x") // blockInternalTxPrefix + num (uint64 big endian) + hash -> block actions
+	receiptLogDataPrefix  = []byte("D") // receiptLogDataPrefix + data hash -> deduplicated receipt log data
+
+	receiptLogRefPrefix = []byte("DR") // receiptLogRefPrefix + data hash + num (uint64 big endian) + hash -> reference to deduplicated receipt log data
 
 	txLookupPrefix        = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
 	bloomBitsPrefix       = []byte("B") // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
+	systemEventsPrefix    = []byte("E") // systemEventsPrefix + section (uint64 big endian) + hash -> system event index entries
 	SnapshotAccountPrefix = []byte("a") // SnapshotAccountPrefix + account hash -> account trie value
 	SnapshotStoragePrefix = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
 	CodePrefix            = []byte("c") // CodePrefix + code hash -> account code
@@ -126,6 +192,10 @@ var (
 	// casperFFGAttestationsKey  = []byte("CFA") // casperFFGAttestationsKey
 	// epochCheckBpsKey          = []byte("ECB")
 	violateCasperFFGPunishKey = []byte("VCF")
+	casperFFGJournalKey       = []byte("CFJ") // casperFFGJournalKey -> the attestation histories journaled periodically and on shutdown
+
+	// attestationJournalPrefix records the votes of the local validators.
+	attestationJournalPrefix = []byte("attestation-journal-") // attestationJournalPrefix + address -> the votes journaled before signing them
 
 	PreimagePrefix = []byte("secure-key-")       // PreimagePrefix + hash -> preimage
 	configPrefix   = []byte("ethereum-config-")  // config prefix for the db
@@ -134,6 +204,15 @@ var (
 	// BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
 	BloomBitsIndexPrefix = []byte("iB")
 
+	// SystemEventsIndexPrefix is the data table of the system event indexer to track its progress
+	SystemEventsIndexPrefix = []byte("iE")
+
+	// EpochSummaryIndexPrefix is the data table of the epoch summary indexer to track its progress
+	EpochSummaryIndexPrefix = []byte("iP")
+
+	// TokenTransfersIndexPrefix is the data table of the token transfer indexer to track its progress
+	TokenTransfersIndexPrefix = []byte("iT")
+
 	ChtPrefix           = []byte("chtRootV2-") // ChtPrefix + chtNum (uint64 big endian) -> trie root hash
 	ChtTablePrefix      = []byte("cht-")
 	ChtIndexTablePrefix = []byte("chtIndexV2-")
@@ -208,6 +287,27 @@ func blockInternalTxsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockInternalTxPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// traceDictEntryKey = traceDictEntryPrefix + entry
+func traceDictEntryKey(entry []byte) []byte {
+	return append(append([]byte{}, traceDictEntryPrefix...), entry...)
+}
+
+// traceDictIndexKey = traceDictIndexPrefix + index (uint64 big endian)
+func traceDictIndexKey(index uint64) []byte {
+	return append(append([]byte{}, traceDictIndexPrefix...), encodeBlockNumber(index)...)
+}
+
+// receiptLogDataKey = receiptLogDataPrefix + data hash
+func receiptLogDataKey(hash common.Hash) []byte {
+	return append(receiptLogDataPrefix, hash.Bytes()...)
+}
+
+// receiptLogRefKey = receiptLogRefPrefix + data hash + num (uint64 big endian) + hash
+func receiptLogRefKey(dataHash common.Hash, number uint64, hash common.Hash) []byte {
+	key := append(append(receiptLogRefPrefix, dataHash.Bytes()...), encodeBlockNumber(number)...)
+	return append(key, hash.Bytes()...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
@@ -242,6 +342,62 @@ func bloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	return key
 }
 
+// systemEventsKey = systemEventsPrefix + section (uint64 big endian) + hash
+func systemEventsKey(section uint64, hash common.Hash) []byte {
+	return append(append(systemEventsPrefix, encodeBlockNumber(section)...), hash.Bytes()...)
+}
+
+// epochSummaryKey = epochSummaryPrefix + epoch (uint64 big endian) + hash
+func epochSummaryKey(epoch uint64, hash common.Hash) []byte {
+	return append(append(epochSummaryPrefix, encodeBlockNumber(epoch)...), hash.Bytes()...)
+}
+
+// contractCreationKey = contractCreationPrefix + address + num (uint64 big endian) + hash
+func contractCreationKey(address common.Address, number uint64, hash common.Hash) []byte {
+	key := append(append([]byte{}, contractCreationPrefix...), address.Bytes()...)
+	return append(append(key, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// bridgeEventsKey = bridgeEventsPrefix + num (uint64 big endian) + hash
+func bridgeEventsKey(number uint64, hash common.Hash) []byte {
+	return append(append(append([]byte{}, bridgeEventsPrefix...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// accessDenialsKey = accessDenialsPrefix + num (uint64 big endian) + hash
+func accessDenialsKey(number uint64, hash common.Hash) []byte {
+	return append(append(append([]byte{}, accessDenialsPrefix...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// signingFloorKey = signingFloorPrefix + address
+func signingFloorKey(validator common.Address) []byte {
+	return append(append([]byte{}, signingFloorPrefix...), validator.Bytes()...)
+}
+
+// stateAccessKey = stateAccessPrefix + address (+ slot)
+func stateAccessKey(address common.Address, slot *common.Hash) []byte {
+	key := append(append([]byte{}, stateAccessPrefix...), address.Bytes()...)
+	if slot != nil {
+		key = append(key, slot.Bytes()...)
+	}
+	return key
+}
+
+// stateAccessEpochKey = stateAccessEpochPrefix + epoch (uint64 big endian)
+func stateAccessEpochKey(epoch uint64) []byte {
+	return append(append([]byte{}, stateAccessEpochPrefix...), encodeBlockNumber(epoch)...)
+}
+
+// blockStateAccessKey = blockStateAccessPrefix + num (uint64 big endian) + hash
+func blockStateAccessKey(number uint64, hash common.Hash) []byte {
+	return append(append(append([]byte{}, blockStateAccessPrefix...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// tokenTransfersKey = tokenTransfersPrefix + address + section (uint64 big endian) + hash
+func tokenTransfersKey(address common.Address, section uint64, hash common.Hash) []byte {
+	key := append(append([]byte{}, tokenTransfersPrefix...), address.Bytes()...)
+	return append(append(key, encodeBlockNumber(section)...), hash.Bytes()...)
+}
+
 // skeletonHeaderKey = skeletonHeaderPrefix + num (uint64 big endian)
 func skeletonHeaderKey(number uint64) []byte {
 	return append(skeletonHeaderPrefix, encodeBlockNumber(number)...)
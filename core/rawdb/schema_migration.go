@@ -0,0 +1,105 @@
+package rawdb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SchemaMigration is a change of the format of the Nero tables, applied to the
+// databases written with an older schema version.
+type SchemaMigration struct {
+	Version  uint64   // Schema version of the migrated database
+	Name     string   // Description of the change
+	Prefixes [][]byte // Key prefixes of the rewritten entries, backed up before migrating
+
+	// Migrate rewrites the entries, it's rerun if interrupted so it must be
+	// idempotent. Nil if the version only marks a format without data to
+	// rewrite.
+	Migrate func(db ethdb.Database) error
+}
+
+// SchemaMigrations are the migrations of the Nero tables, in version order.
+// New formats are introduced by appending a migration.
+var SchemaMigrations = []SchemaMigration{
+	{Version: 1, Name: "track the schema version of the Nero tables"},
+}
+
+// MigrationConfig configures the application of the schema migrations.
+type MigrationConfig struct {
+	DryRun bool // Report the pending migrations without applying them
+
+	// Backup is called with the pending migrations before the first one is
+	// applied, aborting the migration if it fails.
+	Backup func(db ethdb.Database, pending []SchemaMigration) error
+}
+
+// LatestSchemaVersion returns the schema version of the databases migrated
+// with all the migrations.
+func LatestSchemaVersion(migrations []SchemaMigration) uint64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// MigrateSchema applies the migrations newer than the schema version of the
+// database, recording the version after each of them, and returns the pending
+// ones. A new database is written with the latest schema, a database without
+// recorded schema version predates the versioning and has version 0.
+func MigrateSchema(db ethdb.Database, migrations []SchemaMigration, config MigrationConfig) ([]SchemaMigration, error) {
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			return nil, fmt.Errorf("schema migration v%d out of order", migrations[i].Version)
+		}
+	}
+	var (
+		latest  = LatestSchemaVersion(migrations)
+		version uint64
+	)
+	if v := ReadSchemaVersion(db); v != nil {
+		version = *v
+	} else if ReadDatabaseVersion(db) == nil {
+		if !config.DryRun {
+			WriteSchemaVersion(db, latest)
+		}
+		return nil, nil
+	}
+	if version > latest {
+		return nil, fmt.Errorf("schema version is v%d, only v%d is supported", version, latest)
+	}
+	var pending []SchemaMigration
+	for _, migration := range migrations {
+		if migration.Version > version {
+			pending = append(pending, migration)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+	for _, migration := range pending {
+		log.Info("Schema migration pending", "version", migration.Version, "name", migration.Name, "dryrun", config.DryRun)
+	}
+	if config.DryRun {
+		return pending, nil
+	}
+	if config.Backup != nil {
+		if err := config.Backup(db, pending); err != nil {
+			return nil, fmt.Errorf("failed to back up the database before migrating: %v", err)
+		}
+	}
+	for _, migration := range pending {
+		start := time.Now()
+		if migration.Migrate != nil {
+			if err := migration.Migrate(db); err != nil {
+				return nil, fmt.Errorf("schema migration v%d (%s) failed: %v", migration.Version, migration.Name, err)
+			}
+		}
+		WriteSchemaVersion(db, migration.Version)
+		log.Info("Applied schema migration", "version", migration.Version, "name", migration.Name, "elapsed", common.PrettyDuration(time.Since(start)))
+	}
+	return pending, nil
+}
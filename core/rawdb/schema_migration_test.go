@@ -0,0 +1,93 @@
+package rawdb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+func TestMigrateSchema(t *testing.T) {
+	var (
+		applied    []uint64
+		fail       bool
+		migrations = []SchemaMigration{
+			{Version: 1, Name: "baseline"},
+			{Version: 2, Name: "rewrite", Prefixes: [][]byte{[]byte("x")}, Migrate: func(db ethdb.Database) error {
+				if fail {
+					return errors.New("interrupted")
+				}
+				applied = append(applied, 2)
+				return nil
+			}},
+			{Version: 3, Name: "rewrite again", Migrate: func(db ethdb.Database) error {
+				applied = append(applied, 3)
+				return nil
+			}},
+		}
+	)
+	checkVersion := func(db ethdb.Database, want uint64) {
+		t.Helper()
+		if have := ReadSchemaVersion(db); have == nil || *have != want {
+			t.Fatalf("schema version mismatch: have %v, want %d", have, want)
+		}
+	}
+	// A new database is written with the latest schema
+	db := NewMemoryDatabase()
+	if pending, err := MigrateSchema(db, migrations, MigrationConfig{}); err != nil || len(pending) != 0 {
+		t.Fatalf("new database migrated: %v, %v", pending, err)
+	}
+	checkVersion(db, 3)
+
+	// A database predating the versioning is migrated from version 0, a dry run
+	// leaving it untouched
+	db = NewMemoryDatabase()
+	WriteDatabaseVersion(db, 8)
+	pending, err := MigrateSchema(db, migrations, MigrationConfig{DryRun: true})
+	if err != nil || len(pending) != 3 {
+		t.Fatalf("dry run pending migrations mismatch: %d, %v", len(pending), err)
+	}
+	if ReadSchemaVersion(db) != nil || len(applied) != 0 {
+		t.Fatalf("dry run applied migrations")
+	}
+	// An interrupted migration is resumed, the backup seeing the pending ones
+	var backups []int
+	backup := func(db ethdb.Database, pending []SchemaMigration) error {
+		backups = append(backups, len(pending))
+		return nil
+	}
+	fail = true
+	if _, err := MigrateSchema(db, migrations, MigrationConfig{Backup: backup}); err == nil {
+		t.Fatalf("failed migration not reported")
+	}
+	checkVersion(db, 1)
+
+	fail = false
+	if pending, err := MigrateSchema(db, migrations, MigrationConfig{Backup: backup}); err != nil || len(pending) != 2 {
+		t.Fatalf("resumed migration failed: %d, %v", len(pending), err)
+	}
+	checkVersion(db, 3)
+	if len(applied) != 2 || applied[0] != 2 || applied[1] != 3 {
+		t.Errorf("applied migrations mismatch: %v", applied)
+	}
+	if len(backups) != 2 || backups[0] != 3 || backups[1] != 2 {
+		t.Errorf("backups mismatch: %v", backups)
+	}
+	// A failed backup aborts the migration
+	db = NewMemoryDatabase()
+	WriteDatabaseVersion(db, 8)
+	if _, err := MigrateSchema(db, migrations, MigrationConfig{Backup: func(ethdb.Database, []SchemaMigration) error {
+		return errors.New("no space left")
+	}}); err == nil || ReadSchemaVersion(db) != nil {
+		t.Errorf("migration applied despite failed backup: %v", err)
+	}
+	// A database written by a newer release is rejected
+	WriteSchemaVersion(db, 4)
+	if _, err := MigrateSchema(db, migrations, MigrationConfig{}); err == nil {
+		t.Errorf("newer schema version accepted")
+	}
+	// Migrations out of order are rejected
+	if _, err := MigrateSchema(NewMemoryDatabase(), []SchemaMigration{{Version: 2}, {Version: 1}}, MigrationConfig{}); err == nil {
+		t.Errorf("migrations out of order accepted")
+	}
+}
@@ -0,0 +1,39 @@
+package rawdb
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ExportTable describes a namespace of Nero specific key-value data which can
+// be copied between nodes, e.g. to seed an RPC replica from an archive node.
+type ExportTable struct {
+	Prefix  []byte                // Common prefix of the entries
+	Match   func(key []byte) bool // Filter of the entries sharing the prefix
+	Markers [][]byte              // Standalone entries exported along with the table
+}
+
+// ExportTables are the key-value namespaces supported by the table export.
+var ExportTables = map[string]ExportTable{
+	"internaltxs": {
+		Prefix: blockInternalTxPrefix,
+		Match: func(key []byte) bool {
+			return len(key) == len(blockInternalTxPrefix)+8+common.HashLength
+		},
+	},
+	"blockstatus": {
+		Prefix: blockStatusKey,
+		Match: func(key []byte) bool {
+			// The key carries the minimal big endian encoding of the number,
+			// other keys sharing the prefix don't encode back to themselves
+			number := key[len(blockStatusKey):]
+			if len(number) > 8 {
+				return false
+			}
+			return bytes.Equal(key, blockStatusKeyByNum(new(big.Int).SetBytes(number).Uint64()))
+		},
+		Markers: [][]byte{lastBlockStatusKey, lastFinalizedNumKey},
+	},
+}
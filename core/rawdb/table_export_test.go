@@ -0,0 +1,30 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Tests that the table filters only match the keys of their own entries.
+func TestExportTableMatch(t *testing.T) {
+	tests := []struct {
+		table string
+		key   []byte
+		want  bool
+	}{
+		{"blockstatus", blockStatusKeyByNum(0), true},
+		{"blockstatus", blockStatusKeyByNum(1), true},
+		{"blockstatus", blockStatusKeyByNum(1 << 40), true},
+		{"blockstatus", append(blockStatusKeyByNum(1), 0x01), true}, // #257
+		{"blockstatus", append(append([]byte{}, blockStatusKey...), 0x00, 0x01), false},
+		{"blockstatus", append(append([]byte{}, blockStatusKey...), make([]byte, 9)...), false},
+		{"internaltxs", blockInternalTxsKey(1, common.Hash{0x01}), true},
+		{"internaltxs", append(blockInternalTxsKey(1, common.Hash{0x01}), 0x00), false},
+	}
+	for i, tt := range tests {
+		if have := ExportTables[tt.table].Match(tt.key); have != tt.want {
+			t.Errorf("test %d: %s match of %x mismatch: have %v, want %v", i, tt.table, tt.key, have, tt.want)
+		}
+	}
+}
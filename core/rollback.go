@@ -0,0 +1,126 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrRollbackFinalized is returned if a rollback would cross the last finalized
+// block.
+var ErrRollbackFinalized = errors.New("rollback below the finalized block")
+
+// RollbackSummary describes the data discarded by a rollback.
+type RollbackSummary struct {
+	Requested     uint64 // Requested head block
+	Head          uint64 // New head block, below the requested one if its state was missing
+	PreviousHead  uint64
+	Finalized     uint64 // Last finalized block, never crossed
+	Blocks        uint64 // Discarded canonical blocks
+	Transactions  uint64 // Transactions of the discarded blocks
+	InternalTxs   uint64 // Discarded blocks with recorded internal transactions
+	BlockStatuses uint64 // Discarded block statuses
+}
+
+// FinalizedCheckpoint returns the number of the last finalized block, by the
+// Turbo finality or by the beacon chain, zero if none is.
+func (bc *BlockChain) FinalizedCheckpoint() uint64 {
+	finalized := rawdb.LastFinalizedBlockNumber(bc.db).Uint64()
+	if header := bc.CurrentFinalBlock(); header != nil && header.Number.Uint64() > finalized {
+		finalized = header.Number.Uint64()
+	}
+	return finalized
+}
+
+// Rollback rewinds the chain to the given block, refusing to cross the last
+// finalized block. If the state of the block is missing the chain is rewound
+// further back, to the first block with a state, as long as it isn't below the
+// finalized block either.
+//
+// Unlike SetHead, the internal transactions and the statuses of the discarded
+// blocks are removed along with them.
+func (bc *BlockChain) Rollback(number uint64) (*RollbackSummary, error) {
+	var (
+		head      = bc.CurrentBlock().Number.Uint64()
+		finalized = bc.FinalizedCheckpoint()
+	)
+	if number >= head {
+		return nil, fmt.Errorf("rollback target %d not below the head %d", number, head)
+	}
+	if number < finalized {
+		return nil, fmt.Errorf("%w: target %d, finalized %d", ErrRollbackFinalized, number, finalized)
+	}
+	// Find the first block with a state from the target down to the finalized
+	// block, SetHead would rewind to it anyway
+	target := number
+	for {
+		header := bc.GetHeaderByNumber(target)
+		if header == nil {
+			return nil, fmt.Errorf("missing canonical header %d", target)
+		}
+		if bc.HasState(header.Root) || bc.stateRecoverable(header.Root) {
+			break
+		}
+		if target == finalized {
+			return nil, fmt.Errorf("%w: no state available from block %d down to the finalized block %d", ErrRollbackFinalized, number, finalized)
+		}
+		target--
+	}
+	summary := &RollbackSummary{
+		Requested:    number,
+		Head:         target,
+		PreviousHead: head,
+		Finalized:    finalized,
+	}
+	discarded := make(map[uint64]common.Hash, head-target)
+	for n := target + 1; n <= head; n++ {
+		hash := bc.GetCanonicalHash(n)
+		if hash == (common.Hash{}) {
+			continue
+		}
+		discarded[n] = hash
+		summary.Blocks++
+		if body := bc.GetBody(hash); body != nil {
+			summary.Transactions += uint64(len(body.Transactions))
+		}
+	}
+	log.Warn("Rolling back chain", "target", target, "head", head, "finalized", finalized)
+	if err := bc.SetHead(target); err != nil {
+		return nil, err
+	}
+	// Remove the data of the discarded blocks which SetHead doesn't, keyed by
+	// the block hash. Statuses recorded since then for new blocks are kept.
+	batch := bc.db.NewBatch()
+	for n, hash := range discarded {
+		if len(rawdb.ReadInternalTxsRLP(bc.db, hash, n)) > 0 {
+			rawdb.DeleteInternalTxs(batch, hash, n)
+			summary.InternalTxs++
+		}
+		if _, statusHash := rawdb.ReadBlockStatus(bc.db, bc.blockStatusFreezer, n); statusHash == hash {
+			rawdb.DeleteBlockStatus(batch, n)
+			if bc.isTurboEngine {
+				bc.BlockStatusCache.Remove(n)
+			}
+			summary.BlockStatuses++
+		}
+	}
+	last := rawdb.LastBlockStatusNumber(bc.db)
+	if last.Uint64() > target {
+		last = new(big.Int).SetUint64(target)
+		rawdb.WriteLastBlockStatusNumber(batch, last)
+		if bc.isTurboEngine {
+			bc.currentBlockStatusNumber.Store(last)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	if bc.isTurboEngine {
+		bc.BlockStatusCache.Purge()
+	}
+	return summary, nil
+}
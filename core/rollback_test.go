@@ -0,0 +1,80 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that rollbacks never cross the finalized block, and discard the internal
+// transactions and statuses of the rewound blocks.
+func TestRollback(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		signer = types.LatestSigner(params.TestChainConfig)
+		gspec  = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		db = rawdb.NewMemoryDatabase()
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 10, func(i int, gen *BlockGen) {
+		gen.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: uint64(i), GasPrice: gen.BaseFee(), Gas: 21000, To: &common.Address{0x01}}))
+	})
+	chain, err := NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	itxs := types.InternalTxs{{TxHash: common.Hash{0x01}}}
+	for _, block := range blocks {
+		rawdb.WriteInternalTxs(db, block.Hash(), block.NumberU64(), itxs)
+		rawdb.WriteBlockStatus(db, block.Number(), block.Hash(), types.BasJustified)
+	}
+	rawdb.WriteLastBlockStatusNumber(db, big.NewInt(10))
+	rawdb.WriteLastFinalizedBlockNumber(db, big.NewInt(3))
+
+	if _, err := chain.Rollback(2); !errors.Is(err, ErrRollbackFinalized) {
+		t.Fatalf("rollback below finalized block: have %v, want %v", err, ErrRollbackFinalized)
+	}
+	if _, err := chain.Rollback(10); err == nil {
+		t.Fatal("rollback to the head succeeded")
+	}
+	summary, err := chain.Rollback(6)
+	if err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+	want := RollbackSummary{Requested: 6, Head: 6, PreviousHead: 10, Finalized: 3, Blocks: 4, Transactions: 4, InternalTxs: 4, BlockStatuses: 4}
+	if *summary != want {
+		t.Fatalf("summary mismatch: have %+v, want %+v", *summary, want)
+	}
+	if head := chain.CurrentBlock().Number.Uint64(); head != 6 {
+		t.Fatalf("head mismatch: have %d, want 6", head)
+	}
+	for _, block := range blocks {
+		var (
+			number       = block.NumberU64()
+			kept         = number <= 6
+			_, hash      = rawdb.ReadBlockStatusByNum(db, block.Number())
+			hasInternals = rawdb.ReadInternalTxs(db, block.Hash(), number) != nil
+		)
+		if hasInternals != kept || (hash == block.Hash()) != kept {
+			t.Errorf("block %d: internal txs %v, status %v, want kept %v", number, hasInternals, hash == block.Hash(), kept)
+		}
+	}
+	if last := rawdb.LastBlockStatusNumber(db).Uint64(); last != 6 {
+		t.Errorf("last block status mismatch: have %d, want 6", last)
+	}
+}
@@ -53,12 +53,17 @@ const (
 	// triggering range compaction. It's a quite arbitrary number but just
 	// to avoid triggering range compaction because of small deletion.
 	rangeCompactionThreshold = 100000
+
+	// deadlineCheckInterval is the number of database entries iterated between
+	// two checks of the pruning deadline.
+	deadlineCheckInterval = 10000
 )
 
 // Config includes all the configurations for pruning.
 type Config struct {
-	Datadir   string // The directory of the state database
-	BloomSize uint64 // The Megabytes of memory allocated to bloom-filter
+	Datadir     string        // The directory of the state database
+	BloomSize   uint64        // The Megabytes of memory allocated to bloom-filter
+	MaxDuration time.Duration // The maximum duration of a pruning run, zero means unlimited
 }
 
 // Pruner is an offline tool to prune the stale state with the
@@ -117,7 +122,14 @@ func NewPruner(db ethdb.Database, config Config) (*Pruner, error) {
 	}, nil
 }
 
-func prune(snaptree *snapshot.Tree, root common.Hash, maindb ethdb.Database, stateBloom *stateBloom, bloomPath string, middleStateRoots map[common.Hash]struct{}, start time.Time) error {
+// prune deletes the stale state entries, starting from the position recorded by
+// a previously interrupted run, if any. The position is persisted along with
+// every deletion batch. If the deadline passes before the whole database is
+// iterated, the pruning is paused: the snapshot is flattened into the target
+// state and the bloom filter is dropped, leaving the database usable. The next
+// run generates a fresh bloom filter for its own target state and continues
+// from the recorded position.
+func prune(snaptree *snapshot.Tree, root common.Hash, maindb ethdb.Database, stateBloom *stateBloom, bloomPath string, middleStateRoots map[common.Hash]struct{}, start time.Time, deadline time.Time) error {
 	// Delete all stale trie nodes in the disk. With the help of state bloom
 	// the trie nodes(and codes) belong to the active state will be filtered
 	// out. A very small part of stale tries will also be filtered because of
@@ -127,15 +139,29 @@ func prune(snaptree *snapshot.Tree, root common.Hash, maindb ethdb.Database, sta
 	// theory will never ever be visited again.
 	var (
 		skipped, count int
+		iterated       int
+		paused         bool
 		size           common.StorageSize
 		pstart         = time.Now()
 		logged         = time.Now()
 		batch          = maindb.NewBatch()
-		iter           = maindb.NewIterator(nil, nil)
+		marker         = rawdb.ReadPruningMarker(maindb)
 	)
+	if marker != nil {
+		log.Info("Resuming interrupted state pruning", "marker", fmt.Sprintf("%#x", marker))
+	}
+	iter := maindb.NewIterator(nil, marker)
 	for iter.Next() {
 		key := iter.Key()
 
+		iterated++
+		if !deadline.IsZero() && iterated%deadlineCheckInterval == 0 && time.Now().After(deadline) {
+			// Out of time, record the position of the next entry to process
+			rawdb.WritePruningMarker(batch, common.CopyBytes(key))
+			paused = true
+			break
+		}
+
 		// All state entries don't belong to specific state and genesis are deleted here
 		// - trie node
 		// - legacy contract code
@@ -174,6 +200,7 @@ func prune(snaptree *snapshot.Tree, root common.Hash, maindb ethdb.Database, sta
 			// Recreate the iterator after every batch commit in order
 			// to allow the underlying compactor to delete the entries.
 			if batch.ValueSize() >= ethdb.IdealBatchSize {
+				rawdb.WritePruningMarker(batch, common.CopyBytes(key))
 				batch.Write()
 				batch.Reset()
 
@@ -182,11 +209,24 @@ func prune(snaptree *snapshot.Tree, root common.Hash, maindb ethdb.Database, sta
 			}
 		}
 	}
-	if batch.ValueSize() > 0 {
-		batch.Write()
-		batch.Reset()
-	}
 	iter.Release()
+	if paused {
+		// The states above the target are partially deleted, drop their roots
+		// as well so that the chain is rewound to the target state instead of
+		// resolving an incomplete one.
+		for hash := range middleStateRoots {
+			rawdb.DeleteLegacyTrieNode(batch, hash)
+		}
+		if head := rawdb.ReadHeadBlock(maindb); head != nil && head.Root() != root {
+			rawdb.DeleteLegacyTrieNode(batch, head.Root())
+		}
+	} else {
+		rawdb.DeletePruningMarker(batch)
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	batch.Reset()
 	log.Info("Pruned state data", "nodes", count, "size", size, "elapsed", common.PrettyDuration(time.Since(pstart)))
 
 	// Pruning is done, now drop the "useless" layers from the snapshot.
@@ -208,6 +248,11 @@ func prune(snaptree *snapshot.Tree, root common.Hash, maindb ethdb.Database, sta
 	// the things.
 	os.RemoveAll(bloomPath)
 
+	if paused {
+		log.Info("State pruning paused, rerun to continue", "marker", fmt.Sprintf("%#x", rawdb.ReadPruningMarker(maindb)),
+			"elapsed", common.PrettyDuration(time.Since(start)))
+		return nil
+	}
 	// Start compactions, will remove the deleted data from the disk immediately.
 	// Note for small pruning, the compaction is skipped.
 	if count >= rangeCompactionThreshold {
@@ -245,7 +290,7 @@ func (p *Pruner) Prune(root common.Hash) error {
 		return err
 	}
 	if stateBloomRoot != (common.Hash{}) {
-		return RecoverPruning(p.config.Datadir, p.db)
+		return recoverPruning(p.config.Datadir, p.db, p.config.MaxDuration)
 	}
 	// If the target state root is not specified, use the HEAD-127 as the
 	// target. The reason for picking it is:
@@ -330,7 +375,16 @@ func (p *Pruner) Prune(root common.Hash) error {
 		return err
 	}
 	log.Info("State bloom filter committed", "name", filterName)
-	return prune(p.snaptree, root, p.db, p.stateBloom, filterName, middleRoots, start)
+	return prune(p.snaptree, root, p.db, p.stateBloom, filterName, middleRoots, start, deadline(start, p.config.MaxDuration))
+}
+
+// deadline returns the time a pruning run started at start has to be paused,
+// the zero time if the run is unlimited.
+func deadline(start time.Time, maxDuration time.Duration) time.Time {
+	if maxDuration <= 0 {
+		return time.Time{}
+	}
+	return start.Add(maxDuration)
 }
 
 // RecoverPruning will resume the pruning procedure during the system restart.
@@ -341,6 +395,11 @@ func (p *Pruner) Prune(root common.Hash) error {
 // pruning **has to be resumed**. Otherwise a lot of dangling nodes may be left
 // in the disk.
 func RecoverPruning(datadir string, db ethdb.Database) error {
+	return recoverPruning(datadir, db, 0)
+}
+
+func recoverPruning(datadir string, db ethdb.Database, maxDuration time.Duration) error {
+	start := time.Now()
 	stateBloomPath, stateBloomRoot, err := findBloomFilter(datadir)
 	if err != nil {
 		return err
@@ -396,7 +455,7 @@ func RecoverPruning(datadir string, db ethdb.Database) error {
 		log.Error("Pruning target state is not existent")
 		return errors.New("non-existent target state")
 	}
-	return prune(snaptree, stateBloomRoot, db, stateBloom, stateBloomPath, middleRoots, time.Now())
+	return prune(snaptree, stateBloomRoot, db, stateBloom, stateBloomPath, middleRoots, start, deadline(start, maxDuration))
 }
 
 // extractGenesis loads the genesis state and commits all the state entries
@@ -291,6 +291,26 @@ func (s *StateDB) Preimages() map[common.Hash][]byte {
 	return s.preimages
 }
 
+// AccessedState returns the existing accounts accessed so far, along with the
+// storage slots read or written in each.
+func (s *StateDB) AccessedState() map[common.Address][]common.Hash {
+	accessed := make(map[common.Address][]common.Hash, len(s.stateObjects))
+	for addr, obj := range s.stateObjects {
+		keys := make(map[common.Hash]struct{}, len(obj.originStorage))
+		for _, storage := range []Storage{obj.originStorage, obj.pendingStorage, obj.dirtyStorage} {
+			for key := range storage {
+				keys[key] = struct{}{}
+			}
+		}
+		slots := make([]common.Hash, 0, len(keys))
+		for key := range keys {
+			slots = append(slots, key)
+		}
+		accessed[addr] = slots
+	}
+	return accessed
+}
+
 // AddRefund adds gas to the refund counter
 func (s *StateDB) AddRefund(gas uint64) {
 	s.journal.append(refundChange{prev: s.refund})
@@ -0,0 +1,192 @@
+package core
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// stateAccessConfirmations is the number of blocks after its end an epoch is
+// aggregated at, so that its blocks aren't reorged anymore.
+const stateAccessConfirmations = 128
+
+// stateAccessRecorder records the last epoch the accounts and storage slots are
+// accessed in, for the state expiry research mode: it has no effect on the
+// consensus, but tells how much state an expiry period would let the nodes
+// drop, and how often the expired state would have to be resurrected. The
+// accesses of every block are stored along with the block, and aggregated for
+// the canonical blocks once their epoch is confirmed, so neither a crash nor a
+// reorg leaves the records out of line with the chain.
+type stateAccessRecorder struct {
+	length uint64 // Epoch length in blocks
+}
+
+func newStateAccessRecorder(length uint64) *stateAccessRecorder {
+	return &stateAccessRecorder{length: length}
+}
+
+// record stores the state accessed by the block into the batch writing it. The
+// recording starts with the epoch of the first recorded block, and the blocks
+// of the epochs already aggregated, imported again after a rewind, are skipped.
+func (r *stateAccessRecorder) record(db ethdb.KeyValueReader, batch ethdb.KeyValueWriter, block *types.Block, accessed map[common.Address][]common.Hash) {
+	epoch := block.NumberU64() / r.length
+	if next, ok := rawdb.ReadStateAccessHead(db); !ok {
+		rawdb.WriteStateAccessHead(batch, epoch)
+	} else if epoch < next {
+		return
+	}
+	accesses := make([]rawdb.BlockStateAccess, 0, len(accessed))
+	for addr, slots := range accessed {
+		accesses = append(accesses, rawdb.BlockStateAccess{Address: addr, Slots: slots})
+	}
+	rawdb.WriteBlockStateAccesses(batch, block.NumberU64(), block.Hash(), accesses)
+}
+
+// aggregate records the last accesses of the epochs confirmed by the head.
+func (r *stateAccessRecorder) aggregate(db ethdb.Database, head uint64) {
+	next, ok := rawdb.ReadStateAccessHead(db)
+	if !ok {
+		return
+	}
+	for (next+1)*r.length+stateAccessConfirmations <= head {
+		r.aggregateEpoch(db, next)
+		next++
+	}
+}
+
+// aggregateEpoch records the last accesses of the canonical blocks of the epoch
+// and deletes the accesses of all its blocks, along with the aggregation head in
+// a single batch.
+func (r *stateAccessRecorder) aggregateEpoch(db ethdb.Database, epoch uint64) {
+	var (
+		accounts = make(map[common.Address]struct{})
+		slots    = make(map[common.Address]map[common.Hash]struct{})
+		batch    = db.NewBatch()
+	)
+	for number := epoch * r.length; number < (epoch+1)*r.length; number++ {
+		canonical := rawdb.ReadCanonicalHash(db, number)
+		for _, access := range rawdb.ReadBlockStateAccesses(db, number, canonical) {
+			accounts[access.Address] = struct{}{}
+			if len(access.Slots) == 0 {
+				continue
+			}
+			if slots[access.Address] == nil {
+				slots[access.Address] = make(map[common.Hash]struct{}, len(access.Slots))
+			}
+			for _, slot := range access.Slots {
+				slots[access.Address][slot] = struct{}{}
+			}
+		}
+		rawdb.DeleteBlockStateAccesses(batch, number, canonical)
+		for _, hash := range rawdb.ReadAllHashes(db, number) {
+			rawdb.DeleteBlockStateAccesses(batch, number, hash)
+		}
+	}
+	// Epochs partially recorded by previous versions are merged, the state
+	// accessed in both parts only counting once
+	stats := rawdb.ReadStateAccessStats(db, epoch)
+	if stats == nil {
+		stats = new(rawdb.StateAccessStats)
+	}
+	ages := make(map[uint64]*rawdb.StateAccessAge)
+	for _, age := range stats.Ages {
+		ages[age.Age] = &rawdb.StateAccessAge{Age: age.Age, Accounts: age.Accounts, Slots: age.Slots}
+	}
+	age := func(prev uint64) *rawdb.StateAccessAge {
+		if ages[epoch-prev] == nil {
+			ages[epoch-prev] = &rawdb.StateAccessAge{Age: epoch - prev}
+		}
+		return ages[epoch-prev]
+	}
+	for addr := range accounts {
+		prev, ok := rawdb.ReadStateAccess(db, addr, nil)
+		switch {
+		case !ok:
+			stats.NewAccounts++
+		case prev >= epoch:
+			continue
+		default:
+			age(prev).Accounts++
+		}
+		stats.Accounts++
+		rawdb.WriteStateAccess(batch, addr, nil, epoch)
+	}
+	for addr, slots := range slots {
+		for slot := range slots {
+			prev, ok := rawdb.ReadStateAccess(db, addr, &slot)
+			switch {
+			case !ok:
+				stats.NewSlots++
+			case prev >= epoch:
+				continue
+			default:
+				age(prev).Slots++
+			}
+			stats.Slots++
+			rawdb.WriteStateAccess(batch, addr, &slot, epoch)
+		}
+	}
+	stats.Ages = stats.Ages[:0]
+	for _, entry := range ages {
+		stats.Ages = append(stats.Ages, *entry)
+	}
+	sort.Slice(stats.Ages, func(i, j int) bool { return stats.Ages[i].Age < stats.Ages[j].Age })
+	if len(accounts) > 0 {
+		rawdb.WriteStateAccessStats(batch, epoch, stats)
+	}
+	rawdb.WriteStateAccessHead(batch, epoch+1)
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to write state accesses", "err", err)
+	}
+	log.Info("Recorded state accesses", "epoch", epoch, "accounts", len(accounts), "new", stats.NewAccounts)
+}
+
+// ExpiredState is the state not accessed for an expiry period, which the nodes
+// could drop and would have to be resurrected with a witness when accessed.
+type ExpiredState struct {
+	Accounts, Slots     uint64 // Recorded accounts and storage slots
+	ExpiredAccounts     uint64 // Accounts not accessed during the period
+	ExpiredSlots        uint64 // Storage slots not accessed during the period
+	ExpiredAccountBytes uint64 // Snapshot size of the expired accounts still existing
+	ExpiredSlotBytes    uint64 // Snapshot size of the expired storage slots still existing
+}
+
+// StateExpiryEpoch returns the length in blocks of the epochs the state last
+// accesses are recorded in, 0 if they aren't.
+func (bc *BlockChain) StateExpiryEpoch() uint64 {
+	if bc.stateAccesses == nil {
+		return 0
+	}
+	return bc.stateAccesses.length
+}
+
+// ExpiredState returns the recorded state which wasn't accessed during the
+// given number of epochs up to the given one. The sizes are read from the state
+// snapshot, the state which was deleted since its last access not counting.
+// It's a full iteration of the recorded accesses.
+func (bc *BlockChain) ExpiredState(epoch, period uint64) *ExpiredState {
+	expired := new(ExpiredState)
+	rawdb.IterateStateAccesses(bc.db, func(addr common.Address, slot *common.Hash, last uint64) bool {
+		stale := last+period < epoch
+		if slot == nil {
+			expired.Accounts++
+			if stale {
+				expired.ExpiredAccounts++
+				expired.ExpiredAccountBytes += uint64(len(rawdb.ReadAccountSnapshot(bc.db, crypto.Keccak256Hash(addr.Bytes()))))
+			}
+			return true
+		}
+		expired.Slots++
+		if stale {
+			expired.ExpiredSlots++
+			expired.ExpiredSlotBytes += uint64(len(rawdb.ReadStorageSnapshot(bc.db, crypto.Keccak256Hash(addr.Bytes()), crypto.Keccak256Hash(slot.Bytes()))))
+		}
+		return true
+	})
+	return expired
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestStateAccessRecorder(t *testing.T) {
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		recorder = newStateAccessRecorder(10)
+		alice    = common.HexToAddress("0xa1")
+		bob      = common.HexToAddress("0xb0")
+		carol    = common.HexToAddress("0xc0")
+		slot     = common.HexToHash("0x01")
+	)
+	// record stores the accesses of a block, along with its header and canonical
+	// hash unless it's a side block
+	record := func(number int64, side bool, accessed map[common.Address][]common.Hash) *types.Block {
+		header := &types.Header{Number: big.NewInt(number)}
+		if side {
+			header.Extra = []byte("side")
+		}
+		block := types.NewBlockWithHeader(header)
+		batch := db.NewBatch()
+		rawdb.WriteHeader(batch, header)
+		if !side {
+			rawdb.WriteCanonicalHash(batch, block.Hash(), block.NumberU64())
+		}
+		recorder.record(db, batch, block, accessed)
+		if err := batch.Write(); err != nil {
+			t.Fatalf("failed to write block %d: %v", number, err)
+		}
+		return block
+	}
+	// Epoch 0 accesses both accounts and a slot, a side block carol, epoch 3
+	// only alice's slot and bob
+	record(1, false, map[common.Address][]common.Hash{alice: {slot}})
+	record(5, false, map[common.Address][]common.Hash{bob: nil})
+	side := record(5, true, map[common.Address][]common.Hash{carol: nil})
+	record(30, false, map[common.Address][]common.Hash{alice: {slot}})
+	record(31, false, map[common.Address][]common.Hash{alice: {slot}, bob: nil})
+
+	// Epochs are only aggregated once confirmed
+	recorder.aggregate(db, 10+stateAccessConfirmations-1)
+	if stats := rawdb.ReadStateAccessStats(db, 0); stats != nil {
+		t.Fatalf("unconfirmed epoch 0 aggregated: %+v", stats)
+	}
+	recorder.aggregate(db, 10+stateAccessConfirmations)
+	stats := rawdb.ReadStateAccessStats(db, 0)
+	if stats == nil || stats.Accounts != 2 || stats.NewAccounts != 2 || stats.Slots != 1 || stats.NewSlots != 1 {
+		t.Fatalf("epoch 0 stats mismatch: %+v", stats)
+	}
+	if _, ok := rawdb.ReadStateAccess(db, carol, nil); ok {
+		t.Fatalf("side block access recorded")
+	}
+	if accesses := rawdb.ReadBlockStateAccesses(db, 5, side.Hash()); accesses != nil {
+		t.Fatalf("side block accesses not deleted: %v", accesses)
+	}
+	if next, _ := rawdb.ReadStateAccessHead(db); next != 1 {
+		t.Fatalf("aggregation head mismatch: have %d, want 1", next)
+	}
+	// Blocks of the aggregated epochs aren't recorded again
+	record(2, false, map[common.Address][]common.Hash{carol: nil})
+	recorder.aggregate(db, 40+stateAccessConfirmations)
+
+	if _, ok := rawdb.ReadStateAccess(db, carol, nil); ok {
+		t.Fatalf("access of an aggregated epoch recorded")
+	}
+	stats = rawdb.ReadStateAccessStats(db, 3)
+	if stats == nil || stats.Accounts != 2 || stats.NewAccounts != 0 || len(stats.Ages) != 1 {
+		t.Fatalf("epoch 3 stats mismatch: %+v", stats)
+	}
+	if age := stats.Ages[0]; age.Age != 3 || age.Accounts != 2 || age.Slots != 1 {
+		t.Fatalf("epoch 3 ages mismatch: %+v", age)
+	}
+	if epoch, ok := rawdb.ReadStateAccess(db, alice, &slot); !ok || epoch != 3 {
+		t.Fatalf("slot last access mismatch: have %d, %v", epoch, ok)
+	}
+	if next, _ := rawdb.ReadStateAccessHead(db); next != 4 {
+		t.Fatalf("aggregation head mismatch: have %d, want 4", next)
+	}
+}
+
+func TestExpiredState(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		chain = &BlockChain{db: db}
+		alice = common.HexToAddress("0xa1")
+		bob   = common.HexToAddress("0xb0")
+		slot  = common.HexToHash("0x01")
+	)
+	rawdb.WriteStateAccess(db, alice, nil, 2)
+	rawdb.WriteStateAccess(db, alice, &slot, 8)
+	rawdb.WriteStateAccess(db, bob, nil, 9)
+	rawdb.WriteAccountSnapshot(db, crypto.Keccak256Hash(alice.Bytes()), []byte{1, 2, 3})
+
+	expired := chain.ExpiredState(10, 5)
+	if expired.Accounts != 2 || expired.Slots != 1 || expired.ExpiredAccounts != 1 || expired.ExpiredSlots != 0 {
+		t.Fatalf("expired state mismatch: %+v", expired)
+	}
+	if expired.ExpiredAccountBytes != 3 {
+		t.Fatalf("expired account size mismatch: have %d, want 3", expired.ExpiredAccountBytes)
+	}
+	if expired = chain.ExpiredState(10, 1); expired.ExpiredAccounts != 1 || expired.ExpiredSlots != 1 {
+		t.Fatalf("expired state mismatch for short period: %+v", expired)
+	}
+}
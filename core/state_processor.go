@@ -17,6 +17,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -28,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/otel"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -61,7 +63,7 @@ func NewStateProcessor(config *params.ChainConfig, bc *BlockChain, engine consen
 // Process returns the receipts and logs accumulated during the process and
 // returns the amount of gas that was used in the process. If any of the
 // transactions failed to execute due to insufficient gas it will return an error.
-func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config) (types.Receipts, []*types.Log, types.InternalTxs, uint64, error) {
+func (p *StateProcessor) Process(ctx context.Context, block *types.Block, statedb *state.StateDB, cfg vm.Config) (types.Receipts, []*types.Log, types.InternalTxs, uint64, error) {
 	var (
 		receipts    = make([]*types.Receipt, 0)
 		usedGas     = new(uint64)
@@ -114,10 +116,10 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		if isTurboEngine {
 			sender, err := types.Sender(signer, tx)
 			if err != nil {
-				return nil, nil, nil, 0, err
+				return nil, nil, nil, 0, &txApplyError{i, tx.Hash(), err}
 			}
 			if err = turboEngine.ExtraValidateOfTx(sender, tx, header); err != nil {
-				return nil, nil, nil, 0, err
+				return nil, nil, nil, 0, &txApplyError{i, tx.Hash(), err}
 			}
 
 			if ok := turboEngine.IsDoubleSignPunishTransaction(sender, tx, header); ok {
@@ -125,18 +127,18 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 				continue
 			}
 			if err = turboEngine.FilterTx(sender, tx, header, statedb); err != nil {
-				return nil, nil, nil, 0, err
+				return nil, nil, nil, 0, &txApplyError{i, tx.Hash(), err}
 			}
 		}
 		msg, err := TransactionToMessage(tx, signer, header.BaseFee)
 		if err != nil {
-			return nil, nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			return nil, nil, nil, 0, &txApplyError{i, tx.Hash(), err}
 		}
 		statedb.SetTxContext(tx.Hash(), i)
 
 		receipt, err := ApplyTransactionWithEVM(msg, p.config, gp, statedb, blockNumber, blockHash, tx, usedGas, vmenv)
 		if err != nil {
-			return nil, nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			return nil, nil, nil, 0, &txApplyError{i, tx.Hash(), err}
 		}
 		receipts = append(receipts, receipt)
 		allLogs = append(allLogs, receipt.Logs...)
@@ -178,13 +180,31 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		return nil, nil, nil, 0, errors.New("withdrawals before shanghai")
 	}
 	// Finalize the block, applying any consensus engine specific extras (e.g. block rewards)
-	if err := p.engine.Finalize(p.bc, header, statedb, &types.Body{Transactions: commonTxs}, &receipts, punishTxs); err != nil {
+	_, span := otel.Start(ctx, "block.finalize")
+	err := p.engine.Finalize(p.bc, header, statedb, &types.Body{Transactions: commonTxs}, &receipts, punishTxs)
+	span.RecordError(err)
+	span.End()
+	if err != nil {
 		return nil, nil, nil, 0, err
 	}
 
 	return receipts, allLogs, internalTxs, *usedGas, nil
 }
 
+// txApplyError is returned by the processor if a transaction of the block
+// failed to apply.
+type txApplyError struct {
+	index int
+	hash  common.Hash
+	err   error
+}
+
+func (e *txApplyError) Error() string {
+	return fmt.Sprintf("could not apply tx %d [%v]: %v", e.index, e.hash.Hex(), e.err)
+}
+
+func (e *txApplyError) Unwrap() error { return e.err }
+
 // ApplyTransactionWithEVM attempts to apply a transaction to the given state database
 // and uses the input parameters for its environment similar to ApplyTransaction. However,
 // this method takes an already created EVM instance as input.
@@ -243,6 +263,10 @@ func ApplyTransactionWithEVM(msg *Message, config *params.ChainConfig, gp *GasPo
 	receipt.BlockHash = blockHash
 	receipt.BlockNumber = blockNumber
 	receipt.TransactionIndex = uint(statedb.TxIndex())
+	if denial := evm.AccessDenial(); denial != nil {
+		denial.TxIndex = receipt.TransactionIndex
+		receipt.AccessDenial = denial
+	}
 	return receipt, err
 }
 
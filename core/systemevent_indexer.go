@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// systemEventThrottling is the time to wait between processing two consecutive
+// system event index sections.
+const systemEventThrottling = 100 * time.Millisecond
+
+// SystemEventIndexer implements a core.ChainIndexer, recording per section the
+// blocks emitting the governance and punish events of the system contracts, so
+// that their history can be retrieved without scanning the bloom filters of
+// the entire chain.
+type SystemEventIndexer struct {
+	db      ethdb.Database
+	entries []rawdb.SystemEventEntry
+	section uint64
+	head    common.Hash
+}
+
+// NewSystemEventIndexer returns a chain indexer that generates the system event
+// index for the canonical chain.
+func NewSystemEventIndexer(db ethdb.Database, size, confirms uint64) *ChainIndexer {
+	table := rawdb.NewTable(db, string(rawdb.SystemEventsIndexPrefix))
+	return NewChainIndexer(db, table, &SystemEventIndexer{db: db}, size, confirms, systemEventThrottling, "systemevents")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new system event index
+// section.
+func (s *SystemEventIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	s.entries, s.section, s.head = nil, section, common.Hash{}
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, adding the system events of a
+// block into the index.
+func (s *SystemEventIndexer) Process(ctx context.Context, header *types.Header) error {
+	s.entries = append(s.entries, ReadSystemEventEntries(s.db, header)...)
+	s.head = header.Hash()
+	return nil
+}
+
+// Commit implements core.ChainIndexerBackend, writing out the entries of the
+// section into the database.
+func (s *SystemEventIndexer) Commit() error {
+	batch := s.db.NewBatch()
+	rawdb.WriteSystemEvents(batch, s.section, s.head, s.entries)
+	return batch.Write()
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (s *SystemEventIndexer) Prune(threshold uint64) error {
+	return nil
+}
+
+// ReadSystemEventEntries returns the system events emitted by the given block,
+// in the form they are recorded in the system event index. Each event is only
+// listed once per block.
+func ReadSystemEventEntries(db ethdb.Reader, header *types.Header) []rawdb.SystemEventEntry {
+	var emitted bool
+	for _, contract := range system.EventContracts() {
+		if header.Bloom.Test(contract.Bytes()) {
+			emitted = true
+			break
+		}
+	}
+	if !emitted {
+		return nil
+	}
+	var (
+		number  = header.Number.Uint64()
+		entries []rawdb.SystemEventEntry
+		seen    = make(map[uint8]bool)
+	)
+	for _, receipt := range rawdb.ReadRawReceipts(db, header.Hash(), number) {
+		for _, log := range receipt.Logs {
+			if len(log.Topics) == 0 {
+				continue
+			}
+			if id, ok := system.LookupEvent(log.Address, log.Topics[0]); ok && !seen[id] {
+				seen[id] = true
+				entries = append(entries, rawdb.SystemEventEntry{Number: number, Event: id})
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// tokenTransferThrottling is the time to wait between processing two consecutive
+// token transfer index sections.
+const tokenTransferThrottling = 100 * time.Millisecond
+
+var (
+	// transferTopic is the topic of the ERC-20 and ERC-721 Transfer events.
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+	// transferSingleTopic and transferBatchTopic are the topics of the ERC-1155
+	// TransferSingle and TransferBatch events.
+	transferSingleTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	transferBatchTopic  = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+)
+
+// TokenTransferIndexer implements a core.ChainIndexer, recording per section and
+// address the ERC-20, ERC-721 and ERC-1155 token transfers from and to it, so
+// that its token history can be retrieved without an external indexer.
+type TokenTransferIndexer struct {
+	db      ethdb.Database
+	entries map[common.Address][]rawdb.TokenTransferEntry
+	section uint64
+	head    common.Hash
+}
+
+// NewTokenTransferIndexer returns a chain indexer that generates the token
+// transfer index for the canonical chain.
+func NewTokenTransferIndexer(db ethdb.Database, size, confirms uint64) *ChainIndexer {
+	table := rawdb.NewTable(db, string(rawdb.TokenTransfersIndexPrefix))
+	return NewChainIndexer(db, table, &TokenTransferIndexer{db: db}, size, confirms, tokenTransferThrottling, "tokentransfers")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new token transfer index
+// section.
+func (t *TokenTransferIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	t.entries, t.section, t.head = make(map[common.Address][]rawdb.TokenTransferEntry), section, common.Hash{}
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, adding the token transfers of a
+// block into the index of their senders and recipients.
+func (t *TokenTransferIndexer) Process(ctx context.Context, header *types.Header) error {
+	for _, entry := range ReadTokenTransferEntries(t.db, header) {
+		for _, address := range tokenTransferParties(entry) {
+			t.entries[address] = append(t.entries[address], entry)
+		}
+	}
+	t.head = header.Hash()
+	return nil
+}
+
+// Commit implements core.ChainIndexerBackend, writing out the entries of the
+// section into the database.
+func (t *TokenTransferIndexer) Commit() error {
+	batch := t.db.NewBatch()
+	for address, entries := range t.entries {
+		rawdb.WriteTokenTransfers(batch, address, t.section, t.head, entries)
+	}
+	return batch.Write()
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (t *TokenTransferIndexer) Prune(threshold uint64) error {
+	return nil
+}
+
+// tokenTransferParties returns the addresses a transfer is indexed under, its
+// sender and recipient. The zero address of the mints and burns isn't indexed.
+func tokenTransferParties(entry rawdb.TokenTransferEntry) []common.Address {
+	var parties []common.Address
+	if entry.From != (common.Address{}) {
+		parties = append(parties, entry.From)
+	}
+	if entry.To != (common.Address{}) && entry.To != entry.From {
+		parties = append(parties, entry.To)
+	}
+	return parties
+}
+
+// ReadTokenTransferEntries returns the token transfers of the given block, in
+// the form they are recorded in the token transfer index.
+func ReadTokenTransferEntries(db ethdb.Reader, header *types.Header) []rawdb.TokenTransferEntry {
+	if !header.Bloom.Test(transferTopic.Bytes()) && !header.Bloom.Test(transferSingleTopic.Bytes()) && !header.Bloom.Test(transferBatchTopic.Bytes()) {
+		return nil
+	}
+	var (
+		hash   = header.Hash()
+		number = header.Number.Uint64()
+		body   = rawdb.ReadBody(db, hash, number)
+	)
+	if body == nil {
+		return nil
+	}
+	var (
+		entries  []rawdb.TokenTransferEntry
+		logIndex uint32
+	)
+	for i, receipt := range rawdb.ReadRawReceipts(db, hash, number) {
+		if i >= len(body.Transactions) {
+			break
+		}
+		txHash := body.Transactions[i].Hash()
+		for _, log := range receipt.Logs {
+			entries = append(entries, DecodeTokenTransfers(number, txHash, logIndex, log)...)
+			logIndex++
+		}
+	}
+	return entries
+}
+
+// DecodeTokenTransfers decodes the token transfers of a log emitted by the given
+// transaction: a single one for the ERC-20 and ERC-721 Transfer and the ERC-1155
+// TransferSingle events, one per token id for the ERC-1155 TransferBatch event.
+// Logs not matching the standard events are ignored.
+func DecodeTokenTransfers(number uint64, txHash common.Hash, logIndex uint32, log *types.Log) []rawdb.TokenTransferEntry {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+	entry := func(standard uint16, from, to common.Hash, id, value *big.Int) rawdb.TokenTransferEntry {
+		return rawdb.TokenTransferEntry{
+			Number:   number,
+			TxHash:   txHash,
+			LogIndex: logIndex,
+			Token:    log.Address,
+			Standard: standard,
+			From:     common.BytesToAddress(from.Bytes()),
+			To:       common.BytesToAddress(to.Bytes()),
+			TokenID:  id,
+			Value:    value,
+		}
+	}
+	switch log.Topics[0] {
+	case transferTopic:
+		// The ERC-721 token id is indexed, the ERC-20 value isn't
+		if len(log.Topics) == 3 && len(log.Data) == 32 {
+			return []rawdb.TokenTransferEntry{entry(rawdb.TokenERC20, log.Topics[1], log.Topics[2], new(big.Int), new(big.Int).SetBytes(log.Data))}
+		}
+		if len(log.Topics) == 4 && len(log.Data) == 0 {
+			return []rawdb.TokenTransferEntry{entry(rawdb.TokenERC721, log.Topics[1], log.Topics[2], log.Topics[3].Big(), big.NewInt(1))}
+		}
+	case transferSingleTopic:
+		if len(log.Topics) == 4 && len(log.Data) == 64 {
+			return []rawdb.TokenTransferEntry{entry(rawdb.TokenERC1155, log.Topics[2], log.Topics[3], new(big.Int).SetBytes(log.Data[:32]), new(big.Int).SetBytes(log.Data[32:]))}
+		}
+	case transferBatchTopic:
+		if len(log.Topics) != 4 || len(log.Data) < 64 {
+			return nil
+		}
+		ids, values := decodeUint256Array(log.Data, 0), decodeUint256Array(log.Data, 32)
+		if ids == nil || values == nil || len(ids) != len(values) {
+			return nil
+		}
+		entries := make([]rawdb.TokenTransferEntry, len(ids))
+		for i := range ids {
+			entries[i] = entry(rawdb.TokenERC1155, log.Topics[2], log.Topics[3], ids[i], values[i])
+		}
+		return entries
+	}
+	return nil
+}
+
+// decodeUint256Array decodes the ABI encoded uint256 array whose offset is at
+// the given position of the data, returning nil if it's malformed.
+func decodeUint256Array(data []byte, pos int) []*big.Int {
+	offset := new(big.Int).SetBytes(data[pos : pos+32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data)-32) {
+		return nil
+	}
+	start := int(offset.Uint64())
+	length := new(big.Int).SetBytes(data[start : start+32])
+	if !length.IsUint64() || length.Uint64() > uint64(len(data)-start-32)/32 {
+		return nil
+	}
+	items := make([]*big.Int, length.Uint64())
+	for i := range items {
+		at := start + 32 + 32*i
+		items[i] = new(big.Int).SetBytes(data[at : at+32])
+	}
+	return items
+}
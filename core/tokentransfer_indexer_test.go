@@ -0,0 +1,99 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestDecodeTokenTransfers(t *testing.T) {
+	var (
+		token    = common.Address{0x10}
+		operator = common.BytesToHash(common.Address{0x01}.Bytes())
+		from     = common.BytesToHash(common.Address{0xaa}.Bytes())
+		to       = common.BytesToHash(common.Address{0xbb}.Bytes())
+		word     = func(v int64) []byte { return common.BigToHash(big.NewInt(v)).Bytes() }
+		concat   = func(words ...[]byte) (data []byte) {
+			for _, w := range words {
+				data = append(data, w...)
+			}
+			return data
+		}
+	)
+	tests := []struct {
+		name   string
+		topics []common.Hash
+		data   []byte
+		want   []rawdb.TokenTransferEntry
+	}{
+		{
+			name:   "erc20",
+			topics: []common.Hash{transferTopic, from, to},
+			data:   word(1000),
+			want:   []rawdb.TokenTransferEntry{{Standard: rawdb.TokenERC20, TokenID: big.NewInt(0), Value: big.NewInt(1000)}},
+		},
+		{
+			name:   "erc721",
+			topics: []common.Hash{transferTopic, from, to, common.BigToHash(big.NewInt(7))},
+			want:   []rawdb.TokenTransferEntry{{Standard: rawdb.TokenERC721, TokenID: big.NewInt(7), Value: big.NewInt(1)}},
+		},
+		{
+			name:   "erc1155 single",
+			topics: []common.Hash{transferSingleTopic, operator, from, to},
+			data:   concat(word(3), word(50)),
+			want:   []rawdb.TokenTransferEntry{{Standard: rawdb.TokenERC1155, TokenID: big.NewInt(3), Value: big.NewInt(50)}},
+		},
+		{
+			name:   "erc1155 batch",
+			topics: []common.Hash{transferBatchTopic, operator, from, to},
+			data:   concat(word(64), word(160), word(2), word(3), word(4), word(2), word(50), word(60)),
+			want: []rawdb.TokenTransferEntry{
+				{Standard: rawdb.TokenERC1155, TokenID: big.NewInt(3), Value: big.NewInt(50)},
+				{Standard: rawdb.TokenERC1155, TokenID: big.NewInt(4), Value: big.NewInt(60)},
+			},
+		},
+		{
+			name:   "erc1155 batch length mismatch",
+			topics: []common.Hash{transferBatchTopic, operator, from, to},
+			data:   concat(word(64), word(128), word(1), word(3), word(2), word(50), word(60)),
+		},
+		{
+			name:   "erc1155 batch out of bounds",
+			topics: []common.Hash{transferBatchTopic, operator, from, to},
+			data:   concat(word(64), word(1<<40), word(1), word(3)),
+		},
+		{
+			name:   "erc20 approval",
+			topics: []common.Hash{common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"), from, to},
+			data:   word(1000),
+		},
+		{
+			name:   "erc20 without value",
+			topics: []common.Hash{transferTopic, from, to},
+		},
+	}
+	for _, tt := range tests {
+		log := &types.Log{Address: token, Topics: tt.topics, Data: tt.data}
+		have := DecodeTokenTransfers(5, common.Hash{0x05}, 2, log)
+		if len(have) != len(tt.want) {
+			t.Errorf("%s: transfer count mismatch: have %d, want %d", tt.name, len(have), len(tt.want))
+			continue
+		}
+		for i, entry := range have {
+			want := tt.want[i]
+			if entry.Number != 5 || entry.TxHash != (common.Hash{0x05}) || entry.LogIndex != 2 || entry.Token != token {
+				t.Errorf("%s: transfer %d position mismatch: %+v", tt.name, i, entry)
+			}
+			if entry.From != (common.Address{0xaa}) || entry.To != (common.Address{0xbb}) {
+				t.Errorf("%s: transfer %d parties mismatch: %v -> %v", tt.name, i, entry.From, entry.To)
+			}
+			if entry.Standard != want.Standard || entry.TokenID.Cmp(want.TokenID) != 0 || entry.Value.Cmp(want.Value) != 0 {
+				t.Errorf("%s: transfer %d mismatch: have %d id %v value %v, want %d id %v value %v", tt.name, i,
+					entry.Standard, entry.TokenID, entry.Value, want.Standard, want.TokenID, want.Value)
+			}
+		}
+	}
+}
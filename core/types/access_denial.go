@@ -0,0 +1,24 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Kinds of the access filter denials.
+const (
+	AccessDeniedFrom = "from" // A denied address made a call
+	AccessDeniedTo   = "to"   // A denied address was called
+	AccessDeniedLog  = "log"  // A denied event was emitted
+)
+
+// AccessDenial describes the first access filter denial met while executing a
+// transaction, telling the policy blocks apart from the contract failures in
+// the receipts. The denial fails the denied call frame, and the transaction
+// too unless the caller handles the failure.
+type AccessDenial struct {
+	Kind    string         `json:"kind"`
+	Address common.Address `json:"address"`                   // Denied address, or emitter of the denied event
+	Topic   *common.Hash   `json:"topic,omitempty" rlp:"nil"` // First topic of the denied event
+	Rule    string         `json:"rule,omitempty"`            // Rule denying the event, if known
+	TxIndex uint           `json:"-"`                         // Index of the transaction in the block
+}
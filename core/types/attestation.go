@@ -283,6 +283,13 @@ func (cf CasperFFGHistoryList) Less(i, j int) bool {
 }
 func (cf CasperFFGHistoryList) Swap(i, j int) { cf[i], cf[j] = cf[j], cf[i] }
 
+// CasperFFGJournal is the attestation history of a validator, journaled for
+// the Casper FFG rules to still apply after a restart.
+type CasperFFGJournal struct {
+	Signer  common.Address
+	History CasperFFGHistoryList
+}
+
 const (
 	AttestationPending = uint8(0)
 	AttestationStart   = uint8(1)
@@ -65,6 +65,7 @@ type Receipt struct {
 	EffectiveGasPrice *big.Int       `json:"effectiveGasPrice"` // required, but tag omitted for backwards compatibility
 	BlobGasUsed       uint64         `json:"blobGasUsed,omitempty"`
 	BlobGasPrice      *big.Int       `json:"blobGasPrice,omitempty"`
+	AccessDenial      *AccessDenial  `json:"-"` // First access filter denial met by the transaction, if any
 
 	// Inclusion information: These fields provide information about the inclusion of the
 	// transaction corresponding to this receipt.
@@ -171,6 +171,12 @@ func init() {
 
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules params.Rules) []common.Address {
+	return extraPrecompileAddresses(rules, activeBuiltinPrecompiles(rules))
+}
+
+// activeBuiltinPrecompiles returns the built-in precompiles enabled with the
+// current configuration.
+func activeBuiltinPrecompiles(rules params.Rules) []common.Address {
 	switch {
 	case rules.IsPrague:
 		return PrecompiledAddressesPrague
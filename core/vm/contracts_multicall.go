@@ -0,0 +1,118 @@
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// MulticallAddress is the address of the multicall precompile, callable from
+// the block set for "multicall" in the precompiles field of the Turbo config.
+var MulticallAddress = common.HexToAddress("0x000000000000000000000000000000000000F100")
+
+// multicallABI is the interface of the multicall precompile, compatible with
+// the aggregate3 method of the widespread Multicall3 contract.
+const multicallABI = `[{"name":"aggregate3","type":"function","stateMutability":"view",
+"inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],
+"outputs":[{"name":"returnData","type":"tuple[]","components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]}]`
+
+var (
+	errMulticallInput       = errors.New("invalid multicall input")
+	errMulticallUnsupported = errors.New("multicall requires the EVM")
+)
+
+var multicallAggregate abi.Method
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(multicallABI))
+	if err != nil {
+		panic(err)
+	}
+	multicallAggregate = parsed.Methods["aggregate3"]
+
+	RegisterPrecompile("multicall", MulticallAddress, func(params.Rules) PrecompiledContract {
+		return &multicall{}
+	})
+}
+
+// multicallCall is a call batched by the multicall precompile.
+type multicallCall struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// multicallResult is the outcome of a call batched by the multicall precompile.
+type multicallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// multicall implemented as a native contract, performing a batch of static
+// calls within a single call frame instead of the calls of a deployed
+// Multicall contract. Each call is charged on top of the gas it uses like the
+// CALL opcode, and gets all the gas left but one 64th. The batch reverts with
+// the output of the first failed call not allowed to fail.
+type multicall struct{}
+
+func (c *multicall) RequiredGas(input []byte) uint64 {
+	return params.MulticallBaseGas + uint64(len(input)+31)/32*params.MulticallPerWordGas
+}
+
+func (c *multicall) Run(input []byte) ([]byte, error) {
+	return nil, errMulticallUnsupported
+}
+
+func (c *multicall) RunStateful(evm *EVM, self common.Address, input []byte, suppliedGas uint64) ([]byte, uint64, error) {
+	if len(input) < 4 || !bytes.Equal(input[:4], multicallAggregate.ID) {
+		return nil, 0, errMulticallInput
+	}
+	args, err := multicallAggregate.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, 0, errMulticallInput
+	}
+	calls := *abi.ConvertType(args[0], new([]multicallCall)).(*[]multicallCall)
+
+	results := make([]multicallResult, len(calls))
+	for i, call := range calls {
+		// Charge the calls like the CALL opcode, the accounts accessed for the
+		// first time in the transaction being warmed up
+		cost := params.MulticallPerCallGas
+		if evm.chainRules.IsBerlin && !evm.StateDB.AddressInAccessList(call.Target) {
+			evm.StateDB.AddAddressToAccessList(call.Target)
+			cost += params.ColdAccountAccessCostEIP2929 - params.WarmStorageReadCostEIP2929
+		}
+		if suppliedGas < cost {
+			return nil, 0, ErrOutOfGas
+		}
+		suppliedGas -= cost
+
+		// Calls to the precompile itself don't go deeper into the call stack,
+		// refuse them rather than recursing on the remaining gas
+		var (
+			ret []byte
+			err error
+		)
+		if call.Target == self {
+			err = errMulticallInput
+		} else {
+			gas := suppliedGas - suppliedGas/64
+			var left uint64
+			ret, left, err = evm.StaticCall(AccountRef(self), call.Target, call.CallData, gas)
+			suppliedGas -= gas - left
+		}
+		if err != nil && !call.AllowFailure {
+			return ret, suppliedGas, ErrExecutionReverted
+		}
+		results[i] = multicallResult{Success: err == nil, ReturnData: ret}
+	}
+	output, err := multicallAggregate.Outputs.Pack(results)
+	if err != nil {
+		return nil, 0, err
+	}
+	return output, suppliedGas, nil
+}
@@ -0,0 +1,96 @@
+package vm
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+func TestMulticall(t *testing.T) {
+	var (
+		config    = *params.AllTurboProtocolChanges
+		turbo     = *config.Turbo
+		answer    = common.HexToAddress("0xaa") // Returns 42
+		reverter  = common.HexToAddress("0xbb") // Reverts
+		writer    = common.HexToAddress("0xcc") // Writes storage
+		statedb   = newMulticallState(t, answer, reverter, writer)
+		encodeU64 = common.LeftPadBytes([]byte{42}, 32)
+	)
+	turbo.Precompiles = map[string]*big.Int{"multicall": big.NewInt(1)}
+	config.Turbo = &turbo
+
+	call := func(number uint64, calls []multicallCall) ([]byte, uint64, error) {
+		input, err := multicallAggregate.Inputs.Pack(calls)
+		if err != nil {
+			t.Fatalf("failed to pack calls: %v", err)
+		}
+		vmctx := BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+			BlockNumber: new(big.Int).SetUint64(number),
+		}
+		evm := NewEVM(vmctx, TxContext{}, statedb, &config, Config{})
+		ret, left, err := evm.StaticCall(AccountRef(common.Address{}), MulticallAddress, append(multicallAggregate.ID, input...), 1000000)
+		return ret, 1000000 - left, err
+	}
+	// Before the fork, the address is a plain account
+	if ret, _, err := call(0, []multicallCall{{Target: answer}}); err != nil || len(ret) != 0 {
+		t.Fatalf("multicall executed before the fork: %x, %v", ret, err)
+	}
+	// The failures allowed are reported, including the state modifications
+	calls := []multicallCall{
+		{Target: answer},
+		{Target: reverter, AllowFailure: true},
+		{Target: writer, AllowFailure: true},
+		{Target: MulticallAddress, AllowFailure: true},
+	}
+	ret, used, err := call(1, calls)
+	if err != nil {
+		t.Fatalf("multicall failed: %v", err)
+	}
+	out, err := multicallAggregate.Outputs.Unpack(ret)
+	if err != nil {
+		t.Fatalf("failed to unpack results: %v", err)
+	}
+	results := *abi.ConvertType(out[0], new([]multicallResult)).(*[]multicallResult)
+	if len(results) != len(calls) {
+		t.Fatalf("result count mismatch: have %d, want %d", len(results), len(calls))
+	}
+	if !results[0].Success || !bytes.Equal(results[0].ReturnData, encodeU64) {
+		t.Errorf("call result mismatch: %+v", results[0])
+	}
+	for i, result := range results[1:] {
+		if result.Success {
+			t.Errorf("call %d: failure not reported", i+1)
+		}
+	}
+	if min := params.MulticallBaseGas + uint64(len(calls))*params.MulticallPerCallGas; used < min {
+		t.Errorf("gas used too low: have %d, want at least %d", used, min)
+	}
+	// A failure not allowed reverts the batch
+	if _, _, err := call(1, []multicallCall{{Target: answer}, {Target: reverter}}); err != ErrExecutionReverted {
+		t.Fatalf("batch not reverted: %v", err)
+	}
+}
+
+func newMulticallState(t *testing.T, answer, reverter, writer common.Address) *state.StateDB {
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	for addr, code := range map[common.Address]string{
+		answer:   "602a60005260206000f3", // mstore(0, 42) return(0, 32)
+		reverter: "60006000fd",           // revert(0, 0)
+		writer:   "600160005500",         // sstore(0, 1)
+	} {
+		statedb.CreateAccount(addr)
+		statedb.SetCode(addr, common.Hex2Bytes(code))
+	}
+	statedb.Finalise(true)
+	return statedb
+}
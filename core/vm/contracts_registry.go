@@ -0,0 +1,116 @@
+package vm
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// PrecompileFactory returns the implementation of a registered precompiled
+// contract under the given chain rules, so that its gas schedule can change
+// with the forks like the built-in ones.
+type PrecompileFactory func(rules params.Rules) PrecompiledContract
+
+// StatefulPrecompiledContract is a precompiled contract accessing the EVM
+// executing it, e.g. to call other contracts. Its RequiredGas is charged before
+// running it, and the gas it uses on top is deducted from the supplied gas it
+// returns.
+type StatefulPrecompiledContract interface {
+	PrecompiledContract
+	RunStateful(evm *EVM, self common.Address, input []byte, suppliedGas uint64) (ret []byte, remainingGas uint64, err error)
+}
+
+// registeredPrecompile is a precompiled contract registered under a name.
+type registeredPrecompile struct {
+	address common.Address
+	factory PrecompileFactory
+}
+
+var (
+	registeredPrecompilesLock sync.RWMutex
+	registeredPrecompiles     = make(map[string]registeredPrecompile)
+)
+
+// RegisterPrecompile makes a precompiled contract available under the given
+// name at the given address. The precompiles are registered by the in-tree
+// modules in their init function, and are only callable from the block set in
+// the precompiles field of the Turbo chain config, before which the address is
+// a plain account.
+//
+// Registering two precompiles with the same name or address, or a precompile at
+// the address of a built-in one, panics.
+func RegisterPrecompile(name string, addr common.Address, factory PrecompileFactory) {
+	registeredPrecompilesLock.Lock()
+	defer registeredPrecompilesLock.Unlock()
+
+	if _, exists := registeredPrecompiles[name]; exists {
+		panic(fmt.Sprintf("vm: precompile %q registered twice", name))
+	}
+	if _, exists := PrecompiledContractsPrague[addr]; exists {
+		panic(fmt.Sprintf("vm: precompile %q registered at built-in address %v", name, addr))
+	}
+	for other, registered := range registeredPrecompiles {
+		if registered.address == addr {
+			panic(fmt.Sprintf("vm: precompile %q registered at address %v of %q", name, addr, other))
+		}
+	}
+	registeredPrecompiles[name] = registeredPrecompile{address: addr, factory: factory}
+}
+
+// lookupPrecompile returns the precompiled contract registered under the given
+// name.
+func lookupPrecompile(name string) (registeredPrecompile, bool) {
+	registeredPrecompilesLock.RLock()
+	defer registeredPrecompilesLock.RUnlock()
+
+	registered, ok := registeredPrecompiles[name]
+	return registered, ok
+}
+
+// CheckPrecompiles returns an error if the chain config enables a precompiled
+// contract which isn't registered, the calls to it being executed as calls to
+// a plain account otherwise.
+func CheckPrecompiles(config *params.ChainConfig) error {
+	if config.Turbo == nil {
+		return nil
+	}
+	for name := range config.Turbo.Precompiles {
+		if _, ok := lookupPrecompile(name); !ok {
+			return fmt.Errorf("precompile %q enabled by the chain config is not registered", name)
+		}
+	}
+	return nil
+}
+
+// extraPrecompiles returns the registered precompiled contracts enabled by the
+// chain rules, keyed by address.
+func extraPrecompiles(rules params.Rules) map[common.Address]PrecompiledContract {
+	if len(rules.Precompiles) == 0 {
+		return nil
+	}
+	contracts := make(map[common.Address]PrecompiledContract, len(rules.Precompiles))
+	for _, name := range rules.Precompiles {
+		if registered, ok := lookupPrecompile(name); ok {
+			contracts[registered.address] = registered.factory(rules)
+		}
+	}
+	return contracts
+}
+
+// extraPrecompileAddresses returns the addresses of the registered precompiled
+// contracts enabled by the chain rules, appended to the built-in ones.
+func extraPrecompileAddresses(rules params.Rules, builtin []common.Address) []common.Address {
+	if len(rules.Precompiles) == 0 {
+		return builtin
+	}
+	addrs := slices.Clone(builtin)
+	for _, name := range rules.Precompiles {
+		if registered, ok := lookupPrecompile(name); ok {
+			addrs = append(addrs, registered.address)
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,112 @@
+package vm
+
+import (
+	"bytes"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+var testEchoAddress = common.HexToAddress("0x0100")
+
+// testEcho returns its input, repriced at the Cancun fork.
+type testEcho struct{ cancun bool }
+
+func (e *testEcho) RequiredGas(input []byte) uint64 {
+	if e.cancun {
+		return 50
+	}
+	return 100
+}
+
+func (e *testEcho) Run(input []byte) ([]byte, error) {
+	return common.CopyBytes(input), nil
+}
+
+func init() {
+	RegisterPrecompile("testEcho", testEchoAddress, func(rules params.Rules) PrecompiledContract {
+		return &testEcho{cancun: rules.IsCancun}
+	})
+}
+
+func TestRegisteredPrecompile(t *testing.T) {
+	var (
+		config = *params.AllTurboProtocolChanges
+		turbo  = *config.Turbo
+		cancun = uint64(1000)
+		input  = []byte("nero")
+	)
+	turbo.Precompiles = map[string]*big.Int{"testEcho": big.NewInt(10)}
+	config.Turbo, config.CancunTime = &turbo, &cancun
+
+	if err := CheckPrecompiles(&config); err != nil {
+		t.Fatalf("registered precompile rejected: %v", err)
+	}
+	for _, tt := range []struct {
+		number, time uint64
+		active       bool
+		gas          uint64
+	}{
+		{9, 0, false, 0},
+		{10, 0, true, 100},
+		{11, cancun, true, 50},
+	} {
+		rules := config.Rules(new(big.Int).SetUint64(tt.number), false, tt.time)
+		if active := slices.Contains(ActivePrecompiles(rules), testEchoAddress); active != tt.active {
+			t.Errorf("block %d: active mismatch: have %v, want %v", tt.number, active, tt.active)
+		}
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		vmctx := BlockContext{
+			CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+			BlockNumber: new(big.Int).SetUint64(tt.number),
+			Time:        tt.time,
+		}
+		evm := NewEVM(vmctx, TxContext{}, statedb, &config, Config{})
+		ret, left, err := evm.Call(AccountRef(common.Address{}), testEchoAddress, input, 1000, new(uint256.Int))
+		if err != nil {
+			t.Fatalf("block %d: call failed: %v", tt.number, err)
+		}
+		if !tt.active {
+			if len(ret) != 0 || left != 1000 {
+				t.Errorf("block %d: inactive precompile executed", tt.number)
+			}
+			continue
+		}
+		if !bytes.Equal(ret, input) {
+			t.Errorf("block %d: output mismatch: have %x, want %x", tt.number, ret, input)
+		}
+		if used := 1000 - left; used != tt.gas {
+			t.Errorf("block %d: gas mismatch: have %d, want %d", tt.number, used, tt.gas)
+		}
+	}
+	turbo.Precompiles["testMissing"] = big.NewInt(20)
+	if err := CheckPrecompiles(&config); err == nil {
+		t.Fatal("unregistered precompile accepted")
+	}
+}
+
+func TestRegisterPrecompileConflicts(t *testing.T) {
+	factory := func(params.Rules) PrecompiledContract { return &testEcho{} }
+	for name, register := range map[string]func(){
+		"name":     func() { RegisterPrecompile("testEcho", common.HexToAddress("0x0101"), factory) },
+		"address":  func() { RegisterPrecompile("testOther", testEchoAddress, factory) },
+		"built-in": func() { RegisterPrecompile("testOther", common.BytesToAddress([]byte{0x1}), factory) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s conflict not rejected", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
@@ -60,10 +60,30 @@ func (evm *EVM) precompile(addr common.Address) (PrecompiledContract, bool) {
 	default:
 		precompiles = PrecompiledContractsHomestead
 	}
-	p, ok := precompiles[addr]
+	if p, ok := precompiles[addr]; ok {
+		return p, true
+	}
+	p, ok := evm.extraPrecompiles[addr]
 	return p, ok
 }
 
+// runPrecompile runs a precompiled contract in the context of the given
+// address, giving the stateful ones access to the EVM.
+func (evm *EVM) runPrecompile(p PrecompiledContract, self common.Address, input []byte, gas uint64) ([]byte, uint64, error) {
+	stateful, ok := p.(StatefulPrecompiledContract)
+	if !ok {
+		return RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+	}
+	gasCost := p.RequiredGas(input)
+	if gas < gasCost {
+		return nil, 0, ErrOutOfGas
+	}
+	if evm.Config.Tracer != nil && evm.Config.Tracer.OnGasChange != nil {
+		evm.Config.Tracer.OnGasChange(gas, gas-gasCost, tracing.GasChangeCallPrecompiledContract)
+	}
+	return stateful.RunStateful(evm, self, input, gas-gasCost)
+}
+
 type EvmAccessFilter interface {
 	// IsAddressDenied returns whether an address is denied.
 	IsAddressDenied(address common.Address, cType common.AddressCheckType) bool
@@ -71,6 +91,13 @@ type EvmAccessFilter interface {
 	IsLogDenied(log *types.Log) bool
 }
 
+// LogRuleReporter is implemented by the access filters able to describe the
+// rule denying a log.
+type LogRuleReporter interface {
+	// DeniedLogRule describes the rule denying the log, empty if unknown.
+	DeniedLogRule(log *types.Log) string
+}
+
 // BlockContext provides the EVM with auxiliary information. Once provided
 // it shouldn't be modified.
 type BlockContext struct {
@@ -136,8 +163,17 @@ type EVM struct {
 	// global (to this context) ethereum virtual machine
 	// used throughout the execution of the tx.
 	interpreter *EVMInterpreter
+	// extraPrecompiles are the registered precompiles enabled by the chain rules
+	extraPrecompiles map[common.Address]PrecompiledContract
 	// abort is used to abort the EVM calling operations
 	abort atomic.Bool
+	// memory is the memory allocated by the live call frames, only tracked if
+	// limited, and memoryExceeded whether the limit aborted the execution
+	memory         uint64
+	memoryExceeded bool
+	// accessDenial is the first access filter denial failing the transaction,
+	// dropped when a frame catching the failure succeeds
+	accessDenial *types.AccessDenial
 	// callGasTemp holds the gas available for the current call. This is needed because the
 	// available gas is calculated in gasCall* according to the 63/64 rule and later
 	// applied in opCall*.
@@ -166,6 +202,7 @@ func NewEVM(blockCtx BlockContext, txCtx TxContext, statedb StateDB, chainConfig
 		chainConfig: chainConfig,
 		chainRules:  chainConfig.Rules(blockCtx.BlockNumber, blockCtx.Random != nil, blockCtx.Time),
 	}
+	evm.extraPrecompiles = extraPrecompiles(evm.chainRules)
 	evm.interpreter = NewEVMInterpreter(evm)
 	return evm
 }
@@ -178,6 +215,7 @@ func (evm *EVM) Reset(txCtx TxContext, statedb StateDB) {
 	}
 	evm.TxContext = txCtx
 	evm.StateDB = statedb
+	evm.accessDenial = nil
 }
 
 // Cancel cancels any running EVM operation. This may be called concurrently and
@@ -191,6 +229,66 @@ func (evm *EVM) Cancelled() bool {
 	return evm.abort.Load()
 }
 
+// MemoryLimitExceeded returns whether the execution was aborted for exceeding
+// the memory limit.
+func (evm *EVM) MemoryLimitExceeded() bool {
+	return evm.memoryExceeded
+}
+
+// AccessDenial returns the first access filter denial which failed the
+// transaction, nil if none. The denied calls whose failure is handled by the
+// calling contracts aren't reported.
+func (evm *EVM) AccessDenial() *types.AccessDenial {
+	return evm.accessDenial
+}
+
+// checkAccess returns types.ErrAddressDenied if the access filter denies the
+// call, recording the denial.
+func (evm *EVM) checkAccess(caller, addr common.Address) error {
+	switch {
+	case evm.Context.AccessFilter.IsAddressDenied(caller, common.CheckFrom):
+		evm.denyAccess(&types.AccessDenial{Kind: types.AccessDeniedFrom, Address: caller})
+	case evm.Context.AccessFilter.IsAddressDenied(addr, common.CheckTo):
+		evm.denyAccess(&types.AccessDenial{Kind: types.AccessDeniedTo, Address: addr})
+	default:
+		return nil
+	}
+	return types.ErrAddressDenied
+}
+
+// checkLog returns types.ErrAddressDenied if the access filter denies the log,
+// recording the denial.
+func (evm *EVM) checkLog(log *types.Log) error {
+	if !evm.Context.AccessFilter.IsLogDenied(log) {
+		return nil
+	}
+	denial := &types.AccessDenial{Kind: types.AccessDeniedLog, Address: log.Address}
+	if len(log.Topics) > 0 {
+		denial.Topic = &log.Topics[0]
+	}
+	if reporter, ok := evm.Context.AccessFilter.(LogRuleReporter); ok {
+		denial.Rule = reporter.DeniedLogRule(log)
+	}
+	evm.denyAccess(denial)
+	return types.ErrAddressDenied
+}
+
+// denyAccess records the denial if it's the first of the transaction.
+func (evm *EVM) denyAccess(denial *types.AccessDenial) {
+	if evm.accessDenial == nil {
+		evm.accessDenial = denial
+	}
+}
+
+// keepAccessDenial restores the denial recorded before a call frame once the
+// frame returns without error, so that only the denials failing every frame up
+// to the transaction are reported.
+func (evm *EVM) keepAccessDenial(outer *types.AccessDenial, err *error) {
+	if *err == nil {
+		evm.accessDenial = outer
+	}
+}
+
 // Interpreter returns the current interpreter
 func (evm *EVM) Interpreter() *EVMInterpreter {
 	return evm.interpreter
@@ -208,6 +306,10 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 			evm.captureEnd(evm.depth, startGas, leftOverGas, ret, err)
 		}(gas)
 	}
+	// Denials met within a frame which succeeds were handled by the contracts
+	if evm.Context.AccessFilter != nil {
+		defer evm.keepAccessDenial(evm.accessDenial, &err)
+	}
 	// Fail if we're trying to execute above the call depth limit
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, ErrDepth
@@ -215,9 +317,8 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 
 	// Check whether the involved addresses are denied if needed
 	if evm.Context.AccessFilter != nil && evm.depth > 0 {
-		if evm.Context.AccessFilter.IsAddressDenied(caller.Address(), common.CheckFrom) ||
-			evm.Context.AccessFilter.IsAddressDenied(addr, common.CheckTo) {
-			return nil, gas, types.ErrAddressDenied
+		if err := evm.checkAccess(caller.Address(), addr); err != nil {
+			return nil, gas, err
 		}
 	}
 
@@ -248,7 +349,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	evm.Context.Transfer(evm.StateDB, caller.Address(), addr, value)
 
 	if isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+		ret, gas, err = evm.runPrecompile(p, addr, input, gas)
 	} else {
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		// The contract is a scoped environment for this execution context only.
@@ -299,14 +400,17 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 			evm.captureEnd(evm.depth, startGas, leftOverGas, ret, err)
 		}(gas)
 	}
+	// Denials met within a frame which succeeds were handled by the contracts
+	if evm.Context.AccessFilter != nil {
+		defer evm.keepAccessDenial(evm.accessDenial, &err)
+	}
 	// Fail if we're trying to execute above the call depth limit
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, ErrDepth
 	}
 	if evm.Context.AccessFilter != nil {
-		if evm.Context.AccessFilter.IsAddressDenied(caller.Address(), common.CheckFrom) ||
-			evm.Context.AccessFilter.IsAddressDenied(addr, common.CheckTo) {
-			return nil, gas, types.ErrAddressDenied
+		if err := evm.checkAccess(caller.Address(), addr); err != nil {
+			return nil, gas, err
 		}
 	}
 	// Fail if we're trying to transfer more than the available balance
@@ -320,7 +424,7 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 
 	// It is allowed to call precompiles, even via delegatecall
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+		ret, gas, err = evm.runPrecompile(p, caller.Address(), input, gas)
 	} else {
 		addrCopy := addr
 		// Initialise a new contract and set the code that is to be used by the EVM.
@@ -360,6 +464,10 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 			evm.captureEnd(evm.depth, startGas, leftOverGas, ret, err)
 		}(gas)
 	}
+	// Denials met within a frame which succeeds were handled by the contracts
+	if evm.Context.AccessFilter != nil {
+		defer evm.keepAccessDenial(evm.accessDenial, &err)
+	}
 	// Fail if we're trying to execute above the call depth limit
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, ErrDepth
@@ -367,9 +475,8 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 
 	// Check whether the involved addresses are denied if needed
 	if evm.Context.AccessFilter != nil {
-		if evm.Context.AccessFilter.IsAddressDenied(caller.Address(), common.CheckFrom) ||
-			evm.Context.AccessFilter.IsAddressDenied(addr, common.CheckTo) {
-			return nil, gas, types.ErrAddressDenied
+		if err := evm.checkAccess(caller.Address(), addr); err != nil {
+			return nil, gas, err
 		}
 	}
 
@@ -377,7 +484,7 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 
 	// It is allowed to call precompiles, even via delegatecall
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+		ret, gas, err = evm.runPrecompile(p, caller.Address(), input, gas)
 	} else {
 		addrCopy := addr
 		// Initialise a new contract and make initialise the delegate values
@@ -410,6 +517,10 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 			evm.captureEnd(evm.depth, startGas, leftOverGas, ret, err)
 		}(gas)
 	}
+	// Denials met within a frame which succeeds were handled by the contracts
+	if evm.Context.AccessFilter != nil {
+		defer evm.keepAccessDenial(evm.accessDenial, &err)
+	}
 	// Fail if we're trying to execute above the call depth limit
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, ErrDepth
@@ -417,9 +528,8 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 
 	// Check whether the involved addresses are denied if needed
 	if evm.Context.AccessFilter != nil {
-		if evm.Context.AccessFilter.IsAddressDenied(caller.Address(), common.CheckFrom) ||
-			evm.Context.AccessFilter.IsAddressDenied(addr, common.CheckTo) {
-			return nil, gas, types.ErrAddressDenied
+		if err := evm.checkAccess(caller.Address(), addr); err != nil {
+			return nil, gas, err
 		}
 	}
 
@@ -437,7 +547,7 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	evm.StateDB.AddBalance(addr, new(uint256.Int), tracing.BalanceChangeTouchAccount)
 
 	if p, isPrecompile := evm.precompile(addr); isPrecompile {
-		ret, gas, err = RunPrecompiledContract(p, input, gas, evm.Config.Tracer)
+		ret, gas, err = evm.runPrecompile(p, addr, input, gas)
 	} else {
 		// At this point, we use a copy of address. If we don't, the go compiler will
 		// leak the 'contract' to the outer scope, and make allocation for 'contract'
@@ -486,6 +596,10 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 			evm.captureEnd(evm.depth, startGas, leftOverGas, ret, err)
 		}(gas)
 	}
+	// Denials met within a frame which succeeds were handled by the contracts
+	if evm.Context.AccessFilter != nil {
+		defer evm.keepAccessDenial(evm.accessDenial, &err)
+	}
 	// Depth check execution. Fail if we're trying to execute above the
 	// limit.
 	if evm.depth > int(params.CallCreateDepth) {
@@ -865,8 +865,8 @@ func makeLog(size int) executionFunc {
 			BlockNumber: interpreter.evm.Context.BlockNumber.Uint64(),
 		}
 		if interpreter.evm.Context.AccessFilter != nil {
-			if interpreter.evm.Context.AccessFilter.IsLogDenied(evLog) {
-				return nil, types.ErrAddressDenied
+			if err := interpreter.evm.checkLog(evLog); err != nil {
+				return nil, err
 			}
 		}
 		interpreter.evm.StateDB.AddLog(evLog)
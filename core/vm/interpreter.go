@@ -34,6 +34,11 @@ type Config struct {
 	NoBaseFee               bool  // Forces the EIP-1559 baseFee to 0 (needed for 0 price calls)
 	EnablePreimageRecording bool  // Enables recording of SHA3/keccak preimages
 	ExtraEips               []int // Additional EIPS that are to be enabled
+
+	// MemoryLimit caps the memory allocated by all the live call frames at once,
+	// aborting the whole execution once exceeded. It's only meant for the calls
+	// simulated over RPC, the gas bounding the memory of the transactions.
+	MemoryLimit uint64
 }
 
 // ScopeContext contains the things that are per-call, such as stack and memory,
@@ -199,6 +204,9 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 	defer func() {
 		returnStack(stack)
 	}()
+	if in.evm.Config.MemoryLimit > 0 {
+		defer func() { in.evm.memory -= uint64(mem.Len()) }()
+	}
 	contract.Input = input
 
 	if debug {
@@ -287,6 +295,15 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 				}
 			}
 			if memorySize > 0 {
+				if limit := in.evm.Config.MemoryLimit; limit > 0 && memorySize > uint64(mem.Len()) {
+					grown := memorySize - uint64(mem.Len())
+					if in.evm.memory+grown > limit {
+						in.evm.memoryExceeded = true
+						in.evm.Cancel()
+						return nil, ErrMemoryLimit
+					}
+					in.evm.memory += grown
+				}
 				mem.Resize(memorySize)
 			}
 		} else if debug {
@@ -17,6 +17,7 @@
 package vm
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
@@ -75,3 +76,108 @@ func TestLoopInterrupt(t *testing.T) {
 		}
 	}
 }
+
+func TestMemoryLimit(t *testing.T) {
+	address := common.BytesToAddress([]byte("contract"))
+	vmctx := BlockContext{
+		Transfer: func(StateDB, common.Address, common.Address, *uint256.Int) {},
+	}
+	// mstore(0x10000, 1), expanding the memory to 64KiB+32 bytes
+	code := common.Hex2Bytes("6001620100005200")
+
+	for _, tt := range []struct {
+		limit    uint64
+		exceeded bool
+	}{
+		{0, false},
+		{1024, true},
+		{1 << 20, false},
+	} {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, code)
+		statedb.Finalise(true)
+
+		evm := NewEVM(vmctx, TxContext{}, statedb, params.AllEthashProtocolChanges, Config{MemoryLimit: tt.limit})
+		_, _, err := evm.Call(AccountRef(common.Address{}), address, nil, math.MaxUint64, new(uint256.Int))
+		if tt.exceeded {
+			if err != ErrMemoryLimit || !evm.MemoryLimitExceeded() {
+				t.Errorf("limit %d: expected memory limit error, have %v", tt.limit, err)
+			}
+		} else if err != nil || evm.MemoryLimitExceeded() {
+			t.Errorf("limit %d: unexpected error: %v", tt.limit, err)
+		}
+		if evm.memory != 0 {
+			t.Errorf("limit %d: memory not released: %d", tt.limit, evm.memory)
+		}
+	}
+}
+
+// denyingFilter denies the calls to an address and the logs of a topic.
+type denyingFilter struct {
+	to    common.Address
+	topic common.Hash
+}
+
+func (f *denyingFilter) IsAddressDenied(address common.Address, cType common.AddressCheckType) bool {
+	return cType == common.CheckTo && address == f.to
+}
+
+func (f *denyingFilter) IsLogDenied(log *types.Log) bool {
+	return len(log.Topics) > 0 && log.Topics[0] == f.topic
+}
+
+func (f *denyingFilter) DeniedLogRule(log *types.Log) string { return "test rule" }
+
+func TestAccessDenial(t *testing.T) {
+	var (
+		caller   = common.BytesToAddress([]byte("caller"))
+		denied   = common.BytesToAddress([]byte("denied"))
+		reverter = common.BytesToAddress([]byte("reverter"))
+		emitter  = common.BytesToAddress([]byte("emitter"))
+		topic    = common.Hash{31: 0xaa}
+	)
+	vmctx := BlockContext{
+		CanTransfer:  func(StateDB, common.Address, *uint256.Int) bool { return true },
+		Transfer:     func(StateDB, common.Address, common.Address, *uint256.Int) {},
+		AccessFilter: &denyingFilter{to: denied, topic: topic},
+		BlockNumber:  new(big.Int),
+	}
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	// call(0xffff, denied, 0, 0, 0, 0, 0), ignoring the failure
+	statedb.SetCode(caller, common.Hex2Bytes("60006000600060006000"+"73"+common.Bytes2Hex(denied.Bytes())+"61fffff100"))
+	// call(0xffff, denied, 0, 0, 0, 0, 0), reverting on failure
+	statedb.SetCode(reverter, common.Hex2Bytes("60006000600060006000"+"73"+common.Bytes2Hex(denied.Bytes())+"61fffff1"+"15602857005b60006000fd"))
+	// log1(0, 0, 0xaa)
+	statedb.SetCode(emitter, common.Hex2Bytes("60aa60006000a100"))
+	statedb.Finalise(true)
+
+	evm := NewEVM(vmctx, TxContext{}, statedb, params.AllEthashProtocolChanges, Config{})
+	if _, _, err := evm.Call(AccountRef(common.Address{}), caller, nil, 100000, new(uint256.Int)); err != nil {
+		t.Fatalf("caller failed: %v", err)
+	}
+	if have := evm.AccessDenial(); have != nil {
+		t.Fatalf("handled call denial reported: %+v", have)
+	}
+	if _, _, err := evm.Call(AccountRef(common.Address{}), reverter, nil, 100000, new(uint256.Int)); err != ErrExecutionReverted {
+		t.Fatalf("reverter error mismatch: have %v, want %v", err, ErrExecutionReverted)
+	}
+	if have := evm.AccessDenial(); have == nil || have.Kind != types.AccessDeniedTo || have.Address != denied {
+		t.Fatalf("call denial mismatch: %+v", have)
+	}
+	evm.Reset(TxContext{}, statedb)
+	if evm.AccessDenial() != nil {
+		t.Fatalf("denial not reset")
+	}
+	_, _, err := evm.Call(AccountRef(common.Address{}), emitter, nil, 100000, new(uint256.Int))
+	if err != types.ErrAddressDenied {
+		t.Fatalf("log denial error mismatch: have %v, want %v", err, types.ErrAddressDenied)
+	}
+	if code := VMErrorFromErr(err).(*VMError).ErrorCode(); code != VMErrorCodeAccessDenied {
+		t.Errorf("error code mismatch: have %d, want %d", code, VMErrorCodeAccessDenied)
+	}
+	have := evm.AccessDenial()
+	if have == nil || have.Kind != types.AccessDeniedLog || have.Address != emitter || have.Topic == nil || *have.Topic != topic || have.Rule != "test rule" {
+		t.Fatalf("log denial mismatch: %+v", have)
+	}
+}
@@ -0,0 +1,90 @@
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fuzzActionOps are the frame types entered by the fuzzed call sequences.
+var fuzzActionOps = []OpCode{CALL, CALLCODE, DELEGATECALL, STATICCALL, CREATE, CREATE2, SELFDESTRUCT}
+
+// fuzzActionErrs are the errors exiting the fuzzed frames.
+var fuzzActionErrs = []error{nil, ErrExecutionReverted, ErrOutOfGas, ErrDepth, errors.New("fuzz")}
+
+// FuzzActionLogger feeds random sequences of frame enters and exits to the
+// action logger, with mismatched depths, exits of empty stacks, reverts with
+// random outputs and clears in between, checking that it doesn't panic and that
+// the collected actions are consistent.
+func FuzzActionLogger(f *testing.F) {
+	// Balanced call tree, create reverted with a reason, unbalanced exits
+	f.Add([]byte{0x00, 0x00, 0x10, 0x01, 0x00, 0x80, 0x00, 0x80, 0x00})
+	f.Add([]byte{0x04, 0x00, 0x11, 0x00, 0x81, 0x01, 0x08, 0x08, 0xc3})
+	f.Add([]byte{0x80, 0x80, 0x00, 0x00, 0x85, 0x04, 0x80, 0x00, 0x80, 0x00, 0x80, 0x00})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var (
+			logger = NewActionLogger()
+			hooks  = logger.Hooks()
+			depth  int
+		)
+		// Each operation consumes two bytes: the kind and depth shift in the
+		// first one, the frame type or exit error and output in the second
+		for len(data) >= 2 {
+			op, arg := data[0], data[1]
+			data = data[2:]
+
+			// Depth shifted by -1, 0 or +1 from the tracked one, or reset
+			switch (op >> 2) & 0x03 {
+			case 1:
+				depth--
+			case 2:
+				depth++
+			case 3:
+				depth = 0
+			}
+			if depth < 0 {
+				depth = 0
+			}
+			switch {
+			case op&0xf0 == 0xc0:
+				logger.Clear()
+				depth = 0
+			case op&0x80 == 0:
+				typ := fuzzActionOps[int(arg)%len(fuzzActionOps)]
+				value := big.NewInt(int64(arg))
+				if arg&0x80 != 0 {
+					value = nil
+				}
+				hooks.OnEnter(depth, byte(typ), common.Address{op}, common.Address{arg}, data, uint64(arg), value)
+				depth++
+			default:
+				if depth > 0 {
+					depth--
+				}
+				err := fuzzActionErrs[int(arg&0x0f)%len(fuzzActionErrs)]
+				// Revert outputs are either random or a well-formed reason
+				output := data
+				if arg&0x10 != 0 {
+					output = append(common.FromHex("0x08c379a0"), common.LeftPadBytes([]byte{0x20}, 32)...)
+					output = append(output, common.LeftPadBytes([]byte{byte(len(data))}, 32)...)
+					output = append(output, common.RightPadBytes(data, (len(data)+31)/32*32)...)
+				}
+				hooks.OnExit(depth, output, uint64(arg), err, err == ErrExecutionReverted)
+			}
+		}
+		actions, err := logger.GetResult()
+		if err != nil {
+			return
+		}
+		for i, action := range actions[1:] {
+			if uint64(len(action.TraceAddress)) != action.Depth+1 {
+				t.Fatalf("action %d: trace address %v inconsistent with depth %d", i+1, action.TraceAddress, action.Depth)
+			}
+			if action.Success != (action.Error == "") {
+				t.Fatalf("action %d: success %v inconsistent with error %q", i+1, action.Success, action.Error)
+			}
+		}
+	})
+}
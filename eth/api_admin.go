@@ -18,14 +18,18 @@ package eth
 
 import (
 	"compress/gzip"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/kms"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -141,3 +145,53 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// SyncProgress returns a detailed report of the chain synchronisation, with the
+// progress, bandwidth and estimated time left of every sync phase.
+func (api *AdminAPI) SyncProgress() *downloader.SyncDetails {
+	return api.eth.Downloader().DetailedProgress()
+}
+
+// SignerHealth checks the key management services holding the signing keys,
+// reporting their reachability and the latency and failures of the signing
+// requests.
+func (api *AdminAPI) SignerHealth(ctx context.Context) []kms.Health {
+	reports := []kms.Health{}
+	for _, backend := range api.eth.AccountManager().Backends(kms.BackendType) {
+		reports = append(reports, backend.(*kms.Backend).Health(ctx)...)
+	}
+	return reports
+}
+
+// LogIndexStatus reports the progress of the log index (bloombits) serving the
+// log filters.
+type LogIndexStatus struct {
+	SectionSize uint64 `json:"sectionSize"`
+	Sections    uint64 `json:"sections"` // Number of sections indexed
+	Pending     uint64 `json:"pending"`  // Number of complete sections not indexed yet
+}
+
+// LogIndexStatus returns the progress of the log index.
+func (api *AdminAPI) LogIndexStatus() *LogIndexStatus {
+	var (
+		size           = api.eth.config.BloomSectionSize
+		sections, _, _ = api.eth.bloomIndexer.Sections()
+		head           = api.eth.blockchain.CurrentBlock().Number.Uint64()
+		status         = &LogIndexStatus{SectionSize: size, Sections: sections}
+	)
+	if head+1 >= params.BloomConfirms {
+		if complete := (head + 1 - params.BloomConfirms) / size; complete > sections {
+			status.Pending = complete - sections
+		}
+	}
+	return status
+}
+
+// RebuildLogIndex discards the log index and rebuilds it in the background,
+// e.g. once out of sync with a chain imported or pruned offline. The eth_getLogs
+// queries fall back to the slow unindexed search until the sections are indexed
+// again.
+func (api *AdminAPI) RebuildLogIndex() *LogIndexStatus {
+	api.eth.bloomIndexer.Rebuild()
+	return api.LogIndexStatus()
+}
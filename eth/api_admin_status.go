@@ -0,0 +1,217 @@
+package eth
+
+import (
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// databaseStatusTTL is the time a measured database size is reported for, the
+// measurement walking the whole database directory.
+const databaseStatusTTL = 5 * time.Minute
+
+// inTurnChecker is implemented by consensus engines which can tell whether a
+// validator is the in-turn sealer of the next block, e.g. Turbo.
+type inTurnChecker interface {
+	InTurn(chain consensus.ChainHeaderReader, parent *types.Header, validator common.Address) (bool, bool, error)
+}
+
+// ChainStatus is an aggregated view of the node's health, meant to back
+// operator dashboards with a single call.
+type ChainStatus struct {
+	Head      BlockStatus     `json:"head"`
+	Safe      *BlockStatus    `json:"safe,omitempty"` // Nil if no block has been justified yet
+	Finalized uint64          `json:"finalized"`
+	Validator *ValidatorState `json:"validator,omitempty"` // Nil for non-Turbo engines
+	Peers     PeerStatus      `json:"peers"`
+	TxPool    TxPoolStatus    `json:"txpool"`
+	Database  DatabaseStatus  `json:"database"`
+	Sync      SyncStatus      `json:"sync"`
+}
+
+// BlockStatus identifies a block in the chain status report.
+type BlockStatus struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	Time   uint64      `json:"timestamp"`
+}
+
+// ValidatorState reports the local validator's standing for the next block.
+type ValidatorState struct {
+	Address    common.Address `json:"address"`
+	Mining     bool           `json:"mining"`
+	Attesting  bool           `json:"attesting"`
+	Authorized bool           `json:"authorized"`
+	InTurn     bool           `json:"inTurn"`
+	Error      string         `json:"error,omitempty"` // Set if the snapshot could not be retrieved
+}
+
+// PeerStatus reports the connected peer counts.
+type PeerStatus struct {
+	Total int `json:"total"`
+	Max   int `json:"max"`
+	Eth   int `json:"eth"`
+	Snap  int `json:"snap"`
+}
+
+// TxPoolStatus reports the number of pooled transactions.
+type TxPoolStatus struct {
+	Pending int `json:"pending"`
+	Queued  int `json:"queued"`
+}
+
+// DatabaseStatus reports the on-disk footprint of the chain database.
+type DatabaseStatus struct {
+	Size     uint64 `json:"size"`     // Key-value store size in bytes, including nested ancients
+	Ancient  uint64 `json:"ancient"`  // Ancient store size in bytes, if stored outside the key-value store
+	Measured uint64 `json:"measured"` // Time of the measurement, the sizes being cached for a while
+	Error    string `json:"error,omitempty"`
+}
+
+// SyncStatus reports the chain synchronisation state.
+type SyncStatus struct {
+	Mode          string `json:"mode"`
+	Synced        bool   `json:"synced"`
+	Syncing       bool   `json:"syncing"`
+	CurrentBlock  uint64 `json:"currentBlock"`
+	HighestBlock  uint64 `json:"highestBlock"`
+	StartingBlock uint64 `json:"startingBlock"`
+}
+
+// ChainStatus returns an aggregated report of the chain head, finality,
+// validator duty, peers, transaction pool, database size and sync state.
+func (api *AdminAPI) ChainStatus() *ChainStatus {
+	var (
+		eth    = api.eth
+		chain  = eth.BlockChain()
+		head   = chain.CurrentBlock()
+		status = &ChainStatus{
+			Head:      newBlockStatus(head),
+			Finalized: chain.GetLastFinalizedBlockNumber(),
+		}
+	)
+	if safe := chain.CurrentSafeBlock(); safe != nil {
+		s := newBlockStatus(safe)
+		status.Safe = &s
+	}
+	if eth.isTurboEngine {
+		validator := &ValidatorState{
+			Address:   eth.turboEngine.CurrentValidator(),
+			Mining:    eth.IsMining(),
+			Attesting: eth.turboEngine.AttestationStatus() == types.AttestationStart,
+		}
+		if engine, ok := eth.engine.(inTurnChecker); ok {
+			authorized, inturn, err := engine.InTurn(chain, head, validator.Address)
+			if err != nil {
+				validator.Error = err.Error()
+			}
+			validator.Authorized, validator.InTurn = authorized, inturn
+		}
+		status.Validator = validator
+	}
+	status.Peers = PeerStatus{
+		Total: eth.p2pServer.PeerCount(),
+		Max:   eth.p2pServer.MaxPeers,
+		Eth:   eth.handler.peers.len(),
+		Snap:  eth.handler.peers.snapLen(),
+	}
+	status.TxPool.Pending, status.TxPool.Queued = eth.TxPool().Stats()
+	status.Database = eth.databaseStatus()
+
+	progress := eth.Downloader().Progress()
+	status.Sync = SyncStatus{
+		Mode:          eth.SyncMode().String(),
+		Synced:        eth.Synced(),
+		Syncing:       progress.CurrentBlock < progress.HighestBlock,
+		CurrentBlock:  progress.CurrentBlock,
+		HighestBlock:  progress.HighestBlock,
+		StartingBlock: progress.StartingBlock,
+	}
+	return status
+}
+
+func newBlockStatus(header *types.Header) BlockStatus {
+	return BlockStatus{
+		Number: header.Number.Uint64(),
+		Hash:   header.Hash(),
+		Time:   header.Time,
+	}
+}
+
+// databaseStatus returns the on-disk size of the chain database, measured at
+// most once per databaseStatusTTL.
+func (s *Ethereum) databaseStatus() DatabaseStatus {
+	if s.dbDir == "" {
+		return DatabaseStatus{}
+	}
+	return s.dbStatus.get(time.Now(), func() DatabaseStatus {
+		ancient, _ := s.chainDb.AncientDatadir()
+		return measureDatabase(s.dbDir, ancient)
+	})
+}
+
+// databaseStatusCache retains the last measured database size.
+type databaseStatusCache struct {
+	lock   sync.Mutex // Held while measuring, so that concurrent callers share it
+	status DatabaseStatus
+	time   time.Time
+}
+
+// get returns the cached status, measuring it anew if expired.
+func (c *databaseStatusCache) get(now time.Time, measure func() DatabaseStatus) DatabaseStatus {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.time.IsZero() || now.Sub(c.time) >= databaseStatusTTL {
+		c.status, c.time = measure(), now
+		c.status.Measured = uint64(now.Unix())
+	}
+	return c.status
+}
+
+// measureDatabase measures the on-disk size of the chain database. The ancient
+// store is reported separately only if it was relocated out of the key-value
+// store directory.
+func measureDatabase(dir string, ancient string) DatabaseStatus {
+	var status DatabaseStatus
+	size, err := dirSize(dir)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Size = size
+
+	if ancient == "" {
+		return status
+	}
+	if rel, err := filepath.Rel(dir, ancient); err == nil && !strings.HasPrefix(rel, "..") {
+		return status
+	}
+	if status.Ancient, err = dirSize(ancient); err != nil {
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// dirSize sums up the sizes of all regular files below root.
+func dirSize(root string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
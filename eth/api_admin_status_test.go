@@ -0,0 +1,75 @@
+package eth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMeasureDatabase(t *testing.T) {
+	var (
+		root      = t.TempDir()
+		chaindb   = filepath.Join(root, "chaindata")
+		nested    = filepath.Join(chaindb, "ancient")
+		relocated = filepath.Join(root, "ancient")
+	)
+	for _, dir := range []string{nested, relocated} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(chaindb, "000001.sst"), make([]byte, 100), 0644)
+	os.WriteFile(filepath.Join(nested, "headers.0000.cdat"), make([]byte, 40), 0644)
+	os.WriteFile(filepath.Join(relocated, "bodies.0000.cdat"), make([]byte, 7), 0644)
+
+	tests := []struct {
+		ancient       string
+		size, ancSize uint64
+	}{
+		{"", 140, 0},
+		{nested, 140, 0},
+		{relocated, 140, 7},
+	}
+	for i, tt := range tests {
+		status := measureDatabase(chaindb, tt.ancient)
+		if status.Error != "" || status.Size != tt.size || status.Ancient != tt.ancSize {
+			t.Errorf("test %d: status mismatch: have %+v, want size %d ancient %d", i, status, tt.size, tt.ancSize)
+		}
+	}
+	if status := measureDatabase(filepath.Join(root, "missing"), ""); status.Error == "" {
+		t.Error("missing database measured")
+	}
+}
+
+// Tests that the database size is measured again only once expired.
+func TestDatabaseStatusCache(t *testing.T) {
+	var (
+		cache    databaseStatusCache
+		measured uint64
+		start    = time.Unix(1700000000, 0)
+	)
+	measure := func() DatabaseStatus {
+		measured++
+		return DatabaseStatus{Size: measured}
+	}
+	for i, tt := range []struct {
+		elapsed time.Duration
+		size    uint64
+	}{
+		{0, 1},
+		{time.Minute, 1},
+		{databaseStatusTTL - time.Second, 1},
+		{databaseStatusTTL, 2},
+		{databaseStatusTTL + time.Minute, 2},
+	} {
+		now := start.Add(tt.elapsed)
+		status := cache.get(now, measure)
+		if status.Size != tt.size {
+			t.Errorf("test %d: size mismatch: have %d, want %d", i, status.Size, tt.size)
+		}
+		if tt.size == 2 && status.Measured != uint64(start.Add(databaseStatusTTL).Unix()) {
+			t.Errorf("test %d: measurement time mismatch: have %d", i, status.Measured)
+		}
+	}
+}
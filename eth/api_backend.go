@@ -38,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -49,6 +50,7 @@ type EthAPIBackend struct {
 	eth                 *Ethereum
 	gpo                 *gasprice.Oracle
 	gpp                 *gasprice.Prediction
+	callGuard           *ethapi.CallGuard
 }
 
 // ChainConfig returns the active chain configuration.
@@ -60,9 +62,10 @@ func (b *EthAPIBackend) CurrentBlock() *types.Header {
 	return b.eth.blockchain.CurrentBlock()
 }
 
-func (b *EthAPIBackend) SetHead(number uint64) {
+func (b *EthAPIBackend) SetHead(number uint64) error {
 	b.eth.handler.downloader.Cancel()
-	b.eth.blockchain.SetHead(number)
+	_, err := b.eth.blockchain.Rollback(number)
+	return err
 }
 
 func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
@@ -79,14 +82,14 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 		return b.eth.blockchain.CurrentBlock(), nil
 	}
 	if number == rpc.FinalizedBlockNumber {
-		block := b.eth.blockchain.CurrentFinalBlock()
+		block := b.finalizedHeader()
 		if block == nil {
 			return nil, errors.New("finalized block not found")
 		}
 		return block, nil
 	}
 	if number == rpc.SafeBlockNumber {
-		block := b.eth.blockchain.CurrentSafeBlock()
+		block := b.safeHeader()
 		if block == nil {
 			return nil, errors.New("safe block not found")
 		}
@@ -95,6 +98,29 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 	return b.eth.blockchain.GetHeaderByNumber(uint64(number)), nil
 }
 
+// finalizedHeader returns the header of the last finalized block. On Turbo
+// chains, finality is derived from the validator attestations instead of being
+// set by a consensus client.
+func (b *EthAPIBackend) finalizedHeader() *types.Header {
+	if b.eth.isTurboEngine {
+		return b.eth.blockchain.GetHeaderByNumber(b.eth.blockchain.GetLastFinalizedBlockNumber())
+	}
+	return b.eth.blockchain.CurrentFinalBlock()
+}
+
+// safeHeader returns the header of the last safe block. On Turbo chains, it is
+// the last block justified by the validator attestations.
+func (b *EthAPIBackend) safeHeader() *types.Header {
+	if b.eth.isTurboEngine {
+		number := b.eth.blockchain.LastValidJustifiedOrFinalized().Number.Uint64()
+		if finalized := b.eth.blockchain.GetLastFinalizedBlockNumber(); finalized > number {
+			number = finalized
+		}
+		return b.eth.blockchain.GetHeaderByNumber(number)
+	}
+	return b.eth.blockchain.CurrentSafeBlock()
+}
+
 func (b *EthAPIBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
 	if blockNr, ok := blockNrOrHash.Number(); ok {
 		return b.HeaderByNumber(ctx, blockNr)
@@ -131,14 +157,14 @@ func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumbe
 		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
 	}
 	if number == rpc.FinalizedBlockNumber {
-		header := b.eth.blockchain.CurrentFinalBlock()
+		header := b.finalizedHeader()
 		if header == nil {
 			return nil, errors.New("finalized block not found")
 		}
 		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
 	}
 	if number == rpc.SafeBlockNumber {
-		header := b.eth.blockchain.CurrentSafeBlock()
+		header := b.safeHeader()
 		if header == nil {
 			return nil, errors.New("safe block not found")
 		}
@@ -205,7 +231,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	if header == nil {
 		return nil, nil, errors.New("header not found")
 	}
-	stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+	stateDb, err := b.eth.BlockChain().ServingStateAt(header.Root)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -227,7 +253,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 		if blockNrOrHash.RequireCanonical && b.eth.blockchain.GetCanonicalHash(header.Number.Uint64()) != hash {
 			return nil, nil, errors.New("hash is not currently canonical")
 		}
-		stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+		stateDb, err := b.eth.BlockChain().ServingStateAt(header.Root)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -262,18 +288,21 @@ func (b *EthAPIBackend) GetEVM(ctx context.Context, msg *core.Message, state *st
 	} else {
 		context = core.NewEVMBlockContext(header, b.eth.BlockChain(), nil)
 	}
-	if b.eth.isTurboEngine {
+	if b.eth.isTurboEngine && header.Number.Sign() > 0 {
 		// make sure to use parent state to avoid mix up inner cache
 		parent := b.eth.blockchain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
 		if parent == nil {
 			return nil
 		}
-		parentState, err := b.eth.blockchain.StateAt(parent.Root)
+		parentState, err := b.eth.blockchain.ServingStateAt(parent.Root)
 		if err != nil {
 			return nil
 		}
 		context.AccessFilter = b.eth.turboEngine.CreateEvmAccessFilter(header, parentState)
 	}
+	if b.eth.localAccess != nil {
+		context.AccessFilter = b.eth.localAccess.EvmAccessFilter(context.AccessFilter)
+	}
 	return vm.NewEVM(context, txContext, state, b.ChainConfig(), *vmConfig)
 }
 
@@ -293,6 +322,10 @@ func (b *EthAPIBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) e
 	return b.eth.BlockChain().SubscribeChainSideEvent(ch)
 }
 
+func (b *EthAPIBackend) SubscribeChainReorgEvent(ch chan<- core.ChainReorgEvent) event.Subscription {
+	return b.eth.BlockChain().SubscribeChainReorgEvent(ch)
+}
+
 func (b *EthAPIBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return b.eth.BlockChain().SubscribeLogsEvent(ch)
 }
@@ -427,13 +460,17 @@ func (b *EthAPIBackend) RPCEVMTimeout() time.Duration {
 	return b.eth.config.RPCEVMTimeout
 }
 
+func (b *EthAPIBackend) RPCCallGuard() *ethapi.CallGuard {
+	return b.callGuard
+}
+
 func (b *EthAPIBackend) RPCTxFeeCap() float64 {
 	return b.eth.config.RPCTxFeeCap
 }
 
 func (b *EthAPIBackend) BloomStatus() (uint64, uint64) {
 	sections, _, _ := b.eth.bloomIndexer.Sections()
-	return params.BloomBitsBlocks, sections
+	return b.eth.config.BloomSectionSize, sections
 }
 
 func (b *EthAPIBackend) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {
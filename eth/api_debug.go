@@ -18,6 +18,7 @@ package eth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -30,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
@@ -80,7 +82,7 @@ func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
 	if header == nil {
 		return state.Dump{}, fmt.Errorf("block #%d not found", blockNr)
 	}
-	stateDb, err := api.eth.BlockChain().StateAt(header.Root)
+	stateDb, err := api.eth.BlockChain().ServingStateAt(header.Root)
 	if err != nil {
 		return state.Dump{}, err
 	}
@@ -129,6 +131,37 @@ func (api *DebugAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error)
 	return results, nil
 }
 
+// GetBadBlockBundle returns the forensic bundle captured when the block with
+// the given hash was rejected: its RLP, parent state availability, the local
+// receipts against the header commitments, the action trace of the failing
+// transaction and the consensus engine snapshot at the parent.
+func (api *DebugAPI) GetBadBlockBundle(ctx context.Context, hash common.Hash) (json.RawMessage, error) {
+	return api.eth.blockchain.ReadBadBlockBundle(hash)
+}
+
+// GetBlockBuildLog returns the audit logs of the transaction inclusion in the
+// last blocks sealed by the node, newest first, only those of the given block
+// number if set. The logs tell why the candidate transactions were included or
+// left out of the blocks.
+func (api *DebugAPI) GetBlockBuildLog(number *hexutil.Uint64) []*miner.BlockBuildLog {
+	if number == nil {
+		return api.eth.Miner().BlockBuildLogs(nil)
+	}
+	n := uint64(*number)
+	return api.eth.Miner().BlockBuildLogs(&n)
+}
+
+// GetShadowBlocks returns the blocks the node assembled for the last slots in
+// shadow mode, i.e. without sealing them, along with the blocks actually sealed,
+// newest first, only those of the given block number if set.
+func (api *DebugAPI) GetShadowBlocks(number *hexutil.Uint64) []*miner.ShadowBlock {
+	if number == nil {
+		return api.eth.Miner().ShadowBlocks(nil)
+	}
+	n := uint64(*number)
+	return api.eth.Miner().ShadowBlocks(&n)
+}
+
 // AccountRangeMaxResults is the maximum number of results to be returned per call
 const AccountRangeMaxResults = 256
 
@@ -165,7 +198,7 @@ func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hex
 			if header == nil {
 				return state.Dump{}, fmt.Errorf("block #%d not found", number)
 			}
-			stateDb, err = api.eth.BlockChain().StateAt(header.Root)
+			stateDb, err = api.eth.BlockChain().ServingStateAt(header.Root)
 			if err != nil {
 				return state.Dump{}, err
 			}
@@ -175,7 +208,7 @@ func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hex
 		if block == nil {
 			return state.Dump{}, fmt.Errorf("block %s not found", hash.Hex())
 		}
-		stateDb, err = api.eth.BlockChain().StateAt(block.Root())
+		stateDb, err = api.eth.BlockChain().ServingStateAt(block.Root())
 		if err != nil {
 			return state.Dump{}, err
 		}
@@ -0,0 +1,225 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxAccountHistoryRange is the maximum number of blocks covered by an account
+// history query.
+const maxAccountHistoryRange = 8192
+
+// AccountState is the balance, nonce and code hash of an account at a block.
+type AccountState struct {
+	Balance  *hexutil.Big   `json:"balance"`
+	Nonce    hexutil.Uint64 `json:"nonce"`
+	CodeHash common.Hash    `json:"codeHash"`
+}
+
+// AccountChange is the change of an account caused by a block. Before or
+// After is nil if the account didn't exist before or after the block.
+type AccountChange struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Before      *AccountState  `json:"before"`
+	After       *AccountState  `json:"after"`
+	Delta       *hexutil.Big   `json:"balanceDelta"`
+}
+
+// AccountHistory is the list of changes of an account within a block range.
+type AccountHistory struct {
+	Address   common.Address  `json:"address"`
+	FromBlock hexutil.Uint64  `json:"fromBlock"`
+	ToBlock   hexutil.Uint64  `json:"toBlock"`
+	Changes   []AccountChange `json:"changes"`
+}
+
+// GetAccountHistory returns the balance, nonce and code hash changes of an
+// account within the given block range (both included), block by block. The
+// range covers at most maxAccountHistoryRange blocks, its ends being block
+// numbers or the latest, safe or finalized tags.
+//
+// It relies on the state histories (reverse diffs) of the path-based state
+// scheme, so it's limited to the blocks covered by the retained histories
+// (see --history.state) and the in-memory state layers above them. Changes
+// of the account storage only are not reported.
+func (api *DebugAPI) GetAccountHistory(address common.Address, fromBlock, toBlock rpc.BlockNumber) (*AccountHistory, error) {
+	chain := api.eth.blockchain
+	tdb := chain.TrieDB()
+	if tdb.Scheme() != rawdb.PathScheme {
+		return nil, errors.New("account history is only available in path-based scheme")
+	}
+	resolve := func(num rpc.BlockNumber) (*types.Header, error) {
+		var header *types.Header
+		switch num {
+		case rpc.LatestBlockNumber:
+			header = chain.CurrentBlock()
+		case rpc.FinalizedBlockNumber:
+			header = chain.CurrentFinalBlock()
+		case rpc.SafeBlockNumber:
+			header = chain.CurrentSafeBlock()
+		case rpc.PendingBlockNumber:
+			return nil, errors.New("account history of the pending block is not available")
+		default:
+			if num < 0 {
+				return nil, fmt.Errorf("unsupported block tag %d", num)
+			}
+			header = chain.GetHeaderByNumber(uint64(num))
+		}
+		if header == nil {
+			return nil, fmt.Errorf("block #%d not found", num)
+		}
+		return header, nil
+	}
+	from, err := resolve(fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolve(toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from.Number.Uint64() > to.Number.Uint64() {
+		return nil, errors.New("fromBlock is above toBlock")
+	}
+	if blocks := to.Number.Uint64() - from.Number.Uint64() + 1; blocks > maxAccountHistoryRange {
+		return nil, fmt.Errorf("range of %d blocks exceeds the limit of %d", blocks, maxAccountHistoryRange)
+	}
+	if from.Number.Sign() == 0 {
+		return nil, errors.New("the genesis block has no history")
+	}
+	first, last, err := tdb.HistoryRange()
+	if err != nil {
+		return nil, err
+	}
+	if from.Number.Uint64() < first {
+		return nil, fmt.Errorf("history of block #%d is not available, earliest available history is #%d", from.Number, first)
+	}
+	result := &AccountHistory{
+		Address:   address,
+		FromBlock: hexutil.Uint64(from.Number.Uint64()),
+		ToBlock:   hexutil.Uint64(to.Number.Uint64()),
+		Changes:   []AccountChange{},
+	}
+	// Collect the changes covered by the persisted state histories. The value
+	// after a change is the origin of the next change, or the value in the
+	// state of the last history for the most recent one.
+	if from.Number.Uint64() <= last {
+		start := rawdb.ReadStateID(api.eth.ChainDb(), from.Root)
+		if start == nil {
+			return nil, fmt.Errorf("history of block #%d is not available", from.Number)
+		}
+		stats, err := tdb.AccountHistory(address, *start, 0)
+		if err != nil {
+			return nil, err
+		}
+		lastHeader := chain.GetHeaderByNumber(last)
+		if lastHeader == nil {
+			return nil, fmt.Errorf("block #%d not found", last)
+		}
+		statedb, err := chain.ServingStateAt(lastHeader.Root)
+		if err != nil {
+			return nil, err
+		}
+		for i, number := range stats.Blocks {
+			if number < from.Number.Uint64() || number > to.Number.Uint64() {
+				continue
+			}
+			before, err := historicAccountState(stats.Origins[i])
+			if err != nil {
+				return nil, err
+			}
+			var after *AccountState
+			if i+1 < len(stats.Blocks) {
+				if after, err = historicAccountState(stats.Origins[i+1]); err != nil {
+					return nil, err
+				}
+			} else {
+				after = currentAccountState(statedb, address)
+			}
+			result.appendChange(number, chain.GetCanonicalHash(number), before, after)
+		}
+	}
+	// Collect the changes of the blocks above the persisted histories by
+	// comparing the in-memory states.
+	for number := max(from.Number.Uint64(), last+1); number <= to.Number.Uint64(); number++ {
+		parent, header := chain.GetHeaderByNumber(number-1), chain.GetHeaderByNumber(number)
+		if parent == nil || header == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		parentState, err := chain.ServingStateAt(parent.Root)
+		if err != nil {
+			return nil, fmt.Errorf("state of block #%d is not available: %v", number-1, err)
+		}
+		headerState, err := chain.ServingStateAt(header.Root)
+		if err != nil {
+			return nil, fmt.Errorf("state of block #%d is not available: %v", number, err)
+		}
+		result.appendChange(number, header.Hash(), currentAccountState(parentState, address), currentAccountState(headerState, address))
+	}
+	return result, nil
+}
+
+// appendChange records the change of the account in the given block, unless
+// the balance, nonce and code hash are unchanged.
+func (h *AccountHistory) appendChange(number uint64, hash common.Hash, before, after *AccountState) {
+	if before == nil && after == nil {
+		return
+	}
+	if before != nil && after != nil && before.Balance.ToInt().Cmp(after.Balance.ToInt()) == 0 &&
+		before.Nonce == after.Nonce && before.CodeHash == after.CodeHash {
+		return
+	}
+	delta := new(big.Int)
+	if after != nil {
+		delta.Add(delta, after.Balance.ToInt())
+	}
+	if before != nil {
+		delta.Sub(delta, before.Balance.ToInt())
+	}
+	h.Changes = append(h.Changes, AccountChange{
+		BlockNumber: hexutil.Uint64(number),
+		BlockHash:   hash,
+		Before:      before,
+		After:       after,
+		Delta:       (*hexutil.Big)(delta),
+	})
+}
+
+// historicAccountState decodes an account origin of a state history, nil if
+// the account didn't exist.
+func historicAccountState(blob []byte) (*AccountState, error) {
+	if len(blob) == 0 {
+		return nil, nil
+	}
+	account, err := types.FullAccount(blob)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountState{
+		Balance:  (*hexutil.Big)(account.Balance.ToBig()),
+		Nonce:    hexutil.Uint64(account.Nonce),
+		CodeHash: common.BytesToHash(account.CodeHash),
+	}, nil
+}
+
+// currentAccountState retrieves the account from the given state, nil if the
+// account doesn't exist.
+func currentAccountState(statedb *state.StateDB, address common.Address) *AccountState {
+	if !statedb.Exist(address) {
+		return nil
+	}
+	return &AccountState{
+		Balance:  (*hexutil.Big)(statedb.GetBalance(address).ToBig()),
+		Nonce:    hexutil.Uint64(statedb.GetNonce(address)),
+		CodeHash: statedb.GetCodeHash(address),
+	}
+}
@@ -0,0 +1,251 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// NeroAPI provides an API to access the Nero specific chain data, such as the
+// events of the system contracts.
+type NeroAPI struct {
+	eth *Ethereum
+
+	genesisSupply atomic.Pointer[big.Int] // Total balance allocated at genesis, once computed
+}
+
+// NewNeroAPI creates a new Nero API instance.
+func NewNeroAPI(eth *Ethereum) *NeroAPI {
+	return &NeroAPI{eth: eth}
+}
+
+// SystemEvent is a decoded event emitted by a system contract.
+type SystemEvent struct {
+	Event       string                 `json:"event"`
+	Contract    common.Address         `json:"contract"`
+	Args        map[string]interface{} `json:"args"`
+	BlockNumber hexutil.Uint64         `json:"blockNumber"`
+	BlockHash   common.Hash            `json:"blockHash"`
+	TxHash      common.Hash            `json:"transactionHash"`
+	LogIndex    hexutil.Uint           `json:"logIndex"`
+}
+
+// GetGovernanceHistory returns the governance events (admin and permission
+// changes, validator registrations) of the system contracts emitted within
+// the given block range (both included).
+func (api *NeroAPI) GetGovernanceHistory(fromBlock, toBlock rpc.BlockNumber) ([]*SystemEvent, error) {
+	return api.systemEvents(system.GovernanceEvents, fromBlock, toBlock, nil)
+}
+
+// GetPunishHistory returns the validator punish events emitted within the
+// given block range (both included), optionally limited to one validator.
+func (api *NeroAPI) GetPunishHistory(fromBlock, toBlock rpc.BlockNumber, validator *common.Address) ([]*SystemEvent, error) {
+	return api.systemEvents(system.PunishEvents, fromBlock, toBlock, validator)
+}
+
+// systemEvents retrieves the system events of the given category. The blocks
+// emitting them are looked up in the system event index, only the blocks not
+// covered by indexed sections yet are checked one by one.
+func (api *NeroAPI) systemEvents(category system.EventCategory, fromBlock, toBlock rpc.BlockNumber, validator *common.Address) ([]*SystemEvent, error) {
+	var (
+		chain = api.eth.blockchain
+		db    = api.eth.chainDb
+		head  = chain.CurrentBlock().Number.Uint64()
+	)
+	resolve := func(num rpc.BlockNumber) (uint64, error) {
+		if num.Int64() < 0 {
+			return head, nil
+		}
+		if uint64(num.Int64()) > head {
+			return 0, fmt.Errorf("block #%d not found", num)
+		}
+		return uint64(num.Int64()), nil
+	}
+	from, err := resolve(fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolve(toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, errors.New("fromBlock is above toBlock")
+	}
+	var (
+		size           = uint64(params.BloomBitsBlocks)
+		sections, _, _ = api.eth.systemEventIndexer.Sections()
+		blocks         []uint64
+		wanted         = func(entry rawdb.SystemEventEntry) bool {
+			return entry.Number >= from && entry.Number <= to && system.Events[entry.Event].Category == category &&
+				(len(blocks) == 0 || blocks[len(blocks)-1] != entry.Number)
+		}
+		number = from
+	)
+	for section := from / size; section < sections && section <= to/size; section++ {
+		hash := rawdb.ReadCanonicalHash(db, (section+1)*size-1)
+		entries := rawdb.ReadSystemEvents(db, section, hash)
+		if entries == nil {
+			return nil, fmt.Errorf("system event index section %d not found", section)
+		}
+		for _, entry := range entries {
+			if wanted(entry) {
+				blocks = append(blocks, entry.Number)
+			}
+		}
+		number = (section + 1) * size
+	}
+	for ; number <= to; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		for _, entry := range core.ReadSystemEventEntries(db, header) {
+			if wanted(entry) {
+				blocks = append(blocks, entry.Number)
+			}
+		}
+	}
+	events := []*SystemEvent{}
+	for _, number := range blocks {
+		hash := rawdb.ReadCanonicalHash(db, number)
+		for _, receipt := range chain.GetReceiptsByHash(hash) {
+			for _, log := range receipt.Logs {
+				if len(log.Topics) == 0 {
+					continue
+				}
+				id, ok := system.LookupEvent(log.Address, log.Topics[0])
+				if !ok || system.Events[id].Category != category {
+					continue
+				}
+				if validator != nil && (len(log.Topics) < 2 || common.BytesToAddress(log.Topics[1].Bytes()) != *validator) {
+					continue
+				}
+				event, err := decodeSystemEvent(system.Events[id], log)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
+
+// decodeSystemEvent unpacks the arguments of a system contract event.
+func decodeSystemEvent(event system.Event, log *types.Log) (*SystemEvent, error) {
+	var (
+		definition = event.ABI()
+		args       = make(map[string]interface{})
+		indexed    abi.Arguments
+	)
+	for _, arg := range definition.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err := definition.Inputs.UnpackIntoMap(args, log.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %v", event.Name, err)
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event topics: %v", event.Name, err)
+	}
+	return &SystemEvent{
+		Event:       event.Name,
+		Contract:    log.Address,
+		Args:        args,
+		BlockNumber: hexutil.Uint64(log.BlockNumber),
+		BlockHash:   log.BlockHash,
+		TxHash:      log.TxHash,
+		LogIndex:    hexutil.Uint(log.Index),
+	}, nil
+}
+
+// EpochSummary is the summary of a Turbo epoch.
+type EpochSummary struct {
+	Epoch          hexutil.Uint64   `json:"epoch"`
+	FirstBlock     hexutil.Uint64   `json:"firstBlock"`
+	LastBlock      hexutil.Uint64   `json:"lastBlock"`
+	LastBlockHash  common.Hash      `json:"lastBlockHash"`
+	Validators     []common.Address `json:"validators"`
+	Stakes         []*hexutil.Big   `json:"stakes"`
+	TotalStake     *hexutil.Big     `json:"totalStake"`
+	FeeRewards     *hexutil.Big     `json:"feeRewards"`
+	StakingRewards *hexutil.Big     `json:"stakingRewards"`
+	Punishes       []*EpochPunish   `json:"punishes"`
+}
+
+// EpochPunish is a validator punishment executed during an epoch.
+type EpochPunish struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Validator   common.Address `json:"validator"`
+	Event       string         `json:"event"`
+	Deduction   *hexutil.Big   `json:"deduction"`
+}
+
+// GetEpochSummary returns the validator set, stakes, rewards distributed and
+// punishes executed of the given Turbo epoch. The stakes and staking rewards
+// are null if the state of the last epoch block was unavailable when the epoch
+// was summarized.
+func (api *NeroAPI) GetEpochSummary(epoch hexutil.Uint64) (*EpochSummary, error) {
+	summary, hash, err := api.epochSummary(uint64(epoch))
+	if err != nil {
+		return nil, err
+	}
+	result := &EpochSummary{
+		Epoch:          hexutil.Uint64(summary.Epoch),
+		FirstBlock:     hexutil.Uint64(summary.FirstBlock),
+		LastBlock:      hexutil.Uint64(summary.LastBlock),
+		LastBlockHash:  hash,
+		Validators:     summary.Validators,
+		TotalStake:     (*hexutil.Big)(summary.TotalStake),
+		FeeRewards:     (*hexutil.Big)(summary.FeeRewards),
+		StakingRewards: (*hexutil.Big)(summary.StakingRewards),
+		Punishes:       make([]*EpochPunish, len(summary.Punishes)),
+	}
+	if summary.Stakes != nil {
+		result.Stakes = make([]*hexutil.Big, len(summary.Stakes))
+		for i, stake := range summary.Stakes {
+			result.Stakes[i] = (*hexutil.Big)(stake)
+		}
+	}
+	for i, punish := range summary.Punishes {
+		result.Punishes[i] = &EpochPunish{
+			BlockNumber: hexutil.Uint64(punish.Number),
+			Validator:   punish.Validator,
+			Event:       system.Events[punish.Event].Name,
+			Deduction:   (*hexutil.Big)(punish.Deduction),
+		}
+	}
+	return result, nil
+}
+
+// epochSummary retrieves the summary of the given epoch and the hash of the
+// last block of the epoch.
+func (api *NeroAPI) epochSummary(epoch uint64) (*rawdb.EpochSummary, common.Hash, error) {
+	indexer := api.eth.epochSummaryIndexer
+	if indexer == nil {
+		return nil, common.Hash{}, errors.New("epoch summaries are only available with the Turbo engine")
+	}
+	config := api.eth.blockchain.Config().Turbo
+	if epoch >= summarizedEpochs(indexer, config) {
+		return nil, common.Hash{}, fmt.Errorf("epoch %d not summarized yet", epoch)
+	}
+	hash := rawdb.ReadCanonicalHash(api.eth.chainDb, config.EpochStart(epoch+1)-1)
+	summary := rawdb.ReadEpochSummary(api.eth.chainDb, epoch, hash)
+	if summary == nil {
+		return nil, common.Hash{}, fmt.Errorf("epoch %d summary not found", epoch)
+	}
+	return summary, hash, nil
+}
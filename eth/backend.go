@@ -19,6 +19,7 @@ package eth
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"runtime"
@@ -40,12 +41,19 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/checkpoint"
+	"github.com/ethereum/go-ethereum/eth/clock"
 	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/epochcheck"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/failover"
+	"github.com/ethereum/go-ethereum/eth/firehose"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/localaccess"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/watchdog"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -92,6 +100,10 @@ type Ethereum struct {
 	bloomIndexer      *core.ChainIndexer             // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
+	systemEventIndexer   *core.ChainIndexer // System event indexer operating during block imports
+	epochSummaryIndexer  *core.ChainIndexer // Turbo epoch summary indexer, nil for other engines
+	tokenTransferIndexer *core.ChainIndexer // Token transfer indexer, nil if disabled
+
 	APIBackend *EthAPIBackend
 
 	miner     *miner.Miner
@@ -106,6 +118,16 @@ type Ethereum struct {
 	lock sync.RWMutex // Protects the variadic fields (e.g. gas price and etherbase)
 
 	shutdownTracker *shutdowncheck.ShutdownTracker // Tracks if and when the node has shutdown ungracefully
+
+	checkpointServer *checkpoint.Server  // Builds and serves finalized state checkpoints, nil if disabled
+	dbDir            string              // Key-value store directory, empty for in-memory databases
+	dbStatus         databaseStatusCache // Recently measured size of the database
+	watchdog         *watchdog.Watchdog  // Captures diagnostics on chain stalls, nil if disabled
+	clockMonitor     *clock.Monitor      // Checks the local clock against NTP, nil if disabled
+	epochChecker     *epochcheck.Checker // Cross-checks the epoch validator sets, nil for other engines
+	failover         *failover.Service   // Coordinates the sealing with a failover peer, nil if disabled
+	localAccess      *localaccess.List   // Local access list of the pool and RPC, nil if disabled
+	ioThrottler      *ioThrottler        // Throttles the background I/O while in turn, nil if disabled
 }
 
 // New creates a new Ethereum object (including the initialisation of the common Ethereum object),
@@ -128,6 +150,15 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		}
 		config.TrieDirtyCache = 0
 	}
+	if config.BloomSectionSize == 0 {
+		config.BloomSectionSize = params.BloomBitsBlocks
+	}
+	if config.BloomSectionSize%8 != 0 {
+		return nil, fmt.Errorf("log index section size %d is not a multiple of 8", config.BloomSectionSize)
+	}
+	if config.TrieCleanRPCShare < 0 || config.TrieCleanRPCShare >= 100 {
+		return nil, fmt.Errorf("invalid RPC trie cache share %d%%, must be below 100", config.TrieCleanRPCShare)
+	}
 	log.Info("Allocated trie memory caches", "clean", common.StorageSize(config.TrieCleanCache)*1024*1024, "dirty", common.StorageSize(config.TrieDirtyCache)*1024*1024)
 
 	// Assemble the Ethereum object
@@ -135,10 +166,21 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := verifyChainDatabase(chainDb, config.DatabaseVerify); err != nil {
+		chainDb.Close()
+		return nil, err
+	}
+	if err := migrateChainDatabase(chainDb, config); err != nil {
+		chainDb.Close()
+		return nil, err
+	}
 	scheme, err := rawdb.ParseStateScheme(config.StateScheme, chainDb)
 	if err != nil {
 		return nil, err
 	}
+	if scheme == rawdb.PathScheme && config.TrieCleanRPCShare > 0 {
+		log.Warn("RPC trie cache share not supported by the path scheme, sharing the cache", "share", config.TrieCleanRPCShare)
+	}
 	// Try to recover offline state pruning only in hash-based.
 	if scheme == rawdb.HashScheme {
 		if err := pruner.RecoverPruning(stack.ResolvePath(""), chainDb); err != nil {
@@ -169,7 +211,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		gasPrice:          config.Miner.GasPrice,
 		etherbase:         config.Miner.Etherbase,
 		bloomRequests:     make(chan chan *bloombits.Retrieval),
-		bloomIndexer:      core.NewBloomIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms),
+		bloomIndexer:      core.NewBloomIndexer(chainDb, config.BloomSectionSize, params.BloomConfirms),
 		p2pServer:         stack.Server(),
 		shutdownTracker:   shutdowncheck.NewShutdownTracker(chainDb),
 	}
@@ -193,6 +235,10 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			rawdb.WriteDatabaseVersion(chainDb, core.BlockChainVersion)
 		}
 	}
+	if config.TraceArchive && config.TraceAction != 2 {
+		log.Warn("Archiving action traces requires tracing all actions", "traceaction", config.TraceAction, "updated", 2)
+		config.TraceAction = 2
+	}
 	var (
 		vmConfig = vm.Config{
 			EnablePreimageRecording: config.EnablePreimageRecording,
@@ -201,6 +247,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		cacheConfig = &core.CacheConfig{
 			TrieCleanLimit:      config.TrieCleanCache,
 			TrieCleanNoPrefetch: config.NoPrefetch,
+			TrieCleanRPCShare:   config.TrieCleanRPCShare,
 			TrieDirtyLimit:      config.TrieDirtyCache,
 			TrieDirtyDisabled:   config.NoPruning,
 			TrieTimeLimit:       config.TrieTimeout,
@@ -208,8 +255,15 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			Preimages:           config.Preimages,
 			StateHistory:        config.StateHistory,
 			StateScheme:         scheme,
+			ReceiptDedup:        config.ReceiptDedup,
+			TraceArchive:        config.TraceArchive,
+			BadBlockDir:         config.BadBlockDir,
+			StateExpiryEpoch:    config.StateExpiryEpoch,
 		}
 	)
+	if cacheConfig.BadBlockDir == "" {
+		cacheConfig.BadBlockDir = stack.ResolvePath("badblocks")
+	}
 	if config.VMTrace != "" {
 		var traceConfig json.RawMessage
 		if config.VMTraceJsonConfig != "" {
@@ -240,7 +294,16 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 	log.Info("is TraceAction enabled", "TraceAction", strconv.Itoa(config.TraceAction))
 
+	if prepareBloomIndex(chainDb, config.BloomSectionSize) || config.BloomRebuild {
+		eth.bloomIndexer.Rebuild()
+	}
 	eth.bloomIndexer.Start(eth.blockchain)
+	eth.systemEventIndexer = core.NewSystemEventIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms)
+	eth.systemEventIndexer.Start(eth.blockchain)
+	if config.TokenTransferIndex {
+		eth.tokenTransferIndexer = core.NewTokenTransferIndexer(chainDb, params.BloomBitsBlocks, params.BloomConfirms)
+		eth.tokenTransferIndexer.Start(eth.blockchain)
+	}
 
 	if config.BlobPool.Datadir != "" {
 		config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
@@ -257,14 +320,49 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 
+	if config.LocalAccessList != "" {
+		if eth.localAccess, err = localaccess.New(config.LocalAccessList); err != nil {
+			return nil, err
+		}
+	}
 	// do some extra work if consensus engine is turbo.
 	if turboEngine, ok := eth.engine.(*turbo.Turbo); ok {
 		// set chain & state fn
 		turboEngine.SetChain(eth.blockchain)
 		turboEngine.SetStateFn(eth.blockchain.StateAt)
 
-		// set consensus-related transaction validator
-		eth.txPool.InitTxFilter(turboEngine)
+		// tolerate the clock drifts sized for the block period
+		turboEngine.SetMaxClockDrift(config.Clock.MaxDrift)
+		eth.blockchain.SetFutureBlockWindow(config.Clock.MaxDrift + config.Clock.FutureWindow)
+		if config.Clock.NTPServer != "" {
+			eth.clockMonitor = clock.NewMonitor(config.Clock)
+		}
+
+		// set consensus-related transaction validator, merged with the local
+		// access list which only applies to the pool
+		if eth.localAccess != nil {
+			eth.txPool.InitTxFilter(eth.localAccess.TxFilter(turboEngine))
+		} else {
+			eth.txPool.InitTxFilter(turboEngine)
+		}
+
+		eth.epochSummaryIndexer = newEpochSummaryIndexer(chainDb, eth.blockchain)
+		eth.epochSummaryIndexer.Start(eth.blockchain)
+		eth.epochChecker = epochcheck.New(eth.blockchain)
+		if config.Failover.Standby && config.Failover.Peer == "" {
+			return nil, errors.New("failover standby requires a failover peer")
+		}
+		if config.Failover.Peer != "" {
+			eth.failover = failover.New(config.Failover, eth.blockchain, turboEngine, eth.signText, eth.Synced)
+		}
+		if !config.NoIOThrottle {
+			eth.ioThrottler = newIOThrottler(eth.blockchain, turboEngine, eth.IsMining)
+		}
+		if config.HaltOverride != nil {
+			turboEngine.OverrideHalt(*config.HaltOverride)
+		}
+	} else if eth.localAccess != nil {
+		eth.txPool.InitTxFilter(eth.localAccess.TxFilter(nil))
 	}
 
 	// Permit the downloader to use the trie cache allowance during fast sync
@@ -286,7 +384,14 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	eth.miner = miner.New(eth, &config.Miner, chainConfig, eth.EventMux(), eth.engine, eth.isLocalBlock)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 
-	eth.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, eth, nil, nil}
+	eth.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, eth, nil, nil, nil}
+	eth.APIBackend.callGuard = ethapi.NewCallGuard(ethapi.CallLimits{
+		Timeout:         config.RPCEVMTimeout,
+		TraceTimeout:    config.RPCTraceTimeout,
+		MemoryLimit:     config.RPCEVMMemoryLimit,
+		BreakerTrips:    config.RPCCallBreakerTrips,
+		BreakerCooldown: config.RPCCallBreakerCooldown,
+	})
 	if eth.APIBackend.allowUnprotectedTxs {
 		log.Info("Unprotected transactions allowed")
 	}
@@ -295,6 +400,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		gpoParams.Default = config.Miner.GasPrice
 	}
 	eth.APIBackend.gpo = gasprice.NewOracle(eth.APIBackend, gpoParams)
+	if gpoParams.Strategy == gasprice.StrategyPoSA || (gpoParams.Strategy == gasprice.StrategyAuto && eth.isTurboEngine) {
+		eth.APIBackend.gpo.UsePoSA(eth.txPool)
+	}
 
 	// Setup DNS discovery iterators.
 	dnsclient := dnsdisc.NewClient(dnsdisc.Config{})
@@ -314,6 +422,23 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	// Start the RPC service
 	eth.netRPCService = ethapi.NewNetAPI(eth.p2pServer, networkID)
 
+	// Serve finalized state checkpoints if requested, they are only available
+	// on chains finalized by the turbo attestations.
+	if config.Checkpoint.Enabled {
+		if !eth.isTurboEngine {
+			return nil, errors.New("checkpoint serving requires the turbo consensus engine")
+		}
+		eth.checkpointServer, err = checkpoint.NewServer(config.Checkpoint, stack.ResolvePath("checkpoints"), eth.blockchain, chainDb)
+		if err != nil {
+			return nil, err
+		}
+		stack.RegisterHandler("State checkpoints", checkpoint.HTTPPath, eth.checkpointServer)
+	}
+	eth.dbDir = stack.ResolvePath("chaindata")
+	if config.Watchdog.Enabled {
+		eth.watchdog = watchdog.New(config.Watchdog, stack.ResolvePath("diagnostics"), eth)
+	}
+
 	// Register the backend on the node
 	stack.RegisterAPIs(eth.APIs())
 	stack.RegisterProtocols(eth.Protocols())
@@ -369,6 +494,45 @@ func checkPricePredictionConfig(cfg *gasprice.Config) *gasprice.Config {
 	return cfg
 }
 
+// verifyChainDatabase checks the consistency of the chain database, reporting
+// the found issues along with their suggested repairs. The startup is aborted
+// if the node can't safely operate on the database.
+func verifyChainDatabase(db ethdb.Database, mode string) error {
+	issues, err := rawdb.VerifyChain(db, mode)
+	if err != nil {
+		return err
+	}
+	var fatal bool
+	for _, issue := range issues {
+		if issue.Fatal {
+			log.Error("Chain database is corrupted", "check", issue.Check, "problem", issue.Problem, "repair", issue.Suggestion)
+			fatal = true
+		} else {
+			log.Warn("Chain database is inconsistent", "check", issue.Check, "problem", issue.Problem, "repair", issue.Suggestion)
+		}
+	}
+	if fatal {
+		return errors.New("chain database is corrupted, see the logs for repair suggestions (--db.verify=off skips the check)")
+	}
+	return nil
+}
+
+// migrateChainDatabase applies the pending schema migrations of the Nero tables,
+// or reports them without starting on a dry run.
+func migrateChainDatabase(db ethdb.Database, config *ethconfig.Config) error {
+	pending, err := rawdb.MigrateSchema(db, rawdb.SchemaMigrations, rawdb.MigrationConfig{
+		DryRun: config.DatabaseMigrationDryRun,
+		Backup: config.DatabaseMigrationBackup,
+	})
+	if err != nil {
+		return err
+	}
+	if config.DatabaseMigrationDryRun && len(pending) > 0 {
+		return fmt.Errorf("%d schema migrations pending, not starting on a dry run (see the logs)", len(pending))
+	}
+	return nil
+}
+
 func makeExtraData(extra []byte) []byte {
 	if len(extra) == 0 {
 		// create default extradata
@@ -394,6 +558,13 @@ func (s *Ethereum) APIs() []rpc.API {
 	// Append any APIs exposed explicitly by the consensus engine
 	apis = append(apis, s.engine.APIs(s.BlockChain())...)
 
+	// Append the failover API, only served to the peer if enabled
+	if s.failover != nil {
+		apis = append(apis, rpc.API{
+			Namespace: "failover",
+			Service:   failover.NewAPI(s.failover),
+		})
+	}
 	// Append all the local APIs and return
 	return append(apis, []rpc.API{
 		{
@@ -411,6 +582,12 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
+		}, {
+			Namespace: "nero",
+			Service:   NewNeroAPI(s),
+		}, {
+			Namespace: "nero",
+			Service:   firehose.NewAPI(s.blockchain, s.chainDb),
 		},
 	}...)
 }
@@ -602,7 +779,7 @@ func (s *Ethereum) Start() error {
 	eth.StartENRUpdater(s.blockchain, s.p2pServer.LocalNode())
 
 	// Start the bloom bits servicing goroutines
-	s.startBloomHandlers(params.BloomBitsBlocks)
+	s.startBloomHandlers(s.config.BloomSectionSize)
 
 	// Regularly update shutdown marker
 	s.shutdownTracker.Start()
@@ -617,22 +794,80 @@ func (s *Ethereum) Start() error {
 	}
 	// Start the networking layer and the light server if requested
 	s.handler.Start(maxPeers)
+
+	if s.checkpointServer != nil {
+		s.checkpointServer.Start()
+	}
+	if s.watchdog != nil {
+		s.watchdog.Start()
+	}
+	if s.clockMonitor != nil {
+		s.clockMonitor.Start()
+	}
+	if s.epochChecker != nil {
+		s.epochChecker.Start()
+	}
+	if s.failover != nil {
+		s.failover.Start()
+	}
+	if s.localAccess != nil {
+		s.localAccess.Start()
+	}
+	if s.ioThrottler != nil {
+		s.ioThrottler.Start()
+	}
 	return nil
 }
 
 // Stop implements node.Lifecycle, terminating all internal goroutines used by the
 // Ethereum protocol.
 func (s *Ethereum) Stop() error {
+	// Hand the slots of the local validator over while still connected.
+	s.handover()
+
 	// Stop all the peer-related stuff first.
 	s.ethDialCandidates.Close()
 	s.snapDialCandidates.Close()
 	s.handler.Stop()
 
 	// Then stop everything else.
+	if s.checkpointServer != nil {
+		s.checkpointServer.Stop()
+	}
+	if s.watchdog != nil {
+		s.watchdog.Stop()
+	}
+	if s.clockMonitor != nil {
+		s.clockMonitor.Stop()
+	}
+	if s.epochChecker != nil {
+		s.epochChecker.Stop()
+	}
+	if s.failover != nil {
+		s.failover.Stop()
+	}
+	if s.localAccess != nil {
+		s.localAccess.Stop()
+	}
+	if s.ioThrottler != nil {
+		s.ioThrottler.Stop()
+	}
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
+	s.systemEventIndexer.Close()
+	if s.epochSummaryIndexer != nil {
+		s.epochSummaryIndexer.Close()
+	}
+	if s.tokenTransferIndexer != nil {
+		s.tokenTransferIndexer.Close()
+	}
 	s.txPool.Close()
 	s.miner.Close()
+	if engine, ok := s.engine.(*turbo.Turbo); ok {
+		if err := engine.FlushSnapshot(s.blockchain); err != nil {
+			log.Warn("Failed to flush validator snapshot", "err", err)
+		}
+	}
 	s.blockchain.Stop()
 	s.engine.Close()
 
@@ -17,10 +17,15 @@
 package eth
 
 import (
+	"math"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/bitutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 const (
@@ -41,6 +46,29 @@ const (
 	bloomRetrievalWait = time.Duration(0)
 )
 
+// prepareBloomIndex records the section size of the bloombits index, deleting
+// the bloom bits indexed with another size. It returns whether the size changed
+// and the index must be rebuilt.
+func prepareBloomIndex(db ethdb.Database, size uint64) bool {
+	stored := rawdb.ReadBloomSectionSize(db)
+	if stored == size {
+		return false
+	}
+	rawdb.WriteBloomSectionSize(db, size)
+	if stored == 0 {
+		// The indexes predating the setting use the default size
+		stored = params.BloomBitsBlocks
+		if stored == size {
+			return false
+		}
+	}
+	log.Warn("Log index section size changed, rebuilding the index", "old", stored, "new", size)
+	for bit := uint(0); bit < types.BloomBitLength; bit++ {
+		rawdb.DeleteBloombits(db, bit, 0, math.MaxUint64)
+	}
+	return true
+}
+
 // startBloomHandlers starts a batch of goroutines to accept bloom bit database
 // retrievals from possibly a range of filters and serving the data to satisfy.
 func (eth *Ethereum) startBloomHandlers(sectionSize uint64) {
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth"
@@ -871,6 +872,11 @@ func (api *ConsensusAPI) ExchangeCapabilities([]string) []string {
 // GetClientVersionV1 exchanges client version data of this node.
 func (api *ConsensusAPI) GetClientVersionV1(info engine.ClientVersionV1) []engine.ClientVersionV1 {
 	log.Trace("Engine API request received", "method", "GetClientVersionV1", "info", info.String())
+	return clientVersion()
+}
+
+// clientVersion returns the client version data of this node.
+func clientVersion() []engine.ClientVersionV1 {
 	commit := make([]byte, 4)
 	if vcs, ok := version.VCS(); ok {
 		commit = common.FromHex(vcs.Commit)[0:4]
@@ -888,17 +894,25 @@ func (api *ConsensusAPI) GetClientVersionV1(info engine.ClientVersionV1) []engin
 // GetPayloadBodiesByHashV1 implements engine_getPayloadBodiesByHashV1 which allows for retrieval of a list
 // of block bodies by the engine api.
 func (api *ConsensusAPI) GetPayloadBodiesByHashV1(hashes []common.Hash) []*engine.ExecutionPayloadBodyV1 {
+	return getBodiesByHash(api.eth.BlockChain(), hashes)
+}
+
+// GetPayloadBodiesByRangeV1 implements engine_getPayloadBodiesByRangeV1 which allows for retrieval of a range
+// of block bodies by the engine api.
+func (api *ConsensusAPI) GetPayloadBodiesByRangeV1(start, count hexutil.Uint64) ([]*engine.ExecutionPayloadBodyV1, error) {
+	return getBodiesByRange(api.eth.BlockChain(), start, count)
+}
+
+func getBodiesByHash(chain *core.BlockChain, hashes []common.Hash) []*engine.ExecutionPayloadBodyV1 {
 	bodies := make([]*engine.ExecutionPayloadBodyV1, len(hashes))
 	for i, hash := range hashes {
-		block := api.eth.BlockChain().GetBlockByHash(hash)
+		block := chain.GetBlockByHash(hash)
 		bodies[i] = getBody(block)
 	}
 	return bodies
 }
 
-// GetPayloadBodiesByRangeV1 implements engine_getPayloadBodiesByRangeV1 which allows for retrieval of a range
-// of block bodies by the engine api.
-func (api *ConsensusAPI) GetPayloadBodiesByRangeV1(start, count hexutil.Uint64) ([]*engine.ExecutionPayloadBodyV1, error) {
+func getBodiesByRange(chain *core.BlockChain, start, count hexutil.Uint64) ([]*engine.ExecutionPayloadBodyV1, error) {
 	if start == 0 || count == 0 {
 		return nil, engine.InvalidParams.With(fmt.Errorf("invalid start or count, start: %v count: %v", start, count))
 	}
@@ -906,14 +920,14 @@ func (api *ConsensusAPI) GetPayloadBodiesByRangeV1(start, count hexutil.Uint64)
 		return nil, engine.TooLargeRequest.With(fmt.Errorf("requested count too large: %v", count))
 	}
 	// limit count up until current
-	current := api.eth.BlockChain().CurrentBlock().Number.Uint64()
+	current := chain.CurrentBlock().Number.Uint64()
 	last := uint64(start) + uint64(count) - 1
 	if last > current {
 		last = current
 	}
 	bodies := make([]*engine.ExecutionPayloadBodyV1, 0, uint64(count))
 	for i := uint64(start); i <= last; i++ {
-		block := api.eth.BlockChain().GetBlockByNumber(i)
+		block := chain.GetBlockByNumber(i)
 		bodies = append(bodies, getBody(block))
 	}
 	return bodies, nil
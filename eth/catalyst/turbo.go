@@ -0,0 +1,93 @@
+package catalyst
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// turboCaps are the engine API methods served on Turbo chains.
+var turboCaps = []string{
+	"engine_getPayloadBodiesByHashV1",
+	"engine_getPayloadBodiesByRangeV1",
+	"engine_getClientVersionV1",
+	"engine_getForkchoiceStateV1",
+}
+
+// RegisterTurbo adds the read-only engine API compatibility shim to the full
+// node of a Turbo chain, in place of the engine API.
+func RegisterTurbo(stack *node.Node, backend *eth.Ethereum) {
+	log.Info("Engine API compatibility shim enabled", "protocol", "eth")
+	stack.RegisterAPIs([]rpc.API{
+		{
+			Namespace:     "engine",
+			Service:       NewTurboAPI(backend),
+			Authenticated: true,
+		},
+	})
+}
+
+// TurboAPI is a read-only engine API compatibility shim for Turbo chains, for
+// the tooling expecting the engine API of post-merge nodes.
+//
+// It is not part of the consensus: Turbo blocks are sealed by the validators
+// and finalized by their attestations, not driven by a consensus client. The
+// methods building, importing or choosing blocks are therefore not served,
+// and the forkchoice state is only a view of the Turbo head, justified and
+// finalized blocks.
+type TurboAPI struct {
+	eth *eth.Ethereum
+}
+
+// NewTurboAPI creates the engine API compatibility shim of a Turbo chain.
+func NewTurboAPI(eth *eth.Ethereum) *TurboAPI {
+	return &TurboAPI{eth: eth}
+}
+
+// ExchangeCapabilities returns the engine API methods served by the shim.
+func (api *TurboAPI) ExchangeCapabilities([]string) []string {
+	return turboCaps
+}
+
+// GetClientVersionV1 exchanges client version data of this node.
+func (api *TurboAPI) GetClientVersionV1(info engine.ClientVersionV1) []engine.ClientVersionV1 {
+	return clientVersion()
+}
+
+// GetPayloadBodiesByHashV1 implements engine_getPayloadBodiesByHashV1, returning
+// the bodies of the given blocks, nil for the unknown ones.
+func (api *TurboAPI) GetPayloadBodiesByHashV1(hashes []common.Hash) []*engine.ExecutionPayloadBodyV1 {
+	return getBodiesByHash(api.eth.BlockChain(), hashes)
+}
+
+// GetPayloadBodiesByRangeV1 implements engine_getPayloadBodiesByRangeV1,
+// returning the bodies of the canonical blocks of the range up to the head.
+func (api *TurboAPI) GetPayloadBodiesByRangeV1(start, count hexutil.Uint64) ([]*engine.ExecutionPayloadBodyV1, error) {
+	return getBodiesByRange(api.eth.BlockChain(), start, count)
+}
+
+// GetForkchoiceStateV1 returns the forkchoice state the node would be driven
+// with by a consensus client: the head block, the safe block mapped onto the
+// last justified block and the last finalized block. The safe and finalized
+// hashes are left zero if their blocks are not found.
+//
+// This method is not part of the engine API specification.
+func (api *TurboAPI) GetForkchoiceStateV1() engine.ForkchoiceStateV1 {
+	var (
+		backend = api.eth.APIBackend
+		state   = engine.ForkchoiceStateV1{HeadBlockHash: api.eth.BlockChain().CurrentBlock().Hash()}
+	)
+	if safe, err := backend.HeaderByNumber(context.Background(), rpc.SafeBlockNumber); err == nil && safe != nil {
+		state.SafeBlockHash = safe.Hash()
+	}
+	if finalized, err := backend.HeaderByNumber(context.Background(), rpc.FinalizedBlockNumber); err == nil && finalized != nil {
+		state.FinalizedBlockHash = finalized.Hash()
+	}
+	return state
+}
@@ -0,0 +1,133 @@
+package catalyst
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func newTurboAPITest(t *testing.T, blocks int) (*TurboAPI, []*types.Block) {
+	t.Helper()
+
+	var (
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		genesis = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		signer = types.LatestSigner(genesis.Config)
+	)
+	_, chain, _ := core.GenerateChainWithGenesis(genesis, beacon.New(ethash.NewFaker()), blocks, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), common.Address{0x01}, big.NewInt(1), params.TxGas, b.BaseFee(), nil), signer, key)
+		b.AddTx(tx)
+	})
+	n, err := node.New(&node.Config{
+		P2P: p2p.Config{
+			ListenAddr:  "127.0.0.1:0",
+			NoDiscovery: true,
+			MaxPeers:    0,
+		},
+	})
+	if err != nil {
+		t.Fatal("can't create node:", err)
+	}
+	ethcfg := &ethconfig.Config{Genesis: genesis, SyncMode: downloader.FullSync, TrieTimeout: time.Minute, TrieDirtyCache: 256, TrieCleanCache: 256, Miner: miner.DefaultConfig}
+	ethservice, err := eth.New(n, ethcfg)
+	if err != nil {
+		t.Fatal("can't create eth service:", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatal("can't start node:", err)
+	}
+	t.Cleanup(func() { n.Close() })
+
+	if _, err := ethservice.BlockChain().InsertChain(chain); err != nil {
+		t.Fatal("can't import test blocks:", err)
+	}
+	return NewTurboAPI(ethservice), chain
+}
+
+func TestTurboAPIPayloadBodies(t *testing.T) {
+	api, chain := newTurboAPITest(t, 10)
+
+	checkBody := func(have *engine.ExecutionPayloadBodyV1, want *types.Block) {
+		t.Helper()
+		if have == nil {
+			t.Fatalf("missing body of block #%d", want.NumberU64())
+		}
+		if len(have.TransactionData) != len(want.Transactions()) {
+			t.Fatalf("block #%d: transaction count mismatch: have %d, want %d", want.NumberU64(), len(have.TransactionData), len(want.Transactions()))
+		}
+		for i, tx := range want.Transactions() {
+			if enc, _ := tx.MarshalBinary(); string(enc) != string(have.TransactionData[i]) {
+				t.Errorf("block #%d: transaction %d mismatch", want.NumberU64(), i)
+			}
+		}
+	}
+	hashes := []common.Hash{chain[2].Hash(), {0xff}, chain[7].Hash()}
+	bodies := api.GetPayloadBodiesByHashV1(hashes)
+	if len(bodies) != len(hashes) {
+		t.Fatalf("body count mismatch: have %d, want %d", len(bodies), len(hashes))
+	}
+	checkBody(bodies[0], chain[2])
+	if bodies[1] != nil {
+		t.Errorf("unknown block body returned")
+	}
+	checkBody(bodies[2], chain[7])
+
+	// The range is capped at the head
+	bodies, err := api.GetPayloadBodiesByRangeV1(8, 5)
+	if err != nil {
+		t.Fatalf("failed to get body range: %v", err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("body count mismatch: have %d, want 3", len(bodies))
+	}
+	for i, body := range bodies {
+		checkBody(body, chain[7+i])
+	}
+	if _, err := api.GetPayloadBodiesByRangeV1(0, 1); err == nil {
+		t.Errorf("range from the genesis accepted")
+	}
+}
+
+func TestTurboAPIForkchoiceState(t *testing.T) {
+	api, chain := newTurboAPITest(t, 10)
+
+	want := engine.ForkchoiceStateV1{HeadBlockHash: chain[9].Hash()}
+	if have := api.GetForkchoiceStateV1(); have != want {
+		t.Errorf("forkchoice state mismatch: have %+v, want %+v", have, want)
+	}
+	api.eth.BlockChain().SetSafe(chain[6].Header())
+	api.eth.BlockChain().SetFinalized(chain[4].Header())
+
+	want.SafeBlockHash, want.FinalizedBlockHash = chain[6].Hash(), chain[4].Hash()
+	if have := api.GetForkchoiceStateV1(); have != want {
+		t.Errorf("forkchoice state mismatch: have %+v, want %+v", have, want)
+	}
+	caps := api.ExchangeCapabilities(nil)
+	for _, method := range []string{"engine_forkchoiceUpdatedV1", "engine_newPayloadV1", "engine_getPayloadV1"} {
+		for _, c := range caps {
+			if c == method {
+				t.Errorf("consensus method %s advertised", method)
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package checkpoint implements serving and adopting finalized state checkpoints.
+//
+// A checkpoint is the full state of a block finalized by the Casper FFG
+// attestations of the Turbo engine. The state is flattened into an ordered
+// stream of accounts, contract codes and storage slots, which is cut into
+// chunks addressed by their keccak256 hash. A manifest ties the chunks to the
+// block and carries the attestations that finalized it, so a bootstrapping node
+// can check the finality proof against its header chain before importing the
+// state and can verify the imported state against the block's state root.
+package checkpoint
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/turbo/light"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Kinds of the items contained in a checkpoint chunk.
+const (
+	ItemAccount uint8 = iota // Key is the account hash, value is the slim-RLP account
+	ItemCode                 // Key is the code hash, value is the contract code
+	ItemStorage              // Key is the slot hash, value is the RLP-encoded slot
+)
+
+// chunkSize is the target size of a chunk in bytes. A chunk is cut as soon as
+// the encoded items exceed this size, so chunks may be slightly larger.
+const chunkSize = 4 * 1024 * 1024
+
+var (
+	errUnknownItem      = errors.New("unknown checkpoint item kind")
+	errUnorderedItem    = errors.New("checkpoint items are not ordered")
+	errDanglingItem     = errors.New("checkpoint item without preceding account")
+	errCodeMismatch     = errors.New("contract code does not match its hash")
+	errChunkMismatch    = errors.New("chunk content does not match its hash")
+	errManifestMismatch = errors.New("manifest content does not match its id")
+	errStorageMismatch  = errors.New("storage root mismatch")
+	errRootMismatch     = errors.New("state root mismatch")
+)
+
+// Item is a single entry of the flattened state.
+//
+// Items are ordered by account hash. Every account is followed by its
+// contract code (only on the first occurrence of a code hash) and by its
+// storage slots ordered by slot hash.
+type Item struct {
+	Kind  uint8
+	Key   common.Hash
+	Value []byte
+}
+
+// FinalityProof contains the attestations proving that a checkpoint block is
+// finalized, see light.FinalityProof.
+type FinalityProof = light.FinalityProof
+
+// Manifest describes a checkpoint and references its chunks in stream order.
+type Manifest struct {
+	Number uint64        // Number of the checkpoint block
+	Hash   common.Hash   // Hash of the checkpoint block
+	Root   common.Hash   // State root of the checkpoint block
+	Chunks []common.Hash // Hashes of the chunks in stream order
+	Proof  FinalityProof // Finality proof of the checkpoint block
+}
+
+// ID returns the content address of the manifest.
+func (m *Manifest) ID() common.Hash {
+	blob, err := rlp.EncodeToBytes(m)
+	if err != nil {
+		panic(err)
+	}
+	return crypto.Keccak256Hash(blob)
+}
+
+// Summary is the human readable description of a served checkpoint.
+type Summary struct {
+	ID     common.Hash `json:"id"`
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	Root   common.Hash `json:"root"`
+	Chunks int         `json:"chunks"`
+}
+
+func newSummary(m *Manifest) Summary {
+	return Summary{
+		ID:     m.ID(),
+		Number: m.Number,
+		Hash:   m.Hash,
+		Root:   m.Root,
+		Chunks: len(m.Chunks),
+	}
+}
+
+// DecodeManifest decodes a manifest and checks it against its content address.
+func DecodeManifest(id common.Hash, blob []byte) (*Manifest, error) {
+	if crypto.Keccak256Hash(blob) != id {
+		return nil, errManifestMismatch
+	}
+	m := new(Manifest)
+	if err := rlp.DecodeBytes(blob, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DecodeChunk decodes a chunk and checks it against its content address.
+func DecodeChunk(hash common.Hash, blob []byte) ([]Item, error) {
+	if crypto.Keccak256Hash(blob) != hash {
+		return nil, errChunkMismatch
+	}
+	var items []Item
+	if err := rlp.DecodeBytes(blob, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
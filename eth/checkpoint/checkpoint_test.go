@@ -0,0 +1,365 @@
+package checkpoint
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+)
+
+// makeState creates a state with plain accounts, contracts sharing code and
+// contract storage, and returns its root together with a snapshot tree.
+func makeState(t *testing.T, db ethdb.Database) (common.Hash, *snapshot.Tree) {
+	sdb := state.NewDatabase(db)
+	statedb, err := state.New(types.EmptyRootHash, sdb, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 200; i++ {
+		addr := common.BytesToAddress([]byte{byte(i), 0x01})
+		statedb.SetBalance(addr, uint256.NewInt(uint64(i+1)), tracing.BalanceChangeUnspecified)
+		statedb.SetNonce(addr, uint64(i))
+		if i%10 == 0 {
+			statedb.SetCode(addr, []byte{0x60, byte(i % 3)})
+			for j := 0; j < 50; j++ {
+				statedb.SetState(addr, common.Hash{byte(j)}, common.Hash{byte(i), byte(j + 1)})
+			}
+		}
+	}
+	root, err := statedb.Commit(0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	snaps, err := snapshot.New(snapshot.Config{CacheSize: 16}, db, sdb.TrieDB(), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root, snaps
+}
+
+func exportTestState(t *testing.T, dir string, limit int) (common.Hash, *chunker) {
+	db := rawdb.NewMemoryDatabase()
+	root, snaps := makeState(t, db)
+
+	chunks := &chunker{dir: dir, limit: limit}
+	if err := exportState(snaps, db, root, chunks, make(chan struct{})); err != nil {
+		t.Fatalf("failed to export state: %v", err)
+	}
+	if len(chunks.hashes) == 0 {
+		t.Fatal("no chunks exported")
+	}
+	return root, chunks
+}
+
+func fileFetcher(dir string) func(common.Hash) ([]Item, error) {
+	return func(hash common.Hash) ([]Item, error) {
+		blob, err := os.ReadFile(filepath.Join(dir, hash.Hex()))
+		if err != nil {
+			return nil, err
+		}
+		return DecodeChunk(hash, blob)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	dir := t.TempDir()
+	root, chunks := exportTestState(t, dir, 0)
+
+	db := rawdb.NewMemoryDatabase()
+	m := &Manifest{Root: root, Chunks: chunks.hashes}
+	if err := Import(db, rawdb.HashScheme, m, fileFetcher(dir)); err != nil {
+		t.Fatalf("failed to import checkpoint: %v", err)
+	}
+	if have := rawdb.ReadSnapshotRoot(db); have != root {
+		t.Fatalf("snapshot root mismatch: have %x, want %x", have, root)
+	}
+	// The imported state must be readable through the tries
+	statedb, err := state.New(root, state.NewDatabase(db), nil)
+	if err != nil {
+		t.Fatalf("failed to open imported state: %v", err)
+	}
+	for i := 0; i < 200; i++ {
+		addr := common.BytesToAddress([]byte{byte(i), 0x01})
+		if have := statedb.GetBalance(addr).Uint64(); have != uint64(i+1) {
+			t.Fatalf("account %d: balance mismatch: have %d, want %d", i, have, i+1)
+		}
+		if i%10 == 0 {
+			if have := statedb.GetState(addr, common.Hash{0x07}); have != (common.Hash{byte(i), 0x08}) {
+				t.Fatalf("account %d: storage mismatch: have %x", i, have)
+			}
+			if have := statedb.GetCode(addr); len(have) != 2 {
+				t.Fatalf("account %d: code missing", i)
+			}
+		}
+	}
+}
+
+func TestImportTampered(t *testing.T) {
+	dir := t.TempDir()
+	root, chunks := exportTestState(t, dir, 0)
+
+	tamper := func(modify func([]Item) []Item) error {
+		fetch := fileFetcher(dir)
+		m := &Manifest{Root: root, Chunks: chunks.hashes}
+		return Import(rawdb.NewMemoryDatabase(), rawdb.HashScheme, m, func(hash common.Hash) ([]Item, error) {
+			items, err := fetch(hash)
+			if err != nil {
+				return nil, err
+			}
+			return modify(items), nil
+		})
+	}
+	// Modified account balance
+	err := tamper(func(items []Item) []Item {
+		account, _ := types.FullAccount(items[0].Value)
+		account.Balance = uint256.NewInt(1000)
+		items[0].Value = types.SlimAccountRLP(*account)
+		return items
+	})
+	if !errors.Is(err, errRootMismatch) {
+		t.Errorf("modified account: have %v, want %v", err, errRootMismatch)
+	}
+	// Dropped storage slot
+	err = tamper(func(items []Item) []Item {
+		for i, item := range items {
+			if item.Kind == ItemStorage {
+				return append(items[:i], items[i+1:]...)
+			}
+		}
+		return items
+	})
+	if !errors.Is(err, errStorageMismatch) {
+		t.Errorf("dropped slot: have %v, want %v", err, errStorageMismatch)
+	}
+	// Swapped accounts
+	err = tamper(func(items []Item) []Item {
+		for i := 0; i+2 < len(items); i++ {
+			if items[i].Kind == ItemAccount && items[i+1].Kind == ItemAccount && items[i+2].Kind == ItemAccount {
+				items[i], items[i+1] = items[i+1], items[i]
+				break
+			}
+		}
+		return items
+	})
+	if !errors.Is(err, errUnorderedItem) {
+		t.Errorf("swapped accounts: have %v, want %v", err, errUnorderedItem)
+	}
+}
+
+// Tests that the state cut into many chunks, with the iterators reopened after
+// each of them, is exported in the same order as in a single chunk.
+func TestExportChunked(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		_, whole = exportTestState(t, dir, 0)
+		fetch    = fileFetcher(dir)
+	)
+	root, chunks := exportTestState(t, dir, 512)
+	if len(chunks.hashes) < 10 {
+		t.Fatalf("too few chunks: have %d", len(chunks.hashes))
+	}
+	var have []Item
+	for _, hash := range chunks.hashes {
+		items, err := fetch(hash)
+		if err != nil {
+			t.Fatalf("failed to read chunk %x: %v", hash, err)
+		}
+		have = append(have, items...)
+	}
+	want, err := fetch(whole.hashes[0])
+	if err != nil {
+		t.Fatalf("failed to read chunk: %v", err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Fatalf("chunked export mismatch: have %d items, want %d", len(have), len(want))
+	}
+	m := &Manifest{Root: root, Chunks: chunks.hashes}
+	if err := Import(rawdb.NewMemoryDatabase(), rawdb.HashScheme, m, fetch); err != nil {
+		t.Fatalf("failed to import chunked checkpoint: %v", err)
+	}
+}
+
+// Tests that the snapshot already stored is discarded by the import.
+func TestImportWipesSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	root, chunks := exportTestState(t, dir, 0)
+
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		stale = common.Hash{0xff, 0xff}
+	)
+	rawdb.WriteAccountSnapshot(db, stale, []byte{0x01})
+	rawdb.WriteStorageSnapshot(db, stale, common.Hash{0x01}, []byte{0x01})
+
+	m := &Manifest{Root: root, Chunks: chunks.hashes}
+	if err := Import(db, rawdb.HashScheme, m, fileFetcher(dir)); err != nil {
+		t.Fatalf("failed to import checkpoint: %v", err)
+	}
+	if blob := rawdb.ReadAccountSnapshot(db, stale); len(blob) != 0 {
+		t.Fatal("stale account snapshot not deleted")
+	}
+	if blob := rawdb.ReadStorageSnapshot(db, stale, common.Hash{0x01}); len(blob) != 0 {
+		t.Fatal("stale storage snapshot not deleted")
+	}
+}
+
+// Tests that the chunks of the builds interrupted by a crash are deleted when
+// the server starts, and the ones of the stored checkpoints kept.
+func TestSweepChunks(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{manifestDir, chunkDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root, chunks := exportTestState(t, filepath.Join(dir, chunkDir), 512)
+
+	// Store a checkpoint referencing only the first half of the chunks
+	m := &Manifest{Root: root, Chunks: chunks.hashes[:len(chunks.hashes)/2]}
+	blob, err := rlp.EncodeToBytes(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, manifestDir, m.ID().Hex()), blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tmp := filepath.Join(dir, chunkDir, chunks.hashes[0].Hex()+".tmp")
+	if err := os.WriteFile(tmp, []byte{0x01}, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewServer(Config{}, dir, nil, nil); err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	for i, hash := range chunks.hashes {
+		_, err := os.Stat(filepath.Join(dir, chunkDir, hash.Hex()))
+		if live := i < len(chunks.hashes)/2; live != (err == nil) {
+			t.Errorf("chunk %d: live %v, stored %v", i, live, err == nil)
+		}
+	}
+	if _, err := os.Stat(tmp); err == nil {
+		t.Error("temporary chunk not deleted")
+	}
+}
+
+func TestDecodeChunk(t *testing.T) {
+	items := []Item{{Kind: ItemAccount, Key: common.Hash{0x01}, Value: []byte{0xc0}}}
+	blob, _ := rlp.EncodeToBytes(items)
+	hash := crypto.Keccak256Hash(blob)
+
+	if _, err := DecodeChunk(hash, blob); err != nil {
+		t.Fatalf("failed to decode chunk: %v", err)
+	}
+	blob[len(blob)-1] ^= 0xff
+	if _, err := DecodeChunk(hash, blob); err != errChunkMismatch {
+		t.Fatalf("tampered chunk: have %v, want %v", err, errChunkMismatch)
+	}
+}
+
+// testChain is a header chain containing only the headers it is given.
+type testChain struct {
+	consensus.ChainHeaderReader
+	headers map[common.Hash]*types.Header
+}
+
+func (c *testChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if h, ok := c.headers[hash]; ok && h.Number.Uint64() == number {
+		return h
+	}
+	return nil
+}
+
+// testVerifier accepts attestations of a fixed validator set.
+type testVerifier struct {
+	validators map[common.Address]bool
+}
+
+func (v *testVerifier) VerifyAttestation(chain consensus.ChainHeaderReader, a *types.Attestation) (common.Address, int, error) {
+	signer, err := a.RecoverSigner()
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+	if !v.validators[signer] {
+		return common.Address{}, 0, errors.New("not a validator")
+	}
+	return signer, len(v.validators)*2/3 + 1, nil
+}
+
+func attest(t *testing.T, key *ecdsa.PrivateKey, source, target *types.Header) *types.Attestation {
+	s := &types.RangeEdge{Hash: source.Hash(), Number: source.Number}
+	d := &types.RangeEdge{Hash: target.Hash(), Number: target.Number}
+	sig, err := crypto.Sign(types.AttestationSignHash(s, d).Bytes(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return types.NewAttestation(s, d, sig)
+}
+
+func TestVerifyProof(t *testing.T) {
+	var (
+		keys     []*ecdsa.PrivateKey
+		verifier = &testVerifier{validators: make(map[common.Address]bool)}
+	)
+	for i := 0; i < 4; i++ {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+		verifier.validators[crypto.PubkeyToAddress(key.PublicKey)] = true
+	}
+	source := &types.Header{Number: big.NewInt(9), Extra: []byte("source")}
+	target := &types.Header{Number: big.NewInt(10), ParentHash: source.Hash(), Root: common.Hash{0x01}}
+	child := &types.Header{Number: big.NewInt(11), ParentHash: target.Hash()}
+	stranger := &types.Header{Number: big.NewInt(11), ParentHash: common.Hash{0xff}}
+
+	chain := &testChain{headers: make(map[common.Hash]*types.Header)}
+	for _, h := range []*types.Header{source, target, child, stranger} {
+		chain.headers[h.Hash()] = h
+	}
+	proof := func(n int, child *types.Header) FinalityProof {
+		var p FinalityProof
+		for _, key := range keys[:n] {
+			p.Target = append(p.Target, attest(t, key, source, target))
+			p.Child = append(p.Child, attest(t, key, target, child))
+		}
+		return p
+	}
+	tests := []struct {
+		name string
+		m    *Manifest
+		err  error
+	}{
+		{"valid", &Manifest{Number: 10, Hash: target.Hash(), Root: target.Root, Proof: proof(3, child)}, nil},
+		{"unknown block", &Manifest{Number: 10, Hash: common.Hash{0x02}, Root: target.Root, Proof: proof(3, child)}, errUnknownBlock},
+		{"wrong root", &Manifest{Number: 10, Hash: target.Hash(), Root: common.Hash{0x02}, Proof: proof(3, child)}, errRootMismatch},
+		{"below threshold", &Manifest{Number: 10, Hash: target.Hash(), Root: target.Root, Proof: proof(2, child)}, errNotJustified},
+		{"not a child", &Manifest{Number: 10, Hash: target.Hash(), Root: target.Root, Proof: proof(3, stranger)}, errNotChild},
+	}
+	for _, tt := range tests {
+		err := VerifyProof(chain, verifier, tt.m)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: have %v, want %v", tt.name, err, tt.err)
+		}
+	}
+	// Duplicated attestations of a single validator must not reach the threshold
+	m := &Manifest{Number: 10, Hash: target.Hash(), Root: target.Root, Proof: proof(3, child)}
+	m.Proof.Target = []*types.Attestation{m.Proof.Target[0], m.Proof.Target[0], m.Proof.Target[0]}
+	if err := VerifyProof(chain, verifier, m); !errors.Is(err, errNotJustified) {
+		t.Errorf("duplicated attestations: have %v, want %v", err, errNotJustified)
+	}
+}
@@ -0,0 +1,81 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxResponseSize caps the size of the responses accepted from a checkpoint server.
+const maxResponseSize = 4 * chunkSize
+
+// Client retrieves checkpoints from a remote checkpoint server. Everything
+// retrieved is checked against its content address, so the server does not
+// need to be trusted for the integrity of the data.
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// NewClient creates a client for the checkpoint server at the given URL,
+// e.g. http://127.0.0.1:8545/checkpoint.
+func NewClient(url string) *Client {
+	return &Client{
+		url:    strings.TrimSuffix(url, "/"),
+		client: new(http.Client),
+	}
+}
+
+// Checkpoints retrieves the list of checkpoints served by the remote server,
+// newest first.
+func (c *Client) Checkpoints(ctx context.Context) ([]Summary, error) {
+	blob, err := c.get(ctx, "/")
+	if err != nil {
+		return nil, err
+	}
+	var list []Summary
+	if err := json.Unmarshal(blob, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Manifest retrieves the manifest with the given id.
+func (c *Client) Manifest(ctx context.Context, id common.Hash) (*Manifest, error) {
+	blob, err := c.get(ctx, "/manifest/"+id.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return DecodeManifest(id, blob)
+}
+
+// Chunk retrieves the chunk with the given hash.
+func (c *Client) Chunk(ctx context.Context, hash common.Hash) ([]Item, error) {
+	blob, err := c.get(ctx, "/chunk/"+hash.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return DecodeChunk(hash, blob)
+}
+
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("checkpoint server returned %s for %s", res.Status, path)
+	}
+	return io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+}
@@ -0,0 +1,542 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state/snapshot"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// HTTPPath is the path the checkpoint server is mounted on in the node's HTTP server.
+const HTTPPath = "/checkpoint/"
+
+const (
+	manifestDir = "manifests"
+	chunkDir    = "chunks"
+)
+
+var (
+	errServerStopped   = errors.New("checkpoint server stopped")
+	errSnapshotMissing = errors.New("state snapshot not available")
+	errProofMissing    = errors.New("finality proof not available")
+)
+
+// Config contains the settings of the checkpoint server.
+type Config struct {
+	Enabled  bool   // Whether to build and serve finalized state checkpoints
+	Interval uint64 // Minimum number of blocks between two checkpoints
+	Retain   int    // Number of checkpoints kept on disk
+}
+
+// DefaultConfig contains the default checkpoint server settings.
+var DefaultConfig = Config{
+	Interval: 28800, // about a day with 3s blocks
+	Retain:   2,
+}
+
+// Server periodically exports the state of the latest finalized block into a
+// checkpoint on disk and serves the stored checkpoints over HTTP.
+type Server struct {
+	config Config
+	dir    string
+	chain  *core.BlockChain
+	db     ethdb.Database
+
+	manifests []*Manifest // Stored checkpoints, oldest first
+	lock      sync.RWMutex
+
+	building atomic.Bool
+	quit     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewServer creates a checkpoint server storing its checkpoints in dir, and
+// loads the checkpoints left there by previous runs.
+func NewServer(config Config, dir string, chain *core.BlockChain, db ethdb.Database) (*Server, error) {
+	if config.Interval == 0 {
+		config.Interval = DefaultConfig.Interval
+	}
+	if config.Retain <= 0 {
+		config.Retain = DefaultConfig.Retain
+	}
+	for _, sub := range []string{manifestDir, chunkDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, err
+		}
+	}
+	s := &Server{
+		config: config,
+		dir:    dir,
+		chain:  chain,
+		db:     db,
+		quit:   make(chan struct{}),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	s.sweep()
+	return s, nil
+}
+
+// load reads the stored manifests from disk.
+func (s *Server) load() error {
+	entries, err := os.ReadDir(filepath.Join(s.dir, manifestDir))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		id := common.HexToHash(entry.Name())
+		if id.Hex() != entry.Name() {
+			continue
+		}
+		blob, err := os.ReadFile(filepath.Join(s.dir, manifestDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		m, err := DecodeManifest(id, blob)
+		if err != nil {
+			log.Warn("Dropping corrupted checkpoint manifest", "id", id, "err", err)
+			os.Remove(filepath.Join(s.dir, manifestDir, entry.Name()))
+			continue
+		}
+		s.manifests = append(s.manifests, m)
+	}
+	sort.Slice(s.manifests, func(i, j int) bool {
+		return s.manifests[i].Number < s.manifests[j].Number
+	})
+	if len(s.manifests) > 0 {
+		latest := s.manifests[len(s.manifests)-1]
+		log.Info("Loaded state checkpoints", "count", len(s.manifests), "latest", latest.Number, "hash", latest.Hash)
+	}
+	return nil
+}
+
+// sweep deletes the chunks not referenced by any stored checkpoint, left by
+// the builds interrupted by a crash.
+func (s *Server) sweep() {
+	entries, err := os.ReadDir(filepath.Join(s.dir, chunkDir))
+	if err != nil {
+		return
+	}
+	var hashes []common.Hash
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), ".tmp") {
+			os.Remove(filepath.Join(s.dir, chunkDir, entry.Name()))
+			continue
+		}
+		if hash := common.HexToHash(entry.Name()); hash.Hex() == entry.Name() {
+			hashes = append(hashes, hash)
+		}
+	}
+	if n := s.removeChunks(hashes); n > 0 {
+		log.Info("Deleted orphaned checkpoint chunks", "count", n)
+	}
+}
+
+// Start launches the loop waiting for finalized blocks to checkpoint.
+func (s *Server) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop terminates the server, aborting any checkpoint being built.
+func (s *Server) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+// Latest returns the most recent stored checkpoint, or nil if there is none.
+func (s *Server) Latest() *Manifest {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if len(s.manifests) == 0 {
+		return nil
+	}
+	return s.manifests[len(s.manifests)-1]
+}
+
+func (s *Server) loop() {
+	defer s.wg.Done()
+
+	jfCh := make(chan core.NewJustifiedOrFinalizedBlockEvent, 10)
+	sub := s.chain.SubscribeNewJustifiedOrFinalizedBlockEvent(jfCh)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-jfCh:
+			finalized := s.chain.GetLastFinalizedBlockNumber()
+			if finalized == 0 || !s.due(finalized) || !s.building.CompareAndSwap(false, true) {
+				continue
+			}
+			// The finality proof is only kept in a small in-memory cache, so
+			// collect it right away and leave the slow state export to a
+			// background routine.
+			m, err := s.prepare(finalized)
+			if err != nil {
+				log.Debug("Skipping state checkpoint", "number", finalized, "err", err)
+				s.building.Store(false)
+				continue
+			}
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				defer s.building.Store(false)
+
+				if err := s.build(m); err != nil {
+					log.Warn("Failed to build state checkpoint", "number", m.Number, "hash", m.Hash, "err", err)
+				}
+			}()
+		case <-sub.Err():
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// due reports whether a checkpoint should be made for the given finalized block.
+func (s *Server) due(number uint64) bool {
+	latest := s.Latest()
+	return latest == nil || number >= latest.Number+s.config.Interval
+}
+
+// prepare assembles the manifest of the given finalized block without its chunks.
+func (s *Server) prepare(number uint64) (*Manifest, error) {
+	status, hash := s.chain.GetBlockStatusByNum(number)
+	if status != types.BasFinalized {
+		return nil, fmt.Errorf("block %d not finalized", number)
+	}
+	header := s.chain.GetHeader(hash, number)
+	if header == nil {
+		return nil, fmt.Errorf("header %d not found", number)
+	}
+	_, childHash := s.chain.GetBlockStatusByNum(number + 1)
+
+	target, err := s.chain.GetHistoryAttestations(new(big.Int).SetUint64(number), hash)
+	if err != nil {
+		return nil, errProofMissing
+	}
+	child, err := s.chain.GetHistoryAttestations(new(big.Int).SetUint64(number+1), childHash)
+	if err != nil {
+		return nil, errProofMissing
+	}
+	return &Manifest{
+		Number: number,
+		Hash:   hash,
+		Root:   header.Root,
+		Proof:  FinalityProof{Target: target, Child: child},
+	}, nil
+}
+
+// build exports the state of the manifest's block into chunks and stores the
+// completed checkpoint.
+func (s *Server) build(m *Manifest) error {
+	snaps := s.chain.Snapshots()
+	if snaps == nil {
+		return errSnapshotMissing
+	}
+	var (
+		start  = time.Now()
+		chunks = &chunker{dir: filepath.Join(s.dir, chunkDir)}
+	)
+	if err := exportState(snaps, s.db, m.Root, chunks, s.quit); err != nil {
+		s.removeChunks(chunks.hashes)
+		return err
+	}
+	m.Chunks = chunks.hashes
+
+	blob, err := rlp.EncodeToBytes(m)
+	if err != nil {
+		return err
+	}
+	id := crypto.Keccak256Hash(blob)
+	if err := writeFile(filepath.Join(s.dir, manifestDir, id.Hex()), blob); err != nil {
+		s.removeChunks(chunks.hashes)
+		return err
+	}
+	s.lock.Lock()
+	s.manifests = append(s.manifests, m)
+	stale := s.manifests
+	if len(s.manifests) > s.config.Retain {
+		s.manifests = s.manifests[len(s.manifests)-s.config.Retain:]
+	}
+	stale = stale[:len(stale)-len(s.manifests)]
+	s.lock.Unlock()
+
+	s.prune(stale)
+	log.Info("Created state checkpoint", "number", m.Number, "hash", m.Hash, "id", id,
+		"chunks", len(m.Chunks), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// prune deletes the given manifests and the chunks no longer referenced by
+// any of the retained checkpoints.
+func (s *Server) prune(stale []*Manifest) {
+	for _, m := range stale {
+		os.Remove(filepath.Join(s.dir, manifestDir, m.ID().Hex()))
+		s.removeChunks(m.Chunks)
+	}
+}
+
+// removeChunks deletes the given chunks unless referenced by any of the
+// retained checkpoints, and returns the number of chunks deleted.
+func (s *Server) removeChunks(hashes []common.Hash) int {
+	s.lock.RLock()
+	live := make(map[common.Hash]struct{})
+	for _, m := range s.manifests {
+		for _, hash := range m.Chunks {
+			live[hash] = struct{}{}
+		}
+	}
+	s.lock.RUnlock()
+
+	var removed int
+	for _, hash := range hashes {
+		if _, ok := live[hash]; ok {
+			continue
+		}
+		if os.Remove(filepath.Join(s.dir, chunkDir, hash.Hex())) == nil {
+			removed++
+		}
+	}
+	return removed
+}
+
+// ServeHTTP implements http.Handler, serving
+//
+//	/checkpoint/               the list of stored checkpoints, newest first
+//	/checkpoint/manifest/<id>  the RLP-encoded manifest with the given id
+//	/checkpoint/chunk/<hash>   the RLP-encoded chunk with the given hash
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, HTTPPath)
+	if path == "" {
+		s.lock.RLock()
+		list := make([]Summary, 0, len(s.manifests))
+		for i := len(s.manifests) - 1; i >= 0; i-- {
+			list = append(list, newSummary(s.manifests[i]))
+		}
+		s.lock.RUnlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+		return
+	}
+	kind, name, ok := strings.Cut(path, "/")
+	if !ok || common.HexToHash(name).Hex() != name {
+		http.NotFound(w, r)
+		return
+	}
+	switch kind {
+	case "manifest":
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeFile(w, r, filepath.Join(s.dir, manifestDir, name))
+	case "chunk":
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		http.ServeFile(w, r, filepath.Join(s.dir, chunkDir, name))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// chunker cuts a stream of items into content-addressed chunks stored in dir.
+type chunker struct {
+	dir    string
+	limit  int // Size at which a chunk is cut, chunkSize if zero
+	items  []Item
+	size   int
+	hashes []common.Hash
+}
+
+// add appends an item to the current chunk, and reports whether the chunk was
+// cut and stored.
+func (c *chunker) add(item Item) (bool, error) {
+	limit := c.limit
+	if limit == 0 {
+		limit = chunkSize
+	}
+	c.items = append(c.items, item)
+	c.size += len(item.Value) + common.HashLength + 8
+	if c.size < limit {
+		return false, nil
+	}
+	return true, c.flush()
+}
+
+func (c *chunker) flush() error {
+	if len(c.items) == 0 {
+		return nil
+	}
+	blob, err := rlp.EncodeToBytes(c.items)
+	if err != nil {
+		return err
+	}
+	hash := crypto.Keccak256Hash(blob)
+	path := filepath.Join(c.dir, hash.Hex())
+	if _, err := os.Stat(path); err != nil {
+		if err := writeFile(path, blob); err != nil {
+			return err
+		}
+	}
+	c.hashes = append(c.hashes, hash)
+	c.items, c.size = c.items[:0], 0
+	return nil
+}
+
+// exportState flattens the state with the given root into the chunker.
+//
+// The snapshot iterators are reopened after every chunk cut instead of being
+// held for the whole export, which takes minutes on a large state: a held
+// iterator would keep walking the diff layers flattened into the disk layer
+// meanwhile. If the layer of the root is gone when reopening, the export fails
+// and is retried with a later checkpoint.
+func exportState(snaps *snapshot.Tree, db ethdb.KeyValueReader, root common.Hash, chunks *chunker, quit chan struct{}) error {
+	var (
+		codes = make(map[common.Hash]struct{})
+		pos   exportPosition
+	)
+	for {
+		select {
+		case <-quit:
+			return errServerStopped
+		default:
+		}
+		done, err := exportChunk(snaps, db, root, chunks, codes, &pos)
+		if err != nil {
+			return err
+		}
+		if done {
+			return chunks.flush()
+		}
+	}
+}
+
+// exportPosition is the position of the next item to export.
+type exportPosition struct {
+	account common.Hash // Next account to export, or the one whose storage is exported
+	storage bool        // Whether the items of the account are exported up to its storage
+	slot    common.Hash // Next storage slot of the account to export
+}
+
+// exportChunk exports the items from the given position until a chunk is cut,
+// advancing the position, and reports whether the whole state was exported.
+func exportChunk(snaps *snapshot.Tree, db ethdb.KeyValueReader, root common.Hash, chunks *chunker, codes map[common.Hash]struct{}, pos *exportPosition) (bool, error) {
+	accIt, err := snaps.AccountIterator(root, pos.account)
+	if err != nil {
+		return false, err
+	}
+	defer accIt.Release()
+
+	for accIt.Next() {
+		accHash := accIt.Hash()
+		account, err := types.FullAccount(accIt.Account())
+		if err != nil {
+			return false, err
+		}
+		if !pos.storage || pos.account != accHash {
+			*pos = exportPosition{account: accHash, storage: true}
+
+			cut, err := chunks.add(Item{Kind: ItemAccount, Key: accHash, Value: common.CopyBytes(accIt.Account())})
+			if err != nil {
+				return false, err
+			}
+			codeHash := common.BytesToHash(account.CodeHash)
+			if _, ok := codes[codeHash]; !ok && codeHash != types.EmptyCodeHash {
+				code := rawdb.ReadCode(db, codeHash)
+				if len(code) == 0 {
+					return false, fmt.Errorf("missing code %x", codeHash)
+				}
+				if cut, err = chunks.add(Item{Kind: ItemCode, Key: codeHash, Value: code}); err != nil {
+					return false, err
+				}
+				codes[codeHash] = struct{}{}
+			}
+			if cut {
+				return false, nil
+			}
+		}
+		if account.Root != types.EmptyRootHash {
+			cut, err := exportStorage(snaps, root, chunks, pos)
+			if err != nil || cut {
+				return false, err
+			}
+		}
+		next, ok := nextHash(accHash)
+		if !ok {
+			return true, nil
+		}
+		*pos = exportPosition{account: next}
+	}
+	return true, accIt.Error()
+}
+
+// exportStorage exports the storage of the account at the given position until
+// a chunk is cut, advancing the position, and reports whether a chunk was cut
+// before the end of the storage.
+func exportStorage(snaps *snapshot.Tree, root common.Hash, chunks *chunker, pos *exportPosition) (bool, error) {
+	stIt, err := snaps.StorageIterator(root, pos.account, pos.slot)
+	if err != nil {
+		return false, err
+	}
+	defer stIt.Release()
+
+	for stIt.Next() {
+		cut, err := chunks.add(Item{Kind: ItemStorage, Key: stIt.Hash(), Value: common.CopyBytes(stIt.Slot())})
+		if err != nil {
+			return false, err
+		}
+		next, ok := nextHash(stIt.Hash())
+		if !ok {
+			break
+		}
+		pos.slot = next
+		if cut {
+			return true, nil
+		}
+	}
+	return false, stIt.Error()
+}
+
+// nextHash returns the hash following h, or false if h is the last one.
+func nextHash(h common.Hash) (common.Hash, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i]++
+		if h[i] != 0 {
+			return h, true
+		}
+	}
+	return h, false
+}
+
+// writeFile atomically writes blob into the file at path.
+func writeFile(path string, blob []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, blob, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
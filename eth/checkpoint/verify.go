@@ -0,0 +1,271 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/turbo/light"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+var (
+	errUnknownBlock = errors.New("unknown checkpoint block")
+	errNotChild     = errors.New("child attestations do not target a child of the checkpoint block")
+	errNotJustified = light.ErrNotJustified
+)
+
+// AttestationVerifier checks attestations against the validator set of their
+// target block. It's implemented by the Turbo consensus engine.
+type AttestationVerifier interface {
+	VerifyAttestation(chain consensus.ChainHeaderReader, a *types.Attestation) (common.Address, int, error)
+}
+
+// VerifyProof checks that the manifest describes a block of the local header
+// chain and that its finality proof is valid, i.e. that both the checkpoint
+// block and its child are justified by the validators of the chain.
+func VerifyProof(chain consensus.ChainHeaderReader, engine AttestationVerifier, m *Manifest) error {
+	header := chain.GetHeader(m.Hash, m.Number)
+	if header == nil {
+		return errUnknownBlock
+	}
+	if header.Root != m.Root {
+		return errRootMismatch
+	}
+	if err := verifyJustified(chain, engine, m.Proof.Target, m.Number, m.Hash); err != nil {
+		return fmt.Errorf("checkpoint block %d: %w", m.Number, err)
+	}
+	if len(m.Proof.Child) == 0 || m.Proof.Child[0].SanityCheck() != nil {
+		return fmt.Errorf("child block %d: %w", m.Number+1, errNotJustified)
+	}
+	childHash := m.Proof.Child[0].TargetRangeEdge.Hash
+	child := chain.GetHeader(childHash, m.Number+1)
+	if child == nil {
+		return errUnknownBlock
+	}
+	if child.ParentHash != m.Hash {
+		return errNotChild
+	}
+	if err := verifyJustified(chain, engine, m.Proof.Child, m.Number+1, childHash); err != nil {
+		return fmt.Errorf("child block %d: %w", m.Number+1, err)
+	}
+	return nil
+}
+
+// verifyJustified checks that the attestations justify the given block.
+func verifyJustified(chain consensus.ChainHeaderReader, engine AttestationVerifier, atts []*types.Attestation, number uint64, hash common.Hash) error {
+	return light.VerifyJustification(atts, number, hash, func(a *types.Attestation) (common.Address, int, error) {
+		return engine.VerifyAttestation(chain, a)
+	})
+}
+
+// Import writes the state of a checkpoint into db, retrieving its chunks in
+// stream order through fetch. Every storage trie and the account trie are
+// rebuilt from the items and checked against the roots committed to by the
+// manifest, the snapshot is marked complete only if all of them match. Any
+// snapshot already in db is discarded first, as it describes another state.
+//
+// The finality proof of the manifest is not checked here, callers should use
+// VerifyProof before adopting a checkpoint. If an error is returned, the data
+// already written is not usable and should be discarded.
+func Import(db ethdb.Database, scheme string, m *Manifest, fetch func(hash common.Hash) ([]Item, error)) error {
+	if err := wipeSnapshot(db); err != nil {
+		return err
+	}
+	imp := newImporter(db, scheme)
+	for _, hash := range m.Chunks {
+		items, err := fetch(hash)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := imp.process(item); err != nil {
+				return err
+			}
+		}
+	}
+	root, err := imp.finish()
+	if err != nil {
+		return err
+	}
+	if root != m.Root {
+		return fmt.Errorf("%w: have %x, want %x", errRootMismatch, root, m.Root)
+	}
+	rawdb.WriteSnapshotRoot(db, root)
+	return nil
+}
+
+// wipeSnapshot deletes the snapshot stored in db, so that the entries of its
+// accounts missing from the checkpoint don't linger in the imported one.
+func wipeSnapshot(db ethdb.Database) error {
+	rawdb.DeleteSnapshotRoot(db)
+	rawdb.DeleteSnapshotJournal(db)
+	rawdb.DeleteSnapshotGenerator(db)
+
+	batch := db.NewBatch()
+	for _, table := range []struct {
+		prefix []byte
+		keyLen int
+	}{
+		{rawdb.SnapshotAccountPrefix, len(rawdb.SnapshotAccountPrefix) + common.HashLength},
+		{rawdb.SnapshotStoragePrefix, len(rawdb.SnapshotStoragePrefix) + 2*common.HashLength},
+	} {
+		it := db.NewIterator(table.prefix, nil)
+		for it.Next() {
+			if len(it.Key()) != table.keyLen {
+				continue
+			}
+			if err := batch.Delete(it.Key()); err != nil {
+				it.Release()
+				return err
+			}
+			if batch.ValueSize() >= ethdb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					it.Release()
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// importer rebuilds the state tries from a stream of checkpoint items.
+type importer struct {
+	db     ethdb.Database
+	batch  ethdb.Batch
+	scheme string
+
+	accTrie *trie.StackTrie
+	codes   map[common.Hash]struct{}
+
+	started  bool        // Whether an account has been processed yet
+	account  common.Hash // Hash of the account being processed
+	codeHash common.Hash // Code hash of the account being processed
+	root     common.Hash // Storage root of the account being processed
+	stTrie   *trie.StackTrie
+	lastSlot *common.Hash
+}
+
+func newImporter(db ethdb.Database, scheme string) *importer {
+	imp := &importer{
+		db:     db,
+		batch:  db.NewBatch(),
+		scheme: scheme,
+		codes:  make(map[common.Hash]struct{}),
+	}
+	imp.accTrie = trie.NewStackTrie(imp.nodeWriter(common.Hash{}))
+	return imp
+}
+
+func (imp *importer) nodeWriter(owner common.Hash) trie.OnTrieNode {
+	return func(path []byte, hash common.Hash, blob []byte) {
+		rawdb.WriteTrieNode(imp.batch, owner, path, hash, blob, imp.scheme)
+	}
+}
+
+func (imp *importer) process(item Item) error {
+	switch item.Kind {
+	case ItemAccount:
+		if imp.started && item.Key.Cmp(imp.account) <= 0 {
+			return errUnorderedItem
+		}
+		if err := imp.finishAccount(); err != nil {
+			return err
+		}
+		account, err := types.FullAccount(item.Value)
+		if err != nil {
+			return err
+		}
+		blob, err := rlp.EncodeToBytes(account)
+		if err != nil {
+			return err
+		}
+		if err := imp.accTrie.Update(item.Key[:], blob); err != nil {
+			return err
+		}
+		rawdb.WriteAccountSnapshot(imp.batch, item.Key, item.Value)
+
+		imp.started = true
+		imp.account = item.Key
+		imp.codeHash = common.BytesToHash(account.CodeHash)
+		imp.root = account.Root
+		imp.stTrie = trie.NewStackTrie(imp.nodeWriter(item.Key))
+		imp.lastSlot = nil
+
+	case ItemCode:
+		if !imp.started {
+			return errDanglingItem
+		}
+		if item.Key != imp.codeHash || crypto.Keccak256Hash(item.Value) != item.Key {
+			return errCodeMismatch
+		}
+		rawdb.WriteCode(imp.batch, item.Key, item.Value)
+		imp.codes[item.Key] = struct{}{}
+
+	case ItemStorage:
+		if !imp.started {
+			return errDanglingItem
+		}
+		if imp.lastSlot != nil && item.Key.Cmp(*imp.lastSlot) <= 0 {
+			return errUnorderedItem
+		}
+		if err := imp.stTrie.Update(item.Key[:], item.Value); err != nil {
+			return err
+		}
+		rawdb.WriteStorageSnapshot(imp.batch, imp.account, item.Key, item.Value)
+		key := item.Key
+		imp.lastSlot = &key
+
+	default:
+		return errUnknownItem
+	}
+	if imp.batch.ValueSize() >= ethdb.IdealBatchSize {
+		if err := imp.batch.Write(); err != nil {
+			return err
+		}
+		imp.batch.Reset()
+	}
+	return nil
+}
+
+// finishAccount checks the storage and code of the account being processed.
+func (imp *importer) finishAccount() error {
+	if !imp.started {
+		return nil
+	}
+	if root := imp.stTrie.Hash(); root != imp.root {
+		return fmt.Errorf("%w: account %x have %x, want %x", errStorageMismatch, imp.account, root, imp.root)
+	}
+	if imp.codeHash != types.EmptyCodeHash {
+		if _, ok := imp.codes[imp.codeHash]; !ok {
+			return fmt.Errorf("%w: account %x misses code %x", errCodeMismatch, imp.account, imp.codeHash)
+		}
+	}
+	return nil
+}
+
+// finish completes the import and returns the root of the rebuilt account trie.
+func (imp *importer) finish() (common.Hash, error) {
+	if err := imp.finishAccount(); err != nil {
+		return common.Hash{}, err
+	}
+	root := imp.accTrie.Hash()
+	if err := imp.batch.Write(); err != nil {
+		return common.Hash{}, err
+	}
+	imp.batch.Reset()
+	return root, nil
+}
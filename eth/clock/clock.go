@@ -0,0 +1,116 @@
+// Package clock guards the block timestamps against the drift of the local
+// clock.
+//
+// The Turbo engine seals a block every few seconds, far below the tolerances
+// inherited from the proof-of-work era. The package configures how far ahead
+// of the local clock the headers are accepted, and how far beyond that the
+// blocks are queued for a later import instead of being dropped. It also
+// checks the local clock against an NTP server periodically and reports the
+// drift via metrics, so that the validators notice a skewed clock before it
+// makes them miss their slots or reject valid blocks.
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/p2p/discover"
+)
+
+// ntpMeasurements is the number of measurements averaged by an NTP check.
+const ntpMeasurements = 3
+
+// Config contains the timestamp tolerances and the clock health check settings.
+type Config struct {
+	MaxDrift     time.Duration // Time the headers may be stamped ahead of the local clock
+	FutureWindow time.Duration // Time beyond the max drift the blocks are queued for later import
+	NTPServer    string        // NTP server the local clock is checked against, empty to disable
+	NTPInterval  time.Duration // Time between two NTP checks
+}
+
+// DefaultConfig contains the default settings, sized for a 3 seconds period.
+var DefaultConfig = Config{
+	MaxDrift:     time.Second,
+	FutureWindow: 9 * time.Second,
+	NTPServer:    "pool.ntp.org",
+	NTPInterval:  10 * time.Minute,
+}
+
+var (
+	driftGauge   = metrics.NewRegisteredGauge("clock/ntp/drift", nil)   // Last measured drift, in milliseconds
+	healthyGauge = metrics.NewRegisteredGauge("clock/ntp/healthy", nil) // 1 if the last drift is within the max drift, 0 otherwise
+	failureMeter = metrics.NewRegisteredMeter("clock/ntp/failures", nil)
+)
+
+// Monitor checks the local clock against an NTP server periodically.
+type Monitor struct {
+	config Config
+	drift  func(server string, measurements int) (time.Duration, error)
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewMonitor creates a monitor of the local clock.
+func NewMonitor(config Config) *Monitor {
+	if config.NTPInterval <= 0 {
+		config.NTPInterval = DefaultConfig.NTPInterval
+	}
+	return &Monitor{
+		config: config,
+		drift:  discover.SNTPDrift,
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start launches the periodic checks.
+func (m *Monitor) Start() {
+	m.wg.Add(1)
+	go m.loop()
+}
+
+// Stop terminates the periodic checks.
+func (m *Monitor) Stop() {
+	close(m.quit)
+	m.wg.Wait()
+}
+
+func (m *Monitor) loop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.config.NTPInterval)
+	defer ticker.Stop()
+
+	for {
+		m.check()
+
+		select {
+		case <-ticker.C:
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+// check measures the drift of the local clock and reports it, returning the
+// drift and whether it's within the tolerated header drift.
+func (m *Monitor) check() (time.Duration, bool, error) {
+	drift, err := m.drift(m.config.NTPServer, ntpMeasurements)
+	if err != nil {
+		failureMeter.Mark(1)
+		log.Debug("NTP clock check failed", "server", m.config.NTPServer, "err", err)
+		return 0, false, err
+	}
+	driftGauge.Update(drift.Milliseconds())
+	if drift < -m.config.MaxDrift || drift > m.config.MaxDrift {
+		healthyGauge.Update(0)
+		log.Warn("System clock drift exceeds the tolerated header drift", "drift", common.PrettyDuration(drift), "max", common.PrettyDuration(m.config.MaxDrift))
+		return drift, false, nil
+	}
+	healthyGauge.Update(1)
+	log.Debug("NTP clock check done", "drift", common.PrettyDuration(drift))
+	return drift, true, nil
+}
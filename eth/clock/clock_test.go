@@ -0,0 +1,48 @@
+package clock
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMonitorCheck(t *testing.T) {
+	var (
+		m      = NewMonitor(Config{MaxDrift: time.Second, NTPServer: "ntp.test"})
+		drift  time.Duration
+		fail   error
+		server string
+	)
+	m.drift = func(s string, measurements int) (time.Duration, error) {
+		server = s
+		return drift, fail
+	}
+	tests := []struct {
+		drift   time.Duration
+		err     error
+		healthy bool
+	}{
+		{300 * time.Millisecond, nil, true},
+		{-1500 * time.Millisecond, nil, false},
+		{-time.Second, nil, true},
+		{2 * time.Second, nil, false},
+		{0, errors.New("timeout"), false},
+	}
+	for i, tt := range tests {
+		drift, fail = tt.drift, tt.err
+
+		have, healthy, err := m.check()
+		if server != "ntp.test" {
+			t.Fatalf("test %d: server mismatch: have %q", i, server)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if err == nil && have != tt.drift {
+			t.Errorf("test %d: drift mismatch: have %v, want %v", i, have, tt.drift)
+		}
+		if healthy != tt.healthy {
+			t.Errorf("test %d: health mismatch: have %v, want %v", i, healthy, tt.healthy)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractCreation is the creation of a contract, by a transaction or by
+// another contract.
+type ContractCreation struct {
+	Address      common.Address `json:"address"`
+	Creator      common.Address `json:"creator"` // Sender of the creation transaction, or the creating contract
+	TxHash       common.Hash    `json:"transactionHash"`
+	BlockNumber  hexutil.Uint64 `json:"blockNumber"`
+	BlockHash    common.Hash    `json:"blockHash"`
+	InitCodeHash common.Hash    `json:"initCodeHash"`
+}
+
+// GetContractCreation returns the creation of the contract by the canonical
+// chain, the latest one if it was created again after destructing itself, or
+// null if it isn't recorded. The creations are recorded as the blocks are
+// imported: the ones by contracts, CREATE2 included, are read from the internal
+// transactions, so the node must run with --traceaction=2 for them to be
+// recorded, and the genesis contracts have no creation.
+func (api *NeroAPI) GetContractCreation(address common.Address) (*ContractCreation, error) {
+	creation := api.eth.blockchain.ContractCreation(address)
+	if creation == nil {
+		return nil, nil
+	}
+	return &ContractCreation{
+		Address:      address,
+		Creator:      creation.Creator,
+		TxHash:       creation.TxHash,
+		BlockNumber:  hexutil.Uint64(creation.Number),
+		BlockHash:    creation.BlockHash,
+		InitCodeHash: creation.InitCodeHash,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package eth
+
+import (
+	"cmp"
+	"errors"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// topValidators is the number of validators sealing the most blocks whose share
+// of the blocks is reported.
+const topValidators = 3
+
+var (
+	activeValidatorsGauge = metrics.NewRegisteredGauge("nero/validators/active", nil)
+	totalStakeGauge       = metrics.NewRegisteredGauge("nero/validators/stake/total", nil)  // In ether
+	medianStakeGauge      = metrics.NewRegisteredGauge("nero/validators/stake/median", nil) // In ether
+	nakamotoGauge         = metrics.NewRegisteredGauge("nero/validators/nakamoto", nil)
+	topBlockShareGauge    = metrics.NewRegisteredGaugeFloat64("nero/validators/blockshare/top", nil)
+)
+
+// DecentralizationStats measures how the validation of a Turbo epoch is spread
+// among the validators.
+type DecentralizationStats struct {
+	Epoch               hexutil.Uint64 `json:"epoch"`
+	FirstBlock          hexutil.Uint64 `json:"firstBlock"`
+	LastBlock           hexutil.Uint64 `json:"lastBlock"`
+	Validators          hexutil.Uint64 `json:"validators"`
+	TotalStake          *hexutil.Big   `json:"totalStake"`          // Stake of the validators, null if unknown
+	MedianStake         *hexutil.Big   `json:"medianStake"`         // Null if the stakes are unknown
+	NakamotoCoefficient hexutil.Uint64 `json:"nakamotoCoefficient"` // Fewest validators holding over a third of the stake, 0 if unknown
+	TopBlockShare       float64        `json:"topBlockShare"`       // Share of the blocks sealed by the top validators
+}
+
+// GetDecentralizationStats returns the validator count, stake distribution and
+// block production concentration of the given Turbo epoch, the last summarized
+// one if omitted. The Nakamoto coefficient is the number of validators which
+// would have to collude to hold over a third of the stake, enough to stall the
+// finality of the chain, and the top block share the share of the blocks of the
+// epoch sealed by the three most productive validators.
+func (api *NeroAPI) GetDecentralizationStats(epoch *hexutil.Uint64) (*DecentralizationStats, error) {
+	var number uint64
+	if epoch != nil {
+		number = uint64(*epoch)
+	} else if indexer := api.eth.epochSummaryIndexer; indexer != nil {
+		epochs := summarizedEpochs(indexer, api.eth.blockchain.Config().Turbo)
+		if epochs == 0 {
+			return nil, errors.New("no epoch summarized yet")
+		}
+		number = epochs - 1
+	}
+	summary, _, err := api.epochSummary(number)
+	if err != nil {
+		return nil, err
+	}
+	return decentralizationStats(summary, sealedBlocks(api.eth.blockchain, summary.FirstBlock, summary.LastBlock)), nil
+}
+
+// sealedBlocks counts the canonical blocks of the range sealed by each signer.
+func sealedBlocks(chain *core.BlockChain, first, last uint64) map[common.Address]uint64 {
+	blocks := make(map[common.Address]uint64)
+	for number := first; number <= last; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			continue
+		}
+		if signer, err := chain.Engine().Author(header); err == nil {
+			blocks[signer]++
+		}
+	}
+	return blocks
+}
+
+// decentralizationStats computes the decentralization of the summarized epoch,
+// given the blocks sealed by each signer.
+func decentralizationStats(summary *rawdb.EpochSummary, blocks map[common.Address]uint64) *DecentralizationStats {
+	stats := &DecentralizationStats{
+		Epoch:      hexutil.Uint64(summary.Epoch),
+		FirstBlock: hexutil.Uint64(summary.FirstBlock),
+		LastBlock:  hexutil.Uint64(summary.LastBlock),
+		Validators: hexutil.Uint64(len(summary.Validators)),
+	}
+	if len(summary.Stakes) == len(summary.Validators) && len(summary.Stakes) > 0 {
+		var (
+			stakes = slices.Clone(summary.Stakes)
+			total  = new(big.Int)
+		)
+		slices.SortFunc(stakes, func(a, b *big.Int) int { return b.Cmp(a) })
+		for _, stake := range stakes {
+			total.Add(total, stake)
+		}
+		median := new(big.Int).Set(stakes[len(stakes)/2])
+		if len(stakes)%2 == 0 {
+			median.Add(median, stakes[len(stakes)/2-1]).Rsh(median, 1)
+		}
+		stats.TotalStake, stats.MedianStake = (*hexutil.Big)(total), (*hexutil.Big)(median)
+
+		held := new(big.Int)
+		for i, stake := range stakes {
+			if total.Sign() == 0 {
+				break
+			}
+			held.Add(held, stake)
+			if new(big.Int).Mul(held, big.NewInt(3)).Cmp(total) > 0 {
+				stats.NakamotoCoefficient = hexutil.Uint64(i + 1)
+				break
+			}
+		}
+	}
+	counts := make([]uint64, 0, len(blocks))
+	for _, count := range blocks {
+		counts = append(counts, count)
+	}
+	slices.SortFunc(counts, func(a, b uint64) int { return cmp.Compare(b, a) })
+	var top uint64
+	for i := 0; i < len(counts) && i < topValidators; i++ {
+		top += counts[i]
+	}
+	if sealed := summary.LastBlock - summary.FirstBlock + 1; sealed > 0 {
+		stats.TopBlockShare = float64(top) / float64(sealed)
+	}
+	return stats
+}
+
+// updateDecentralizationMetrics reports the decentralization of the last
+// summarized epoch.
+func updateDecentralizationMetrics(stats *DecentralizationStats) {
+	activeValidatorsGauge.Update(int64(stats.Validators))
+	if stats.TotalStake != nil {
+		ether := big.NewInt(params.Ether)
+		totalStakeGauge.Update(new(big.Int).Div(stats.TotalStake.ToInt(), ether).Int64())
+		medianStakeGauge.Update(new(big.Int).Div(stats.MedianStake.ToInt(), ether).Int64())
+	}
+	nakamotoGauge.Update(int64(stats.NakamotoCoefficient))
+	topBlockShareGauge.Update(stats.TopBlockShare)
+}
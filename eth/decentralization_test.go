@@ -0,0 +1,39 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestDecentralizationStats(t *testing.T) {
+	validators := []common.Address{{0x01}, {0x02}, {0x03}, {0x04}}
+	summary := &rawdb.EpochSummary{
+		Epoch:      2,
+		FirstBlock: 400,
+		LastBlock:  599,
+		Validators: validators,
+		Stakes:     []*big.Int{big.NewInt(100), big.NewInt(330), big.NewInt(250), big.NewInt(320)},
+	}
+	blocks := map[common.Address]uint64{validators[0]: 20, validators[1]: 80, validators[2]: 60, validators[3]: 40}
+
+	stats := decentralizationStats(summary, blocks)
+	if stats.Validators != 4 || stats.TotalStake.ToInt().Int64() != 1000 || stats.MedianStake.ToInt().Int64() != 285 {
+		t.Errorf("stake distribution mismatch: %+v", stats)
+	}
+	// 330 doesn't exceed a third of 1000, 330+320 does
+	if stats.NakamotoCoefficient != 2 {
+		t.Errorf("nakamoto coefficient mismatch: have %d, want 2", stats.NakamotoCoefficient)
+	}
+	if stats.TopBlockShare != 0.9 {
+		t.Errorf("top block share mismatch: have %v, want 0.9", stats.TopBlockShare)
+	}
+	// Without the stakes, only the block production is known
+	summary.Stakes = nil
+	stats = decentralizationStats(summary, blocks)
+	if stats.TotalStake != nil || stats.MedianStake != nil || stats.NakamotoCoefficient != 0 || stats.TopBlockShare != 0.9 {
+		t.Errorf("stats without stakes mismatch: %+v", stats)
+	}
+}
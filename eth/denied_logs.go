@@ -0,0 +1,117 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/filters"
+)
+
+// deniedLogsReexec is the maximum number of blocks re-executed to regenerate
+// the state of a replayed block.
+const deniedLogsReexec = 128
+
+// deniedLogRecorder wraps an access filter, recording the logs it denies.
+type deniedLogRecorder struct {
+	vm.EvmAccessFilter
+	logs []*filters.DeniedLog
+}
+
+// IsLogDenied implements vm.EvmAccessFilter.
+func (r *deniedLogRecorder) IsLogDenied(log *types.Log) bool {
+	if !r.EvmAccessFilter.IsLogDenied(log) {
+		return false
+	}
+	var rule string
+	if reporter, ok := r.EvmAccessFilter.(vm.LogRuleReporter); ok {
+		rule = reporter.DeniedLogRule(log)
+	}
+	r.logs = append(r.logs, &filters.DeniedLog{Log: log, Rule: rule})
+	return true
+}
+
+// deniedLogs replays the block with a recording access filter, returning the
+// logs denied during its execution. A denied log fails the call frame emitting
+// it, which its callers may recover from, so all the transactions are replayed.
+// The denied logs take the index of the first log of their transaction.
+func (eth *Ethereum) deniedLogs(ctx context.Context, block *types.Block, reexec uint64) ([]*filters.DeniedLog, error) {
+	if !eth.isTurboEngine || block.NumberU64() == 0 || len(block.Transactions()) == 0 {
+		return nil, nil
+	}
+	receipts := eth.blockchain.GetReceiptsByHash(block.Hash())
+	if len(receipts) != len(block.Transactions()) {
+		return nil, fmt.Errorf("receipts of block %#x not found", block.Hash())
+	}
+	parent := eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, release, err := eth.stateAtBlock(ctx, parent, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	header := block.Header()
+	if err := eth.turboEngine.PreHandle(eth.blockchain, header, statedb); err != nil {
+		return nil, err
+	}
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		context := core.NewEVMBlockContext(header, eth.blockchain, nil)
+		vmenv := vm.NewEVM(context, vm.TxContext{}, statedb, eth.blockchain.Config(), vm.Config{})
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
+	var (
+		signer   = types.MakeSigner(eth.blockchain.Config(), block.Number(), block.Time())
+		recorder = &deniedLogRecorder{EvmAccessFilter: eth.turboEngine.CreateEvmAccessFilter(header, statedb)}
+		denied   []*filters.DeniedLog
+		logIndex uint
+	)
+	for idx, tx := range block.Transactions() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
+		context := core.NewEVMBlockContext(header, eth.blockchain, nil)
+		context.AccessFilter = recorder
+		vmenv := vm.NewEVM(context, core.NewEVMTxContext(msg), statedb, eth.blockchain.Config(), vm.Config{})
+		statedb.SetTxContext(tx.Hash(), idx)
+
+		sender, _ := types.Sender(signer, tx)
+		if eth.turboEngine.IsDoubleSignPunishTransaction(sender, tx, header) {
+			if _, _, err := eth.turboEngine.ApplyDoubleSignPunishTx(vmenv, sender, tx); err != nil {
+				return nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+			}
+		} else if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(tx.Gas())); err != nil {
+			return nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+		}
+		statedb.Finalise(vmenv.ChainConfig().IsEIP158(block.Number()))
+
+		for _, d := range recorder.logs {
+			log := *d.Log
+			log.BlockNumber = block.NumberU64()
+			log.BlockHash = block.Hash()
+			log.TxHash = tx.Hash()
+			log.TxIndex = uint(idx)
+			log.Index = logIndex
+			denied = append(denied, &filters.DeniedLog{Log: &log, Rule: d.Rule})
+		}
+		recorder.logs = nil
+		logIndex += uint(len(receipts[idx].Logs))
+	}
+	return denied, nil
+}
+
+// DeniedLogs implements filters.DeniedLogsBackend.
+func (b *EthAPIBackend) DeniedLogs(ctx context.Context, blockHash common.Hash) ([]*filters.DeniedLog, error) {
+	block := b.eth.blockchain.GetBlockByHash(blockHash)
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+	return b.eth.deniedLogs(ctx, block, deniedLogsReexec)
+}
@@ -102,6 +102,7 @@ type Downloader struct {
 	syncStatsChainOrigin uint64 // Origin block number where syncing started at
 	syncStatsChainHeight uint64 // Highest block number known when syncing started
 	syncStatsState       stateSyncStats
+	syncStatsMeter       *syncMeter   // Data downloaded per phase since the sync started
 	syncStatsLock        sync.RWMutex // Lock protecting the sync stats fields
 
 	lightchain LightChain
@@ -484,6 +485,14 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 	d.syncStatsLock.Lock()
 	if d.syncStatsChainHeight <= origin || d.syncStatsChainOrigin > origin {
 		d.syncStatsChainOrigin = origin
+
+		block := d.lightchain.CurrentHeader().Number.Uint64()
+		if mode == FullSync {
+			block = d.blockchain.CurrentBlock().Number.Uint64()
+		} else if mode == FastSync {
+			block = d.blockchain.CurrentSnapBlock().Number.Uint64()
+		}
+		d.syncStatsMeter = newSyncMeter(d.lightchain.CurrentHeader().Number.Uint64(), block)
 	}
 	d.syncStatsChainHeight = height
 	d.syncStatsLock.Unlock()
@@ -1996,6 +2005,7 @@ func (d *Downloader) DeliverSnapPacket(peer *snap.Peer, packet snap.Packet) erro
 func (d *Downloader) deliver(destCh chan dataPack, packet dataPack, inMeter, dropMeter metrics.Meter) (err error) {
 	// Update the delivery metrics for both good and failed deliveries
 	inMeter.Mark(int64(packet.Items()))
+
 	defer func() {
 		if err != nil {
 			dropMeter.Mark(int64(packet.Items()))
@@ -2010,6 +2020,11 @@ func (d *Downloader) deliver(destCh chan dataPack, packet dataPack, inMeter, dro
 	}
 	select {
 	case destCh <- packet:
+		d.syncStatsLock.RLock()
+		if d.syncStatsMeter != nil {
+			d.syncStatsMeter.mark(packet)
+		}
+		d.syncStatsLock.RUnlock()
 		return nil
 	case <-cancel:
 		return errNoSyncActive
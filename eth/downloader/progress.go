@@ -0,0 +1,279 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Names of the synchronisation phases reported by DetailedProgress.
+const (
+	PhaseHeaders  = "headers"
+	PhaseBodies   = "bodies"
+	PhaseReceipts = "receipts"
+	PhaseState    = "state"
+	PhaseHeal     = "heal"
+)
+
+// PhaseProgress is the progress of a single synchronisation phase.
+type PhaseProgress struct {
+	Name      string   `json:"name"`
+	Current   uint64   `json:"current"`       // Blocks (or state bytes) processed so far
+	Target    uint64   `json:"target"`        // Blocks (or estimated state bytes) to process in total
+	Pending   uint64   `json:"pending"`       // Items scheduled for retrieval but not yet delivered
+	Percent   float64  `json:"percent"`       // Completion of the phase
+	Done      bool     `json:"done"`          // Whether the phase is complete
+	Bytes     uint64   `json:"bytes"`         // Bytes downloaded in this phase since the sync started
+	Bandwidth float64  `json:"bandwidth"`     // Average download rate of the phase in bytes per second
+	ETA       *float64 `json:"eta,omitempty"` // Estimated seconds left, nil if unknown
+}
+
+// SyncDetails is a detailed report of the synchronisation progress.
+type SyncDetails struct {
+	Mode          string          `json:"mode"`
+	Syncing       bool            `json:"syncing"`
+	StartingBlock uint64          `json:"startingBlock"`
+	CurrentBlock  uint64          `json:"currentBlock"`
+	HighestBlock  uint64          `json:"highestBlock"`
+	Elapsed       float64         `json:"elapsed"`              // Seconds since the sync started
+	Phases        []PhaseProgress `json:"phases"`               // Progress of the phases of the current mode
+	Bottleneck    string          `json:"bottleneck,omitempty"` // Phase expected to finish last
+	Bandwidth     float64         `json:"bandwidth"`            // Average total download rate in bytes per second
+	ETA           *float64        `json:"eta,omitempty"`        // Estimated seconds until the sync completes, nil if unknown
+}
+
+// syncMeter accumulates the data downloaded per phase since the sync started,
+// along with the chain positions the sync started at to derive rates from.
+type syncMeter struct {
+	start       time.Time
+	startHeader uint64 // Local head header when the sync started
+	startBlock  uint64 // Local head (snap) block when the sync started
+
+	headerBytes  atomic.Uint64
+	bodyBytes    atomic.Uint64
+	receiptBytes atomic.Uint64
+	stateBytes   atomic.Uint64
+}
+
+// newSyncMeter creates a meter for a sync starting at the given chain positions.
+func newSyncMeter(header, block uint64) *syncMeter {
+	return &syncMeter{
+		start:       time.Now(),
+		startHeader: header,
+		startBlock:  block,
+	}
+}
+
+// mark accounts the size of an accepted packet to its phase. The packet is not
+// validated yet, so the malformed headers are skipped.
+func (m *syncMeter) mark(packet dataPack) {
+	switch packet := packet.(type) {
+	case *headerPack:
+		var size uint64
+		for _, header := range packet.headers {
+			if header == nil || header.Number == nil || header.Difficulty == nil {
+				continue
+			}
+			size += uint64(header.Size())
+		}
+		m.headerBytes.Add(size)
+	case *bodyPack:
+		var size uint64
+		for _, txs := range packet.transactions {
+			for _, tx := range txs {
+				size += tx.Size()
+			}
+		}
+		for _, uncles := range packet.uncles {
+			for _, uncle := range uncles {
+				size += uint64(uncle.Size())
+			}
+		}
+		m.bodyBytes.Add(size)
+	case *receiptPack:
+		var size uint64
+		for _, receipts := range packet.receipts {
+			for _, receipt := range receipts {
+				size += uint64(receipt.Size())
+			}
+		}
+		m.receiptBytes.Add(size)
+	case *statePack:
+		var size uint64
+		for _, state := range packet.states {
+			size += uint64(len(state))
+		}
+		m.stateBytes.Add(size)
+	}
+}
+
+// blockPhase assembles the progress of a phase advancing block by block.
+func blockPhase(name string, start, current, target, pending, bytes uint64, elapsed float64) PhaseProgress {
+	phase := PhaseProgress{
+		Name:    name,
+		Current: current,
+		Target:  target,
+		Pending: pending,
+		Bytes:   bytes,
+		Done:    current >= target,
+	}
+	if target > 0 {
+		phase.Percent = float64(min(current, target)) * 100 / float64(target)
+	}
+	if elapsed > 0 {
+		phase.Bandwidth = float64(bytes) / elapsed
+	}
+	switch {
+	case phase.Done:
+		phase.Percent, phase.ETA = 100, new(float64)
+	case current > start && elapsed > 0:
+		eta := float64(target-current) * elapsed / float64(current-start)
+		phase.ETA = &eta
+	}
+	return phase
+}
+
+// DetailedProgress reports the progress of every phase of the current sync
+// cycle, along with the download bandwidth, an estimation of the time left and
+// the phase expected to finish last.
+//
+// Rates are averaged over the whole sync, so the estimations are rough at the
+// start of a sync and whenever the network conditions change.
+func (d *Downloader) DetailedProgress() *SyncDetails {
+	progress := d.Progress()
+	mode := d.getMode()
+
+	d.syncStatsLock.RLock()
+	meter := d.syncStatsMeter
+	d.syncStatsLock.RUnlock()
+
+	details := &SyncDetails{
+		Mode:          mode.String(),
+		Syncing:       d.Synchronising(),
+		StartingBlock: progress.StartingBlock,
+		CurrentBlock:  progress.CurrentBlock,
+		HighestBlock:  progress.HighestBlock,
+		Phases:        []PhaseProgress{},
+	}
+	if mode == FastSync && d.snapSync {
+		details.Mode = SnapSync.String()
+	}
+	if meter == nil {
+		return details
+	}
+	elapsed := time.Since(meter.start).Seconds()
+	details.Elapsed = elapsed
+
+	header := d.lightchain.CurrentHeader().Number.Uint64()
+	details.Phases = append(details.Phases, blockPhase(PhaseHeaders, meter.startHeader, header, progress.HighestBlock,
+		uint64(d.queue.PendingHeaders()), meter.headerBytes.Load(), elapsed))
+
+	if mode != LightSync && d.blockchain != nil {
+		details.Phases = append(details.Phases, blockPhase(PhaseBodies, meter.startBlock, progress.CurrentBlock, progress.HighestBlock,
+			uint64(d.queue.PendingBlocks()), meter.bodyBytes.Load(), elapsed))
+	}
+	if mode == FastSync && d.blockchain != nil {
+		details.Phases = append(details.Phases, blockPhase(PhaseReceipts, meter.startBlock, progress.CurrentBlock, progress.HighestBlock,
+			uint64(d.queue.PendingReceipts()), meter.receiptBytes.Load(), elapsed))
+		details.Phases = append(details.Phases, d.statePhases(meter, elapsed)...)
+	}
+	// The sync completes with its slowest phase. Healing has no estimation as
+	// the amount of state to heal is only discovered along the way, it's not
+	// accounted for in the total estimation.
+	var (
+		eta     float64
+		unknown bool
+	)
+	for _, phase := range details.Phases {
+		details.Bandwidth += phase.Bandwidth
+		if phase.Done {
+			continue
+		}
+		if phase.ETA == nil {
+			if details.Bottleneck == "" {
+				details.Bottleneck = phase.Name
+			}
+			unknown = unknown || phase.Name != PhaseHeal
+			continue
+		}
+		if *phase.ETA >= eta {
+			details.Bottleneck, eta = phase.Name, *phase.ETA
+		}
+	}
+	if !unknown {
+		details.ETA = &eta
+	}
+	return details
+}
+
+// statePhases reports the progress of the state retrieval and healing.
+func (d *Downloader) statePhases(meter *syncMeter, elapsed float64) []PhaseProgress {
+	state := PhaseProgress{Name: PhaseState}
+	heal := PhaseProgress{Name: PhaseHeal}
+
+	if !d.snapSync {
+		// Trie node based state sync, the total number of nodes is only
+		// discovered along the way.
+		d.syncStatsLock.RLock()
+		stats := d.syncStatsState
+		d.syncStatsLock.RUnlock()
+
+		state.Current, state.Pending = stats.processed, stats.pending
+		state.Target = stats.processed + stats.pending
+		state.Bytes = meter.stateBytes.Load()
+		state.Done = stats.pending == 0 && stats.processed > 0
+		if state.Target > 0 {
+			state.Percent = float64(state.Current) * 100 / float64(state.Target)
+		}
+		if elapsed > 0 {
+			state.Bandwidth = float64(state.Bytes) / elapsed
+		}
+		return []PhaseProgress{state}
+	}
+	progress, pending := d.SnapSyncer.Progress()
+	fill, snapped := d.SnapSyncer.AccountFill()
+
+	synced := uint64(progress.AccountBytes + progress.BytecodeBytes + progress.StorageBytes)
+	state.Current, state.Bytes, state.Done = synced, synced, snapped
+	if elapsed > 0 {
+		state.Bandwidth = float64(synced) / elapsed
+	}
+	switch {
+	case snapped:
+		state.Target, state.Percent, state.ETA = synced, 100, new(float64)
+	case fill > 0:
+		state.Target = uint64(float64(synced) / fill)
+		state.Percent = fill * 100
+		eta := elapsed * (1 - fill) / fill
+		state.ETA = &eta
+	}
+	heal.Current = progress.TrienodeHealSynced + progress.BytecodeHealSynced
+	heal.Pending = pending.TrienodeHeal + pending.BytecodeHeal
+	heal.Bytes = uint64(progress.TrienodeHealBytes + progress.BytecodeHealBytes)
+	if elapsed > 0 {
+		heal.Bandwidth = float64(heal.Bytes) / elapsed
+	}
+	// Healing has no known target, it's done once nothing is pending after the
+	// state retrieval completed.
+	heal.Target = heal.Current + heal.Pending
+	heal.Done = snapped && heal.Pending == 0 && heal.Current > 0
+	if heal.Target > 0 {
+		heal.Percent = float64(heal.Current) * 100 / float64(heal.Target)
+	}
+	return []PhaseProgress{state, heal}
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestBlockPhase(t *testing.T) {
+	// Half way through after 10 seconds, starting from block 100
+	phase := blockPhase(PhaseBodies, 100, 150, 200, 8, 5000, 10)
+	if phase.Done {
+		t.Fatal("phase reported done")
+	}
+	if phase.Percent != 75 {
+		t.Errorf("percent mismatch: have %v, want 75", phase.Percent)
+	}
+	if phase.Bandwidth != 500 {
+		t.Errorf("bandwidth mismatch: have %v, want 500", phase.Bandwidth)
+	}
+	if phase.ETA == nil || *phase.ETA != 10 {
+		t.Errorf("eta mismatch: have %v, want 10", phase.ETA)
+	}
+	// No progress yet, the rate is unknown
+	if phase := blockPhase(PhaseBodies, 100, 100, 200, 0, 0, 10); phase.ETA != nil {
+		t.Errorf("eta without progress: have %v, want nil", *phase.ETA)
+	}
+	// Completed phase
+	phase = blockPhase(PhaseHeaders, 100, 200, 200, 0, 5000, 10)
+	if !phase.Done || phase.Percent != 100 || phase.ETA == nil || *phase.ETA != 0 {
+		t.Errorf("completed phase mismatch: %+v", phase)
+	}
+}
+
+// Tests that the malformed headers of a packet are not metered, instead of
+// crashing the delivery.
+func TestMeterMalformedHeaders(t *testing.T) {
+	header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(1)}
+	meter := newSyncMeter(0, 0)
+	meter.mark(&headerPack{headers: []*types.Header{{}, nil, header}})
+	if have, want := meter.headerBytes.Load(), uint64(header.Size()); have != want {
+		t.Fatalf("header bytes mismatch: have %d, want %d", have, want)
+	}
+}
@@ -0,0 +1,127 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// maxEarningsEpochs is the maximum number of epochs of an earnings statement.
+const maxEarningsEpochs = 4096
+
+// ValidatorEarnings is the earnings statement of a validator over a range of
+// Turbo epochs.
+type ValidatorEarnings struct {
+	Validator    common.Address   `json:"validator"`
+	FromEpoch    hexutil.Uint64   `json:"fromEpoch"`
+	ToEpoch      hexutil.Uint64   `json:"toEpoch"`
+	Epochs       []*EpochEarnings `json:"epochs"`
+	BlockRewards *hexutil.Big     `json:"blockRewards"`
+	FeeRewards   *hexutil.Big     `json:"feeRewards"`
+	Slashed      *hexutil.Big     `json:"slashed"`
+	Net          *hexutil.Big     `json:"net"`
+}
+
+// EpochEarnings is the line of an earnings statement covering one epoch.
+type EpochEarnings struct {
+	Epoch        hexutil.Uint64 `json:"epoch"`
+	FirstBlock   hexutil.Uint64 `json:"firstBlock"`
+	LastBlock    hexutil.Uint64 `json:"lastBlock"`
+	Active       bool           `json:"active"`
+	Stake        *hexutil.Big   `json:"stake"`
+	BlockRewards *hexutil.Big   `json:"blockRewards"`
+	FeeRewards   *hexutil.Big   `json:"feeRewards"`
+	Slashed      *hexutil.Big   `json:"slashed"`
+	Net          *hexutil.Big   `json:"net"`
+}
+
+// GetValidatorEarnings returns the earnings statement of the validator over the
+// given epoch range (both included), from the epoch summaries:
+//
+//   - the block rewards are the share of the staking rewards released in the
+//     epoch proportional to the stake of the validator, delegations included,
+//     zero if the stakes of the epoch are unknown
+//   - the fee rewards are the share of the transaction fees distributed by the
+//     engine to the active validators through the Staking contract
+//   - the slashed amount is the stake deducted by the punishments executed in
+//     transactions, as reported by the Staking contract events
+func (api *NeroAPI) GetValidatorEarnings(validator common.Address, fromEpoch, toEpoch hexutil.Uint64) (*ValidatorEarnings, error) {
+	if fromEpoch > toEpoch {
+		return nil, errors.New("fromEpoch is above toEpoch")
+	}
+	if toEpoch-fromEpoch >= maxEarningsEpochs {
+		return nil, fmt.Errorf("epoch range too large, the maximum is %d", maxEarningsEpochs)
+	}
+	var (
+		blockRewards = new(big.Int)
+		feeRewards   = new(big.Int)
+		slashed      = new(big.Int)
+		epochs       = make([]*EpochEarnings, 0, toEpoch-fromEpoch+1)
+	)
+	for epoch := uint64(fromEpoch); epoch <= uint64(toEpoch); epoch++ {
+		summary, _, err := api.epochSummary(epoch)
+		if err != nil {
+			return nil, err
+		}
+		earnings := epochEarnings(summary, validator)
+		blockRewards.Add(blockRewards, earnings.BlockRewards.ToInt())
+		feeRewards.Add(feeRewards, earnings.FeeRewards.ToInt())
+		slashed.Add(slashed, earnings.Slashed.ToInt())
+		epochs = append(epochs, earnings)
+	}
+	net := new(big.Int).Add(blockRewards, feeRewards)
+	return &ValidatorEarnings{
+		Validator:    validator,
+		FromEpoch:    fromEpoch,
+		ToEpoch:      toEpoch,
+		Epochs:       epochs,
+		BlockRewards: (*hexutil.Big)(blockRewards),
+		FeeRewards:   (*hexutil.Big)(feeRewards),
+		Slashed:      (*hexutil.Big)(slashed),
+		Net:          (*hexutil.Big)(net.Sub(net, slashed)),
+	}, nil
+}
+
+// epochEarnings computes the earnings of the validator in the summarized epoch.
+func epochEarnings(summary *rawdb.EpochSummary, validator common.Address) *EpochEarnings {
+	var (
+		blockRewards = new(big.Int)
+		feeRewards   = new(big.Int)
+		slashed      = new(big.Int)
+		earnings     = &EpochEarnings{
+			Epoch:      hexutil.Uint64(summary.Epoch),
+			FirstBlock: hexutil.Uint64(summary.FirstBlock),
+			LastBlock:  hexutil.Uint64(summary.LastBlock),
+		}
+	)
+	for i, active := range summary.Validators {
+		if active != validator {
+			continue
+		}
+		earnings.Active = true
+		feeRewards.Div(summary.FeeRewards, big.NewInt(int64(len(summary.Validators))))
+
+		if i < len(summary.Stakes) && summary.TotalStake != nil && summary.TotalStake.Sign() > 0 {
+			stake := summary.Stakes[i]
+			earnings.Stake = (*hexutil.Big)(new(big.Int).Set(stake))
+			blockRewards.Mul(summary.StakingRewards, stake)
+			blockRewards.Div(blockRewards, summary.TotalStake)
+		}
+		break
+	}
+	for _, punish := range summary.Punishes {
+		if punish.Validator == validator && punish.Deduction != nil {
+			slashed.Add(slashed, punish.Deduction)
+		}
+	}
+	net := new(big.Int).Add(blockRewards, feeRewards)
+	earnings.BlockRewards = (*hexutil.Big)(blockRewards)
+	earnings.FeeRewards = (*hexutil.Big)(feeRewards)
+	earnings.Slashed = (*hexutil.Big)(slashed)
+	earnings.Net = (*hexutil.Big)(net.Sub(net, slashed))
+	return earnings
+}
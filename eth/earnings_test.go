@@ -0,0 +1,61 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestEpochEarnings(t *testing.T) {
+	var (
+		alice = common.Address{0x01}
+		bob   = common.Address{0x02}
+		carol = common.Address{0x03}
+	)
+	summary := &rawdb.EpochSummary{
+		Epoch:          3,
+		FirstBlock:     600,
+		LastBlock:      799,
+		Validators:     []common.Address{alice, bob},
+		FeeRewards:     big.NewInt(1000),
+		Punishes:       []rawdb.EpochPunish{{Number: 700, Validator: bob, Event: 7, Deduction: big.NewInt(150)}},
+		TotalStake:     big.NewInt(4000),
+		StakingRewards: big.NewInt(2000),
+		Stakes:         []*big.Int{big.NewInt(3000), big.NewInt(1000)},
+	}
+	tests := []struct {
+		validator                              common.Address
+		active                                 bool
+		blockRewards, feeRewards, slashed, net int64
+	}{
+		{alice, true, 1500, 500, 0, 2000},
+		{bob, true, 500, 500, 150, 850},
+		{carol, false, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		have := epochEarnings(summary, tt.validator)
+		if have.Active != tt.active {
+			t.Errorf("%x: active mismatch: have %v, want %v", tt.validator, have.Active, tt.active)
+		}
+		for _, amount := range []struct {
+			name       string
+			have, want int64
+		}{
+			{"block rewards", have.BlockRewards.ToInt().Int64(), tt.blockRewards},
+			{"fee rewards", have.FeeRewards.ToInt().Int64(), tt.feeRewards},
+			{"slashed", have.Slashed.ToInt().Int64(), tt.slashed},
+			{"net", have.Net.ToInt().Int64(), tt.net},
+		} {
+			if amount.have != amount.want {
+				t.Errorf("%x: %s mismatch: have %d, want %d", tt.validator, amount.name, amount.have, amount.want)
+			}
+		}
+	}
+	// Without the stakes, only the fees are known.
+	summary.TotalStake, summary.StakingRewards, summary.Stakes = nil, nil, nil
+	if have := epochEarnings(summary, alice); have.Stake != nil || have.BlockRewards.ToInt().Sign() != 0 || have.FeeRewards.ToInt().Int64() != 500 {
+		t.Errorf("earnings without stakes mismatch: %+v", have)
+	}
+}
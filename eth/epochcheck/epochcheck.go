@@ -0,0 +1,173 @@
+// Package epochcheck cross-checks the validator sets recorded at the Turbo
+// epoch boundaries.
+//
+// The validator set of an epoch is recorded in the extra-data of its epoch
+// block, as computed by the Staking contract at the state of the parent block.
+// The imported blocks are verified against the contract already, but not the
+// blocks of a snap sync nor the locally sealed ones. The checker computes the
+// set again from the parent state of each new epoch block, and raises an alert
+// on mismatch, an early warning that the local state or the system contracts
+// diverged from the rest of the network.
+package epochcheck
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/turbo"
+	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const chainHeadChanSize = 10
+
+var (
+	checkedMeter  = metrics.NewRegisteredMeter("turbo/epochcheck/checked", nil)
+	skippedMeter  = metrics.NewRegisteredMeter("turbo/epochcheck/skipped", nil) // Parent state unavailable
+	mismatchMeter = metrics.NewRegisteredMeter("turbo/epochcheck/mismatch", nil)
+)
+
+// Chain is the blockchain whose epoch blocks are checked.
+type Chain interface {
+	Config() *params.ChainConfig
+	CurrentBlock() *types.Header
+	GetHeader(hash common.Hash, number uint64) *types.Header
+	GetHeaderByNumber(number uint64) *types.Header
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// topValidatorsFn computes the validator set elected at the state of the given
+// block.
+type topValidatorsFn func(parent *types.Header) ([]common.Address, error)
+
+// Checker verifies the validator set of the new epoch blocks as the chain
+// progresses.
+type Checker struct {
+	chain         Chain
+	config        *params.TurboConfig
+	topValidators topValidatorsFn
+	next          uint64 // Next block to check
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a checker of the epoch blocks of the chain, electing the
+// validators with the Staking contract.
+func New(chain *core.BlockChain) *Checker {
+	return newChecker(chain, func(parent *types.Header) ([]common.Address, error) {
+		statedb, err := chain.StateAt(parent.Root)
+		if err != nil {
+			return nil, err
+		}
+		return systemcontract.GetTopValidators(&contracts.CallContext{
+			Statedb:      statedb,
+			Header:       parent,
+			ChainContext: chain,
+			ChainConfig:  chain.Config(),
+		})
+	})
+}
+
+func newChecker(chain Chain, topValidators topValidatorsFn) *Checker {
+	return &Checker{
+		chain:         chain,
+		config:        chain.Config().Turbo,
+		topValidators: topValidators,
+		quit:          make(chan struct{}),
+	}
+}
+
+// Start launches the checks, from the last epoch block of the chain.
+func (c *Checker) Start() {
+	c.next = c.config.LastEpoch(c.chain.CurrentBlock().Number.Uint64())
+	c.wg.Add(1)
+	go c.loop()
+}
+
+// Stop terminates the checks.
+func (c *Checker) Stop() {
+	close(c.quit)
+	c.wg.Wait()
+}
+
+func (c *Checker) loop() {
+	defer c.wg.Done()
+
+	heads := make(chan core.ChainHeadEvent, chainHeadChanSize)
+	sub := c.chain.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	for {
+		c.checkUpTo(c.chain.CurrentBlock().Number.Uint64())
+
+		select {
+		case <-heads:
+		case <-sub.Err():
+			return
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// checkUpTo checks the epoch blocks not checked yet up to the given head. The
+// checks resume from the last epoch block if the chain was rewound below the
+// checked blocks.
+func (c *Checker) checkUpTo(head uint64) {
+	if head+1 < c.next {
+		c.next = c.config.LastEpoch(head)
+	}
+	for ; c.next <= head; c.next++ {
+		if c.next == 0 || !c.config.IsEpoch(c.next) {
+			continue
+		}
+		select {
+		case <-c.quit:
+			return
+		default:
+		}
+		header := c.chain.GetHeaderByNumber(c.next)
+		if header == nil {
+			return
+		}
+		if _, err := c.check(header); err != nil {
+			log.Debug("Skipped epoch validator set check", "number", c.next, "err", err)
+			skippedMeter.Mark(1)
+		}
+	}
+}
+
+// check verifies the validator set recorded in the epoch header against the
+// one elected at the parent state, raising an alert on mismatch, and returns
+// whether they match. An error is returned if the check couldn't be done.
+func (c *Checker) check(header *types.Header) (bool, error) {
+	number := header.Number.Uint64()
+	parent := c.chain.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return false, fmt.Errorf("parent %#x not found", header.ParentHash)
+	}
+	elected, err := c.topValidators(parent)
+	if err != nil {
+		return false, err
+	}
+	checkedMeter.Mark(1)
+
+	recorded := turbo.EpochValidators(header)
+	if slices.Equal(recorded, elected) {
+		log.Debug("Checked epoch validator set", "number", number, "validators", len(recorded))
+		return true, nil
+	}
+	mismatchMeter.Mark(1)
+	log.Error("Epoch validator set mismatch, the state or the system contracts diverged", log.EventKey, log.EventValidatorSetMismatch,
+		"number", number, "hash", header.Hash(), "recorded", recorded, "elected", elected)
+	return false, nil
+}
@@ -0,0 +1,103 @@
+package epochcheck
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	validatorsA = []common.Address{{0x01}, {0x02}}
+	validatorsB = []common.Address{{0x02}, {0x03}}
+)
+
+// testChain is a chain of epoch length 4, recording the given validator sets
+// in its epoch blocks.
+type testChain struct {
+	config  *params.ChainConfig
+	headers []*types.Header
+	feed    event.Feed
+}
+
+func newTestChain(n int, recorded func(number uint64) []common.Address) *testChain {
+	c := &testChain{config: &params.ChainConfig{Turbo: &params.TurboConfig{Period: 3, Epoch: 4}}}
+	for i := 0; i <= n; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), Extra: make([]byte, 32)}
+		if i > 0 {
+			header.ParentHash = c.headers[i-1].Hash()
+			if c.config.Turbo.IsEpoch(uint64(i)) {
+				for _, validator := range recorded(uint64(i)) {
+					header.Extra = append(header.Extra, validator.Bytes()...)
+				}
+			}
+		}
+		header.Extra = append(header.Extra, make([]byte, 65)...)
+		c.headers = append(c.headers, header)
+	}
+	return c
+}
+
+func (c *testChain) Config() *params.ChainConfig { return c.config }
+func (c *testChain) CurrentBlock() *types.Header { return c.headers[len(c.headers)-1] }
+
+func (c *testChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.GetHeaderByNumber(number); header != nil && header.Hash() == hash {
+		return header
+	}
+	return nil
+}
+
+func (c *testChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.headers)) {
+		return nil
+	}
+	return c.headers[number]
+}
+
+func (c *testChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+func TestCheck(t *testing.T) {
+	// The contract elects A until block 6 and B afterwards, while the chain
+	// records A at every epoch block
+	chain := newTestChain(13, func(uint64) []common.Address { return validatorsA })
+	var checked []uint64
+	c := newChecker(chain, func(parent *types.Header) ([]common.Address, error) {
+		checked = append(checked, parent.Number.Uint64()+1)
+		if parent.Number.Uint64() == 10 {
+			return nil, errors.New("state unavailable")
+		}
+		if parent.Number.Uint64() >= 6 {
+			return validatorsB, nil
+		}
+		return validatorsA, nil
+	})
+	for number, want := range map[uint64]bool{4: true, 8: false} {
+		if match, err := c.check(chain.headers[number]); err != nil || match != want {
+			t.Errorf("block %d: check mismatch: have %v (%v), want %v", number, match, err, want)
+		}
+	}
+	if _, err := c.check(chain.headers[11]); err == nil {
+		t.Errorf("block 11: check without state succeeded")
+	}
+	// The checks resume from the last epoch block, and again after a rewind
+	checked = nil
+	c.next = c.config.LastEpoch(5)
+	c.checkUpTo(13)
+	if want := []uint64{4, 8, 12}; !slices.Equal(checked, want) || c.next != 14 {
+		t.Errorf("checked blocks mismatch: have %v (next %d), want %v", checked, c.next, want)
+	}
+	checked = nil
+	c.checkUpTo(9)
+	if want := []uint64{8}; !slices.Equal(checked, want) || c.next != 10 {
+		t.Errorf("rewound checks mismatch: have %v (next %d), want %v", checked, c.next, want)
+	}
+}
@@ -0,0 +1,225 @@
+package eth
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/turbo"
+	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
+	"github.com/ethereum/go-ethereum/contracts"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// epochSummaryConfirms is the number of confirmation blocks before an epoch
+	// is summarized. It's kept low for the state of the last block of the epoch
+	// to still be available when the summary is written.
+	epochSummaryConfirms = 16
+
+	// epochSummaryThrottling is the time to wait between summarizing two
+	// consecutive epochs.
+	epochSummaryThrottling = 100 * time.Millisecond
+)
+
+// epochSummarySectionKey tracks the section size of the epoch summary indexer,
+// in its data table.
+var epochSummarySectionKey = []byte("sectionSize")
+
+// epochSummaryIndexer implements a core.ChainIndexer recording the validator
+// set, stake, rewards and punishes of each Turbo epoch so that they don't have
+// to be reconstructed by replaying the system contract calls.
+//
+// As the epoch length can change with the Turbo schedule, the sections are the
+// ranges of blocks aligned on all the epoch boundaries. An epoch spans one or
+// more consecutive sections, and is summarized along with its last section.
+type epochSummaryIndexer struct {
+	db     ethdb.Database
+	chain  *core.BlockChain
+	config *params.TurboConfig
+	params *systemcontract.ParamsReader
+	size   uint64 // Section size of the indexer
+
+	summary *rawdb.EpochSummary
+	head    *types.Header
+	next    uint64 // Next block of the epoch to summarize
+}
+
+// newEpochSummaryIndexer returns a chain indexer that summarizes the epochs of
+// the canonical chain.
+func newEpochSummaryIndexer(db ethdb.Database, chain *core.BlockChain) *core.ChainIndexer {
+	backend := &epochSummaryIndexer{
+		db:     db,
+		chain:  chain,
+		config: chain.Config().Turbo,
+		params: systemcontract.NewParamsReader(),
+		size:   chain.Config().Turbo.EpochAlignment(),
+	}
+	table := rawdb.NewTable(db, string(rawdb.EpochSummaryIndexPrefix))
+
+	// The epochs are summarized anew if a schedule change resized the sections,
+	// which were an epoch long before being tracked.
+	size := backend.config.Epoch
+	if blob, _ := table.Get(epochSummarySectionKey); len(blob) == 8 {
+		size = binary.BigEndian.Uint64(blob)
+	}
+	if size != backend.size {
+		log.Warn("Epoch summary sections resized, summarizing the epochs again", "old", size, "new", backend.size)
+		table.Delete([]byte("count"))
+	}
+	table.Put(epochSummarySectionKey, binary.BigEndian.AppendUint64(nil, backend.size))
+
+	return core.NewChainIndexer(db, table, backend, backend.size, epochSummaryConfirms, epochSummaryThrottling, "epochsummary")
+}
+
+// summarizedEpochs returns the number of epochs summarized by the indexer.
+func summarizedEpochs(indexer *core.ChainIndexer, config *params.TurboConfig) uint64 {
+	sections, _, _ := indexer.Sections()
+	return config.EpochIndex(sections * config.EpochAlignment())
+}
+
+// Reset implements core.ChainIndexerBackend, starting the summary of a new epoch
+// at its first section. The summary of the epoch goes on at its next sections,
+// the blocks of its previous sections being summarized again if the indexer
+// restarted or rolled back in between.
+func (s *epochSummaryIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	first := section * s.size
+	if s.summary != nil && s.next == first && !s.config.IsEpoch(first) {
+		return nil
+	}
+	start := s.config.LastEpoch(first)
+	s.summary = &rawdb.EpochSummary{
+		Epoch:      s.config.EpochIndex(first),
+		Validators: []common.Address{},
+		FeeRewards: new(big.Int),
+		Punishes:   []rawdb.EpochPunish{},
+	}
+	s.head, s.next = nil, start
+
+	for number := start; number < first; number++ {
+		header := s.chain.GetHeaderByNumber(number)
+		if header == nil {
+			return fmt.Errorf("missing header %d of epoch %d", number, s.summary.Epoch)
+		}
+		if err := s.Process(ctx, header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, adding the fees and punishes of a
+// block into the summary of its epoch.
+func (s *epochSummaryIndexer) Process(ctx context.Context, header *types.Header) error {
+	number := header.Number.Uint64()
+	if s.head == nil {
+		s.summary.FirstBlock = number
+		s.summary.Validators = turbo.EpochValidators(header)
+	}
+	s.summary.LastBlock = number
+	s.head, s.next = header, number+1
+
+	receipts := rawdb.ReadReceipts(s.db, header.Hash(), number, header.Time, s.chain.Config())
+	for _, receipt := range receipts {
+		tip := new(big.Int).Set(receipt.EffectiveGasPrice)
+		if header.BaseFee != nil {
+			tip.Sub(tip, header.BaseFee)
+		}
+		if tip.Sign() > 0 {
+			s.summary.FeeRewards.Add(s.summary.FeeRewards, tip.Mul(tip, new(big.Int).SetUint64(receipt.GasUsed)))
+		}
+		if !header.Bloom.Test(system.StakingContract.Bytes()) {
+			continue
+		}
+		for _, log := range receipt.Logs {
+			if len(log.Topics) < 2 {
+				continue
+			}
+			if id, ok := system.LookupEvent(log.Address, log.Topics[0]); ok && system.Events[id].Category == system.PunishEvents {
+				validator := common.BytesToAddress(log.Topics[1].Bytes())
+				s.summary.Punishes = append(s.summary.Punishes, rawdb.EpochPunish{
+					Number:    number,
+					Validator: validator,
+					Event:     id,
+					Deduction: punishDeduction(receipt, validator),
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// Commit implements core.ChainIndexerBackend, reading the stakes at the last
+// block of the epoch and writing out the summary into the database once the
+// last section of the epoch is processed.
+func (s *epochSummaryIndexer) Commit() error {
+	if !s.config.IsEpoch(s.next) {
+		return nil
+	}
+	if statedb, err := s.chain.StateAt(s.head.Root); err == nil {
+		ctx := &contracts.CallContext{
+			Statedb:      statedb,
+			Header:       s.head,
+			ChainContext: s.chain,
+			ChainConfig:  s.chain.Config(),
+		}
+		params, err := s.params.Read(ctx)
+		if err != nil {
+			return err
+		}
+		stakes, err := systemcontract.GetValidatorStakes(ctx, s.summary.Validators)
+		if err != nil {
+			return err
+		}
+		blocks := new(big.Int).SetUint64(s.summary.LastBlock - s.summary.FirstBlock + 1)
+		s.summary.TotalStake = params.TotalStake
+		s.summary.StakingRewards = new(big.Int).Mul(params.RewardsPerBlock, blocks)
+		s.summary.Stakes = stakes
+	} else {
+		log.Debug("Epoch summarized without stake", "epoch", s.summary.Epoch, "err", err)
+	}
+	batch := s.db.NewBatch()
+	rawdb.WriteEpochSummary(batch, s.head.Hash(), s.summary)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	updateDecentralizationMetrics(decentralizationStats(s.summary, sealedBlocks(s.chain, s.summary.FirstBlock, s.summary.LastBlock)))
+	return nil
+}
+
+// punishDeduction returns the stake slashed from the validator by the punishment
+// executed in the receipt, as reported by the TotalStakeChanged event of the
+// Staking contract. Nil is returned if the stake didn't change.
+func punishDeduction(receipt *types.Receipt, validator common.Address) *big.Int {
+	event := system.ABI(system.StakingContract).Events["TotalStakeChanged"]
+	for _, log := range receipt.Logs {
+		if log.Address != system.StakingContract || len(log.Topics) < 2 || log.Topics[0] != event.ID {
+			continue
+		}
+		if common.BytesToAddress(log.Topics[1].Bytes()) != validator || len(log.Data) != 2*common.HashLength {
+			continue
+		}
+		var (
+			oldStake = new(big.Int).SetBytes(log.Data[:common.HashLength])
+			newStake = new(big.Int).SetBytes(log.Data[common.HashLength:])
+		)
+		if oldStake.Cmp(newStake) > 0 {
+			return oldStake.Sub(oldStake, newStake)
+		}
+	}
+	return nil
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (s *epochSummaryIndexer) Prune(threshold uint64) error {
+	return nil
+}
@@ -27,10 +27,15 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/consensus/turbo"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/eth/checkpoint"
+	"github.com/ethereum/go-ethereum/eth/clock"
 	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/failover"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/watchdog"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/params"
@@ -63,25 +68,34 @@ var DefaultPredictionConfig = gasprice.PredConfig{
 
 // Defaults contains default settings for use on the Ethereum main net.
 var Defaults = Config{
-	SyncMode:           downloader.SnapSync,
-	NetworkId:          0, // enable auto configuration of networkID == chainID
-	TxLookupLimit:      0,
-	TransactionHistory: 0,
-	StateHistory:       params.FullImmutabilityThreshold,
-	LightPeers:         100,
-	DatabaseCache:      512,
-	TrieCleanCache:     154,
-	TrieDirtyCache:     256,
-	TrieTimeout:        60 * time.Minute,
-	SnapshotCache:      102,
-	FilterLogCacheSize: 32,
-	Miner:              miner.DefaultConfig,
-	TxPool:             legacypool.DefaultConfig,
-	BlobPool:           blobpool.DefaultConfig,
-	RPCGasCap:          50000000,
-	RPCEVMTimeout:      5 * time.Second,
-	GPO:                FullNodeGPO,
-	RPCTxFeeCap:        1, // 1 ether
+	SyncMode:               downloader.SnapSync,
+	NetworkId:              0, // enable auto configuration of networkID == chainID
+	TxLookupLimit:          0,
+	TransactionHistory:     0,
+	StateHistory:           params.FullImmutabilityThreshold,
+	LightPeers:             100,
+	DatabaseCache:          512,
+	DatabaseVerify:         rawdb.VerifyFast,
+	TrieCleanCache:         154,
+	TrieDirtyCache:         256,
+	TrieTimeout:            60 * time.Minute,
+	SnapshotCache:          102,
+	FilterLogCacheSize:     32,
+	FilterMaxLogs:          10000,
+	BloomSectionSize:       params.BloomBitsBlocks,
+	Miner:                  miner.DefaultConfig,
+	TxPool:                 legacypool.DefaultConfig,
+	BlobPool:               blobpool.DefaultConfig,
+	RPCGasCap:              50000000,
+	RPCEVMTimeout:          5 * time.Second,
+	RPCEVMMemoryLimit:      128 * 1024 * 1024,
+	RPCCallBreakerCooldown: time.Minute,
+	GPO:                    FullNodeGPO,
+	RPCTxFeeCap:            1, // 1 ether
+	Checkpoint:             checkpoint.DefaultConfig,
+	Watchdog:               watchdog.DefaultConfig,
+	Clock:                  clock.DefaultConfig,
+	Failover:               failover.DefaultConfig,
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -110,6 +124,13 @@ type Config struct {
 	TxLookupLimit      uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 	TransactionHistory uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
 	StateHistory       uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
+	ReceiptDedup       bool   `toml:",omitempty"` // Whether to store large receipt log data deduplicated
+	TraceArchive       bool   `toml:",omitempty"` // Whether to store the full action traces of all blocks dictionary compressed
+	TokenTransferIndex bool   `toml:",omitempty"` // Whether to index the ERC-20/721/1155 token transfers per address
+	BloomSectionSize   uint64 `toml:",omitempty"` // Number of blocks of the log index (bloombits) sections, a change rebuilds the index
+	BloomRebuild       bool   `toml:",omitempty"` // Whether to rebuild the log index in the background on startup
+	BadBlockDir        string `toml:",omitempty"` // Directory to capture bad block forensic bundles into (default = <datadir>/badblocks)
+	StateExpiryEpoch   uint64 `toml:",omitempty"` // Length in blocks of the epochs the state last accesses are recorded in (0 = disabled)
 
 	// State scheme represents the scheme used to store ethereum states and trie
 	// nodes on top. It can be 'hash', 'path', or none which means use the scheme
@@ -134,16 +155,28 @@ type Config struct {
 	DatabaseHandles    int  `toml:"-"`
 	DatabaseCache      int
 	DatabaseFreezer    string
+	DatabaseVerify     string // Consistency check of the chain database at startup (off, fast or full)
 
-	TrieCleanCache int
-	TrieDirtyCache int
-	TrieTimeout    time.Duration `toml:",omitempty"`
-	SnapshotCache  int
-	Preimages      bool
+	// Schema migration options of the Nero tables, the dry run reporting the
+	// pending migrations instead of starting, the backup being called before
+	// they're applied.
+	DatabaseMigrationDryRun bool                                                           `toml:",omitempty"`
+	DatabaseMigrationBackup func(db ethdb.Database, pending []rawdb.SchemaMigration) error `toml:"-"`
+
+	TrieCleanCache    int
+	TrieCleanRPCShare int `toml:",omitempty"` // Percentage of the clean trie cache reserved to the RPC state reads (0 = shared)
+	TrieDirtyCache    int
+	TrieTimeout       time.Duration `toml:",omitempty"`
+	SnapshotCache     int
+	Preimages         bool
 
 	// This is the number of blocks for which logs will be cached in the filter system.
 	FilterLogCacheSize int
 
+	// FilterMaxLogs is the maximum number of logs returned by eth_getLogs, the
+	// larger results being paged with nero_getLogsPaged, 0 = unlimited.
+	FilterMaxLogs int
+
 	// Mining options
 	Miner miner.Config
 
@@ -170,6 +203,19 @@ type Config struct {
 	// RPCEVMTimeout is the global timeout for eth-call.
 	RPCEVMTimeout time.Duration
 
+	// RPCEVMMemoryLimit caps the EVM memory of the eth-call, gas estimation and
+	// trace executions, in bytes.
+	RPCEVMMemoryLimit uint64
+
+	// RPCTraceTimeout caps the timeout requested by the traces, 0 = uncapped.
+	RPCTraceTimeout time.Duration
+
+	// RPCCallBreakerTrips is the number of consecutive executions of eth-call
+	// variants to a contract hitting the limits after which the calls to it are
+	// refused for RPCCallBreakerCooldown, 0 = disabled.
+	RPCCallBreakerTrips    int
+	RPCCallBreakerCooldown time.Duration
+
 	// RPCTxFeeCap is the global transaction fee(price * gaslimit) cap for
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
@@ -182,6 +228,32 @@ type Config struct {
 
 	// Enable record action trace
 	TraceAction int `toml:",omitempty"`
+
+	// Finalized state checkpoint serving options
+	Checkpoint checkpoint.Config
+
+	// Chain stall watchdog options
+	Watchdog watchdog.Config
+
+	// Header timestamp drift and clock health check options
+	Clock clock.Config
+
+	// Active-passive validator failover options
+	Failover failover.Config
+
+	// NoIOThrottle keeps the snapshot generation, compactions and pruning at
+	// full speed while the local validator is in turn, see package iosched.
+	NoIOThrottle bool `toml:",omitempty"`
+
+	// HaltOverride is the block above which the validator stops sealing in
+	// place of the governed emergency halt, 0 to ignore the governed halt. The
+	// governed halt applies if nil.
+	HaltOverride *uint64 `toml:",omitempty"`
+
+	// LocalAccessList is the file of the local access list merged with the
+	// on-chain access filter for the transaction pool and RPC, see package
+	// localaccess. Empty if disabled.
+	LocalAccessList string `toml:",omitempty"`
 }
 
 // CreateConsensusEngine creates a consensus engine for the given chain config.
@@ -194,6 +266,9 @@ func CreateConsensusEngine(config *params.ChainConfig, db ethdb.Database) (conse
 	}
 	// If proof-of-stake-authority is requested, set it up
 	if config.Turbo != nil {
+		if err := turbo.CheckTxRules(config.Turbo.TxRules); err != nil {
+			return nil, err
+		}
 		return turbo.New(config, db), nil
 	}
 
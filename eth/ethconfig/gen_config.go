@@ -7,10 +7,16 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/eth/checkpoint"
+	"github.com/ethereum/go-ethereum/eth/clock"
 	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/failover"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/watchdog"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/miner"
 )
 
@@ -27,6 +33,13 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		TxLookupLimit           uint64                 `toml:",omitempty"`
 		TransactionHistory      uint64                 `toml:",omitempty"`
 		StateHistory            uint64                 `toml:",omitempty"`
+		ReceiptDedup            bool                   `toml:",omitempty"`
+		TraceArchive            bool                   `toml:",omitempty"`
+		TokenTransferIndex      bool                   `toml:",omitempty"`
+		BloomSectionSize        uint64                 `toml:",omitempty"`
+		BloomRebuild            bool                   `toml:",omitempty"`
+		StateExpiryEpoch        uint64                 `toml:",omitempty"`
+		BadBlockDir             string                 `toml:",omitempty"`
 		StateScheme             string                 `toml:",omitempty"`
 		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
 		LightServ               int                    `toml:",omitempty"`
@@ -39,12 +52,17 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		DatabaseHandles         int                    `toml:"-"`
 		DatabaseCache           int
 		DatabaseFreezer         string
+		DatabaseVerify          string
+		DatabaseMigrationDryRun bool                                                           `toml:",omitempty"`
+		DatabaseMigrationBackup func(db ethdb.Database, pending []rawdb.SchemaMigration) error `toml:"-"`
 		TrieCleanCache          int
+		TrieCleanRPCShare       int `toml:",omitempty"`
 		TrieDirtyCache          int
 		TrieTimeout             time.Duration
 		SnapshotCache           int
 		Preimages               bool
 		FilterLogCacheSize      int
+		FilterMaxLogs           int
 		Miner                   miner.Config
 		TxPool                  legacypool.Config
 		BlobPool                blobpool.Config
@@ -55,9 +73,20 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		DocRoot                 string `toml:"-"`
 		RPCGasCap               uint64
 		RPCEVMTimeout           time.Duration
+		RPCEVMMemoryLimit       uint64
+		RPCTraceTimeout         time.Duration
+		RPCCallBreakerTrips     int
+		RPCCallBreakerCooldown  time.Duration
 		RPCTxFeeCap             float64
 		OverrideCancun          *uint64 `toml:",omitempty"`
 		OverrideVerkle          *uint64 `toml:",omitempty"`
+		Checkpoint              checkpoint.Config
+		Watchdog                watchdog.Config
+		Clock                   clock.Config
+		Failover                failover.Config
+		NoIOThrottle            bool    `toml:",omitempty"`
+		HaltOverride            *uint64 `toml:",omitempty"`
+		LocalAccessList         string  `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -70,6 +99,13 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.TxLookupLimit = c.TxLookupLimit
 	enc.TransactionHistory = c.TransactionHistory
 	enc.StateHistory = c.StateHistory
+	enc.ReceiptDedup = c.ReceiptDedup
+	enc.TraceArchive = c.TraceArchive
+	enc.TokenTransferIndex = c.TokenTransferIndex
+	enc.BloomSectionSize = c.BloomSectionSize
+	enc.BloomRebuild = c.BloomRebuild
+	enc.StateExpiryEpoch = c.StateExpiryEpoch
+	enc.BadBlockDir = c.BadBlockDir
 	enc.StateScheme = c.StateScheme
 	enc.RequiredBlocks = c.RequiredBlocks
 	enc.LightServ = c.LightServ
@@ -82,12 +118,17 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.DatabaseHandles = c.DatabaseHandles
 	enc.DatabaseCache = c.DatabaseCache
 	enc.DatabaseFreezer = c.DatabaseFreezer
+	enc.DatabaseVerify = c.DatabaseVerify
+	enc.DatabaseMigrationDryRun = c.DatabaseMigrationDryRun
+	enc.DatabaseMigrationBackup = c.DatabaseMigrationBackup
 	enc.TrieCleanCache = c.TrieCleanCache
+	enc.TrieCleanRPCShare = c.TrieCleanRPCShare
 	enc.TrieDirtyCache = c.TrieDirtyCache
 	enc.TrieTimeout = c.TrieTimeout
 	enc.SnapshotCache = c.SnapshotCache
 	enc.Preimages = c.Preimages
 	enc.FilterLogCacheSize = c.FilterLogCacheSize
+	enc.FilterMaxLogs = c.FilterMaxLogs
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
@@ -98,9 +139,20 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.DocRoot = c.DocRoot
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
+	enc.RPCEVMMemoryLimit = c.RPCEVMMemoryLimit
+	enc.RPCTraceTimeout = c.RPCTraceTimeout
+	enc.RPCCallBreakerTrips = c.RPCCallBreakerTrips
+	enc.RPCCallBreakerCooldown = c.RPCCallBreakerCooldown
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
 	enc.OverrideCancun = c.OverrideCancun
 	enc.OverrideVerkle = c.OverrideVerkle
+	enc.Checkpoint = c.Checkpoint
+	enc.Watchdog = c.Watchdog
+	enc.Clock = c.Clock
+	enc.Failover = c.Failover
+	enc.NoIOThrottle = c.NoIOThrottle
+	enc.HaltOverride = c.HaltOverride
+	enc.LocalAccessList = c.LocalAccessList
 	return &enc, nil
 }
 
@@ -117,6 +169,13 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		TxLookupLimit           *uint64                `toml:",omitempty"`
 		TransactionHistory      *uint64                `toml:",omitempty"`
 		StateHistory            *uint64                `toml:",omitempty"`
+		ReceiptDedup            *bool                  `toml:",omitempty"`
+		TraceArchive            *bool                  `toml:",omitempty"`
+		TokenTransferIndex      *bool                  `toml:",omitempty"`
+		BloomSectionSize        *uint64                `toml:",omitempty"`
+		BloomRebuild            *bool                  `toml:",omitempty"`
+		StateExpiryEpoch        *uint64                `toml:",omitempty"`
+		BadBlockDir             *string                `toml:",omitempty"`
 		StateScheme             *string                `toml:",omitempty"`
 		RequiredBlocks          map[uint64]common.Hash `toml:"-"`
 		LightServ               *int                   `toml:",omitempty"`
@@ -129,12 +188,17 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		DatabaseHandles         *int                   `toml:"-"`
 		DatabaseCache           *int
 		DatabaseFreezer         *string
+		DatabaseVerify          *string
+		DatabaseMigrationDryRun *bool                                                          `toml:",omitempty"`
+		DatabaseMigrationBackup func(db ethdb.Database, pending []rawdb.SchemaMigration) error `toml:"-"`
 		TrieCleanCache          *int
+		TrieCleanRPCShare       *int `toml:",omitempty"`
 		TrieDirtyCache          *int
 		TrieTimeout             *time.Duration
 		SnapshotCache           *int
 		Preimages               *bool
 		FilterLogCacheSize      *int
+		FilterMaxLogs           *int
 		Miner                   *miner.Config
 		TxPool                  *legacypool.Config
 		BlobPool                *blobpool.Config
@@ -145,9 +209,20 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		DocRoot                 *string `toml:"-"`
 		RPCGasCap               *uint64
 		RPCEVMTimeout           *time.Duration
+		RPCEVMMemoryLimit       *uint64
+		RPCTraceTimeout         *time.Duration
+		RPCCallBreakerTrips     *int
+		RPCCallBreakerCooldown  *time.Duration
 		RPCTxFeeCap             *float64
 		OverrideCancun          *uint64 `toml:",omitempty"`
 		OverrideVerkle          *uint64 `toml:",omitempty"`
+		Checkpoint              *checkpoint.Config
+		Watchdog                *watchdog.Config
+		Clock                   *clock.Config
+		Failover                *failover.Config
+		NoIOThrottle            *bool   `toml:",omitempty"`
+		HaltOverride            *uint64 `toml:",omitempty"`
+		LocalAccessList         *string `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -183,6 +258,27 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.StateHistory != nil {
 		c.StateHistory = *dec.StateHistory
 	}
+	if dec.ReceiptDedup != nil {
+		c.ReceiptDedup = *dec.ReceiptDedup
+	}
+	if dec.TraceArchive != nil {
+		c.TraceArchive = *dec.TraceArchive
+	}
+	if dec.TokenTransferIndex != nil {
+		c.TokenTransferIndex = *dec.TokenTransferIndex
+	}
+	if dec.BloomSectionSize != nil {
+		c.BloomSectionSize = *dec.BloomSectionSize
+	}
+	if dec.BloomRebuild != nil {
+		c.BloomRebuild = *dec.BloomRebuild
+	}
+	if dec.StateExpiryEpoch != nil {
+		c.StateExpiryEpoch = *dec.StateExpiryEpoch
+	}
+	if dec.BadBlockDir != nil {
+		c.BadBlockDir = *dec.BadBlockDir
+	}
 	if dec.StateScheme != nil {
 		c.StateScheme = *dec.StateScheme
 	}
@@ -219,9 +315,21 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DatabaseFreezer != nil {
 		c.DatabaseFreezer = *dec.DatabaseFreezer
 	}
+	if dec.DatabaseVerify != nil {
+		c.DatabaseVerify = *dec.DatabaseVerify
+	}
+	if dec.DatabaseMigrationDryRun != nil {
+		c.DatabaseMigrationDryRun = *dec.DatabaseMigrationDryRun
+	}
+	if dec.DatabaseMigrationBackup != nil {
+		c.DatabaseMigrationBackup = dec.DatabaseMigrationBackup
+	}
 	if dec.TrieCleanCache != nil {
 		c.TrieCleanCache = *dec.TrieCleanCache
 	}
+	if dec.TrieCleanRPCShare != nil {
+		c.TrieCleanRPCShare = *dec.TrieCleanRPCShare
+	}
 	if dec.TrieDirtyCache != nil {
 		c.TrieDirtyCache = *dec.TrieDirtyCache
 	}
@@ -237,6 +345,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.FilterLogCacheSize != nil {
 		c.FilterLogCacheSize = *dec.FilterLogCacheSize
 	}
+	if dec.FilterMaxLogs != nil {
+		c.FilterMaxLogs = *dec.FilterMaxLogs
+	}
 	if dec.Miner != nil {
 		c.Miner = *dec.Miner
 	}
@@ -267,6 +378,18 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCEVMTimeout != nil {
 		c.RPCEVMTimeout = *dec.RPCEVMTimeout
 	}
+	if dec.RPCEVMMemoryLimit != nil {
+		c.RPCEVMMemoryLimit = *dec.RPCEVMMemoryLimit
+	}
+	if dec.RPCTraceTimeout != nil {
+		c.RPCTraceTimeout = *dec.RPCTraceTimeout
+	}
+	if dec.RPCCallBreakerTrips != nil {
+		c.RPCCallBreakerTrips = *dec.RPCCallBreakerTrips
+	}
+	if dec.RPCCallBreakerCooldown != nil {
+		c.RPCCallBreakerCooldown = *dec.RPCCallBreakerCooldown
+	}
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
@@ -276,5 +399,26 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.OverrideVerkle != nil {
 		c.OverrideVerkle = dec.OverrideVerkle
 	}
+	if dec.Checkpoint != nil {
+		c.Checkpoint = *dec.Checkpoint
+	}
+	if dec.Watchdog != nil {
+		c.Watchdog = *dec.Watchdog
+	}
+	if dec.Clock != nil {
+		c.Clock = *dec.Clock
+	}
+	if dec.Failover != nil {
+		c.Failover = *dec.Failover
+	}
+	if dec.NoIOThrottle != nil {
+		c.NoIOThrottle = *dec.NoIOThrottle
+	}
+	if dec.HaltOverride != nil {
+		c.HaltOverride = dec.HaltOverride
+	}
+	if dec.LocalAccessList != nil {
+		c.LocalAccessList = *dec.LocalAccessList
+	}
 	return nil
 }
@@ -0,0 +1,442 @@
+// Package failover coordinates an active-passive pair of nodes running the same
+// Turbo validator.
+//
+// The active node seals the blocks while the passive one, running the same
+// validator key, keeps its sealing stopped and polls the heartbeat of its peer.
+// The passive node takes the sealing over once the active one hands it over
+// with a message signed by the validator key, or once the active node showed
+// no sign of life, neither heartbeat nor block sealed by the validator, for the
+// expiry time.
+//
+// Double signing is prevented by the signing floor of the engine, the lowest
+// block number the validator may sign, persisted before each signature. The
+// heartbeats and the handover carry the floor of the active node, and the
+// passive node raises its own above it before sealing. On expiry, the floor is
+// raised above the head too, skipping the blocks the active node might have
+// signed past its last heartbeat.
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const chainHeadChanSize = 10
+
+// Config contains the failover settings.
+type Config struct {
+	Peer      string        // RPC endpoint of the failover peer, empty to disable the failover
+	Standby   bool          // Whether the node starts passive, the active one otherwise
+	Heartbeat time.Duration // Time between two heartbeats polled from the peer
+	Expiry    time.Duration // Time without sign of life of the active peer before the passive node takes over
+}
+
+// DefaultConfig contains the default settings, sized for a 3 seconds period.
+var DefaultConfig = Config{
+	Heartbeat: time.Second,
+	Expiry:    30 * time.Second,
+}
+
+var (
+	activeGauge     = metrics.NewRegisteredGauge("turbo/failover/active", nil) // 1 if the node seals, 0 if passive
+	heartbeatMeter  = metrics.NewRegisteredMeter("turbo/failover/heartbeats", nil)
+	missedMeter     = metrics.NewRegisteredMeter("turbo/failover/missed", nil) // Heartbeats the peer failed to answer
+	takeoverCounter = metrics.NewRegisteredCounter("turbo/failover/takeovers", nil)
+)
+
+var (
+	errPassive          = errors.New("node is passive")
+	errUnknownValidator = errors.New("validator not authorized yet")
+)
+
+// Engine is the consensus engine whose sealing is coordinated.
+type Engine interface {
+	Author(header *types.Header) (common.Address, error)
+	CurrentValidator() common.Address
+	StopSealing(after uint64)
+	ResumeSealing()
+	SigningFloor(val common.Address) uint64
+	RaiseSigningFloor(val common.Address, number uint64)
+}
+
+// Chain is the blockchain sealed by the validator.
+type Chain interface {
+	CurrentBlock() *types.Header
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// Peer is the failover peer node.
+type Peer interface {
+	Heartbeat(ctx context.Context) (*Heartbeat, error)
+}
+
+// SignFn signs a text message with the validator key.
+type SignFn func(val common.Address, text []byte) ([]byte, error)
+
+// Heartbeat is the status of a node, polled by its failover peer.
+type Heartbeat struct {
+	Validator common.Address `json:"validator"`
+	Active    bool           `json:"active"`
+	Floor     hexutil.Uint64 `json:"floor"` // Signing floor of the validator on the node
+	Head      hexutil.Uint64 `json:"head"`
+	Handover  *Handover      `json:"handover,omitempty"` // Set once the node handed the sealing over
+}
+
+// Handover passes the sealing of the validator over to the failover peer.
+type Handover struct {
+	Validator common.Address `json:"validator"`
+	Floor     hexutil.Uint64 `json:"floor"` // Lowest block number the peer may sign
+	Time      hexutil.Uint64 `json:"time"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// text returns the message signed by the validator key.
+func (h *Handover) text() []byte {
+	return []byte(fmt.Sprintf("turbo failover handover of %s from block %d at %d", h.Validator.Hex(), h.Floor, h.Time))
+}
+
+// verify checks the handover is signed by the validator.
+func (h *Handover) verify() error {
+	if len(h.Signature) != crypto.SignatureLength {
+		return errors.New("invalid handover signature length")
+	}
+	sig := common.CopyBytes(h.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash(h.text()), sig)
+	if err != nil {
+		return err
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != h.Validator {
+		return fmt.Errorf("handover signed by %s instead of the validator", signer)
+	}
+	return nil
+}
+
+// Service seals with the validator only while the node is the active one of
+// the failover pair.
+type Service struct {
+	config Config
+	chain  Chain
+	engine Engine
+	peer   Peer
+	signFn SignFn
+	synced func() bool
+	now    func() time.Time
+
+	lock     sync.Mutex
+	active   bool
+	handover *Handover // Handover signed when the node passed the sealing to its peer
+	lastSeen time.Time // Last sign of life of the active peer
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates the failover service of the node, polling the peer over RPC.
+func New(config Config, chain Chain, engine Engine, signFn SignFn, synced func() bool) *Service {
+	return newService(config, chain, engine, &rpcPeer{url: config.Peer}, signFn, synced)
+}
+
+func newService(config Config, chain Chain, engine Engine, peer Peer, signFn SignFn, synced func() bool) *Service {
+	if config.Heartbeat <= 0 {
+		config.Heartbeat = DefaultConfig.Heartbeat
+	}
+	if config.Expiry <= 0 {
+		config.Expiry = DefaultConfig.Expiry
+	}
+	return &Service{
+		config: config,
+		chain:  chain,
+		engine: engine,
+		peer:   peer,
+		signFn: signFn,
+		synced: synced,
+		now:    time.Now,
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start stops the sealing if the node starts passive, or if the peer is found
+// sealing already, e.g. as the node restarts after a failover, and launches the
+// heartbeats.
+func (s *Service) Start() {
+	s.start()
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// start picks the role of the node.
+func (s *Service) start() {
+	active := !s.config.Standby
+	if active {
+		ctx, cancel := context.WithTimeout(context.Background(), s.config.Heartbeat)
+		hb, err := s.peer.Heartbeat(ctx)
+		cancel()
+		if err == nil && hb.Active {
+			log.Warn("Failover peer is sealing, starting passive", "peer", s.config.Peer)
+			active = false
+		}
+	}
+	s.lock.Lock()
+	s.active, s.lastSeen = active, s.now()
+	s.lock.Unlock()
+
+	if active {
+		activeGauge.Update(1)
+		log.Info("Failover started active", "peer", s.config.Peer)
+	} else {
+		s.engine.StopSealing(0)
+		activeGauge.Update(0)
+		log.Info("Failover started passive", "peer", s.config.Peer, "expiry", common.PrettyDuration(s.config.Expiry))
+	}
+}
+
+// Stop terminates the heartbeats.
+func (s *Service) Stop() {
+	close(s.quit)
+	s.wg.Wait()
+}
+
+func (s *Service) loop() {
+	defer s.wg.Done()
+
+	heads := make(chan core.ChainHeadEvent, chainHeadChanSize)
+	sub := s.chain.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	ticker := time.NewTicker(s.config.Heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.poll()
+		case head := <-heads:
+			s.newHead(head.Block.Header())
+		case <-sub.Err():
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// Active returns whether the node seals.
+func (s *Service) Active() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.active
+}
+
+// Heartbeat returns the status of the node.
+func (s *Service) Heartbeat() *Heartbeat {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	val := s.engine.CurrentValidator()
+	return &Heartbeat{
+		Validator: val,
+		Active:    s.active,
+		Floor:     hexutil.Uint64(s.engine.SigningFloor(val)),
+		Head:      hexutil.Uint64(s.chain.CurrentBlock().Number.Uint64()),
+		Handover:  s.handover,
+	}
+}
+
+// Handover stops the sealing of the active node and signs the handover of the
+// sealing to the peer, which takes over at its next heartbeat.
+func (s *Service) Handover() (*Handover, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.active {
+		return nil, errPassive
+	}
+	val := s.engine.CurrentValidator()
+	if val == (common.Address{}) {
+		return nil, errUnknownValidator
+	}
+	// Stop first, the floor is final once no block can be signed anymore
+	s.engine.StopSealing(s.chain.CurrentBlock().Number.Uint64())
+	handover := &Handover{
+		Validator: val,
+		Floor:     hexutil.Uint64(s.engine.SigningFloor(val) + 1),
+		Time:      hexutil.Uint64(s.now().Unix()),
+	}
+	sig, err := s.signFn(val, handover.text())
+	if err != nil {
+		s.engine.ResumeSealing()
+		return nil, err
+	}
+	handover.Signature = sig
+
+	s.active, s.handover, s.lastSeen = false, handover, s.now()
+	activeGauge.Update(0)
+	log.Info("Handed sealing over to failover peer", "validator", val, "floor", uint64(handover.Floor))
+	return handover, nil
+}
+
+// poll polls the heartbeat of the peer and takes the sealing over if the peer
+// handed it over or expired.
+func (s *Service) poll() {
+	if s.Active() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Heartbeat)
+	hb, err := s.peer.Heartbeat(ctx)
+	cancel()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// The node might have been made active meanwhile
+	if s.active {
+		return
+	}
+	val := s.engine.CurrentValidator()
+	switch {
+	case err != nil:
+		missedMeter.Mark(1)
+		log.Debug("Failover heartbeat failed", "peer", s.config.Peer, "err", err)
+
+	case val != (common.Address{}) && hb.Validator != val:
+		missedMeter.Mark(1)
+		log.Error("Failover peer runs another validator", "peer", s.config.Peer, "validator", val, "peer validator", hb.Validator)
+
+	default:
+		heartbeatMeter.Mark(1)
+		// A passive peer keeps a standby node passive, while the primary one
+		// takes the sealing over once the peer expired, not to leave the
+		// validator idle
+		if hb.Active || s.config.Standby {
+			s.lastSeen = s.now()
+		}
+		if val == (common.Address{}) {
+			// Nothing may be signed before the validator is authorized
+			return
+		}
+		// The peer might sign a block at its own floor
+		s.engine.RaiseSigningFloor(val, uint64(hb.Floor)+1)
+		if hb.Active || hb.Handover == nil {
+			return
+		}
+		if err := s.acceptHandover(val, hb.Handover); err != nil {
+			log.Warn("Rejected failover handover", "peer", s.config.Peer, "err", err)
+			return
+		}
+		s.takeOver(val, uint64(hb.Handover.Floor), "handover")
+		return
+	}
+	// Take over once the peer expired, unless the node is not synced, possibly
+	// missing blocks signed by the peer
+	if val == (common.Address{}) || s.now().Sub(s.lastSeen) < s.config.Expiry || !s.synced() {
+		return
+	}
+	log.Warn("Failover peer expired, taking sealing over", log.EventKey, log.EventFailoverTakeover,
+		"peer", s.config.Peer, "validator", val, "lastseen", common.PrettyAge(s.lastSeen))
+	s.takeOver(val, s.chain.CurrentBlock().Number.Uint64()+1, "expiry")
+}
+
+// acceptHandover checks the handover of the peer was signed for the validator,
+// and recently enough not to be an old one replayed.
+func (s *Service) acceptHandover(val common.Address, handover *Handover) error {
+	if handover.Validator != val {
+		return fmt.Errorf("handover of validator %s", handover.Validator)
+	}
+	if age := s.now().Sub(time.Unix(int64(handover.Time), 0)); age > s.config.Expiry {
+		return fmt.Errorf("handover signed %v ago", common.PrettyDuration(age))
+	}
+	return handover.verify()
+}
+
+// takeOver raises the signing floor and resumes the sealing. The lock is held.
+func (s *Service) takeOver(val common.Address, floor uint64, reason string) {
+	s.engine.RaiseSigningFloor(val, floor)
+	s.engine.ResumeSealing()
+	s.active, s.handover = true, nil
+
+	activeGauge.Update(1)
+	takeoverCounter.Inc(1)
+	log.Info("Took sealing over from failover peer", "validator", val, "floor", s.engine.SigningFloor(val), "reason", reason)
+}
+
+// newHead tracks the blocks sealed by the validator while passive, a sign of
+// life of the active peer whose blocks must never be signed again.
+func (s *Service) newHead(header *types.Header) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.active {
+		return
+	}
+	val := s.engine.CurrentValidator()
+	if val == (common.Address{}) {
+		return
+	}
+	if signer, err := s.engine.Author(header); err == nil && signer == val {
+		s.lastSeen = s.now()
+		s.engine.RaiseSigningFloor(val, header.Number.Uint64()+1)
+	}
+}
+
+// rpcPeer polls the heartbeats of the peer over RPC.
+type rpcPeer struct {
+	url    string
+	client *rpc.Client
+}
+
+func (p *rpcPeer) Heartbeat(ctx context.Context) (*Heartbeat, error) {
+	if p.client == nil {
+		client, err := rpc.DialContext(ctx, p.url)
+		if err != nil {
+			return nil, err
+		}
+		p.client = client
+	}
+	var hb *Heartbeat
+	if err := p.client.CallContext(ctx, &hb, "failover_heartbeat"); err != nil {
+		return nil, err
+	}
+	if hb == nil {
+		return nil, errors.New("empty heartbeat")
+	}
+	return hb, nil
+}
+
+// API exposes the failover status and the handover over RPC.
+type API struct {
+	service *Service
+}
+
+// NewAPI creates the failover API of the service.
+func NewAPI(service *Service) *API {
+	return &API{service: service}
+}
+
+// Heartbeat returns the status of the node, polled by the failover peer.
+func (api *API) Heartbeat() *Heartbeat {
+	return api.service.Heartbeat()
+}
+
+// Handover stops the sealing of the active node and hands it over to the
+// failover peer, returning the signed handover.
+func (api *API) Handover() (*Handover, error) {
+	return api.service.Handover()
+}
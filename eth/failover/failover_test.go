@@ -0,0 +1,186 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// testEngine seals with the validator unless stopped, tracking its floor.
+type testEngine struct {
+	validator common.Address
+	stopped   bool
+	floor     uint64
+	authors   map[uint64]common.Address
+}
+
+func (e *testEngine) Author(header *types.Header) (common.Address, error) {
+	return e.authors[header.Number.Uint64()], nil
+}
+
+func (e *testEngine) CurrentValidator() common.Address       { return e.validator }
+func (e *testEngine) StopSealing(after uint64)               { e.stopped = true }
+func (e *testEngine) ResumeSealing()                         { e.stopped = false }
+func (e *testEngine) SigningFloor(val common.Address) uint64 { return e.floor }
+
+func (e *testEngine) RaiseSigningFloor(val common.Address, number uint64) {
+	e.floor = max(e.floor, number)
+}
+
+type testChain struct {
+	head *types.Header
+	feed event.Feed
+}
+
+func (c *testChain) CurrentBlock() *types.Header { return c.head }
+
+func (c *testChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.feed.Subscribe(ch)
+}
+
+// testPeer answers the heartbeats with the given status, or fails if nil.
+type testPeer struct {
+	heartbeat *Heartbeat
+}
+
+func (p *testPeer) Heartbeat(ctx context.Context) (*Heartbeat, error) {
+	if p.heartbeat == nil {
+		return nil, errors.New("unreachable")
+	}
+	return p.heartbeat, nil
+}
+
+type testPair struct {
+	service *Service
+	engine  *testEngine
+	chain   *testChain
+	peer    *testPeer
+	signFn  SignFn
+	now     time.Time
+	synced  bool
+}
+
+func newTestPair(standby bool, peer *Heartbeat) *testPair {
+	key, _ := crypto.GenerateKey()
+	p := &testPair{
+		engine: &testEngine{validator: crypto.PubkeyToAddress(key.PublicKey), authors: make(map[uint64]common.Address)},
+		chain:  &testChain{head: &types.Header{Number: big.NewInt(100)}},
+		peer:   &testPeer{heartbeat: peer},
+		signFn: func(val common.Address, text []byte) ([]byte, error) {
+			return crypto.Sign(accounts.TextHash(text), key)
+		},
+		now:    time.Unix(1000, 0),
+		synced: true,
+	}
+	config := Config{Peer: "test", Standby: standby, Heartbeat: time.Second, Expiry: 10 * time.Second}
+	p.service = newService(config, p.chain, p.engine, p.peer, p.signFn, func() bool { return p.synced })
+	p.service.now = func() time.Time { return p.now }
+	p.service.start()
+	return p
+}
+
+func TestStartPassive(t *testing.T) {
+	// A standby starts passive
+	standby := newTestPair(true, nil)
+	if standby.service.Active() || !standby.engine.stopped {
+		t.Fatal("standby started sealing")
+	}
+	// A primary starts active, unless its peer seals already
+	primary := newTestPair(false, nil)
+	if !primary.service.Active() || primary.engine.stopped {
+		t.Fatal("primary started passive")
+	}
+	restarted := newTestPair(false, &Heartbeat{Active: true})
+	if restarted.service.Active() || !restarted.engine.stopped {
+		t.Fatal("primary started sealing along its active peer")
+	}
+}
+
+func TestHandover(t *testing.T) {
+	primary := newTestPair(false, nil)
+	primary.engine.floor = 105
+
+	handover, err := primary.service.Handover()
+	if err != nil {
+		t.Fatalf("failed to hand over: %v", err)
+	}
+	if primary.service.Active() || !primary.engine.stopped {
+		t.Fatal("primary still sealing after handover")
+	}
+	if handover.Floor != 106 {
+		t.Fatalf("handover floor mismatch: have %d, want 106", handover.Floor)
+	}
+	if _, err := primary.service.Handover(); err != errPassive {
+		t.Fatalf("handed over twice: %v", err)
+	}
+	// The standby keeps passive while the primary seals, raising its floor
+	standby := newTestPair(true, &Heartbeat{Active: true, Floor: 104})
+	standby.engine.validator = handover.Validator
+	standby.peer.heartbeat.Validator = handover.Validator
+	standby.service.poll()
+	if standby.service.Active() || standby.engine.floor != 105 {
+		t.Fatalf("passive node mismatch: active %v, floor %d", standby.service.Active(), standby.engine.floor)
+	}
+	// A handover signed by another key is rejected
+	forged := *handover
+	forged.Floor = 100
+	standby.peer.heartbeat = &Heartbeat{Validator: handover.Validator, Floor: 105, Handover: &forged}
+	standby.service.poll()
+	if standby.service.Active() {
+		t.Fatal("took over on forged handover")
+	}
+	// The signed handover is accepted
+	standby.peer.heartbeat = primary.service.Heartbeat()
+	standby.service.poll()
+	if !standby.service.Active() || standby.engine.stopped {
+		t.Fatal("didn't take over on handover")
+	}
+	if standby.engine.floor != 106 {
+		t.Fatalf("floor mismatch: have %d, want 106", standby.engine.floor)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	p := newTestPair(true, nil)
+	val := p.engine.validator
+
+	// A block sealed by the active peer is a sign of life
+	p.now = p.now.Add(9 * time.Second)
+	p.engine.authors[101] = val
+	p.chain.head = &types.Header{Number: big.NewInt(101)}
+	p.service.newHead(p.chain.head)
+	if p.engine.floor != 102 {
+		t.Fatalf("floor mismatch: have %d, want 102", p.engine.floor)
+	}
+	p.now = p.now.Add(9 * time.Second)
+	p.service.poll()
+	if p.service.Active() {
+		t.Fatal("took over before expiry")
+	}
+	// An unsynced node doesn't take over
+	p.now = p.now.Add(2 * time.Second)
+	p.synced = false
+	p.service.poll()
+	if p.service.Active() {
+		t.Fatal("took over while syncing")
+	}
+	// The expired peer is replaced, above the head
+	p.synced = true
+	p.chain.head = &types.Header{Number: big.NewInt(110)}
+	p.service.poll()
+	if !p.service.Active() || p.engine.stopped {
+		t.Fatal("didn't take over on expiry")
+	}
+	if p.engine.floor != 111 {
+		t.Fatalf("floor mismatch: have %d, want 111", p.engine.floor)
+	}
+}
@@ -18,6 +18,7 @@
 package fetcher
 
 import (
+	"context"
 	"math/big"
 	"math/rand"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/prque"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/otel"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/trie"
@@ -89,8 +91,9 @@ type chainHeightFn func() uint64
 // headersInsertFn is a callback type to insert a batch of headers into the local chain.
 type headersInsertFn func(headers []*types.Header) (int, error)
 
-// chainInsertFn is a callback type to insert a batch of blocks into the local chain.
-type chainInsertFn func(types.Blocks) (int, error)
+// chainInsertFn is a callback type to insert a batch of blocks into the local
+// chain, tracing the imports as children of the span carried by the context.
+type chainInsertFn func(context.Context, types.Blocks) (int, error)
 
 // peerDropFn is a callback type for dropping a peer detected as malicious.
 type peerDropFn func(id string)
@@ -797,6 +800,15 @@ func (f *BlockFetcher) importBlocks(peer string, block *types.Block) {
 	go func() {
 		defer func() { f.done <- hash }()
 
+		// Trace the propagation from the block's arrival until its import
+		received := block.ReceivedAt
+		if received.IsZero() {
+			received = time.Now()
+		}
+		span := otel.StartAt(nil, "block.fetch", received,
+			otel.Uint64("block.number", block.NumberU64()), otel.String("block.hash", hash.Hex()), otel.String("peer", peer))
+		defer span.End()
+
 		// If the parent's unknown, abort insertion
 		parent := f.getBlock(block.ParentHash())
 		if parent == nil {
@@ -816,11 +828,13 @@ func (f *BlockFetcher) importBlocks(peer string, block *types.Block) {
 		default:
 			// Something went very wrong, drop the peer
 			log.Debug("Propagated block verification failed", "peer", peer, "number", block.Number(), "hash", hash, "err", err)
+			span.RecordError(err)
 			f.dropPeer(peer)
 			return
 		}
 		// Run the actual import and log any issues
-		if _, err := f.insertChain(types.Blocks{block}); err != nil {
+		if _, err := f.insertChain(otel.ContextWithSpan(context.Background(), span), types.Blocks{block}); err != nil {
+			span.RecordError(err)
 			log.Debug("Propagated block import failed", "peer", peer, "number", block.Number(), "hash", hash, "err", err)
 			return
 		}
@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -41,6 +42,19 @@ var (
 	errExceedMaxTopics        = errors.New("exceed max topics")
 )
 
+// logsLimitError is returned by the log queries matching more logs than the
+// limit of the node.
+type logsLimitError struct {
+	limit int
+}
+
+func (e *logsLimitError) Error() string {
+	return fmt.Sprintf("query returned more than %d logs, narrow the range or page through it with nero_getLogsPaged", e.limit)
+}
+
+// ErrorCode returns the limit exceeded code of EIP-1474.
+func (e *logsLimitError) ErrorCode() int { return -32005 }
+
 // The maximum number of topic criteria allowed, vm.LOG4 - vm.LOG0
 const maxTopics = 4
 
@@ -223,8 +237,51 @@ func (api *FilterAPI) NewBlockFilter() rpc.ID {
 	return headerSub.ID
 }
 
+// NewHeadsOptions are the options of a newHeads subscription.
+type NewHeadsOptions struct {
+	// Reorgs enables the notification of the chain reorgs, sent before the
+	// header of the new chain head.
+	Reorgs bool `json:"reorgs"`
+}
+
+// BlockID identifies a block in a reorg notification.
+type BlockID struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+// ReorgNotification is the newHeads notification of a chain reorg. It is told
+// apart from the headers by its type.
+type ReorgNotification struct {
+	Type                   string         `json:"type"` // Always "reorg"
+	Depth                  hexutil.Uint64 `json:"depth"`
+	CommonAncestor         BlockID        `json:"commonAncestor"`
+	OldHead                BlockID        `json:"oldHead"`
+	NewHead                BlockID        `json:"newHead"`
+	DroppedTransactions    []common.Hash  `json:"droppedTransactions"`
+	ReincludedTransactions []common.Hash  `json:"reincludedTransactions"`
+}
+
+func newReorgNotification(ev *core.ChainReorgEvent) *ReorgNotification {
+	blockID := func(header *types.Header) BlockID {
+		return BlockID{Number: hexutil.Uint64(header.Number.Uint64()), Hash: header.Hash()}
+	}
+	return &ReorgNotification{
+		Type:                   "reorg",
+		Depth:                  hexutil.Uint64(ev.Depth()),
+		CommonAncestor:         blockID(ev.Common),
+		OldHead:                blockID(ev.OldHead),
+		NewHead:                blockID(ev.NewHead),
+		DroppedTransactions:    ev.Dropped,
+		ReincludedTransactions: ev.Reincluded,
+	}
+}
+
 // NewHeads send a notification each time a new (header) block is appended to the chain.
-func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+// If the reorgs are enabled in the options, a reorg notification listing the dropped
+// and the re-included transactions is also sent each time blocks are dropped from the
+// chain, before the header of the new head.
+func (api *FilterAPI) NewHeads(ctx context.Context, opts *NewHeadsOptions) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
@@ -233,14 +290,25 @@ func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 	rpcSub := notifier.CreateSubscription()
 
 	go func() {
-		headers := make(chan *types.Header)
-		headersSub := api.events.SubscribeNewHeads(headers)
+		var (
+			headers    = make(chan *types.Header)
+			reorgs     chan *core.ChainReorgEvent
+			headersSub *Subscription
+		)
+		if opts != nil && opts.Reorgs {
+			reorgs = make(chan *core.ChainReorgEvent)
+			headersSub = api.events.SubscribeNewHeadsAndReorgs(headers, reorgs)
+		} else {
+			headersSub = api.events.SubscribeNewHeads(headers)
+		}
 		defer headersSub.Unsubscribe()
 
 		for {
 			select {
 			case h := <-headers:
 				notifier.Notify(rpcSub.ID, h)
+			case ev := <-reorgs:
+				notifier.Notify(rpcSub.ID, newReorgNotification(ev))
 			case <-rpcSub.Err():
 				return
 			}
@@ -334,35 +402,61 @@ func (api *FilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
 
 // GetLogs returns logs matching the given argument that are stored within the state.
 func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*types.Log, error) {
+	filter, err := api.sys.newCriteriaFilter(crit)
+	if err != nil {
+		return nil, err
+	}
+	// Run the filter and return all the logs
+	logs, err := api.sys.limitedLogs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return returnLogs(logs), err
+}
+
+// limitedLogs runs the filter, failing if it matches more logs than allowed.
+func (sys *FilterSystem) limitedLogs(ctx context.Context, filter *Filter) ([]*types.Log, error) {
+	limit := sys.cfg.MaxLogs
+	logs, err := filter.logs(ctx, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(logs) > limit {
+		return nil, &logsLimitError{limit: limit}
+	}
+	return logs, nil
+}
+
+// newCriteriaFilter creates the single-shot filter of the criteria.
+func (sys *FilterSystem) newCriteriaFilter(crit FilterCriteria) (*Filter, error) {
 	if len(crit.Topics) > maxTopics {
 		return nil, errExceedMaxTopics
 	}
-	var filter *Filter
 	if crit.BlockHash != nil {
 		// Block filter requested, construct a single-shot filter
-		filter = api.sys.NewBlockFilter(*crit.BlockHash, crit.Addresses, crit.Topics)
-	} else {
-		// Convert the RPC block numbers into internal representations
-		begin := rpc.LatestBlockNumber.Int64()
-		if crit.FromBlock != nil {
-			begin = crit.FromBlock.Int64()
-		}
-		end := rpc.LatestBlockNumber.Int64()
-		if crit.ToBlock != nil {
-			end = crit.ToBlock.Int64()
-		}
-		if begin > 0 && end > 0 && begin > end {
-			return nil, errInvalidBlockRange
-		}
-		// Construct the range filter
-		filter = api.sys.NewRangeFilter(begin, end, crit.Addresses, crit.Topics)
+		return sys.NewBlockFilter(*crit.BlockHash, crit.Addresses, crit.Topics), nil
 	}
-	// Run the filter and return all the logs
-	logs, err := filter.Logs(ctx)
-	if err != nil {
-		return nil, err
+	// Convert the RPC block numbers into internal representations
+	begin, end := criteriaRange(crit)
+	if begin > 0 && end > 0 && begin > end {
+		return nil, errInvalidBlockRange
 	}
-	return returnLogs(logs), err
+	// Construct the range filter
+	return sys.NewRangeFilter(begin, end, crit.Addresses, crit.Topics), nil
+}
+
+// criteriaRange returns the block range of the criteria, the latest block by
+// default.
+func criteriaRange(crit FilterCriteria) (int64, int64) {
+	begin := rpc.LatestBlockNumber.Int64()
+	if crit.FromBlock != nil {
+		begin = crit.FromBlock.Int64()
+	}
+	end := rpc.LatestBlockNumber.Int64()
+	if crit.ToBlock != nil {
+		end = crit.ToBlock.Int64()
+	}
+	return begin, end
 }
 
 // UninstallFilter removes the filter with the given filter id.
@@ -409,7 +503,7 @@ func (api *FilterAPI) GetFilterLogs(ctx context.Context, id rpc.ID) ([]*types.Lo
 		filter = api.sys.NewRangeFilter(begin, end, f.crit.Addresses, f.crit.Topics)
 	}
 	// Run the filter and return all the logs
-	logs, err := filter.Logs(ctx)
+	logs, err := api.sys.limitedLogs(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
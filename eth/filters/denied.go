@@ -0,0 +1,179 @@
+package filters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxDeniedLogsRange is the maximum number of blocks replayed by a log query
+// including the denied logs.
+const maxDeniedLogsRange = 128
+
+var errPendingDeniedLogs = errors.New("denied logs of the pending block not available")
+
+// DeniedLog is a log whose emission was denied by the access filter, failing
+// the call frame emitting it.
+type DeniedLog struct {
+	Log  *types.Log
+	Rule string // Description of the rule denying the log, empty if unknown
+}
+
+// DeniedLogsBackend finds the logs denied by the access filter in a block,
+// which aren't stored as they failed their call frame.
+type DeniedLogsBackend interface {
+	DeniedLogs(ctx context.Context, blockHash common.Hash) ([]*DeniedLog, error)
+}
+
+// LogsOptions are the options of the debug log queries.
+type LogsOptions struct {
+	IncludeDenied bool `json:"includeDenied"` // Include the logs denied by the access filter
+}
+
+// DebugLog is a log returned by the debug log queries, flagged if it was denied
+// by the access filter.
+type DebugLog struct {
+	*types.Log
+	Denied bool
+	Rule   string
+}
+
+// MarshalJSON marshals the log as in the eth namespace, adding the denial
+// fields to the denied logs.
+func (l *DebugLog) MarshalJSON() ([]byte, error) {
+	blob, err := json.Marshal(l.Log)
+	if err != nil || !l.Denied {
+		return blob, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(blob, &fields); err != nil {
+		return nil, err
+	}
+	fields["denied"] = true
+	if l.Rule != "" {
+		fields["rule"] = l.Rule
+	}
+	return json.Marshal(fields)
+}
+
+// DebugFilterAPI offers the log queries exposing the logs denied by the access
+// filter, for debugging contract integrations.
+type DebugFilterAPI struct {
+	sys     *FilterSystem
+	backend DeniedLogsBackend
+}
+
+// NewDebugFilterAPI returns a new DebugFilterAPI instance.
+func NewDebugFilterAPI(system *FilterSystem, backend DeniedLogsBackend) *DebugFilterAPI {
+	return &DebugFilterAPI{sys: system, backend: backend}
+}
+
+// GetLogs returns the logs matching the criteria like eth_getLogs. With the
+// includeDenied option, the logs denied by the access filter are included,
+// flagged with the rule denying them. As they aren't stored, the blocks are
+// replayed to find them, limiting the queried range.
+func (api *DebugFilterAPI) GetLogs(ctx context.Context, crit FilterCriteria, opts *LogsOptions) ([]*DebugLog, error) {
+	filter, err := api.sys.newCriteriaFilter(crit)
+	if err != nil {
+		return nil, err
+	}
+	var headers []*types.Header
+	if opts != nil && opts.IncludeDenied {
+		// Resolve the blocks before running the filter, failing fast on
+		// too large ranges
+		if headers, err = api.criteriaHeaders(ctx, crit); err != nil {
+			return nil, err
+		}
+	}
+	logs, err := filter.Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*DebugLog, 0, len(logs))
+	for _, log := range logs {
+		result = append(result, &DebugLog{Log: log})
+	}
+	if len(headers) == 0 {
+		return result, nil
+	}
+	for _, header := range headers {
+		denied, err := api.backend.DeniedLogs(ctx, header.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay block %d: %v", header.Number, err)
+		}
+		for _, d := range denied {
+			if len(filterLogs([]*types.Log{d.Log}, nil, nil, crit.Addresses, crit.Topics)) > 0 {
+				result = append(result, &DebugLog{Log: d.Log, Denied: true, Rule: d.Rule})
+			}
+		}
+	}
+	// Order the denied logs after the stored ones of their transaction
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].BlockNumber != result[j].BlockNumber {
+			return result[i].BlockNumber < result[j].BlockNumber
+		}
+		return result[i].TxIndex < result[j].TxIndex
+	})
+	return result, nil
+}
+
+// criteriaHeaders resolves the headers of the blocks covered by the criteria.
+func (api *DebugFilterAPI) criteriaHeaders(ctx context.Context, crit FilterCriteria) ([]*types.Header, error) {
+	backend := api.sys.backend
+	if crit.BlockHash != nil {
+		header, err := backend.HeaderByHash(ctx, *crit.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, errors.New("unknown block")
+		}
+		return []*types.Header{header}, nil
+	}
+	begin, end := criteriaRange(crit)
+	if begin == rpc.PendingBlockNumber.Int64() || end == rpc.PendingBlockNumber.Int64() {
+		return nil, errPendingDeniedLogs
+	}
+	resolve := func(number int64) (uint64, error) {
+		header, err := backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return 0, err
+		}
+		if header == nil {
+			return 0, fmt.Errorf("block %d not found", number)
+		}
+		return header.Number.Uint64(), nil
+	}
+	first, err := resolve(begin)
+	if err != nil {
+		return nil, err
+	}
+	last, err := resolve(end)
+	if err != nil {
+		return nil, err
+	}
+	if first > last {
+		return nil, errInvalidBlockRange
+	}
+	if last-first+1 > maxDeniedLogsRange {
+		return nil, fmt.Errorf("range of %d blocks exceeds the limit of %d with denied logs", last-first+1, maxDeniedLogsRange)
+	}
+	headers := make([]*types.Header, 0, last-first+1)
+	for number := first; number <= last; number++ {
+		header, err := backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("block %d not found", number)
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
@@ -0,0 +1,191 @@
+package filters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// deniedTestBackend serves preset denied logs.
+type deniedTestBackend struct {
+	denied map[common.Hash][]*DeniedLog
+}
+
+func (b *deniedTestBackend) DeniedLogs(ctx context.Context, blockHash common.Hash) ([]*DeniedLog, error) {
+	return b.denied[blockHash], nil
+}
+
+// Tests that the denied logs are merged with the stored ones when requested,
+// flagged and filtered by the criteria.
+func TestDebugGetLogs(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		_, sys = newTestFilterSystem(t, db, Config{})
+
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		signer = types.NewLondonSigner(big.NewInt(1))
+
+		// Contract emitting a log with topic 1: LOG1(0, 0, 1)
+		contract = common.Address{0xfe}
+		bytecode = common.FromHex("0x600160006000a100")
+		topic    = common.BigToHash(big.NewInt(1))
+		other    = common.Address{0xff}
+
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr:     {Balance: big.NewInt(0).Mul(big.NewInt(100), big.NewInt(params.Ether))},
+				contract: {Balance: big.NewInt(0), Code: bytecode},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	if _, err := gspec.Commit(db, triedb.NewDatabase(db, nil)); err != nil {
+		t.Fatal(err)
+	}
+	chain, _ := core.GenerateChain(gspec.Config, gspec.ToBlock(), ethash.NewFaker(), db, 3, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			GasPrice: gen.BaseFee(),
+			Gas:      30000,
+			To:       &contract,
+		}), signer, key)
+		gen.AddTx(tx)
+	})
+	var l uint64
+	bc, err := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, &l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.InsertChain(chain); err != nil {
+		t.Fatal(err)
+	}
+	deniedLog := func(block *types.Block, address common.Address) *types.Log {
+		return &types.Log{
+			Address:     address,
+			Topics:      []common.Hash{topic},
+			BlockNumber: block.NumberU64(),
+			BlockHash:   block.Hash(),
+			TxHash:      block.Transactions()[0].Hash(),
+		}
+	}
+	backend := &deniedTestBackend{denied: map[common.Hash][]*DeniedLog{
+		chain[1].Hash(): {
+			{Log: deniedLog(chain[1], contract), Rule: "topic rule"},
+			{Log: deniedLog(chain[1], other)},
+		},
+	}}
+	api := NewDebugFilterAPI(sys, backend)
+
+	crit := FilterCriteria{
+		FromBlock: big.NewInt(1),
+		ToBlock:   big.NewInt(3),
+		Addresses: []common.Address{contract},
+	}
+	logs, err := api.GetLogs(context.Background(), crit, nil)
+	if err != nil {
+		t.Fatalf("failed to get logs: %v", err)
+	}
+	if len(logs) != 3 {
+		t.Fatalf("stored logs mismatch: have %d, want 3", len(logs))
+	}
+	logs, err = api.GetLogs(context.Background(), crit, &LogsOptions{IncludeDenied: true})
+	if err != nil {
+		t.Fatalf("failed to get logs with denied ones: %v", err)
+	}
+	// The denied log of the other address is filtered out, the one of the
+	// contract follows the stored log of its transaction
+	if len(logs) != 4 {
+		t.Fatalf("logs mismatch: have %d, want 4", len(logs))
+	}
+	for i, want := range []uint64{1, 2, 2, 3} {
+		if logs[i].BlockNumber != want {
+			t.Errorf("log %d: block mismatch: have %d, want %d", i, logs[i].BlockNumber, want)
+		}
+	}
+	if logs[1].Denied || !logs[2].Denied || logs[2].Rule != "topic rule" {
+		t.Errorf("denied log not flagged: %+v", logs[2])
+	}
+	for i, want := range []bool{false, true} {
+		blob, err := json.Marshal(logs[1+i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(blob, &fields); err != nil {
+			t.Fatal(err)
+		}
+		if _, denied := fields["denied"]; denied != want {
+			t.Errorf("log %d: denied field mismatch: have %v, want %v", 1+i, denied, want)
+		}
+		if fields["transactionHash"] != chain[1].Transactions()[0].Hash().Hex() {
+			t.Errorf("log %d: transaction hash mismatch: %v", 1+i, fields["transactionHash"])
+		}
+	}
+	// Block hash queries include the denied logs too
+	hash := chain[1].Hash()
+	logs, err = api.GetLogs(context.Background(), FilterCriteria{BlockHash: &hash}, &LogsOptions{IncludeDenied: true})
+	if err != nil {
+		t.Fatalf("failed to get block logs: %v", err)
+	}
+	if len(logs) != 3 {
+		t.Errorf("block logs mismatch: have %d, want 3", len(logs))
+	}
+}
+
+// Tests that the queries replaying the blocks reject the pending block and the
+// too large ranges.
+func TestDebugGetLogsLimits(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		_, sys = newTestFilterSystem(t, db, Config{})
+		api    = NewDebugFilterAPI(sys, &deniedTestBackend{})
+		gspec  = &core.Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+	)
+	if _, err := gspec.Commit(db, triedb.NewDatabase(db, nil)); err != nil {
+		t.Fatal(err)
+	}
+	chain, _ := core.GenerateChain(gspec.Config, gspec.ToBlock(), ethash.NewFaker(), db, maxDeniedLogsRange, func(i int, gen *core.BlockGen) {})
+	var l uint64
+	bc, err := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, &l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.InsertChain(chain); err != nil {
+		t.Fatal(err)
+	}
+	opts := &LogsOptions{IncludeDenied: true}
+
+	crit := FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())}
+	if _, err := api.GetLogs(context.Background(), crit, opts); err == nil {
+		t.Errorf("range above the limit accepted")
+	}
+	crit.FromBlock = big.NewInt(1)
+	if _, err := api.GetLogs(context.Background(), crit, opts); err != nil {
+		t.Errorf("range at the limit rejected: %v", err)
+	}
+	crit.ToBlock = big.NewInt(rpc.PendingBlockNumber.Int64())
+	if _, err := api.GetLogs(context.Background(), crit, opts); !errors.Is(err, errPendingDeniedLogs) {
+		t.Errorf("pending block error mismatch: have %v, want %v", err, errPendingDeniedLogs)
+	}
+	// Without denied logs, the range isn't limited
+	crit.FromBlock = big.NewInt(0)
+	crit.ToBlock = big.NewInt(rpc.LatestBlockNumber.Int64())
+	if _, err := api.GetLogs(context.Background(), crit, nil); err != nil {
+		t.Errorf("range without denied logs rejected: %v", err)
+	}
+}
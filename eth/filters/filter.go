@@ -96,6 +96,13 @@ func newFilter(sys *FilterSystem, addresses []common.Address, topics [][]common.
 // Logs searches the blockchain for matching log entries, returning all from the
 // first block that contains matches, updating the start of the filter accordingly.
 func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
+	return f.logs(ctx, 0, 0)
+}
+
+// logs searches the matching log entries like Logs, skipping the ones of the
+// first block below the given log index. The search stops once more than limit
+// logs are found (0 = no limit), the extra log being the first of the next page.
+func (f *Filter) logs(ctx context.Context, skip uint, limit int) ([]*types.Log, error) {
 	// If we're doing singleton block filtering, execute and return
 	if f.block != nil {
 		header, err := f.sys.backend.HeaderByHash(ctx, *f.block)
@@ -105,7 +112,17 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		if header == nil {
 			return nil, errors.New("unknown block")
 		}
-		return f.blockLogs(ctx, header)
+		logs, err := f.blockLogs(ctx, header)
+		if err != nil {
+			return nil, err
+		}
+		for len(logs) > 0 && logs[0].Index < skip {
+			logs = logs[1:]
+		}
+		if limit > 0 && len(logs) > limit+1 {
+			logs = logs[:limit+1]
+		}
+		return logs, nil
 	}
 
 	// Disallow pending logs.
@@ -148,12 +165,27 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		return nil, err
 	}
 
-	logChan, errChan := f.rangeLogsAsync(ctx)
-	var logs []*types.Log
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		first            = uint64(f.begin)
+		logChan, errChan = f.rangeLogsAsync(ctx)
+		logs             []*types.Log
+	)
 	for {
 		select {
 		case log := <-logChan:
+			if log.BlockNumber == first && log.Index < skip {
+				continue
+			}
 			logs = append(logs, log)
+			if limit > 0 && len(logs) > limit {
+				// Interrupt the search and wait for it to terminate
+				cancel()
+				<-errChan
+				return logs, nil
+			}
 		case err := <-errChan:
 			return logs, err
 		}
@@ -238,7 +270,11 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64, logChan chan *type
 				return err
 			}
 			for _, log := range found {
-				logChan <- log
+				select {
+				case logChan <- log:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
 			}
 
 		case <-ctx.Done():
@@ -251,6 +287,9 @@ func (f *Filter) indexedLogs(ctx context.Context, end uint64, logChan chan *type
 // iteration and bloom matching.
 func (f *Filter) unindexedLogs(ctx context.Context, end uint64, logChan chan *types.Log) error {
 	for ; f.begin <= int64(end); f.begin++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		header, err := f.sys.backend.HeaderByNumber(ctx, rpc.BlockNumber(f.begin))
 		if header == nil || err != nil {
 			return err
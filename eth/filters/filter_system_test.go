@@ -46,6 +46,7 @@ type testBackend struct {
 	logsFeed        event.Feed
 	rmLogsFeed      event.Feed
 	chainFeed       event.Feed
+	reorgFeed       event.Feed
 	pendingBlock    *types.Block
 	pendingReceipts types.Receipts
 }
@@ -137,6 +138,10 @@ func (b *testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subsc
 	return b.chainFeed.Subscribe(ch)
 }
 
+func (b *testBackend) SubscribeChainReorgEvent(ch chan<- core.ChainReorgEvent) event.Subscription {
+	return b.reorgFeed.Subscribe(ch)
+}
+
 func (b *testBackend) BloomStatus() (uint64, uint64) {
 	return params.BloomBitsBlocks, b.sections
 }
@@ -238,6 +243,71 @@ func TestBlockSubscription(t *testing.T) {
 	<-sub1.Err()
 }
 
+// TestReorgSubscription tests that the reorgs are only delivered to the
+// subscriptions asking for them, before the header of the new head.
+func TestReorgSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(t, db, Config{})
+		api          = NewFilterAPI(sys)
+		genesis      = &core.Genesis{
+			Config:  params.TestChainConfig,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		_, chain, _ = core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 3, func(i int, gen *core.BlockGen) {})
+		_, fork, _  = core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 3, func(i int, gen *core.BlockGen) {
+			if i > 0 {
+				gen.SetCoinbase(common.Address{0x1})
+			}
+		})
+		reorg = core.ChainReorgEvent{
+			OldHead:    chain[2].Header(),
+			NewHead:    fork[2].Header(),
+			Common:     chain[0].Header(),
+			Dropped:    []common.Hash{{0x1}},
+			Reincluded: []common.Hash{{0x2}},
+		}
+	)
+	headers0, reorgs0 := make(chan *types.Header), make(chan *core.ChainReorgEvent)
+	sub0 := api.events.SubscribeNewHeadsAndReorgs(headers0, reorgs0)
+	defer sub0.Unsubscribe()
+	headers1 := make(chan *types.Header)
+	sub1 := api.events.SubscribeNewHeads(headers1)
+	defer sub1.Unsubscribe()
+
+	backend.reorgFeed.Send(reorg)
+	backend.chainFeed.Send(core.ChainEvent{Hash: fork[2].Hash(), Block: fork[2]})
+
+	// The reorg is delivered first, then the new head.
+	var received []string
+	for len(received) < 3 {
+		select {
+		case ev := <-reorgs0:
+			received = append(received, "reorg")
+			if n := newReorgNotification(ev); n.Depth != 2 || n.CommonAncestor.Hash != chain[0].Hash() || n.NewHead.Hash != fork[2].Hash() {
+				t.Errorf("reorg notification mismatch: %+v", n)
+			}
+		case header := <-headers0:
+			received = append(received, "head")
+			if len(received) == 1 {
+				t.Error("head delivered before the reorg")
+			}
+			if header.Hash() != fork[2].Hash() {
+				t.Errorf("head mismatch: have %x, want %x", header.Hash(), fork[2].Hash())
+			}
+		case header := <-headers1:
+			received = append(received, "plain head")
+			if header.Hash() != fork[2].Hash() {
+				t.Errorf("head mismatch: have %x, want %x", header.Hash(), fork[2].Hash())
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout, received %v", received)
+		}
+	}
+}
+
 // TestPendingTxFilter tests whether pending tx filters retrieve all pending transactions that are posted to the event mux.
 func TestPendingTxFilter(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,158 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// defaultLogsPageLimit and maxLogsPageLimit are the default and maximum
+	// numbers of logs of a GetLogsPaged page.
+	defaultLogsPageLimit = 1000
+	maxLogsPageLimit     = 10000
+)
+
+// LogsCursor is the position of a log in the logs matching a query: the block
+// of the log and its index among the logs of the block.
+type LogsCursor struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Index       hexutil.Uint   `json:"logIndex"`
+}
+
+// LogsPageOptions are the pagination options of the log queries.
+type LogsPageOptions struct {
+	Limit  *hexutil.Uint64 `json:"limit"`  // Maximum number of logs, defaults to 1000
+	Cursor *LogsCursor     `json:"cursor"` // Position to resume from, the next cursor of the previous page
+}
+
+// LogsPage is a page of the logs matching a query over a block range.
+type LogsPage struct {
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+	Logs      []*types.Log   `json:"logs"`
+	Next      *LogsCursor    `json:"next"` // Nil once the range is exhausted
+}
+
+// NeroFilterAPI offers the paginated log queries, streaming large ranges
+// without hitting the limits of eth_getLogs.
+type NeroFilterAPI struct {
+	sys *FilterSystem
+}
+
+// NewNeroFilterAPI returns a new NeroFilterAPI instance.
+func NewNeroFilterAPI(system *FilterSystem) *NeroFilterAPI {
+	return &NeroFilterAPI{sys: system}
+}
+
+// GetLogsPaged returns the logs matching the criteria like eth_getLogs, one
+// page at a time. Each page returns the resolved block range and the cursor to
+// pass, along with the same criteria, to get the next one. The search stops as
+// soon as the page is full, so that large ranges are served without buffering
+// all their logs.
+//
+// As the latest block moves, the toBlock of the first page should be passed to
+// the next calls to page through a stable range.
+func (api *NeroFilterAPI) GetLogsPaged(ctx context.Context, crit FilterCriteria, opts *LogsPageOptions) (*LogsPage, error) {
+	if len(crit.Topics) > maxTopics {
+		return nil, errExceedMaxTopics
+	}
+	maxLimit := uint64(maxLogsPageLimit)
+	if api.sys.cfg.MaxLogs > 0 {
+		maxLimit = min(maxLimit, uint64(api.sys.cfg.MaxLogs))
+	}
+	var (
+		limit  = min(defaultLogsPageLimit, maxLimit)
+		cursor *LogsCursor
+	)
+	if opts != nil {
+		if opts.Limit != nil {
+			limit = uint64(*opts.Limit)
+		}
+		cursor = opts.Cursor
+	}
+	if limit == 0 || limit > maxLimit {
+		return nil, fmt.Errorf("invalid limit %d, must be between 1 and %d", limit, maxLimit)
+	}
+	from, to, err := api.resolveRange(ctx, crit)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		start = from
+		skip  uint
+	)
+	if cursor != nil {
+		start, skip = uint64(cursor.BlockNumber), uint(cursor.Index)
+		if start < from || start > to {
+			return nil, fmt.Errorf("cursor block #%d outside of the range", start)
+		}
+	}
+	var filter *Filter
+	if crit.BlockHash != nil {
+		filter = api.sys.NewBlockFilter(*crit.BlockHash, crit.Addresses, crit.Topics)
+	} else {
+		filter = api.sys.NewRangeFilter(int64(start), int64(to), crit.Addresses, crit.Topics)
+	}
+	logs, err := filter.logs(ctx, skip, int(limit))
+	if err != nil {
+		return nil, err
+	}
+	page := &LogsPage{
+		FromBlock: hexutil.Uint64(from),
+		ToBlock:   hexutil.Uint64(to),
+		Logs:      returnLogs(logs),
+	}
+	if uint64(len(logs)) > limit {
+		next := logs[limit]
+		page.Logs = logs[:limit]
+		page.Next = &LogsCursor{BlockNumber: hexutil.Uint64(next.BlockNumber), Index: hexutil.Uint(next.Index)}
+	}
+	return page, nil
+}
+
+// resolveRange returns the numbers of the first and last blocks covered by the
+// criteria.
+func (api *NeroFilterAPI) resolveRange(ctx context.Context, crit FilterCriteria) (uint64, uint64, error) {
+	backend := api.sys.backend
+	if crit.BlockHash != nil {
+		header, err := backend.HeaderByHash(ctx, *crit.BlockHash)
+		if err != nil {
+			return 0, 0, err
+		}
+		if header == nil {
+			return 0, 0, errors.New("unknown block")
+		}
+		return header.Number.Uint64(), header.Number.Uint64(), nil
+	}
+	begin, end := criteriaRange(crit)
+	if begin == rpc.PendingBlockNumber.Int64() || end == rpc.PendingBlockNumber.Int64() {
+		return 0, 0, errPendingLogsUnsupported
+	}
+	resolve := func(number int64) (uint64, error) {
+		header, err := backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return 0, err
+		}
+		if header == nil {
+			return 0, fmt.Errorf("block %d not found", number)
+		}
+		return header.Number.Uint64(), nil
+	}
+	first, err := resolve(begin)
+	if err != nil {
+		return 0, 0, err
+	}
+	last, err := resolve(end)
+	if err != nil {
+		return 0, 0, err
+	}
+	if first > last {
+		return 0, 0, errInvalidBlockRange
+	}
+	return first, last, nil
+}
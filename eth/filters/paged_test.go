@@ -0,0 +1,163 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// newPagedTestChain inserts a chain of 4 blocks emitting 2 logs each.
+func newPagedTestChain(t *testing.T, db ethdb.Database) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		signer = types.NewLondonSigner(big.NewInt(1))
+
+		// Contract emitting a log with topic 1: LOG1(0, 0, 1)
+		contract = common.Address{0xfe}
+		bytecode = common.FromHex("0x600160006000a100")
+
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr:     {Balance: big.NewInt(0).Mul(big.NewInt(100), big.NewInt(params.Ether))},
+				contract: {Balance: big.NewInt(0), Code: bytecode},
+			},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	if _, err := gspec.Commit(db, triedb.NewDatabase(db, nil)); err != nil {
+		t.Fatal(err)
+	}
+	var nonce uint64
+	chain, _ := core.GenerateChain(gspec.Config, gspec.ToBlock(), ethash.NewFaker(), db, 4, func(i int, gen *core.BlockGen) {
+		for j := 0; j < 2; j++ {
+			tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+				Nonce:    nonce,
+				GasPrice: gen.BaseFee(),
+				Gas:      30000,
+				To:       &contract,
+			}), signer, key)
+			gen.AddTx(tx)
+			nonce++
+		}
+	})
+	var l uint64
+	bc, err := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, &l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bc.InsertChain(chain); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Tests that the logs of a range are paged through with the cursors, each log
+// returned once and in order.
+func TestGetLogsPaged(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		_, sys = newTestFilterSystem(t, db, Config{})
+		api    = NewNeroFilterAPI(sys)
+	)
+	newPagedTestChain(t, db)
+
+	var (
+		crit   = FilterCriteria{FromBlock: big.NewInt(0)}
+		limit  = hexutil.Uint64(3)
+		opts   = &LogsPageOptions{Limit: &limit}
+		logs   []*types.Log
+		pages  int
+		cursor *LogsCursor
+	)
+	for {
+		opts.Cursor = cursor
+		page, err := api.GetLogsPaged(context.Background(), crit, opts)
+		if err != nil {
+			t.Fatalf("page %d: %v", pages, err)
+		}
+		if page.FromBlock != 0 || page.ToBlock != 4 {
+			t.Fatalf("page %d range mismatch: have %d-%d, want 0-4", pages, page.FromBlock, page.ToBlock)
+		}
+		if len(page.Logs) > int(limit) {
+			t.Fatalf("page %d exceeds the limit: %d logs", pages, len(page.Logs))
+		}
+		logs = append(logs, page.Logs...)
+		pages++
+		if cursor = page.Next; cursor == nil {
+			break
+		}
+		crit.ToBlock = big.NewInt(int64(page.ToBlock))
+	}
+	if pages != 3 {
+		t.Fatalf("page count mismatch: have %d, want 3", pages)
+	}
+	if len(logs) != 8 {
+		t.Fatalf("log count mismatch: have %d, want 8", len(logs))
+	}
+	for i, log := range logs {
+		if want := uint64(i/2 + 1); log.BlockNumber != want || log.Index != uint(i%2) {
+			t.Errorf("log %d position mismatch: have block %d index %d, want block %d index %d", i, log.BlockNumber, log.Index, want, i%2)
+		}
+	}
+	// A page ending exactly on the last log has no next cursor
+	limit = 8
+	opts.Cursor = nil
+	page, err := api.GetLogsPaged(context.Background(), crit, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Logs) != 8 || page.Next != nil {
+		t.Fatalf("full page mismatch: %d logs, next %v", len(page.Logs), page.Next)
+	}
+	// Cursors out of the range and invalid limits are rejected
+	opts.Cursor = &LogsCursor{BlockNumber: 5}
+	if _, err := api.GetLogsPaged(context.Background(), crit, opts); err == nil {
+		t.Fatal("cursor out of range accepted")
+	}
+	limit = maxLogsPageLimit + 1
+	opts.Cursor = nil
+	if _, err := api.GetLogsPaged(context.Background(), crit, opts); err == nil {
+		t.Fatal("limit above the maximum accepted")
+	}
+}
+
+// Tests that eth_getLogs fails on the queries matching more logs than allowed.
+func TestGetLogsLimit(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	newPagedTestChain(t, db)
+
+	crit := FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(4)}
+	for _, tt := range []struct {
+		max     int
+		limited bool
+	}{
+		{0, false},
+		{8, false},
+		{7, true},
+	} {
+		_, sys := newTestFilterSystem(t, db, Config{MaxLogs: tt.max})
+		logs, err := NewFilterAPI(sys).GetLogs(context.Background(), crit)
+
+		var limitErr *logsLimitError
+		if limited := errors.As(err, &limitErr); limited != tt.limited {
+			t.Fatalf("max %d: limit error mismatch: have %v, want limited %v", tt.max, err, tt.limited)
+		}
+		if !tt.limited && len(logs) != 8 {
+			t.Fatalf("max %d: log count mismatch: have %d, want 8", tt.max, len(logs))
+		}
+	}
+}
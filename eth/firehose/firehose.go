@@ -0,0 +1,482 @@
+// Package firehose streams the chain to RPC subscribers for exactly-once
+// ingestion.
+//
+// A firehose subscription multiplexes the canonical blocks, their receipts and
+// internal transactions, the reorgs and the finality updates into a single
+// stream in strict order: the events of a block are streamed in turn, and a
+// finality update is only streamed after its block. Every event carries a
+// sequence number the client acknowledges through nero_firehoseAck, at most a
+// window of unacknowledged events being in flight: the stream pauses until the
+// client catches up, slow clients never holding the chain back.
+//
+// Every event also carries the cursor of the stream position. A client storing
+// the cursor along with the ingested data resumes the stream from it after a
+// disconnection, receiving exactly the events following it. The blocks reorged
+// in the meantime are retracted by a reorg event before the new canonical ones
+// are streamed.
+package firehose
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Kinds of the streamed events.
+const (
+	KindBlock    = "block"
+	KindReceipts = "receipts"
+	KindTraces   = "traces"
+	KindFinality = "finality"
+	KindReorg    = "reorg"
+)
+
+const (
+	// DefaultWindow is the default number of unacknowledged events in flight.
+	DefaultWindow = 64
+
+	// maxWindow is the maximum number of unacknowledged events in flight.
+	maxWindow = 1024
+
+	chainHeadChanSize = 10
+	finalityChanSize  = 16
+)
+
+// blockKinds are the kinds of the events streamed for every block, in order.
+var blockKinds = []string{KindBlock, KindReceipts, KindTraces}
+
+var (
+	errUnknownStream = errors.New("unknown firehose subscription")
+	errUnknownCursor = errors.New("unknown cursor block")
+)
+
+// Cursor is a position in the stream, after the given event of the block.
+type Cursor struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Kind   string         `json:"kind"` // "block", "receipts" or "traces"
+}
+
+// Event is a streamed event. The reorg events carry the common ancestor of the
+// reorged blocks, the other events the block they relate to.
+type Event struct {
+	Seq    hexutil.Uint64 `json:"seq"`
+	Kind   string         `json:"kind"`
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Cursor Cursor         `json:"cursor"`
+	Data   interface{}    `json:"data"`
+}
+
+// FinalityUpdate is the data of the finality events.
+type FinalityUpdate struct {
+	Status string `json:"status"` // "justified" or "finalized"
+}
+
+// Reorg is the data of the reorg events.
+type Reorg struct {
+	Removed []common.Hash `json:"removed"` // Retracted blocks, highest first
+}
+
+// Options are the options of a firehose subscription.
+type Options struct {
+	Cursor    *Cursor         `json:"cursor"`    // Position to resume the stream from
+	FromBlock *hexutil.Uint64 `json:"fromBlock"` // Block to stream from without cursor, the chain head by default
+	Window    *hexutil.Uint64 `json:"window"`    // Number of unacknowledged events in flight
+}
+
+// Chain is the blockchain streamed.
+type Chain interface {
+	Config() *params.ChainConfig
+	CurrentBlock() *types.Header
+	CurrentSafeBlock() *types.Header
+	CurrentFinalBlock() *types.Header
+	GetHeader(hash common.Hash, number uint64) *types.Header
+	GetCanonicalHash(number uint64) common.Hash
+	GetBlock(hash common.Hash, number uint64) *types.Block
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+	SubscribeNewJustifiedOrFinalizedBlockEvent(ch chan<- core.NewJustifiedOrFinalizedBlockEvent) event.Subscription
+}
+
+// API serves the firehose subscriptions.
+type API struct {
+	chain Chain
+	db    ethdb.Database
+
+	lock    sync.Mutex
+	streams map[rpc.ID]*stream
+}
+
+// NewAPI creates the firehose API streaming the chain.
+func NewAPI(chain Chain, db ethdb.Database) *API {
+	return &API{
+		chain:   chain,
+		db:      db,
+		streams: make(map[rpc.ID]*stream),
+	}
+}
+
+// Firehose streams the chain from the cursor, or from the given block.
+func (api *API) Firehose(ctx context.Context, opts *Options) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if opts == nil {
+		opts = new(Options)
+	}
+	window := uint64(DefaultWindow)
+	if opts.Window != nil {
+		window = uint64(*opts.Window)
+		if window == 0 || window > maxWindow {
+			return nil, fmt.Errorf("window must be between 1 and %d", maxWindow)
+		}
+	}
+	s := &stream{
+		chain:    api.chain,
+		db:       api.db,
+		notifier: notifier,
+		window:   window,
+		acks:     make(chan struct{}, 1),
+		wake:     make(chan struct{}, 1),
+		quit:     make(chan struct{}),
+	}
+	switch {
+	case opts.Cursor != nil:
+		if err := s.resume(opts.Cursor); err != nil {
+			return nil, err
+		}
+	case opts.FromBlock != nil:
+		s.next = uint64(*opts.FromBlock)
+		if s.next > 0 {
+			s.parent = api.chain.GetCanonicalHash(s.next - 1)
+		}
+	default:
+		head := api.chain.CurrentBlock()
+		s.next, s.parent = head.Number.Uint64(), head.ParentHash
+	}
+	s.sub = notifier.CreateSubscription()
+
+	api.lock.Lock()
+	api.streams[s.sub.ID] = s
+	api.lock.Unlock()
+
+	go func() {
+		defer func() {
+			api.lock.Lock()
+			delete(api.streams, s.sub.ID)
+			api.lock.Unlock()
+		}()
+		s.run()
+	}()
+	return s.sub, nil
+}
+
+// FirehoseAck acknowledges the events of the subscription up to the given
+// sequence number, letting the following ones be streamed.
+func (api *API) FirehoseAck(id rpc.ID, seq hexutil.Uint64) error {
+	api.lock.Lock()
+	s := api.streams[id]
+	api.lock.Unlock()
+
+	if s == nil {
+		return errUnknownStream
+	}
+	return s.ack(uint64(seq))
+}
+
+// stream is a firehose subscription. The position is the block streamed next
+// and the number of its events already streamed, along with the parent hash
+// to detect the reorgs.
+type stream struct {
+	chain    Chain
+	db       ethdb.Database
+	notifier *rpc.Notifier
+	sub      *rpc.Subscription
+	window   uint64
+
+	next    uint64
+	step    int         // Number of events of the next block already streamed
+	current common.Hash // Hash of the next block if partially streamed
+	parent  common.Hash // Hash of the last block fully streamed, zero if unknown
+
+	lock     sync.Mutex
+	seq      uint64               // Sequence number of the last event streamed
+	acked    uint64               // Sequence number of the last event acknowledged
+	finality []*types.BlockStatus // Finality updates waiting to be streamed
+	acks     chan struct{}
+	wake     chan struct{}
+	quit     chan struct{}
+}
+
+// resume sets the stream position up after the cursor.
+func (s *stream) resume(cursor *Cursor) error {
+	step := -1
+	for i, kind := range blockKinds {
+		if cursor.Kind == kind {
+			step = i + 1
+		}
+	}
+	if step < 0 {
+		return fmt.Errorf("invalid cursor kind %q", cursor.Kind)
+	}
+	number := uint64(cursor.Number)
+	if step == len(blockKinds) {
+		s.next, s.parent = number+1, cursor.Hash
+		return nil
+	}
+	header := s.chain.GetHeader(cursor.Hash, number)
+	if header == nil {
+		return errUnknownCursor
+	}
+	s.next, s.step, s.current, s.parent = number, step, cursor.Hash, header.ParentHash
+	return nil
+}
+
+// cursor returns the cursor of the stream position.
+func (s *stream) cursor() Cursor {
+	if s.step > 0 {
+		return Cursor{Number: hexutil.Uint64(s.next), Hash: s.current, Kind: blockKinds[s.step-1]}
+	}
+	if s.next == 0 {
+		return Cursor{}
+	}
+	return Cursor{Number: hexutil.Uint64(s.next - 1), Hash: s.parent, Kind: KindTraces}
+}
+
+// ack acknowledges the events up to the sequence number.
+func (s *stream) ack(seq uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if seq > s.seq {
+		return fmt.Errorf("event %d not streamed yet", seq)
+	}
+	if seq > s.acked {
+		s.acked = seq
+		select {
+		case s.acks <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// run streams the chain until the subscription ends.
+func (s *stream) run() {
+	defer close(s.quit)
+
+	var current []*types.BlockStatus
+	if header := s.chain.CurrentSafeBlock(); header != nil {
+		current = append(current, &types.BlockStatus{BlockNumber: header.Number, Hash: header.Hash(), Status: types.BasJustified})
+	}
+	if header := s.chain.CurrentFinalBlock(); header != nil {
+		current = append(current, &types.BlockStatus{BlockNumber: header.Number, Hash: header.Hash(), Status: types.BasFinalized})
+	}
+	s.finality = current
+
+	heads := make(chan core.ChainHeadEvent, chainHeadChanSize)
+	headSub := s.chain.SubscribeChainHeadEvent(heads)
+	defer headSub.Unsubscribe()
+
+	updates := make(chan core.NewJustifiedOrFinalizedBlockEvent, finalityChanSize)
+	updateSub := s.chain.SubscribeNewJustifiedOrFinalizedBlockEvent(updates)
+	defer updateSub.Unsubscribe()
+
+	go s.collect(heads, headSub, updates, updateSub)
+	for {
+		if err := s.catchUp(); err != nil {
+			log.Debug("Firehose subscription ended", "id", s.sub.ID, "err", err)
+			return
+		}
+		select {
+		case <-s.wake:
+		case <-s.sub.Err():
+			return
+		}
+	}
+}
+
+// collect collects the chain events, waking the stream up. The subscriptions
+// are never blocked by the stream.
+func (s *stream) collect(heads chan core.ChainHeadEvent, headSub event.Subscription, updates chan core.NewJustifiedOrFinalizedBlockEvent, updateSub event.Subscription) {
+	for {
+		select {
+		case <-heads:
+		case ev := <-updates:
+			if ev.JF == nil {
+				continue
+			}
+			s.lock.Lock()
+			s.finality = append(s.finality, ev.JF)
+			s.lock.Unlock()
+		case <-headSub.Err():
+			return
+		case <-updateSub.Err():
+			return
+		case <-s.quit:
+			return
+		}
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// catchUp streams the blocks up to the chain head, each followed by the
+// finality updates of the blocks streamed.
+func (s *stream) catchUp() error {
+	for {
+		if err := s.rewind(); err != nil {
+			return err
+		}
+		if err := s.streamFinality(); err != nil {
+			return err
+		}
+		if s.next > s.chain.CurrentBlock().Number.Uint64() {
+			return nil
+		}
+		if err := s.streamBlock(); err != nil {
+			return err
+		}
+	}
+}
+
+// rewind moves the position back to the canonical chain if the streamed
+// blocks were reorged, retracting them by a reorg event.
+func (s *stream) rewind() error {
+	var removed []common.Hash
+	if s.step > 0 && s.chain.GetCanonicalHash(s.next) != s.current {
+		removed = append(removed, s.current)
+		s.step, s.current = 0, common.Hash{}
+	}
+	for s.next > 0 && s.parent != (common.Hash{}) {
+		if s.chain.GetCanonicalHash(s.next-1) == s.parent {
+			break
+		}
+		removed = append(removed, s.parent)
+		header := s.chain.GetHeader(s.parent, s.next-1)
+		if header == nil {
+			// The reorged block is gone, stream again the canonical one.
+			s.parent = common.Hash{}
+			break
+		}
+		s.next--
+		s.parent = header.ParentHash
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	log.Debug("Rewinding firehose subscription", "id", s.sub.ID, "next", s.next, "removed", len(removed))
+	cursor := s.cursor()
+	return s.send(KindReorg, uint64(cursor.Number), cursor.Hash, cursor, &Reorg{Removed: removed})
+}
+
+// streamBlock streams the remaining events of the next canonical block.
+func (s *stream) streamBlock() error {
+	var (
+		hash  = s.chain.GetCanonicalHash(s.next)
+		block = s.chain.GetBlock(hash, s.next)
+	)
+	if block == nil {
+		return errors.New("missing canonical block")
+	}
+	for ; s.step < len(blockKinds); s.step++ {
+		var data interface{}
+		switch blockKinds[s.step] {
+		case KindBlock:
+			data = ethapi.RPCMarshalBlock(block, true, true, s.chain.Config())
+		case KindReceipts:
+			receipts := s.chain.GetReceiptsByHash(hash)
+			if receipts == nil {
+				receipts = types.Receipts{}
+			}
+			data = receipts
+		case KindTraces:
+			itxs := rawdb.ReadInternalTxs(s.db, hash, s.next)
+			if itxs == nil {
+				itxs = []*types.InternalTx{}
+			}
+			data = itxs
+		}
+		cursor := Cursor{Number: hexutil.Uint64(s.next), Hash: hash, Kind: blockKinds[s.step]}
+		if err := s.send(blockKinds[s.step], s.next, hash, cursor, data); err != nil {
+			return err
+		}
+		s.current = hash
+	}
+	s.next, s.step, s.current, s.parent = s.next+1, 0, common.Hash{}, hash
+	return nil
+}
+
+// streamFinality streams the pending finality updates of the blocks streamed,
+// the later ones staying pending.
+func (s *stream) streamFinality() error {
+	s.lock.Lock()
+	var ready, pending []*types.BlockStatus
+	for _, update := range s.finality {
+		if update.BlockNumber.Uint64() < s.next {
+			ready = append(ready, update)
+		} else {
+			pending = append(pending, update)
+		}
+	}
+	s.finality = pending
+	s.lock.Unlock()
+
+	for _, update := range ready {
+		status := "justified"
+		if update.Status == types.BasFinalized {
+			status = "finalized"
+		}
+		if err := s.send(KindFinality, update.BlockNumber.Uint64(), update.Hash, s.cursor(), &FinalityUpdate{Status: status}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send streams an event once the window allows it.
+func (s *stream) send(kind string, number uint64, hash common.Hash, cursor Cursor, data interface{}) error {
+	for {
+		s.lock.Lock()
+		full := s.seq-s.acked >= s.window
+		s.lock.Unlock()
+		if !full {
+			break
+		}
+		select {
+		case <-s.acks:
+		case <-s.sub.Err():
+			return errors.New("unsubscribed")
+		}
+	}
+	s.lock.Lock()
+	s.seq++
+	seq := s.seq
+	s.lock.Unlock()
+
+	return s.notifier.Notify(s.sub.ID, &Event{
+		Seq:    hexutil.Uint64(seq),
+		Kind:   kind,
+		Number: hexutil.Uint64(number),
+		Hash:   hash,
+		Cursor: cursor,
+		Data:   data,
+	})
+}
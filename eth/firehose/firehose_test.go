@@ -0,0 +1,218 @@
+package firehose
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testChain is a chain whose finality updates are sent by the test.
+type testChain struct {
+	*core.BlockChain
+	finality event.Feed
+}
+
+func (c *testChain) SubscribeNewJustifiedOrFinalizedBlockEvent(ch chan<- core.NewJustifiedOrFinalizedBlockEvent) event.Subscription {
+	return c.finality.Subscribe(ch)
+}
+
+func newTestChain(t *testing.T) (*testChain, ethdb.Database) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{Config: params.TestChainConfig}
+	)
+	chain, err := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	t.Cleanup(chain.Stop)
+	return &testChain{BlockChain: chain}, db
+}
+
+// generate creates blocks on top of the genesis, the ones after the fork point
+// having a distinct coinbase.
+func generate(chain *testChain, db ethdb.Database, n int, fork int) []*types.Block {
+	blocks, _ := core.GenerateChain(chain.Config(), chain.Genesis(), ethash.NewFaker(), db, n, func(i int, gen *core.BlockGen) {
+		if fork >= 0 && i >= fork {
+			gen.SetCoinbase(common.Address{0x01})
+		}
+	})
+	return blocks
+}
+
+// subscribe opens a firehose subscription over an in-process connection,
+// returning the function acknowledging its events.
+func subscribe(t *testing.T, chain *testChain, db ethdb.Database, opts *Options) (chan *Event, func(seq uint64) error) {
+	var (
+		api    = NewAPI(chain, db)
+		server = rpc.NewServer()
+	)
+	if err := server.RegisterName("nero", api); err != nil {
+		t.Fatalf("failed to register API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+	events := make(chan *Event, 100)
+	if _, err := client.Subscribe(context.Background(), "nero", events, "firehose", opts); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	var id rpc.ID
+	api.lock.Lock()
+	for id = range api.streams {
+	}
+	api.lock.Unlock()
+
+	return events, func(seq uint64) error {
+		return client.Call(nil, "nero_firehoseAck", id, hexutil.Uint64(seq))
+	}
+}
+
+// expect waits for the events of the given kinds and block numbers.
+func expect(t *testing.T, events chan *Event, kinds []string, numbers []uint64) []*Event {
+	t.Helper()
+	var received []*Event
+	for i := range kinds {
+		select {
+		case ev := <-events:
+			if ev.Kind != kinds[i] || uint64(ev.Number) != numbers[i] {
+				t.Fatalf("event %d mismatch: have %s #%d, want %s #%d", i, ev.Kind, ev.Number, kinds[i], numbers[i])
+			}
+			received = append(received, ev)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event %d (%s #%d) not received", i, kinds[i], numbers[i])
+		}
+	}
+	return received
+}
+
+func expectNone(t *testing.T, events chan *Event) {
+	t.Helper()
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected event: %s #%d", ev.Kind, ev.Number)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestFirehoseWindow(t *testing.T) {
+	chain, db := newTestChain(t)
+	if _, err := chain.InsertChain(generate(chain, db, 3, -1)); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	var (
+		from   = hexutil.Uint64(1)
+		window = hexutil.Uint64(4)
+	)
+	events, ack := subscribe(t, chain, db, &Options{FromBlock: &from, Window: &window})
+
+	// The stream pauses once the window is full
+	received := expect(t, events, []string{KindBlock, KindReceipts, KindTraces, KindBlock}, []uint64{1, 1, 1, 2})
+	expectNone(t, events)
+	for i, ev := range received {
+		if uint64(ev.Seq) != uint64(i+1) {
+			t.Fatalf("event %d sequence mismatch: have %d", i, ev.Seq)
+		}
+	}
+	if c := received[1].Cursor; uint64(c.Number) != 1 || c.Hash != chain.GetCanonicalHash(1) || c.Kind != KindReceipts {
+		t.Fatalf("cursor mismatch: %+v", c)
+	}
+	if err := ack(5); err == nil {
+		t.Fatal("event not streamed yet acknowledged")
+	}
+	// Acknowledging the events moves the window
+	if err := ack(2); err != nil {
+		t.Fatalf("failed to acknowledge events: %v", err)
+	}
+	expect(t, events, []string{KindReceipts, KindTraces}, []uint64{2, 2})
+	expectNone(t, events)
+	if err := ack(6); err != nil {
+		t.Fatalf("failed to acknowledge events: %v", err)
+	}
+	expect(t, events, []string{KindBlock, KindReceipts, KindTraces}, []uint64{3, 3, 3})
+	expectNone(t, events)
+}
+
+func TestFirehoseResume(t *testing.T) {
+	chain, db := newTestChain(t)
+	if _, err := chain.InsertChain(generate(chain, db, 2, -1)); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	// Resuming in the middle of a block streams its remaining events
+	cursor := &Cursor{Number: 1, Hash: chain.GetCanonicalHash(1), Kind: KindReceipts}
+	events, _ := subscribe(t, chain, db, &Options{Cursor: cursor})
+	received := expect(t, events, []string{KindTraces, KindBlock, KindReceipts, KindTraces}, []uint64{1, 2, 2, 2})
+	expectNone(t, events)
+
+	// Resuming after a block streams the next one
+	events, _ = subscribe(t, chain, db, &Options{Cursor: &received[0].Cursor})
+	expect(t, events, []string{KindBlock, KindReceipts, KindTraces}, []uint64{2, 2, 2})
+	expectNone(t, events)
+}
+
+func TestFirehoseReorg(t *testing.T) {
+	chain, db := newTestChain(t)
+	var (
+		blocks = generate(chain, db, 3, -1)
+		fork   = generate(chain, db, 4, 1)
+	)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	// The blocks streamed before the reorg are retracted
+	cursor := &Cursor{Number: 3, Hash: blocks[2].Hash(), Kind: KindBlock}
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	events, _ := subscribe(t, chain, db, &Options{Cursor: cursor})
+	received := expect(t, events, []string{KindReorg, KindBlock}, []uint64{1, 2})
+	reorg := received[0]
+	if reorg.Hash != blocks[0].Hash() || reorg.Cursor.Kind != KindTraces || reorg.Cursor.Hash != blocks[0].Hash() {
+		t.Fatalf("reorg event mismatch: %+v", reorg)
+	}
+	removed := reorg.Data.(map[string]interface{})["removed"].([]interface{})
+	if len(removed) != 2 || common.HexToHash(removed[0].(string)) != blocks[2].Hash() || common.HexToHash(removed[1].(string)) != blocks[1].Hash() {
+		t.Fatalf("removed blocks mismatch: %v", removed)
+	}
+	if received[1].Hash != fork[1].Hash() {
+		t.Fatalf("fork block mismatch: have %x, want %x", received[1].Hash, fork[1].Hash())
+	}
+}
+
+func TestFirehoseFinality(t *testing.T) {
+	chain, db := newTestChain(t)
+	blocks := generate(chain, db, 2, -1)
+	if _, err := chain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	from := hexutil.Uint64(1)
+	events, _ := subscribe(t, chain, db, &Options{FromBlock: &from})
+	expect(t, events, []string{KindBlock, KindReceipts, KindTraces}, []uint64{1, 1, 1})
+
+	// The finality updates are streamed after their block
+	chain.finality.Send(core.NewJustifiedOrFinalizedBlockEvent{JF: &types.BlockStatus{BlockNumber: big.NewInt(2), Hash: blocks[1].Hash(), Status: types.BasFinalized}})
+	expectNone(t, events)
+	if _, err := chain.InsertChain(blocks[1:]); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	received := expect(t, events, []string{KindBlock, KindReceipts, KindTraces, KindFinality}, []uint64{2, 2, 2, 2})
+	finality := received[3]
+	if finality.Hash != blocks[1].Hash() || finality.Data.(map[string]interface{})["status"] != "finalized" || finality.Cursor != received[2].Cursor {
+		t.Fatalf("finality event mismatch: %+v", finality)
+	}
+}
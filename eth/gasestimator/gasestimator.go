@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -43,7 +44,38 @@ type Options struct {
 	Header *types.Header       // Header defining the block context to execute in
 	State  *state.StateDB      // Pre-state on top of which to estimate the gas
 
+	AccessFilter vm.EvmAccessFilter // Access filter of the consensus engine, nil if none
+
 	ErrorRatio float64 // Allowed overestimation ratio for faster estimation termination
+
+	Timeout     time.Duration // Wall-clock time each execution may take, 0 = unlimited
+	MemoryLimit uint64        // EVM memory each execution may allocate, 0 = unlimited
+}
+
+// ErrExecutionTimeout is returned if an execution of the estimation exceeds its
+// timeout.
+var ErrExecutionTimeout = errors.New("execution timeout")
+
+// AccessDeniedError is returned if the execution fails on a denial of the access
+// filter, as it would on chain whatever the gas limit.
+type AccessDeniedError struct {
+	Denial *types.AccessDenial // First denial met by the execution
+	Err    error               // Failure of the execution
+}
+
+func (e *AccessDeniedError) Error() string {
+	msg := fmt.Sprintf("access denied (%s %s)", e.Denial.Kind, e.Denial.Address.Hex())
+	if e.Denial.Rule != "" {
+		msg += ": " + e.Denial.Rule
+	}
+	if !errors.Is(e.Err, types.ErrAddressDenied) {
+		msg += ", execution failed: " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *AccessDeniedError) Unwrap() error {
+	return types.ErrAddressDenied
 }
 
 // Estimate returns the lowest possible gas limit that allows the transaction to
@@ -219,14 +251,22 @@ func run(ctx context.Context, call *core.Message, opts *Options) (*core.Executio
 	var (
 		msgContext = core.NewEVMTxContext(call)
 		evmContext = core.NewEVMBlockContext(opts.Header, opts.Chain, nil)
+	)
+	evmContext.AccessFilter = opts.AccessFilter
 
+	var (
 		dirtyState = opts.State.Copy()
-		evm        = vm.NewEVM(evmContext, msgContext, dirtyState, opts.Config, vm.Config{NoBaseFee: true})
+		evm        = vm.NewEVM(evmContext, msgContext, dirtyState, opts.Config, vm.Config{NoBaseFee: true, MemoryLimit: opts.MemoryLimit})
 	)
 	// Monitor the outer context and interrupt the EVM upon cancellation. To avoid
 	// a dangling goroutine until the outer estimation finishes, create an internal
 	// context for the lifetime of this method call.
-	ctx, cancel := context.WithCancel(ctx)
+	var cancel context.CancelFunc
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
 	defer cancel()
 
 	go func() {
@@ -238,8 +278,23 @@ func run(ctx context.Context, call *core.Message, opts *Options) (*core.Executio
 	if vmerr := dirtyState.Error(); vmerr != nil {
 		return nil, vmerr
 	}
+	// An aborted execution is neither a success nor a lack of gas
+	if evm.MemoryLimitExceeded() {
+		return nil, vm.ErrMemoryLimit
+	}
+	if evm.Cancelled() {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && opts.Timeout > 0 {
+			return nil, ErrExecutionTimeout
+		}
+		return nil, ctx.Err()
+	}
 	if err != nil {
 		return result, fmt.Errorf("failed with %d gas: %w", call.GasLimit, err)
 	}
+	// A denied access fails the frame it occurs in whatever the gas, so the
+	// failures it leads to can't be fixed by raising the limit
+	if denial := evm.AccessDenial(); denial != nil && result.Failed() && !errors.Is(result.Err, vm.ErrOutOfGas) {
+		return nil, &AccessDeniedError{Denial: denial, Err: result.Err}
+	}
 	return result, nil
 }
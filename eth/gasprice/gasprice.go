@@ -48,6 +48,7 @@ type Config struct {
 	Default          *big.Int `toml:",omitempty"`
 	MaxPrice         *big.Int `toml:",omitempty"`
 	IgnorePrice      *big.Int `toml:",omitempty"`
+	Strategy         string   `toml:",omitempty"` // Strategy of the tip suggestions, see the Strategy constants
 
 	PredConfig
 }
@@ -66,6 +67,7 @@ type OracleBackend interface {
 // blocks. Suitable for both light and full clients.
 type Oracle struct {
 	backend     OracleBackend
+	pool        PoolBackend // Pool steering the PoSA strategy, nil when sampling
 	lastHead    common.Hash
 	lastPrice   *big.Int
 	maxPrice    *big.Int
@@ -107,6 +109,11 @@ func NewOracle(backend OracleBackend, params Config) *Oracle {
 	} else if ignorePrice.Int64() > 0 {
 		log.Info("Gasprice oracle is ignoring threshold set", "threshold", ignorePrice)
 	}
+	switch params.Strategy {
+	case StrategyAuto, StrategySample, StrategyPoSA:
+	default:
+		log.Warn("Sanitizing invalid gasprice oracle strategy", "provided", params.Strategy, "updated", StrategyAuto)
+	}
 	maxHeaderHistory := params.MaxHeaderHistory
 	if maxHeaderHistory < 1 {
 		maxHeaderHistory = 1
@@ -171,6 +178,18 @@ func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
 	if headHash == lastHead {
 		return new(big.Int).Set(lastPrice), nil
 	}
+	if oracle.pool != nil {
+		price, err := oracle.posaTipCap(ctx, head, lastHead, lastPrice)
+		if err != nil {
+			return nil, err
+		}
+		oracle.cacheLock.Lock()
+		oracle.lastHead = headHash
+		oracle.lastPrice = price
+		oracle.cacheLock.Unlock()
+
+		return new(big.Int).Set(price), nil
+	}
 	var (
 		sent, exp int
 		number    = head.Number.Uint64()
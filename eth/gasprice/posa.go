@@ -0,0 +1,178 @@
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+)
+
+// Strategies of the gas price oracle.
+const (
+	StrategyAuto   = ""       // PoSA strategy on the chains sealed by the turbo engine, sampling otherwise
+	StrategySample = "sample" // Percentile of the tips sampled from the recent blocks
+	StrategyPoSA   = "posa"   // Tuned for the fixed block times of the PoSA chains
+)
+
+// posaDecay is the fraction of the previous suggestion the PoSA strategy may
+// give up per block, so that bursts don't make the suggestions oscillate.
+const posaDecay = 8
+
+// PoolBackend is the transaction pool whose congestion and minimum tip steer
+// the PoSA strategy.
+type PoolBackend interface {
+	GasTip() *big.Int
+	Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction
+}
+
+// UsePoSA switches the oracle to the PoSA strategy. With the blocks sealed at
+// fixed times by a known validator set, inclusion only costs the minimum tip
+// of the validators unless the demand exceeds the block space, which is read
+// from the base fee trajectory and the backlog of the pool.
+func (oracle *Oracle) UsePoSA(pool PoolBackend) {
+	oracle.pool = pool
+}
+
+// posaTipCap computes the tip cap suggested by the PoSA strategy on top of the
+// given head. The suggestion is the highest of:
+//   - the minimum tip of the pool and the one signaled by the validators, i.e.
+//     the lowest tip each of them included into a block with spare gas,
+//   - the configured percentile of the recent tips if the base fee rose in most
+//     of the checked blocks,
+//   - the lowest tip still fitting into the next block if the pending
+//     transactions exceed its gas limit.
+//
+// A suggestion lower than the one of the parent head only decays towards it.
+func (oracle *Oracle) posaTipCap(ctx context.Context, head *types.Header, lastHead common.Hash, lastPrice *big.Int) (*big.Int, error) {
+	var (
+		config  = oracle.backend.ChainConfig()
+		floor   = new(big.Int).Set(oracle.pool.GasTip())
+		signals = make(map[common.Address]*big.Int)
+		tips    []*big.Int
+
+		number       = head.Number.Uint64()
+		child        *big.Int
+		rises, steps int
+	)
+	for i := 0; i < oracle.checkBlocks && number > 0; i, number = i+1, number-1 {
+		block, err := oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(number))
+		if block == nil {
+			return nil, err
+		}
+		if baseFee := block.BaseFee(); baseFee != nil && child != nil {
+			steps++
+			if child.Cmp(baseFee) > 0 {
+				rises++
+			}
+		}
+		child = block.BaseFee()
+
+		values := oracle.blockTips(block)
+		if len(values) == 0 {
+			continue
+		}
+		tips = append(tips, values[:min(len(values), sampleNumber)]...)
+		if block.GasUsed() < block.GasLimit()/config.ElasticityMultiplier() {
+			if signal := signals[block.Coinbase()]; signal == nil || values[0].Cmp(signal) < 0 {
+				signals[block.Coinbase()] = values[0]
+			}
+		}
+	}
+	price := floor
+	for _, signal := range signals {
+		price = bigMax(price, signal)
+	}
+	if rises*2 > steps && len(tips) > 0 {
+		slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+		price = bigMax(price, tips[(len(tips)-1)*oracle.percentile/100])
+	}
+	var baseFee *big.Int
+	if next := new(big.Int).Add(head.Number, common.Big1); config.IsLondon(next) {
+		baseFee = eip1559.CalcBaseFee(config, head)
+	}
+	if marginal := oracle.marginalTip(floor, baseFee, head.GasLimit); marginal != nil {
+		price = bigMax(price, marginal)
+	}
+	if lastPrice != nil && lastHead == head.ParentHash {
+		price = bigMax(price, new(big.Int).Sub(lastPrice, new(big.Int).Div(lastPrice, big.NewInt(posaDecay))))
+	}
+	if price.Cmp(oracle.maxPrice) > 0 {
+		price = new(big.Int).Set(oracle.maxPrice)
+	}
+	return price, nil
+}
+
+// blockTips returns the effective tips of the transactions of the block in
+// ascending order, skipping the ones sent by the validator sealing it and the
+// ones below the ignored price.
+func (oracle *Oracle) blockTips(block *types.Block) []*big.Int {
+	var (
+		signer  = types.MakeSigner(oracle.backend.ChainConfig(), block.Number(), block.Time())
+		baseFee = block.BaseFee()
+		tips    []*big.Int
+	)
+	for _, tx := range block.Transactions() {
+		tip, _ := tx.EffectiveGasTip(baseFee)
+		if oracle.ignorePrice != nil && tip.Cmp(oracle.ignorePrice) < 0 {
+			continue
+		}
+		if sender, err := types.Sender(signer, tx); err != nil || sender == block.Coinbase() {
+			continue
+		}
+		tips = append(tips, tip)
+	}
+	slices.SortFunc(tips, func(a, b *big.Int) int { return a.Cmp(b) })
+	return tips
+}
+
+// marginalTip returns the tip of the pending transaction overflowing the next
+// block if the ones paying more fill it, nil if the pool has spare room.
+func (oracle *Oracle) marginalTip(minTip *big.Int, baseFee *big.Int, gasLimit uint64) *big.Int {
+	filter := txpool.PendingFilter{MinTip: uint256.MustFromBig(minTip)}
+	if baseFee != nil {
+		filter.BaseFee = uint256.MustFromBig(baseFee)
+	}
+	type pending struct {
+		tip *big.Int
+		gas uint64
+	}
+	var txs []pending
+	for _, list := range oracle.pool.Pending(filter) {
+		for _, tx := range list {
+			tip := tx.GasTipCap.ToBig()
+			if baseFee != nil {
+				tip = bigMin(tip, new(big.Int).Sub(tx.GasFeeCap.ToBig(), baseFee))
+			}
+			txs = append(txs, pending{tip, tx.Gas})
+		}
+	}
+	slices.SortFunc(txs, func(a, b pending) int { return b.tip.Cmp(a.tip) })
+
+	var gas uint64
+	for _, tx := range txs {
+		if gas += tx.gas; gas > gasLimit {
+			return tx.tip
+		}
+	}
+	return nil
+}
+
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func bigMin(a, b *big.Int) *big.Int {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,95 @@
+package gasprice
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+)
+
+type testPool struct {
+	tip     *big.Int
+	pending []*txpool.LazyTransaction
+}
+
+func (p *testPool) GasTip() *big.Int { return p.tip }
+
+func (p *testPool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
+	return map[common.Address][]*txpool.LazyTransaction{{}: p.pending}
+}
+
+func gweis(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.GWei))
+}
+
+func TestPoSATipCap(t *testing.T) {
+	backend := newTestBackend(t, big.NewInt(0), nil, false)
+	defer backend.teardown()
+
+	head, _ := backend.HeaderByNumber(context.Background(), rpc.LatestBlockNumber)
+	config := Config{Blocks: 5, Percentile: 60}
+
+	// The blocks sealed by the single validator have spare gas and tips
+	// decreasing with their age, signaling the lowest one as its minimum.
+	pending := func(tips ...int64) []*txpool.LazyTransaction {
+		var txs []*txpool.LazyTransaction
+		for _, tip := range tips {
+			txs = append(txs, &txpool.LazyTransaction{
+				GasTipCap: uint256.MustFromBig(gweis(tip)),
+				GasFeeCap: uint256.MustFromBig(gweis(1000)),
+				Gas:       head.GasLimit / 2,
+			})
+		}
+		return txs
+	}
+	tests := []struct {
+		pool *testPool
+		want *big.Int
+	}{
+		// Signaled by the validator
+		{&testPool{tip: gweis(1)}, gweis(28)},
+		// Minimum tip of the pool
+		{&testPool{tip: gweis(40)}, gweis(40)},
+		// Pending transactions fitting into the next block
+		{&testPool{tip: gweis(1), pending: pending(100, 60)}, gweis(28)},
+		// Pending transactions overflowing the next block
+		{&testPool{tip: gweis(1), pending: pending(10, 100, 50, 60)}, gweis(50)},
+	}
+	for i, c := range tests {
+		oracle := NewOracle(backend, config)
+		oracle.UsePoSA(c.pool)
+
+		got, err := oracle.SuggestTipCap(context.Background())
+		if err != nil {
+			t.Fatalf("test %d: failed to suggest tip cap: %v", i, err)
+		}
+		if got.Cmp(c.want) != 0 {
+			t.Errorf("test %d: tip cap mismatch: have %v, want %v", i, got, c.want)
+		}
+	}
+}
+
+func TestPoSATipCapDecay(t *testing.T) {
+	backend := newTestBackend(t, big.NewInt(0), nil, false)
+	defer backend.teardown()
+
+	head, _ := backend.HeaderByNumber(context.Background(), rpc.LatestBlockNumber)
+	oracle := NewOracle(backend, Config{Blocks: 5, Percentile: 60})
+	oracle.UsePoSA(&testPool{tip: gweis(1)})
+
+	// A suggestion made on the parent head only decays
+	oracle.lastHead, oracle.lastPrice = head.ParentHash, gweis(80)
+	if got, _ := oracle.SuggestTipCap(context.Background()); got.Cmp(gweis(70)) != 0 {
+		t.Fatalf("decayed tip cap mismatch: have %v, want %v", got, gweis(70))
+	}
+	// The ones made on unrelated heads are ignored
+	oracle.lastHead, oracle.lastPrice = common.Hash{0x01}, gweis(80)
+	if got, _ := oracle.SuggestTipCap(context.Background()); got.Cmp(gweis(28)) != 0 {
+		t.Fatalf("tip cap mismatch: have %v, want %v", got, gweis(28))
+	}
+}
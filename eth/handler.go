@@ -17,6 +17,7 @@
 package eth
 
 import (
+	"context"
 	"errors"
 	"math"
 	"math/big"
@@ -199,7 +200,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 	heighter := func() uint64 {
 		return h.chain.CurrentBlock().Number.Uint64()
 	}
-	inserter := func(blocks types.Blocks) (int, error) {
+	inserter := func(ctx context.Context, blocks types.Blocks) (int, error) {
 		// If sync hasn't reached the checkpoint yet, deny importing weird blocks.
 		//
 		// Ideally we would also compare the head block's timestamp and similarly reject
@@ -219,7 +220,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 			log.Warn("Snap syncing, discarded propagated block", "number", blocks[0].Number(), "hash", blocks[0].Hash())
 			return 0, nil
 		}
-		n, err := h.chain.InsertChain(blocks)
+		n, err := h.chain.InsertChainWithContext(ctx, blocks)
 		if err == nil {
 			h.synced.Store(true)
 		}
@@ -0,0 +1,66 @@
+package eth
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/turbo"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// handoverGrace is the time the shutdown waits past the in-turn slot of the
+// local validator for its block to be sealed and imported.
+const handoverGrace = time.Second
+
+// handover hands the slots of the local validator over before shutting down,
+// so that rolling upgrades neither miss blocks nor get the validators lazy
+// punished. The block of the next slot is still sealed if the validator is in
+// turn for it, otherwise the sealing stops at the head and the slot is skipped
+// cleanly, without a block broadcast while the node goes down.
+func (s *Ethereum) handover() {
+	engine, ok := s.engine.(*turbo.Turbo)
+	if !ok || !s.IsMining() {
+		return
+	}
+	head := s.blockchain.CurrentBlock()
+	_, inturn, err := engine.InTurn(s.blockchain, head, engine.CurrentValidator())
+	if err != nil || !inturn {
+		engine.StopSealing(head.Number.Uint64())
+		return
+	}
+	number := head.Number.Uint64() + 1
+	engine.StopSealing(number)
+
+	heads := make(chan core.ChainHeadEvent, 1)
+	sub := s.blockchain.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	slot := time.Unix(int64(head.Time+s.blockchain.Config().Turbo.PeriodAt(number)), 0)
+	timeout := time.NewTimer(time.Until(slot) + handoverGrace)
+	defer timeout.Stop()
+
+	log.Info("Finishing in-turn slot before shutdown", "number", number, "wait", common.PrettyDuration(time.Until(slot)))
+	for s.blockchain.CurrentBlock().Number.Uint64() < number {
+		select {
+		case <-heads:
+		case <-timeout.C:
+			engine.StopSealing(head.Number.Uint64())
+			log.Warn("In-turn block not sealed in time, skipping slot", "number", number)
+			return
+		}
+	}
+	log.Info("Sealed in-turn block before shutdown", "number", number)
+}
+
+// signText signs a text message with the key of the validator, used by the
+// failover to sign the handovers.
+func (s *Ethereum) signText(val common.Address, text []byte) ([]byte, error) {
+	account := accounts.Account{Address: val}
+	wallet, err := s.accountManager.Find(account)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.SignText(account, text)
+}
@@ -0,0 +1,130 @@
+package eth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/iosched"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ioThrottleGrace is the time the background I/O stays throttled past the
+// in-turn slot, for the sealed block to be imported.
+const ioThrottleGrace = time.Second
+
+// ioThrottleChain is the chain whose heads schedule the background I/O.
+type ioThrottleChain interface {
+	consensus.ChainHeaderReader
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+}
+
+// ioThrottleEngine is the engine telling the turns of the local validator.
+type ioThrottleEngine interface {
+	inTurnChecker
+	CurrentValidator() common.Address
+}
+
+// ioThrottler throttles the background disk I/O of the node while the local
+// validator is in turn: the snapshot generation, the compactions and the index
+// pruning pause until the in-turn block is imported or its slot is over, so
+// that they don't delay the sealing.
+type ioThrottler struct {
+	chain  ioThrottleChain
+	engine ioThrottleEngine
+	mining func() bool
+	now    func() time.Time
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newIOThrottler(chain ioThrottleChain, engine ioThrottleEngine, mining func() bool) *ioThrottler {
+	return &ioThrottler{
+		chain:  chain,
+		engine: engine,
+		mining: mining,
+		now:    time.Now,
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start launches the scheduling of the background I/O.
+func (t *ioThrottler) Start() {
+	t.wg.Add(1)
+	go t.loop()
+}
+
+// Stop terminates the scheduling, resuming the background I/O at full speed.
+func (t *ioThrottler) Stop() {
+	close(t.quit)
+	t.wg.Wait()
+}
+
+func (t *ioThrottler) loop() {
+	defer t.wg.Done()
+	defer iosched.Release()
+
+	heads := make(chan core.ChainHeadEvent, 1)
+	sub := t.chain.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	expiry := time.NewTimer(0)
+	defer expiry.Stop()
+	<-expiry.C
+
+	head := t.chain.CurrentHeader()
+	for {
+		if until, ok := t.schedule(head); ok {
+			expiry.Reset(until)
+		}
+		select {
+		case ev := <-heads:
+			head = ev.Block.Header()
+			if !expiry.Stop() {
+				select {
+				case <-expiry.C:
+				default:
+				}
+			}
+		case <-expiry.C:
+			log.Debug("In-turn slot over, resuming background I/O", "number", head.Number.Uint64()+1)
+			iosched.Release()
+		case <-sub.Err():
+			return
+		case <-t.quit:
+			return
+		}
+	}
+}
+
+// schedule throttles the background I/O if the local validator is in turn for
+// the block on top of the head, releasing it otherwise. It returns the time
+// left until the throttle expires with the in-turn slot.
+func (t *ioThrottler) schedule(head *types.Header) (time.Duration, bool) {
+	if !t.mining() {
+		iosched.Release()
+		return 0, false
+	}
+	_, inturn, err := t.engine.InTurn(t.chain, head, t.engine.CurrentValidator())
+	if err != nil || !inturn {
+		iosched.Release()
+		return 0, false
+	}
+	number := head.Number.Uint64() + 1
+	slot := time.Unix(int64(head.Time+t.chain.Config().Turbo.PeriodAt(number)), 0)
+	until := slot.Sub(t.now()) + ioThrottleGrace
+	if until <= 0 {
+		iosched.Release()
+		return 0, false
+	}
+	if !iosched.Throttled() {
+		log.Debug("In turn, throttling background I/O", "number", number, "slot", common.PrettyDuration(until))
+	}
+	iosched.Throttle()
+	return until, true
+}
@@ -0,0 +1,233 @@
+// Package localaccess implements an operator controlled access list merged with
+// the on-chain access filter for the transaction pool and the RPC calls of the
+// local node.
+//
+// The list is read from a JSON file reloaded whenever it changes, e.g.
+//
+//	{
+//	  "deny":  ["0x1111111111111111111111111111111111111111"],
+//	  "allow": ["0x2222222222222222222222222222222222222222"]
+//	}
+//
+// A denied address can neither send nor receive transactions accepted by the
+// pool, nor be touched by calls served over RPC. The "*" deny entry denies all
+// the addresses but the allowed ones, the allow entries being exceptions to the
+// local denials only. The local list never relaxes the on-chain access filter
+// and isn't used by the block production and validation, so it can't affect
+// consensus.
+package localaccess
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// reloadInterval is the interval of the checks for changes of the list file.
+const reloadInterval = 3 * time.Second
+
+// denyAll is the deny entry denying all the addresses not allowed.
+const denyAll = "*"
+
+// listFile is the content of the list file.
+type listFile struct {
+	Deny  []string `json:"deny"`
+	Allow []string `json:"allow"`
+}
+
+// list is a loaded access list.
+type list struct {
+	denyAll bool
+	deny    map[common.Address]struct{}
+	allow   map[common.Address]struct{}
+}
+
+// denied reports whether the list denies the address.
+func (l *list) denied(addr common.Address) bool {
+	if _, ok := l.allow[addr]; ok {
+		return false
+	}
+	if l.denyAll {
+		return true
+	}
+	_, ok := l.deny[addr]
+	return ok
+}
+
+// parseList decodes the content of a list file.
+func parseList(blob []byte) (*list, error) {
+	var file listFile
+	if err := json.Unmarshal(blob, &file); err != nil {
+		return nil, err
+	}
+	l := &list{
+		deny:  make(map[common.Address]struct{}),
+		allow: make(map[common.Address]struct{}),
+	}
+	for _, entry := range file.Deny {
+		if entry == denyAll {
+			l.denyAll = true
+			continue
+		}
+		if !common.IsHexAddress(entry) {
+			return nil, fmt.Errorf("invalid deny entry %q", entry)
+		}
+		l.deny[common.HexToAddress(entry)] = struct{}{}
+	}
+	for _, entry := range file.Allow {
+		if !common.IsHexAddress(entry) {
+			return nil, fmt.Errorf("invalid allow entry %q", entry)
+		}
+		l.allow[common.HexToAddress(entry)] = struct{}{}
+	}
+	return l, nil
+}
+
+// List is the local access list loaded from a file, reloaded when the file
+// changes. A file failing to load leaves the previous list in place.
+type List struct {
+	file    string
+	list    atomic.Pointer[list]
+	modTime time.Time
+	size    int64
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New loads the local access list from the file.
+func New(file string) (*List, error) {
+	l := &List{file: file, quit: make(chan struct{})}
+	if _, err := l.reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// reload loads the list file if it changed since the last load, reporting
+// whether it did.
+func (l *List) reload() (bool, error) {
+	info, err := os.Stat(l.file)
+	if err != nil {
+		return false, err
+	}
+	if l.list.Load() != nil && info.ModTime().Equal(l.modTime) && info.Size() == l.size {
+		return false, nil
+	}
+	blob, err := os.ReadFile(l.file)
+	if err != nil {
+		return false, err
+	}
+	parsed, err := parseList(blob)
+	if err != nil {
+		return false, fmt.Errorf("invalid local access list %s: %v", l.file, err)
+	}
+	l.list.Store(parsed)
+	l.modTime, l.size = info.ModTime(), info.Size()
+
+	log.Info("Loaded local access list", "file", l.file, "deny", len(parsed.deny), "denyall", parsed.denyAll, "allow", len(parsed.allow))
+	return true, nil
+}
+
+// Start launches the reloading of the list file.
+func (l *List) Start() {
+	l.wg.Add(1)
+	go l.loop()
+}
+
+// Stop terminates the reloading of the list file.
+func (l *List) Stop() {
+	close(l.quit)
+	l.wg.Wait()
+}
+
+func (l *List) loop() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := l.reload(); err != nil {
+				log.Error("Failed to reload local access list, keeping the previous one", "err", err)
+			}
+		case <-l.quit:
+			return
+		}
+	}
+}
+
+// Denied reports whether the local list denies the address.
+func (l *List) Denied(addr common.Address) bool {
+	return l.list.Load().denied(addr)
+}
+
+// TxFilter returns the transaction pool filter rejecting the transactions from
+// or to the locally denied addresses, and those rejected by the given filter,
+// which may be nil.
+func (l *List) TxFilter(filter txpool.TxFilter) txpool.TxFilter {
+	return &txFilter{list: l, filter: filter}
+}
+
+// EvmAccessFilter returns the access filter of the calls served over RPC,
+// denying the locally denied addresses and those denied by the given filter,
+// which may be nil.
+func (l *List) EvmAccessFilter(filter vm.EvmAccessFilter) vm.EvmAccessFilter {
+	return &evmAccessFilter{list: l, filter: filter}
+}
+
+type txFilter struct {
+	list   *List
+	filter txpool.TxFilter
+}
+
+// FilterTx implements txpool.TxFilter.
+func (f *txFilter) FilterTx(sender common.Address, tx *types.Transaction, header *types.Header, parentState *state.StateDB) error {
+	if f.list.Denied(sender) || (tx.To() != nil && f.list.Denied(*tx.To())) {
+		return types.ErrAddressDenied
+	}
+	if f.filter == nil {
+		return nil
+	}
+	return f.filter.FilterTx(sender, tx, header, parentState)
+}
+
+// CanCreate implements txpool.TxFilter.
+func (f *txFilter) CanCreate(state consensus.StateReader, addr common.Address, isContract bool, height *big.Int) bool {
+	if f.filter == nil {
+		return true
+	}
+	return f.filter.CanCreate(state, addr, isContract, height)
+}
+
+type evmAccessFilter struct {
+	list   *List
+	filter vm.EvmAccessFilter
+}
+
+// IsAddressDenied implements vm.EvmAccessFilter.
+func (f *evmAccessFilter) IsAddressDenied(address common.Address, cType common.AddressCheckType) bool {
+	if f.list.Denied(address) {
+		return true
+	}
+	return f.filter != nil && f.filter.IsAddressDenied(address, cType)
+}
+
+// IsLogDenied implements vm.EvmAccessFilter.
+func (f *evmAccessFilter) IsLogDenied(log *types.Log) bool {
+	return f.filter != nil && f.filter.IsLogDenied(log)
+}
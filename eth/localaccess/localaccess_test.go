@@ -0,0 +1,139 @@
+package localaccess
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	alice = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	bob   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	carol = common.HexToAddress("0x3333333333333333333333333333333333333333")
+)
+
+// chainFilter is an on-chain access filter denying a single address.
+type chainFilter struct {
+	denied common.Address
+}
+
+func (f *chainFilter) FilterTx(sender common.Address, tx *types.Transaction, header *types.Header, parentState *state.StateDB) error {
+	if sender == f.denied || (tx.To() != nil && *tx.To() == f.denied) {
+		return types.ErrAddressDenied
+	}
+	return nil
+}
+
+func (f *chainFilter) CanCreate(state consensus.StateReader, addr common.Address, isContract bool, height *big.Int) bool {
+	return true
+}
+
+func (f *chainFilter) IsAddressDenied(address common.Address, cType common.AddressCheckType) bool {
+	return address == f.denied
+}
+
+func (f *chainFilter) IsLogDenied(log *types.Log) bool {
+	return false
+}
+
+func writeList(t *testing.T, file string, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func transfer(to common.Address) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{To: &to, Gas: 21000, GasPrice: big.NewInt(1)})
+}
+
+// Tests that the local denials are merged with the on-chain ones, the allowed
+// addresses only being exempt from the local ones.
+func TestFilters(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "access.json")
+	writeList(t, file, `{"deny": ["`+alice.Hex()+`"], "allow": ["`+carol.Hex()+`"]}`)
+
+	list, err := New(file)
+	if err != nil {
+		t.Fatalf("failed to load list: %v", err)
+	}
+	var (
+		chain     = &chainFilter{denied: carol}
+		txFilter  = list.TxFilter(chain)
+		evmFilter = list.EvmAccessFilter(chain)
+	)
+	tests := []struct {
+		from, to common.Address
+		denied   bool
+	}{
+		{from: bob, to: bob},
+		{from: alice, to: bob, denied: true},
+		{from: bob, to: alice, denied: true},
+		{from: carol, to: bob, denied: true}, // Denied on-chain, not relaxed locally
+	}
+	for i, tt := range tests {
+		err := txFilter.FilterTx(tt.from, transfer(tt.to), nil, nil)
+		if denied := errors.Is(err, types.ErrAddressDenied); denied != tt.denied {
+			t.Errorf("test %d: pool denial mismatch: have %v, want %v", i, err, tt.denied)
+		}
+		denied := evmFilter.IsAddressDenied(tt.from, common.CheckFrom) || evmFilter.IsAddressDenied(tt.to, common.CheckTo)
+		if denied != tt.denied {
+			t.Errorf("test %d: call denial mismatch: have %v, want %v", i, denied, tt.denied)
+		}
+	}
+	// The filters also work without on-chain access filter
+	if err := list.TxFilter(nil).FilterTx(alice, transfer(bob), nil, nil); !errors.Is(err, types.ErrAddressDenied) {
+		t.Errorf("locally denied sender accepted without on-chain filter: %v", err)
+	}
+	if list.EvmAccessFilter(nil).IsAddressDenied(bob, common.CheckTo) {
+		t.Errorf("address denied without being listed")
+	}
+}
+
+// Tests that changes of the list file are picked up, and that invalid ones
+// leave the previous list in place.
+func TestReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "access.json")
+	writeList(t, file, `{"deny": ["`+alice.Hex()+`"]}`)
+
+	list, err := New(file)
+	if err != nil {
+		t.Fatalf("failed to load list: %v", err)
+	}
+	if !list.Denied(alice) || list.Denied(bob) {
+		t.Fatalf("initial list not loaded")
+	}
+	// Deny all but bob
+	writeList(t, file, `{"deny": ["*"], "allow": ["`+bob.Hex()+`"]}`)
+	os.Chtimes(file, time.Now(), time.Now().Add(time.Second))
+	if reloaded, err := list.reload(); !reloaded || err != nil {
+		t.Fatalf("changed list not reloaded: %v", err)
+	}
+	if !list.Denied(alice) || !list.Denied(carol) || list.Denied(bob) {
+		t.Errorf("deny all list not applied")
+	}
+	if reloaded, err := list.reload(); reloaded || err != nil {
+		t.Errorf("unchanged list reloaded: %v", err)
+	}
+	// An invalid list keeps the previous one
+	writeList(t, file, `{"deny": ["0xinvalid"]}`)
+	os.Chtimes(file, time.Now(), time.Now().Add(2*time.Second))
+	if _, err := list.reload(); err == nil {
+		t.Errorf("invalid list loaded")
+	}
+	if !list.Denied(alice) || list.Denied(bob) {
+		t.Errorf("previous list not kept")
+	}
+	if _, err := New(file); err == nil {
+		t.Errorf("invalid list accepted at startup")
+	}
+}
@@ -475,6 +475,7 @@ type Syncer struct {
 	storageBytes   common.StorageSize // Number of storage trie bytes persisted to disk
 
 	extProgress *SyncProgress // progress that can be exposed to external caller.
+	extFill     float64       // fraction of the account hash space retrieved, exposed to external caller.
 
 	// Request tracking during healing phase
 	trienodeHealIdlers map[string]struct{} // Peers that aren't serving trie node requests
@@ -712,6 +713,7 @@ func (s *Syncer) Sync(root common.Hash, cancel chan struct{}) error {
 			BytecodeHealSynced: s.bytecodeHealSynced,
 			BytecodeHealBytes:  s.bytecodeHealBytes,
 		}
+		s.extFill = s.accountFill()
 		s.lock.Unlock()
 		// Wait for something to happen
 		select {
@@ -938,6 +940,29 @@ func (s *Syncer) Progress() (*SyncProgress, *SyncPending) {
 	return s.extProgress, pending
 }
 
+// AccountFill returns the fraction of the account hash space already retrieved
+// and whether the account ranges are complete and the sync is healing.
+func (s *Syncer) AccountFill() (float64, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.extFill, s.snapped
+}
+
+// accountFill calculates the fraction of the account hash space covered by the
+// completed parts of the account tasks.
+func (s *Syncer) accountFill() float64 {
+	if len(s.tasks) == 0 {
+		return 1
+	}
+	gaps := new(big.Int)
+	for _, task := range s.tasks {
+		gaps.Add(gaps, new(big.Int).Sub(task.Last.Big(), task.Next.Big()))
+	}
+	fill, _ := new(big.Rat).SetFrac(new(big.Int).Sub(hashSpace, gaps), hashSpace).Float64()
+	return fill
+}
+
 // cleanAccountTasks removes account range retrieval tasks that have already been
 // completed.
 func (s *Syncer) cleanAccountTasks() {
@@ -0,0 +1,203 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+const (
+	// maxSimulatedTxs is the maximum number of transactions simulated at once.
+	maxSimulatedTxs = 100
+
+	// defaultSimulateTimeout is the time the simulation of the transactions
+	// may take if not configured, within the trace timeout of the node.
+	defaultSimulateTimeout = 5 * time.Second
+
+	// defaultSimulateTracer traces the simulated transactions if no tracer is
+	// configured.
+	defaultSimulateTracer = "callTracer"
+)
+
+var errNoPendingBlock = errors.New("pending block not available")
+
+// SimulateConfig configures the simulation of transactions.
+type SimulateConfig struct {
+	Tracer       *string         `json:"tracer"` // Tracer of the transactions, the call tracer by default, none if empty
+	TracerConfig json.RawMessage `json:"tracerConfig"`
+	Timeout      *string         `json:"timeout"` // Time the whole simulation may take
+}
+
+// SimulatedTx is the outcome of a simulated transaction.
+type SimulatedTx struct {
+	TxHash            common.Hash         `json:"transactionHash"`
+	From              common.Address      `json:"from"`
+	Status            hexutil.Uint64      `json:"status"`
+	GasUsed           hexutil.Uint64      `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64      `json:"cumulativeGasUsed"` // Within the pending block
+	ContractAddress   *common.Address     `json:"contractAddress,omitempty"`
+	Logs              []*types.Log        `json:"logs"`
+	AccessDenied      *types.AccessDenial `json:"accessDenied,omitempty"`
+	Trace             json.RawMessage     `json:"trace,omitempty"`
+	Error             string              `json:"error,omitempty"` // Why the transaction can't be included, the other fields being empty
+}
+
+// SimulatedPending is the outcome of transactions simulated on top of the
+// pending block.
+type SimulatedPending struct {
+	BlockNumber  hexutil.Uint64 `json:"blockNumber"`
+	ParentHash   common.Hash    `json:"parentHash"`
+	Transactions []*SimulatedTx `json:"transactions"`
+}
+
+// SimulatePending executes the signed transactions in order on top of the
+// pending block being assembled, with its access filters, returning their
+// receipts and traces as if they were included next. The transactions which
+// can't be included, e.g. for a wrong nonce or the block being full, are
+// reported failed and skipped. Nothing is submitted to the pool.
+func (api *NeroAPI) SimulatePending(ctx context.Context, txs []hexutil.Bytes, config *SimulateConfig) (*SimulatedPending, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("no transactions to simulate")
+	}
+	if len(txs) > maxSimulatedTxs {
+		return nil, fmt.Errorf("too many transactions, max %d", maxSimulatedTxs)
+	}
+	decoded := make([]*types.Transaction, len(txs))
+	for i, raw := range txs {
+		decoded[i] = new(types.Transaction)
+		if err := decoded[i].UnmarshalBinary(raw); err != nil {
+			return nil, fmt.Errorf("transaction %d: %v", i, err)
+		}
+	}
+	block, statedb := api.eth.miner.Pending()
+	if block == nil || statedb == nil {
+		return nil, errNoPendingBlock
+	}
+	return api.eth.simulate(ctx, block, statedb, decoded, config)
+}
+
+// simulate executes the transactions on top of the given block and its state.
+func (eth *Ethereum) simulate(ctx context.Context, block *types.Block, statedb *state.StateDB, txs []*types.Transaction, config *SimulateConfig) (*SimulatedPending, error) {
+	if config == nil {
+		config = new(SimulateConfig)
+	}
+	tracer := defaultSimulateTracer
+	if config.Tracer != nil {
+		tracer = *config.Tracer
+	}
+	guard := eth.APIBackend.RPCCallGuard()
+	timeout := defaultSimulateTimeout
+	if config.Timeout != nil {
+		var err error
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, err
+		}
+	}
+	timeout = guard.TraceTimeout(timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var (
+		chainConfig = eth.blockchain.Config()
+		header      = block.Header()
+		signer      = types.MakeSigner(chainConfig, header.Number, header.Time)
+		gp          = new(core.GasPool).AddGas(header.GasLimit - header.GasUsed)
+		usedGas     = header.GasUsed
+		simulated   = make([]*SimulatedTx, 0, len(txs))
+	)
+	for i, tx := range txs {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("simulation aborted: %w", err)
+		}
+		result := &SimulatedTx{TxHash: tx.Hash(), Logs: []*types.Log{}}
+		simulated = append(simulated, result)
+
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.From = from
+		msg, err := core.TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		if err := guard.Admit(msg.To); err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		var (
+			index = len(block.Transactions()) + i
+			vmcfg = vm.Config{MemoryLimit: guard.Limits().MemoryLimit}
+			trace *tracers.Tracer
+		)
+		if tracer != "" {
+			txctx := &tracers.Context{BlockHash: block.Hash(), BlockNumber: block.Number(), TxIndex: index, TxHash: tx.Hash()}
+			if trace, err = tracers.DefaultDirectory.New(tracer, txctx, config.TracerConfig); err != nil {
+				return nil, err
+			}
+			vmcfg.Tracer = trace.Hooks
+		}
+		blockCtx := core.NewEVMBlockContext(header, eth.blockchain, nil)
+		if eth.isTurboEngine {
+			blockCtx.AccessFilter = eth.turboEngine.CreateEvmAccessFilter(header, statedb)
+		}
+		vmenv := vm.NewEVM(blockCtx, vm.TxContext{}, statedb, chainConfig, vmcfg)
+		stop := context.AfterFunc(ctx, vmenv.Cancel)
+
+		statedb.SetTxContext(tx.Hash(), index)
+		statedb.SetLogger(vmcfg.Tracer)
+		var (
+			snap     = statedb.Snapshot()
+			gasPool  = *gp
+			prevUsed = usedGas
+		)
+		receipt, err := core.ApplyTransactionWithEVM(msg, chainConfig, gp, statedb, header.Number, block.Hash(), tx, &usedGas, vmenv)
+		stop()
+		if err != nil {
+			// The transaction can't be included, the next ones are simulated
+			// without it
+			statedb.RevertToSnapshot(snap)
+			*gp, usedGas = gasPool, prevUsed
+			result.Error = err.Error()
+			continue
+		}
+		// The executed transaction is finalised, a limit hit aborts the whole
+		// simulation
+		if err := guard.Outcome(vmenv, msg.To, timeout); err != nil {
+			return nil, err
+		}
+		result.Status = hexutil.Uint64(receipt.Status)
+		result.GasUsed = hexutil.Uint64(receipt.GasUsed)
+		result.CumulativeGasUsed = hexutil.Uint64(receipt.CumulativeGasUsed)
+		if receipt.ContractAddress != (common.Address{}) {
+			result.ContractAddress = &receipt.ContractAddress
+		}
+		if receipt.Logs != nil {
+			result.Logs = receipt.Logs
+		}
+		result.AccessDenied = receipt.AccessDenial
+		if trace != nil {
+			if result.Trace, err = trace.GetResult(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	statedb.SetLogger(nil)
+	return &SimulatedPending{
+		BlockNumber:  hexutil.Uint64(header.Number.Uint64()),
+		ParentHash:   header.ParentHash,
+		Transactions: simulated,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSimulate(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		to     = common.Address{0xaa}
+		gspec  = &core.Genesis{
+			Config:   params.TestChainConfig,
+			GasLimit: 1_000_000,
+			Alloc:    types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	block := chain.CurrentBlock()
+	statedb, err := chain.StateAt(block.Root)
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	signer := types.LatestSigner(gspec.Config)
+	transfer := func(nonce uint64, gas uint64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: nonce, To: &to, Value: big.NewInt(1), Gas: gas, GasPrice: big.NewInt(params.GWei)})
+	}
+	eth := &Ethereum{blockchain: chain, APIBackend: new(EthAPIBackend)}
+	txs := []*types.Transaction{
+		transfer(0, params.TxGas),
+		transfer(0, params.TxGas),   // Nonce already used
+		transfer(1, gspec.GasLimit), // Above the gas left in the block
+		transfer(1, params.TxGas),
+	}
+	tracer := "callTracer"
+	result, err := eth.simulate(context.Background(), types.NewBlockWithHeader(block), statedb, txs, &SimulateConfig{Tracer: &tracer})
+	if err != nil {
+		t.Fatalf("failed to simulate: %v", err)
+	}
+	if len(result.Transactions) != len(txs) {
+		t.Fatalf("simulated transaction count mismatch: have %d, want %d", len(result.Transactions), len(txs))
+	}
+	for i, want := range []struct {
+		failed     bool
+		cumulative uint64
+	}{
+		{false, params.TxGas},
+		{true, 0},
+		{true, 0},
+		{false, 2 * params.TxGas},
+	} {
+		have := result.Transactions[i]
+		if have.TxHash != txs[i].Hash() {
+			t.Errorf("tx %d: hash mismatch", i)
+		}
+		if failed := have.Error != ""; failed != want.failed {
+			t.Errorf("tx %d: failure mismatch: have %q, want failed %v", i, have.Error, want.failed)
+			continue
+		}
+		if want.failed {
+			continue
+		}
+		if have.From != sender || uint64(have.Status) != types.ReceiptStatusSuccessful || uint64(have.CumulativeGasUsed) != want.cumulative {
+			t.Errorf("tx %d: receipt mismatch: %+v", i, have)
+		}
+		var frame struct {
+			To common.Address `json:"to"`
+		}
+		if err := json.Unmarshal(have.Trace, &frame); err != nil || frame.To != to {
+			t.Errorf("tx %d: trace mismatch: %s", i, have.Trace)
+		}
+	}
+}
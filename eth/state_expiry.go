@@ -0,0 +1,98 @@
+package eth
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+const (
+	stateExpiryReportEpochs    = 16   // Default number of epochs reported
+	maxStateExpiryReportEpochs = 1024 // Maximum number of epochs reported
+)
+
+var errStateExpiryDisabled = errors.New("state expiry research mode disabled, enable it with --state.expiryresearch")
+
+// StateExpiryReport tells the effect an expiry period would have on the state,
+// from the last accesses of the accounts and storage slots.
+type StateExpiryReport struct {
+	EpochLength         hexutil.Uint64      `json:"epochLength"` // Epoch length in blocks
+	Epoch               hexutil.Uint64      `json:"epoch"`       // Epoch of the head block
+	Period              hexutil.Uint64      `json:"period"`      // Expiry period in epochs
+	Accounts            hexutil.Uint64      `json:"accounts"`
+	Slots               hexutil.Uint64      `json:"slots"`
+	ExpiredAccounts     hexutil.Uint64      `json:"expiredAccounts"`
+	ExpiredSlots        hexutil.Uint64      `json:"expiredSlots"`
+	ExpiredAccountBytes hexutil.Uint64      `json:"expiredAccountBytes"`
+	ExpiredSlotBytes    hexutil.Uint64      `json:"expiredSlotBytes"`
+	ResurrectableBytes  hexutil.Uint64      `json:"resurrectableBytes"` // Size of the expired state still existing
+	Epochs              []*StateExpiryEpoch `json:"epochs"`             // Newest first
+}
+
+// StateExpiryEpoch is the state accessed during an epoch, and the part of it
+// which would have had to be resurrected under the expiry period.
+type StateExpiryEpoch struct {
+	Epoch               hexutil.Uint64 `json:"epoch"`
+	Accounts            hexutil.Uint64 `json:"accounts"`
+	Slots               hexutil.Uint64 `json:"slots"`
+	NewAccounts         hexutil.Uint64 `json:"newAccounts"`
+	NewSlots            hexutil.Uint64 `json:"newSlots"`
+	ResurrectedAccounts hexutil.Uint64 `json:"resurrectedAccounts"`
+	ResurrectedSlots    hexutil.Uint64 `json:"resurrectedSlots"`
+}
+
+// GetStateExpiryReport reports the state not accessed during the given number
+// of epochs, which an expiry period of that length would let the nodes drop,
+// and the state which would have had to be resurrected during the last epochs,
+// 16 unless set. The node must run in the experimental state expiry research
+// mode, and the report only covers the state accessed since it was enabled.
+// The report iterates all the recorded accesses, so it's slow on large states.
+func (api *DebugAPI) GetStateExpiryReport(period hexutil.Uint64, epochs *hexutil.Uint64) (*StateExpiryReport, error) {
+	chain := api.eth.blockchain
+	length := chain.StateExpiryEpoch()
+	if length == 0 {
+		return nil, errStateExpiryDisabled
+	}
+	count := uint64(stateExpiryReportEpochs)
+	if epochs != nil {
+		count = min(uint64(*epochs), maxStateExpiryReportEpochs)
+	}
+	epoch := chain.CurrentBlock().Number.Uint64() / length
+	expired := chain.ExpiredState(epoch, uint64(period))
+
+	report := &StateExpiryReport{
+		EpochLength:         hexutil.Uint64(length),
+		Epoch:               hexutil.Uint64(epoch),
+		Period:              period,
+		Accounts:            hexutil.Uint64(expired.Accounts),
+		Slots:               hexutil.Uint64(expired.Slots),
+		ExpiredAccounts:     hexutil.Uint64(expired.ExpiredAccounts),
+		ExpiredSlots:        hexutil.Uint64(expired.ExpiredSlots),
+		ExpiredAccountBytes: hexutil.Uint64(expired.ExpiredAccountBytes),
+		ExpiredSlotBytes:    hexutil.Uint64(expired.ExpiredSlotBytes),
+		ResurrectableBytes:  hexutil.Uint64(expired.ExpiredAccountBytes + expired.ExpiredSlotBytes),
+		Epochs:              make([]*StateExpiryEpoch, 0),
+	}
+	for i := uint64(0); i < count && i <= epoch; i++ {
+		stats := rawdb.ReadStateAccessStats(api.eth.chainDb, epoch-i)
+		if stats == nil {
+			continue
+		}
+		entry := &StateExpiryEpoch{
+			Epoch:       hexutil.Uint64(epoch - i),
+			Accounts:    hexutil.Uint64(stats.Accounts),
+			Slots:       hexutil.Uint64(stats.Slots),
+			NewAccounts: hexutil.Uint64(stats.NewAccounts),
+			NewSlots:    hexutil.Uint64(stats.NewSlots),
+		}
+		for _, age := range stats.Ages {
+			if age.Age > uint64(period) {
+				entry.ResurrectedAccounts += hexutil.Uint64(age.Accounts)
+				entry.ResurrectedSlots += hexutil.Uint64(age.Slots)
+			}
+		}
+		report.Epochs = append(report.Epochs, entry)
+	}
+	return report, nil
+}
@@ -0,0 +1,102 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/turbo/systemcontract"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SupplyInfo is the breakdown of the native token supply at a block.
+//
+// All the supply is allocated at genesis, the staking rewards being released
+// from the reserve of the Staking contract, so only the burned base fees reduce
+// the total supply. The burns, emissions and fee flows are counted from the
+// supply accounting fork block, and are null for the blocks before it.
+type SupplyInfo struct {
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	CountedFrom       *hexutil.Uint64 `json:"countedFrom"`
+	GenesisAlloc      *hexutil.Big    `json:"genesisAlloc"`
+	Emitted           *hexutil.Big    `json:"emitted"`
+	Burned            *hexutil.Big    `json:"burned"`
+	Fees              *hexutil.Big    `json:"fees"`
+	TreasuryFees      *hexutil.Big    `json:"treasuryFees"`
+	Locked            *hexutil.Big    `json:"locked"`
+	TotalSupply       *hexutil.Big    `json:"totalSupply"`
+	CirculatingSupply *hexutil.Big    `json:"circulatingSupply"`
+}
+
+// GetSupplyInfo returns the supply components at the given block: the balance
+// allocated at genesis, the staking rewards released, the base fees burned, the
+// transaction fees distributed to the validators and the treasury, and the
+// balance still locked in the GenesisLock contract. The circulating supply is
+// the total supply less the locked balance.
+func (api *NeroAPI) GetSupplyInfo(ctx context.Context, blockNr rpc.BlockNumber) (*SupplyInfo, error) {
+	config := api.eth.blockchain.Config()
+	if config.Turbo == nil {
+		return nil, errors.New("supply info is only available with the Turbo engine")
+	}
+	statedb, header, err := api.eth.APIBackend.StateAndHeaderByNumber(ctx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	if statedb == nil || header == nil {
+		return nil, fmt.Errorf("block #%d not found", blockNr)
+	}
+	genesis, err := api.genesisAlloc()
+	if err != nil {
+		return nil, err
+	}
+	info := &SupplyInfo{
+		BlockNumber:  hexutil.Uint64(header.Number.Uint64()),
+		BlockHash:    header.Hash(),
+		GenesisAlloc: (*hexutil.Big)(genesis),
+		Locked:       (*hexutil.Big)(statedb.GetBalance(system.GenesisLockContract).ToBig()),
+	}
+	total := new(big.Int).Set(genesis)
+	if config.Turbo.IsSupply(header.Number) {
+		from := hexutil.Uint64(config.Turbo.SupplyBlock.Uint64())
+		counters := systemcontract.ReadSupplyCounters(statedb)
+
+		info.CountedFrom = &from
+		info.Emitted = (*hexutil.Big)(counters.Emitted)
+		info.Burned = (*hexutil.Big)(counters.Burned)
+		info.Fees = (*hexutil.Big)(counters.Fees)
+		info.TreasuryFees = (*hexutil.Big)(counters.TreasuryFees)
+		total.Sub(total, counters.Burned)
+	}
+	info.TotalSupply = (*hexutil.Big)(total)
+	info.CirculatingSupply = (*hexutil.Big)(new(big.Int).Sub(total, info.Locked.ToInt()))
+	return info, nil
+}
+
+// genesisAlloc returns the total balance allocated at genesis, read from the
+// genesis state since the balances of the system contracts are only set when
+// they are initialized.
+func (api *NeroAPI) genesisAlloc() (*big.Int, error) {
+	if supply := api.genesisSupply.Load(); supply != nil {
+		return supply, nil
+	}
+	genesis, err := core.ReadGenesis(api.eth.chainDb)
+	if err != nil {
+		return nil, err
+	}
+	statedb, err := api.eth.blockchain.StateAt(api.eth.blockchain.Genesis().Root())
+	if err != nil {
+		return nil, fmt.Errorf("genesis state unavailable: %v", err)
+	}
+	supply := new(big.Int)
+	for addr := range genesis.Alloc {
+		supply.Add(supply, statedb.GetBalance(addr).ToBig())
+	}
+	api.genesisSupply.Store(supply)
+	return supply, nil
+}
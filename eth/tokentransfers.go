@@ -0,0 +1,220 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// defaultTokenTransferLimit and maxTokenTransferLimit are the default and
+	// maximum numbers of transfers of a GetTokenTransfers page.
+	defaultTokenTransferLimit = 100
+	maxTokenTransferLimit     = 1000
+
+	// maxUnindexedTokenTransferBlocks is the maximum number of blocks not covered
+	// by the token transfer index yet scanned by a single GetTokenTransfers call.
+	maxUnindexedTokenTransferBlocks = 2 * params.BloomBitsBlocks
+)
+
+// errTokenTransfersDisabled is returned if the token transfer index is queried
+// without being enabled.
+var errTokenTransfersDisabled = errors.New("token transfer index disabled, enable it with --history.tokentransfers")
+
+// tokenStandards are the names of the token standards of the transfers.
+var tokenStandards = map[uint16]string{
+	rawdb.TokenERC20:   "erc20",
+	rawdb.TokenERC721:  "erc721",
+	rawdb.TokenERC1155: "erc1155",
+}
+
+// TokenTransfer is a token moved from or to an address.
+type TokenTransfer struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	TxHash      common.Hash    `json:"transactionHash"`
+	LogIndex    hexutil.Uint   `json:"logIndex"`
+	Token       common.Address `json:"token"`
+	Standard    string         `json:"standard"`
+	From        common.Address `json:"from"`
+	To          common.Address `json:"to"`
+	TokenID     *hexutil.Big   `json:"tokenId,omitempty"` // Nil for the ERC-20 transfers
+	Value       *hexutil.Big   `json:"value"`
+}
+
+// TokenTransferCursor is the position of a transfer in the transfers of an
+// address: the index of the transfer among the ones of its block.
+type TokenTransferCursor struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Index       hexutil.Uint   `json:"index"`
+}
+
+// TokenTransferOptions are the filter and pagination options of the transfers
+// of an address.
+type TokenTransferOptions struct {
+	Token  *common.Address      `json:"token"`  // Only the transfers of this token
+	Limit  *hexutil.Uint64      `json:"limit"`  // Maximum number of transfers, defaults to 100
+	Cursor *TokenTransferCursor `json:"cursor"` // Position to resume from, the next cursor of the previous page
+}
+
+// TokenTransfers is a page of the token transfers of an address over a block
+// range.
+type TokenTransfers struct {
+	Address   common.Address       `json:"address"`
+	FromBlock hexutil.Uint64       `json:"fromBlock"`
+	ToBlock   hexutil.Uint64       `json:"toBlock"`
+	Transfers []*TokenTransfer     `json:"transfers"`
+	Next      *TokenTransferCursor `json:"next"` // Nil once the range is exhausted
+}
+
+// GetTokenTransfers returns the ERC-20, ERC-721 and ERC-1155 token transfers
+// from and to the address within the given block range (both included), in
+// chronological order. The transfers are looked up in the token transfer index,
+// maintained if the node runs with --history.tokentransfers, only the blocks not
+// covered by indexed sections yet are checked one by one.
+//
+// The transfers are paginated, each page returning the cursor to pass, along
+// with the same range and token, to get the next one.
+func (api *NeroAPI) GetTokenTransfers(address common.Address, fromBlock, toBlock rpc.BlockNumber, opts *TokenTransferOptions) (*TokenTransfers, error) {
+	indexer := api.eth.tokenTransferIndexer
+	if indexer == nil {
+		return nil, errTokenTransfersDisabled
+	}
+	if address == (common.Address{}) {
+		return nil, errors.New("transfers of the zero address not indexed")
+	}
+	var (
+		chain = api.eth.blockchain
+		db    = api.eth.chainDb
+		head  = chain.CurrentBlock().Number.Uint64()
+	)
+	resolve := func(num rpc.BlockNumber) (uint64, error) {
+		if num.Int64() < 0 {
+			return head, nil
+		}
+		if uint64(num.Int64()) > head {
+			return 0, fmt.Errorf("block #%d not found", num)
+		}
+		return uint64(num.Int64()), nil
+	}
+	from, err := resolve(fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolve(toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, errors.New("fromBlock is above toBlock")
+	}
+	var (
+		limit = uint64(defaultTokenTransferLimit)
+		start = from
+		skip  uint
+		token *common.Address
+	)
+	if opts != nil {
+		if opts.Limit != nil {
+			limit = uint64(*opts.Limit)
+		}
+		if opts.Cursor != nil {
+			start, skip = uint64(opts.Cursor.BlockNumber), uint(opts.Cursor.Index)
+			if start < from || start > to {
+				return nil, fmt.Errorf("cursor block #%d outside of the range", start)
+			}
+		}
+		token = opts.Token
+	}
+	if limit == 0 || limit > maxTokenTransferLimit {
+		return nil, fmt.Errorf("invalid limit %d, must be between 1 and %d", limit, maxTokenTransferLimit)
+	}
+	var (
+		size           = uint64(params.BloomBitsBlocks)
+		sections, _, _ = indexer.Sections()
+		indexed        = sections * size // First block not covered by the index
+	)
+	if to >= indexed {
+		if unindexed := to - max(start, indexed) + 1; unindexed > maxUnindexedTokenTransferBlocks {
+			return nil, fmt.Errorf("token transfer index still building, indexed up to block #%d", indexed)
+		}
+	}
+	res := &TokenTransfers{
+		Address:   address,
+		FromBlock: hexutil.Uint64(from),
+		ToBlock:   hexutil.Uint64(to),
+		Transfers: []*TokenTransfer{},
+	}
+	var (
+		block   uint64 // Block of the last visited transfer
+		inBlock uint   // Number of transfers visited in the block
+	)
+	// visit adds a transfer to the page, returning false once the page is full
+	visit := func(entry rawdb.TokenTransferEntry) bool {
+		if entry.Number < start || entry.Number > to || (token != nil && entry.Token != *token) {
+			return true
+		}
+		if entry.Number != block {
+			block, inBlock = entry.Number, 0
+		}
+		index := inBlock
+		inBlock++
+		if entry.Number == start && index < skip {
+			return true
+		}
+		if uint64(len(res.Transfers)) == limit {
+			res.Next = &TokenTransferCursor{BlockNumber: hexutil.Uint64(entry.Number), Index: hexutil.Uint(index)}
+			return false
+		}
+		res.Transfers = append(res.Transfers, newTokenTransfer(entry))
+		return true
+	}
+	number := start
+	for section := start / size; section < sections && section <= to/size; section++ {
+		hash := rawdb.ReadCanonicalHash(db, (section+1)*size-1)
+		for _, entry := range rawdb.ReadTokenTransfers(db, address, section, hash) {
+			if !visit(entry) {
+				return res, nil
+			}
+		}
+		number = (section + 1) * size
+	}
+	for ; number <= to; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		for _, entry := range core.ReadTokenTransferEntries(db, header) {
+			if entry.From != address && entry.To != address {
+				continue
+			}
+			if !visit(entry) {
+				return res, nil
+			}
+		}
+	}
+	return res, nil
+}
+
+// newTokenTransfer converts a token transfer index entry to its RPC form.
+func newTokenTransfer(entry rawdb.TokenTransferEntry) *TokenTransfer {
+	transfer := &TokenTransfer{
+		BlockNumber: hexutil.Uint64(entry.Number),
+		TxHash:      entry.TxHash,
+		LogIndex:    hexutil.Uint(entry.LogIndex),
+		Token:       entry.Token,
+		Standard:    tokenStandards[entry.Standard],
+		From:        entry.From,
+		To:          entry.To,
+		Value:       (*hexutil.Big)(entry.Value),
+	}
+	if entry.Standard != rawdb.TokenERC20 {
+		transfer.TokenID = (*hexutil.Big)(entry.TokenID)
+	}
+	return transfer
+}
@@ -0,0 +1,30 @@
+package tracers
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// stateDiffTracer is the name of the native tracer computing the state diffs,
+// registered by the native tracers package.
+const stateDiffTracer = "stateDiffTracer"
+
+// NeroAPI offers the Nero specific tracing methods.
+type NeroAPI struct {
+	api *API
+}
+
+// NewNeroAPI creates a new Nero tracing API instance.
+func NewNeroAPI(backend Backend) *NeroAPI {
+	return &NeroAPI{api: NewAPI(backend)}
+}
+
+// GetStateDiff returns the balance, nonce, code and storage changes made by a
+// transaction, in the format of the parity stateDiff traces: each modified
+// account maps its fields to "=" if unchanged, {"+": value} if created,
+// {"-": value} if deleted, or {"*": {"from": value, "to": value}} if changed.
+func (api *NeroAPI) GetStateDiff(ctx context.Context, hash common.Hash) (interface{}, error) {
+	tracer := stateDiffTracer
+	return api.api.TraceTransaction(ctx, hash, &TraceConfig{Tracer: &tracer})
+}
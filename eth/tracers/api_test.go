@@ -119,6 +119,10 @@ func (b *testBackend) GetTransaction(ctx context.Context, txHash common.Hash) (b
 	return tx != nil, tx, hash, blockNumber, index, nil
 }
 
+func (b *testBackend) RPCCallGuard() *ethapi.CallGuard {
+	return nil
+}
+
 func (b *testBackend) RPCGasCap() uint64 {
 	return 25000000
 }
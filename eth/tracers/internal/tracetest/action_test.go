@@ -0,0 +1,126 @@
+package tracetest
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/tests"
+)
+
+func TestActionTracer(t *testing.T) {
+	var (
+		key, _      = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender      = crypto.PubkeyToAddress(key.PublicKey)
+		beneficiary = common.HexToAddress("0xbeef")
+		payer       = common.HexToAddress("0x1000") // call(gas, beneficiary, 1, 0, 0, 0, 0)
+		reverter    = common.HexToAddress("0x2000") // call(gas, beneficiary, 1, 0, 0, 0, 0); revert(0, 0)
+		alloc       = types.GenesisAlloc{
+			sender:   {Balance: big.NewInt(params.Ether)},
+			payer:    {Balance: big.NewInt(100), Code: common.FromHex("0x6000600060006000600161beef5af15000")},
+			reverter: {Balance: big.NewInt(100), Code: common.FromHex("0x6000600060006000600161beef5af15060006000fd")},
+		}
+	)
+	cases := []struct {
+		name      string
+		to        common.Address
+		onlyValue bool
+		want      []types.Action // Compared fields only
+	}{
+		{
+			name: "all",
+			to:   payer,
+			want: []types.Action{
+				{From: sender, To: payer, OpCode: "CALL"}, // The logger only completes the top-level creations
+				{From: payer, To: beneficiary, OpCode: "CALL", Success: true, Value: big.NewInt(1)},
+			},
+		},
+		{
+			name:      "value",
+			to:        payer,
+			onlyValue: true,
+			want: []types.Action{
+				{From: payer, To: beneficiary, OpCode: "CALL", Success: true, Value: big.NewInt(1)},
+			},
+		},
+		{
+			name: "reverted",
+			to:   reverter,
+			want: []types.Action{
+				{From: sender, To: reverter, OpCode: "CALL"},
+				{From: reverter, To: beneficiary, OpCode: "CALL", Value: big.NewInt(1)},
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				config  = params.AllEthashProtocolChanges
+				context = vm.BlockContext{
+					CanTransfer: core.CanTransfer,
+					Transfer:    core.Transfer,
+					BlockNumber: big.NewInt(1),
+					Difficulty:  big.NewInt(1),
+					GasLimit:    10_000_000,
+					BaseFee:     big.NewInt(1),
+				}
+				state = tests.MakePreState(rawdb.NewMemoryDatabase(), alloc, false, rawdb.HashScheme)
+			)
+			defer state.Close()
+
+			signer := types.MakeSigner(config, context.BlockNumber, context.Time)
+			tx := types.MustSignNewTx(key, signer, &types.LegacyTx{To: &tt.to, Gas: 100_000, GasPrice: big.NewInt(2)})
+			msg, err := core.TransactionToMessage(tx, signer, context.BaseFee)
+			if err != nil {
+				t.Fatalf("failed to prepare transaction for tracing: %v", err)
+			}
+			cfg, _ := json.Marshal(map[string]bool{"onlyValue": tt.onlyValue})
+			tracer, err := tracers.DefaultDirectory.New("actionTracer", new(tracers.Context), cfg)
+			if err != nil {
+				t.Fatalf("failed to create action tracer: %v", err)
+			}
+			evm := vm.NewEVM(context, vm.TxContext{}, state.StateDB, config, vm.Config{Tracer: tracer.Hooks})
+
+			var usedGas uint64
+			receipt, err := core.ApplyTransactionWithEVM(msg, config, new(core.GasPool).AddGas(tx.Gas()), state.StateDB, context.BlockNumber, common.Hash{}, tx, &usedGas, evm)
+			if err != nil {
+				t.Fatalf("failed to execute transaction: %v", err)
+			}
+			tracer.OnTxEnd(receipt, nil)
+			res, err := tracer.GetResult()
+			if err != nil {
+				t.Fatalf("failed to retrieve trace result: %v", err)
+			}
+			var actions []struct {
+				From    common.Address `json:"from"`
+				To      common.Address `json:"to"`
+				Value   *big.Int       `json:"value"`
+				Success bool           `json:"success"`
+				OpCode  string         `json:"opcode"`
+			}
+			if err := json.Unmarshal(res, &actions); err != nil {
+				t.Fatalf("failed to parse trace result: %v", err)
+			}
+			if len(actions) != len(tt.want) {
+				t.Fatalf("action count mismatch: have %d, want %d", len(actions), len(tt.want))
+			}
+			for i, want := range tt.want {
+				have := &actions[i]
+				if have.From != want.From || have.To != want.To || have.OpCode != want.OpCode || have.Success != want.Success {
+					t.Errorf("action %d mismatch: have %+v, want %+v", i, have, want)
+				}
+				if want.Value != nil && (have.Value == nil || have.Value.Cmp(want.Value) != 0) {
+					t.Errorf("action %d value mismatch: have %v, want %v", i, have.Value, want.Value)
+				}
+			}
+		})
+	}
+}
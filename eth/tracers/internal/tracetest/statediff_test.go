@@ -0,0 +1,157 @@
+package tracetest
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/tests"
+)
+
+// stateDiff is the result of a stateDiffTracer run, with the account fields
+// left encoded.
+type stateDiff = map[common.Address]map[string]json.RawMessage
+
+func TestStateDiffTracer(t *testing.T) {
+	var (
+		key, _      = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender      = crypto.PubkeyToAddress(key.PublicKey)
+		coinbase    = common.HexToAddress("0xc014ba5e")
+		beneficiary = common.HexToAddress("0xbeef")
+		store       = common.HexToAddress("0x1000") // sstore(0, 1)
+		reverter    = common.HexToAddress("0x2000") // sstore(0, 1); revert(0, 0)
+		destructor  = common.HexToAddress("0x3000") // selfdestruct(beneficiary)
+		alloc       = types.GenesisAlloc{
+			sender: {Balance: big.NewInt(params.Ether)},
+			store: {
+				Code:    common.FromHex("0x600160005500"),
+				Storage: map[common.Hash]common.Hash{{}: common.HexToHash("0x02"), common.HexToHash("0x01"): common.HexToHash("0x03")},
+			},
+			reverter: {Code: common.FromHex("0x600160005560006000fd")},
+			destructor: {
+				Balance: big.NewInt(100),
+				Code:    append(append([]byte{0x73}, beneficiary.Bytes()...), 0xff),
+			},
+		}
+		created = crypto.CreateAddress(sender, 0)
+	)
+	cases := []struct {
+		name  string
+		to    *common.Address
+		data  []byte
+		check func(t *testing.T, diff stateDiff)
+	}{
+		{
+			name: "store",
+			to:   &store,
+			check: func(t *testing.T, diff stateDiff) {
+				checkAccounts(t, diff, sender, store, coinbase)
+				checkField(t, diff, sender, "nonce", `{"*":{"from":"0x0","to":"0x1"}}`)
+				checkField(t, diff, sender, "code", `"="`)
+				checkField(t, diff, store, "balance", `"="`)
+				checkField(t, diff, store, "storage", `{"0x0000000000000000000000000000000000000000000000000000000000000000":{"*":{"from":"0x0000000000000000000000000000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000000000000000000000000000001"}}}`)
+				checkField(t, diff, coinbase, "nonce", `{"+":"0x0"}`)
+			},
+		},
+		{
+			name: "create",
+			data: common.FromHex("0x600160005560016000f3"), // sstore(0, 1); return 0x00
+			check: func(t *testing.T, diff stateDiff) {
+				checkAccounts(t, diff, sender, created, coinbase)
+				checkField(t, diff, created, "balance", `{"+":"0x0"}`)
+				checkField(t, diff, created, "code", `{"+":"0x00"}`)
+				checkField(t, diff, created, "nonce", `{"+":"0x1"}`)
+				checkField(t, diff, created, "storage", `{"0x0000000000000000000000000000000000000000000000000000000000000000":{"+":"0x0000000000000000000000000000000000000000000000000000000000000001"}}`)
+			},
+		},
+		{
+			name: "revert",
+			to:   &reverter,
+			check: func(t *testing.T, diff stateDiff) {
+				checkAccounts(t, diff, sender, coinbase)
+			},
+		},
+		{
+			name: "selfdestruct",
+			to:   &destructor,
+			check: func(t *testing.T, diff stateDiff) {
+				checkAccounts(t, diff, sender, destructor, beneficiary, coinbase)
+				checkField(t, diff, destructor, "balance", `{"-":"0x64"}`)
+				checkField(t, diff, destructor, "nonce", `{"-":"0x0"}`)
+				checkField(t, diff, beneficiary, "balance", `{"+":"0x64"}`)
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				config  = params.AllEthashProtocolChanges
+				context = vm.BlockContext{
+					CanTransfer: core.CanTransfer,
+					Transfer:    core.Transfer,
+					Coinbase:    coinbase,
+					BlockNumber: big.NewInt(1),
+					Difficulty:  big.NewInt(1),
+					GasLimit:    10_000_000,
+					BaseFee:     big.NewInt(1),
+				}
+				state = tests.MakePreState(rawdb.NewMemoryDatabase(), alloc, false, rawdb.HashScheme)
+			)
+			defer state.Close()
+
+			signer := types.MakeSigner(config, context.BlockNumber, context.Time)
+			tx := types.MustSignNewTx(key, signer, &types.LegacyTx{To: tt.to, Gas: 100_000, GasPrice: big.NewInt(2), Data: tt.data})
+			msg, err := core.TransactionToMessage(tx, signer, context.BaseFee)
+			if err != nil {
+				t.Fatalf("failed to prepare transaction for tracing: %v", err)
+			}
+			tracer, err := tracers.DefaultDirectory.New("stateDiffTracer", new(tracers.Context), nil)
+			if err != nil {
+				t.Fatalf("failed to create state diff tracer: %v", err)
+			}
+			state.StateDB.SetLogger(tracer.Hooks)
+			evm := vm.NewEVM(context, vm.TxContext{}, state.StateDB, config, vm.Config{Tracer: tracer.Hooks})
+
+			var usedGas uint64
+			if _, err := core.ApplyTransactionWithEVM(msg, config, new(core.GasPool).AddGas(tx.Gas()), state.StateDB, context.BlockNumber, common.Hash{}, tx, &usedGas, evm); err != nil {
+				t.Fatalf("failed to execute transaction: %v", err)
+			}
+			res, err := tracer.GetResult()
+			if err != nil {
+				t.Fatalf("failed to retrieve trace result: %v", err)
+			}
+			var diff stateDiff
+			if err := json.Unmarshal(res, &diff); err != nil {
+				t.Fatalf("failed to parse trace result: %v", err)
+			}
+			tt.check(t, diff)
+		})
+	}
+}
+
+func checkAccounts(t *testing.T, diff stateDiff, want ...common.Address) {
+	t.Helper()
+	if len(diff) != len(want) {
+		t.Errorf("account count mismatch: have %d, want %d", len(diff), len(want))
+	}
+	for _, addr := range want {
+		if _, ok := diff[addr]; !ok {
+			t.Errorf("account %s missing", addr)
+		}
+	}
+}
+
+func checkField(t *testing.T, diff stateDiff, addr common.Address, field string, want string) {
+	t.Helper()
+	if have := string(diff[addr][field]); have != want {
+		t.Errorf("%s %s mismatch: have %s, want %s", addr, field, have, want)
+	}
+}
@@ -0,0 +1,99 @@
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("actionTracer", newActionTracer, false)
+}
+
+type actionTracerConfig struct {
+	OnlyValue bool `json:"onlyValue"` // If true, only the actions transferring value are reported, as with --traceaction=1
+}
+
+// actionTracer regenerates the action trace of a transaction as the block
+// processor records it with --traceaction, to be compared with the stored one,
+// e.g. across client versions.
+type actionTracer struct {
+	logger    *vm.ActionLogger
+	config    actionTracerConfig
+	failed    bool
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+func newActionTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, error) {
+	var config actionTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	t := &actionTracer{logger: vm.NewActionLogger(), config: config}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxEnd: t.OnTxEnd,
+			OnEnter: t.OnEnter,
+			OnExit:  t.OnExit,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *actionTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.interrupt.Load() {
+		return
+	}
+	t.logger.OnEnter(depth, typ, from, to, input, gas, value)
+}
+
+func (t *actionTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if t.interrupt.Load() {
+		return
+	}
+	t.logger.OnExit(depth, output, gasUsed, err, reverted)
+}
+
+func (t *actionTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	t.failed = receipt != nil && receipt.Status == types.ReceiptStatusFailed
+}
+
+// GetResult returns the actions of the transaction, as stored by the block
+// processor: all of them marked failed if the transaction failed.
+func (t *actionTracer) GetResult() (json.RawMessage, error) {
+	actions, err := t.logger.GetResult()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.Action, 0, len(actions))
+	for _, action := range actions {
+		if t.failed {
+			action.Success = false
+		}
+		if t.config.OnlyValue && (action.Value == nil || action.Value.Sign() == 0) {
+			continue
+		}
+		result = append(result, action)
+	}
+	res, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *actionTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
@@ -56,6 +56,7 @@ type callFrame struct {
 	Output       []byte          `json:"output,omitempty" rlp:"optional"`
 	Error        string          `json:"error,omitempty" rlp:"optional"`
 	RevertReason string          `json:"revertReason,omitempty"`
+	ErrorCode    int             `json:"errorCode,omitempty"` // Set for the access filter denials only
 	Calls        []callFrame     `json:"calls,omitempty" rlp:"optional"`
 	Logs         []callLog       `json:"logs,omitempty" rlp:"optional"`
 	// Placed at end on purpose. The RLP will be decoded to 0 instead of
@@ -85,6 +86,9 @@ func (f *callFrame) processOutput(output []byte, err error, reverted bool) {
 	}
 	f.Error = err.Error()
 	f.revertedSnapshot = reverted
+	if errors.Is(err, types.ErrAddressDenied) {
+		f.ErrorCode = vm.VMErrorCodeAccessDenied
+	}
 	if f.Type == vm.CREATE || f.Type == vm.CREATE2 {
 		f.To = nil
 	}
@@ -0,0 +1,255 @@
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("stateDiffTracer", newStateDiffTracer, false)
+}
+
+// Markers of the state diffs, as in the parity stateDiff traces.
+const (
+	diffSame    = "="
+	diffBorn    = "+"
+	diffDied    = "-"
+	diffChanged = "*"
+)
+
+// diffValue is the diff of a single value. It encodes as "=" if unchanged,
+// {"+": to} if created, {"-": from} if deleted and {"*": {"from", "to"}} if
+// changed.
+type diffValue struct {
+	kind     string
+	from, to interface{}
+}
+
+func (d diffValue) MarshalJSON() ([]byte, error) {
+	switch d.kind {
+	case diffBorn:
+		return json.Marshal(map[string]interface{}{diffBorn: d.to})
+	case diffDied:
+		return json.Marshal(map[string]interface{}{diffDied: d.from})
+	case diffChanged:
+		return json.Marshal(map[string]interface{}{diffChanged: map[string]interface{}{"from": d.from, "to": d.to}})
+	default:
+		return json.Marshal(diffSame)
+	}
+}
+
+// accountDiff is the diff of an account modified by a transaction.
+type accountDiff struct {
+	Balance diffValue                 `json:"balance"`
+	Code    diffValue                 `json:"code"`
+	Nonce   diffValue                 `json:"nonce"`
+	Storage map[common.Hash]diffValue `json:"storage"`
+}
+
+// accountPrestate is the state of an account before the transaction, as far
+// as it was modified. The unset fields weren't modified.
+type accountPrestate struct {
+	balance *big.Int
+	nonce   *uint64
+	code    []byte
+	codeSet bool
+	storage map[common.Hash]common.Hash
+}
+
+// stateDiffTracer returns the balance, nonce, code and storage changes made
+// by a transaction, in the format of the parity stateDiff traces. The changes
+// are collected from the state hooks, so unlike the prestateTracer, reads and
+// accesses are not reported, and only the storage slots written by the
+// transaction are listed, deleted accounts included.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x7c5b...", {tracer: "stateDiffTracer"})
+//	{
+//	  "0x71562b71999873db5b286df957af199ec94617f7": {
+//	    "balance": {"*": {"from": "0x1bc16d674ec80000", "to": "0x1bc0f8d8c2b52000"}},
+//	    "code": "=",
+//	    "nonce": {"*": {"from": "0x0", "to": "0x1"}},
+//	    "storage": {}
+//	  },
+//	  ...
+//	}
+type stateDiffTracer struct {
+	env       *tracing.VMContext
+	pre       map[common.Address]*accountPrestate
+	diff      map[common.Address]*accountDiff
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+func newStateDiffTracer(ctx *tracers.Context, _ json.RawMessage) (*tracers.Tracer, error) {
+	t := &stateDiffTracer{
+		pre:  make(map[common.Address]*accountPrestate),
+		diff: make(map[common.Address]*accountDiff),
+	}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart:       t.OnTxStart,
+			OnTxEnd:         t.OnTxEnd,
+			OnBalanceChange: t.OnBalanceChange,
+			OnNonceChange:   t.OnNonceChange,
+			OnCodeChange:    t.OnCodeChange,
+			OnStorageChange: t.OnStorageChange,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *stateDiffTracer) OnTxStart(env *tracing.VMContext, tx *types.Transaction, from common.Address) {
+	t.env = env
+}
+
+// OnTxEnd computes the diffs against the final state. The changes are only
+// compared at the end, so the reverted ones cancel out.
+func (t *stateDiffTracer) OnTxEnd(receipt *types.Receipt, err error) {
+	if err != nil || t.interrupt.Load() {
+		return
+	}
+	for addr, pre := range t.pre {
+		if diff := t.accountDiff(addr, pre); diff != nil {
+			t.diff[addr] = diff
+		}
+	}
+}
+
+func (t *stateDiffTracer) OnBalanceChange(addr common.Address, prev, _ *big.Int, reason tracing.BalanceChangeReason) {
+	if pre := t.prestate(addr); pre.balance == nil {
+		pre.balance = new(big.Int).Set(prev)
+	}
+}
+
+func (t *stateDiffTracer) OnNonceChange(addr common.Address, prev, _ uint64) {
+	if pre := t.prestate(addr); pre.nonce == nil {
+		pre.nonce = &prev
+	}
+}
+
+func (t *stateDiffTracer) OnCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	if pre := t.prestate(addr); !pre.codeSet {
+		pre.code, pre.codeSet = common.CopyBytes(prevCode), true
+	}
+}
+
+func (t *stateDiffTracer) OnStorageChange(addr common.Address, slot common.Hash, prev, _ common.Hash) {
+	pre := t.prestate(addr)
+	if _, ok := pre.storage[slot]; !ok {
+		pre.storage[slot] = prev
+	}
+}
+
+// GetResult returns the json-encoded state diffs, and any error arising from
+// the encoding or forceful termination (via `Stop`).
+func (t *stateDiffTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.diff)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *stateDiffTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+func (t *stateDiffTracer) prestate(addr common.Address) *accountPrestate {
+	pre, ok := t.pre[addr]
+	if !ok {
+		pre = &accountPrestate{storage: make(map[common.Hash]common.Hash)}
+		t.pre[addr] = pre
+	}
+	return pre
+}
+
+// accountDiff compares the prestate of the account with its current state,
+// returning nil if the account is unchanged. An account is created if it was
+// empty before the transaction, and deleted if it doesn't exist anymore, as
+// after a self-destruct or the removal of an empty account.
+func (t *stateDiffTracer) accountDiff(addr common.Address, pre *accountPrestate) *accountDiff {
+	var (
+		db = t.env.StateDB
+
+		postBalance = db.GetBalance(addr).ToBig()
+		postNonce   = db.GetNonce(addr)
+		postCode    = db.GetCode(addr)
+		postExists  = db.Exist(addr)
+
+		preBalance = postBalance
+		preNonce   = postNonce
+		preCode    = postCode
+	)
+	if pre.balance != nil {
+		preBalance = pre.balance
+	}
+	if pre.nonce != nil {
+		preNonce = *pre.nonce
+	}
+	if pre.codeSet {
+		preCode = pre.code
+	}
+	preExists := preBalance.Sign() != 0 || preNonce != 0 || len(preCode) != 0
+	diff := &accountDiff{Storage: make(map[common.Hash]diffValue)}
+	switch {
+	case !preExists && !postExists:
+		return nil
+
+	case !preExists:
+		diff.Balance = diffValue{kind: diffBorn, to: (*hexutil.Big)(postBalance)}
+		diff.Code = diffValue{kind: diffBorn, to: hexutil.Bytes(postCode)}
+		diff.Nonce = diffValue{kind: diffBorn, to: hexutil.Uint64(postNonce)}
+		for slot := range pre.storage {
+			if val := db.GetState(addr, slot); val != (common.Hash{}) {
+				diff.Storage[slot] = diffValue{kind: diffBorn, to: val}
+			}
+		}
+		return diff
+
+	case !postExists:
+		diff.Balance = diffValue{kind: diffDied, from: (*hexutil.Big)(preBalance)}
+		diff.Code = diffValue{kind: diffDied, from: hexutil.Bytes(preCode)}
+		diff.Nonce = diffValue{kind: diffDied, from: hexutil.Uint64(preNonce)}
+		for slot, val := range pre.storage {
+			if val != (common.Hash{}) {
+				diff.Storage[slot] = diffValue{kind: diffDied, from: val}
+			}
+		}
+		return diff
+	}
+	modified := false
+	if preBalance.Cmp(postBalance) != 0 {
+		diff.Balance = diffValue{kind: diffChanged, from: (*hexutil.Big)(preBalance), to: (*hexutil.Big)(postBalance)}
+		modified = true
+	}
+	if !bytes.Equal(preCode, postCode) {
+		diff.Code = diffValue{kind: diffChanged, from: hexutil.Bytes(preCode), to: hexutil.Bytes(postCode)}
+		modified = true
+	}
+	if preNonce != postNonce {
+		diff.Nonce = diffValue{kind: diffChanged, from: hexutil.Uint64(preNonce), to: hexutil.Uint64(postNonce)}
+		modified = true
+	}
+	for slot, val := range pre.storage {
+		if post := db.GetState(addr, slot); post != val {
+			diff.Storage[slot] = diffValue{kind: diffChanged, from: val, to: post}
+		}
+	}
+	if !modified && len(diff.Storage) == 0 {
+		return nil
+	}
+	return diff
+}
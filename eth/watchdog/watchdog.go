@@ -0,0 +1,323 @@
+// Package watchdog detects chain stalls and captures runtime diagnostics.
+//
+// A stall is declared if the chain head does not advance within a configured
+// number of block periods while the node considers itself synced. On a stall
+// the watchdog profiles the process and dumps the consensus state into a
+// timestamped directory, so the capture can be attached to a bug report.
+package watchdog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// defaultPeriod is the block period assumed for chains without a configured one.
+const defaultPeriod = 12 * time.Second
+
+// Config contains the settings of the stall watchdog.
+type Config struct {
+	Enabled    bool          // Whether to watch for chain stalls
+	Periods    uint64        // Number of block periods without head advance considered a stall
+	CPUProfile time.Duration // Duration of the CPU profile captured on a stall
+	Retain     int           // Number of diagnostics captures kept on disk
+}
+
+// DefaultConfig contains the default watchdog settings.
+var DefaultConfig = Config{
+	Periods:    10,
+	CPUProfile: 10 * time.Second,
+	Retain:     5,
+}
+
+// Backend is the node functionality needed by the watchdog.
+type Backend interface {
+	BlockChain() *core.BlockChain
+	Engine() consensus.Engine
+	IsMining() bool
+	Synced() bool
+}
+
+// snapshotter is implemented by consensus engines which can dump their
+// internal authorization snapshot, e.g. Turbo.
+type snapshotter interface {
+	SnapshotAt(chain consensus.ChainHeaderReader, number uint64, hash common.Hash) (interface{}, error)
+}
+
+// Watchdog monitors the chain head and captures diagnostics on stalls.
+type Watchdog struct {
+	config  Config
+	dir     string
+	backend Backend
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a stall watchdog writing its captures into dir.
+func New(config Config, dir string, backend Backend) *Watchdog {
+	if config.Periods == 0 {
+		config.Periods = DefaultConfig.Periods
+	}
+	if config.Retain <= 0 {
+		config.Retain = DefaultConfig.Retain
+	}
+	if dir == "" { // ephemeral node without a datadir
+		dir = filepath.Join(os.TempDir(), "geth-diagnostics")
+	}
+	return &Watchdog{
+		config:  config,
+		dir:     dir,
+		backend: backend,
+		quit:    make(chan struct{}),
+	}
+}
+
+// blockPeriod returns the expected time between the given block and its parent.
+func blockPeriod(config *params.ChainConfig, number uint64) time.Duration {
+	switch {
+	case config.Turbo != nil && config.Turbo.PeriodAt(number) > 0:
+		return time.Duration(config.Turbo.PeriodAt(number)) * time.Second
+	case config.Clique != nil && config.Clique.Period > 0:
+		return time.Duration(config.Clique.Period) * time.Second
+	default:
+		return defaultPeriod
+	}
+}
+
+// Start launches the head monitoring loop.
+func (w *Watchdog) Start() {
+	w.wg.Add(1)
+	go w.loop()
+}
+
+// Stop terminates the watchdog, aborting any capture in progress.
+func (w *Watchdog) Stop() {
+	close(w.quit)
+	w.wg.Wait()
+}
+
+// threshold returns the time the head may stay still before the given block is
+// considered stalled.
+func (w *Watchdog) threshold(number uint64) time.Duration {
+	return time.Duration(w.config.Periods) * blockPeriod(w.backend.BlockChain().Config(), number)
+}
+
+func (w *Watchdog) loop() {
+	defer w.wg.Done()
+
+	chain := w.backend.BlockChain()
+	headCh := make(chan core.ChainHeadEvent, 10)
+	sub := chain.SubscribeChainHeadEvent(headCh)
+	defer sub.Unsubscribe()
+
+	var (
+		threshold = w.threshold(chain.CurrentBlock().Number.Uint64() + 1)
+		timer     = time.NewTimer(threshold)
+		advanced  = time.Now()
+		stalled   bool
+
+		// The capture runs in the background, as its CPU profile lasts long
+		// enough for the head events to pile up in the subscription.
+		capturing bool
+		captured  = make(chan struct{}, 1)
+	)
+	defer timer.Stop()
+
+	for {
+		select {
+		case ev := <-headCh:
+			if stalled {
+				log.Info("Chain head advancing again", "number", ev.Block.NumberU64(), "hash", ev.Block.Hash(), "stalled", common.PrettyDuration(time.Since(advanced)))
+				stalled = false
+			}
+			advanced = time.Now()
+			threshold = w.threshold(ev.Block.NumberU64() + 1)
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(threshold)
+
+		case <-timer.C:
+			timer.Reset(threshold)
+
+			// Only capture once per stall, one capture at a time, and only if
+			// the head is expected to advance at all.
+			if stalled || capturing || !w.backend.Synced() {
+				continue
+			}
+			stalled, capturing = true, true
+
+			kind := "processing"
+			if w.backend.IsMining() {
+				kind = "sealing"
+			}
+			head := chain.CurrentBlock()
+			log.Warn("Chain stall detected", "kind", kind, "number", head.Number, "hash", head.Hash(), "stalled", common.PrettyDuration(time.Since(advanced)))
+
+			w.wg.Add(1)
+			go func(stalled time.Duration) {
+				defer w.wg.Done()
+				defer func() { captured <- struct{}{} }()
+
+				path, err := w.capture(kind, head, stalled)
+				if err != nil {
+					log.Error("Failed to capture stall diagnostics", "err", err)
+					return
+				}
+				log.Warn("Captured stall diagnostics", "path", path)
+			}(time.Since(advanced))
+
+		case <-captured:
+			capturing = false
+
+		case <-sub.Err():
+			return
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+// consensusState is the consensus related node state dumped on a stall.
+type consensusState struct {
+	Kind      string          `json:"kind"`
+	Stalled   string          `json:"stalled"`
+	Number    uint64          `json:"number"`
+	Hash      common.Hash     `json:"hash"`
+	Time      uint64          `json:"time"`
+	Finalized uint64          `json:"finalized"`
+	Mining    bool            `json:"mining"`
+	Synced    bool            `json:"synced"`
+	Validator *common.Address `json:"validator,omitempty"`
+	Attesting *bool           `json:"attesting,omitempty"`
+	Snapshot  json.RawMessage `json:"snapshot,omitempty"`
+}
+
+// capture writes the profiles and the consensus state into a new directory
+// and prunes old captures.
+func (w *Watchdog) capture(kind string, head *types.Header, stalled time.Duration) (string, error) {
+	dir := filepath.Join(w.dir, fmt.Sprintf("%s-%s-%d", time.Now().UTC().Format("20060102T150405"), kind, head.Number.Uint64()))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	if err := w.writeConsensusState(filepath.Join(dir, "consensus.json"), kind, head, stalled); err != nil {
+		log.Warn("Failed to dump consensus state", "err", err)
+	}
+	if err := writeProfile(filepath.Join(dir, "goroutine.txt"), "goroutine", 2); err != nil {
+		log.Warn("Failed to write goroutine profile", "err", err)
+	}
+	if err := writeProfile(filepath.Join(dir, "heap.pprof"), "heap", 0); err != nil {
+		log.Warn("Failed to write heap profile", "err", err)
+	}
+	if err := w.writeCPUProfile(filepath.Join(dir, "cpu.pprof")); err != nil {
+		log.Warn("Failed to write CPU profile", "err", err)
+	}
+	w.prune()
+	return dir, nil
+}
+
+// writeConsensusState dumps the chain and consensus engine state as JSON.
+func (w *Watchdog) writeConsensusState(path string, kind string, head *types.Header, stalled time.Duration) error {
+	var (
+		chain  = w.backend.BlockChain()
+		engine = w.backend.Engine()
+	)
+	state := &consensusState{
+		Kind:      kind,
+		Stalled:   common.PrettyDuration(stalled).String(),
+		Number:    head.Number.Uint64(),
+		Hash:      head.Hash(),
+		Time:      head.Time,
+		Finalized: chain.GetLastFinalizedBlockNumber(),
+		Mining:    w.backend.IsMining(),
+		Synced:    w.backend.Synced(),
+	}
+	if turbo, ok := engine.(consensus.TurboEngine); ok {
+		validator := turbo.CurrentValidator()
+		attesting := turbo.AttestationStatus() == types.AttestationStart
+		state.Validator, state.Attesting = &validator, &attesting
+	}
+	if engine, ok := engine.(snapshotter); ok {
+		if snap, err := engine.SnapshotAt(chain, head.Number.Uint64(), head.Hash()); err == nil {
+			state.Snapshot, _ = json.Marshal(snap)
+		}
+	}
+	blob, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, blob, 0644)
+}
+
+// writeProfile writes the named runtime profile into path.
+func writeProfile(path string, name string, debug int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return pprof.Lookup(name).WriteTo(f, debug)
+}
+
+// writeCPUProfile records a CPU profile of the configured duration into path.
+// The recording fails if another CPU profile is already running, e.g. one
+// started through debug_cpuProfile.
+func (w *Watchdog) writeCPUProfile(path string) error {
+	if w.config.CPUProfile <= 0 {
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := pprof.StartCPUProfile(f); err != nil {
+		os.Remove(path)
+		return err
+	}
+	select {
+	case <-time.After(w.config.CPUProfile):
+	case <-w.quit:
+	}
+	pprof.StopCPUProfile()
+	return nil
+}
+
+// prune removes the oldest captures beyond the retention limit. Capture
+// directories are prefixed with their UTC timestamp, so they sort by age.
+func (w *Watchdog) prune() {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+	if len(dirs) <= w.config.Retain {
+		return
+	}
+	sort.Strings(dirs)
+	for _, name := range dirs[:len(dirs)-w.config.Retain] {
+		os.RemoveAll(filepath.Join(w.dir, name))
+	}
+}
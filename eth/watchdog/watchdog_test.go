@@ -0,0 +1,56 @@
+package watchdog
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestBlockPeriod(t *testing.T) {
+	scheduled := &params.ChainConfig{Turbo: &params.TurboConfig{Period: 3, Epoch: 10, Schedule: []params.TurboSchedule{{Block: big.NewInt(20), Period: 2, Epoch: 20}}}}
+	tests := []struct {
+		config *params.ChainConfig
+		number uint64
+		want   time.Duration
+	}{
+		{&params.ChainConfig{Turbo: &params.TurboConfig{Period: 3}}, 1, 3 * time.Second},
+		{&params.ChainConfig{Clique: &params.CliqueConfig{Period: 5}}, 1, 5 * time.Second},
+		{&params.ChainConfig{Turbo: &params.TurboConfig{}}, 1, defaultPeriod},
+		{&params.ChainConfig{}, 1, defaultPeriod},
+		{scheduled, 19, 3 * time.Second},
+		{scheduled, 20, 2 * time.Second},
+	}
+	for i, tt := range tests {
+		if have := blockPeriod(tt.config, tt.number); have != tt.want {
+			t.Errorf("test %d: period mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	w := &Watchdog{config: Config{Retain: 2}, dir: t.TempDir()}
+
+	names := []string{
+		"20240101T000000-sealing-10",
+		"20240101T000100-processing-11",
+		"20240102T000000-sealing-12",
+		"20240103T000000-sealing-13",
+	}
+	for _, name := range names {
+		if err := os.Mkdir(filepath.Join(w.dir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.prune()
+
+	for i, name := range names {
+		_, err := os.Stat(filepath.Join(w.dir, name))
+		if exist := err == nil; exist != (i >= 2) {
+			t.Errorf("capture %s: existence mismatch, have %v", name, exist)
+		}
+	}
+}
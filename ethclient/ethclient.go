@@ -530,6 +530,34 @@ func (ec *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockN
 	return hex, nil
 }
 
+// BatchCallContract executes several message calls at the given block in a
+// single JSON-RPC batch request, returning their outputs in order.
+func (ec *Client) BatchCallContract(ctx context.Context, msgs []ethereum.CallMsg, blockNumber *big.Int) ([][]byte, error) {
+	var (
+		block   = toBlockNumArg(blockNumber)
+		outputs = make([]hexutil.Bytes, len(msgs))
+		reqs    = make([]rpc.BatchElem, len(msgs))
+	)
+	for i, msg := range msgs {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_call",
+			Args:   []interface{}{toCallArg(msg), block},
+			Result: &outputs[i],
+		}
+	}
+	if err := ec.c.BatchCallContext(ctx, reqs); err != nil {
+		return nil, err
+	}
+	results := make([][]byte, len(msgs))
+	for i := range reqs {
+		if reqs[i].Error != nil {
+			return nil, fmt.Errorf("call %d: %w", i, reqs[i].Error)
+		}
+		results[i] = outputs[i]
+	}
+	return results, nil
+}
+
 // CallContractAtHash is almost the same as CallContract except that it selects
 // the block by block hash instead of block height.
 func (ec *Client) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
@@ -617,6 +645,45 @@ func (ec *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64
 	return uint64(hex), nil
 }
 
+// PreflightFailure describes why a prepared transaction would fail: the reason
+// is one of "denied_address", "blocked_event", "filtered", "create_denied",
+// "revert" or "error".
+type PreflightFailure struct {
+	Reason  string          `json:"reason"`
+	Message string          `json:"message"`
+	Address *common.Address `json:"address,omitempty"`
+	Topic   *common.Hash    `json:"topic,omitempty"`
+	Data    hexutil.Bytes   `json:"data,omitempty"`
+}
+
+// Error implements error.
+func (f *PreflightFailure) Error() string {
+	return f.Reason + ": " + f.Message
+}
+
+// PreparedTransaction is an unsigned transaction filled by the node, along with
+// the result of its preflight.
+type PreparedTransaction struct {
+	Tx      *types.Transaction `json:"tx"`
+	GasUsed hexutil.Uint64     `json:"gasUsed"`
+	Failure *PreflightFailure  `json:"failure,omitempty"`
+}
+
+// PrepareTransaction fills the nonce, fee and gas fields of the transaction and
+// runs it on the latest state of the node, checking the access filter and the
+// execution. The reason of a failing preflight is returned in the Failure field,
+// the transaction being ready to be signed and sent otherwise.
+func (ec *Client) PrepareTransaction(ctx context.Context, msg ethereum.CallMsg) (*PreparedTransaction, error) {
+	var prepared PreparedTransaction
+	if err := ec.c.CallContext(ctx, &prepared, "nero_prepareTransaction", toCallArg(msg)); err != nil {
+		return nil, err
+	}
+	if prepared.Tx == nil {
+		return nil, errors.New("missing prepared transaction")
+	}
+	return &prepared, nil
+}
+
 // SendTransaction injects a signed transaction into the pending pool for execution.
 //
 // If the transaction was a contract creation use the TransactionReceipt method to get the
@@ -0,0 +1,294 @@
+package simulated
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/consensus/turbo"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TurboBackend is a simulated blockchain sealed by the Turbo consensus engine.
+// Unlike Backend, the genesis deploys the Nero system contracts (staking,
+// genesis lock, ...) and blocks are finalized by attestations, so contract code
+// depending on staking, governance or finality can be tested from Go bindings.
+//
+// The chain is run by a single validator, which is also the admin of the system
+// contracts and funded in the genesis.
+type TurboBackend struct {
+	node      *node.Node
+	eth       *eth.Ethereum
+	engine    *turbo.Turbo
+	validator common.Address
+	signFn    turbo.ValidatorFn // Signs with the validator key
+	client    simClient
+
+	lock sync.Mutex // Serializes block production
+}
+
+// NewTurboBackend creates a new simulated Turbo blockchain validated by the
+// given key, that can be used as a backend for contract bindings in unit tests.
+//
+// A simulated Turbo backend always uses chainID 1337.
+func NewTurboBackend(alloc types.GenesisAlloc, validatorKey *ecdsa.PrivateKey, options ...func(nodeConf *node.Config, ethConf *ethconfig.Config)) *TurboBackend {
+	validator := crypto.PubkeyToAddress(validatorKey.PublicKey)
+
+	// Create the default configurations for the outer node shell and the Ethereum
+	// service to mutate with the options afterwards
+	nodeConf := node.DefaultConfig
+	nodeConf.DataDir = ""
+	nodeConf.P2P = p2p.Config{NoDiscovery: true}
+
+	config := *params.AllTurboProtocolChanges
+	turboConfig := *config.Turbo
+	config.Turbo = &turboConfig
+
+	genesis := core.BasicTurboGenesisBlock(&config, []common.Address{validator}, validator)
+	genesis.GasLimit = ethconfig.Defaults.Miner.GasCeil
+	for addr, account := range alloc {
+		genesis.Alloc[addr] = account
+	}
+	ethConf := ethconfig.Defaults
+	ethConf.Genesis = genesis
+	ethConf.SyncMode = downloader.FullSync
+	ethConf.TxPool.NoLocals = true
+	ethConf.Miner.Etherbase = validator
+
+	for _, option := range options {
+		option(&nodeConf, &ethConf)
+	}
+	// Assemble the Ethereum stack to run the chain with
+	stack, err := node.New(&nodeConf)
+	if err != nil {
+		panic(err) // this should never happen
+	}
+	sim, err := newTurboWithNode(stack, &ethConf, validatorKey)
+	if err != nil {
+		panic(err) // this should never happen
+	}
+	return sim
+}
+
+// newTurboWithNode sets up a simulated Turbo backend on an existing node. The
+// provided node must not be started and will be started by this method.
+func newTurboWithNode(stack *node.Node, conf *eth.Config, validatorKey *ecdsa.PrivateKey) (*TurboBackend, error) {
+	backend, err := eth.New(stack, conf)
+	if err != nil {
+		return nil, err
+	}
+	engine, ok := backend.Engine().(*turbo.Turbo)
+	if !ok {
+		return nil, errors.New("chain config does not enable the turbo engine")
+	}
+	// Register the filter system
+	filterSystem := filters.NewFilterSystem(backend.APIBackend, filters.Config{})
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "eth",
+		Service:   filters.NewFilterAPI(filterSystem),
+	}})
+	// Start the node
+	if err := stack.Start(); err != nil {
+		return nil, err
+	}
+	// Authorize the validator to seal blocks and attest to them
+	var (
+		validator = crypto.PubkeyToAddress(validatorKey.PublicKey)
+		signFn    = func(account accounts.Account, mimeType string, message []byte) ([]byte, error) {
+			return crypto.Sign(crypto.Keccak256(message), validatorKey)
+		}
+		signTxFn = func(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+			return types.SignTx(tx, types.LatestSignerForChainID(chainID), validatorKey)
+		}
+	)
+	engine.Authorize(validator, signFn, signTxFn)
+	engine.StartAttestation()
+
+	return &TurboBackend{
+		node:      stack,
+		eth:       backend,
+		engine:    engine,
+		validator: validator,
+		signFn:    signFn,
+		client:    simClient{ethclient.NewClient(stack.Attach())},
+	}, nil
+}
+
+// Close shuts down the simulated Turbo backend.
+// The simulated backend can't be used afterwards.
+func (n *TurboBackend) Close() error {
+	if n.client.Client != nil {
+		n.client.Close()
+		n.client = simClient{}
+	}
+	var err error
+	if n.node != nil {
+		err = n.node.Close()
+		n.node = nil
+	}
+	return err
+}
+
+// Commit seals a block with the pending transactions, attests to the chain and
+// moves it forward to a new block.
+//
+// Blocks are attested with the configured attestation delay: a block becomes
+// justified once its descendant at that distance is committed, and finalized
+// once its child is justified too.
+func (n *TurboBackend) Commit() common.Hash {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if err := n.sealBlock(); err != nil {
+		log.Warn("Error performing sealing work", "err", err)
+	}
+	return n.eth.BlockChain().CurrentBlock().Hash()
+}
+
+// Rollback removes all pending transactions, reverting to the last committed state.
+func (n *TurboBackend) Rollback() {
+	// Flush all transactions from the transaction pools
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)
+	n.eth.TxPool().SetGasTip(maxUint256)
+	// Set the gas tip back to accept new transactions
+	n.eth.TxPool().SetGasTip(n.eth.Config().Miner.GasPrice)
+}
+
+// Validator returns the address of the validator sealing the simulated chain.
+func (n *TurboBackend) Validator() common.Address {
+	return n.validator
+}
+
+// Finalized returns the number of the last block finalized by attestations.
+func (n *TurboBackend) Finalized() uint64 {
+	return n.eth.BlockChain().GetLastFinalizedBlockNumber()
+}
+
+// Client returns a client that accesses the simulated chain.
+func (n *TurboBackend) Client() Client {
+	return n.client
+}
+
+// sealBlock assembles a block of the pending transactions on top of the current
+// head, seals it, imports it and attests to the new chain head.
+func (n *TurboBackend) sealBlock() error {
+	var (
+		chain  = n.eth.BlockChain()
+		config = chain.Config()
+		parent = chain.CurrentBlock()
+		header = &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			GasLimit:   core.CalcGasLimit(parent.GasLimit, n.eth.Config().Miner.GasCeil),
+		}
+	)
+	if config.IsLondon(header.Number) {
+		header.BaseFee = eip1559.CalcBaseFee(config, parent)
+	}
+	if err := n.engine.Prepare(chain, header); err != nil {
+		return err
+	}
+	statedb, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return err
+	}
+	if err := n.engine.PreHandle(chain, header, statedb); err != nil {
+		return err
+	}
+	// Execute the pending transactions the same way the miner does
+	var (
+		signer   = types.MakeSigner(config, header.Number, header.Time)
+		filter   = n.engine.CreateEvmAccessFilter(header, statedb)
+		gasPool  = new(core.GasPool).AddGas(n.engine.CalculateGasPool(header))
+		txs      types.Transactions
+		receipts []*types.Receipt
+	)
+	pending := make(map[common.Address]types.Transactions)
+	for addr, batch := range n.eth.TxPool().Pending(txpool.PendingFilter{}) {
+		for _, lazy := range batch {
+			if tx := lazy.Resolve(); tx != nil {
+				pending[addr] = append(pending[addr], tx)
+			}
+		}
+	}
+	ordered := types.NewTransactionsByPriceAndNonce(signer, pending, header.BaseFee)
+	for tx := ordered.Peek(); tx != nil; tx = ordered.Peek() {
+		from, _ := types.Sender(signer, tx)
+		if err := n.engine.FilterTx(from, tx, header, statedb); err != nil {
+			ordered.Pop()
+			continue
+		}
+		snap := statedb.Snapshot()
+		statedb.SetTxContext(tx.Hash(), len(txs))
+		receipt, err := core.ApplyTransaction(config, chain, &header.Coinbase, gasPool, statedb, header, tx, &header.GasUsed, *chain.GetVMConfig(), filter)
+		if err != nil {
+			statedb.RevertToSnapshot(snap)
+			ordered.Pop()
+			continue
+		}
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+		ordered.Shift()
+	}
+	block, _, err := n.engine.FinalizeAndAssemble(chain, header, statedb, &types.Body{Transactions: txs}, receipts)
+	if err != nil {
+		return err
+	}
+	// Seal the block right away, the simulated chain doesn't wait for its slot
+	sealed := block.Header()
+	sig, err := n.signFn(accounts.Account{Address: n.validator}, accounts.MimetypeTurbo, turbo.TurboRLP(sealed))
+	if err != nil {
+		return err
+	}
+	copy(sealed.Extra[len(sealed.Extra)-crypto.SignatureLength:], sig)
+	if _, err := chain.InsertChain(types.Blocks{block.WithSeal(sealed)}); err != nil {
+		return err
+	}
+	return n.attest(chain.CurrentBlock())
+}
+
+// attest creates the validator's attestation for the block at the attestation
+// delay behind the head, justifying and finalizing the chain.
+func (n *TurboBackend) attest(head *types.Header) error {
+	chain := n.eth.BlockChain()
+	if err := chain.UpdateCurrentEpochBPList(head.Hash(), head.Number.Uint64()); err != nil {
+		return err
+	}
+	number, err := n.engine.CurrentNeedHandleHeight(head.Number.Uint64())
+	if err != nil {
+		return nil // Chain too short to attest yet
+	}
+	block := chain.GetBlockByNumber(number)
+	target := &types.RangeEdge{Hash: block.Hash(), Number: block.Number()}
+
+	a, err := n.engine.Attest(chain, block.Number(), chain.LastValidJustifiedOrFinalized(), target)
+	if err != nil {
+		return err
+	}
+	threshold, err := n.engine.AttestationThreshold(chain, target.Hash, number)
+	if err != nil {
+		return err
+	}
+	if err := chain.AddOneValidAttestationToRecentCache(a, threshold, n.validator); err != nil {
+		return err
+	}
+	chain.StoreLastAttested(target.Number)
+	return nil
+}
@@ -0,0 +1,65 @@
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestTurboBackend(t *testing.T) {
+	validatorKey, _ := crypto.GenerateKey()
+	sim := NewTurboBackend(types.GenesisAlloc{
+		testAddr: {Balance: big.NewInt(params.Ether)},
+	}, validatorKey)
+	defer sim.Close()
+
+	var (
+		ctx    = context.Background()
+		client = sim.Client()
+	)
+	// The system contracts are deployed in the genesis
+	code, err := client.CodeAt(ctx, system.StakingContract, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(code) == 0 {
+		t.Fatal("staking contract not deployed")
+	}
+	// Transactions are included by the validator
+	head, _ := client.HeaderByNumber(ctx, nil)
+	tx := types.MustSignNewTx(testKey, types.LatestSignerForChainID(params.AllTurboProtocolChanges.ChainID), &types.DynamicFeeTx{
+		ChainID:   params.AllTurboProtocolChanges.ChainID,
+		Gas:       params.TxGas,
+		GasFeeCap: new(big.Int).Add(head.BaseFee, big.NewInt(params.GWei)),
+		GasTipCap: big.NewInt(params.GWei),
+		To:        &testAddr,
+	})
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatalf("transaction not included: %v", err)
+	}
+	block, err := client.BlockByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Coinbase() != sim.Validator() {
+		t.Errorf("wrong block sealer: have %x, want %x", block.Coinbase(), sim.Validator())
+	}
+	// Blocks get finalized by the validator's attestations
+	for i := 0; i < 4; i++ {
+		sim.Commit()
+	}
+	if sim.Finalized() == 0 {
+		t.Error("no block finalized")
+	}
+}
@@ -87,6 +87,18 @@ type Database struct {
 // New returns a wrapped LevelDB object. The namespace is the prefix that the
 // metrics reporting should use for surfacing internal stats.
 func New(file string, cache int, handles int, namespace string, readonly bool) (*Database, error) {
+	return NewWithConfig(file, cache, handles, namespace, readonly, Config{})
+}
+
+// Config contains the tuning knobs of the database. The zero value of every
+// field selects the default behaviour.
+type Config struct {
+	WriteBufferShare int // Percentage of the cache used for the two write buffers (default 50)
+	BloomBits        int // Bits per key of the bloom filters (default 10)
+}
+
+// NewWithConfig returns a wrapped LevelDB object tuned by the given config.
+func NewWithConfig(file string, cache int, handles int, namespace string, readonly bool, config Config) (*Database, error) {
 	return NewCustom(file, namespace, func(options *opt.Options) {
 		// Ensure we have some minimal caching and file guarantees
 		if cache < minCache {
@@ -99,6 +111,13 @@ func New(file string, cache int, handles int, namespace string, readonly bool) (
 		options.OpenFilesCacheCapacity = handles
 		options.BlockCacheCapacity = cache / 2 * opt.MiB
 		options.WriteBuffer = cache / 4 * opt.MiB // Two of these are used internally
+		if share := config.WriteBufferShare; share > 0 && share < 100 {
+			options.BlockCacheCapacity = cache * (100 - share) / 100 * opt.MiB
+			options.WriteBuffer = cache * share / 200 * opt.MiB
+		}
+		if config.BloomBits > 0 {
+			options.Filter = filter.NewBloomFilter(config.BloomBits)
+		}
 		if readonly {
 			options.ReadOnly = true
 		}
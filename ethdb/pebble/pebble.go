@@ -145,6 +145,19 @@ func (l panicLogger) Fatalf(format string, args ...interface{}) {
 // New returns a wrapped pebble DB object. The namespace is the prefix that the
 // metrics reporting should use for surfacing internal stats.
 func New(file string, cache int, handles int, namespace string, readonly bool, ephemeral bool) (*Database, error) {
+	return NewWithConfig(file, cache, handles, namespace, readonly, ephemeral, Config{})
+}
+
+// Config contains the tuning knobs of the database. The zero value of every
+// field selects the default behaviour.
+type Config struct {
+	MemTableLimit         int // Number of memory tables, including the frozen ones (default 2)
+	CompactionConcurrency int // Maximum number of concurrent compactions (default all CPUs)
+	BloomBits             int // Bits per key of the bloom filters (default 10)
+}
+
+// NewWithConfig returns a wrapped pebble DB object tuned by the given config.
+func NewWithConfig(file string, cache int, handles int, namespace string, readonly bool, ephemeral bool, config Config) (*Database, error) {
 	// Ensure we have some minimal caching and file guarantees
 	if cache < minCache {
 		cache = minCache
@@ -170,6 +183,9 @@ func New(file string, cache int, handles int, namespace string, readonly bool, e
 	// Two memory tables is configured which is identical to leveldb,
 	// including a frozen memory table and another live one.
 	memTableLimit := 2
+	if config.MemTableLimit > 0 {
+		memTableLimit = config.MemTableLimit
+	}
 	memTableSize := cache * 1024 * 1024 / 2 / memTableLimit
 
 	// The memory table size is currently capped at maxMemTableSize-1 due to a
@@ -181,6 +197,20 @@ func New(file string, cache int, handles int, namespace string, readonly bool, e
 	if memTableSize >= maxMemTableSize {
 		memTableSize = maxMemTableSize - 1
 	}
+	compactions := runtime.NumCPU
+	if config.CompactionConcurrency > 0 {
+		compactions = func() int { return config.CompactionConcurrency }
+	}
+	bloomBits := 10
+	if config.BloomBits > 0 {
+		bloomBits = config.BloomBits
+	}
+	levels := make([]pebble.LevelOptions, 7)
+	for i := range levels {
+		levels[i] = pebble.LevelOptions{TargetFileSize: 2 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(bloomBits)}
+	}
+	logger.Info("Configured database tuning", "memtables", memTableLimit, "compactions", compactions(), "bloombits", bloomBits)
+
 	db := &Database{
 		fn:           file,
 		log:          logger,
@@ -206,20 +236,13 @@ func New(file string, cache int, handles int, namespace string, readonly bool, e
 		MemTableStopWritesThreshold: memTableLimit,
 
 		// The default compaction concurrency(1 thread),
-		// Here use all available CPUs for faster compaction.
-		MaxConcurrentCompactions: runtime.NumCPU,
+		// Here use all available CPUs for faster compaction,
+		// unless configured otherwise.
+		MaxConcurrentCompactions: compactions,
 
 		// Per-level options. Options for at least one level must be specified. The
 		// options for the last level are used for all subsequent levels.
-		Levels: []pebble.LevelOptions{
-			{TargetFileSize: 2 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
-			{TargetFileSize: 2 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
-			{TargetFileSize: 2 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
-			{TargetFileSize: 2 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
-			{TargetFileSize: 2 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
-			{TargetFileSize: 2 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
-			{TargetFileSize: 2 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
-		},
+		Levels:   levels,
 		ReadOnly: readonly,
 		EventListener: &pebble.EventListener{
 			CompactionBegin: db.onCompactionBegin,
@@ -0,0 +1,428 @@
+// Package eventstream publishes the chain events to message brokers.
+//
+// The canonical blocks, their receipts and internal transactions, the validator
+// set changes and the finality updates are published to Kafka or NATS topics
+// with at-least-once delivery: the progress is persisted in a cursor advanced
+// once the broker acknowledged the messages, the publication restarting from
+// the cursor after a failure or a restart. The blocks replaced by a reorg are
+// published again from the common ancestor, consumers must handle the
+// duplicates by block hash.
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+)
+
+// Topics the events are published to, after the configured prefix.
+const (
+	TopicBlocks      = "blocks"
+	TopicReceipts    = "receipts"
+	TopicInternalTxs = "internaltxs"
+	TopicValidators  = "validators"
+	TopicFinality    = "finality"
+)
+
+const (
+	// DefaultPrefix is the default prefix of the topics.
+	DefaultPrefix = "nero"
+
+	// maxBatchBlocks is the maximum number of blocks published at once.
+	maxBatchBlocks = 64
+
+	// retryInterval is the time waited before publishing again after a failure.
+	retryInterval = 5 * time.Second
+
+	chainHeadChanSize = 10
+	finalityChanSize  = 16
+)
+
+// Config is the configuration of the event publisher.
+type Config struct {
+	URL    string  `toml:",omitempty"` // Broker URL, see NewSink
+	Prefix string  `toml:",omitempty"` // Prefix of the topics, also naming the cursor
+	Replay *uint64 `toml:",omitempty"` // Block to publish from, overriding the cursor
+}
+
+// Event is the envelope of the published messages.
+type Event struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Data   interface{}    `json:"data"`
+}
+
+// FinalityUpdate is the data of the finality events.
+type FinalityUpdate struct {
+	Status string `json:"status"` // "justified" or "finalized"
+}
+
+// Chain is the blockchain the events are published from.
+type Chain interface {
+	consensus.ChainHeaderReader
+	Engine() consensus.Engine
+	CurrentBlock() *types.Header
+	CurrentSafeBlock() *types.Header
+	CurrentFinalBlock() *types.Header
+	GetCanonicalHash(number uint64) common.Hash
+	GetBlock(hash common.Hash, number uint64) *types.Block
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+	SubscribeNewJustifiedOrFinalizedBlockEvent(ch chan<- core.NewJustifiedOrFinalizedBlockEvent) event.Subscription
+}
+
+// Service publishes the chain events, run as a node lifecycle.
+type Service struct {
+	chain  Chain
+	db     ethdb.Database
+	sink   Sink
+	prefix string
+	replay *uint64
+
+	cursor     *rawdb.EventStreamCursor
+	validators []common.Address // Validator set of the last published block, nil if unknown
+
+	lock     sync.Mutex
+	finality []*types.BlockStatus // Finality updates waiting to be published
+	wake     chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates the event publisher and registers it with the node.
+func New(stack *node.Node, chain Chain, db ethdb.Database, config Config) (*Service, error) {
+	if config.URL == "" {
+		return nil, errors.New("missing event stream URL")
+	}
+	sink, err := NewSink(config.URL)
+	if err != nil {
+		return nil, err
+	}
+	s := newService(chain, db, sink, config)
+	stack.RegisterLifecycle(s)
+	return s, nil
+}
+
+func newService(chain Chain, db ethdb.Database, sink Sink, config Config) *Service {
+	prefix := config.Prefix
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	return &Service{
+		chain:  chain,
+		db:     db,
+		sink:   sink,
+		prefix: prefix,
+		replay: config.Replay,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Start implements node.Lifecycle, publishing the events in the background.
+func (s *Service) Start() error {
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.wg.Add(2)
+	go s.loop()
+	go s.publisher()
+	return nil
+}
+
+// Stop implements node.Lifecycle, interrupting the pending publication.
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	return s.sink.Close()
+}
+
+// loop collects the chain events, waking the publisher up. The subscriptions
+// are never blocked by the publication.
+func (s *Service) loop() {
+	defer s.wg.Done()
+
+	heads := make(chan core.ChainHeadEvent, chainHeadChanSize)
+	headSub := s.chain.SubscribeChainHeadEvent(heads)
+	defer headSub.Unsubscribe()
+
+	updates := make(chan core.NewJustifiedOrFinalizedBlockEvent, finalityChanSize)
+	updateSub := s.chain.SubscribeNewJustifiedOrFinalizedBlockEvent(updates)
+	defer updateSub.Unsubscribe()
+
+	for {
+		select {
+		case <-heads:
+		case ev := <-updates:
+			if ev.JF == nil {
+				continue
+			}
+			s.lock.Lock()
+			s.finality = append(s.finality, ev.JF)
+			s.lock.Unlock()
+		case <-headSub.Err():
+			return
+		case <-updateSub.Err():
+			return
+		case <-s.ctx.Done():
+			return
+		}
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// publisher publishes the events as the chain progresses, retrying after
+// failures.
+func (s *Service) publisher() {
+	defer s.wg.Done()
+
+	s.init()
+	log.Info("Started event publisher", "prefix", s.prefix, "next", s.cursor.Next)
+
+	for {
+		var retry <-chan time.Time
+		if err := s.publish(); err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			log.Warn("Failed to publish chain events", "next", s.cursor.Next, "err", err)
+			retry = time.After(retryInterval)
+		}
+		select {
+		case <-s.wake:
+		case <-retry:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// init sets the cursor up, from the replay block if requested, from the stored
+// cursor otherwise, or from the current head if the publisher never ran. The
+// current finality checkpoints are queued, consumers may have missed them.
+func (s *Service) init() {
+	switch {
+	case s.replay != nil:
+		s.cursor = &rawdb.EventStreamCursor{Next: *s.replay}
+		if *s.replay > 0 {
+			s.cursor.Parent = s.chain.GetCanonicalHash(*s.replay - 1)
+		}
+	default:
+		if s.cursor = rawdb.ReadEventStreamCursor(s.db, s.prefix); s.cursor == nil {
+			head := s.chain.CurrentBlock()
+			s.cursor = &rawdb.EventStreamCursor{Next: head.Number.Uint64(), Parent: head.ParentHash}
+		}
+	}
+	var current []*types.BlockStatus
+	if header := s.chain.CurrentSafeBlock(); header != nil {
+		current = append(current, &types.BlockStatus{BlockNumber: header.Number, Hash: header.Hash(), Status: types.BasJustified})
+	}
+	if header := s.chain.CurrentFinalBlock(); header != nil {
+		current = append(current, &types.BlockStatus{BlockNumber: header.Number, Hash: header.Hash(), Status: types.BasFinalized})
+	}
+	s.lock.Lock()
+	s.finality = append(current, s.finality...)
+	s.lock.Unlock()
+}
+
+// publish publishes the blocks from the cursor up to the chain head, then the
+// pending finality updates.
+func (s *Service) publish() error {
+	for {
+		s.rewind()
+
+		head := s.chain.CurrentBlock().Number.Uint64()
+		if s.cursor.Next > head {
+			break
+		}
+		last := s.cursor.Next + maxBatchBlocks - 1
+		if last > head {
+			last = head
+		}
+		if err := s.publishBlocks(s.cursor.Next, last); err != nil {
+			return err
+		}
+	}
+	return s.publishFinality()
+}
+
+// rewind moves the cursor back to the canonical chain if the published blocks
+// were reorged.
+func (s *Service) rewind() {
+	for s.cursor.Next > 0 && s.cursor.Parent != (common.Hash{}) {
+		if s.chain.GetCanonicalHash(s.cursor.Next-1) == s.cursor.Parent {
+			return
+		}
+		header := s.chain.GetHeader(s.cursor.Parent, s.cursor.Next-1)
+		if header == nil {
+			// The reorged block is gone, publish again the canonical one.
+			s.cursor.Parent = common.Hash{}
+			s.validators = nil
+			return
+		}
+		log.Debug("Rewinding event publisher", "number", header.Number, "hash", header.Hash())
+		s.cursor.Next--
+		s.cursor.Parent = header.ParentHash
+		s.validators = nil
+	}
+}
+
+// publishBlocks publishes the events of the canonical blocks in the range and
+// advances the cursor.
+func (s *Service) publishBlocks(first, last uint64) error {
+	var (
+		config     = s.chain.Config()
+		topics     = []string{TopicBlocks, TopicReceipts, TopicInternalTxs, TopicValidators}
+		msgs       = make(map[string][]Message)
+		validators = s.validators
+	)
+	blocks := make([]*types.Block, 0, last-first+1)
+	for number := first; number <= last; number++ {
+		block := s.chain.GetBlock(s.chain.GetCanonicalHash(number), number)
+		if block == nil {
+			return errors.New("missing canonical block")
+		}
+		blocks = append(blocks, block)
+	}
+	for _, block := range blocks {
+		var (
+			hash   = block.Hash()
+			number = block.NumberU64()
+		)
+		receipts := s.chain.GetReceiptsByHash(hash)
+		if receipts == nil {
+			receipts = types.Receipts{}
+		}
+		itxs := rawdb.ReadInternalTxs(s.db, hash, number)
+		if itxs == nil {
+			itxs = []*types.InternalTx{}
+		}
+		for topic, data := range map[string]interface{}{
+			TopicBlocks:      ethapi.RPCMarshalBlock(block, true, true, config),
+			TopicReceipts:    receipts,
+			TopicInternalTxs: itxs,
+		} {
+			msg, err := s.message(topic, number, hash, data, "")
+			if err != nil {
+				return err
+			}
+			msgs[topic] = append(msgs[topic], msg)
+		}
+		set, changed, err := s.validatorChange(validators, block.Header())
+		if err != nil {
+			return err
+		}
+		if changed {
+			msg, err := s.message(TopicValidators, number, hash, set, "")
+			if err != nil {
+				return err
+			}
+			msgs[TopicValidators] = append(msgs[TopicValidators], msg)
+		}
+		validators = set
+	}
+	for _, topic := range topics {
+		if len(msgs[topic]) == 0 {
+			continue
+		}
+		if err := s.sink.Publish(s.ctx, s.topic(topic), msgs[topic]); err != nil {
+			return err
+		}
+	}
+	s.validators = validators
+	s.cursor = &rawdb.EventStreamCursor{Next: last + 1, Parent: blocks[len(blocks)-1].Hash()}
+	rawdb.WriteEventStreamCursor(s.db, s.prefix, s.cursor)
+	return nil
+}
+
+// validatorChange retrieves the validator set of the block and reports whether
+// it differs from the previous one, or the parent one if unknown. Chains not
+// run by the Turbo engine have no validator sets.
+func (s *Service) validatorChange(prev []common.Address, header *types.Header) ([]common.Address, bool, error) {
+	engine, ok := s.chain.Engine().(consensus.TurboEngine)
+	if !ok {
+		return nil, false, nil
+	}
+	set, err := engine.Validators(s.chain, header.Hash(), header.Number.Uint64())
+	if err != nil {
+		return nil, false, err
+	}
+	if prev == nil && header.Number.Uint64() > 0 {
+		if prev, err = engine.Validators(s.chain, header.ParentHash, header.Number.Uint64()-1); err != nil {
+			return nil, false, err
+		}
+	}
+	if len(set) != len(prev) {
+		return set, true, nil
+	}
+	for i := range set {
+		if set[i] != prev[i] {
+			return set, true, nil
+		}
+	}
+	return set, false, nil
+}
+
+// publishFinality publishes the pending finality updates.
+func (s *Service) publishFinality() error {
+	s.lock.Lock()
+	updates := s.finality
+	s.lock.Unlock()
+
+	if len(updates) == 0 {
+		return nil
+	}
+	msgs := make([]Message, 0, len(updates))
+	for _, update := range updates {
+		status := "justified"
+		if update.Status == types.BasFinalized {
+			status = "finalized"
+		}
+		msg, err := s.message(TopicFinality, update.BlockNumber.Uint64(), update.Hash, &FinalityUpdate{Status: status}, status)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	if err := s.sink.Publish(s.ctx, s.topic(TopicFinality), msgs); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	s.finality = s.finality[len(updates):]
+	s.lock.Unlock()
+	return nil
+}
+
+// message creates the message of an event, identified by the topic, the block
+// hash and the discriminator.
+func (s *Service) message(topic string, number uint64, hash common.Hash, data interface{}, discriminator string) (Message, error) {
+	value, err := json.Marshal(&Event{Number: hexutil.Uint64(number), Hash: hash, Data: data})
+	if err != nil {
+		return Message{}, err
+	}
+	id := topic + "-" + hash.Hex()
+	if discriminator != "" {
+		id += "-" + discriminator
+	}
+	return Message{ID: id, Key: hash.Bytes(), Value: value}, nil
+}
+
+func (s *Service) topic(name string) string {
+	return s.prefix + "." + name
+}
@@ -0,0 +1,220 @@
+package eventstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testSink records the published messages, failing the publications while
+// an error is set.
+type testSink struct {
+	lock sync.Mutex
+	msgs map[string][]Message
+	err  error
+}
+
+func newTestSink() *testSink {
+	return &testSink{msgs: make(map[string][]Message)}
+}
+
+func (s *testSink) Publish(ctx context.Context, topic string, msgs []Message) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.msgs[topic] = append(s.msgs[topic], msgs...)
+	return nil
+}
+
+func (s *testSink) Close() error { return nil }
+
+// numbers returns the block numbers of the events published to the topic.
+func (s *testSink) numbers(t *testing.T, topic string) []uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var numbers []uint64
+	for _, msg := range s.msgs[topic] {
+		var ev Event
+		if err := json.Unmarshal(msg.Value, &ev); err != nil {
+			t.Fatalf("invalid event: %v", err)
+		}
+		if common.BytesToHash(msg.Key) != ev.Hash {
+			t.Errorf("message key %x doesn't match event hash %x", msg.Key, ev.Hash)
+		}
+		numbers = append(numbers, uint64(ev.Number))
+	}
+	return numbers
+}
+
+func (s *testSink) reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.msgs = make(map[string][]Message)
+}
+
+func newTestChain(t *testing.T) (*core.BlockChain, ethdb.Database) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{Config: params.TestChainConfig}
+	)
+	chain, err := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	t.Cleanup(chain.Stop)
+	return chain, db
+}
+
+// generate creates blocks on top of the genesis, the ones after the fork point
+// having a distinct coinbase.
+func generate(chain *core.BlockChain, db ethdb.Database, n int, fork int) []*types.Block {
+	blocks, _ := core.GenerateChain(chain.Config(), chain.Genesis(), ethash.NewFaker(), db, n, func(i int, gen *core.BlockGen) {
+		if fork >= 0 && i >= fork {
+			gen.SetCoinbase(common.Address{0x01})
+		}
+	})
+	return blocks
+}
+
+func newTestService(chain *core.BlockChain, db ethdb.Database, sink Sink, config Config) *Service {
+	s := newService(chain, db, sink, config)
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.init()
+	return s
+}
+
+func checkNumbers(t *testing.T, sink *testSink, topic string, want ...uint64) {
+	t.Helper()
+	have := sink.numbers(t, topic)
+	if len(have) != len(want) {
+		t.Fatalf("%s: published blocks mismatch: have %v, want %v", topic, have, want)
+	}
+	for i := range have {
+		if have[i] != want[i] {
+			t.Fatalf("%s: published blocks mismatch: have %v, want %v", topic, have, want)
+		}
+	}
+}
+
+func TestPublishResume(t *testing.T) {
+	chain, db := newTestChain(t)
+	blocks := generate(chain, db, 7, -1)
+	if _, err := chain.InsertChain(blocks[:5]); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	sink := newTestSink()
+	replay := uint64(1)
+	s := newTestService(chain, db, sink, Config{Replay: &replay})
+	if err := s.publish(); err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+	checkNumbers(t, sink, "nero.blocks", 1, 2, 3, 4, 5)
+	checkNumbers(t, sink, "nero.receipts", 1, 2, 3, 4, 5)
+	checkNumbers(t, sink, "nero.internaltxs", 1, 2, 3, 4, 5)
+
+	if cursor := rawdb.ReadEventStreamCursor(db, DefaultPrefix); cursor == nil || cursor.Next != 6 || cursor.Parent != blocks[4].Hash() {
+		t.Fatalf("cursor mismatch: have %+v, want next 6", cursor)
+	}
+	// Restart from the stored cursor.
+	if _, err := chain.InsertChain(blocks[5:]); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	sink.reset()
+	s = newTestService(chain, db, sink, Config{})
+	if err := s.publish(); err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+	checkNumbers(t, sink, "nero.blocks", 6, 7)
+}
+
+func TestPublishRetry(t *testing.T) {
+	chain, db := newTestChain(t)
+	if _, err := chain.InsertChain(generate(chain, db, 3, -1)); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	sink := newTestSink()
+	sink.err = errors.New("broker down")
+
+	replay := uint64(0)
+	s := newTestService(chain, db, sink, Config{Prefix: "test", Replay: &replay})
+	if err := s.publish(); err == nil {
+		t.Fatal("publication succeeded with a failing broker")
+	}
+	if cursor := rawdb.ReadEventStreamCursor(db, "test"); cursor != nil {
+		t.Fatalf("cursor advanced on failure: %+v", cursor)
+	}
+	sink.err = nil
+	if err := s.publish(); err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+	checkNumbers(t, sink, "test.blocks", 0, 1, 2, 3)
+}
+
+func TestPublishReorg(t *testing.T) {
+	chain, db := newTestChain(t)
+	if _, err := chain.InsertChain(generate(chain, db, 4, -1)); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	sink := newTestSink()
+	replay := uint64(1)
+	s := newTestService(chain, db, sink, Config{Replay: &replay})
+	if err := s.publish(); err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+	// Reorg the chain from block 3 and check the new blocks get published
+	// from the common ancestor.
+	fork := generate(chain, db, 6, 2)
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	if chain.CurrentBlock().Hash() != fork[5].Hash() {
+		t.Fatal("fork not canonical")
+	}
+	sink.reset()
+	if err := s.publish(); err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+	checkNumbers(t, sink, "nero.blocks", 3, 4, 5, 6)
+}
+
+func TestPublishFinality(t *testing.T) {
+	chain, db := newTestChain(t)
+	blocks := generate(chain, db, 2, -1)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert blocks: %v", err)
+	}
+	chain.SetFinalized(blocks[0].Header())
+
+	sink := newTestSink()
+	s := newTestService(chain, db, sink, Config{})
+	s.finality = append(s.finality, &types.BlockStatus{BlockNumber: blocks[1].Number(), Hash: blocks[1].Hash(), Status: types.BasJustified})
+	if err := s.publish(); err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+	checkNumbers(t, sink, "nero.finality", 1, 2)
+
+	var ev struct {
+		Data FinalityUpdate `json:"data"`
+	}
+	if err := json.Unmarshal(sink.msgs["nero.finality"][0].Value, &ev); err != nil || ev.Data.Status != "finalized" {
+		t.Errorf("finality status mismatch: have %q (%v), want finalized", ev.Data.Status, err)
+	}
+	if len(s.finality) != 0 {
+		t.Errorf("finality updates left pending: %d", len(s.finality))
+	}
+}
@@ -0,0 +1,108 @@
+package eventstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// kafkaTimeout is the time allowed to the REST proxy to acknowledge a batch.
+const kafkaTimeout = 30 * time.Second
+
+// kafkaSink publishes messages through a Kafka REST proxy, using the v2 API.
+type kafkaSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newKafkaSink(u *url.URL) (*kafkaSink, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing Kafka REST proxy host in %q", u.Redacted())
+	}
+	endpoint := *u
+	endpoint.Scheme = strings.TrimPrefix(u.Scheme, "kafka+")
+	return &kafkaSink{
+		endpoint: strings.TrimSuffix(endpoint.String(), "/"),
+		client:   &http.Client{Timeout: kafkaTimeout},
+	}, nil
+}
+
+type kafkaRecord struct {
+	Key   []byte `json:"key,omitempty"`
+	Value []byte `json:"value"`
+}
+
+type kafkaOffset struct {
+	Partition int32  `json:"partition"`
+	Offset    int64  `json:"offset"`
+	ErrorCode *int   `json:"error_code"`
+	Error     string `json:"error"`
+}
+
+type kafkaError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// Publish implements Sink, producing the messages as binary records.
+func (s *kafkaSink) Publish(ctx context.Context, topic string, msgs []Message) error {
+	records := make([]kafkaRecord, len(msgs))
+	for i, msg := range msgs {
+		records[i] = kafkaRecord{Key: msg.Key, Value: msg.Value}
+	}
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/topics/"+url.PathEscape(topic), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.binary.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	blob, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		var kerr kafkaError
+		if json.Unmarshal(blob, &kerr) == nil && kerr.Message != "" {
+			return fmt.Errorf("kafka proxy error %d: %s", kerr.ErrorCode, kerr.Message)
+		}
+		return fmt.Errorf("kafka proxy error: %s", res.Status)
+	}
+	var result struct {
+		Offsets []kafkaOffset `json:"offsets"`
+	}
+	if err := json.Unmarshal(blob, &result); err != nil {
+		return fmt.Errorf("invalid kafka proxy response: %v", err)
+	}
+	if len(result.Offsets) != len(msgs) {
+		return fmt.Errorf("kafka proxy acknowledged %d of %d records", len(result.Offsets), len(msgs))
+	}
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("kafka record error %d: %s", *offset.ErrorCode, offset.Error)
+		}
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *kafkaSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,271 @@
+package eventstream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// natsTimeout is the time allowed to the server to acknowledge a batch,
+	// unless the context has an earlier deadline.
+	natsTimeout = 30 * time.Second
+
+	// natsDefaultPort is the default client port of the NATS servers.
+	natsDefaultPort = "4222"
+)
+
+// natsSink publishes messages to NATS JetStream, waiting for the stream
+// acknowledgements. The message identifiers are sent in the Nats-Msg-Id
+// header, letting the streams deduplicate the messages published again after
+// a failure.
+//
+// The sink speaks the NATS client protocol directly, the streams capturing the
+// subjects must be created beforehand.
+type natsSink struct {
+	addr string
+	user *url.Userinfo
+
+	lock  sync.Mutex
+	conn  net.Conn
+	r     *bufio.Reader
+	inbox string
+}
+
+func newNATSSink(u *url.URL) (*natsSink, error) {
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("missing NATS host in %q", u.Redacted())
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+	return &natsSink{addr: addr, user: u.User}, nil
+}
+
+// Publish implements Sink. The connection is reestablished on the next call if
+// the publication fails.
+func (s *natsSink) Publish(ctx context.Context, subject string, msgs []Message) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn == nil {
+		if err := s.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if err := s.publish(ctx, subject, msgs); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *natsSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// setDeadline bounds the exchanges on the connection by the context deadline
+// or the default timeout.
+func (s *natsSink) setDeadline(ctx context.Context) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(natsTimeout)
+	}
+	s.conn.SetDeadline(deadline)
+}
+
+// connect dials the server, performs the handshake and subscribes to the inbox
+// receiving the acknowledgements.
+func (s *natsSink) connect(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	s.conn, s.r = conn, bufio.NewReader(conn)
+	s.setDeadline(ctx)
+
+	if err := s.handshake(); err != nil {
+		s.conn.Close()
+		s.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (s *natsSink) handshake() error {
+	line, err := s.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("unexpected NATS greeting %q", line)
+	}
+	options := map[string]interface{}{
+		"verbose":       false,
+		"pedantic":      false,
+		"lang":          "go",
+		"version":       params.Version,
+		"protocol":      1,
+		"headers":       true,
+		"no_responders": true,
+	}
+	if s.user != nil {
+		options["user"] = s.user.Username()
+		if pass, ok := s.user.Password(); ok {
+			options["pass"] = pass
+		}
+	}
+	blob, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return err
+	}
+	s.inbox = "_INBOX." + hex.EncodeToString(nonce[:])
+
+	if _, err := fmt.Fprintf(s.conn, "CONNECT %s\r\nSUB %s.* 1\r\nPING\r\n", blob, s.inbox); err != nil {
+		return err
+	}
+	for {
+		line, err := s.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+// publish sends the messages with a reply subject each, then waits for all the
+// stream acknowledgements.
+func (s *natsSink) publish(ctx context.Context, subject string, msgs []Message) error {
+	s.setDeadline(ctx)
+
+	var buf bytes.Buffer
+	for i, msg := range msgs {
+		header := "NATS/1.0\r\n"
+		if msg.ID != "" {
+			header += "Nats-Msg-Id: " + msg.ID + "\r\n"
+		}
+		header += "\r\n"
+		fmt.Fprintf(&buf, "HPUB %s %s.%d %d %d\r\n%s", subject, s.inbox, i, len(header), len(header)+len(msg.Value), header)
+		buf.Write(msg.Value)
+		buf.WriteString("\r\n")
+	}
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	acked := make(map[string]bool)
+	for len(acked) < len(msgs) {
+		line, err := s.readLine()
+		if err != nil {
+			return err
+		}
+		op, args, _ := strings.Cut(line, " ")
+		switch op {
+		case "PING":
+			if _, err := io.WriteString(s.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case "-ERR":
+			return fmt.Errorf("NATS server error: %s", args)
+		case "MSG", "HMSG":
+			reply, err := s.readAck(op == "HMSG", strings.Fields(args))
+			if err != nil {
+				return err
+			}
+			acked[reply] = true
+		}
+	}
+	return nil
+}
+
+// readAck reads the payload of an inbox message and checks it acknowledges the
+// publication, returning the subject the acknowledgement was received on.
+func (s *natsSink) readAck(headers bool, args []string) (string, error) {
+	// MSG <subject> <sid> [reply] <size>, HMSG <subject> <sid> [reply] <header size> <size>
+	fields := 4
+	if headers {
+		fields = 5
+	}
+	if len(args) != fields && len(args) != fields-1 {
+		return "", fmt.Errorf("invalid NATS message arguments %q", args)
+	}
+	size, err := strconv.Atoi(args[len(args)-1])
+	if err != nil || size < 0 {
+		return "", fmt.Errorf("invalid NATS message size %q", args[len(args)-1])
+	}
+	payload := make([]byte, size+2)
+	if _, err := io.ReadFull(s.r, payload); err != nil {
+		return "", err
+	}
+	payload = payload[:size]
+
+	if headers {
+		hsize, err := strconv.Atoi(args[len(args)-2])
+		if err != nil || hsize < 0 || hsize > size {
+			return "", fmt.Errorf("invalid NATS header size %q", args[len(args)-2])
+		}
+		// Status headers without payload are sent when no stream captured
+		// the subject.
+		status, _, _ := strings.Cut(string(payload[:hsize]), "\r\n")
+		if hsize == size && strings.Contains(status, " 503") {
+			return "", errors.New("no NATS stream captures the subject")
+		}
+		payload = payload[hsize:]
+	}
+	var ack struct {
+		Stream string `json:"stream"`
+		Error  *struct {
+			Code        int    `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(payload, &ack); err != nil {
+		return "", fmt.Errorf("invalid NATS acknowledgement: %v", err)
+	}
+	if ack.Error != nil {
+		return "", fmt.Errorf("NATS stream error %d: %s", ack.Error.Code, ack.Error.Description)
+	}
+	return args[0], nil
+}
+
+func (s *natsSink) readLine() (string, error) {
+	line, err := s.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,43 @@
+package eventstream
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Message is a message published to a topic.
+type Message struct {
+	ID    string // Unique identifier, for the brokers deduplicating messages
+	Key   []byte // Partitioning key
+	Value []byte
+}
+
+// Sink delivers messages to a message broker.
+type Sink interface {
+	// Publish delivers the messages to the topic, returning once the broker
+	// acknowledged all of them. The messages may have been partially delivered
+	// if an error is returned.
+	Publish(ctx context.Context, topic string, msgs []Message) error
+
+	// Close releases the connections to the broker.
+	Close() error
+}
+
+// NewSink creates the sink delivering messages to the broker at the URL, either
+// nats://[user:pass@]host:port for a NATS JetStream server or
+// kafka+http(s)://host:port for a Kafka REST proxy.
+func NewSink(rawurl string) (Sink, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "nats":
+		return newNATSSink(u)
+	case "kafka+http", "kafka+https":
+		return newKafkaSink(u)
+	default:
+		return nil, fmt.Errorf("unsupported event stream scheme %q", u.Scheme)
+	}
+}
@@ -0,0 +1,147 @@
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestKafkaSink(t *testing.T) {
+	var received []kafkaRecord
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/topics/nero.blocks" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error_code":40401,"message":"Topic not found"}`)
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/vnd.kafka.binary.v2+json" {
+			t.Errorf("content type mismatch: %s", ct)
+		}
+		var body struct {
+			Records []kafkaRecord `json:"records"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid request: %v", err)
+		}
+		received = append(received, body.Records...)
+		offsets := make([]kafkaOffset, len(body.Records))
+		for i := range offsets {
+			offsets[i].Offset = int64(i)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"offsets": offsets})
+	}))
+	defer server.Close()
+
+	sink, err := NewSink("kafka+" + server.URL)
+	if err != nil {
+		t.Fatalf("failed to create sink: %v", err)
+	}
+	defer sink.Close()
+
+	msgs := []Message{{Key: []byte{1}, Value: []byte(`{"a":1}`)}, {Key: []byte{2}, Value: []byte(`{"b":2}`)}}
+	if err := sink.Publish(context.Background(), "nero.blocks", msgs); err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+	if len(received) != 2 || string(received[1].Value) != `{"b":2}` || received[1].Key[0] != 2 {
+		t.Errorf("received records mismatch: %+v", received)
+	}
+	err = sink.Publish(context.Background(), "nero.missing", msgs)
+	if err == nil || !strings.Contains(err.Error(), "Topic not found") {
+		t.Errorf("missing topic error mismatch: %v", err)
+	}
+}
+
+// serveNATS runs a minimal JetStream server on the connection, acknowledging
+// the messages published to the subjects captured by the stream and answering
+// with no responders otherwise.
+func serveNATS(t *testing.T, conn net.Conn, stream string, published chan<- string) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"headers\":true}\r\n")
+
+	var seq int
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		op, args, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		switch op {
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "HPUB":
+			fields := strings.Fields(args)
+			size, _ := strconv.Atoi(fields[3])
+			hsize, _ := strconv.Atoi(fields[2])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			if !strings.Contains(string(payload[:hsize]), "Nats-Msg-Id: ") {
+				t.Errorf("missing message id header")
+			}
+			if !strings.HasPrefix(fields[0], stream+".") {
+				status := "NATS/1.0 503\r\n\r\n"
+				fmt.Fprintf(conn, "HMSG %s 1 %d %d\r\n%s\r\n", fields[1], len(status), len(status), status)
+				continue
+			}
+			seq++
+			published <- string(payload[hsize:size])
+			ack := fmt.Sprintf(`{"stream":%q,"seq":%d}`, stream, seq)
+			fmt.Fprintf(conn, "MSG %s 1 %d\r\n%s\r\n", fields[1], len(ack), ack)
+		}
+	}
+}
+
+func TestNATSSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	published := make(chan string, 16)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveNATS(t, conn, "nero", published)
+		}
+	}()
+	sink, err := NewSink("nats://" + listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to create sink: %v", err)
+	}
+	defer sink.Close()
+
+	msgs := []Message{{ID: "a", Value: []byte(`{"a":1}`)}, {ID: "b", Value: []byte(`{"b":2}`)}}
+	if err := sink.Publish(context.Background(), "nero.blocks", msgs); err != nil {
+		t.Fatalf("failed to publish: %v", err)
+	}
+	for _, want := range []string{`{"a":1}`, `{"b":2}`} {
+		if have := <-published; have != want {
+			t.Errorf("published message mismatch: have %s, want %s", have, want)
+		}
+	}
+	if err := sink.Publish(context.Background(), "other.blocks", msgs[:1]); err == nil {
+		t.Error("publication succeeded without stream")
+	}
+	// The connection is reestablished after the failure.
+	if err := sink.Publish(context.Background(), "nero.finality", msgs[:1]); err != nil {
+		t.Fatalf("failed to publish after failure: %v", err)
+	}
+	if have := <-published; have != `{"a":1}` {
+		t.Errorf("published message mismatch: have %s", have)
+	}
+}
@@ -0,0 +1,146 @@
+package grpcapi
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/grpcapi/pb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// bigBytes encodes the integer as big-endian bytes, nil if unset.
+func bigBytes(n *big.Int) []byte {
+	if n == nil {
+		return nil
+	}
+	return n.Bytes()
+}
+
+func newCheckpoint(header *types.Header) *pb.Checkpoint {
+	return &pb.Checkpoint{Number: header.Number.Uint64(), Hash: header.Hash().Bytes()}
+}
+
+func newHeader(header *types.Header) *pb.Header {
+	return &pb.Header{
+		Hash:             header.Hash().Bytes(),
+		ParentHash:       header.ParentHash.Bytes(),
+		Number:           header.Number.Uint64(),
+		Time:             header.Time,
+		Coinbase:         header.Coinbase.Bytes(),
+		StateRoot:        header.Root.Bytes(),
+		TransactionsRoot: header.TxHash.Bytes(),
+		ReceiptsRoot:     header.ReceiptHash.Bytes(),
+		LogsBloom:        header.Bloom.Bytes(),
+		Difficulty:       bigBytes(header.Difficulty),
+		GasLimit:         header.GasLimit,
+		GasUsed:          header.GasUsed,
+		BaseFee:          bigBytes(header.BaseFee),
+		Extra:            header.Extra,
+		MixDigest:        header.MixDigest.Bytes(),
+		Nonce:            header.Nonce.Uint64(),
+	}
+}
+
+// newTransaction converts the transaction, the block being nil for pending
+// transactions.
+func newTransaction(signer types.Signer, tx *types.Transaction, block *types.Header, index uint64) *pb.Transaction {
+	raw, _ := tx.MarshalBinary()
+	from, _ := types.Sender(signer, tx)
+
+	res := &pb.Transaction{
+		Hash: tx.Hash().Bytes(),
+		Raw:  raw,
+		From: from.Bytes(),
+	}
+	if block != nil {
+		res.BlockHash = block.Hash().Bytes()
+		res.BlockNumber = block.Number.Uint64()
+		res.Index = index
+	}
+	return res
+}
+
+func newBlock(config *params.ChainConfig, block *types.Block, fullTxs bool) *pb.Block {
+	var (
+		header = block.Header()
+		txs    = block.Transactions()
+		signer = types.MakeSigner(config, block.Number(), block.Time())
+	)
+	res := &pb.Block{
+		Header:            newHeader(header),
+		Size:              block.Size(),
+		TransactionHashes: make([][]byte, len(txs)),
+	}
+	for i, tx := range txs {
+		res.TransactionHashes[i] = tx.Hash().Bytes()
+		if fullTxs {
+			res.Transactions = append(res.Transactions, newTransaction(signer, tx, header, uint64(i)))
+		}
+	}
+	return res
+}
+
+func newReceipt(signer types.Signer, tx *types.Transaction, receipt *types.Receipt) *pb.Receipt {
+	from, _ := types.Sender(signer, tx)
+
+	res := &pb.Receipt{
+		TransactionHash:   receipt.TxHash.Bytes(),
+		TransactionIndex:  uint64(receipt.TransactionIndex),
+		BlockHash:         receipt.BlockHash.Bytes(),
+		BlockNumber:       receipt.BlockNumber.Uint64(),
+		From:              from.Bytes(),
+		Type:              uint32(receipt.Type),
+		Status:            receipt.Status,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: bigBytes(receipt.EffectiveGasPrice),
+		LogsBloom:         receipt.Bloom.Bytes(),
+		Logs:              make([]*pb.Log, len(receipt.Logs)),
+	}
+	if to := tx.To(); to != nil {
+		res.To = to.Bytes()
+	}
+	if receipt.ContractAddress != (common.Address{}) {
+		res.ContractAddress = receipt.ContractAddress.Bytes()
+	}
+	for i, log := range receipt.Logs {
+		topics := make([][]byte, len(log.Topics))
+		for j, topic := range log.Topics {
+			topics[j] = topic.Bytes()
+		}
+		res.Logs[i] = &pb.Log{
+			Address: log.Address.Bytes(),
+			Topics:  topics,
+			Data:    log.Data,
+			Index:   uint64(log.Index),
+		}
+	}
+	return res
+}
+
+func newInternalTransaction(itx *types.InternalTx) *pb.InternalTransaction {
+	res := &pb.InternalTransaction{
+		TransactionHash: itx.TxHash.Bytes(),
+		Actions:         make([]*pb.Action, len(itx.Actions)),
+	}
+	for i, action := range itx.Actions {
+		res.Actions[i] = &pb.Action{
+			From:         action.From.Bytes(),
+			Value:        bigBytes(action.Value),
+			Success:      action.Success,
+			Opcode:       action.OpCode,
+			Depth:        action.Depth,
+			Gas:          action.Gas,
+			GasUsed:      action.GasUsed,
+			Input:        action.Input,
+			Output:       action.Output,
+			TraceAddress: action.TraceAddress,
+			Error:        action.Error,
+		}
+		if action.To != (common.Address{}) {
+			res.Actions[i].To = action.To.Bytes()
+		}
+	}
+	return res
+}
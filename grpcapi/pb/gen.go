@@ -0,0 +1,5 @@
+// Package pb contains the protocol buffer messages and the gRPC service of the
+// node API, generated from node.proto.
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative node.proto
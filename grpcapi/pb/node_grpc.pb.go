@@ -0,0 +1,490 @@
+// Protocol buffer definitions of the gRPC node API, mirroring the most used
+// JSON-RPC endpoints for indexers.
+//
+// Hashes, addresses and raw data are encoded as bytes, integers that may not
+// fit 64 bits (values, fees, difficulties) as big-endian unsigned bytes.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: node.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Node_GetBlock_FullMethodName         = "/nero.node.v1.Node/GetBlock"
+	Node_GetTransaction_FullMethodName   = "/nero.node.v1.Node/GetTransaction"
+	Node_GetReceipt_FullMethodName       = "/nero.node.v1.Node/GetReceipt"
+	Node_GetBlockReceipts_FullMethodName = "/nero.node.v1.Node/GetBlockReceipts"
+	Node_GetTraces_FullMethodName        = "/nero.node.v1.Node/GetTraces"
+	Node_GetFinality_FullMethodName      = "/nero.node.v1.Node/GetFinality"
+	Node_GetValidators_FullMethodName    = "/nero.node.v1.Node/GetValidators"
+	Node_StreamBlocks_FullMethodName     = "/nero.node.v1.Node/StreamBlocks"
+	Node_StreamFinality_FullMethodName   = "/nero.node.v1.Node/StreamFinality"
+)
+
+// NodeClient is the client API for Node service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NodeClient interface {
+	// GetBlock retrieves a block by number, hash or tag.
+	GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// GetTransaction retrieves a transaction by hash, including pending ones.
+	GetTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	// GetReceipt retrieves the receipt of an included transaction.
+	GetReceipt(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Receipt, error)
+	// GetBlockReceipts retrieves the receipts of all the transactions of a block.
+	GetBlockReceipts(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Receipts, error)
+	// GetTraces retrieves the internal transactions recorded for a block.
+	GetTraces(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Traces, error)
+	// GetFinality retrieves the head, the last justified and the last finalized
+	// blocks.
+	GetFinality(ctx context.Context, in *FinalityRequest, opts ...grpc.CallOption) (*Finality, error)
+	// GetValidators retrieves the validators of a block.
+	GetValidators(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Validators, error)
+	// StreamBlocks streams the canonical blocks in ascending order, starting
+	// from a past block if requested and then following the chain head. The
+	// blocks replaced by a reorg are streamed again from the common ancestor.
+	StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (Node_StreamBlocksClient, error)
+	// StreamFinality streams the blocks as they get justified or finalized.
+	StreamFinality(ctx context.Context, in *StreamFinalityRequest, opts ...grpc.CallOption) (Node_StreamFinalityClient, error)
+}
+
+type nodeClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNodeClient(cc grpc.ClientConnInterface) NodeClient {
+	return &nodeClient{cc}
+}
+
+func (c *nodeClient) GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, Node_GetBlock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, Node_GetTransaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetReceipt(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Receipt, error) {
+	out := new(Receipt)
+	err := c.cc.Invoke(ctx, Node_GetReceipt_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetBlockReceipts(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Receipts, error) {
+	out := new(Receipts)
+	err := c.cc.Invoke(ctx, Node_GetBlockReceipts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetTraces(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Traces, error) {
+	out := new(Traces)
+	err := c.cc.Invoke(ctx, Node_GetTraces_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetFinality(ctx context.Context, in *FinalityRequest, opts ...grpc.CallOption) (*Finality, error) {
+	out := new(Finality)
+	err := c.cc.Invoke(ctx, Node_GetFinality_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) GetValidators(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Validators, error) {
+	out := new(Validators)
+	err := c.cc.Invoke(ctx, Node_GetValidators_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeClient) StreamBlocks(ctx context.Context, in *StreamBlocksRequest, opts ...grpc.CallOption) (Node_StreamBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Node_ServiceDesc.Streams[0], Node_StreamBlocks_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeStreamBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_StreamBlocksClient interface {
+	Recv() (*Block, error)
+	grpc.ClientStream
+}
+
+type nodeStreamBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeStreamBlocksClient) Recv() (*Block, error) {
+	m := new(Block)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeClient) StreamFinality(ctx context.Context, in *StreamFinalityRequest, opts ...grpc.CallOption) (Node_StreamFinalityClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Node_ServiceDesc.Streams[1], Node_StreamFinality_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeStreamFinalityClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Node_StreamFinalityClient interface {
+	Recv() (*FinalityUpdate, error)
+	grpc.ClientStream
+}
+
+type nodeStreamFinalityClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeStreamFinalityClient) Recv() (*FinalityUpdate, error) {
+	m := new(FinalityUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NodeServer is the server API for Node service.
+// All implementations must embed UnimplementedNodeServer
+// for forward compatibility
+type NodeServer interface {
+	// GetBlock retrieves a block by number, hash or tag.
+	GetBlock(context.Context, *BlockRequest) (*Block, error)
+	// GetTransaction retrieves a transaction by hash, including pending ones.
+	GetTransaction(context.Context, *TransactionRequest) (*Transaction, error)
+	// GetReceipt retrieves the receipt of an included transaction.
+	GetReceipt(context.Context, *TransactionRequest) (*Receipt, error)
+	// GetBlockReceipts retrieves the receipts of all the transactions of a block.
+	GetBlockReceipts(context.Context, *BlockRequest) (*Receipts, error)
+	// GetTraces retrieves the internal transactions recorded for a block.
+	GetTraces(context.Context, *BlockRequest) (*Traces, error)
+	// GetFinality retrieves the head, the last justified and the last finalized
+	// blocks.
+	GetFinality(context.Context, *FinalityRequest) (*Finality, error)
+	// GetValidators retrieves the validators of a block.
+	GetValidators(context.Context, *BlockRequest) (*Validators, error)
+	// StreamBlocks streams the canonical blocks in ascending order, starting
+	// from a past block if requested and then following the chain head. The
+	// blocks replaced by a reorg are streamed again from the common ancestor.
+	StreamBlocks(*StreamBlocksRequest, Node_StreamBlocksServer) error
+	// StreamFinality streams the blocks as they get justified or finalized.
+	StreamFinality(*StreamFinalityRequest, Node_StreamFinalityServer) error
+	mustEmbedUnimplementedNodeServer()
+}
+
+// UnimplementedNodeServer must be embedded to have forward compatible implementations.
+type UnimplementedNodeServer struct {
+}
+
+func (UnimplementedNodeServer) GetBlock(context.Context, *BlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedNodeServer) GetTransaction(context.Context, *TransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedNodeServer) GetReceipt(context.Context, *TransactionRequest) (*Receipt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceipt not implemented")
+}
+func (UnimplementedNodeServer) GetBlockReceipts(context.Context, *BlockRequest) (*Receipts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockReceipts not implemented")
+}
+func (UnimplementedNodeServer) GetTraces(context.Context, *BlockRequest) (*Traces, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTraces not implemented")
+}
+func (UnimplementedNodeServer) GetFinality(context.Context, *FinalityRequest) (*Finality, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFinality not implemented")
+}
+func (UnimplementedNodeServer) GetValidators(context.Context, *BlockRequest) (*Validators, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidators not implemented")
+}
+func (UnimplementedNodeServer) StreamBlocks(*StreamBlocksRequest, Node_StreamBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBlocks not implemented")
+}
+func (UnimplementedNodeServer) StreamFinality(*StreamFinalityRequest, Node_StreamFinalityServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFinality not implemented")
+}
+func (UnimplementedNodeServer) mustEmbedUnimplementedNodeServer() {}
+
+// UnsafeNodeServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NodeServer will
+// result in compilation errors.
+type UnsafeNodeServer interface {
+	mustEmbedUnimplementedNodeServer()
+}
+
+func RegisterNodeServer(s grpc.ServiceRegistrar, srv NodeServer) {
+	s.RegisterService(&Node_ServiceDesc, srv)
+}
+
+func _Node_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Node_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetBlock(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Node_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Node_GetReceipt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetReceipt(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetBlockReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetBlockReceipts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Node_GetBlockReceipts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetBlockReceipts(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetTraces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetTraces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Node_GetTraces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetTraces(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetFinality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetFinality(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Node_GetFinality_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetFinality(ctx, req.(*FinalityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_GetValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).GetValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Node_GetValidators_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetValidators(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Node_StreamBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBlocksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).StreamBlocks(m, &nodeStreamBlocksServer{stream})
+}
+
+type Node_StreamBlocksServer interface {
+	Send(*Block) error
+	grpc.ServerStream
+}
+
+type nodeStreamBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeStreamBlocksServer) Send(m *Block) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Node_StreamFinality_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFinalityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServer).StreamFinality(m, &nodeStreamFinalityServer{stream})
+}
+
+type Node_StreamFinalityServer interface {
+	Send(*FinalityUpdate) error
+	grpc.ServerStream
+}
+
+type nodeStreamFinalityServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeStreamFinalityServer) Send(m *FinalityUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Node_ServiceDesc is the grpc.ServiceDesc for Node service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Node_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "nero.node.v1.Node",
+	HandlerType: (*NodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlock",
+			Handler:    _Node_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _Node_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetReceipt",
+			Handler:    _Node_GetReceipt_Handler,
+		},
+		{
+			MethodName: "GetBlockReceipts",
+			Handler:    _Node_GetBlockReceipts_Handler,
+		},
+		{
+			MethodName: "GetTraces",
+			Handler:    _Node_GetTraces_Handler,
+		},
+		{
+			MethodName: "GetFinality",
+			Handler:    _Node_GetFinality_Handler,
+		},
+		{
+			MethodName: "GetValidators",
+			Handler:    _Node_GetValidators_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBlocks",
+			Handler:       _Node_StreamBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamFinality",
+			Handler:       _Node_StreamFinality_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "node.proto",
+}
@@ -0,0 +1,366 @@
+package grpcapi
+
+import (
+	"context"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/grpcapi/pb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamReorgDepth is the number of streamed blocks remembered to detect the
+// reorgs, deeper reorgs are not streamed again.
+const streamReorgDepth = 256
+
+var (
+	errBlockNotFound = status.Error(codes.NotFound, "block not found")
+	errTxNotFound    = status.Error(codes.NotFound, "transaction not found")
+	errInvalidHash   = status.Error(codes.InvalidArgument, "invalid hash")
+)
+
+// server implements the Node gRPC service.
+type server struct {
+	pb.UnimplementedNodeServer
+
+	b Backend
+}
+
+func newServer(b Backend) *server {
+	return &server{b: b}
+}
+
+// toHash converts a hash of the requests, rejecting ill-sized ones.
+func toHash(b []byte) (common.Hash, error) {
+	if len(b) != common.HashLength {
+		return common.Hash{}, errInvalidHash
+	}
+	return common.BytesToHash(b), nil
+}
+
+// blockNumberOrHash converts the block selector of a request.
+func blockNumberOrHash(req *pb.BlockRequest) (rpc.BlockNumberOrHash, error) {
+	switch block := req.Block.(type) {
+	case *pb.BlockRequest_Number:
+		if block.Number > math.MaxInt64 {
+			return rpc.BlockNumberOrHash{}, status.Error(codes.InvalidArgument, "block number too large")
+		}
+		return rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(block.Number)), nil
+
+	case *pb.BlockRequest_Hash:
+		hash, err := toHash(block.Hash)
+		if err != nil {
+			return rpc.BlockNumberOrHash{}, err
+		}
+		return rpc.BlockNumberOrHashWithHash(hash, false), nil
+
+	case *pb.BlockRequest_Tag:
+		switch block.Tag {
+		case pb.BlockTag_BLOCK_TAG_EARLIEST:
+			return rpc.BlockNumberOrHashWithNumber(rpc.EarliestBlockNumber), nil
+		case pb.BlockTag_BLOCK_TAG_SAFE:
+			return rpc.BlockNumberOrHashWithNumber(rpc.SafeBlockNumber), nil
+		case pb.BlockTag_BLOCK_TAG_FINALIZED:
+			return rpc.BlockNumberOrHashWithNumber(rpc.FinalizedBlockNumber), nil
+		}
+	}
+	return rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil
+}
+
+// block retrieves the block selected by the request.
+func (s *server) block(ctx context.Context, req *pb.BlockRequest) (*types.Block, error) {
+	blockNrOrHash, err := blockNumberOrHash(req)
+	if err != nil {
+		return nil, err
+	}
+	block, err := s.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, errBlockNotFound
+	}
+	return block, nil
+}
+
+// header retrieves the header of the block selected by the request.
+func (s *server) header(ctx context.Context, req *pb.BlockRequest) (*types.Header, error) {
+	blockNrOrHash, err := blockNumberOrHash(req)
+	if err != nil {
+		return nil, err
+	}
+	header, err := s.b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, errBlockNotFound
+	}
+	return header, nil
+}
+
+// GetBlock implements pb.NodeServer.
+func (s *server) GetBlock(ctx context.Context, req *pb.BlockRequest) (*pb.Block, error) {
+	block, err := s.block(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return newBlock(s.b.ChainConfig(), block, req.FullTransactions), nil
+}
+
+// GetTransaction implements pb.NodeServer.
+func (s *server) GetTransaction(ctx context.Context, req *pb.TransactionRequest) (*pb.Transaction, error) {
+	hash, err := toHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	found, tx, blockHash, _, index, err := s.b.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if !found {
+		if tx = s.b.GetPoolTransaction(hash); tx == nil {
+			return nil, errTxNotFound
+		}
+		return newTransaction(types.LatestSigner(s.b.ChainConfig()), tx, nil, 0), nil
+	}
+	header, err := s.b.HeaderByHash(ctx, blockHash)
+	if header == nil || err != nil {
+		return nil, errBlockNotFound
+	}
+	signer := types.MakeSigner(s.b.ChainConfig(), header.Number, header.Time)
+	return newTransaction(signer, tx, header, index), nil
+}
+
+// GetReceipt implements pb.NodeServer.
+func (s *server) GetReceipt(ctx context.Context, req *pb.TransactionRequest) (*pb.Receipt, error) {
+	hash, err := toHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	found, _, blockHash, _, index, err := s.b.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if !found {
+		return nil, errTxNotFound
+	}
+	block, err := s.b.BlockByHash(ctx, blockHash)
+	if block == nil || err != nil {
+		return nil, errBlockNotFound
+	}
+	receipts, err := s.b.GetReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if uint64(len(receipts)) <= index {
+		return nil, status.Error(codes.NotFound, "receipt not found")
+	}
+	signer := types.MakeSigner(s.b.ChainConfig(), block.Number(), block.Time())
+	return newReceipt(signer, block.Transactions()[index], receipts[index]), nil
+}
+
+// GetBlockReceipts implements pb.NodeServer.
+func (s *server) GetBlockReceipts(ctx context.Context, req *pb.BlockRequest) (*pb.Receipts, error) {
+	block, err := s.block(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := s.b.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return nil, status.Errorf(codes.Internal, "receipts length mismatch: %d vs %d", len(txs), len(receipts))
+	}
+	signer := types.MakeSigner(s.b.ChainConfig(), block.Number(), block.Time())
+	res := &pb.Receipts{Receipts: make([]*pb.Receipt, len(receipts))}
+	for i, receipt := range receipts {
+		res.Receipts[i] = newReceipt(signer, txs[i], receipt)
+	}
+	return res, nil
+}
+
+// GetTraces implements pb.NodeServer.
+func (s *server) GetTraces(ctx context.Context, req *pb.BlockRequest) (*pb.Traces, error) {
+	header, err := s.header(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.Traces{
+		BlockHash:   header.Hash().Bytes(),
+		BlockNumber: header.Number.Uint64(),
+	}
+	for _, itx := range rawdb.ReadInternalTxs(s.b.ChainDb(), header.Hash(), header.Number.Uint64()) {
+		res.Transactions = append(res.Transactions, newInternalTransaction(itx))
+	}
+	return res, nil
+}
+
+// GetFinality implements pb.NodeServer. The justified and finalized blocks are
+// left unset if there are none yet.
+func (s *server) GetFinality(ctx context.Context, req *pb.FinalityRequest) (*pb.Finality, error) {
+	res := &pb.Finality{Head: newCheckpoint(s.b.CurrentHeader())}
+	if header, err := s.b.HeaderByNumber(ctx, rpc.SafeBlockNumber); header != nil && err == nil {
+		res.Justified = newCheckpoint(header)
+	}
+	if header, err := s.b.HeaderByNumber(ctx, rpc.FinalizedBlockNumber); header != nil && err == nil {
+		res.Finalized = newCheckpoint(header)
+	}
+	return res, nil
+}
+
+// GetValidators implements pb.NodeServer.
+func (s *server) GetValidators(ctx context.Context, req *pb.BlockRequest) (*pb.Validators, error) {
+	engine, ok := s.b.Engine().(consensus.TurboEngine)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "validators are only available with the turbo engine")
+	}
+	header, err := s.header(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := engine.Validators(&headerReader{ctx: ctx, b: s.b}, header.Hash(), header.Number.Uint64())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &pb.Validators{Block: newCheckpoint(header)}
+	for _, validator := range validators {
+		res.Validators = append(res.Validators, validator.Bytes())
+	}
+	return res, nil
+}
+
+// StreamBlocks implements pb.NodeServer.
+func (s *server) StreamBlocks(req *pb.StreamBlocksRequest, stream pb.Node_StreamBlocksServer) error {
+	heads := make(chan core.ChainHeadEvent, 16)
+	sub := s.b.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	var (
+		ctx     = stream.Context()
+		config  = s.b.ChainConfig()
+		next    = s.b.CurrentHeader().Number.Uint64() + 1
+		emitted = make(map[uint64]common.Hash) // Hashes of the last streamed blocks
+	)
+	if req.FromNumber != nil {
+		next = *req.FromNumber
+	}
+	// sendUpTo streams the canonical blocks up to the given head, starting
+	// again from the common ancestor if the streamed blocks were reorged.
+	sendUpTo := func(head uint64) error {
+		for next > 0 {
+			hash, ok := emitted[next-1]
+			if !ok {
+				break
+			}
+			header, _ := s.b.HeaderByNumber(ctx, rpc.BlockNumber(next-1))
+			if header != nil && header.Hash() == hash {
+				break
+			}
+			delete(emitted, next-1)
+			next--
+		}
+		for ; next <= head; next++ {
+			block, err := s.b.BlockByNumber(ctx, rpc.BlockNumber(next))
+			if block == nil || err != nil {
+				return errBlockNotFound
+			}
+			if err := stream.Send(newBlock(config, block, req.FullTransactions)); err != nil {
+				return err
+			}
+			emitted[next] = block.Hash()
+			delete(emitted, next-streamReorgDepth)
+		}
+		return nil
+	}
+	if err := sendUpTo(s.b.CurrentHeader().Number.Uint64()); err != nil {
+		return err
+	}
+	for {
+		select {
+		case ev := <-heads:
+			if err := sendUpTo(ev.Block.NumberU64()); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			if err != nil {
+				return status.Error(codes.Unavailable, err.Error())
+			}
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// StreamFinality implements pb.NodeServer.
+func (s *server) StreamFinality(req *pb.StreamFinalityRequest, stream pb.Node_StreamFinalityServer) error {
+	updates := make(chan core.NewJustifiedOrFinalizedBlockEvent, 16)
+	sub := s.b.SubscribeBlockPredictStatusEvent(updates)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-updates:
+			update := &pb.FinalityUpdate{
+				Block: &pb.Checkpoint{Number: ev.JF.BlockNumber.Uint64(), Hash: ev.JF.Hash.Bytes()},
+			}
+			switch ev.JF.Status {
+			case types.BasJustified:
+				update.Status = pb.FinalityStatus_FINALITY_STATUS_JUSTIFIED
+			case types.BasFinalized:
+				update.Status = pb.FinalityStatus_FINALITY_STATUS_FINALIZED
+			}
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		case err := <-sub.Err():
+			if err != nil {
+				return status.Error(codes.Unavailable, err.Error())
+			}
+			return nil
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// headerReader implements consensus.ChainHeaderReader on top of the backend.
+type headerReader struct {
+	ctx context.Context
+	b   Backend
+}
+
+func (r *headerReader) Config() *params.ChainConfig {
+	return r.b.ChainConfig()
+}
+
+func (r *headerReader) CurrentHeader() *types.Header {
+	return r.b.CurrentHeader()
+}
+
+func (r *headerReader) GetHeader(hash common.Hash, number uint64) *types.Header {
+	header, _ := r.b.HeaderByHash(r.ctx, hash)
+	if header == nil || header.Number.Uint64() != number {
+		return nil
+	}
+	return header
+}
+
+func (r *headerReader) GetHeaderByNumber(number uint64) *types.Header {
+	header, _ := r.b.HeaderByNumber(r.ctx, rpc.BlockNumber(number))
+	return header
+}
+
+func (r *headerReader) GetHeaderByHash(hash common.Hash) *types.Header {
+	header, _ := r.b.HeaderByHash(r.ctx, hash)
+	return header
+}
+
+func (r *headerReader) GetTd(hash common.Hash, number uint64) *big.Int {
+	return r.b.GetTd(r.ctx, hash)
+}
@@ -0,0 +1,175 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/grpcapi/pb"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// newTestService starts a node with a chain of generated blocks, each holding
+// a transfer, and returns a client connected to its gRPC service.
+func newTestService(t *testing.T, blocks int) (pb.NodeClient, []*types.Block) {
+	var (
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		genesis = &core.Genesis{
+			Config:     params.AllEthashProtocolChanges,
+			GasLimit:   11500000,
+			Difficulty: common.Big1,
+			Alloc:      types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(genesis.Config)
+	)
+	stack, err := node.New(&node.Config{})
+	if err != nil {
+		t.Fatalf("could not create node: %v", err)
+	}
+	t.Cleanup(func() { stack.Close() })
+
+	backend, err := eth.New(stack, &ethconfig.Config{
+		Genesis:        genesis,
+		NetworkId:      1337,
+		TrieCleanCache: 5,
+		TrieDirtyCache: 5,
+		TrieTimeout:    60 * time.Minute,
+		SnapshotCache:  5,
+		StateScheme:    rawdb.HashScheme,
+	})
+	if err != nil {
+		t.Fatalf("could not create eth backend: %v", err)
+	}
+	chain, _ := core.GenerateChain(genesis.Config, backend.BlockChain().Genesis(), ethash.NewFaker(), backend.ChainDb(), blocks, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &common.Address{0x01},
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: gen.BaseFee(),
+		})
+		gen.AddTx(tx)
+	})
+	if _, err := backend.BlockChain().InsertChain(chain); err != nil {
+		t.Fatalf("could not import blocks: %v", err)
+	}
+	service, err := New(stack, backend.APIBackend, "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not create gRPC service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	conn, err := grpc.Dial(service.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("could not dial gRPC service: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewNodeClient(conn), chain
+}
+
+func TestGetBlock(t *testing.T) {
+	client, chain := newTestService(t, 3)
+	ctx := context.Background()
+
+	for _, req := range []*pb.BlockRequest{
+		{Block: &pb.BlockRequest_Number{Number: 2}, FullTransactions: true},
+		{Block: &pb.BlockRequest_Hash{Hash: chain[1].Hash().Bytes()}, FullTransactions: true},
+	} {
+		block, err := client.GetBlock(ctx, req)
+		if err != nil {
+			t.Fatalf("failed to get block %v: %v", req, err)
+		}
+		if !bytes.Equal(block.Header.Hash, chain[1].Hash().Bytes()) {
+			t.Errorf("block hash mismatch: have %x, want %x", block.Header.Hash, chain[1].Hash())
+		}
+		if len(block.Transactions) != 1 {
+			t.Fatalf("transaction count mismatch: have %d, want 1", len(block.Transactions))
+		}
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(block.Transactions[0].Raw); err != nil {
+			t.Fatalf("failed to decode transaction: %v", err)
+		}
+		if tx.Hash() != chain[1].Transactions()[0].Hash() {
+			t.Errorf("transaction mismatch: have %x, want %x", tx.Hash(), chain[1].Transactions()[0].Hash())
+		}
+	}
+	latest, err := client.GetBlock(ctx, &pb.BlockRequest{Block: &pb.BlockRequest_Tag{Tag: pb.BlockTag_BLOCK_TAG_LATEST}})
+	if err != nil {
+		t.Fatalf("failed to get latest block: %v", err)
+	}
+	if latest.Header.Number != 3 || len(latest.Transactions) != 0 || len(latest.TransactionHashes) != 1 {
+		t.Errorf("latest block mismatch: number %d, %d transactions, %d hashes", latest.Header.Number, len(latest.Transactions), len(latest.TransactionHashes))
+	}
+	_, err = client.GetBlock(ctx, &pb.BlockRequest{Block: &pb.BlockRequest_Number{Number: 100}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("missing block error mismatch: have %v, want NotFound", err)
+	}
+}
+
+func TestGetReceipt(t *testing.T) {
+	client, chain := newTestService(t, 2)
+	ctx := context.Background()
+
+	tx := chain[1].Transactions()[0]
+	receipt, err := client.GetReceipt(ctx, &pb.TransactionRequest{Hash: tx.Hash().Bytes()})
+	if err != nil {
+		t.Fatalf("failed to get receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful || receipt.GasUsed != params.TxGas || receipt.BlockNumber != 2 {
+		t.Errorf("receipt mismatch: status %d, gas used %d, block %d", receipt.Status, receipt.GasUsed, receipt.BlockNumber)
+	}
+	if !bytes.Equal(receipt.To, common.Address{0x01}.Bytes()) {
+		t.Errorf("receipt recipient mismatch: have %x", receipt.To)
+	}
+	receipts, err := client.GetBlockReceipts(ctx, &pb.BlockRequest{Block: &pb.BlockRequest_Number{Number: 1}})
+	if err != nil {
+		t.Fatalf("failed to get block receipts: %v", err)
+	}
+	if len(receipts.Receipts) != 1 || !bytes.Equal(receipts.Receipts[0].TransactionHash, chain[0].Transactions()[0].Hash().Bytes()) {
+		t.Errorf("block receipts mismatch: %v", receipts.Receipts)
+	}
+	_, err = client.GetReceipt(ctx, &pb.TransactionRequest{Hash: common.Hash{0x01}.Bytes()})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("missing receipt error mismatch: have %v, want NotFound", err)
+	}
+}
+
+func TestStreamBlocks(t *testing.T) {
+	client, chain := newTestService(t, 4)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	from := uint64(2)
+	stream, err := client.StreamBlocks(ctx, &pb.StreamBlocksRequest{FromNumber: &from})
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	for _, want := range chain[1:] {
+		block, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("failed to receive block %d: %v", want.NumberU64(), err)
+		}
+		if !bytes.Equal(block.Header.Hash, want.Hash().Bytes()) {
+			t.Errorf("block %d hash mismatch: have %x, want %x", want.NumberU64(), block.Header.Hash, want.Hash())
+		}
+	}
+}
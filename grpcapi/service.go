@@ -0,0 +1,96 @@
+// Package grpcapi implements a gRPC service mirroring the most used node APIs:
+// block, transaction and receipt queries, internal transaction traces, finality
+// status and validator sets, along with block and finality streams.
+//
+// It targets high-throughput indexers, for which the binary encoding and the
+// HTTP/2 streams of gRPC are cheaper than JSON-RPC. The protocol buffer
+// definitions are in the pb package.
+package grpcapi
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/grpcapi/pb"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"google.golang.org/grpc"
+)
+
+// Backend is the node backend serving the gRPC requests.
+type Backend interface {
+	ethapi.Backend
+
+	// SubscribeBlockPredictStatusEvent subscribes to the blocks getting
+	// justified or finalized.
+	SubscribeBlockPredictStatusEvent(ch chan<- core.NewJustifiedOrFinalizedBlockEvent) event.Subscription
+}
+
+// Service is the gRPC server, run as a node lifecycle.
+type Service struct {
+	endpoint string
+	server   *grpc.Server
+
+	lock     sync.Mutex
+	listener net.Listener
+}
+
+// New creates the gRPC service listening on the endpoint and registers it with
+// the node.
+func New(stack *node.Node, backend Backend, endpoint string) (*Service, error) {
+	if endpoint == "" {
+		return nil, errors.New("missing gRPC endpoint")
+	}
+	s := &Service{
+		endpoint: endpoint,
+		server:   grpc.NewServer(),
+	}
+	pb.RegisterNodeServer(s.server, newServer(backend))
+	stack.RegisterLifecycle(s)
+	return s, nil
+}
+
+// Start implements node.Lifecycle, listening on the endpoint and serving the
+// requests in the background.
+func (s *Service) Start() error {
+	listener, err := net.Listen("tcp", s.endpoint)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	s.listener = listener
+	s.lock.Unlock()
+
+	go s.server.Serve(listener)
+	log.Info("gRPC endpoint opened", "url", listener.Addr())
+	return nil
+}
+
+// Stop implements node.Lifecycle, closing the connections and cancelling the
+// pending requests and streams.
+func (s *Service) Stop() error {
+	s.server.Stop()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.listener != nil {
+		log.Info("gRPC endpoint closed", "url", s.listener.Addr())
+		s.listener = nil
+	}
+	return nil
+}
+
+// Addr returns the address the service listens on, nil if not started.
+func (s *Service) Addr() net.Addr {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
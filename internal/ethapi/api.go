@@ -38,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/iosched"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
@@ -1244,6 +1245,10 @@ func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.S
 	if err := overrides.Apply(state); err != nil {
 		return nil, err
 	}
+	guard := b.RPCCallGuard()
+	if err := guard.Admit(args.To); err != nil {
+		return nil, err
+	}
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
 	var cancel context.CancelFunc
@@ -1265,7 +1270,7 @@ func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.S
 		return nil, err
 	}
 	msg := args.ToMessage(blockCtx.BaseFee)
-	evm := b.GetEVM(ctx, msg, state, header, &vm.Config{NoBaseFee: true}, &blockCtx)
+	evm := b.GetEVM(ctx, msg, state, header, &vm.Config{NoBaseFee: true, MemoryLimit: guard.Limits().MemoryLimit}, &blockCtx)
 
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
@@ -1281,9 +1286,9 @@ func doCall(ctx context.Context, b Backend, args TransactionArgs, state *state.S
 		return nil, err
 	}
 
-	// If the timer caused an abort, return an appropriate error message
-	if evm.Cancelled() {
-		return nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+	// If a limit caused an abort, return an appropriate error message
+	if err := guard.Outcome(evm, args.To, timeout); err != nil {
+		return nil, err
 	}
 	if err != nil {
 		return result, fmt.Errorf("err: %w (supplied gas %d)", err, msg.GasLimit)
@@ -1337,13 +1342,19 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	if err = overrides.Apply(state); err != nil {
 		return 0, err
 	}
+	guard := b.RPCCallGuard()
+	if err := guard.Admit(args.To); err != nil {
+		return 0, err
+	}
 	// Construct the gas estimator option from the user input
 	opts := &gasestimator.Options{
-		Config:     b.ChainConfig(),
-		Chain:      NewChainContext(ctx, b),
-		Header:     header,
-		State:      state,
-		ErrorRatio: estimateGasErrorRatio,
+		Config:      b.ChainConfig(),
+		Chain:       NewChainContext(ctx, b),
+		Header:      header,
+		State:       state,
+		ErrorRatio:  estimateGasErrorRatio,
+		Timeout:     guard.Limits().Timeout,
+		MemoryLimit: guard.Limits().MemoryLimit,
 	}
 	// Set any required transaction default, but make sure the gas cap itself is not messed with
 	// if it was not specified in the original argument list.
@@ -1355,9 +1366,21 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 	}
 	call := args.ToMessage(header.BaseFee)
 
+	// Execute with the access filter of the calls, so that the transactions the
+	// filter fails aren't estimated
+	evm := b.GetEVM(ctx, call, state, header, &vm.Config{NoBaseFee: true}, nil)
+	if evm == nil {
+		return 0, errors.New("failed to create EVM")
+	}
+	opts.AccessFilter = evm.Context.AccessFilter
+
 	// Run the gas estimation and wrap any revertals into a custom return
 	estimate, revert, err := gasestimator.Estimate(ctx, call, opts, gasCap)
-	if err != nil {
+	if err = guard.ErrorOutcome(err, args.To, opts.Timeout); err != nil {
+		var denied *gasestimator.AccessDeniedError
+		if errors.As(err, &denied) {
+			return 0, &accessDeniedError{denied}
+		}
 		if len(revert) > 0 {
 			return 0, newRevertError(revert)
 		}
@@ -1891,6 +1914,10 @@ func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber u
 	if receipt.ContractAddress != (common.Address{}) {
 		fields["contractAddress"] = receipt.ContractAddress
 	}
+	// Tell the access filter denials apart from the contract failures
+	if receipt.AccessDenial != nil {
+		fields["accessDenied"] = receipt.AccessDenial
+	}
 	return fields
 }
 
@@ -2271,6 +2298,8 @@ func (api *DebugAPI) ChaindbCompact() error {
 		if b == 255 {
 			end = nil
 		}
+		// Pause between the ranges while the local validator is in turn
+		iosched.Wait("compaction")
 		log.Info("Compacting database", "range", fmt.Sprintf("%#X-%#X", start, end), "elapsed", common.PrettyDuration(time.Since(cstart)))
 		if err := api.b.ChainDb().Compact(start, end); err != nil {
 			log.Error("Database compaction failed", "err", err)
@@ -2280,9 +2309,10 @@ func (api *DebugAPI) ChaindbCompact() error {
 	return nil
 }
 
-// SetHead rewinds the head of the blockchain to a previous block.
-func (api *DebugAPI) SetHead(number hexutil.Uint64) {
-	api.b.SetHead(uint64(number))
+// SetHead rewinds the head of the blockchain to a previous block, refusing to
+// cross the last finalized block.
+func (api *DebugAPI) SetHead(number hexutil.Uint64) error {
+	return api.b.SetHead(uint64(number))
 }
 
 // NetAPI offers network related RPC methods
@@ -479,9 +479,10 @@ func (b testBackend) AccountManager() *accounts.Manager                   { retu
 func (b testBackend) ExtRPCEnabled() bool                                 { return false }
 func (b testBackend) RPCGasCap() uint64                                   { return 10000000 }
 func (b testBackend) RPCEVMTimeout() time.Duration                        { return time.Second }
+func (b testBackend) RPCCallGuard() *CallGuard                            { return nil }
 func (b testBackend) RPCTxFeeCap() float64                                { return 0 }
 func (b testBackend) UnprotectedAllowed() bool                            { return false }
-func (b testBackend) SetHead(number uint64)                               {}
+func (b testBackend) SetHead(number uint64) error                         { return nil }
 func (b testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
 	if number == rpc.LatestBlockNumber {
 		return b.chain.CurrentBlock(), nil
@@ -585,6 +586,9 @@ func (b testBackend) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) even
 func (b testBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) event.Subscription {
 	panic("implement me")
 }
+func (b testBackend) SubscribeChainReorgEvent(ch chan<- core.ChainReorgEvent) event.Subscription {
+	panic("implement me")
+}
 func (b testBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	panic("implement me")
 }
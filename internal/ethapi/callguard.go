@@ -0,0 +1,186 @@
+package ethapi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/gasestimator"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Execution limits reported by ExecutionLimitError.
+const (
+	LimitTimeout = "timeout" // The call exceeded its wall-clock execution time
+	LimitMemory  = "memory"  // The call exceeded the EVM memory cap
+	LimitBreaker = "breaker" // The calls to the contract are refused after hitting the limits repeatedly
+)
+
+// CallLimits are the execution limits of the calls simulated over RPC, i.e.
+// eth_call, eth_estimateGas and the traces, protecting the public nodes from
+// the pathological contracts.
+type CallLimits struct {
+	Timeout         time.Duration // Wall-clock time a simulated call may execute, 0 = unlimited
+	TraceTimeout    time.Duration // Cap of the timeout requested by the traces, 0 = uncapped
+	MemoryLimit     uint64        // EVM memory a call may allocate across its frames, 0 = unlimited
+	BreakerTrips    int           // Consecutive limit hits of a contract tripping its breaker, 0 = disabled
+	BreakerCooldown time.Duration // Time the calls to a tripped contract are refused
+}
+
+// ExecutionLimitError is an API error returned for a call aborted by one of the
+// execution limits, or refused by the circuit breaker of the called contract.
+type ExecutionLimitError struct {
+	Limit      string          `json:"limit"`
+	Value      string          `json:"value"`
+	Contract   *common.Address `json:"contract,omitempty"`
+	RetryAfter string          `json:"retryAfter,omitempty"` // Time until the breaker closes
+}
+
+func (e *ExecutionLimitError) Error() string {
+	switch e.Limit {
+	case LimitTimeout:
+		return fmt.Sprintf("execution aborted (timeout = %v)", e.Value)
+	case LimitMemory:
+		return fmt.Sprintf("execution aborted (memory limit = %v)", e.Value)
+	default:
+		return fmt.Sprintf("execution refused (contract %v exceeded the execution limits, retry after %v)", e.Contract.Hex(), e.RetryAfter)
+	}
+}
+
+// ErrorCode returns the JSON error code of the limit exceeded error.
+// See: https://eips.ethereum.org/EIPS/eip-1474
+func (e *ExecutionLimitError) ErrorCode() int {
+	return -32005
+}
+
+// ErrorData returns the limit hit by the call.
+func (e *ExecutionLimitError) ErrorData() interface{} {
+	return e
+}
+
+// breakerState is the circuit breaker of a contract.
+type breakerState struct {
+	trips int       // Consecutive calls hitting the limits
+	open  time.Time // Time until which the calls are refused
+}
+
+// CallGuard enforces the execution limits of the simulated calls. The calls to
+// a contract consecutively hitting them trip its circuit breaker, refusing the
+// next calls to it for a cooldown, so that a pathological contract doesn't keep
+// the node busy up to the limits.
+type CallGuard struct {
+	limits CallLimits
+
+	breakers map[common.Address]*breakerState
+	lock     sync.Mutex
+}
+
+// NewCallGuard creates a guard enforcing the given limits.
+func NewCallGuard(limits CallLimits) *CallGuard {
+	return &CallGuard{
+		limits:   limits,
+		breakers: make(map[common.Address]*breakerState),
+	}
+}
+
+// Limits returns the enforced limits, none if the guard is nil.
+func (g *CallGuard) Limits() CallLimits {
+	if g == nil {
+		return CallLimits{}
+	}
+	return g.limits
+}
+
+// TraceTimeout returns the timeout of a trace requesting the given one.
+func (g *CallGuard) TraceTimeout(requested time.Duration) time.Duration {
+	if limit := g.Limits().TraceTimeout; limit > 0 && requested > limit {
+		return limit
+	}
+	return requested
+}
+
+// Admit returns an error if the breaker of the called contract is open.
+func (g *CallGuard) Admit(to *common.Address) error {
+	if g == nil || to == nil || g.limits.BreakerTrips <= 0 {
+		return nil
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	breaker := g.breakers[*to]
+	if breaker == nil || breaker.trips < g.limits.BreakerTrips {
+		return nil
+	}
+	if wait := time.Until(breaker.open); wait > 0 {
+		return &ExecutionLimitError{
+			Limit:      LimitBreaker,
+			Value:      fmt.Sprint(g.limits.BreakerTrips),
+			Contract:   to,
+			RetryAfter: common.PrettyDuration(wait).String(),
+		}
+	}
+	// The cooldown elapsed, let the calls through again on probation: the next
+	// hit trips the breaker right away
+	breaker.trips = g.limits.BreakerTrips - 1
+	return nil
+}
+
+// Outcome records the outcome of a call to the given contract, executed with
+// the given timeout by the EVM, returning the structured error of the limit
+// hit if any.
+func (g *CallGuard) Outcome(evm *vm.EVM, to *common.Address, timeout time.Duration) error {
+	var err error
+	switch {
+	case evm.MemoryLimitExceeded():
+		err = &ExecutionLimitError{Limit: LimitMemory, Value: fmt.Sprint(g.Limits().MemoryLimit)}
+	case evm.Cancelled():
+		err = &ExecutionLimitError{Limit: LimitTimeout, Value: timeout.String()}
+	}
+	g.record(to, err != nil)
+	return err
+}
+
+// ErrorOutcome records the outcome of a call to the given contract, executed
+// with the given timeout, converting its error into the structured error of the
+// limit hit if any.
+func (g *CallGuard) ErrorOutcome(err error, to *common.Address, timeout time.Duration) error {
+	var limit error
+	switch {
+	case errors.Is(err, vm.ErrMemoryLimit):
+		limit = &ExecutionLimitError{Limit: LimitMemory, Value: fmt.Sprint(g.Limits().MemoryLimit)}
+	case errors.Is(err, gasestimator.ErrExecutionTimeout):
+		limit = &ExecutionLimitError{Limit: LimitTimeout, Value: timeout.String()}
+	}
+	g.record(to, limit != nil)
+	if limit != nil {
+		return limit
+	}
+	return err
+}
+
+// record counts a call to the contract hitting the limits, tripping its breaker
+// after enough consecutive ones, or resets the count.
+func (g *CallGuard) record(to *common.Address, hit bool) {
+	if g == nil || to == nil || g.limits.BreakerTrips <= 0 {
+		return
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if !hit {
+		delete(g.breakers, *to)
+		return
+	}
+	breaker := g.breakers[*to]
+	if breaker == nil {
+		breaker = new(breakerState)
+		g.breakers[*to] = breaker
+	}
+	if breaker.trips++; breaker.trips >= g.limits.BreakerTrips {
+		breaker.open = time.Now().Add(g.limits.BreakerCooldown)
+		log.Warn("Tripped call breaker of contract", "contract", *to, "trips", breaker.trips, "cooldown", g.limits.BreakerCooldown)
+	}
+}
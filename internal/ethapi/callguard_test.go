@@ -0,0 +1,80 @@
+package ethapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/gasestimator"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestCallGuardBreaker(t *testing.T) {
+	var (
+		guard   = NewCallGuard(CallLimits{Timeout: time.Second, BreakerTrips: 2, BreakerCooldown: time.Hour})
+		to      = common.HexToAddress("0x1234")
+		timeout = gasestimator.ErrExecutionTimeout
+	)
+	// A successful call resets the consecutive hits
+	guard.ErrorOutcome(timeout, &to, time.Second)
+	guard.ErrorOutcome(nil, &to, time.Second)
+	guard.ErrorOutcome(timeout, &to, time.Second)
+	if err := guard.Admit(&to); err != nil {
+		t.Fatalf("breaker tripped by non consecutive hits: %v", err)
+	}
+	err := guard.ErrorOutcome(timeout, &to, time.Second)
+	if limit, ok := err.(*ExecutionLimitError); !ok || limit.Limit != LimitTimeout {
+		t.Fatalf("unexpected timeout error: %v", err)
+	}
+	err = guard.Admit(&to)
+	if limit, ok := err.(*ExecutionLimitError); !ok || limit.Limit != LimitBreaker || *limit.Contract != to {
+		t.Fatalf("breaker not tripped: %v", err)
+	}
+	if other := common.HexToAddress("0x5678"); guard.Admit(&other) != nil {
+		t.Fatal("breaker tripped for another contract")
+	}
+	// Once the cooldown elapsed, a single hit trips the breaker again
+	guard.breakers[to].open = time.Now()
+	if err := guard.Admit(&to); err != nil {
+		t.Fatalf("breaker still open after cooldown: %v", err)
+	}
+	guard.ErrorOutcome(vm.ErrMemoryLimit, &to, time.Second)
+	if err := guard.Admit(&to); err == nil {
+		t.Fatal("breaker not tripped on probation")
+	}
+}
+
+func TestCallGuardDisabled(t *testing.T) {
+	var (
+		guard *CallGuard
+		to    = common.HexToAddress("0x1234")
+	)
+	for i := 0; i < 10; i++ {
+		guard.ErrorOutcome(gasestimator.ErrExecutionTimeout, &to, time.Second)
+	}
+	if err := guard.Admit(&to); err != nil {
+		t.Fatalf("nil guard refused call: %v", err)
+	}
+	if have := guard.TraceTimeout(time.Minute); have != time.Minute {
+		t.Fatalf("nil guard capped trace timeout: %v", have)
+	}
+	if have := NewCallGuard(CallLimits{TraceTimeout: time.Second}).TraceTimeout(time.Minute); have != time.Second {
+		t.Fatalf("trace timeout not capped: %v", have)
+	}
+}
+
+func TestExecutionLimitError(t *testing.T) {
+	var err error = &ExecutionLimitError{Limit: LimitTimeout, Value: "5s"}
+	if have, want := err.Error(), "execution aborted (timeout = 5s)"; have != want {
+		t.Fatalf("message mismatch: have %q, want %q", have, want)
+	}
+	if code := err.(rpc.Error).ErrorCode(); code != -32005 {
+		t.Fatalf("code mismatch: have %d, want %d", code, -32005)
+	}
+	data, _ := json.Marshal(err.(rpc.DataError).ErrorData())
+	if have, want := string(data), `{"limit":"timeout","value":"5s"}`; have != want {
+		t.Fatalf("data mismatch: have %s, want %s", have, want)
+	}
+}
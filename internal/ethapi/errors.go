@@ -22,6 +22,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/gasestimator"
 )
 
 // revertError is an API error that encompasses an EVM revert with JSON error
@@ -56,6 +57,24 @@ func newRevertError(revert []byte) *revertError {
 	}
 }
 
+// accessDeniedError is an API error returned if the execution fails on a denial
+// of the access filter, with the denial as data.
+type accessDeniedError struct {
+	*gasestimator.AccessDeniedError
+}
+
+// ErrorCode returns the JSON error code of a transaction rejected by the access
+// filter.
+// See: https://eips.ethereum.org/EIPS/eip-1474
+func (e *accessDeniedError) ErrorCode() int {
+	return -32003
+}
+
+// ErrorData returns the denial met by the execution.
+func (e *accessDeniedError) ErrorData() interface{} {
+	return e.Denial
+}
+
 // TxIndexingError is an API error that indicates the transaction indexing is not
 // fully finished yet with JSON error code and a binary data blob.
 type TxIndexingError struct{}
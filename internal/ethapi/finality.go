@@ -0,0 +1,59 @@
+package ethapi
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Finality statuses of a transaction, from the weakest to the strongest.
+const (
+	FinalityPending   = "pending"   // In the transaction pool
+	FinalityIncluded  = "included"  // In a canonical block, not yet justified
+	FinalityJustified = "justified" // In a block at or below the safe one
+	FinalityFinalized = "finalized" // In a block at or below the finalized one
+)
+
+// GetTransactionReceipt returns the receipt of the transaction like its eth
+// namespace counterpart, with its finality status and the number of blocks
+// confirming it, the head included. Transactions still in the pool are returned
+// as pending without receipt fields.
+func (api *NeroTransactionAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	fields, err := NewTransactionAPI(api.b, nil).GetTransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		if api.b.GetPoolTransaction(hash) == nil {
+			return nil, nil
+		}
+		return map[string]interface{}{
+			"transactionHash": hash,
+			"finalityStatus":  FinalityPending,
+			"confirmations":   hexutil.Uint64(0),
+		}, nil
+	}
+	number := uint64(fields["blockNumber"].(hexutil.Uint64))
+
+	var confirmations uint64
+	if head := api.b.CurrentHeader().Number.Uint64(); head >= number {
+		confirmations = head - number + 1
+	}
+	fields["finalityStatus"] = api.finalityStatus(ctx, number)
+	fields["confirmations"] = hexutil.Uint64(confirmations)
+	return fields, nil
+}
+
+// finalityStatus returns the finality status of the canonical block. Chains
+// without finalized or safe blocks yet leave the block included.
+func (api *NeroTransactionAPI) finalityStatus(ctx context.Context, number uint64) string {
+	if header, err := api.b.HeaderByNumber(ctx, rpc.FinalizedBlockNumber); err == nil && header != nil && header.Number.Uint64() >= number {
+		return FinalityFinalized
+	}
+	if header, err := api.b.HeaderByNumber(ctx, rpc.SafeBlockNumber); err == nil && header != nil && header.Number.Uint64() >= number {
+		return FinalityJustified
+	}
+	return FinalityIncluded
+}
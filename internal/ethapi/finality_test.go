@@ -0,0 +1,107 @@
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// finalityBackend serves preset finalized and safe blocks and the pending
+// transactions of a mock pool.
+type finalityBackend struct {
+	*testBackend
+	finalized, safe uint64
+	pending         map[common.Hash]*types.Transaction
+}
+
+func (b finalityBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	switch number {
+	case rpc.FinalizedBlockNumber:
+		return b.chain.GetHeaderByNumber(b.finalized), nil
+	case rpc.SafeBlockNumber:
+		return b.chain.GetHeaderByNumber(b.safe), nil
+	}
+	return b.testBackend.HeaderByNumber(ctx, number)
+}
+
+func (b finalityBackend) GetTransaction(ctx context.Context, txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64, error) {
+	found, tx, blockHash, blockNumber, index, err := b.testBackend.GetTransaction(ctx, txHash)
+	return found && tx != nil, tx, blockHash, blockNumber, index, err
+}
+
+func (b finalityBackend) GetPoolTransaction(hash common.Hash) *types.Transaction {
+	return b.pending[hash]
+}
+
+func TestGetTransactionReceiptFinality(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(genesis.Config)
+		to     = common.HexToAddress("0x1000")
+		hashes []common.Hash
+	)
+	backend := newTestBackend(t, 5, genesis, ethash.NewFaker(), func(i int, b *core.BlockGen) {
+		tx := types.MustSignNewTx(accounts[0].key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			GasPrice: b.BaseFee(),
+			Gas:      params.TxGas,
+			To:       &to,
+			Value:    big.NewInt(1),
+		})
+		b.AddTx(tx)
+		hashes = append(hashes, tx.Hash())
+	})
+	pending := types.MustSignNewTx(accounts[0].key, signer, &types.LegacyTx{
+		Nonce:    5,
+		GasPrice: big.NewInt(params.GWei),
+		Gas:      params.TxGas,
+		To:       &to,
+	})
+	api := NewNeroTransactionAPI(finalityBackend{
+		testBackend: backend,
+		finalized:   2,
+		safe:        3,
+		pending:     map[common.Hash]*types.Transaction{pending.Hash(): pending},
+	})
+	ctx := context.Background()
+
+	for i, want := range []string{FinalityFinalized, FinalityFinalized, FinalityJustified, FinalityIncluded, FinalityIncluded} {
+		receipt, err := api.GetTransactionReceipt(ctx, hashes[i])
+		if err != nil {
+			t.Fatalf("tx %d: failed to get receipt: %v", i, err)
+		}
+		if receipt["finalityStatus"] != want {
+			t.Errorf("tx %d: finality status mismatch: have %v, want %v", i, receipt["finalityStatus"], want)
+		}
+		if have, want := receipt["confirmations"], hexutil.Uint64(5-i); have != want {
+			t.Errorf("tx %d: confirmations mismatch: have %v, want %v", i, have, want)
+		}
+		if receipt["status"] != hexutil.Uint(types.ReceiptStatusSuccessful) {
+			t.Errorf("tx %d: receipt fields missing: %v", i, receipt)
+		}
+	}
+	receipt, err := api.GetTransactionReceipt(ctx, pending.Hash())
+	if err != nil {
+		t.Fatalf("failed to get pending receipt: %v", err)
+	}
+	if receipt["finalityStatus"] != FinalityPending || receipt["confirmations"] != hexutil.Uint64(0) {
+		t.Errorf("pending receipt mismatch: %v", receipt)
+	}
+	if receipt, err := api.GetTransactionReceipt(ctx, common.Hash{0x1}); receipt != nil || err != nil {
+		t.Errorf("unknown transaction receipt: have %v, %v", receipt, err)
+	}
+}
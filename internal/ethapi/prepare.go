@@ -0,0 +1,218 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Preflight failure reasons reported by PrepareTransaction.
+const (
+	PreflightDeniedAddress = "denied_address" // An address involved in the execution is denied
+	PreflightBlockedEvent  = "blocked_event"  // An event emitted by the execution is blocked
+	PreflightFiltered      = "filtered"       // The transaction is rejected by the consensus filter
+	PreflightCreateDenied  = "create_denied"  // The sender isn't allowed to deploy contracts
+	PreflightRevert        = "revert"         // The execution reverted
+	PreflightError         = "error"          // The execution failed otherwise
+)
+
+// NeroTransactionAPI provides the Nero specific transaction helpers.
+type NeroTransactionAPI struct {
+	b Backend
+}
+
+// NewNeroTransactionAPI creates a new Nero transaction API.
+func NewNeroTransactionAPI(b Backend) *NeroTransactionAPI {
+	return &NeroTransactionAPI{b: b}
+}
+
+// PreflightFailure describes why a prepared transaction would fail.
+type PreflightFailure struct {
+	Reason  string          `json:"reason"`
+	Message string          `json:"message"`
+	Address *common.Address `json:"address,omitempty"` // Denied address or contract emitting the blocked event
+	Topic   *common.Hash    `json:"topic,omitempty"`   // Signature of the blocked event
+	Data    hexutil.Bytes   `json:"data,omitempty"`    // Revert data
+}
+
+// PreparedTransaction is an unsigned transaction with all the fields filled,
+// along with the result of its preflight on the latest state.
+type PreparedTransaction struct {
+	Raw     hexutil.Bytes      `json:"raw"`
+	Tx      *types.Transaction `json:"tx"`
+	GasUsed hexutil.Uint64     `json:"gasUsed"`
+	Failure *PreflightFailure  `json:"failure,omitempty"` // Nil if the preflight succeeded
+}
+
+// PrepareTransaction fills the nonce, fee and gas fields of the transaction and
+// runs it on the latest state, checking the consensus access filter and the
+// execution. A failing preflight isn't an error, the reason is returned within
+// the prepared transaction so it can be reported before broadcasting.
+func (api *NeroTransactionAPI) PrepareTransaction(ctx context.Context, args TransactionArgs) (*PreparedTransaction, error) {
+	if args.From == nil {
+		return nil, errors.New("missing from address")
+	}
+	gasSet := args.Gas != nil
+	if err := args.setDefaults(ctx, api.b, true); err != nil {
+		return nil, err
+	}
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if state == nil || err != nil {
+		return nil, err
+	}
+	failure, gasUsed, err := preflight(ctx, api.b, args, gasSet, state, header)
+	if err != nil {
+		return nil, err
+	}
+	// Only estimate the gas of transactions expected to succeed, the estimation
+	// would fail anyway otherwise
+	if failure == nil && !gasSet {
+		estimateArgs := args
+		estimateArgs.Gas = nil
+		estimated, err := DoEstimateGas(ctx, api.b, estimateArgs, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil, api.b.RPCGasCap())
+		if err != nil {
+			failure = &PreflightFailure{Reason: PreflightError, Message: err.Error()}
+		} else {
+			args.Gas = &estimated
+		}
+	}
+	tx := args.ToTransaction()
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &PreparedTransaction{Raw: raw, Tx: tx, GasUsed: hexutil.Uint64(gasUsed), Failure: failure}, nil
+}
+
+// preflight checks the transaction against the consensus filters and executes
+// it on the given state, returning the reason of the failure if any.
+func preflight(ctx context.Context, b Backend, args TransactionArgs, gasSet bool, state *state.StateDB, header *types.Header) (*PreflightFailure, uint64, error) {
+	// Check the transaction filters the pool would apply, against a mock of the
+	// next block as the pool does
+	if engine, ok := b.Engine().(consensus.TurboEngine); ok {
+		next := &types.Header{
+			ParentHash: header.Hash(),
+			Difficulty: new(big.Int).Set(header.Difficulty),
+			Number:     new(big.Int).Add(header.Number, common.Big1),
+			GasLimit:   header.GasLimit,
+			Time:       header.Time + 1,
+		}
+		from := args.from()
+		if err := engine.FilterTx(from, args.ToTransaction(), next, state.Copy()); err != nil {
+			failure := &PreflightFailure{Reason: PreflightFiltered, Message: err.Error()}
+			if errors.Is(err, types.ErrAddressDenied) {
+				failure.Reason, failure.Address = PreflightDeniedAddress, &from
+			}
+			return failure, 0, nil
+		}
+		if args.To == nil && !engine.CanCreate(state, from, false, next.Number) {
+			return &PreflightFailure{Reason: PreflightCreateDenied, Message: core.ErrUnauthorizedDeveloper.Error(), Address: &from}, 0, nil
+		}
+	}
+	// Execute the transaction as eth_call does, without fees so that an unset gas
+	// limit defaults to the cap, recording what the access filter denies
+	ctx, cancel := context.WithTimeout(ctx, b.RPCEVMTimeout())
+	defer cancel()
+
+	call := TransactionArgs{
+		From:       args.From,
+		To:         args.To,
+		Value:      args.Value,
+		Input:      args.Input,
+		Data:       args.Data,
+		AccessList: args.AccessList,
+	}
+	if gasSet {
+		call.Gas = args.Gas
+	}
+	blockCtx := core.NewEVMBlockContext(header, NewChainContext(ctx, b), nil)
+	if err := call.CallDefaults(b.RPCGasCap(), blockCtx.BaseFee, b.ChainConfig().ChainID); err != nil {
+		return nil, 0, err
+	}
+	msg := call.ToMessage(blockCtx.BaseFee)
+	evm := b.GetEVM(ctx, msg, state, header, &vm.Config{NoBaseFee: true}, &blockCtx)
+	if evm == nil {
+		return nil, 0, errors.New("failed to create EVM")
+	}
+	var filter *recordingAccessFilter
+	if evm.Context.AccessFilter != nil {
+		filter = &recordingAccessFilter{EvmAccessFilter: evm.Context.AccessFilter}
+		evm.Context.AccessFilter = filter
+	}
+	go func() {
+		<-ctx.Done()
+		evm.Cancel()
+	}()
+	result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64))
+	if err := state.Error(); err != nil {
+		return nil, 0, err
+	}
+	if evm.Cancelled() {
+		return nil, 0, fmt.Errorf("execution aborted (timeout = %v)", b.RPCEVMTimeout())
+	}
+	if err != nil {
+		// The message can't be applied at all, e.g. insufficient funds
+		return &PreflightFailure{Reason: PreflightError, Message: err.Error()}, 0, nil
+	}
+	// A denied access only fails the frame it occurs in, which the callers may
+	// ignore, so report it even if the execution succeeded
+	if filter != nil && filter.log != nil {
+		failure := &PreflightFailure{Reason: PreflightBlockedEvent, Message: "event blocked", Address: &filter.log.Address}
+		if len(filter.log.Topics) > 0 {
+			failure.Topic = &filter.log.Topics[0]
+		}
+		return failure, result.UsedGas, nil
+	}
+	if filter != nil && filter.address != nil {
+		return &PreflightFailure{Reason: PreflightDeniedAddress, Message: types.ErrAddressDenied.Error(), Address: filter.address}, result.UsedGas, nil
+	}
+	if !result.Failed() {
+		return nil, result.UsedGas, nil
+	}
+	failure := &PreflightFailure{Reason: PreflightError, Message: result.Err.Error()}
+	if errors.Is(result.Err, vm.ErrExecutionReverted) {
+		failure.Reason, failure.Data = PreflightRevert, result.Revert()
+		if reason, err := abi.UnpackRevert(result.Revert()); err == nil {
+			failure.Message = fmt.Sprintf("%v: %v", vm.ErrExecutionReverted, reason)
+		}
+	}
+	return failure, result.UsedGas, nil
+}
+
+// recordingAccessFilter wraps an access filter, recording the first address or
+// log it denies.
+type recordingAccessFilter struct {
+	vm.EvmAccessFilter
+	address *common.Address
+	log     *types.Log
+}
+
+// IsAddressDenied implements vm.EvmAccessFilter.
+func (f *recordingAccessFilter) IsAddressDenied(address common.Address, cType common.AddressCheckType) bool {
+	denied := f.EvmAccessFilter.IsAddressDenied(address, cType)
+	if denied && f.address == nil && f.log == nil {
+		f.address = &address
+	}
+	return denied
+}
+
+// IsLogDenied implements vm.EvmAccessFilter.
+func (f *recordingAccessFilter) IsLogDenied(log *types.Log) bool {
+	denied := f.EvmAccessFilter.IsLogDenied(log)
+	if denied && f.address == nil && f.log == nil {
+		f.log = log
+	}
+	return denied
+}
@@ -0,0 +1,172 @@
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testAccessFilter denies an address and the events with a topic.
+type testAccessFilter struct {
+	address common.Address
+	topic   common.Hash
+}
+
+func (f *testAccessFilter) IsAddressDenied(address common.Address, cType common.AddressCheckType) bool {
+	return address == f.address
+}
+
+func (f *testAccessFilter) IsLogDenied(log *types.Log) bool {
+	return len(log.Topics) > 0 && log.Topics[0] == f.topic
+}
+
+// filterBackend is a test backend whose EVMs run with an access filter.
+type filterBackend struct {
+	*testBackend
+	filter vm.EvmAccessFilter
+}
+
+func (b filterBackend) GetEVM(ctx context.Context, msg *core.Message, state *state.StateDB, header *types.Header, vmConfig *vm.Config, blockContext *vm.BlockContext) *vm.EVM {
+	evm := b.testBackend.GetEVM(ctx, msg, state, header, vmConfig, blockContext)
+	evm.Context.AccessFilter = b.filter
+	return evm
+}
+
+func TestPrepareTransaction(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		denied   = common.HexToAddress("0xdead")
+		topic    = common.HexToHash("0xbad")
+		reverter = common.HexToAddress("0x1000")
+		caller   = common.HexToAddress("0x2000")
+		emitter  = common.HexToAddress("0x3000")
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+				// REVERT(0, 1)
+				reverter: {Code: hexutil.MustDecode("0x60016000fd")},
+				// CALL(gas, 0xdead, 0, 0, 0, 0, 0)
+				caller: {Code: hexutil.MustDecode("0x6000600060006000600073000000000000000000000000000000000000dead5af100")},
+				// LOG1(0, 0, 0xbad)
+				emitter: {Code: hexutil.MustDecode("0x7f0000000000000000000000000000000000000000000000000000000000000bad60006000a100")},
+			},
+		}
+		signer = types.HomesteadSigner{}
+	)
+	backend := newTestBackend(t, 2, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &accounts[1].addr, Value: big.NewInt(1000), Gas: params.TxGas, GasPrice: b.BaseFee()}), signer, accounts[0].key)
+		b.AddTx(tx)
+		b.SetPoS()
+	})
+	api := NewNeroTransactionAPI(filterBackend{testBackend: backend, filter: &testAccessFilter{address: denied, topic: topic}})
+
+	var tests = []struct {
+		to      common.Address
+		reason  string
+		address *common.Address
+		topic   *common.Hash
+		data    hexutil.Bytes
+	}{
+		{to: accounts[0].addr},
+		{to: reverter, reason: PreflightRevert, data: hexutil.Bytes{0}},
+		{to: caller, reason: PreflightDeniedAddress, address: &denied},
+		{to: emitter, reason: PreflightBlockedEvent, address: &emitter, topic: &topic},
+	}
+	for i, tt := range tests {
+		to := tt.to
+		prepared, err := api.PrepareTransaction(context.Background(), TransactionArgs{From: &accounts[1].addr, To: &to})
+		if err != nil {
+			t.Fatalf("test %d: failed to prepare transaction: %v", i, err)
+		}
+		if nonce := prepared.Tx.Nonce(); nonce != 0 {
+			t.Errorf("test %d: nonce mismatch: have %d, want 0", i, nonce)
+		}
+		if prepared.Tx.GasFeeCap().Sign() == 0 {
+			t.Errorf("test %d: fee cap not filled", i)
+		}
+		if tt.reason == "" {
+			if prepared.Failure != nil {
+				t.Fatalf("test %d: unexpected failure: %+v", i, prepared.Failure)
+			}
+			if gas := prepared.Tx.Gas(); gas != params.TxGas {
+				t.Errorf("test %d: gas mismatch: have %d, want %d", i, gas, params.TxGas)
+			}
+			continue
+		}
+		failure := prepared.Failure
+		if failure == nil {
+			t.Fatalf("test %d: missing failure", i)
+		}
+		if failure.Reason != tt.reason {
+			t.Errorf("test %d: reason mismatch: have %s, want %s", i, failure.Reason, tt.reason)
+		}
+		if (failure.Address == nil) != (tt.address == nil) || (tt.address != nil && *failure.Address != *tt.address) {
+			t.Errorf("test %d: address mismatch: have %v, want %v", i, failure.Address, tt.address)
+		}
+		if (failure.Topic == nil) != (tt.topic == nil) || (tt.topic != nil && *failure.Topic != *tt.topic) {
+			t.Errorf("test %d: topic mismatch: have %v, want %v", i, failure.Topic, tt.topic)
+		}
+		if string(failure.Data) != string(tt.data) {
+			t.Errorf("test %d: data mismatch: have %x, want %x", i, failure.Data, tt.data)
+		}
+	}
+}
+
+// Tests that the gas estimation runs with the access filter of the calls,
+// failing on the denials which fail the transaction.
+func TestEstimateGasAccessFilter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		denied   = common.HexToAddress("0xdead")
+		caller   = common.HexToAddress("0x2000")
+		checker  = common.HexToAddress("0x3000")
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				// CALL(gas, 0xdead, 0, 0, 0, 0, 0)
+				caller: {Code: hexutil.MustDecode("0x6000600060006000600073000000000000000000000000000000000000dead5af100")},
+				// CALL(gas, 0xdead, 0, 0, 0, 0, 0), REVERT(0, 0) on failure
+				checker: {Code: hexutil.MustDecode("0x6000600060006000600073000000000000000000000000000000000000dead5af160295760006000fd5b00")},
+			},
+		}
+	)
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	})
+	api := NewBlockChainAPI(filterBackend{testBackend: backend, filter: &testAccessFilter{address: denied}})
+
+	// A denied call whose failure is ignored succeeds on chain too
+	if _, err := api.EstimateGas(context.Background(), TransactionArgs{From: &accounts[0].addr, To: &caller}, nil, nil); err != nil {
+		t.Fatalf("failed to estimate ignored denial: %v", err)
+	}
+	// A denied call failing the transaction is reported
+	_, err := api.EstimateGas(context.Background(), TransactionArgs{From: &accounts[0].addr, To: &checker}, nil, nil)
+	deniedErr, ok := err.(*accessDeniedError)
+	if !ok {
+		t.Fatalf("denial not reported: %v", err)
+	}
+	if deniedErr.ErrorCode() != -32003 {
+		t.Errorf("error code mismatch: have %d, want -32003", deniedErr.ErrorCode())
+	}
+	denial := deniedErr.Denial
+	if denial.Kind != types.AccessDeniedTo || denial.Address != denied {
+		t.Errorf("denial mismatch: have %s %v, want %s %v", denial.Kind, denial.Address, types.AccessDeniedTo, denied)
+	}
+}
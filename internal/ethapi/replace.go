@@ -0,0 +1,153 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// minFeeBump is the minimum fee increase, in percent, of a transaction replacing
+// a pending one, as required by the transaction pool by default.
+const minFeeBump = 10
+
+// ReplacementResult is a transaction replacing a pending one. The transaction
+// is signed and submitted if the node holds the key of the sender, otherwise
+// it's returned unsigned for the caller to sign and broadcast.
+type ReplacementResult struct {
+	Hash   *common.Hash       `json:"hash,omitempty"` // Hash of the submitted transaction, nil if unsigned
+	Raw    hexutil.Bytes      `json:"raw"`
+	Tx     *types.Transaction `json:"tx"`
+	Signed bool               `json:"signed"`
+}
+
+// CancelTransaction replaces the pending transaction with a transfer of nothing
+// from the sender to itself, paying fees raised by the given percentage, by
+// default the minimum accepted by the transaction pool.
+func (api *NeroTransactionAPI) CancelTransaction(ctx context.Context, hash common.Hash, feeBump *hexutil.Uint64) (*ReplacementResult, error) {
+	return api.replaceTransaction(ctx, hash, feeBump, true)
+}
+
+// SpeedUpTransaction replaces the pending transaction with the same transaction
+// paying fees raised by the given percentage, by default the minimum accepted
+// by the transaction pool.
+func (api *NeroTransactionAPI) SpeedUpTransaction(ctx context.Context, hash common.Hash, feeBump *hexutil.Uint64) (*ReplacementResult, error) {
+	return api.replaceTransaction(ctx, hash, feeBump, false)
+}
+
+// replaceTransaction creates the replacement of the pending transaction, signing
+// and submitting it if the node holds the key of the sender.
+func (api *NeroTransactionAPI) replaceTransaction(ctx context.Context, hash common.Hash, feeBump *hexutil.Uint64, cancel bool) (*ReplacementResult, error) {
+	bump := uint64(minFeeBump)
+	if feeBump != nil {
+		bump = uint64(*feeBump)
+	}
+	if bump < minFeeBump {
+		return nil, fmt.Errorf("fee bump %d%% below the minimum of %d%%", bump, minFeeBump)
+	}
+	tx := api.b.GetPoolTransaction(hash)
+	if tx == nil {
+		return nil, fmt.Errorf("transaction %#x not pending", hash)
+	}
+	if tx.Type() == types.BlobTxType {
+		return nil, errBlobTxNotSupported
+	}
+	head := api.b.CurrentHeader()
+	from, err := types.Sender(types.LatestSigner(api.b.ChainConfig()), tx)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := api.b.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Pay at least the bumped fees for the pool to accept the replacement, and
+	// the current ones for it to be included
+	var (
+		price  = new(big.Int).Set(tip) // Current legacy gas price
+		tipCap = maxBig(bumpFee(tx.GasTipCap(), bump), tip)
+		feeCap = bumpFee(tx.GasFeeCap(), bump)
+	)
+	if head.BaseFee != nil {
+		price.Add(price, head.BaseFee)
+		feeCap = maxBig(feeCap, new(big.Int).Add(tipCap, new(big.Int).Mul(head.BaseFee, common.Big2)))
+	}
+	feeCap = maxBig(feeCap, tipCap)
+
+	var (
+		to    = tx.To()
+		value = tx.Value()
+		gas   = tx.Gas()
+		data  = tx.Data()
+		al    = tx.AccessList()
+	)
+	if cancel {
+		to, value, gas, data, al = &from, new(big.Int), params.TxGas, nil, nil
+	}
+	var inner types.TxData
+	switch tx.Type() {
+	case types.LegacyTxType:
+		inner = &types.LegacyTx{Nonce: tx.Nonce(), GasPrice: maxBig(feeCap, price), Gas: gas, To: to, Value: value, Data: data}
+	case types.AccessListTxType:
+		inner = &types.AccessListTx{ChainID: tx.ChainId(), Nonce: tx.Nonce(), GasPrice: maxBig(feeCap, price), Gas: gas, To: to, Value: value, Data: data, AccessList: al}
+	case types.DynamicFeeTxType:
+		inner = &types.DynamicFeeTx{ChainID: tx.ChainId(), Nonce: tx.Nonce(), GasTipCap: tipCap, GasFeeCap: feeCap, Gas: gas, To: to, Value: value, Data: data, AccessList: al}
+	default:
+		return nil, fmt.Errorf("transaction type %d not supported", tx.Type())
+	}
+	replacement := types.NewTx(inner)
+	if err := checkTxFee(replacement.GasPrice(), replacement.Gas(), api.b.RPCTxFeeCap()); err != nil {
+		return nil, err
+	}
+	// Return the unsigned replacement if the node doesn't hold the key
+	account := accounts.Account{Address: from}
+	wallet, err := api.b.AccountManager().Find(account)
+	if errors.Is(err, accounts.ErrUnknownAccount) {
+		raw, err := replacement.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		return &ReplacementResult{Raw: raw, Tx: replacement}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	signed, err := wallet.SignTx(account, replacement, api.b.ChainConfig().ChainID)
+	if err != nil {
+		return nil, err
+	}
+	submitted, err := SubmitTransaction(ctx, api.b, signed)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &ReplacementResult{Hash: &submitted, Raw: raw, Tx: signed, Signed: true}, nil
+}
+
+// bumpFee returns the fee raised by the given percentage, and at least by one
+// wei as the pool requires a strictly higher fee.
+func bumpFee(fee *big.Int, bump uint64) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+bump))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, common.Big1)
+	}
+	return bumped
+}
+
+// maxBig returns the larger of the two values.
+func maxBig(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
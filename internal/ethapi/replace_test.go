@@ -0,0 +1,143 @@
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// poolBackend serves the pending transactions of a mock pool, recording the
+// submitted ones.
+type poolBackend struct {
+	*testBackend
+	pending map[common.Hash]*types.Transaction
+	sent    *[]*types.Transaction
+}
+
+func (b poolBackend) GetPoolTransaction(hash common.Hash) *types.Transaction {
+	return b.pending[hash]
+}
+
+func (b poolBackend) SendTx(ctx context.Context, tx *types.Transaction) error {
+	*b.sent = append(*b.sent, tx)
+	return nil
+}
+
+func TestReplaceTransaction(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc:  types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		to = common.HexToAddress("0x1000")
+	)
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	})
+	var (
+		signer  = types.LatestSigner(genesis.Config)
+		baseFee = backend.CurrentHeader().BaseFee
+		dynamic = types.MustSignNewTx(accounts[0].key, signer, &types.DynamicFeeTx{
+			ChainID:   genesis.Config.ChainID,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: new(big.Int).Mul(baseFee, big.NewInt(10)),
+			Gas:       50000,
+			To:        &to,
+			Value:     big.NewInt(1),
+			Data:      []byte{0x1},
+		})
+		sent []*types.Transaction
+	)
+	wallet, err := backend.accman.Find(backend.acc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local, err := wallet.SignTx(backend.acc, types.NewTx(&types.LegacyTx{
+		GasPrice: new(big.Int).Mul(baseFee, big.NewInt(10)),
+		Gas:      50000,
+		To:       &to,
+		Value:    big.NewInt(1),
+	}), genesis.Config.ChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := NewNeroTransactionAPI(poolBackend{
+		testBackend: backend,
+		pending:     map[common.Hash]*types.Transaction{dynamic.Hash(): dynamic, local.Hash(): local},
+		sent:        &sent,
+	})
+	ctx := context.Background()
+
+	// The replacement of a foreign transaction is returned unsigned
+	res, err := api.SpeedUpTransaction(ctx, dynamic.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to speed up transaction: %v", err)
+	}
+	if res.Signed || res.Hash != nil {
+		t.Errorf("foreign replacement signed")
+	}
+	checkReplacement(t, dynamic, res.Tx, minFeeBump)
+	if *res.Tx.To() != to || res.Tx.Value().Cmp(dynamic.Value()) != 0 || res.Tx.Gas() != dynamic.Gas() || len(res.Tx.Data()) != 1 {
+		t.Errorf("sped up transaction changed")
+	}
+	bump := hexutil.Uint64(50)
+	res, err = api.CancelTransaction(ctx, dynamic.Hash(), &bump)
+	if err != nil {
+		t.Fatalf("failed to cancel transaction: %v", err)
+	}
+	checkReplacement(t, dynamic, res.Tx, 50)
+	if *res.Tx.To() != accounts[0].addr || res.Tx.Value().Sign() != 0 || res.Tx.Gas() != params.TxGas || len(res.Tx.Data()) != 0 {
+		t.Errorf("cancellation is not an empty transfer to the sender")
+	}
+	// The replacement of a transaction of a local account is signed and submitted
+	res, err = api.CancelTransaction(ctx, local.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to cancel local transaction: %v", err)
+	}
+	if !res.Signed || res.Hash == nil || len(sent) != 1 || sent[0].Hash() != *res.Hash {
+		t.Fatalf("local replacement not submitted")
+	}
+	if from, _ := types.Sender(signer, sent[0]); from != backend.acc.Address {
+		t.Errorf("replacement sender mismatch: have %x, want %x", from, backend.acc.Address)
+	}
+	checkReplacement(t, local, sent[0], minFeeBump)
+
+	// Replacements the pool would reject aren't created
+	low := hexutil.Uint64(minFeeBump - 1)
+	if _, err := api.SpeedUpTransaction(ctx, dynamic.Hash(), &low); err == nil {
+		t.Errorf("fee bump below the minimum accepted")
+	}
+	if _, err := api.SpeedUpTransaction(ctx, common.Hash{0x1}, nil); err == nil {
+		t.Errorf("unknown transaction replaced")
+	}
+}
+
+// checkReplacement checks that the replacement has the nonce of the original
+// transaction and fees raised by at least the given percentage.
+func checkReplacement(t *testing.T, original, replacement *types.Transaction, bump int64) {
+	t.Helper()
+
+	if replacement.Nonce() != original.Nonce() || replacement.Type() != original.Type() {
+		t.Errorf("replacement nonce or type mismatch")
+	}
+	threshold := func(fee *big.Int) *big.Int {
+		return new(big.Int).Div(new(big.Int).Mul(fee, big.NewInt(100+bump)), big.NewInt(100))
+	}
+	if replacement.GasFeeCapIntCmp(threshold(original.GasFeeCap())) < 0 {
+		t.Errorf("fee cap not bumped: have %v, original %v", replacement.GasFeeCap(), original.GasFeeCap())
+	}
+	if replacement.GasTipCapIntCmp(threshold(original.GasTipCap())) < 0 {
+		t.Errorf("tip cap not bumped: have %v, original %v", replacement.GasTipCap(), original.GasTipCap())
+	}
+}
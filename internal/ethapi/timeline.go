@@ -0,0 +1,253 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxTimelineBlocks is the maximum number of blocks scanned by a single
+	// GetAddressTimeline call.
+	maxTimelineBlocks = 10000
+
+	// defaultTimelineLimit and maxTimelineLimit are the default and maximum
+	// numbers of entries of a timeline page.
+	defaultTimelineLimit = 100
+	maxTimelineLimit     = 1000
+)
+
+// Kinds of the timeline entries.
+const (
+	TimelineTransaction = "transaction" // Transaction sent by or to the address, or creating it
+	TimelineInternal    = "internal"    // Internal call, creation or self-destruct from or to the address
+	TimelineLog         = "log"         // Log emitted by the address or with it as a topic
+	TimelineStaking     = "staking"     // Log of the staking contract with the address as a topic
+)
+
+var timelineKinds = []string{TimelineTransaction, TimelineInternal, TimelineLog, TimelineStaking}
+
+// TimelineEntry is a single activity of an address.
+type TimelineEntry struct {
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	BlockHash    common.Hash     `json:"blockHash"`
+	Timestamp    hexutil.Uint64  `json:"timestamp"`
+	TxHash       common.Hash     `json:"transactionHash"`
+	TxIndex      hexutil.Uint    `json:"transactionIndex"`
+	Kind         string          `json:"kind"`
+	From         *common.Address `json:"from,omitempty"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Success      *bool           `json:"success,omitempty"`
+	TraceAddress []uint64        `json:"traceAddress,omitempty"` // Set for the internal actions
+	OpCode       string          `json:"opcode,omitempty"`       // Set for the internal actions
+	Log          *types.Log      `json:"log,omitempty"`          // Set for the logs and staking events
+}
+
+// TimelineCursor is the position of an entry in a timeline: the index of the
+// entry among the ones of its block.
+type TimelineCursor struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Index       hexutil.Uint   `json:"index"`
+}
+
+// TimelineOptions are the pagination options of a timeline.
+type TimelineOptions struct {
+	Limit  *hexutil.Uint64 `json:"limit"`  // Maximum number of entries, defaults to 100
+	Cursor *TimelineCursor `json:"cursor"` // Position to resume from, the next cursor of the previous page
+}
+
+// Timeline is a page of the activity of an address over a block range.
+type Timeline struct {
+	Address   common.Address   `json:"address"`
+	FromBlock hexutil.Uint64   `json:"fromBlock"`
+	ToBlock   hexutil.Uint64   `json:"toBlock"`
+	Entries   []*TimelineEntry `json:"entries"`
+	Next      *TimelineCursor  `json:"next"` // Nil once the range is exhausted
+}
+
+// GetAddressTimeline returns the activity of the address within the given block
+// range (both included) in chronological order, optionally restricted to the
+// given kinds: the transactions, the internal actions, the logs and the staking
+// events. Within a transaction, the transaction itself comes first, followed by
+// its internal actions and its logs.
+//
+// The timeline is paginated, each page returning the cursor to pass to get the
+// next one. The internal actions are read from the internal transactions
+// recorded by the node, so it must run with --traceaction=2 for them to be
+// included.
+func (api *NeroLedgerAPI) GetAddressTimeline(ctx context.Context, address common.Address, fromBlock, toBlock rpc.BlockNumber, kinds []string, opts *TimelineOptions) (*Timeline, error) {
+	include := make(map[string]bool)
+	for _, kind := range kinds {
+		if !slices.Contains(timelineKinds, kind) {
+			return nil, fmt.Errorf("unknown timeline kind %q", kind)
+		}
+		include[kind] = true
+	}
+	if len(include) == 0 {
+		for _, kind := range timelineKinds {
+			include[kind] = true
+		}
+	}
+	from, err := api.resolveBlockNumber(ctx, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.resolveBlockNumber(ctx, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, errors.New("fromBlock is after toBlock")
+	}
+	var (
+		limit = uint64(defaultTimelineLimit)
+		start = from
+		skip  uint
+	)
+	if opts != nil {
+		if opts.Limit != nil {
+			limit = uint64(*opts.Limit)
+		}
+		if opts.Cursor != nil {
+			start, skip = uint64(opts.Cursor.BlockNumber), uint(opts.Cursor.Index)
+			if start < from || start > to {
+				return nil, fmt.Errorf("cursor block #%d outside of the range", start)
+			}
+		}
+	}
+	if limit == 0 || limit > maxTimelineLimit {
+		return nil, fmt.Errorf("invalid limit %d, must be between 1 and %d", limit, maxTimelineLimit)
+	}
+	// Bound the scan of a single page, the cursor resuming it
+	end := to
+	if end-start >= maxTimelineBlocks {
+		end = start + maxTimelineBlocks - 1
+	}
+	res := &Timeline{
+		Address:   address,
+		FromBlock: hexutil.Uint64(from),
+		ToBlock:   hexutil.Uint64(to),
+		Entries:   []*TimelineEntry{},
+	}
+	for number := start; number <= end; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := api.b.BlockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		entries, err := api.blockTimeline(ctx, address, block, include)
+		if err != nil {
+			return nil, err
+		}
+		if skip > 0 {
+			if skip >= uint(len(entries)) {
+				entries = nil
+			} else {
+				entries = entries[skip:]
+			}
+		}
+		if room := limit - uint64(len(res.Entries)); uint64(len(entries)) > room {
+			res.Entries = append(res.Entries, entries[:room]...)
+			res.Next = &TimelineCursor{BlockNumber: hexutil.Uint64(number), Index: hexutil.Uint(skip + uint(room))}
+			return res, nil
+		}
+		res.Entries = append(res.Entries, entries...)
+		skip = 0
+	}
+	if end < to {
+		res.Next = &TimelineCursor{BlockNumber: hexutil.Uint64(end + 1)}
+	}
+	return res, nil
+}
+
+// blockTimeline returns the timeline entries of the address within the block,
+// restricted to the included kinds.
+func (api *NeroLedgerAPI) blockTimeline(ctx context.Context, address common.Address, block *types.Block, include map[string]bool) ([]*TimelineEntry, error) {
+	txs := block.Transactions()
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	receipts, err := api.b.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) != len(txs) {
+		return nil, fmt.Errorf("receipts of block #%d not found", block.NumberU64())
+	}
+	var (
+		entries  []*TimelineEntry
+		signer   = types.MakeSigner(api.b.ChainConfig(), block.Number(), block.Time())
+		internal = make(map[common.Hash][]*types.Action)
+		topic    = common.BytesToHash(address.Bytes())
+	)
+	if include[TimelineInternal] {
+		for _, itx := range rawdb.ReadInternalTxs(api.b.ChainDb(), block.Hash(), block.NumberU64()) {
+			internal[itx.TxHash] = itx.Actions
+		}
+	}
+	for i, tx := range txs {
+		receipt := receipts[i]
+		entry := func(kind string) *TimelineEntry {
+			e := &TimelineEntry{
+				BlockNumber: hexutil.Uint64(block.NumberU64()),
+				BlockHash:   block.Hash(),
+				Timestamp:   hexutil.Uint64(block.Time()),
+				TxHash:      tx.Hash(),
+				TxIndex:     hexutil.Uint(i),
+				Kind:        kind,
+			}
+			entries = append(entries, e)
+			return e
+		}
+		if include[TimelineTransaction] {
+			sender, err := types.Sender(signer, tx)
+			if err != nil {
+				return nil, err
+			}
+			recipient := tx.To()
+			if recipient == nil {
+				recipient = &receipt.ContractAddress
+			}
+			if sender == address || *recipient == address {
+				e := entry(TimelineTransaction)
+				success := receipt.Status == types.ReceiptStatusSuccessful
+				e.From, e.To, e.Value, e.Success = &sender, recipient, (*hexutil.Big)(tx.Value()), &success
+			}
+		}
+		// The internal actions, skipping the top level call of the transaction.
+		for _, action := range internal[tx.Hash()] {
+			if len(action.TraceAddress) == 0 || (action.From != address && action.To != address) {
+				continue
+			}
+			e := entry(TimelineInternal)
+			from, to, success := action.From, action.To, action.Success
+			e.From, e.To, e.Value, e.Success = &from, &to, (*hexutil.Big)(action.Value), &success
+			e.TraceAddress, e.OpCode = action.TraceAddress, action.OpCode
+		}
+		for _, log := range receipt.Logs {
+			kind := TimelineLog
+			if log.Address == system.StakingContract {
+				kind = TimelineStaking
+			}
+			if !include[kind] || (log.Address != address && !slices.Contains(log.Topics, topic)) {
+				continue
+			}
+			entry(kind).Log = log
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,146 @@
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestGetAddressTimeline(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts  = newAccounts(2)
+		forwarder = common.HexToAddress("0x1000")
+		logger    = common.HexToAddress("0x2000")
+		// LOG1(0, 0, accounts[1])
+		logCode = append(append([]byte{0x73}, accounts[1].addr.Bytes()...), hexutil.MustDecode("0x60006000a100")...)
+		genesis = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				// CALL(gas, accounts[1], callvalue, 0, 0, 0, 0)
+				forwarder:              {Code: append(append(hexutil.MustDecode("0x60006000600060003473"), accounts[1].addr.Bytes()...), 0x5a, 0xf1, 0x00)},
+				logger:                 {Code: logCode},
+				system.StakingContract: {Code: logCode},
+			},
+		}
+		signer = types.HomesteadSigner{}
+		to     = [][]common.Address{{accounts[1].addr}, {forwarder}, {logger, system.StakingContract}}
+		nonce  uint64
+	)
+	backend := newTestBackend(t, 3, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		for _, recipient := range to[i] {
+			tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: nonce, To: &recipient, Value: big.NewInt(100), Gas: 100000, GasPrice: b.BaseFee()}), signer, accounts[0].key)
+			b.AddTx(tx)
+			nonce++
+		}
+		b.SetPoS()
+	})
+	block := backend.chain.GetBlockByNumber(2)
+	rawdb.WriteInternalTxs(backend.db, block.Hash(), 2, types.InternalTxs{{
+		TxHash: block.Transactions()[0].Hash(),
+		Actions: []*types.Action{
+			{From: accounts[0].addr, To: forwarder, Value: big.NewInt(100), OpCode: "CALL", Depth: ^uint64(0), Success: true},
+			{From: forwarder, To: accounts[1].addr, Value: big.NewInt(100), OpCode: "CALL", TraceAddress: []uint64{0}, Success: true},
+		},
+	}})
+	api := NewNeroLedgerAPI(backend)
+	ctx := context.Background()
+
+	// The whole activity of the recipient, in chronological order.
+	res, err := api.GetAddressTimeline(ctx, accounts[1].addr, 1, rpc.LatestBlockNumber, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to get timeline: %v", err)
+	}
+	want := []struct {
+		number uint64
+		kind   string
+	}{
+		{1, TimelineTransaction},
+		{2, TimelineInternal},
+		{3, TimelineLog},
+		{3, TimelineStaking},
+	}
+	if len(res.Entries) != len(want) || res.Next != nil {
+		t.Fatalf("entry count mismatch: have %d (next %v), want %d", len(res.Entries), res.Next, len(want))
+	}
+	for i, entry := range res.Entries {
+		if uint64(entry.BlockNumber) != want[i].number || entry.Kind != want[i].kind {
+			t.Errorf("entry %d mismatch: have #%d %s, want #%d %s", i, entry.BlockNumber, entry.Kind, want[i].number, want[i].kind)
+		}
+	}
+	if trace := res.Entries[1].TraceAddress; len(trace) != 1 || trace[0] != 0 {
+		t.Errorf("internal trace address mismatch: %v", trace)
+	}
+	if log := res.Entries[3].Log; log == nil || log.Address != system.StakingContract {
+		t.Errorf("staking log mismatch: %v", log)
+	}
+	// Restricted to some kinds.
+	res, err = api.GetAddressTimeline(ctx, accounts[1].addr, 1, 3, []string{TimelineInternal, TimelineStaking}, nil)
+	if err != nil {
+		t.Fatalf("failed to get filtered timeline: %v", err)
+	}
+	if len(res.Entries) != 2 || res.Entries[0].Kind != TimelineInternal || res.Entries[1].Kind != TimelineStaking {
+		t.Errorf("filtered entries mismatch: %+v", res.Entries)
+	}
+	// Paginated one entry at a time, resuming within and across blocks.
+	var (
+		limit  = hexutil.Uint64(1)
+		opts   = &TimelineOptions{Limit: &limit}
+		paged  []*TimelineEntry
+		cursor *TimelineCursor
+	)
+	for i := 0; i <= len(want); i++ {
+		opts.Cursor = cursor
+		res, err := api.GetAddressTimeline(ctx, accounts[1].addr, 1, 3, nil, opts)
+		if err != nil {
+			t.Fatalf("page %d: failed to get timeline: %v", i, err)
+		}
+		paged = append(paged, res.Entries...)
+		if cursor = res.Next; cursor == nil {
+			break
+		}
+	}
+	if cursor != nil || len(paged) != len(want) {
+		t.Fatalf("paged entries mismatch: have %d, want %d", len(paged), len(want))
+	}
+	for i, entry := range paged {
+		if entry.Kind != want[i].kind {
+			t.Errorf("paged entry %d mismatch: have %s, want %s", i, entry.Kind, want[i].kind)
+		}
+	}
+	// The sender sends all the transactions.
+	res, err = api.GetAddressTimeline(ctx, accounts[0].addr, 1, 3, []string{TimelineTransaction}, nil)
+	if err != nil {
+		t.Fatalf("failed to get sender timeline: %v", err)
+	}
+	if len(res.Entries) != 4 {
+		t.Errorf("sender entries mismatch: have %d, want 4", len(res.Entries))
+	}
+	// Invalid requests are rejected.
+	if _, err := api.GetAddressTimeline(ctx, accounts[1].addr, 1, 3, []string{"unknown"}, nil); err == nil {
+		t.Error("no error for unknown kind")
+	}
+	if _, err := api.GetAddressTimeline(ctx, accounts[1].addr, 2, 1, nil, nil); err == nil {
+		t.Error("no error for inverted range")
+	}
+	limit = 0
+	if _, err := api.GetAddressTimeline(ctx, accounts[1].addr, 1, 3, nil, &TimelineOptions{Limit: &limit}); err == nil {
+		t.Error("no error for zero limit")
+	}
+	if _, err := api.GetAddressTimeline(ctx, accounts[1].addr, 2, 3, nil, &TimelineOptions{Cursor: &TimelineCursor{BlockNumber: 1}}); err == nil {
+		t.Error("no error for cursor outside of the range")
+	}
+}
@@ -0,0 +1,146 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxTokenBalanceTokens is the maximum number of tokens queried by a single
+	// GetTokenBalances call.
+	maxTokenBalanceTokens = 1000
+
+	// tokenBalanceCallGas is the execution gas limit of a single balanceOf call,
+	// on top of the intrinsic gas.
+	tokenBalanceCallGas = 100_000
+
+	// tokenBalanceGasBudget is the execution gas budget of a GetTokenBalances
+	// call shared by its balanceOf calls, further capped by the RPC gas cap.
+	tokenBalanceGasBudget = 5_000_000
+
+	// tokenBalanceCacheSize is the number of token balances cached.
+	tokenBalanceCacheSize = 4096
+)
+
+// balanceOfSelector is the selector of the ERC-20 balanceOf(address) method.
+var balanceOfSelector = hexutil.MustDecode("0x70a08231")
+
+// errTokenGasBudget is returned for the balances not queried once the gas budget
+// of the request is exhausted.
+var errTokenGasBudget = errors.New("gas budget exhausted")
+
+// tokenBalanceKey identifies a cached token balance, by block hash as the
+// balances at a block never change.
+type tokenBalanceKey struct {
+	block  common.Hash
+	holder common.Address
+	token  common.Address
+}
+
+// TokenBalance is the balance of a token, or the error retrieving it.
+type TokenBalance struct {
+	Token   common.Address `json:"token"`
+	Balance *hexutil.Big   `json:"balance"` // Nil if the balance couldn't be retrieved
+	Error   string         `json:"error,omitempty"`
+}
+
+// TokenBalances are the token balances of an address at a block.
+type TokenBalances struct {
+	Address     common.Address  `json:"address"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	BlockHash   common.Hash     `json:"blockHash"`
+	Balances    []*TokenBalance `json:"balances"`
+}
+
+// GetTokenBalances returns the ERC-20 balances of the address for the given
+// tokens at the given block, in the order of the tokens. The balanceOf calls are
+// executed in a batch on the same state, sharing a gas budget: the balances not
+// queried once it's exhausted, as well as the ones of the tokens reverting or
+// not returning a balance, are reported with an error.
+func (api *NeroLedgerAPI) GetTokenBalances(ctx context.Context, address common.Address, tokens []common.Address, blockNrOrHash *rpc.BlockNumberOrHash) (*TokenBalances, error) {
+	if len(tokens) > maxTokenBalanceTokens {
+		return nil, fmt.Errorf("too many tokens, maximum is %d", maxTokenBalanceTokens)
+	}
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	state, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	// Bound the whole batch by the call timeout, and its gas by the budget
+	if timeout := api.b.RPCEVMTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	budget := uint64(tokenBalanceGasBudget)
+	if gasCap := api.b.RPCGasCap(); gasCap != 0 && gasCap < budget {
+		budget = gasCap
+	}
+	var (
+		hash         = header.Hash()
+		data         = append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(address.Bytes(), 32)...)
+		intrinsic, _ = core.IntrinsicGas(data, nil, false, true, true, true)
+		res          = &TokenBalances{
+			Address:     address,
+			BlockNumber: hexutil.Uint64(header.Number.Uint64()),
+			BlockHash:   hash,
+			Balances:    make([]*TokenBalance, len(tokens)),
+		}
+	)
+	for i, token := range tokens {
+		balance := &TokenBalance{Token: token}
+		res.Balances[i] = balance
+
+		key := tokenBalanceKey{block: hash, holder: address, token: token}
+		if cached, ok := api.balances.Get(key); ok {
+			balance.Balance = (*hexutil.Big)(cached)
+			continue
+		}
+		if budget == 0 {
+			balance.Error = errTokenGasBudget.Error()
+			continue
+		}
+		var (
+			gas  = hexutil.Uint64(intrinsic + min(budget, tokenBalanceCallGas))
+			to   = token
+			args = TransactionArgs{To: &to, Gas: &gas, Input: (*hexutil.Bytes)(&data)}
+			snap = state.Snapshot()
+		)
+		result, err := doCall(ctx, api.b, args, state, header, nil, nil, 0, uint64(gas))
+		state.RevertToSnapshot(snap)
+		if err != nil {
+			return nil, err
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", api.b.RPCEVMTimeout())
+		}
+		budget -= min(budget, result.UsedGas-intrinsic)
+
+		switch {
+		case result.Err != nil:
+			if errors.Is(result.Err, vm.ErrExecutionReverted) && len(result.Revert()) > 0 {
+				balance.Error = newRevertError(result.Revert()).Error()
+			} else {
+				balance.Error = result.Err.Error()
+			}
+		case len(result.Return()) < 32:
+			balance.Error = "invalid balanceOf result"
+		default:
+			value := new(big.Int).SetBytes(result.Return()[:32])
+			api.balances.Add(key, value)
+			balance.Balance = (*hexutil.Big)(value)
+		}
+	}
+	return res, nil
+}
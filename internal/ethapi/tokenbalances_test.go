@@ -0,0 +1,92 @@
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestGetTokenBalances(t *testing.T) {
+	t.Parallel()
+
+	var (
+		holder = common.HexToAddress("0xaaaa")
+		token  = common.HexToAddress("0x1000")
+		revert = common.HexToAddress("0x2000")
+		hog    = common.HexToAddress("0x3000")
+		eoa    = common.HexToAddress("0x4000")
+		// Returns the storage slot keyed by the queried address
+		tokenCode = hexutil.MustDecode("0x6004355460005260206000f3")
+		genesis   = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				token:  {Code: tokenCode, Storage: map[common.Hash]common.Hash{common.BytesToHash(holder.Bytes()): common.BigToHash(big.NewInt(1234))}},
+				revert: {Code: hexutil.MustDecode("0x60006000fd")},
+				hog:    {Code: hexutil.MustDecode("0x5b600056")},
+			},
+		}
+	)
+	backend := newTestBackend(t, 1, genesis, ethash.NewFaker(), func(i int, b *core.BlockGen) {})
+	api := NewNeroLedgerAPI(backend)
+	ctx := context.Background()
+
+	res, err := api.GetTokenBalances(ctx, holder, []common.Address{token, revert, eoa}, nil)
+	if err != nil {
+		t.Fatalf("failed to get token balances: %v", err)
+	}
+	if res.BlockNumber != 1 || res.BlockHash != backend.CurrentHeader().Hash() {
+		t.Errorf("block mismatch: have #%d %x", res.BlockNumber, res.BlockHash)
+	}
+	if b := res.Balances[0]; b.Balance == nil || b.Balance.ToInt().Int64() != 1234 || b.Error != "" {
+		t.Errorf("token balance mismatch: %+v", b)
+	}
+	for i, b := range res.Balances[1:] {
+		if b.Balance != nil || b.Error == "" {
+			t.Errorf("balance %d: error missing: %+v", i+1, b)
+		}
+	}
+	// Other holders and blocks have their own balances
+	res, err = api.GetTokenBalances(ctx, eoa, []common.Address{token}, nil)
+	if err != nil {
+		t.Fatalf("failed to get token balances: %v", err)
+	}
+	if b := res.Balances[0]; b.Balance == nil || b.Balance.ToInt().Sign() != 0 {
+		t.Errorf("other holder balance mismatch: %+v", b)
+	}
+	genesisBlock := rpc.BlockNumberOrHashWithNumber(0)
+	res, err = api.GetTokenBalances(ctx, holder, []common.Address{token}, &genesisBlock)
+	if err != nil {
+		t.Fatalf("failed to get genesis token balances: %v", err)
+	}
+	if b := res.Balances[0]; res.BlockNumber != 0 || b.Balance == nil || b.Balance.ToInt().Int64() != 1234 {
+		t.Errorf("genesis balance mismatch: %+v", b)
+	}
+	// The calls exhausting the gas budget leave the next tokens unqueried,
+	// except the cached ones
+	tokens := make([]common.Address, tokenBalanceGasBudget/tokenBalanceCallGas)
+	for i := range tokens {
+		tokens[i] = hog
+	}
+	tokens = append(tokens, revert, token)
+	res, err = api.GetTokenBalances(ctx, holder, tokens, nil)
+	if err != nil {
+		t.Fatalf("failed to get token balances: %v", err)
+	}
+	if b := res.Balances[len(tokens)-2]; b.Error != errTokenGasBudget.Error() {
+		t.Errorf("budget error mismatch: have %q, want %q", b.Error, errTokenGasBudget)
+	}
+	if b := res.Balances[len(tokens)-1]; b.Balance == nil || b.Balance.ToInt().Int64() != 1234 {
+		t.Errorf("cached balance mismatch: %+v", b)
+	}
+	if _, err := api.GetTokenBalances(ctx, holder, make([]common.Address, maxTokenBalanceTokens+1), nil); err == nil {
+		t.Error("no error for too many tokens")
+	}
+}
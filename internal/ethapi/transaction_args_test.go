@@ -330,9 +330,10 @@ func (b *backendMock) AccountManager() *accounts.Manager                   { ret
 func (b *backendMock) ExtRPCEnabled() bool                                 { return false }
 func (b *backendMock) RPCGasCap() uint64                                   { return 0 }
 func (b *backendMock) RPCEVMTimeout() time.Duration                        { return time.Second }
+func (b *backendMock) RPCCallGuard() *CallGuard                            { return nil }
 func (b *backendMock) RPCTxFeeCap() float64                                { return 0 }
 func (b *backendMock) UnprotectedAllowed() bool                            { return false }
-func (b *backendMock) SetHead(number uint64)                               {}
+func (b *backendMock) SetHead(number uint64) error                         { return nil }
 func (b *backendMock) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
 	return nil, nil
 }
@@ -379,6 +380,9 @@ func (b *backendMock) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) eve
 func (b *backendMock) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) event.Subscription {
 	return nil
 }
+func (b *backendMock) SubscribeChainReorgEvent(ch chan<- core.ChainReorgEvent) event.Subscription {
+	return nil
+}
 func (b *backendMock) SendTx(ctx context.Context, signedTx *types.Transaction) error { return nil }
 func (b *backendMock) GetTransaction(ctx context.Context, txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64, error) {
 	return false, nil, [32]byte{}, 0, 0, nil
@@ -0,0 +1,279 @@
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/contracts/system"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxValueFlowBlocks is the maximum number of blocks scanned by a single
+// GetAddressValueFlows call.
+const maxValueFlowBlocks = 10000
+
+// Kinds of the value flows.
+const (
+	FlowTransaction = "transaction" // Value of a transaction
+	FlowInternal    = "internal"    // Value moved by an internal call, creation or self-destruct
+	FlowStaking     = "staking"     // Value moved to or from the staking contract
+	FlowFee         = "fee"         // Fee paid by the sender of a transaction, burnt base fee included
+	FlowReward      = "reward"      // Block fees distributed to the staking contract as validator rewards
+)
+
+// ValueFlow is a single credit or debit of an address.
+type ValueFlow struct {
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	BlockHash    common.Hash     `json:"blockHash"`
+	TxHash       *common.Hash    `json:"transactionHash"` // Nil for the block level flows
+	TxIndex      *hexutil.Uint   `json:"transactionIndex"`
+	TraceAddress []uint64        `json:"traceAddress,omitempty"`
+	Kind         string          `json:"kind"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to"`     // Nil for the fees
+	Amount       *hexutil.Big    `json:"amount"` // Positive for credits, negative for debits
+}
+
+// ValueFlows is the ledger of an address over a block range.
+type ValueFlows struct {
+	Address   common.Address `json:"address"`
+	FromBlock hexutil.Uint64 `json:"fromBlock"`
+	ToBlock   hexutil.Uint64 `json:"toBlock"`
+	Flows     []*ValueFlow   `json:"flows"`
+	Net       *hexutil.Big   `json:"net"`
+}
+
+// NeroLedgerAPI provides the Nero specific accounting helpers.
+type NeroLedgerAPI struct {
+	b        Backend
+	balances *lru.Cache[tokenBalanceKey, *big.Int] // Token balances already queried
+}
+
+// NewNeroLedgerAPI creates a new Nero ledger API.
+func NewNeroLedgerAPI(b Backend) *NeroLedgerAPI {
+	return &NeroLedgerAPI{
+		b:        b,
+		balances: lru.NewCache[tokenBalanceKey, *big.Int](tokenBalanceCacheSize),
+	}
+}
+
+// GetAddressValueFlows returns the signed ledger of the native token moved to
+// and from the address within the given block range (both included), in
+// execution order: the value of the transactions, the internal transfers, the
+// fees paid and, for the staking contract, the block fees distributed to it.
+//
+// The internal transfers are read from the internal transactions recorded by
+// the node, so it must run with --traceaction=2 for the ledger to be complete.
+// Transfers made by internal calls are only accounted if the call and all its
+// parents succeeded.
+func (api *NeroLedgerAPI) GetAddressValueFlows(ctx context.Context, address common.Address, fromBlock, toBlock rpc.BlockNumber) (*ValueFlows, error) {
+	from, err := api.resolveBlockNumber(ctx, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.resolveBlockNumber(ctx, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, errors.New("fromBlock is after toBlock")
+	}
+	if to-from >= maxValueFlowBlocks {
+		return nil, fmt.Errorf("block range too large, maximum is %d blocks", maxValueFlowBlocks)
+	}
+	res := &ValueFlows{
+		Address:   address,
+		FromBlock: hexutil.Uint64(from),
+		ToBlock:   hexutil.Uint64(to),
+		Flows:     []*ValueFlow{},
+	}
+	net := new(big.Int)
+	for number := from; number <= to; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := api.b.BlockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		flows, err := api.blockValueFlows(ctx, address, block)
+		if err != nil {
+			return nil, err
+		}
+		for _, flow := range flows {
+			net.Add(net, flow.Amount.ToInt())
+		}
+		res.Flows = append(res.Flows, flows...)
+	}
+	res.Net = (*hexutil.Big)(net)
+	return res, nil
+}
+
+func (api *NeroLedgerAPI) resolveBlockNumber(ctx context.Context, number rpc.BlockNumber) (uint64, error) {
+	header, err := api.b.HeaderByNumber(ctx, number)
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, fmt.Errorf("block #%d not found", number)
+	}
+	return header.Number.Uint64(), nil
+}
+
+// blockValueFlows returns the value flows of the address within the block.
+func (api *NeroLedgerAPI) blockValueFlows(ctx context.Context, address common.Address, block *types.Block) ([]*ValueFlow, error) {
+	txs := block.Transactions()
+	if len(txs) == 0 {
+		return nil, nil
+	}
+	receipts, err := api.b.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) != len(txs) {
+		return nil, fmt.Errorf("receipts of block #%d not found", block.NumberU64())
+	}
+	var (
+		flows    []*ValueFlow
+		signer   = types.MakeSigner(api.b.ChainConfig(), block.Number(), block.Time())
+		internal = make(map[common.Hash][]*types.Action)
+		fees     = new(big.Int)
+	)
+	for _, itx := range rawdb.ReadInternalTxs(api.b.ChainDb(), block.Hash(), block.NumberU64()) {
+		internal[itx.TxHash] = itx.Actions
+	}
+	for i, tx := range txs {
+		var (
+			receipt = receipts[i]
+			txHash  = tx.Hash()
+			txIndex = hexutil.Uint(i)
+		)
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		flow := func(kind string, from common.Address, to *common.Address, amount *big.Int, trace []uint64) {
+			flows = append(flows, &ValueFlow{
+				BlockNumber:  hexutil.Uint64(block.NumberU64()),
+				BlockHash:    block.Hash(),
+				TxHash:       &txHash,
+				TxIndex:      &txIndex,
+				TraceAddress: trace,
+				Kind:         kind,
+				From:         from,
+				To:           to,
+				Amount:       (*hexutil.Big)(amount),
+			})
+		}
+		// The value of the transaction, only moved if it succeeded.
+		recipient := tx.To()
+		if recipient == nil {
+			recipient = &receipt.ContractAddress
+		}
+		if receipt.Status == types.ReceiptStatusSuccessful && tx.Value().Sign() > 0 {
+			kind := transferKind(FlowTransaction, sender, *recipient)
+			if sender == address {
+				flow(kind, sender, recipient, new(big.Int).Neg(tx.Value()), nil)
+			}
+			if *recipient == address {
+				flow(kind, sender, recipient, new(big.Int).Set(tx.Value()), nil)
+			}
+		}
+		// The internal transfers, skipping the top level call of the transaction.
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			var failed [][]uint64
+			for _, action := range internal[txHash] {
+				if len(action.TraceAddress) == 0 {
+					continue
+				}
+				if !action.Success || hasFailedParent(failed, action.TraceAddress) {
+					failed = append(failed, action.TraceAddress)
+					continue
+				}
+				if action.Value == nil || action.Value.Sign() <= 0 || !movesValue(action.OpCode) {
+					continue
+				}
+				to := action.To
+				kind := transferKind(FlowInternal, action.From, to)
+				if action.From == address {
+					flow(kind, action.From, &to, new(big.Int).Neg(action.Value), action.TraceAddress)
+				}
+				if to == address {
+					flow(kind, action.From, &to, new(big.Int).Set(action.Value), action.TraceAddress)
+				}
+			}
+		}
+		// The fee paid by the sender, whatever the transaction outcome.
+		price := receipt.EffectiveGasPrice
+		if price == nil {
+			price = tx.GasPrice()
+		}
+		fee := new(big.Int).Mul(price, new(big.Int).SetUint64(receipt.GasUsed))
+		if sender == address && fee.Sign() > 0 {
+			flow(FlowFee, sender, nil, fee.Neg(fee), nil)
+		}
+		// The fees beyond the base fee are collected and distributed to the
+		// staking contract at the end of the block.
+		tip := new(big.Int).Set(price)
+		if block.BaseFee() != nil {
+			tip.Sub(tip, block.BaseFee())
+		}
+		if tip.Sign() > 0 {
+			fees.Add(fees, tip.Mul(tip, new(big.Int).SetUint64(receipt.GasUsed)))
+		}
+	}
+	if address == system.StakingContract && api.b.ChainConfig().Turbo != nil && fees.Sign() > 0 {
+		flows = append(flows, &ValueFlow{
+			BlockNumber: hexutil.Uint64(block.NumberU64()),
+			BlockHash:   block.Hash(),
+			Kind:        FlowReward,
+			From:        system.EngineCaller,
+			To:          &system.StakingContract,
+			Amount:      (*hexutil.Big)(fees),
+		})
+	}
+	return flows, nil
+}
+
+// transferKind returns the kind of a transfer, distinguishing the ones to or
+// from the staking contract: stakes, unstakes and claimed rewards.
+func transferKind(kind string, from, to common.Address) string {
+	if from == system.StakingContract || to == system.StakingContract {
+		return FlowStaking
+	}
+	return kind
+}
+
+// movesValue reports whether the value of an action is actually transferred.
+// The value of a delegate call is the one of its parent, and a call code
+// transfers the value to the caller itself.
+func movesValue(opcode string) bool {
+	switch opcode {
+	case vm.DELEGATECALL.String(), vm.CALLCODE.String(), vm.STATICCALL.String():
+		return false
+	}
+	return true
+}
+
+// hasFailedParent reports whether one of the failed trace addresses is a
+// parent of the given trace address.
+func hasFailedParent(failed [][]uint64, trace []uint64) bool {
+	for _, parent := range failed {
+		if len(parent) < len(trace) && slices.Equal(parent, trace[:len(parent)]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestGetAddressValueFlows(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts  = newAccounts(3)
+		forwarder = common.HexToAddress("0x1000")
+		genesis   = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				// CALL(gas, accounts[1], callvalue, 0, 0, 0, 0)
+				forwarder: {Code: append(append(hexutil.MustDecode("0x60006000600060003473"), accounts[1].addr.Bytes()...), 0x5a, 0xf1, 0x00)},
+			},
+		}
+		signer = types.HomesteadSigner{}
+		to     = []common.Address{accounts[1].addr, forwarder}
+		values = []int64{1000, 500}
+	)
+	backend := newTestBackend(t, 2, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: uint64(i), To: &to[i], Value: big.NewInt(values[i]), Gas: 100000, GasPrice: b.BaseFee()}), signer, accounts[0].key)
+		b.AddTx(tx)
+		b.SetPoS()
+	})
+	// Record the internal transactions of the forwarding, along with a transfer
+	// reverted by its parent and a delegate call not moving its value.
+	block := backend.chain.GetBlockByNumber(2)
+	rawdb.WriteInternalTxs(backend.db, block.Hash(), 2, types.InternalTxs{{
+		TxHash: block.Transactions()[0].Hash(),
+		Actions: []*types.Action{
+			{From: accounts[0].addr, To: forwarder, Value: big.NewInt(500), OpCode: "CALL", Depth: ^uint64(0), Success: true},
+			{From: forwarder, To: accounts[1].addr, Value: big.NewInt(500), OpCode: "CALL", TraceAddress: []uint64{0}, Success: true},
+			{From: forwarder, To: accounts[2].addr, Value: big.NewInt(0), OpCode: "CALL", TraceAddress: []uint64{1}},
+			{From: accounts[2].addr, To: accounts[1].addr, Value: big.NewInt(7), OpCode: "CALL", TraceAddress: []uint64{1, 0}, Success: true},
+			{From: forwarder, To: accounts[1].addr, Value: big.NewInt(9), OpCode: "DELEGATECALL", TraceAddress: []uint64{2}, Success: true},
+		},
+	}})
+	api := NewNeroLedgerAPI(backend)
+
+	// The net flows match the balance changes.
+	genesisState, _, _ := backend.StateAndHeaderByNumber(context.Background(), 0)
+	headState, _, _ := backend.StateAndHeaderByNumber(context.Background(), rpc.LatestBlockNumber)
+	for _, addr := range []common.Address{accounts[0].addr, accounts[1].addr, forwarder} {
+		res, err := api.GetAddressValueFlows(context.Background(), addr, 1, rpc.LatestBlockNumber)
+		if err != nil {
+			t.Fatalf("failed to get value flows of %s: %v", addr, err)
+		}
+		want := new(big.Int).Sub(headState.GetBalance(addr).ToBig(), genesisState.GetBalance(addr).ToBig())
+		if res.Net.ToInt().Cmp(want) != 0 {
+			t.Errorf("net flow mismatch of %s: have %v, want %v", addr, res.Net, want)
+		}
+	}
+	// The flows of the recipient are the transfer and the internal forwarding.
+	res, err := api.GetAddressValueFlows(context.Background(), accounts[1].addr, 1, 2)
+	if err != nil {
+		t.Fatalf("failed to get value flows: %v", err)
+	}
+	want := []struct {
+		kind   string
+		from   common.Address
+		amount int64
+		trace  []uint64
+	}{
+		{FlowTransaction, accounts[0].addr, 1000, nil},
+		{FlowInternal, forwarder, 500, []uint64{0}},
+	}
+	if len(res.Flows) != len(want) {
+		t.Fatalf("flow count mismatch: have %d, want %d", len(res.Flows), len(want))
+	}
+	for i, flow := range res.Flows {
+		if flow.Kind != want[i].kind || flow.From != want[i].from || flow.Amount.ToInt().Int64() != want[i].amount || len(flow.TraceAddress) != len(want[i].trace) {
+			t.Errorf("flow %d mismatch: have %s from %s amount %v trace %v", i, flow.Kind, flow.From, flow.Amount, flow.TraceAddress)
+		}
+	}
+	// The sender pays the fees.
+	res, err = api.GetAddressValueFlows(context.Background(), accounts[0].addr, 2, 2)
+	if err != nil {
+		t.Fatalf("failed to get value flows: %v", err)
+	}
+	if len(res.Flows) != 2 || res.Flows[0].Amount.ToInt().Int64() != -500 || res.Flows[1].Kind != FlowFee || res.Flows[1].To != nil {
+		t.Errorf("sender flows mismatch: %+v", res.Flows)
+	}
+	// Invalid ranges are rejected.
+	if _, err := api.GetAddressValueFlows(context.Background(), accounts[0].addr, 2, 1); err == nil {
+		t.Error("no error for inverted range")
+	}
+	if _, err := api.GetAddressValueFlows(context.Background(), accounts[0].addr, 1, 3); err == nil {
+		t.Error("no error for missing block")
+	}
+}
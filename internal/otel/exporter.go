@@ -0,0 +1,290 @@
+package otel
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	queueSize     = 4096            // Maximum number of ended spans waiting for export
+	batchSize     = 512             // Maximum number of spans exported in one request
+	flushInterval = 5 * time.Second // Maximum time an ended span waits for export
+)
+
+var droppedSpansMeter = metrics.NewRegisteredMeter("otel/spans/dropped", nil)
+
+// Config contains the settings of the span export.
+type Config struct {
+	Endpoint    string            // OTLP/HTTP traces endpoint of the collector
+	ServiceName string            // Service name reported to the collector
+	SampleRatio float64           // Fraction of the traces to record
+	Headers     map[string]string // Extra headers sent with each export, e.g. for authentication
+}
+
+// DefaultConfig is the default span export configuration.
+var DefaultConfig = Config{
+	Endpoint:    "http://localhost:4318/v1/traces",
+	ServiceName: "geth",
+	SampleRatio: 1,
+}
+
+// exporter batches the ended spans and sends them to the collector.
+type exporter struct {
+	config    Config
+	endpoint  string
+	threshold uint64
+	client    *http.Client
+	queue     chan *Span
+	flush     chan chan struct{}
+	closed    chan struct{}
+}
+
+// Setup enables recording spans and starts exporting them to the configured
+// collector.
+func Setup(config Config) error {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return fmt.Errorf("invalid OTLP endpoint %q", config.Endpoint)
+	}
+	if endpoint.Path == "" || endpoint.Path == "/" {
+		endpoint.Path = "/v1/traces"
+	}
+	if config.ServiceName == "" {
+		config.ServiceName = DefaultConfig.ServiceName
+	}
+	exp := &exporter{
+		config:    config,
+		endpoint:  endpoint.String(),
+		threshold: sampleThreshold(config.SampleRatio),
+		client:    &http.Client{Timeout: 10 * time.Second},
+		queue:     make(chan *Span, queueSize),
+		flush:     make(chan chan struct{}),
+		closed:    make(chan struct{}),
+	}
+	if old := tracer.Swap(exp); old != nil {
+		old.close()
+	}
+	go exp.loop()
+
+	log.Info("Enabled OpenTelemetry tracing", "endpoint", exp.endpoint, "service", config.ServiceName, "sample", config.SampleRatio)
+	return nil
+}
+
+// Shutdown disables recording spans and exports the pending ones.
+func Shutdown() {
+	if exp := tracer.Swap(nil); exp != nil {
+		exp.close()
+	}
+}
+
+// sample decides whether a new trace is recorded.
+func (e *exporter) sample() bool {
+	return e.threshold != 0 && randUint64() <= e.threshold
+}
+
+// enqueue queues an ended span for export, dropping it if the exporter can't
+// keep up.
+func (e *exporter) enqueue(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+		droppedSpansMeter.Mark(1)
+	}
+}
+
+// close exports the pending spans and stops the exporter.
+func (e *exporter) close() {
+	done := make(chan struct{})
+	select {
+	case e.flush <- done:
+		<-done
+	case <-e.closed:
+	}
+}
+
+// loop batches the queued spans and exports them.
+func (e *exporter) loop() {
+	defer close(e.closed)
+
+	var (
+		batch = make([]*Span, 0, batchSize)
+		timer = time.NewTimer(flushInterval)
+	)
+	defer timer.Stop()
+
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.export(batch); err != nil {
+			log.Debug("Failed to export spans", "count", len(batch), "err", err)
+			droppedSpansMeter.Mark(int64(len(batch)))
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= batchSize {
+				export()
+			}
+		case <-timer.C:
+			export()
+			timer.Reset(flushInterval)
+
+		case done := <-e.flush:
+			for len(e.queue) > 0 {
+				batch = append(batch, <-e.queue)
+				if len(batch) >= batchSize {
+					export()
+				}
+			}
+			export()
+			close(done)
+			return
+		}
+	}
+}
+
+// export sends a batch of spans to the collector.
+func (e *exporter) export(spans []*Span) error {
+	body, err := json.Marshal(e.encode(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.config.Headers {
+		req.Header.Set(key, value)
+	}
+	res, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return errors.New(res.Status)
+	}
+	return nil
+}
+
+// OTLP JSON encoding of the trace export requests, see
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID      string          `json:"traceId"`
+		SpanID       string          `json:"spanId"`
+		ParentSpanID string          `json:"parentSpanId,omitempty"`
+		Name         string          `json:"name"`
+		Kind         SpanKind        `json:"kind"`
+		Start        string          `json:"startTimeUnixNano"`
+		End          string          `json:"endTimeUnixNano"`
+		Attributes   []otlpAttribute `json:"attributes,omitempty"`
+		Status       *otlpStatus     `json:"status,omitempty"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		String *string  `json:"stringValue,omitempty"`
+		Int    *string  `json:"intValue,omitempty"`
+		Bool   *bool    `json:"boolValue,omitempty"`
+		Double *float64 `json:"doubleValue,omitempty"`
+	}
+)
+
+// encode converts the spans into an OTLP export request.
+func (e *exporter) encode(spans []*Span) *otlpRequest {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		span.lock.Lock()
+		item := otlpSpan{
+			TraceID:    hex.EncodeToString(span.traceID[:]),
+			SpanID:     hex.EncodeToString(span.spanID[:]),
+			Name:       span.name,
+			Kind:       span.kind,
+			Start:      strconv.FormatInt(span.start.UnixNano(), 10),
+			End:        strconv.FormatInt(span.end.UnixNano(), 10),
+			Attributes: encodeAttributes(span.attrs),
+		}
+		if span.parent != ([8]byte{}) {
+			item.ParentSpanID = hex.EncodeToString(span.parent[:])
+		}
+		if span.err != "" {
+			item.Status = &otlpStatus{Code: 2, Message: span.err}
+		}
+		span.lock.Unlock()
+		encoded = append(encoded, item)
+	}
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: encodeAttributes([]Attribute{String("service.name", e.config.ServiceName)}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: "github.com/ethereum/go-ethereum"},
+				Spans: encoded,
+			}},
+		}},
+	}
+}
+
+// encodeAttributes converts the attributes into their OTLP form.
+func encodeAttributes(attrs []Attribute) []otlpAttribute {
+	encoded := make([]otlpAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		var value otlpValue
+		switch v := attr.Value.(type) {
+		case string:
+			value.String = &v
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			value.Int = &s
+		case bool:
+			value.Bool = &v
+		case float64:
+			value.Double = &v
+		default:
+			s := fmt.Sprint(v)
+			value.String = &s
+		}
+		encoded = append(encoded, otlpAttribute{Key: attr.Key, Value: value})
+	}
+	return encoded
+}
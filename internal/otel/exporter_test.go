@@ -0,0 +1,91 @@
+package otel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests []otlpRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var req otlpRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		lock.Lock()
+		requests = append(requests, req)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	if err := Setup(Config{Endpoint: server.URL, ServiceName: "test", SampleRatio: 1, Headers: map[string]string{"X-Token": "secret"}}); err != nil {
+		t.Fatal(err)
+	}
+	ctx, root := Start(context.Background(), "root", Uint64("number", 1))
+	_, child := Start(ctx, "child")
+	child.RecordError(errors.New("failed"))
+	child.End()
+	root.End()
+	Shutdown()
+
+	if Enabled() {
+		t.Fatal("tracing still enabled after shutdown")
+	}
+	if len(requests) != 1 {
+		t.Fatalf("export request count mismatch: have %d, want 1", len(requests))
+	}
+	spans := requests[0].ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("span count mismatch: have %d, want 2", len(spans))
+	}
+	exportedChild, exportedRoot := spans[0], spans[1]
+	if exportedRoot.Name != "root" || exportedRoot.ParentSpanID != "" || *exportedRoot.Attributes[0].Value.Int != "1" {
+		t.Errorf("root span mismatch: %+v", exportedRoot)
+	}
+	if exportedChild.TraceID != exportedRoot.TraceID || exportedChild.ParentSpanID != exportedRoot.SpanID {
+		t.Errorf("child span not linked to root: %+v", exportedChild)
+	}
+	if exportedChild.Status == nil || exportedChild.Status.Code != 2 || exportedChild.Status.Message != "failed" {
+		t.Errorf("child span status mismatch: %+v", exportedChild.Status)
+	}
+	if name := *requests[0].ResourceSpans[0].Resource.Attributes[0].Value.String; name != "test" {
+		t.Errorf("service name mismatch: have %s, want test", name)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	ctx, span := Start(context.Background(), "root")
+	if span != nil || SpanFromContext(ctx) != nil {
+		t.Fatal("span recorded while tracing is disabled")
+	}
+	// Nil spans must be usable
+	span.Child("child").End()
+	span.SetAttributes(Bool("ok", true))
+	span.End()
+}
+
+func TestSampling(t *testing.T) {
+	if err := Setup(Config{Endpoint: "http://localhost:4318", SampleRatio: 0}); err != nil {
+		t.Fatal(err)
+	}
+	defer Shutdown()
+
+	for i := 0; i < 100; i++ {
+		if _, span := Start(context.Background(), "root"); span != nil {
+			t.Fatal("span recorded with zero sample ratio")
+		}
+	}
+}
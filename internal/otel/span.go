@@ -0,0 +1,190 @@
+// Package otel implements a lightweight OpenTelemetry span tracer, exporting
+// the recorded spans to an OTLP/HTTP collector.
+package otel
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SpanKind is the role of a span in a trace, see the OTLP span kinds.
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+)
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{} // string, int64, bool or float64
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute { return Attribute{key, value} }
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attribute { return Attribute{key, int64(value)} }
+
+// Uint64 returns an integer attribute. Values above the int64 range are
+// truncated, which doesn't matter for block numbers or gas amounts.
+func Uint64(key string, value uint64) Attribute { return Attribute{key, int64(value)} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute { return Attribute{key, value} }
+
+// Span is a timed operation within a trace. All methods are safe to call on
+// a nil span, which is what is handed out if tracing is disabled or the trace
+// isn't sampled, so instrumented code needs no checks.
+type Span struct {
+	traceID [16]byte
+	spanID  [8]byte
+	parent  [8]byte
+	name    string
+	kind    SpanKind
+	start   time.Time
+
+	lock  sync.Mutex
+	end   time.Time
+	attrs []Attribute
+	err   string
+	ended bool
+}
+
+// tracer is the active exporter, nil if tracing is disabled.
+var tracer atomic.Pointer[exporter]
+
+// Enabled reports whether spans are recorded.
+func Enabled() bool {
+	return tracer.Load() != nil
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of the context carrying the span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span carried by the context, if any.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// Start starts a new span as a child of the span carried by the context, or as
+// the root of a new trace. The returned context carries the new span.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	span := StartAt(SpanFromContext(ctx), name, time.Now(), attrs...)
+	return ContextWithSpan(ctx, span), span
+}
+
+// StartAt starts a new span with the given start time as a child of the given
+// parent span. If the parent is nil, a new trace is started, subject to the
+// sampling ratio.
+func StartAt(parent *Span, name string, start time.Time, attrs ...Attribute) *Span {
+	exp := tracer.Load()
+	if exp == nil {
+		return nil
+	}
+	span := &Span{name: name, kind: KindInternal, start: start, attrs: attrs}
+	if parent != nil {
+		span.traceID, span.parent = parent.traceID, parent.spanID
+	} else {
+		if !exp.sample() {
+			return nil
+		}
+		rand.Read(span.traceID[:])
+	}
+	rand.Read(span.spanID[:])
+	return span
+}
+
+// Child starts a new child span of the span. Nil is returned if the span is nil.
+func (s *Span) Child(name string, attrs ...Attribute) *Span {
+	if s == nil {
+		return nil
+	}
+	return StartAt(s, name, time.Now(), attrs...)
+}
+
+// SetKind sets the role of the span in the trace.
+func (s *Span) SetKind(kind SpanKind) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.kind = kind
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// RecordError marks the span as failed if err is non-nil.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.err = err.Error()
+}
+
+// End completes the span and queues it for export.
+func (s *Span) End() {
+	s.EndAt(time.Now())
+}
+
+// EndAt completes the span with the given end time and queues it for export.
+// Only the first call has an effect.
+func (s *Span) EndAt(end time.Time) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	if s.ended {
+		s.lock.Unlock()
+		return
+	}
+	s.end, s.ended = end, true
+	s.lock.Unlock()
+
+	if exp := tracer.Load(); exp != nil {
+		exp.enqueue(s)
+	}
+}
+
+// sampleThreshold converts a sampling ratio into a threshold for the first 8
+// bytes of random trace ids.
+func sampleThreshold(ratio float64) uint64 {
+	switch {
+	case ratio >= 1:
+		return ^uint64(0)
+	case ratio <= 0:
+		return 0
+	}
+	return uint64(ratio * float64(^uint64(0)))
+}
+
+// randUint64 returns a random number used for sampling.
+func randUint64() uint64 {
+	var b [8]byte
+	rand.Read(b[:])
+	return binary.BigEndian.Uint64(b[:])
+}
@@ -0,0 +1,95 @@
+package version
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Provenance describes how the running executable was built, so that the
+// operators can verify the nodes run official builds. The reproducibility hash
+// covers the inputs of the build: rebuilding the release sources with the same
+// toolchain and settings yields the same hash.
+type Provenance struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Dirty     bool   `json:"dirty"` // Built from a modified checkout
+	GoVersion string `json:"goVersion"`
+	CGO       bool   `json:"cgo"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	Tags      string `json:"tags,omitempty"`
+	Hash      string `json:"reproducibilityHash,omitempty"` // Empty if the build information is missing
+}
+
+var (
+	provenanceOnce sync.Once
+	provenance     Provenance
+)
+
+// BuildProvenance returns the provenance of the current executable.
+func BuildProvenance() Provenance {
+	provenanceOnce.Do(func() {
+		info, _ := debug.ReadBuildInfo()
+		provenance = readProvenance(info)
+	})
+	return provenance
+}
+
+// readProvenance assembles the provenance from the build information, which is
+// nil if not embedded in the executable.
+func readProvenance(info *debug.BuildInfo) Provenance {
+	p := Provenance{
+		Version:   params.VersionWithMeta,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+	if vcs, ok := VCS(); ok {
+		p.Commit, p.Date, p.Dirty = vcs.Commit, vcs.Date, vcs.Dirty
+	}
+	if info == nil {
+		return p
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "CGO_ENABLED":
+			p.CGO = setting.Value == "1"
+		case "-tags":
+			p.Tags = setting.Value
+		case "vcs.modified":
+			// Also set if the commit is injected by the build script
+			p.Dirty = p.Dirty || setting.Value == "true"
+		}
+	}
+	p.Hash = buildHash(info)
+	return p
+}
+
+// buildHash hashes the inputs of the build: the toolchain, the modules and the
+// build settings, including the revision of the sources.
+func buildHash(info *debug.BuildInfo) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "go %s\npath %s\n", info.GoVersion, info.Path)
+	writeModule(h, "mod", &info.Main)
+	for _, dep := range info.Deps {
+		writeModule(h, "dep", dep)
+	}
+	for _, setting := range info.Settings {
+		fmt.Fprintf(h, "build %s=%s\n", setting.Key, setting.Value)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func writeModule(w io.Writer, kind string, mod *debug.Module) {
+	fmt.Fprintf(w, "%s %s %s %s\n", kind, mod.Path, mod.Version, mod.Sum)
+	if mod.Replace != nil {
+		writeModule(w, "=>", mod.Replace)
+	}
+}
@@ -0,0 +1,47 @@
+package version
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+// Tests that the provenance is read from the build settings, and that the
+// reproducibility hash changes with any input of the build.
+func TestReadProvenance(t *testing.T) {
+	newInfo := func() *debug.BuildInfo {
+		return &debug.BuildInfo{
+			GoVersion: "go1.21.0",
+			Path:      "github.com/ethereum/go-ethereum/cmd/geth",
+			Main:      debug.Module{Path: ourPath, Version: "(devel)"},
+			Deps:      []*debug.Module{{Path: "golang.org/x/crypto", Version: "v0.1.0", Sum: "h1:abc"}},
+			Settings: []debug.BuildSetting{
+				{Key: "-tags", Value: "urfave_cli_no_docs,ckzg"},
+				{Key: "CGO_ENABLED", Value: "1"},
+				{Key: "vcs.revision", Value: "0123456789abcdef"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}
+	}
+	p := readProvenance(newInfo())
+	if !p.CGO || !p.Dirty || p.Tags != "urfave_cli_no_docs,ckzg" {
+		t.Fatalf("settings mismatch: cgo %v, dirty %v, tags %q", p.CGO, p.Dirty, p.Tags)
+	}
+	if p.Hash == "" || readProvenance(newInfo()).Hash != p.Hash {
+		t.Fatalf("hash not deterministic: %q", p.Hash)
+	}
+	for name, change := range map[string]func(*debug.BuildInfo){
+		"toolchain": func(info *debug.BuildInfo) { info.GoVersion = "go1.21.1" },
+		"dep":       func(info *debug.BuildInfo) { info.Deps[0].Sum = "h1:def" },
+		"replace":   func(info *debug.BuildInfo) { info.Deps[0].Replace = &debug.Module{Path: "../crypto"} },
+		"revision":  func(info *debug.BuildInfo) { info.Settings[2].Value = "fedcba9876543210" },
+	} {
+		info := newInfo()
+		change(info)
+		if readProvenance(info).Hash == p.Hash {
+			t.Errorf("hash unchanged by the %s", name)
+		}
+	}
+	if p := readProvenance(nil); p.Hash != "" || p.GoVersion == "" {
+		t.Fatalf("missing build information mismatch: %+v", p)
+	}
+}
@@ -20,6 +20,7 @@ package web3ext
 var Modules = map[string]string{
 	"admin":    AdminJs,
 	"turbo":    TurboJs,
+	"nero":     NeroJs,
 	"ethash":   EthashJs,
 	"debug":    DebugJs,
 	"eth":      EthJs,
@@ -31,6 +32,8 @@ var Modules = map[string]string{
 	"les":      LESJs,
 	"vflux":    VfluxJs,
 	"dev":      DevJs,
+	"bridge":   BridgeJs,
+	"failover": FailoverJs,
 }
 
 const TurboJs = `
@@ -59,11 +62,186 @@ web3._extend({
 			call: 'turbo_getValidatorsAtHash',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'getJailedValidators',
+			call: 'turbo_getJailedValidators',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getSlashingParams',
+			call: 'turbo_getSlashingParams',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getValidatorsFromHeaders',
+			call: 'turbo_getValidatorsFromHeaders',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'status',
 			call: 'turbo_status',
 			params: 0
 		}),
+		new web3._extend.Method({
+			name: 'stopSealing',
+			call: 'turbo_stopSealing',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'resumeSealing',
+			call: 'turbo_resumeSealing',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getHaltStatus',
+			call: 'turbo_getHaltStatus',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'overrideHalt',
+			call: 'turbo_overrideHalt',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'clearHaltOverride',
+			call: 'turbo_clearHaltOverride',
+			params: 0
+		}),
+	]
+});
+`
+
+const NeroJs = `
+web3._extend({
+	property: 'nero',
+	methods: [
+		new web3._extend.Method({
+			name: 'getGovernanceHistory',
+			call: 'nero_getGovernanceHistory',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getPunishHistory',
+			call: 'nero_getPunishHistory',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'prepareTransaction',
+			call: 'nero_prepareTransaction',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'cancelTransaction',
+			call: 'nero_cancelTransaction',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'speedUpTransaction',
+			call: 'nero_speedUpTransaction',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getTransactionReceipt',
+			call: 'nero_getTransactionReceipt',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'verifyContract',
+			call: 'nero_verifyContract',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getVerifiedContract',
+			call: 'nero_getVerifiedContract',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getStateDiff',
+			call: 'nero_getStateDiff',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'getAddressValueFlows',
+			call: 'nero_getAddressValueFlows',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getAddressTimeline',
+			call: 'nero_getAddressTimeline',
+			params: 5,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getTokenBalances',
+			call: 'nero_getTokenBalances',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getEpochSummary',
+			call: 'nero_getEpochSummary',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getValidatorEarnings',
+			call: 'nero_getValidatorEarnings',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getSupplyInfo',
+			call: 'nero_getSupplyInfo',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getTokenTransfers',
+			call: 'nero_getTokenTransfers',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'getLogsPaged',
+			call: 'nero_getLogsPaged',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getContractCreation',
+			call: 'nero_getContractCreation',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'firehoseAck',
+			call: 'nero_firehoseAck',
+			params: 2
+		}),
+		new web3._extend.Method({
+			name: 'getDecentralizationStats',
+			call: 'nero_getDecentralizationStats',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'simulatePending',
+			call: 'nero_simulatePending',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
 	]
 });
 `
@@ -131,6 +309,10 @@ web3._extend({
 			call: 'admin_importChain',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'rebuildLogIndex',
+			call: 'admin_rebuildLogIndex'
+		}),
 		new web3._extend.Method({
 			name: 'sleepBlocks',
 			call: 'admin_sleepBlocks',
@@ -178,6 +360,30 @@ web3._extend({
 			name: 'peers',
 			getter: 'admin_peers'
 		}),
+		new web3._extend.Property({
+			name: 'natStatus',
+			getter: 'admin_natStatus'
+		}),
+		new web3._extend.Property({
+			name: 'syncProgress',
+			getter: 'admin_syncProgress'
+		}),
+		new web3._extend.Property({
+			name: 'rpcUsage',
+			getter: 'admin_rpcUsage'
+		}),
+		new web3._extend.Property({
+			name: 'chainStatus',
+			getter: 'admin_chainStatus'
+		}),
+		new web3._extend.Property({
+			name: 'signerHealth',
+			getter: 'admin_signerHealth'
+		}),
+		new web3._extend.Property({
+			name: 'logIndexStatus',
+			getter: 'admin_logIndexStatus'
+		}),
 		new web3._extend.Property({
 			name: 'datadir',
 			getter: 'admin_datadir'
@@ -444,6 +650,35 @@ web3._extend({
 			call: 'debug_getBadBlocks',
 			params: 0,
 		}),
+		new web3._extend.Method({
+			name: 'getBadBlockBundle',
+			call: 'debug_getBadBlockBundle',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getBlockBuildLog',
+			call: 'debug_getBlockBuildLog',
+			params: 1,
+			inputFormatter: [null],
+		}),
+		new web3._extend.Method({
+			name: 'getShadowBlocks',
+			call: 'debug_getShadowBlocks',
+			params: 1,
+			inputFormatter: [null],
+		}),
+		new web3._extend.Method({
+			name: 'getStateExpiryReport',
+			call: 'debug_getStateExpiryReport',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, null],
+		}),
+		new web3._extend.Method({
+			name: 'getLogs',
+			call: 'debug_getLogs',
+			params: 2,
+			inputFormatter: [null, null],
+		}),
 		new web3._extend.Method({
 			name: 'storageRangeAt',
 			call: 'debug_storageRangeAt',
@@ -472,6 +707,12 @@ web3._extend({
 			params: 2,
 			inputFormatter:[web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'getAccountHistory',
+			call: 'debug_getAccountHistory',
+			params: 3,
+			inputFormatter:[web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'dbGet',
 			call: 'debug_dbGet',
@@ -903,3 +1144,51 @@ web3._extend({
 	],
 });
 `
+
+const BridgeJs = `
+web3._extend({
+	property: 'bridge',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'getEvents',
+			call: 'bridge_getEvents',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getAttestation',
+			call: 'bridge_getAttestation',
+			params: 2,
+			inputFormatter: [null, web3._extend.utils.fromDecimal]
+		}),
+	],
+	properties:
+	[
+		new web3._extend.Property({
+			name: 'status',
+			getter: 'bridge_status'
+		}),
+	]
+});
+`
+
+const FailoverJs = `
+web3._extend({
+	property: 'failover',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'handover',
+			call: 'failover_handover'
+		}),
+	],
+	properties:
+	[
+		new web3._extend.Property({
+			name: 'heartbeat',
+			getter: 'failover_heartbeat'
+		}),
+	]
+});
+`
@@ -0,0 +1,40 @@
+package log
+
+// EventKey is the attribute key identifying the key chain events in the logs.
+// Together with the fixed attribute keys listed for each event, it forms a
+// stable schema for log based alerting (e.g. with --log.format=json), which
+// doesn't depend on the wording of the log messages.
+const EventKey = "event"
+
+// Identifiers of the key chain events, logged as the value of EventKey.
+const (
+	// EventBlockSealed is logged when the local validator sealed a block.
+	// Attributes: number, hash, sealhash, txs, elapsed.
+	EventBlockSealed = "block_sealed"
+
+	// EventMissedTurn is logged when an imported block was sealed out of turn.
+	// Attributes: number, validator (missing the turn), signer.
+	EventMissedTurn = "missed_turn"
+
+	// EventPunishExecuted is logged when an imported block punishes a validator.
+	// Attributes: number, validator, kind (lazy or doublesign).
+	EventPunishExecuted = "punish_executed"
+
+	// EventFinalityAdvanced is logged when a newer block got finalized.
+	// Attributes: number, hash.
+	EventFinalityAdvanced = "finality_advanced"
+
+	// EventBadBlock is logged when a block failed to be processed.
+	// Attributes: number, hash, err.
+	EventBadBlock = "bad_block"
+
+	// EventValidatorSetMismatch is logged when the validator set recorded at
+	// an epoch block differs from the one elected at its parent state.
+	// Attributes: number, hash, recorded, elected.
+	EventValidatorSetMismatch = "validator_set_mismatch"
+
+	// EventFailoverTakeover is logged when a passive failover node takes the
+	// sealing over from an expired peer.
+	// Attributes: peer, validator, lastseen.
+	EventFailoverTakeover = "failover_takeover"
+)
@@ -0,0 +1,24 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestEventJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	logger := NewLogger(JSONHandler(out))
+	logger.Info("Successfully sealed new block", EventKey, EventBlockSealed, "number", uint64(100), "txs", 2)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("invalid json output %q: %v", out.String(), err)
+	}
+	if record[EventKey] != EventBlockSealed {
+		t.Errorf("event mismatch: have %v, want %v", record[EventKey], EventBlockSealed)
+	}
+	if record["number"] != float64(100) || record["txs"] != float64(2) {
+		t.Errorf("attributes mismatch: %v", record)
+	}
+}
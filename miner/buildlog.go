@@ -0,0 +1,100 @@
+package miner
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Decisions of the block building about the candidate transactions.
+const (
+	TxIncluded = "included" // The transaction is included in the block
+	TxDropped  = "dropped"  // The transaction is left out, the next ones of its sender are still considered
+	TxSkipped  = "skipped"  // The transaction and the next ones of its sender are left out
+)
+
+// Reasons of the block building for leaving out a transaction, the reasons
+// of the other failures being the execution errors.
+const (
+	reasonFeeTooLow      = "fee cap below base fee"
+	reasonGasExhausted   = "block gas exhausted"
+	reasonGasLimit       = "gas limit reached"
+	reasonNonceTooLow    = "nonce too low"
+	reasonNonceTooHigh   = "nonce too high"
+	reasonUnsupported    = "transaction type not supported"
+	reasonReplayable     = "replay protection not active"
+	reasonAccessDenied   = "denied by access filter: "
+	reasonExecutionError = "execution failed: "
+)
+
+// TxBuildDecision is the decision of the block building about a candidate
+// transaction, with its reason if the transaction was left out.
+type TxBuildDecision struct {
+	Hash     common.Hash    `json:"hash"`
+	From     common.Address `json:"from"`
+	Nonce    hexutil.Uint64 `json:"nonce"`
+	Decision string         `json:"decision"`
+	Reason   string         `json:"reason,omitempty"`
+}
+
+// BlockBuildLog is the audit log of the building of a sealed block, listing the
+// decisions about the candidate transactions in the order they were considered.
+// The candidates not reached before the block was full are not listed.
+type BlockBuildLog struct {
+	Number hexutil.Uint64     `json:"number"`
+	Hash   common.Hash        `json:"hash"`
+	Txs    []*TxBuildDecision `json:"txs"`
+}
+
+// buildLogs retains the audit logs of the last sealed blocks.
+type buildLogs struct {
+	limit int
+	logs  []*BlockBuildLog // Oldest first
+	lock  sync.RWMutex
+}
+
+func newBuildLogs(limit int) *buildLogs {
+	return &buildLogs{limit: limit}
+}
+
+// add retains the log of a sealed block, evicting the oldest one if needed.
+func (l *buildLogs) add(log *BlockBuildLog) {
+	if l.limit <= 0 {
+		return
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.logs = append(l.logs, log)
+	if len(l.logs) > l.limit {
+		l.logs = append(l.logs[:0], l.logs[len(l.logs)-l.limit:]...)
+	}
+}
+
+// get returns the retained logs newest first, only those of the given block
+// number if not nil.
+func (l *buildLogs) get(number *uint64) []*BlockBuildLog {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	logs := make([]*BlockBuildLog, 0)
+	for i := len(l.logs) - 1; i >= 0; i-- {
+		if number == nil || uint64(l.logs[i].Number) == *number {
+			logs = append(logs, l.logs[i])
+		}
+	}
+	return logs
+}
+
+// newTxBuildDecision creates the decision about a transaction of the sender.
+func newTxBuildDecision(tx *types.Transaction, from common.Address, decision, reason string) *TxBuildDecision {
+	return &TxBuildDecision{
+		Hash:     tx.Hash(),
+		From:     from,
+		Nonce:    hexutil.Uint64(tx.Nonce()),
+		Decision: decision,
+		Reason:   reason,
+	}
+}
@@ -53,12 +53,16 @@ type Config struct {
 	GasPrice   *big.Int       // Minimum gas price for mining a transaction
 	Recommit   time.Duration  // The time interval for miner to re-create mining work.
 	Noverify   bool           // Disable remote mining solution verification(only useful in ethash).
+
+	BuildLogBlocks int `toml:",omitempty"` // Number of sealed blocks whose transaction inclusion decisions are retained
+	ShadowBlocks   int `toml:",omitempty"` // Number of slots whose blocks are assembled and retained while not sealing (0 = disabled)
 }
 
 var DefaultConfig = Config{
-	GasCeil:  8000000,
-	GasPrice: big.NewInt(params.GWei),
-	Recommit: 3 * time.Second,
+	GasCeil:        8000000,
+	GasPrice:       big.NewInt(params.GWei),
+	Recommit:       3 * time.Second,
+	BuildLogBlocks: 64,
 }
 
 // Miner creates blocks and searches for proof-of-work values.
@@ -240,6 +244,19 @@ func (miner *Miner) DisablePreseal() {
 	miner.worker.disablePreseal()
 }
 
+// BlockBuildLogs returns the audit logs of the transaction inclusion in the
+// last sealed blocks, newest first, only those of the given block number if
+// not nil.
+func (miner *Miner) BlockBuildLogs(number *uint64) []*BlockBuildLog {
+	return miner.worker.buildLogs.get(number)
+}
+
+// ShadowBlocks returns the blocks assembled for the last slots in shadow mode,
+// newest first, only those of the given block number if not nil.
+func (miner *Miner) ShadowBlocks(number *uint64) []*ShadowBlock {
+	return miner.worker.shadows.get(number)
+}
+
 // SubscribePendingLogs starts delivering logs from pending transactions
 // to the given channel.
 func (miner *Miner) SubscribePendingLogs(ch chan<- []*types.Log) event.Subscription {
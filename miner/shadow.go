@@ -0,0 +1,156 @@
+package miner
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	shadowBuildTimer = metrics.NewRegisteredTimer("miner/shadow/build", nil)
+	shadowLateMeter  = metrics.NewRegisteredMeter("miner/shadow/late", nil)
+)
+
+// ShadowBlock is the block a node not sealing assembled for a slot, recorded
+// along with the block actually sealed for it. Running a prospective validator
+// in shadow mode tells whether its hardware and configuration keep up with the
+// slots before staking: the blocks must be assembled before their slot time
+// and be on par with the sealed ones.
+type ShadowBlock struct {
+	Number     hexutil.Uint64 `json:"number"`
+	ParentHash common.Hash    `json:"parentHash"`
+	Txs        int            `json:"txs"`
+	GasUsed    hexutil.Uint64 `json:"gasUsed"`
+	Fees       *hexutil.Big   `json:"fees"`      // Tips collected by the block, in wei
+	BuildTime  string         `json:"buildTime"` // Time spent assembling the block since the parent was imported
+	Late       bool           `json:"late"`      // Whether the block was assembled past its slot time
+
+	Sealed *SealedBlock `json:"sealed,omitempty"` // Block sealed for the slot, nil until imported
+
+	elapsed time.Duration
+	hashes  map[common.Hash]struct{}
+}
+
+// SealedBlock is the block actually sealed for the slot of a shadow block.
+type SealedBlock struct {
+	Hash      common.Hash    `json:"hash"`
+	Coinbase  common.Address `json:"coinbase"`
+	Txs       int            `json:"txs"`
+	GasUsed   hexutil.Uint64 `json:"gasUsed"`
+	SharedTxs int            `json:"sharedTxs"` // Transactions included by both blocks
+}
+
+// shadowBlocks retains the shadow blocks of the last slots.
+type shadowBlocks struct {
+	limit  int
+	blocks []*ShadowBlock // Oldest first
+	lock   sync.RWMutex
+}
+
+func newShadowBlocks(limit int) *shadowBlocks {
+	return &shadowBlocks{limit: limit}
+}
+
+// enabled returns whether the shadow blocks are recorded.
+func (s *shadowBlocks) enabled() bool {
+	return s.limit > 0
+}
+
+// add records the block assembled for a slot in the given time, replacing the
+// one previously assembled on the same parent, and evicting the oldest one if
+// needed.
+func (s *shadowBlocks) add(block *types.Block, receipts []*types.Receipt, elapsed time.Duration) {
+	if s.limit <= 0 || len(receipts) != len(block.Transactions()) {
+		return
+	}
+	fees := new(big.Int)
+	for i, tx := range block.Transactions() {
+		tip, _ := tx.EffectiveGasTip(block.BaseFee())
+		fees.Add(fees, new(big.Int).Mul(new(big.Int).SetUint64(receipts[i].GasUsed), tip))
+	}
+	shadow := &ShadowBlock{
+		Number:     hexutil.Uint64(block.NumberU64()),
+		ParentHash: block.ParentHash(),
+		Txs:        len(block.Transactions()),
+		GasUsed:    hexutil.Uint64(block.GasUsed()),
+		Fees:       (*hexutil.Big)(fees),
+		BuildTime:  common.PrettyDuration(elapsed).String(),
+		Late:       time.Now().After(time.Unix(int64(block.Time()), 0)),
+		elapsed:    elapsed,
+		hashes:     make(map[common.Hash]struct{}, len(block.Transactions())),
+	}
+	for _, tx := range block.Transactions() {
+		shadow.hashes[tx.Hash()] = struct{}{}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if n := len(s.blocks); n > 0 && s.blocks[n-1].ParentHash == shadow.ParentHash {
+		s.blocks[n-1] = shadow
+		return
+	}
+	s.blocks = append(s.blocks, shadow)
+	if len(s.blocks) > s.limit {
+		s.blocks = append(s.blocks[:0], s.blocks[len(s.blocks)-s.limit:]...)
+	}
+}
+
+// seal compares the block sealed for a slot with the shadow block assembled
+// on the same parent, if any.
+func (s *shadowBlocks) seal(block *types.Block) {
+	if s.limit <= 0 {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i := len(s.blocks) - 1; i >= 0; i-- {
+		shadow := *s.blocks[i]
+		if uint64(shadow.Number) != block.NumberU64() || shadow.ParentHash != block.ParentHash() {
+			continue
+		}
+		sealed := &SealedBlock{
+			Hash:     block.Hash(),
+			Coinbase: block.Coinbase(),
+			Txs:      len(block.Transactions()),
+			GasUsed:  hexutil.Uint64(block.GasUsed()),
+		}
+		for _, tx := range block.Transactions() {
+			if _, ok := shadow.hashes[tx.Hash()]; ok {
+				sealed.SharedTxs++
+			}
+		}
+		shadow.Sealed = sealed
+		s.blocks[i] = &shadow
+
+		shadowBuildTimer.Update(shadow.elapsed)
+		if shadow.Late {
+			shadowLateMeter.Mark(1)
+		}
+		log.Info("Compared shadow block", "number", block.NumberU64(), "txs", shadow.Txs, "sealed", sealed.Txs,
+			"shared", sealed.SharedTxs, "gas", uint64(shadow.GasUsed), "sealedgas", block.GasUsed(),
+			"build", shadow.BuildTime, "late", shadow.Late)
+		return
+	}
+}
+
+// get returns the retained shadow blocks newest first, only those of the given
+// block number if not nil.
+func (s *shadowBlocks) get(number *uint64) []*ShadowBlock {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	blocks := make([]*ShadowBlock, 0)
+	for i := len(s.blocks) - 1; i >= 0; i-- {
+		if number == nil || uint64(s.blocks[i].Number) == *number {
+			blocks = append(blocks, s.blocks[i])
+		}
+	}
+	return blocks
+}
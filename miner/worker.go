@@ -27,6 +27,7 @@ import (
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core"
@@ -35,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/otel"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
@@ -97,6 +99,7 @@ type environment struct {
 	receipts []*types.Receipt
 
 	accessFilter vm.EvmAccessFilter
+	decisions    []*TxBuildDecision // decisions about the candidate transactions, in order
 }
 
 // task contains all information for consensus engine sealing and result submitting.
@@ -104,6 +107,7 @@ type task struct {
 	receipts  []*types.Receipt
 	state     *state.StateDB
 	block     *types.Block
+	decisions []*TxBuildDecision
 	createdAt time.Time
 }
 
@@ -165,6 +169,8 @@ type worker struct {
 	localUncles  map[common.Hash]*types.Block // A set of side blocks generated locally as the possible uncle blocks.
 	remoteUncles map[common.Hash]*types.Block // A set of side blocks as the possible uncle blocks.
 	unconfirmed  *unconfirmedBlocks           // A set of locally mined blocks pending canonicalness confirmations.
+	buildLogs    *buildLogs                   // Audit logs of the transaction inclusion in the last sealed blocks
+	shadows      *shadowBlocks                // Blocks assembled for the last slots while not sealing
 
 	mu       sync.RWMutex // The lock used to protect the coinbase and extra fields
 	coinbase common.Address
@@ -214,6 +220,8 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		localUncles:        make(map[common.Hash]*types.Block),
 		remoteUncles:       make(map[common.Hash]*types.Block),
 		unconfirmed:        newUnconfirmedBlocks(eth.BlockChain(), miningLogAtDepth),
+		buildLogs:          newBuildLogs(config.BuildLogBlocks),
+		shadows:            newShadowBlocks(config.ShadowBlocks),
 		pendingTasks:       make(map[common.Hash]*task),
 		txsCh:              make(chan core.NewTxsEvent, txChanSize),
 		chainHeadCh:        make(chan core.ChainHeadEvent, chainHeadChanSize),
@@ -510,7 +518,7 @@ func (w *worker) mainLoop() {
 						uncles = append(uncles, uncle.Header())
 						return false
 					})
-					w.commit(uncles, nil, true, start)
+					w.commit(nil, uncles, nil, true, start)
 				}
 			}
 
@@ -674,8 +682,11 @@ func (w *worker) resultLoop() {
 				log.Error("Failed writing block to chain", "err", err)
 				continue
 			}
-			log.Info("Successfully sealed new block", "number", block.Number(), "sealhash", sealhash, "hash", hash,
-				"elapsed", common.PrettyDuration(time.Since(task.createdAt)))
+			log.Info("Successfully sealed new block", log.EventKey, log.EventBlockSealed, "number", block.NumberU64(),
+				"hash", hash, "sealhash", sealhash, "txs", len(block.Transactions()), "elapsed", common.PrettyDuration(time.Since(task.createdAt)))
+
+			// Retain the inclusion decisions for the audit
+			w.buildLogs.add(&BlockBuildLog{Number: hexutil.Uint64(block.NumberU64()), Hash: hash, Txs: task.decisions})
 
 			// Broadcast the block and announce chain insertion event
 			w.mux.Post(core.NewMinedBlockEvent{Block: block})
@@ -831,11 +842,6 @@ func (w *worker) commitTransactions(txs *types.TransactionsByPriceAndNonce, coin
 			}
 			return atomic.LoadInt32(interrupt) == commitInterruptNewHead
 		}
-		// If we don't have enough gas for any further transactions then we're done
-		if w.current.gasPool.Gas() < params.TxGas {
-			log.Trace("Not enough gas for further transactions", "have", w.current.gasPool, "want", params.TxGas)
-			break
-		}
 		// Retrieve the next transaction and abort if all done
 		tx := txs.Peek()
 		if tx == nil {
@@ -846,10 +852,18 @@ func (w *worker) commitTransactions(txs *types.TransactionsByPriceAndNonce, coin
 		//
 		// We use the eip155 signer regardless of the current hf.
 		from, _ := types.Sender(w.current.signer, tx)
+
+		// If we don't have enough gas for any further transactions then we're done
+		if w.current.gasPool.Gas() < params.TxGas {
+			log.Trace("Not enough gas for further transactions", "have", w.current.gasPool, "want", params.TxGas)
+			w.decide(tx, from, TxSkipped, reasonGasExhausted)
+			break
+		}
 		// Check whether the tx is replay protected. If we're not in the EIP155 hf
 		// phase, start ignoring the sender until we do.
 		if tx.Protected() && !w.chainConfig.IsEIP155(w.current.header.Number) {
 			log.Trace("Ignoring reply protected transaction", "hash", tx.Hash(), "eip155", w.chainConfig.EIP155Block)
+			w.decide(tx, from, TxSkipped, reasonReplayable)
 
 			txs.Pop()
 			continue
@@ -860,6 +874,7 @@ func (w *worker) commitTransactions(txs *types.TransactionsByPriceAndNonce, coin
 			err := w.turboEngine.FilterTx(from, tx, w.current.header, w.current.state)
 			if err != nil {
 				log.Trace("Ignoring consensus invalid transaction", "hash", tx.Hash().String(), "from", from.String(), "to", tx.To(), "err", err)
+				w.decide(tx, from, TxSkipped, reasonAccessDenied+err.Error())
 				txs.Pop()
 				continue
 			}
@@ -873,33 +888,39 @@ func (w *worker) commitTransactions(txs *types.TransactionsByPriceAndNonce, coin
 		case errors.Is(err, core.ErrGasLimitReached):
 			// Pop the current out-of-gas transaction without shifting in the next from the account
 			log.Trace("Gas limit exceeded for current block", "sender", from)
+			w.decide(tx, from, TxSkipped, reasonGasLimit)
 			txs.Pop()
 
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "sender", from, "nonce", tx.Nonce())
+			w.decide(tx, from, TxDropped, reasonNonceTooLow)
 			txs.Shift()
 
 		case errors.Is(err, core.ErrNonceTooHigh):
 			// Reorg notification data race between the transaction pool and miner, skip account =
 			log.Trace("Skipping account with hight nonce", "sender", from, "nonce", tx.Nonce())
+			w.decide(tx, from, TxSkipped, reasonNonceTooHigh)
 			txs.Pop()
 
 		case errors.Is(err, nil):
 			// Everything ok, collect the logs and shift in the next transaction from the same account
 			coalescedLogs = append(coalescedLogs, logs...)
 			w.current.tcount++
+			w.decide(tx, from, TxIncluded, "")
 			txs.Shift()
 
 		case errors.Is(err, core.ErrTxTypeNotSupported):
 			// Pop the unsupported transaction without shifting in the next from the account
 			log.Trace("Skipping unsupported transaction type", "sender", from, "type", tx.Type())
+			w.decide(tx, from, TxSkipped, reasonUnsupported)
 			txs.Pop()
 
 		default:
 			// Strange error, discard the transaction and get the next in line (note, the
 			// nonce-too-high clause will prevent us from executing in vain).
 			log.Debug("Transaction failed, account skipped", "hash", tx.Hash(), "err", err)
+			w.decide(tx, from, TxDropped, reasonExecutionError+err.Error())
 			txs.Shift()
 		}
 	}
@@ -927,6 +948,26 @@ func (w *worker) commitTransactions(txs *types.TransactionsByPriceAndNonce, coin
 	return false
 }
 
+// decide records the decision about a candidate transaction of the current
+// block.
+func (w *worker) decide(tx *types.Transaction, from common.Address, decision, reason string) {
+	w.current.decisions = append(w.current.decisions, newTxBuildDecision(tx, from, decision, reason))
+}
+
+// decideUnderpriced records the pending transactions not paying the base fee,
+// which are left out of the current block along with the next ones of their
+// senders.
+func (w *worker) decideUnderpriced(pending map[common.Address]types.Transactions, baseFee *big.Int) {
+	for from, txs := range pending {
+		for _, tx := range txs {
+			if tx.GasFeeCapIntCmp(baseFee) < 0 {
+				w.decide(tx, from, TxSkipped, reasonFeeTooLow)
+				break
+			}
+		}
+	}
+}
+
 // commitNewWork generates several new sealing tasks based on the parent block.
 func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64) {
 	w.mu.RLock()
@@ -935,6 +976,13 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 	tstart := time.Now()
 	parent := w.chain.CurrentBlock()
 
+	// In shadow mode, compare the block sealed for the last slot with ours
+	if w.shadows.enabled() && !w.isRunning() {
+		if block := w.chain.GetBlock(parent.Hash(), parent.Number.Uint64()); block != nil {
+			w.shadows.seal(block)
+		}
+	}
+
 	if parent.Time >= uint64(timestamp) {
 		timestamp = int64(parent.Time + 1)
 	}
@@ -974,8 +1022,13 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 		}
 	}
 
+	// Trace the block building, the sealing is done asynchronously
+	span := otel.StartAt(nil, "block.build", tstart, otel.Uint64("block.number", header.Number.Uint64()))
+	defer span.End()
+
 	if err := w.engine.Prepare(w.chain, header); err != nil {
 		log.Error("Failed to prepare header for mining", "err", err)
+		span.RecordError(err)
 		return
 	}
 
@@ -983,6 +1036,7 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 	err := w.makeCurrent(parent, header)
 	if err != nil {
 		log.Error("Failed to create mining context", "err", err)
+		span.RecordError(err)
 		return
 	}
 	// Create the current work task and check any fork transitions needed
@@ -990,10 +1044,13 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 	if w.isTurboEngine {
 		if err := w.turboEngine.PreHandle(w.chain, header, env.state); err != nil {
 			log.Error("Failed to apply system contract upgrade", "err", err)
+			span.RecordError(err)
 			return
 		}
 		env.accessFilter = w.turboEngine.CreateEvmAccessFilter(header, env.state)
 	}
+	otel.StartAt(span, "block.prepare", tstart).End()
+
 	// Accumulate the uncles for the current block
 	uncles := make([]*types.Header, 0, 2)
 	commitUncles := func(blocks map[common.Hash]*types.Block) {
@@ -1022,10 +1079,13 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 	// Create an empty block based on temporary copied state for
 	// sealing in advance without waiting block execution finished.
 	if !noempty && atomic.LoadUint32(&w.noempty) == 0 {
-		w.commit(uncles, nil, false, tstart)
+		w.commit(span, uncles, nil, false, tstart)
 	}
 
 	// Fill the block with all available pending transactions.
+	fill := span.Child("block.fill")
+	defer fill.End()
+
 	pendingFilter := txpool.PendingFilter{MinTip: uint256.MustFromBig(w.eth.TxPool().GasTip())}
 	pendingLazy := w.eth.TxPool().Pending(pendingFilter)
 	var pending map[common.Address]types.Transactions = make(map[common.Address]types.Transactions)
@@ -1045,6 +1105,11 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 		w.updateSnapshot()
 		return
 	}
+	// The transactions paying less than the base fee are left out along with
+	// the next ones of their senders when ordered by price
+	if header.BaseFee != nil {
+		w.decideUnderpriced(pending, header.BaseFee)
+	}
 	// Split the pending transactions into locals and remotes
 	localTxs, remoteTxs := make(map[common.Address]types.Transactions), pending
 	for _, account := range w.eth.TxPool().Locals() {
@@ -1056,37 +1121,51 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 	if len(localTxs) > 0 {
 		txs := types.NewTransactionsByPriceAndNonce(w.current.signer, localTxs, header.BaseFee)
 		if w.commitTransactions(txs, w.coinbase, interrupt) {
+			fill.SetAttributes(otel.Bool("interrupted", true))
 			return
 		}
 	}
 	if len(remoteTxs) > 0 {
 		txs := types.NewTransactionsByPriceAndNonce(w.current.signer, remoteTxs, header.BaseFee)
 		if w.commitTransactions(txs, w.coinbase, interrupt) {
+			fill.SetAttributes(otel.Bool("interrupted", true))
 			return
 		}
 	}
-	w.commit(uncles, w.fullTaskHook, true, tstart)
+	fill.SetAttributes(otel.Int("txs", w.current.tcount))
+	fill.End()
+
+	w.commit(span, uncles, w.fullTaskHook, true, tstart)
 }
 
 // commit runs any post-transaction state modifications, assembles the final block
-// and commits new work if consensus engine is running.
-func (w *worker) commit(uncles []*types.Header, interval func(), update bool, start time.Time) error {
+// and commits new work if consensus engine is running. The assembly is traced as
+// a child of the given block building span.
+func (w *worker) commit(span *otel.Span, uncles []*types.Header, interval func(), update bool, start time.Time) error {
 	// Deep copy receipts here to avoid interaction between different tasks.
 	cpyReceipts := copyReceipts(w.current.receipts)
 	// copy transactions to a new slice to avoid interaction between different tasks.
 	txs := make([]*types.Transaction, len(w.current.txs))
 	copy(txs, w.current.txs)
+	decisions := make([]*TxBuildDecision, len(w.current.decisions))
+	copy(decisions, w.current.decisions)
 	s := w.current.state.Copy()
+	assemble := span.Child("block.assemble", otel.Int("txs", len(txs)))
 	block, receipts, err := w.engine.FinalizeAndAssemble(w.chain, w.current.header, s, &types.Body{Transactions: txs}, cpyReceipts)
+	assemble.RecordError(err)
+	assemble.End()
 	if err != nil {
 		return err
 	}
+	if w.shadows.enabled() && !w.isRunning() {
+		w.shadows.add(block, receipts, time.Since(start))
+	}
 	if w.isRunning() {
 		if interval != nil {
 			interval()
 		}
 		select {
-		case w.taskCh <- &task{receipts: receipts, state: s, block: block, createdAt: time.Now()}:
+		case w.taskCh <- &task{receipts: receipts, state: s, block: block, decisions: decisions, createdAt: time.Now()}:
 			w.unconfirmed.Shift(block.NumberU64() - 1)
 			log.Info("Commit new mining work", "number", block.Number(), "sealhash", w.engine.SealHash(block.Header()),
 				"uncles", len(uncles), "txs", w.current.tcount,
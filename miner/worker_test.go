@@ -17,14 +17,17 @@
 package miner
 
 import (
+	"crypto/ecdsa"
 	"crypto/rand"
 	"math/big"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
@@ -70,8 +73,9 @@ var (
 	newTxs     []*types.Transaction
 
 	testConfig = &Config{
-		Recommit: time.Second,
-		GasCeil:  params.GenesisGasLimit,
+		Recommit:       time.Second,
+		GasCeil:        params.GenesisGasLimit,
+		BuildLogBlocks: 8,
 	}
 )
 
@@ -257,6 +261,24 @@ func testGenerateBlockAndImport(t *testing.T, isClique bool) {
 			if _, err := chain.InsertChain([]*types.Block{block}); err != nil {
 				t.Fatalf("failed to insert new mined block %d: %v", block.NumberU64(), err)
 			}
+			// The inclusion decisions of the sealed block are retained
+			number := block.NumberU64()
+			logs := w.buildLogs.get(&number)
+			if len(logs) != 1 || logs[0].Hash != block.Hash() {
+				t.Fatalf("block %d: missing build log", number)
+			}
+			var included int
+			for _, decision := range logs[0].Txs {
+				if decision.Decision == TxIncluded {
+					if tx := block.Transaction(decision.Hash); tx == nil {
+						t.Errorf("block %d: included transaction %x missing", number, decision.Hash)
+					}
+					included++
+				}
+			}
+			if included != len(block.Transactions()) {
+				t.Errorf("block %d: included transaction count mismatch: have %d, want %d", number, included, len(block.Transactions()))
+			}
 		case <-time.After(3 * time.Second): // Worker needs 1s to include new changes.
 			t.Fatalf("timeout")
 		}
@@ -519,3 +541,135 @@ func testAdjustInterval(t *testing.T, chainConfig *params.ChainConfig, engine co
 		t.Error("interval reset timeout")
 	}
 }
+
+// Tests that the reasons for leaving the candidate transactions out of a block
+// are recorded.
+func TestBuildDecisions(t *testing.T) {
+	// Create the worker without pending transactions, the pool events would
+	// apply them to the pending block concurrently
+	b := newTestWorkerBackend(t, ethashChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	w := newWorker(testConfig, ethashChainConfig, ethash.NewFaker(), b, new(event.TypeMux), nil, false)
+	defer w.close()
+
+	var (
+		signer   = types.LatestSigner(ethashChainConfig)
+		parent   = b.chain.CurrentBlock()
+		baseFee  = big.NewInt(params.InitialBaseFee)
+		gasPrice = new(big.Int).Mul(baseFee, common.Big2)
+		newTx    = func(key *ecdsa.PrivateKey, nonce uint64, price *big.Int) *types.Transaction {
+			return types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: nonce, To: &testUserAddress, Value: big.NewInt(1), Gas: params.TxGas, GasPrice: price})
+		}
+	)
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		GasLimit:   params.GenesisGasLimit,
+		BaseFee:    baseFee,
+		Time:       parent.Time + 1,
+		Difficulty: common.Big1,
+	}
+	if err := w.makeCurrent(parent, header); err != nil {
+		t.Fatalf("failed to create mining context: %v", err)
+	}
+	// The bank has a nonce gap and an underpriced transaction, the unfunded
+	// user can't pay for its transaction
+	pending := map[common.Address]types.Transactions{
+		testBankAddress: {newTx(testBankKey, 0, gasPrice), newTx(testBankKey, 1, gasPrice), newTx(testBankKey, 3, gasPrice), newTx(testBankKey, 4, common.Big1)},
+		testUserAddress: {newTx(testUserKey, 0, gasPrice)},
+	}
+	want := map[common.Hash][2]string{
+		pending[testBankAddress][0].Hash(): {TxIncluded, ""},
+		pending[testBankAddress][1].Hash(): {TxIncluded, ""},
+		pending[testBankAddress][2].Hash(): {TxSkipped, reasonNonceTooHigh},
+		pending[testBankAddress][3].Hash(): {TxSkipped, reasonFeeTooLow},
+		pending[testUserAddress][0].Hash(): {TxDropped, reasonExecutionError},
+	}
+	w.decideUnderpriced(pending, baseFee)
+	w.commitTransactions(types.NewTransactionsByPriceAndNonce(signer, pending, baseFee), testBankAddress, nil)
+
+	if len(w.current.decisions) != len(want) {
+		t.Fatalf("decision count mismatch: have %d, want %d", len(w.current.decisions), len(want))
+	}
+	for _, decision := range w.current.decisions {
+		expect, ok := want[decision.Hash]
+		if !ok {
+			t.Fatalf("unexpected decision about %x", decision.Hash)
+		}
+		if decision.Decision != expect[0] || !strings.HasPrefix(decision.Reason, expect[1]) || (expect[1] == "" && decision.Reason != "") {
+			t.Errorf("transaction %d of %x: decision mismatch: have %s (%s), want %s (%s)", decision.Nonce, decision.From, decision.Decision, decision.Reason, expect[0], expect[1])
+		}
+	}
+}
+
+func TestBuildLogsEviction(t *testing.T) {
+	logs := newBuildLogs(2)
+	for i := 0; i < 3; i++ {
+		logs.add(&BlockBuildLog{Number: hexutil.Uint64(i)})
+	}
+	all := logs.get(nil)
+	if len(all) != 2 || all[0].Number != 2 || all[1].Number != 1 {
+		t.Fatalf("retained logs mismatch: have %v", all)
+	}
+	number := uint64(0)
+	if evicted := logs.get(&number); len(evicted) != 0 {
+		t.Errorf("evicted log retained")
+	}
+	disabled := newBuildLogs(0)
+	disabled.add(&BlockBuildLog{})
+	if len(disabled.get(nil)) != 0 {
+		t.Errorf("log retained while disabled")
+	}
+}
+
+func TestShadowBlocks(t *testing.T) {
+	var (
+		shadows = newShadowBlocks(2)
+		parent  = common.Hash{0x01}
+		slot    = uint64(time.Now().Add(time.Minute).Unix())
+	)
+	block := func(number uint64, parent common.Hash, txs ...*types.Transaction) *types.Block {
+		header := &types.Header{Number: new(big.Int).SetUint64(number), ParentHash: parent, Time: slot, GasUsed: params.TxGas * uint64(len(txs))}
+		return types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs})
+	}
+	receipts := func(block *types.Block) []*types.Receipt {
+		receipts := make([]*types.Receipt, len(block.Transactions()))
+		for i := range receipts {
+			receipts[i] = &types.Receipt{GasUsed: params.TxGas}
+		}
+		return receipts
+	}
+	// The full block assembled for a slot replaces the empty one
+	empty := block(1, parent)
+	shadows.add(empty, receipts(empty), time.Millisecond)
+	full := block(1, parent, pendingTxs[0], newTxs[0])
+	shadows.add(full, receipts(full), 2*time.Millisecond)
+
+	number := uint64(1)
+	blocks := shadows.get(&number)
+	if len(blocks) != 1 || blocks[0].Txs != 2 || blocks[0].Late {
+		t.Fatalf("shadow block mismatch: have %+v", blocks)
+	}
+	// Blocks sealed on other parents are not compared
+	shadows.seal(block(1, common.Hash{0x02}, pendingTxs[0]))
+	if blocks = shadows.get(&number); blocks[0].Sealed != nil {
+		t.Fatalf("compared block sealed on another parent")
+	}
+	sealed := block(1, parent, pendingTxs[0])
+	shadows.seal(sealed)
+	if have := shadows.get(&number)[0].Sealed; have == nil || have.Hash != sealed.Hash() || have.Txs != 1 || have.SharedTxs != 1 {
+		t.Fatalf("sealed block mismatch: have %+v", have)
+	}
+	// The oldest slots are evicted
+	for n := uint64(2); n <= 3; n++ {
+		next := block(n, common.Hash{byte(n)})
+		shadows.add(next, receipts(next), time.Millisecond)
+	}
+	if all := shadows.get(nil); len(all) != 2 || all[0].Number != 3 || all[1].Number != 2 {
+		t.Fatalf("retained shadow blocks mismatch: have %v", all)
+	}
+	disabled := newShadowBlocks(0)
+	disabled.add(full, receipts(full), time.Millisecond)
+	if len(disabled.get(nil)) != 0 {
+		t.Errorf("shadow block retained while disabled")
+	}
+}
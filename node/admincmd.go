@@ -0,0 +1,223 @@
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// PrivilegedMethods are the dangerous methods only served by the admin command
+// channel once enabled: rewinding the chain, accessing the chain database and
+// managing the validator keys and stopping the sealing.
+var PrivilegedMethods = []string{
+	"debug_setHead",
+	"debug_chaindbCompact",
+	"debug_chaindbProperty",
+	"debug_dbGet",
+	"debug_dbAncient",
+	"debug_dbAncients",
+	"blskey_newKey",
+	"blskey_importKey",
+	"blskey_unlockKey",
+	"blskey_lockKey",
+	"blskey_sign",
+	"turbo_stopSealing",
+	"turbo_resumeSealing",
+}
+
+// secretParamMethods are the privileged methods taking passwords, whose
+// parameters are never recorded.
+var secretParamMethods = map[string]bool{
+	"blskey_newKey":    true,
+	"blskey_importKey": true,
+	"blskey_unlockKey": true,
+}
+
+// Stages of the calls recorded in the audit log.
+const (
+	auditIssued   = "issued"
+	auditAnswered = "answered"
+)
+
+// auditEntry is a line of the audit log.
+type auditEntry struct {
+	Time   time.Time       `json:"time"`
+	ID     uint64          `json:"id"` // Identifier of the call, shared by its entries
+	Stage  string          `json:"stage"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+	PID    int32           `json:"pid"`
+	UID    uint32          `json:"uid"`
+	Result string          `json:"result,omitempty"` // "ok" or the error of the answered calls
+}
+
+// auditLog records the calls of the admin command channel, appending an entry
+// to the log file when a call is issued and when it is answered. The calls of
+// unidentified processes and the ones failing to be recorded are refused.
+type auditLog struct {
+	log  log.Logger
+	lock sync.Mutex
+	file *os.File
+	last uint64 // Identifier of the last call recorded
+}
+
+// openAuditLog opens the audit log for appending, resuming the call identifiers
+// after the last one recorded by a previous run.
+func openAuditLog(logger log.Logger, path string) (*auditLog, error) {
+	last, partial, err := lastAuditID(path)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	// Terminate a partial entry left by a crash, keeping the new ones apart
+	if partial {
+		if _, err := file.Write([]byte{'\n'}); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return &auditLog{log: logger, file: file, last: last}, nil
+}
+
+// lastAuditID returns the highest call identifier recorded in the audit log, or
+// zero if it doesn't exist yet, and whether the log ends with a partial entry
+// left by a crash. The lines which can't be decoded are skipped.
+func lastAuditID(path string) (uint64, bool, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	defer file.Close()
+
+	var (
+		last   uint64
+		reader = bufio.NewReader(file)
+	)
+	for {
+		line, err := reader.ReadBytes('\n')
+		var entry auditEntry
+		if json.Unmarshal(line, &entry) == nil {
+			last = max(last, entry.ID)
+		}
+		if errors.Is(err, io.EOF) {
+			return last, len(line) > 0, nil
+		}
+		if err != nil {
+			return 0, false, err
+		}
+	}
+}
+
+// Audit implements rpc.Auditor, recording the issued call.
+func (a *auditLog) Audit(info rpc.PeerInfo, method string, params json.RawMessage) (func(err error), error) {
+	if info.Cred == nil {
+		return nil, errors.New("unidentified issuer")
+	}
+	if secretParamMethods[method] {
+		params = json.RawMessage(`"[redacted]"`)
+	}
+	a.lock.Lock()
+	a.last++
+	issued := &auditEntry{
+		Time:   time.Now(),
+		ID:     a.last,
+		Stage:  auditIssued,
+		Method: method,
+		Params: params,
+		PID:    info.Cred.PID,
+		UID:    info.Cred.UID,
+	}
+	err := a.write(issued)
+	a.lock.Unlock()
+
+	if err != nil {
+		a.log.Error("Failed to record admin command", "method", method, "pid", issued.PID, "uid", issued.UID, "err", err)
+		return nil, err
+	}
+	a.log.Info("Admin command issued", "id", issued.ID, "method", method, "pid", issued.PID, "uid", issued.UID)
+
+	return func(callErr error) {
+		result := "ok"
+		if callErr != nil {
+			result = callErr.Error()
+		}
+		answered := &auditEntry{
+			Time:   time.Now(),
+			ID:     issued.ID,
+			Stage:  auditAnswered,
+			Method: method,
+			PID:    issued.PID,
+			UID:    issued.UID,
+			Result: result,
+		}
+		a.lock.Lock()
+		err := a.write(answered)
+		a.lock.Unlock()
+
+		if err != nil {
+			a.log.Error("Failed to record admin command result", "id", issued.ID, "method", method, "result", result, "err", err)
+			return
+		}
+		a.log.Info("Admin command answered", "id", issued.ID, "method", method, "result", result)
+	}, nil
+}
+
+// write appends the entry to the log file, synced to disk.
+func (a *auditLog) write(entry *auditEntry) error {
+	blob, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := a.file.Write(append(blob, '\n')); err != nil {
+		return err
+	}
+	return a.file.Sync()
+}
+
+func (a *auditLog) close() error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.file.Close()
+}
+
+// startAdminIPC opens the audit log and starts the admin command channel.
+func (n *Node) startAdminIPC(apis []rpc.API) error {
+	path := n.config.ResolvePath(datadirAdminAuditLog)
+	if path == "" {
+		return errors.New("the admin command channel requires a data directory")
+	}
+	audit, err := openAuditLog(n.log, path)
+	if err != nil {
+		return err
+	}
+	n.adminIPC.auditor = audit
+	if err := n.adminIPC.start(apis); err != nil {
+		audit.close()
+		return err
+	}
+	n.audit = audit
+	return nil
+}
+
+// stopAdminIPC stops the admin command channel and closes the audit log.
+func (n *Node) stopAdminIPC() {
+	n.adminIPC.stop()
+	if n.audit != nil {
+		n.audit.close()
+		n.audit = nil
+	}
+}
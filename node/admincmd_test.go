@@ -0,0 +1,185 @@
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type adminTestService struct {
+	head uint64
+}
+
+func (s *adminTestService) SetHead(number hexutil.Uint64) { s.head = uint64(number) }
+
+func (s *adminTestService) DbGet(key string) (hexutil.Bytes, error) {
+	return nil, errors.New("not found")
+}
+
+func (s *adminTestService) Ping() string { return "pong" }
+
+func TestAdminCommandChannel(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("peer credentials are only supported on Linux")
+	}
+	stack, err := New(&Config{DataDir: t.TempDir(), IPCPath: "test.ipc", AdminIPCPath: "admin.ipc"})
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	defer stack.Close()
+
+	service := new(adminTestService)
+	stack.RegisterAPIs([]rpc.API{{Namespace: "debug", Service: service}})
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	client, err := rpc.Dial(stack.IPCEndpoint())
+	if err != nil {
+		t.Fatalf("failed to dial IPC: %v", err)
+	}
+	defer client.Close()
+	admin, err := rpc.Dial(stack.config.AdminIPCEndpoint())
+	if err != nil {
+		t.Fatalf("failed to dial admin IPC: %v", err)
+	}
+	defer admin.Close()
+
+	// The privileged methods are refused by the other endpoints
+	if err := client.Call(nil, "debug_setHead", hexutil.Uint64(10)); err == nil {
+		t.Fatal("privileged method served by the IPC endpoint")
+	}
+	var pong string
+	if err := client.Call(&pong, "debug_ping"); err != nil || pong != "pong" {
+		t.Fatalf("unprivileged method failed: %v", err)
+	}
+	// The admin channel serves them, recording the calls
+	if err := admin.Call(nil, "debug_setHead", hexutil.Uint64(10)); err != nil {
+		t.Fatalf("failed to set head: %v", err)
+	}
+	if service.head != 10 {
+		t.Fatalf("head mismatch: have %d, want 10", service.head)
+	}
+	if err := admin.Call(nil, "debug_dbGet", "0x01"); err == nil {
+		t.Fatal("no error for failing call")
+	}
+	file, err := os.Open(stack.ResolvePath(datadirAdminAuditLog))
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	var entries []*auditEntry
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		entry := new(auditEntry)
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			t.Fatalf("invalid audit entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	want := []struct {
+		id     uint64
+		stage  string
+		method string
+		result string
+	}{
+		{1, auditIssued, "debug_setHead", ""},
+		{1, auditAnswered, "debug_setHead", "ok"},
+		{2, auditIssued, "debug_dbGet", ""},
+		{2, auditAnswered, "debug_dbGet", "not found"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("audit entry count mismatch: have %d, want %d", len(entries), len(want))
+	}
+	for i, entry := range entries {
+		if entry.ID != want[i].id || entry.Stage != want[i].stage || entry.Method != want[i].method || entry.Result != want[i].result {
+			t.Errorf("entry %d mismatch: %+v", i, entry)
+		}
+		if entry.PID != int32(os.Getpid()) || entry.UID != uint32(os.Getuid()) {
+			t.Errorf("entry %d: issuer mismatch: pid %d, uid %d", i, entry.PID, entry.UID)
+		}
+	}
+	if string(entries[0].Params) != `["0xa"]` {
+		t.Errorf("params mismatch: have %s", entries[0].Params)
+	}
+}
+
+func TestAuditLogRedaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	audit, err := openAuditLog(log.Root(), path)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer audit.close()
+
+	// Unidentified issuers are refused
+	if _, err := audit.Audit(rpc.PeerInfo{Transport: "ipc"}, "debug_setHead", nil); err == nil {
+		t.Fatal("call of unidentified issuer recorded")
+	}
+	// The passwords aren't recorded
+	info := rpc.PeerInfo{Transport: "ipc", Cred: &rpc.PeerCred{PID: 1, UID: 2}}
+	done, err := audit.Audit(info, "blskey_unlockKey", json.RawMessage(`["0x01","s3cr3t"]`))
+	if err != nil {
+		t.Fatalf("failed to record call: %v", err)
+	}
+	done(nil)
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if strings.Contains(string(blob), "s3cr3t") || !strings.Contains(string(blob), `"params":"[redacted]"`) {
+		t.Fatalf("password not redacted: %s", blob)
+	}
+}
+
+// Tests that the call identifiers keep increasing across restarts, skipping a
+// partial entry left by a crash.
+func TestAuditLogResume(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "audit.log")
+		info = rpc.PeerInfo{Transport: "ipc", Cred: &rpc.PeerCred{PID: 1, UID: 2}}
+	)
+	for run, first := range []uint64{1, 3, 5} {
+		audit, err := openAuditLog(log.Root(), path)
+		if err != nil {
+			t.Fatalf("run %d: failed to open audit log: %v", run, err)
+		}
+		for i := uint64(0); i < 2; i++ {
+			done, err := audit.Audit(info, "debug_setHead", nil)
+			if err != nil {
+				t.Fatalf("run %d: failed to record call: %v", run, err)
+			}
+			done(nil)
+		}
+		if audit.last != first+1 {
+			t.Fatalf("run %d: last identifier mismatch: have %d, want %d", run, audit.last, first+1)
+		}
+		if _, err := audit.file.WriteString(`{"time":"2024-01-01T00:00:00Z","id":`); err != nil {
+			t.Fatalf("run %d: failed to write partial entry: %v", run, err)
+		}
+		audit.close()
+	}
+	// Every complete entry is on its own line
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	var ids []uint64
+	for _, line := range strings.Split(string(blob), "\n") {
+		var entry auditEntry
+		if json.Unmarshal([]byte(line), &entry) == nil && entry.Stage == auditIssued {
+			ids = append(ids, entry.ID)
+		}
+	}
+	if len(ids) != 6 || ids[0] != 1 || ids[5] != 6 {
+		t.Fatalf("issued identifiers mismatch: have %v, want [1 2 3 4 5 6]", ids)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/debug"
+	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/discover"
@@ -181,6 +182,8 @@ func (api *adminAPI) StartHTTP(host *string, port *int, cors *string, apis *stri
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			usage:                  api.node.rpcUsage,
+			usageHeader:            api.node.config.RPCUsageHeader,
 		},
 	}
 	if cors != nil {
@@ -259,6 +262,8 @@ func (api *adminAPI) StartWS(host *string, port *int, allowedOrigins *string, ap
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			usage:                  api.node.rpcUsage,
+			usageHeader:            api.node.config.RPCUsageHeader,
 		},
 	}
 	if apis != nil {
@@ -307,14 +312,40 @@ func (api *adminAPI) Peers() ([]*p2p.PeerInfo, error) {
 	return server.PeersInfo(), nil
 }
 
+// NodeInfo is the information known about the host node, along with the
+// provenance of its build.
+type NodeInfo struct {
+	*p2p.NodeInfo
+	Build version.Provenance `json:"build"`
+}
+
 // NodeInfo retrieves all the information we know about the host node at the
 // protocol granularity.
-func (api *adminAPI) NodeInfo() (*p2p.NodeInfo, error) {
+func (api *adminAPI) NodeInfo() (*NodeInfo, error) {
 	server := api.node.Server()
 	if server == nil {
 		return nil, ErrNodeStopped
 	}
-	return server.NodeInfo(), nil
+	return &NodeInfo{NodeInfo: server.NodeInfo(), Build: version.BuildProvenance()}, nil
+}
+
+// NatStatus reports the state of the NAT traversal: the discovered external IP,
+// the port mappings and the result of probing the advertised endpoint.
+func (api *adminAPI) NatStatus() (*p2p.NATStatus, error) {
+	server := api.node.Server()
+	if server == nil {
+		return nil, ErrNodeStopped
+	}
+	return server.NATStatus(), nil
+}
+
+// RPCUsage returns the accounted usage of the HTTP and WebSocket endpoints per
+// consumer, the heaviest consumers by compute time first.
+func (api *adminAPI) RPCUsage() ([]*rpc.ConsumerUsage, error) {
+	if api.node.rpcUsage == nil {
+		return nil, ErrUsageDisabled
+	}
+	return api.node.rpcUsage.Usage(), nil
 }
 
 // Datadir retrieves the current data directory the node is using.
@@ -332,6 +363,12 @@ func (s *web3API) ClientVersion() string {
 	return s.stack.Server().Name
 }
 
+// ClientProvenance returns the provenance of the node's build, extending the
+// client version with what's needed to verify the build is an official one.
+func (s *web3API) ClientProvenance() version.Provenance {
+	return version.BuildProvenance()
+}
+
 // Sha3 applies the ethereum sha3 implementation on the input.
 // It assumes the input is hex encoded.
 func (s *web3API) Sha3(input hexutil.Bytes) hexutil.Bytes {
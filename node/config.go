@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -39,6 +40,7 @@ const (
 	datadirStaticNodes     = "static-nodes.json"  // Path within the datadir to the static node list
 	datadirTrustedNodes    = "trusted-nodes.json" // Path within the datadir to the trusted node list
 	datadirNodeDatabase    = "nodes"              // Path within the datadir to store the node infos
+	datadirAdminAuditLog   = "admin-audit.log"    // Path within the datadir to the audit log of the admin command channel
 )
 
 // Config represents a small collection of configuration values to fine tune the
@@ -79,6 +81,14 @@ type Config struct {
 	// ExternalSigner specifies an external URI for a clef-type signer.
 	ExternalSigner string `toml:",omitempty"`
 
+	// KMSKeys lists the keys held by key management services to sign with, as
+	// awskms://<region>/<key id> or gcpkms://<key version resource name>.
+	KMSKeys []string `toml:",omitempty"`
+
+	// KMSTimeout is the latency budget of the requests to the key management
+	// services. Zero means the default budget.
+	KMSTimeout time.Duration `toml:",omitempty"`
+
 	// UseLightweightKDF lowers the memory and CPU requirements of the key store
 	// scrypt KDF at the expense of security.
 	UseLightweightKDF bool `toml:",omitempty"`
@@ -102,6 +112,13 @@ type Config struct {
 	// relative), then that specific path is enforced. An empty path disables IPC.
 	IPCPath string
 
+	// AdminIPCPath is the location of the admin command channel, an IPC endpoint
+	// serving the privileged methods, which the other endpoints no longer serve.
+	// Every call is recorded in the audit log of the data directory along with
+	// the credentials of the issuing process. The path is resolved like IPCPath,
+	// an empty path disables the channel.
+	AdminIPCPath string `toml:",omitempty"`
+
 	// HTTPHost is the host interface on which to start the HTTP RPC server. If this
 	// field is empty, no HTTP API endpoint will be started.
 	HTTPHost string
@@ -176,6 +193,10 @@ type Config struct {
 	// private APIs to untrusted users is a major security risk.
 	WSExposeAll bool `toml:",omitempty"`
 
+	// WSCompression enables the permessage-deflate compression of the large
+	// messages sent to the websocket clients supporting it.
+	WSCompression bool `toml:",omitempty"`
+
 	// GraphQLCors is the Cross-Origin Resource Sharing header to send to requesting
 	// clients. Please be aware that CORS is a browser enforced security, it's fully
 	// useless for custom HTTP clients.
@@ -190,6 +211,13 @@ type Config struct {
 	// Requests using ip address directly are not affected
 	GraphQLVirtualHosts []string `toml:",omitempty"`
 
+	// GRPCHost is the host interface on which to start the gRPC server. If this
+	// field is empty, no gRPC API endpoint will be started.
+	GRPCHost string
+
+	// GRPCPort is the TCP port number on which to start the gRPC server.
+	GRPCPort int `toml:",omitempty"`
+
 	// Logger is a custom logger to use with the p2p.Server.
 	Logger log.Logger `toml:",omitempty"`
 
@@ -204,6 +232,35 @@ type Config struct {
 	// BatchResponseMaxSize is the maximum number of bytes returned from a batched rpc call.
 	BatchResponseMaxSize int `toml:",omitempty"`
 
+	// RPCUsageConsumers is the number of most recently active consumers whose
+	// usage of the HTTP and WebSocket endpoints is accounted. Zero disables the
+	// usage accounting.
+	RPCUsageConsumers int `toml:",omitempty"`
+
+	// RPCUsageHeader is the HTTP header identifying RPC consumers (e.g. an API key)
+	// for usage accounting. Authenticated consumers are identified by their
+	// principal, the other ones not sending it by IP address.
+	RPCUsageHeader string `toml:",omitempty"`
+
+	// RPCAuthJWTSecret is the path to the hex-encoded secret of the HS256 tokens
+	// authenticating the callers of the HTTP, WebSocket and GraphQL endpoints.
+	RPCAuthJWTSecret string `toml:",omitempty"`
+
+	// RPCAuthAPIKeys is the path to the file of the API keys authenticating the
+	// callers of the HTTP, WebSocket and GraphQL endpoints, one <name>:<key>
+	// pair per line.
+	RPCAuthAPIKeys string `toml:",omitempty"`
+
+	// RPCAuthPublicModules are the API namespaces available to unauthenticated
+	// callers if authentication is configured, "graphql" standing for the
+	// GraphQL endpoint. The other namespaces require authentication.
+	RPCAuthPublicModules []string `toml:",omitempty"`
+
+	// RPCEndpoints are additional HTTP endpoints, each exposing its own API
+	// namespaces, e.g. the public namespaces on one port and the debugging ones
+	// behind token authentication on another.
+	RPCEndpoints []RPCEndpointConfig `toml:",omitempty"`
+
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
@@ -211,31 +268,44 @@ type Config struct {
 	EnablePersonal bool `toml:"-"`
 
 	DBEngine string `toml:",omitempty"`
+
+	// DBProfile selects the tuning profile of the databases, see rawdb.DatabaseProfiles.
+	DBProfile string `toml:",omitempty"`
 }
 
 // IPCEndpoint resolves an IPC endpoint based on a configured value, taking into
 // account the set data folders as well as the designated platform we're currently
 // running on.
 func (c *Config) IPCEndpoint() string {
+	return c.resolveIPC(c.IPCPath)
+}
+
+// AdminIPCEndpoint resolves the endpoint of the admin command channel like the
+// IPC endpoint.
+func (c *Config) AdminIPCEndpoint() string {
+	return c.resolveIPC(c.AdminIPCPath)
+}
+
+func (c *Config) resolveIPC(path string) string {
 	// Short circuit if IPC has not been enabled
-	if c.IPCPath == "" {
+	if path == "" {
 		return ""
 	}
 	// On windows we can only use plain top-level pipes
 	if runtime.GOOS == "windows" {
-		if strings.HasPrefix(c.IPCPath, `\\.\pipe\`) {
-			return c.IPCPath
+		if strings.HasPrefix(path, `\\.\pipe\`) {
+			return path
 		}
-		return `\\.\pipe\` + c.IPCPath
+		return `\\.\pipe\` + path
 	}
 	// Resolve names into the data directory full paths otherwise
-	if filepath.Base(c.IPCPath) == c.IPCPath {
+	if filepath.Base(path) == path {
 		if c.DataDir == "" {
-			return filepath.Join(os.TempDir(), c.IPCPath)
+			return filepath.Join(os.TempDir(), path)
 		}
-		return filepath.Join(c.DataDir, c.IPCPath)
+		return filepath.Join(c.DataDir, path)
 	}
-	return c.IPCPath
+	return path
 }
 
 // NodeDB returns the path to the discovery node database.
@@ -267,6 +337,15 @@ func (c *Config) HTTPEndpoint() string {
 	return net.JoinHostPort(c.HTTPHost, fmt.Sprintf("%d", c.HTTPPort))
 }
 
+// GRPCEndpoint resolves the gRPC endpoint based on the configured host
+// interface and port parameters.
+func (c *Config) GRPCEndpoint() string {
+	if c.GRPCHost == "" {
+		return ""
+	}
+	return net.JoinHostPort(c.GRPCHost, fmt.Sprintf("%d", c.GRPCPort))
+}
+
 // DefaultHTTPEndpoint returns the HTTP endpoint used by default.
 func DefaultHTTPEndpoint() string {
 	config := &Config{HTTPHost: DefaultHTTPHost, HTTPPort: DefaultHTTPPort, AuthPort: DefaultAuthPort}
@@ -34,6 +34,8 @@ const (
 	DefaultWSPort   = 8546        // Default TCP port for the websocket RPC server
 	DefaultAuthHost = "localhost" // Default host interface for the authenticated apis
 	DefaultAuthPort = 8551        // Default port for the authenticated apis
+	DefaultGRPCHost = "localhost" // Default host interface for the gRPC server
+	DefaultGRPCPort = 8549        // Default TCP port for the gRPC server
 )
 
 const (
@@ -67,6 +69,7 @@ var DefaultConfig = Config{
 	BatchRequestLimit:    1000,
 	BatchResponseMaxSize: 25 * 1000 * 1000,
 	GraphQLVirtualHosts:  []string{"localhost"},
+	GRPCPort:             DefaultGRPCPort,
 	P2P: p2p.Config{
 		ListenAddr: ":30303",
 		MaxPeers:   50,
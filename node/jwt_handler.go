@@ -17,6 +17,7 @@
 package node
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -43,38 +44,50 @@ func newJWTHandler(secret []byte, next http.Handler) http.Handler {
 
 // ServeHTTP implements http.Handler
 func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
-	var (
-		strToken string
-		claims   jwt.RegisteredClaims
-	)
-	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
-		strToken = strings.TrimPrefix(auth, "Bearer ")
-	}
+	strToken := bearerToken(r)
 	if len(strToken) == 0 {
 		http.Error(out, "missing token", http.StatusUnauthorized)
 		return
 	}
+	if _, err := verifyJWT(strToken, handler.keyFunc); err != nil {
+		http.Error(out, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	handler.next.ServeHTTP(out, r)
+}
+
+// bearerToken returns the bearer token of the Authorization header, if any.
+func bearerToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return ""
+}
+
+// verifyJWT parses the token and checks its signature and its issuance time,
+// which must be within jwtExpiryTimeout of now.
+func verifyJWT(strToken string, keyFunc jwt.Keyfunc) (*jwt.RegisteredClaims, error) {
 	// We explicitly set only HS256 allowed, and also disables the
 	// claim-check: the RegisteredClaims internally requires 'iat' to
 	// be no later than 'now', but we allow for a bit of drift.
-	token, err := jwt.ParseWithClaims(strToken, &claims, handler.keyFunc,
+	var claims jwt.RegisteredClaims
+	token, err := jwt.ParseWithClaims(strToken, &claims, keyFunc,
 		jwt.WithValidMethods([]string{"HS256"}),
 		jwt.WithoutClaimsValidation())
 
 	switch {
 	case err != nil:
-		http.Error(out, err.Error(), http.StatusUnauthorized)
+		return nil, err
 	case !token.Valid:
-		http.Error(out, "invalid token", http.StatusUnauthorized)
+		return nil, errors.New("invalid token")
 	case !claims.VerifyExpiresAt(time.Now(), false): // optional
-		http.Error(out, "token is expired", http.StatusUnauthorized)
+		return nil, errors.New("token is expired")
 	case claims.IssuedAt == nil:
-		http.Error(out, "missing issued-at", http.StatusUnauthorized)
+		return nil, errors.New("missing issued-at")
 	case time.Since(claims.IssuedAt.Time) > jwtExpiryTimeout:
-		http.Error(out, "stale token", http.StatusUnauthorized)
+		return nil, errors.New("stale token")
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
-		http.Error(out, "future token", http.StatusUnauthorized)
-	default:
-		handler.next.ServeHTTP(out, r)
+		return nil, errors.New("future token")
 	}
+	return &claims, nil
 }
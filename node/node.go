@@ -57,14 +57,19 @@ type Node struct {
 	state         int           // Tracks state of node lifecycle
 
 	lock          sync.Mutex
-	lifecycles    []Lifecycle // All registered backends, services, and auxiliary services that have a lifecycle
-	rpcAPIs       []rpc.API   // List of APIs currently provided by the node
-	http          *httpServer //
-	ws            *httpServer //
-	httpAuth      *httpServer //
-	wsAuth        *httpServer //
-	ipc           *ipcServer  // Stores information about the ipc http server
-	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
+	lifecycles    []Lifecycle       // All registered backends, services, and auxiliary services that have a lifecycle
+	rpcAPIs       []rpc.API         // List of APIs currently provided by the node
+	http          *httpServer       //
+	ws            *httpServer       //
+	httpAuth      *httpServer       //
+	wsAuth        *httpServer       //
+	endpoints     []*httpServer     // Additional HTTP endpoints, as configured by RPCEndpoints
+	ipc           *ipcServer        // Stores information about the ipc http server
+	adminIPC      *ipcServer        // Admin command channel serving the privileged methods
+	audit         *auditLog         // Audit log of the admin command channel, nil if not running
+	inprocHandler *rpc.Server       // In-process RPC request handler to process the API requests
+	rpcUsage      *rpc.UsageTracker // Per-consumer usage accounting of the public endpoints, nil if disabled
+	rpcAuth       *rpcAuth          // Authentication of the public endpoints, nil if disabled
 
 	databases map[*closeTrackingDB]struct{} // All open databases
 }
@@ -114,6 +119,14 @@ func New(conf *Config) (*Node, error) {
 		server:        &p2p.Server{Config: conf.P2P},
 		databases:     make(map[*closeTrackingDB]struct{}),
 	}
+	if conf.RPCUsageConsumers > 0 {
+		node.rpcUsage = rpc.NewUsageTracker(conf.RPCUsageConsumers)
+	}
+	auth, err := newRPCAuth(conf)
+	if err != nil {
+		return nil, err
+	}
+	node.rpcAuth = auth
 
 	// Register built-in APIs.
 	node.rpcAPIs = append(node.rpcAPIs, node.apis()...)
@@ -148,13 +161,20 @@ func New(conf *Config) (*Node, error) {
 	if err := validatePrefix("WebSocket", conf.WSPathPrefix); err != nil {
 		return nil, err
 	}
+	if err := validateRPCEndpoints(conf.RPCEndpoints); err != nil {
+		return nil, err
+	}
 
 	// Configure RPC servers.
 	node.http = newHTTPServer(node.log, conf.HTTPTimeouts)
 	node.httpAuth = newHTTPServer(node.log, conf.HTTPTimeouts)
 	node.ws = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
 	node.wsAuth = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
+	for range conf.RPCEndpoints {
+		node.endpoints = append(node.endpoints, newHTTPServer(node.log, conf.HTTPTimeouts))
+	}
 	node.ipc = newIPCServer(node.log, conf.IPCEndpoint())
+	node.adminIPC = newIPCServer(node.log, conf.AdminIPCEndpoint())
 
 	return node, nil
 }
@@ -391,7 +411,17 @@ func (n *Node) startRPC() error {
 		return err
 	}
 
+	// Configure the admin command channel, the privileged methods being no
+	// longer served by the other endpoints.
+	var restricted []string
+	if n.adminIPC.endpoint != "" {
+		if err := n.startAdminIPC(apis); err != nil {
+			return err
+		}
+		restricted = PrivilegedMethods
+	}
 	// Configure IPC.
+	n.ipc.restricted = restricted
 	if n.ipc.endpoint != "" {
 		if err := n.ipc.start(apis); err != nil {
 			return err
@@ -405,6 +435,10 @@ func (n *Node) startRPC() error {
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		usage:                  n.rpcUsage,
+		usageHeader:            n.config.RPCUsageHeader,
+		auth:                   n.rpcAuth,
+		restricted:             restricted,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
@@ -433,6 +467,7 @@ func (n *Node) startRPC() error {
 			Modules:           n.config.WSModules,
 			Origins:           n.config.WSOrigins,
 			prefix:            n.config.WSPathPrefix,
+			compression:       n.config.WSCompression,
 			rpcEndpointConfig: rpcConfig,
 		}); err != nil {
 			return err
@@ -452,6 +487,7 @@ func (n *Node) startRPC() error {
 			batchItemLimit:         engineAPIBatchItemLimit,
 			batchResponseSizeLimit: engineAPIBatchResponseSizeLimit,
 			httpBodyLimit:          engineAPIBodyLimit,
+			restricted:             restricted,
 		}
 		err := server.enableRPC(allAPIs, httpConfig{
 			CorsAllowedOrigins: DefaultAuthCors,
@@ -506,6 +542,14 @@ func (n *Node) startRPC() error {
 			return err
 		}
 	}
+	// Configure the additional HTTP endpoints
+	for i, endpoint := range n.config.RPCEndpoints {
+		server := n.endpoints[i]
+		if err := n.initRPCEndpoint(server, endpoint, openAPIs, allAPIs, rpcConfig); err != nil {
+			return fmt.Errorf("RPC endpoint %q: %w", endpoint.Name, err)
+		}
+		servers = append(servers, server)
+	}
 	// Start the servers
 	for _, server := range servers {
 		if err := server.start(); err != nil {
@@ -531,7 +575,11 @@ func (n *Node) stopRPC() {
 	n.ws.stop()
 	n.httpAuth.stop()
 	n.wsAuth.stop()
+	for _, server := range n.endpoints {
+		server.stop()
+	}
 	n.ipc.stop()
+	n.stopAdminIPC()
 	n.stopInProc()
 }
 
@@ -618,6 +666,16 @@ func (n *Node) RegisterHandler(name, path string, handler http.Handler) {
 	n.http.handlerNames[path] = name
 }
 
+// AuthorizeHandler wraps a handler served on the HTTP endpoint, so that it only
+// serves the authenticated callers unless the module is one of the public
+// modules. The handler is returned as is if authentication isn't configured.
+func (n *Node) AuthorizeHandler(module string, handler http.Handler) http.Handler {
+	if n.rpcAuth == nil {
+		return handler
+	}
+	return newModuleAuthHandler(n.rpcAuth, module, handler)
+}
+
 // Attach creates an RPC client attached to an in-process API handler.
 func (n *Node) Attach() *rpc.Client {
 	return rpc.DialInProc(n.inprocHandler)
@@ -702,6 +760,18 @@ func (n *Node) WSAuthEndpoint() string {
 	return "ws://" + n.wsAuth.listenAddr() + n.wsAuth.wsConfig.prefix
 }
 
+// RPCEndpoint returns the URL of the additional HTTP endpoint with the given
+// name, empty if there's no such endpoint. Note that this URL does not contain
+// the JSON-RPC path prefix of the endpoint.
+func (n *Node) RPCEndpoint(name string) string {
+	for i, endpoint := range n.config.RPCEndpoints {
+		if endpoint.Name == name {
+			return "http://" + n.endpoints[i].listenAddr()
+		}
+	}
+	return ""
+}
+
 // EventMux retrieves the event multiplexer used by all the network services in
 // the current protocol stack.
 func (n *Node) EventMux() *event.TypeMux {
@@ -726,6 +796,7 @@ func (n *Node) OpenDatabase(name string, cache, handles int, namespace string, r
 		db, err = rawdb.Open(rawdb.OpenOptions{
 			Type:      n.config.DBEngine,
 			Directory: n.ResolvePath(name),
+			Profile:   n.config.DBProfile,
 			Namespace: namespace,
 			Cache:     cache,
 			Handles:   handles,
@@ -762,6 +833,7 @@ func (n *Node) OpenDatabaseWithFreezer(name string, cache, handles int, ancient
 			Namespace:         namespace,
 			Cache:             cache,
 			Handles:           handles,
+			Profile:           n.config.DBProfile,
 			ReadOnly:          readonly,
 		})
 	}
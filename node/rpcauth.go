@@ -0,0 +1,166 @@
+package node
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// apiKeyHeader is the HTTP header carrying the API keys.
+	apiKeyHeader = "X-API-Key"
+
+	// jwtPrincipal is the principal of the callers authenticated by a token
+	// without subject.
+	jwtPrincipal = "jwt"
+)
+
+// rpcAuth authenticates the callers of the public HTTP, WebSocket and GraphQL
+// endpoints, either with an HS256 token as on the engine API, or with an API
+// key. Unauthenticated callers are restricted to the public namespaces.
+type rpcAuth struct {
+	jwtSecret []byte            // Secret of the tokens, nil if disabled
+	apiKeys   map[string]string // Names of the key holders by API key
+	public    []string          // Namespaces available to unauthenticated callers
+}
+
+// newRPCAuth loads the authentication settings of the configuration, returning
+// nil if authentication isn't configured.
+func newRPCAuth(conf *Config) (*rpcAuth, error) {
+	if conf.RPCAuthJWTSecret == "" && conf.RPCAuthAPIKeys == "" {
+		return nil, nil
+	}
+	auth := &rpcAuth{public: conf.RPCAuthPublicModules}
+	if conf.RPCAuthJWTSecret != "" {
+		data, err := os.ReadFile(conf.RPCAuthJWTSecret)
+		if err != nil {
+			return nil, err
+		}
+		auth.jwtSecret = common.FromHex(strings.TrimSpace(string(data)))
+		if len(auth.jwtSecret) != 32 {
+			return nil, fmt.Errorf("invalid RPC JWT secret %s: must be 32 hex-encoded bytes", conf.RPCAuthJWTSecret)
+		}
+	}
+	if conf.RPCAuthAPIKeys != "" {
+		keys, err := loadAPIKeys(conf.RPCAuthAPIKeys)
+		if err != nil {
+			return nil, err
+		}
+		auth.apiKeys = keys
+	}
+	log.Info("Enabled RPC authentication", "jwt", auth.jwtSecret != nil, "apikeys", len(auth.apiKeys), "public", strings.Join(auth.public, ","))
+	return auth, nil
+}
+
+// loadAPIKeys reads the <name>:<key> pairs of the file, skipping the empty
+// lines and the comments.
+func loadAPIKeys(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		keys    = make(map[string]string)
+		scanner = bufio.NewScanner(file)
+	)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, key, ok := strings.Cut(text, ":")
+		name, key = strings.TrimSpace(name), strings.TrimSpace(key)
+		if !ok || name == "" || key == "" {
+			return nil, fmt.Errorf("invalid API key at %s:%d, want <name>:<key>", path, line)
+		}
+		if _, exists := keys[key]; exists {
+			return nil, fmt.Errorf("duplicate API key at %s:%d", path, line)
+		}
+		keys[key] = name
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// authenticate returns the principal of the caller, empty if the request
+// carries no credentials. Invalid credentials are an error.
+func (a *rpcAuth) authenticate(r *http.Request) (string, error) {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		for candidate, name := range a.apiKeys {
+			if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+				return name, nil
+			}
+		}
+		return "", errors.New("invalid API key")
+	}
+	if token := bearerToken(r); token != "" {
+		if a.jwtSecret == nil {
+			return "", errors.New("token authentication not enabled")
+		}
+		claims, err := verifyJWT(token, func(*jwt.Token) (interface{}, error) { return a.jwtSecret, nil })
+		if err != nil {
+			return "", err
+		}
+		if claims.Subject != "" {
+			return claims.Subject, nil
+		}
+		return jwtPrincipal, nil
+	}
+	return "", nil
+}
+
+// isPublic reports whether the namespace is available to unauthenticated callers.
+func (a *rpcAuth) isPublic(module string) bool {
+	for _, public := range a.public {
+		if public == module {
+			return true
+		}
+	}
+	return false
+}
+
+// newRPCAuthHandler creates a handler authenticating the callers, passing the
+// principal to the RPC server. Requests with invalid credentials are rejected.
+func newRPCAuthHandler(auth *rpcAuth, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, err := auth.authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if principal != "" {
+			r = r.WithContext(rpc.WithPrincipal(r.Context(), principal))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newModuleAuthHandler creates a handler only serving authenticated callers,
+// unless the module is public.
+func newModuleAuthHandler(auth *rpcAuth, module string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, err := auth.authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if principal == "" && !auth.isPublic(module) {
+			http.Error(w, "authentication required", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,136 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// rpcResult is the decoded response of a single RPC request.
+type rpcResult struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func decodeRPCResult(t *testing.T, resp *http.Response) *rpcResult {
+	t.Helper()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+	res := new(rpcResult)
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		t.Fatal("invalid response:", err)
+	}
+	return res
+}
+
+func TestRPCAuth(t *testing.T) {
+	secret := make([]byte, 32)
+	auth := &rpcAuth{
+		jwtSecret: secret,
+		apiKeys:   map[string]string{"s3cr3t": "alice"},
+		public:    []string{"rpc"},
+	}
+	cfg := rpcEndpointConfig{auth: auth}
+	srv := createAndStartServer(t, &httpConfig{rpcEndpointConfig: cfg}, false, nil, nil)
+	url := fmt.Sprintf("http://%v", srv.listenAddr())
+
+	// Unauthenticated callers only reach the public namespaces.
+	if res := decodeRPCResult(t, rpcRequest(t, url, "rpc_modules")); res.Error != nil {
+		t.Fatalf("public method failed: %v", res.Error.Message)
+	}
+	res := decodeRPCResult(t, rpcRequest(t, url, "test_greet"))
+	if res.Error == nil || res.Error.Code != -32006 {
+		t.Fatalf("unauthenticated call not rejected: %s", res.Result)
+	}
+	// Authenticated callers reach all the namespaces.
+	if res := decodeRPCResult(t, rpcRequest(t, url, "test_greet", apiKeyHeader, "s3cr3t")); res.Error != nil {
+		t.Fatalf("call with API key failed: %v", res.Error.Message)
+	}
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:  "bob",
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}).SignedString(secret)
+	if res := decodeRPCResult(t, rpcRequest(t, url, "test_greet", "Authorization", "Bearer "+token)); res.Error != nil {
+		t.Fatalf("call with token failed: %v", res.Error.Message)
+	}
+	// Invalid credentials are rejected, even for the public namespaces.
+	if resp := rpcRequest(t, url, "rpc_modules", apiKeyHeader, "wrong"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("invalid API key: have status %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+	forged, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+	}).SignedString([]byte("forged"))
+	if resp := rpcRequest(t, url, "rpc_modules", "Authorization", "Bearer "+forged); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("invalid token: have status %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestRPCAuthWebsocket(t *testing.T) {
+	auth := &rpcAuth{apiKeys: map[string]string{"s3cr3t": "alice"}}
+	cfg := rpcEndpointConfig{auth: auth}
+	srv := createAndStartServer(t, &httpConfig{}, true, &wsConfig{Origins: []string{"*"}, rpcEndpointConfig: cfg}, nil)
+	url := fmt.Sprintf("ws://%v", srv.listenAddr())
+
+	if err := srv.wsHandler.Load().(*rpcHandler).server.RegisterName("test", new(testService)); err != nil {
+		t.Fatal(err)
+	}
+	anonymous, err := rpc.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer anonymous.Close()
+
+	var greeting string
+	if err := anonymous.Call(&greeting, "test_greet"); err == nil {
+		t.Fatal("unauthenticated call not rejected")
+	}
+	client, err := rpc.DialOptions(context.Background(), url, rpc.WithHeader(apiKeyHeader, "s3cr3t"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Call(&greeting, "test_greet"); err != nil {
+		t.Fatal("call with API key failed:", err)
+	}
+	if greeting != "Hello" {
+		t.Errorf("greeting mismatch: have %q, want %q", greeting, "Hello")
+	}
+}
+
+func TestLoadAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apikeys")
+	if err := os.WriteFile(path, []byte("# explorers\nalice: key1\n\nbob:key2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := loadAPIKeys(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || keys["key1"] != "alice" || keys["key2"] != "bob" {
+		t.Errorf("keys mismatch: %v", keys)
+	}
+	for _, invalid := range []string{"alice\n", "alice:\n", "alice:key1\nbob:key1\n"} {
+		if err := os.WriteFile(path, []byte(invalid), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadAPIKeys(path); err == nil {
+			t.Errorf("no error for invalid keys %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RPCEndpointConfig is the configuration of an additional HTTP endpoint. Each
+// endpoint listens on its own address and exposes its own API namespaces, so
+// that a node can serve both public and internal consumers, e.g.
+//
+//	[[Node.RPCEndpoints]]
+//	Name = "public"
+//	Host = "0.0.0.0"
+//	Port = 8545
+//	Modules = ["eth", "net", "web3"]
+//	VirtualHosts = ["*"]
+//
+//	[[Node.RPCEndpoints]]
+//	Name = "internal"
+//	Host = "127.0.0.1"
+//	Port = 8552
+//	Modules = ["debug", "trace", "nero"]
+//	JWTSecret = "/secrets/internal.hex"
+type RPCEndpointConfig struct {
+	// Name identifies the endpoint in the logs and errors.
+	Name string
+
+	// Host is the host interface on which to start the endpoint.
+	Host string
+
+	// Port is the TCP port number on which to start the endpoint.
+	Port int
+
+	// PathPrefix specifies a path prefix on which the endpoint is served.
+	PathPrefix string `toml:",omitempty"`
+
+	// Modules is the list of API modules exposed by the endpoint. Unlike for
+	// the default HTTP endpoint, it must not be empty.
+	Modules []string
+
+	// Cors is the Cross-Origin Resource Sharing header to send to requesting
+	// clients.
+	Cors []string `toml:",omitempty"`
+
+	// VirtualHosts is the list of virtual hostnames which are allowed on
+	// incoming requests, localhost if empty.
+	VirtualHosts []string `toml:",omitempty"`
+
+	// JWTSecret is the path to the hex-encoded secret of the HS256 tokens the
+	// callers must present, as on the engine API. The endpoint may then expose
+	// the authenticated-only modules too. If empty, the endpoint is public and
+	// subject to the RPC authentication settings of the node, if any.
+	JWTSecret string `toml:",omitempty"`
+}
+
+// validateRPCEndpoints checks the configuration of the additional endpoints.
+func validateRPCEndpoints(endpoints []RPCEndpointConfig) error {
+	names := make(map[string]struct{})
+	for _, endpoint := range endpoints {
+		if endpoint.Name == "" {
+			return errors.New("RPC endpoint without name")
+		}
+		if _, ok := names[endpoint.Name]; ok {
+			return fmt.Errorf("duplicate RPC endpoint %q", endpoint.Name)
+		}
+		names[endpoint.Name] = struct{}{}
+
+		if endpoint.Host == "" {
+			return fmt.Errorf("RPC endpoint %q without host", endpoint.Name)
+		}
+		// An empty module list would expose all the modules
+		if len(endpoint.Modules) == 0 {
+			return fmt.Errorf("RPC endpoint %q without modules", endpoint.Name)
+		}
+		if err := validatePrefix(fmt.Sprintf("Endpoint %q", endpoint.Name), endpoint.PathPrefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initRPCEndpoint configures the server of an additional endpoint. Endpoints
+// authenticated by token may serve all the APIs, the others the open ones only.
+func (n *Node) initRPCEndpoint(server *httpServer, endpoint RPCEndpointConfig, openAPIs, allAPIs []rpc.API, rpcConfig rpcEndpointConfig) error {
+	if err := server.setListenAddr(endpoint.Host, endpoint.Port); err != nil {
+		return err
+	}
+	apis := openAPIs
+	if endpoint.JWTSecret != "" {
+		secret, err := n.obtainJWTSecret(endpoint.JWTSecret)
+		if err != nil {
+			return err
+		}
+		apis = allAPIs
+		rpcConfig.jwtSecret = secret
+		rpcConfig.auth = nil
+	}
+	vhosts := endpoint.VirtualHosts
+	if len(vhosts) == 0 {
+		vhosts = DefaultConfig.HTTPVirtualHosts
+	}
+	return server.enableRPC(apis, httpConfig{
+		CorsAllowedOrigins: endpoint.Cors,
+		Vhosts:             vhosts,
+		Modules:            endpoint.Modules,
+		prefix:             endpoint.PathPrefix,
+		rpcEndpointConfig:  rpcConfig,
+	})
+}
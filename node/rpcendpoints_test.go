@@ -0,0 +1,127 @@
+package node
+
+import (
+	"context"
+	crand "crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that the additional endpoints only expose their own namespaces, the
+// authenticated ones only to the callers presenting a valid token.
+func TestRPCEndpoints(t *testing.T) {
+	var secret [32]byte
+	if _, err := crand.Read(secret[:]); err != nil {
+		t.Fatalf("failed to create jwt secret: %v", err)
+	}
+	jwtPath := filepath.Join(t.TempDir(), "jwt_secret")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		t.Fatalf("failed to prepare jwt secret file: %v", err)
+	}
+	conf := &Config{
+		RPCEndpoints: []RPCEndpointConfig{
+			{Name: "public", Host: "127.0.0.1", Modules: []string{"eth"}},
+			{Name: "internal", Host: "127.0.0.1", Modules: []string{"debug", "engine"}, JWTSecret: jwtPath, PathPrefix: "/internal"},
+		},
+	}
+	node, err := New(conf)
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs([]rpc.API{
+		{Namespace: "eth", Service: helloRPC("hello eth")},
+		{Namespace: "debug", Service: helloRPC("hello debug")},
+		{Namespace: "engine", Service: helloRPC("hello engine"), Authenticated: true},
+	})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	defer node.Close()
+
+	public, internal := node.RPCEndpoint("public"), node.RPCEndpoint("internal")+"/internal"
+	if public == internal {
+		t.Fatalf("expected different endpoints, got %q", public)
+	}
+	if endpoint := node.RPCEndpoint("missing"); endpoint != "" {
+		t.Errorf("unknown endpoint resolved to %q", endpoint)
+	}
+	tests := []struct {
+		endpoint string
+		auth     rpc.HTTPAuth
+		method   string
+		want     string // Expected result, empty if the call should fail
+	}{
+		{endpoint: public, method: "eth_helloWorld", want: "hello eth"},
+		{endpoint: public, method: "debug_helloWorld"},
+		{endpoint: public, method: "engine_helloWorld"},
+		{endpoint: internal, auth: NewJWTAuth(secret), method: "debug_helloWorld", want: "hello debug"},
+		{endpoint: internal, auth: NewJWTAuth(secret), method: "engine_helloWorld", want: "hello engine"},
+		{endpoint: internal, auth: NewJWTAuth(secret), method: "eth_helloWorld"},
+		{endpoint: internal, auth: NewJWTAuth([32]byte{}), method: "debug_helloWorld"},
+		{endpoint: internal, method: "debug_helloWorld"},
+	}
+	for i, tt := range tests {
+		var opts []rpc.ClientOption
+		if tt.auth != nil {
+			opts = append(opts, rpc.WithHTTPAuth(tt.auth))
+		}
+		client, err := rpc.DialOptions(context.Background(), tt.endpoint, opts...)
+		if err != nil {
+			t.Fatalf("test %d: failed to dial %s: %v", i, tt.endpoint, err)
+		}
+		var have string
+		err = client.Call(&have, tt.method)
+		client.Close()
+
+		switch {
+		case tt.want == "" && err == nil:
+			t.Errorf("test %d: %s on %s not rejected", i, tt.method, tt.endpoint)
+		case tt.want != "" && err != nil:
+			t.Errorf("test %d: %s on %s failed: %v", i, tt.method, tt.endpoint, err)
+		case have != tt.want:
+			t.Errorf("test %d: result mismatch: have %q, want %q", i, have, tt.want)
+		}
+	}
+}
+
+func TestRPCEndpointsValidation(t *testing.T) {
+	tests := []struct {
+		endpoints []RPCEndpointConfig
+		err       string
+	}{
+		{
+			endpoints: []RPCEndpointConfig{{Host: "127.0.0.1", Modules: []string{"eth"}}},
+			err:       "without name",
+		},
+		{
+			endpoints: []RPCEndpointConfig{
+				{Name: "public", Host: "127.0.0.1", Modules: []string{"eth"}},
+				{Name: "public", Host: "127.0.0.1", Modules: []string{"debug"}},
+			},
+			err: "duplicate",
+		},
+		{
+			endpoints: []RPCEndpointConfig{{Name: "public", Modules: []string{"eth"}}},
+			err:       "without host",
+		},
+		{
+			endpoints: []RPCEndpointConfig{{Name: "public", Host: "127.0.0.1"}},
+			err:       "without modules",
+		},
+		{
+			endpoints: []RPCEndpointConfig{{Name: "public", Host: "127.0.0.1", Modules: []string{"eth"}, PathPrefix: "public"}},
+			err:       "leading",
+		},
+	}
+	for i, tt := range tests {
+		err := validateRPCEndpoints(tt.endpoints)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %q", i, err, tt.err)
+		}
+	}
+}
@@ -47,9 +47,10 @@ type httpConfig struct {
 
 // wsConfig is the JSON-RPC/Websocket configuration
 type wsConfig struct {
-	Origins []string
-	Modules []string
-	prefix  string // path prefix on which to mount ws handler
+	Origins     []string
+	Modules     []string
+	prefix      string // path prefix on which to mount ws handler
+	compression bool   // negotiate permessage-deflate with the clients
 	rpcEndpointConfig
 }
 
@@ -58,6 +59,10 @@ type rpcEndpointConfig struct {
 	batchItemLimit         int
 	batchResponseSizeLimit int
 	httpBodyLimit          int
+	usage                  *rpc.UsageTracker // optional per-consumer usage accounting
+	usageHeader            string
+	auth                   *rpcAuth // optional authentication of the callers
+	restricted             []string // methods served by the admin command channel only
 }
 
 type rpcHandler struct {
@@ -309,12 +314,21 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	if config.usage != nil {
+		srv.SetUsageTracker(config.usage, config.usageHeader)
+	}
+	srv.SetRestrictedMethods(config.restricted)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
+	var handler http.Handler = srv
+	if config.auth != nil {
+		srv.SetPublicModules(config.auth.public)
+		handler = newRPCAuthHandler(config.auth, srv)
+	}
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret),
+		Handler: NewHTTPHandlerStack(handler, config.CorsAllowedOrigins, config.Vhosts, config.jwtSecret),
 		server:  srv,
 	})
 	return nil
@@ -344,12 +358,22 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	if config.httpBodyLimit > 0 {
 		srv.SetHTTPBodyLimit(config.httpBodyLimit)
 	}
+	if config.usage != nil {
+		srv.SetUsageTracker(config.usage, config.usageHeader)
+	}
+	srv.SetRestrictedMethods(config.restricted)
+	srv.SetWebsocketCompression(config.compression)
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
+	handler := srv.WebsocketHandler(config.Origins)
+	if config.auth != nil {
+		srv.SetPublicModules(config.auth.public)
+		handler = newRPCAuthHandler(config.auth, handler)
+	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: NewWSHandlerStack(srv.WebsocketHandler(config.Origins), config.jwtSecret),
+		Handler: NewWSHandlerStack(handler, config.jwtSecret),
 		server:  srv,
 	})
 	return nil
@@ -585,8 +609,10 @@ func newGzipHandler(next http.Handler) http.Handler {
 }
 
 type ipcServer struct {
-	log      log.Logger
-	endpoint string
+	log        log.Logger
+	endpoint   string
+	restricted []string    // methods served by the admin command channel only
+	auditor    rpc.Auditor // records the served calls, nil if disabled
 
 	mu       sync.Mutex
 	listener net.Listener
@@ -605,7 +631,17 @@ func (is *ipcServer) start(apis []rpc.API) error {
 	if is.listener != nil {
 		return nil // already running
 	}
-	listener, srv, err := rpc.StartIPCEndpoint(is.endpoint, apis)
+	srv := rpc.NewServer()
+	srv.SetRestrictedMethods(is.restricted)
+	if is.auditor != nil {
+		srv.SetAuditor(is.auditor)
+	}
+	for _, api := range apis {
+		if err := srv.RegisterName(api.Namespace, api.Service); err != nil {
+			return err
+		}
+	}
+	listener, err := rpc.ServeIPCEndpoint(is.endpoint, srv)
 	if err != nil {
 		is.log.Warn("IPC opening failed", "url", is.endpoint, "error", err)
 		return err
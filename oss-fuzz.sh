@@ -106,6 +106,14 @@ compile_fuzzer github.com/ethereum/go-ethereum/core/types \
   FuzzRLP fuzzRlp \
   $repo/core/types/rlp_fuzzer_test.go
 
+compile_fuzzer github.com/ethereum/go-ethereum/core/vm \
+  FuzzActionLogger fuzzActionLogger \
+  $repo/core/vm/logger_action_fuzz_test.go
+
+compile_fuzzer github.com/ethereum/go-ethereum/core/rawdb \
+  FuzzInternalTxs fuzzInternalTxs \
+  $repo/core/rawdb/internal_tx_fuzz_test.go,$repo/core/rawdb/accessors_trace_archive_test.go
+
 compile_fuzzer github.com/ethereum/go-ethereum/crypto/blake2b \
   Fuzz fuzzBlake2b \
   $repo/crypto/blake2b/blake2b_f_fuzz_test.go
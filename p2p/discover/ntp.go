@@ -36,7 +36,7 @@ const (
 // checkClockDrift queries an NTP server for clock drifts and warns the user if
 // one large enough is detected.
 func checkClockDrift() {
-	drift, err := sntpDrift(ntpChecks)
+	drift, err := SNTPDrift(ntpPool, ntpChecks)
 	if err != nil {
 		return
 	}
@@ -48,15 +48,15 @@ func checkClockDrift() {
 	}
 }
 
-// sntpDrift does a naive time resolution against an NTP server and returns the
+// SNTPDrift does a naive time resolution against an NTP server and returns the
 // measured drift. This method uses the simple version of NTP. It's not precise
 // but should be fine for these purposes.
 //
 // Note, it executes two extra measurements compared to the number of requested
 // ones to be able to discard the two extremes as outliers.
-func sntpDrift(measurements int) (time.Duration, error) {
+func SNTPDrift(server string, measurements int) (time.Duration, error) {
 	// Resolve the address of the NTP server
-	addr, err := net.ResolveUDPAddr("udp", ntpPool+":123")
+	addr, err := net.ResolveUDPAddr("udp", server+":123")
 	if err != nil {
 		return 0, err
 	}
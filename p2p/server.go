@@ -198,6 +198,7 @@ type Server struct {
 
 	// This is read by the NAT port mapping loop.
 	portMappingRegister chan *portMapping
+	natStatus           natStatus
 
 	// Channels into the run loop.
 	quit                    chan struct{}
@@ -18,6 +18,8 @@ package p2p
 
 import (
 	"net"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/mclock"
@@ -31,6 +33,7 @@ const (
 	portMapRefreshInterval = 8 * time.Minute
 	portMapRetryInterval   = 5 * time.Minute
 	extipRetryInterval     = 2 * time.Minute
+	natProbeTimeout        = 3 * time.Second
 )
 
 type portMapping struct {
@@ -43,6 +46,146 @@ type portMapping struct {
 	nextTime mclock.AbsTime
 }
 
+// NATStatus describes the state of the NAT traversal of the server.
+type NATStatus struct {
+	Interface    string             `json:"interface"`           // NAT mechanism in use, empty if none is configured
+	ExternalIP   string             `json:"externalIP"`          // Last external IP reported by the NAT interface
+	IPError      string             `json:"ipError,omitempty"`   // Error of the last external IP request
+	IPChanged    *time.Time         `json:"ipChanged,omitempty"` // Time the external IP last changed
+	Mappings     []NATMappingStatus `json:"mappings"`            // Port mappings requested from the NAT interface
+	Advertised   NATEndpoint        `json:"advertised"`          // Endpoint advertised in the local node record
+	Reachability NATReachability    `json:"reachability"`        // Results of the reachability checks
+}
+
+// NATMappingStatus describes a single port mapping.
+type NATMappingStatus struct {
+	Protocol     string     `json:"protocol"`
+	InternalPort int        `json:"internalPort"`
+	ExternalPort int        `json:"externalPort"` // Zero if the port is not mapped
+	Mapped       bool       `json:"mapped"`
+	Error        string     `json:"error,omitempty"` // Error of the last mapping attempt
+	LastAttempt  *time.Time `json:"lastAttempt,omitempty"`
+}
+
+// NATEndpoint is the endpoint other nodes use to contact the local node.
+type NATEndpoint struct {
+	IP  string `json:"ip"`
+	TCP int    `json:"tcp"`
+	UDP int    `json:"udp"`
+}
+
+// NATReachability contains the evidence gathered about the reachability of the
+// advertised endpoint from the Internet.
+type NATReachability struct {
+	// InboundPeers is the number of connected peers which dialed the local node.
+	// Any inbound peer proves that the TCP port is reachable.
+	InboundPeers int `json:"inboundPeers"`
+
+	// TCPProbe is the result of dialing the advertised TCP endpoint, either
+	// "reachable" or the dial error. Note the probe goes through the local
+	// gateway, so it fails on gateways without hairpin NAT support even if
+	// the port is reachable from outside.
+	TCPProbe string `json:"tcpProbe"`
+}
+
+// natStatus records the outcome of the port mapping loop for NATStatus.
+type natStatus struct {
+	lock      sync.Mutex
+	extIP     net.IP
+	ipErr     error
+	ipChanged time.Time
+	mappings  map[string]*NATMappingStatus
+}
+
+func (s *natStatus) setExternalIP(ip net.IP, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.ipErr = err
+	if err == nil {
+		if !ip.Equal(s.extIP) {
+			s.ipChanged = time.Now()
+		}
+		s.extIP = ip
+	}
+}
+
+func (s *natStatus) setMapping(m *portMapping, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.mappings == nil {
+		s.mappings = make(map[string]*NATMappingStatus)
+	}
+	now := time.Now()
+	status := &NATMappingStatus{
+		Protocol:     m.protocol,
+		InternalPort: m.port,
+		ExternalPort: m.extPort,
+		Mapped:       err == nil && m.extPort != 0,
+		LastAttempt:  &now,
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	s.mappings[m.protocol] = status
+}
+
+// fill copies the recorded NAT traversal results into status.
+func (s *natStatus) fill(status *NATStatus) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.extIP != nil {
+		status.ExternalIP = s.extIP.String()
+	}
+	if s.ipErr != nil {
+		status.IPError = s.ipErr.Error()
+	}
+	if !s.ipChanged.IsZero() {
+		changed := s.ipChanged
+		status.IPChanged = &changed
+	}
+	for _, proto := range []string{"TCP", "UDP"} {
+		if m := s.mappings[proto]; m != nil {
+			status.Mappings = append(status.Mappings, *m)
+		}
+	}
+}
+
+// NATStatus reports the external IP discovered through the NAT interface, the
+// state of the port mappings and the result of probing the advertised endpoint.
+func (srv *Server) NATStatus() *NATStatus {
+	status := &NATStatus{Mappings: []NATMappingStatus{}}
+	if srv.NAT != nil {
+		status.Interface = srv.NAT.String()
+	}
+	srv.natStatus.fill(status)
+
+	self := srv.Self()
+	status.Advertised = NATEndpoint{TCP: self.TCP(), UDP: self.UDP()}
+	if ip := self.IPAddr(); ip.IsValid() && !ip.IsUnspecified() {
+		status.Advertised.IP = ip.String()
+	}
+	for _, p := range srv.Peers() {
+		if p.Inbound() {
+			status.Reachability.InboundPeers++
+		}
+	}
+	if status.Advertised.IP == "" || status.Advertised.TCP == 0 {
+		status.Reachability.TCPProbe = "no advertised TCP endpoint"
+		return status
+	}
+	addr := net.JoinHostPort(status.Advertised.IP, strconv.Itoa(status.Advertised.TCP))
+	if conn, err := net.DialTimeout("tcp", addr, natProbeTimeout); err != nil {
+		status.Reachability.TCPProbe = err.Error()
+	} else {
+		conn.Close()
+		status.Reachability.TCPProbe = "reachable"
+	}
+	return status
+}
+
 // setupPortMapping starts the port mapping loop if necessary.
 // Note: this needs to be called after the LocalNode instance has been set on the server.
 func (srv *Server) setupPortMapping() {
@@ -62,6 +205,7 @@ func (srv *Server) setupPortMapping() {
 		// ExtIP doesn't block, set the IP right away.
 		ip, _ := srv.NAT.ExternalIP()
 		srv.localnode.SetStaticIP(ip)
+		srv.natStatus.setExternalIP(ip, nil)
 		srv.loopWG.Add(1)
 		go srv.consumePortMappingRequests()
 
@@ -122,20 +266,30 @@ func (srv *Server) portMappingLoop() {
 		case <-extip.C():
 			extip.Schedule(srv.clock.Now().Add(extipRetryInterval))
 			ip, err := srv.NAT.ExternalIP()
+			srv.natStatus.setExternalIP(ip, err)
 			if err != nil {
 				log.Debug("Couldn't get external IP", "err", err, "interface", srv.NAT)
 			} else if !ip.Equal(lastExtIP) {
-				log.Debug("External IP changed", "ip", extip, "interface", srv.NAT)
+				if lastExtIP != nil {
+					log.Info("External IP changed", "old", lastExtIP, "ip", ip, "interface", srv.NAT)
+				} else {
+					log.Debug("External IP discovered", "ip", ip, "interface", srv.NAT)
+				}
 			} else {
 				continue
 			}
 			// Here, we either failed to get the external IP, or it has changed.
-			lastExtIP = ip
-			srv.localnode.SetStaticIP(ip)
-			// Ensure port mappings are refreshed in case we have moved to a new network.
+			// The mappings made for the previous address are dropped and all ports
+			// are mapped again right away in case we have moved to a new network.
 			for _, m := range mappings {
+				if err == nil && lastExtIP != nil && m.extPort != 0 {
+					newLogger(m.protocol, m.extPort, m.port).Debug("Deleting stale port mapping")
+					srv.NAT.DeleteMapping(m.protocol, m.extPort, m.port)
+				}
 				m.nextTime = srv.clock.Now()
 			}
+			lastExtIP = ip
+			srv.localnode.SetStaticIP(ip)
 
 		case m := <-srv.portMappingRegister:
 			if m.protocol != "TCP" && m.protocol != "UDP" {
@@ -162,11 +316,13 @@ func (srv *Server) portMappingLoop() {
 					log.Debug("Couldn't add port mapping", "err", err)
 					m.extPort = 0
 					m.nextTime = srv.clock.Now().Add(portMapRetryInterval)
+					srv.natStatus.setMapping(m, err)
 					continue
 				}
 				// It was mapped!
 				m.extPort = int(p)
 				m.nextTime = srv.clock.Now().Add(portMapRefreshInterval)
+				srv.natStatus.setMapping(m, nil)
 				if external != m.extPort {
 					log = newLogger(m.protocol, m.extPort, m.port)
 					log.Info("NAT mapped alternative port")
@@ -76,11 +76,78 @@ func TestServerPortMapping(t *testing.T) {
 	}
 }
 
+func TestServerPortMappingIPChange(t *testing.T) {
+	clock := new(mclock.Simulated)
+	mockNAT := &mockNAT{mappedPort: 30000}
+	srv := Server{
+		Config: Config{
+			PrivateKey: newkey(),
+			NoDial:     true,
+			ListenAddr: ":0",
+			NAT:        mockNAT,
+			Logger:     testlog.Logger(t, log.LvlTrace),
+			clock:      clock,
+		},
+	}
+	err := srv.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+
+	waitRequests := func(n int32) {
+		deadline := clock.Now().Add(portMapRefreshInterval)
+		for clock.Now() < deadline && mockNAT.mapRequests.Load() < n {
+			time.Sleep(10 * time.Millisecond)
+			clock.Run(1 * time.Second)
+		}
+	}
+	waitRequests(2)
+
+	status := new(NATStatus)
+	srv.natStatus.fill(status)
+	if status.ExternalIP != "192.0.2.0" {
+		t.Error("wrong external IP in status:", status.ExternalIP)
+	}
+	if len(status.Mappings) != 2 {
+		t.Fatal("wrong mapping count in status:", len(status.Mappings))
+	}
+	for _, m := range status.Mappings {
+		if !m.Mapped || m.ExternalPort != 30000 {
+			t.Errorf("wrong %s mapping in status: %+v", m.Protocol, m)
+		}
+	}
+
+	// Change the external IP, the mappings should be dropped and made again.
+	mockNAT.setIP(net.ParseIP("192.0.2.1"))
+	waitRequests(4)
+
+	if reqCount := mockNAT.mapRequests.Load(); reqCount != 4 {
+		t.Error("wrong request count:", reqCount)
+	}
+	if unmapCount := mockNAT.unmapRequests.Load(); unmapCount != 2 {
+		t.Error("wrong unmap request count:", unmapCount)
+	}
+	if ip := srv.LocalNode().Node().IPAddr(); ip != netip.MustParseAddr("192.0.2.1") {
+		t.Error("wrong IP in ENR:", ip)
+	}
+	status = new(NATStatus)
+	srv.natStatus.fill(status)
+	if status.ExternalIP != "192.0.2.1" || status.IPChanged == nil {
+		t.Errorf("wrong external IP in status: %s (changed %v)", status.ExternalIP, status.IPChanged)
+	}
+}
+
 type mockNAT struct {
 	mappedPort    uint16
 	mapRequests   atomic.Int32
 	unmapRequests atomic.Int32
 	ipRequests    atomic.Int32
+	ip            atomic.Pointer[net.IP]
+}
+
+func (m *mockNAT) setIP(ip net.IP) {
+	m.ip.Store(&ip)
 }
 
 func (m *mockNAT) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) (uint16, error) {
@@ -95,6 +162,9 @@ func (m *mockNAT) DeleteMapping(protocol string, extport, intport int) error {
 
 func (m *mockNAT) ExternalIP() (net.IP, error) {
 	m.ipRequests.Add(1)
+	if ip := m.ip.Load(); ip != nil {
+		return *ip, nil
+	}
 	return net.ParseIP("192.0.2.0"), nil
 }
 
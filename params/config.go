@@ -19,6 +19,7 @@ package params
 import (
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params/forks"
@@ -441,6 +442,9 @@ func (c CliqueConfig) String() string {
 }
 
 // TurboConfig is the consensus engine configs for proof-of-stake-authority based sealing.
+//
+// Nero specific forks are scheduled here. Fields named with a Block (*big.Int)
+// or Time (*uint64) suffix are treated as forks and are part of the fork id.
 type TurboConfig struct {
 	Period uint64 `json:"period"` // Number of seconds between blocks to enforce
 	Epoch  uint64 `json:"epoch"`  // Epoch length to reset votes and checkpoint
@@ -448,6 +452,367 @@ type TurboConfig struct {
 	// AttestationDelay is the delay number for a validator to provide an attestation.
 	// That is: only attest to a block which height is ≤ `currentHead - AttestationDelay`
 	AttestationDelay uint64 `json:"attestationDelay,omitempty"`
+
+	// TxRules enables additional transaction validation rules registered with
+	// the consensus engine, mapping their name to the first block they apply to.
+	TxRules map[string]*big.Int `json:"txRules,omitempty"`
+
+	// Precompiles enables additional precompiled contracts registered with the
+	// EVM, mapping their name to the first block they are callable at.
+	Precompiles map[string]*big.Int `json:"precompiles,omitempty"`
+
+	// SlashingBlock is the first block the slashing parameters are read from
+	// the governed parameter contract set in Slashing, instead of using the
+	// values hardcoded in the Staking contract.
+	SlashingBlock *big.Int        `json:"slashingBlock,omitempty"`
+	Slashing      *SlashingConfig `json:"slashing,omitempty"`
+
+	// TreasuryBlock is the first block a share of the transaction fees is
+	// redirected to the treasury set in Treasury, instead of being entirely
+	// distributed to the validators.
+	TreasuryBlock *big.Int        `json:"treasuryBlock,omitempty"`
+	Treasury      *TreasuryConfig `json:"treasury,omitempty"`
+
+	// SupplyBlock is the first block the burned base fees, the released staking
+	// rewards and the distributed transaction fees are counted in the state.
+	SupplyBlock *big.Int `json:"supplyBlock,omitempty"`
+
+	// FinalityChoiceBlock is the first block the fork choice prefers the
+	// branch with the highest justified or finalized block over the heavier
+	// branch, instead of comparing the total difficulties only.
+	FinalityChoiceBlock *big.Int `json:"finalityChoiceBlock,omitempty"`
+
+	// StrictTimeBlock is the first block the timestamps strictly increase and
+	// the slot right after the period is reserved to the in-turn validator,
+	// the out-of-turn validators having to stamp their blocks later.
+	StrictTimeBlock *big.Int `json:"strictTimeBlock,omitempty"`
+
+	// SystemGasBlock is the first block the system transactions are metered
+	// against the SystemGasLimit allowance of the block, separate from the
+	// block gas limit: the mandatory system calls are charged the gas they use
+	// without being limited, the double sign punishments over the allowance
+	// left are deferred, and their receipts report the gas actually used
+	// instead of the gas used by the block.
+	SystemGasBlock *big.Int `json:"systemGasBlock,omitempty"`
+	SystemGasLimit uint64   `json:"systemGasLimit,omitempty"` // Gas allowance of the system transactions per block, DefaultSystemGasLimit if 0, MinSystemGasLimit at least
+
+	// JailBlock is the first block the jails of the Staking contract are
+	// enforced: the epoch blocks record the jailed validators of the set
+	// taking over, which are left out of the sealing rotation until the next
+	// epoch block releases them.
+	JailBlock *big.Int `json:"jailBlock,omitempty"`
+
+	// Emission schedules the staking rewards released per block, applied by
+	// the engine to the Staking contract at the epoch blocks.
+	Emission *EmissionConfig `json:"emission,omitempty"`
+
+	// Halt is the governed emergency halt of the chain, letting the DAO stop
+	// the validators from sealing past a block for a coordinated upgrade.
+	Halt *HaltConfig `json:"halt,omitempty"`
+
+	// Schedule changes the block period and the epoch length at the given
+	// fork blocks, in ascending order. Period and Epoch apply before the first
+	// entry.
+	Schedule []TurboSchedule `json:"schedule,omitempty"`
+}
+
+// TurboSchedule changes the block period and the epoch length from the given
+// block on. The block must be an epoch block under the previous rules, so that
+// the epochs never straddle a change.
+type TurboSchedule struct {
+	Block  *big.Int `json:"block"`
+	Period uint64   `json:"period"` // Number of seconds between blocks to enforce
+	Epoch  uint64   `json:"epoch"`  // Epoch length to reset votes and checkpoint
+}
+
+// rules returns the first block, the block period and the epoch length of the
+// schedule entry in effect at the given block.
+func (c *TurboConfig) rules(number uint64) (start, period, epoch uint64) {
+	period, epoch = c.Period, c.Epoch
+	for _, entry := range c.Schedule {
+		if entry.Block == nil || entry.Block.Uint64() > number {
+			break
+		}
+		start, period, epoch = entry.Block.Uint64(), entry.Period, entry.Epoch
+	}
+	return start, period, epoch
+}
+
+// PeriodAt returns the number of seconds enforced between the given block and
+// its parent.
+func (c *TurboConfig) PeriodAt(number uint64) uint64 {
+	_, period, _ := c.rules(number)
+	return period
+}
+
+// EpochAt returns the length of the epoch the given block belongs to.
+func (c *TurboConfig) EpochAt(number uint64) uint64 {
+	_, _, epoch := c.rules(number)
+	return epoch
+}
+
+// IsEpoch returns whether the given block is an epoch block, recording the
+// validator set in its extra-data.
+func (c *TurboConfig) IsEpoch(number uint64) bool {
+	start, _, epoch := c.rules(number)
+	return (number-start)%epoch == 0
+}
+
+// LastEpoch returns the last epoch block at or before the given block.
+func (c *TurboConfig) LastEpoch(number uint64) uint64 {
+	start, _, epoch := c.rules(number)
+	return number - (number-start)%epoch
+}
+
+// EpochIndex returns the index of the epoch the given block belongs to,
+// counting the epochs of every length since the genesis.
+func (c *TurboConfig) EpochIndex(number uint64) uint64 {
+	var (
+		index, start uint64
+		epoch        = c.Epoch
+	)
+	for _, entry := range c.Schedule {
+		if entry.Block == nil || entry.Block.Uint64() > number {
+			break
+		}
+		index += (entry.Block.Uint64() - start) / epoch
+		start, epoch = entry.Block.Uint64(), entry.Epoch
+	}
+	return index + (number-start)/epoch
+}
+
+// EpochStart returns the first block of the epoch of the given index, the
+// inverse of EpochIndex.
+func (c *TurboConfig) EpochStart(index uint64) uint64 {
+	var (
+		first, start uint64 // Index and first block of the current rules
+		epoch        = c.Epoch
+	)
+	for _, entry := range c.Schedule {
+		if entry.Block == nil {
+			break
+		}
+		count := (entry.Block.Uint64() - start) / epoch
+		if index < first+count {
+			break
+		}
+		first += count
+		start, epoch = entry.Block.Uint64(), entry.Epoch
+	}
+	return start + (index-first)*epoch
+}
+
+// EpochAlignment returns the largest number of blocks dividing every epoch
+// boundary, scheduled or not: each epoch spans whole aligned ranges of that
+// many blocks.
+func (c *TurboConfig) EpochAlignment() uint64 {
+	gcd := func(a, b uint64) uint64 {
+		for b != 0 {
+			a, b = b, a%b
+		}
+		return a
+	}
+	align := c.Epoch
+	for _, entry := range c.Schedule {
+		if entry.Block == nil {
+			break
+		}
+		align = gcd(gcd(align, entry.Block.Uint64()), entry.Epoch)
+	}
+	return align
+}
+
+// CheckSchedule checks that the schedule entries are in ascending order, and
+// that each of them starts at an epoch block of the previous rules.
+func (c *TurboConfig) CheckSchedule() error {
+	var last uint64
+	for i, entry := range c.Schedule {
+		if entry.Block == nil || entry.Block.Sign() == 0 {
+			return fmt.Errorf("turbo schedule entry %d: missing activation block", i)
+		}
+		if entry.Epoch <= 1 {
+			return fmt.Errorf("turbo schedule entry %d: invalid epoch length %d", i, entry.Epoch)
+		}
+		block := entry.Block.Uint64()
+		if block <= last {
+			return fmt.Errorf("turbo schedule entry %d: block %d not after block %d", i, block, last)
+		}
+		if start, _, epoch := c.rules(block - 1); (block-start)%epoch != 0 {
+			return fmt.Errorf("turbo schedule entry %d: block %d is not an epoch block", i, block)
+		}
+		last = block
+	}
+	return nil
+}
+
+// CheckSystemGas checks that the gas allowance of the system transactions
+// fits a double sign punishment, the mandatory system calls running over it.
+func (c *TurboConfig) CheckSystemGas() error {
+	if limit := c.SystemGasAllowance(); limit < MinSystemGasLimit {
+		return fmt.Errorf("turbo system gas limit %d below minimum %d", limit, MinSystemGasLimit)
+	}
+	return nil
+}
+
+// IsTreasury returns whether num is either equal to the treasury fee
+// redirection fork block or greater.
+func (c *TurboConfig) IsTreasury(num *big.Int) bool {
+	return isBlockForked(c.TreasuryBlock, num)
+}
+
+// IsSupply returns whether num is either equal to the supply accounting fork
+// block or greater.
+func (c *TurboConfig) IsSupply(num *big.Int) bool {
+	return isBlockForked(c.SupplyBlock, num)
+}
+
+// IsFinalityChoice returns whether num is either equal to the finality-aware
+// fork choice fork block or greater.
+func (c *TurboConfig) IsFinalityChoice(num *big.Int) bool {
+	return isBlockForked(c.FinalityChoiceBlock, num)
+}
+
+// IsStrictTime returns whether num is either equal to the strict timestamps
+// fork block or greater.
+func (c *TurboConfig) IsStrictTime(num *big.Int) bool {
+	return isBlockForked(c.StrictTimeBlock, num)
+}
+
+// IsSystemGas returns whether num is either equal to the system transaction
+// gas metering fork block or greater.
+func (c *TurboConfig) IsSystemGas(num *big.Int) bool {
+	return isBlockForked(c.SystemGasBlock, num)
+}
+
+// IsJail returns whether num is either equal to the validator jails fork block
+// or greater.
+func (c *TurboConfig) IsJail(num *big.Int) bool {
+	return isBlockForked(c.JailBlock, num)
+}
+
+// SystemGasAllowance returns the gas allowance of the system transactions of a
+// block once metered.
+func (c *TurboConfig) SystemGasAllowance() uint64 {
+	if c.SystemGasLimit == 0 {
+		return DefaultSystemGasLimit
+	}
+	return c.SystemGasLimit
+}
+
+// ActivePrecompiles returns the names of the additional precompiled contracts
+// enabled at num, sorted.
+func (c *TurboConfig) ActivePrecompiles(num *big.Int) []string {
+	var names []string
+	for name, block := range c.Precompiles {
+		if isBlockForked(block, num) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EmissionConfig is a schedule of the staking rewards released per block. The
+// rewards are set by steps, and optionally decay every few epochs after the
+// last reached step, e.g. halving with a decay rate of 500. Before the first
+// step, the rewards of the Staking contract are left untouched.
+type EmissionConfig struct {
+	Steps         []EmissionStep `json:"steps"`
+	DecayInterval uint64         `json:"decayInterval,omitempty"` // Epochs between two decays, 0 for none
+	DecayRate     uint64         `json:"decayRate,omitempty"`     // Share of the rewards removed at each decay, per thousand
+}
+
+// EmissionStep sets the rewards per block from the given block on. Steps are
+// applied at the first epoch block they are reached at.
+type EmissionStep struct {
+	Block           *big.Int `json:"block"`
+	RewardsPerBlock *big.Int `json:"rewardsPerBlock"`
+}
+
+// step returns the last step reached at the given block, nil if none.
+func (c *EmissionConfig) step(number uint64) *EmissionStep {
+	var step *EmissionStep
+	for i := range c.Steps {
+		if block := c.Steps[i].Block; block != nil && block.Uint64() <= number && (step == nil || block.Cmp(step.Block) > 0) {
+			step = &c.Steps[i]
+		}
+	}
+	return step
+}
+
+// RewardsPerBlock returns the staking rewards per block scheduled at the given
+// block by the emission config. The decays are counted in epochs since the first
+// epoch block of the step, whatever their lengths, so a change of the epoch
+// length only affects the decays after it. Nil is returned without emission
+// config or before the first step.
+func (c *TurboConfig) RewardsPerBlock(number uint64) *big.Int {
+	if c.Emission == nil {
+		return nil
+	}
+	step := c.Emission.step(number)
+	if step == nil {
+		return nil
+	}
+	rewards := new(big.Int).Set(step.RewardsPerBlock)
+	if c.Emission.DecayInterval == 0 || c.Emission.DecayRate == 0 || c.Epoch == 0 {
+		return rewards
+	}
+	first := c.EpochIndex(step.Block.Uint64())
+	if !c.IsEpoch(step.Block.Uint64()) {
+		first++
+	}
+	current := c.EpochIndex(number)
+	if current <= first {
+		return rewards
+	}
+	var (
+		decays = (current - first) / c.Emission.DecayInterval
+		keep   = new(big.Int).SetUint64(1000 - min(c.Emission.DecayRate, 1000))
+		base   = big.NewInt(1000)
+	)
+	for i := uint64(0); i < decays && rewards.Sign() > 0; i++ {
+		rewards.Div(rewards.Mul(rewards, keep), base)
+	}
+	return rewards
+}
+
+// SlashingConfig is the governed slashing parameter set enabled by the
+// SlashingBlock fork. The defaults apply while the parameter contract isn't
+// deployed or leaves a parameter unset, zero defaults keeping the pre-fork
+// values. The engine schedules the decreases of the missed blocks counters
+// with DecreaseInterval, the Staking contract is upgraded at the fork block to
+// read the other parameters itself.
+type SlashingConfig struct {
+	Contract common.Address `json:"contract"` // Parameter contract, governed by the DAO
+
+	LazyPunishThreshold uint64 `json:"lazyPunishThreshold,omitempty"` // Missed blocks before a validator is jailed
+	DecreaseInterval    uint64 `json:"decreaseInterval,omitempty"`    // Blocks between two decreases of the missed blocks counters
+	DoubleSignPenalty   uint64 `json:"doubleSignPenalty,omitempty"`   // Stake share slashed for double signing, per thousand
+}
+
+// TreasuryConfig is the governed fee redirection enabled by the TreasuryBlock
+// fork. The defaults apply while the parameter contract isn't deployed or
+// leaves a parameter unset.
+type TreasuryConfig struct {
+	Contract common.Address `json:"contract"` // Parameter contract, governed by the DAO
+
+	Address common.Address `json:"address"`         // Treasury the fees are redirected to
+	Share   uint64         `json:"share,omitempty"` // Share of the fees redirected, per thousand
+}
+
+// HaltConfig is the governed emergency halt. The validators stop sealing the
+// blocks above the halt block set in the contract, which freezes the chain at
+// that height until the governance lifts it or the upgraded validators
+// override it. The halt isn't a consensus rule, the blocks sealed past it stay
+// valid.
+type HaltConfig struct {
+	Contract common.Address `json:"contract"` // Halt contract, governed by the DAO
+}
+
+// IsSlashing returns whether num is either equal to the governed slashing
+// parameters fork block or greater.
+func (c *TurboConfig) IsSlashing(num *big.Int) bool {
+	return isBlockForked(c.SlashingBlock, num)
 }
 
 // String implements the stringer interface, returning the consensus engine details.
@@ -744,6 +1109,12 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 			lastFork = cur
 		}
 	}
+	if c.Turbo != nil {
+		if err := c.Turbo.CheckSchedule(); err != nil {
+			return err
+		}
+		return c.Turbo.CheckSystemGas()
+	}
 	return nil
 }
 
@@ -815,6 +1186,62 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if isForkTimestampIncompatible(c.VerkleTime, newcfg.VerkleTime, headTimestamp) {
 		return newTimestampCompatError("Verkle fork timestamp", c.VerkleTime, newcfg.VerkleTime)
 	}
+	if c.Turbo != nil && newcfg.Turbo != nil {
+		if isForkBlockIncompatible(c.Turbo.SlashingBlock, newcfg.Turbo.SlashingBlock, headNumber) {
+			return newBlockCompatError("Turbo slashing fork block", c.Turbo.SlashingBlock, newcfg.Turbo.SlashingBlock)
+		}
+		if isForkBlockIncompatible(c.Turbo.TreasuryBlock, newcfg.Turbo.TreasuryBlock, headNumber) {
+			return newBlockCompatError("Turbo treasury fork block", c.Turbo.TreasuryBlock, newcfg.Turbo.TreasuryBlock)
+		}
+		if isForkBlockIncompatible(c.Turbo.SupplyBlock, newcfg.Turbo.SupplyBlock, headNumber) {
+			return newBlockCompatError("Turbo supply accounting fork block", c.Turbo.SupplyBlock, newcfg.Turbo.SupplyBlock)
+		}
+		if isForkBlockIncompatible(c.Turbo.FinalityChoiceBlock, newcfg.Turbo.FinalityChoiceBlock, headNumber) {
+			return newBlockCompatError("Turbo finality fork choice block", c.Turbo.FinalityChoiceBlock, newcfg.Turbo.FinalityChoiceBlock)
+		}
+		if isForkBlockIncompatible(c.Turbo.StrictTimeBlock, newcfg.Turbo.StrictTimeBlock, headNumber) {
+			return newBlockCompatError("Turbo strict timestamps fork block", c.Turbo.StrictTimeBlock, newcfg.Turbo.StrictTimeBlock)
+		}
+		if isForkBlockIncompatible(c.Turbo.SystemGasBlock, newcfg.Turbo.SystemGasBlock, headNumber) {
+			return newBlockCompatError("Turbo system gas fork block", c.Turbo.SystemGasBlock, newcfg.Turbo.SystemGasBlock)
+		}
+		if isBlockForked(c.Turbo.SystemGasBlock, headNumber) && c.Turbo.SystemGasAllowance() != newcfg.Turbo.SystemGasAllowance() {
+			return newBlockCompatError("Turbo system gas limit", c.Turbo.SystemGasBlock, newcfg.Turbo.SystemGasBlock)
+		}
+		if isForkBlockIncompatible(c.Turbo.JailBlock, newcfg.Turbo.JailBlock, headNumber) {
+			return newBlockCompatError("Turbo validator jails fork block", c.Turbo.JailBlock, newcfg.Turbo.JailBlock)
+		}
+		for _, name := range scheduledNames(c.Turbo.Precompiles, newcfg.Turbo.Precompiles) {
+			stored, updated := c.Turbo.Precompiles[name], newcfg.Turbo.Precompiles[name]
+			if isForkBlockIncompatible(stored, updated, headNumber) {
+				return newBlockCompatError(fmt.Sprintf("Turbo precompile %q block", name), stored, updated)
+			}
+		}
+		if err := c.Turbo.Emission.checkCompatible(newcfg.Turbo.Emission, headNumber); err != nil {
+			return err
+		}
+		for _, name := range scheduledNames(c.Turbo.TxRules, newcfg.Turbo.TxRules) {
+			stored, updated := c.Turbo.TxRules[name], newcfg.Turbo.TxRules[name]
+			if isForkBlockIncompatible(stored, updated, headNumber) {
+				return newBlockCompatError(fmt.Sprintf("Turbo tx rule %q block", name), stored, updated)
+			}
+		}
+		for i := 0; i < max(len(c.Turbo.Schedule), len(newcfg.Turbo.Schedule)); i++ {
+			var stored, updated TurboSchedule
+			if i < len(c.Turbo.Schedule) {
+				stored = c.Turbo.Schedule[i]
+			}
+			if i < len(newcfg.Turbo.Schedule) {
+				updated = newcfg.Turbo.Schedule[i]
+			}
+			if isForkBlockIncompatible(stored.Block, updated.Block, headNumber) {
+				return newBlockCompatError(fmt.Sprintf("Turbo schedule entry %d block", i), stored.Block, updated.Block)
+			}
+			if isBlockForked(stored.Block, headNumber) && (stored.Period != updated.Period || stored.Epoch != updated.Epoch) {
+				return newBlockCompatError(fmt.Sprintf("Turbo schedule entry %d rules", i), stored.Block, updated.Block)
+			}
+		}
+	}
 	return nil
 }
 
@@ -858,6 +1285,55 @@ func (c *ChainConfig) IsTurboCompatible(newcfg *ChainConfig) bool {
 	return c.Turbo == nil && newcfg.Turbo == nil
 }
 
+// checkCompatible checks whether the updated emission config keeps the steps
+// already reached at head, and the decay rules once a step is reached.
+func (c *EmissionConfig) checkCompatible(newcfg *EmissionConfig, headNumber *big.Int) *ConfigCompatError {
+	var stored, updated []EmissionStep
+	if c != nil {
+		stored = c.Steps
+	}
+	if newcfg != nil {
+		updated = newcfg.Steps
+	}
+	for i := 0; i < max(len(stored), len(updated)); i++ {
+		var storedStep, updatedStep EmissionStep
+		if i < len(stored) {
+			storedStep = stored[i]
+		}
+		if i < len(updated) {
+			updatedStep = updated[i]
+		}
+		if isForkBlockIncompatible(storedStep.Block, updatedStep.Block, headNumber) {
+			return newBlockCompatError(fmt.Sprintf("Turbo emission step %d block", i), storedStep.Block, updatedStep.Block)
+		}
+		if isBlockForked(storedStep.Block, headNumber) && !configBlockEqual(storedStep.RewardsPerBlock, updatedStep.RewardsPerBlock) {
+			return newBlockCompatError(fmt.Sprintf("Turbo emission step %d rewards", i), storedStep.Block, updatedStep.Block)
+		}
+	}
+	if c != nil && newcfg != nil && (c.DecayInterval != newcfg.DecayInterval || c.DecayRate != newcfg.DecayRate) {
+		if step := c.step(headNumber.Uint64()); step != nil {
+			return newBlockCompatError("Turbo emission decay", step.Block, step.Block)
+		}
+	}
+	return nil
+}
+
+// scheduledNames returns the names of the precompiles or the rules scheduled
+// by either config, sorted.
+func scheduledNames(stored, updated map[string]*big.Int) []string {
+	names := make([]string, 0, len(stored)+len(updated))
+	for name := range stored {
+		names = append(names, name)
+	}
+	for name := range updated {
+		if _, ok := stored[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // isForkBlockIncompatible returns true if a fork scheduled at block s1 cannot be
 // rescheduled to block s2 because head is already past the fork.
 func isForkBlockIncompatible(s1, s2, head *big.Int) bool {
@@ -1000,6 +1476,10 @@ type Rules struct {
 	IsBerlin, IsLondon                                      bool
 	IsMerge, IsShanghai, IsCancun, IsPrague                 bool
 	IsVerkle                                                bool
+
+	// Precompiles are the names of the additional precompiled contracts
+	// enabled by the Turbo config, sorted.
+	Precompiles []string
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -1011,6 +1491,10 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool, timestamp uint64) Rules
 	// disallow setting Merge out of order
 	isMerge = isMerge && c.IsLondon(num)
 	isVerkle := isMerge && c.IsVerkle(num, timestamp)
+	var precompiles []string
+	if c.Turbo != nil {
+		precompiles = c.Turbo.ActivePrecompiles(num)
+	}
 	return Rules{
 		ChainID:          new(big.Int).Set(chainID),
 		IsHomestead:      c.IsHomestead(num),
@@ -1030,5 +1514,6 @@ func (c *ChainConfig) Rules(num *big.Int, isMerge bool, timestamp uint64) Rules
 		IsPrague:         c.IsPrague(num, timestamp),
 		IsVerkle:         isVerkle,
 		IsEIP4762:        isVerkle,
+		Precompiles:      precompiles,
 	}
 }
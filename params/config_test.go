@@ -155,3 +155,251 @@ func TestTimestampCompatError(t *testing.T) {
 	require.Equal(t, newTimestampCompatError(errWhat, newUint64(0), newUint64(1681338455)).Error(),
 		"mismatching Shanghai fork timestamp in database (have timestamp 0, want timestamp 1681338455, rewindto timestamp 0)")
 }
+
+func TestEmissionSchedule(t *testing.T) {
+	// Halving every two epochs from block 20, after a flat start at block 0.
+	config := &TurboConfig{Epoch: 10, Emission: &EmissionConfig{
+		Steps: []EmissionStep{
+			{Block: big.NewInt(20), RewardsPerBlock: big.NewInt(1000)},
+			{Block: big.NewInt(0), RewardsPerBlock: big.NewInt(300)},
+		},
+		DecayInterval: 2,
+		DecayRate:     500,
+	}}
+	for _, tt := range []struct {
+		number uint64
+		want   int64
+	}{
+		{0, 300}, {19, 300}, {20, 1000}, {39, 1000}, {40, 500}, {60, 250}, {79, 250}, {80, 125},
+	} {
+		if have := config.RewardsPerBlock(tt.number); have.Int64() != tt.want {
+			t.Errorf("block %d: rewards mismatch: have %v, want %d", tt.number, have, tt.want)
+		}
+	}
+	// The total supply emitted until the rewards run out is the sum of the
+	// steps: 300 for 20 blocks, then each halving for 20 blocks.
+	want := int64(300 * 20)
+	for rewards := int64(1000); rewards > 0; rewards /= 2 {
+		want += rewards * 20
+	}
+	if total := totalEmission(config); total.Int64() != want {
+		t.Errorf("total emission mismatch: have %v, want %d", total, want)
+	}
+	// No rewards are scheduled before the first step, or without emission.
+	config.Emission.Steps = config.Emission.Steps[:1]
+	if have := config.RewardsPerBlock(19); have != nil {
+		t.Errorf("rewards scheduled before the first step: %v", have)
+	}
+	if have := (&TurboConfig{Epoch: 10}).RewardsPerBlock(100); have != nil {
+		t.Errorf("rewards scheduled without emission: %v", have)
+	}
+}
+
+// Tests that the decays are anchored to the epochs since the step, so a change
+// of the epoch length doesn't rewrite the past rewards.
+func TestEmissionScheduleChange(t *testing.T) {
+	emission := &EmissionConfig{
+		Steps:         []EmissionStep{{Block: big.NewInt(0), RewardsPerBlock: big.NewInt(1000)}},
+		DecayInterval: 2,
+		DecayRate:     500,
+	}
+	var (
+		before = &TurboConfig{Epoch: 10, Emission: emission}
+		after  = &TurboConfig{Epoch: 10, Emission: emission, Schedule: []TurboSchedule{{Block: big.NewInt(40), Period: 1, Epoch: 5}}}
+	)
+	for number := uint64(0); number < 40; number++ {
+		if have, want := after.RewardsPerBlock(number), before.RewardsPerBlock(number); have.Cmp(want) != 0 {
+			t.Fatalf("block %d: rewards rewritten: have %v, want %v", number, have, want)
+		}
+	}
+	// 1000 and 500 for 20 blocks each, then each halving for 10 blocks
+	want := int64(1000*20 + 500*20)
+	for rewards := int64(250); rewards > 0; rewards /= 2 {
+		want += rewards * 10
+	}
+	if total := totalEmission(after); total.Int64() != want {
+		t.Errorf("total emission mismatch: have %v, want %d", total, want)
+	}
+}
+
+// totalEmission sums the rewards of the blocks until they run out.
+func totalEmission(config *TurboConfig) *big.Int {
+	total := new(big.Int)
+	for number := uint64(0); ; number++ {
+		rewards := config.RewardsPerBlock(number)
+		if rewards.Sign() == 0 {
+			return total
+		}
+		total.Add(total, rewards)
+	}
+}
+
+func TestEmissionCompatible(t *testing.T) {
+	stored := &ChainConfig{Turbo: &TurboConfig{Epoch: 10, Emission: &EmissionConfig{
+		Steps:         []EmissionStep{{Block: big.NewInt(10), RewardsPerBlock: big.NewInt(100)}},
+		DecayInterval: 2,
+		DecayRate:     500,
+	}}}
+	for i, tt := range []struct {
+		emission   *EmissionConfig
+		head       uint64
+		compatible bool
+	}{
+		{&EmissionConfig{Steps: []EmissionStep{{Block: big.NewInt(10), RewardsPerBlock: big.NewInt(100)}, {Block: big.NewInt(30), RewardsPerBlock: big.NewInt(50)}}, DecayInterval: 2, DecayRate: 500}, 20, true},
+		{&EmissionConfig{Steps: []EmissionStep{{Block: big.NewInt(20), RewardsPerBlock: big.NewInt(100)}}, DecayInterval: 2, DecayRate: 500}, 5, true},
+		{&EmissionConfig{Steps: []EmissionStep{{Block: big.NewInt(20), RewardsPerBlock: big.NewInt(100)}}, DecayInterval: 2, DecayRate: 500}, 10, false},
+		{&EmissionConfig{Steps: []EmissionStep{{Block: big.NewInt(10), RewardsPerBlock: big.NewInt(200)}}, DecayInterval: 2, DecayRate: 500}, 10, false},
+		{&EmissionConfig{Steps: []EmissionStep{{Block: big.NewInt(10), RewardsPerBlock: big.NewInt(100)}}, DecayInterval: 4, DecayRate: 500}, 5, true},
+		{&EmissionConfig{Steps: []EmissionStep{{Block: big.NewInt(10), RewardsPerBlock: big.NewInt(100)}}, DecayInterval: 4, DecayRate: 500}, 10, false},
+		{nil, 10, false},
+	} {
+		updated := &ChainConfig{Turbo: &TurboConfig{Epoch: 10, Emission: tt.emission}}
+		err := stored.CheckCompatible(updated, tt.head, 0)
+		if (err == nil) != tt.compatible {
+			t.Errorf("test %d: compatibility mismatch: have %v, want %v", i, err, tt.compatible)
+		}
+	}
+}
+
+func TestTurboSchedule(t *testing.T) {
+	// Epochs of 10 blocks at 3s, then of 4 blocks at 2s from block 20, then of
+	// 6 blocks at 1s from block 28.
+	config := &TurboConfig{
+		Period: 3,
+		Epoch:  10,
+		Schedule: []TurboSchedule{
+			{Block: big.NewInt(20), Period: 2, Epoch: 4},
+			{Block: big.NewInt(28), Period: 1, Epoch: 6},
+		},
+	}
+	if err := config.CheckSchedule(); err != nil {
+		t.Fatalf("valid schedule rejected: %v", err)
+	}
+	for _, tt := range []struct {
+		number      uint64
+		period      uint64
+		epoch       bool
+		last, index uint64
+	}{
+		{0, 3, true, 0, 0}, {10, 3, true, 10, 1}, {19, 3, false, 10, 1},
+		{20, 2, true, 20, 2}, {23, 2, false, 20, 2}, {24, 2, true, 24, 3},
+		{28, 1, true, 28, 4}, {33, 1, false, 28, 4}, {34, 1, true, 34, 5},
+	} {
+		if have := config.PeriodAt(tt.number); have != tt.period {
+			t.Errorf("block %d: period mismatch: have %d, want %d", tt.number, have, tt.period)
+		}
+		if have := config.IsEpoch(tt.number); have != tt.epoch {
+			t.Errorf("block %d: epoch block mismatch: have %v, want %v", tt.number, have, tt.epoch)
+		}
+		if have := config.LastEpoch(tt.number); have != tt.last {
+			t.Errorf("block %d: last epoch block mismatch: have %d, want %d", tt.number, have, tt.last)
+		}
+		if have := config.EpochIndex(tt.number); have != tt.index {
+			t.Errorf("block %d: epoch index mismatch: have %d, want %d", tt.number, have, tt.index)
+		}
+		if have := config.EpochStart(tt.index); have != tt.last {
+			t.Errorf("epoch %d: first block mismatch: have %d, want %d", tt.index, have, tt.last)
+		}
+	}
+	if have := config.EpochAlignment(); have != 2 {
+		t.Errorf("epoch alignment mismatch: have %d, want 2", have)
+	}
+	// The changes must start at epoch blocks, in ascending order
+	for i, schedule := range [][]TurboSchedule{
+		{{Block: big.NewInt(25), Period: 2, Epoch: 4}},
+		{{Block: big.NewInt(20), Period: 2, Epoch: 4}, {Block: big.NewInt(26), Period: 1, Epoch: 6}},
+		{{Block: big.NewInt(20), Period: 2, Epoch: 4}, {Block: big.NewInt(20), Period: 1, Epoch: 6}},
+		{{Block: big.NewInt(20), Period: 2, Epoch: 0}},
+		{{Period: 2, Epoch: 4}},
+	} {
+		config.Schedule = schedule
+		if err := config.CheckSchedule(); err == nil {
+			t.Errorf("schedule %d: invalid schedule accepted", i)
+		}
+	}
+}
+
+func TestTurboPrecompilesCompatible(t *testing.T) {
+	stored := &ChainConfig{Turbo: &TurboConfig{Precompiles: map[string]*big.Int{"a": big.NewInt(10)}}}
+	for i, tt := range []struct {
+		precompiles map[string]*big.Int
+		head        uint64
+		compatible  bool
+	}{
+		{map[string]*big.Int{"a": big.NewInt(10), "b": big.NewInt(20)}, 15, true},
+		{map[string]*big.Int{"a": big.NewInt(12)}, 5, true},
+		{map[string]*big.Int{"a": big.NewInt(12)}, 10, false},
+		{map[string]*big.Int{}, 10, false},
+		{map[string]*big.Int{"a": big.NewInt(10), "b": big.NewInt(5)}, 10, false},
+	} {
+		updated := &ChainConfig{Turbo: &TurboConfig{Precompiles: tt.precompiles}}
+		err := stored.CheckCompatible(updated, tt.head, 0)
+		if (err == nil) != tt.compatible {
+			t.Errorf("test %d: compatibility mismatch: have %v, want %v", i, err, tt.compatible)
+		}
+	}
+	if have := stored.Rules(big.NewInt(10), false, 0).Precompiles; len(have) != 1 || have[0] != "a" {
+		t.Fatalf("active precompiles mismatch: have %v", have)
+	}
+}
+
+func TestTurboTxRulesCompatible(t *testing.T) {
+	stored := &ChainConfig{Turbo: &TurboConfig{TxRules: map[string]*big.Int{"a": big.NewInt(10)}}}
+	for i, tt := range []struct {
+		rules      map[string]*big.Int
+		head       uint64
+		compatible bool
+	}{
+		{map[string]*big.Int{"a": big.NewInt(10), "b": big.NewInt(20)}, 15, true},
+		{map[string]*big.Int{"a": big.NewInt(12)}, 5, true},
+		{map[string]*big.Int{"a": big.NewInt(12)}, 10, false},
+		{map[string]*big.Int{}, 10, false},
+		{map[string]*big.Int{"a": big.NewInt(10), "b": big.NewInt(5)}, 10, false},
+	} {
+		updated := &ChainConfig{Turbo: &TurboConfig{TxRules: tt.rules}}
+		err := stored.CheckCompatible(updated, tt.head, 0)
+		if (err == nil) != tt.compatible {
+			t.Errorf("test %d: compatibility mismatch: have %v, want %v", i, err, tt.compatible)
+		}
+	}
+}
+
+func TestTurboCheckSystemGas(t *testing.T) {
+	for i, tt := range []struct {
+		limit uint64
+		valid bool
+	}{
+		{0, true},
+		{MinSystemGasLimit, true},
+		{MinSystemGasLimit - 1, false},
+		{1000, false},
+	} {
+		config := &ChainConfig{Turbo: &TurboConfig{Epoch: 200, SystemGasLimit: tt.limit}}
+		if err := config.CheckConfigForkOrder(); (err == nil) != tt.valid {
+			t.Errorf("test %d: validity mismatch: have %v, want %v", i, err, tt.valid)
+		}
+	}
+}
+
+func TestTurboSystemGasCompatible(t *testing.T) {
+	stored := &ChainConfig{Turbo: &TurboConfig{SystemGasBlock: big.NewInt(10)}}
+	for i, tt := range []struct {
+		block      *big.Int
+		limit      uint64
+		head       uint64
+		compatible bool
+	}{
+		{big.NewInt(10), DefaultSystemGasLimit, 15, true},
+		{big.NewInt(12), 0, 5, true},
+		{big.NewInt(10), 1000000, 5, true},
+		{big.NewInt(12), 0, 10, false},
+		{big.NewInt(10), 1000000, 10, false},
+		{nil, 0, 10, false},
+	} {
+		updated := &ChainConfig{Turbo: &TurboConfig{SystemGasBlock: tt.block, SystemGasLimit: tt.limit}}
+		err := stored.CheckCompatible(updated, tt.head, 0)
+		if (err == nil) != tt.compatible {
+			t.Errorf("test %d: compatibility mismatch: have %v, want %v", i, err, tt.compatible)
+		}
+	}
+}
@@ -160,6 +160,13 @@ const (
 	Bls12381MapG1Gas          uint64 = 5500  // Gas price for BLS12-381 mapping field element to G1 operation
 	Bls12381MapG2Gas          uint64 = 75000 // Gas price for BLS12-381 mapping field element to G2 operation
 
+	MulticallBaseGas    uint64 = 700 // Base price for a Nero multicall batch
+	MulticallPerWordGas uint64 = 3   // Per-word price for decoding a Nero multicall batch
+	MulticallPerCallGas uint64 = 100 // Per-call price of a Nero multicall batch, on top of the gas used by the call and the cold account access
+
+	DefaultSystemGasLimit uint64 = 10_000_000 // Default gas allowance of the Nero system transactions per block, once metered
+	MinSystemGasLimit     uint64 = 1_000_000  // Minimum gas allowance of the Nero system transactions per block, fitting a double sign punishment
+
 	// The Refund Quotient is the cap on how much of the used gas can be refunded. Before EIP-3529,
 	// up to half the consumed gas could be refunded. Redefined as 1/5th in EIP-3529
 	RefundQuotient        uint64 = 2
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// errcodeAuditFailure is returned for the calls refused because they couldn't be
+// recorded by the auditor.
+const errcodeAuditFailure = -32007
+
+// Auditor records the calls served by a server.
+type Auditor interface {
+	// Audit records the call before it is run, the call being refused if an
+	// error is returned. The returned function records the outcome of the call,
+	// the error being nil on success.
+	Audit(info PeerInfo, method string, params json.RawMessage) (func(err error), error)
+}
+
+// PeerCred contains the credentials of the process at the other end of a unix
+// socket connection.
+type PeerCred struct {
+	PID int32
+	UID uint32
+	GID uint32
+}
+
+type auditError struct{ err error }
+
+func (e *auditError) ErrorCode() int { return errcodeAuditFailure }
+
+func (e *auditError) Error() string {
+	return fmt.Sprintf("call refused, audit failed: %v", e.err)
+}
@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// errcodeUnauthorized is returned for the calls to namespaces restricted to
+// authenticated callers.
+const errcodeUnauthorized = -32006
+
+type principalContextKey struct{}
+
+// WithPrincipal returns a copy of the HTTP request context carrying the
+// identity of the caller, as authenticated by an HTTP middleware in front of
+// the server. The principal is reported in the PeerInfo of the connection.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func principalFromContext(ctx context.Context) string {
+	principal, _ := ctx.Value(principalContextKey{}).(string)
+	return principal
+}
+
+type unauthorizedError struct{ method string }
+
+func (e *unauthorizedError) ErrorCode() int { return errcodeUnauthorized }
+
+func (e *unauthorizedError) Error() string {
+	return fmt.Sprintf("the method %s requires authentication", e.method)
+}
+
+type restrictedError struct{ method string }
+
+func (e *restrictedError) ErrorCode() int { return errcodeUnauthorized }
+
+func (e *restrictedError) Error() string {
+	return fmt.Sprintf("the method %s is not available on this endpoint", e.method)
+}
+
+// authorized reports whether the caller may call the method: either all the
+// namespaces are public, the caller is authenticated, or the namespace of the
+// method is public.
+func authorized(public map[string]struct{}, info PeerInfo, method string) bool {
+	if public == nil || info.Principal != "" {
+		return true
+	}
+	namespace, _, _ := strings.Cut(method, serviceMethodSeparator)
+	_, ok := public[namespace]
+	return ok
+}
@@ -88,6 +88,10 @@ type Client struct {
 	// config fields
 	batchItemLimit       int
 	batchResponseMaxSize int
+	usage                *UsageTracker
+	publicModules        map[string]struct{}
+	restricted           map[string]struct{}
+	auditor              Auditor
 
 	// writeConn is used for writing to the connection on the caller's goroutine. It should
 	// only be accessed outside of dispatch, with the write lock held. The write lock is
@@ -120,6 +124,10 @@ func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	ctx = context.WithValue(ctx, clientContextKey{}, c)
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, conn.peerInfo())
 	handler := newHandler(ctx, conn, c.idgen, c.services, c.batchItemLimit, c.batchResponseMaxSize)
+	handler.usage = c.usage
+	handler.publicModules = c.publicModules
+	handler.restricted = c.restricted
+	handler.auditor = c.auditor
 	return &clientConn{conn, handler}
 }
 
@@ -247,6 +255,10 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 		idgen:                cfg.idgen,
 		batchItemLimit:       cfg.batchItemLimit,
 		batchResponseMaxSize: cfg.batchResponseLimit,
+		usage:                cfg.usage,
+		publicModules:        cfg.publicModules,
+		restricted:           cfg.restricted,
+		auditor:              cfg.auditor,
 		writeConn:            conn,
 		close:                make(chan struct{}),
 		closing:              make(chan struct{}),
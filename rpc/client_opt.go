@@ -41,6 +41,10 @@ type clientConfig struct {
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+	usage              *UsageTracker
+	publicModules      map[string]struct{}
+	restricted         map[string]struct{}
+	auditor            Auditor
 }
 
 func (cfg *clientConfig) initHeaders() {
@@ -43,10 +43,19 @@ func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, er
 	}
 	log.Debug("IPCs registered", "namespaces", strings.Join(registered, ","))
 	// All APIs registered, start the IPC listener.
-	listener, err := ipcListen(ipcEndpoint)
+	listener, err := ServeIPCEndpoint(ipcEndpoint, handler)
 	if err != nil {
 		return nil, nil, err
 	}
-	go handler.ServeListener(listener)
 	return listener, handler, nil
 }
+
+// ServeIPCEndpoint starts an IPC endpoint served by the given server.
+func ServeIPCEndpoint(ipcEndpoint string, handler *Server) (net.Listener, error) {
+	listener, err := ipcListen(ipcEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	go handler.ServeListener(listener)
+	return listener, nil
+}
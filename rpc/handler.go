@@ -19,12 +19,14 @@ package rpc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"reflect"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/internal/otel"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -62,6 +64,10 @@ type handler struct {
 	allowSubscribe       bool
 	batchRequestLimit    int
 	batchResponseMaxSize int
+	usage                *UsageTracker       // per-consumer accounting of served calls, nil if disabled
+	publicModules        map[string]struct{} // namespaces callable anonymously, nil if all are
+	restricted           map[string]struct{} // methods refused, served by another server
+	auditor              Auditor             // records the served calls, nil if disabled
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -491,6 +497,30 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if !authorized(h.publicModules, PeerInfoFromContext(cp.ctx), msg.Method) {
+		return msg.errorResponse(&unauthorizedError{method: msg.Method})
+	}
+	if _, ok := h.restricted[msg.Method]; ok {
+		return msg.errorResponse(&restrictedError{method: msg.Method})
+	}
+	if h.auditor == nil {
+		return h.serveCall(cp, msg)
+	}
+	done, err := h.auditor.Audit(PeerInfoFromContext(cp.ctx), msg.Method, msg.Params)
+	if err != nil {
+		return msg.errorResponse(&auditError{err: err})
+	}
+	answer := h.serveCall(cp, msg)
+	if answer.Error != nil {
+		done(answer.Error)
+	} else {
+		done(nil)
+	}
+	return answer
+}
+
+// serveCall runs the method call, or the subscription.
+func (h *handler) serveCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -509,7 +539,13 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 		return msg.errorResponse(&invalidParamsError{err.Error()})
 	}
 	start := time.Now()
-	answer := h.runMethod(cp.ctx, msg, callb, args)
+	ctx, span := otel.Start(cp.ctx, "rpc."+msg.Method, otel.String("rpc.system", "jsonrpc"), otel.String("rpc.method", msg.Method))
+	span.SetKind(otel.KindServer)
+	answer := h.runMethod(ctx, msg, callb, args)
+	if answer.Error != nil {
+		span.RecordError(errors.New(answer.Error.Message))
+	}
+	span.End()
 
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
@@ -522,6 +558,10 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 		}
 		rpcServingTimer.UpdateSince(start)
 		updateServeTimeHistogram(msg.Method, answer.Error == nil, time.Since(start))
+
+		if h.usage != nil {
+			h.usage.record(PeerInfoFromContext(cp.ctx), msg.Method, answer.Error != nil, time.Since(start), len(answer.Result))
+		}
 	}
 
 	return answer
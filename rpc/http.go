@@ -259,6 +259,9 @@ func (s *Server) newHTTPServerConn(r *http.Request, w http.ResponseWriter) Serve
 
 	encoder := func(v any, isErrorResponse bool) error {
 		if !isErrorResponse {
+			if batch, ok := v.([]*jsonrpcMessage); ok {
+				return writeBatch(conn, batch)
+			}
 			return json.NewEncoder(conn).Encode(v)
 		}
 
@@ -323,6 +326,10 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	connInfo.HTTP.Host = r.Host
 	connInfo.HTTP.Origin = r.Header.Get("Origin")
 	connInfo.HTTP.UserAgent = r.Header.Get("User-Agent")
+	connInfo.Principal = principalFromContext(r.Context())
+	if s.usageHeader != "" {
+		connInfo.HTTP.Consumer = r.Header.Get(s.usageHeader)
+	}
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, connInfo)
 
@@ -17,12 +17,14 @@
 package rpc
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"reflect"
 	"strings"
 	"sync"
@@ -37,6 +39,8 @@ const (
 	notificationMethodSuffix = "_subscription"
 
 	defaultWriteTimeout = 10 * time.Second // used if context has no deadline
+
+	batchWriteBuffer = 4096 // buffer size of the streamed batch responses
 )
 
 var null = json.RawMessage("null")
@@ -179,6 +183,7 @@ type ConnRemoteAddr interface {
 // support for parsing arguments and serializing (result) objects.
 type jsonCodec struct {
 	remote  string
+	cred    *PeerCred        // credentials of the peer process of unix socket connections
 	closer  sync.Once        // close closed channel once
 	closeCh chan interface{} // closed on Close
 	decode  decodeFunc       // decoder to allow multiple transports
@@ -204,6 +209,9 @@ func NewFuncCodec(conn deadlineCloser, encode encodeFunc, decode decodeFunc) Ser
 	if ra, ok := conn.(ConnRemoteAddr); ok {
 		codec.remote = ra.RemoteAddr()
 	}
+	if uc, ok := conn.(*net.UnixConn); ok {
+		codec.cred = unixPeerCred(uc)
+	}
 	return codec
 }
 
@@ -215,14 +223,39 @@ func NewCodec(conn Conn) ServerCodec {
 	dec.UseNumber()
 
 	encode := func(v interface{}, isErrorResponse bool) error {
+		if batch, ok := v.([]*jsonrpcMessage); ok {
+			return writeBatch(conn, batch)
+		}
 		return enc.Encode(v)
 	}
 	return NewFuncCodec(conn, encode, dec.Decode)
 }
 
+// writeBatch streams the batch of messages to w, encoding the messages one by one
+// instead of buffering the encoding of the whole batch, which can be very large
+// for trace or log queries.
+func writeBatch(w io.Writer, batch []*jsonrpcMessage) error {
+	bw := bufio.NewWriterSize(w, batchWriteBuffer)
+	bw.WriteByte('[')
+	for i, msg := range batch {
+		if i > 0 {
+			bw.WriteByte(',')
+		}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		if _, err := bw.Write(data); err != nil {
+			return err
+		}
+	}
+	bw.WriteString("]\n")
+	return bw.Flush()
+}
+
 func (c *jsonCodec) peerInfo() PeerInfo {
 	// This returns "ipc" because all other built-in transports have a separate codec type.
-	return PeerInfo{Transport: "ipc", RemoteAddr: c.remote}
+	return PeerInfo{Transport: "ipc", RemoteAddr: c.remote, Cred: c.cred}
 }
 
 func (c *jsonCodec) remoteAddr() string {
@@ -0,0 +1,28 @@
+//go:build linux
+
+package rpc
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// unixPeerCred retrieves the credentials of the peer process of the connection,
+// nil if unavailable.
+func unixPeerCred(conn *net.UnixConn) *PeerCred {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return nil
+	}
+	var (
+		cred    *unix.Ucred
+		credErr error
+	)
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil || credErr != nil {
+		return nil
+	}
+	return &PeerCred{PID: cred.Pid, UID: cred.Uid, GID: cred.Gid}
+}
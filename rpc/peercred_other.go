@@ -0,0 +1,11 @@
+//go:build !linux
+
+package rpc
+
+import "net"
+
+// unixPeerCred retrieves the credentials of the peer process of the connection,
+// only supported on Linux.
+func unixPeerCred(conn *net.UnixConn) *PeerCred {
+	return nil
+}
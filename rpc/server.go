@@ -52,6 +52,12 @@ type Server struct {
 	batchItemLimit     int
 	batchResponseLimit int
 	httpBodyLimit      int
+	usage              *UsageTracker
+	usageHeader        string
+	wsCompression      bool
+	publicModules      map[string]struct{} // namespaces callable anonymously, nil if all are
+	restricted         map[string]struct{} // methods refused by the server
+	auditor            Auditor             // records the served calls, nil if disabled
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -87,6 +93,59 @@ func (s *Server) SetHTTPBodyLimit(limit int) {
 	s.httpBodyLimit = limit
 }
 
+// SetUsageTracker enables per-consumer usage accounting of the served calls.
+// Consumers are identified by the value of the given HTTP header, or by their
+// IP address if the header is empty or not sent.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetUsageTracker(tracker *UsageTracker, header string) {
+	s.usage = tracker
+	s.usageHeader = header
+}
+
+// SetWebsocketCompression enables the negotiation of permessage-deflate on the
+// WebSocket connections, compressing the large messages sent to the clients
+// supporting it.
+//
+// This method should be called before processing any requests via WebsocketHandler.
+func (s *Server) SetWebsocketCompression(enabled bool) {
+	s.wsCompression = enabled
+}
+
+// SetPublicModules restricts the unauthenticated callers to the given namespaces,
+// the other ones requiring a caller authenticated through WithPrincipal.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetPublicModules(modules []string) {
+	s.publicModules = make(map[string]struct{}, len(modules))
+	for _, module := range modules {
+		s.publicModules[module] = struct{}{}
+	}
+}
+
+// SetRestrictedMethods refuses the calls to the given methods, served by another
+// server only.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetRestrictedMethods(methods []string) {
+	s.restricted = make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		s.restricted[method] = struct{}{}
+	}
+}
+
+// SetAuditor records all the calls served by the server with the auditor, the
+// calls failing to be recorded being refused.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetAuditor(auditor Auditor) {
+	s.auditor = auditor
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -112,6 +171,10 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		usage:              s.usage,
+		publicModules:      s.publicModules,
+		restricted:         s.restricted,
+		auditor:            s.auditor,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -147,6 +210,10 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit)
 	h.allowSubscribe = false
+	h.usage = s.usage
+	h.publicModules = s.publicModules
+	h.restricted = s.restricted
+	h.auditor = s.auditor
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -210,6 +277,14 @@ type PeerInfo struct {
 	// Address of client. This will usually contain the IP address and port.
 	RemoteAddr string
 
+	// Identity of the caller authenticated by the HTTP transport, empty for
+	// anonymous callers.
+	Principal string
+
+	// Credentials of the peer process of unix socket connections, nil for the
+	// other transports or if unavailable.
+	Cred *PeerCred
+
 	// Additional information for HTTP and WebSocket connections.
 	HTTP struct {
 		// Protocol version, i.e. "HTTP/1.1". This is not set for WebSocket.
@@ -218,6 +293,9 @@ type PeerInfo struct {
 		UserAgent string
 		Origin    string
 		Host      string
+
+		// Value of the server's consumer identification header, if configured.
+		Consumer string
 	}
 }
 
@@ -0,0 +1,198 @@
+package rpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// usageMetricsPrefix is the prefix of the per-consumer usage metrics.
+	usageMetricsPrefix = "rpc/usage/"
+
+	// maxConsumerIDLength caps the length of client supplied consumer identifiers.
+	maxConsumerIDLength = 64
+)
+
+// ConsumerUsage is the resource usage accounted to a single RPC consumer.
+type ConsumerUsage struct {
+	Consumer      string                  `json:"consumer"`
+	Requests      uint64                  `json:"requests"`
+	Failures      uint64                  `json:"failures"`
+	ComputeTime   float64                 `json:"computeTime"`   // Seconds spent serving the requests
+	ResponseBytes uint64                  `json:"responseBytes"` // Size of the returned results
+	LastSeen      time.Time               `json:"lastSeen"`
+	Methods       map[string]*MethodUsage `json:"methods"`
+}
+
+// MethodUsage is the resource usage of a single method by a consumer.
+type MethodUsage struct {
+	Requests      uint64  `json:"requests"`
+	Failures      uint64  `json:"failures"`
+	ComputeTime   float64 `json:"computeTime"`
+	ResponseBytes uint64  `json:"responseBytes"`
+}
+
+// consumerMeters are the metrics exported for a single consumer.
+type consumerMeters struct {
+	requests metrics.Counter
+	failures metrics.Counter
+	time     metrics.Counter // Nanoseconds
+	bytes    metrics.Counter
+}
+
+type consumerEntry struct {
+	usage  ConsumerUsage
+	meters consumerMeters
+	names  []string // Registered metric names, unregistered on eviction
+}
+
+// UsageTracker accounts request counts, compute time and returned bytes per
+// RPC consumer. Consumers are identified by their authenticated principal,
+// falling back to the value of a configurable HTTP header (e.g. an API key) and
+// to the remote IP address. Only the most recently active consumers are retained.
+type UsageTracker struct {
+	lock      sync.Mutex
+	limit     int
+	consumers lru.BasicLRU[string, *consumerEntry]
+}
+
+// NewUsageTracker creates a usage tracker retaining at most limit consumers.
+func NewUsageTracker(limit int) *UsageTracker {
+	return &UsageTracker{
+		limit:     limit,
+		consumers: lru.NewBasicLRU[string, *consumerEntry](limit),
+	}
+}
+
+// consumerID derives the accounting identity of the remote end of a connection,
+// and the name identifying it in the metric names. The client supplied header
+// values are hashed in the metric names, so that neither the keys leak into
+// the metrics nor arbitrary names can be injected.
+func consumerID(info PeerInfo) (id string, metric string) {
+	if info.Principal != "" {
+		return info.Principal, metricSafeName(info.Principal)
+	}
+	if id := info.HTTP.Consumer; id != "" {
+		if len(id) > maxConsumerIDLength {
+			id = id[:maxConsumerIDLength]
+		}
+		hash := sha256.Sum256([]byte(id))
+		return id, "key-" + hex.EncodeToString(hash[:8])
+	}
+	id = info.RemoteAddr
+	if host, _, err := net.SplitHostPort(info.RemoteAddr); err == nil {
+		id = host
+	}
+	return id, metricSafeName(id)
+}
+
+// metricSafeName replaces the characters of name not allowed in a metric name
+// component, and caps its length.
+func metricSafeName(name string) string {
+	if len(name) > maxConsumerIDLength {
+		name = name[:maxConsumerIDLength]
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// record accounts a served call to the consumer identified by info.
+func (t *UsageTracker) record(info PeerInfo, method string, failed bool, elapsed time.Duration, size int) {
+	id, metric := consumerID(info)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	entry, ok := t.consumers.Get(id)
+	if !ok {
+		entry = t.newEntry(id, metric)
+	}
+	entry.usage.LastSeen = time.Now()
+	entry.usage.Requests++
+	entry.usage.ComputeTime += elapsed.Seconds()
+	entry.usage.ResponseBytes += uint64(size)
+	if failed {
+		entry.usage.Failures++
+	}
+	m := entry.usage.Methods[method]
+	if m == nil {
+		m = new(MethodUsage)
+		entry.usage.Methods[method] = m
+	}
+	m.Requests++
+	m.ComputeTime += elapsed.Seconds()
+	m.ResponseBytes += uint64(size)
+	if failed {
+		m.Failures++
+	}
+	entry.meters.requests.Inc(1)
+	entry.meters.time.Inc(elapsed.Nanoseconds())
+	entry.meters.bytes.Inc(int64(size))
+	if failed {
+		entry.meters.failures.Inc(1)
+	}
+}
+
+// newEntry creates and inserts the accounting entry of a new consumer, evicting
+// the least recently active one if the tracker is full. The caller must hold
+// the lock.
+func (t *UsageTracker) newEntry(id string, metric string) *consumerEntry {
+	if t.consumers.Len() >= t.limit {
+		_, evicted, _ := t.consumers.RemoveOldest()
+		for _, name := range evicted.names {
+			metrics.Unregister(name)
+		}
+	}
+	var (
+		prefix = usageMetricsPrefix + metric + "/"
+		entry  = &consumerEntry{
+			usage: ConsumerUsage{Consumer: id, Methods: make(map[string]*MethodUsage)},
+			names: []string{prefix + "requests", prefix + "failures", prefix + "time", prefix + "bytes"},
+		}
+	)
+	entry.meters = consumerMeters{
+		requests: metrics.GetOrRegisterCounter(entry.names[0], nil),
+		failures: metrics.GetOrRegisterCounter(entry.names[1], nil),
+		time:     metrics.GetOrRegisterCounter(entry.names[2], nil),
+		bytes:    metrics.GetOrRegisterCounter(entry.names[3], nil),
+	}
+	t.consumers.Add(id, entry)
+	return entry
+}
+
+// Usage returns a copy of the accounted usage of the tracked consumers, the
+// heaviest ones by compute time first.
+func (t *UsageTracker) Usage() []*ConsumerUsage {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	usage := make([]*ConsumerUsage, 0, t.consumers.Len())
+	for _, id := range t.consumers.Keys() {
+		entry, _ := t.consumers.Peek(id)
+		u := entry.usage
+		u.Methods = make(map[string]*MethodUsage, len(entry.usage.Methods))
+		for method, m := range entry.usage.Methods {
+			mu := *m
+			u.Methods[method] = &mu
+		}
+		usage = append(usage, &u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		return usage[i].ComputeTime > usage[j].ComputeTime
+	})
+	return usage
+}
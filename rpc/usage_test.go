@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUsageTracking(t *testing.T) {
+	tracker := NewUsageTracker(1)
+
+	s := newTestServer()
+	s.SetUsageTracker(tracker, "X-Api-Key")
+	defer s.Stop()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	c, err := Dial(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.SetHeader("X-Api-Key", "alice")
+	for i := 0; i < 2; i++ {
+		var res string
+		if err := c.Call(&res, "test_repeat", "x", 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.Call(nil, "test_returnError"); err == nil {
+		t.Fatal("expected error")
+	}
+	usage := tracker.Usage()
+	if len(usage) != 1 {
+		t.Fatalf("wrong number of consumers: have %d, want 1", len(usage))
+	}
+	alice := usage[0]
+	if alice.Consumer != "alice" {
+		t.Errorf("wrong consumer: have %q, want %q", alice.Consumer, "alice")
+	}
+	if alice.Requests != 3 || alice.Failures != 1 {
+		t.Errorf("wrong request counts: have %d/%d, want 3/1", alice.Requests, alice.Failures)
+	}
+	if have := alice.Methods["test_repeat"].ResponseBytes; have != 2*uint64(len(`"xxx"`)) {
+		t.Errorf("wrong response bytes: have %d, want %d", have, 2*len(`"xxx"`))
+	}
+
+	// Consumers without the header are identified by IP, evicting alice.
+	c.SetHeader("X-Api-Key", "")
+	if err := c.Call(nil, "test_noArgsRets"); err != nil {
+		t.Fatal(err)
+	}
+	usage = tracker.Usage()
+	if len(usage) != 1 || usage[0].Consumer != "127.0.0.1" {
+		t.Fatalf("wrong consumers after eviction: %v", usage)
+	}
+}
+
+func TestConsumerID(t *testing.T) {
+	withHeader := func(info PeerInfo, header string) PeerInfo {
+		info.HTTP.Consumer = header
+		return info
+	}
+	tests := []struct {
+		info         PeerInfo
+		id, metric   string
+		hashedMetric bool
+	}{
+		{info: PeerInfo{RemoteAddr: "10.0.0.1:30303"}, id: "10.0.0.1", metric: "10.0.0.1"},
+		{info: PeerInfo{RemoteAddr: "[::1]:30303"}, id: "::1", metric: "__1"},
+		{info: withHeader(PeerInfo{RemoteAddr: "10.0.0.1:30303", Principal: "ops/alice"}, "key"), id: "ops/alice", metric: "ops_alice"},
+		{info: withHeader(PeerInfo{RemoteAddr: "10.0.0.1:30303"}, "secret/../key"), id: "secret/../key", hashedMetric: true},
+	}
+	for i, tt := range tests {
+		id, metric := consumerID(tt.info)
+		if id != tt.id {
+			t.Errorf("test %d: id mismatch: have %q, want %q", i, id, tt.id)
+		}
+		if tt.hashedMetric {
+			if !strings.HasPrefix(metric, "key-") || strings.Contains(metric, "secret") || metricSafeName(metric) != metric {
+				t.Errorf("test %d: metric name not hashed: %q", i, metric)
+			}
+		} else if metric != tt.metric {
+			t.Errorf("test %d: metric mismatch: have %q, want %q", i, metric, tt.metric)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package rpc
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -39,6 +40,11 @@ const (
 	wsPingWriteTimeout = 5 * time.Second
 	wsPongTimeout      = 30 * time.Second
 	wsDefaultReadLimit = 32 * 1024 * 1024
+
+	// wsCompressionThreshold is the minimum size of the messages compressed on
+	// the connections which negotiated permessage-deflate. The batches are
+	// always compressed, their size is unknown until streamed.
+	wsCompressionThreshold = 1024
 )
 
 var wsBufferPool = new(sync.Pool)
@@ -49,10 +55,11 @@ var wsBufferPool = new(sync.Pool)
 // To allow connections with any origin, pass "*".
 func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 	var upgrader = websocket.Upgrader{
-		ReadBufferSize:  wsReadBuffer,
-		WriteBufferSize: wsWriteBuffer,
-		WriteBufferPool: wsBufferPool,
-		CheckOrigin:     wsHandshakeValidator(allowedOrigins),
+		ReadBufferSize:    wsReadBuffer,
+		WriteBufferSize:   wsWriteBuffer,
+		WriteBufferPool:   wsBufferPool,
+		CheckOrigin:       wsHandshakeValidator(allowedOrigins),
+		EnableCompression: s.wsCompression,
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		conn, err := upgrader.Upgrade(w, r, nil)
@@ -61,6 +68,10 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			return
 		}
 		codec := newWebsocketCodec(conn, r.Host, r.Header, wsDefaultReadLimit)
+		if s.usageHeader != "" {
+			codec.(*websocketCodec).info.HTTP.Consumer = r.Header.Get(s.usageHeader)
+		}
+		codec.(*websocketCodec).info.Principal = principalFromContext(r.Context())
 		s.ServeCodec(codec, 0)
 	})
 }
@@ -225,10 +236,11 @@ func newClientTransportWS(endpoint string, cfg *clientConfig) (reconnectFunc, er
 	dialer := cfg.wsDialer
 	if dialer == nil {
 		dialer = &websocket.Dialer{
-			ReadBufferSize:  wsReadBuffer,
-			WriteBufferSize: wsWriteBuffer,
-			WriteBufferPool: wsBufferPool,
-			Proxy:           http.ProxyFromEnvironment,
+			ReadBufferSize:    wsReadBuffer,
+			WriteBufferSize:   wsWriteBuffer,
+			WriteBufferPool:   wsBufferPool,
+			Proxy:             http.ProxyFromEnvironment,
+			EnableCompression: true,
 		}
 	}
 
@@ -294,7 +306,24 @@ type websocketCodec struct {
 func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readLimit int64) ServerCodec {
 	conn.SetReadLimit(readLimit)
 	encode := func(v interface{}, isErrorResponse bool) error {
-		return conn.WriteJSON(v)
+		if batch, ok := v.([]*jsonrpcMessage); ok {
+			conn.EnableWriteCompression(true)
+			w, err := conn.NextWriter(websocket.TextMessage)
+			if err != nil {
+				return err
+			}
+			if err := writeBatch(w, batch); err != nil {
+				w.Close()
+				return err
+			}
+			return w.Close()
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		conn.EnableWriteCompression(len(data) >= wsCompressionThreshold)
+		return conn.WriteMessage(websocket.TextMessage, data)
 	}
 	wc := &websocketCodec{
 		jsonCodec:    NewFuncCodec(conn, encode, conn.ReadJSON).(*jsonCodec),
@@ -113,6 +113,64 @@ func TestWebsocketLargeCall(t *testing.T) {
 	}
 }
 
+// This test checks that permessage-deflate is negotiated when enabled, and that
+// small and large messages as well as batches go through compressed connections.
+func TestWebsocketCompression(t *testing.T) {
+	t.Parallel()
+
+	for _, enabled := range []bool{false, true} {
+		srv := newTestServer()
+		srv.SetWebsocketCompression(enabled)
+		httpsrv := httptest.NewServer(srv.WebsocketHandler([]string{"*"}))
+		wsURL := "ws:" + strings.TrimPrefix(httpsrv.URL, "http:")
+
+		// Check the extension negotiation.
+		dialer := websocket.Dialer{EnableCompression: true}
+		conn, resp, err := dialer.Dial(wsURL, nil)
+		if err != nil {
+			t.Fatalf("can't dial: %v", err)
+		}
+		negotiated := resp.Header.Get("Sec-Websocket-Extensions")
+		conn.Close()
+		if have := strings.Contains(negotiated, "permessage-deflate"); have != enabled {
+			t.Errorf("compression %v: extension negotiated %v (%q)", enabled, have, negotiated)
+		}
+		client, err := DialWebsocket(context.Background(), wsURL, "")
+		if err != nil {
+			t.Fatalf("can't dial: %v", err)
+		}
+		for _, arg := range []string{"x", strings.Repeat("x", 4*wsCompressionThreshold)} {
+			var result echoResult
+			if err := client.Call(&result, "test_echo", arg, 1); err != nil {
+				t.Fatalf("compression %v: call failed: %v", enabled, err)
+			}
+			if result.String != arg {
+				t.Fatalf("compression %v: wrong string echoed", enabled)
+			}
+		}
+		batch := []BatchElem{
+			{Method: "test_echo", Args: []any{"x", 1}, Result: new(echoResult)},
+			{Method: "test_echo", Args: []any{strings.Repeat("y", 4*wsCompressionThreshold), 2}, Result: new(echoResult)},
+			{Method: "no_such_method", Result: new(echoResult)},
+		}
+		if err := client.BatchCall(batch); err != nil {
+			t.Fatalf("compression %v: batch call failed: %v", enabled, err)
+		}
+		if batch[0].Error != nil || batch[0].Result.(*echoResult).String != "x" {
+			t.Errorf("compression %v: wrong first batch result: %v", enabled, batch[0].Error)
+		}
+		if batch[1].Error != nil || batch[1].Result.(*echoResult).Int != 2 {
+			t.Errorf("compression %v: wrong second batch result: %v", enabled, batch[1].Error)
+		}
+		if batch[2].Error == nil {
+			t.Errorf("compression %v: no error for unknown method in batch", enabled)
+		}
+		client.Close()
+		srv.Stop()
+		httpsrv.Close()
+	}
+}
+
 // This test checks whether the wsMessageSizeLimit option is obeyed.
 func TestWebsocketLargeRead(t *testing.T) {
 	t.Parallel()
@@ -44,9 +44,9 @@ const (
 	// numberOfAccountsToDerive For hardware wallets, the number of accounts to derive
 	numberOfAccountsToDerive = 10
 	// ExternalAPIVersion -- see extapi_changelog.md
-	ExternalAPIVersion = "6.1.0"
+	ExternalAPIVersion = "6.2.0"
 	// InternalAPIVersion -- see intapi_changelog.md
-	InternalAPIVersion = "7.0.1"
+	InternalAPIVersion = "7.1.0"
 )
 
 // ExternalAPI defines the external API through which signing requests are made.
@@ -243,6 +243,19 @@ type (
 		Callinfo    []apitypes.ValidationInfo `json:"call_info"`
 		Hash        hexutil.Bytes             `json:"hash"`
 		Meta        Metadata                  `json:"meta"`
+		Consensus   *ConsensusMessage         `json:"consensus,omitempty"` // Set for consensus messages
+	}
+	// ConsensusMessage describes a Turbo header or attestation to sign, so that
+	// rules can enforce signing policies, e.g. never signing twice at a height.
+	ConsensusMessage struct {
+		Kind         string          `json:"kind"`   // "header" or "attestation"
+		Number       uint64          `json:"number"` // Header number or attestation target number
+		ParentHash   *common.Hash    `json:"parentHash,omitempty"`
+		Coinbase     *common.Address `json:"coinbase,omitempty"`
+		Time         uint64          `json:"time,omitempty"`
+		SourceNumber uint64          `json:"sourceNumber,omitempty"`
+		SourceHash   *common.Hash    `json:"sourceHash,omitempty"`
+		TargetHash   *common.Hash    `json:"targetHash,omitempty"`
 	}
 	SignDataResponse struct {
 		Approved bool `json:"approved"`
@@ -300,6 +300,14 @@ var (
 		accounts.MimetypeClique,
 		0x02,
 	}
+	ApplicationTurbo = SigFormat{
+		accounts.MimetypeTurbo,
+		0x02,
+	}
+	ApplicationTurboAttestation = SigFormat{
+		accounts.MimetypeTurboAttestation,
+		0x02,
+	}
 	TextPlain = SigFormat{
 		accounts.MimetypeTextPlain,
 		0x45,
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"mime"
 
 	"github.com/ethereum/go-ethereum/accounts"
@@ -164,6 +165,67 @@ func (api *SignerAPI) determineSignatureFormat(ctx context.Context, contentType
 		// Clique uses V on the form 0 or 1
 		useEthereumV = false
 		req = &SignDataRequest{ContentType: mediaType, Rawdata: cliqueRlp, Messages: messages, Hash: sighash}
+	case apitypes.ApplicationTurbo.Mime:
+		// Turbo sends the header without its seal, ready for hashing
+		turboData, err := fromHex(data)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+		header := new(turboSigHeader)
+		if err := rlp.DecodeBytes(turboData, header); err != nil {
+			return nil, useEthereumV, err
+		}
+		messages := []*apitypes.NameValueType{
+			{
+				Name:  "Turbo header",
+				Typ:   "turbo",
+				Value: fmt.Sprintf("turbo header %d [parent %#x]", header.Number, header.ParentHash),
+			},
+		}
+		// Turbo uses V on the form 0 or 1
+		useEthereumV = false
+		req = &SignDataRequest{ContentType: mediaType, Rawdata: turboData, Messages: messages, Hash: crypto.Keccak256(turboData)}
+		req.Consensus = &ConsensusMessage{
+			Kind:       "header",
+			Number:     header.Number.Uint64(),
+			ParentHash: &header.ParentHash,
+			Coinbase:   &header.Coinbase,
+			Time:       header.Time,
+		}
+	case apitypes.ApplicationTurboAttestation.Mime:
+		// Attestations vote for a target block justifying a source one
+		attestation, err := fromHex(data)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+		if len(attestation) != 4*common.HashLength {
+			return nil, useEthereumV, fmt.Errorf("invalid attestation length %d", len(attestation))
+		}
+		var (
+			sourceHash   = common.BytesToHash(attestation[:common.HashLength])
+			targetHash   = common.BytesToHash(attestation[common.HashLength : 2*common.HashLength])
+			sourceNumber = new(big.Int).SetBytes(attestation[2*common.HashLength : 3*common.HashLength])
+			targetNumber = new(big.Int).SetBytes(attestation[3*common.HashLength:])
+		)
+		if !sourceNumber.IsUint64() || !targetNumber.IsUint64() {
+			return nil, useEthereumV, errors.New("attestation number out of range")
+		}
+		messages := []*apitypes.NameValueType{
+			{
+				Name:  "Turbo attestation",
+				Typ:   "turbo",
+				Value: fmt.Sprintf("turbo attestation %d [%#x] -> %d [%#x]", sourceNumber, sourceHash, targetNumber, targetHash),
+			},
+		}
+		useEthereumV = false
+		req = &SignDataRequest{ContentType: mediaType, Rawdata: attestation, Messages: messages, Hash: crypto.Keccak256(attestation)}
+		req.Consensus = &ConsensusMessage{
+			Kind:         "attestation",
+			Number:       targetNumber.Uint64(),
+			SourceNumber: sourceNumber.Uint64(),
+			SourceHash:   &sourceHash,
+			TargetHash:   &targetHash,
+		}
 	case apitypes.DataTyped.Mime:
 		// EIP-712 conformant typed data
 		var err error
@@ -202,6 +264,26 @@ func SignTextValidator(validatorData apitypes.ValidatorData) (hexutil.Bytes, str
 	return crypto.Keccak256([]byte(msg)), msg
 }
 
+// turboSigHeader is the header signed by Turbo validators: the header without
+// the base fee and with the seal stripped from the extra data.
+type turboSigHeader struct {
+	ParentHash  common.Hash
+	UncleHash   common.Hash
+	Coinbase    common.Address
+	Root        common.Hash
+	TxHash      common.Hash
+	ReceiptHash common.Hash
+	Bloom       types.Bloom
+	Difficulty  *big.Int
+	Number      *big.Int
+	GasLimit    uint64
+	GasUsed     uint64
+	Time        uint64
+	Extra       []byte
+	MixDigest   common.Hash
+	Nonce       types.BlockNonce
+}
+
 // cliqueHeaderHashAndRlp returns the hash which is used as input for the proof-of-authority
 // signing. It is the hash of the entire header apart from the 65 byte signature
 // contained at the end of the extra data.
@@ -31,6 +31,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus/turbo"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
@@ -248,6 +250,54 @@ func TestSignData(t *testing.T) {
 	}
 }
 
+func TestSignTurbo(t *testing.T) {
+	t.Parallel()
+	api, control := setup(t)
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := common.NewMixedcaseAddress(list[0])
+
+	header := &types.Header{
+		ParentHash: common.Hash{1},
+		Number:     big.NewInt(100),
+		Difficulty: big.NewInt(1),
+		Time:       1000,
+		Extra:      make([]byte, 32+crypto.SignatureLength),
+	}
+	source := &types.RangeEdge{Hash: common.Hash{2}, Number: big.NewInt(90)}
+	target := &types.RangeEdge{Hash: common.Hash{3}, Number: big.NewInt(99)}
+
+	for _, tt := range []struct {
+		mime string
+		data []byte
+	}{
+		{apitypes.ApplicationTurbo.Mime, turbo.TurboRLP(header)},
+		{apitypes.ApplicationTurboAttestation.Mime, types.AttestationData(source, target)},
+	} {
+		control.approveCh <- "Y"
+		control.inputCh <- "a_long_password"
+		signature, err := api.SignData(context.Background(), tt.mime, a, hexutil.Encode(tt.data))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.mime, err)
+		}
+		// Consensus signatures use V on the form 0 or 1
+		pub, err := crypto.SigToPub(crypto.Keccak256(tt.data), signature)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.mime, err)
+		}
+		if have := crypto.PubkeyToAddress(*pub); have != a.Address() {
+			t.Errorf("%s: wrong signer: have %x, want %x", tt.mime, have, a.Address())
+		}
+	}
+	if _, err := api.SignData(context.Background(), apitypes.ApplicationTurboAttestation.Mime, a, hexutil.Encode([]byte{1})); err == nil {
+		t.Error("expected error for truncated attestation")
+	}
+}
+
 func TestDomainChainId(t *testing.T) {
 	t.Parallel()
 	withoutChainID := apitypes.TypedData{
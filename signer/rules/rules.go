@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/dop251/goja"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -48,6 +49,10 @@ type rulesetUI struct {
 	next    core.UIClientAPI // The next handler, for manual processing
 	storage storage.Storage
 	jsRules string // The rules to use
+
+	// lock serializes the rule evaluations, so that rules reading and updating
+	// the storage, e.g. to only sign increasing heights, can't race.
+	lock sync.Mutex
 }
 
 func NewRuleEvaluator(next core.UIClientAPI, jsbackend storage.Storage) (*rulesetUI, error) {
@@ -134,6 +139,9 @@ func (r *rulesetUI) checkApproval(jsfunc string, jsarg []byte, err error) (bool,
 	if err != nil {
 		return false, err
 	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
 	v, err := r.execute(jsfunc, string(jsarg))
 	if err != nil {
 		log.Info("error occurred during execution", "error", err)
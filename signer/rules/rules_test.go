@@ -624,3 +624,45 @@ function ApproveSignData(r){
 		t.Fatalf("Expected approved")
 	}
 }
+
+func TestConsensusSigningRule(t *testing.T) {
+	t.Parallel()
+	js := `
+	function ApproveSignData(req) {
+		if (!req.consensus) {
+			return "Reject"
+		}
+		var key = "last-" + req.consensus.kind
+		var last = storage.get(key)
+		if (last != "" && req.consensus.number <= parseInt(last)) {
+			return "Reject"
+		}
+		storage.put(key, String(req.consensus.number))
+		return "Approve"
+	}
+`
+	r, err := initRuleEngine(js)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tt := range []struct {
+		kind   string
+		number uint64
+		want   bool
+	}{
+		{"header", 10, true},
+		{"header", 10, false}, // Same height must not be signed twice
+		{"header", 9, false},
+		{"attestation", 10, true}, // Heights are tracked per kind
+		{"header", 11, true},
+	} {
+		req := &core.SignDataRequest{Consensus: &core.ConsensusMessage{Kind: tt.kind, Number: tt.number}}
+		resp, err := r.ApproveSignData(req)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if resp.Approved != tt.want {
+			t.Errorf("test %d: wrong approval for %s %d: have %v, want %v", i, tt.kind, tt.number, resp.Approved, tt.want)
+		}
+	}
+}
@@ -84,6 +84,7 @@ type Database struct {
 	diskdb    ethdb.Database // Persistent database to store the snapshot
 	preimages *preimageStore // The store for caching preimages
 	backend   backend        // The backend for managing trie nodes
+	serving   bool           // Whether the readers serve the RPC state reads
 }
 
 // NewDatabase initializes the trie database with default settings, note
@@ -120,11 +121,24 @@ func NewDatabase(diskdb ethdb.Database, config *Config) *Database {
 	return db
 }
 
+// ServingView returns a view of the database whose readers serve the RPC state
+// reads. With the hash scheme, the clean nodes they load are cached in their
+// own partition if configured, so that they don't evict the nodes of the block
+// import. The view shares the backend of the database and mustn't be closed.
+func (db *Database) ServingView() *Database {
+	view := *db
+	view.serving = true
+	return &view
+}
+
 // Reader returns a reader for accessing all trie nodes with provided state root.
 // An error will be returned if the requested state is not available.
 func (db *Database) Reader(blockRoot common.Hash) (database.Reader, error) {
 	switch b := db.backend.(type) {
 	case *hashdb.Database:
+		if db.serving {
+			return b.ServingReader(blockRoot)
+		}
 		return b.Reader(blockRoot)
 	case *pathdb.Database:
 		return b.Reader(blockRoot)
@@ -41,6 +41,15 @@ var (
 	memcacheCleanReadMeter  = metrics.NewRegisteredMeter("hashdb/memcache/clean/read", nil)
 	memcacheCleanWriteMeter = metrics.NewRegisteredMeter("hashdb/memcache/clean/write", nil)
 
+	// Clean cache usage of the import and serving (RPC) readers. The nodes
+	// loaded by the serving readers evict the import ones unless the cache
+	// is partitioned, showing as import misses.
+	memcacheCleanImportHitMeter    = metrics.NewRegisteredMeter("hashdb/memcache/clean/import/hit", nil)
+	memcacheCleanImportMissMeter   = metrics.NewRegisteredMeter("hashdb/memcache/clean/import/miss", nil)
+	memcacheCleanServingHitMeter   = metrics.NewRegisteredMeter("hashdb/memcache/clean/serving/hit", nil)
+	memcacheCleanServingMissMeter  = metrics.NewRegisteredMeter("hashdb/memcache/clean/serving/miss", nil)
+	memcacheCleanServingWriteMeter = metrics.NewRegisteredMeter("hashdb/memcache/clean/serving/write", nil)
+
 	memcacheDirtyHitMeter   = metrics.NewRegisteredMeter("hashdb/memcache/dirty/hit", nil)
 	memcacheDirtyMissMeter  = metrics.NewRegisteredMeter("hashdb/memcache/dirty/miss", nil)
 	memcacheDirtyReadMeter  = metrics.NewRegisteredMeter("hashdb/memcache/dirty/read", nil)
@@ -67,7 +76,8 @@ type ChildResolver interface {
 
 // Config contains the settings for database.
 type Config struct {
-	CleanCacheSize int // Maximum memory allowance (in bytes) for caching clean nodes
+	CleanCacheSize   int // Maximum memory allowance (in bytes) for caching clean nodes
+	ServingCacheSize int // Memory allowance (in bytes) of the separate clean cache of the serving readers, 0 = shared
 }
 
 // Defaults is the default setting for database if it's not specified.
@@ -87,6 +97,7 @@ type Database struct {
 	resolver ChildResolver  // The handler to resolve children of nodes
 
 	cleans  *fastcache.Cache            // GC friendly memory cache of clean node RLPs
+	serving *fastcache.Cache            // Clean cache partition of the serving readers, nil if shared
 	dirties map[common.Hash]*cachedNode // Data and references relationships of dirty trie nodes
 	oldest  common.Hash                 // Oldest tracked node, flush-list head
 	newest  common.Hash                 // Newest tracked node, flush-list tail
@@ -135,14 +146,18 @@ func New(diskdb ethdb.Database, config *Config, resolver ChildResolver) *Databas
 	if config == nil {
 		config = Defaults
 	}
-	var cleans *fastcache.Cache
+	var cleans, serving *fastcache.Cache
 	if config.CleanCacheSize > 0 {
 		cleans = fastcache.New(config.CleanCacheSize)
 	}
+	if config.ServingCacheSize > 0 {
+		serving = fastcache.New(config.ServingCacheSize)
+	}
 	return &Database{
 		diskdb:   diskdb,
 		resolver: resolver,
 		cleans:   cleans,
+		serving:  serving,
 		dirties:  make(map[common.Hash]*cachedNode),
 	}
 }
@@ -180,7 +195,10 @@ func (db *Database) insert(hash common.Hash, node []byte) {
 
 // node retrieves an encoded cached trie node from memory. If it cannot be found
 // cached, the method queries the persistent database for the content.
-func (db *Database) node(hash common.Hash) ([]byte, error) {
+//
+// The nodes loaded for the serving readers are cached in their own partition
+// if configured, so that the RPC state reads don't evict the import nodes.
+func (db *Database) node(hash common.Hash, serving bool) ([]byte, error) {
 	// It doesn't make sense to retrieve the metaroot
 	if hash == (common.Hash{}) {
 		return nil, errors.New("not found")
@@ -190,8 +208,23 @@ func (db *Database) node(hash common.Hash) ([]byte, error) {
 		if enc := db.cleans.Get(nil, hash[:]); enc != nil {
 			memcacheCleanHitMeter.Mark(1)
 			memcacheCleanReadMeter.Mark(int64(len(enc)))
+			if serving {
+				memcacheCleanServingHitMeter.Mark(1)
+			} else {
+				memcacheCleanImportHitMeter.Mark(1)
+			}
+			return enc, nil
+		}
+	}
+	cleans := db.cleans
+	if serving && db.serving != nil {
+		if enc := db.serving.Get(nil, hash[:]); enc != nil {
+			memcacheCleanHitMeter.Mark(1)
+			memcacheCleanReadMeter.Mark(int64(len(enc)))
+			memcacheCleanServingHitMeter.Mark(1)
 			return enc, nil
 		}
+		cleans = db.serving
 	}
 	// Retrieve the node from the dirty cache if available.
 	db.lock.RLock()
@@ -211,10 +244,16 @@ func (db *Database) node(hash common.Hash) ([]byte, error) {
 	// Content unavailable in memory, attempt to retrieve from disk
 	enc := rawdb.ReadLegacyTrieNode(db.diskdb, hash)
 	if len(enc) != 0 {
-		if db.cleans != nil {
-			db.cleans.Set(hash[:], enc)
+		if cleans != nil {
+			cleans.Set(hash[:], enc)
 			memcacheCleanMissMeter.Mark(1)
 			memcacheCleanWriteMeter.Mark(int64(len(enc)))
+			if serving {
+				memcacheCleanServingMissMeter.Mark(1)
+				memcacheCleanServingWriteMeter.Mark(int64(len(enc)))
+			} else {
+				memcacheCleanImportMissMeter.Mark(1)
+			}
 		}
 		return enc, nil
 	}
@@ -551,7 +590,7 @@ func (db *Database) Initialized(genesisRoot common.Hash) bool {
 func (db *Database) Update(root common.Hash, parent common.Hash, block uint64, nodes *trienode.MergedNodeSet, states *triestate.Set) error {
 	// Ensure the parent state is present and signal a warning if not.
 	if parent != types.EmptyRootHash {
-		if blob, _ := db.node(parent); len(blob) == 0 {
+		if blob, _ := db.node(parent, false); len(blob) == 0 {
 			log.Error("parent state is not present")
 		}
 	}
@@ -620,26 +659,41 @@ func (db *Database) Close() error {
 	if db.cleans != nil {
 		db.cleans.Reset()
 	}
+	if db.serving != nil {
+		db.serving.Reset()
+	}
 	return nil
 }
 
 // Reader retrieves a node reader belonging to the given state root.
 // An error will be returned if the requested state is not available.
 func (db *Database) Reader(root common.Hash) (*reader, error) {
-	if _, err := db.node(root); err != nil {
+	return db.reader(root, false)
+}
+
+// ServingReader retrieves a node reader belonging to the given state root for
+// serving the RPC state reads, caching the nodes it loads in the serving
+// partition of the clean cache if configured.
+func (db *Database) ServingReader(root common.Hash) (*reader, error) {
+	return db.reader(root, true)
+}
+
+func (db *Database) reader(root common.Hash, serving bool) (*reader, error) {
+	if _, err := db.node(root, serving); err != nil {
 		return nil, fmt.Errorf("state %#x is not available, %v", root, err)
 	}
-	return &reader{db: db}, nil
+	return &reader{db: db, serving: serving}, nil
 }
 
 // reader is a state reader of Database which implements the Reader interface.
 type reader struct {
-	db *Database
+	db      *Database
+	serving bool // Whether the reader serves the RPC state reads
 }
 
 // Node retrieves the trie node with the given node hash. No error will be
 // returned if the node is not found.
 func (reader *reader) Node(owner common.Hash, path []byte, hash common.Hash) ([]byte, error) {
-	blob, _ := reader.db.node(hash)
+	blob, _ := reader.db.node(hash, reader.serving)
 	return blob, nil
 }
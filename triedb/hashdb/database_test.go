@@ -0,0 +1,54 @@
+package hashdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Tests that the nodes loaded by the serving readers are cached in their own
+// partition, leaving the clean cache of the import untouched.
+func TestServingCachePartition(t *testing.T) {
+	var (
+		diskdb = rawdb.NewMemoryDatabase()
+		blob   = []byte{0xc2, 0x80, 0x80}
+		hash   = crypto.Keccak256Hash(blob)
+	)
+	rawdb.WriteLegacyTrieNode(diskdb, hash, blob)
+
+	db := New(diskdb, &Config{CleanCacheSize: 1024 * 1024, ServingCacheSize: 1024 * 1024}, trie.MerkleResolver{})
+	defer db.Close()
+
+	reader, err := db.ServingReader(hash)
+	if err != nil {
+		t.Fatalf("failed to open serving reader: %v", err)
+	}
+	if have, _ := reader.Node(hash, nil, hash); string(have) != string(blob) {
+		t.Fatalf("node mismatch: have %x, want %x", have, blob)
+	}
+	if db.cleans.Has(hash[:]) {
+		t.Fatal("serving read cached in the import partition")
+	}
+	if !db.serving.Has(hash[:]) {
+		t.Fatal("serving read not cached in the serving partition")
+	}
+	// The import reads fill the import partition
+	if _, err := db.Reader(hash); err != nil {
+		t.Fatalf("failed to open reader: %v", err)
+	}
+	if !db.cleans.Has(hash[:]) {
+		t.Fatal("import read not cached")
+	}
+	// Without partition, the serving reads share the clean cache
+	shared := New(diskdb, &Config{CleanCacheSize: 1024 * 1024}, trie.MerkleResolver{})
+	defer shared.Close()
+
+	if _, err := shared.ServingReader(hash); err != nil {
+		t.Fatalf("failed to open serving reader: %v", err)
+	}
+	if !shared.cleans.Has(hash[:]) {
+		t.Fatal("serving read not cached in the shared cache")
+	}
+}
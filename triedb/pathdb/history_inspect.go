@@ -53,8 +53,8 @@ func sanitizeRange(start, end uint64, freezer ethdb.AncientReader) (uint64, uint
 	if end != 0 && end < last {
 		last = end
 	}
-	// Make sure the range is valid
-	if first >= last {
+	// Make sure the range is valid, a single history is allowed
+	if first > last {
 		return 0, 0, fmt.Errorf("range is invalid, first: %d, last: %d", first, last)
 	}
 	return first, last, nil
@@ -0,0 +1,84 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/compiler"
+)
+
+// Matches of the compiled code against the deployed one.
+const (
+	MatchFull    = "full"    // The code and the metadata hash match
+	MatchPartial = "partial" // The code matches, not the metadata hash
+)
+
+var errCodeMismatch = errors.New("compiled code doesn't match the deployed code")
+
+// matchCode compares the compiled runtime bytecode to the deployed code. The
+// library addresses and the immutable values, unknown at compilation, are taken
+// from the deployed code. The match is partial if the code only differs by the
+// metadata appended by solc, such as the hash of the sources, which changes
+// with the comments and the file names.
+func matchCode(compiled *compiler.StandardBytecode, deployed []byte) (string, error) {
+	object := strings.TrimPrefix(compiled.Object, "0x")
+
+	var ranges []compiler.CodeRange
+	for _, libs := range compiled.LinkReferences {
+		for _, refs := range libs {
+			ranges = append(ranges, refs...)
+		}
+	}
+	for _, refs := range compiled.ImmutableReferences {
+		ranges = append(ranges, refs...)
+	}
+	// Zero the library placeholders so the object decodes, their value is
+	// copied from the deployed code below.
+	for _, r := range ranges {
+		start, end := 2*r.Start, 2*(r.Start+r.Length)
+		if r.Start < 0 || r.Length < 0 || end > len(object) {
+			return "", fmt.Errorf("invalid code reference %d+%d", r.Start, r.Length)
+		}
+		object = object[:start] + strings.Repeat("0", end-start) + object[end:]
+	}
+	code, err := hex.DecodeString(object)
+	if err != nil {
+		return "", fmt.Errorf("invalid compiled bytecode: %v", err)
+	}
+	if len(code) == 0 {
+		return "", errors.New("contract has no runtime code")
+	}
+	for _, r := range ranges {
+		if r.Start+r.Length <= len(deployed) {
+			copy(code[r.Start:r.Start+r.Length], deployed[r.Start:r.Start+r.Length])
+		}
+	}
+	if bytes.Equal(code, deployed) {
+		return MatchFull, nil
+	}
+	if bytes.Equal(stripMetadata(code), stripMetadata(deployed)) {
+		return MatchPartial, nil
+	}
+	return "", errCodeMismatch
+}
+
+// stripMetadata removes the CBOR encoded metadata appended by solc to the code,
+// followed by its length on two bytes.
+func stripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	size := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if size == 0 || size+2 > len(code) {
+		return code
+	}
+	// The metadata is a CBOR map, of major type 5.
+	if code[len(code)-2-size]&0xe0 != 0xa0 {
+		return code
+	}
+	return code[:len(code)-2-size]
+}
@@ -0,0 +1,96 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/compiler"
+)
+
+// metadata returns the CBOR metadata appended by solc 0.8.19, with an IPFS hash
+// filled with the given byte.
+func metadata(hash byte) []byte {
+	var meta []byte
+	meta = append(meta, 0xa2, 0x64, 'i', 'p', 'f', 's', 0x58, 0x22)
+	meta = append(meta, bytes.Repeat([]byte{hash}, 34)...)
+	meta = append(meta, 0x64, 's', 'o', 'l', 'c', 0x43, 0x00, 0x08, 0x13)
+	return append(meta, 0x00, byte(len(meta)))
+}
+
+func TestStripMetadata(t *testing.T) {
+	code := common.FromHex("0x6080604052")
+	if have := stripMetadata(append(code, metadata(1)...)); !bytes.Equal(have, code) {
+		t.Errorf("metadata not stripped: %x", have)
+	}
+	// Code without metadata is left as is.
+	for _, code := range [][]byte{nil, {0x00}, common.FromHex("0x60806040520000"), common.FromHex("0x6080604052ff01")} {
+		if have := stripMetadata(code); !bytes.Equal(have, code) {
+			t.Errorf("code %x stripped to %x", code, have)
+		}
+	}
+}
+
+func TestMatchCode(t *testing.T) {
+	var (
+		body     = common.FromHex("0x608060405273")
+		library  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		deployed = append(append(append([]byte{}, body...), library.Bytes()...), metadata(1)...)
+		object   = hex.EncodeToString(body) + "__$" + strings.Repeat("a", 34) + "$__" + hex.EncodeToString(metadata(1))
+		links    = map[string]map[string][]compiler.CodeRange{"Lib.sol": {"Lib": {{Start: len(body), Length: 20}}}}
+	)
+	tests := []struct {
+		name     string
+		compiled compiler.StandardBytecode
+		deployed []byte
+		want     string
+	}{
+		{
+			name:     "full",
+			compiled: compiler.StandardBytecode{Object: object, LinkReferences: links},
+			deployed: deployed,
+			want:     MatchFull,
+		},
+		{
+			name:     "partial",
+			compiled: compiler.StandardBytecode{Object: strings.Replace(object, hex.EncodeToString(metadata(1)), hex.EncodeToString(metadata(2)), 1), LinkReferences: links},
+			deployed: deployed,
+			want:     MatchPartial,
+		},
+		{
+			name: "immutable",
+			compiled: compiler.StandardBytecode{
+				Object:              "0x7f" + strings.Repeat("00", 32) + "00",
+				ImmutableReferences: map[string][]compiler.CodeRange{"3": {{Start: 1, Length: 32}}},
+			},
+			deployed: append(append([]byte{0x7f}, bytes.Repeat([]byte{0x42}, 32)...), 0x00),
+			want:     MatchFull,
+		},
+		{
+			name:     "mismatch",
+			compiled: compiler.StandardBytecode{Object: "0x6080604052" + strings.Repeat("00", 20) + hex.EncodeToString(metadata(1)), LinkReferences: links},
+			deployed: append(common.FromHex("0x6080604053"), deployed[5:]...),
+		},
+		{
+			name:     "unlinked",
+			compiled: compiler.StandardBytecode{Object: object},
+			deployed: deployed,
+		},
+	}
+	for _, tt := range tests {
+		have, err := matchCode(&tt.compiled, tt.deployed)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%s: no error for mismatching code, have %s", tt.name, have)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: match failed: %v", tt.name, err)
+		} else if have != tt.want {
+			t.Errorf("%s: match mismatch: have %s, want %s", tt.name, have, tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/compiler"
+)
+
+const (
+	// solcTimeout is the time allowed to a compilation.
+	solcTimeout = 2 * time.Minute
+
+	// maxSolcOutput is the maximum size of the compiler outputs.
+	maxSolcOutput = 64 * 1024 * 1024
+)
+
+var (
+	errOutputTooLarge = errors.New("compiler output too large")
+
+	// versionRegexp matches the compiler versions, as in 0.8.19 or
+	// v0.8.19+commit.7dd6d404.
+	versionRegexp = regexp.MustCompile(`^v?([0-9]+\.[0-9]+\.[0-9]+)(\+commit\.[0-9a-f]{8})?$`)
+)
+
+// Compiler compiles solc standard-json inputs.
+type Compiler interface {
+	Compile(ctx context.Context, version string, input []byte) ([]byte, error)
+}
+
+// Solc runs the solc executables in a sandbox: the sources must be inlined in
+// the input, solc runs in an empty temporary directory with an empty
+// environment, and the compilation time and output size are bounded.
+type Solc struct {
+	path string // solc executable, or directory of solc-<version> executables
+}
+
+// NewSolc creates the compiler running the solc executable at the path, or the
+// solc-<version> executables of the directory at the path.
+func NewSolc(path string) *Solc {
+	return &Solc{path: path}
+}
+
+// Compile implements Compiler.
+func (s *Solc) Compile(ctx context.Context, version string, input []byte) ([]byte, error) {
+	input, err := sanitizeInput(input)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, solcTimeout)
+	defer cancel()
+
+	exe, err := s.executable(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "solc-sandbox-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		stdout = &limitedBuffer{limit: maxSolcOutput}
+		stderr = &limitedBuffer{limit: 64 * 1024}
+	)
+	cmd := exec.CommandContext(ctx, exe, "--standard-json")
+	cmd.Dir = dir
+	cmd.Env = []string{}
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("compilation interrupted: %v", ctx.Err())
+		}
+		if errors.Is(err, errOutputTooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("solc: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// executable resolves the solc executable of the version. A single executable
+// must be of the requested version.
+func (s *Solc) executable(ctx context.Context, version string) (string, error) {
+	match := versionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return "", fmt.Errorf("invalid compiler version %q", version)
+	}
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		out, err := exec.CommandContext(ctx, s.path, "--version").Output()
+		if err != nil {
+			return "", fmt.Errorf("solc: %v", err)
+		}
+		if !strings.Contains(string(out), "Version: "+match[1]+"+") {
+			return "", fmt.Errorf("unsupported compiler version %s", version)
+		}
+		return s.path, nil
+	}
+	for _, name := range []string{"solc-v" + match[1] + match[2], "solc-v" + match[1], "solc-" + match[1]} {
+		path := filepath.Join(s.path, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported compiler version %s", version)
+}
+
+// sanitizeInput checks the standard-json input only contains inlined sources,
+// and replaces its output selection by the one needed for the verification.
+func sanitizeInput(input []byte) ([]byte, error) {
+	var req struct {
+		Language string `json:"language"`
+		Sources  map[string]struct {
+			Content *string         `json:"content"`
+			URLs    json.RawMessage `json:"urls,omitempty"`
+		} `json:"sources"`
+		Settings map[string]interface{} `json:"settings"`
+	}
+	if err := json.Unmarshal(input, &req); err != nil {
+		return nil, fmt.Errorf("invalid standard-json input: %v", err)
+	}
+	if req.Language != "Solidity" {
+		return nil, fmt.Errorf("unsupported language %q", req.Language)
+	}
+	if len(req.Sources) == 0 {
+		return nil, errors.New("no sources")
+	}
+	for name, source := range req.Sources {
+		if source.Content == nil || len(source.URLs) > 0 {
+			return nil, fmt.Errorf("source %q must be inlined", name)
+		}
+	}
+	if req.Settings == nil {
+		req.Settings = make(map[string]interface{})
+	}
+	req.Settings["outputSelection"] = compiler.StandardOutputSelection
+	return json.Marshal(&req)
+}
+
+// limitedBuffer is a buffer failing the writes beyond its limit.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errOutputTooLarge
+	}
+	return b.Buffer.Write(p)
+}
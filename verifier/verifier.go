@@ -0,0 +1,153 @@
+// Package verifier implements the verification of the contract sources against
+// the deployed code, storing the results for the explorers.
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/compiler"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxCompilations is the maximum number of concurrent compilations.
+const maxCompilations = 2
+
+// Backend is the node backend the deployed code is retrieved from.
+type Backend interface {
+	StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error)
+	ChainDb() ethdb.Database
+}
+
+// VerifyArgs are the arguments of a contract verification.
+type VerifyArgs struct {
+	Address         common.Address  `json:"address"`
+	Contract        string          `json:"contract"` // Fully qualified name, as in contracts/Token.sol:Token
+	CompilerVersion string          `json:"compilerVersion"`
+	Input           json.RawMessage `json:"input"` // solc standard-json input
+}
+
+// Verification is the record of a verified contract.
+type Verification struct {
+	Address         common.Address  `json:"address"`
+	Contract        string          `json:"contract"`
+	CompilerVersion string          `json:"compilerVersion"`
+	Match           string          `json:"match"`
+	CodeHash        common.Hash     `json:"codeHash"`
+	BlockNumber     hexutil.Uint64  `json:"blockNumber"`
+	ABI             json.RawMessage `json:"abi"`
+	Metadata        string          `json:"metadata,omitempty"`
+	Input           json.RawMessage `json:"input"`
+}
+
+// API offers the contract verification methods, in the nero namespace.
+type API struct {
+	b        Backend
+	compiler Compiler
+	slots    chan struct{}
+}
+
+// NewAPI creates the verification API compiling with the given compiler.
+func NewAPI(b Backend, compiler Compiler) *API {
+	return &API{b: b, compiler: compiler, slots: make(chan struct{}, maxCompilations)}
+}
+
+// APIs returns the RPC descriptors of the verification API.
+func APIs(b Backend, compiler Compiler) []rpc.API {
+	return []rpc.API{{
+		Namespace: "nero",
+		Service:   NewAPI(b, compiler),
+	}}
+}
+
+// VerifyContract compiles the sources and compares the code of the contract
+// with the code deployed at the address in the latest block. The verification
+// is stored if the code matches, unless the contract is already fully verified
+// and the match is partial.
+func (api *API) VerifyContract(ctx context.Context, args VerifyArgs) (*Verification, error) {
+	source, name, ok := strings.Cut(args.Contract, ":")
+	if !ok || source == "" || name == "" {
+		return nil, errors.New("contract must be fully qualified, as in <source>:<name>")
+	}
+	statedb, header, err := api.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+	deployed := statedb.GetCode(args.Address)
+	if len(deployed) == 0 {
+		return nil, fmt.Errorf("no contract deployed at %s", args.Address)
+	}
+	// Bound the concurrent compilations, solc being memory hungry.
+	select {
+	case api.slots <- struct{}{}:
+		defer func() { <-api.slots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	output, err := api.compiler.Compile(ctx, args.CompilerVersion, args.Input)
+	if err != nil {
+		return nil, err
+	}
+	res, err := compiler.ParseStandardJSON(output)
+	if err != nil {
+		return nil, err
+	}
+	contract, ok := res.Contracts[source][name]
+	if !ok {
+		return nil, fmt.Errorf("contract %s not found in the compilation output", args.Contract)
+	}
+	match, err := matchCode(&contract.EVM.DeployedBytecode, deployed)
+	if err != nil {
+		return nil, err
+	}
+	verification := &Verification{
+		Address:         args.Address,
+		Contract:        args.Contract,
+		CompilerVersion: args.CompilerVersion,
+		Match:           match,
+		CodeHash:        statedb.GetCodeHash(args.Address),
+		BlockNumber:     hexutil.Uint64(header.Number.Uint64()),
+		ABI:             contract.ABI,
+		Metadata:        contract.Metadata,
+		Input:           args.Input,
+	}
+	if prev := api.verification(args.Address); prev != nil && prev.Match == MatchFull && prev.CodeHash == verification.CodeHash && match != MatchFull {
+		return verification, nil
+	}
+	blob, err := json.Marshal(verification)
+	if err != nil {
+		return nil, err
+	}
+	rawdb.WriteVerifiedContract(api.b.ChainDb(), args.Address, blob)
+	log.Info("Verified contract", "address", args.Address, "contract", args.Contract, "match", match)
+	return verification, nil
+}
+
+// GetVerifiedContract returns the stored verification of the contract, nil if
+// it isn't verified.
+func (api *API) GetVerifiedContract(address common.Address) *Verification {
+	return api.verification(address)
+}
+
+func (api *API) verification(address common.Address) *Verification {
+	blob := rawdb.ReadVerifiedContract(api.b.ChainDb(), address)
+	if len(blob) == 0 {
+		return nil
+	}
+	verification := new(Verification)
+	if err := json.Unmarshal(blob, verification); err != nil {
+		log.Error("Invalid contract verification", "address", address, "err", err)
+		return nil
+	}
+	return verification
+}
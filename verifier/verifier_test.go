@@ -0,0 +1,137 @@
+package verifier
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type testBackend struct {
+	db    ethdb.Database
+	state *state.StateDB
+}
+
+func newTestBackend(code map[common.Address][]byte) *testBackend {
+	db := rawdb.NewMemoryDatabase()
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(db), nil)
+	for addr, code := range code {
+		statedb.SetCode(addr, code)
+	}
+	return &testBackend{db: db, state: statedb}
+}
+
+func (b *testBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error) {
+	return b.state, &types.Header{Number: big.NewInt(10)}, nil
+}
+
+func (b *testBackend) ChainDb() ethdb.Database { return b.db }
+
+// testCompiler returns the canned output for a contract with the given code.
+type testCompiler struct {
+	code []byte
+}
+
+func (c *testCompiler) Compile(ctx context.Context, version string, input []byte) ([]byte, error) {
+	return []byte(fmt.Sprintf(`{
+		"contracts": {"Token.sol": {"Token": {
+			"abi": [],
+			"metadata": "{}",
+			"evm": {"deployedBytecode": {"object": "%s"}}
+		}}},
+		"errors": [{"severity": "warning", "message": "unused variable"}]
+	}`, hex.EncodeToString(c.code))), nil
+}
+
+func TestVerifyContract(t *testing.T) {
+	var (
+		addr     = common.HexToAddress("0x1000")
+		body     = common.FromHex("0x6080604052")
+		deployed = append(append([]byte{}, body...), metadata(1)...)
+		backend  = newTestBackend(map[common.Address][]byte{addr: deployed})
+		solc     = &testCompiler{code: append(append([]byte{}, body...), metadata(2)...)}
+		api      = NewAPI(backend, solc)
+		args     = VerifyArgs{Address: addr, Contract: "Token.sol:Token", CompilerVersion: "0.8.19", Input: json.RawMessage(`{}`)}
+	)
+	if api.GetVerifiedContract(addr) != nil {
+		t.Fatal("contract verified before the verification")
+	}
+	// A partial match is stored.
+	res, err := api.VerifyContract(context.Background(), args)
+	if err != nil {
+		t.Fatalf("verification failed: %v", err)
+	}
+	if res.Match != MatchPartial || res.BlockNumber != 10 || res.CodeHash != backend.state.GetCodeHash(addr) {
+		t.Fatalf("verification mismatch: %+v", res)
+	}
+	if stored := api.GetVerifiedContract(addr); stored == nil || stored.Match != MatchPartial {
+		t.Fatalf("partial verification not stored: %+v", stored)
+	}
+	// A full match replaces it, and isn't replaced by a later partial match.
+	solc.code = deployed
+	if res, err := api.VerifyContract(context.Background(), args); err != nil || res.Match != MatchFull {
+		t.Fatalf("full verification failed: %v %+v", err, res)
+	}
+	solc.code = append(append([]byte{}, body...), metadata(3)...)
+	if res, err := api.VerifyContract(context.Background(), args); err != nil || res.Match != MatchPartial {
+		t.Fatalf("partial verification failed: %v %+v", err, res)
+	}
+	if stored := api.GetVerifiedContract(addr); stored == nil || stored.Match != MatchFull {
+		t.Fatalf("full verification replaced: %+v", stored)
+	}
+	// Mismatching code, unknown contracts and empty accounts fail.
+	solc.code = common.FromHex("0x00")
+	if _, err := api.VerifyContract(context.Background(), args); err != errCodeMismatch {
+		t.Errorf("mismatching code: have %v, want %v", err, errCodeMismatch)
+	}
+	for _, args := range []VerifyArgs{
+		{Address: addr, Contract: "Token.sol:Other"},
+		{Address: addr, Contract: "Token"},
+		{Address: common.HexToAddress("0x2000"), Contract: "Token.sol:Token"},
+	} {
+		if _, err := api.VerifyContract(context.Background(), args); err == nil {
+			t.Errorf("no error verifying %s at %s", args.Contract, args.Address)
+		}
+	}
+}
+
+func TestSanitizeInput(t *testing.T) {
+	for _, input := range []string{
+		`{"language": "Vyper", "sources": {"a.vy": {"content": ""}}}`,
+		`{"language": "Solidity", "sources": {}}`,
+		`{"language": "Solidity", "sources": {"a.sol": {"urls": ["/etc/passwd"]}}}`,
+		`{"language": "Solidity", "sources": {"a.sol": {"content": "", "urls": ["/etc/passwd"]}}}`,
+	} {
+		if _, err := sanitizeInput([]byte(input)); err == nil {
+			t.Errorf("input accepted: %s", input)
+		}
+	}
+	out, err := sanitizeInput([]byte(`{"language": "Solidity", "sources": {"a.sol": {"content": "contract A {}"}}, "settings": {"optimizer": {"enabled": true}, "outputSelection": {"*": {"*": ["*"]}}}}`))
+	if err != nil {
+		t.Fatalf("input rejected: %v", err)
+	}
+	var req struct {
+		Settings struct {
+			Optimizer       map[string]bool                `json:"optimizer"`
+			OutputSelection map[string]map[string][]string `json:"outputSelection"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal(out, &req); err != nil {
+		t.Fatal(err)
+	}
+	if !req.Settings.Optimizer["enabled"] {
+		t.Error("settings not preserved")
+	}
+	if len(req.Settings.OutputSelection["*"]["*"]) != 5 {
+		t.Errorf("output selection not replaced: %v", req.Settings.OutputSelection)
+	}
+}